@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/cmd"
+	"github.com/alchemillahq/sylve/internal/config"
+	consolepath "github.com/alchemillahq/sylve/internal/console"
+	"github.com/alchemillahq/sylve/internal/services/zelta"
+	"github.com/urfave/cli/v3"
+)
+
+// newBackupCommand holds backup tooling that runs directly on the host
+// without talking to a Sylve daemon, so it also works on plain ZFS backup
+// targets that only have the sylve binary installed.
+func newBackupCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "backup",
+		Usage: "Offline backup tooling",
+		Commands: []*cli.Command{
+			{
+				Name:  "seed-import",
+				Usage: "Receive a backup seed from removable media into a backup root",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "dir", Usage: "Seed directory (contains sylve-seed.json)", Required: true},
+					&cli.StringFlag{Name: "root", Usage: "Backup root dataset configured on the backup target", Required: true},
+					&cli.BoolFlag{Name: "json", Usage: "output in JSON format"},
+				},
+				Action: func(ctx context.Context, command *cli.Command) error {
+					manifest, err := zelta.ImportBackupSeed(ctx, command.String("dir"), command.String("root"))
+					if err != nil {
+						return err
+					}
+					return printBackupSeedImport(os.Stdout, manifest, command.String("root"), command.Bool("json"))
+				},
+			},
+			{
+				Name:  "standby-activate",
+				Usage: "Adopt a node standby replicated to this host (Sylve must be stopped)",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "root", Usage: "Standby dataset on this host (<backup root>/<dest suffix>)", Required: true},
+					&cli.BoolFlag{Name: "force", Usage: "move an existing database and <pool>/sylve datasets aside"},
+					&cli.BoolFlag{Name: "json", Usage: "output in JSON format"},
+				},
+				Action: func(ctx context.Context, command *cli.Command) error {
+					configPath, err := cmd.ResolveConfigPath(command.String("config"))
+					if err != nil {
+						return err
+					}
+					cfg := config.ParseConfig(configPath)
+
+					if conn, err := net.DialTimeout("unix", consolepath.SocketPath(cfg.DataPath), time.Second); err == nil {
+						conn.Close()
+						return fmt.Errorf("sylve is running; stop it before activating a standby")
+					}
+
+					activation, err := zelta.ActivateNodeStandby(ctx, command.String("root"), cfg.DataPath, command.Bool("force"))
+					if err != nil {
+						return err
+					}
+					return printNodeStandbyActivation(os.Stdout, activation, command.Bool("json"))
+				},
+			},
+		},
+	}
+}
+
+func printBackupSeedImport(w io.Writer, manifest *zelta.BackupSeedManifest, backupRoot string, jsonMode bool) error {
+	if jsonMode {
+		encoded, err := json.Marshal(manifest)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(encoded))
+		return err
+	}
+
+	fmt.Fprintf(w, "Imported seed %s for job %d (%s) into %s\n", manifest.SnapshotName, manifest.JobID, manifest.JobName, backupRoot)
+	for _, stream := range manifest.Streams {
+		fmt.Fprintf(w, "  %s -> %s (%d bytes)\n", stream.SourceDataset, stream.DestSuffix, stream.Bytes)
+	}
+	fmt.Fprintf(w, "Adopt it on the source node with snapshot name %s to continue the job incrementally.\n", manifest.SnapshotName)
+	return nil
+}
+
+func printNodeStandbyActivation(w io.Writer, activation *zelta.NodeStandbyActivation, jsonMode bool) error {
+	if jsonMode {
+		encoded, err := json.Marshal(activation)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(encoded))
+		return err
+	}
+
+	fmt.Fprintf(w, "Activated standby of %s from %s (%s)\n",
+		activation.Manifest.Hostname, activation.Manifest.SnapshotName, activation.Manifest.CreatedAt.Format(time.RFC3339))
+	for _, dataset := range activation.Datasets {
+		fmt.Fprintf(w, "  adopted %s\n", dataset)
+	}
+	fmt.Fprintf(w, "Installed database at %s (%d dataset references updated)\n", activation.DatabasePath, activation.RewrittenGUIDs)
+	for _, warning := range activation.Warnings {
+		fmt.Fprintf(w, "  warning: %s\n", warning)
+	}
+	fmt.Fprintln(w, "Start Sylve to bring the guests up.")
+	return nil
+}
@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/alchemillahq/sylve/internal/services/zelta"
+	"github.com/urfave/cli/v3"
+)
+
+// newBackupTenantShellCommand is the command forced in a backup tenant's
+// authorized_keys. It runs the zfs command the tenant's client asked for only
+// if it stays inside the tenant's dataset and, for writes, its namespace.
+func newBackupTenantShellCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "backup-tenant-shell",
+		Usage:  "Run a backup tenant's SSH command if it is allowed",
+		Hidden: true,
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "dataset", Usage: "Tenant dataset", Required: true},
+			&cli.StringFlag{Name: "namespace", Usage: "Child dataset creates and receives must stay in"},
+		},
+		Action: func(ctx context.Context, command *cli.Command) error {
+			argv, err := zelta.BackupTenantCommand(
+				os.Getenv("SSH_ORIGINAL_COMMAND"),
+				command.String("dataset"),
+				command.String("namespace"),
+			)
+			if err != nil {
+				return err
+			}
+
+			path, err := exec.LookPath(argv[0])
+			if err != nil {
+				return fmt.Errorf("backup_tenant_command_not_found: %w", err)
+			}
+			return syscall.Exec(path, argv, os.Environ())
+		},
+	}
+}
@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// hostShutdownMarkerPath is created by the rc script when rc.shutdown stops
+// Sylve. Plain service restarts leave it absent so guests keep running.
+const hostShutdownMarkerPath = "/var/run/sylve.host-shutdown"
+
+// shouldShutdownGuests reports whether the guest shutdown phase runs on exit
+// and consumes the marker so it cannot leak into the next run.
+func shouldShutdownGuests(markerPath string, stopOnExit bool) bool {
+	err := os.Remove(markerPath)
+	return stopOnExit || err == nil || !errors.Is(err, fs.ErrNotExist)
+}
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestShouldShutdownGuests(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "sylve.host-shutdown")
+
+	if shouldShutdownGuests(marker, false) {
+		t.Fatalf("expected plain restarts to leave guests running")
+	}
+	if !shouldShutdownGuests(marker, true) {
+		t.Fatalf("expected stopOnExit to shut guests down")
+	}
+
+	if err := os.WriteFile(marker, nil, 0644); err != nil {
+		t.Fatalf("failed to write marker: %v", err)
+	}
+	if !shouldShutdownGuests(marker, false) {
+		t.Fatalf("expected host shutdown marker to shut guests down")
+	}
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Fatalf("expected marker to be consumed, got %v", err)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"os"
 	"os/signal"
 	"runtime"
+	"strconv"
 	"sync"
 	"syscall"
 	"time"
@@ -30,6 +31,8 @@ import (
 	dbModels "github.com/alchemillahq/sylve/internal/db/models"
 	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
 	"github.com/alchemillahq/sylve/internal/handlers"
+	metadataHandlers "github.com/alchemillahq/sylve/internal/handlers/metadata"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/internal/logger"
 	notificationFacade "github.com/alchemillahq/sylve/internal/notifications"
 	"github.com/alchemillahq/sylve/internal/repl"
@@ -37,6 +40,7 @@ import (
 	"github.com/alchemillahq/sylve/internal/services/auth"
 	"github.com/alchemillahq/sylve/internal/services/cluster"
 	"github.com/alchemillahq/sylve/internal/services/disk"
+	"github.com/alchemillahq/sylve/internal/services/guestalerts"
 	"github.com/alchemillahq/sylve/internal/services/info"
 	"github.com/alchemillahq/sylve/internal/services/iscsi"
 	"github.com/alchemillahq/sylve/internal/services/jail"
@@ -44,12 +48,15 @@ import (
 	"github.com/alchemillahq/sylve/internal/services/lifecycle"
 	"github.com/alchemillahq/sylve/internal/services/mdns"
 	networkService "github.com/alchemillahq/sylve/internal/services/network"
+	"github.com/alchemillahq/sylve/internal/services/notes"
 	notificationsService "github.com/alchemillahq/sylve/internal/services/notifications"
 	"github.com/alchemillahq/sylve/internal/services/samba"
 	"github.com/alchemillahq/sylve/internal/services/system"
+	"github.com/alchemillahq/sylve/internal/services/usage"
 	"github.com/alchemillahq/sylve/internal/services/utilities"
 	"github.com/alchemillahq/sylve/internal/services/zelta"
 	"github.com/alchemillahq/sylve/internal/services/zfs"
+	webhookFacade "github.com/alchemillahq/sylve/internal/webhooks"
 
 	portnetwork "github.com/alchemillahq/sylve/pkg/network"
 	"github.com/gin-contrib/gzip"
@@ -59,6 +66,7 @@ import (
 
 func main() {
 	rootCmd := cmd.NewRootCommand(daemonAction)
+	rootCmd.Commands = append(rootCmd.Commands, newBackupCommand(), newTransferLimitCommand(), newBackupTenantShellCommand())
 
 	if err := rootCmd.Run(context.Background(), os.Args); err != nil {
 		fmt.Fprintln(os.Stderr, "Error:", err)
@@ -80,6 +88,9 @@ func daemonAction(ctx context.Context, c *cli.Command) error {
 	}
 
 	cfg := config.ParseConfig(resolvedConfigPath)
+	if err := layout.Validate(cfg); err != nil {
+		return fmt.Errorf("invalid datasets config: %w", err)
+	}
 	socketPath := consolepath.SocketPath(cfg.DataPath)
 	historyPath := consolepath.HistoryPath(cfg.DataPath)
 
@@ -171,6 +182,7 @@ func daemonAction(ctx context.Context, c *cli.Command) error {
 		logger.L.Fatal().Err(err).Msg("failed_to_migrate_legacy_disk_smart_notifications")
 	}
 	notificationFacade.SetEmitter(notificationService)
+	webhookFacade.SetPublisher(serviceRegistry.WebhookService)
 
 	sysS.(*system.Service).SetDiskService(dS)
 
@@ -184,6 +196,10 @@ func daemonAction(ctx context.Context, c *cli.Command) error {
 	lifecycleSvc := lifecycle.NewService(d, telemetryDB, libvirtSvc, jailSvc)
 	migrationSvc := serviceRegistry.MigrationService
 	lifecycleSvc.SetMigrationExecutor(migrationSvc.ExecuteMigration)
+	lifecycleSvc.SetVMCloneExecutor(zeltaS.CloneVMToNode)
+	lifecycleSvc.SetStaleRestoreDatasetCleaner(zeltaS.DestroyStaleRestoreDataset)
+	zeltaS.SetApplicationGate(lifecycleSvc.WaitForApplicationDependencies)
+	zeltaS.SetHeavyOpGate(lifecycleSvc.AcquireHeavyOp)
 	refreshEmitter := func(reason string) {
 		clusterSvc.EmitLeftPanelRefreshClusterWide(reason)
 	}
@@ -194,6 +210,8 @@ func daemonAction(ctx context.Context, c *cli.Command) error {
 	zeltaS.RegisterJobs()
 	lifecycleSvc.RegisterJobs()
 	dS.(*disk.Service).RegisterJobs()
+	libvirtSvc.RegisterJobs()
+	jailSvc.RegisterJobs()
 
 	zfs.EncryptionKeyCreatedHook = func(uuid, keyData, keyFormat string) {
 		if err := clusterSvc.ForwardEncryptionKeyToLeader(uuid, keyData, keyFormat); err != nil {
@@ -211,6 +229,9 @@ func daemonAction(ctx context.Context, c *cli.Command) error {
 	if err := lifecycleSvc.RecoverInterruptedTasks(initContext); err != nil {
 		logger.L.Error().Err(err).Msg("failed_to_recover_interrupted_lifecycle_tasks")
 	}
+	if err := zeltaS.RecoverTaskJournal(initContext); err != nil {
+		logger.L.Error().Err(err).Msg("failed_to_recover_task_journal")
+	}
 
 	logger.L.Info().Msg("Basic initializations complete")
 
@@ -220,6 +241,7 @@ func daemonAction(ctx context.Context, c *cli.Command) error {
 
 	go nS.(*networkService.Service).StartObjectRefreshWorker(qCtx)
 	go ddnsS.StartWorker(qCtx)
+	go serviceRegistry.WebhookService.StartWorker(qCtx)
 
 	startAdvancedStartupWorkers, basicSettings, settingsErr := shouldStartAdvancedStartupWorkers(func() (dbModels.BasicSettings, error) {
 		var settings dbModels.BasicSettings
@@ -241,9 +263,11 @@ func daemonAction(ctx context.Context, c *cli.Command) error {
 		go sysS.StartNetlinkWatcher(qCtx)
 		sysS.StartDiskSmartMonitor(qCtx)
 		go dS.(*disk.Service).StartSelfTestScheduler(qCtx)
+		go jailSvc.StartSnapshotScheduler(qCtx)
 
 		if libvirtSvc.IsVirtualizationEnabled() {
 			go libvirtSvc.StartLifecycleWatcher(qCtx)
+			go libvirtSvc.StartSnapshotScheduler(qCtx)
 		}
 
 		enqueueCtx, enqueueCancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -268,6 +292,19 @@ func daemonAction(ctx context.Context, c *cli.Command) error {
 	if err := zeltaS.ReconcileBackupRunAudits(); err != nil {
 		logger.L.Warn().Err(err).Msg("failed_to_reconcile_backup_run_audits_after_restart")
 	}
+	if err := zeltaS.ReconcileBulkRestoresAfterRestart(); err != nil {
+		logger.L.Warn().Err(err).Msg("failed_to_reconcile_bulk_restores_after_restart")
+	}
+
+	if err := zeltaS.RecoverInterruptedRestorePromotions(context.Background()); err != nil {
+		logger.L.Warn().Err(err).Msg("failed_to_recover_interrupted_restore_promotions")
+	}
+
+	if report, err := lifecycleSvc.CheckConsistency(context.Background()); err != nil {
+		logger.L.Warn().Err(err).Msg("failed_to_run_startup_consistency_check")
+	} else if len(report.Issues) > 0 {
+		logger.L.Warn().Int("issues", len(report.Issues)).Msg("startup_consistency_check_found_issues")
+	}
 
 	if err := zelta.EnsureZeltaInstalled(); err != nil {
 		logger.L.Error().Err(err).Msg("Failed to install Zelta; skipping Zelta schedulers")
@@ -279,6 +316,17 @@ func daemonAction(ctx context.Context, c *cli.Command) error {
 	go migrationSvc.StartRecoveryTicker(qCtx)
 	go aS.ClearExpiredJWTTokens(qCtx)
 
+	usageSvc := usage.NewService(d, libvirtSvc, jailSvc)
+	go usageSvc.Run(qCtx)
+
+	guestAlertSvc := guestalerts.NewService(d)
+	go guestAlertSvc.Run(qCtx)
+
+	notesSvc := notes.NewService(d)
+	if err := notesSvc.PruneOrphans(); err != nil {
+		logger.L.Warn().Err(err).Msg("failed_to_prune_orphan_guest_notes")
+	}
+
 	gin.SetMode(gin.ReleaseMode)
 	gin.DefaultWriter = io.Discard
 	gin.DefaultErrorWriter = io.Discard
@@ -304,12 +352,16 @@ func daemonAction(ctx context.Context, c *cli.Command) error {
 		smbS.(*samba.Service),
 		mdS.(*mdns.Service),
 		ddnsS,
+		serviceRegistry.WebhookService,
 		iscsiSvc,
 		jailSvc,
 		lifecycleSvc,
 		clusterSvc,
 		zeltaS,
 		migrationSvc,
+		usageSvc,
+		notesSvc,
+		guestAlertSvc,
 		fsm,
 		d,
 		telemetryDB,
@@ -353,13 +405,13 @@ func daemonAction(ctx context.Context, c *cli.Command) error {
 	}
 
 	httpsServer := &http.Server{
-		Addr:      fmt.Sprintf("%s:%d", cfg.IP, cfg.Port),
+		Addr:      net.JoinHostPort(cfg.IP, strconv.Itoa(cfg.Port)),
 		Handler:   r,
 		TLSConfig: tlsConfig,
 	}
 
 	httpServer := &http.Server{
-		Addr:    fmt.Sprintf("%s:%d", cfg.IP, cfg.HTTPPort),
+		Addr:    net.JoinHostPort(cfg.IP, strconv.Itoa(cfg.HTTPPort)),
 		Handler: r,
 	}
 
@@ -368,7 +420,7 @@ func daemonAction(ctx context.Context, c *cli.Command) error {
 		name string
 		srv  *http.Server
 	}
-	startedServers := make([]namedServer, 0, 2)
+	startedServers := make([]namedServer, 0, 3)
 	logger.L.Info().
 		Int("https", cfg.Port).
 		Int("http", cfg.HTTPPort).
@@ -382,7 +434,7 @@ func daemonAction(ctx context.Context, c *cli.Command) error {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			logger.L.Info().Msgf("HTTPS server started on %s:%d", cfg.IP, cfg.Port)
+			logger.L.Info().Msgf("HTTPS server started on %s", httpsServer.Addr)
 			if err := httpsServer.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
 				logger.L.Fatal().Err(err).Msg("Failed to start HTTPS server")
 			}
@@ -394,13 +446,32 @@ func daemonAction(ctx context.Context, c *cli.Command) error {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			logger.L.Info().Msgf("HTTP server started on %s:%d", cfg.IP, cfg.HTTPPort)
+			logger.L.Info().Msgf("HTTP server started on %s", httpServer.Addr)
 			if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 				logger.L.Fatal().Err(err).Msg("Failed to start HTTP server")
 			}
 		}()
 	}
 
+	if cfg.Guests.MetadataPort != 0 {
+		if err := libvirtSvc.EnsureGuestMetadataAddress(); err != nil {
+			logger.L.Error().Err(err).Msg("Failed to add the guest metadata address to standard switches")
+		}
+		metadataServer := &http.Server{
+			Addr:    net.JoinHostPort(libvirt.GuestMetadataAddress, strconv.Itoa(cfg.Guests.MetadataPort)),
+			Handler: metadataHandlers.NewRouter(libvirtSvc),
+		}
+		startedServers = append(startedServers, namedServer{name: "Guest metadata", srv: metadataServer})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			logger.L.Info().Msgf("Guest metadata server started on %s", metadataServer.Addr)
+			if err := metadataServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.L.Error().Err(err).Msg("Failed to start guest metadata server")
+			}
+		}()
+	}
+
 	// clusterHTTPS holds the intra-cluster HTTPS server when started; guarded by clusterHTTPSMu.
 	var clusterHTTPSMu sync.Mutex
 	var activeClusterHTTPS *http.Server
@@ -417,15 +488,15 @@ func daemonAction(ctx context.Context, c *cli.Command) error {
 		}
 
 		srv := &http.Server{
-			Addr:      fmt.Sprintf("%s:%d", clusterIP, cluster.ClusterEmbeddedHTTPSPort),
+			Addr:      cluster.ClusterAPIHost(clusterIP),
 			Handler:   r,
-			TLSConfig: tlsConfig,
+			TLSConfig: clusterSvc.ClusterServerTLSConfig(tlsConfig),
 		}
 		activeClusterHTTPS = srv
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			logger.L.Info().Msgf("Intra-cluster HTTPS server started on %s:%d", clusterIP, cluster.ClusterEmbeddedHTTPSPort)
+			logger.L.Info().Msgf("Intra-cluster HTTPS server started on %s", srv.Addr)
 			if err := srv.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
 				logger.L.Fatal().Err(err).Msg("Failed to start intra-cluster HTTPS server")
 			}
@@ -466,5 +537,9 @@ func daemonAction(ctx context.Context, c *cli.Command) error {
 
 	wg.Wait()
 	logger.L.Info().Msg("Servers exited properly")
+
+	if shouldShutdownGuests(hostShutdownMarkerPath, cfg.Guests.StopOnExit) {
+		lifecycleSvc.ShutdownGuests(context.Background())
+	}
 	return nil
 }
@@ -61,6 +61,16 @@ func buildPortRequirements(cfg *internal.SylveConfig) ([]portRequirement, error)
 		reqs = append(reqs, portRequirement{role: "https", ip: cfg.IP, port: cfg.Port})
 	}
 
+	if cfg.Guests.MetadataPort != 0 {
+		if !utils.IsValidPort(cfg.Guests.MetadataPort) {
+			return nil, fmt.Errorf("invalid_guest_metadata_port: %d", cfg.Guests.MetadataPort)
+		}
+		// The server binds the link-local metadata address, which is only
+		// aliased onto the bridges later in startup; probe the port on all
+		// addresses so a conflicting listener is still caught here.
+		reqs = append(reqs, portRequirement{role: "guest_metadata", ip: "", port: cfg.Guests.MetadataPort})
+	}
+
 	for _, req := range reqs {
 		if !utils.IsValidPort(req.port) {
 			return nil, fmt.Errorf("invalid_required_port role=%s port=%d", req.role, req.port)
@@ -141,3 +141,30 @@ func TestPreflightRequiredPortsChecksAllExpectedRoles(t *testing.T) {
 		}
 	}
 }
+
+func TestBuildPortRequirementsIncludesGuestMetadataPort(t *testing.T) {
+	cfg := &internal.SylveConfig{
+		IP:       "192.168.1.1",
+		HTTPPort: 8182,
+		Guests:   internal.GuestsConfig{MetadataPort: 8182},
+	}
+
+	if _, err := buildPortRequirements(cfg); err == nil || !strings.Contains(err.Error(), "guest_metadata") {
+		t.Fatalf("expected the metadata port to collide with http, got: %v", err)
+	}
+
+	cfg.Guests.MetadataPort = 8169
+	reqs, err := buildPortRequirements(cfg)
+	if err != nil {
+		t.Fatalf("buildPortRequirements returned error: %v", err)
+	}
+	for _, req := range reqs {
+		if req.role == "guest_metadata" {
+			if req.port != 8169 || req.ip != "" {
+				t.Fatalf("expected the metadata port to be probed on all addresses, got %+v", req)
+			}
+			return
+		}
+	}
+	t.Fatal("missing guest_metadata requirement")
+}
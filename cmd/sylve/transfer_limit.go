@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/alchemillahq/sylve/internal/services/zelta"
+	"github.com/alchemillahq/sylve/pkg/ratelimit"
+	"github.com/urfave/cli/v3"
+)
+
+// newTransferLimitCommand wraps the ssh commands zelta runs for backups and
+// restores so the daemon can pace them through a rate file it rewrites as
+// transfers on the same link start and finish.
+func newTransferLimitCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "transfer-limit",
+		Usage:  "Run a command with its stdin and stdout rate limited",
+		Hidden: true,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "rate-file",
+				Usage:    "File holding the current rate in bytes per second",
+				Sources:  cli.EnvVars(zelta.TransferRateFileEnv),
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, command *cli.Command) error {
+			args := command.Args().Slice()
+			if len(args) == 0 {
+				return fmt.Errorf("transfer_limit_command_required")
+			}
+
+			err := ratelimit.Run(ctx, ratelimit.FileRate(command.String("rate-file")), os.Stdin, os.Stdout, os.Stderr, args[0], args[1:]...)
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				os.Exit(exitErr.ExitCode())
+			}
+			return err
+		},
+	}
+}
@@ -0,0 +1,18 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+// Package swagger holds the swag output for the handler annotations.
+package swagger
+
+import _ "embed"
+
+// JSON is the swagger 2.0 document scripts/generate_swagger.sh writes next
+// to this file.
+//
+//go:embed swagger.json
+var JSON []byte
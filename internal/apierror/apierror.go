@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package apierror
+
+import (
+	"errors"
+	"strings"
+)
+
+// Detail is the structured form of an API error. Code is stable across
+// releases and languages; Message and Hint are localized from the catalog.
+type Detail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+// Error is an error carrying a stable code. Its Error string keeps the
+// "code: cause" shape the rest of the tree already produces.
+type Error struct {
+	Code  string
+	Hint  string
+	Cause error
+}
+
+func New(code string) *Error {
+	return &Error{Code: code}
+}
+
+func Wrap(code string, cause error) *Error {
+	return &Error{Code: code, Cause: cause}
+}
+
+func (e *Error) WithHint(hint string) *Error {
+	e.Hint = hint
+	return e
+}
+
+func (e *Error) Error() string {
+	if e.Cause == nil {
+		return e.Code
+	}
+	return e.Code + ": " + e.Cause.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// CodeOf extracts the leading snake_case code from an ad hoc error string
+// such as "restore_destination_already_running: dataset=tank/a". It returns
+// an empty string when the text does not start with a code.
+func CodeOf(raw string) string {
+	raw = strings.TrimSpace(raw)
+	end := strings.IndexAny(raw, ": \t\n")
+	if end >= 0 {
+		raw = raw[:end]
+	}
+	if !isCode(raw) {
+		return ""
+	}
+	return raw
+}
+
+func isCode(s string) bool {
+	if s == "" || !strings.Contains(s, "_") {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z':
+		case (r >= '0' && r <= '9') || r == '_':
+			if i == 0 {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Describe builds the detail for err in the best language matching
+// acceptLanguage. Typed errors keep their own code and hint.
+func Describe(err error, acceptLanguage string) Detail {
+	var typed *Error
+	if errors.As(err, &typed) {
+		detail := Lookup(typed.Code, acceptLanguage)
+		if typed.Hint != "" {
+			detail.Hint = typed.Hint
+		}
+		return detail
+	}
+	return Resolve("", err.Error(), acceptLanguage)
+}
+
+// Resolve picks the most specific known code from a response's message and
+// error fields and describes it. The error text usually carries the precise
+// failure while the message is a generic handler code, so the error wins
+// when the catalog knows it.
+func Resolve(message, errText, acceptLanguage string) Detail {
+	errCode := CodeOf(errText)
+	msgCode := CodeOf(message)
+
+	for _, code := range []string{errCode, msgCode} {
+		if code != "" && Known(code) {
+			return Lookup(code, acceptLanguage)
+		}
+	}
+	for _, code := range []string{errCode, msgCode} {
+		if code != "" {
+			return Lookup(code, acceptLanguage)
+		}
+	}
+	return Lookup("internal_server_error", acceptLanguage)
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCodeOf(t *testing.T) {
+	cases := map[string]string{
+		"restore_destination_already_running: dataset=tank/a": "restore_destination_already_running",
+		"vm_not_found":               "vm_not_found",
+		"  backup_target_disabled\n": "backup_target_disabled",
+		"failed to open file":        "",
+		"Invalid_Request":            "",
+		"404_not_found":              "",
+		"invalid":                    "",
+		"":                           "",
+	}
+	for in, want := range cases {
+		if got := CodeOf(in); got != want {
+			t.Fatalf("CodeOf(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestResolvePrefersKnownErrorCode(t *testing.T) {
+	detail := Resolve("invalid_request", "backup_target_disabled", "")
+	if detail.Code != "backup_target_disabled" || detail.Hint == "" {
+		t.Fatalf("unexpected detail: %#v", detail)
+	}
+
+	detail = Resolve("vm_not_found", "some_unknown_failure: boom", "")
+	if detail.Code != "vm_not_found" {
+		t.Fatalf("expected the catalogued message code, got %#v", detail)
+	}
+
+	detail = Resolve("", "some_unknown_failure: boom", "")
+	if detail.Code != "some_unknown_failure" || detail.Message != "Some unknown failure" {
+		t.Fatalf("expected a derived message, got %#v", detail)
+	}
+
+	detail = Resolve("", "exit status 1", "")
+	if detail.Code != "internal_server_error" {
+		t.Fatalf("expected the generic code, got %#v", detail)
+	}
+}
+
+func TestLookupFallsBackToDefaultLanguage(t *testing.T) {
+	detail := Lookup("vm_not_found", "xx-YY, zz;q=0.5")
+	english := Lookup("vm_not_found", "")
+	if detail != english {
+		t.Fatalf("expected english fallback, got %#v", detail)
+	}
+	if got := preferredLanguages("de-CH, fr;q=0.8, *"); len(got) != 2 || got[0] != "de" || got[1] != "fr" {
+		t.Fatalf("unexpected languages: %v", got)
+	}
+}
+
+func TestDescribeTypedError(t *testing.T) {
+	err := fmt.Errorf("run: %w", Wrap("backup_target_disabled", errors.New("target 3")).WithHint("custom hint"))
+	detail := Describe(err, "")
+	if detail.Code != "backup_target_disabled" || detail.Hint != "custom hint" {
+		t.Fatalf("unexpected detail: %#v", detail)
+	}
+	if got := Wrap("backup_target_disabled", errors.New("target 3")).Error(); got != "backup_target_disabled: target 3" {
+		t.Fatalf("unexpected error string: %q", got)
+	}
+}
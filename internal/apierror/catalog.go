@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package apierror
+
+import (
+	"embed"
+	"encoding/json"
+	"path"
+	"strings"
+	"sync"
+)
+
+const DefaultLanguage = "en"
+
+// Catalogs live in catalog/<language>.json. A language only needs to carry
+// the codes it translates; anything missing falls back to English.
+//
+//go:embed catalog/*.json
+var catalogFS embed.FS
+
+type entry struct {
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+var (
+	catalogOnce sync.Once
+	catalogs    map[string]map[string]entry
+)
+
+func loadCatalogs() {
+	catalogs = make(map[string]map[string]entry)
+
+	files, err := catalogFS.ReadDir("catalog")
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || path.Ext(name) != ".json" {
+			continue
+		}
+		raw, err := catalogFS.ReadFile("catalog/" + name)
+		if err != nil {
+			continue
+		}
+		entries := make(map[string]entry)
+		if err := json.Unmarshal(raw, &entries); err != nil {
+			continue
+		}
+		catalogs[strings.TrimSuffix(name, ".json")] = entries
+	}
+}
+
+// Languages lists the catalog languages available.
+func Languages() []string {
+	catalogOnce.Do(loadCatalogs)
+	out := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		out = append(out, lang)
+	}
+	return out
+}
+
+// Known reports whether code has an entry in the default catalog.
+func Known(code string) bool {
+	catalogOnce.Do(loadCatalogs)
+	_, ok := catalogs[DefaultLanguage][code]
+	return ok
+}
+
+// Lookup describes code in the best language for acceptLanguage. Codes that
+// are not in any catalog get a message derived from the code itself.
+func Lookup(code, acceptLanguage string) Detail {
+	catalogOnce.Do(loadCatalogs)
+
+	detail := Detail{Code: code}
+	for _, lang := range append(preferredLanguages(acceptLanguage), DefaultLanguage) {
+		if e, ok := catalogs[lang][code]; ok {
+			detail.Message = e.Message
+			detail.Hint = e.Hint
+			return detail
+		}
+	}
+
+	detail.Message = humanize(code)
+	return detail
+}
+
+// preferredLanguages parses an Accept-Language header into base language
+// tags in the order given. Quality values are ignored; clients list their
+// preferences first in practice.
+func preferredLanguages(header string) []string {
+	var out []string
+	for _, part := range strings.Split(header, ",") {
+		tag := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+		tag = strings.ToLower(strings.SplitN(tag, "-", 2)[0])
+		out = append(out, tag)
+	}
+	return out
+}
+
+func humanize(code string) string {
+	text := strings.TrimSpace(strings.ReplaceAll(code, "_", " "))
+	if text == "" {
+		return ""
+	}
+	return strings.ToUpper(text[:1]) + text[1:]
+}
@@ -112,6 +112,14 @@ func IsDevFSDisabled() bool {
 	return false
 }
 
+func GetJailMountRoots() []string {
+	if ParsedConfig != nil && len(ParsedConfig.Jails.MountRoots) > 0 {
+		return ParsedConfig.Jails.MountRoots
+	}
+
+	return []string{"/mnt", "/media", "/usr/home"}
+}
+
 func GetDataPath() (string, error) {
 	configuredPath := ""
 	if ParsedConfig != nil {
@@ -189,6 +197,7 @@ func SetupDataPath() error {
 		filepath.Join(dataPath, "downloads", "http"),
 		filepath.Join(dataPath, "downloads", "path"),
 		filepath.Join(dataPath, "downloads", "extracted"),
+		filepath.Join(dataPath, "downloads", "jail-bases"),
 	}
 
 	for _, dir := range dirs {
@@ -217,6 +226,8 @@ func GetDownloadsPath(dType string) string {
 		return filepath.Join(dataPath, "downloads", "path")
 	case "extracted":
 		return filepath.Join(dataPath, "downloads", "extracted")
+	case "jail-bases":
+		return filepath.Join(dataPath, "downloads", "jail-bases")
 	}
 
 	return filepath.Join(dataPath, "downloads")
@@ -28,6 +28,7 @@ import (
 	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
 	utilitiesModels "github.com/alchemillahq/sylve/internal/db/models/utilities"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	webhookModels "github.com/alchemillahq/sylve/internal/db/models/webhooks"
 	zfsModels "github.com/alchemillahq/sylve/internal/db/models/zfs"
 	"github.com/alchemillahq/sylve/internal/db/replicationguard"
 	"github.com/alchemillahq/sylve/internal/logger"
@@ -106,6 +107,7 @@ func SetupDatabase(cfg *internal.SylveConfig, isTest bool) *gorm.DB {
 		&models.DiskSmartSelfTestEvent{},
 		&models.DiskSmartSelfTestRun{},
 		&models.DiskSmartSelfTestSchedulerLease{},
+		&models.DiskBenchmark{},
 
 		&models.System{},
 		&models.User{},
@@ -115,12 +117,16 @@ func SetupDatabase(cfg *internal.SylveConfig, isTest bool) *gorm.DB {
 		&models.WebAuthnCredential{},
 		&models.WebAuthnChallenge{},
 		&models.SystemSecrets{},
+		&models.SecretsDataKey{},
+		&models.JWTSigningKey{},
+		&models.LoginNotices{},
 
 		&vmModels.Storage{},
 		&vmModels.Network{},
 		&vmModels.VMStats{},
 		&vmModels.VMCPUPinning{},
 		&vmModels.VMSnapshot{},
+		&vmModels.VMSnapshotSchedule{},
 		&vmModels.VMTemplate{},
 		&vmModels.VM{},
 
@@ -129,12 +135,18 @@ func SetupDatabase(cfg *internal.SylveConfig, isTest bool) *gorm.DB {
 		&jailModels.JailStats{},
 		&jailModels.JailHooks{},
 		&jailModels.JailSnapshot{},
+		&jailModels.JailSnapshotSchedule{},
 		&jailModels.JailTemplate{},
 		&jailModels.Jail{},
 		&jailModels.JailBootstrap{},
+		&jailModels.JailBaseCache{},
 
 		&models.PassedThroughIDs{},
 		&models.Triggers{},
+		&models.GuestNote{},
+		&models.GuestNoteRevision{},
+		&models.GuestNoteAttachment{},
+		&models.GuestAlertRule{},
 		&models.ZFSCacheInvalidation{},
 		&models.SystemTunable{},
 
@@ -146,6 +158,10 @@ func SetupDatabase(cfg *internal.SylveConfig, isTest bool) *gorm.DB {
 		&networkModels.FirewallNATRule{},
 		&networkModels.FirewallAdvancedSettings{},
 		&networkModels.StaticRoute{},
+		&networkModels.HostInterface{},
+		&networkModels.SRIOVPhysicalFunction{},
+		&networkModels.SRIOVVirtualFunction{},
+		&networkModels.PacketCapture{},
 		&networkModels.WireGuardServer{},
 		&networkModels.WireGuardServerPeer{},
 		&networkModels.WireGuardClient{},
@@ -156,12 +172,17 @@ func SetupDatabase(cfg *internal.SylveConfig, isTest bool) *gorm.DB {
 		// &networkModels.DHCPOption{},
 
 		&infoModels.Note{},
+		&infoModels.GuestUsage{},
 
 		&zfsModels.PeriodicSnapshot{},
+		&zfsModels.StorageFence{},
+		&zfsModels.Delegation{},
+		&zfsModels.HotSparePolicy{},
 
 		&networkModels.ManualSwitch{},
 		&networkModels.StandardSwitch{},
 		&networkModels.NetworkPort{},
+		&networkModels.IsolationGroup{},
 
 		&utilitiesModels.CloudInitTemplate{},
 		&utilitiesModels.DownloadedFile{},
@@ -176,6 +197,9 @@ func SetupDatabase(cfg *internal.SylveConfig, isTest bool) *gorm.DB {
 
 		&dynamicDNSModels.Entry{},
 
+		&webhookModels.Webhook{},
+		&webhookModels.Delivery{},
+
 		&iscsiModels.ISCSIInitiator{},
 		&iscsiModels.ISCSITarget{},
 		&iscsiModels.ISCSITargetPortal{},
@@ -188,15 +212,37 @@ func SetupDatabase(cfg *internal.SylveConfig, isTest bool) *gorm.DB {
 		&clusterModels.BackupTarget{},
 		&clusterModels.BackupJob{},
 		&clusterModels.BackupEvent{},
+		&clusterModels.BackupEventDataset{},
+		&clusterModels.BackupTenant{},
+		&clusterModels.PostRestoreScript{},
+		&clusterModels.NodeStandby{},
+		&clusterModels.StaleDatasetJanitor{},
+		&clusterModels.RestoreScratch{},
+		&clusterModels.GuestExport{},
+		&clusterModels.BulkRestore{},
+		&clusterModels.BulkRestoreItem{},
+		&clusterModels.RestorePromotion{},
 		&clusterModels.ReplicationPolicy{},
 		&clusterModels.ReplicationPolicyTarget{},
 		&clusterModels.ReplicationLease{},
 		&clusterModels.ReplicationGuestOperation{},
 		&clusterModels.ReplicationGuestOperationReceipt{},
 		&clusterModels.ReplicationEvent{},
+		&clusterModels.ReplicationEventTransfer{},
 		&clusterModels.ClusterSSHIdentity{},
+		&clusterModels.ClusterSSHKeyRotation{},
 		&clusterModels.EncryptionKey{},
+		&clusterModels.MaintenanceWindow{},
+		&clusterModels.MaintenanceSuppression{},
+		&clusterModels.ZeltaProfile{},
+		&clusterModels.ReplicationPolicyTemplate{},
+		&clusterModels.ClusterCA{},
+		&clusterModels.ClusterNodeCertificate{},
 		&taskModels.GuestLifecycleTask{},
+		&taskModels.JournalEntry{},
+		&taskModels.GuestHook{},
+		&taskModels.Application{},
+		&taskModels.ApplicationMember{},
 
 		&models.Migrations{},
 	)
@@ -210,6 +256,10 @@ func SetupDatabase(cfg *internal.SylveConfig, isTest bool) *gorm.DB {
 	sqlDB.SetMaxOpenConns(1)
 	sqlDB.SetMaxIdleConns(1)
 
+	if err := setupSecrets(db, cfg, isTest); err != nil {
+		logger.L.Fatal().Msgf("Error unlocking stored secrets: %v", err)
+	}
+
 	err = setupInitUsers(db, cfg)
 	if err != nil {
 		logger.L.Fatal().Msgf("Error setting up initial users: %v", err)
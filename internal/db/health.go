@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+var errWritableProbeRollback = errors.New("writable_probe_rollback")
+
+// CheckWritable proves the database accepts writes by creating a table inside
+// a transaction that is always rolled back, so probing leaves nothing behind.
+// A read-only or locked database fails here rather than on the next real write.
+func CheckWritable(ctx context.Context, d *gorm.DB) error {
+	if d == nil {
+		return fmt.Errorf("database_not_setup")
+	}
+
+	err := d.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("CREATE TABLE sylve_writable_probe (id INTEGER)").Error; err != nil {
+			return err
+		}
+		return errWritableProbeRollback
+	})
+	if err != nil && !errors.Is(err, errWritableProbeRollback) {
+		return fmt.Errorf("database_not_writable: %w", err)
+	}
+	return nil
+}
+
+// QueueHealth reports whether the job queue is set up and its backing store
+// answers queries.
+func QueueHealth(ctx context.Context) error {
+	setupQueueMu.RLock()
+	lanes := len(laneRunners)
+	setupQueueMu.RUnlock()
+
+	if dbConn == nil || lanes == 0 {
+		return fmt.Errorf("queue_not_setup")
+	}
+
+	var pending int
+	if err := dbConn.QueryRowContext(ctx, "select count(*) from goqite").Scan(&pending); err != nil {
+		return fmt.Errorf("queue_unavailable: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alchemillahq/sylve/internal/testutil"
+)
+
+func TestCheckWritableLeavesNoProbeTable(t *testing.T) {
+	d := testutil.NewSQLiteTestDB(t)
+
+	for i := 0; i < 2; i++ {
+		if err := CheckWritable(context.Background(), d); err != nil {
+			t.Fatalf("CheckWritable run %d: %v", i, err)
+		}
+	}
+	if d.Migrator().HasTable("sylve_writable_probe") {
+		t.Fatal("probe table survived the rolled back transaction")
+	}
+
+	if err := CheckWritable(context.Background(), nil); err == nil {
+		t.Fatal("expected error for nil database")
+	}
+}
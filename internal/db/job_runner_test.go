@@ -358,3 +358,54 @@ func TestJobRunnerAllowsInFlightJobsToFinishAfterShutdown(t *testing.T) {
 		t.Fatal("timed out waiting for runner shutdown")
 	}
 }
+
+func TestSettleInFlightMessagesDropsJournaledAndRequeuesRest(t *testing.T) {
+	sqlDB, queue, _ := newJobRunnerErrorPolicyTestHarness(t)
+	ctx := context.Background()
+
+	for _, msg := range []struct{ name, body string }{
+		{"journaled", "owned"},
+		{"journaled", "orphan"},
+		{"other", "owned"},
+	} {
+		if err := createJobMessage(ctx, queue, msg.name, []byte(msg.body)); err != nil {
+			t.Fatalf("failed to enqueue %s: %v", msg.name, err)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		if m, err := queue.Receive(ctx); err != nil || m == nil {
+			t.Fatalf("failed to receive message: %v", err)
+		}
+	}
+	if err := createJobMessage(ctx, queue, "journaled", []byte("owned")); err != nil {
+		t.Fatalf("failed to enqueue pending message: %v", err)
+	}
+	if _, err := sqlDB.ExecContext(ctx,
+		"update goqite set timeout = strftime('%Y-%m-%dT%H:%M:%fZ', 'now', '+1 hour') where received > 0",
+	); err != nil {
+		t.Fatalf("failed to hold received messages: %v", err)
+	}
+
+	dropped, requeued, err := settleInFlightMessages(ctx, sqlDB, func(_ string, body []byte) bool {
+		return string(body) == "owned"
+	}, []string{"journaled"})
+	if err != nil {
+		t.Fatalf("settle failed: %v", err)
+	}
+	if dropped != 1 || requeued != 1 {
+		t.Fatalf("expected one dropped and one requeued message, got %d and %d", dropped, requeued)
+	}
+	if got := queuedMessageCount(t, sqlDB); got != 3 {
+		t.Fatalf("expected the orphan, pending and unrelated messages to remain, got %d", got)
+	}
+
+	var visible int
+	if err := sqlDB.QueryRowContext(ctx,
+		"select count(*) from goqite where received > 0 and timeout <= strftime('%Y-%m-%dT%H:%M:%fZ')",
+	).Scan(&visible); err != nil {
+		t.Fatalf("count visible messages: %v", err)
+	}
+	if visible != 1 {
+		t.Fatalf("expected only the requeued message to be visible again, got %d", visible)
+	}
+}
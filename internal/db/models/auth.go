@@ -10,6 +10,8 @@ package models
 
 import (
 	"time"
+
+	"github.com/alchemillahq/sylve/internal/db/secrets"
 )
 
 type User struct {
@@ -35,10 +37,28 @@ type User struct {
 	UpdatedAt       time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
 	LastLoginTime   time.Time `json:"lastLoginTime"`
 
+	// Sign-in history shown back to the user when they next log in, with
+	// FailedLoginCount covering failures since the last success.
+	// LastLoginTime above follows activity and moves on every request.
+	LastSuccessfulLoginAt *time.Time `json:"lastSuccessfulLoginAt"`
+	LastSuccessfulLoginIP string     `json:"lastSuccessfulLoginIp"`
+	LastFailedLoginAt     *time.Time `json:"lastFailedLoginAt"`
+	LastFailedLoginIP     string     `json:"lastFailedLoginIp"`
+	FailedLoginCount      int        `json:"failedLoginCount"`
+
 	Tokens []Token `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"tokens,omitempty"`
 	Groups []Group `gorm:"many2many:user_groups;constraint:OnDelete:CASCADE" json:"groups,omitempty"`
 }
 
+// LoginNotices is a single row of operator text: Banner is shown on the
+// login page before anyone authenticates, MOTD once they have.
+type LoginNotices struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	Banner    string    `gorm:"type:text" json:"banner"`
+	MOTD      string    `gorm:"type:text" json:"motd"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
 type Group struct {
 	ID        uint      `gorm:"primarykey" json:"id"`
 	Name      string    `gorm:"unique" json:"name"`
@@ -64,6 +84,11 @@ type Token struct {
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdAt,omitempty"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updatedAt,omitempty"`
 
+	ClientIP   string     `json:"clientIp,omitempty"`
+	UserAgent  string     `json:"userAgent,omitempty"`
+	LastSeenAt *time.Time `json:"lastSeenAt,omitempty"`
+	LastSeenIP string     `json:"lastSeenIp,omitempty"`
+
 	User *User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"user,omitempty"`
 }
 
@@ -91,10 +116,26 @@ type WebAuthnChallenge struct {
 	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
 }
 
+// JWTSigningKey is one key in the token keyring. Tokens name the key that
+// signed them in their kid header; a rotated-out key keeps verifying until
+// RetiresAt so sessions it signed survive the rotation.
+type JWTSigningKey struct {
+	ID         uint           `gorm:"primarykey" json:"id"`
+	KID        string         `gorm:"column:kid;uniqueIndex;not null" json:"kid"`
+	Purpose    string         `gorm:"index;not null" json:"purpose"`
+	Algorithm  string         `gorm:"not null" json:"algorithm"`
+	PrivateKey secrets.String `gorm:"type:text;not null" json:"-"`
+	PublicKey  string         `gorm:"type:text" json:"publicKey,omitempty"`
+	Active     bool           `gorm:"index;default:false" json:"active"`
+	RetiresAt  *time.Time     `json:"retiresAt"`
+	CreatedAt  time.Time      `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt  time.Time      `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
 type SystemSecrets struct {
 	ID        uint   `gorm:"primarykey"`
 	Name      string `gorm:"primarykey,unique"`
-	Data      string
+	Data      secrets.String
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdAt,omitempty"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updatedAt,omitempty"`
 }
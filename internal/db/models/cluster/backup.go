@@ -10,9 +10,11 @@ package clusterModels
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/alchemillahq/sylve/internal/db/secrets"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -23,66 +25,193 @@ const (
 	BackupJobModeVM      = "vm"
 )
 
+// Share quiesce modes for backups of datasets that host Samba shares.
+// Flush disconnects the shares' clients right before the transfer so cached
+// writes reach disk; shadow copy leaves clients connected and relies on the
+// atomic ZFS snapshot, recording which files were open for writing.
+const (
+	BackupShareQuiesceNone       = ""
+	BackupShareQuiesceFlush      = "flush"
+	BackupShareQuiesceShadowCopy = "shadow_copy"
+)
+
+// Quiesce outcomes recorded on a BackupEvent.
+const (
+	BackupQuiesceNoShares  = "no_shares"
+	BackupQuiesceFlushed   = "flushed"
+	BackupQuiesceClean     = "clean"
+	BackupQuiesceOpenFiles = "open_files"
+	BackupQuiesceFailed    = "failed"
+)
+
+func ValidBackupShareQuiesce(mode string) bool {
+	switch mode {
+	case BackupShareQuiesceNone, BackupShareQuiesceFlush, BackupShareQuiesceShadowCopy:
+		return true
+	}
+	return false
+}
+
+// What a failing pre- or post-backup hook does to the run. Fail skips the
+// transfer when the pre-backup hook fails and marks the run failed either
+// way; ignore only records the failure in the event output.
+const (
+	BackupHookFailurePolicyFail   = "fail"
+	BackupHookFailurePolicyIgnore = "ignore"
+)
+
+const (
+	BackupHookDefaultTimeoutSeconds = 30
+	BackupHookMaxTimeoutSeconds     = 600
+)
+
+func ValidBackupHookFailurePolicy(policy string) bool {
+	switch policy {
+	case BackupHookFailurePolicyFail, BackupHookFailurePolicyIgnore:
+		return true
+	}
+	return false
+}
+
+// SplitBackupDatasetPatterns turns a job's comma or newline separated
+// dataset patterns into a trimmed list without empty entries or repeats.
+func SplitBackupDatasetPatterns(raw string) []string {
+	fields := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == '\n' || r == '\r'
+	})
+
+	patterns := make([]string, 0, len(fields))
+	for _, field := range fields {
+		field = strings.Trim(strings.TrimSpace(field), "/")
+		if field == "" || slices.Contains(patterns, field) {
+			continue
+		}
+		patterns = append(patterns, field)
+	}
+	return patterns
+}
+
 // BackupTarget represents a remote ZFS host reachable via SSH for Zelta replication.
 type BackupTarget struct {
-	ID               uint        `gorm:"primaryKey" json:"id"`
-	Name             string      `gorm:"uniqueIndex;not null" json:"name"`
-	SSHHost          string      `gorm:"column:ssh_host;" json:"sshHost"`           // user@host
-	SSHPort          int         `gorm:"column:ssh_port;default:22" json:"sshPort"` // SSH port (default 22)
-	SSHKeyPath       string      `gorm:"column:ssh_key_path" json:"sshKeyPath"`     // path to private key on host filesystem
-	SSHKey           string      `gorm:"column:ssh_key;type:text" json:"-"`
-	BackupRoot       string      `gorm:"column:backup_root;" json:"backupRoot"` // target pool/dataset prefix (e.g., tank/Backups)
-	CreateBackupRoot bool        `gorm:"column:create_backup_root;default:false" json:"createBackupRoot"`
-	Description      string      `json:"description"`
-	Enabled          bool        `json:"enabled"`
-	CreatedAt        time.Time   `gorm:"autoCreateTime" json:"createdAt"`
-	UpdatedAt        time.Time   `gorm:"autoUpdateTime" json:"updatedAt"`
-	Jobs             []BackupJob `json:"jobs,omitempty" gorm:"foreignKey:TargetID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	ID                 uint           `gorm:"primaryKey" json:"id"`
+	Name               string         `gorm:"uniqueIndex;not null" json:"name"`
+	SSHHost            string         `gorm:"column:ssh_host;" json:"sshHost"`           // user@host
+	SSHPort            int            `gorm:"column:ssh_port;default:22" json:"sshPort"` // SSH port (default 22)
+	SSHKeyPath         string         `gorm:"column:ssh_key_path" json:"sshKeyPath"`     // path to private key on host filesystem
+	SSHKey             secrets.String `gorm:"column:ssh_key;type:text" json:"-"`
+	BackupRoot         string         `gorm:"column:backup_root;" json:"backupRoot"` // target pool/dataset prefix (e.g., tank/Backups)
+	CreateBackupRoot   bool           `gorm:"column:create_backup_root;default:false" json:"createBackupRoot"`
+	BandwidthLimitKBps uint64         `gorm:"column:bandwidth_limit_kbps;default:0" json:"bandwidthLimitKBps"` // KiB/s shared by all transfers to this endpoint, 0 = unlimited
+	SSHCiphers         string         `gorm:"column:ssh_ciphers" json:"sshCiphers"`                            // comma-separated OpenSSH ciphers, empty = OpenSSH default
+	SSHCompression     bool           `gorm:"column:ssh_compression;default:false" json:"sshCompression"`
+	SSHControlPersist  int            `gorm:"column:ssh_control_persist;default:0" json:"sshControlPersist"` // seconds an idle shared connection is kept, 0 = 60
+	Description        string         `json:"description"`
+	Enabled            bool           `json:"enabled"`
+	CreatedAt          time.Time      `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt          time.Time      `gorm:"autoUpdateTime" json:"updatedAt"`
+	Jobs               []BackupJob    `json:"jobs,omitempty" gorm:"foreignKey:TargetID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
 
 type BackupTargetReplicationPayload struct {
-	ID               uint   `json:"id"`
-	Name             string `json:"name"`
-	SSHHost          string `json:"sshHost"`
-	SSHPort          int    `json:"sshPort"`
-	SSHKeyPath       string `json:"sshKeyPath"`
-	SSHKey           string `json:"sshKey"`
-	BackupRoot       string `json:"backupRoot"`
-	CreateBackupRoot bool   `json:"createBackupRoot"`
-	Description      string `json:"description"`
-	Enabled          bool   `json:"enabled"`
+	ID                 uint   `json:"id"`
+	Name               string `json:"name"`
+	SSHHost            string `json:"sshHost"`
+	SSHPort            int    `json:"sshPort"`
+	SSHKeyPath         string `json:"sshKeyPath"`
+	SSHKey             string `json:"sshKey"`
+	BackupRoot         string `json:"backupRoot"`
+	CreateBackupRoot   bool   `json:"createBackupRoot"`
+	BandwidthLimitKBps uint64 `json:"bandwidthLimitKBps"`
+	SSHCiphers         string `json:"sshCiphers"`
+	SSHCompression     bool   `json:"sshCompression"`
+	SSHControlPersist  int    `json:"sshControlPersist"`
+	Description        string `json:"description"`
+	Enabled            bool   `json:"enabled"`
 }
 
 func BackupTargetToReplicationPayload(target BackupTarget) BackupTargetReplicationPayload {
 	return BackupTargetReplicationPayload{
-		ID:               target.ID,
-		Name:             target.Name,
-		SSHHost:          target.SSHHost,
-		SSHPort:          target.SSHPort,
-		SSHKeyPath:       target.SSHKeyPath,
-		SSHKey:           target.SSHKey,
-		BackupRoot:       target.BackupRoot,
-		CreateBackupRoot: target.CreateBackupRoot,
-		Description:      target.Description,
-		Enabled:          target.Enabled,
+		ID:                 target.ID,
+		Name:               target.Name,
+		SSHHost:            target.SSHHost,
+		SSHPort:            target.SSHPort,
+		SSHKeyPath:         target.SSHKeyPath,
+		SSHKey:             string(target.SSHKey),
+		BackupRoot:         target.BackupRoot,
+		CreateBackupRoot:   target.CreateBackupRoot,
+		BandwidthLimitKBps: target.BandwidthLimitKBps,
+		SSHCiphers:         target.SSHCiphers,
+		SSHCompression:     target.SSHCompression,
+		SSHControlPersist:  target.SSHControlPersist,
+		Description:        target.Description,
+		Enabled:            target.Enabled,
 	}
 }
 
 func (p BackupTargetReplicationPayload) ToModel() BackupTarget {
 	return BackupTarget{
-		ID:               p.ID,
-		Name:             p.Name,
-		SSHHost:          p.SSHHost,
-		SSHPort:          p.SSHPort,
-		SSHKeyPath:       p.SSHKeyPath,
-		SSHKey:           p.SSHKey,
-		BackupRoot:       p.BackupRoot,
-		CreateBackupRoot: p.CreateBackupRoot,
-		Description:      p.Description,
-		Enabled:          p.Enabled,
+		ID:                 p.ID,
+		Name:               p.Name,
+		SSHHost:            p.SSHHost,
+		SSHPort:            p.SSHPort,
+		SSHKeyPath:         p.SSHKeyPath,
+		SSHKey:             secrets.String(p.SSHKey),
+		BackupRoot:         p.BackupRoot,
+		CreateBackupRoot:   p.CreateBackupRoot,
+		BandwidthLimitKBps: p.BandwidthLimitKBps,
+		SSHCiphers:         p.SSHCiphers,
+		SSHCompression:     p.SSHCompression,
+		SSHControlPersist:  p.SSHControlPersist,
+		Description:        p.Description,
+		Enabled:            p.Enabled,
 	}
 }
 
+const (
+	BackupTargetSSHControlPersistDefault = 60
+	BackupTargetSSHControlPersistMax     = 3600
+)
+
+// backupTargetSSHCiphers are the OpenSSH ciphers a target may be tuned to,
+// keyed by the short names users tend to type.
+var backupTargetSSHCiphers = map[string]string{
+	"aes128-gcm":        "aes128-gcm@openssh.com",
+	"aes256-gcm":        "aes256-gcm@openssh.com",
+	"chacha20-poly1305": "chacha20-poly1305@openssh.com",
+	"aes128-ctr":        "aes128-ctr",
+	"aes192-ctr":        "aes192-ctr",
+	"aes256-ctr":        "aes256-ctr",
+}
+
+// NormalizeBackupTargetSSHCiphers canonicalizes a comma-separated cipher list,
+// accepting names with or without the @openssh.com suffix.
+func NormalizeBackupTargetSSHCiphers(value string) (string, error) {
+	var ciphers []string
+	for _, part := range strings.Split(value, ",") {
+		name := strings.ToLower(strings.TrimSpace(part))
+		if name == "" {
+			continue
+		}
+		canonical, ok := backupTargetSSHCiphers[strings.TrimSuffix(name, "@openssh.com")]
+		if !ok {
+			return "", fmt.Errorf("invalid_ssh_cipher: %s", name)
+		}
+		if !slices.Contains(ciphers, canonical) {
+			ciphers = append(ciphers, canonical)
+		}
+	}
+	return strings.Join(ciphers, ","), nil
+}
+
+// SSHControlPersistSeconds is how long an idle shared SSH connection to the
+// target is kept open.
+func (t *BackupTarget) SSHControlPersistSeconds() int {
+	if t == nil || t.SSHControlPersist <= 0 {
+		return BackupTargetSSHControlPersistDefault
+	}
+	return t.SSHControlPersist
+}
+
 // ZeltaEndpoint returns the Zelta-formatted endpoint string: user@host:pool/dataset
 func (t *BackupTarget) ZeltaEndpoint(suffix string) string {
 	root := t.BackupRoot
@@ -108,15 +237,102 @@ type BackupJob struct {
 	PruneTarget      bool         `gorm:"column:prune_target;default:false" json:"pruneTarget"`
 	StopBeforeBackup bool         `gorm:"column:stop_before_backup;default:false" json:"stopBeforeBackup"`
 	Recursive        bool         `gorm:"column:recursive;default:false" json:"recursive"`
-	Encrypted        bool         `gorm:"column:encrypted;default:false" json:"encrypted"`
-	CronExpr         string       `gorm:"not null" json:"cronExpr"`
-	Enabled          bool         `gorm:"index" json:"enabled"`
-	LastRunAt        *time.Time   `json:"lastRunAt"`
-	NextRunAt        *time.Time   `gorm:"index" json:"nextRunAt"`
-	LastStatus       string       `gorm:"index" json:"lastStatus"`
-	LastError        string       `gorm:"type:text" json:"lastError"`
-	CreatedAt        time.Time    `gorm:"autoCreateTime" json:"createdAt"`
-	UpdatedAt        time.Time    `gorm:"autoUpdateTime" json:"updatedAt"`
+	ShareQuiesce     string       `gorm:"column:share_quiesce;default:''" json:"shareQuiesce"`
+	// Comma-separated glob patterns choosing which of a VM's storage datasets
+	// are transferred, matched against the name relative to the VM dataset
+	// (e.g. "zvol-3", "raw-*") or the full dataset name. Empty includes
+	// everything; excludes win over includes.
+	VMDatasetIncludes string `gorm:"column:vm_dataset_includes;type:text;default:''" json:"vmDatasetIncludes"`
+	VMDatasetExcludes string `gorm:"column:vm_dataset_excludes;type:text;default:''" json:"vmDatasetExcludes"`
+	// Hook scripts run on the runner node right before the transfer and once
+	// it has finished. They are absolute paths to root-owned executables.
+	PreBackupScript    string     `gorm:"column:pre_backup_script;default:''" json:"preBackupScript"`
+	PostBackupScript   string     `gorm:"column:post_backup_script;default:''" json:"postBackupScript"`
+	HookTimeoutSeconds int        `gorm:"column:hook_timeout_seconds;default:0" json:"hookTimeoutSeconds"` // 0 = 30s
+	HookFailurePolicy  string     `gorm:"column:hook_failure_policy;default:'fail'" json:"hookFailurePolicy"`
+	Encrypted          bool       `gorm:"column:encrypted;default:false" json:"encrypted"`
+	ZeltaProfileID     uint       `gorm:"column:zelta_profile_id;default:0;index" json:"zeltaProfileId"` // 0 = zelta defaults
+	CronExpr           string     `gorm:"not null" json:"cronExpr"`
+	Enabled            bool       `gorm:"index" json:"enabled"`
+	LastRunAt          *time.Time `json:"lastRunAt"`
+	NextRunAt          *time.Time `gorm:"index" json:"nextRunAt"`
+	LastStatus         string     `gorm:"index" json:"lastStatus"`
+	LastError          string     `gorm:"type:text" json:"lastError"`
+	CreatedAt          time.Time  `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt          time.Time  `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+// BackupJobDatasetRename moves the source paths of the backup jobs run by
+// one node after a dataset there was renamed. Jobs without a runner are run
+// by the leader, so IncludeUnassigned is set when the leader renamed it.
+type BackupJobDatasetRename struct {
+	RunnerNodeID      string `json:"runnerNodeId"`
+	IncludeUnassigned bool   `json:"includeUnassigned"`
+	From              string `json:"from"`
+	To                string `json:"to"`
+}
+
+// RenameBackupJobDatasets applies a BackupJobDatasetRename in one
+// transaction. Dataset-mode jobs whose friendly source is still the old path
+// follow it; guest-mode jobs keep the guest name.
+func RenameBackupJobDatasets(db *gorm.DB, rename BackupJobDatasetRename) error {
+	from := strings.Trim(strings.TrimSpace(rename.From), "/")
+	to := strings.Trim(strings.TrimSpace(rename.To), "/")
+	if from == "" || to == "" {
+		return fmt.Errorf("backup_job_dataset_rename_names_required")
+	}
+	if from == to {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var jobs []BackupJob
+		query := tx.Select("id", "runner_node_id", "mode", "source_dataset", "jail_root_dataset", "friendly_src")
+		runner := strings.TrimSpace(rename.RunnerNodeID)
+		if rename.IncludeUnassigned {
+			query = query.Where("runner_node_id = ? OR runner_node_id = '' OR runner_node_id IS NULL", runner)
+		} else {
+			query = query.Where("runner_node_id = ?", runner)
+		}
+		if err := query.Find(&jobs).Error; err != nil {
+			return err
+		}
+
+		for _, job := range jobs {
+			updates := map[string]any{}
+			if moved, ok := renameDatasetPath(job.SourceDataset, from, to); ok {
+				updates["source_dataset"] = moved
+				if job.Mode == BackupJobModeDataset && strings.TrimSpace(job.FriendlySrc) == strings.TrimSpace(job.SourceDataset) {
+					updates["friendly_src"] = moved
+				}
+			}
+			if moved, ok := renameDatasetPath(job.JailRootDataset, from, to); ok {
+				updates["jail_root_dataset"] = moved
+			}
+			if len(updates) == 0 {
+				continue
+			}
+			if err := tx.Model(&BackupJob{}).Where("id = ?", job.ID).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// renameDatasetPath returns name with the from prefix replaced by to when
+// name is from itself or one of its descendants.
+func renameDatasetPath(name, from, to string) (string, bool) {
+	name = strings.TrimSpace(name)
+	switch {
+	case name == from:
+		return to, true
+	case strings.HasPrefix(name, from+"/"):
+		return to + strings.TrimPrefix(name, from), true
+	default:
+		return name, false
+	}
 }
 
 // BackupEvent records the result of a Zelta backup run.
@@ -126,15 +342,37 @@ type BackupEvent struct {
 	SourceDataset  string     `json:"sourceDataset"`
 	TargetEndpoint string     `json:"targetEndpoint"`
 	Mode           string     `json:"mode"`
-	Status         string     `gorm:"index" json:"status"` // "running", "success", "failed"
+	Status         string     `gorm:"index" json:"status"` // "running", "success", "failed", "simulated"
 	Error          string     `gorm:"type:text" json:"error"`
 	Output         string     `gorm:"type:text" json:"output"` // zelta output
+	QuiesceStatus  string     `json:"quiesceStatus"`
+	QuiesceDetail  string     `gorm:"type:text" json:"quiesceDetail"`
 	StartedAt      time.Time  `gorm:"index" json:"startedAt"`
 	CompletedAt    *time.Time `json:"completedAt"`
 	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"createdAt"`
 	UpdatedAt      time.Time  `gorm:"autoUpdateTime" json:"updatedAt"`
 }
 
+// BackupEventDataset is one dataset of a restore that spans several, such as
+// the disks of a VM. The parent BackupEvent keeps the overall outcome and the
+// combined output; each child records its own status and bytes so progress
+// can be reported per dataset. Position is 1-based in restore order.
+type BackupEventDataset struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	EventID       uint       `gorm:"index;not null" json:"eventId"`
+	Position      int        `gorm:"not null" json:"position"`
+	SourceDataset string     `json:"sourceDataset"`
+	TargetDataset string     `json:"targetDataset"`
+	Status        string     `gorm:"index;not null" json:"status"` // "pending", "running", "success", "failed", "skipped"
+	MovedBytes    *uint64    `json:"movedBytes"`
+	TotalBytes    *uint64    `json:"totalBytes"`
+	Error         string     `gorm:"type:text" json:"error"`
+	StartedAt     *time.Time `json:"startedAt"`
+	CompletedAt   *time.Time `json:"completedAt"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt     time.Time  `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
 func upsertBackupTarget(db *gorm.DB, target *BackupTarget) error {
 	if target.ID == 0 {
 		return fmt.Errorf("backup_target_id_required")
@@ -160,16 +398,20 @@ func upsertBackupTarget(db *gorm.DB, target *BackupTarget) error {
 
 		now := time.Now()
 		updates := map[string]any{
-			"name":               target.Name,
-			"ssh_host":           target.SSHHost,
-			"ssh_port":           target.SSHPort,
-			"ssh_key_path":       target.SSHKeyPath,
-			"ssh_key":            target.SSHKey,
-			"backup_root":        target.BackupRoot,
-			"create_backup_root": target.CreateBackupRoot,
-			"description":        target.Description,
-			"enabled":            target.Enabled,
-			"updated_at":         now,
+			"name":                 target.Name,
+			"ssh_host":             target.SSHHost,
+			"ssh_port":             target.SSHPort,
+			"ssh_key_path":         target.SSHKeyPath,
+			"ssh_key":              target.SSHKey,
+			"backup_root":          target.BackupRoot,
+			"create_backup_root":   target.CreateBackupRoot,
+			"bandwidth_limit_kbps": target.BandwidthLimitKBps,
+			"ssh_ciphers":          target.SSHCiphers,
+			"ssh_compression":      target.SSHCompression,
+			"ssh_control_persist":  target.SSHControlPersist,
+			"description":          target.Description,
+			"enabled":              target.Enabled,
+			"updated_at":           now,
 		}
 
 		switch {
@@ -224,6 +466,10 @@ func backupTargetsEquivalent(existing BackupTarget, incoming BackupTarget) bool
 		existing.SSHKey == incoming.SSHKey &&
 		existing.BackupRoot == incoming.BackupRoot &&
 		existing.CreateBackupRoot == incoming.CreateBackupRoot &&
+		existing.BandwidthLimitKBps == incoming.BandwidthLimitKBps &&
+		existing.SSHCiphers == incoming.SSHCiphers &&
+		existing.SSHCompression == incoming.SSHCompression &&
+		existing.SSHControlPersist == incoming.SSHControlPersist &&
 		existing.Description == incoming.Description &&
 		existing.Enabled == incoming.Enabled
 }
@@ -248,6 +494,7 @@ func upsertBackupJob(db *gorm.DB, job *BackupJob) error {
 			"prune_target",
 			"stop_before_backup",
 			"recursive",
+			"zelta_profile_id",
 			"cron_expr",
 			"enabled",
 			"next_run_at",
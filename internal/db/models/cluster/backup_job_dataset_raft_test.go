@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterModels
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFSMDispatcherBackupJobDatasetRename(t *testing.T) {
+	db := newClusterModelTestDB(t, &BackupJob{})
+	fsm := NewFSMDispatcher(db)
+	RegisterDefaultHandlers(fsm)
+
+	seedJobs := []BackupJob{
+		{ID: 1, Name: "exact", TargetID: 10, RunnerNodeID: "node-a", Mode: BackupJobModeDataset,
+			SourceDataset: "tank/data", FriendlySrc: "tank/data", CronExpr: "* * * * *"},
+		{ID: 2, Name: "child", TargetID: 10, RunnerNodeID: "node-a", Mode: BackupJobModeDataset,
+			SourceDataset: "tank/data/photos", FriendlySrc: "Photos", CronExpr: "* * * * *"},
+		{ID: 3, Name: "sibling", TargetID: 10, RunnerNodeID: "node-a", Mode: BackupJobModeDataset,
+			SourceDataset: "tank/database", FriendlySrc: "tank/database", CronExpr: "* * * * *"},
+		{ID: 4, Name: "other-node", TargetID: 10, RunnerNodeID: "node-b", Mode: BackupJobModeDataset,
+			SourceDataset: "tank/data", FriendlySrc: "tank/data", CronExpr: "* * * * *"},
+		{ID: 5, Name: "unassigned", TargetID: 10, Mode: BackupJobModeJail,
+			JailRootDataset: "tank/data/jail", FriendlySrc: "web", CronExpr: "* * * * *"},
+	}
+	for _, job := range seedJobs {
+		if err := db.Create(&job).Error; err != nil {
+			t.Fatalf("seed job %d: %v", job.ID, err)
+		}
+	}
+
+	apply := func(rename BackupJobDatasetRename) {
+		t.Helper()
+		raw, _ := json.Marshal(rename)
+		if err := applyFSMCommand(t, fsm, Command{Type: "backup_job_dataset", Action: "rename", Data: raw}); err != nil {
+			t.Fatalf("rename failed: %v", err)
+		}
+	}
+	load := func(id uint) BackupJob {
+		t.Helper()
+		var job BackupJob
+		if err := db.First(&job, id).Error; err != nil {
+			t.Fatalf("load job %d: %v", id, err)
+		}
+		return job
+	}
+
+	apply(BackupJobDatasetRename{RunnerNodeID: "node-a", From: "tank/data", To: "tank/archive/data"})
+
+	if job := load(1); job.SourceDataset != "tank/archive/data" || job.FriendlySrc != "tank/archive/data" {
+		t.Fatalf("exact match not renamed: %+v", job)
+	}
+	if job := load(2); job.SourceDataset != "tank/archive/data/photos" || job.FriendlySrc != "Photos" {
+		t.Fatalf("descendant not renamed or custom friendly source lost: %+v", job)
+	}
+	if job := load(3); job.SourceDataset != "tank/database" {
+		t.Fatalf("sibling with a shared prefix must not move: %+v", job)
+	}
+	if job := load(4); job.SourceDataset != "tank/data" {
+		t.Fatalf("another node's job must not move: %+v", job)
+	}
+	if job := load(5); job.JailRootDataset != "tank/data/jail" {
+		t.Fatalf("unassigned job must only move when the leader renamed: %+v", job)
+	}
+
+	apply(BackupJobDatasetRename{RunnerNodeID: "node-a", IncludeUnassigned: true, From: "tank/data", To: "tank/archive/data"})
+	if job := load(5); job.JailRootDataset != "tank/archive/data/jail" {
+		t.Fatalf("unassigned job not renamed: %+v", job)
+	}
+
+	raw, _ := json.Marshal(BackupJobDatasetRename{RunnerNodeID: "node-a", To: "tank/x"})
+	if err := applyFSMCommand(t, fsm, Command{Type: "backup_job_dataset", Action: "rename", Data: raw}); err == nil {
+		t.Fatal("expected an error without a source name")
+	}
+}
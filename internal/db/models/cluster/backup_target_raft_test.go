@@ -56,6 +56,8 @@ func TestFSMDispatcherBackupTargetCommands(t *testing.T) {
 		SSHKey:           "key-b",
 		BackupRoot:       "pool-b/backups",
 		CreateBackupRoot: true,
+		SSHCiphers:       "aes128-gcm@openssh.com",
+		SSHCompression:   true,
 		Description:      "updated target",
 		Enabled:          false,
 	})
@@ -74,6 +76,9 @@ func TestFSMDispatcherBackupTargetCommands(t *testing.T) {
 	if updated.Name != "target-a-updated" || updated.SSHPort != 2222 || updated.Enabled != false {
 		t.Fatalf("updated backup target mismatch: %+v", updated)
 	}
+	if updated.SSHCiphers != "aes128-gcm@openssh.com" || !updated.SSHCompression {
+		t.Fatalf("updated backup target ssh tuning mismatch: %+v", updated)
+	}
 
 	deletePayload, _ := json.Marshal(struct {
 		ID uint `json:"id"`
@@ -164,3 +169,20 @@ func TestFSMDispatcherBackupTargetMalformedPayload(t *testing.T) {
 		t.Fatalf("expected handler wrapped error, got: %v", err)
 	}
 }
+
+func TestNormalizeBackupTargetSSHCiphers(t *testing.T) {
+	got, err := NormalizeBackupTargetSSHCiphers(" AES128-GCM, aes128-gcm@openssh.com,chacha20-poly1305 ,")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "aes128-gcm@openssh.com,chacha20-poly1305@openssh.com" {
+		t.Fatalf("unexpected cipher list %q", got)
+	}
+
+	if _, err := NormalizeBackupTargetSSHCiphers("aes128-gcm,3des-cbc"); err == nil {
+		t.Fatal("expected an unsupported cipher to be refused")
+	}
+	if got, err := NormalizeBackupTargetSSHCiphers(""); err != nil || got != "" {
+		t.Fatalf("expected an empty list to stay empty, got %q, %v", got, err)
+	}
+}
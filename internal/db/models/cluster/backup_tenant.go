@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterModels
+
+import "time"
+
+// BackupTenant is a client namespace on a node that receives backups from
+// other Sylve installations. Tenants describe local datasets and Unix users,
+// so they are kept in the node's own database and never replicated by raft.
+//
+// Namespace, when set, is the child of Dataset the tenant's client has to
+// create and receive into: the source's node UUID or a prefix the operator
+// picked. It keeps sources that share a tenant off each other's lineages.
+type BackupTenant struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	Name         string    `gorm:"uniqueIndex;not null" json:"name"`
+	Dataset      string    `gorm:"uniqueIndex;not null" json:"dataset"`
+	SSHUser      string    `gorm:"column:ssh_user;uniqueIndex;not null" json:"sshUser"`
+	SSHPublicKey string    `gorm:"column:ssh_public_key;type:text" json:"sshPublicKey"`
+	QuotaBytes   uint64    `gorm:"column:quota_bytes;default:0" json:"quotaBytes"`
+	Namespace    string    `gorm:"default:''" json:"namespace"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+}
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterModels
+
+import "time"
+
+const (
+	BulkRestoreStatusPlanned     = "planned"
+	BulkRestoreStatusRunning     = "running"
+	BulkRestoreStatusCompleted   = "completed"
+	BulkRestoreStatusPartial     = "partial"
+	BulkRestoreStatusFailed      = "failed"
+	BulkRestoreStatusCancelled   = "cancelled"
+	BulkRestoreStatusInterrupted = "interrupted"
+
+	BulkRestoreItemPending   = "pending"
+	BulkRestoreItemRunning   = "running"
+	BulkRestoreItemCompleted = "completed"
+	BulkRestoreItemFailed    = "failed"
+	BulkRestoreItemSkipped   = "skipped"
+)
+
+// BulkRestore restores a set of guests from one backup target onto this
+// node, the way an operator rebuilds a host that was lost. It runs on the
+// node that receives the guests, so it is never replicated by raft.
+type BulkRestore struct {
+	ID             uint              `gorm:"primaryKey" json:"id"`
+	TargetID       uint              `gorm:"index;not null" json:"targetId"`
+	TargetName     string            `json:"targetName"`
+	Pool           string            `json:"pool"` // empty keeps each guest's original pool
+	Concurrency    int               `json:"concurrency"`
+	RestoreNetwork bool              `json:"restoreNetwork"`
+	Status         string            `gorm:"index" json:"status"`
+	Total          int               `json:"total"`
+	Completed      int               `json:"completed"`
+	Failed         int               `json:"failed"`
+	Skipped        int               `json:"skipped"`
+	Report         string            `gorm:"type:text" json:"report"`
+	Items          []BulkRestoreItem `gorm:"foreignKey:BulkRestoreID;constraint:OnDelete:CASCADE" json:"items"`
+	StartedAt      time.Time         `json:"startedAt"`
+	CompletedAt    *time.Time        `json:"completedAt"`
+}
+
+// BulkRestoreItem is one guest of a BulkRestore. Items start in Position
+// order; at most Concurrency of them run at once.
+type BulkRestoreItem struct {
+	ID                 uint       `gorm:"primaryKey" json:"id"`
+	BulkRestoreID      uint       `gorm:"index;not null" json:"bulkRestoreId"`
+	Position           int        `json:"position"`
+	Kind               string     `json:"kind"`
+	GuestID            uint       `json:"guestId"`
+	RemoteDataset      string     `json:"remoteDataset"`
+	Snapshot           string     `json:"snapshot"`
+	DestinationDataset string     `json:"destinationDataset"`
+	Status             string     `json:"status"`
+	Error              string     `gorm:"type:text" json:"error"`
+	StartedAt          *time.Time `json:"startedAt"`
+	CompletedAt        *time.Time `json:"completedAt"`
+}
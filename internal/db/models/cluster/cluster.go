@@ -11,17 +11,24 @@ package clusterModels
 import (
 	"time"
 
+	"github.com/alchemillahq/sylve/internal/db/secrets"
 	hub "github.com/alchemillahq/sylve/internal/events"
 	"gorm.io/gorm"
 )
 
 type Cluster struct {
-	ID            uint   `gorm:"primaryKey" json:"id"`
-	Enabled       bool   `json:"enabled"`
-	Key           string `json:"key"`
-	RaftBootstrap *bool  `json:"raftBootstrap"`
-	RaftIP        string `json:"raftIP"`
-	RaftPort      int    `json:"raftPort"`
+	ID            uint           `gorm:"primaryKey" json:"id"`
+	Enabled       bool           `json:"enabled"`
+	Key           secrets.String `json:"key"`
+	RaftBootstrap *bool          `json:"raftBootstrap"`
+	RaftIP        string         `json:"raftIP"`
+	RaftPort      int            `json:"raftPort"`
+	// ReplicationIP, when set, is advertised to peers for SSH transfers
+	// instead of RaftIP so bulk traffic stays on a dedicated network.
+	ReplicationIP string `json:"replicationIP"`
+	// SSHKeyRotationDays rotates this node's cluster SSH key on a schedule;
+	// 0 leaves rotation to the operator.
+	SSHKeyRotationDays int `json:"sshKeyRotationDays"`
 }
 
 func publishClusterRefresh() {
@@ -12,6 +12,7 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestFSMDispatcherClusterSSHIdentityCommands(t *testing.T) {
@@ -77,6 +78,50 @@ func TestFSMDispatcherClusterSSHIdentityCommands(t *testing.T) {
 		}
 	})
 
+	t.Run("rotation keeps previous key until republish without one", func(t *testing.T) {
+		graceUntil := time.Now().Add(time.Hour).UTC()
+		raw, _ := json.Marshal(ClusterSSHIdentity{
+			NodeUUID: "node-uuid-1", SSHUser: "admin",
+			SSHHost: "10.0.0.1", SSHPort: 22,
+			PublicKey:            "ssh-ed25519 CCCC...",
+			PreviousPublicKey:    "ssh-ed25519 BBBB...",
+			PreviousKeyExpiresAt: &graceUntil,
+		})
+		if err := applyFSMCommand(t, fsm, Command{
+			Type: "cluster_ssh_identity", Action: "upsert", Data: raw,
+		}); err != nil {
+			t.Fatalf("rotation upsert failed: %v", err)
+		}
+
+		// A routine republish carries no previous key and must not clear it.
+		raw, _ = json.Marshal(ClusterSSHIdentity{
+			NodeUUID: "node-uuid-1", SSHUser: "admin",
+			SSHHost: "10.0.0.1", SSHPort: 22,
+			PublicKey: "ssh-ed25519 CCCC...",
+		})
+		if err := applyFSMCommand(t, fsm, Command{
+			Type: "cluster_ssh_identity", Action: "upsert", Data: raw,
+		}); err != nil {
+			t.Fatalf("republish failed: %v", err)
+		}
+
+		var identity ClusterSSHIdentity
+		db.Where("node_uuid = ?", "node-uuid-1").First(&identity)
+		if identity.PreviousPublicKey != "ssh-ed25519 BBBB..." {
+			t.Fatalf("previous key lost: %q", identity.PreviousPublicKey)
+		}
+
+		keys := identity.AcceptedPublicKeys(time.Now())
+		if len(keys) != 2 || keys[0] != "ssh-ed25519 CCCC..." || keys[1] != "ssh-ed25519 BBBB..." {
+			t.Fatalf("unexpected accepted keys in grace window: %v", keys)
+		}
+
+		keys = identity.AcceptedPublicKeys(graceUntil.Add(time.Second))
+		if len(keys) != 1 || keys[0] != "ssh-ed25519 CCCC..." {
+			t.Fatalf("expected only current key after grace window: %v", keys)
+		}
+	})
+
 	t.Run("upsert empty public_key fails validation", func(t *testing.T) {
 		raw, _ := json.Marshal(ClusterSSHIdentity{
 			NodeUUID: "node-uuid-2", SSHHost: "host2",
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterModels
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/db/secrets"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ClusterCAID is the primary key of the only ClusterCA row.
+const ClusterCAID = 1
+
+// ClusterCA is the authority peers pin for intra-cluster HTTPS. Every member
+// holds the key so whichever node is leader can sign certificates. While a
+// rotation is in progress the replaced certificate stays trusted until each
+// node carries a certificate from the new one.
+type ClusterCA struct {
+	ID                  uint           `gorm:"primaryKey" json:"id"`
+	CertPEM             string         `gorm:"type:text;not null" json:"certPEM"`
+	KeyPEM              secrets.String `gorm:"type:text;not null" json:"keyPEM"`
+	Fingerprint         string         `gorm:"not null" json:"fingerprint"`
+	NotAfter            time.Time      `json:"notAfter"`
+	PreviousCertPEM     string         `gorm:"type:text" json:"previousCertPEM"`
+	PreviousFingerprint string         `json:"previousFingerprint"`
+	// Enforce rejects peers whose certificate does not chain to the CA.
+	// It stays off until every node holds a certificate, so a cluster can
+	// be upgraded node by node.
+	Enforce   bool       `gorm:"not null;default:false" json:"enforce"`
+	RotatedAt *time.Time `json:"rotatedAt"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt time.Time  `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+// ClusterNodeCertificate records the certificate the CA last issued to a
+// node. The private key never leaves the node.
+type ClusterNodeCertificate struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	NodeUUID      string    `gorm:"column:node_uuid;uniqueIndex;not null" json:"nodeUUID"`
+	CertPEM       string    `gorm:"type:text;not null" json:"certPEM"`
+	Serial        string    `gorm:"not null" json:"serial"`
+	CAFingerprint string    `gorm:"not null" json:"caFingerprint"`
+	NotAfter      time.Time `json:"notAfter"`
+	IssuedAt      time.Time `json:"issuedAt"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+func UpsertClusterCA(db *gorm.DB, ca *ClusterCA) error {
+	if strings.TrimSpace(ca.CertPEM) == "" || strings.TrimSpace(string(ca.KeyPEM)) == "" {
+		return fmt.Errorf("cluster_ca_material_required")
+	}
+	ca.ID = ClusterCAID
+
+	return db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"cert_pem",
+			"key_pem",
+			"fingerprint",
+			"not_after",
+			"previous_cert_pem",
+			"previous_fingerprint",
+			"enforce",
+			"rotated_at",
+			"updated_at",
+		}),
+	}).Create(ca).Error
+}
+
+func UpsertClusterNodeCertificate(db *gorm.DB, cert *ClusterNodeCertificate) error {
+	cert.NodeUUID = strings.TrimSpace(cert.NodeUUID)
+	if cert.NodeUUID == "" {
+		return fmt.Errorf("node_uuid_required")
+	}
+	if strings.TrimSpace(cert.CertPEM) == "" {
+		return fmt.Errorf("cluster_node_certificate_required")
+	}
+
+	return db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "node_uuid"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"cert_pem",
+			"serial",
+			"ca_fingerprint",
+			"not_after",
+			"issued_at",
+			"updated_at",
+		}),
+	}).Create(cert).Error
+}
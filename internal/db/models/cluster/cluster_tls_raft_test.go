@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterModels
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestFSMDispatcherClusterCARotation(t *testing.T) {
+	db := newClusterModelTestDB(t, &ClusterCA{})
+	fsm := NewFSMDispatcher(db)
+	RegisterDefaultHandlers(fsm)
+
+	first, _ := json.Marshal(ClusterCA{CertPEM: "cert-a", KeyPEM: "key-a", Fingerprint: "fp-a"})
+	if err := applyFSMCommand(t, fsm, Command{Type: "cluster_ca", Action: "upsert", Data: first}); err != nil {
+		t.Fatalf("cluster CA upsert failed: %v", err)
+	}
+
+	rotatedAt := time.Now().UTC()
+	second, _ := json.Marshal(ClusterCA{
+		CertPEM:             "cert-b",
+		KeyPEM:              "key-b",
+		Fingerprint:         "fp-b",
+		PreviousCertPEM:     "cert-a",
+		PreviousFingerprint: "fp-a",
+		Enforce:             true,
+		RotatedAt:           &rotatedAt,
+	})
+	if err := applyFSMCommand(t, fsm, Command{Type: "cluster_ca", Action: "upsert", Data: second}); err != nil {
+		t.Fatalf("cluster CA rotation failed: %v", err)
+	}
+
+	var cas []ClusterCA
+	if err := db.Find(&cas).Error; err != nil {
+		t.Fatalf("failed to load cluster CA: %v", err)
+	}
+	if len(cas) != 1 || cas[0].ID != ClusterCAID {
+		t.Fatalf("expected a single cluster CA row, got %+v", cas)
+	}
+	if cas[0].KeyPEM != "key-b" || cas[0].PreviousFingerprint != "fp-a" || !cas[0].Enforce {
+		t.Fatalf("rotation not applied: %+v", cas[0])
+	}
+
+	empty, _ := json.Marshal(ClusterCA{Fingerprint: "fp-c"})
+	if err := applyFSMCommand(t, fsm, Command{Type: "cluster_ca", Action: "upsert", Data: empty}); err == nil {
+		t.Fatal("expected a CA without key material to be rejected")
+	}
+}
+
+func TestFSMDispatcherClusterNodeCertificate(t *testing.T) {
+	db := newClusterModelTestDB(t, &ClusterNodeCertificate{})
+	fsm := NewFSMDispatcher(db)
+	RegisterDefaultHandlers(fsm)
+
+	for _, fp := range []string{"fp-a", "fp-b"} {
+		data, _ := json.Marshal(ClusterNodeCertificate{NodeUUID: "node-1", CertPEM: "cert-" + fp, Serial: fp, CAFingerprint: fp})
+		if err := applyFSMCommand(t, fsm, Command{Type: "cluster_node_certificate", Action: "upsert", Data: data}); err != nil {
+			t.Fatalf("node certificate upsert failed: %v", err)
+		}
+	}
+
+	var stored []ClusterNodeCertificate
+	if err := db.Find(&stored).Error; err != nil {
+		t.Fatalf("failed to load node certificates: %v", err)
+	}
+	if len(stored) != 1 || stored[0].CAFingerprint != "fp-b" {
+		t.Fatalf("expected the renewed certificate to replace the old one, got %+v", stored)
+	}
+
+	data, _ := json.Marshal(map[string]string{"nodeUUID": "node-1"})
+	if err := applyFSMCommand(t, fsm, Command{Type: "cluster_node_certificate", Action: "delete", Data: data}); err != nil {
+		t.Fatalf("node certificate delete failed: %v", err)
+	}
+
+	var count int64
+	db.Model(&ClusterNodeCertificate{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("expected node certificate to be deleted, %d left", count)
+	}
+}
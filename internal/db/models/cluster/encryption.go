@@ -8,13 +8,17 @@
 
 package clusterModels
 
-import "time"
+import (
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/db/secrets"
+)
 
 type EncryptionKey struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	UUID      string    `gorm:"uniqueIndex;not null" json:"uuid"`
-	KeyData   string    `gorm:"type:text;not null" json:"keyData"`
-	KeyFormat string    `gorm:"default:passphrase" json:"keyFormat"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdAt"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	UUID      string         `gorm:"uniqueIndex;not null" json:"uuid"`
+	KeyData   secrets.String `gorm:"type:text;not null" json:"keyData"`
+	KeyFormat string         `gorm:"default:passphrase" json:"keyFormat"`
+	CreatedAt time.Time      `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updatedAt"`
 }
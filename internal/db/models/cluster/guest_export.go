@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterModels
+
+import "time"
+
+const (
+	GuestExportFormatZFS = "zfs"
+	GuestExportFormatTar = "tar"
+
+	GuestExportStatusRunning = "running"
+	GuestExportStatusReady   = "ready"
+	GuestExportStatusFailed  = "failed"
+)
+
+// GuestExport is a cold copy of a stopped guest written to a single archive
+// for download. Like RestoreScratch it is node-local: the archive only exists
+// on the node that wrote it.
+type GuestExport struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	GuestType   string     `gorm:"not null;index" json:"guestType"`
+	GuestID     uint       `gorm:"not null;index" json:"guestId"`
+	GuestName   string     `json:"guestName"`
+	Format      string     `gorm:"not null" json:"format"`
+	Path        string     `gorm:"not null" json:"path"`
+	Bytes       int64      `json:"bytes"`
+	Status      string     `gorm:"not null;index" json:"status"`
+	Error       string     `gorm:"type:text" json:"error"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"createdAt"`
+	CompletedAt *time.Time `json:"completedAt"`
+}
@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterModels
+
+import "time"
+
+const DefaultStaleDatasetMinAgeHours = 72
+
+// StaleDatasetJanitor configures the sweep for leftover .restoring and
+// .pre_sylve_ datasets on this node and its backup targets. Like NodeStandby
+// it is node-local and each node keeps at most one row. Without a row the
+// janitor only reports.
+type StaleDatasetJanitor struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	AutoCleanup    bool       `json:"autoCleanup"`
+	MinAgeHours    int        `gorm:"default:72" json:"minAgeHours"`
+	IncludeTargets bool       `gorm:"default:true" json:"includeTargets"`
+	LastRunAt      *time.Time `json:"lastRunAt"`
+	LastDestroyed  int        `json:"lastDestroyed"`
+	LastError      string     `gorm:"type:text" json:"lastError"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime" json:"updatedAt"`
+}
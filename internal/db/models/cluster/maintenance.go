@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterModels
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	MaintenanceScopeNode   = "node"
+	MaintenanceScopeGuest  = "guest"
+	MaintenanceScopePolicy = "policy"
+
+	MaintenanceActionNotification = "notification"
+	MaintenanceActionFailover     = "failover"
+	MaintenanceActionFailback     = "failback"
+	MaintenanceActionCrashRestart = "crash_restart"
+)
+
+// MaintenanceWindow silences notifications and the replication watchdog
+// (crash restarts, automatic failover and failback) for a node, a guest or a
+// replication policy between StartsAt and EndsAt. Windows are replicated by
+// raft so the leader's failover controller sees windows opened on any node,
+// and they stop applying at EndsAt on their own.
+type MaintenanceWindow struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Scope     string    `gorm:"index;not null" json:"scope"`
+	NodeID    string    `gorm:"index" json:"nodeId"`
+	GuestType string    `json:"guestType"`
+	GuestID   uint      `json:"guestId"`
+	PolicyID  uint      `gorm:"index" json:"policyId"`
+	Reason    string    `gorm:"type:text" json:"reason"`
+	CreatedBy string    `json:"createdBy"`
+	StartsAt  time.Time `gorm:"index;not null" json:"startsAt"`
+	EndsAt    time.Time `gorm:"index;not null" json:"endsAt"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+// MaintenanceSubject is whatever an alert or a watchdog action is about.
+// Empty fields never match a window of that scope.
+type MaintenanceSubject struct {
+	NodeID    string
+	GuestType string
+	GuestID   uint
+	PolicyID  uint
+}
+
+func (w MaintenanceWindow) ActiveAt(now time.Time) bool {
+	return !now.Before(w.StartsAt) && now.Before(w.EndsAt)
+}
+
+func (w MaintenanceWindow) Covers(subject MaintenanceSubject) bool {
+	switch w.Scope {
+	case MaintenanceScopeNode:
+		nodeID := strings.TrimSpace(subject.NodeID)
+		return nodeID != "" && nodeID == strings.TrimSpace(w.NodeID)
+	case MaintenanceScopeGuest:
+		return subject.GuestID != 0 && subject.GuestID == w.GuestID &&
+			strings.TrimSpace(subject.GuestType) == w.GuestType
+	case MaintenanceScopePolicy:
+		return subject.PolicyID != 0 && subject.PolicyID == w.PolicyID
+	default:
+		return false
+	}
+}
+
+// MatchMaintenanceWindow returns the window that silences subject at now. When
+// several overlap, the one ending last wins so the audit names the window
+// that actually kept the alert quiet.
+func MatchMaintenanceWindow(windows []MaintenanceWindow, subject MaintenanceSubject, now time.Time) *MaintenanceWindow {
+	var match *MaintenanceWindow
+	for i := range windows {
+		if !windows[i].ActiveAt(now) || !windows[i].Covers(subject) {
+			continue
+		}
+		if match == nil || windows[i].EndsAt.After(match.EndsAt) {
+			match = &windows[i]
+		}
+	}
+	return match
+}
+
+func ListActiveMaintenanceWindows(db *gorm.DB, now time.Time) ([]MaintenanceWindow, error) {
+	var windows []MaintenanceWindow
+	err := db.
+		Where("starts_at <= ? AND ends_at > ?", now, now).
+		Order("id ASC").
+		Find(&windows).Error
+	return windows, err
+}
+
+// MaintenanceSuppression records what a window silenced on this node. Repeats
+// of the same event fold into one row so a flapping alarm cannot flood the
+// audit. Rows are node-local; the leader records suppressed failovers.
+type MaintenanceSuppression struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	WindowID    uint      `gorm:"uniqueIndex:idx_maintenance_suppression;not null" json:"windowId"`
+	Action      string    `gorm:"uniqueIndex:idx_maintenance_suppression;not null" json:"action"`
+	Key         string    `gorm:"uniqueIndex:idx_maintenance_suppression;not null" json:"key"`
+	Title       string    `json:"title"`
+	Detail      string    `gorm:"type:text" json:"detail"`
+	Occurrences int       `gorm:"not null;default:1" json:"occurrences"`
+	FirstAt     time.Time `json:"firstAt"`
+	LastAt      time.Time `gorm:"index" json:"lastAt"`
+}
+
+func RecordMaintenanceSuppression(db *gorm.DB, entry MaintenanceSuppression, now time.Time) error {
+	entry.ID = 0
+	entry.Occurrences = 1
+	entry.FirstAt = now
+	entry.LastAt = now
+
+	return db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "window_id"}, {Name: "action"}, {Name: "key"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"title":       entry.Title,
+			"detail":      entry.Detail,
+			"occurrences": gorm.Expr("maintenance_suppressions.occurrences + 1"),
+			"last_at":     now,
+		}),
+	}).Create(&entry).Error
+}
+
+func UpsertMaintenanceWindow(db *gorm.DB, w *MaintenanceWindow) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if w.ID == 0 {
+			var next uint
+			if err := tx.
+				Table("maintenance_windows").
+				Select("COALESCE(MAX(id), 0) + 1").
+				Scan(&next).Error; err != nil {
+				return err
+			}
+			w.ID = next
+		}
+
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"scope", "node_id", "guest_type", "guest_id", "policy_id",
+				"reason", "created_by", "starts_at", "ends_at", "updated_at",
+			}),
+		}).Create(w).Error
+	})
+}
+
+// EndMaintenanceWindow closes a window early. A window that has not started
+// yet collapses to an empty interval so it never applies.
+func EndMaintenanceWindow(db *gorm.DB, id uint, at time.Time) error {
+	var w MaintenanceWindow
+	if err := db.First(&w, id).Error; err != nil {
+		return err
+	}
+	if !at.Before(w.EndsAt) {
+		return nil
+	}
+
+	startsAt := w.StartsAt
+	if at.Before(startsAt) {
+		startsAt = at
+	}
+	return db.Model(&MaintenanceWindow{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"starts_at": startsAt, "ends_at": at}).Error
+}
+
+// DeleteMaintenanceWindow drops a window together with this node's audit of
+// it, so a later window reusing the ID starts with a clean history.
+func DeleteMaintenanceWindow(db *gorm.DB, id uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&MaintenanceWindow{}, id).Error; err != nil {
+			return err
+		}
+		return tx.Where("window_id = ?", id).Delete(&MaintenanceSuppression{}).Error
+	})
+}
@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterModels
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestMatchMaintenanceWindow(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC)
+	windows := []MaintenanceWindow{
+		{ID: 1, Scope: MaintenanceScopeNode, NodeID: "node-1", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+		{ID: 2, Scope: MaintenanceScopeNode, NodeID: "node-1", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(3 * time.Hour)},
+		{ID: 3, Scope: MaintenanceScopeGuest, GuestType: ReplicationGuestTypeJail, GuestID: 7, StartsAt: now, EndsAt: now.Add(time.Hour)},
+		{ID: 4, Scope: MaintenanceScopePolicy, PolicyID: 12, StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour)},
+		{ID: 5, Scope: MaintenanceScopePolicy, PolicyID: 13, StartsAt: now.Add(-2 * time.Hour), EndsAt: now},
+	}
+
+	cases := []struct {
+		name    string
+		subject MaintenanceSubject
+		want    uint
+	}{
+		{"longest node window wins", MaintenanceSubject{NodeID: "node-1"}, 2},
+		{"other node", MaintenanceSubject{NodeID: "node-2"}, 0},
+		{"guest", MaintenanceSubject{NodeID: "node-2", GuestType: ReplicationGuestTypeJail, GuestID: 7}, 3},
+		{"guest type must match", MaintenanceSubject{GuestType: ReplicationGuestTypeVM, GuestID: 7}, 0},
+		{"window not started", MaintenanceSubject{PolicyID: 12}, 0},
+		{"window ended", MaintenanceSubject{PolicyID: 13}, 0},
+		{"empty subject", MaintenanceSubject{}, 0},
+	}
+	for _, tc := range cases {
+		got := MatchMaintenanceWindow(windows, tc.subject, now)
+		if tc.want == 0 && got != nil || tc.want != 0 && (got == nil || got.ID != tc.want) {
+			t.Fatalf("%s: expected window %d, got %+v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestFSMDispatcherMaintenanceWindowLifecycle(t *testing.T) {
+	db := newClusterModelTestDB(t, &MaintenanceWindow{}, &MaintenanceSuppression{})
+	fsm := NewFSMDispatcher(db)
+	RegisterDefaultHandlers(fsm)
+
+	now := time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC)
+	for _, w := range []MaintenanceWindow{
+		{Scope: MaintenanceScopeNode, NodeID: "node-1", Reason: "disks", StartsAt: now, EndsAt: now.Add(time.Hour)},
+		{Scope: MaintenanceScopePolicy, PolicyID: 4, Reason: "later", StartsAt: now.Add(2 * time.Hour), EndsAt: now.Add(3 * time.Hour)},
+	} {
+		data, _ := json.Marshal(w)
+		if err := applyFSMCommand(t, fsm, Command{Type: "maintenance_window", Action: "create", Data: data}); err != nil {
+			t.Fatalf("create apply failed: %v", err)
+		}
+	}
+
+	active, err := ListActiveMaintenanceWindows(db, now.Add(time.Minute))
+	if err != nil || len(active) != 1 || active[0].ID != 1 {
+		t.Fatalf("expected window 1 to be active, got %+v (%v)", active, err)
+	}
+
+	endAt := now.Add(30 * time.Minute)
+	for _, id := range []uint{1, 2} {
+		data, _ := json.Marshal(map[string]any{"id": id, "at": endAt})
+		if err := applyFSMCommand(t, fsm, Command{Type: "maintenance_window", Action: "end", Data: data}); err != nil {
+			t.Fatalf("end apply failed: %v", err)
+		}
+	}
+
+	var windows []MaintenanceWindow
+	db.Order("id ASC").Find(&windows)
+	if len(windows) != 2 || !windows[0].EndsAt.Equal(endAt) {
+		t.Fatalf("expected window 1 to end early, got %+v", windows)
+	}
+	if !windows[1].StartsAt.Equal(endAt) || !windows[1].EndsAt.Equal(endAt) {
+		t.Fatalf("expected the unstarted window to collapse, got %+v", windows[1])
+	}
+
+	if err := RecordMaintenanceSuppression(db, MaintenanceSuppression{
+		WindowID: 1, Action: MaintenanceActionNotification, Key: "system.zfs.pool_state.tank",
+	}, now); err != nil {
+		t.Fatalf("record failed: %v", err)
+	}
+	data, _ := json.Marshal(map[string]any{"id": 1})
+	if err := applyFSMCommand(t, fsm, Command{Type: "maintenance_window", Action: "delete", Data: data}); err != nil {
+		t.Fatalf("delete apply failed: %v", err)
+	}
+
+	var remaining, audit int64
+	db.Model(&MaintenanceWindow{}).Count(&remaining)
+	db.Model(&MaintenanceSuppression{}).Count(&audit)
+	if remaining != 1 || audit != 0 {
+		t.Fatalf("expected one window and no audit left, got %d windows and %d rows", remaining, audit)
+	}
+}
+
+func TestRecordMaintenanceSuppressionFoldsRepeats(t *testing.T) {
+	db := newClusterModelTestDB(t, &MaintenanceSuppression{})
+	first := time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 3; i++ {
+		if err := RecordMaintenanceSuppression(db, MaintenanceSuppression{
+			WindowID: 1,
+			Action:   MaintenanceActionFailover,
+			Key:      "policy:4",
+			Title:    "Failover held",
+			Detail:   "attempt",
+		}, first.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatalf("record %d failed: %v", i, err)
+		}
+	}
+	if err := RecordMaintenanceSuppression(db, MaintenanceSuppression{
+		WindowID: 1, Action: MaintenanceActionFailback, Key: "policy:4",
+	}, first); err != nil {
+		t.Fatalf("record failback failed: %v", err)
+	}
+
+	var rows []MaintenanceSuppression
+	db.Order("id ASC").Find(&rows)
+	if len(rows) != 2 {
+		t.Fatalf("expected one row per action, got %+v", rows)
+	}
+	if rows[0].Occurrences != 3 || !rows[0].FirstAt.Equal(first) || !rows[0].LastAt.Equal(first.Add(2*time.Minute)) {
+		t.Fatalf("expected repeats to fold into one row, got %+v", rows[0])
+	}
+}
@@ -8,21 +8,47 @@
 
 package clusterModels
 
-import "time"
+import (
+	"strings"
+	"time"
+)
+
+// NodePoolHealth is one of a node's Sylve pools as of its last heartbeat.
+// The error counters are summed over the pool's leaf devices.
+type NodePoolHealth struct {
+	Name           string  `json:"name"`
+	State          string  `json:"state"`
+	Size           uint64  `json:"size"`
+	Alloc          uint64  `json:"alloc"`
+	UsedPct        float64 `json:"usedPct"`
+	ReadErrors     uint64  `json:"readErrors"`
+	WriteErrors    uint64  `json:"writeErrors"`
+	ChecksumErrors uint64  `json:"checksumErrors"`
+}
+
+func (p NodePoolHealth) Online() bool {
+	return p.State == "" || strings.EqualFold(p.State, "ONLINE")
+}
 
 type ClusterNode struct {
-	ID          uint      `gorm:"primaryKey" json:"id"`
-	NodeUUID    string    `json:"nodeUUID" gorm:"column:node_uuid;uniqueIndex;default:'';not null"`
-	Status      string    `json:"status"`
-	Hostname    string    `json:"hostname"`
-	API         string    `json:"api"`
-	CPU         int       `json:"cpu"`
-	CPUUsage    float64   `json:"cpuUsage"`
-	Memory      uint64    `json:"memory"`
-	MemoryUsage float64   `json:"memoryUsage"`
-	Disk        uint64    `json:"disk"`
-	DiskUsage   float64   `json:"diskUsage"`
-	GuestIDs    []uint    `json:"guestIDs" gorm:"serializer:json;type:json"`
-	CreatedAt   time.Time `json:"createdAt" gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+	ID          uint             `gorm:"primaryKey" json:"id"`
+	NodeUUID    string           `json:"nodeUUID" gorm:"column:node_uuid;uniqueIndex;default:'';not null"`
+	Status      string           `json:"status"`
+	Hostname    string           `json:"hostname"`
+	API         string           `json:"api"`
+	CPU         int              `json:"cpu"`
+	CPUUsage    float64          `json:"cpuUsage"`
+	Memory      uint64           `json:"memory"`
+	MemoryUsage float64          `json:"memoryUsage"`
+	Disk        uint64           `json:"disk"`
+	DiskUsage   float64          `json:"diskUsage"`
+	GuestIDs    []uint           `json:"guestIDs" gorm:"serializer:json;type:json"`
+	ClockStatus string           `json:"clockStatus" gorm:"default:'unknown'"`
+	ClockSkewMs int64            `json:"clockSkewMs"`
+	NTPSource   string           `json:"ntpSource"`
+	NTPSynced   bool             `json:"ntpSynced"`
+	NTPOffsetMs float64          `json:"ntpOffsetMs"`
+	Pools       []NodePoolHealth `json:"pools" gorm:"serializer:json;type:json"`
+	CreatedAt   time.Time        `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time        `json:"updatedAt" gorm:"autoUpdateTime"`
 }
@@ -16,10 +16,15 @@ import (
 )
 
 type ClusterOption struct {
-	ID             uint      `gorm:"primaryKey;autoIncrement:false" json:"id"`
-	KeyboardLayout string    `json:"keyboardLayout"`
-	CreatedAt      time.Time `gorm:"autoCreateTime" json:"createdAt"`
-	UpdatedAt      time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+	ID             uint   `gorm:"primaryKey;autoIncrement:false" json:"id"`
+	KeyboardLayout string `json:"keyboardLayout"`
+
+	// RestoreOverwriteGroup, when set, limits restores that overwrite an
+	// existing guest to members of this local group.
+	RestoreOverwriteGroup string `json:"restoreOverwriteGroup"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
 }
 
 func upsertOption(db *gorm.DB, o *ClusterOption) error {
@@ -29,8 +34,9 @@ func upsertOption(db *gorm.DB, o *ClusterOption) error {
 	return db.Clauses(clause.OnConflict{
 		Columns: []clause.Column{{Name: "id"}},
 		DoUpdates: clause.Assignments(map[string]any{
-			"keyboard_layout": o.KeyboardLayout,
-			"updated_at":      time.Now(),
+			"keyboard_layout":         o.KeyboardLayout,
+			"restore_overwrite_group": o.RestoreOverwriteGroup,
+			"updated_at":              time.Now(),
 		}),
 	}).Create(o).Error
 }
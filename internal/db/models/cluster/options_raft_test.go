@@ -57,6 +57,24 @@ func TestFSMDispatcherOptionsCommands(t *testing.T) {
 		}
 	})
 
+	t.Run("set restore_overwrite_group", func(t *testing.T) {
+		raw, _ := json.Marshal(ClusterOption{
+			KeyboardLayout:        "us",
+			RestoreOverwriteGroup: "senior-operators",
+		})
+		if err := applyFSMCommand(t, fsm, Command{
+			Type: "options", Action: "set", Data: raw,
+		}); err != nil {
+			t.Fatalf("set failed: %v", err)
+		}
+
+		var opt ClusterOption
+		db.First(&opt, 1)
+		if opt.RestoreOverwriteGroup != "senior-operators" || opt.KeyboardLayout != "us" {
+			t.Fatalf("unexpected options: %+v", opt)
+		}
+	})
+
 	t.Run("unknown action is no-op", func(t *testing.T) {
 		raw, _ := json.Marshal(ClusterOption{
 			KeyboardLayout: "us",
@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterModels
+
+import "time"
+
+const PostRestoreScriptDefaultInterpreter = "/bin/sh"
+
+// PostRestoreScript is run inside a guest after it has been restored from a
+// backup, through jexec for jails and the guest agent for VMs, so that
+// restored copies can fix up addresses, domain membership and the like. The
+// script is passed to the interpreter with -c. Scripts are kept in the node's
+// own database next to the restore events they report to.
+type PostRestoreScript struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+
+	GuestType string `gorm:"uniqueIndex:idx_post_restore_script_guest;not null" json:"guestType"`
+	GuestID   uint   `gorm:"uniqueIndex:idx_post_restore_script_guest;not null" json:"guestId"`
+
+	Script         string `gorm:"type:text;not null" json:"script"`
+	Interpreter    string `gorm:"not null;default:/bin/sh" json:"interpreter"`
+	TimeoutSeconds int    `json:"timeoutSeconds"`
+	Enabled        bool   `json:"enabled"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+}
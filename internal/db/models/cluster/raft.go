@@ -89,6 +89,11 @@ type ClusterSnapshot struct {
 	ReplicationEvents      []ReplicationEvent                 `json:"replicationEvents"`
 	SSHIdentities          []ClusterSSHIdentity               `json:"sshIdentities"`
 	EncryptionKeys         []EncryptionKey                    `json:"encryptionKeys"`
+	MaintenanceWindows     []MaintenanceWindow                `json:"maintenanceWindows"`
+	ZeltaProfiles          []ZeltaProfile                     `json:"zeltaProfiles"`
+	ReplicationTemplates   []ReplicationPolicyTemplate        `json:"replicationTemplates"`
+	ClusterCAs             []ClusterCA                        `json:"clusterCAs"`
+	NodeCertificates       []ClusterNodeCertificate           `json:"nodeCertificates"`
 	// We can add more tables here as needed
 }
 
@@ -142,6 +147,21 @@ func (f *FSMDispatcher) Snapshot() (raft.FSMSnapshot, error) {
 	if err := f.DB.Order("id ASC").Find(&snap.EncryptionKeys).Error; err != nil {
 		return nil, err
 	}
+	if err := f.DB.Order("id ASC").Find(&snap.MaintenanceWindows).Error; err != nil {
+		return nil, err
+	}
+	if err := f.DB.Order("id ASC").Find(&snap.ZeltaProfiles).Error; err != nil {
+		return nil, err
+	}
+	if err := f.DB.Order("id ASC").Find(&snap.ReplicationTemplates).Error; err != nil {
+		return nil, err
+	}
+	if err := f.DB.Order("id ASC").Find(&snap.ClusterCAs).Error; err != nil {
+		return nil, err
+	}
+	if err := f.DB.Order("id ASC").Find(&snap.NodeCertificates).Error; err != nil {
+		return nil, err
+	}
 	return &snap, nil
 }
 
@@ -207,6 +227,11 @@ func (f *FSMDispatcher) Restore(rc io.ReadCloser) error {
 			restoreSet{"backup_targets", backupTargets, 200},
 			restoreSet{"cluster_notes", snap.Notes, 500},
 			restoreSet{"cluster_options", snap.Options, 100},
+			restoreSet{"maintenance_windows", snap.MaintenanceWindows, 500},
+			restoreSet{"zelta_profiles", snap.ZeltaProfiles, 200},
+			restoreSet{"replication_policy_templates", snap.ReplicationTemplates, 200},
+			restoreSet{"cluster_cas", snap.ClusterCAs, 10},
+			restoreSet{"cluster_node_certificates", snap.NodeCertificates, 200},
 		)
 
 		createSets := []restoreSet{
@@ -224,6 +249,11 @@ func (f *FSMDispatcher) Restore(rc io.ReadCloser) error {
 			restoreSet{"backup_jobs", snap.BackupJobs, 500},
 			restoreSet{"cluster_notes", snap.Notes, 500},
 			restoreSet{"cluster_options", snap.Options, 100},
+			restoreSet{"maintenance_windows", snap.MaintenanceWindows, 500},
+			restoreSet{"zelta_profiles", snap.ZeltaProfiles, 200},
+			restoreSet{"replication_policy_templates", snap.ReplicationTemplates, 200},
+			restoreSet{"cluster_cas", snap.ClusterCAs, 10},
+			restoreSet{"cluster_node_certificates", snap.NodeCertificates, 200},
 		)
 
 		for _, s := range deleteSets {
@@ -364,21 +394,29 @@ func RegisterDefaultHandlers(fsm *FSMDispatcher) {
 			}
 			// Use Updates with map to properly handle boolean false values
 			return db.Model(&BackupJob{}).Where("id = ?", job.ID).Updates(map[string]any{
-				"name":               job.Name,
-				"target_id":          job.TargetID,
-				"runner_node_id":     job.RunnerNodeID,
-				"mode":               job.Mode,
-				"source_dataset":     job.SourceDataset,
-				"jail_root_dataset":  job.JailRootDataset,
-				"friendly_src":       job.FriendlySrc,
-				"dest_suffix":        job.DestSuffix,
-				"prune_keep_last":    job.PruneKeepLast,
-				"prune_target":       job.PruneTarget,
-				"stop_before_backup": job.StopBeforeBackup,
-				"recursive":          job.Recursive,
-				"cron_expr":          job.CronExpr,
-				"enabled":            job.Enabled,
-				"next_run_at":        job.NextRunAt,
+				"name":                 job.Name,
+				"target_id":            job.TargetID,
+				"runner_node_id":       job.RunnerNodeID,
+				"mode":                 job.Mode,
+				"source_dataset":       job.SourceDataset,
+				"jail_root_dataset":    job.JailRootDataset,
+				"friendly_src":         job.FriendlySrc,
+				"dest_suffix":          job.DestSuffix,
+				"prune_keep_last":      job.PruneKeepLast,
+				"prune_target":         job.PruneTarget,
+				"stop_before_backup":   job.StopBeforeBackup,
+				"recursive":            job.Recursive,
+				"share_quiesce":        job.ShareQuiesce,
+				"vm_dataset_includes":  job.VMDatasetIncludes,
+				"vm_dataset_excludes":  job.VMDatasetExcludes,
+				"pre_backup_script":    job.PreBackupScript,
+				"post_backup_script":   job.PostBackupScript,
+				"hook_timeout_seconds": job.HookTimeoutSeconds,
+				"hook_failure_policy":  job.HookFailurePolicy,
+				"zelta_profile_id":     job.ZeltaProfileID,
+				"cron_expr":            job.CronExpr,
+				"enabled":              job.Enabled,
+				"next_run_at":          job.NextRunAt,
 			}).Error
 		case "delete":
 			var payload struct {
@@ -492,6 +530,19 @@ func RegisterDefaultHandlers(fsm *FSMDispatcher) {
 		}
 	})
 
+	fsm.Register("backup_job_dataset", func(db *gorm.DB, action string, raw json.RawMessage) error {
+		switch action {
+		case "rename":
+			var payload BackupJobDatasetRename
+			if err := json.Unmarshal(raw, &payload); err != nil {
+				return err
+			}
+			return RenameBackupJobDatasets(db, payload)
+		default:
+			return nil
+		}
+	})
+
 	fsm.Register("replication_policy", func(db *gorm.DB, action string, raw json.RawMessage) error {
 		switch action {
 		case "create", "update":
@@ -546,6 +597,15 @@ func RegisterDefaultHandlers(fsm *FSMDispatcher) {
 				"next_run_at": payload.NextRunAt,
 			}
 			return db.Model(&ReplicationPolicy{}).Where("id = ?", payload.ID).Updates(updates).Error
+		case "pause":
+			var payload ReplicationPolicyPausePayload
+			if err := json.Unmarshal(raw, &payload); err != nil {
+				return err
+			}
+			if payload.ID == 0 {
+				return nil
+			}
+			return SetReplicationPolicyPausedTxn(db, &payload)
 		default:
 			return nil
 		}
@@ -736,6 +796,125 @@ func RegisterDefaultHandlers(fsm *FSMDispatcher) {
 		}
 	})
 
+	fsm.Register("maintenance_window", func(db *gorm.DB, action string, raw json.RawMessage) error {
+		switch action {
+		case "create":
+			var window MaintenanceWindow
+			if err := json.Unmarshal(raw, &window); err != nil {
+				return err
+			}
+			return UpsertMaintenanceWindow(db, &window)
+		case "end":
+			var payload struct {
+				ID uint      `json:"id"`
+				At time.Time `json:"at"`
+			}
+			if err := json.Unmarshal(raw, &payload); err != nil {
+				return err
+			}
+			return EndMaintenanceWindow(db, payload.ID, payload.At)
+		case "delete":
+			var payload struct {
+				ID uint `json:"id"`
+			}
+			if err := json.Unmarshal(raw, &payload); err != nil {
+				return err
+			}
+			if payload.ID == 0 {
+				return nil
+			}
+			return DeleteMaintenanceWindow(db, payload.ID)
+		default:
+			return nil
+		}
+	})
+
+	fsm.Register("zelta_profile", func(db *gorm.DB, action string, raw json.RawMessage) error {
+		switch action {
+		case "upsert":
+			var profile ZeltaProfile
+			if err := json.Unmarshal(raw, &profile); err != nil {
+				return err
+			}
+			return UpsertZeltaProfile(db, &profile)
+		case "delete":
+			var payload struct {
+				ID uint `json:"id"`
+			}
+			if err := json.Unmarshal(raw, &payload); err != nil {
+				return err
+			}
+			if payload.ID == 0 {
+				return nil
+			}
+			return DeleteZeltaProfile(db, payload.ID)
+		default:
+			return nil
+		}
+	})
+
+	fsm.Register("replication_policy_template", func(db *gorm.DB, action string, raw json.RawMessage) error {
+		switch action {
+		case "upsert":
+			var template ReplicationPolicyTemplate
+			if err := json.Unmarshal(raw, &template); err != nil {
+				return err
+			}
+			return UpsertReplicationPolicyTemplate(db, &template)
+		case "delete":
+			var payload struct {
+				ID uint `json:"id"`
+			}
+			if err := json.Unmarshal(raw, &payload); err != nil {
+				return err
+			}
+			if payload.ID == 0 {
+				return nil
+			}
+			return DeleteReplicationPolicyTemplate(db, payload.ID)
+		default:
+			return nil
+		}
+	})
+
+	fsm.Register("cluster_ca", func(db *gorm.DB, action string, raw json.RawMessage) error {
+		switch action {
+		case "upsert":
+			var ca ClusterCA
+			if err := json.Unmarshal(raw, &ca); err != nil {
+				return err
+			}
+			return UpsertClusterCA(db, &ca)
+		default:
+			return nil
+		}
+	})
+
+	fsm.Register("cluster_node_certificate", func(db *gorm.DB, action string, raw json.RawMessage) error {
+		switch action {
+		case "upsert":
+			var cert ClusterNodeCertificate
+			if err := json.Unmarshal(raw, &cert); err != nil {
+				return err
+			}
+			return UpsertClusterNodeCertificate(db, &cert)
+		case "delete":
+			var payload struct {
+				NodeUUID string `json:"nodeUUID"`
+			}
+			if err := json.Unmarshal(raw, &payload); err != nil {
+				return err
+			}
+			payload.NodeUUID = strings.TrimSpace(payload.NodeUUID)
+			if payload.NodeUUID == "" {
+				return nil
+			}
+			return db.Where("node_uuid = ?", payload.NodeUUID).Delete(&ClusterNodeCertificate{}).Error
+		default:
+			return nil
+		}
+	})
+
 	fsm.Register("replication_event", func(db *gorm.DB, action string, raw json.RawMessage) error {
 		switch action {
 		case "create", "update":
@@ -779,7 +958,7 @@ func upsertEncryptionKey(db *gorm.DB, key *EncryptionKey) error {
 	if strings.TrimSpace(key.UUID) == "" {
 		return fmt.Errorf("encryption_key_uuid_required")
 	}
-	if strings.TrimSpace(key.KeyData) == "" {
+	if strings.TrimSpace(string(key.KeyData)) == "" {
 		return fmt.Errorf("encryption_key_data_required")
 	}
 
@@ -32,6 +32,11 @@ func allSnapshotModels() []any {
 		&ReplicationEvent{},
 		&ClusterSSHIdentity{},
 		&EncryptionKey{},
+		&MaintenanceWindow{},
+		&ZeltaProfile{},
+		&ReplicationPolicyTemplate{},
+		&ClusterCA{},
+		&ClusterNodeCertificate{},
 	}
 }
 
@@ -121,6 +126,19 @@ func TestClusterSnapshotRoundTrip(t *testing.T) {
 		t.Fatalf("failed to seed encryption key: %v", err)
 	}
 
+	if err := sourceDB.Create(&MaintenanceWindow{
+		ID: 900, Scope: MaintenanceScopeNode, NodeID: "node-2", Reason: "disk swap",
+		StartsAt: completedAt, EndsAt: completedAt.Add(time.Hour),
+	}).Error; err != nil {
+		t.Fatalf("failed to seed maintenance window: %v", err)
+	}
+
+	if err := sourceDB.Create(&ZeltaProfile{
+		ID: 1000, Name: "wan", Env: map[string]string{"ZELTA_SEND_DEFAULT": "-L -c"},
+	}).Error; err != nil {
+		t.Fatalf("failed to seed zelta profile: %v", err)
+	}
+
 	snap, err := fsmSrc.Snapshot()
 	if err != nil {
 		t.Fatalf("Snapshot() failed: %v", err)
@@ -221,6 +239,18 @@ func TestClusterSnapshotRoundTrip(t *testing.T) {
 	if len(keys) != 1 || keys[0].UUID != "key-1" {
 		t.Fatalf("encryption keys mismatch: %+v", keys)
 	}
+
+	var windows []MaintenanceWindow
+	destDB.Find(&windows)
+	if len(windows) != 1 || windows[0].NodeID != "node-2" || !windows[0].EndsAt.Equal(completedAt.Add(time.Hour)) {
+		t.Fatalf("maintenance windows mismatch: %+v", windows)
+	}
+
+	var profiles []ZeltaProfile
+	destDB.Find(&profiles)
+	if len(profiles) != 1 || profiles[0].Env["ZELTA_SEND_DEFAULT"] != "-L -c" {
+		t.Fatalf("zelta profiles mismatch: %+v", profiles)
+	}
 }
 
 type writerSnapSink struct {
@@ -62,8 +62,13 @@ const (
 	// but it can only be released from pre-cutover and is never eligible for the
 	// migration seal/completion paths.
 	ReplicationGuestOperationEmergencyRestore = "emergency_restore"
-	ReplicationGuestOperationPreCutover       = "pre_cutover"
-	ReplicationGuestOperationCutover          = "cutover"
+	// ReplicationGuestOperationClone reserves a not-yet-registered guest ID
+	// cluster-wide while a copy of another guest is built on TargetNodeID.
+	// It never seals; the initiator aborts it once the clone is registered
+	// on the target or the attempt fails.
+	ReplicationGuestOperationClone      = "clone"
+	ReplicationGuestOperationPreCutover = "pre_cutover"
+	ReplicationGuestOperationCutover    = "cutover"
 )
 
 type ReplicationPolicy struct {
@@ -83,7 +88,13 @@ type ReplicationPolicy struct {
 	CrashRestartMax                int                       `gorm:"not null;default:3" json:"crashRestartMax"`
 	PoolHealthCheck                bool                      `gorm:"not null;default:true" json:"poolHealthCheck"`
 	PoolCapacityPct                int                       `gorm:"not null;default:90" json:"poolCapacityPct"`
+	SourceBookmarks                bool                      `gorm:"not null;default:false" json:"sourceBookmarks"`
+	ZeltaProfileID                 uint                      `gorm:"not null;default:0;index" json:"zeltaProfileId"`
+	TemplateID                     uint                      `gorm:"not null;default:0;index" json:"templateId"` // 0 = managed by hand
 	Enabled                        bool                      `gorm:"index" json:"enabled"`
+	Paused                         bool                      `gorm:"not null;default:false;index" json:"paused"`
+	PausedReason                   string                    `gorm:"type:text" json:"pausedReason"`
+	PausedAt                       *time.Time                `json:"pausedAt"`
 	ProtectionState                string                    `gorm:"not null;default:'';index" json:"protectionState"`
 	LastRunAt                      *time.Time                `json:"lastRunAt"`
 	NextRunAt                      *time.Time                `gorm:"index" json:"nextRunAt"`
@@ -250,15 +261,62 @@ type ReplicationEvent struct {
 	UpdatedAt       time.Time  `gorm:"autoUpdateTime" json:"updatedAt"`
 }
 
+// ReplicationEventTransfer is one target/dataset transfer inside a
+// replication run. A run fans out to every target and dataset, so the parent
+// ReplicationEvent only carries the overall outcome and concatenated output
+// while each child records its own timing, bytes and status.
+type ReplicationEventTransfer struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	EventID       uint       `gorm:"index;not null" json:"eventId"`
+	TargetNodeID  string     `gorm:"index" json:"targetNodeId"`
+	SourceDataset string     `json:"sourceDataset"`
+	TargetDataset string     `json:"targetDataset"`
+	Status        string     `gorm:"index;not null" json:"status"`
+	MovedBytes    *uint64    `json:"movedBytes"`
+	TotalBytes    *uint64    `json:"totalBytes"`
+	Error         string     `gorm:"type:text" json:"error"`
+	StartedAt     time.Time  `json:"startedAt"`
+	CompletedAt   *time.Time `json:"completedAt"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt     time.Time  `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
 type ClusterSSHIdentity struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	NodeUUID  string    `gorm:"uniqueIndex;not null" json:"nodeUUID"`
-	SSHUser   string    `gorm:"not null;default:root" json:"sshUser"`
-	SSHHost   string    `gorm:"not null" json:"sshHost"`
-	SSHPort   int       `gorm:"not null;default:8183" json:"sshPort"`
-	PublicKey string    `gorm:"type:text;not null" json:"publicKey"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdAt"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+	ID        uint   `gorm:"primaryKey" json:"id"`
+	NodeUUID  string `gorm:"uniqueIndex;not null" json:"nodeUUID"`
+	SSHUser   string `gorm:"not null;default:root" json:"sshUser"`
+	SSHHost   string `gorm:"not null" json:"sshHost"`
+	SSHPort   int    `gorm:"not null;default:8183" json:"sshPort"`
+	PublicKey string `gorm:"type:text;not null" json:"publicKey"`
+	// PreviousPublicKey stays accepted until PreviousKeyExpiresAt so that
+	// sessions opened just before a key rotation can still authenticate.
+	PreviousPublicKey    string     `gorm:"type:text" json:"previousPublicKey"`
+	PreviousKeyExpiresAt *time.Time `json:"previousKeyExpiresAt"`
+	// TokenKeys are the public keys the node signs ES256 cluster tokens
+	// with, so peers can verify them without the shared cluster key.
+	TokenKeys []ClusterTokenKey `gorm:"serializer:json;type:json" json:"tokenKeys"`
+	CreatedAt time.Time         `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt time.Time         `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+type ClusterTokenKey struct {
+	KID       string     `json:"kid"`
+	PublicKey string     `json:"publicKey"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// AcceptedPublicKeys returns the identity's current key and, while the
+// rotation grace window is open, its previous key.
+func (i ClusterSSHIdentity) AcceptedPublicKeys(now time.Time) []string {
+	keys := []string{}
+	if pub := strings.TrimSpace(i.PublicKey); pub != "" {
+		keys = append(keys, pub)
+	}
+	if prev := strings.TrimSpace(i.PreviousPublicKey); prev != "" &&
+		i.PreviousKeyExpiresAt != nil && now.Before(*i.PreviousKeyExpiresAt) {
+		keys = append(keys, prev)
+	}
+	return keys
 }
 
 type ReplicationPolicyPayload struct {
@@ -394,7 +452,8 @@ func acquireReplicationGuestOperation(db *gorm.DB, payload *ReplicationGuestOper
 	payload.TargetNodeID = strings.TrimSpace(payload.TargetNodeID)
 	if payload.Operation != ReplicationGuestOperationMigration &&
 		payload.Operation != ReplicationGuestOperationEmergencyRestore &&
-		payload.Operation != ReplicationGuestOperationRestore {
+		payload.Operation != ReplicationGuestOperationRestore &&
+		payload.Operation != ReplicationGuestOperationClone {
 		return fmt.Errorf("invalid_replication_guest_operation")
 	}
 	if payload.Token == "" || payload.OwnerNodeID == "" || payload.AcquiredAt.IsZero() {
@@ -416,6 +475,13 @@ func acquireReplicationGuestOperation(db *gorm.DB, payload *ReplicationGuestOper
 		if payload.TargetNodeID != "" || payload.TaskID == 0 {
 			return fmt.Errorf("replication_restore_scope_invalid")
 		}
+	case ReplicationGuestOperationClone:
+		if payload.TargetNodeID == "" || payload.TaskID == 0 {
+			return fmt.Errorf("replication_guest_operation_identity_required")
+		}
+		if payload.OwnerNodeID == payload.TargetNodeID {
+			return fmt.Errorf("replication_guest_operation_target_must_differ")
+		}
 	}
 	payload.AcquiredAt = payload.AcquiredAt.UTC()
 
@@ -470,7 +536,8 @@ func normalizeReplicationGuestOperationTransition(payload *ReplicationGuestOpera
 	payload.Token = strings.TrimSpace(payload.Token)
 	if (payload.Operation != ReplicationGuestOperationMigration &&
 		payload.Operation != ReplicationGuestOperationEmergencyRestore &&
-		payload.Operation != ReplicationGuestOperationRestore) || payload.Token == "" {
+		payload.Operation != ReplicationGuestOperationRestore &&
+		payload.Operation != ReplicationGuestOperationClone) || payload.Token == "" {
 		return fmt.Errorf("replication_guest_operation_identity_required")
 	}
 	payload.TargetNodeID = strings.TrimSpace(payload.TargetNodeID)
@@ -930,6 +997,9 @@ func upsertReplicationPolicy(db *gorm.DB, policy *ReplicationPolicy, targets []R
 				"crash_restart_max",
 				"pool_health_check",
 				"pool_capacity_pct",
+				"source_bookmarks",
+				"zelta_profile_id",
+				"template_id",
 				"enabled",
 				"protection_state",
 				"last_run_at",
@@ -1016,6 +1086,8 @@ func updateReplicationPolicy(db *gorm.DB, payload *ReplicationPolicyPayload) err
 				"crash_restart_max": policy.CrashRestartMax,
 				"pool_health_check": policy.PoolHealthCheck,
 				"pool_capacity_pct": policy.PoolCapacityPct,
+				"source_bookmarks":  policy.SourceBookmarks,
+				"zelta_profile_id":  policy.ZeltaProfileID,
 				"enabled":           policy.Enabled,
 				"protection_state":  protectionState,
 				"next_run_at":       policy.NextRunAt,
@@ -2223,15 +2295,27 @@ func upsertClusterSSHIdentity(db *gorm.DB, identity *ClusterSSHIdentity) error {
 		return fmt.Errorf("cluster_ssh_identity_pubkey_required")
 	}
 
+	columns := []string{
+		"ssh_user",
+		"ssh_host",
+		"ssh_port",
+		"public_key",
+		"updated_at",
+	}
+	// Routine republishes carry no previous key; only a rotation replaces the
+	// grace-window key, so a republish cannot cut the window short.
+	if strings.TrimSpace(identity.PreviousPublicKey) != "" {
+		identity.PreviousPublicKey = strings.TrimSpace(identity.PreviousPublicKey)
+		columns = append(columns, "previous_public_key", "previous_key_expires_at")
+	}
+	// Nodes that predate token keys publish none; keep whatever is stored.
+	if identity.TokenKeys != nil {
+		columns = append(columns, "token_keys")
+	}
+
 	return db.Clauses(clause.OnConflict{
-		Columns: []clause.Column{{Name: "node_uuid"}},
-		DoUpdates: clause.AssignmentColumns([]string{
-			"ssh_user",
-			"ssh_host",
-			"ssh_port",
-			"public_key",
-			"updated_at",
-		}),
+		Columns:   []clause.Column{{Name: "node_uuid"}},
+		DoUpdates: clause.AssignmentColumns(columns),
 	}).Create(identity).Error
 }
 
@@ -2257,6 +2341,47 @@ func UpdateReplicationPolicyTxn(db *gorm.DB, payload *ReplicationPolicyPayload)
 	return updateReplicationPolicy(db, payload)
 }
 
+// ReplicationPolicyPausePayload pauses or resumes a policy. Pausing keeps
+// the policy enabled and its targets' readiness intact; only scheduling and
+// automatic failover stop.
+type ReplicationPolicyPausePayload struct {
+	ID        uint       `json:"id"`
+	Paused    bool       `json:"paused"`
+	Reason    string     `json:"reason"`
+	At        time.Time  `json:"at"`
+	NextRunAt *time.Time `json:"nextRunAt"`
+}
+
+func SetReplicationPolicyPausedTxn(db *gorm.DB, payload *ReplicationPolicyPausePayload) error {
+	if payload == nil || payload.ID == 0 {
+		return fmt.Errorf("replication_policy_id_required")
+	}
+
+	updates := map[string]any{
+		"paused":        payload.Paused,
+		"paused_reason": "",
+		"paused_at":     nil,
+		"updated_at":    time.Now().UTC(),
+	}
+	if payload.Paused {
+		at := payload.At.UTC()
+		updates["paused_reason"] = payload.Reason
+		updates["paused_at"] = &at
+	} else {
+		updates["next_run_at"] = payload.NextRunAt
+	}
+
+	result := db.Model(&ReplicationPolicy{}).Where("id = ?", payload.ID).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected != 1 {
+		return fmt.Errorf("replication_policy_not_found")
+	}
+
+	return nil
+}
+
 func DeleteReplicationPolicyTxn(db *gorm.DB, policyID uint) error {
 	if policyID == 0 {
 		return fmt.Errorf("replication_policy_id_required")
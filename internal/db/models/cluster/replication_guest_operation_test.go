@@ -368,3 +368,57 @@ func TestEmergencyRestoreGuestOperationSerializesWithoutMigrationPrivileges(t *t
 		t.Fatalf("released emergency restore guard remains: %d", count)
 	}
 }
+
+func TestCloneGuestOperationReservesIDWithoutSealPrivileges(t *testing.T) {
+	db := testutil.NewSQLiteTestDB(t,
+		&ReplicationPolicy{}, &ReplicationPolicyTarget{}, &ReplicationLease{}, &ReplicationGuestOperation{},
+		&ReplicationGuestOperationReceipt{}, &ReplicationEvent{},
+	)
+
+	now := time.Now().UTC()
+	sameNode := ReplicationGuestOperationAcquire{
+		GuestType: ReplicationGuestTypeVM, GuestID: 606,
+		Operation: ReplicationGuestOperationClone,
+		Token:     "clone-606", OwnerNodeID: "node-a", TargetNodeID: "node-a", TaskID: 9, AcquiredAt: now,
+	}
+	if err := AcquireReplicationGuestOperationTxn(db, &sameNode); err == nil ||
+		!strings.Contains(err.Error(), "target_must_differ") {
+		t.Fatalf("clone onto the owner node was accepted: %v", err)
+	}
+
+	acquire := sameNode
+	acquire.TargetNodeID = "node-b"
+	if err := AcquireReplicationGuestOperationTxn(db, &acquire); err != nil {
+		t.Fatalf("acquire clone reservation: %v", err)
+	}
+
+	competing := ReplicationGuestOperationAcquire{
+		GuestType: ReplicationGuestTypeVM, GuestID: 606,
+		Operation: ReplicationGuestOperationClone,
+		Token:     "clone-606-other", OwnerNodeID: "node-c", TargetNodeID: "node-b", TaskID: 10, AcquiredAt: now,
+	}
+	if err := AcquireReplicationGuestOperationTxn(db, &competing); err == nil ||
+		!strings.Contains(err.Error(), "guest_operation_in_progress") {
+		t.Fatalf("competing clone reserved the same ID: %v", err)
+	}
+
+	transition := ReplicationGuestOperationTransition{
+		GuestType: ReplicationGuestTypeVM, GuestID: 606,
+		Operation: ReplicationGuestOperationClone,
+		Token:     acquire.Token, TargetNodeID: "node-b", OccurredAt: now.Add(time.Second),
+	}
+	if err := SealReplicationGuestOperationTxn(db, &transition); err == nil ||
+		!strings.Contains(err.Error(), "seal_requires_migration") {
+		t.Fatalf("clone reservation gained seal privilege: %v", err)
+	}
+	if err := AbortReplicationGuestOperationTxn(db, &transition); err != nil {
+		t.Fatalf("release clone reservation: %v", err)
+	}
+	var count int64
+	if err := db.Model(&ReplicationGuestOperation{}).Count(&count).Error; err != nil {
+		t.Fatalf("count clone reservations: %v", err)
+	}
+	if count != 0 {
+		t.Fatalf("released clone reservation remains: %d", count)
+	}
+}
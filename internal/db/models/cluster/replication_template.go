@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterModels
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReplicationTemplateTarget struct {
+	NodeID string `json:"nodeId"`
+	Weight int    `json:"weight"`
+}
+
+// ReplicationPolicyTemplate enrolls every guest matching its selector into
+// a replication policy built from the template. An empty selector field
+// matches any guest; tags only ever match VMs since jails carry none.
+type ReplicationPolicyTemplate struct {
+	ID           uint                        `gorm:"primaryKey" json:"id"`
+	Name         string                      `gorm:"uniqueIndex;not null" json:"name"`
+	Description  string                      `gorm:"type:text" json:"description"`
+	GuestType    string                      `gorm:"not null;default:''" json:"guestType"`
+	Tags         []string                    `gorm:"serializer:json;type:json" json:"tags"`
+	Pools        []string                    `gorm:"serializer:json;type:json" json:"pools"`
+	Targets      []ReplicationTemplateTarget `gorm:"serializer:json;type:json" json:"targets"`
+	CronExpr     string                      `gorm:"not null" json:"cronExpr"`
+	FailbackMode string                      `gorm:"not null;default:manual" json:"failbackMode"`
+	FailoverMode string                      `gorm:"not null;default:manual" json:"failoverMode"`
+	Enabled      bool                        `gorm:"not null;default:true" json:"enabled"`
+	CreatedAt    time.Time                   `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt    time.Time                   `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+func UpsertReplicationPolicyTemplate(db *gorm.DB, template *ReplicationPolicyTemplate) error {
+	if template.ID == 0 {
+		return fmt.Errorf("replication_template_id_required")
+	}
+
+	return db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"name",
+			"description",
+			"guest_type",
+			"tags",
+			"pools",
+			"targets",
+			"cron_expr",
+			"failback_mode",
+			"failover_mode",
+			"enabled",
+			"updated_at",
+		}),
+	}).Create(template).Error
+}
+
+// DeleteReplicationPolicyTemplate keeps the policies the template enrolled;
+// they become ordinary policies so deleting a template never drops HA
+// protection from a guest.
+func DeleteReplicationPolicyTemplate(db *gorm.DB, id uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&ReplicationPolicy{}).
+			Where("template_id = ?", id).
+			Update("template_id", 0).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&ReplicationPolicyTemplate{}, id).Error
+	})
+}
@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterModels
+
+import "time"
+
+const (
+	RestorePromotionPhasePending    = "pending"
+	RestorePromotionPhaseArchived   = "archived"
+	RestorePromotionPhaseDone       = "done"
+	RestorePromotionPhaseRolledBack = "rolled_back"
+	RestorePromotionPhaseManual     = "manual"
+)
+
+// RestorePromotion journals the dataset renames that move a restored
+// dataset into its live path. The row is written before the first rename
+// and advanced after each one, so startup recovery can tell how far an
+// interrupted promotion got. It describes local datasets and is never
+// replicated by raft.
+type RestorePromotion struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	RestorePath   string    `gorm:"not null" json:"restorePath"`
+	Destination   string    `gorm:"index;not null" json:"destination"`
+	BackupDataset string    `json:"backupDataset"`
+	Phase         string    `gorm:"index;not null" json:"phase"`
+	Error         string    `gorm:"type:text" json:"error"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+}
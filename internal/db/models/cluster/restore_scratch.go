@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterModels
+
+import "time"
+
+const DefaultRestoreScratchHeadroomPercent = 10
+
+// RestoreScratch points restore staging at a scratch dataset instead of a
+// .restoring sibling of the destination. Like StaleDatasetJanitor it is
+// node-local and each node keeps at most one row. HeadroomPercent is added
+// to the estimated restore size by the space check, which runs with or
+// without a scratch dataset.
+type RestoreScratch struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	Enabled         bool      `json:"enabled"`
+	Dataset         string    `json:"dataset"`
+	HeadroomPercent int       `gorm:"default:10" json:"headroomPercent"`
+	CreatedAt       time.Time `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+}
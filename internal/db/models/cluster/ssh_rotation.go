@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterModels
+
+import "time"
+
+const (
+	SSHKeyRotationReasonManual    = "manual"
+	SSHKeyRotationReasonScheduled = "scheduled"
+)
+
+// ClusterSSHKeyRotation records one rotation of this node's cluster SSH key.
+type ClusterSSHKeyRotation struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	NodeUUID       string    `gorm:"index;not null" json:"nodeUUID"`
+	Reason         string    `gorm:"not null" json:"reason"`
+	RequestedBy    string    `json:"requestedBy"`
+	OldFingerprint string    `json:"oldFingerprint"`
+	NewFingerprint string    `json:"newFingerprint"`
+	GraceUntil     time.Time `json:"graceUntil"`
+	Error          string    `json:"error"`
+	CreatedAt      time.Time `gorm:"autoCreateTime;index" json:"createdAt"`
+}
@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterModels
+
+import "time"
+
+// NodeStandby replicates every <pool>/sylve hierarchy of this node, together
+// with an export of the node database, to a backup target that acts as a cold
+// standby. It describes this node only, so it is never replicated by raft and
+// each node keeps at most one row.
+type NodeStandby struct {
+	ID           uint         `gorm:"primaryKey" json:"id"`
+	TargetID     uint         `gorm:"index;not null" json:"targetId"`
+	Target       BackupTarget `json:"target" gorm:"foreignKey:TargetID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	DestSuffix   string       `gorm:"column:dest_suffix" json:"destSuffix"` // appended to target's BackupRoot
+	CronExpr     string       `gorm:"not null" json:"cronExpr"`
+	Enabled      bool         `json:"enabled"`
+	LastRunAt    *time.Time   `json:"lastRunAt"`
+	NextRunAt    *time.Time   `json:"nextRunAt"`
+	LastStatus   string       `json:"lastStatus"`
+	LastError    string       `gorm:"type:text" json:"lastError"`
+	LastSnapshot string       `json:"lastSnapshot"`
+	CreatedAt    time.Time    `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt    time.Time    `gorm:"autoUpdateTime" json:"updatedAt"`
+}
@@ -0,0 +1,240 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterModels
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ZeltaProfile is a named set of zelta environment overrides that backup jobs
+// and replication policies can select. Profiles are replicated by raft so a
+// job runs with the same options on whichever node picks it up.
+type ZeltaProfile struct {
+	ID          uint              `gorm:"primaryKey" json:"id"`
+	Name        string            `gorm:"uniqueIndex;not null" json:"name"`
+	Description string            `gorm:"type:text" json:"description"`
+	Env         map[string]string `gorm:"serializer:json;type:json" json:"env"`
+	CreatedAt   time.Time         `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt   time.Time         `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+type zeltaProfileValueKind int
+
+const (
+	zeltaProfileSendFlags zeltaProfileValueKind = iota
+	zeltaProfileRecvFlags
+	zeltaProfileToggle
+	zeltaProfileLogLevel
+)
+
+// zeltaProfileKeys are the variables a profile may set. Remote commands,
+// logging mode, snapshot naming and recursion stay with Sylve: the transfer
+// limiter wraps the remote commands, event output is parsed as JSON, and
+// pruning and restores find backups by their bk_ snapshot prefix. The log
+// level can only be raised, as backup outcomes are read from zelta's notices.
+var zeltaProfileKeys = map[string]zeltaProfileValueKind{
+	"ZELTA_SEND_DEFAULT":   zeltaProfileSendFlags,
+	"ZELTA_SEND_RAW":       zeltaProfileSendFlags,
+	"ZELTA_SEND_NEW":       zeltaProfileSendFlags,
+	"ZELTA_SEND_REPLICATE": zeltaProfileSendFlags,
+	"ZELTA_RECV_DEFAULT":   zeltaProfileRecvFlags,
+	"ZELTA_RECV_TOP":       zeltaProfileRecvFlags,
+	"ZELTA_RECV_FS":        zeltaProfileRecvFlags,
+	"ZELTA_RECV_VOL":       zeltaProfileRecvFlags,
+	"ZELTA_RECV_PARTIAL":   zeltaProfileRecvFlags,
+	"ZELTA_SEND_INTR":      zeltaProfileToggle,
+	"ZELTA_RESUME":         zeltaProfileToggle,
+	"ZELTA_CREATE_PARENT":  zeltaProfileToggle,
+	"ZELTA_LIST_WRITTEN":   zeltaProfileToggle,
+	"ZELTA_LOG_LEVEL":      zeltaProfileLogLevel,
+}
+
+var zeltaProfileSendOptions = []string{
+	"-L", "--large-block",
+	"-c", "--compressed",
+	"-e", "--embed",
+	"-w", "--raw",
+	"-p", "--props",
+	"-h", "--holds",
+	"-b", "--backup",
+	"-s", "--skip-missing",
+	"-R", "--replicate",
+}
+
+// Receive options that would roll back or discard data on the target, such as
+// -F, are left out on purpose.
+var zeltaProfileRecvOptions = []string{"-u", "-s", "-e", "-d"}
+
+var zeltaProfilePropertyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9:._-]*$`)
+
+// zeltaProfileValuePattern keeps values free of anything a shell would
+// interpret, since zelta splices them into the commands it runs.
+var zeltaProfileValuePattern = regexp.MustCompile(`^[A-Za-z0-9 ._=:/,@+-]*$`)
+
+const zeltaProfileValueMaxLen = 256
+
+// NormalizeZeltaProfileEnv validates profile overrides and returns them with
+// upper-case ZELTA_ prefixed keys and single-spaced values. Keys may be given
+// without the ZELTA_ prefix.
+func NormalizeZeltaProfileEnv(env map[string]string) (map[string]string, error) {
+	out := make(map[string]string, len(env))
+	for rawKey, rawValue := range env {
+		key := strings.ToUpper(strings.TrimSpace(rawKey))
+		if !strings.HasPrefix(key, "ZELTA_") {
+			key = "ZELTA_" + key
+		}
+		kind, ok := zeltaProfileKeys[key]
+		if !ok {
+			return nil, fmt.Errorf("zelta_profile_key_not_allowed: %s", strings.TrimSpace(rawKey))
+		}
+		if _, dup := out[key]; dup {
+			return nil, fmt.Errorf("zelta_profile_key_duplicate: %s", key)
+		}
+
+		value := strings.Join(strings.Fields(rawValue), " ")
+		if len(value) > zeltaProfileValueMaxLen || !zeltaProfileValuePattern.MatchString(value) {
+			return nil, fmt.Errorf("zelta_profile_value_invalid: %s", key)
+		}
+
+		var err error
+		switch kind {
+		case zeltaProfileSendFlags:
+			err = validateZeltaProfileFlags(value, zeltaProfileSendOptions, false)
+		case zeltaProfileRecvFlags:
+			err = validateZeltaProfileFlags(value, zeltaProfileRecvOptions, true)
+		case zeltaProfileToggle:
+			if value != "0" && value != "1" {
+				err = fmt.Errorf("expected 0 or 1")
+			}
+		case zeltaProfileLogLevel:
+			if len(value) != 1 || value[0] < '2' || value[0] > '4' {
+				err = fmt.Errorf("expected 2 to 4")
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("zelta_profile_value_invalid: %s: %w", key, err)
+		}
+		out[key] = value
+	}
+
+	if raw, ok := out["ZELTA_SEND_RAW"]; ok {
+		flags := strings.Fields(raw)
+		if !slices.Contains(flags, "-w") && !slices.Contains(flags, "--raw") {
+			return nil, fmt.Errorf("zelta_profile_value_invalid: ZELTA_SEND_RAW: encrypted sends need -w")
+		}
+	}
+
+	return out, nil
+}
+
+// validateZeltaProfileFlags accepts single-letter or long options from
+// allowed and, for receives, -o property=value and -x property pairs.
+func validateZeltaProfileFlags(value string, allowed []string, properties bool) error {
+	fields := strings.Fields(value)
+	for i := 0; i < len(fields); i++ {
+		flag := fields[i]
+		if properties && (flag == "-o" || flag == "-x") {
+			if i+1 >= len(fields) {
+				return fmt.Errorf("%s needs a property", flag)
+			}
+			i++
+			property, propertyValue, hasValue := strings.Cut(fields[i], "=")
+			if !zeltaProfilePropertyPattern.MatchString(property) {
+				return fmt.Errorf("invalid property %q", fields[i])
+			}
+			if flag == "-o" && (!hasValue || propertyValue == "") {
+				return fmt.Errorf("-o needs property=value")
+			}
+			if flag == "-x" && hasValue {
+				return fmt.Errorf("-x takes a property name")
+			}
+			continue
+		}
+		if !slices.Contains(allowed, flag) {
+			return fmt.Errorf("option %q is not allowed", flag)
+		}
+	}
+	return nil
+}
+
+// ZeltaEnv returns the profile as KEY=value entries in a stable order.
+func (p *ZeltaProfile) ZeltaEnv() []string {
+	if p == nil {
+		return nil
+	}
+	keys := make([]string, 0, len(p.Env))
+	for key := range p.Env {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	env := make([]string, 0, len(keys))
+	for _, key := range keys {
+		env = append(env, key+"="+p.Env[key])
+	}
+	return env
+}
+
+// SendOptions returns the profile's zfs send options for a plain or a raw
+// encrypted stream, or nil when the profile leaves them at their defaults.
+func (p *ZeltaProfile) SendOptions(encrypted bool) []string {
+	if p == nil {
+		return nil
+	}
+	key := "ZELTA_SEND_DEFAULT"
+	if encrypted {
+		key = "ZELTA_SEND_RAW"
+	}
+	value, ok := p.Env[key]
+	if !ok {
+		return nil
+	}
+	return strings.Fields(value)
+}
+
+func UpsertZeltaProfile(db *gorm.DB, profile *ZeltaProfile) error {
+	if profile.ID == 0 {
+		return fmt.Errorf("zelta_profile_id_required")
+	}
+	env, err := NormalizeZeltaProfileEnv(profile.Env)
+	if err != nil {
+		return err
+	}
+	profile.Env = env
+
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "description", "env", "updated_at"}),
+	}).Create(profile).Error
+}
+
+// DeleteZeltaProfile refuses to drop a profile that a backup job or a
+// replication policy still selects.
+func DeleteZeltaProfile(db *gorm.DB, id uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var jobs, policies int64
+		if err := tx.Model(&BackupJob{}).Where("zelta_profile_id = ?", id).Count(&jobs).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&ReplicationPolicy{}).Where("zelta_profile_id = ?", id).Count(&policies).Error; err != nil {
+			return err
+		}
+		if jobs > 0 || policies > 0 {
+			return fmt.Errorf("zelta_profile_in_use: backup_jobs=%d replication_policies=%d", jobs, policies)
+		}
+		return tx.Delete(&ZeltaProfile{}, id).Error
+	})
+}
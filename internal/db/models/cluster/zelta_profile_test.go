@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterModels
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeZeltaProfileEnv(t *testing.T) {
+	env, err := NormalizeZeltaProfileEnv(map[string]string{
+		"send_default":    " -L   -c ",
+		"ZELTA_RECV_FS":   "-u -x mountpoint -o canmount=noauto",
+		"ZELTA_LOG_LEVEL": "3",
+		"resume":          "0",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{
+		"ZELTA_SEND_DEFAULT": "-L -c",
+		"ZELTA_RECV_FS":      "-u -x mountpoint -o canmount=noauto",
+		"ZELTA_LOG_LEVEL":    "3",
+		"ZELTA_RESUME":       "0",
+	}
+	for key, value := range want {
+		if env[key] != value {
+			t.Fatalf("expected %s=%q, got %q", key, value, env[key])
+		}
+	}
+
+	for name, bad := range map[string]map[string]string{
+		"zelta_profile_key_not_allowed": {"ZELTA_REMOTE_SEND": "ssh -n"},
+		"snapshot naming":               {"ZELTA_SNAP_NAME": "daily"},
+		"shell metacharacters":          {"ZELTA_SEND_DEFAULT": "-L; rm -rf /"},
+		"unknown send option":           {"ZELTA_SEND_DEFAULT": "-L -D"},
+		"forced receive":                {"ZELTA_RECV_DEFAULT": "-F"},
+		"-o without value":              {"ZELTA_RECV_TOP": "-o readonly"},
+		"dangling -x":                   {"ZELTA_RECV_FS": "-u -x"},
+		"raw without -w":                {"ZELTA_SEND_RAW": "-L"},
+		"toggle":                        {"ZELTA_SEND_INTR": "yes"},
+		"log level":                     {"ZELTA_LOG_LEVEL": "1"},
+		"duplicate key":                 {"SEND_NEW": "-p", "ZELTA_SEND_NEW": "-p"},
+	} {
+		if _, err := NormalizeZeltaProfileEnv(bad); err == nil {
+			t.Fatalf("%s: expected %v to be refused", name, bad)
+		}
+	}
+}
+
+func TestZeltaProfileEnvAndSendOptions(t *testing.T) {
+	profile := &ZeltaProfile{Env: map[string]string{
+		"ZELTA_SEND_RAW":     "-L -w",
+		"ZELTA_LOG_LEVEL":    "3",
+		"ZELTA_SEND_DEFAULT": "-L -c -e",
+	}}
+
+	if got := profile.ZeltaEnv(); !slices.Equal(got, []string{
+		"ZELTA_LOG_LEVEL=3",
+		"ZELTA_SEND_DEFAULT=-L -c -e",
+		"ZELTA_SEND_RAW=-L -w",
+	}) {
+		t.Fatalf("unexpected env %q", got)
+	}
+	if got := profile.SendOptions(true); !slices.Equal(got, []string{"-L", "-w"}) {
+		t.Fatalf("unexpected raw send options %q", got)
+	}
+
+	var none *ZeltaProfile
+	if none.ZeltaEnv() != nil || none.SendOptions(false) != nil {
+		t.Fatal("expected a missing profile to leave zelta at its defaults")
+	}
+}
+
+func TestZeltaProfileRaftLifecycle(t *testing.T) {
+	db := newClusterModelTestDB(t, &ZeltaProfile{}, &BackupJob{}, &ReplicationPolicy{})
+	fsm := NewFSMDispatcher(db)
+	RegisterDefaultHandlers(fsm)
+
+	data, _ := json.Marshal(ZeltaProfile{ID: 1, Name: "wan", Env: map[string]string{"send_default": "-L -c"}})
+	if err := applyFSMCommand(t, fsm, Command{Type: "zelta_profile", Action: "upsert", Data: data}); err != nil {
+		t.Fatalf("upsert apply failed: %v", err)
+	}
+
+	var profile ZeltaProfile
+	if err := db.First(&profile, 1).Error; err != nil {
+		t.Fatalf("profile not stored: %v", err)
+	}
+	if profile.Env["ZELTA_SEND_DEFAULT"] != "-L -c" {
+		t.Fatalf("expected normalized env, got %+v", profile.Env)
+	}
+
+	data, _ = json.Marshal(ZeltaProfile{ID: 1, Name: "wan", Env: map[string]string{"ZELTA_REMOTE_RECV": "ssh"}})
+	if err := applyFSMCommand(t, fsm, Command{Type: "zelta_profile", Action: "upsert", Data: data}); err == nil {
+		t.Fatal("expected an invalid profile to be refused on apply")
+	}
+
+	if err := db.Create(&BackupJob{ID: 5, Name: "nightly", TargetID: 1, CronExpr: "@daily", ZeltaProfileID: 1}).Error; err != nil {
+		t.Fatalf("failed to seed backup job: %v", err)
+	}
+	data, _ = json.Marshal(map[string]any{"id": 1})
+	err := applyFSMCommand(t, fsm, Command{Type: "zelta_profile", Action: "delete", Data: data})
+	if err == nil || !strings.Contains(err.Error(), "zelta_profile_in_use") {
+		t.Fatalf("expected a profile in use to be kept, got %v", err)
+	}
+
+	if err := db.Model(&BackupJob{}).Where("id = ?", 5).Update("zelta_profile_id", 0).Error; err != nil {
+		t.Fatalf("failed to clear job profile: %v", err)
+	}
+	if err := applyFSMCommand(t, fsm, Command{Type: "zelta_profile", Action: "delete", Data: data}); err != nil {
+		t.Fatalf("delete apply failed: %v", err)
+	}
+	var count int64
+	db.Model(&ZeltaProfile{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("expected the profile to be deleted, %d left", count)
+	}
+}
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package models
+
+import "time"
+
+// DiskBenchmark is one storage benchmark run against a scratch dataset or
+// zvol on a pool. Metrics for tests that were not asked for stay zero.
+type DiskBenchmark struct {
+	ID                  uint       `json:"id" gorm:"primaryKey"`
+	Pool                string     `json:"pool" gorm:"not null;index:idx_disk_benchmark_pool_target,priority:1"`
+	Target              string     `json:"target" gorm:"not null;index:idx_disk_benchmark_pool_target,priority:2"`
+	SizeBytes           int64      `json:"sizeBytes" gorm:"not null"`
+	Tests               []string   `json:"tests" gorm:"serializer:json;type:json"`
+	Status              string     `json:"status" gorm:"not null;index"`
+	Error               string     `json:"error"`
+	HardwareFingerprint string     `json:"hardwareFingerprint"`
+	Devices             []string   `json:"devices" gorm:"serializer:json;type:json"`
+	HardwareChanged     bool       `json:"hardwareChanged" gorm:"not null;default:false"`
+	BaselineID          *uint      `json:"baselineId"`
+	SeqReadMBps         float64    `json:"seqReadMBps"`
+	SeqWriteMBps        float64    `json:"seqWriteMBps"`
+	RandReadIOPS        float64    `json:"randReadIops"`
+	RandWriteIOPS       float64    `json:"randWriteIops"`
+	Regressions         []string   `json:"regressions" gorm:"serializer:json;type:json"`
+	StartedAt           time.Time  `json:"startedAt" gorm:"not null;index"`
+	CompletedAt         *time.Time `json:"completedAt"`
+}
@@ -8,7 +8,11 @@
 
 package dynamicdnsModels
 
-import "time"
+import (
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/db/secrets"
+)
 
 const (
 	ProviderCloudflare = "cloudflare"
@@ -29,7 +33,7 @@ type Entry struct {
 	Enabled          bool              `json:"enabled" gorm:"not null;default:true"`
 	Provider         string            `json:"provider" gorm:"not null;index"`
 	ProviderSettings map[string]string `json:"providerSettings" gorm:"serializer:json;type:json"`
-	ProviderSecret   string            `json:"-"`
+	ProviderSecret   secrets.String    `json:"-"`
 
 	Hostname        string `json:"hostname" gorm:"not null;index"`
 	RecordType      string `json:"recordType" gorm:"not null"`
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package models
+
+import "time"
+
+const (
+	GuestAlertMetricCPU         = "cpu"
+	GuestAlertMetricMemory      = "memory"
+	GuestAlertMetricDiskLatency = "disk_latency"
+)
+
+// GuestAlertRule raises a notification when a VM or jail stays above
+// Threshold for ForMinutes. The alert clears once the metric stays below
+// Threshold minus Hysteresis for the same window. CPU and memory are
+// percentages, disk latency is in milliseconds.
+type GuestAlertRule struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	Name       string    `json:"name" gorm:"not null"`
+	GuestType  string    `json:"guestType" gorm:"not null;index:idx_guest_alert_rule_guest,priority:1"`
+	GuestID    uint      `json:"guestId" gorm:"not null;default:0;index:idx_guest_alert_rule_guest,priority:2"` // 0 = every guest of GuestType
+	Metric     string    `json:"metric" gorm:"not null"`
+	Threshold  float64   `json:"threshold" gorm:"not null"`
+	Hysteresis float64   `json:"hysteresis" gorm:"not null;default:0"`
+	ForMinutes int       `json:"forMinutes" gorm:"not null;default:0"`
+	Severity   string    `json:"severity" gorm:"not null;default:warning"`
+	Enabled    bool      `json:"enabled" gorm:"not null;default:true"`
+	CreatedAt  time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package models
+
+import "time"
+
+// GuestNote is the current markdown notes document of a VM or jail. Every
+// save also lands in GuestNoteRevision so earlier text can be recovered.
+type GuestNote struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	GuestType string    `json:"guestType" gorm:"not null;uniqueIndex:idx_guest_note_guest,priority:1"`
+	GuestID   uint      `json:"guestId" gorm:"not null;uniqueIndex:idx_guest_note_guest,priority:2"`
+	Body      string    `json:"body" gorm:"type:text"`
+	Revision  int       `json:"revision" gorm:"not null;default:0"`
+	UpdatedBy string    `json:"updatedBy"`
+	CreatedAt time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+type GuestNoteRevision struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	GuestType string    `json:"guestType" gorm:"not null;index:idx_guest_note_revision_guest,priority:1"`
+	GuestID   uint      `json:"guestId" gorm:"not null;index:idx_guest_note_revision_guest,priority:2"`
+	Revision  int       `json:"revision" gorm:"not null"`
+	Body      string    `json:"body" gorm:"type:text"`
+	Author    string    `json:"author"`
+	CreatedAt time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+// GuestNoteAttachment is a small file (runbook, license key) kept under the
+// data path next to a guest's notes. Path is never exposed over the API.
+type GuestNoteAttachment struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	GuestType  string    `json:"guestType" gorm:"not null;index:idx_guest_note_attachment_guest,priority:1"`
+	GuestID    uint      `json:"guestId" gorm:"not null;index:idx_guest_note_attachment_guest,priority:2"`
+	Name       string    `json:"name" gorm:"not null"`
+	Size       int64     `json:"size" gorm:"not null"`
+	SHA256     string    `json:"sha256" gorm:"not null"`
+	Path       string    `json:"-" gorm:"not null"`
+	UploadedBy string    `json:"uploadedBy"`
+	CreatedAt  time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
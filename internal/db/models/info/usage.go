@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package infoModels
+
+import "time"
+
+// GuestUsage is one guest's accumulated resource usage for one UTC day.
+// Allocated figures count only while the guest runs; storage accrues for
+// as long as the guest exists.
+type GuestUsage struct {
+	ID        uint   `json:"id" gorm:"primaryKey"`
+	Day       string `json:"day" gorm:"size:10;not null;uniqueIndex:idx_guest_usage_day_guest,priority:1"` // YYYY-MM-DD
+	GuestType string `json:"guestType" gorm:"not null;uniqueIndex:idx_guest_usage_day_guest,priority:2"`
+	GuestID   uint   `json:"guestId" gorm:"not null;uniqueIndex:idx_guest_usage_day_guest,priority:3"` // rid or ctid
+	Name      string `json:"name"`
+	Pool      string `json:"pool" gorm:"index"`
+
+	RunningSeconds     float64 `json:"runningSeconds"`
+	CPUSeconds         float64 `json:"cpuSeconds"`     // allocated vCPU/core seconds
+	CPUUsedSeconds     float64 `json:"cpuUsedSeconds"` // measured busy core seconds
+	RAMByteSeconds     float64 `json:"ramByteSeconds"`
+	StorageByteSeconds float64 `json:"storageByteSeconds"`
+	NetBytesIn         uint64  `json:"netBytesIn"`
+	NetBytesOut        uint64  `json:"netBytesOut"`
+
+	UpdatedAt time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
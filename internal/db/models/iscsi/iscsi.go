@@ -8,21 +8,25 @@
 
 package iscsiModels
 
-import "time"
+import (
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/db/secrets"
+)
 
 type ISCSIInitiator struct {
-	ID            uint      `json:"id" gorm:"primaryKey;autoIncrement"`
-	Nickname      string    `json:"nickname" gorm:"uniqueIndex"`
-	TargetAddress string    `json:"targetAddress"`
-	TargetName    string    `json:"targetName"`
-	InitiatorName string    `json:"initiatorName"`
-	AuthMethod    string    `json:"authMethod" gorm:"default:'None'"`
-	CHAPName      string    `json:"chapName"`
-	CHAPSecret    string    `json:"chapSecret"`
-	TgtCHAPName   string    `json:"tgtChapName"`
-	TgtCHAPSecret string    `json:"tgtChapSecret"`
-	CreatedAt     time.Time `json:"createdAt" gorm:"autoCreateTime"`
-	UpdatedAt     time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+	ID            uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	Nickname      string         `json:"nickname" gorm:"uniqueIndex"`
+	TargetAddress string         `json:"targetAddress"`
+	TargetName    string         `json:"targetName"`
+	InitiatorName string         `json:"initiatorName"`
+	AuthMethod    string         `json:"authMethod" gorm:"default:'None'"`
+	CHAPName      string         `json:"chapName"`
+	CHAPSecret    secrets.String `json:"chapSecret"`
+	TgtCHAPName   string         `json:"tgtChapName"`
+	TgtCHAPSecret secrets.String `json:"tgtChapSecret"`
+	CreatedAt     time.Time      `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time      `json:"updatedAt" gorm:"autoUpdateTime"`
 }
 
 type ISCSITargetPortal struct {
@@ -49,9 +53,9 @@ type ISCSITarget struct {
 	Alias            string              `json:"alias"`
 	AuthMethod       string              `json:"authMethod" gorm:"default:'None'"`
 	CHAPName         string              `json:"chapName"`
-	CHAPSecret       string              `json:"chapSecret"`
+	CHAPSecret       secrets.String      `json:"chapSecret"`
 	MutualCHAPName   string              `json:"mutualChapName"`
-	MutualCHAPSecret string              `json:"mutualChapSecret"`
+	MutualCHAPSecret secrets.String      `json:"mutualChapSecret"`
 	Portals          []ISCSITargetPortal `json:"portals" gorm:"foreignKey:TargetID"`
 	LUNs             []ISCSITargetLUN    `json:"luns" gorm:"foreignKey:TargetID"`
 	CreatedAt        time.Time           `json:"createdAt" gorm:"autoCreateTime"`
@@ -47,6 +47,8 @@ type Network struct {
 	IPv6GwObj *networkModels.Object `json:"ipv6GwObj" gorm:"foreignKey:IPv6GwID"`
 
 	DefaultGateway bool `json:"defaultGateway" gorm:"default:false"`
+	// Routing table (setfib) the interface and its default gateway live in.
+	FIB uint `json:"fib" gorm:"default:0"`
 
 	DHCP  bool `json:"dhcp" gorm:"default:false"`
 	SLAAC bool `json:"slaac" gorm:"default:false"`
@@ -162,6 +164,7 @@ type JailTemplateNetwork struct {
 	DHCP           bool   `json:"dhcp"`
 	SLAAC          bool   `json:"slaac"`
 	DefaultGateway bool   `json:"defaultGateway"`
+	FIB            uint   `json:"fib"`
 }
 
 type JailTemplateHook struct {
@@ -190,6 +193,7 @@ type JailTemplate struct {
 	InheritIPv4 bool `json:"inheritIPv4"`
 	InheritIPv6 bool `json:"inheritIPv6"`
 
+	Mounts            []JailMount           `json:"mounts" gorm:"serializer:json;type:json"`
 	Fstab             string                `json:"fstab"`
 	ResolvConf        string                `json:"resolvConf"`
 	DevFSRuleset      string                `json:"devfsRuleset"`
@@ -213,6 +217,10 @@ type JailSnapshot struct {
 
 	ParentSnapshotID *uint `json:"parentSnapshotId" gorm:"column:parent_snapshot_id;index"`
 
+	// ScheduleID is set on snapshots taken by a JailSnapshotSchedule. Only
+	// those count towards the schedule's MaxKeep.
+	ScheduleID *uint `json:"scheduleId" gorm:"column:schedule_id;index"`
+
 	Name        string `json:"name" gorm:"not null"`
 	Description string `json:"description" gorm:"default:''"`
 
@@ -227,6 +235,54 @@ func (JailSnapshot) TableName() string {
 	return "jail_snapshots"
 }
 
+// JailSnapshotSchedule takes snapshots of a jail on a cron schedule. Once a
+// run leaves more than MaxKeep scheduled snapshots the oldest are deleted;
+// zero keeps them all. NamePattern may use {jail}, {ctid}, {date} and {time}.
+type JailSnapshotSchedule struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	JailID uint `json:"jid" gorm:"column:jid;uniqueIndex"`
+	CTID   uint `json:"ctId" gorm:"column:ct_id;index"`
+
+	Enabled     bool   `json:"enabled"`
+	CronExpr    string `json:"cronExpr" gorm:"not null"`
+	MaxKeep     int    `json:"maxKeep" gorm:"default:0"`
+	NamePattern string `json:"namePattern" gorm:"default:''"`
+
+	LastRunAt  *time.Time `json:"lastRunAt"`
+	NextRunAt  *time.Time `json:"nextRunAt"`
+	LastStatus string     `json:"lastStatus"`
+	LastError  string     `json:"lastError"`
+
+	CreatedAt time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+func (JailSnapshotSchedule) TableName() string {
+	return "jail_snapshot_schedules"
+}
+
+type JailMountType string
+
+const (
+	JailMountTypeNullFS    JailMountType = "nullfs"
+	JailMountTypeTmpFS     JailMountType = "tmpfs"
+	JailMountTypeProcFS    JailMountType = "procfs"
+	JailMountTypeFdescFS   JailMountType = "fdescfs"
+	JailMountTypeLinProcFS JailMountType = "linprocfs"
+	JailMountTypeLinSysFS  JailMountType = "linsysfs"
+)
+
+// JailMount is one filesystem mounted into a jail when it starts. JailPath
+// is relative to the jail root, so mounts survive the jail moving pools or
+// being restored elsewhere. HostPath is only used by nullfs mounts.
+type JailMount struct {
+	HostPath string        `json:"hostPath"`
+	JailPath string        `json:"jailPath"`
+	Type     JailMountType `json:"type"`
+	ReadOnly bool          `json:"readOnly"`
+}
+
 type JailType string
 
 const (
@@ -255,8 +311,19 @@ type Jail struct {
 	Memory         int    `json:"memory"`
 	DevFSRuleset   string `json:"devfsRuleset"`
 
-	Fstab             string      `json:"fstab"`
-	ResolvConf        string      `json:"resolvConf"`
+	// Mounts are rendered into the jail's fstab ahead of the raw Fstab
+	// entries, which are kept for anything the structured form can't express.
+	Mounts     []JailMount `json:"mounts" gorm:"serializer:json;type:json"`
+	Fstab      string      `json:"fstab"`
+	ResolvConf string      `json:"resolvConf"`
+
+	// With ManageEtcFiles set, /etc/resolv.conf and /etc/hosts are rendered
+	// from the jail's networks, the search domain and the static host entries,
+	// and re-rendered whenever those change.
+	ManageEtcFiles  bool   `json:"manageEtcFiles" gorm:"default:false"`
+	DNSSearchDomain string `json:"dnsSearchDomain"`
+	HostEntries     string `json:"hostEntries"`
+
 	CleanEnvironment  bool        `json:"cleanEnvironment"`
 	AdditionalOptions string      `json:"additionalOptions"`
 	AllowedOptions    []string    `json:"allowedOptions" gorm:"serializer:json;type:json"`
@@ -273,10 +340,10 @@ type Jail struct {
 	CreatedAt time.Time `json:"createdAt" gorm:"autoCreateTime"`
 	UpdatedAt time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
 
-	StartLogs string     `json:"startLogs" gorm:"default:''"`
-	StopLogs  string     `json:"stopLogs" gorm:"default:''"`
-	StartedAt           *time.Time `json:"startedAt" gorm:"default:null"`
-	StoppedAt           *time.Time `json:"stoppedAt" gorm:"default:null"`
+	StartLogs            string     `json:"startLogs" gorm:"default:''"`
+	StopLogs             string     `json:"stopLogs" gorm:"default:''"`
+	StartedAt            *time.Time `json:"startedAt" gorm:"default:null"`
+	StoppedAt            *time.Time `json:"stoppedAt" gorm:"default:null"`
 	IntentionallyStopped bool       `json:"intentionallyStopped" gorm:"default:false"`
 }
 
@@ -303,3 +370,30 @@ type JailBootstrap struct {
 func (JailBootstrap) TableName() string {
 	return "jail_bootstraps"
 }
+
+// JailBaseCache is one checksum-verified base.txz per FreeBSD release, kept
+// under the downloads directory together with its extracted root so repeated
+// jail creation copies from disk instead of fetching the archive again.
+type JailBaseCache struct {
+	ID      uint   `gorm:"primaryKey" json:"id"`
+	Release string `json:"release" gorm:"not null;uniqueIndex"`
+	Arch    string `json:"arch" gorm:"not null"`
+	URL     string `json:"url" gorm:"not null"`
+
+	ArchivePath string `json:"archivePath" gorm:"not null"`
+	RootPath    string `json:"rootPath" gorm:"not null"`
+	SHA256      string `json:"sha256" gorm:"default:''"`
+	Size        int64  `json:"size" gorm:"default:0"`
+	RootSize    int64  `json:"rootSize" gorm:"default:0"`
+
+	Status     string     `json:"status" gorm:"not null;default:'pending'"`
+	Error      string     `json:"error" gorm:"default:''"`
+	VerifiedAt *time.Time `json:"verifiedAt" gorm:"default:null"`
+
+	CreatedAt time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+func (JailBaseCache) TableName() string {
+	return "jail_base_caches"
+}
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package networkModels
+
+import "time"
+
+const (
+	PacketCaptureStatusRunning   = "running"
+	PacketCaptureStatusCompleted = "completed"
+	PacketCaptureStatusStopped   = "stopped"
+	PacketCaptureStatusFailed    = "failed"
+)
+
+type PacketCapture struct {
+	ID              uint       `json:"id" gorm:"primaryKey"`
+	Interface       string     `json:"interface" gorm:"not null;index"`
+	Filter          string     `json:"filter"`
+	DurationSeconds int        `json:"durationSeconds" gorm:"not null"`
+	MaxSizeBytes    int64      `json:"maxSizeBytes" gorm:"not null"`
+	MaxPackets      int        `json:"maxPackets" gorm:"not null"`
+	Status          string     `json:"status" gorm:"not null;index"` // running|completed|stopped|failed
+	StopReason      string     `json:"stopReason"`                   // duration|size|packets|user
+	Error           string     `json:"error"`
+	FilePath        string     `json:"-" gorm:"not null"`
+	SizeBytes       int64      `json:"sizeBytes"`
+	StartedAt       time.Time  `json:"startedAt"`
+	FinishedAt      *time.Time `json:"finishedAt"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package networkModels
+
+import "time"
+
+// HostInterface is Sylve-managed configuration for one of the host's own
+// NICs. It takes the place of the interface's rc.conf ifconfig_ line and is
+// reapplied at startup.
+type HostInterface struct {
+	ID          uint     `json:"id" gorm:"primaryKey"`
+	Name        string   `json:"name" gorm:"uniqueIndex;not null"`
+	Description string   `json:"description"`
+	MTU         int      `json:"mtu"` // 0 keeps the driver default
+	IPv4        []string `json:"ipv4" gorm:"serializer:json;type:json"`
+	IPv6        []string `json:"ipv6" gorm:"serializer:json;type:json"`
+	Media       string   `json:"media"`
+	MediaOpt    string   `json:"mediaOpt"`
+	Up          bool     `json:"up" gorm:"not null;default:true"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
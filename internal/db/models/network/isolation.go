@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package networkModels
+
+import "time"
+
+const (
+	IsolationGuestVM   = "vm"
+	IsolationGuestJail = "jail"
+)
+
+type IsolationGroupMember struct {
+	GuestType string `json:"guestType"` // vm|jail
+	GuestID   uint   `json:"guestId"`   // RID for VMs, CTID for jails
+}
+
+// IsolationGroup is a set of guests on a standard switch. Guests in
+// different groups on the same switch cannot reach each other; guests in the
+// same group, or in no group, are left alone.
+type IsolationGroup struct {
+	ID          uint                   `json:"id" gorm:"primaryKey"`
+	SwitchID    uint                   `json:"switchId" gorm:"not null;uniqueIndex:idx_isolation_group_name_per_switch,priority:1"`
+	Name        string                 `json:"name" gorm:"not null;uniqueIndex:idx_isolation_group_name_per_switch,priority:2"`
+	Description string                 `json:"description"`
+	Members     []IsolationGroupMember `json:"members" gorm:"serializer:json;type:json"`
+	CreatedAt   time.Time              `json:"createdAt"`
+	UpdatedAt   time.Time              `json:"updatedAt"`
+}
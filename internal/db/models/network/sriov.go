@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package networkModels
+
+import "time"
+
+// SRIOVPhysicalFunction is an SR-IOV capable NIC whose virtual functions are
+// managed by Sylve. The VFs are recreated through iovctl(8) at startup.
+type SRIOVPhysicalFunction struct {
+	ID        uint   `json:"id" gorm:"primaryKey"`
+	Interface string `json:"interface" gorm:"uniqueIndex;not null"`
+
+	VirtualFunctions []SRIOVVirtualFunction `json:"virtualFunctions" gorm:"foreignKey:PhysicalFunctionID;constraint:OnDelete:CASCADE"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type SRIOVVirtualFunction struct {
+	ID                 uint `json:"id" gorm:"primaryKey"`
+	PhysicalFunctionID uint `json:"physicalFunctionId" gorm:"not null;uniqueIndex:idx_sriov_vf_index,priority:1"`
+	Index              int  `json:"index" gorm:"not null;uniqueIndex:idx_sriov_vf_index,priority:2"`
+
+	MAC         string `json:"mac"`
+	VLAN        int    `json:"vlan"` // 0 leaves the VF untagged
+	Passthrough bool   `json:"passthrough"`
+
+	// DeviceID is the VF's bus/slot/function once iovctl has created it, and
+	// PPTID the PassedThroughIDs row VMs reference when Passthrough is set.
+	DeviceID string `json:"deviceId"`
+	PPTID    *int   `json:"pptId" gorm:"column:ppt_id"`
+}
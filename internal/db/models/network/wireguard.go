@@ -8,7 +8,11 @@
 
 package networkModels
 
-import "time"
+import (
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/db/secrets"
+)
 
 type WireGuardServer struct {
 	ID uint `json:"id" gorm:"primaryKey"`
@@ -22,8 +26,8 @@ type WireGuardServer struct {
 	MasqueradeIPv4Interface string `json:"masqueradeIPv4Interface"`
 	MasqueradeIPv6Interface string `json:"masqueradeIPv6Interface"`
 
-	PrivateKey string `json:"privateKey"`
-	PublicKey  string `json:"publicKey"`
+	PrivateKey secrets.String `json:"privateKey"`
+	PublicKey  string         `json:"publicKey"`
 
 	Peers []WireGuardServerPeer `json:"peers" gorm:"foreignKey:WireGuardServerID"`
 
@@ -49,9 +53,9 @@ type WireGuardServerPeer struct {
 	Enabled           bool   `json:"enabled" gorm:"default:true"`
 	WireGuardServerID uint   `json:"wireguardServerId"`
 
-	PrivateKey   string `json:"privateKey"`
-	PublicKey    string `json:"publicKey"`
-	PreSharedKey string `json:"preSharedKey"`
+	PrivateKey   secrets.String `json:"privateKey"`
+	PublicKey    string         `json:"publicKey"`
+	PreSharedKey secrets.String `json:"preSharedKey"`
 
 	ClientIPs           []string `json:"clientIPs" gorm:"serializer:json;type:json"`
 	RoutableIPs         []string `json:"routableIPs" gorm:"serializer:json;type:json"`
@@ -79,10 +83,10 @@ type WireGuardClient struct {
 	EndpointPort uint   `json:"endpointPort"`
 	ListenPort   uint   `json:"listenPort"`
 
-	PrivateKey    string `json:"privateKey"`
-	PublicKey     string `json:"publicKey"`
-	PeerPublicKey string `json:"peerPublicKey"`
-	PreSharedKey  string `json:"preSharedKey"`
+	PrivateKey    secrets.String `json:"privateKey"`
+	PublicKey     string         `json:"publicKey"`
+	PeerPublicKey string         `json:"peerPublicKey"`
+	PreSharedKey  secrets.String `json:"preSharedKey"`
 
 	AllowedIPs      []string `json:"allowedIPs" gorm:"serializer:json;type:json"`
 	Addresses       []string `json:"addresses" gorm:"serializer:json;type:json"`
@@ -8,7 +8,11 @@
 
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/db/secrets"
+)
 
 type NotificationSeverity string
 
@@ -57,23 +61,23 @@ type NotificationKindRule struct {
 }
 
 type NotificationTransportConfig struct {
-	ID               uint      `json:"id" gorm:"primaryKey"`
-	Name             string    `json:"name" gorm:"not null;default:default;index"`
-	Type             string    `json:"type" gorm:"not null;default:smtp;index"`
-	NtfyEnabled      bool      `json:"ntfyEnabled" gorm:"not null;default:false"`
-	NtfyBaseURL      string    `json:"ntfyBaseUrl" gorm:"not null;default:https://ntfy.sh"`
-	NtfyTopic        string    `json:"ntfyTopic"`
-	NtfyAuthToken    string    `json:"-"`
-	EmailEnabled     bool      `json:"emailEnabled" gorm:"not null;default:false"`
-	SMTPHost         string    `json:"smtpHost"`
-	SMTPPort         int       `json:"smtpPort" gorm:"not null;default:587"`
-	SMTPUsername     string    `json:"smtpUsername"`
-	SMTPFrom         string    `json:"smtpFrom"`
-	SMTPUseTLS       bool      `json:"smtpUseTls" gorm:"not null;default:true"`
-	SMTPPassword     string    `json:"-"`
-	EmailRecipients  []string  `json:"emailRecipients" gorm:"serializer:json;type:json"`
-	DiscordEnabled   bool   `json:"discordEnabled" gorm:"not null;default:false"`
-	DiscordWebhookURL string `json:"discordWebhookUrl"`
-	CreatedAt        time.Time `json:"createdAt" gorm:"autoCreateTime"`
-	UpdatedAt        time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+	ID                uint           `json:"id" gorm:"primaryKey"`
+	Name              string         `json:"name" gorm:"not null;default:default;index"`
+	Type              string         `json:"type" gorm:"not null;default:smtp;index"`
+	NtfyEnabled       bool           `json:"ntfyEnabled" gorm:"not null;default:false"`
+	NtfyBaseURL       string         `json:"ntfyBaseUrl" gorm:"not null;default:https://ntfy.sh"`
+	NtfyTopic         string         `json:"ntfyTopic"`
+	NtfyAuthToken     secrets.String `json:"-"`
+	EmailEnabled      bool           `json:"emailEnabled" gorm:"not null;default:false"`
+	SMTPHost          string         `json:"smtpHost"`
+	SMTPPort          int            `json:"smtpPort" gorm:"not null;default:587"`
+	SMTPUsername      string         `json:"smtpUsername"`
+	SMTPFrom          string         `json:"smtpFrom"`
+	SMTPUseTLS        bool           `json:"smtpUseTls" gorm:"not null;default:true"`
+	SMTPPassword      secrets.String `json:"-"`
+	EmailRecipients   []string       `json:"emailRecipients" gorm:"serializer:json;type:json"`
+	DiscordEnabled    bool           `json:"discordEnabled" gorm:"not null;default:false"`
+	DiscordWebhookURL string         `json:"discordWebhookUrl"`
+	CreatedAt         time.Time      `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt         time.Time      `json:"updatedAt" gorm:"autoUpdateTime"`
 }
@@ -0,0 +1,22 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package models
+
+import "time"
+
+// SecretsDataKey holds this node's data key wrapped by the master key. The
+// salt feeds the key derivation, so a new master key needs a new row.
+type SecretsDataKey struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	KDF        string    `gorm:"not null" json:"kdf"`
+	Salt       string    `gorm:"not null" json:"-"`
+	WrappedKey string    `gorm:"type:text;not null" json:"-"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+}
@@ -28,6 +28,13 @@ type PassedThroughIDs struct {
 	Domain    int    `json:"domain"`
 	OldDriver string `json:"oldDriver"`
 	DeviceID  string `json:"deviceID" gorm:"uniqueIndex"`
+
+	// Missing is set when the reserved device is no longer present or no
+	// longer bound to ppt (typically noticed after a reboot). VMs using it
+	// refuse to start until it is reclaimed or the reservation is removed.
+	Missing       bool       `json:"missing"`
+	MissingReason string     `json:"missingReason"`
+	CheckedAt     *time.Time `json:"checkedAt"`
 }
 
 type Triggers struct {
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package taskModels
+
+import "time"
+
+const (
+	ApplicationHealthRunning = "running"
+	ApplicationHealthTCP     = "tcp"
+)
+
+// Application groups VMs and jails that are managed as one stack. Members
+// start in ascending start order, each waiting for the previous member's
+// health check, and stop in reverse.
+type Application struct {
+	ID          uint   `gorm:"primaryKey" json:"id"`
+	Name        string `gorm:"uniqueIndex;not null" json:"name"`
+	Description string `json:"description"`
+
+	Members []ApplicationMember `gorm:"foreignKey:ApplicationID;constraint:OnDelete:CASCADE" json:"members"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+// ApplicationMember is one guest of an application. HealthTarget is the
+// host:port probed when HealthCheck is "tcp".
+type ApplicationMember struct {
+	ID            uint `gorm:"primaryKey" json:"id"`
+	ApplicationID uint `gorm:"uniqueIndex:idx_application_member_guest;not null" json:"applicationId"`
+
+	GuestType  string `gorm:"uniqueIndex:idx_application_member_guest;index:idx_application_member_lookup;not null" json:"guestType"`
+	GuestID    uint   `gorm:"uniqueIndex:idx_application_member_guest;index:idx_application_member_lookup;not null" json:"guestId"`
+	StartOrder int    `gorm:"not null;default:0" json:"startOrder"`
+
+	HealthCheck          string `gorm:"not null;default:running" json:"healthCheck"`
+	HealthTarget         string `json:"healthTarget"`
+	HealthTimeoutSeconds int    `json:"healthTimeoutSeconds"`
+}
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package taskModels
+
+import "time"
+
+const (
+	GuestHookPhasePreStart  = "pre-start"
+	GuestHookPhasePostStart = "post-start"
+	GuestHookPhasePreStop   = "pre-stop"
+	GuestHookPhasePostStop  = "post-stop"
+)
+
+// GuestHook is a host-side script run around a guest's start and stop. The
+// script is executed directly, not through a shell, with the phase, guest
+// type and guest ID as arguments.
+type GuestHook struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+
+	GuestType string `gorm:"uniqueIndex:idx_guest_hook_phase;not null" json:"guestType"`
+	GuestID   uint   `gorm:"uniqueIndex:idx_guest_hook_phase;not null" json:"guestId"`
+	Phase     string `gorm:"uniqueIndex:idx_guest_hook_phase;not null" json:"phase"`
+
+	Script         string `gorm:"not null" json:"script"`
+	TimeoutSeconds int    `json:"timeoutSeconds"`
+	Enabled        bool   `json:"enabled"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+}
@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package taskModels
+
+import "time"
+
+const (
+	JournalKindBackupJob         = "backup_job"
+	JournalKindRestoreJob        = "restore_job"
+	JournalKindRestoreFromTarget = "restore_from_target"
+	JournalKindNodeStandby       = "node_standby"
+)
+
+const (
+	JournalPhasePreflight = "preflight"
+	JournalPhaseTransfer  = "transfer"
+	JournalPhasePromote   = "promote"
+)
+
+// JournalEntry marks an operation in flight. Entries are removed when the
+// operation returns, so any row left at startup belongs to a previous process.
+type JournalEntry struct {
+	ID       uint   `gorm:"primaryKey" json:"id"`
+	Kind     string `gorm:"index;not null" json:"kind"`
+	RefID    uint   `gorm:"index" json:"refId"` // backup job or standby ID, 0 for ad-hoc restores
+	TargetID uint   `json:"targetId"`
+	Phase    string `json:"phase"`
+	Dataset  string `json:"dataset"` // local dataset the operation reads or writes
+
+	// ResumeDataset is the receiving dataset on the target while a transfer
+	// zelta can resume from its receive_resume_token is running.
+	ResumeDataset string `json:"resumeDataset"`
+
+	StartedAt time.Time `gorm:"index" json:"startedAt"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+}
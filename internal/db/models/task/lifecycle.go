@@ -25,8 +25,10 @@ const (
 )
 
 const (
-	LifecycleTaskSourceUser    = "user"
-	LifecycleTaskSourceStartup = "startup"
+	LifecycleTaskSourceUser         = "user"
+	LifecycleTaskSourceStartup      = "startup"
+	LifecycleTaskSourceHostShutdown = "host_shutdown"
+	LifecycleTaskSourceApplication  = "application"
 )
 
 type GuestLifecycleTask struct {
@@ -46,6 +48,10 @@ type GuestLifecycleTask struct {
 
 	OverrideRequested bool `gorm:"index;default:false" json:"overrideRequested"`
 
+	// QueuePosition is where a task waiting for pool capacity stands in line.
+	// It is tracked in memory and filled in when tasks are read.
+	QueuePosition int `gorm:"-" json:"queuePosition,omitempty"`
+
 	StartedAt  *time.Time `json:"startedAt"`
 	FinishedAt *time.Time `json:"finishedAt"`
 
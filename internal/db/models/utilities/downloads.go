@@ -35,6 +35,18 @@ const (
 	DownloadUTypeOther     DownloadUType = "uncategoried"
 )
 
+// DownloadVerification tells whether a download was checked against a
+// known SHA256 or signature. Unverified downloads had nothing to check
+// against; pending ones have expectations that were not checked yet.
+type DownloadVerification string
+
+const (
+	DownloadVerificationUnverified DownloadVerification = "unverified"
+	DownloadVerificationPending    DownloadVerification = "pending"
+	DownloadVerificationVerified   DownloadVerification = "verified"
+	DownloadVerificationFailed     DownloadVerification = "failed"
+)
+
 type DownloadedFile struct {
 	ID         int       `json:"id" gorm:"primaryKey"`
 	DownloadID int       `json:"downloadId" gorm:"not null"`
@@ -60,6 +72,16 @@ type Downloads struct {
 	IgnoreTLS              bool             `json:"ignoreTLS" gorm:"not null;default:false"`
 	ExtractedPath          string           `json:"extractedPath"`
 	Status                 DownloadStatus   `json:"status" gorm:"not null;default:'done'"`
-	CreatedAt              time.Time        `json:"createdAt" gorm:"autoCreateTime"`
-	UpdatedAt              time.Time        `json:"updatedAt" gorm:"autoUpdateTime"`
+
+	ExpectedSHA256     string               `json:"expectedSha256"`
+	ChecksumURL        string               `json:"checksumUrl"`
+	SignatureURL       string               `json:"signatureUrl"`
+	SignaturePublicKey string               `json:"signaturePublicKey"`
+	SHA256             string               `json:"sha256"`
+	Verification       DownloadVerification `json:"verification" gorm:"not null;default:'unverified'"`
+	VerificationError  string               `json:"verificationError"`
+	VerifiedAt         *time.Time           `json:"verifiedAt"`
+
+	CreatedAt time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
 }
@@ -16,6 +16,7 @@ import (
 	"time"
 
 	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
 	"github.com/digitalocean/go-libvirt"
 	"gorm.io/gorm"
 )
@@ -283,6 +284,10 @@ type VMSnapshot struct {
 
 	ParentSnapshotID *uint `json:"parentSnapshotId" gorm:"column:parent_snapshot_id;index"`
 
+	// ScheduleID is set on snapshots taken by a VMSnapshotSchedule. Only
+	// those count towards the schedule's MaxKeep.
+	ScheduleID *uint `json:"scheduleId" gorm:"column:schedule_id;index"`
+
 	Name        string `json:"name" gorm:"not null"`
 	Description string `json:"description" gorm:"default:''"`
 
@@ -297,6 +302,33 @@ func (VMSnapshot) TableName() string {
 	return "vm_snapshots"
 }
 
+// VMSnapshotSchedule takes snapshots of a VM on a cron schedule. Once a run
+// leaves more than MaxKeep scheduled snapshots the oldest are deleted; zero
+// keeps them all. NamePattern may use {vm}, {rid}, {date} and {time}.
+type VMSnapshotSchedule struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	VMID uint `json:"vmId" gorm:"column:vm_id;uniqueIndex"`
+	RID  uint `json:"rid" gorm:"column:rid;index"`
+
+	Enabled     bool   `json:"enabled"`
+	CronExpr    string `json:"cronExpr" gorm:"not null"`
+	MaxKeep     int    `json:"maxKeep" gorm:"default:0"`
+	NamePattern string `json:"namePattern" gorm:"default:''"`
+
+	LastRunAt  *time.Time `json:"lastRunAt"`
+	NextRunAt  *time.Time `json:"nextRunAt"`
+	LastStatus string     `json:"lastStatus"`
+	LastError  string     `json:"lastError"`
+
+	CreatedAt time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+func (VMSnapshotSchedule) TableName() string {
+	return "vm_snapshot_schedules"
+}
+
 type VM struct {
 	ID          uint   `gorm:"primaryKey" json:"id"`
 	Name        string `json:"name"`
@@ -316,12 +348,12 @@ type VM struct {
 
 	Serial bool `json:"serial" gorm:"default:false"`
 
-	VNCEnabled    bool   `json:"vncEnabled"`
-	VNCPort       int    `json:"vncPort"`
-	VNCBind       string `json:"vncBind"`
-	VNCPassword   string `json:"vncPassword"`
-	VNCResolution string `json:"vncResolution"`
-	VNCWait       bool   `json:"vncWait"`
+	VNCEnabled    bool           `json:"vncEnabled"`
+	VNCPort       int            `json:"vncPort"`
+	VNCBind       string         `json:"vncBind"`
+	VNCPassword   secrets.String `json:"vncPassword"`
+	VNCResolution string         `json:"vncResolution"`
+	VNCWait       bool           `json:"vncWait"`
 
 	StartAtBoot bool       `json:"startAtBoot"`
 	StartOrder  int        `json:"startOrder"`
@@ -341,9 +373,12 @@ type VM struct {
 	CloudInitData          string       `json:"cloudInitData" gorm:"type:text"`
 	CloudInitMetaData      string       `json:"cloudInitMetaData" gorm:"type:text"`
 	CloudInitNetworkConfig string       `json:"cloudInitNetworkConfig" gorm:"type:text"`
+	SSHKeys                []string     `json:"sshKeys" gorm:"column:ssh_keys;serializer:json;type:json"`
+	Tags                   []string     `json:"tags" gorm:"serializer:json;type:json"`
 	BootROM                VMBootROM    `json:"bootRom" gorm:"column:boot_rom"`
 	ExtraBhyveOptions      []string     `json:"extraBhyveOptions" gorm:"serializer:json;type:json"`
 	IgnoreUMSR             bool         `json:"ignoreUMSR" gorm:"default:false"`
+	CPUFeatures            []string     `json:"cpuFeatures" gorm:"serializer:json;type:json"`
 	QemuGuestAgent         bool         `json:"qemuGuestAgent" gorm:"default:false"`
 	Snapshots              []VMSnapshot `json:"snapshots,omitempty" gorm:"foreignKey:VMID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 
@@ -393,6 +428,7 @@ type VMTemplate struct {
 	BootROM                VMBootROM `json:"bootRom" gorm:"column:boot_rom"`
 	ExtraBhyveOptions      []string  `json:"extraBhyveOptions" gorm:"serializer:json;type:json"`
 	IgnoreUMSR             bool      `json:"ignoreUMSR" gorm:"default:false"`
+	CPUFeatures            []string  `json:"cpuFeatures" gorm:"serializer:json;type:json"`
 	QemuGuestAgent         bool      `json:"qemuGuestAgent" gorm:"default:false"`
 
 	Storages []VMTemplateStorage `json:"storages" gorm:"serializer:json;type:json"`
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package webhookModels
+
+import (
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/db/secrets"
+)
+
+const (
+	DeliveryStatusPending = "pending"
+	DeliveryStatusSuccess = "success"
+	DeliveryStatusFailed  = "failed"
+)
+
+type Webhook struct {
+	ID uint `json:"id" gorm:"primaryKey;autoIncrement"`
+
+	Name    string         `json:"name" gorm:"not null;uniqueIndex"`
+	URL     string         `json:"url" gorm:"not null"`
+	Secret  secrets.String `json:"-"`
+	Events  []string       `json:"events" gorm:"serializer:json;type:json"`
+	Enabled bool           `json:"enabled" gorm:"not null;default:true"`
+
+	MaxAttempts         uint `json:"maxAttempts" gorm:"not null;default:5"`
+	RetryBackoffSeconds uint `json:"retryBackoffSeconds" gorm:"not null;default:30"`
+	TimeoutSeconds      uint `json:"timeoutSeconds" gorm:"not null;default:10"`
+
+	CreatedAt time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+type Delivery struct {
+	ID        uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	WebhookID uint   `json:"webhookId" gorm:"not null;index"`
+	EventID   string `json:"eventId" gorm:"not null;index"`
+	EventType string `json:"eventType" gorm:"not null;index"`
+	Payload   string `json:"payload" gorm:"type:text"`
+
+	Status         string     `json:"status" gorm:"not null;index"`
+	Attempts       uint       `json:"attempts" gorm:"not null;default:0"`
+	ResponseStatus int        `json:"responseStatus"`
+	Error          string     `json:"error" gorm:"type:text"`
+	NextAttemptAt  *time.Time `json:"nextAttemptAt" gorm:"index"`
+	LastAttemptAt  *time.Time `json:"lastAttemptAt"`
+	DeliveredAt    *time.Time `json:"deliveredAt"`
+
+	CreatedAt time.Time `json:"createdAt" gorm:"autoCreateTime;index"`
+	UpdatedAt time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
@@ -30,6 +30,38 @@ type PeriodicSnapshot struct {
 	KeepMonthly int `json:"keepMonthly" gorm:"default:0"` // e.g., keep 12 monthly
 	KeepYearly  int `json:"keepYearly" gorm:"default:0"`  // e.g., keep 3 yearly
 
+	// BookmarkPruned converts pruned snapshots to bookmarks so they remain
+	// usable as incremental send bases.
+	BookmarkPruned bool `json:"bookmarkPruned" gorm:"default:false"`
+
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdAt"`
 	LastRunAt time.Time `json:"lastRunAt"`
 }
+
+// StorageFence guards a pool on shared storage so that only one node of a
+// two-node HA pair can import it or start guests from it. Mode "mmp" relies
+// on the pool's multihost property; "scsi" holds a SCSI-3 persistent
+// reservation on each listed LUN.
+type StorageFence struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	Pool           string    `gorm:"uniqueIndex;not null" json:"pool"`
+	Mode           string    `gorm:"not null" json:"mode"`
+	Devices        []string  `gorm:"serializer:json;type:json" json:"devices"`
+	ReservationKey string    `json:"reservationKey"`
+	Enabled        bool      `json:"enabled"`
+	LastCheckedAt  time.Time `json:"lastCheckedAt"`
+	LastError      string    `json:"lastError"`
+	CreatedAt      time.Time `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+// Delegation records a `zfs allow` grant made through Sylve so it can be
+// listed and revoked later. Permissions are the ZFS permission names.
+type Delegation struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Dataset     string    `gorm:"uniqueIndex:uniq_delegation_dataset_user,priority:1;not null" json:"dataset"`
+	User        string    `gorm:"uniqueIndex:uniq_delegation_dataset_user,priority:2;not null" json:"user"`
+	Permissions []string  `gorm:"serializer:json;type:json" json:"permissions"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+}
@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zfsModels
+
+import "time"
+
+// HotSparePolicy is the per-pool opt-in for replacing a FAULTED member with
+// one of the pool's spares as soon as the fault is reported. The spares
+// themselves stay in the pool configuration; this only records the policy
+// and the outcome of the last automatic replacement.
+type HotSparePolicy struct {
+	ID          uint   `gorm:"primaryKey" json:"id"`
+	Pool        string `gorm:"uniqueIndex" json:"pool"`
+	AutoReplace bool   `json:"autoReplace"`
+
+	LastReplacedAt *time.Time `json:"lastReplacedAt"`
+	LastFaulted    string     `json:"lastFaulted"`
+	LastSpare      string     `json:"lastSpare"`
+	LastError      string     `gorm:"type:text" json:"lastError"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+}
@@ -103,6 +103,40 @@ func EnforceReplicationEventRetention(db *gorm.DB, now time.Time) error {
 	return nil
 }
 
+// CleanupOrphanReplicationEventTransfers drops per-transfer records whose
+// parent replication event has been pruned or deleted with its policy.
+func CleanupOrphanReplicationEventTransfers(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&clusterModels.ReplicationEventTransfer{}) {
+		return nil
+	}
+
+	if err := db.Where(
+		"event_id NOT IN (?)",
+		db.Model(&clusterModels.ReplicationEvent{}).Select("id"),
+	).Delete(&clusterModels.ReplicationEventTransfer{}).Error; err != nil {
+		return fmt.Errorf("failed_to_prune_orphan_replication_event_transfers: %w", err)
+	}
+
+	return nil
+}
+
+// CleanupOrphanBackupEventDatasets drops per-dataset restore records whose
+// parent backup event has been pruned or deleted with its job.
+func CleanupOrphanBackupEventDatasets(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&clusterModels.BackupEventDataset{}) {
+		return nil
+	}
+
+	if err := db.Where(
+		"event_id NOT IN (?)",
+		db.Model(&clusterModels.BackupEvent{}).Select("id"),
+	).Delete(&clusterModels.BackupEventDataset{}).Error; err != nil {
+		return fmt.Errorf("failed_to_prune_orphan_backup_event_datasets: %w", err)
+	}
+
+	return nil
+}
+
 func CleanupOrphanBackupEvents(db *gorm.DB) error {
 	deleteResult := db.Where(
 		"job_id IS NOT NULL AND job_id NOT IN (?)",
@@ -128,10 +162,18 @@ func PruneJobs(db *gorm.DB) error {
 		return err
 	}
 
+	if err := CleanupOrphanBackupEventDatasets(db); err != nil {
+		return err
+	}
+
 	if err := EnforceReplicationEventRetention(db, time.Now()); err != nil {
 		return err
 	}
 
+	if err := CleanupOrphanReplicationEventTransfers(db); err != nil {
+		return err
+	}
+
 	if err := EnforceAuditRecordRetention(db, time.Now()); err != nil {
 		return err
 	}
@@ -9,8 +9,10 @@
 package db
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/gob"
 	"encoding/json"
 	"fmt"
 	"path/filepath"
@@ -282,3 +284,70 @@ func EnqueueJSON[T any](ctx context.Context, name string, payload T) error {
 func EnqueueNoPayload(ctx context.Context, name string) error {
 	return Enqueue(ctx, name, nil)
 }
+
+// SettleInFlightQueueMessages resolves messages for the named jobs that were
+// received but never completed. It must run before StartQueue, when any such
+// message can only belong to a previous process. Messages journaled reports
+// as owned by a journal row are deleted, since the caller settles them from
+// the journal; the rest are made visible again so they run right away
+// instead of waiting out their receive timeout.
+func SettleInFlightQueueMessages(ctx context.Context, journaled func(name string, body []byte) bool, names ...string) (dropped, requeued int, err error) {
+	if dbConn == nil {
+		return 0, 0, fmt.Errorf("queue_not_setup")
+	}
+	return settleInFlightMessages(ctx, dbConn, journaled, names)
+}
+
+func settleInFlightMessages(ctx context.Context, d *sql.DB, journaled func(name string, body []byte) bool, names []string) (int, int, error) {
+	wanted := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		wanted[name] = struct{}{}
+	}
+
+	rows, err := d.QueryContext(ctx, "select id, body from goqite where received > 0")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var dropIDs, requeueIDs []string
+	for rows.Next() {
+		var id string
+		var body []byte
+		if err := rows.Scan(&id, &body); err != nil {
+			rows.Close()
+			return 0, 0, err
+		}
+
+		var qm queueJobMessage
+		if err := gob.NewDecoder(bytes.NewReader(body)).Decode(&qm); err != nil {
+			continue
+		}
+		if _, ok := wanted[qm.Name]; !ok {
+			continue
+		}
+		if journaled != nil && journaled(qm.Name, qm.Message) {
+			dropIDs = append(dropIDs, id)
+		} else {
+			requeueIDs = append(requeueIDs, id)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return 0, 0, err
+	}
+	rows.Close()
+
+	for _, id := range dropIDs {
+		if _, err := d.ExecContext(ctx, "delete from goqite where id = ?", id); err != nil {
+			return 0, 0, err
+		}
+	}
+	for _, id := range requeueIDs {
+		if _, err := d.ExecContext(ctx,
+			"update goqite set timeout = strftime('%Y-%m-%dT%H:%M:%fZ') where id = ?", id,
+		); err != nil {
+			return len(dropIDs), 0, err
+		}
+	}
+	return len(dropIDs), len(requeueIDs), nil
+}
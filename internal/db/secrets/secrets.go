@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+// Package secrets encrypts secret columns at rest. Values are sealed with a
+// random data key, and the data key is stored wrapped by a master key that
+// never touches the database.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql/driver"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Prefix marks a sealed value. Anything without it is legacy plaintext,
+// which reads back as is until the boot migration seals it.
+const Prefix = "sylve:enc:v1:"
+
+const (
+	KDFArgon2id = "argon2id"
+
+	dataKeySize = 32
+	saltSize    = 16
+)
+
+var (
+	mu   sync.RWMutex
+	aead cipher.AEAD
+)
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// SetDataKey installs the key secret columns are sealed with. A nil key
+// locks the store again.
+func SetDataKey(key []byte) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if key == nil {
+		aead = nil
+		return nil
+	}
+	if len(key) != dataKeySize {
+		return fmt.Errorf("secrets_data_key_invalid_length")
+	}
+
+	a, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+	aead = a
+	return nil
+}
+
+// Unlocked reports whether a data key is installed.
+func Unlocked() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return aead != nil
+}
+
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
+
+// Seal encrypts a value for storage. Empty values stay empty so that
+// "not set" checks in SQL keep working. Without a data key it fails rather
+// than store the secret in plaintext.
+func Seal(plain string) (string, error) {
+	if plain == "" || IsSealed(plain) {
+		return plain, nil
+	}
+
+	mu.RLock()
+	a := aead
+	mu.RUnlock()
+	if a == nil {
+		return "", fmt.Errorf("secrets_locked")
+	}
+
+	nonce := make([]byte, a.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := a.Seal(nonce, nonce, []byte(plain), nil)
+	return Prefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts a stored value. Plaintext left over from before encryption
+// is returned unchanged.
+func Open(stored string) (string, error) {
+	if !IsSealed(stored) {
+		return stored, nil
+	}
+
+	mu.RLock()
+	a := aead
+	mu.RUnlock()
+	if a == nil {
+		return "", fmt.Errorf("secrets_locked")
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(stored, Prefix))
+	if err != nil || len(raw) < a.NonceSize() {
+		return "", fmt.Errorf("secrets_value_malformed")
+	}
+	plain, err := a.Open(nil, raw[:a.NonceSize()], raw[a.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("secrets_value_undecryptable")
+	}
+	return string(plain), nil
+}
+
+// String is a string column sealed on write and opened on read. It goes
+// through driver.Valuer rather than a gorm serializer so that map updates
+// and raw Update calls are sealed too.
+type String string
+
+func (s String) Value() (driver.Value, error) {
+	return Seal(string(s))
+}
+
+func (s *String) Scan(src any) error {
+	var stored string
+	switch v := src.(type) {
+	case nil:
+		*s = ""
+		return nil
+	case string:
+		stored = v
+	case []byte:
+		stored = string(v)
+	default:
+		return fmt.Errorf("secrets_unsupported_scan_type: %T", src)
+	}
+
+	plain, err := Open(stored)
+	if err != nil {
+		return err
+	}
+	*s = String(plain)
+	return nil
+}
+
+// NewDataKey returns a fresh random data key.
+func NewDataKey() ([]byte, error) {
+	key := make([]byte, dataKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// NewSalt returns a fresh salt for deriving a key-encryption key.
+func NewSalt() ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return salt, nil
+}
+
+// DeriveKEK stretches master key material into the key that wraps the data
+// key. Passphrases typed at the console need the stretching; random keys
+// from a file or KMS just pass through it.
+func DeriveKEK(master, salt []byte) []byte {
+	return argon2.IDKey(master, salt, 1, 64*1024, 4, 32)
+}
+
+// WrapDataKey returns base64(nonce || ciphertext) of the data key.
+func WrapDataKey(kek, dataKey []byte) (string, error) {
+	a, err := newAEAD(kek)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, a.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(a.Seal(nonce, nonce, dataKey, nil)), nil
+}
+
+func UnwrapDataKey(kek []byte, wrapped string) ([]byte, error) {
+	a, err := newAEAD(kek)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := base64.StdEncoding.DecodeString(wrapped)
+	if err != nil || len(raw) < a.NonceSize() {
+		return nil, fmt.Errorf("secrets_wrapped_key_malformed")
+	}
+	key, err := a.Open(nil, raw[:a.NonceSize()], raw[a.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("secrets_master_key_mismatch")
+	}
+	return key, nil
+}
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package db
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
+	"github.com/alchemillahq/sylve/pkg/utils"
+)
+
+const (
+	masterKeySourceFile    = "file"
+	masterKeySourceEnv     = "env"
+	masterKeySourceCommand = "command"
+	masterKeySourcePrompt  = "prompt"
+
+	defaultMasterKeyEnv = "SYLVE_MASTER_KEY"
+)
+
+var runMasterKeyCommand = utils.RunCommand
+
+// defaultMasterKeyFile is where a generated master key lives when the
+// config does not name one.
+func defaultMasterKeyFile(dataPath string) string {
+	return filepath.Join(dataPath, "secrets", "master.key")
+}
+
+// loadMasterKey fetches the master key material from the configured source.
+func loadMasterKey(cfg internal.SecretsConfig, dataPath string) ([]byte, error) {
+	source := strings.ToLower(strings.TrimSpace(cfg.MasterKeySource))
+	if source == "" {
+		source = masterKeySourceFile
+	}
+
+	var material string
+	switch source {
+	case masterKeySourceFile:
+		path := strings.TrimSpace(cfg.MasterKeyFile)
+		if path == "" {
+			path = defaultMasterKeyFile(dataPath)
+		}
+		key, err := readOrCreateMasterKeyFile(path)
+		if err != nil {
+			return nil, err
+		}
+		material = key
+	case masterKeySourceEnv:
+		name := strings.TrimSpace(cfg.MasterKeyEnv)
+		if name == "" {
+			name = defaultMasterKeyEnv
+		}
+		material = os.Getenv(name)
+	case masterKeySourceCommand:
+		if len(cfg.MasterKeyCommand) == 0 || strings.TrimSpace(cfg.MasterKeyCommand[0]) == "" {
+			return nil, fmt.Errorf("secrets_master_key_command_required")
+		}
+		out, err := runMasterKeyCommand(cfg.MasterKeyCommand[0], cfg.MasterKeyCommand[1:]...)
+		if err != nil {
+			return nil, fmt.Errorf("secrets_master_key_command_failed: %w", err)
+		}
+		material = out
+	case masterKeySourcePrompt:
+		passphrase, err := promptMasterKey()
+		if err != nil {
+			return nil, err
+		}
+		material = passphrase
+	default:
+		return nil, fmt.Errorf("secrets_master_key_source_invalid: %s", source)
+	}
+
+	material = strings.TrimSpace(material)
+	if material == "" {
+		return nil, fmt.Errorf("secrets_master_key_empty: %s", source)
+	}
+	return []byte(material), nil
+}
+
+func readOrCreateMasterKeyFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		return string(data), nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("secrets_master_key_read_failed: %w", err)
+	}
+
+	key, err := secrets.NewDataKey()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", fmt.Errorf("secrets_master_key_dir_failed: %w", err)
+	}
+	encoded := hex.EncodeToString(key)
+	if err := os.WriteFile(path, []byte(encoded+"\n"), 0600); err != nil {
+		return "", fmt.Errorf("secrets_master_key_write_failed: %w", err)
+	}
+	return encoded, nil
+}
+
+// promptMasterKey asks for the passphrase on the controlling terminal with
+// echo turned off, so an unattended boot fails rather than hanging on a
+// closed stdin.
+func promptMasterKey() (string, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return "", fmt.Errorf("secrets_master_key_prompt_unavailable: %w", err)
+	}
+	defer tty.Close()
+
+	setTTYEcho(tty, false)
+	defer setTTYEcho(tty, true)
+
+	fmt.Fprint(tty, "Sylve master key: ")
+	line, err := bufio.NewReader(tty).ReadString('\n')
+	fmt.Fprintln(tty)
+	if err != nil && line == "" {
+		return "", fmt.Errorf("secrets_master_key_prompt_failed: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func setTTYEcho(tty *os.File, on bool) {
+	mode := "-echo"
+	if on {
+		mode = "echo"
+	}
+	cmd := exec.Command("stty", mode)
+	cmd.Stdin = tty
+	_ = cmd.Run()
+}
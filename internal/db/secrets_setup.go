@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package db
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/db/models"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	dynamicDNSModels "github.com/alchemillahq/sylve/internal/db/models/dynamicdns"
+	iscsiModels "github.com/alchemillahq/sylve/internal/db/models/iscsi"
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	webhookModels "github.com/alchemillahq/sylve/internal/db/models/webhooks"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"gorm.io/gorm"
+)
+
+// secretColumns lists every column typed secrets.String. The boot
+// migration seals whatever plaintext is still sitting in them.
+var secretColumns = []struct {
+	model  any
+	column string
+}{
+	{&models.SystemSecrets{}, "data"},
+	{&models.JWTSigningKey{}, "private_key"},
+	{&clusterModels.Cluster{}, "key"},
+	{&clusterModels.BackupTarget{}, "ssh_key"},
+	{&clusterModels.EncryptionKey{}, "key_data"},
+	{&vmModels.VM{}, "vnc_password"},
+	{&networkModels.WireGuardServer{}, "private_key"},
+	{&networkModels.WireGuardServerPeer{}, "private_key"},
+	{&networkModels.WireGuardServerPeer{}, "pre_shared_key"},
+	{&networkModels.WireGuardClient{}, "private_key"},
+	{&networkModels.WireGuardClient{}, "pre_shared_key"},
+	{&webhookModels.Webhook{}, "secret"},
+	{&models.NotificationTransportConfig{}, "ntfy_auth_token"},
+	{&models.NotificationTransportConfig{}, "smtp_password"},
+	{&iscsiModels.ISCSIInitiator{}, "chap_secret"},
+	{&iscsiModels.ISCSIInitiator{}, "tgt_chap_secret"},
+	{&iscsiModels.ISCSITarget{}, "chap_secret"},
+	{&iscsiModels.ISCSITarget{}, "mutual_chap_secret"},
+	{&dynamicDNSModels.Entry{}, "provider_secret"},
+}
+
+// setupSecrets unlocks the data key with the configured master key,
+// creating and wrapping a new one on first boot, then seals legacy
+// plaintext. Tests get a throwaway key so nothing touches the disk.
+func setupSecrets(db *gorm.DB, cfg *internal.SylveConfig, isTest bool) error {
+	if isTest {
+		key, err := secrets.NewDataKey()
+		if err != nil {
+			return err
+		}
+		return secrets.SetDataKey(key)
+	}
+
+	master, err := loadMasterKey(cfg.Secrets, cfg.DataPath)
+	if err != nil {
+		return err
+	}
+
+	dataKey, err := unwrapOrCreateDataKey(db, master)
+	if err != nil {
+		return err
+	}
+	if err := secrets.SetDataKey(dataKey); err != nil {
+		return err
+	}
+
+	return sealPlaintextSecrets(db)
+}
+
+func unwrapOrCreateDataKey(db *gorm.DB, master []byte) ([]byte, error) {
+	var record models.SecretsDataKey
+	err := db.Order("id DESC").First(&record).Error
+	if err == nil {
+		salt, err := base64.StdEncoding.DecodeString(record.Salt)
+		if err != nil {
+			return nil, fmt.Errorf("secrets_salt_malformed: %w", err)
+		}
+		return secrets.UnwrapDataKey(secrets.DeriveKEK(master, salt), record.WrappedKey)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	dataKey, err := secrets.NewDataKey()
+	if err != nil {
+		return nil, err
+	}
+	salt, err := secrets.NewSalt()
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := secrets.WrapDataKey(secrets.DeriveKEK(master, salt), dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	record = models.SecretsDataKey{
+		KDF:        secrets.KDFArgon2id,
+		Salt:       base64.StdEncoding.EncodeToString(salt),
+		WrappedKey: wrapped,
+	}
+	if err := db.Create(&record).Error; err != nil {
+		return nil, fmt.Errorf("secrets_data_key_store_failed: %w", err)
+	}
+
+	logger.L.Info().Msg("Generated a new data key for stored secrets")
+	return dataKey, nil
+}
+
+// sealPlaintextSecrets reads the secret columns raw, so nothing is opened,
+// and rewrites any value that predates encryption. It is cheap enough to
+// run on every boot, which also catches rows restored from old backups.
+func sealPlaintextSecrets(db *gorm.DB) error {
+	for _, col := range secretColumns {
+		if !db.Migrator().HasColumn(col.model, col.column) {
+			continue
+		}
+		table := tableName(db, col.model)
+
+		var rows []struct {
+			ID    uint
+			Value string
+		}
+		if err := db.Model(col.model).
+			Select(fmt.Sprintf("id, %q AS value", col.column)).
+			Where(fmt.Sprintf("%q IS NOT NULL AND %q != '' AND %q NOT LIKE ?", col.column, col.column, col.column), secrets.Prefix+"%").
+			Scan(&rows).Error; err != nil {
+			return fmt.Errorf("secrets_scan_failed %s.%s: %w", table, col.column, err)
+		}
+
+		for _, row := range rows {
+			sealed, err := secrets.Seal(row.Value)
+			if err != nil {
+				return err
+			}
+			if err := db.Model(col.model).Where("id = ?", row.ID).UpdateColumn(col.column, sealed).Error; err != nil {
+				return fmt.Errorf("secrets_seal_failed %s.%s: %w", table, col.column, err)
+			}
+		}
+
+		if len(rows) > 0 {
+			logger.L.Info().
+				Str("table", table).
+				Str("column", col.column).
+				Int("rows", len(rows)).
+				Msg("Encrypted plaintext secrets")
+		}
+	}
+
+	return nil
+}
+
+func tableName(db *gorm.DB, model any) string {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil || stmt.Schema == nil {
+		return fmt.Sprintf("%T", model)
+	}
+	return stmt.Schema.Table
+}
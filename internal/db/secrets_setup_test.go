@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package db
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/alchemillahq/sylve/internal/db/models"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
+	"github.com/alchemillahq/sylve/internal/testutil"
+	"gorm.io/gorm"
+)
+
+func TestSealPlaintextSecretsEncryptsLegacyRows(t *testing.T) {
+	dbConn := testutil.NewSQLiteTestDB(t, &models.SecretsDataKey{}, &clusterModels.BackupTarget{}, &clusterModels.BackupJob{})
+	t.Cleanup(func() { _ = secrets.SetDataKey(nil) })
+
+	if err := dbConn.Exec(
+		"INSERT INTO backup_targets (name, ssh_host, backup_root, ssh_key) VALUES (?, ?, ?, ?)",
+		"offsite", "backup@example.com", "tank/backups", "legacy-private-key",
+	).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	dataKey, err := unwrapOrCreateDataKey(dbConn, []byte("master"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := secrets.SetDataKey(dataKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := sealPlaintextSecrets(dbConn); err != nil {
+		t.Fatal(err)
+	}
+
+	var raw string
+	if err := dbConn.Raw("SELECT ssh_key FROM backup_targets WHERE name = ?", "offsite").Scan(&raw).Error; err != nil {
+		t.Fatal(err)
+	}
+	if !secrets.IsSealed(raw) {
+		t.Fatalf("ssh_key left in plaintext: %q", raw)
+	}
+
+	var target clusterModels.BackupTarget
+	if err := dbConn.Where("name = ?", "offsite").First(&target).Error; err != nil {
+		t.Fatal(err)
+	}
+	if target.SSHKey != "legacy-private-key" {
+		t.Fatalf("SSHKey = %q", target.SSHKey)
+	}
+
+	again, err := unwrapOrCreateDataKey(dbConn, []byte("master"))
+	if err != nil || string(again) != string(dataKey) {
+		t.Fatalf("expected the stored data key back, err=%v", err)
+	}
+	if _, err := unwrapOrCreateDataKey(dbConn, []byte("another master")); err == nil {
+		t.Fatal("expected a different master key to be rejected")
+	}
+}
+
+func TestSecretColumnsAreSecretStrings(t *testing.T) {
+	dbConn := testutil.NewSQLiteTestDB(t)
+
+	for _, col := range secretColumns {
+		stmt := &gorm.Statement{DB: dbConn}
+		if err := stmt.Parse(col.model); err != nil {
+			t.Fatal(err)
+		}
+		field := stmt.Schema.LookUpField(col.column)
+		if field == nil {
+			t.Fatalf("%s has no column %q", stmt.Schema.Table, col.column)
+		}
+		if field.FieldType != reflect.TypeOf(secrets.String("")) {
+			t.Fatalf("%s.%s is %s, want secrets.String", stmt.Schema.Table, col.column, field.FieldType)
+		}
+	}
+}
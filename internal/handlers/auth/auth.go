@@ -33,6 +33,7 @@ type SuccessfulLogin struct {
 	Hostname      string               `json:"hostname"`
 	NodeID        string               `json:"nodeId"`
 	BasicSettings models.BasicSettings `json:"basicSettings"`
+	LoginHistory  auth.LoginHistory    `json:"loginHistory"`
 }
 
 type LoginConfig struct {
@@ -83,6 +84,7 @@ func LoginHandler(authService *auth.Service) gin.HandlerFunc {
 		userId, token, err := authService.CreateJWT(r.Username, r.Password, r.AuthType, r.Remember)
 
 		if err != nil {
+			authService.RecordLoginFailure(r.Username, c.ClientIP())
 			c.JSON(http.StatusUnauthorized, internal.APIResponse[any]{
 				Status:  "error",
 				Message: "invalid_credentials",
@@ -92,6 +94,8 @@ func LoginHandler(authService *auth.Service) gin.HandlerFunc {
 			return
 		}
 
+		authService.RecordSessionClient(token, c.ClientIP(), c.Request.UserAgent())
+		loginHistory, _ := authService.RecordLoginSuccess(userId, c.ClientIP())
 		clusterToken, _ := authService.CreateClusterJWT(userId, r.Username, r.AuthType, "")
 		hostname, err := utils.GetSystemHostname()
 
@@ -137,6 +141,7 @@ func LoginHandler(authService *auth.Service) gin.HandlerFunc {
 				Hostname:      hostname,
 				NodeID:        nodeId,
 				BasicSettings: basicSettings,
+				LoginHistory:  loginHistory,
 			},
 		})
 	}
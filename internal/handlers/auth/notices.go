@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package authHandlers
+
+import (
+	"net/http"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/db/models"
+	"github.com/alchemillahq/sylve/internal/services/auth"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type LoginBanner struct {
+	Banner string `json:"banner"`
+}
+
+type MOTD struct {
+	MOTD string `json:"motd"`
+}
+
+type UpdateLoginNoticesRequest struct {
+	Banner string `json:"banner" binding:"max=8192"`
+	MOTD   string `json:"motd" binding:"max=8192"`
+}
+
+// @Summary Get Login Banner
+// @Description Get the notice shown on the login page. Served without authentication.
+// @Tags Authentication
+// @Produce json
+// @Success 200 {object} internal.APIResponse[LoginBanner] "Success"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /auth/login/banner [get]
+func LoginBannerHandler(authService *auth.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		notices, err := authService.GetLoginNotices()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "internal_server_error",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[LoginBanner]{
+			Status:  "success",
+			Message: "login_banner_retrieved",
+			Error:   "",
+			Data:    LoginBanner{Banner: notices.Banner},
+		})
+	}
+}
+
+// @Summary Get MOTD
+// @Description Get the message of the day shown after login
+// @Tags Authentication
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} internal.APIResponse[MOTD] "Success"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /auth/motd [get]
+func MOTDHandler(authService *auth.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		notices, err := authService.GetLoginNotices()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "internal_server_error",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[MOTD]{
+			Status:  "success",
+			Message: "motd_retrieved",
+			Error:   "",
+			Data:    MOTD{MOTD: notices.MOTD},
+		})
+	}
+}
+
+// @Summary Get Login Notices
+// @Description Get the login banner and message of the day for editing
+// @Tags Authentication
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} internal.APIResponse[models.LoginNotices] "Success"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /auth/notices [get]
+func GetLoginNoticesHandler(authService *auth.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		notices, err := authService.GetLoginNotices()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "internal_server_error",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[models.LoginNotices]{
+			Status:  "success",
+			Message: "login_notices_retrieved",
+			Error:   "",
+			Data:    notices,
+		})
+	}
+}
+
+// @Summary Update Login Notices
+// @Description Set the login banner and message of the day. Empty values turn them off.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body UpdateLoginNoticesRequest true "Login notices"
+// @Success 200 {object} internal.APIResponse[models.LoginNotices] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /auth/notices [put]
+func UpdateLoginNoticesHandler(authService *auth.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req UpdateLoginNoticesRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request_payload",
+				Error:   "validation_error",
+				Data:    utils.MapValidationErrors(err, UpdateLoginNoticesRequest{}),
+			})
+			return
+		}
+
+		notices, err := authService.UpdateLoginNotices(req.Banner, req.MOTD)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if err.Error() == "login_notice_too_long" {
+				status = http.StatusBadRequest
+			}
+			c.JSON(status, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "login_notices_update_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[models.LoginNotices]{
+			Status:  "success",
+			Message: "login_notices_updated",
+			Error:   "",
+			Data:    notices,
+		})
+	}
+}
@@ -302,6 +302,8 @@ func FinishPasskeyLoginHandler(authService *auth.Service) gin.HandlerFunc {
 			return
 		}
 
+		authService.RecordSessionClient(token, c.ClientIP(), c.Request.UserAgent())
+		loginHistory, _ := authService.RecordLoginSuccess(user.ID, c.ClientIP())
 		clusterToken, _ := authService.CreateClusterJWT(user.ID, user.Username, auth.AuthTypeSylvePasskey, "")
 		hostname, err := utils.GetSystemHostname()
 		if err != nil {
@@ -346,6 +348,7 @@ func FinishPasskeyLoginHandler(authService *auth.Service) gin.HandlerFunc {
 				Hostname:      hostname,
 				NodeID:        nodeID,
 				BasicSettings: basicSettings,
+				LoginHistory:  loginHistory,
 			},
 		})
 	}
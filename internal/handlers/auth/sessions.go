@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package authHandlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/services/auth"
+	"github.com/gin-gonic/gin"
+)
+
+type RevokeAllSessionsResponse struct {
+	Revoked int64 `json:"revoked"`
+}
+
+func requireSessionOwner(c *gin.Context) (uint, bool) {
+	userID := c.GetUint("UserID")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, internal.APIResponse[any]{
+			Status:  "error",
+			Message: "invalid_credentials",
+			Error:   "invalid_credentials",
+			Data:    nil,
+		})
+		return 0, false
+	}
+
+	return userID, true
+}
+
+// @Summary List Sessions
+// @Description List the active sessions of the current user
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} internal.APIResponse[[]auth.Session] "Success"
+// @Failure 401 {object} internal.APIResponse[any] "Unauthorized"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /auth/sessions [get]
+func ListSessionsHandler(authService *auth.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := requireSessionOwner(c)
+		if !ok {
+			return
+		}
+
+		sessions, err := authService.ListSessions(userID, c.GetString("Token"))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "internal_server_error",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]auth.Session]{
+			Status:  "success",
+			Message: "sessions_listed",
+			Error:   "",
+			Data:    sessions,
+		})
+	}
+}
+
+// @Summary Revoke Session
+// @Description Revoke one of the current user's sessions
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Session ID"
+// @Success 200 {object} internal.APIResponse[any] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 404 {object} internal.APIResponse[any] "Not Found"
+// @Router /auth/sessions/{id} [delete]
+func RevokeSessionHandler(authService *auth.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := requireSessionOwner(c)
+		if !ok {
+			return
+		}
+
+		sessionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil || sessionID == 0 {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_session_id",
+				Error:   "invalid_session_id",
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := authService.RevokeSession(userID, uint(sessionID)); err != nil {
+			status := http.StatusInternalServerError
+			if err.Error() == "session_not_found" {
+				status = http.StatusNotFound
+			}
+			c.JSON(status, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "session_revoke_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "session_revoked",
+			Error:   "",
+			Data:    nil,
+		})
+	}
+}
+
+// @Summary Revoke All Sessions
+// @Description Log the current user out everywhere. Pass keepCurrent=true to keep the calling session.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param keepCurrent query bool false "Keep the calling session"
+// @Success 200 {object} internal.APIResponse[RevokeAllSessionsResponse] "Success"
+// @Failure 401 {object} internal.APIResponse[any] "Unauthorized"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /auth/sessions [delete]
+func RevokeAllSessionsHandler(authService *auth.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := requireSessionOwner(c)
+		if !ok {
+			return
+		}
+
+		keepToken := ""
+		if keep, _ := strconv.ParseBool(c.Query("keepCurrent")); keep {
+			keepToken = c.GetString("Token")
+		}
+
+		revoked, err := authService.RevokeAllSessions(userID, keepToken)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "internal_server_error",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[RevokeAllSessionsResponse]{
+			Status:  "success",
+			Message: "sessions_revoked",
+			Error:   "",
+			Data:    RevokeAllSessionsResponse{Revoked: revoked},
+		})
+	}
+}
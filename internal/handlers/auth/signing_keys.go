@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package authHandlers
+
+import (
+	"net/http"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/db/models"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/internal/services/auth"
+	"github.com/alchemillahq/sylve/internal/services/cluster"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary List Signing Keys
+// @Description List the JWT signing keys of this node, including retired keys still in their grace period
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} internal.APIResponse[[]models.JWTSigningKey] "Success"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /auth/signing-keys [get]
+func ListSigningKeysHandler(authService *auth.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		keys, err := authService.ListSigningKeys()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_list_signing_keys",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]models.JWTSigningKey]{
+			Status:  "success",
+			Message: "signing_keys_listed",
+			Error:   "",
+			Data:    keys,
+		})
+	}
+}
+
+// @Summary Rotate Signing Key
+// @Description Create a new active JWT signing key; the previous key keeps verifying tokens until its grace period ends
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body auth.RotateSigningKeyRequest true "Signing key rotation request"
+// @Success 200 {object} internal.APIResponse[models.JWTSigningKey] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Router /auth/signing-keys/rotate [post]
+func RotateSigningKeyHandler(authService *auth.Service, clusterService *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req auth.RotateSigningKeyRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		key, err := authService.RotateSigningKey(req.Purpose, req.Algorithm)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_rotate_signing_key",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		// Peers only accept the new cluster key once they see it in our identity.
+		if key.Purpose == auth.SigningKeyPurposeCluster && clusterService != nil {
+			if err := clusterService.EnsureAndPublishLocalSSHIdentity(); err != nil {
+				logger.L.Warn().Err(err).Str("kid", key.KID).Msg("cluster_token_key_publish_failed")
+			}
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[models.JWTSigningKey]{
+			Status:  "success",
+			Message: "signing_key_rotated",
+			Error:   "",
+			Data:    *key,
+		})
+	}
+}
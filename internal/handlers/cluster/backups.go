@@ -20,6 +20,7 @@ import (
 	"github.com/alchemillahq/sylve/internal"
 	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
 	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/internal/services/cluster"
 	"github.com/alchemillahq/sylve/internal/services/zelta"
 	"github.com/alchemillahq/sylve/pkg/utils"
@@ -243,9 +244,11 @@ func RunBackupJobNow(cS *cluster.Service, zS *zelta.Service) gin.HandlerFunc {
 			localNodeID = strings.TrimSpace(detail.NodeID)
 		}
 
+		dryRun := strings.EqualFold(strings.TrimSpace(c.Query("dryRun")), "true")
+
 		runnerNodeID := strings.TrimSpace(job.RunnerNodeID)
 		if runnerNodeID != "" && localNodeID != "" && runnerNodeID != localNodeID {
-			body, statusCode, err := forwardBackupJobRunToRunner(c, cS, uint(id64), runnerNodeID)
+			body, statusCode, err := forwardBackupJobRunToRunner(c, cS, uint(id64), runnerNodeID, dryRun)
 			if err != nil {
 				c.JSON(http.StatusBadGateway, internal.APIResponse[any]{
 					Status:  "error",
@@ -265,7 +268,7 @@ func RunBackupJobNow(cS *cluster.Service, zS *zelta.Service) gin.HandlerFunc {
 			return
 		}
 
-		if err := zS.EnqueueBackupJob(c.Request.Context(), job.ID); err != nil {
+		if err := zS.EnqueueBackupJob(c.Request.Context(), job.ID, dryRun); err != nil {
 			status := http.StatusBadRequest
 			msg := "backup_job_enqueue_failed"
 			if strings.Contains(err.Error(), "already_running") {
@@ -281,6 +284,15 @@ func RunBackupJobNow(cS *cluster.Service, zS *zelta.Service) gin.HandlerFunc {
 			return
 		}
 
+		if dryRun {
+			c.JSON(http.StatusOK, internal.APIResponse[any]{
+				Status:  "success",
+				Message: "backup_job_simulation_started",
+				Data:    nil,
+			})
+			return
+		}
+
 		c.Set("AuditAsyncJobID", job.ID)
 		c.Set("AuditAsyncJobType", "backup_job_run")
 
@@ -292,7 +304,7 @@ func RunBackupJobNow(cS *cluster.Service, zS *zelta.Service) gin.HandlerFunc {
 	}
 }
 
-func forwardBackupJobRunToRunner(c *gin.Context, cS *cluster.Service, jobID uint, runnerNodeID string) ([]byte, int, error) {
+func forwardBackupJobRunToRunner(c *gin.Context, cS *cluster.Service, jobID uint, runnerNodeID string, dryRun bool) ([]byte, int, error) {
 	targetAPI, err := resolveClusterNodeAPI(cS, runnerNodeID)
 	if err != nil {
 		return nil, 0, err
@@ -319,6 +331,9 @@ func forwardBackupJobRunToRunner(c *gin.Context, cS *cluster.Service, jobID uint
 	}
 
 	runURL := fmt.Sprintf("https://%s/api/cluster/backups/jobs/run/%d", targetAPI, jobID)
+	if dryRun {
+		runURL += "?dryRun=true"
+	}
 	body, statusCode, err := utils.HTTPPostJSONRead(runURL, map[string]any{}, map[string]string{
 		"Accept":          "application/json",
 		"Content-Type":    "application/json",
@@ -426,7 +441,7 @@ func extractGuestFromDatasetPath(dataset string) (string, uint) {
 	parts := strings.Split(strings.Trim(dataset, "/"), "/")
 	for idx := 0; idx+1 < len(parts); idx++ {
 		segment := strings.TrimSpace(parts[idx])
-		if segment != "jails" && segment != "virtual-machines" {
+		if segment != layout.JailsName() && segment != layout.VirtualMachinesName() {
 			continue
 		}
 
@@ -446,7 +461,7 @@ func extractGuestFromDatasetPath(dataset string) (string, uint) {
 
 		guestID, err := strconv.ParseUint(raw, 10, 64)
 		if err == nil && guestID > 0 {
-			if segment == "jails" {
+			if segment == layout.JailsName() {
 				return clusterModels.BackupJobModeJail, uint(guestID)
 			}
 			return clusterModels.BackupJobModeVM, uint(guestID)
@@ -573,6 +588,8 @@ func RestoreBackupJob(cS *cluster.Service, zS *zelta.Service) gin.HandlerFunc {
 			Snapshot            string `json:"snapshot"`
 			EncryptionKey       string `json:"encryptionKey"`
 			EncryptionKeyFormat string `json:"encryptionKeyFormat"`
+			PostRestoreScript   string `json:"postRestoreScript"`
+			restoreConfirmation
 		}
 		if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Snapshot) == "" {
 			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
@@ -613,6 +630,16 @@ func RestoreBackupJob(cS *cluster.Service, zS *zelta.Service) gin.HandlerFunc {
 		}
 
 		if runnerNodeID == "" && cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			req.restoreConfirmation = req.forwarded(c, cS)
+			if err := rewriteRequestBody(c, req); err != nil {
+				c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+					Status:  "error",
+					Message: "restore_forward_failed",
+					Error:   err.Error(),
+					Data:    nil,
+				})
+				return
+			}
 			forwardToLeader(c, cS)
 			return
 		}
@@ -653,6 +680,10 @@ func RestoreBackupJob(cS *cluster.Service, zS *zelta.Service) gin.HandlerFunc {
 					})
 					return
 				}
+
+				if !requireRestoreOverwriteConfirmation(c, cS, job.Mode, guestID, req.restoreConfirmation) {
+					return
+				}
 			}
 		}
 
@@ -666,7 +697,7 @@ func RestoreBackupJob(cS *cluster.Service, zS *zelta.Service) gin.HandlerFunc {
 			return
 		}
 
-		if err := zS.EnqueueRestoreJob(c.Request.Context(), job.ID, req.Snapshot); err != nil {
+		if err := zS.EnqueueRestoreJob(c.Request.Context(), job.ID, req.Snapshot, req.PostRestoreScript); err != nil {
 			status := http.StatusBadRequest
 			msg := "restore_enqueue_failed"
 			if strings.Contains(err.Error(), "already_running") {
@@ -764,3 +795,75 @@ func UpdateBackupJobFriendlySourceInternal(cS *cluster.Service) gin.HandlerFunc
 		})
 	}
 }
+
+func RenameBackupJobDatasetsInternal(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		var req clusterModels.BackupJobDatasetRename
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := cS.RenameBackupJobDatasets(req, cS.Raft == nil); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "backup_job_dataset_rename_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "backup_job_datasets_renamed",
+			Data:    nil,
+		})
+	}
+}
+
+func ReattachBackupJobInternal(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		var req clusterServiceInterfaces.BackupJobReattachReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := cS.ProposeBackupJobCreateWithID(req.ID, req.Job, cS.Raft == nil); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "backup_job_reattach_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusCreated, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "backup_job_reattached",
+			Data:    nil,
+		})
+	}
+}
@@ -0,0 +1,222 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterHandlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/services/zelta"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type bulkRestoreZelta interface {
+	ListBulkRestoreCandidates(ctx context.Context, targetID uint) ([]zelta.BulkRestoreCandidate, error)
+	StartBulkRestore(ctx context.Context, req clusterServiceInterfaces.BulkRestoreReq) (*clusterModels.BulkRestore, error)
+	CancelBulkRestore(id uint) error
+	ListBulkRestores(limit int) ([]clusterModels.BulkRestore, error)
+	GetBulkRestore(id uint) (*clusterModels.BulkRestore, error)
+}
+
+func bulkRestoreStartError(err error) int {
+	message := err.Error()
+	switch {
+	case strings.Contains(message, "bulk_restore_already_running"):
+		return http.StatusConflict
+	case strings.Contains(message, "bulk_restore_guest_not_found"),
+		strings.Contains(message, "bulk_restore_duplicate_guest"),
+		strings.Contains(message, "bulk_restore_pool_required"),
+		strings.Contains(message, "bulk_restore_nothing_to_restore"),
+		strings.Contains(message, "destination_dataset_pool_missing"),
+		strings.Contains(message, "backup_target_disabled"):
+		return http.StatusBadRequest
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+func BulkRestoreCandidates(zS bulkRestoreZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id64, err := strconv.ParseUint(c.Query("targetId"), 10, 64)
+		if err != nil || id64 == 0 {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_target_id",
+				Error:   "invalid_target_id",
+				Data:    nil,
+			})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 45*time.Second)
+		defer cancel()
+
+		candidates, err := zS.ListBulkRestoreCandidates(ctx, uint(id64))
+		if err != nil {
+			c.JSON(http.StatusBadGateway, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "list_bulk_restore_candidates_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]zelta.BulkRestoreCandidate]{
+			Status:  "success",
+			Message: "bulk_restore_candidates_listed",
+			Data:    candidates,
+		})
+	}
+}
+
+func BulkRestores(zS bulkRestoreZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		restores, err := zS.ListBulkRestores(20)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "list_bulk_restores_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]clusterModels.BulkRestore]{
+			Status:  "success",
+			Message: "bulk_restores_listed",
+			Data:    restores,
+		})
+	}
+}
+
+func BulkRestoreByID(zS bulkRestoreZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil || id64 == 0 {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_bulk_restore_id",
+				Error:   "invalid_bulk_restore_id",
+				Data:    nil,
+			})
+			return
+		}
+
+		restore, err := zS.GetBulkRestore(uint(id64))
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				status = http.StatusNotFound
+			}
+			c.JSON(status, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "get_bulk_restore_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*clusterModels.BulkRestore]{
+			Status:  "success",
+			Message: "bulk_restore_fetched",
+			Data:    restore,
+		})
+	}
+}
+
+func StartBulkRestore(zS bulkRestoreZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req clusterServiceInterfaces.BulkRestoreReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Minute)
+		defer cancel()
+
+		restore, err := zS.StartBulkRestore(ctx, req)
+		if err != nil {
+			c.JSON(bulkRestoreStartError(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "bulk_restore_start_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if req.DryRun {
+			c.JSON(http.StatusOK, internal.APIResponse[*clusterModels.BulkRestore]{
+				Status:  "success",
+				Message: "bulk_restore_planned",
+				Data:    restore,
+			})
+			return
+		}
+
+		c.Set("AuditAsyncJobID", restore.ID)
+		c.Set("AuditAsyncJobType", "backup_bulk_restore")
+
+		c.JSON(http.StatusAccepted, internal.APIResponse[*clusterModels.BulkRestore]{
+			Status:  "success",
+			Message: "bulk_restore_started",
+			Data:    restore,
+		})
+	}
+}
+
+func CancelBulkRestore(zS bulkRestoreZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil || id64 == 0 {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_bulk_restore_id",
+				Error:   "invalid_bulk_restore_id",
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := zS.CancelBulkRestore(uint(id64)); err != nil {
+			c.JSON(http.StatusConflict, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "bulk_restore_cancel_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "bulk_restore_cancelling",
+			Data:    nil,
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterHandlers
+
+import (
+	"net/http"
+
+	"github.com/alchemillahq/sylve/internal"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/services/cluster"
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/raft"
+)
+
+// ExportBackupConfig is a POST so the key-wrapping passphrase stays out of
+// URLs and access logs.
+func ExportBackupConfig(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req clusterServiceInterfaces.BackupConfigExportReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		doc, err := cS.ExportBackupConfig(req)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "backup_config_export_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*clusterServiceInterfaces.BackupConfigDocument]{
+			Status:  "success",
+			Message: "backup_config_exported",
+			Data:    doc,
+		})
+	}
+}
+
+func ImportBackupConfig(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		var req clusterServiceInterfaces.BackupConfigImportReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		result, err := cS.ImportBackupConfig(req)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "backup_config_import_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*clusterServiceInterfaces.BackupConfigImportResult]{
+			Status:  "success",
+			Message: "backup_config_imported",
+			Data:    result,
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterHandlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strconv"
+
+	"github.com/alchemillahq/sylve/internal"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type guestExportZelta interface {
+	ListGuestExports() ([]clusterModels.GuestExport, error)
+	StartGuestExport(ctx context.Context, req clusterServiceInterfaces.GuestExportReq) (*clusterModels.GuestExport, error)
+	GetReadyGuestExport(id uint) (*clusterModels.GuestExport, error)
+	DeleteGuestExport(id uint) error
+}
+
+func guestExportID(c *gin.Context) (uint, bool) {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id64 == 0 {
+		c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+			Status:  "error",
+			Message: "invalid_guest_export_id",
+			Error:   "invalid_guest_export_id",
+			Data:    nil,
+		})
+		return 0, false
+	}
+	return uint(id64), true
+}
+
+func guestExportErrorStatus(err error) int {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
+
+func GuestExports(zS guestExportZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		exports, err := zS.ListGuestExports()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "list_guest_exports_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]clusterModels.GuestExport]{
+			Status:  "success",
+			Message: "guest_exports_listed",
+			Data:    exports,
+		})
+	}
+}
+
+// StartGuestExport returns as soon as the guest is snapshotted; the archive
+// is written in the background and the row turns ready or failed.
+func StartGuestExport(zS guestExportZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req clusterServiceInterfaces.GuestExportReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		export, err := zS.StartGuestExport(c.Request.Context(), req)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "start_guest_export_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusAccepted, internal.APIResponse[*clusterModels.GuestExport]{
+			Status:  "success",
+			Message: "guest_export_started",
+			Data:    export,
+		})
+	}
+}
+
+// DownloadGuestExport serves the archive with range support, so an
+// interrupted download can be resumed.
+func DownloadGuestExport(zS guestExportZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := guestExportID(c)
+		if !ok {
+			return
+		}
+
+		export, err := zS.GetReadyGuestExport(id)
+		if err != nil {
+			c.JSON(guestExportErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "download_guest_export_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.FileAttachment(export.Path, filepath.Base(export.Path))
+	}
+}
+
+func DeleteGuestExport(zS guestExportZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := guestExportID(c)
+		if !ok {
+			return
+		}
+
+		if err := zS.DeleteGuestExport(id); err != nil {
+			c.JSON(guestExportErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "delete_guest_export_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "guest_export_deleted",
+			Data:    nil,
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterHandlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/services/zelta"
+	"github.com/gin-gonic/gin"
+)
+
+type staleDatasetJanitorZelta interface {
+	GetStaleDatasetJanitor() (*clusterModels.StaleDatasetJanitor, error)
+	ConfigureStaleDatasetJanitor(req clusterServiceInterfaces.StaleDatasetJanitorReq) (*clusterModels.StaleDatasetJanitor, error)
+	ScanStaleDatasets(ctx context.Context) (*zelta.StaleDatasetReport, error)
+	CleanupStaleDatasets(ctx context.Context, refs []clusterServiceInterfaces.StaleDatasetRef) (*zelta.StaleDatasetCleanupResult, error)
+}
+
+func GetStaleDatasetJanitor(zS staleDatasetJanitorZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		janitor, err := zS.GetStaleDatasetJanitor()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "get_stale_dataset_janitor_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*clusterModels.StaleDatasetJanitor]{
+			Status:  "success",
+			Message: "stale_dataset_janitor_fetched",
+			Data:    janitor,
+		})
+	}
+}
+
+func ConfigureStaleDatasetJanitor(zS staleDatasetJanitorZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req clusterServiceInterfaces.StaleDatasetJanitorReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		janitor, err := zS.ConfigureStaleDatasetJanitor(req)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "stale_dataset_janitor_configure_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*clusterModels.StaleDatasetJanitor]{
+			Status:  "success",
+			Message: "stale_dataset_janitor_configured",
+			Data:    janitor,
+		})
+	}
+}
+
+func ScanStaleDatasets(zS staleDatasetJanitorZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Minute)
+		defer cancel()
+
+		report, err := zS.ScanStaleDatasets(ctx)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "stale_dataset_scan_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*zelta.StaleDatasetReport]{
+			Status:  "success",
+			Message: "stale_datasets_scanned",
+			Data:    report,
+		})
+	}
+}
+
+func CleanupStaleDatasets(zS staleDatasetJanitorZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req clusterServiceInterfaces.StaleDatasetCleanupReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Minute)
+		defer cancel()
+
+		result, err := zS.CleanupStaleDatasets(ctx, req.Datasets)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "stale_dataset_cleanup_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*zelta.StaleDatasetCleanupResult]{
+			Status:  "success",
+			Message: "stale_datasets_cleaned",
+			Data:    result,
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterHandlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/alchemillahq/sylve/internal"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/gin-gonic/gin"
+)
+
+type postRestoreScriptZelta interface {
+	ListPostRestoreScripts(ctx context.Context) ([]clusterModels.PostRestoreScript, error)
+	SavePostRestoreScript(ctx context.Context, req clusterServiceInterfaces.PostRestoreScriptReq) (*clusterModels.PostRestoreScript, error)
+	DeletePostRestoreScript(ctx context.Context, id uint) error
+}
+
+func PostRestoreScripts(zS postRestoreScriptZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scripts, err := zS.ListPostRestoreScripts(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "list_post_restore_scripts_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]clusterModels.PostRestoreScript]{
+			Status:  "success",
+			Message: "post_restore_scripts_listed",
+			Data:    scripts,
+		})
+	}
+}
+
+func SavePostRestoreScript(zS postRestoreScriptZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req clusterServiceInterfaces.PostRestoreScriptReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		script, err := zS.SavePostRestoreScript(c.Request.Context(), req)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "post_restore_script_save_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*clusterModels.PostRestoreScript]{
+			Status:  "success",
+			Message: "post_restore_script_saved",
+			Data:    script,
+		})
+	}
+}
+
+func DeletePostRestoreScript(zS postRestoreScriptZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil || id64 == 0 {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_post_restore_script_id",
+				Error:   "invalid_post_restore_script_id",
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := zS.DeletePostRestoreScript(c.Request.Context(), uint(id64)); err != nil {
+			status := http.StatusInternalServerError
+			if err.Error() == "post_restore_script_not_found" {
+				status = http.StatusNotFound
+			}
+			c.JSON(status, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "post_restore_script_delete_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "post_restore_script_deleted",
+			Data:    nil,
+		})
+	}
+}
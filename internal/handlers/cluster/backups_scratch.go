@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterHandlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/alchemillahq/sylve/internal"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/gin-gonic/gin"
+)
+
+type restoreScratchZelta interface {
+	GetRestoreScratch() (*clusterModels.RestoreScratch, error)
+	ConfigureRestoreScratch(ctx context.Context, req clusterServiceInterfaces.RestoreScratchReq) (*clusterModels.RestoreScratch, error)
+}
+
+func GetRestoreScratch(zS restoreScratchZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scratch, err := zS.GetRestoreScratch()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "get_restore_scratch_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*clusterModels.RestoreScratch]{
+			Status:  "success",
+			Message: "restore_scratch_fetched",
+			Data:    scratch,
+		})
+	}
+}
+
+func ConfigureRestoreScratch(zS restoreScratchZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req clusterServiceInterfaces.RestoreScratchReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		scratch, err := zS.ConfigureRestoreScratch(c.Request.Context(), req)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "restore_scratch_configure_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*clusterModels.RestoreScratch]{
+			Status:  "success",
+			Message: "restore_scratch_configured",
+			Data:    scratch,
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterHandlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/alchemillahq/sylve/internal"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/services/zelta"
+	"github.com/gin-gonic/gin"
+)
+
+type backupSeedZelta interface {
+	ExportBackupSeed(ctx context.Context, jobID uint, directory string) (*zelta.BackupSeedManifest, error)
+	AdoptBackupSeed(ctx context.Context, jobID uint, snapshotName string) error
+}
+
+type backupSeedExportResponse struct {
+	Directory string                    `json:"directory"`
+	Manifest  *zelta.BackupSeedManifest `json:"manifest"`
+}
+
+// ExportBackupSeed and AdoptBackupSeed act on the job's runner node, which
+// owns the removable disk, so neither is forwarded to the leader.
+func ExportBackupSeed(zS backupSeedZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil || id64 == 0 {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_job_id",
+				Error:   "invalid_job_id",
+				Data:    nil,
+			})
+			return
+		}
+
+		var req clusterServiceInterfaces.BackupSeedExportReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		manifest, err := zS.ExportBackupSeed(c.Request.Context(), uint(id64), req.Directory)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "backup_seed_export_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[backupSeedExportResponse]{
+			Status:  "success",
+			Message: "backup_seed_exported",
+			Data: backupSeedExportResponse{
+				Directory: manifest.Directory,
+				Manifest:  manifest,
+			},
+		})
+	}
+}
+
+func AdoptBackupSeed(zS backupSeedZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil || id64 == 0 {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_job_id",
+				Error:   "invalid_job_id",
+				Data:    nil,
+			})
+			return
+		}
+
+		var req clusterServiceInterfaces.BackupSeedAdoptReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := zS.AdoptBackupSeed(c.Request.Context(), uint(id64), req.SnapshotName); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "backup_seed_adopt_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "backup_seed_adopted",
+			Data:    nil,
+		})
+	}
+}
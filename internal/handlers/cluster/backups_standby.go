@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterHandlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/alchemillahq/sylve/internal"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/gin-gonic/gin"
+)
+
+type nodeStandbyZelta interface {
+	GetNodeStandby() (*clusterModels.NodeStandby, error)
+	ConfigureNodeStandby(ctx context.Context, req clusterServiceInterfaces.NodeStandbyReq) (*clusterModels.NodeStandby, error)
+	DeleteNodeStandby() error
+	EnqueueNodeStandbyRun(ctx context.Context) error
+}
+
+func GetNodeStandby(zS nodeStandbyZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		standby, err := zS.GetNodeStandby()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "get_node_standby_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*clusterModels.NodeStandby]{
+			Status:  "success",
+			Message: "node_standby_fetched",
+			Data:    standby,
+		})
+	}
+}
+
+func ConfigureNodeStandby(zS nodeStandbyZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req clusterServiceInterfaces.NodeStandbyReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		standby, err := zS.ConfigureNodeStandby(c.Request.Context(), req)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "node_standby_configure_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*clusterModels.NodeStandby]{
+			Status:  "success",
+			Message: "node_standby_configured",
+			Data:    standby,
+		})
+	}
+}
+
+func DeleteNodeStandby(zS nodeStandbyZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := zS.DeleteNodeStandby(); err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "node_standby_delete_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "node_standby_deleted",
+			Data:    nil,
+		})
+	}
+}
+
+func RunNodeStandbyNow(zS nodeStandbyZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := zS.EnqueueNodeStandbyRun(c.Request.Context()); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "node_standby_run_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusAccepted, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "node_standby_run_started",
+			Data:    nil,
+		})
+	}
+}
@@ -222,7 +222,7 @@ func UpdateBackupTarget(cS *cluster.Service, zS backupTargetZelta) gin.HandlerFu
 
 		sshKeyData := strings.TrimSpace(req.SSHKey)
 		if sshKeyData == "" {
-			sshKeyData = existing.SSHKey
+			sshKeyData = string(existing.SSHKey)
 		}
 
 		req.SSHKeyPath = sshKeyPath
@@ -367,6 +367,194 @@ func BackupTargetDatasets(zS *zelta.Service) gin.HandlerFunc {
 	}
 }
 
+func BackupTargetSpace(zS *zelta.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil || id64 == 0 {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_target_id",
+				Error:   "invalid_target_id",
+				Data:    nil,
+			})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 45*time.Second)
+		defer cancel()
+
+		report, err := zS.TargetSpaceReport(ctx, uint(id64))
+		if err != nil {
+			c.JSON(http.StatusBadGateway, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "target_space_report_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*zelta.BackupTargetSpaceReport]{
+			Status:  "success",
+			Message: "target_space_reported",
+			Data:    report,
+		})
+	}
+}
+
+func ReattachBackupTarget(zS *zelta.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil || id64 == 0 {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_target_id",
+				Error:   "invalid_target_id",
+				Data:    nil,
+			})
+			return
+		}
+
+		var req clusterServiceInterfaces.BackupTargetReattachReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Minute)
+		defer cancel()
+
+		result, err := zS.ReattachBackupTarget(ctx, uint(id64), req)
+		if err != nil {
+			c.JSON(http.StatusBadGateway, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "backup_target_reattach_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		message := "backup_target_reattached"
+		if req.DryRun {
+			message = "backup_target_reattach_planned"
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*zelta.BackupTargetReattachResult]{
+			Status:  "success",
+			Message: message,
+			Data:    result,
+		})
+	}
+}
+
+func ProbeBackupTargetSSH(zS *zelta.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil || id64 == 0 {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_target_id",
+				Error:   "invalid_target_id",
+				Data:    nil,
+			})
+			return
+		}
+
+		var req struct {
+			SizeMiB int `json:"sizeMiB"`
+		}
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+					Status:  "error",
+					Message: "invalid_request",
+					Error:   err.Error(),
+					Data:    nil,
+				})
+				return
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Minute)
+		defer cancel()
+
+		report, err := zS.ProbeBackupTargetSSH(ctx, uint(id64), req.SizeMiB)
+		if err != nil {
+			status := http.StatusBadGateway
+			if err.Error() == "ssh_probe_size_invalid" {
+				status = http.StatusBadRequest
+			}
+			c.JSON(status, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "backup_target_ssh_probe_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*zelta.SSHProbeReport]{
+			Status:  "success",
+			Message: "backup_target_ssh_probed",
+			Data:    report,
+		})
+	}
+}
+
+func CleanupBackupTargetLineages(zS *zelta.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil || id64 == 0 {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_target_id",
+				Error:   "invalid_target_id",
+				Data:    nil,
+			})
+			return
+		}
+
+		var req struct {
+			Datasets []string `json:"datasets" binding:"required,min=1"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Minute)
+		defer cancel()
+
+		result, err := zS.CleanupTargetLineages(ctx, uint(id64), req.Datasets)
+		if err != nil {
+			c.JSON(http.StatusBadGateway, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "target_lineage_cleanup_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*zelta.BackupTargetCleanupResult]{
+			Status:  "success",
+			Message: "target_lineages_cleaned",
+			Data:    result,
+		})
+	}
+}
+
 func BackupTargetDatasetSnapshots(zS *zelta.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
@@ -557,6 +745,7 @@ func RestoreBackupTargetDataset(cS *cluster.Service, zS *zelta.Service) gin.Hand
 			RestoreNetwork      *bool  `json:"restoreNetwork"`
 			EncryptionKey       string `json:"encryptionKey"`
 			EncryptionKeyFormat string `json:"encryptionKeyFormat"`
+			PostRestoreScript   string `json:"postRestoreScript"`
 		}
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
@@ -619,6 +808,7 @@ func RestoreBackupTargetDataset(cS *cluster.Service, zS *zelta.Service) gin.Hand
 				"restoreNetwork":      restoreNetwork,
 				"encryptionKey":       req.EncryptionKey,
 				"encryptionKeyFormat": req.EncryptionKeyFormat,
+				"postRestoreScript":   req.PostRestoreScript,
 			})
 			if err != nil {
 				if hasForwardedRestoreResponse(body, statusCode) {
@@ -655,6 +845,7 @@ func RestoreBackupTargetDataset(cS *cluster.Service, zS *zelta.Service) gin.Hand
 			req.Snapshot,
 			req.DestinationDataset,
 			restoreNetwork,
+			req.PostRestoreScript,
 		); err != nil {
 			status, msg := restoreFromTargetEnqueueError(err)
 			c.JSON(status, internal.APIResponse[any]{
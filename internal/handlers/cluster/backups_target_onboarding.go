@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterHandlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/services/cluster"
+	"github.com/alchemillahq/sylve/internal/services/zelta"
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/raft"
+)
+
+// Onboarding state lives in memory on one node, so every step runs on the
+// leader, which is also the node that has to propose the final create.
+
+func onboardingErrorStatus(err error) int {
+	if errors.Is(err, zelta.ErrBackupTargetOnboardingNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
+
+func StartBackupTargetOnboarding(cS *cluster.Service, zS *zelta.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		var req clusterServiceInterfaces.BackupTargetOnboardingReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		onboarding, err := zS.StartBackupTargetOnboarding(req)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "backup_target_onboarding_start_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusCreated, internal.APIResponse[*zelta.BackupTargetOnboarding]{
+			Status:  "success",
+			Message: "backup_target_onboarding_started",
+			Data:    onboarding,
+		})
+	}
+}
+
+func GetBackupTargetOnboarding(cS *cluster.Service, zS *zelta.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		onboarding, err := zS.GetBackupTargetOnboarding(c.Param("id"))
+		if err != nil {
+			c.JSON(onboardingErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "backup_target_onboarding_get_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*zelta.BackupTargetOnboarding]{
+			Status:  "success",
+			Message: "backup_target_onboarding_fetched",
+			Data:    onboarding,
+		})
+	}
+}
+
+// TestBackupTargetOnboarding runs the connection checks. A failed check is
+// still a 200; the steps say which one failed and why.
+func TestBackupTargetOnboarding(cS *cluster.Service, zS *zelta.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 60*time.Second)
+		defer cancel()
+
+		onboarding, err := zS.TestBackupTargetOnboarding(ctx, c.Param("id"))
+		if err != nil {
+			c.JSON(onboardingErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "backup_target_onboarding_test_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*zelta.BackupTargetOnboarding]{
+			Status:  "success",
+			Message: "backup_target_onboarding_tested",
+			Data:    onboarding,
+		})
+	}
+}
+
+func FinishBackupTargetOnboarding(cS *cluster.Service, zS *zelta.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		var req clusterServiceInterfaces.BackupTargetOnboardingFinishReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		id := c.Param("id")
+		targetReq, err := zS.BackupTargetOnboardingRequest(id, req)
+		if err != nil {
+			c.JSON(onboardingErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "backup_target_onboarding_finish_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := cS.ProposeBackupTargetCreate(targetReq, cS.Raft == nil); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "backup_target_create_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		zS.CancelBackupTargetOnboarding(id)
+
+		c.JSON(http.StatusCreated, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "backup_target_created",
+			Data:    nil,
+		})
+	}
+}
+
+func CancelBackupTargetOnboarding(cS *cluster.Service, zS *zelta.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		zS.CancelBackupTargetOnboarding(c.Param("id"))
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "backup_target_onboarding_cancelled",
+			Data:    nil,
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterHandlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/alchemillahq/sylve/internal"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/services/zelta"
+	"github.com/gin-gonic/gin"
+)
+
+type backupTenantZelta interface {
+	CreateBackupTenant(ctx context.Context, req clusterServiceInterfaces.BackupTenantReq) (*clusterModels.BackupTenant, error)
+	ListBackupTenants(ctx context.Context) ([]zelta.BackupTenantStatus, error)
+	SetBackupTenantQuota(ctx context.Context, id uint, quotaBytes uint64) error
+	SetBackupTenantNamespace(ctx context.Context, id uint, namespace string) error
+	DeleteBackupTenant(ctx context.Context, id uint, destroyData bool) error
+}
+
+func parseBackupTenantID(c *gin.Context) (uint, bool) {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id64 == 0 {
+		c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+			Status:  "error",
+			Message: "invalid_tenant_id",
+			Error:   "invalid_tenant_id",
+			Data:    nil,
+		})
+		return 0, false
+	}
+	return uint(id64), true
+}
+
+func BackupTenants(zS backupTenantZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenants, err := zS.ListBackupTenants(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "list_backup_tenants_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]zelta.BackupTenantStatus]{
+			Status:  "success",
+			Message: "backup_tenants_listed",
+			Data:    tenants,
+		})
+	}
+}
+
+func CreateBackupTenant(zS backupTenantZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req clusterServiceInterfaces.BackupTenantReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		tenant, err := zS.CreateBackupTenant(c.Request.Context(), req)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "backup_tenant_create_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusCreated, internal.APIResponse[*clusterModels.BackupTenant]{
+			Status:  "success",
+			Message: "backup_tenant_created",
+			Data:    tenant,
+		})
+	}
+}
+
+func SetBackupTenantQuota(zS backupTenantZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseBackupTenantID(c)
+		if !ok {
+			return
+		}
+
+		var req clusterServiceInterfaces.BackupTenantQuotaReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := zS.SetBackupTenantQuota(c.Request.Context(), id, req.QuotaBytes); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "backup_tenant_quota_update_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "backup_tenant_quota_updated",
+			Data:    nil,
+		})
+	}
+}
+
+func SetBackupTenantNamespace(zS backupTenantZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseBackupTenantID(c)
+		if !ok {
+			return
+		}
+
+		var req clusterServiceInterfaces.BackupTenantNamespaceReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := zS.SetBackupTenantNamespace(c.Request.Context(), id, req.Namespace); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "backup_tenant_namespace_update_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "backup_tenant_namespace_updated",
+			Data:    nil,
+		})
+	}
+}
+
+func DeleteBackupTenant(zS backupTenantZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseBackupTenantID(c)
+		if !ok {
+			return
+		}
+
+		destroyData := c.Query("destroyData") == "true"
+		if err := zS.DeleteBackupTenant(c.Request.Context(), id, destroyData); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "backup_tenant_delete_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "backup_tenant_deleted",
+			Data:    nil,
+		})
+	}
+}
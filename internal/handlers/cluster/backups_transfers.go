@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterHandlers
+
+import (
+	"net/http"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/services/zelta"
+	"github.com/gin-gonic/gin"
+)
+
+type activeTransfersZelta interface {
+	ActiveTransfers() []zelta.ActiveTransfer
+}
+
+func ActiveTransfers(zS activeTransfersZelta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, internal.APIResponse[[]zelta.ActiveTransfer]{
+			Status:  "success",
+			Message: "active_transfers_fetched",
+			Data:    zS.ActiveTransfers(),
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterHandlers
+
+import (
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/services/cluster"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Simulate Guest Placement
+// @Description Place hypothetical guests onto the online cluster nodes and report whether they fit, per-node headroom and the first resource to run out
+// @Tags Cluster
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body cluster.CapacitySimulationRequest true "Guest profiles to place"
+// @Success 200 {object} internal.APIResponse[cluster.CapacitySimulation] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /cluster/capacity/simulate [post]
+func SimulateCapacity(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req cluster.CapacitySimulationRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(400, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		sim, err := cS.SimulateCapacity(req)
+		if err != nil {
+			status := 500
+			message := "capacity_simulation_failed"
+			if strings.HasPrefix(err.Error(), "invalid_capacity_profile") ||
+				strings.HasPrefix(err.Error(), "too_many_simulated_guests") {
+				status, message = 400, "invalid_request"
+			}
+			c.JSON(status, internal.APIResponse[any]{
+				Status:  "error",
+				Message: message,
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(200, internal.APIResponse[*cluster.CapacitySimulation]{
+			Status:  "success",
+			Message: "capacity_simulated",
+			Error:   "",
+			Data:    sim,
+		})
+	}
+}
@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterHandlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/services/cluster"
+	"github.com/alchemillahq/sylve/internal/services/lifecycle"
+	"github.com/alchemillahq/sylve/internal/services/zelta"
+	"github.com/gin-gonic/gin"
+)
+
+type cloneImportVMRequest struct {
+	GuestID            uint     `json:"guestId"`
+	OperationToken     string   `json:"operationToken"`
+	SourceDatasetRoots []string `json:"sourceDatasetRoots"`
+	WithNetwork        bool     `json:"withNetwork"`
+}
+
+// @Summary Clone VM To Node
+// @Description Copy a local virtual machine to another cluster node under a new RID. The source VM is left untouched; the copy runs as a lifecycle task.
+// @Tags Cluster
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rid path int true "Virtual Machine ID"
+// @Param request body clusterServiceInterfaces.CloneGuestRequest true "Clone request"
+// @Success 202 {object} internal.APIResponse[any] "Accepted"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 409 {object} internal.APIResponse[any] "Conflict"
+// @Router /cluster/vm/{rid}/clone [post]
+func CloneVM(cS *cluster.Service, lifecycleService *lifecycle.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rid, err := strconv.ParseUint(c.Param("rid"), 10, 0)
+		if err != nil || rid == 0 {
+			c.JSON(400, internal.APIResponse[any]{Status: "error", Message: "invalid_rid_format", Error: "Virtual Machine ID must be a valid integer"})
+			return
+		}
+
+		var req clusterServiceInterfaces.CloneGuestRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(400, internal.APIResponse[any]{Status: "error", Message: "invalid_request_body", Error: err.Error()})
+			return
+		}
+		req.TargetNodeID = strings.TrimSpace(req.TargetNodeID)
+		if req.TargetNodeID == cS.LocalNodeID() {
+			c.JSON(400, internal.APIResponse[any]{Status: "error", Message: "clone_target_must_be_another_node", Error: "clone_target_must_be_another_node"})
+			return
+		}
+		if err := cS.RequireGuestIDAvailable(c.Request.Context(), req.CloneID); err != nil {
+			c.JSON(http.StatusConflict, internal.APIResponse[any]{Status: "error", Message: "clone_id_unavailable", Error: err.Error()})
+			return
+		}
+
+		payload, err := json.Marshal(req)
+		if err != nil {
+			c.JSON(500, internal.APIResponse[any]{Status: "error", Message: "invalid_request_body", Error: err.Error()})
+			return
+		}
+
+		task, outcome, err := lifecycleService.RequestActionWithPayload(
+			c.Request.Context(),
+			taskModels.GuestTypeVM,
+			uint(rid),
+			"clone",
+			taskModels.LifecycleTaskSourceUser,
+			strings.TrimSpace(c.GetString("Username")),
+			string(payload),
+		)
+		if err != nil {
+			c.JSON(http.StatusConflict, internal.APIResponse[any]{Status: "error", Message: "clone_request_failed", Error: err.Error()})
+			return
+		}
+
+		c.Set("AuditAsyncJobID", task.ID)
+		c.Set("AuditAsyncJobType", "vm_clone")
+
+		c.JSON(http.StatusAccepted, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "vm_clone_queued",
+			Data:    map[string]any{"taskId": task.ID, "guestId": task.GuestID, "cloneId": req.CloneID, "outcome": outcome},
+		})
+	}
+}
+
+func CloneImportVMInternal(zS *zelta.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req cloneImportVMRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error()})
+			return
+		}
+		if req.GuestID == 0 || strings.TrimSpace(req.OperationToken) == "" || len(req.SourceDatasetRoots) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "guest_id_operation_token_and_dataset_roots_required"})
+			return
+		}
+		if zS == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"status": "error", "message": "zelta_not_configured"})
+			return
+		}
+
+		if err := zS.RequireCloneTargetReservation(c.Request.Context(), req.GuestID, req.OperationToken); err != nil {
+			c.JSON(http.StatusConflict, gin.H{"status": "error", "message": "clone_reservation_rejected", "error": err.Error()})
+			return
+		}
+
+		warnings, err := zS.ImportClonedVMWithRoots(c.Request.Context(), req.GuestID, req.SourceDatasetRoots, req.WithNetwork)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "clone_import_failed", "error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"status": "success", "message": "vm_clone_imported", "warnings": warnings})
+	}
+}
@@ -14,10 +14,12 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/alchemillahq/sylve/internal"
 	"github.com/alchemillahq/sylve/internal/cmd"
 	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/internal/services/auth"
 	"github.com/alchemillahq/sylve/internal/services/cluster"
 	"github.com/alchemillahq/sylve/internal/services/zelta"
@@ -30,10 +32,12 @@ type CreateClusterRequest struct {
 	IP string `json:"ip" binding:"required,ip"`
 }
 
+// JoinClusterRequest.LeaderIP may also be a hostname, resolved with
+// cluster.ResolveClusterHost.
 type JoinClusterRequest struct {
 	NodeID     string `json:"nodeId" binding:"required"`
 	NodeIP     string `json:"nodeIp" binding:"required,ip"`
-	LeaderIP   string `json:"leaderIp" binding:"required,ip"`
+	LeaderIP   string `json:"leaderIp" binding:"required"`
 	ClusterKey string `json:"clusterKey" binding:"required"`
 }
 
@@ -56,20 +60,37 @@ func joinLeaderAPIHost(leaderIP string) string {
 
 type basicHealthData struct {
 	SylveVersion string `json:"sylveVersion"`
+	Time         int64  `json:"time"`
 }
 
-func fetchNodeVersionFromHealth(healthURL string, payload any, headers map[string]string) (string, error) {
+// fetchBasicHealth returns the node's version and, when it reports its
+// clock, how far that clock is ahead of ours.
+func fetchBasicHealth(healthURL string, payload any, headers map[string]string) (string, *time.Duration, error) {
+	sentAt := time.Now()
 	body, _, err := utils.HTTPPostJSONRead(healthURL, payload, headers)
+	receivedAt := time.Now()
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	var healthResp internal.APIResponse[basicHealthData]
 	if err := json.Unmarshal(body, &healthResp); err != nil {
-		return "", fmt.Errorf("decode_health_response_failed: %w", err)
+		return "", nil, fmt.Errorf("decode_health_response_failed: %w", err)
+	}
+
+	var offset *time.Duration
+	if healthResp.Data.Time > 0 {
+		remote := time.UnixMilli(healthResp.Data.Time)
+		measured := cluster.ClockOffset(sentAt, remote, remote, receivedAt)
+		offset = &measured
 	}
 
-	return strings.TrimSpace(healthResp.Data.SylveVersion), nil
+	return strings.TrimSpace(healthResp.Data.SylveVersion), offset, nil
+}
+
+func fetchNodeVersionFromHealth(healthURL string, payload any, headers map[string]string) (string, error) {
+	version, _, err := fetchBasicHealth(healthURL, payload, headers)
+	return version, err
 }
 
 func postJoinAdmission(
@@ -218,7 +239,7 @@ func CreateCluster(as *auth.Service, cS *cluster.Service, fsm raft.FSM) gin.Hand
 		username := c.GetString("Username")
 		authType := c.GetString("AuthType")
 
-		clusterToken, err := as.CreateClusterJWT(userId, username, authType, details.Cluster.Key)
+		clusterToken, err := as.CreateClusterJWT(userId, username, authType, string(details.Cluster.Key))
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
 				Status:  "error",
@@ -262,7 +283,8 @@ func JoinCluster(aS *auth.Service, cS *cluster.Service, zS *zelta.Service, fsm r
 			return
 		}
 
-		if !utils.IsValidIP(req.LeaderIP) {
+		leaderIP, err := cluster.ResolveClusterHost(req.LeaderIP)
+		if err != nil {
 			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
 				Status:  "error",
 				Message: "invalid_leader_ip",
@@ -271,6 +293,19 @@ func JoinCluster(aS *auth.Service, cS *cluster.Service, zS *zelta.Service, fsm r
 			})
 			return
 		}
+		req.LeaderIP = leaderIP
+
+		nodeIP, err := cluster.NormalizeClusterIP(req.NodeIP)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_node_ip",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+		req.NodeIP = nodeIP
 
 		leaderAPIHost := joinLeaderAPIHost(req.LeaderIP)
 
@@ -296,7 +331,7 @@ func JoinCluster(aS *auth.Service, cS *cluster.Service, zS *zelta.Service, fsm r
 			leaderAPIHost,
 		)
 
-		leaderVersion, err := fetchNodeVersionFromHealth(healthURL, req, headers)
+		leaderVersion, leaderClockOffset, err := fetchBasicHealth(healthURL, req, headers)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
 				Status:  "error",
@@ -328,6 +363,26 @@ func JoinCluster(aS *auth.Service, cS *cluster.Service, zS *zelta.Service, fsm r
 			return
 		}
 
+		if leaderClockOffset != nil {
+			if status := cluster.ClockSkewStatus(*leaderClockOffset); status != cluster.ClockStatusOK {
+				logger.L.Warn().
+					Str("leader", req.LeaderIP).
+					Int64("clock_skew_ms", leaderClockOffset.Milliseconds()).
+					Str("clock_status", status).
+					Msg("joining a cluster whose leader's clock differs from ours")
+
+				if status == cluster.ClockStatusCritical && cluster.RefuseJoinOnClockSkew() {
+					c.JSON(http.StatusConflict, internal.APIResponse[any]{
+						Status:  "error",
+						Message: "cluster_clock_skew",
+						Error:   fmt.Sprintf("leader_clock_offset_ms=%d", leaderClockOffset.Milliseconds()),
+						Data:    nil,
+					})
+					return
+				}
+			}
+		}
+
 		localNodeID := strings.TrimSpace(cS.LocalNodeID())
 		if localNodeID == "" || localNodeID != strings.TrimSpace(req.NodeID) {
 			c.JSON(http.StatusConflict, internal.APIResponse[any]{
@@ -473,6 +528,18 @@ func AcceptJoin(cS *cluster.Service) gin.HandlerFunc {
 			return
 		}
 
+		nodeIP, err := cluster.NormalizeClusterIP(req.NodeIP)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_node_ip",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+		req.NodeIP = nodeIP
+
 		localVersion := strings.TrimSpace(cmd.Version)
 		nodeVersion := strings.TrimSpace(req.NodeVersion)
 		if localVersion == "" || nodeVersion == "" || nodeVersion != localVersion {
@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterHandlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/services/cluster"
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/raft"
+)
+
+func clusterTLSErrorStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.HasPrefix(msg, "cluster_ca_not_configured"):
+		return http.StatusNotFound
+	case strings.HasPrefix(msg, "cluster_ca_rotation_in_progress"),
+		strings.HasPrefix(msg, "cluster_tls_nodes_not_ready"):
+		return http.StatusConflict
+	case strings.HasPrefix(msg, "invalid_csr"),
+		strings.HasPrefix(msg, "csr_node_mismatch"),
+		strings.HasPrefix(msg, "node_not_cluster_member"):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func ClusterTLSStatus(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		status, err := cS.ClusterTLSStatus()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "get_cluster_tls_status_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*cluster.ClusterTLSStatus]{
+			Status:  "success",
+			Message: "cluster_tls_status",
+			Data:    status,
+		})
+	}
+}
+
+func RotateClusterCA(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		status, err := cS.RotateClusterCA()
+		if err != nil {
+			c.JSON(clusterTLSErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "rotate_cluster_ca_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*cluster.ClusterTLSStatus]{
+			Status:  "success",
+			Message: "cluster_ca_rotated",
+			Data:    status,
+		})
+	}
+}
+
+func SetClusterTLSEnforce(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		var req clusterServiceInterfaces.ClusterTLSEnforceReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		status, err := cS.SetClusterTLSEnforce(*req.Enforce)
+		if err != nil {
+			c.JSON(clusterTLSErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "set_cluster_tls_enforce_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*cluster.ClusterTLSStatus]{
+			Status:  "success",
+			Message: "cluster_tls_enforce_updated",
+			Data:    status,
+		})
+	}
+}
+
+func SignClusterNodeCertificateInternal(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		var req clusterServiceInterfaces.ClusterTLSSignReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		cert, err := cS.IssueNodeCertificate(req)
+		if err != nil {
+			c.JSON(clusterTLSErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "sign_cluster_node_certificate_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*clusterModels.ClusterNodeCertificate]{
+			Status:  "success",
+			Message: "cluster_node_certificate_issued",
+			Data:    cert,
+		})
+	}
+}
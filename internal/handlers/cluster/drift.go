@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterHandlers
+
+import (
+	"net/http"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/services/cluster"
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/raft"
+)
+
+// NodeConfigInternal returns the configuration this node contributes to the
+// cluster drift check. Routing places it behind the internal-cluster JWT
+// middleware.
+func NodeConfigInternal(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, internal.APIResponse[cluster.NodeConfigSnapshot]{
+			Status:  "success",
+			Message: "node_config_collected",
+			Error:   "",
+			Data:    cS.LocalNodeConfig(),
+		})
+	}
+}
+
+// @Summary Cluster Config Drift
+// @Description Compare sysctl, loader.conf module, zelta and OpenSSH settings across cluster nodes and list where each node differs from the cluster baseline
+// @Tags Cluster
+// @Produce json
+// @Security BearerAuth
+// @Param refresh query bool false "Run a new check instead of returning the last one"
+// @Success 200 {object} internal.APIResponse[cluster.ConfigDriftReport] "Success"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /cluster/drift [get]
+func ConfigDrift(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		report, err := cS.ConfigDrift(c.Request.Context(), c.Query("refresh") == "true")
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "config_drift_check_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*cluster.ConfigDriftReport]{
+			Status:  "success",
+			Message: "config_drift_checked",
+			Error:   "",
+			Data:    report,
+		})
+	}
+}
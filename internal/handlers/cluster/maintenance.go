@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterHandlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/services/cluster"
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/raft"
+	"gorm.io/gorm"
+)
+
+func maintenanceWindowErrorStatus(err error) int {
+	message := err.Error()
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case strings.HasPrefix(message, "maintenance_"):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func maintenanceWindowID(c *gin.Context) (uint, bool) {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id64 == 0 {
+		c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+			Status:  "error",
+			Message: "invalid_maintenance_window_id",
+			Error:   "invalid_maintenance_window_id",
+			Data:    nil,
+		})
+		return 0, false
+	}
+	return uint(id64), true
+}
+
+func MaintenanceWindows(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		windows, err := cS.ListMaintenanceWindows(100)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "list_maintenance_windows_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]clusterModels.MaintenanceWindow]{
+			Status:  "success",
+			Message: "maintenance_windows_listed",
+			Data:    windows,
+		})
+	}
+}
+
+func CreateMaintenanceWindow(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		var req clusterServiceInterfaces.MaintenanceWindowReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := cS.ProposeMaintenanceWindowCreate(req, c.GetString("Username"), cS.Raft == nil); err != nil {
+			c.JSON(maintenanceWindowErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "create_maintenance_window_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "maintenance_window_created",
+			Data:    nil,
+		})
+	}
+}
+
+func EndMaintenanceWindow(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		id, ok := maintenanceWindowID(c)
+		if !ok {
+			return
+		}
+
+		if err := cS.ProposeMaintenanceWindowEnd(id, cS.Raft == nil); err != nil {
+			c.JSON(maintenanceWindowErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "end_maintenance_window_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "maintenance_window_ended",
+			Data:    nil,
+		})
+	}
+}
+
+func DeleteMaintenanceWindow(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		id, ok := maintenanceWindowID(c)
+		if !ok {
+			return
+		}
+
+		if err := cS.ProposeMaintenanceWindowDelete(id, cS.Raft == nil); err != nil {
+			c.JSON(maintenanceWindowErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "delete_maintenance_window_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "maintenance_window_deleted",
+			Data:    nil,
+		})
+	}
+}
+
+func MaintenanceSuppressions(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var windowID uint64
+		if raw := strings.TrimSpace(c.Query("windowId")); raw != "" {
+			var err error
+			windowID, err = strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+					Status:  "error",
+					Message: "invalid_maintenance_window_id",
+					Error:   "invalid_maintenance_window_id",
+					Data:    nil,
+				})
+				return
+			}
+		}
+
+		suppressions, err := cS.ListMaintenanceSuppressions(uint(windowID), 200)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "list_maintenance_suppressions_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]clusterModels.MaintenanceSuppression]{
+			Status:  "success",
+			Message: "maintenance_suppressions_listed",
+			Data:    suppressions,
+		})
+	}
+}
@@ -337,6 +337,9 @@ func RunReplicationPolicyNow(cS *cluster.Service, zS *zelta.Service) gin.Handler
 			if strings.Contains(err.Error(), "already_running") {
 				status = http.StatusConflict
 				msg = "replication_policy_already_running"
+			} else if strings.Contains(err.Error(), "replication_policy_paused") {
+				status = http.StatusConflict
+				msg = "replication_policy_paused"
 			}
 			c.JSON(status, internal.APIResponse[any]{
 				Status:  "error",
@@ -358,6 +361,103 @@ func RunReplicationPolicyNow(cS *cluster.Service, zS *zelta.Service) gin.Handler
 	}
 }
 
+func PauseReplicationPolicy(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil || id64 == 0 {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_policy_id",
+				Error:   "invalid_policy_id",
+				Data:    nil,
+			})
+			return
+		}
+
+		var req clusterServiceInterfaces.ReplicationPolicyPauseReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := cS.ProposeReplicationPolicyPause(uint(id64), true, req.Reason, cS.Raft == nil); err != nil {
+			c.JSON(replicationPauseErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "pause_replication_policy_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "replication_policy_paused",
+			Data:    nil,
+		})
+	}
+}
+
+func ResumeReplicationPolicy(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil || id64 == 0 {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_policy_id",
+				Error:   "invalid_policy_id",
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := cS.ProposeReplicationPolicyPause(uint(id64), false, "", cS.Raft == nil); err != nil {
+			c.JSON(replicationPauseErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "resume_replication_policy_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "replication_policy_resumed",
+			Data:    nil,
+		})
+	}
+}
+
+func replicationPauseErrorStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.HasPrefix(msg, "replication_policy_not_found"):
+		return http.StatusNotFound
+	case strings.Contains(msg, "transition_in_progress"),
+		strings.Contains(msg, "already_paused"),
+		strings.Contains(msg, "not_paused"):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
 func FailoverReplicationPolicy(cS *cluster.Service, zS *zelta.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
@@ -458,6 +558,79 @@ func FailoverReplicationPolicy(cS *cluster.Service, zS *zelta.Service) gin.Handl
 	}
 }
 
+func SwitchoverReplicationPolicy(cS *cluster.Service, zS *zelta.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil || id64 == 0 {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_policy_id",
+				Error:   "invalid_policy_id",
+				Data:    nil,
+			})
+			return
+		}
+		if zS == nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "replication_service_unavailable",
+				Error:   "replication_service_unavailable",
+				Data:    nil,
+			})
+			return
+		}
+
+		var req struct {
+			TargetNodeID string `json:"targetNodeId" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := zS.EnqueueReplicationPolicySwitchover(uint(id64), req.TargetNodeID); err != nil {
+			statusCode := http.StatusBadRequest
+			message := "switchover_replication_policy_failed"
+			lowerErr := strings.ToLower(err.Error())
+			if strings.Contains(lowerErr, "transition_already_running") {
+				statusCode = http.StatusConflict
+				message = "replication_policy_transition_already_running"
+			} else if strings.Contains(lowerErr, "not_leader") {
+				statusCode = http.StatusConflict
+				message = "not_leader"
+			}
+			c.JSON(statusCode, internal.APIResponse[any]{
+				Status:  "error",
+				Message: message,
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		// Switchover finalizes through the failover transition, so it shares
+		// the failover audit record type.
+		c.Set("AuditAsyncJobID", uint(id64))
+		c.Set("AuditAsyncJobType", "replication_policy_failover")
+
+		c.JSON(http.StatusAccepted, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "replication_policy_switchover_queued",
+			Data:    nil,
+		})
+	}
+}
+
 func forwardReplicationRunToNode(c *gin.Context, cS *cluster.Service, policyID uint, nodeID string) ([]byte, int, error) {
 	targetAPI, err := resolveClusterNodeAPI(cS, nodeID)
 	if err != nil {
@@ -609,6 +782,115 @@ func ReplicationEventByID(cS *cluster.Service) gin.HandlerFunc {
 	}
 }
 
+func ReplicationEventTimelineByID(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil || id64 == 0 {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_event_id",
+				Error:   "invalid_event_id",
+				Data:    nil,
+			})
+			return
+		}
+
+		requestedNodeID := strings.TrimSpace(c.Query("nodeId"))
+		if shouldForwardReplicationEventsRequest(cS, requestedNodeID) {
+			path := fmt.Sprintf("/api/cluster/replication/events/%d/timeline", id64)
+			body, statusCode, err := forwardReplicationEventsRequestToNode(c, cS, requestedNodeID, path)
+			if err != nil {
+				c.JSON(http.StatusBadGateway, internal.APIResponse[any]{
+					Status:  "error",
+					Message: "replication_event_remote_forward_failed",
+					Error:   err.Error(),
+					Data:    nil,
+				})
+				return
+			}
+
+			c.Data(statusCode, "application/json", body)
+			return
+		}
+
+		timeline, err := cS.GetReplicationEventTimeline(uint(id64))
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				c.JSON(http.StatusNotFound, internal.APIResponse[any]{
+					Status:  "error",
+					Message: "replication_event_not_found",
+					Error:   "replication_event_not_found",
+					Data:    nil,
+				})
+				return
+			}
+
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "get_replication_event_timeline_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*cluster.ReplicationEventTimeline]{
+			Status:  "success",
+			Message: "replication_event_timeline_fetched",
+			Data:    timeline,
+		})
+	}
+}
+
+func ReplicationPolicyTimeline(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil || id64 == 0 {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_policy_id",
+				Error:   "invalid_policy_id",
+				Data:    nil,
+			})
+			return
+		}
+
+		limit := 200
+		if q := c.Query("limit"); q != "" {
+			if parsed, err := strconv.Atoi(q); err == nil {
+				limit = parsed
+			}
+		}
+
+		timeline, err := cS.GetReplicationPolicyTimeline(uint(id64), limit)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				c.JSON(http.StatusNotFound, internal.APIResponse[any]{
+					Status:  "error",
+					Message: "replication_policy_not_found",
+					Error:   "replication_policy_not_found",
+					Data:    nil,
+				})
+				return
+			}
+
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "get_replication_policy_timeline_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*cluster.ReplicationPolicyTimeline]{
+			Status:  "success",
+			Message: "replication_policy_timeline_fetched",
+			Data:    timeline,
+		})
+	}
+}
+
 func ReplicationEventProgressByID(cS *cluster.Service, zS *zelta.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
@@ -1440,3 +1722,106 @@ func forwardReplicationEventsRequestToNode(c *gin.Context, cS *cluster.Service,
 
 	return body, statusCode, nil
 }
+
+// SetReplicationSSHUser switches the account that replication sessions into
+// this node run as. It is node-local, but the new user is published through
+// raft so that peers connect with it.
+func SetReplicationSSHUser(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			User string `json:"user"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := cS.SetReplicationSSHUser(req.User); err != nil {
+			status := http.StatusInternalServerError
+			if strings.HasPrefix(err.Error(), "replication_ssh_user_not_found") ||
+				err.Error() == "cluster_not_enabled" {
+				status = http.StatusBadRequest
+			}
+			c.JSON(status, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "set_replication_ssh_user_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "replication_ssh_user_updated",
+			Data:    nil,
+		})
+	}
+}
+
+func ReplicationNetwork(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		network, err := cS.GetReplicationNetwork()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "get_replication_network_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[cluster.ReplicationNetwork]{
+			Status:  "success",
+			Message: "replication_network_fetched",
+			Data:    network,
+		})
+	}
+}
+
+// SetReplicationNetwork tags one local address as this node's dedicated
+// replication and backup network. An empty ip clears it.
+func SetReplicationNetwork(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			IP string `json:"ip"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := cS.SetReplicationNetwork(req.IP); err != nil {
+			status := http.StatusInternalServerError
+			msg := err.Error()
+			if msg == "invalid_replication_ip" || msg == "cluster_not_enabled" ||
+				strings.HasPrefix(msg, "replication_ip_not_assigned_locally") {
+				status = http.StatusBadRequest
+			}
+			c.JSON(status, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "set_replication_network_failed",
+				Error:   msg,
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "replication_network_updated",
+			Data:    nil,
+		})
+	}
+}
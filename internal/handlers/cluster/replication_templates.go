@@ -0,0 +1,205 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterHandlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/services/cluster"
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/raft"
+	"gorm.io/gorm"
+)
+
+func replicationTemplateErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "replication_template_"):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func replicationTemplateID(c *gin.Context) (uint, bool) {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id64 == 0 {
+		c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+			Status:  "error",
+			Message: "invalid_replication_template_id",
+			Error:   "invalid_replication_template_id",
+			Data:    nil,
+		})
+		return 0, false
+	}
+	return uint(id64), true
+}
+
+func ReplicationPolicyTemplates(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		templates, err := cS.ListReplicationPolicyTemplates()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "list_replication_templates_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]clusterModels.ReplicationPolicyTemplate]{
+			Status:  "success",
+			Message: "replication_templates_listed",
+			Data:    templates,
+		})
+	}
+}
+
+func CreateReplicationPolicyTemplate(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		var req clusterServiceInterfaces.ReplicationPolicyTemplateReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := cS.ProposeReplicationPolicyTemplateCreate(req, cS.Raft == nil); err != nil {
+			c.JSON(replicationTemplateErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "create_replication_template_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusCreated, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "replication_template_created",
+			Data:    nil,
+		})
+	}
+}
+
+func UpdateReplicationPolicyTemplate(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		id, ok := replicationTemplateID(c)
+		if !ok {
+			return
+		}
+
+		var req clusterServiceInterfaces.ReplicationPolicyTemplateReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := cS.ProposeReplicationPolicyTemplateUpdate(id, req, cS.Raft == nil); err != nil {
+			c.JSON(replicationTemplateErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "update_replication_template_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "replication_template_updated",
+			Data:    nil,
+		})
+	}
+}
+
+func DeleteReplicationPolicyTemplate(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		id, ok := replicationTemplateID(c)
+		if !ok {
+			return
+		}
+
+		if err := cS.ProposeReplicationPolicyTemplateDelete(id, cS.Raft == nil); err != nil {
+			c.JSON(replicationTemplateErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "delete_replication_template_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "replication_template_deleted",
+			Data:    nil,
+		})
+	}
+}
+
+// EnrollReplicationPolicyTemplates runs template enrollment right away
+// instead of waiting for the leader's next periodic pass.
+func EnrollReplicationPolicyTemplates(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		results, err := cS.EnrollReplicationTemplates()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "enroll_replication_templates_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]clusterServiceInterfaces.ReplicationTemplateEnrollment]{
+			Status:  "success",
+			Message: "replication_templates_enrolled",
+			Data:    results,
+		})
+	}
+}
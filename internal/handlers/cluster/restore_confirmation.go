@@ -0,0 +1,311 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterHandlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/db/models"
+	"github.com/alchemillahq/sylve/internal/services/cluster"
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/raft"
+)
+
+const (
+	restoreOverwriteTokenScope = "restore_overwrite"
+	restoreOverwriteTokenTTL   = 300
+)
+
+// restoreConfirmation is carried by restore requests that may overwrite an
+// existing guest. Either the guest's name typed back or a token from
+// IssueRestoreConfirmation confirms the overwrite. Tokens are signed per
+// node, so a node forwarding the restore checks the token itself and passes
+// ReauthConfirmed on; the receiving node only trusts that flag on
+// cluster-token requests.
+type restoreConfirmation struct {
+	ConfirmGuestName string `json:"confirmGuestName,omitempty"`
+	ConfirmToken     string `json:"confirmToken,omitempty"`
+	ReauthConfirmed  bool   `json:"reauthConfirmed,omitempty"`
+}
+
+type RestoreOverwriteGuest struct {
+	GuestType string `json:"guestType"`
+	GuestID   uint   `json:"guestId"`
+	GuestName string `json:"guestName"`
+}
+
+type RestoreConfirmationTokenResponse struct {
+	Token     string `json:"token"`
+	ExpiresIn int64  `json:"expiresIn"`
+}
+
+type RestorePolicy struct {
+	RestoreOverwriteGroup string `json:"restoreOverwriteGroup"`
+}
+
+func (rc restoreConfirmation) reauthenticated(c *gin.Context, cS *cluster.Service) bool {
+	if rc.ReauthConfirmed && strings.TrimSpace(c.GetString("AuthScope")) == "cluster" {
+		return true
+	}
+
+	token := strings.TrimSpace(rc.ConfirmToken)
+	if token == "" || cS.AuthService == nil {
+		return false
+	}
+
+	claims, err := cS.AuthService.ValidateScopedJWT(token, restoreOverwriteTokenScope)
+	return err == nil && claims.UserID != 0 && claims.UserID == c.GetUint("UserID")
+}
+
+// forwarded returns the confirmation to send to the node that runs the
+// restore, swapping a locally issued token for ReauthConfirmed.
+func (rc restoreConfirmation) forwarded(c *gin.Context, cS *cluster.Service) restoreConfirmation {
+	return restoreConfirmation{
+		ConfirmGuestName: rc.ConfirmGuestName,
+		ReauthConfirmed:  rc.reauthenticated(c, cS),
+	}
+}
+
+// rewriteRequestBody replaces the already bound request body so that
+// forwardToLeader sends the forwarded form of the request.
+func rewriteRequestBody(c *gin.Context, body any) error {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(raw))
+	c.Request.ContentLength = int64(len(raw))
+	return nil
+}
+
+// requireRestoreOverwriteConfirmation guards a restore onto guestID on this
+// node. Nothing is asked for when no such guest is registered here;
+// otherwise the caller must be allowed by the restore policy and must have
+// confirmed the overwrite. It writes the error response and returns false
+// when the restore may not go ahead.
+func requireRestoreOverwriteConfirmation(c *gin.Context, cS *cluster.Service, guestType string, guestID uint, rc restoreConfirmation) bool {
+	if guestID == 0 {
+		return true
+	}
+
+	name, exists, err := cS.LocalGuestName(guestType, guestID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+			Status:  "error",
+			Message: "restore_precheck_failed",
+			Error:   err.Error(),
+			Data:    nil,
+		})
+		return false
+	}
+	if !exists {
+		return true
+	}
+
+	guest := RestoreOverwriteGuest{GuestType: guestType, GuestID: guestID, GuestName: name}
+
+	group, err := cS.RestoreOverwriteGroup()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+			Status:  "error",
+			Message: "restore_precheck_failed",
+			Error:   err.Error(),
+			Data:    nil,
+		})
+		return false
+	}
+	if group != "" && !userInGroup(cS, c.GetUint("UserID"), group) {
+		c.JSON(http.StatusForbidden, internal.APIResponse[RestoreOverwriteGuest]{
+			Status:  "error",
+			Message: "restore_overwrite_not_permitted",
+			Error:   "overwriting an existing guest is limited to members of group " + group,
+			Data:    guest,
+		})
+		return false
+	}
+
+	typed := strings.TrimSpace(rc.ConfirmGuestName)
+	if (typed != "" && typed == strings.TrimSpace(name)) || rc.reauthenticated(c, cS) {
+		return true
+	}
+
+	message := "restore_overwrite_confirmation_required"
+	if typed != "" || strings.TrimSpace(rc.ConfirmToken) != "" {
+		message = "restore_overwrite_confirmation_invalid"
+	}
+
+	c.JSON(http.StatusPreconditionRequired, internal.APIResponse[RestoreOverwriteGuest]{
+		Status:  "error",
+		Message: message,
+		Error:   "restore would overwrite existing " + guestType + " " + name + "; confirm with the guest name or re-authenticate",
+		Data:    guest,
+	})
+	return false
+}
+
+func userInGroup(cS *cluster.Service, userID uint, group string) bool {
+	if cS.AuthService == nil || userID == 0 {
+		return false
+	}
+
+	user, err := cS.AuthService.GetUserByID(userID)
+	if err != nil || user == nil {
+		return false
+	}
+
+	return slices.ContainsFunc(user.Groups, func(g models.Group) bool {
+		return g.Name == group
+	})
+}
+
+// IssueRestoreConfirmation re-authenticates the signed-in user and returns a
+// short-lived token that confirms one or more overwriting restores.
+func IssueRestoreConfirmation(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Password string `json:"password" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		userID := c.GetUint("UserID")
+		authType := strings.TrimSpace(c.GetString("AuthType"))
+		if err := cS.AuthService.VerifyUserPassword(userID, authType, req.Password); err != nil {
+			c.JSON(http.StatusUnauthorized, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "restore_reauth_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		token, err := cS.AuthService.CreateScopedJWT(
+			userID,
+			strings.TrimSpace(c.GetString("Username")),
+			authType,
+			restoreOverwriteTokenScope,
+			restoreOverwriteTokenTTL,
+		)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_create_restore_confirmation",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[RestoreConfirmationTokenResponse]{
+			Status:  "success",
+			Message: "restore_confirmation_created",
+			Data: RestoreConfirmationTokenResponse{
+				Token:     token,
+				ExpiresIn: restoreOverwriteTokenTTL,
+			},
+		})
+	}
+}
+
+func GetRestorePolicy(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		group, err := cS.RestoreOverwriteGroup()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_get_restore_policy",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[RestorePolicy]{
+			Status:  "success",
+			Message: "restore_policy",
+			Data:    RestorePolicy{RestoreOverwriteGroup: group},
+		})
+	}
+}
+
+// UpdateRestorePolicy sets the group allowed to overwrite existing guests
+// by restore. Group membership is checked on the node running the restore,
+// so the group has to exist there too.
+func UpdateRestorePolicy(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		var req RestorePolicy
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		group := strings.TrimSpace(req.RestoreOverwriteGroup)
+		if group != "" {
+			groups, err := cS.AuthService.ListGroups()
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+					Status:  "error",
+					Message: "failed_to_list_groups",
+					Error:   err.Error(),
+					Data:    nil,
+				})
+				return
+			}
+			if !slices.ContainsFunc(groups, func(g models.Group) bool { return g.Name == group }) {
+				c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+					Status:  "error",
+					Message: "group_not_found",
+					Error:   "group_not_found: " + group,
+					Data:    nil,
+				})
+				return
+			}
+		}
+
+		if err := cS.ProposeRestoreOverwriteGroup(group, cS.Raft == nil); err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_update_restore_policy",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[RestorePolicy]{
+			Status:  "success",
+			Message: "restore_policy_updated",
+			Data:    RestorePolicy{RestoreOverwriteGroup: group},
+		})
+	}
+}
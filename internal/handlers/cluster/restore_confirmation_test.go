@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+
+package clusterHandlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alchemillahq/sylve/internal/db/models"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	serviceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services"
+	"github.com/alchemillahq/sylve/internal/services/cluster"
+	"github.com/gin-gonic/gin"
+)
+
+type restoreConfirmAuthStub struct {
+	serviceInterfaces.AuthServiceInterface
+	groups []models.Group
+}
+
+func (restoreConfirmAuthStub) ValidateScopedJWT(token, scope string) (serviceInterfaces.CustomClaims, error) {
+	if token != "good-token" || scope != restoreOverwriteTokenScope {
+		return serviceInterfaces.CustomClaims{}, errors.New("invalid_token")
+	}
+	return serviceInterfaces.CustomClaims{UserID: 7}, nil
+}
+
+func (s restoreConfirmAuthStub) GetUserByID(id uint) (*models.User, error) {
+	return &models.User{ID: id, Groups: s.groups}, nil
+}
+
+func newRestoreConfirmTestService(t *testing.T, groups ...models.Group) *cluster.Service {
+	t.Helper()
+
+	db := newClusterHandlerTestDB(t, &vmModels.VM{}, &jailModels.Jail{}, &clusterModels.ClusterOption{})
+	if err := db.Create(&jailModels.Jail{CTID: 105, Name: "web-prod"}).Error; err != nil {
+		t.Fatalf("seed jail: %v", err)
+	}
+
+	return &cluster.Service{DB: db, AuthService: restoreConfirmAuthStub{groups: groups}}
+}
+
+func checkRestoreConfirmation(cS *cluster.Service, scope string, guestID uint, rc restoreConfirmation) (*httptest.ResponseRecorder, bool) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Set("UserID", uint(7))
+	c.Set("AuthScope", scope)
+
+	ok := requireRestoreOverwriteConfirmation(c, cS, clusterModels.BackupJobModeJail, guestID, rc)
+	return recorder, ok
+}
+
+func TestRestoreOverwriteConfirmationRequired(t *testing.T) {
+	cS := newRestoreConfirmTestService(t)
+
+	if _, ok := checkRestoreConfirmation(cS, "local", 106, restoreConfirmation{}); !ok {
+		t.Fatal("expected a restore onto a missing guest to need no confirmation")
+	}
+
+	recorder, ok := checkRestoreConfirmation(cS, "local", 105, restoreConfirmation{})
+	if ok || recorder.Code != http.StatusPreconditionRequired {
+		t.Fatalf("expected 428 without confirmation, got %d", recorder.Code)
+	}
+
+	var decoded handlerAPIResponse[RestoreOverwriteGuest]
+	if err := json.Unmarshal(recorder.Body.Bytes(), &decoded); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if decoded.Message != "restore_overwrite_confirmation_required" || decoded.Data.GuestName != "web-prod" {
+		t.Fatalf("unexpected response: %+v", decoded)
+	}
+
+	if recorder, ok := checkRestoreConfirmation(cS, "local", 105, restoreConfirmation{ConfirmGuestName: "web-dev"}); ok || recorder.Code != http.StatusPreconditionRequired {
+		t.Fatalf("expected a wrong guest name to be refused, got %d", recorder.Code)
+	}
+	if _, ok := checkRestoreConfirmation(cS, "local", 105, restoreConfirmation{ReauthConfirmed: true}); ok {
+		t.Fatal("expected reauthConfirmed to be ignored outside cluster-token requests")
+	}
+}
+
+func TestRestoreOverwriteConfirmationAccepted(t *testing.T) {
+	cS := newRestoreConfirmTestService(t)
+
+	for name, rc := range map[string]restoreConfirmation{
+		"typed name": {ConfirmGuestName: " web-prod "},
+		"token":      {ConfirmToken: "good-token"},
+	} {
+		if recorder, ok := checkRestoreConfirmation(cS, "local", 105, rc); !ok {
+			t.Fatalf("%s: expected restore to be confirmed, got %d", name, recorder.Code)
+		}
+	}
+
+	if _, ok := checkRestoreConfirmation(cS, "cluster", 105, restoreConfirmation{ReauthConfirmed: true}); !ok {
+		t.Fatal("expected a forwarded re-auth to be trusted")
+	}
+}
+
+func TestRestoreOverwriteGroupPolicy(t *testing.T) {
+	cS := newRestoreConfirmTestService(t, models.Group{Name: "operators"})
+	if err := cS.ProposeRestoreOverwriteGroup("senior-operators", true); err != nil {
+		t.Fatalf("set policy: %v", err)
+	}
+
+	recorder, ok := checkRestoreConfirmation(cS, "local", 105, restoreConfirmation{ConfirmGuestName: "web-prod"})
+	if ok || recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for a user outside the group, got %d", recorder.Code)
+	}
+
+	cS.AuthService = restoreConfirmAuthStub{groups: []models.Group{{Name: "senior-operators"}}}
+	if _, ok := checkRestoreConfirmation(cS, "local", 105, restoreConfirmation{ConfirmGuestName: "web-prod"}); !ok {
+		t.Fatal("expected a group member to be allowed")
+	}
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterHandlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/services/cluster"
+	"github.com/gin-gonic/gin"
+)
+
+// RotateClusterSSHIdentity rotates this node's cluster SSH key. Like the
+// replication SSH user it is node-local; the new key reaches peers via raft.
+func RotateClusterSSHIdentity(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		username := strings.TrimSpace(c.GetString("Username"))
+		rotation, err := cS.RotateLocalSSHIdentity(clusterModels.SSHKeyRotationReasonManual, username)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if err.Error() == "cluster_not_enabled" || err.Error() == "leader_unknown" {
+				status = http.StatusBadRequest
+			}
+			c.JSON(status, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "cluster_ssh_key_rotation_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*clusterModels.ClusterSSHKeyRotation]{
+			Status:  "success",
+			Message: "cluster_ssh_key_rotated",
+			Data:    rotation,
+		})
+	}
+}
+
+func ClusterSSHKeyRotations(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+
+		rotations, err := cS.ListSSHKeyRotations(limit)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "list_cluster_ssh_key_rotations_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]clusterModels.ClusterSSHKeyRotation]{
+			Status:  "success",
+			Message: "cluster_ssh_key_rotations_listed",
+			Data:    rotations,
+		})
+	}
+}
+
+func SetClusterSSHKeyRotationSchedule(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			Days int `json:"days"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := cS.SetSSHKeyRotationSchedule(req.Days); err != nil {
+			status := http.StatusInternalServerError
+			if strings.HasPrefix(err.Error(), "invalid_") || err.Error() == "cluster_not_enabled" {
+				status = http.StatusBadRequest
+			}
+			c.JSON(status, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "set_cluster_ssh_key_rotation_schedule_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "cluster_ssh_key_rotation_schedule_updated",
+			Data:    nil,
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterHandlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	zfsModels "github.com/alchemillahq/sylve/internal/db/models/zfs"
+	"github.com/alchemillahq/sylve/internal/services/zelta"
+	"github.com/gin-gonic/gin"
+)
+
+// Storage fences are node-local: each node of a shared-storage pair keeps
+// its own reservation key, so these handlers never forward to the leader.
+
+func storageFenceErrorStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case msg == "storage_fence_not_found":
+		return http.StatusNotFound
+	case strings.HasPrefix(msg, "invalid_"),
+		strings.HasPrefix(msg, "storage_fence_mmp_takes_no_devices"),
+		strings.HasPrefix(msg, "storage_fence_devices_required"),
+		strings.HasPrefix(msg, "storage_fence_reservation_key_required"):
+		return http.StatusBadRequest
+	case strings.HasPrefix(msg, "storage_fence_reserved_by_other_host"),
+		strings.HasPrefix(msg, "storage_fence_pool_active_elsewhere"):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+func StorageFences(zS *zelta.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fences, err := zS.ListStorageFences()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "list_storage_fences_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]zfsModels.StorageFence]{
+			Status:  "success",
+			Message: "storage_fences_listed",
+			Data:    fences,
+		})
+	}
+}
+
+func SaveStorageFence(zS *zelta.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req zelta.StorageFenceRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		fence, err := zS.SaveStorageFence(req)
+		if err != nil {
+			c.JSON(storageFenceErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "save_storage_fence_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[zfsModels.StorageFence]{
+			Status:  "success",
+			Message: "storage_fence_saved",
+			Data:    fence,
+		})
+	}
+}
+
+func DeleteStorageFence(zS *zelta.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := zS.DeleteStorageFence(c.Param("pool")); err != nil {
+			c.JSON(storageFenceErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "delete_storage_fence_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "storage_fence_deleted",
+			Data:    nil,
+		})
+	}
+}
+
+// CheckStorageFence acquires the fence for a pool and imports it if needed.
+// It never preempts a peer; that only happens during an authorized failover.
+func CheckStorageFence(zS *zelta.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := zS.CheckStorageFence(c.Request.Context(), c.Param("pool"), false); err != nil {
+			c.JSON(storageFenceErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "storage_fence_check_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "storage_fence_held",
+			Data:    nil,
+		})
+	}
+}
@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterHandlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/services/cluster"
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/raft"
+	"gorm.io/gorm"
+)
+
+func zeltaProfileErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "zelta_profile_"):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func zeltaProfileID(c *gin.Context) (uint, bool) {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id64 == 0 {
+		c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+			Status:  "error",
+			Message: "invalid_zelta_profile_id",
+			Error:   "invalid_zelta_profile_id",
+			Data:    nil,
+		})
+		return 0, false
+	}
+	return uint(id64), true
+}
+
+func ZeltaProfiles(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		profiles, err := cS.ListZeltaProfiles()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "list_zelta_profiles_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]clusterModels.ZeltaProfile]{
+			Status:  "success",
+			Message: "zelta_profiles_listed",
+			Data:    profiles,
+		})
+	}
+}
+
+func CreateZeltaProfile(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		var req clusterServiceInterfaces.ZeltaProfileReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := cS.ProposeZeltaProfileCreate(req, cS.Raft == nil); err != nil {
+			c.JSON(zeltaProfileErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "create_zelta_profile_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "zelta_profile_created",
+			Data:    nil,
+		})
+	}
+}
+
+func UpdateZeltaProfile(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		id, ok := zeltaProfileID(c)
+		if !ok {
+			return
+		}
+
+		var req clusterServiceInterfaces.ZeltaProfileReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := cS.ProposeZeltaProfileUpdate(id, req, cS.Raft == nil); err != nil {
+			c.JSON(zeltaProfileErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "update_zelta_profile_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "zelta_profile_updated",
+			Data:    nil,
+		})
+	}
+}
+
+func DeleteZeltaProfile(cS *cluster.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cS.Raft != nil && cS.Raft.State() != raft.Leader {
+			forwardToLeader(c, cS)
+			return
+		}
+
+		id, ok := zeltaProfileID(c)
+		if !ok {
+			return
+		}
+
+		if err := cS.ProposeZeltaProfileDelete(id, cS.Raft == nil); err != nil {
+			c.JSON(zeltaProfileErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "delete_zelta_profile_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "zelta_profile_deleted",
+			Data:    nil,
+		})
+	}
+}
@@ -9,7 +9,6 @@
 package handlers
 
 import (
-	"crypto/tls"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -42,8 +41,10 @@ var secureTransport = &http.Transport{
 	}).DialContext,
 }
 
-var insecureTransport = &http.Transport{
-	TLSClientConfig:       &tls.Config{InsecureSkipVerify: true},
+// clusterPeerTransport proxies requests to other cluster nodes, whose
+// certificates are pinned to the cluster CA.
+var clusterPeerTransport = &http.Transport{
+	TLSClientConfig:       utils.IntraClusterTLSConfig(),
 	MaxIdleConns:          64,
 	MaxIdleConnsPerHost:   32,
 	IdleConnTimeout:       60 * time.Second,
@@ -114,14 +115,14 @@ func ReverseProxy(c *gin.Context, backend string) {
 	c.Abort()
 }
 
-func ReverseProxyInsecure(c *gin.Context, backend string) {
+func ReverseProxyClusterPeer(c *gin.Context, backend string) {
 	remote, err := url.Parse(backend)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to parse proxy URL"})
 		c.Abort()
 		return
 	}
-	p := newReverseProxy(remote, insecureTransport, false)
+	p := newReverseProxy(remote, clusterPeerTransport, false)
 	p.ServeHTTP(c.Writer, c.Request)
 	c.Abort()
 }
@@ -241,7 +242,7 @@ func EnsureCorrectHost(db *gorm.DB, authService *authService.Service) gin.Handle
 
 			if node.Status == "online" {
 				injectForwardClusterAuth(c, authService)
-				ReverseProxyInsecure(c, fmt.Sprintf("https://%s", node.API))
+				ReverseProxyClusterPeer(c, fmt.Sprintf("https://%s", node.API))
 				return
 			}
 
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package diskHandlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/db/models"
+	"github.com/alchemillahq/sylve/internal/services/disk"
+	"github.com/gin-gonic/gin"
+)
+
+type diskBenchmarkService interface {
+	ListBenchmarks(context.Context, string) ([]models.DiskBenchmark, error)
+	StartBenchmark(context.Context, disk.BenchmarkInput) (*models.DiskBenchmark, error)
+}
+
+func benchmarkErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, disk.ErrInvalidBenchmark):
+		return http.StatusBadRequest
+	case errors.Is(err, disk.ErrBenchmarkRunning):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// @Summary List storage benchmarks
+// @Description List past storage benchmark runs, newest first, optionally for one pool
+// @Tags Disk
+// @Accept json
+// @Produce json
+// @Param pool query string false "Pool name"
+// @Security BearerAuth
+// @Success 200 {object} internal.APIResponse[[]models.DiskBenchmark] "Success"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /disk/benchmark [get]
+func ListBenchmarks(service diskBenchmarkService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		benchmarks, err := service.ListBenchmarks(c.Request.Context(), c.Query("pool"))
+		if err != nil {
+			c.JSON(benchmarkErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "error_listing_disk_benchmarks",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+		c.JSON(http.StatusOK, internal.APIResponse[[]models.DiskBenchmark]{
+			Status:  "success",
+			Message: "disk_benchmarks_listed",
+			Error:   "",
+			Data:    benchmarks,
+		})
+	}
+}
+
+// @Summary Start a storage benchmark
+// @Description Run bounded sequential and random read/write tests against a scratch dataset or zvol on a pool. The run continues in the background; poll the list for its result.
+// @Tags Disk
+// @Accept json
+// @Produce json
+// @Param request body disk.BenchmarkInput true "Benchmark request body"
+// @Security BearerAuth
+// @Success 202 {object} internal.APIResponse[models.DiskBenchmark] "Accepted"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 409 {object} internal.APIResponse[any] "Conflict"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /disk/benchmark [post]
+func StartBenchmark(service diskBenchmarkService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input disk.BenchmarkInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request_payload",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+		benchmark, err := service.StartBenchmark(c.Request.Context(), input)
+		if err != nil {
+			c.JSON(benchmarkErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "error_starting_disk_benchmark",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+		c.JSON(http.StatusAccepted, internal.APIResponse[*models.DiskBenchmark]{
+			Status:  "success",
+			Message: "disk_benchmark_started",
+			Error:   "",
+			Data:    benchmark,
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package diskHandlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alchemillahq/sylve/internal/db/models"
+	"github.com/alchemillahq/sylve/internal/services/disk"
+	"github.com/gin-gonic/gin"
+)
+
+type benchmarkHandlerStub struct {
+	pool  string
+	input disk.BenchmarkInput
+	err   error
+}
+
+func (s *benchmarkHandlerStub) ListBenchmarks(_ context.Context, pool string) ([]models.DiskBenchmark, error) {
+	s.pool = pool
+	return []models.DiskBenchmark{{ID: 1, Pool: "tank"}}, s.err
+}
+
+func (s *benchmarkHandlerStub) StartBenchmark(_ context.Context, input disk.BenchmarkInput) (*models.DiskBenchmark, error) {
+	s.input = input
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &models.DiskBenchmark{ID: 2, Pool: input.Pool, Status: disk.BenchmarkStatusRunning}, nil
+}
+
+func TestBenchmarkHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	service := &benchmarkHandlerStub{}
+	router := gin.New()
+	router.GET("/disk/benchmark", ListBenchmarks(service))
+	router.POST("/disk/benchmark", StartBenchmark(service))
+
+	request := httptest.NewRequest(http.MethodGet, "/disk/benchmark?pool=tank", nil)
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+	if response.Code != http.StatusOK || service.pool != "tank" {
+		t.Fatalf("list: got %d for pool %q: %s", response.Code, service.pool, response.Body.String())
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/disk/benchmark", bytes.NewBufferString(`{"pool":"tank","target":"zvol","sizeMB":64}`))
+	request.Header.Set("Content-Type", "application/json")
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+	if response.Code != http.StatusAccepted {
+		t.Fatalf("start: got %d: %s", response.Code, response.Body.String())
+	}
+	if service.input.Pool != "tank" || service.input.Target != "zvol" || service.input.SizeMB != 64 {
+		t.Fatalf("unexpected input: %+v", service.input)
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/disk/benchmark", bytes.NewBufferString(`{}`))
+	request.Header.Set("Content-Type", "application/json")
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+	if response.Code != http.StatusBadRequest {
+		t.Fatalf("missing pool: got %d", response.Code)
+	}
+}
+
+func TestBenchmarkHandlerErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		err    error
+		status int
+	}{
+		{err: fmt.Errorf("%w: size", disk.ErrInvalidBenchmark), status: http.StatusBadRequest},
+		{err: disk.ErrBenchmarkRunning, status: http.StatusConflict},
+		{err: errors.New("failure"), status: http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		service := &benchmarkHandlerStub{err: test.err}
+		router := gin.New()
+		router.POST("/disk/benchmark", StartBenchmark(service))
+		request := httptest.NewRequest(http.MethodPost, "/disk/benchmark", bytes.NewBufferString(`{"pool":"tank"}`))
+		request.Header.Set("Content-Type", "application/json")
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+		if response.Code != test.status {
+			t.Fatalf("%v: got %d, want %d", test.err, response.Code, test.status)
+		}
+	}
+}
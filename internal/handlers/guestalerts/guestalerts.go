@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package guestAlertsHandlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/db/models"
+	"github.com/alchemillahq/sylve/internal/services/guestalerts"
+	"github.com/gin-gonic/gin"
+)
+
+func guestAlertErrorStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "_not_found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(msg, "invalid_"):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func respondGuestAlertError(c *gin.Context, message string, err error) {
+	c.JSON(guestAlertErrorStatus(err), internal.APIResponse[any]{
+		Status:  "error",
+		Message: message,
+		Error:   err.Error(),
+	})
+}
+
+func ruleIDParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+			Status:  "error",
+			Message: "invalid_rule_id",
+			Error:   "rule id must be a positive integer",
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// @Summary List guest alert rules
+// @Description List the CPU, memory and disk latency alert rules for VMs and jails
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} internal.APIResponse[[]models.GuestAlertRule] "Success"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /notifications/guest-alerts/rules [get]
+func ListRules(alertService *guestalerts.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rules, err := alertService.ListRules()
+		if err != nil {
+			respondGuestAlertError(c, "failed_to_list_guest_alert_rules", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]models.GuestAlertRule]{
+			Status:  "success",
+			Message: "guest_alert_rules_listed",
+			Data:    rules,
+		})
+	}
+}
+
+// @Summary Create guest alert rule
+// @Description Alert when a guest stays above a threshold. guestId 0 applies the rule to every guest of guestType.
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body guestalerts.RuleInput true "Rule"
+// @Success 200 {object} internal.APIResponse[models.GuestAlertRule] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Router /notifications/guest-alerts/rules [post]
+func CreateRule(alertService *guestalerts.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req guestalerts.RuleInput
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		rule, err := alertService.CreateRule(req)
+		if err != nil {
+			respondGuestAlertError(c, "failed_to_create_guest_alert_rule", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*models.GuestAlertRule]{
+			Status:  "success",
+			Message: "guest_alert_rule_created",
+			Data:    rule,
+		})
+	}
+}
+
+// @Summary Update guest alert rule
+// @Description Replace a guest alert rule. An alert that is firing for it is re-evaluated from scratch.
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Rule ID"
+// @Param request body guestalerts.RuleInput true "Rule"
+// @Success 200 {object} internal.APIResponse[models.GuestAlertRule] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 404 {object} internal.APIResponse[any] "Not Found"
+// @Router /notifications/guest-alerts/rules/{id} [put]
+func UpdateRule(alertService *guestalerts.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := ruleIDParam(c)
+		if !ok {
+			return
+		}
+
+		var req guestalerts.RuleInput
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		rule, err := alertService.UpdateRule(id, req)
+		if err != nil {
+			respondGuestAlertError(c, "failed_to_update_guest_alert_rule", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*models.GuestAlertRule]{
+			Status:  "success",
+			Message: "guest_alert_rule_updated",
+			Data:    rule,
+		})
+	}
+}
+
+// @Summary Delete guest alert rule
+// @Description Delete a guest alert rule
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Rule ID"
+// @Success 200 {object} internal.APIResponse[any] "Success"
+// @Failure 404 {object} internal.APIResponse[any] "Not Found"
+// @Router /notifications/guest-alerts/rules/{id} [delete]
+func DeleteRule(alertService *guestalerts.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := ruleIDParam(c)
+		if !ok {
+			return
+		}
+
+		if err := alertService.DeleteRule(id); err != nil {
+			respondGuestAlertError(c, "failed_to_delete_guest_alert_rule", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "guest_alert_rule_deleted",
+		})
+	}
+}
+
+// @Summary List active guest alerts
+// @Description List the guests currently breaching an alert rule on this node
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} internal.APIResponse[[]guestalerts.ActiveAlert] "Success"
+// @Router /notifications/guest-alerts/active [get]
+func ActiveAlerts(alertService *guestalerts.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, internal.APIResponse[[]guestalerts.ActiveAlert]{
+			Status:  "success",
+			Message: "guest_alerts_listed",
+			Data:    alertService.ActiveAlerts(),
+		})
+	}
+}
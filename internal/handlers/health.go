@@ -9,14 +9,24 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/alchemillahq/sylve/internal"
 	"github.com/alchemillahq/sylve/internal/cmd"
+	"github.com/alchemillahq/sylve/internal/db"
+	"github.com/alchemillahq/sylve/internal/services/cluster"
+	"github.com/alchemillahq/sylve/internal/services/libvirt"
 	"github.com/alchemillahq/sylve/internal/services/system"
 	"github.com/alchemillahq/sylve/pkg/utils"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // @Summary Basic health check
@@ -51,6 +61,7 @@ func BasicHealthCheckHandler(systemService *system.Service) gin.HandlerFunc {
 				"initialized":  b.Initialized,
 				"restarted":    b.Restarted,
 				"sylveVersion": cmd.Version,
+				"time":         time.Now().UnixMilli(),
 			},
 		})
 	}
@@ -59,3 +70,153 @@ func BasicHealthCheckHandler(systemService *system.Service) gin.HandlerFunc {
 func HTTPHealthCheckHandler(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
+
+const (
+	readinessCheckTimeout = 3 * time.Second
+
+	readinessOK      = "ok"
+	readinessFailed  = "failed"
+	readinessSkipped = "skipped"
+)
+
+var readinessRunCommand = utils.RunCommandWithContext
+
+type ReadinessCheck struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latencyMs"`
+	Error     string `json:"error,omitempty"`
+}
+
+type ReadinessReport struct {
+	Ready  bool             `json:"ready"`
+	Checks []ReadinessCheck `json:"checks"`
+}
+
+// skipReadiness marks a dependency this node does not use; it is reported
+// but does not make the node unready.
+type skipReadiness string
+
+func (s skipReadiness) Error() string { return string(s) }
+
+type readinessProbe struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+func readinessProbes(database *gorm.DB, clusterService *cluster.Service, libvirtService *libvirt.Service) []readinessProbe {
+	return []readinessProbe{
+		{name: "database", run: func(ctx context.Context) error {
+			return db.CheckWritable(ctx, database)
+		}},
+		{name: "queue", run: db.QueueHealth},
+		{name: "raft", run: func(ctx context.Context) error {
+			if clusterService == nil || clusterService.Raft == nil {
+				return skipReadiness("cluster_not_enabled")
+			}
+			if addr, _ := clusterService.Raft.LeaderWithID(); addr == "" {
+				return fmt.Errorf("raft_no_leader")
+			}
+			return nil
+		}},
+		{name: "libvirt", run: func(ctx context.Context) error {
+			if libvirtService == nil || !libvirtService.IsVirtualizationEnabled() {
+				return skipReadiness("virtualization_not_enabled")
+			}
+			return libvirtService.PingSocket(ctx)
+		}},
+		{name: "zfs", run: func(ctx context.Context) error {
+			if out, err := readinessRunCommand(ctx, "zfs", "version"); err != nil {
+				return fmt.Errorf("zfs_command_failed: %s", strings.TrimSpace(out))
+			}
+			return nil
+		}},
+	}
+}
+
+// runReadinessProbes runs every probe concurrently, each under its own
+// timeout, and records how long each one took.
+func runReadinessProbes(ctx context.Context, probes []readinessProbe) ReadinessReport {
+	report := ReadinessReport{Ready: true, Checks: make([]ReadinessCheck, len(probes))}
+
+	var wg sync.WaitGroup
+	for i, probe := range probes {
+		wg.Add(1)
+		go func(i int, probe readinessProbe) {
+			defer wg.Done()
+
+			probeCtx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := probe.run(probeCtx)
+			check := ReadinessCheck{
+				Name:      probe.name,
+				Status:    readinessOK,
+				LatencyMs: time.Since(start).Milliseconds(),
+			}
+
+			var skip skipReadiness
+			switch {
+			case errors.As(err, &skip):
+				check.Status = readinessSkipped
+				check.Error = err.Error()
+			case err != nil:
+				check.Status = readinessFailed
+				check.Error = err.Error()
+			}
+			report.Checks[i] = check
+		}(i, probe)
+	}
+	wg.Wait()
+
+	for _, check := range report.Checks {
+		if check.Status == readinessFailed {
+			report.Ready = false
+		}
+	}
+	return report
+}
+
+// @Summary Liveness probe
+// @Description Reports that the Sylve process is up and serving requests. Unauthenticated.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} internal.APIResponse[any] "Success"
+// @Router /healthz [get]
+func LivenessHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, internal.APIResponse[any]{
+		Status:  "success",
+		Message: "alive",
+		Data:    gin.H{"sylveVersion": cmd.Version},
+	})
+}
+
+// @Summary Readiness probe
+// @Description Checks that the database is writable, the job queue answers, Raft has a leader, libvirt is reachable and zfs works, with per-check latency. Unauthenticated; returns 503 when any check fails.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} internal.APIResponse[ReadinessReport] "Ready"
+// @Failure 503 {object} internal.APIResponse[ReadinessReport] "Not Ready"
+// @Router /readyz [get]
+func ReadinessHandler(database *gorm.DB, clusterService *cluster.Service, libvirtService *libvirt.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := runReadinessProbes(c.Request.Context(), readinessProbes(database, clusterService, libvirtService))
+
+		if !report.Ready {
+			c.JSON(http.StatusServiceUnavailable, internal.APIResponse[ReadinessReport]{
+				Status:  "error",
+				Message: "not_ready",
+				Error:   "readiness_checks_failed",
+				Data:    report,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[ReadinessReport]{
+			Status:  "success",
+			Message: "ready",
+			Data:    report,
+		})
+	}
+}
@@ -10,6 +10,7 @@ package infoHandlers
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/alchemillahq/sylve/internal"
 	infoServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/info"
@@ -47,3 +48,29 @@ func NodeInfo(infoService *info.Service) gin.HandlerFunc {
 		})
 	}
 }
+
+// @Summary Get Node Clock
+// @Description Get the node's wall clock and NTP status, stamped on receipt and reply so cluster peers can measure skew
+// @Tags Info
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} internal.APIResponse[infoServiceInterfaces.ClockInfo] "Success"
+// @Router /info/clock [get]
+func ClockInfo(infoService *info.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		receivedAt := time.Now()
+		ntp := infoService.GetNTPStatus()
+
+		c.JSON(http.StatusOK, internal.APIResponse[infoServiceInterfaces.ClockInfo]{
+			Status:  "success",
+			Message: "",
+			Error:   "",
+			Data: infoServiceInterfaces.ClockInfo{
+				ReceivedAt: receivedAt.UnixMilli(),
+				SentAt:     time.Now().UnixMilli(),
+				NTP:        ntp,
+			},
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/alchemillahq/sylve/internal"
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
 	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
 	"github.com/alchemillahq/sylve/internal/services/jail"
 	"github.com/gin-gonic/gin"
@@ -155,3 +156,108 @@ func CreateBootstrap(jailService *jail.Service) gin.HandlerFunc {
 		})
 	}
 }
+
+type BaseCacheRequest struct {
+	Release string `json:"release" binding:"required"`
+}
+
+// @Summary List cached release bases
+// @Description List the checksum-verified base.txz archives cached per FreeBSD release
+// @Tags Jail
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} internal.APIResponse[[]jailModels.JailBaseCache] "Success"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /jail/base-cache [get]
+func ListBaseCache(jailService *jail.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		records, err := jailService.ListBaseCache()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_list_base_cache",
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]jailModels.JailBaseCache]{
+			Status:  "success",
+			Message: "base_cache_listed",
+			Data:    records,
+		})
+	}
+}
+
+// @Summary Prefetch release base
+// @Description Download and verify base.txz for a FreeBSD release into the cache. Returns immediately; the download runs asynchronously.
+// @Tags Jail
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body BaseCacheRequest true "Release"
+// @Success 202 {object} internal.APIResponse[any] "Accepted"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Router /jail/base-cache [post]
+func PrefetchBaseCache(jailService *jail.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req BaseCacheRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		if err := jailService.PrefetchReleaseBase(strings.TrimSpace(req.Release)); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: err.Error(),
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusAccepted, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "base_cache_prefetch_started",
+		})
+	}
+}
+
+// @Summary Delete cached release base
+// @Description Remove the cached archive and extracted root of a FreeBSD release
+// @Tags Jail
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param release path string true "Release (e.g. 14.3-RELEASE)"
+// @Success 200 {object} internal.APIResponse[any] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /jail/base-cache/{release} [delete]
+func DeleteBaseCache(jailService *jail.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := jailService.DeleteBaseCache(c.Param("release")); err != nil {
+			msg := err.Error()
+			statusCode := http.StatusInternalServerError
+			if strings.HasPrefix(msg, "invalid_release") || strings.HasPrefix(msg, "unsupported_release") {
+				statusCode = http.StatusBadRequest
+			}
+			c.JSON(statusCode, internal.APIResponse[any]{
+				Status:  "error",
+				Message: msg,
+				Error:   msg,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "base_cache_deleted",
+		})
+	}
+}
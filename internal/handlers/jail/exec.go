@@ -0,0 +1,206 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package jailHandlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal"
+	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
+	"github.com/alchemillahq/sylve/internal/services/jail"
+	"github.com/alchemillahq/sylve/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// jailExecFrame is one text frame on the exec stream: output as it arrives,
+// then a single exit frame before the server closes the connection.
+type jailExecFrame struct {
+	Type   string                                `json:"type"`
+	Stream string                                `json:"stream,omitempty"`
+	Data   string                                `json:"data,omitempty"`
+	Result *jailServiceInterfaces.JailExecResult `json:"result,omitempty"`
+}
+
+// @Summary Run a command in a jail
+// @Description Starts a command inside a running jail through jexec and returns an ID to stream its output from
+// @Tags Jail
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param ctid path int true "Jail CTID"
+// @Param request body jailServiceInterfaces.JailExecRequest true "Command"
+// @Success 202 {object} internal.APIResponse[jailServiceInterfaces.JailExecStarted] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 404 {object} internal.APIResponse[any] "Not Found"
+// @Failure 409 {object} internal.APIResponse[any] "Conflict"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /jail/{ctid}/exec [post]
+func StartJailExec(jailService *jail.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctID, err := utils.ParamUint(c, "ctid")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		var req jailServiceInterfaces.JailExecRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		allowed, err := jailService.CanMutateProtectedJail(ctID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "jail_mutation_guard_unavailable",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+		if !allowed {
+			c.JSON(http.StatusConflict, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "restore_in_progress",
+				Error:   "restore_in_progress",
+				Data:    nil,
+			})
+			return
+		}
+
+		e, err := jailService.StartJailExec(ctID, req)
+		if err != nil {
+			status := http.StatusInternalServerError
+			switch {
+			case err.Error() == "jail_not_found":
+				status = http.StatusNotFound
+			case err.Error() == "jail_not_running":
+				status = http.StatusConflict
+			case strings.HasPrefix(err.Error(), "exec_"):
+				status = http.StatusBadRequest
+			}
+
+			c.JSON(status, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_start_jail_exec",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusAccepted, internal.APIResponse[jailServiceInterfaces.JailExecStarted]{
+			Status:  "success",
+			Message: "jail_exec_started",
+			Error:   "",
+			Data: jailServiceInterfaces.JailExecStarted{
+				ID:   e.ID,
+				CTID: e.CTID,
+			},
+		})
+	}
+}
+
+// HandleJailExecWebsocket streams the output of a command started with
+// StartJailExec. Output from before the client attached is replayed first.
+// A text frame of "cancel" from the client kills the command; closing the
+// connection leaves it running.
+func HandleJailExecWebsocket(jailService *jail.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		e, ok := jailService.GetJailExec(c.Param("id"))
+		if !ok {
+			c.JSON(http.StatusNotFound, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "jail_exec_not_found",
+				Error:   "jail_exec_not_found",
+				Data:    nil,
+			})
+			return
+		}
+
+		conn, err := WSUpgrader.Upgrade(c.Writer, c.Request, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		conn.SetReadLimit(wsReadLimit)
+		_ = conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		})
+
+		gone := make(chan struct{})
+		go func() {
+			defer close(gone)
+			for {
+				messageType, data, err := conn.ReadMessage()
+				if err != nil {
+					return
+				}
+				if messageType == websocket.TextMessage && strings.TrimSpace(string(data)) == "cancel" {
+					e.Cancel()
+				}
+			}
+		}()
+
+		ticker := time.NewTicker(wsPingPeriod)
+		defer ticker.Stop()
+
+		offset := 0
+		for {
+			chunks, done, result, wait := e.Next(offset)
+			for _, chunk := range chunks {
+				_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+				if err := conn.WriteJSON(jailExecFrame{Type: "output", Stream: chunk.Stream, Data: chunk.Data}); err != nil {
+					return
+				}
+			}
+			offset += len(chunks)
+
+			if done {
+				_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+				if err := conn.WriteJSON(jailExecFrame{Type: "exit", Result: &result}); err != nil {
+					return
+				}
+				_ = conn.WriteControl(
+					websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+					time.Now().Add(wsWriteTimeout),
+				)
+				return
+			}
+
+			select {
+			case <-wait:
+			case <-gone:
+				return
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
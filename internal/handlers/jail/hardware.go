@@ -9,7 +9,11 @@
 package jailHandlers
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/alchemillahq/sylve/internal"
+	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
 	"github.com/alchemillahq/sylve/internal/services/jail"
 
 	"github.com/gin-gonic/gin"
@@ -20,9 +24,12 @@ type JailUpdateMemoryRequest struct {
 	Memory int64 `json:"memory" binding:"required"`
 }
 
+// JailUpdateCPURequest sets either CPUSet, an explicit list of host cores,
+// or Cores, a count that is auto-assigned.
 type JailUpdateCPURequest struct {
-	CTID  uint  `json:"ctId" binding:"required"`
-	Cores int64 `json:"cores" binding:"required"`
+	CTID   uint  `json:"ctId" binding:"required"`
+	Cores  int64 `json:"cores"`
+	CPUSet []int `json:"cpuSet"`
 }
 
 // @Summary Update Jail Memory
@@ -69,7 +76,7 @@ func UpdateJailMemory(jailService *jail.Service) gin.HandlerFunc {
 }
 
 // @Summary Update Jail CPU
-// @Description Update the CPU limit of a jail by its ID
+// @Description Update the CPU set of a jail by its ID, either from an explicit core list or by auto-assigning a core count
 // @Tags Jail
 // @Accept json
 // @Produce json
@@ -91,7 +98,7 @@ func UpdateJailCPU(jailService *jail.Service) gin.HandlerFunc {
 			return
 		}
 
-		err := jailService.UpdateCPU(req.CTID, req.Cores)
+		err := jailService.UpdateCPU(req.CTID, req.Cores, req.CPUSet)
 		if err != nil {
 			c.JSON(500, internal.APIResponse[any]{
 				Status:  "error",
@@ -110,3 +117,66 @@ func UpdateJailCPU(jailService *jail.Service) gin.HandlerFunc {
 		})
 	}
 }
+
+// @Summary Get Jail CPU Topology
+// @Description Get the host CPU layout with the cores pinned by VMs and used by other jails, optionally with an auto-assigned CPU set for a core count
+// @Tags Jail
+// @Produce json
+// @Security BearerAuth
+// @Param ctId query int false "Jail CTID, omitted for a new jail"
+// @Param cores query int false "Core count to suggest a CPU set for"
+// @Success 200 {object} internal.APIResponse[jailServiceInterfaces.CPUSetTopology] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /jail/cpu/topology [get]
+func GetJailCPUTopology(jailService *jail.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var ctId uint64
+		var cores int
+		var err error
+
+		if raw := strings.TrimSpace(c.Query("ctId")); raw != "" {
+			ctId, err = strconv.ParseUint(raw, 10, 32)
+			if err != nil {
+				c.JSON(400, internal.APIResponse[any]{
+					Status:  "error",
+					Message: "invalid_request",
+					Data:    nil,
+					Error:   "invalid_ct_id",
+				})
+				return
+			}
+		}
+
+		if raw := strings.TrimSpace(c.Query("cores")); raw != "" {
+			cores, err = strconv.Atoi(raw)
+			if err != nil || cores < 0 {
+				c.JSON(400, internal.APIResponse[any]{
+					Status:  "error",
+					Message: "invalid_request",
+					Data:    nil,
+					Error:   "invalid_cores",
+				})
+				return
+			}
+		}
+
+		topology, err := jailService.GetCPUSetTopology(uint(ctId), cores)
+		if err != nil {
+			c.JSON(500, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_get_cpu_topology",
+				Data:    nil,
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		c.JSON(200, internal.APIResponse[jailServiceInterfaces.CPUSetTopology]{
+			Status:  "success",
+			Message: "jail_cpu_topology",
+			Data:    topology,
+			Error:   "",
+		})
+	}
+}
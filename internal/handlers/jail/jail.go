@@ -18,10 +18,12 @@ import (
 	"github.com/alchemillahq/sylve/internal"
 	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
 	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
 	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
 	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/internal/services/cluster"
 	"github.com/alchemillahq/sylve/internal/services/jail"
+	"github.com/alchemillahq/sylve/internal/services/lifecycle"
 
 	"github.com/gin-gonic/gin"
 )
@@ -58,9 +60,12 @@ var jailCreateConflictCodes = map[string]struct{}{
 var jailCreateBadRequestCodes = map[string]struct{}{
 	"base_is_not_a_directory":                        {},
 	"base_path_does_not_exist":                       {},
+	"devfs_management_disabled":                      {},
+	"devfs_ruleset_header_not_allowed":               {},
 	"download_is_not_base_or_rootfs":                 {},
 	"download_uuid_required":                         {},
 	"failed_to_find_download":                        {},
+	"insufficient_pool_space":                        {},
 	"invalid_ct_id":                                  {},
 	"invalid_description":                            {},
 	"invalid_hostname":                               {},
@@ -70,10 +75,13 @@ var jailCreateBadRequestCodes = map[string]struct{}{
 	"invalid_jail_type":                              {},
 	"invalid_vm_name":                                {},
 	"linux_jails_cannot_use_dhcp_or_slaac":           {},
+	"multiple_jail_sources_specified":                {},
 	"pool_not_found":                                 {},
+	"release_base_requires_freebsd_jail":             {},
 	"standard_switch_not_found":                      {},
 	"start_order_must_be_greater_than_or_equal_to_0": {},
 	"switch_name_required":                           {},
+	"unsupported_release":                            {},
 }
 
 var jailCreateAliasCodes = map[string]string{
@@ -166,6 +174,9 @@ func classifyCreateJailError(err error) (int, string) {
 		"system_service_not_initialized",
 		"zfs_client_not_initialized":
 		return http.StatusInternalServerError, "jail_create_dependency_not_ready"
+	case "base_archive_checksum_mismatch",
+		"manifest_entry_not_found":
+		return http.StatusBadGateway, code
 	}
 
 	if _, ok := jailCreateConflictCodes[code]; ok {
@@ -426,10 +437,11 @@ func GetSimpleJailByIdentifier(jailService *jail.Service) gin.HandlerFunc {
 // @Security BearerAuth
 // @Param request body jailServiceInterfaces.CreateJailRequest true "Create Jail Request"
 // @Success 200 {object} internal.APIResponse[any] "Success"
+// @Success 202 {object} internal.APIResponse[taskModels.GuestLifecycleTask] "Queued until the pool has capacity"
 // @Failure 400 {object} internal.APIResponse[any] "Bad Request"
 // @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
 // @Router /jail [post]
-func CreateJail(jailService *jail.Service) gin.HandlerFunc {
+func CreateJail(jailService *jail.Service, lifecycleService *lifecycle.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req jailServiceInterfaces.CreateJailRequest
 
@@ -444,7 +456,15 @@ func CreateJail(jailService *jail.Service) gin.HandlerFunc {
 		}
 
 		ctx := c.Request.Context()
-		err := jailService.CreateJail(ctx, req)
+		task, err := lifecycleService.RunOrQueueCreate(
+			ctx,
+			taskModels.GuestTypeJail,
+			*req.CTID,
+			[]string{req.Pool},
+			req,
+			strings.TrimSpace(c.GetString("Username")),
+			func() error { return jailService.CreateJail(ctx, req) },
+		)
 
 		if err != nil {
 			statusCode, errorCode := classifyCreateJailError(err)
@@ -458,6 +478,19 @@ func CreateJail(jailService *jail.Service) gin.HandlerFunc {
 			return
 		}
 
+		if task != nil {
+			c.Set("AuditAsyncJobID", task.ID)
+			c.Set("AuditAsyncJobType", "jail_create")
+
+			c.JSON(http.StatusAccepted, internal.APIResponse[*taskModels.GuestLifecycleTask]{
+				Status:  "success",
+				Message: "jail_create_queued",
+				Data:    task,
+				Error:   "",
+			})
+			return
+		}
+
 		c.JSON(200, internal.APIResponse[any]{
 			Status:  "success",
 			Message: "jail_created",
@@ -467,6 +500,40 @@ func CreateJail(jailService *jail.Service) gin.HandlerFunc {
 	}
 }
 
+// @Summary Validate a Jail Spec
+// @Description Run every creation check against a jail spec and report all errors and warnings without creating anything
+// @Tags Jail
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body jailServiceInterfaces.CreateJailRequest true "Create Jail Request"
+// @Success 200 {object} internal.APIResponse[jailServiceInterfaces.CreateJailPreflightReport] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Router /jail/validate [post]
+func ValidateCreateJail(jailService *jail.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req jailServiceInterfaces.CreateJailRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(400, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request_data",
+				Data:    nil,
+				Error:   "Invalid request data: " + err.Error(),
+			})
+			return
+		}
+
+		report := jailService.PreflightCreateJail(c.Request.Context(), req)
+
+		c.JSON(200, internal.APIResponse[jailServiceInterfaces.CreateJailPreflightReport]{
+			Status:  "success",
+			Message: "jail_spec_validated",
+			Data:    report,
+			Error:   "",
+		})
+	}
+}
+
 // @Summary Delete a Jail
 // @Description Delete a jail by its CTID
 // @Tags Jail
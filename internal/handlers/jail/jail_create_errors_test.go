@@ -59,6 +59,18 @@ func TestClassifyCreateJailError(t *testing.T) {
 			wantStatus: http.StatusBadRequest,
 			wantCode:   "base_is_not_a_directory",
 		},
+		{
+			name:       "pool space shortfall is bad request",
+			err:        fmt.Errorf("insufficient_pool_space: need 536870912 bytes, 1024 free"),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "insufficient_pool_space",
+		},
+		{
+			name:       "bad release archive is upstream failure",
+			err:        fmt.Errorf("failed_to_prepare_release_base: base_archive_checksum_mismatch: expected aa, got bb"),
+			wantStatus: http.StatusBadGateway,
+			wantCode:   "base_archive_checksum_mismatch",
+		},
 		{
 			name:       "runtime wrapper returns runtime failure code",
 			err:        fmt.Errorf("failed_to_create_jail: duplicated key not allowed"),
@@ -13,7 +13,9 @@ import (
 
 	"github.com/alchemillahq/sylve/internal"
 	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
+	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/internal/services/jail"
+	networkService "github.com/alchemillahq/sylve/internal/services/network"
 
 	"github.com/gin-gonic/gin"
 )
@@ -98,7 +100,7 @@ func SetNetworkInheritance(jailService *jail.Service) gin.HandlerFunc {
 // @Success 200 {object} internal.APIResponse[any] "Success"
 // @Failure 400 {object} internal.APIResponse[any] "Bad Request"
 // @Router /jail/network [post]
-func AddNetwork(jailService *jail.Service) gin.HandlerFunc {
+func AddNetwork(jailService *jail.Service, networkSvc *networkService.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req jailServiceInterfaces.AddJailNetworkRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
@@ -122,6 +124,10 @@ func AddNetwork(jailService *jail.Service) gin.HandlerFunc {
 			return
 		}
 
+		if err := networkSvc.SyncIsolationGroups(); err != nil {
+			logger.L.Error().Err(err).Msg("Failed to sync isolation groups")
+		}
+
 		c.JSON(200, internal.APIResponse[any]{
 			Status:  "success",
 			Message: "network_added_to_jail",
@@ -142,7 +148,7 @@ func AddNetwork(jailService *jail.Service) gin.HandlerFunc {
 // @Success 200 {object} internal.APIResponse[any] "Success"
 // @Failure 400 {object} internal.APIResponse[any] "Bad Request"
 // @Router /jail/network/{ctId}/{networkId} [delete]
-func DeleteNetwork(jailService *jail.Service) gin.HandlerFunc {
+func DeleteNetwork(jailService *jail.Service, networkSvc *networkService.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctId := c.Param("ctId")
 		networkId := c.Param("networkId")
@@ -190,6 +196,10 @@ func DeleteNetwork(jailService *jail.Service) gin.HandlerFunc {
 			return
 		}
 
+		if err := networkSvc.SyncIsolationGroups(); err != nil {
+			logger.L.Error().Err(err).Msg("Failed to sync isolation groups")
+		}
+
 		c.JSON(200, internal.APIResponse[any]{
 			Status:  "success",
 			Message: "network_deleted_from_jail",
@@ -209,7 +219,7 @@ func DeleteNetwork(jailService *jail.Service) gin.HandlerFunc {
 // @Success 200 {object} internal.APIResponse[any] "Success"
 // @Failure 400 {object} internal.APIResponse[any] "Bad Request"
 // @Router /jail/network [put]
-func EditNetwork(jailService *jail.Service) gin.HandlerFunc {
+func EditNetwork(jailService *jail.Service, networkSvc *networkService.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req jailServiceInterfaces.EditJailNetworkRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
@@ -233,6 +243,10 @@ func EditNetwork(jailService *jail.Service) gin.HandlerFunc {
 			return
 		}
 
+		if err := networkSvc.SyncIsolationGroups(); err != nil {
+			logger.L.Error().Err(err).Msg("Failed to sync isolation groups")
+		}
+
 		c.JSON(200, internal.APIResponse[any]{
 			Status:  "success",
 			Message: "network_updated_for_jail",
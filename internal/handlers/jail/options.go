@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/alchemillahq/sylve/internal"
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
 	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
 	"github.com/alchemillahq/sylve/internal/services/jail"
 	"github.com/alchemillahq/sylve/pkg/utils"
@@ -31,6 +32,10 @@ type ModifyFstabRequest struct {
 	Fstab *string `json:"fstab"`
 }
 
+type ModifyMountsRequest struct {
+	Mounts []jailModels.JailMount `json:"mounts"`
+}
+
 type ModifyResolvConfRequest struct {
 	ResolvConf *string `json:"resolvConf"`
 }
@@ -238,6 +243,60 @@ func ModifyFstab(jailService *jail.Service) gin.HandlerFunc {
 	}
 }
 
+// @Summary Modify Mounts of a Jail
+// @Description Replace the filesystems mounted into a jail. Nullfs host paths must exist and sit under the configured mount roots
+// @Tags Jail
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body ModifyMountsRequest true "Modify Mounts Request"
+// @Success 200 {object} internal.APIResponse[any] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /options/mounts/:rid [put]
+func ModifyMounts(jailService *jail.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rid, err := utils.ParamUint(c, "rid")
+		if err != nil {
+			c.JSON(400, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Data:    nil,
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		var req ModifyMountsRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(400, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Data:    nil,
+				Error:   "invalid_request: " + err.Error(),
+			})
+			return
+		}
+
+		if err := jailService.ModifyMounts(rid, req.Mounts); err != nil {
+			c.JSON(500, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "internal_server_error",
+				Data:    nil,
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		c.JSON(200, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "mounts_modified",
+			Data:    nil,
+			Error:   "",
+		})
+	}
+}
+
 // @Summary Modify resolv.conf of a Jail
 // @Description Modify /etc/resolv.conf content for a jail
 // @Tags Jail
@@ -297,6 +356,67 @@ func ModifyResolvConf(jailService *jail.Service) gin.HandlerFunc {
 	}
 }
 
+// @Summary Modify managed /etc files of a Jail
+// @Description Have Sylve render /etc/resolv.conf and /etc/hosts of a jail from its networks, a search domain and static host entries
+// @Tags Jail
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body jailServiceInterfaces.ModifyJailEtcFilesRequest true "Modify Etc Files Request"
+// @Success 200 {object} internal.APIResponse[any] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /options/etc-files/:rid [put]
+func ModifyEtcFiles(jailService *jail.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rid, err := utils.ParamUint(c, "rid")
+		if err != nil {
+			c.JSON(400, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Data:    nil,
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		var req jailServiceInterfaces.ModifyJailEtcFilesRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(400, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Data:    nil,
+				Error:   "invalid_request: " + err.Error(),
+			})
+			return
+		}
+
+		if err := jailService.ModifyEtcFiles(rid, req); err != nil {
+			statusCode := 500
+			message := "internal_server_error"
+			if strings.HasPrefix(err.Error(), "invalid_") {
+				statusCode = 400
+				message = "invalid_request"
+			}
+
+			c.JSON(statusCode, internal.APIResponse[any]{
+				Status:  "error",
+				Message: message,
+				Data:    nil,
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		c.JSON(200, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "etc_files_modified",
+			Data:    nil,
+			Error:   "",
+		})
+	}
+}
+
 // @Summary Modify DevFS rules of a Jail
 // @Description Modify the DevFS rules configuration of a jail
 // @Tags Jail
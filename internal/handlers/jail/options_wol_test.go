@@ -41,6 +41,7 @@ func TestModifyWakeOnLan_Success(t *testing.T) {
 		&jailModels.Storage{},
 		&jailModels.JailHooks{},
 		&jailModels.JailSnapshot{},
+		&jailModels.JailSnapshotSchedule{},
 		&jailModels.Network{},
 		&networkModels.Object{},
 		&networkModels.ObjectEntry{},
@@ -10,11 +10,15 @@ package jailHandlers
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/alchemillahq/sylve/internal"
 	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
 	"github.com/alchemillahq/sylve/internal/services/jail"
 	"github.com/alchemillahq/sylve/pkg/utils"
+	"github.com/alchemillahq/sylve/pkg/zfsdiff"
 	"github.com/gin-gonic/gin"
 )
 
@@ -190,3 +194,268 @@ func DeleteJailSnapshot(jailService *jail.Service) gin.HandlerFunc {
 		})
 	}
 }
+
+func DiffJailSnapshot(jailService *jail.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctID, err := utils.ParamUint(c, "id")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		snapshotID, err := utils.ParamUint(c, "snapshotId")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		var toSnapshotID uint
+		if raw := c.Query("to"); raw != "" {
+			parsed, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil || parsed == 0 {
+				c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+					Status:  "error",
+					Message: "invalid_to_snapshot",
+					Error:   "to_must_be_a_snapshot_id",
+					Data:    nil,
+				})
+				return
+			}
+			toSnapshotID = uint(parsed)
+		}
+
+		diff, err := jailService.DiffJailSnapshot(c.Request.Context(), ctID, snapshotID, toSnapshotID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_diff_jail_snapshot",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*zfsdiff.DatasetDiff]{
+			Status:  "success",
+			Message: "jail_snapshot_diffed",
+			Error:   "",
+			Data:    diff,
+		})
+	}
+}
+
+type RestoreJailPathRequest struct {
+	Snapshot string `json:"snapshot" binding:"required"`
+	Path     string `json:"path" binding:"required"`
+	AsCopy   bool   `json:"asCopy"`
+}
+
+func ListJailPathVersions(jailService *jail.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctID, err := utils.ParamUint(c, "id")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		versions, err := jailService.ListJailPathVersions(c.Request.Context(), ctID, c.Query("path"))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_list_jail_path_versions",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*jailServiceInterfaces.PathVersions]{
+			Status:  "success",
+			Message: "jail_path_versions_listed",
+			Error:   "",
+			Data:    versions,
+		})
+	}
+}
+
+func RestoreJailPathFromSnapshot(jailService *jail.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctID, err := utils.ParamUint(c, "id")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		var req RestoreJailPathRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		restored, err := jailService.RestoreJailPathFromSnapshot(
+			c.Request.Context(),
+			ctID,
+			req.Snapshot,
+			req.Path,
+			req.AsCopy,
+		)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_restore_jail_path",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[string]{
+			Status:  "success",
+			Message: "jail_path_restored",
+			Error:   "",
+			Data:    restored,
+		})
+	}
+}
+
+func GetJailSnapshotSchedule(jailService *jail.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctID, err := utils.ParamUint(c, "id")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		schedule, err := jailService.GetJailSnapshotSchedule(ctID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_get_jail_snapshot_schedule",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*jailModels.JailSnapshotSchedule]{
+			Status:  "success",
+			Message: "jail_snapshot_schedule_retrieved",
+			Error:   "",
+			Data:    schedule,
+		})
+	}
+}
+
+func SetJailSnapshotSchedule(jailService *jail.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctID, err := utils.ParamUint(c, "id")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		var req jail.JailSnapshotScheduleInput
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		schedule, err := jailService.SetJailSnapshotSchedule(ctID, req)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if strings.HasPrefix(err.Error(), "invalid_") || err.Error() == "name_pattern_too_long" {
+				status = http.StatusBadRequest
+			}
+			c.JSON(status, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_set_jail_snapshot_schedule",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[jailModels.JailSnapshotSchedule]{
+			Status:  "success",
+			Message: "jail_snapshot_schedule_set",
+			Error:   "",
+			Data:    *schedule,
+		})
+	}
+}
+
+func DeleteJailSnapshotSchedule(jailService *jail.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctID, err := utils.ParamUint(c, "id")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := jailService.DeleteJailSnapshotSchedule(ctID); err != nil {
+			status := http.StatusInternalServerError
+			if err.Error() == "jail_snapshot_schedule_not_found" {
+				status = http.StatusNotFound
+			}
+			c.JSON(status, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_delete_jail_snapshot_schedule",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "jail_snapshot_schedule_deleted",
+			Error:   "",
+			Data:    nil,
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package jailHandlers
+
+import (
+	"net/http"
+
+	"github.com/alchemillahq/sylve/internal"
+	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
+	"github.com/alchemillahq/sylve/internal/services/jail"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+func MigrateJailStorage(jailService *jail.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctID, err := utils.ParamUint(c, "ctid")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		var req jailServiceInterfaces.MigrateJailStorageRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		result, err := jailService.MigrateJailStorage(c.Request.Context(), ctID, req)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_migrate_jail_storage",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*jailServiceInterfaces.JailStorageMigrationResult]{
+			Status:  "success",
+			Message: "jail_storage_migrated",
+			Error:   "",
+			Data:    result,
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package jailHandlers
+
+import (
+	"net/http"
+
+	"github.com/alchemillahq/sylve/internal"
+	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
+	"github.com/alchemillahq/sylve/internal/services/jail"
+	"github.com/alchemillahq/sylve/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func jailUsageErrorStatus(err error) int {
+	switch err.Error() {
+	case "jail_not_found":
+		return http.StatusNotFound
+	case "jail_not_running", "racct_disabled":
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// @Summary List Jail Processes
+// @Description List the processes running inside a jail, read with ps through jexec
+// @Tags Jail
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Jail CTID"
+// @Success 200 {object} internal.APIResponse[[]jailServiceInterfaces.JailProcess] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 404 {object} internal.APIResponse[any] "Not Found"
+// @Failure 409 {object} internal.APIResponse[any] "Conflict"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /jail/{id}/processes [get]
+func ListJailProcesses(jailService *jail.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctID, err := utils.ParamUint(c, "id")
+		if err != nil || ctID == 0 {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_jail_id",
+				Error:   "ctid must be a positive integer",
+				Data:    nil,
+			})
+			return
+		}
+
+		processes, err := jailService.ListJailProcesses(c.Request.Context(), ctID)
+		if err != nil {
+			c.JSON(jailUsageErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_list_jail_processes",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]jailServiceInterfaces.JailProcess]{
+			Status:  "success",
+			Message: "jail_processes_listed",
+			Data:    processes,
+			Error:   "",
+		})
+	}
+}
+
+// @Summary Get Jail Resource Usage
+// @Description Read a jail's racct counters with rctl; needs kern.racct.enable=1
+// @Tags Jail
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Jail CTID"
+// @Success 200 {object} internal.APIResponse[jailServiceInterfaces.JailResourceUsage] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 404 {object} internal.APIResponse[any] "Not Found"
+// @Failure 409 {object} internal.APIResponse[any] "Conflict"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /jail/{id}/usage [get]
+func GetJailResourceUsage(jailService *jail.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctID, err := utils.ParamUint(c, "id")
+		if err != nil || ctID == 0 {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_jail_id",
+				Error:   "ctid must be a positive integer",
+				Data:    nil,
+			})
+			return
+		}
+
+		usage, err := jailService.GetJailResourceUsage(c.Request.Context(), ctID)
+		if err != nil {
+			c.JSON(jailUsageErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_get_jail_usage",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[jailServiceInterfaces.JailResourceUsage]{
+			Status:  "success",
+			Message: "jail_usage_retrieved",
+			Data:    usage,
+			Error:   "",
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package metadataHandlers
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+	"github.com/alchemillahq/sylve/internal/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+const guestMetadataKey = "GuestMetadata"
+
+type guestMetadataSource interface {
+	GuestMetadataForAddress(remoteIP string) (libvirtServiceInterfaces.GuestMetadata, error)
+}
+
+// NewRouter serves the NoCloud seed layout (meta-data, user-data, ...) and a
+// small EC2-style tree under /latest/meta-data. Guests point cloud-init at it
+// with "seedfrom" or ds=nocloud-net, so edits made in Sylve reach the guest on
+// its next boot without rebuilding the cloud-init ISO.
+func NewRouter(source guestMetadataSource) *gin.Engine {
+	r := gin.New()
+	r.Use(gin.Recovery())
+	r.Use(resolveGuest(source))
+
+	r.GET("/meta-data", func(c *gin.Context) {
+		c.String(http.StatusOK, guest(c).MetaData)
+	})
+	r.GET("/user-data", func(c *gin.Context) {
+		c.String(http.StatusOK, guest(c).UserData)
+	})
+	r.GET("/vendor-data", func(c *gin.Context) {
+		c.String(http.StatusOK, "")
+	})
+	r.GET("/network-config", func(c *gin.Context) {
+		config := guest(c).NetworkConfig
+		if strings.TrimSpace(config) == "" {
+			c.String(http.StatusNotFound, "")
+			return
+		}
+		c.String(http.StatusOK, config)
+	})
+
+	latest := r.Group("/latest/meta-data")
+	{
+		latest.GET("/instance-id", func(c *gin.Context) {
+			c.String(http.StatusOK, guest(c).InstanceID)
+		})
+		latest.GET("/hostname", func(c *gin.Context) {
+			c.String(http.StatusOK, guest(c).Hostname)
+		})
+		latest.GET("/local-hostname", func(c *gin.Context) {
+			c.String(http.StatusOK, guest(c).Hostname)
+		})
+		latest.GET("/public-keys", func(c *gin.Context) {
+			c.String(http.StatusOK, lines(guest(c).SSHKeys))
+		})
+		latest.GET("/tags", func(c *gin.Context) {
+			c.String(http.StatusOK, lines(guest(c).Tags))
+		})
+	}
+
+	r.GET("/sylve/v1/instance", func(c *gin.Context) {
+		c.JSON(http.StatusOK, guest(c))
+	})
+
+	return r
+}
+
+// resolveGuest uses the TCP peer address, never forwarded headers, since the
+// peer address is what the service checks against the ARP table.
+func resolveGuest(source guestMetadataSource) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		host, _, err := net.SplitHostPort(c.Request.RemoteAddr)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		meta, err := source.GuestMetadataForAddress(host)
+		if err != nil {
+			logger.L.Debug().Err(err).Str("remote", host).Msg("guest_metadata_request_rejected")
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+
+		c.Set(guestMetadataKey, meta)
+		c.Next()
+	}
+}
+
+func guest(c *gin.Context) libvirtServiceInterfaces.GuestMetadata {
+	return c.MustGet(guestMetadataKey).(libvirtServiceInterfaces.GuestMetadata)
+}
+
+func lines(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return strings.Join(values, "\n") + "\n"
+}
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/services/zelta"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Transfer metrics
+// @Description Backup job and replication policy transfer metrics of this node in the Prometheus text format: duration and byte histograms, snapshots created and pruned, retries and the last success timestamp, labeled by job, target and guest.
+// @Tags Health
+// @Produce plain
+// @Security BearerAuth
+// @Success 200 {string} string "Prometheus text exposition"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /metrics [get]
+func MetricsHandler(zeltaService *zelta.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var out bytes.Buffer
+		if err := zeltaService.WriteTransferMetrics(&out); err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "metrics_render_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.Data(http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", out.Bytes())
+	}
+}
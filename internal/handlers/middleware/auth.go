@@ -48,6 +48,7 @@ func EnsureAuthenticated(authService *authService.Service) gin.HandlerFunc {
 		isWSAuthPath := strings.HasPrefix(path, "/api/vnc/") ||
 			path == "/api/info/terminal" ||
 			path == "/api/vm/console" ||
+			path == "/api/vm/live-stats" ||
 			path == "/api/jail/console"
 		isSSEPath := path == "/api/events/stream"
 
@@ -131,6 +132,7 @@ func EnsureAuthenticated(authService *authService.Service) gin.HandlerFunc {
 						c.Set("Username", claims.Username)
 						c.Set("AuthType", claims.AuthType)
 						authService.UpdateLastUsageTime(claims.UserID)
+						authService.TouchSession(tok, c.ClientIP())
 						c.Next()
 						return
 					}
@@ -209,6 +211,7 @@ func EnsureAuthenticated(authService *authService.Service) gin.HandlerFunc {
 		c.Set("Username", claims.Username)
 		c.Set("AuthType", claims.AuthType)
 		authService.UpdateLastUsageTime(claims.UserID)
+		authService.TouchSession(localJWT, c.ClientIP())
 		c.Next()
 	}
 }
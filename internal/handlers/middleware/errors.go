@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal/apierror"
+	"github.com/gin-gonic/gin"
+)
+
+// errorDetailWriter holds back JSON error bodies so the catalog detail can be
+// attached once the handler is done. Everything else streams straight through.
+type errorDetailWriter struct {
+	gin.ResponseWriter
+	decided  bool
+	buffered bool
+	body     bytes.Buffer
+}
+
+func (w *errorDetailWriter) decide() {
+	if w.decided {
+		return
+	}
+	w.decided = true
+	w.buffered = w.ResponseWriter.Status() >= http.StatusBadRequest &&
+		strings.HasPrefix(w.ResponseWriter.Header().Get("Content-Type"), "application/json")
+}
+
+func (w *errorDetailWriter) WriteHeaderNow() {
+	w.decide()
+	if !w.buffered {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *errorDetailWriter) Written() bool {
+	return w.buffered || w.ResponseWriter.Written()
+}
+
+func (w *errorDetailWriter) Size() int {
+	if w.buffered {
+		return w.body.Len()
+	}
+	return w.ResponseWriter.Size()
+}
+
+func (w *errorDetailWriter) Write(b []byte) (int, error) {
+	w.decide()
+	if w.buffered {
+		return w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *errorDetailWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *errorDetailWriter) Flush() {
+	w.decide()
+	if !w.buffered {
+		w.ResponseWriter.Flush()
+	}
+}
+
+// ErrorCatalog attaches a structured errorDetail (stable code, localized
+// message and remediation hint) to every JSON error response. Handlers keep
+// writing the usual APIResponse; a typed apierror.Error passed to c.Error
+// takes precedence over the codes parsed from the body.
+func ErrorCatalog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		w := &errorDetailWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+
+		c.Next()
+
+		c.Writer = w.ResponseWriter
+		if !w.buffered {
+			return
+		}
+
+		body := w.body.Bytes()
+		if rewritten, ok := attachErrorDetail(c, body); ok {
+			body = rewritten
+		}
+
+		w.ResponseWriter.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		_, _ = w.ResponseWriter.Write(body)
+	}
+}
+
+func attachErrorDetail(c *gin.Context, body []byte) ([]byte, bool) {
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, false
+	}
+	if existing, ok := payload["errorDetail"]; ok && string(existing) != "null" {
+		return nil, false
+	}
+
+	lang := c.GetHeader("Accept-Language")
+
+	var detail apierror.Detail
+	var typed *apierror.Error
+	for _, ginErr := range c.Errors {
+		if errors.As(ginErr.Err, &typed) {
+			break
+		}
+	}
+	if typed != nil {
+		detail = apierror.Describe(typed, lang)
+	} else {
+		detail = apierror.Resolve(jsonString(payload["message"]), jsonString(payload["error"]), lang)
+	}
+
+	encoded, err := json.Marshal(detail)
+	if err != nil {
+		return nil, false
+	}
+	payload["errorDetail"] = encoded
+
+	out, err := json.Marshal(payload)
+	if err != nil {
+		return nil, false
+	}
+	return out, true
+}
+
+func jsonString(raw json.RawMessage) string {
+	var s string
+	if len(raw) == 0 || json.Unmarshal(raw, &s) != nil {
+		return ""
+	}
+	return s
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/apierror"
+	"github.com/gin-gonic/gin"
+)
+
+func performErrorCatalogRequest(t *testing.T, handler gin.HandlerFunc) (*httptest.ResponseRecorder, internal.APIResponse[any]) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(ErrorCatalog())
+	r.GET("/x", handler)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/x", nil))
+
+	var resp internal.APIResponse[any]
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v (%s)", err, rec.Body.String())
+	}
+	return rec, resp
+}
+
+func TestErrorCatalogAttachesDetail(t *testing.T) {
+	rec, resp := performErrorCatalogRequest(t, func(c *gin.Context) {
+		c.JSON(http.StatusConflict, internal.APIResponse[any]{
+			Status:  "error",
+			Message: "invalid_request",
+			Error:   "backup_target_disabled: id=3",
+		})
+	})
+
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected status to be preserved, got %d", rec.Code)
+	}
+	if resp.Error != "backup_target_disabled: id=3" || resp.Message != "invalid_request" {
+		t.Fatalf("original fields must be kept: %#v", resp)
+	}
+	if resp.ErrorDetail == nil || resp.ErrorDetail.Code != "backup_target_disabled" || resp.ErrorDetail.Hint == "" {
+		t.Fatalf("unexpected error detail: %#v", resp.ErrorDetail)
+	}
+}
+
+func TestErrorCatalogUsesTypedError(t *testing.T) {
+	_, resp := performErrorCatalogRequest(t, func(c *gin.Context) {
+		err := apierror.New("vm_not_found").WithHint("look elsewhere")
+		_ = c.Error(err)
+		c.JSON(http.StatusNotFound, internal.APIResponse[any]{
+			Status:  "error",
+			Message: "invalid_request",
+			Error:   err.Error(),
+		})
+	})
+
+	if resp.ErrorDetail == nil || resp.ErrorDetail.Code != "vm_not_found" || resp.ErrorDetail.Hint != "look elsewhere" {
+		t.Fatalf("unexpected error detail: %#v", resp.ErrorDetail)
+	}
+}
+
+func TestErrorCatalogLeavesSuccessResponses(t *testing.T) {
+	_, resp := performErrorCatalogRequest(t, func(c *gin.Context) {
+		c.JSON(http.StatusOK, internal.APIResponse[any]{Status: "success", Message: "ok"})
+	})
+
+	if resp.ErrorDetail != nil {
+		t.Fatalf("success responses must not carry error detail: %#v", resp.ErrorDetail)
+	}
+}
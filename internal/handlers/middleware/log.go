@@ -57,6 +57,14 @@ func shouldRedactAuditPayload(path string) bool {
 		path == "/api/utilities/downloads/signed-url"
 }
 
+// isManagedFileTransfer reports whether path moves a file through the managed
+// file browser. Those requests are always audited, downloads included, but
+// the file contents are kept out of the record and out of memory.
+func isManagedFileTransfer(path string) bool {
+	return strings.HasPrefix(path, "/api/system/managed-files/") &&
+		(strings.HasSuffix(path, "/download") || strings.HasSuffix(path, "/upload"))
+}
+
 func isSensitiveAuditKey(key string) bool {
 	key = strings.ToLower(strings.TrimSpace(key))
 	key = strings.ReplaceAll(key, "_", "")
@@ -255,11 +263,14 @@ func getClaims(c *gin.Context, authService *authService.Service) (claim, error)
 
 type bodyWriter struct {
 	gin.ResponseWriter
-	body *bytes.Buffer
+	body    *bytes.Buffer
+	discard bool
 }
 
 func (w bodyWriter) Write(b []byte) (int, error) {
-	w.body.Write(b)
+	if !w.discard {
+		w.body.Write(b)
+	}
 	return w.ResponseWriter.Write(b)
 }
 
@@ -285,14 +296,15 @@ func RequestLoggerMiddleware(telemetryDB *gorm.DB, authService *authService.Serv
 			return
 		}
 
-		if !utils.Contains(importantGetPaths, c.Request.URL.Path) && !strings.Contains(c.Request.URL.Path, "vnc") {
+		fileTransfer := isManagedFileTransfer(c.Request.URL.Path)
+		if !utils.Contains(importantGetPaths, c.Request.URL.Path) && !strings.Contains(c.Request.URL.Path, "vnc") && !fileTransfer {
 			if c.Request.Method == "OPTIONS" || c.Request.Method == "HEAD" || c.Request.Method == "GET" {
 				c.Next()
 				return
 			}
 		}
 
-		bw := &bodyWriter{body: bytes.NewBufferString(""), ResponseWriter: c.Writer}
+		bw := &bodyWriter{body: bytes.NewBufferString(""), ResponseWriter: c.Writer, discard: fileTransfer && c.Request.Method == "GET"}
 		c.Writer = bw
 
 		var claims claim
@@ -327,7 +339,7 @@ func RequestLoggerMiddleware(telemetryDB *gorm.DB, authService *authService.Serv
 		act.Path = c.Request.URL.Path
 		act.Query = c.Request.URL.RawQuery
 
-		if c.Request.Body != nil && c.Request.ContentLength > 0 {
+		if c.Request.Body != nil && c.Request.ContentLength > 0 && !fileTransfer {
 			buf := new(bytes.Buffer)
 			tee := io.TeeReader(c.Request.Body, buf)
 
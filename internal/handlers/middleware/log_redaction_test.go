@@ -29,6 +29,24 @@ func TestShouldRedactAuditPayload(t *testing.T) {
 	}
 }
 
+func TestIsManagedFileTransfer(t *testing.T) {
+	cases := []struct {
+		path string
+		want bool
+	}{
+		{path: "/api/system/managed-files/isos/download", want: true},
+		{path: "/api/system/managed-files/jails/105/upload", want: true},
+		{path: "/api/system/managed-files/isos", want: false},
+		{path: "/api/system/file-explorer/download", want: false},
+	}
+
+	for _, tc := range cases {
+		if got := isManagedFileTransfer(tc.path); got != tc.want {
+			t.Fatalf("path=%s expected=%v got=%v", tc.path, tc.want, got)
+		}
+	}
+}
+
 func TestSanitizeAuditPayloadNested(t *testing.T) {
 	input := map[string]interface{}{
 		"username": "admin",
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/openapi"
+	"github.com/gin-gonic/gin"
+)
+
+func TestValidateRequestsRejectsMalformedBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	reached := false
+	r := gin.New()
+	r.Use(ValidateRequests())
+	r.POST("/api/auth/groups", func(c *gin.Context) {
+		reached = true
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, string(body))
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/groups", strings.NewReader(`{"name":"ops"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(rec, req)
+
+	if reached || rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected the request to be rejected, got %d", rec.Code)
+	}
+	var resp internal.APIResponse[[]openapi.FieldError]
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Message != "request_validation_failed" || len(resp.Data) != 1 || resp.Data[0].Field != "body.members" {
+		t.Fatalf("unexpected response: %#v", resp)
+	}
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/api/auth/groups", strings.NewReader(`{"name":"ops","members":[]}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(rec, req)
+
+	if !reached || rec.Code != http.StatusOK || rec.Body.String() != `{"name":"ops","members":[]}` {
+		t.Fatalf("expected the body to reach the handler intact, got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestValidateRequestsPassesOversizedBodiesWhole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var got int
+	r := gin.New()
+	r.Use(ValidateRequests())
+	r.POST("/api/auth/groups", func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		got = len(body)
+		c.Status(http.StatusOK)
+	})
+
+	// A chunked request has no Content-Length for the early size check.
+	big := strings.Repeat(" ", maxValidatedBodyBytes+1024) + `{}`
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/groups", io.MultiReader(strings.NewReader(big)))
+	req.ContentLength = -1
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK || got != len(big) {
+		t.Fatalf("expected the whole body to reach the handler, got %d with %d of %d bytes", rec.Code, got, len(big))
+	}
+}
+
+func TestValidateRequestsOnlyReadsExplicitJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(ValidateRequests())
+	r.POST("/api/auth/groups", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for contentType, want := range map[string]int{
+		"":                                http.StatusOK,
+		"text/plain":                      http.StatusOK,
+		"application/jsonx":               http.StatusOK,
+		"application/json; charset=utf-8": http.StatusBadRequest,
+	} {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/groups", strings.NewReader(`{"name":"ops"}`))
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != want {
+			t.Fatalf("content type %q: expected %d, got %d", contentType, want, rec.Code)
+		}
+	}
+}
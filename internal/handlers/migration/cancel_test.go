@@ -27,6 +27,13 @@ func (*cancelMigrationServiceStub) ValidateMigration(
 	return nil, errors.New("unexpected validation call")
 }
 
+func (*cancelMigrationServiceStub) CheckCompatibility(
+	context.Context,
+	migrationIface.MigrateRequest,
+) (*migrationIface.CompatibilityReport, error) {
+	return nil, errors.New("unexpected compatibility call")
+}
+
 func (*cancelMigrationServiceStub) ExecuteMigration(context.Context, uint) error {
 	return errors.New("unexpected execution call")
 }
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/alchemillahq/sylve/internal/config"
+	"github.com/alchemillahq/sylve/internal/db/models"
 	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
 	utilitiesModels "github.com/alchemillahq/sylve/internal/db/models/utilities"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
@@ -28,10 +29,11 @@ import (
 
 type checkVMTargetResponse struct {
 	Data struct {
-		MissingMedia      []string `json:"missingMedia"`
-		VNCPortInUse      bool     `json:"vncPortInUse"`
-		MissingSwitches   []string `json:"missingSwitches"`
-		MissingFsDatasets []string `json:"missingFsDatasets"`
+		MissingMedia       []string `json:"missingMedia"`
+		VNCPortInUse       bool     `json:"vncPortInUse"`
+		MissingSwitches    []string `json:"missingSwitches"`
+		MissingFsDatasets  []string `json:"missingFsDatasets"`
+		MissingPassthrough []string `json:"missingPassthrough"`
 	} `json:"data"`
 }
 
@@ -44,6 +46,7 @@ func TestIntraClusterCheckVMTarget(t *testing.T) {
 		&utilitiesModels.DownloadedFile{},
 		&networkModels.ManualSwitch{},
 		&vmModels.VM{},
+		&models.PassedThroughIDs{},
 	)
 	svc := &libvirt.Service{DB: db}
 
@@ -76,6 +79,10 @@ func TestIntraClusterCheckVMTarget(t *testing.T) {
 		t.Fatalf("failed to seed vm: %v", err)
 	}
 
+	if err := db.Create(&models.PassedThroughIDs{DeviceID: "pci0:3:0:0"}).Error; err != nil {
+		t.Fatalf("failed to seed passthrough device: %v", err)
+	}
+
 	reqBody := CheckVMTargetRequest{
 		RID:        999,
 		MediaUUIDs: []string{"present-uuid", "missing-uuid", "present-uuid"},
@@ -84,6 +91,7 @@ func TestIntraClusterCheckVMTarget(t *testing.T) {
 			{Name: "WAN", Type: "manual", Bridge: "bridge0"},
 			{Name: "LAN", Type: "manual", Bridge: "bridge9"},
 		},
+		PassthroughDevices: []string{"pci0:3:0:0", "pci0:4:0:0"},
 	}
 	body, err := json.Marshal(reqBody)
 	if err != nil {
@@ -112,6 +120,9 @@ func TestIntraClusterCheckVMTarget(t *testing.T) {
 	if len(resp.Data.MissingSwitches) != 1 || resp.Data.MissingSwitches[0] != "LAN" {
 		t.Fatalf("expected missingSwitches [LAN], got %v", resp.Data.MissingSwitches)
 	}
+	if len(resp.Data.MissingPassthrough) != 1 || resp.Data.MissingPassthrough[0] != "pci0:4:0:0" {
+		t.Fatalf("expected missingPassthrough [pci0:4:0:0], got %v", resp.Data.MissingPassthrough)
+	}
 	if !resp.Data.VNCPortInUse {
 		t.Fatalf("expected vncPortInUse true (port 5900 already used by another VM)")
 	}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/alchemillahq/gzfs"
 	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/db/models"
 	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
 	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
 	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
@@ -216,6 +217,43 @@ func ValidateMigration(migrationService migrationIface.MigrationServiceInterface
 	}
 }
 
+func CheckMigrationCompatibility(migrationService migrationIface.MigrationServiceInterface) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		guestType := c.Query("guestType")
+		guestIDStr := c.Query("guestId")
+		targetNodeUUID := c.Query("targetNodeUuid")
+
+		if guestType == "" || guestIDStr == "" || targetNodeUUID == "" {
+			c.JSON(400, internal.APIResponse[any]{Status: "error", Message: "invalid_request", Error: "guestType, guestId, and targetNodeUuid query params are required"})
+			return
+		}
+
+		guestID, err := strconv.ParseUint(guestIDStr, 10, 0)
+		if err != nil {
+			c.JSON(400, internal.APIResponse[any]{Status: "error", Message: "invalid_guest_id", Error: err.Error()})
+			return
+		}
+
+		report, err := migrationService.CheckCompatibility(c.Request.Context(), migrationIface.MigrateRequest{
+			GuestType:      guestType,
+			GuestID:        uint(guestID),
+			TargetNodeUUID: targetNodeUUID,
+		})
+		if err != nil {
+			status := 500
+			if strings.HasPrefix(err.Error(), "compatibility_check_vm_only") {
+				status = 400
+			} else if strings.HasPrefix(err.Error(), "target_node_not_found") || strings.HasPrefix(err.Error(), "vm_not_found") {
+				status = 404
+			}
+			c.JSON(status, internal.APIResponse[any]{Status: "error", Message: "compatibility_check_failed", Error: err.Error()})
+			return
+		}
+
+		c.JSON(200, internal.APIResponse[*migrationIface.CompatibilityReport]{Status: "success", Message: "compatibility_check_complete", Data: report})
+	}
+}
+
 type targetMigrationImportRequest struct {
 	GuestID            uint     `json:"guestId"`
 	OperationToken     string   `json:"operationToken"`
@@ -589,6 +627,11 @@ type CheckVMTargetRequest struct {
 	VNCPort    int                   `json:"vncPort"`
 	Switches   []CheckVMTargetSwitch `json:"switches"`
 	FsDatasets []string              `json:"fsDatasets"`
+
+	VCPUs              int      `json:"vcpus"`
+	CPUFeatures        []string `json:"cpuFeatures"`
+	BootROM            string   `json:"bootRom"`
+	PassthroughDevices []string `json:"passthroughDevices"`
 }
 
 func IntraClusterCheckVMTarget(libvirtService *libvirt.Service) gin.HandlerFunc {
@@ -689,14 +732,40 @@ func IntraClusterCheckVMTarget(libvirtService *libvirt.Service) gin.HandlerFunc
 			}
 		}
 
+		missingPassthrough := make([]string, 0, len(req.PassthroughDevices))
+		if db != nil {
+			for _, deviceID := range req.PassthroughDevices {
+				deviceID = strings.TrimSpace(deviceID)
+				if deviceID == "" {
+					continue
+				}
+				var ppt models.PassedThroughIDs
+				if err := db.Where("device_id = ?", deviceID).First(&ppt).Error; err != nil || ppt.Missing {
+					missingPassthrough = append(missingPassthrough, deviceID)
+				}
+			}
+		}
+
+		caps := libvirtService.GetCPUCapabilities(0)
+		bootROMAvailable := true
+		if strings.TrimSpace(req.BootROM) != "" {
+			bootROMAvailable = libvirt.BootROMAvailable(vmModels.VMBootROM(req.BootROM))
+		}
+
 		c.JSON(http.StatusOK, internal.APIResponse[map[string]any]{
 			Status:  "success",
 			Message: "vm_target_check_complete",
 			Data: map[string]any{
-				"missingMedia":      missingMedia,
-				"vncPortInUse":      vncPortInUse,
-				"missingSwitches":   missingSwitches,
-				"missingFsDatasets": missingFsDatasets,
+				"missingMedia":           missingMedia,
+				"vncPortInUse":           vncPortInUse,
+				"missingSwitches":        missingSwitches,
+				"missingFsDatasets":      missingFsDatasets,
+				"logicalCores":           caps.LogicalCores,
+				"virtualization":         caps.Virtualization,
+				"unsupportedCpuFeatures": libvirt.UnsupportedCPUFeatures(req.CPUFeatures),
+				"bootRomAvailable":       bootROMAvailable,
+				"tpmAvailable":           libvirt.TPMAvailable(),
+				"missingPassthrough":     missingPassthrough,
 			},
 		})
 	}
@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package networkHandlers
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strconv"
+
+	"github.com/alchemillahq/sylve/internal"
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	networkServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/network"
+	"github.com/alchemillahq/sylve/internal/services/network"
+	"github.com/gin-gonic/gin"
+)
+
+func packetCaptureErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, network.ErrInvalidPacketCapture):
+		return http.StatusBadRequest
+	case errors.Is(err, network.ErrPacketCaptureNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, network.ErrPacketCaptureRunning):
+		return http.StatusConflict
+	case errors.Is(err, network.ErrPacketCaptureLimit):
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func StartPacketCapture(svc *network.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req networkServiceInterfaces.StartPacketCaptureRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		capture, err := svc.StartPacketCapture(req)
+		if err != nil {
+			c.JSON(packetCaptureErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_start_packet_capture",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusAccepted, internal.APIResponse[*networkModels.PacketCapture]{
+			Status:  "success",
+			Message: "packet_capture_started",
+			Error:   "",
+			Data:    capture,
+		})
+	}
+}
+
+func ListPacketCaptures(svc *network.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		captures, err := svc.ListPacketCaptures()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_list_packet_captures",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]networkModels.PacketCapture]{
+			Status:  "success",
+			Message: "packet_captures_listed",
+			Error:   "",
+			Data:    captures,
+		})
+	}
+}
+
+func StopPacketCapture(svc *network.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := packetCaptureID(c)
+		if !ok {
+			return
+		}
+
+		if err := svc.StopPacketCapture(id); err != nil {
+			c.JSON(packetCaptureErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_stop_packet_capture",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "packet_capture_stopping",
+			Error:   "",
+			Data:    nil,
+		})
+	}
+}
+
+func DeletePacketCapture(svc *network.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := packetCaptureID(c)
+		if !ok {
+			return
+		}
+
+		if err := svc.DeletePacketCapture(id); err != nil {
+			c.JSON(packetCaptureErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_delete_packet_capture",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "packet_capture_deleted",
+			Error:   "",
+			Data:    nil,
+		})
+	}
+}
+
+func DownloadPacketCapture(svc *network.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := packetCaptureID(c)
+		if !ok {
+			return
+		}
+
+		path, err := svc.PacketCaptureFile(id)
+		if err != nil {
+			c.JSON(packetCaptureErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_download_packet_capture",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.Header("Content-Type", "application/vnd.tcpdump.pcap")
+		c.FileAttachment(path, filepath.Base(path))
+	}
+}
+
+func packetCaptureID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, strconv.IntSize)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+			Status:  "error",
+			Message: "invalid_id",
+			Error:   "invalid_packet_capture_id",
+			Data:    nil,
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
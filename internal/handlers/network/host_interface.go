@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package networkHandlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	networkServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/network"
+	"github.com/alchemillahq/sylve/internal/services/network"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func hostInterfaceErrorStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound),
+		strings.HasPrefix(msg, "host_interface_not_found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(msg, "invalid_"),
+		strings.HasPrefix(msg, "duplicate_"),
+		strings.HasPrefix(msg, "host_interface_"):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func ListHostInterfaces(svc *network.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		interfaces, err := svc.GetHostInterfaces()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_list_host_interfaces",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]networkModels.HostInterface]{
+			Status:  "success",
+			Message: "host_interfaces_listed",
+			Error:   "",
+			Data:    interfaces,
+		})
+	}
+}
+
+func UpsertHostInterface(svc *network.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req networkServiceInterfaces.UpsertHostInterfaceRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		hi, err := svc.UpsertHostInterface(&req)
+		if err != nil {
+			c.JSON(hostInterfaceErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_save_host_interface",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*networkModels.HostInterface]{
+			Status:  "success",
+			Message: "host_interface_saved",
+			Error:   "",
+			Data:    hi,
+		})
+	}
+}
+
+func DeleteHostInterface(svc *network.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_id",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if deleteErr := svc.DeleteHostInterface(uint(id)); deleteErr != nil {
+			c.JSON(hostInterfaceErrorStatus(deleteErr), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_delete_host_interface",
+				Error:   deleteErr.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "host_interface_deleted",
+			Error:   "",
+			Data:    nil,
+		})
+	}
+}
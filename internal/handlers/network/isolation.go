@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package networkHandlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/alchemillahq/sylve/internal"
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	networkServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/network"
+	"github.com/alchemillahq/sylve/internal/services/network"
+	"github.com/gin-gonic/gin"
+)
+
+func ListIsolationGroups(svc *network.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		groups, err := svc.GetIsolationGroups()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_list_isolation_groups",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]networkModels.IsolationGroup]{
+			Status:  "success",
+			Message: "isolation_groups_listed",
+			Error:   "",
+			Data:    groups,
+		})
+	}
+}
+
+func CreateIsolationGroup(svc *network.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req networkServiceInterfaces.UpsertIsolationGroupRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		id, err := svc.CreateIsolationGroup(&req)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_create_isolation_group",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[uint]{
+			Status:  "success",
+			Message: "isolation_group_created",
+			Error:   "",
+			Data:    id,
+		})
+	}
+}
+
+func EditIsolationGroup(svc *network.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_id",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		var req networkServiceInterfaces.UpsertIsolationGroupRequest
+		if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   bindErr.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if updateErr := svc.EditIsolationGroup(uint(id), &req); updateErr != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_edit_isolation_group",
+				Error:   updateErr.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "isolation_group_updated",
+			Error:   "",
+			Data:    nil,
+		})
+	}
+}
+
+func DeleteIsolationGroup(svc *network.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_id",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if deleteErr := svc.DeleteIsolationGroup(uint(id)); deleteErr != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_delete_isolation_group",
+				Error:   deleteErr.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "isolation_group_deleted",
+			Error:   "",
+			Data:    nil,
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/alchemillahq/sylve/internal"
 	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	networkServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/network"
 	"github.com/alchemillahq/sylve/internal/services/network"
 
 	"github.com/gin-gonic/gin"
@@ -270,3 +271,52 @@ func BulkDeleteNetworkObjects(svc *network.Service) gin.HandlerFunc {
 		})
 	}
 }
+
+// @Summary Import Network Objects
+// @Description Import network objects from a CSV or JSON export, optionally as a dry run that only reports what would be created
+// @Tags Network
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body networkServiceInterfaces.NetworkImportRequest true "Import Request"
+// @Success 200 {object} internal.APIResponse[networkServiceInterfaces.NetworkImportReport] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /network/object/import [post]
+func ImportNetworkObjects(svc *network.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request networkServiceInterfaces.NetworkImportRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		report, err := svc.ImportObjects(&request)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_import_objects",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		message := "objects_imported"
+		if report.DryRun {
+			message = "objects_import_planned"
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*networkServiceInterfaces.NetworkImportReport]{
+			Status:  "success",
+			Message: message,
+			Error:   "",
+			Data:    report,
+		})
+	}
+}
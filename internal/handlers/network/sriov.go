@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package networkHandlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	networkServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/network"
+	"github.com/alchemillahq/sylve/internal/services/network"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func sriovErrorStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case strings.HasPrefix(msg, "invalid_"),
+		strings.HasPrefix(msg, "duplicate_"),
+		strings.HasPrefix(msg, "sriov_not_supported"),
+		strings.HasSuffix(msg, "_not_supported_by_driver"):
+		return http.StatusBadRequest
+	case strings.HasPrefix(msg, "sriov_vf_in_use_by_vm"):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+func ListSRIOVInterfaces(svc *network.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		interfaces, err := svc.ListSRIOVInterfaces()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_list_sriov_interfaces",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]networkServiceInterfaces.SRIOVInterface]{
+			Status:  "success",
+			Message: "sriov_interfaces_listed",
+			Error:   "",
+			Data:    interfaces,
+		})
+	}
+}
+
+func ConfigureSRIOV(svc *network.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req networkServiceInterfaces.ConfigureSRIOVRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		pf, err := svc.ConfigureSRIOV(req)
+		if err != nil {
+			c.JSON(sriovErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_configure_sriov",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*networkModels.SRIOVPhysicalFunction]{
+			Status:  "success",
+			Message: "sriov_configured",
+			Error:   "",
+			Data:    pf,
+		})
+	}
+}
+
+func DeleteSRIOV(svc *network.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_id",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if deleteErr := svc.DeleteSRIOV(uint(id)); deleteErr != nil {
+			c.JSON(sriovErrorStatus(deleteErr), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_delete_sriov",
+				Error:   deleteErr.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "sriov_deleted",
+			Error:   "",
+			Data:    nil,
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package networkHandlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	networkServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/network"
+	"github.com/alchemillahq/sylve/internal/services/network"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func switchMTUErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "invalid_"):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// @Summary Get Standard Switch MTU Report
+// @Description Compare the configured MTU of a standard switch with its bridge, uplinks and guest interfaces
+// @Tags Network
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Switch ID"
+// @Success 200 {object} internal.APIResponse[networkServiceInterfaces.SwitchMTUReport] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 404 {object} internal.APIResponse[any] "Not Found"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /network/switch/standard/{id}/mtu [get]
+func GetStandardSwitchMTU(networkService *network.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_switch_id",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		report, err := networkService.GetStandardSwitchMTUReport(uint(id))
+		if err != nil {
+			c.JSON(switchMTUErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_get_switch_mtu",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*networkServiceInterfaces.SwitchMTUReport]{
+			Status:  "success",
+			Message: "switch_mtu_report",
+			Error:   "",
+			Data:    report,
+		})
+	}
+}
+
+// @Summary Propagate Standard Switch MTU
+// @Description Apply one MTU to a standard switch, its bridge, uplinks and attached guest interfaces
+// @Tags Network
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Switch ID"
+// @Param request body networkServiceInterfaces.PropagateSwitchMTURequest true "Propagate MTU Request"
+// @Success 200 {object} internal.APIResponse[networkServiceInterfaces.SwitchMTUReport] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 404 {object} internal.APIResponse[any] "Not Found"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /network/switch/standard/{id}/mtu [post]
+func PropagateStandardSwitchMTU(networkService *network.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_switch_id",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		var req networkServiceInterfaces.PropagateSwitchMTURequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		report, err := networkService.PropagateStandardSwitchMTU(uint(id), req.MTU)
+		if err != nil {
+			c.JSON(switchMTUErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_propagate_switch_mtu",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*networkServiceInterfaces.SwitchMTUReport]{
+			Status:  "success",
+			Message: "switch_mtu_propagated",
+			Error:   "",
+			Data:    report,
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/alchemillahq/sylve/internal"
 	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	networkServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/network"
 	"github.com/alchemillahq/sylve/internal/services/network"
 
 	"github.com/gin-gonic/gin"
@@ -404,3 +405,52 @@ func UpdateStandardSwitch(networkService *network.Service) gin.HandlerFunc {
 		})
 	}
 }
+
+// @Summary Import Standard Switches
+// @Description Import standard switches from a CSV or JSON export, optionally as a dry run that only reports what would be created
+// @Tags Network
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body networkServiceInterfaces.NetworkImportRequest true "Import Request"
+// @Success 200 {object} internal.APIResponse[networkServiceInterfaces.NetworkImportReport] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /network/switch/standard/import [post]
+func ImportStandardSwitches(svc *network.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request networkServiceInterfaces.NetworkImportRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		report, err := svc.ImportStandardSwitches(&request)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_import_switches",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		message := "switches_imported"
+		if report.DryRun {
+			message = "switches_import_planned"
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*networkServiceInterfaces.NetworkImportReport]{
+			Status:  "success",
+			Message: message,
+			Error:   "",
+			Data:    report,
+		})
+	}
+}
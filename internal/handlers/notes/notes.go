@@ -0,0 +1,287 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package notesHandlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/db/models"
+	"github.com/alchemillahq/sylve/internal/services/notes"
+	"github.com/gin-gonic/gin"
+)
+
+type SaveGuestNoteRequest struct {
+	Body         string `json:"body"`
+	BaseRevision int    `json:"baseRevision"`
+}
+
+func notesErrorStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "_not_found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(msg, "note_revision_conflict"):
+		return http.StatusConflict
+	case strings.HasPrefix(msg, "invalid_"),
+		strings.HasPrefix(msg, "note_too_large"),
+		strings.HasPrefix(msg, "attachment_too_large"),
+		strings.HasPrefix(msg, "too_many_attachments"):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func guestIDParam(c *gin.Context, param string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(param), 10, 32)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+			Status:  "error",
+			Message: "invalid_guest_id",
+			Error:   "guest id must be a positive integer",
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func respondNotesError(c *gin.Context, message string, err error) {
+	c.JSON(notesErrorStatus(err), internal.APIResponse[any]{
+		Status:  "error",
+		Message: message,
+		Error:   err.Error(),
+	})
+}
+
+// @Summary Get guest notes
+// @Description Get the markdown notes and attachment list of a VM (by RID) or jail (by CTID)
+// @Tags Notes
+// @Produce json
+// @Security BearerAuth
+// @Param rid path int true "VM RID or jail CTID"
+// @Success 200 {object} internal.APIResponse[notes.GuestNotes] "Success"
+// @Failure 404 {object} internal.APIResponse[any] "Not Found"
+// @Router /vm/notes/{rid} [get]
+// @Router /jail/notes/{ctid} [get]
+func GetGuestNotes(notesService *notes.Service, guestType string, param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		guestID, ok := guestIDParam(c, param)
+		if !ok {
+			return
+		}
+
+		out, err := notesService.GetNotes(guestType, guestID)
+		if err != nil {
+			respondNotesError(c, "failed_to_get_notes", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[notes.GuestNotes]{
+			Status:  "success",
+			Message: "notes_fetched",
+			Data:    out,
+		})
+	}
+}
+
+// @Summary Save guest notes
+// @Description Replace the markdown notes of a guest. baseRevision must match the current revision.
+// @Tags Notes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rid path int true "VM RID or jail CTID"
+// @Param request body SaveGuestNoteRequest true "Notes"
+// @Success 200 {object} internal.APIResponse[models.GuestNote] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 409 {object} internal.APIResponse[any] "Conflict"
+// @Router /vm/notes/{rid} [put]
+// @Router /jail/notes/{ctid} [put]
+func SaveGuestNote(notesService *notes.Service, guestType string, param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		guestID, ok := guestIDParam(c, param)
+		if !ok {
+			return
+		}
+
+		var req SaveGuestNoteRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		note, err := notesService.SaveNote(guestType, guestID, req.Body, req.BaseRevision, c.GetString("Username"))
+		if err != nil {
+			respondNotesError(c, "failed_to_save_notes", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[models.GuestNote]{
+			Status:  "success",
+			Message: "notes_saved",
+			Data:    note,
+		})
+	}
+}
+
+// @Summary List guest note revisions
+// @Description List earlier versions of a guest's notes, newest first
+// @Tags Notes
+// @Produce json
+// @Security BearerAuth
+// @Param rid path int true "VM RID or jail CTID"
+// @Success 200 {object} internal.APIResponse[[]models.GuestNoteRevision] "Success"
+// @Failure 404 {object} internal.APIResponse[any] "Not Found"
+// @Router /vm/notes/{rid}/revisions [get]
+// @Router /jail/notes/{ctid}/revisions [get]
+func ListGuestNoteRevisions(notesService *notes.Service, guestType string, param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		guestID, ok := guestIDParam(c, param)
+		if !ok {
+			return
+		}
+
+		revisions, err := notesService.ListRevisions(guestType, guestID)
+		if err != nil {
+			respondNotesError(c, "failed_to_list_note_revisions", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]models.GuestNoteRevision]{
+			Status:  "success",
+			Message: "note_revisions_listed",
+			Data:    revisions,
+		})
+	}
+}
+
+// @Summary Upload guest note attachment
+// @Description Attach a small file to a guest's notes
+// @Tags Notes
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param rid path int true "VM RID or jail CTID"
+// @Param file formData file true "Attachment"
+// @Success 200 {object} internal.APIResponse[models.GuestNoteAttachment] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Router /vm/notes/{rid}/attachments [post]
+// @Router /jail/notes/{ctid}/attachments [post]
+func UploadGuestNoteAttachment(notesService *notes.Service, guestType string, param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		guestID, ok := guestIDParam(c, param)
+		if !ok {
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, notes.MaxAttachmentBytes+(1<<20))
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_attachment_upload",
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_attachment_upload",
+				Error:   err.Error(),
+			})
+			return
+		}
+		defer file.Close()
+
+		attachment, err := notesService.AddAttachment(guestType, guestID, fileHeader.Filename, file, c.GetString("Username"))
+		if err != nil {
+			respondNotesError(c, "failed_to_add_attachment", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[models.GuestNoteAttachment]{
+			Status:  "success",
+			Message: "attachment_added",
+			Data:    attachment,
+		})
+	}
+}
+
+// @Summary Download guest note attachment
+// @Tags Notes
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param rid path int true "VM RID or jail CTID"
+// @Param attachmentId path int true "Attachment ID"
+// @Success 200 {file} file "Attachment"
+// @Failure 404 {object} internal.APIResponse[any] "Not Found"
+// @Router /vm/notes/{rid}/attachments/{attachmentId} [get]
+// @Router /jail/notes/{ctid}/attachments/{attachmentId} [get]
+func DownloadGuestNoteAttachment(notesService *notes.Service, guestType string, param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		guestID, ok := guestIDParam(c, param)
+		if !ok {
+			return
+		}
+		attachmentID, ok := guestIDParam(c, "attachmentId")
+		if !ok {
+			return
+		}
+
+		attachment, err := notesService.GetAttachment(guestType, guestID, attachmentID)
+		if err != nil {
+			respondNotesError(c, "failed_to_get_attachment", err)
+			return
+		}
+
+		c.FileAttachment(attachment.Path, attachment.Name)
+	}
+}
+
+// @Summary Delete guest note attachment
+// @Tags Notes
+// @Produce json
+// @Security BearerAuth
+// @Param rid path int true "VM RID or jail CTID"
+// @Param attachmentId path int true "Attachment ID"
+// @Success 200 {object} internal.APIResponse[any] "Success"
+// @Failure 404 {object} internal.APIResponse[any] "Not Found"
+// @Router /vm/notes/{rid}/attachments/{attachmentId} [delete]
+// @Router /jail/notes/{ctid}/attachments/{attachmentId} [delete]
+func DeleteGuestNoteAttachment(notesService *notes.Service, guestType string, param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		guestID, ok := guestIDParam(c, param)
+		if !ok {
+			return
+		}
+		attachmentID, ok := guestIDParam(c, "attachmentId")
+		if !ok {
+			return
+		}
+
+		if err := notesService.DeleteAttachment(guestType, guestID, attachmentID); err != nil {
+			respondNotesError(c, "failed_to_delete_attachment", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "attachment_deleted",
+		})
+	}
+}
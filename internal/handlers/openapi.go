@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary OpenAPI specification
+// @Description OpenAPI 3 document describing this API
+// @Tags Health
+// @Produce json
+// @Success 200 {object} map[string]any "OpenAPI document"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /openapi.json [get]
+func OpenAPISpecHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		spec, err := openapi.Spec()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "internal_server_error",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.Data(http.StatusOK, "application/json", spec)
+	}
+}
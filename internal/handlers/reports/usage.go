@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package reportsHandlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/services/usage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Guest Usage Report
+// @Description Per-guest CPU-hours, RAM-hours, storage GiB-days and network bytes for a billing month, grouped by guest or pool
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Produce text/csv
+// @Security BearerAuth
+// @Param period query string false "Billing month (YYYY-MM), defaults to the current month"
+// @Param groupBy query string false "guest or pool"
+// @Param format query string false "json or csv"
+// @Success 200 {object} internal.APIResponse[usage.Report] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /reports/usage [get]
+func UsageReport(usageService *usage.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report, err := usageService.Report(c.Query("period"), c.Query("groupBy"))
+		if err != nil {
+			status := http.StatusInternalServerError
+			message := "failed_to_build_usage_report"
+			if strings.HasPrefix(err.Error(), "invalid_period") {
+				status, message = http.StatusBadRequest, "invalid_period"
+			} else if strings.HasPrefix(err.Error(), "invalid_group_by") {
+				status, message = http.StatusBadRequest, "invalid_group_by"
+			}
+			c.JSON(status, internal.APIResponse[any]{
+				Status:  "error",
+				Message: message,
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if strings.EqualFold(c.Query("format"), "csv") {
+			var buf bytes.Buffer
+			if err := usage.WriteCSV(&buf, report); err != nil {
+				c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+					Status:  "error",
+					Message: "failed_to_build_usage_report",
+					Error:   err.Error(),
+					Data:    nil,
+				})
+				return
+			}
+			c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"usage-%s-%s.csv\"", report.Period, report.GroupBy))
+			c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*usage.Report]{
+			Status:  "success",
+			Message: "usage_report_fetched",
+			Error:   "",
+			Data:    report,
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 	diskHandlers "github.com/alchemillahq/sylve/internal/handlers/disk"
 	dynamicDNSHandlers "github.com/alchemillahq/sylve/internal/handlers/dynamicdns"
 	eventsHandlers "github.com/alchemillahq/sylve/internal/handlers/events"
+	guestAlertsHandlers "github.com/alchemillahq/sylve/internal/handlers/guestalerts"
 	infoHandlers "github.com/alchemillahq/sylve/internal/handlers/info"
 	iscsiHandlers "github.com/alchemillahq/sylve/internal/handlers/iscsi"
 	jailHandlers "github.com/alchemillahq/sylve/internal/handlers/jail"
@@ -33,18 +34,24 @@ import (
 	"github.com/alchemillahq/sylve/internal/handlers/middleware"
 	migrationHandlers "github.com/alchemillahq/sylve/internal/handlers/migration"
 	networkHandlers "github.com/alchemillahq/sylve/internal/handlers/network"
+	notesHandlers "github.com/alchemillahq/sylve/internal/handlers/notes"
 	notificationsHandlers "github.com/alchemillahq/sylve/internal/handlers/notifications"
+	reportsHandlers "github.com/alchemillahq/sylve/internal/handlers/reports"
 	sambaHandlers "github.com/alchemillahq/sylve/internal/handlers/samba"
+	searchHandlers "github.com/alchemillahq/sylve/internal/handlers/search"
 	systemHandlers "github.com/alchemillahq/sylve/internal/handlers/system"
 	taskHandlers "github.com/alchemillahq/sylve/internal/handlers/task"
 	utilitiesHandlers "github.com/alchemillahq/sylve/internal/handlers/utilities"
 	vmHandlers "github.com/alchemillahq/sylve/internal/handlers/vm"
 	vncHandler "github.com/alchemillahq/sylve/internal/handlers/vnc"
+	webhookHandlers "github.com/alchemillahq/sylve/internal/handlers/webhooks"
 	zfsHandlers "github.com/alchemillahq/sylve/internal/handlers/zfs"
 	authService "github.com/alchemillahq/sylve/internal/services/auth"
 	"github.com/alchemillahq/sylve/internal/services/cluster"
+	"github.com/alchemillahq/sylve/internal/services/desiredstate"
 	diskService "github.com/alchemillahq/sylve/internal/services/disk"
 	"github.com/alchemillahq/sylve/internal/services/dynamicdns"
+	"github.com/alchemillahq/sylve/internal/services/guestalerts"
 	infoService "github.com/alchemillahq/sylve/internal/services/info"
 	"github.com/alchemillahq/sylve/internal/services/iscsi"
 	"github.com/alchemillahq/sylve/internal/services/jail"
@@ -53,12 +60,17 @@ import (
 	"github.com/alchemillahq/sylve/internal/services/mdns"
 	"github.com/alchemillahq/sylve/internal/services/migration"
 	networkService "github.com/alchemillahq/sylve/internal/services/network"
+	"github.com/alchemillahq/sylve/internal/services/notes"
 	notificationsService "github.com/alchemillahq/sylve/internal/services/notifications"
 	"github.com/alchemillahq/sylve/internal/services/samba"
+	"github.com/alchemillahq/sylve/internal/services/search"
 	systemService "github.com/alchemillahq/sylve/internal/services/system"
+	"github.com/alchemillahq/sylve/internal/services/usage"
 	utilitiesService "github.com/alchemillahq/sylve/internal/services/utilities"
+	"github.com/alchemillahq/sylve/internal/services/webhooks"
 	"github.com/alchemillahq/sylve/internal/services/zelta"
 	zfsService "github.com/alchemillahq/sylve/internal/services/zfs"
+	"github.com/alchemillahq/sylve/pkg/zfstrace"
 )
 
 // @title           Sylve API
@@ -95,29 +107,46 @@ func RegisterRoutes(r *gin.Engine,
 	sambaService *samba.Service,
 	mdnsService *mdns.Service,
 	dynamicDNSService *dynamicdns.Service,
+	webhookService *webhooks.Service,
 	iscsiService *iscsi.Service,
 	jailService *jail.Service,
 	lifecycleService *lifecycle.Service,
 	clusterService *cluster.Service,
 	zeltaService *zelta.Service,
 	migrationService *migration.Service,
+	usageService *usage.Service,
+	notesService *notes.Service,
+	guestAlertService *guestalerts.Service,
 	fsm *clusterModels.FSMDispatcher,
 	db *gorm.DB,
 	telemetryDB *gorm.DB,
 ) {
 	api := r.Group("/api")
+	api.Use(middleware.ErrorCatalog())
+	api.GET("/openapi.json", OpenAPISpecHandler())
 	api.GET("/auth/login/config", authHandlers.LoginConfigHandler())
+	api.GET("/auth/login/banner", authHandlers.LoginBannerHandler(authService))
+	api.GET("/healthz", LivenessHandler)
+	api.GET("/readyz", ReadinessHandler(db, clusterService, libvirtService))
 
 	health := api.Group("/health")
 	health.Use(middleware.EnsureAuthenticated(authService))
+	health.Use(middleware.ValidateRequests())
 	{
 		health.GET("/basic", BasicHealthCheckHandler(systemService))
 		health.POST("/basic", BasicHealthCheckHandler(systemService))
 		health.GET("/http", HTTPHealthCheckHandler)
 	}
 
+	metrics := api.Group("/metrics")
+	metrics.Use(middleware.EnsureAuthenticated(authService))
+	{
+		metrics.GET("", MetricsHandler(zeltaService))
+	}
+
 	basic := api.Group("/basic")
 	basic.Use(middleware.EnsureAuthenticated(authService))
+	basic.Use(middleware.ValidateRequests())
 	{
 		basic.GET("/settings", basicHandlers.GetBasicSettings(systemService))
 		basic.POST("/initialize", basicHandlers.Initialize(systemService))
@@ -126,6 +155,7 @@ func RegisterRoutes(r *gin.Engine,
 
 	info := api.Group("/info")
 	info.Use(middleware.EnsureAuthenticated(authService))
+	info.Use(middleware.ValidateRequests())
 	info.Use(EnsureCorrectHost(db, authService))
 	info.Use(middleware.RequestLoggerMiddleware(telemetryDB, authService))
 	{
@@ -155,10 +185,12 @@ func RegisterRoutes(r *gin.Engine,
 		info.GET("/terminal", infoHandlers.HandleHostTerminal)
 
 		info.GET("/node", infoHandlers.NodeInfo(infoService))
+		info.GET("/clock", infoHandlers.ClockInfo(infoService))
 	}
 
 	zfs := api.Group("/zfs")
 	zfs.Use(middleware.EnsureAuthenticated(authService))
+	zfs.Use(middleware.ValidateRequests())
 	zfs.Use(EnsureCorrectHost(db, authService))
 	zfs.Use(middleware.RequestLoggerMiddleware(telemetryDB, authService))
 	{
@@ -177,6 +209,15 @@ func RegisterRoutes(r *gin.Engine,
 			)
 			pools.PATCH("/:guid/replace-device", zfsHandlers.ReplaceDevice(infoService, zfsService))
 			pools.POST("/:guid/detach", zfsHandlers.DetachDevice(infoService, zfsService))
+			pools.GET("/:guid/spares", zfsHandlers.GetHotSpares(zfsService))
+			pools.PUT("/:guid/spares", zfsHandlers.SetHotSparePolicy(zfsService))
+		}
+
+		delegations := zfs.Group("/delegations")
+		{
+			delegations.GET("", zfsHandlers.ListDelegations(zfsService))
+			delegations.POST("", zfsHandlers.Delegate(zfsService))
+			delegations.DELETE("/:id", zfsHandlers.RemoveDelegation(zfsService))
 		}
 
 		datasets := zfs.Group("/datasets")
@@ -189,6 +230,10 @@ func RegisterRoutes(r *gin.Engine,
 				zfsHandlers.ReplicationDatasetMutationGuard(zfsService, zfsHandlers.ReplicationGuardRollbackSnapshot),
 				zfsHandlers.RollbackSnapshot(zfsService),
 			)
+			datasets.POST("/snapshot/rename",
+				zfsHandlers.ReplicationDatasetMutationGuard(zfsService, zfsHandlers.ReplicationGuardRenameSnapshot),
+				zfsHandlers.RenameSnapshot(zfsService),
+			)
 			datasets.DELETE("/snapshot/:guid",
 				zfsHandlers.ReplicationDatasetMutationGuard(zfsService, zfsHandlers.ReplicationGuardDatasetGUID),
 				zfsHandlers.DeleteSnapshot(zfsService),
@@ -238,11 +283,17 @@ func RegisterRoutes(r *gin.Engine,
 				zfsHandlers.ReplicationDatasetMutationGuard(zfsService, zfsHandlers.ReplicationGuardBulkNames),
 				zfsHandlers.BulkDeleteDatasetsByName(zfsService),
 			)
+
+			datasets.POST("/rename",
+				zfsHandlers.ReplicationDatasetMutationGuard(zfsService, zfsHandlers.ReplicationGuardRenameDataset),
+				zfsHandlers.RenameDataset(zfsService),
+			)
 		}
 	}
 
 	samba := api.Group("/samba")
 	samba.Use(middleware.EnsureAuthenticated(authService))
+	samba.Use(middleware.ValidateRequests())
 	samba.Use(EnsureCorrectHost(db, authService))
 	samba.Use(middleware.RequestLoggerMiddleware(telemetryDB, authService))
 	{
@@ -254,11 +305,16 @@ func RegisterRoutes(r *gin.Engine,
 		samba.PUT("/shares", sambaHandlers.UpdateShare(sambaService))
 		samba.DELETE("/shares/:id", sambaHandlers.DeleteShare(sambaService))
 
+		samba.GET("/shares/:id/quotas", sambaHandlers.GetShareQuotas(sambaService))
+		samba.PUT("/shares/:id/quotas", sambaHandlers.SetShareQuota(sambaService))
+		samba.DELETE("/shares/:id/quotas/:type/:name", sambaHandlers.RemoveShareQuota(sambaService))
+
 		samba.GET("/audit-logs", sambaHandlers.GetAuditLogs(sambaService))
 	}
 
 	mdnsGroup := api.Group("/mdns")
 	mdnsGroup.Use(middleware.EnsureAuthenticated(authService))
+	mdnsGroup.Use(middleware.ValidateRequests())
 	mdnsGroup.Use(EnsureCorrectHost(db, authService))
 	mdnsGroup.Use(middleware.RequestLoggerMiddleware(telemetryDB, authService))
 	{
@@ -273,6 +329,7 @@ func RegisterRoutes(r *gin.Engine,
 
 	dynamicDNSGroup := api.Group("/dynamic-dns")
 	dynamicDNSGroup.Use(middleware.EnsureAuthenticated(authService))
+	dynamicDNSGroup.Use(middleware.ValidateRequests())
 	dynamicDNSGroup.Use(EnsureCorrectHost(db, authService))
 	dynamicDNSGroup.Use(middleware.RequestLoggerMiddleware(telemetryDB, authService))
 	{
@@ -283,8 +340,24 @@ func RegisterRoutes(r *gin.Engine,
 		dynamicDNSGroup.POST("/entries/:id/sync", dynamicDNSHandlers.SyncEntry(dynamicDNSService))
 	}
 
+	webhookGroup := api.Group("/webhooks")
+	webhookGroup.Use(middleware.EnsureAuthenticated(authService))
+	webhookGroup.Use(middleware.ValidateRequests())
+	webhookGroup.Use(EnsureCorrectHost(db, authService))
+	webhookGroup.Use(middleware.RequestLoggerMiddleware(telemetryDB, authService))
+	{
+		webhookGroup.GET("", webhookHandlers.ListWebhooks(webhookService))
+		webhookGroup.POST("", webhookHandlers.CreateWebhook(webhookService))
+		webhookGroup.PUT("/:id", webhookHandlers.UpdateWebhook(webhookService))
+		webhookGroup.DELETE("/:id", webhookHandlers.DeleteWebhook(webhookService))
+		webhookGroup.POST("/:id/test", webhookHandlers.TestWebhook(webhookService))
+		webhookGroup.GET("/:id/deliveries", webhookHandlers.ListDeliveries(webhookService))
+		webhookGroup.POST("/:id/deliveries/:deliveryId/redeliver", webhookHandlers.RedeliverDelivery(webhookService))
+	}
+
 	iscsiGroup := api.Group("/iscsi")
 	iscsiGroup.Use(middleware.EnsureAuthenticated(authService))
+	iscsiGroup.Use(middleware.ValidateRequests())
 	iscsiGroup.Use(EnsureCorrectHost(db, authService))
 	iscsiGroup.Use(middleware.RequestLoggerMiddleware(telemetryDB, authService))
 	{
@@ -308,6 +381,7 @@ func RegisterRoutes(r *gin.Engine,
 
 	disk := api.Group("/disk")
 	disk.Use(middleware.EnsureAuthenticated(authService))
+	disk.Use(middleware.ValidateRequests())
 	disk.Use(EnsureCorrectHost(db, authService))
 	disk.Use(middleware.RequestLoggerMiddleware(telemetryDB, authService))
 	{
@@ -319,6 +393,8 @@ func RegisterRoutes(r *gin.Engine,
 		disk.POST("/smart/self-test/schedules", diskHandlers.CreateSelfTestSchedule(diskService))
 		disk.PUT("/smart/self-test/schedules/:id", diskHandlers.UpdateSelfTestSchedule(diskService))
 		disk.DELETE("/smart/self-test/schedules/:id", diskHandlers.DeleteSelfTestSchedule(diskService))
+		disk.GET("/benchmark", diskHandlers.ListBenchmarks(diskService))
+		disk.POST("/benchmark", diskHandlers.StartBenchmark(diskService))
 		disk.POST("/wipe", diskHandlers.WipeDisk(diskService, infoService))
 		disk.POST("/initialize-gpt", diskHandlers.InitializeGPT(diskService, infoService))
 		disk.POST("/create-partitions", diskHandlers.CreatePartition(infoService))
@@ -327,12 +403,14 @@ func RegisterRoutes(r *gin.Engine,
 
 	network := api.Group("/network")
 	network.Use(middleware.EnsureAuthenticated(authService))
+	network.Use(middleware.ValidateRequests())
 	network.Use(EnsureCorrectHost(db, authService))
 	network.Use(middleware.RequestLoggerMiddleware(telemetryDB, authService))
 	{
 		network.GET("/object", networkHandlers.ListNetworkObjects(networkService))
 		network.POST("/object", networkHandlers.CreateNetworkObject(networkService))
 		network.POST("/object/bulk-delete", networkHandlers.BulkDeleteNetworkObjects(networkService))
+		network.POST("/object/import", networkHandlers.ImportNetworkObjects(networkService))
 		network.DELETE("/object/:id", networkHandlers.DeleteNetworkObject(networkService))
 		network.PUT("/object/:id", networkHandlers.EditNetworkObject(networkService))
 
@@ -362,6 +440,17 @@ func RegisterRoutes(r *gin.Engine,
 		network.DELETE("/route/:id", networkHandlers.DeleteStaticRoute(networkService))
 		network.POST("/route/suggest-from-nat/:id", networkHandlers.SuggestStaticRoutesFromNATRule(networkService))
 
+		network.GET("/isolation-group", networkHandlers.ListIsolationGroups(networkService))
+		network.POST("/isolation-group", networkHandlers.CreateIsolationGroup(networkService))
+		network.PUT("/isolation-group/:id", networkHandlers.EditIsolationGroup(networkService))
+		network.DELETE("/isolation-group/:id", networkHandlers.DeleteIsolationGroup(networkService))
+
+		network.GET("/capture", networkHandlers.ListPacketCaptures(networkService))
+		network.POST("/capture", networkHandlers.StartPacketCapture(networkService))
+		network.POST("/capture/:id/stop", networkHandlers.StopPacketCapture(networkService))
+		network.GET("/capture/:id/download", networkHandlers.DownloadPacketCapture(networkService))
+		network.DELETE("/capture/:id", networkHandlers.DeletePacketCapture(networkService))
+
 		network.GET("/wireguard/server", networkHandlers.GetWireGuardServer(networkService))
 		network.POST("/wireguard/server", networkHandlers.InitWireGuardServer(networkService))
 		network.PUT("/wireguard/server", networkHandlers.EditWireGuardServer(networkService))
@@ -381,14 +470,24 @@ func RegisterRoutes(r *gin.Engine,
 		network.PUT("/wireguard/clients/toggle/:clientId", networkHandlers.ToggleWireGuardClient(networkService))
 
 		network.GET("/interface", networkHandlers.ListInterfaces(networkService))
+		network.GET("/interface/config", networkHandlers.ListHostInterfaces(networkService))
+		network.PUT("/interface/config", networkHandlers.UpsertHostInterface(networkService))
+		network.DELETE("/interface/config/:id", networkHandlers.DeleteHostInterface(networkService))
+
+		network.GET("/sriov", networkHandlers.ListSRIOVInterfaces(networkService))
+		network.PUT("/sriov", networkHandlers.ConfigureSRIOV(networkService))
+		network.DELETE("/sriov/:id", networkHandlers.DeleteSRIOV(networkService))
 
 		network.POST("/manual-switch", networkHandlers.CreateManualSwitch(networkService))
 		network.DELETE("/manual-switch/:id", networkHandlers.DeleteManualSwitch(networkService))
 
 		network.GET("/switch", networkHandlers.ListSwitches(networkService))
 		network.POST("/switch/standard", networkHandlers.CreateStandardSwitch(networkService))
+		network.POST("/switch/standard/import", networkHandlers.ImportStandardSwitches(networkService))
 		network.DELETE("/switch/standard/:id", networkHandlers.DeleteStandardSwitch(networkService))
 		network.PUT("/switch/standard", networkHandlers.UpdateStandardSwitch(networkService))
+		network.GET("/switch/standard/:id/mtu", networkHandlers.GetStandardSwitchMTU(networkService))
+		network.POST("/switch/standard/:id/mtu", networkHandlers.PropagateStandardSwitchMTU(networkService))
 
 		network.GET("/dhcp/config", networkHandlers.GetDHCPConfig(networkService))
 		network.PUT("/dhcp/config", networkHandlers.ModifyDHCPConfig(networkService))
@@ -405,22 +504,35 @@ func RegisterRoutes(r *gin.Engine,
 		network.POST("/dhcp/lease/dynamic", networkHandlers.DeleteDynamicDHCPLease(networkService))
 	}
 
+	desiredStateService := desiredstate.NewService(db, networkService, libvirtService, jailService, clusterService)
+
 	system := api.Group("/system")
 	system.Use(middleware.EnsureAuthenticated(authService))
+	system.Use(middleware.ValidateRequests())
 	system.Use(EnsureCorrectHost(db, authService))
 	system.Use(middleware.RequestLoggerMiddleware(telemetryDB, authService))
 	{
 		system.GET("/pci-devices", systemHandlers.ListDevices())
+		system.GET("/usb-devices", systemHandlers.ListUSBDevices(systemService))
 		system.GET("/ppt-devices", systemHandlers.ListPPTDevices(systemService))
 		system.POST("/ppt-devices", systemHandlers.AddPPTDevice(systemService))
 		system.POST("/ppt-devices/prepare", systemHandlers.PreparePPTDevice(systemService))
 		system.POST("/ppt-devices/import", systemHandlers.ImportPPTDevice(systemService))
 		system.DELETE("/ppt-devices/:id", systemHandlers.RemovePPTDevice(systemService))
+		system.POST("/ppt-devices/:id/reclaim", systemHandlers.ReclaimPPTDevice(systemService))
 		system.GET("/basic-settings", systemHandlers.BasicSettings(systemService))
 		system.PUT("/basic-settings/pools", systemHandlers.AddUsablePools(systemService))
+		system.GET("/pools/adoptable", systemHandlers.ListAdoptablePools(systemService))
+		system.POST("/pools/adopt", systemHandlers.AdoptPool(systemService))
 		system.PUT("/basic-settings/services/:service/toggle", systemHandlers.ToggleService(systemService, networkService))
 		system.GET("/tunables/remote", systemHandlers.TunablesRemote(systemService))
 		system.PUT("/tunables", systemHandlers.SetTunable(systemService))
+		system.GET("/consistency", systemHandlers.ConsistencyReport(lifecycleService))
+		system.POST("/consistency/check", systemHandlers.CheckConsistency(lifecycleService))
+		system.POST("/consistency/fix", systemHandlers.ApplyConsistencyFixes(lifecycleService))
+		system.POST("/apply", middleware.RequireLocalAdmin(authService), systemHandlers.ApplyDesiredState(desiredStateService))
+		system.POST("/selftest", middleware.RequireLocalAdmin(authService), systemHandlers.RunSelfTest(systemService))
+		system.GET("/zfs-trace", systemHandlers.ZFSTrace(zfstrace.Default))
 	}
 
 	fileExplorer := system.Group("/file-explorer")
@@ -444,8 +556,24 @@ func RegisterRoutes(r *gin.Engine,
 		fileExplorer.DELETE("/upload", systemHandlers.DeleteUpload(systemService))
 	}
 
+	managedFiles := system.Group("/managed-files")
+	managedFiles.Use(middleware.EnsureAuthenticated(authService))
+	managedFiles.Use(EnsureCorrectHost(db, authService))
+	managedFiles.Use(middleware.RequestLoggerMiddleware(telemetryDB, authService))
+	{
+		managedFiles.GET("/isos", systemHandlers.ListManagedFiles(systemService))
+		managedFiles.GET("/isos/download", systemHandlers.DownloadManagedFile(systemService))
+		managedFiles.POST("/isos/upload", systemHandlers.UploadManagedFile(systemService))
+
+		jailFiles := managedFiles.Group("/jails/:ctid", middleware.RequireLocalAdmin(authService))
+		jailFiles.GET("", systemHandlers.ListManagedFiles(systemService))
+		jailFiles.GET("/download", systemHandlers.DownloadManagedFile(systemService))
+		jailFiles.POST("/upload", systemHandlers.UploadManagedFile(systemService))
+	}
+
 	vm := api.Group("/vm")
 	vm.Use(middleware.EnsureAuthenticated(authService))
+	vm.Use(middleware.ValidateRequests())
 	vm.Use(EnsureCorrectHost(db, authService))
 	vm.Use(middleware.RequestLoggerMiddleware(telemetryDB, authService))
 	{
@@ -458,7 +586,11 @@ func RegisterRoutes(r *gin.Engine,
 		vm.POST("/templates/create/:id", vmHandlers.CreateVMFromTemplate(libvirtService, lifecycleService))
 		vm.DELETE("/templates/:id", vmHandlers.DeleteVMTemplate(libvirtService))
 		vm.GET("/simple/:id", vmHandlers.GetSimpleVMByIdentifier(libvirtService))
+		vm.GET("/snapshots/schedule/:id", vmHandlers.GetVMSnapshotSchedule(libvirtService))
+		vm.PUT("/snapshots/schedule/:id", vmHandlers.SetVMSnapshotSchedule(libvirtService))
+		vm.DELETE("/snapshots/schedule/:id", vmHandlers.DeleteVMSnapshotSchedule(libvirtService))
 		vm.GET("/snapshots/:id", vmHandlers.ListVMSnapshots(libvirtService))
+		vm.GET("/snapshots/:id/:snapshotId/diff", vmHandlers.DiffVMSnapshot(libvirtService))
 		vm.POST("/snapshots/:id", vmHandlers.CreateVMSnapshot(libvirtService))
 		vm.POST("/snapshots/rollback/:id/:snapshotId",
 			vmHandlers.RequireVMReplicationTopologyMutable(libvirtService, "id"),
@@ -470,7 +602,10 @@ func RegisterRoutes(r *gin.Engine,
 		)
 		vm.GET("/:id", vmHandlers.GetVMByIdentifier(libvirtService))
 		vm.GET("", vmHandlers.ListVMs(libvirtService))
-		vm.POST("", vmHandlers.CreateVM(libvirtService))
+		vm.POST("", vmHandlers.CreateVM(libvirtService, lifecycleService))
+		vm.POST("/validate", vmHandlers.ValidateCreateVM(libvirtService))
+		vm.GET("/presets/:name", vmHandlers.GetVMPreset(libvirtService))
+		vm.POST("/presets/windows/driver-media", vmHandlers.FetchWindowsDriverMedia(libvirtService, utilitiesService))
 		vm.DELETE("/:id",
 			vmHandlers.RequireVMDeletionDetached(libvirtService, "id"),
 			vmHandlers.RequireVMReplicationTopologyMutable(libvirtService, "id"),
@@ -479,18 +614,28 @@ func RegisterRoutes(r *gin.Engine,
 		vm.GET("/domain/:rid", vmHandlers.GetLvDomain(libvirtService, lifecycleService))
 		vm.GET("/logs/:rid", vmHandlers.GetVMLogs(libvirtService))
 		vm.GET("/stats/:rid/:step", vmHandlers.GetVMStats(libvirtService))
+		// Shares the :id wildcard with GET /vm/:id; the value is the RID.
+		vm.GET("/:id/flows", vmHandlers.GetVMFlows(libvirtService))
 		vm.PUT("/description", vmHandlers.UpdateVMDescription(libvirtService))
+		vm.GET("/notes/:rid", notesHandlers.GetGuestNotes(notesService, notes.GuestTypeVM, "rid"))
+		vm.PUT("/notes/:rid", notesHandlers.SaveGuestNote(notesService, notes.GuestTypeVM, "rid"))
+		vm.GET("/notes/:rid/revisions", notesHandlers.ListGuestNoteRevisions(notesService, notes.GuestTypeVM, "rid"))
+		vm.POST("/notes/:rid/attachments", notesHandlers.UploadGuestNoteAttachment(notesService, notes.GuestTypeVM, "rid"))
+		vm.GET("/notes/:rid/attachments/:attachmentId", notesHandlers.DownloadGuestNoteAttachment(notesService, notes.GuestTypeVM, "rid"))
+		vm.DELETE("/notes/:rid/attachments/:attachmentId", notesHandlers.DeleteGuestNoteAttachment(notesService, notes.GuestTypeVM, "rid"))
 		vm.PUT("/name", vmHandlers.UpdateVMName(libvirtService, clusterService))
 
 		vm.POST("/storage/detach", vmHandlers.StorageDetach(libvirtService))
 		vm.POST("/storage/attach", vmHandlers.StorageAttach(libvirtService))
 		vm.PUT("/storage/update", vmHandlers.StorageUpdate(libvirtService))
 
-		vm.POST("/network/detach", vmHandlers.NetworkDetach(libvirtService))
-		vm.POST("/network/attach", vmHandlers.NetworkAttach(libvirtService))
-		vm.PUT("/network/update", vmHandlers.NetworkUpdate(libvirtService))
+		vm.POST("/network/detach", vmHandlers.NetworkDetach(libvirtService, networkService))
+		vm.POST("/network/attach", vmHandlers.NetworkAttach(libvirtService, networkService))
+		vm.PUT("/network/update", vmHandlers.NetworkUpdate(libvirtService, networkService))
 
+		vm.GET("/hardware/cpu/capabilities", vmHandlers.GetCPUCapabilities(libvirtService))
 		vm.PUT("/hardware/cpu/:rid", vmHandlers.ModifyCPU(libvirtService))
+		vm.PUT("/hardware/cpu-features/:rid", vmHandlers.ModifyCPUFeatures(libvirtService))
 		vm.PUT("/hardware/ram/:rid", vmHandlers.ModifyRAM(libvirtService))
 		vm.PUT("/hardware/vnc/:rid", vmHandlers.ModifyVNC(libvirtService))
 		vm.PUT("/hardware/ppt/:rid", vmHandlers.ModifyPassthroughDevices(libvirtService))
@@ -501,18 +646,22 @@ func RegisterRoutes(r *gin.Engine,
 		vm.PUT("/options/serial-console/:rid", vmHandlers.ModifySerialConsole(libvirtService))
 		vm.PUT("/options/shutdown-wait-time/:rid", vmHandlers.ModifyShutdownWaitTime(libvirtService))
 		vm.PUT("/options/cloud-init/:rid", vmHandlers.ModifyCloudInitData(libvirtService))
+		vm.PUT("/options/guest-metadata/:rid", vmHandlers.ModifyGuestMetadata(libvirtService))
 		vm.PUT("/options/boot-rom/:rid", vmHandlers.ModifyBootROM(libvirtService))
 		vm.PUT("/options/extra-bhyve-options/:rid", vmHandlers.ModifyExtraBhyveOptions(libvirtService))
 		vm.PUT("/options/ignore-umsrs/:rid", vmHandlers.ModifyIgnoreUMSRs(libvirtService))
 		vm.PUT("/options/qemu-guest-agent/:rid", vmHandlers.ModifyQemuGuestAgent(libvirtService))
 		vm.PUT("/options/tpm/:rid", vmHandlers.ModifyTPM(libvirtService))
 		vm.GET("/qga/:rid", vmHandlers.GetQemuGuestAgentInfo(libvirtService))
+		vm.POST("/qga/:rid/exec", middleware.RequireLocalAdmin(authService), vmHandlers.ExecInGuest(libvirtService))
 
 		vm.GET("/console", vmHandlers.HandleLibvirtTerminalWebsocket(libvirtService))
+		vm.GET("/live-stats", vmHandlers.HandleVMLiveStatsWebsocket(libvirtService))
 	}
 
 	jail := api.Group("/jail")
 	jail.Use(middleware.EnsureAuthenticated(authService))
+	jail.Use(middleware.ValidateRequests())
 	jail.Use(EnsureCorrectHost(db, authService))
 	jail.Use(middleware.RequestLoggerMiddleware(telemetryDB, authService))
 	{
@@ -520,6 +669,10 @@ func RegisterRoutes(r *gin.Engine,
 		jail.GET("/bootstraps", jailHandlers.ListBootstraps(jailService))
 		jail.POST("/bootstrap", jailHandlers.CreateBootstrap(jailService))
 		jail.DELETE("/bootstrap", jailHandlers.DeleteBootstrap(jailService))
+		jail.GET("/base-cache", jailHandlers.ListBaseCache(jailService))
+		jail.POST("/base-cache", jailHandlers.PrefetchBaseCache(jailService))
+		jail.DELETE("/base-cache/:release", jailHandlers.DeleteBaseCache(jailService))
+		jail.POST("/validate", jailHandlers.ValidateCreateJail(jailService))
 		jail.GET("/templates/simple", jailHandlers.ListJailTemplatesSimple(jailService))
 		jail.GET("/templates/:id", jailHandlers.GetJailTemplateByID(jailService))
 		jail.POST("/templates/convert/:ctid", jailHandlers.ConvertJailToTemplate(jailService, lifecycleService))
@@ -530,12 +683,21 @@ func RegisterRoutes(r *gin.Engine,
 		jail.GET("/state/:id", jailHandlers.GetJailState(jailService, lifecycleService))
 		jail.GET("", jailHandlers.ListJails(jailService))
 		jail.GET("/:id", jailHandlers.GetJailByIdentifier(jailService))
+		jail.GET("/snapshots/schedule/:id", jailHandlers.GetJailSnapshotSchedule(jailService))
+		jail.PUT("/snapshots/schedule/:id", jailHandlers.SetJailSnapshotSchedule(jailService))
+		jail.DELETE("/snapshots/schedule/:id", jailHandlers.DeleteJailSnapshotSchedule(jailService))
 		jail.GET("/snapshots/:id", jailHandlers.ListJailSnapshots(jailService))
+		jail.GET("/snapshots/:id/:snapshotId/diff", jailHandlers.DiffJailSnapshot(jailService))
+		jail.GET("/snapshots/:id/versions", jailHandlers.ListJailPathVersions(jailService))
 		jail.POST("/snapshots/:id", jailHandlers.CreateJailSnapshot(jailService))
 		jail.POST("/snapshots/rollback/:id/:snapshotId",
 			jailHandlers.RequireJailReplicationTopologyMutable(jailService, "id"),
 			jailHandlers.RollbackJailSnapshot(jailService),
 		)
+		jail.POST("/snapshots/restore-path/:id",
+			jailHandlers.RequireJailReplicationTopologyMutable(jailService, "id"),
+			jailHandlers.RestoreJailPathFromSnapshot(jailService),
+		)
 		jail.DELETE("/snapshots/:id/:snapshotId",
 			jailHandlers.RequireJailReplicationTopologyMutable(jailService, "id"),
 			jailHandlers.DeleteJailSnapshot(jailService),
@@ -543,32 +705,49 @@ func RegisterRoutes(r *gin.Engine,
 		jail.POST("/migrate/:ctId", migrationHandlers.MigrateJail(migrationService, lifecycleService))
 		jail.POST("/action/:action/:ctId", jailHandlers.JailAction(jailService, lifecycleService))
 		jail.PUT("/description", jailHandlers.UpdateJailDescription(jailService))
+		jail.GET("/notes/:ctid", notesHandlers.GetGuestNotes(notesService, notes.GuestTypeJail, "ctid"))
+		jail.PUT("/notes/:ctid", notesHandlers.SaveGuestNote(notesService, notes.GuestTypeJail, "ctid"))
+		jail.GET("/notes/:ctid/revisions", notesHandlers.ListGuestNoteRevisions(notesService, notes.GuestTypeJail, "ctid"))
+		jail.POST("/notes/:ctid/attachments", notesHandlers.UploadGuestNoteAttachment(notesService, notes.GuestTypeJail, "ctid"))
+		jail.GET("/notes/:ctid/attachments/:attachmentId", notesHandlers.DownloadGuestNoteAttachment(notesService, notes.GuestTypeJail, "ctid"))
+		jail.DELETE("/notes/:ctid/attachments/:attachmentId", notesHandlers.DeleteGuestNoteAttachment(notesService, notes.GuestTypeJail, "ctid"))
 		jail.PUT("/name", jailHandlers.UpdateJailName(jailService, clusterService))
 		jail.GET("/:id/logs", jailHandlers.GetJailLogs(jailService))
+		jail.GET("/:id/processes", jailHandlers.ListJailProcesses(jailService))
+		jail.GET("/:id/usage", jailHandlers.GetJailResourceUsage(jailService))
 		jail.PUT("/memory", jailHandlers.UpdateJailMemory(jailService))
 		jail.PUT("/cpu", jailHandlers.UpdateJailCPU(jailService))
+		jail.GET("/cpu/topology", jailHandlers.GetJailCPUTopology(jailService))
 		jail.GET("/stats/:ctId/:step", jailHandlers.GetJailStats(jailService))
 		jail.PUT("/resource-limits/:ctId", jailHandlers.UpdateResourceLimits(jailService))
 
-		jail.POST("", jailHandlers.CreateJail(jailService))
+		jail.POST("", jailHandlers.CreateJail(jailService, lifecycleService))
 		jail.DELETE("/:ctid",
 			jailHandlers.RequireJailDeletionDetached(jailService, "ctid"),
 			jailHandlers.RequireJailReplicationTopologyMutable(jailService, "ctid"),
 			jailHandlers.DeleteJail(jailService),
 		)
+		jail.POST("/:ctid/migrate-storage",
+			jailHandlers.RequireJailReplicationTopologyMutable(jailService, "ctid"),
+			jailHandlers.MigrateJailStorage(jailService),
+		)
 
 		jail.GET("/console", jailHandlers.HandleJailTerminalWebsocket(jailService))
+		jail.POST("/:ctid/exec", middleware.RequireLocalAdmin(authService), jailHandlers.StartJailExec(jailService))
+		jail.GET("/exec/:id/stream", middleware.RequireLocalAdmin(authService), jailHandlers.HandleJailExecWebsocket(jailService))
 		jail.PUT("/network/inheritance/:ctId", jailHandlers.SetNetworkInheritance(jailService))
 		jail.PUT("/network/disinheritance/:ctId", jailHandlers.SetNetworkInheritance(jailService))
 
-		jail.POST("/network", jailHandlers.AddNetwork(jailService))
-		jail.PUT("/network", jailHandlers.EditNetwork(jailService))
-		jail.DELETE("/network/:ctId/:networkId", jailHandlers.DeleteNetwork(jailService))
+		jail.POST("/network", jailHandlers.AddNetwork(jailService, networkService))
+		jail.PUT("/network", jailHandlers.EditNetwork(jailService, networkService))
+		jail.DELETE("/network/:ctId/:networkId", jailHandlers.DeleteNetwork(jailService, networkService))
 
 		jail.PUT("/options/wol/:rid", jailHandlers.ModifyWakeOnLan(jailService))
 		jail.PUT("/options/boot-order/:rid", jailHandlers.ModifyBootOrder(jailService))
 		jail.PUT("/options/fstab/:rid", jailHandlers.ModifyFstab(jailService))
+		jail.PUT("/options/mounts/:rid", jailHandlers.ModifyMounts(jailService))
 		jail.PUT("/options/resolv-conf/:rid", jailHandlers.ModifyResolvConf(jailService))
+		jail.PUT("/options/etc-files/:rid", jailHandlers.ModifyEtcFiles(jailService))
 		jail.PUT("/options/devfs-rules/:rid", jailHandlers.ModifyDevFSRules(jailService))
 		jail.PUT("/options/additional-options/:rid", jailHandlers.ModifyAdditionalOptions(jailService))
 		jail.PUT("/options/allowed-options/:rid", jailHandlers.ModifyAllowedOptions(jailService))
@@ -578,6 +757,7 @@ func RegisterRoutes(r *gin.Engine,
 
 	utilities := api.Group("/utilities")
 	utilities.Use(middleware.EnsureAuthenticated(authService))
+	utilities.Use(middleware.ValidateRequests())
 	utilities.Use(EnsureCorrectHost(db, authService))
 	utilities.Use(middleware.RequestLoggerMiddleware(telemetryDB, authService))
 	{
@@ -586,6 +766,7 @@ func RegisterRoutes(r *gin.Engine,
 		utilities.GET("/downloads/paths", utilitiesHandlers.GetDownloadPaths())
 		utilities.GET("/downloads/utype", utilitiesHandlers.ListDownloadsByUType(utilitiesService))
 		utilities.PUT("/downloads/:id", utilitiesHandlers.UpdateDownload(utilitiesService))
+		utilities.POST("/downloads/:id/verify", utilitiesHandlers.VerifyDownload(utilitiesService))
 		utilities.DELETE("/downloads/:id", utilitiesHandlers.DeleteDownload(utilitiesService))
 		utilities.POST("/downloads/bulk-delete", utilitiesHandlers.BulkDeleteDownload(utilitiesService))
 		utilities.POST("/downloads/signed-url", utilitiesHandlers.GetSignedDownloadURL(utilitiesService))
@@ -600,6 +781,7 @@ func RegisterRoutes(r *gin.Engine,
 
 	auth := api.Group("/auth")
 	auth.Use(middleware.EnsureAuthenticated(authService))
+	auth.Use(middleware.ValidateRequests())
 	auth.Use(middleware.RequestLoggerMiddleware(telemetryDB, authService))
 	{
 		auth.POST("/login", authHandlers.LoginHandler(authService))
@@ -607,16 +789,26 @@ func RegisterRoutes(r *gin.Engine,
 		auth.POST("/passkeys/login/finish", authHandlers.FinishPasskeyLoginHandler(authService))
 		auth.GET("/logout", authHandlers.LogoutHandler(authService))
 		auth.GET("/sse-token", eventsHandlers.CreateSSEToken(authService))
+		auth.GET("/sessions", authHandlers.ListSessionsHandler(authService))
+		auth.DELETE("/sessions", authHandlers.RevokeAllSessionsHandler(authService))
+		auth.DELETE("/sessions/:id", authHandlers.RevokeSessionHandler(authService))
+		auth.GET("/motd", authHandlers.MOTDHandler(authService))
+		auth.GET("/notices", middleware.RequireLocalAdmin(authService), authHandlers.GetLoginNoticesHandler(authService))
+		auth.PUT("/notices", middleware.RequireLocalAdmin(authService), authHandlers.UpdateLoginNoticesHandler(authService))
+		auth.GET("/signing-keys", middleware.RequireLocalAdmin(authService), authHandlers.ListSigningKeysHandler(authService))
+		auth.POST("/signing-keys/rotate", middleware.RequireLocalAdmin(authService), authHandlers.RotateSigningKeyHandler(authService, clusterService))
 	}
 
 	events := api.Group("/events")
 	events.Use(middleware.EnsureAuthenticated(authService))
+	events.Use(middleware.ValidateRequests())
 	{
 		events.GET("/stream", eventsHandlers.StreamSSE(authService))
 	}
 
 	notifications := api.Group("/notifications")
 	notifications.Use(middleware.EnsureAuthenticated(authService))
+	notifications.Use(middleware.ValidateRequests())
 	notifications.Use(EnsureCorrectHost(db, authService))
 	notifications.Use(middleware.RequestLoggerMiddleware(telemetryDB, authService))
 	{
@@ -636,6 +828,11 @@ func RegisterRoutes(r *gin.Engine,
 		notifications.DELETE("/rules/:id", notificationsHandlers.DeleteRule(notificationService))
 		notifications.POST("/rules/bulk-delete", notificationsHandlers.BulkDeleteRules(notificationService))
 		notifications.POST("/rules/bulk-update", notificationsHandlers.BulkUpdateRules(notificationService))
+		notifications.GET("/guest-alerts/rules", guestAlertsHandlers.ListRules(guestAlertService))
+		notifications.POST("/guest-alerts/rules", guestAlertsHandlers.CreateRule(guestAlertService))
+		notifications.PUT("/guest-alerts/rules/:id", guestAlertsHandlers.UpdateRule(guestAlertService))
+		notifications.DELETE("/guest-alerts/rules/:id", guestAlertsHandlers.DeleteRule(guestAlertService))
+		notifications.GET("/guest-alerts/active", guestAlertsHandlers.ActiveAlerts(guestAlertService))
 	}
 
 	users := auth.Group("/users")
@@ -676,16 +873,19 @@ func RegisterRoutes(r *gin.Engine,
 
 	intraCluster := api.Group("/intra-cluster")
 	intraCluster.Use(middleware.EnsureAuthenticated(authService))
+	intraCluster.Use(middleware.ValidateRequests())
 	intraCluster.Use(middleware.RequireClusterScope())
 	{
 		intraCluster.POST("/migration/import-vm", migrationHandlers.IntraClusterImportVM(zeltaService, libvirtService))
 		intraCluster.POST("/migration/import-jail", migrationHandlers.IntraClusterImportJail(zeltaService, jailService))
 		intraCluster.POST("/migration/check-vm-target", migrationHandlers.IntraClusterCheckVMTarget(libvirtService))
+		intraCluster.POST("/clone/import-vm", clusterHandlers.CloneImportVMInternal(zeltaService))
 		intraCluster.POST("/sync-health", clusterHandlers.SyncHealth(clusterService))
 		intraCluster.POST("/events/left-panel-refresh", clusterHandlers.EmitLeftPanelRefreshLocal(clusterService))
 		intraCluster.POST("/ssh-identity", clusterHandlers.UpsertClusterSSHIdentityInternal(clusterService))
 		intraCluster.POST("/ssh-reconcile", clusterHandlers.ReconcileClusterSSHNow(clusterService))
 		intraCluster.GET("/guest-identity-inventory", clusterHandlers.GuestIdentityInventoryInternal(clusterService))
+		intraCluster.GET("/node-config", clusterHandlers.NodeConfigInternal(clusterService))
 		intraCluster.POST("/run", clusterHandlers.RunReplicationPolicyInternal(clusterService, zeltaService))
 		intraCluster.POST("/activate", clusterHandlers.ActivateReplicationPolicyInternal(clusterService, zeltaService))
 		intraCluster.POST("/demote", clusterHandlers.DemoteReplicationPolicyInternal(clusterService, zeltaService))
@@ -700,15 +900,22 @@ func RegisterRoutes(r *gin.Engine,
 		intraCluster.POST("/backup-job-state", clusterHandlers.UpdateBackupJobStateInternal(clusterService))
 		intraCluster.POST("/replication-policy-state", clusterHandlers.UpdateReplicationPolicyStateInternal(clusterService))
 		intraCluster.POST("/backup-job-friendly-source", clusterHandlers.UpdateBackupJobFriendlySourceInternal(clusterService))
+		intraCluster.POST("/backup-job-dataset-rename", clusterHandlers.RenameBackupJobDatasetsInternal(clusterService))
+		intraCluster.POST("/backup-job-reattach", clusterHandlers.ReattachBackupJobInternal(clusterService))
 		intraCluster.POST("/encryption-key/discover", clusterHandlers.DiscoverEncryptionKeyInternal(clusterService))
+		intraCluster.POST("/tls/sign", clusterHandlers.SignClusterNodeCertificateInternal(clusterService))
 	}
 
 	cluster := api.Group("/cluster")
 	cluster.Use(middleware.EnsureAuthenticated(authService))
+	cluster.Use(middleware.ValidateRequests())
 	cluster.Use(middleware.RequestLoggerMiddleware(telemetryDB, authService))
 	{
 		cluster.GET("/nodes", clusterHandlers.Nodes(clusterService))
 		cluster.GET("/resources", clusterHandlers.Resources(clusterService))
+		cluster.POST("/capacity/simulate", clusterHandlers.SimulateCapacity(clusterService))
+		cluster.GET("/drift", clusterHandlers.ConfigDrift(clusterService))
+		cluster.POST("/vm/:rid/clone", clusterHandlers.CloneVM(clusterService, lifecycleService))
 
 		cluster.GET("", clusterHandlers.GetCluster(clusterService))
 		cluster.POST("", clusterHandlers.CreateCluster(authService, clusterService, fsm))
@@ -729,6 +936,26 @@ func RegisterRoutes(r *gin.Engine,
 		clusterNotes.POST("/bulk-delete", clusterHandlers.BulkDeleteNotes(clusterService))
 	}
 
+	clusterMaintenance := cluster.Group("/maintenance")
+	clusterMaintenance.Use(middleware.RequireLocalAdmin(authService))
+	{
+		clusterMaintenance.GET("", clusterHandlers.MaintenanceWindows(clusterService))
+		clusterMaintenance.POST("", clusterHandlers.CreateMaintenanceWindow(clusterService))
+		clusterMaintenance.POST("/:id/end", clusterHandlers.EndMaintenanceWindow(clusterService))
+		clusterMaintenance.DELETE("/:id", clusterHandlers.DeleteMaintenanceWindow(clusterService))
+		// What a window silenced is recorded on the node that held it back,
+		// so the audit is served locally rather than by the leader.
+		clusterMaintenance.GET("/suppressions", clusterHandlers.MaintenanceSuppressions(clusterService))
+	}
+
+	clusterTLS := cluster.Group("/tls")
+	clusterTLS.Use(middleware.RequireLocalAdmin(authService))
+	{
+		clusterTLS.GET("", clusterHandlers.ClusterTLSStatus(clusterService))
+		clusterTLS.POST("/ca/rotate", clusterHandlers.RotateClusterCA(clusterService))
+		clusterTLS.PUT("/enforce", clusterHandlers.SetClusterTLSEnforce(clusterService))
+	}
+
 	clusterBackups := cluster.Group("/backups")
 	clusterBackups.Use(middleware.RequireLocalAdmin(authService))
 	{
@@ -739,7 +966,16 @@ func RegisterRoutes(r *gin.Engine,
 			targets.PUT("/:id", clusterHandlers.UpdateBackupTarget(clusterService, zeltaService))
 			targets.DELETE("/:id", clusterHandlers.DeleteBackupTarget(clusterService, zeltaService))
 			targets.POST("/validate/:id", clusterHandlers.ValidateBackupTarget(clusterService, zeltaService))
+			targets.POST("/onboarding", clusterHandlers.StartBackupTargetOnboarding(clusterService, zeltaService))
+			targets.GET("/onboarding/:id", clusterHandlers.GetBackupTargetOnboarding(clusterService, zeltaService))
+			targets.POST("/onboarding/:id/test", clusterHandlers.TestBackupTargetOnboarding(clusterService, zeltaService))
+			targets.POST("/onboarding/:id/finish", clusterHandlers.FinishBackupTargetOnboarding(clusterService, zeltaService))
+			targets.DELETE("/onboarding/:id", clusterHandlers.CancelBackupTargetOnboarding(clusterService, zeltaService))
 			targets.GET("/:id/datasets", clusterHandlers.BackupTargetDatasets(zeltaService))
+			targets.GET("/:id/space", clusterHandlers.BackupTargetSpace(zeltaService))
+			targets.POST("/:id/ssh-probe", clusterHandlers.ProbeBackupTargetSSH(zeltaService))
+			targets.POST("/:id/reattach", clusterHandlers.ReattachBackupTarget(zeltaService))
+			targets.POST("/:id/datasets/cleanup", clusterHandlers.CleanupBackupTargetLineages(zeltaService))
 			targets.GET("/:id/datasets/snapshots", clusterHandlers.BackupTargetDatasetSnapshots(zeltaService))
 			targets.GET("/:id/datasets/jail-metadata", clusterHandlers.BackupTargetDatasetJailMetadata(zeltaService))
 			targets.GET("/:id/datasets/vm-metadata", clusterHandlers.BackupTargetDatasetVMMetadata(zeltaService))
@@ -756,8 +992,99 @@ func RegisterRoutes(r *gin.Engine,
 			jobs.POST("/run/:id", clusterHandlers.RunBackupJobNow(clusterService, zeltaService))
 			jobs.GET("/:id/snapshots", clusterHandlers.BackupJobSnapshots(clusterService, zeltaService))
 			jobs.POST("/:id/restore", clusterHandlers.RestoreBackupJob(clusterService, zeltaService))
+			jobs.POST("/:id/seed/export", clusterHandlers.ExportBackupSeed(zeltaService))
+			jobs.POST("/:id/seed/adopt", clusterHandlers.AdoptBackupSeed(zeltaService))
 		}
 
+		// Restores onto an existing guest need a typed guest name or a
+		// re-auth token from here, and may be limited to one group.
+		clusterBackups.POST("/restore-confirmation", clusterHandlers.IssueRestoreConfirmation(clusterService))
+		clusterBackups.GET("/restore-policy", clusterHandlers.GetRestorePolicy(clusterService))
+		clusterBackups.PUT("/restore-policy", clusterHandlers.UpdateRestorePolicy(clusterService))
+
+		zeltaProfiles := clusterBackups.Group("/zelta-profiles")
+		{
+			zeltaProfiles.GET("", clusterHandlers.ZeltaProfiles(clusterService))
+			zeltaProfiles.POST("", clusterHandlers.CreateZeltaProfile(clusterService))
+			zeltaProfiles.PUT("/:id", clusterHandlers.UpdateZeltaProfile(clusterService))
+			zeltaProfiles.DELETE("/:id", clusterHandlers.DeleteZeltaProfile(clusterService))
+		}
+
+		config := clusterBackups.Group("/config")
+		{
+			config.POST("/export", clusterHandlers.ExportBackupConfig(clusterService))
+			config.POST("/import", clusterHandlers.ImportBackupConfig(clusterService))
+		}
+
+		// Tenants are local to the node that receives backups, so these
+		// routes are served directly rather than forwarded to the leader.
+		tenants := clusterBackups.Group("/tenants")
+		{
+			tenants.GET("", clusterHandlers.BackupTenants(zeltaService))
+			tenants.POST("", clusterHandlers.CreateBackupTenant(zeltaService))
+			tenants.PUT("/:id/quota", clusterHandlers.SetBackupTenantQuota(zeltaService))
+			tenants.PUT("/:id/namespace", clusterHandlers.SetBackupTenantNamespace(zeltaService))
+			tenants.DELETE("/:id", clusterHandlers.DeleteBackupTenant(zeltaService))
+		}
+
+		// Post-restore scripts run on the node that restores the guest and
+		// are kept there alongside its restore events.
+		postRestoreScripts := clusterBackups.Group("/post-restore-scripts")
+		{
+			postRestoreScripts.GET("", clusterHandlers.PostRestoreScripts(zeltaService))
+			postRestoreScripts.PUT("", clusterHandlers.SavePostRestoreScript(zeltaService))
+			postRestoreScripts.DELETE("/:id", clusterHandlers.DeletePostRestoreScript(zeltaService))
+		}
+
+		// The standby describes this node only and is never forwarded.
+		standby := clusterBackups.Group("/standby")
+		{
+			standby.GET("", clusterHandlers.GetNodeStandby(zeltaService))
+			standby.PUT("", clusterHandlers.ConfigureNodeStandby(zeltaService))
+			standby.DELETE("", clusterHandlers.DeleteNodeStandby(zeltaService))
+			standby.POST("/run", clusterHandlers.RunNodeStandbyNow(zeltaService))
+		}
+
+		// The janitor sweeps this node's datasets, so it is never forwarded.
+		janitor := clusterBackups.Group("/janitor")
+		{
+			janitor.GET("", clusterHandlers.GetStaleDatasetJanitor(zeltaService))
+			janitor.PUT("", clusterHandlers.ConfigureStaleDatasetJanitor(zeltaService))
+			janitor.GET("/scan", clusterHandlers.ScanStaleDatasets(zeltaService))
+			janitor.POST("/cleanup", clusterHandlers.CleanupStaleDatasets(zeltaService))
+		}
+
+		// Restore staging is node-local, so the scratch dataset is never
+		// forwarded either.
+		scratch := clusterBackups.Group("/restore-scratch")
+		{
+			scratch.GET("", clusterHandlers.GetRestoreScratch(zeltaService))
+			scratch.PUT("", clusterHandlers.ConfigureRestoreScratch(zeltaService))
+		}
+
+		// A bulk restore rebuilds guests onto the node serving the request,
+		// so it is never forwarded.
+		bulkRestore := clusterBackups.Group("/bulk-restore")
+		{
+			bulkRestore.GET("", clusterHandlers.BulkRestores(zeltaService))
+			bulkRestore.POST("", clusterHandlers.StartBulkRestore(zeltaService))
+			bulkRestore.GET("/candidates", clusterHandlers.BulkRestoreCandidates(zeltaService))
+			bulkRestore.GET("/:id", clusterHandlers.BulkRestoreByID(zeltaService))
+			bulkRestore.POST("/:id/cancel", clusterHandlers.CancelBulkRestore(zeltaService))
+		}
+
+		// Export archives are written to the node holding the guest and
+		// only exist there, so these are never forwarded.
+		exports := clusterBackups.Group("/exports")
+		{
+			exports.GET("", clusterHandlers.GuestExports(zeltaService))
+			exports.POST("", clusterHandlers.StartGuestExport(zeltaService))
+			exports.GET("/:id/download", clusterHandlers.DownloadGuestExport(zeltaService))
+			exports.DELETE("/:id", clusterHandlers.DeleteGuestExport(zeltaService))
+		}
+
+		clusterBackups.GET("/transfers", clusterHandlers.ActiveTransfers(zeltaService))
+
 		clusterBackups.GET("/events", clusterHandlers.BackupEvents(clusterService, zeltaService))
 		clusterBackups.GET("/events/remote", clusterHandlers.BackupEventsRemote(clusterService, zeltaService))
 		clusterBackups.GET("/events/:id", clusterHandlers.BackupEventByID(clusterService, zeltaService))
@@ -772,21 +1099,80 @@ func RegisterRoutes(r *gin.Engine,
 		clusterReplication.PUT("/policies/:id", clusterHandlers.UpdateReplicationPolicy(clusterService, zeltaService))
 		clusterReplication.DELETE("/policies/:id", clusterHandlers.DeleteReplicationPolicy(clusterService, zeltaService))
 		clusterReplication.POST("/policies/:id/run", clusterHandlers.RunReplicationPolicyNow(clusterService, zeltaService))
+		clusterReplication.POST("/policies/:id/pause", clusterHandlers.PauseReplicationPolicy(clusterService))
+		clusterReplication.POST("/policies/:id/resume", clusterHandlers.ResumeReplicationPolicy(clusterService))
 		clusterReplication.POST("/policies/:id/failover", clusterHandlers.FailoverReplicationPolicy(clusterService, zeltaService))
+		clusterReplication.POST("/policies/:id/switchover", clusterHandlers.SwitchoverReplicationPolicy(clusterService, zeltaService))
+		clusterReplication.GET("/policies/:id/timeline", clusterHandlers.ReplicationPolicyTimeline(clusterService))
+
+		clusterReplication.GET("/templates", clusterHandlers.ReplicationPolicyTemplates(clusterService))
+		clusterReplication.POST("/templates", clusterHandlers.CreateReplicationPolicyTemplate(clusterService))
+		clusterReplication.PUT("/templates/:id", clusterHandlers.UpdateReplicationPolicyTemplate(clusterService))
+		clusterReplication.DELETE("/templates/:id", clusterHandlers.DeleteReplicationPolicyTemplate(clusterService))
+		clusterReplication.POST("/templates/enroll", clusterHandlers.EnrollReplicationPolicyTemplates(clusterService))
 
 		clusterReplication.GET("/events", clusterHandlers.ReplicationEvents(clusterService))
 		clusterReplication.GET("/events/:id", clusterHandlers.ReplicationEventByID(clusterService))
 		clusterReplication.GET("/events/:id/progress", clusterHandlers.ReplicationEventProgressByID(clusterService, zeltaService))
+		clusterReplication.GET("/events/:id/timeline", clusterHandlers.ReplicationEventTimelineByID(clusterService))
+
+		clusterReplication.GET("/fences", clusterHandlers.StorageFences(zeltaService))
+		clusterReplication.PUT("/fences", clusterHandlers.SaveStorageFence(zeltaService))
+		clusterReplication.DELETE("/fences/:pool", clusterHandlers.DeleteStorageFence(zeltaService))
+		clusterReplication.POST("/fences/:pool/check", clusterHandlers.CheckStorageFence(zeltaService))
+		clusterReplication.PUT("/ssh-user", clusterHandlers.SetReplicationSSHUser(clusterService))
+		clusterReplication.POST("/ssh-identity/rotate", clusterHandlers.RotateClusterSSHIdentity(clusterService))
+		clusterReplication.GET("/ssh-identity/rotations", clusterHandlers.ClusterSSHKeyRotations(clusterService))
+		clusterReplication.PUT("/ssh-identity/rotation-schedule", clusterHandlers.SetClusterSSHKeyRotationSchedule(clusterService))
+		clusterReplication.GET("/network", clusterHandlers.ReplicationNetwork(clusterService))
+		clusterReplication.PUT("/network", clusterHandlers.SetReplicationNetwork(clusterService))
 	}
 
 	vnc := api.Group("/vnc")
 	vnc.Use(middleware.EnsureAuthenticated(authService))
+	vnc.Use(middleware.ValidateRequests())
 	vnc.Use(EnsureCorrectHost(db, authService))
 	vnc.Use(middleware.RequestLoggerMiddleware(telemetryDB, authService))
 	vnc.GET("/:port", vncHandler.VNCProxyHandler(libvirtService))
 
+	reports := api.Group("/reports")
+	reports.Use(middleware.EnsureAuthenticated(authService))
+	reports.Use(middleware.ValidateRequests())
+	reports.Use(EnsureCorrectHost(db, authService))
+	reports.Use(middleware.RequestLoggerMiddleware(telemetryDB, authService))
+	{
+		reports.GET("/usage", reportsHandlers.UsageReport(usageService))
+	}
+
+	searchService := search.NewService(db, clusterService, zfsService)
+
+	searchGroup := api.Group("/search")
+	searchGroup.Use(middleware.EnsureAuthenticated(authService))
+	searchGroup.Use(middleware.ValidateRequests())
+	searchGroup.Use(EnsureCorrectHost(db, authService))
+	searchGroup.Use(middleware.RequestLoggerMiddleware(telemetryDB, authService))
+	{
+		searchGroup.GET("", searchHandlers.Search(searchService))
+	}
+
+	applications := api.Group("/applications")
+	applications.Use(middleware.EnsureAuthenticated(authService))
+	applications.Use(middleware.ValidateRequests())
+	applications.Use(EnsureCorrectHost(db, authService))
+	applications.Use(middleware.RequestLoggerMiddleware(telemetryDB, authService))
+	{
+		applications.GET("", taskHandlers.ListApplications(lifecycleService))
+		applications.POST("", taskHandlers.CreateApplication(lifecycleService))
+		applications.GET("/:id", taskHandlers.GetApplication(lifecycleService))
+		applications.PUT("/:id", taskHandlers.UpdateApplication(lifecycleService))
+		applications.DELETE("/:id", taskHandlers.DeleteApplication(lifecycleService))
+		applications.POST("/:id/start", taskHandlers.ApplicationAction(lifecycleService, "start"))
+		applications.POST("/:id/stop", taskHandlers.ApplicationAction(lifecycleService, "stop"))
+	}
+
 	tasks := api.Group("/tasks")
 	tasks.Use(middleware.EnsureAuthenticated(authService))
+	tasks.Use(middleware.ValidateRequests())
 	tasks.Use(EnsureCorrectHost(db, authService))
 	tasks.Use(middleware.RequestLoggerMiddleware(telemetryDB, authService))
 	{
@@ -795,12 +1181,22 @@ func RegisterRoutes(r *gin.Engine,
 			lifecycleTasks.GET("/active", taskHandlers.ActiveLifecycleTasks(lifecycleService))
 			lifecycleTasks.GET("/active/:guestType/:guestId", taskHandlers.ActiveLifecycleTaskForGuest(lifecycleService))
 			lifecycleTasks.GET("/recent", taskHandlers.RecentLifecycleTasks(lifecycleService))
+			lifecycleTasks.GET("/heavy-ops", taskHandlers.HeavyOps(lifecycleService))
+
+			lifecycleHooks := lifecycleTasks.Group("/hooks")
+			lifecycleHooks.Use(middleware.RequireLocalAdmin(authService))
+			{
+				lifecycleHooks.GET("", taskHandlers.GuestHooks(lifecycleService))
+				lifecycleHooks.PUT("", taskHandlers.SaveGuestHook(lifecycleService))
+				lifecycleHooks.DELETE("/:id", taskHandlers.DeleteGuestHook(lifecycleService))
+			}
 		}
 
 		migrationTasks := tasks.Group("/migration")
 		{
 			migrationTasks.POST("/cancel/:taskId", migrationHandlers.CancelMigration(migrationService))
 			migrationTasks.GET("/validate", migrationHandlers.ValidateMigration(migrationService))
+			migrationTasks.GET("/compatibility", migrationHandlers.CheckMigrationCompatibility(migrationService))
 		}
 	}
 
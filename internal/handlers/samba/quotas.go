@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package sambaHandlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/services/samba"
+
+	"github.com/gin-gonic/gin"
+)
+
+func parseShareID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+			Status:  "error",
+			Message: "invalid_share_id",
+			Error:   err.Error(),
+			Data:    nil,
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// @Summary Get Samba Share Quotas
+// @Description Report per-user and per-group space usage and quotas on a share's dataset
+// @Tags Samba
+// @Accept json
+// @Produce json
+// @Param id path uint true "Share ID"
+// @Success 200 {object} internal.APIResponse[[]samba.ShareQuota] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Invalid request"
+// @Failure 404 {object} internal.APIResponse[any] "Share not found"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /samba/shares/{id}/quotas [get]
+func GetShareQuotas(smbService *samba.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseShareID(c)
+		if !ok {
+			return
+		}
+
+		quotas, err := smbService.GetShareQuotas(c.Request.Context(), id)
+		if err != nil {
+			c.JSON(sambaShareServiceErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_get_share_quotas",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]samba.ShareQuota]{
+			Status:  "success",
+			Message: "share_quotas_retrieved",
+			Error:   "",
+			Data:    quotas,
+		})
+	}
+}
+
+// @Summary Set Samba Share Quota
+// @Description Set a user or group quota on a share's dataset. A zero quota clears it.
+// @Tags Samba
+// @Accept json
+// @Produce json
+// @Param id path uint true "Share ID"
+// @Param request body samba.ShareQuotaRequest true "Share Quota Request"
+// @Success 200 {object} internal.APIResponse[any] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Invalid request"
+// @Failure 404 {object} internal.APIResponse[any] "Share not found"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /samba/shares/{id}/quotas [put]
+func SetShareQuota(smbService *samba.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseShareID(c)
+		if !ok {
+			return
+		}
+
+		var request samba.ShareQuotaRequest
+		if err := strictJSONBind(c, &request); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := smbService.SetShareQuota(c.Request.Context(), id, request); err != nil {
+			c.JSON(sambaShareServiceErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_set_share_quota",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "share_quota_set",
+			Error:   "",
+			Data:    nil,
+		})
+	}
+}
+
+// @Summary Remove Samba Share Quota
+// @Description Clear a user or group quota on a share's dataset
+// @Tags Samba
+// @Accept json
+// @Produce json
+// @Param id path uint true "Share ID"
+// @Param type path string true "Quota type (user or group)"
+// @Param name path string true "User or group name"
+// @Success 200 {object} internal.APIResponse[any] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Invalid request"
+// @Failure 404 {object} internal.APIResponse[any] "Share not found"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /samba/shares/{id}/quotas/{type}/{name} [delete]
+func RemoveShareQuota(smbService *samba.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseShareID(c)
+		if !ok {
+			return
+		}
+
+		if err := smbService.RemoveShareQuota(c.Request.Context(), id, c.Param("type"), c.Param("name")); err != nil {
+			c.JSON(sambaShareServiceErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_remove_share_quota",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "share_quota_removed",
+			Error:   "",
+			Data:    nil,
+		})
+	}
+}
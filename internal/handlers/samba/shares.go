@@ -199,6 +199,8 @@ func sambaShareServiceErrorStatus(err error) int {
 		msg == "invalid_directory_mask",
 		msg == "dataset_not_found",
 		msg == "dataset_not_mounted",
+		msg == "invalid_share_quota_name",
+		msg == "invalid_share_quota_type",
 		strings.HasPrefix(msg, "invalid_audit_operation:"),
 		strings.HasPrefix(msg, "user_not_found:"),
 		strings.HasPrefix(msg, "group_not_found:"),
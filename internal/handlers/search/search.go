@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package searchHandlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/services/search"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Search Resources
+// @Description Search VMs, jails, switches, datasets, backup jobs, replication policies, events and cluster nodes by name, ID, IP or MAC
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Search query"
+// @Param scope query string false "cluster (default) or local"
+// @Param limit query int false "Maximum number of results (default 50, max 200)"
+// @Success 200 {object} internal.APIResponse[search.Response] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /search [get]
+func Search(searchService *search.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := 0
+		if raw := strings.TrimSpace(c.Query("limit")); raw != "" {
+			parsed, err := strconv.Atoi(raw)
+			if err != nil || parsed < 0 {
+				c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+					Status:  "error",
+					Message: "invalid_limit",
+					Error:   "limit must be a non-negative integer",
+					Data:    nil,
+				})
+				return
+			}
+			limit = parsed
+		}
+
+		scope := strings.ToLower(strings.TrimSpace(c.DefaultQuery("scope", "cluster")))
+		if scope != "cluster" && scope != "local" {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_scope",
+				Error:   "scope must be cluster or local",
+				Data:    nil,
+			})
+			return
+		}
+
+		response, err := searchService.Search(c.Request.Context(), c.Query("q"), limit, scope == "cluster")
+		if err != nil {
+			status := http.StatusInternalServerError
+			message := "search_failed"
+			if err.Error() == "query_required" || err.Error() == "query_too_short" {
+				status, message = http.StatusBadRequest, err.Error()
+			}
+			c.JSON(status, internal.APIResponse[any]{
+				Status:  "error",
+				Message: message,
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*search.Response]{
+			Status:  "success",
+			Message: "search_completed",
+			Error:   "",
+			Data:    response,
+		})
+	}
+}
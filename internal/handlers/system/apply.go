@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package systemHandlers
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/services/desiredstate"
+	"github.com/gin-gonic/gin"
+)
+
+const maxDesiredStateDocumentBytes = 1 << 20
+
+// @Summary Apply Desired State
+// @Description Diff a declarative YAML or JSON document describing objects, switches, guests, backup jobs and replication policies against the current state and apply the differences. Pass dryRun=true to only compute the plan. Restricted to local admins, since a document can create backup jobs and replication policies and a plan exposes their current configuration.
+// @Tags System
+// @Accept plain
+// @Produce json
+// @Security BearerAuth
+// @Param dryRun query bool false "Plan only, do not apply"
+// @Param request body desiredstate.Document true "Desired state document"
+// @Success 200 {object} internal.APIResponse[desiredstate.Result] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /system/apply [post]
+func ApplyDesiredState(dS *desiredstate.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		raw, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxDesiredStateDocumentBytes))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		doc, err := desiredstate.ParseDocument(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_desired_state_document",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		result, err := dS.Apply(c.Request.Context(), doc, c.Query("dryRun") == "true")
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "desired_state_apply_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		message := "desired_state_applied"
+		if result.DryRun {
+			message = "desired_state_planned"
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*desiredstate.Result]{
+			Status:  "success",
+			Message: message,
+			Error:   "",
+			Data:    result,
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package systemHandlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/alchemillahq/sylve/internal"
+	systemServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/system"
+	"github.com/alchemillahq/sylve/internal/services/lifecycle"
+	"github.com/gin-gonic/gin"
+)
+
+type consistencyChecker interface {
+	CheckConsistency(ctx context.Context) (lifecycle.ConsistencyReport, error)
+	LastConsistencyReport() *lifecycle.ConsistencyReport
+	ApplyConsistencyFixes(ctx context.Context, issueIDs []string) ([]lifecycle.ConsistencyFixResult, error)
+}
+
+// @Summary Get Consistency Report
+// @Description Get the latest guest, dataset and domain consistency report, running a check if none exists yet
+// @Tags System
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} internal.APIResponse[lifecycle.ConsistencyReport] "Success"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /system/consistency [get]
+func ConsistencyReport(checker consistencyChecker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if report := checker.LastConsistencyReport(); report != nil {
+			c.JSON(http.StatusOK, internal.APIResponse[lifecycle.ConsistencyReport]{
+				Status:  "success",
+				Message: "consistency_report",
+				Error:   "",
+				Data:    *report,
+			})
+			return
+		}
+
+		runConsistencyCheck(c, checker)
+	}
+}
+
+// @Summary Run Consistency Check
+// @Description Reconcile guest records against libvirt domains, jail configs and ZFS datasets
+// @Tags System
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} internal.APIResponse[lifecycle.ConsistencyReport] "Success"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /system/consistency/check [post]
+func CheckConsistency(checker consistencyChecker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		runConsistencyCheck(c, checker)
+	}
+}
+
+func runConsistencyCheck(c *gin.Context, checker consistencyChecker) {
+	report, err := checker.CheckConsistency(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+			Status:  "error",
+			Message: "consistency_check_failed",
+			Error:   err.Error(),
+			Data:    nil,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, internal.APIResponse[lifecycle.ConsistencyReport]{
+		Status:  "success",
+		Message: "consistency_report",
+		Error:   "",
+		Data:    report,
+	})
+}
+
+// @Summary Apply Consistency Fixes
+// @Description Apply the suggested fix for each listed issue that is still present
+// @Tags System
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body systemServiceInterfaces.ConsistencyFixRequest true "Issue IDs to fix"
+// @Success 200 {object} internal.APIResponse[[]lifecycle.ConsistencyFixResult] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /system/consistency/fix [post]
+func ApplyConsistencyFixes(checker consistencyChecker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req systemServiceInterfaces.ConsistencyFixRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		results, err := checker.ApplyConsistencyFixes(c.Request.Context(), req.IssueIDs)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "consistency_fix_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]lifecycle.ConsistencyFixResult]{
+			Status:  "success",
+			Message: "consistency_fixes_applied",
+			Error:   "",
+			Data:    results,
+		})
+	}
+}
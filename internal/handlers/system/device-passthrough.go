@@ -13,6 +13,7 @@ import (
 
 	"github.com/alchemillahq/sylve/internal"
 	"github.com/alchemillahq/sylve/internal/db/models"
+	systemServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/system"
 	"github.com/alchemillahq/sylve/internal/services/system"
 	"github.com/alchemillahq/sylve/pkg/system/pciconf"
 
@@ -89,6 +90,37 @@ func ListPPTDevices(systemService *system.Service) gin.HandlerFunc {
 	}
 }
 
+// @Summary List USB Devices
+// @Description List the host's USB devices and the PCI address of the controller each is attached to. A USB device reaches a VM by passing its controller through
+// @Tags System
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} internal.APIResponse[[]systemServiceInterfaces.USBDevice] "Success"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /system/usb-devices [get]
+func ListUSBDevices(systemService *system.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		devices, err := systemService.ListUSBDevices()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "internal_server_error",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]systemServiceInterfaces.USBDevice]{
+			Status:  "success",
+			Message: "usb_devices_list",
+			Error:   "",
+			Data:    devices,
+		})
+	}
+}
+
 // @Summary Add Passed Through Device
 // @Description Add a device to the passed through devices db
 // @Tags System
@@ -263,3 +295,46 @@ func RemovePPTDevice(systemService *system.Service) gin.HandlerFunc {
 		})
 	}
 }
+
+// @Summary Reclaim Passed Through Device
+// @Description Re-bind a reserved device that is missing from ppt (e.g. after a reboot) and clear its missing flag
+// @Tags System
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Passthrough ID"
+// @Success 200 {object} internal.APIResponse[any] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /system/ppt-devices/{id}/reclaim [post]
+func ReclaimPPTDevice(systemService *system.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		deviceID := c.Param("id")
+		if deviceID == "" {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "bad_request",
+				Error:   "device ID cannot be empty",
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := systemService.ReclaimPPTDevice(deviceID); err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "internal_server_error",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "device_reclaimed",
+			Error:   "",
+			Data:    nil,
+		})
+	}
+}
@@ -0,0 +1,269 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package systemHandlers
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	systemServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/system"
+	"github.com/alchemillahq/sylve/internal/services/system"
+
+	"github.com/gin-gonic/gin"
+)
+
+// managedFileUploadOverhead leaves room for the multipart framing around the
+// file itself when the request body is capped.
+const managedFileUploadOverhead = 1 << 20
+
+func managedFileErrorStatus(err error) int {
+	message := err.Error()
+	switch {
+	case os.IsNotExist(err),
+		message == "managed_file_not_found",
+		message == "jail_not_found":
+		return http.StatusNotFound
+	case message == "managed_file_exists",
+		strings.HasPrefix(message, "restore_in_progress"):
+		return http.StatusConflict
+	case message == "managed_file_too_large":
+		return http.StatusRequestEntityTooLarge
+	case message == "managed_file_path_outside_root":
+		return http.StatusForbidden
+	case strings.HasPrefix(message, "managed_file_"):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// managedFileRoot picks the root from the route: jail routes carry the CTID,
+// every other managed-files route serves the ISO folder.
+func managedFileRoot(c *gin.Context) (systemServiceInterfaces.ManagedFileRoot, bool) {
+	raw, isJail := c.Params.Get("ctid")
+	if !isJail {
+		return systemServiceInterfaces.ManagedFileRoot{Kind: system.ManagedFileRootISOs}, true
+	}
+
+	root := systemServiceInterfaces.ManagedFileRoot{Kind: system.ManagedFileRootJail}
+	ctid, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil || ctid == 0 {
+		c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+			Status:  "error",
+			Message: "invalid_ctid",
+			Error:   "invalid_ctid",
+			Data:    nil,
+		})
+		return root, false
+	}
+	root.CTID = uint(ctid)
+	return root, true
+}
+
+// @Summary List Managed Files
+// @Description List a folder of a Sylve-managed root: the ISO drop folder, or a jail root (admin only)
+// @Tags System
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param ctid path int false "Jail CTID, for jail roots"
+// @Param path query string false "Folder relative to the root"
+// @Success 200 {object} internal.APIResponse[[]systemServiceInterfaces.ManagedFileEntry]
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 403 {object} internal.APIResponse[any] "Forbidden"
+// @Failure 404 {object} internal.APIResponse[any] "Not Found"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /system/managed-files/isos [get]
+// @Router /system/managed-files/jails/{ctid} [get]
+func ListManagedFiles(systemService *system.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		root, ok := managedFileRoot(c)
+		if !ok {
+			return
+		}
+
+		entries, err := systemService.ListManagedFiles(root, c.Query("path"))
+		if err != nil {
+			c.JSON(managedFileErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "list_managed_files_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]systemServiceInterfaces.ManagedFileEntry]{
+			Status:  "success",
+			Message: "managed_files_listed",
+			Error:   "",
+			Data:    entries,
+		})
+	}
+}
+
+// @Summary Download Managed File
+// @Description Download a file from a Sylve-managed root
+// @Tags System
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param ctid path int false "Jail CTID, for jail roots"
+// @Param path query string true "File relative to the root"
+// @Success 200 {file} file
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 403 {object} internal.APIResponse[any] "Forbidden"
+// @Failure 404 {object} internal.APIResponse[any] "Not Found"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /system/managed-files/isos/download [get]
+// @Router /system/managed-files/jails/{ctid}/download [get]
+func DownloadManagedFile(systemService *system.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		root, ok := managedFileRoot(c)
+		if !ok {
+			return
+		}
+
+		rel := c.Query("path")
+		if strings.TrimSpace(rel) == "" {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "bad_request",
+				Error:   "path is required",
+				Data:    nil,
+			})
+			return
+		}
+
+		file, info, err := systemService.OpenManagedFile(root, rel)
+		if err != nil {
+			c.JSON(managedFileErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "download_managed_file_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		defer file.Close()
+
+		// Serve the descriptor that was opened inside the root; reopening
+		// the path by name would let a jail swap it for a link in between.
+		c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": info.Name()}))
+		http.ServeContent(c.Writer, c.Request, info.Name(), info.ModTime(), file)
+	}
+}
+
+// @Summary Upload Managed File
+// @Description Upload a file into a folder of a Sylve-managed root. The multipart field is "file"; existing files are never replaced.
+// @Tags System
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param ctid path int false "Jail CTID, for jail roots"
+// @Param path query string false "Folder relative to the root"
+// @Param file formData file true "File to upload"
+// @Success 200 {object} internal.APIResponse[systemServiceInterfaces.ManagedFileEntry]
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 403 {object} internal.APIResponse[any] "Forbidden"
+// @Failure 409 {object} internal.APIResponse[any] "Conflict"
+// @Failure 413 {object} internal.APIResponse[any] "Request Entity Too Large"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /system/managed-files/isos/upload [post]
+// @Router /system/managed-files/jails/{ctid}/upload [post]
+func UploadManagedFile(systemService *system.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		root, ok := managedFileRoot(c)
+		if !ok {
+			return
+		}
+
+		limit := system.ManagedFileMaxUploadBytes
+		if c.Request.ContentLength > limit+managedFileUploadOverhead {
+			c.JSON(http.StatusRequestEntityTooLarge, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "upload_managed_file_failed",
+				Error:   "managed_file_too_large",
+				Data:    nil,
+			})
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit+managedFileUploadOverhead)
+
+		// The part is streamed straight into the root instead of being parsed
+		// into a temporary file first, so an ISO is only written once.
+		reader, err := c.Request.MultipartReader()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "parse_failed",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		for {
+			part, err := reader.NextPart()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+					Status:  "error",
+					Message: "parse_failed",
+					Error:   err.Error(),
+					Data:    nil,
+				})
+				return
+			}
+			if part.FormName() != "file" || part.FileName() == "" {
+				part.Close()
+				continue
+			}
+
+			entry, err := systemService.SaveManagedFile(root, c.Query("path"), part.FileName(), part, limit)
+			part.Close()
+			if err != nil {
+				var maxErr *http.MaxBytesError
+				if errors.As(err, &maxErr) {
+					err = errors.New("managed_file_too_large")
+				}
+				c.JSON(managedFileErrorStatus(err), internal.APIResponse[any]{
+					Status:  "error",
+					Message: "upload_managed_file_failed",
+					Error:   err.Error(),
+					Data:    nil,
+				})
+				return
+			}
+
+			c.JSON(http.StatusOK, internal.APIResponse[systemServiceInterfaces.ManagedFileEntry]{
+				Status:  "success",
+				Message: "managed_file_uploaded",
+				Error:   "",
+				Data:    entry,
+			})
+			return
+		}
+
+		c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+			Status:  "error",
+			Message: "missing_file",
+			Error:   "no file found in file field",
+			Data:    nil,
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package systemHandlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	systemServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/system"
+	"github.com/alchemillahq/sylve/internal/services/system"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary List Adoptable ZFS Pools
+// @Description List pools on this host that are not yet usable by Sylve, including ones that are not imported
+// @Tags System
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} internal.APIResponse[[]systemServiceInterfaces.AdoptablePool] "Success"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /system/pools/adoptable [get]
+func ListAdoptablePools(systemService *system.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		pools, err := systemService.ListAdoptablePools(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_list_adoptable_pools",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]systemServiceInterfaces.AdoptablePool]{
+			Status:  "success",
+			Message: "adoptable_pools_listed",
+			Error:   "",
+			Data:    pools,
+		})
+	}
+}
+
+// @Summary Adopt ZFS Pool
+// @Description Import an existing pool if needed, validate its health, optionally create the sylve dataset skeleton and add it to the usable pools
+// @Tags System
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body systemServiceInterfaces.AdoptPoolRequest true "Pool to adopt"
+// @Success 200 {object} internal.APIResponse[systemServiceInterfaces.AdoptPoolResult] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 409 {object} internal.APIResponse[any] "Conflict"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /system/pools/adopt [post]
+func AdoptPool(systemService *system.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req systemServiceInterfaces.AdoptPoolRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "bad_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		result, err := systemService.AdoptPool(c.Request.Context(), req)
+		if err != nil {
+			status := http.StatusInternalServerError
+			msg := err.Error()
+			switch {
+			case strings.HasPrefix(msg, "pool_already_usable"),
+				strings.HasPrefix(msg, "pool_imported_by_another_system"),
+				strings.HasPrefix(msg, "pool_has_storage_fence"):
+				status = http.StatusConflict
+			case strings.HasPrefix(msg, "invalid_pool_name"),
+				strings.HasPrefix(msg, "pool_not_found"),
+				strings.HasPrefix(msg, "pool_name_ambiguous"),
+				strings.HasPrefix(msg, "pool_degraded_confirmation_required"),
+				strings.HasPrefix(msg, "pool_unhealthy"),
+				strings.HasPrefix(msg, "pool_missing_sylve_datasets"):
+				status = http.StatusBadRequest
+			}
+
+			c.JSON(status, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_adopt_pool",
+				Error:   msg,
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[systemServiceInterfaces.AdoptPoolResult]{
+			Status:  "success",
+			Message: "pool_adopted",
+			Error:   "",
+			Data:    result,
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package systemHandlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/alchemillahq/sylve/internal"
+	systemServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/system"
+	"github.com/alchemillahq/sylve/internal/services/system"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Run Self-Test
+// @Description Exercise ZFS, jail and send/receive paths on a scratch dataset and report pass/fail per subsystem
+// @Tags System
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body systemServiceInterfaces.SelfTestRequest false "Pool to test on (defaults to the first usable pool)"
+// @Success 200 {object} internal.APIResponse[systemServiceInterfaces.SelfTestReport] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /system/selftest [post]
+func RunSelfTest(systemService *system.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req systemServiceInterfaces.SelfTestRequest
+		if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		report, err := systemService.RunSelfTest(c.Request.Context(), req)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "selftest_failed_to_run",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		message := "selftest_passed"
+		if !report.Passed {
+			message = "selftest_failed"
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[systemServiceInterfaces.SelfTestReport]{
+			Status:  "success",
+			Message: message,
+			Error:   "",
+			Data:    report,
+		})
+	}
+}
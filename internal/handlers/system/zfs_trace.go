@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package systemHandlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/pkg/zfstrace"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get ZFS Command Trace
+// @Description Get recent zfs/zpool/zdb invocations with their duration and outcome, plus the ones that crossed the slow-command threshold
+// @Tags System
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Maximum entries per list (default all)"
+// @Success 200 {object} internal.APIResponse[zfstrace.Snapshot] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Router /system/zfs-trace [get]
+func ZFSTrace(tracer *zfstrace.Tracer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := 0
+		if raw := c.Query("limit"); raw != "" {
+			parsed, err := strconv.Atoi(raw)
+			if err != nil || parsed < 0 {
+				c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+					Status:  "error",
+					Message: "invalid_request",
+					Error:   "invalid_limit",
+					Data:    nil,
+				})
+				return
+			}
+			limit = parsed
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[zfstrace.Snapshot]{
+			Status:  "success",
+			Message: "zfs_trace",
+			Error:   "",
+			Data:    tracer.Snapshot(limit),
+		})
+	}
+}
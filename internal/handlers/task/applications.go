@@ -0,0 +1,226 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package taskHandlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
+	"github.com/alchemillahq/sylve/internal/services/lifecycle"
+	"github.com/gin-gonic/gin"
+)
+
+func applicationErrorStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case errors.Is(err, lifecycle.ErrApplicationNotFound),
+		strings.HasPrefix(msg, "application_member_not_found"):
+		return http.StatusNotFound
+	case errors.Is(err, lifecycle.ErrApplicationInProgress):
+		return http.StatusConflict
+	case errors.Is(err, lifecycle.ErrInvalidGuest),
+		errors.Is(err, lifecycle.ErrInvalidAction),
+		strings.HasPrefix(msg, "invalid_"):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func parseApplicationID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+			Status:  "error",
+			Message: "invalid_application_id",
+			Error:   "invalid_application_id",
+			Data:    nil,
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func ListApplications(lifecycleService *lifecycle.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apps, err := lifecycleService.ListApplications()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_list_applications",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]taskModels.Application]{
+			Status:  "success",
+			Message: "applications_listed",
+			Error:   "",
+			Data:    apps,
+		})
+	}
+}
+
+func GetApplication(lifecycleService *lifecycle.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseApplicationID(c)
+		if !ok {
+			return
+		}
+
+		app, err := lifecycleService.GetApplication(id)
+		if err != nil {
+			c.JSON(applicationErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_get_application",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*taskModels.Application]{
+			Status:  "success",
+			Message: "application_fetched",
+			Error:   "",
+			Data:    app,
+		})
+	}
+}
+
+func CreateApplication(lifecycleService *lifecycle.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req lifecycle.ApplicationRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		app, err := lifecycleService.CreateApplication(req)
+		if err != nil {
+			c.JSON(applicationErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_create_application",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*taskModels.Application]{
+			Status:  "success",
+			Message: "application_created",
+			Error:   "",
+			Data:    app,
+		})
+	}
+}
+
+func UpdateApplication(lifecycleService *lifecycle.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseApplicationID(c)
+		if !ok {
+			return
+		}
+
+		var req lifecycle.ApplicationRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		app, err := lifecycleService.UpdateApplication(id, req)
+		if err != nil {
+			c.JSON(applicationErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_update_application",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*taskModels.Application]{
+			Status:  "success",
+			Message: "application_updated",
+			Error:   "",
+			Data:    app,
+		})
+	}
+}
+
+func DeleteApplication(lifecycleService *lifecycle.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseApplicationID(c)
+		if !ok {
+			return
+		}
+
+		if err := lifecycleService.DeleteApplication(id); err != nil {
+			c.JSON(applicationErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_delete_application",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "application_deleted",
+			Error:   "",
+			Data:    nil,
+		})
+	}
+}
+
+// ApplicationAction queues an ordered start or stop of every application
+// member; progress is visible through the members' lifecycle tasks.
+func ApplicationAction(lifecycleService *lifecycle.Service, action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := parseApplicationID(c)
+		if !ok {
+			return
+		}
+
+		username := strings.TrimSpace(c.GetString("Username"))
+		if err := lifecycleService.RequestApplicationAction(c.Request.Context(), id, action, username); err != nil {
+			c.JSON(applicationErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_" + action + "_application",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusAccepted, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "application_" + action + "_queued",
+			Error:   "",
+			Data:    nil,
+		})
+	}
+}
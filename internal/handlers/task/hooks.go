@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package taskHandlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
+	"github.com/alchemillahq/sylve/internal/services/lifecycle"
+	"github.com/gin-gonic/gin"
+)
+
+func guestHookErrorStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.HasPrefix(msg, "guest_hook_not_found"),
+		strings.HasPrefix(msg, "hook_script_not_found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(msg, "invalid_"):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func GuestHooks(lifecycleService *lifecycle.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		guestType := strings.TrimSpace(c.Query("guestType"))
+		var guestID uint64
+		if raw := strings.TrimSpace(c.Query("guestId")); raw != "" {
+			parsed, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+					Status:  "error",
+					Message: "invalid_guest_id",
+					Error:   err.Error(),
+					Data:    nil,
+				})
+				return
+			}
+			guestID = parsed
+		}
+
+		hooks, err := lifecycleService.ListGuestHooks(guestType, uint(guestID))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_list_guest_hooks",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]taskModels.GuestHook]{
+			Status:  "success",
+			Message: "guest_hooks_listed",
+			Error:   "",
+			Data:    hooks,
+		})
+	}
+}
+
+func SaveGuestHook(lifecycleService *lifecycle.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req lifecycle.GuestHookRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		hook, err := lifecycleService.SaveGuestHook(req)
+		if err != nil {
+			c.JSON(guestHookErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_save_guest_hook",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[taskModels.GuestHook]{
+			Status:  "success",
+			Message: "guest_hook_saved",
+			Error:   "",
+			Data:    hook,
+		})
+	}
+}
+
+func DeleteGuestHook(lifecycleService *lifecycle.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil || id == 0 {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_guest_hook_id",
+				Error:   "invalid_guest_hook_id",
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := lifecycleService.DeleteGuestHook(uint(id)); err != nil {
+			c.JSON(guestHookErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_delete_guest_hook",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "guest_hook_deleted",
+			Error:   "",
+			Data:    nil,
+		})
+	}
+}
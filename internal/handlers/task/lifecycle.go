@@ -149,3 +149,14 @@ func ActiveLifecycleTaskForGuest(lifecycleService *lifecycle.Service) gin.Handle
 		})
 	}
 }
+
+func HeavyOps(lifecycleService *lifecycle.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, internal.APIResponse[[]lifecycle.HeavyOpsPool]{
+			Status:  "success",
+			Message: "heavy_ops_listed",
+			Error:   "",
+			Data:    lifecycleService.HeavyOps(),
+		})
+	}
+}
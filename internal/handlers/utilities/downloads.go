@@ -13,6 +13,7 @@ import (
 	"net/http"
 	"path"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/alchemillahq/sylve/internal"
@@ -431,6 +432,68 @@ func UpdateDownload(utilitiesService *utilities.Service) gin.HandlerFunc {
 	}
 }
 
+// @Summary Verify Download
+// @Description Check a finished download against a SHA256, a published checksum list or a detached signature
+// @Tags Utilities
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Download ID"
+// @Param request body utilitiesServiceInterfaces.DownloadVerificationRequest true "Verification Request"
+// @Success 202 {object} internal.APIResponse[any] "Accepted"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /utilities/downloads/{id}/verify [post]
+func VerifyDownload(utilitiesService *utilities.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := utils.GetIdFromParam(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		var request utilitiesServiceInterfaces.DownloadVerificationRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := utilitiesService.VerifyDownload(uint(id), request); err != nil {
+			status := http.StatusBadRequest
+			switch {
+			case strings.HasPrefix(err.Error(), "download_not_found"):
+				status = http.StatusNotFound
+			case strings.HasPrefix(err.Error(), "failed_to_"):
+				status = http.StatusInternalServerError
+			}
+			c.JSON(status, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_verify_download",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusAccepted, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "download_verification_queued",
+			Error:   "",
+			Data:    nil,
+		})
+	}
+}
+
 // @Summary Download File
 // @Description Download a file from a signed URL
 // @Tags Utilities
@@ -488,10 +488,11 @@ func GetLvDomain(libvirtService *libvirt.Service, lifecycleService *lifecycle.Se
 // @Security BearerAuth
 // @Param request body libvirtServiceInterfaces.CreateVMRequest true "Create Virtual Machine Request"
 // @Success 200 {object} internal.APIResponse[any] "Success"
+// @Success 202 {object} internal.APIResponse[taskModels.GuestLifecycleTask] "Queued until the pool has capacity"
 // @Failure 400 {object} internal.APIResponse[any] "Bad Request"
 // @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
 // @Router /vm [post]
-func CreateVM(libvirtService *libvirt.Service) gin.HandlerFunc {
+func CreateVM(libvirtService *libvirt.Service, lifecycleService *lifecycle.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req libvirtServiceInterfaces.CreateVMRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
@@ -505,7 +506,15 @@ func CreateVM(libvirtService *libvirt.Service) gin.HandlerFunc {
 		}
 
 		ctx := c.Request.Context()
-		err := libvirtService.CreateVM(req, ctx)
+		task, err := lifecycleService.RunOrQueueCreate(
+			ctx,
+			taskModels.GuestTypeVM,
+			*req.RID,
+			[]string{req.StoragePool},
+			req,
+			strings.TrimSpace(c.GetString("Username")),
+			func() error { return libvirtService.CreateVM(req, ctx) },
+		)
 
 		if err != nil {
 			statusCode, errorCode := classifyCreateVMError(err)
@@ -519,6 +528,19 @@ func CreateVM(libvirtService *libvirt.Service) gin.HandlerFunc {
 			return
 		}
 
+		if task != nil {
+			c.Set("AuditAsyncJobID", task.ID)
+			c.Set("AuditAsyncJobType", "vm_create")
+
+			c.JSON(http.StatusAccepted, internal.APIResponse[*taskModels.GuestLifecycleTask]{
+				Status:  "success",
+				Message: "vm_create_queued",
+				Data:    task,
+				Error:   "",
+			})
+			return
+		}
+
 		c.JSON(200, internal.APIResponse[any]{
 			Status:  "success",
 			Message: "vm_created",
@@ -528,6 +550,40 @@ func CreateVM(libvirtService *libvirt.Service) gin.HandlerFunc {
 	}
 }
 
+// @Summary Validate a Virtual Machine Spec
+// @Description Run every creation check against a virtual machine spec and report all errors and warnings without creating anything
+// @Tags VM
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body libvirtServiceInterfaces.CreateVMRequest true "Create Virtual Machine Request"
+// @Success 200 {object} internal.APIResponse[libvirtServiceInterfaces.CreateVMPreflightReport] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Router /vm/validate [post]
+func ValidateCreateVM(libvirtService *libvirt.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req libvirtServiceInterfaces.CreateVMRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(400, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request_data",
+				Data:    nil,
+				Error:   "Invalid request data: " + err.Error(),
+			})
+			return
+		}
+
+		report := libvirtService.PreflightCreateVM(c.Request.Context(), req)
+
+		c.JSON(200, internal.APIResponse[libvirtServiceInterfaces.CreateVMPreflightReport]{
+			Status:  "success",
+			Message: "vm_spec_validated",
+			Data:    report,
+			Error:   "",
+		})
+	}
+}
+
 // @Summary Remove a Virtual Machine
 // @Description Remove a virtual machine by its ID
 // @Tags VM
@@ -41,6 +41,10 @@ type ModifyPassthroughRequest struct {
 	PCIDevices []int `json:"pciDevices" binding:"required"`
 }
 
+type ModifyCPUFeaturesRequest struct {
+	CPUFeatures []string `json:"cpuFeatures"`
+}
+
 // @Summary Modify CPU of a Virtual Machine
 // @Description Modify the CPU configuration of a virtual machine
 // @Tags VM
@@ -316,3 +320,93 @@ func ModifyPassthroughDevices(libvirtService *libvirt.Service) gin.HandlerFunc {
 		})
 	}
 }
+
+// @Summary Get Host CPU Capabilities
+// @Description Get the host CPU as reported by cpuid, the CPU feature toggles available to VMs and, when vcpus is given, a suggested topology
+// @Tags VM
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vcpus query int false "Number of vCPUs to suggest a topology for"
+// @Success 200 {object} internal.APIResponse[libvirtServiceInterfaces.CPUCapabilities] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Router /hardware/cpu/capabilities [get]
+func GetCPUCapabilities(libvirtService *libvirt.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		vcpus := 0
+		if raw := strings.TrimSpace(c.Query("vcpus")); raw != "" {
+			parsed, err := strconv.Atoi(raw)
+			if err != nil || parsed < 0 {
+				c.JSON(400, internal.APIResponse[any]{
+					Status:  "error",
+					Message: "invalid_request",
+					Data:    nil,
+					Error:   "invalid_vcpus",
+				})
+				return
+			}
+			vcpus = parsed
+		}
+
+		c.JSON(200, internal.APIResponse[libvirtServiceInterfaces.CPUCapabilities]{
+			Status:  "success",
+			Message: "cpu_capabilities",
+			Data:    libvirtService.GetCPUCapabilities(vcpus),
+			Error:   "",
+		})
+	}
+}
+
+// @Summary Modify CPU Features of a Virtual Machine
+// @Description Modify the CPU feature toggles exposed to a virtual machine
+// @Tags VM
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body ModifyCPUFeaturesRequest true "Modify CPU Features Request"
+// @Success 200 {object} internal.APIResponse[any] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /hardware/cpu-features/:rid [put]
+func ModifyCPUFeatures(libvirtService *libvirt.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ridInt, err := strconv.Atoi(c.Param("rid"))
+		if err != nil {
+			c.JSON(400, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Data:    nil,
+				Error:   "invalid_rid_format",
+			})
+			return
+		}
+
+		var req ModifyCPUFeaturesRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(400, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Data:    nil,
+				Error:   "invalid_request: " + err.Error(),
+			})
+			return
+		}
+
+		if err := libvirtService.ModifyCPUFeatures(uint(ridInt), req.CPUFeatures); err != nil {
+			c.JSON(500, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "internal_server_error",
+				Data:    nil,
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		c.JSON(200, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "cpu_features_modified",
+			Data:    nil,
+			Error:   "",
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package libvirtHandlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/internal/services/libvirt"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// HandleVMLiveStatsWebsocket streams one JSON VMLiveStats sample per second
+// for as long as the socket is open. Clients only need to read.
+func HandleVMLiveStatsWebsocket(libvirtService *libvirt.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rid, err := strconv.ParseUint(c.Query("rid"), 10, 32)
+		if err != nil || rid == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid rid"})
+			return
+		}
+
+		samples, unsubscribe, err := libvirtService.SubscribeVMLiveStats(uint(rid))
+		if err != nil {
+			status := http.StatusInternalServerError
+			switch {
+			case strings.Contains(err.Error(), "vm_live_stats_subscriber_limit"):
+				status = http.StatusTooManyRequests
+			case strings.Contains(err.Error(), "not_found"):
+				status = http.StatusNotFound
+			}
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+		defer unsubscribe()
+
+		conn, err := VMWSUpgrader.Upgrade(c.Writer, c.Request, nil)
+		if err != nil {
+			logger.L.Error().Err(err).Msg("websocket upgrade failed")
+			return
+		}
+
+		observer := &VMObserver{Conn: conn}
+		defer observer.Close()
+
+		conn.SetReadLimit(1024)
+		_ = conn.SetReadDeadline(time.Now().Add(vmWSPongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(vmWSPongWait))
+		})
+
+		// Reading is only needed to process pongs and notice the close.
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			for {
+				if _, _, err := conn.NextReader(); err != nil {
+					return
+				}
+			}
+		}()
+
+		ping := time.NewTicker(vmWSPingPeriod)
+		defer ping.Stop()
+
+		for {
+			select {
+			case <-closed:
+				return
+			case <-ping.C:
+				if err := observer.WriteControl(websocket.PingMessage, nil, time.Now().Add(vmWSWriteTimeout)); err != nil {
+					return
+				}
+			case sample, ok := <-samples:
+				if !ok {
+					return
+				}
+				payload, err := json.Marshal(sample)
+				if err != nil {
+					logger.L.Warn().Err(err).Uint64("rid", rid).Msg("failed to encode VM live stats")
+					continue
+				}
+				if err := observer.WriteMessage(websocket.TextMessage, payload); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
@@ -11,7 +11,9 @@ package libvirtHandlers
 import (
 	"github.com/alchemillahq/sylve/internal"
 	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/internal/services/libvirt"
+	networkService "github.com/alchemillahq/sylve/internal/services/network"
 
 	"github.com/gin-gonic/gin"
 )
@@ -31,7 +33,7 @@ type NetworkDetachRequest struct {
 // @Failure 400 {object} internal.APIResponse[any] "Bad Request"
 // @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
 // @Router /network/detach [post]
-func NetworkDetach(libvirtService *libvirt.Service) gin.HandlerFunc {
+func NetworkDetach(libvirtService *libvirt.Service, networkSvc *networkService.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req NetworkDetachRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
@@ -54,6 +56,10 @@ func NetworkDetach(libvirtService *libvirt.Service) gin.HandlerFunc {
 			return
 		}
 
+		if err := networkSvc.SyncIsolationGroups(); err != nil {
+			logger.L.Error().Err(err).Msg("Failed to sync isolation groups")
+		}
+
 		c.JSON(200, internal.APIResponse[any]{
 			Status:  "success",
 			Message: "network_detached",
@@ -73,7 +79,7 @@ func NetworkDetach(libvirtService *libvirt.Service) gin.HandlerFunc {
 // @Failure 400 {object} internal.APIResponse[any] "Bad Request"
 // @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
 // @Router /network/attach [post]
-func NetworkAttach(libvirtService *libvirt.Service) gin.HandlerFunc {
+func NetworkAttach(libvirtService *libvirt.Service, networkSvc *networkService.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req libvirtServiceInterfaces.NetworkAttachRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
@@ -96,6 +102,10 @@ func NetworkAttach(libvirtService *libvirt.Service) gin.HandlerFunc {
 			return
 		}
 
+		if err := networkSvc.SyncIsolationGroups(); err != nil {
+			logger.L.Error().Err(err).Msg("Failed to sync isolation groups")
+		}
+
 		c.JSON(200, internal.APIResponse[any]{
 			Status:  "success",
 			Message: "network_attached",
@@ -115,7 +125,7 @@ func NetworkAttach(libvirtService *libvirt.Service) gin.HandlerFunc {
 // @Failure 400 {object} internal.APIResponse[any] "Bad Request"
 // @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
 // @Router /network/update [put]
-func NetworkUpdate(libvirtService *libvirt.Service) gin.HandlerFunc {
+func NetworkUpdate(libvirtService *libvirt.Service, networkSvc *networkService.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req libvirtServiceInterfaces.NetworkUpdateRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
@@ -138,6 +148,10 @@ func NetworkUpdate(libvirtService *libvirt.Service) gin.HandlerFunc {
 			return
 		}
 
+		if err := networkSvc.SyncIsolationGroups(); err != nil {
+			logger.L.Error().Err(err).Msg("Failed to sync isolation groups")
+		}
+
 		c.JSON(200, internal.APIResponse[any]{
 			Status:  "success",
 			Message: "network_updated",
@@ -45,6 +45,11 @@ type ModifyCloudInitDataRequest struct {
 	NetworkConfig string `json:"networkConfig"`
 }
 
+type ModifyGuestMetadataRequest struct {
+	SSHKeys []string `json:"sshKeys"`
+	Tags    []string `json:"tags"`
+}
+
 type ModifyBootROMRequest struct {
 	BootROM string `json:"bootRom"`
 }
@@ -492,6 +497,71 @@ func ModifyCloudInitData(libvirtService *libvirt.Service) gin.HandlerFunc {
 	}
 }
 
+// @Summary Modify Guest Metadata of a Virtual Machine
+// @Description Modify the SSH keys and tags served to a virtual machine by the guest metadata service
+// @Tags VM
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body ModifyGuestMetadataRequest true "Modify Guest Metadata Request"
+// @Success 200 {object} internal.APIResponse[any] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /options/guest-metadata/:rid [put]
+func ModifyGuestMetadata(libvirtService *libvirt.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rid := c.Param("rid")
+		if rid == "" {
+			c.JSON(400, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Data:    nil,
+				Error:   "rid_not_provided",
+			})
+			return
+		}
+
+		ridInt, err := strconv.Atoi(rid)
+		if err != nil {
+			c.JSON(400, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Data:    nil,
+				Error:   "invalid_rid_format",
+			})
+			return
+		}
+
+		var req ModifyGuestMetadataRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(400, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Data:    nil,
+				Error:   "invalid_request: " + err.Error(),
+			})
+			return
+		}
+
+		if err := libvirtService.ModifyGuestMetadata(uint(ridInt), req.SSHKeys, req.Tags); err != nil {
+			c.JSON(500, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "internal_server_error",
+				Data:    nil,
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		c.JSON(200, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "guest_metadata_modified",
+			Data:    nil,
+			Error:   "",
+		})
+	}
+}
+
 // @Summary Modify Boot ROM of a Virtual Machine
 // @Description Modify the Boot ROM mode of a virtual machine
 // @Tags VM
@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package libvirtHandlers
+
+import (
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	utilitiesModels "github.com/alchemillahq/sylve/internal/db/models/utilities"
+	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+	utilitiesServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/utilities"
+	"github.com/alchemillahq/sylve/internal/services/libvirt"
+	"github.com/alchemillahq/sylve/internal/services/utilities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get a VM Creation Preset
+// @Description Describe the settings, device models and managed driver media a creation preset applies
+// @Tags VM
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Preset name"
+// @Success 200 {object} internal.APIResponse[libvirtServiceInterfaces.VMPresetInfo] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Router /vm/presets/{name} [get]
+func GetVMPreset(libvirtService *libvirt.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := libvirtServiceInterfaces.VMPreset(strings.TrimSpace(c.Param("name")))
+
+		info, err := libvirtService.GetVMPreset(name)
+		if err != nil {
+			status := 500
+			if strings.HasPrefix(err.Error(), "unknown_vm_preset") {
+				status = 400
+			}
+
+			c.JSON(status, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_get_vm_preset",
+				Data:    nil,
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		c.JSON(200, internal.APIResponse[libvirtServiceInterfaces.VMPresetInfo]{
+			Status:  "success",
+			Message: "vm_preset",
+			Data:    info,
+			Error:   "",
+		})
+	}
+}
+
+// @Summary Fetch Windows Driver Media
+// @Description Queue the managed virtio-win driver ISO download used by the Windows preset, if it is not already present
+// @Tags VM
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} internal.APIResponse[libvirtServiceInterfaces.VMPresetInfo] "Success"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /vm/presets/windows/driver-media [post]
+func FetchWindowsDriverMedia(libvirtService *libvirt.Service, utilitiesService *utilities.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		info, err := libvirtService.GetVMPreset(libvirtServiceInterfaces.VMPresetWindows)
+		if err != nil {
+			c.JSON(500, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_get_vm_preset",
+				Data:    nil,
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		if info.DriverMedia != nil && info.DriverMedia.DownloadUUID == "" {
+			filename := "virtio-win.iso"
+			downloadID, err := utilitiesService.DownloadFile(utilitiesServiceInterfaces.DownloadFileRequest{
+				URL:          libvirt.VirtioWinISOURL,
+				Filename:     &filename,
+				DownloadType: utilitiesModels.DownloadUTypeOther,
+			})
+			if err != nil {
+				c.JSON(500, internal.APIResponse[any]{
+					Status:  "error",
+					Message: "failed_to_download_file",
+					Data:    nil,
+					Error:   err.Error(),
+				})
+				return
+			}
+
+			c.Set("AuditAsyncJobID", downloadID)
+			c.Set("AuditAsyncJobType", "file_download")
+
+			if info, err = libvirtService.GetVMPreset(libvirtServiceInterfaces.VMPresetWindows); err != nil {
+				c.JSON(500, internal.APIResponse[any]{
+					Status:  "error",
+					Message: "failed_to_get_vm_preset",
+					Data:    nil,
+					Error:   err.Error(),
+				})
+				return
+			}
+		}
+
+		c.JSON(200, internal.APIResponse[libvirtServiceInterfaces.VMPresetInfo]{
+			Status:  "success",
+			Message: "windows_driver_media_requested",
+			Data:    info,
+			Error:   "",
+		})
+	}
+}
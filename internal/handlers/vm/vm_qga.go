@@ -9,7 +9,10 @@
 package libvirtHandlers
 
 import (
+	"strings"
+
 	"github.com/alchemillahq/sylve/internal"
+	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
 	"github.com/alchemillahq/sylve/internal/services/libvirt"
 	"github.com/alchemillahq/sylve/pkg/utils"
 	"github.com/gin-gonic/gin"
@@ -57,3 +60,68 @@ func GetQemuGuestAgentInfo(libvirtService *libvirt.Service) gin.HandlerFunc {
 		})
 	}
 }
+
+// @Summary Run a command in a Virtual Machine
+// @Description Run a command inside a virtual machine through its QEMU Guest Agent and return its output once it exits or times out
+// @Tags VM
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rid path int true "VM RID"
+// @Param request body libvirtServiceInterfaces.VMExecRequest true "Command"
+// @Success 200 {object} internal.APIResponse[libvirtServiceInterfaces.VMExecResult] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 409 {object} internal.APIResponse[any] "Conflict"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /vm/qga/{rid}/exec [post]
+func ExecInGuest(libvirtService *libvirt.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rid, err := utils.ParamUint(c, "rid")
+		if err != nil {
+			c.JSON(400, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Data:    nil,
+				Error:   "invalid_rid_format",
+			})
+			return
+		}
+
+		var req libvirtServiceInterfaces.VMExecRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(400, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Data:    nil,
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		result, err := libvirtService.ExecInGuest(c.Request.Context(), rid, req)
+		if err != nil {
+			status := 500
+			switch {
+			case strings.HasPrefix(err.Error(), "exec_"):
+				status = 400
+			case err.Error() == "qemu_guest_agent_disabled":
+				status = 409
+			}
+
+			c.JSON(status, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_exec_in_guest",
+				Data:    nil,
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		c.JSON(200, internal.APIResponse[libvirtServiceInterfaces.VMExecResult]{
+			Status:  "success",
+			Message: "guest_exec_completed",
+			Data:    result,
+			Error:   "",
+		})
+	}
+}
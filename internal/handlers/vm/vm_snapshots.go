@@ -10,11 +10,14 @@ package libvirtHandlers
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/alchemillahq/sylve/internal"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
 	"github.com/alchemillahq/sylve/internal/services/libvirt"
 	"github.com/alchemillahq/sylve/pkg/utils"
+	"github.com/alchemillahq/sylve/pkg/zfsdiff"
 	"github.com/gin-gonic/gin"
 )
 
@@ -190,3 +193,179 @@ func DeleteVMSnapshot(libvirtService *libvirt.Service) gin.HandlerFunc {
 		})
 	}
 }
+
+func DiffVMSnapshot(libvirtService *libvirt.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rid, err := utils.ParamUint(c, "id")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		snapshotID, err := utils.ParamUint(c, "snapshotId")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		var toSnapshotID uint
+		if raw := c.Query("to"); raw != "" {
+			parsed, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil || parsed == 0 {
+				c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+					Status:  "error",
+					Message: "invalid_to_snapshot",
+					Error:   "to_must_be_a_snapshot_id",
+					Data:    nil,
+				})
+				return
+			}
+			toSnapshotID = uint(parsed)
+		}
+
+		diff, err := libvirtService.DiffVMSnapshot(c.Request.Context(), rid, snapshotID, toSnapshotID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_diff_vm_snapshot",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]zfsdiff.DatasetDiff]{
+			Status:  "success",
+			Message: "vm_snapshot_diffed",
+			Error:   "",
+			Data:    diff,
+		})
+	}
+}
+
+func GetVMSnapshotSchedule(libvirtService *libvirt.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rid, err := utils.ParamUint(c, "id")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		schedule, err := libvirtService.GetVMSnapshotSchedule(rid)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_get_vm_snapshot_schedule",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*vmModels.VMSnapshotSchedule]{
+			Status:  "success",
+			Message: "vm_snapshot_schedule_retrieved",
+			Error:   "",
+			Data:    schedule,
+		})
+	}
+}
+
+func SetVMSnapshotSchedule(libvirtService *libvirt.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rid, err := utils.ParamUint(c, "id")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		var req libvirt.VMSnapshotScheduleInput
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		schedule, err := libvirtService.SetVMSnapshotSchedule(rid, req)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if strings.HasPrefix(err.Error(), "invalid_") || err.Error() == "name_pattern_too_long" {
+				status = http.StatusBadRequest
+			}
+			c.JSON(status, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_set_vm_snapshot_schedule",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[vmModels.VMSnapshotSchedule]{
+			Status:  "success",
+			Message: "vm_snapshot_schedule_set",
+			Error:   "",
+			Data:    *schedule,
+		})
+	}
+}
+
+func DeleteVMSnapshotSchedule(libvirtService *libvirt.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rid, err := utils.ParamUint(c, "id")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := libvirtService.DeleteVMSnapshotSchedule(rid); err != nil {
+			status := http.StatusInternalServerError
+			if err.Error() == "vm_snapshot_schedule_not_found" {
+				status = http.StatusNotFound
+			}
+			c.JSON(status, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_delete_vm_snapshot_schedule",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "vm_snapshot_schedule_deleted",
+			Error:   "",
+			Data:    nil,
+		})
+	}
+}
@@ -9,9 +9,12 @@
 package libvirtHandlers
 
 import (
+	"strconv"
+
 	"github.com/alchemillahq/sylve/internal"
 	"github.com/alchemillahq/sylve/internal/db"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
 	"github.com/alchemillahq/sylve/internal/services/libvirt"
 	"github.com/alchemillahq/sylve/pkg/utils"
 
@@ -61,3 +64,49 @@ func GetVMStats(libvirtService *libvirt.Service) gin.HandlerFunc {
 		})
 	}
 }
+
+// @Summary Get VM Network Flows
+// @Description Retrieve sampled pf connection states, top talkers and tap counters for a running virtual machine
+// @Tags VM
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rid path int true "VM RID"
+// @Param limit query int false "Maximum number of flows to return"
+// @Success 200 {object} internal.APIResponse[libvirtServiceInterfaces.VMFlowStats] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /vm/{rid}/flows [get]
+func GetVMFlows(libvirtService *libvirt.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rid := utils.StringToUint64(c.Param("id"))
+		if rid == 0 {
+			c.JSON(400, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Data:    nil,
+				Error:   "invalid_rid",
+			})
+			return
+		}
+
+		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "0"))
+		flows, err := libvirtService.GetVMFlows(uint(rid), limit)
+		if err != nil {
+			c.JSON(500, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_get_vm_flows",
+				Data:    nil,
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		c.JSON(200, internal.APIResponse[*libvirtServiceInterfaces.VMFlowStats]{
+			Status:  "success",
+			Message: "vm_flows_retrieved",
+			Data:    flows,
+			Error:   "",
+		})
+	}
+}
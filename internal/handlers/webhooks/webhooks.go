@@ -0,0 +1,254 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package webhookHandlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/alchemillahq/sylve/internal"
+	webhookModels "github.com/alchemillahq/sylve/internal/db/models/webhooks"
+	"github.com/alchemillahq/sylve/internal/services/webhooks"
+	"github.com/gin-gonic/gin"
+)
+
+type webhookService interface {
+	ListWebhooks(context.Context) ([]webhooks.WebhookView, error)
+	CreateWebhook(context.Context, webhooks.WebhookInput) (*webhooks.WebhookView, error)
+	UpdateWebhook(context.Context, uint, webhooks.WebhookInput) (*webhooks.WebhookView, error)
+	DeleteWebhook(context.Context, uint) error
+	TestWebhook(context.Context, uint) (*webhookModels.Delivery, error)
+	ListDeliveries(context.Context, uint, int) ([]webhookModels.Delivery, error)
+	RedeliverDelivery(context.Context, uint, uint) (*webhookModels.Delivery, error)
+}
+
+func webhookErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, webhooks.ErrInvalidWebhook):
+		return http.StatusBadRequest
+	case errors.Is(err, webhooks.ErrWebhookNotFound), errors.Is(err, webhooks.ErrDeliveryNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func ListWebhooks(service webhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		hooks, err := service.ListWebhooks(c.Request.Context())
+		if err != nil {
+			c.JSON(webhookErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "error_listing_webhooks",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+		c.JSON(http.StatusOK, internal.APIResponse[[]webhooks.WebhookView]{
+			Status:  "success",
+			Message: "webhooks_listed",
+			Error:   "",
+			Data:    hooks,
+		})
+	}
+}
+
+func CreateWebhook(service webhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input webhooks.WebhookInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_webhook",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		hook, err := service.CreateWebhook(c.Request.Context(), input)
+		if err != nil {
+			c.JSON(webhookErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "error_creating_webhook",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+		c.JSON(http.StatusCreated, internal.APIResponse[*webhooks.WebhookView]{
+			Status:  "success",
+			Message: "webhook_created",
+			Error:   "",
+			Data:    hook,
+		})
+	}
+}
+
+func UpdateWebhook(service webhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := webhookParamID(c, "id")
+		if !ok {
+			return
+		}
+
+		var input webhooks.WebhookInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_webhook",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		hook, err := service.UpdateWebhook(c.Request.Context(), id, input)
+		if err != nil {
+			c.JSON(webhookErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "error_updating_webhook",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+		c.JSON(http.StatusOK, internal.APIResponse[*webhooks.WebhookView]{
+			Status:  "success",
+			Message: "webhook_updated",
+			Error:   "",
+			Data:    hook,
+		})
+	}
+}
+
+func DeleteWebhook(service webhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := webhookParamID(c, "id")
+		if !ok {
+			return
+		}
+
+		if err := service.DeleteWebhook(c.Request.Context(), id); err != nil {
+			c.JSON(webhookErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "error_deleting_webhook",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "webhook_deleted",
+			Error:   "",
+			Data:    nil,
+		})
+	}
+}
+
+func TestWebhook(service webhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := webhookParamID(c, "id")
+		if !ok {
+			return
+		}
+
+		delivery, err := service.TestWebhook(c.Request.Context(), id)
+		if err != nil {
+			c.JSON(webhookErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "error_testing_webhook",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+		c.JSON(http.StatusAccepted, internal.APIResponse[*webhookModels.Delivery]{
+			Status:  "success",
+			Message: "webhook_test_queued",
+			Error:   "",
+			Data:    delivery,
+		})
+	}
+}
+
+func ListDeliveries(service webhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := webhookParamID(c, "id")
+		if !ok {
+			return
+		}
+		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+
+		deliveries, err := service.ListDeliveries(c.Request.Context(), id, limit)
+		if err != nil {
+			c.JSON(webhookErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "error_listing_webhook_deliveries",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+		c.JSON(http.StatusOK, internal.APIResponse[[]webhookModels.Delivery]{
+			Status:  "success",
+			Message: "webhook_deliveries_listed",
+			Error:   "",
+			Data:    deliveries,
+		})
+	}
+}
+
+func RedeliverDelivery(service webhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := webhookParamID(c, "id")
+		if !ok {
+			return
+		}
+		deliveryID, ok := webhookParamID(c, "deliveryId")
+		if !ok {
+			return
+		}
+
+		delivery, err := service.RedeliverDelivery(c.Request.Context(), id, deliveryID)
+		if err != nil {
+			c.JSON(webhookErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "error_redelivering_webhook",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+		c.JSON(http.StatusAccepted, internal.APIResponse[*webhookModels.Delivery]{
+			Status:  "success",
+			Message: "webhook_redelivery_queued",
+			Error:   "",
+			Data:    delivery,
+		})
+	}
+}
+
+func webhookParamID(c *gin.Context, param string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(param), 10, strconv.IntSize)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+			Status:  "error",
+			Message: "invalid_webhook_id",
+			Error:   "invalid_" + param,
+			Data:    nil,
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
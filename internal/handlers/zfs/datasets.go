@@ -63,6 +63,16 @@ type FlashVolumeRequest struct {
 	UUID string `json:"uuid" binding:"required"`
 }
 
+type RenameDatasetRequest struct {
+	GUID    string `json:"guid" binding:"required"`
+	NewName string `json:"newName" binding:"required"`
+}
+
+type RenameSnapshotRequest struct {
+	GUID    string `json:"guid" binding:"required"`
+	NewName string `json:"newName" binding:"required"`
+}
+
 type DatasetListResponse struct {
 	Status  string          `json:"status"`
 	Message string          `json:"message"`
@@ -276,6 +286,98 @@ func RollbackSnapshot(zfsService *zfs.Service) gin.HandlerFunc {
 	}
 }
 
+// @Summary Rename a ZFS snapshot
+// @Description Rename a ZFS snapshot. VM and jail snapshots are renamed on all their root datasets.
+// @Tags ZFS
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body RenameSnapshotRequest true "Rename Snapshot Request"
+// @Success 200 {object} internal.APIResponse[any] "OK"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /zfs/datasets/snapshot/rename [post]
+func RenameSnapshot(zfsService *zfs.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request RenameSnapshotRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		ctx := c.Request.Context()
+		if err := zfsService.RenameSnapshot(ctx, request.GUID, request.NewName); err != nil {
+			status, message := http.StatusInternalServerError, "internal_server_error"
+			if errors.Is(err, zfs.ErrReservedSnapshotNamespace) {
+				status, message = http.StatusBadRequest, "snapshot_namespace_reserved"
+			}
+			c.JSON(status, internal.APIResponse[any]{
+				Status:  "error",
+				Message: message,
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "renamed_snapshot",
+			Error:   "",
+			Data:    nil,
+		})
+	}
+}
+
+// @Summary Rename a ZFS dataset
+// @Description Rename or move a filesystem or volume within its pool and update the Sylve records that reference it
+// @Tags ZFS
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body RenameDatasetRequest true "Rename Dataset Request"
+// @Success 200 {object} internal.APIResponse[any] "OK"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /zfs/datasets/rename [post]
+func RenameDataset(zfsService *zfs.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request RenameDatasetRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		ctx := c.Request.Context()
+		if err := zfsService.RenameDataset(ctx, request.GUID, request.NewName); err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "internal_server_error",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "renamed_dataset",
+			Error:   "",
+			Data:    nil,
+		})
+	}
+}
+
 // @Summary Get all periodic ZFS snapshot jobs
 // @Description Get all periodic ZFS snapshots jobs
 // @Tags ZFS
@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zfsHandlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	zfsModels "github.com/alchemillahq/sylve/internal/db/models/zfs"
+	zfsServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/zfs"
+	"github.com/alchemillahq/sylve/internal/services/zfs"
+	"github.com/gin-gonic/gin"
+)
+
+func delegationErrorStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.HasPrefix(msg, "delegation_not_found"),
+		strings.HasPrefix(msg, "dataset_not_found"),
+		strings.HasPrefix(msg, "delegation_user_not_found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(msg, "invalid_"),
+		strings.HasPrefix(msg, "delegation_user_is_root"):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// @Summary List ZFS delegations
+// @Description List `zfs allow` grants made through Sylve
+// @Tags ZFS
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} internal.APIResponse[[]zfsModels.Delegation] "OK"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /zfs/delegations [get]
+func ListDelegations(zfsService *zfs.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		delegations, err := zfsService.ListDelegations()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_list_delegations",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[[]zfsModels.Delegation]{
+			Status:  "success",
+			Message: "delegations_listed",
+			Error:   "",
+			Data:    delegations,
+		})
+	}
+}
+
+// @Summary Delegate ZFS permissions
+// @Description Grant a non-root user ZFS permissions on a dataset. Defaults to snapshot, send and hold.
+// @Tags ZFS
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body zfsServiceInterfaces.DelegationRequest true "Delegation Request"
+// @Success 200 {object} internal.APIResponse[zfsModels.Delegation] "OK"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 404 {object} internal.APIResponse[any] "Not Found"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /zfs/delegations [post]
+func Delegate(zfsService *zfs.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request zfsServiceInterfaces.DelegationRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		delegation, err := zfsService.Delegate(c.Request.Context(), request)
+		if err != nil {
+			c.JSON(delegationErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_delegate",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[zfsModels.Delegation]{
+			Status:  "success",
+			Message: "delegated",
+			Error:   "",
+			Data:    delegation,
+		})
+	}
+}
+
+// @Summary Revoke ZFS delegation
+// @Tags ZFS
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Delegation ID"
+// @Success 200 {object} internal.APIResponse[any] "OK"
+// @Failure 404 {object} internal.APIResponse[any] "Not Found"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /zfs/delegations/{id} [delete]
+func RemoveDelegation(zfsService *zfs.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil || id == 0 {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_delegation_id",
+				Error:   "invalid_delegation_id",
+				Data:    nil,
+			})
+			return
+		}
+
+		if err := zfsService.RemoveDelegation(c.Request.Context(), uint(id)); err != nil {
+			c.JSON(delegationErrorStatus(err), internal.APIResponse[any]{
+				Status:  "error",
+				Message: "failed_to_remove_delegation",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[any]{
+			Status:  "success",
+			Message: "delegation_removed",
+			Error:   "",
+			Data:    nil,
+		})
+	}
+}
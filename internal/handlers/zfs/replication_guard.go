@@ -29,6 +29,8 @@ const (
 	ReplicationGuardEditVolume       ReplicationMutationGuardOperation = "edit_volume"
 	ReplicationGuardFlashVolume      ReplicationMutationGuardOperation = "flash_volume"
 	ReplicationGuardRollbackSnapshot ReplicationMutationGuardOperation = "rollback_snapshot"
+	ReplicationGuardRenameDataset    ReplicationMutationGuardOperation = "rename_dataset"
+	ReplicationGuardRenameSnapshot   ReplicationMutationGuardOperation = "rename_snapshot"
 )
 
 func decodeAndRestoreMutationBody(c *gin.Context, target any) error {
@@ -128,6 +130,18 @@ func ReplicationDatasetMutationGuard(
 			if err = decodeAndRestoreMutationBody(c, &req); err == nil {
 				err = zfsService.RequireReplicationDatasetGUIDMutationAllowed(ctx, req.GUID)
 			}
+		case ReplicationGuardRenameDataset:
+			var req RenameDatasetRequest
+			if err = decodeAndRestoreMutationBody(c, &req); err == nil {
+				if err = zfsService.RequireReplicationDatasetGUIDMutationAllowed(ctx, req.GUID); err == nil {
+					err = zfsService.RequireReplicationDatasetCreateAllowed(ctx, normalizedGuardDataset(req.NewName))
+				}
+			}
+		case ReplicationGuardRenameSnapshot:
+			var req RenameSnapshotRequest
+			if err = decodeAndRestoreMutationBody(c, &req); err == nil {
+				err = zfsService.RequireReplicationDatasetGUIDMutationAllowed(ctx, req.GUID)
+			}
 		default:
 			err = fmt.Errorf("replication_dataset_guard_operation_invalid")
 		}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zfsHandlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	zfsServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/zfs"
+	"github.com/alchemillahq/sylve/internal/services/zfs"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get Hot Spares
+// @Description Get the hot spares of a ZFS pool and its auto-replace policy
+// @Tags ZFS
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param guid path string true "Pool GUID"
+// @Success 200 {object} internal.APIResponse[zfsServiceInterfaces.PoolHotSpares] "Success"
+// @Failure 404 {object} internal.APIResponse[any] "Not Found"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /zfs/pools/{guid}/spares [get]
+func GetHotSpares(zfsService *zfs.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		spares, err := zfsService.GetHotSpares(c.Request.Context(), c.Param("guid"))
+		if err != nil {
+			hotSpareError(c, "get_hot_spares_failed", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*zfsServiceInterfaces.PoolHotSpares]{
+			Status:  "success",
+			Message: "hot_spares_fetched",
+			Error:   "",
+			Data:    spares,
+		})
+	}
+}
+
+// @Summary Set Hot Spare Policy
+// @Description Enable or disable automatic replacement of faulted pool members with hot spares
+// @Tags ZFS
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param guid path string true "Pool GUID"
+// @Param request body zfsServiceInterfaces.SetHotSparePolicyRequest true "Request"
+// @Success 200 {object} internal.APIResponse[zfsServiceInterfaces.PoolHotSpares] "Success"
+// @Failure 400 {object} internal.APIResponse[any] "Bad Request"
+// @Failure 404 {object} internal.APIResponse[any] "Not Found"
+// @Failure 500 {object} internal.APIResponse[any] "Internal Server Error"
+// @Router /zfs/pools/{guid}/spares [put]
+func SetHotSparePolicy(zfsService *zfs.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request zfsServiceInterfaces.SetHotSparePolicyRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, internal.APIResponse[any]{
+				Status:  "error",
+				Message: "invalid_request",
+				Error:   err.Error(),
+				Data:    nil,
+			})
+			return
+		}
+
+		spares, err := zfsService.SetHotSparePolicy(c.Request.Context(), c.Param("guid"), *request.AutoReplace)
+		if err != nil {
+			hotSpareError(c, "hot_spare_policy_update_failed", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, internal.APIResponse[*zfsServiceInterfaces.PoolHotSpares]{
+			Status:  "success",
+			Message: "hot_spare_policy_updated",
+			Error:   "",
+			Data:    spares,
+		})
+	}
+}
+
+func hotSpareError(c *gin.Context, message string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case strings.HasPrefix(err.Error(), "pool_not_found"):
+		status = http.StatusNotFound
+		message = "pool_not_found"
+	case strings.HasPrefix(err.Error(), "pool_has_no_spares"):
+		status = http.StatusBadRequest
+		message = "pool_has_no_spares"
+	}
+
+	c.JSON(status, internal.APIResponse[any]{
+		Status:  "error",
+		Message: message,
+		Error:   err.Error(),
+		Data:    nil,
+	})
+}
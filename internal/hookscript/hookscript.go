@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+// Package hookscript checks and runs the operator scripts Sylve calls
+// around guest lifecycle actions and backups. The scripts run as root, so
+// every kind of hook goes through the same rules.
+package hookscript
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Stat is swapped out in tests.
+var Stat = os.Stat
+
+// Validate only accepts absolute paths to executable regular files owned by
+// root that no other user or group can rewrite. It returns the cleaned path,
+// which is what should be stored and run. Callers check again before every
+// run, so a script changed after it was configured is refused.
+func Validate(script string) (string, error) {
+	script = strings.TrimSpace(script)
+	if !filepath.IsAbs(script) {
+		return "", fmt.Errorf("invalid_hook_script: path must be absolute")
+	}
+	script = filepath.Clean(script)
+
+	info, err := Stat(script)
+	if err != nil {
+		return "", fmt.Errorf("hook_script_not_found: %s", script)
+	}
+	if !info.Mode().IsRegular() {
+		return "", fmt.Errorf("invalid_hook_script: not a regular file")
+	}
+	if info.Mode().Perm()&0o111 == 0 {
+		return "", fmt.Errorf("invalid_hook_script: not executable")
+	}
+	if info.Mode().Perm()&0o002 != 0 {
+		return "", fmt.Errorf("invalid_hook_script: world writable")
+	}
+	if info.Mode().Perm()&0o020 != 0 {
+		return "", fmt.Errorf("invalid_hook_script: group writable")
+	}
+	if stat, ok := info.Sys().(*syscall.Stat_t); !ok || stat.Uid != 0 {
+		return "", fmt.Errorf("invalid_hook_script: not owned by root")
+	}
+	return script, nil
+}
+
+// Run executes script with args and env added to Sylve's environment and
+// returns its combined output. Cancelling ctx kills the script.
+func Run(ctx context.Context, script string, args []string, env []string) (string, error) {
+	cmd := exec.CommandContext(ctx, script, args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.WaitDelay = 5 * time.Second
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package hookscript
+
+import (
+	"io/fs"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type fakeFileInfo struct {
+	mode fs.FileMode
+	uid  uint32
+}
+
+func (f fakeFileInfo) Name() string       { return "hook.sh" }
+func (f fakeFileInfo) Size() int64        { return 0 }
+func (f fakeFileInfo) Mode() fs.FileMode  { return f.mode }
+func (f fakeFileInfo) ModTime() time.Time { return time.Time{} }
+func (f fakeFileInfo) IsDir() bool        { return f.mode.IsDir() }
+func (f fakeFileInfo) Sys() any           { return &syscall.Stat_t{Uid: f.uid} }
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name string
+		info fakeFileInfo
+		want string
+	}{
+		{"ok", fakeFileInfo{mode: 0o755}, ""},
+		{"not executable", fakeFileInfo{mode: 0o644}, "invalid_hook_script: not executable"},
+		{"world writable", fakeFileInfo{mode: 0o757}, "invalid_hook_script: world writable"},
+		{"group writable", fakeFileInfo{mode: 0o775}, "invalid_hook_script: group writable"},
+		{"not root", fakeFileInfo{mode: 0o755, uid: 1001}, "invalid_hook_script: not owned by root"},
+		{"directory", fakeFileInfo{mode: fs.ModeDir | 0o755}, "invalid_hook_script: not a regular file"},
+	}
+
+	prev := Stat
+	t.Cleanup(func() { Stat = prev })
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			Stat = func(string) (os.FileInfo, error) { return tc.info, nil }
+
+			_, err := Validate("/usr/local/etc/sylve/hook.sh")
+			if tc.want == "" {
+				if err != nil {
+					t.Fatalf("expected script to be accepted, got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.want {
+				t.Fatalf("expected %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestValidateNormalisesPath(t *testing.T) {
+	prev := Stat
+	t.Cleanup(func() { Stat = prev })
+
+	var statted string
+	Stat = func(name string) (os.FileInfo, error) {
+		statted = name
+		return fakeFileInfo{mode: 0o755}, nil
+	}
+
+	script, err := Validate("  /usr/local/etc/sylve/../sylve/hooks//pf.sh \n")
+	if err != nil {
+		t.Fatalf("expected script to be accepted, got %v", err)
+	}
+	if script != "/usr/local/etc/sylve/hooks/pf.sh" || statted != script {
+		t.Fatalf("expected the cleaned path to be checked and returned, got %q (stat %q)", script, statted)
+	}
+
+	if _, err := Validate("hooks/pf.sh"); err == nil || !strings.Contains(err.Error(), "path must be absolute") {
+		t.Fatalf("expected a relative path to be rejected, got %v", err)
+	}
+}
@@ -87,6 +87,7 @@ type AuthServiceInterface interface {
 	UpdateLastUsageTime(userID uint) error
 
 	AuthenticatePAM(username, password string) (bool, error)
+	VerifyUserPassword(userID uint, authType, password string) error
 
 	GetSylveCertificate() (*tls.Config, error)
 }
@@ -8,30 +8,256 @@
 
 package clusterServiceInterfaces
 
+import "time"
+
 type BackupTargetReq struct {
-	ID               uint   `json:"id,omitempty"`
-	Name             string `json:"name" binding:"required,min=2"`
-	SSHHost          string `json:"sshHost" binding:"required,min=3"`
-	SSHPort          int    `json:"sshPort"`
-	SSHKey           string `json:"sshKey"`
-	SSHKeyPath       string `json:"-"`
-	BackupRoot       string `json:"backupRoot" binding:"required,min=2"`
-	CreateBackupRoot *bool  `json:"createBackupRoot"`
-	Description      string `json:"description"`
-	Enabled          *bool  `json:"enabled"`
+	ID                 uint   `json:"id,omitempty"`
+	Name               string `json:"name" binding:"required,min=2"`
+	SSHHost            string `json:"sshHost" binding:"required,min=3"`
+	SSHPort            int    `json:"sshPort"`
+	SSHKey             string `json:"sshKey"`
+	SSHKeyPath         string `json:"-"`
+	BackupRoot         string `json:"backupRoot" binding:"required,min=2"`
+	CreateBackupRoot   *bool  `json:"createBackupRoot"`
+	BandwidthLimitKBps uint64 `json:"bandwidthLimitKBps"`
+	SSHCiphers         string `json:"sshCiphers"`
+	SSHCompression     bool   `json:"sshCompression"`
+	SSHControlPersist  int    `json:"sshControlPersist"`
+	Description        string `json:"description"`
+	Enabled            *bool  `json:"enabled"`
 }
 
 type BackupJobReq struct {
-	Name             string `json:"name" binding:"required,min=2"`
-	TargetID         uint   `json:"targetId" binding:"required"`
-	RunnerNodeID     string `json:"runnerNodeId"`
-	Mode             string `json:"mode" binding:"required"`
-	SourceDataset    string `json:"sourceDataset"`
-	JailRootDataset  string `json:"jailRootDataset"`
-	PruneKeepLast    int    `json:"pruneKeepLast"`
-	PruneTarget      bool   `json:"pruneTarget"`
-	StopBeforeBackup bool   `json:"stopBeforeBackup"`
-	Recursive        bool   `json:"recursive"`
-	CronExpr         string `json:"cronExpr"`
-	Enabled          *bool  `json:"enabled"`
+	Name               string `json:"name" binding:"required,min=2"`
+	TargetID           uint   `json:"targetId" binding:"required"`
+	RunnerNodeID       string `json:"runnerNodeId"`
+	Mode               string `json:"mode" binding:"required"`
+	SourceDataset      string `json:"sourceDataset"`
+	JailRootDataset    string `json:"jailRootDataset"`
+	PruneKeepLast      int    `json:"pruneKeepLast"`
+	PruneTarget        bool   `json:"pruneTarget"`
+	StopBeforeBackup   bool   `json:"stopBeforeBackup"`
+	Recursive          bool   `json:"recursive"`
+	ShareQuiesce       string `json:"shareQuiesce"`
+	VMDatasetIncludes  string `json:"vmDatasetIncludes"`
+	VMDatasetExcludes  string `json:"vmDatasetExcludes"`
+	PreBackupScript    string `json:"preBackupScript"`
+	PostBackupScript   string `json:"postBackupScript"`
+	HookTimeoutSeconds int    `json:"hookTimeoutSeconds"`
+	HookFailurePolicy  string `json:"hookFailurePolicy"`
+	ZeltaProfileID     uint   `json:"zeltaProfileId"`
+	CronExpr           string `json:"cronExpr"`
+	Enabled            *bool  `json:"enabled"`
+}
+
+// BackupJobReattachReq recreates a backup job under the ID it had on a
+// previous cluster. It is what followers forward to the leader while
+// reattaching a backup target.
+type BackupJobReattachReq struct {
+	ID  uint         `json:"id" binding:"required"`
+	Job BackupJobReq `json:"job"`
+}
+
+// BackupTargetReattachReq recreates the jobs behind the lineages a previous
+// cluster left on a backup target. JobIDs limits the run to those lineages;
+// recreated jobs stay disabled unless Enabled is set.
+type BackupTargetReattachReq struct {
+	DryRun        bool   `json:"dryRun"`
+	JobIDs        []uint `json:"jobIds"`
+	CronExpr      string `json:"cronExpr"`
+	PruneKeepLast int    `json:"pruneKeepLast"`
+	Enabled       *bool  `json:"enabled"`
+}
+
+type BackupTargetOnboardingReq struct {
+	SSHHost    string `json:"sshHost" binding:"required,min=3"`
+	SSHPort    int    `json:"sshPort"`
+	BackupRoot string `json:"backupRoot" binding:"required,min=2"`
+}
+
+type BackupTargetOnboardingFinishReq struct {
+	Name               string `json:"name" binding:"required,min=2"`
+	BandwidthLimitKBps uint64 `json:"bandwidthLimitKBps"`
+	Description        string `json:"description"`
+	Enabled            *bool  `json:"enabled"`
+}
+
+type BackupTenantReq struct {
+	Name         string `json:"name" binding:"required,min=2,max=24"`
+	BackupRoot   string `json:"backupRoot" binding:"required,min=2"`
+	SSHPublicKey string `json:"sshPublicKey" binding:"required"`
+	QuotaBytes   uint64 `json:"quotaBytes"`
+	Namespace    string `json:"namespace"`
+}
+
+type BackupTenantQuotaReq struct {
+	QuotaBytes uint64 `json:"quotaBytes"`
+}
+
+type BackupTenantNamespaceReq struct {
+	Namespace string `json:"namespace"`
+}
+
+type PostRestoreScriptReq struct {
+	GuestType      string `json:"guestType" binding:"required,oneof=jail vm"`
+	GuestID        uint   `json:"guestId" binding:"required"`
+	Script         string `json:"script" binding:"required"`
+	Interpreter    string `json:"interpreter"`
+	TimeoutSeconds int    `json:"timeoutSeconds"`
+	Enabled        *bool  `json:"enabled"`
+}
+
+type BackupSeedExportReq struct {
+	Directory string `json:"directory" binding:"required"`
+}
+
+type GuestExportReq struct {
+	GuestType string `json:"guestType" binding:"required,oneof=vm jail"`
+	GuestID   uint   `json:"guestId" binding:"required"`
+	Format    string `json:"format" binding:"required,oneof=zfs tar"`
+	Directory string `json:"directory"`
+}
+
+type BackupSeedAdoptReq struct {
+	SnapshotName string `json:"snapshotName" binding:"required"`
+}
+
+type NodeStandbyReq struct {
+	TargetID   uint   `json:"targetId" binding:"required"`
+	DestSuffix string `json:"destSuffix"`
+	CronExpr   string `json:"cronExpr" binding:"required"`
+	Enabled    *bool  `json:"enabled"`
+}
+
+type StaleDatasetJanitorReq struct {
+	AutoCleanup    *bool `json:"autoCleanup"`
+	MinAgeHours    *int  `json:"minAgeHours" binding:"omitempty,min=1"`
+	IncludeTargets *bool `json:"includeTargets"`
+}
+
+type RestoreScratchReq struct {
+	Enabled         *bool   `json:"enabled"`
+	Dataset         *string `json:"dataset"`
+	HeadroomPercent *int    `json:"headroomPercent" binding:"omitempty,min=0,max=100"`
+}
+
+type StaleDatasetRef struct {
+	TargetID uint   `json:"targetId"` // 0 for a dataset on this node
+	Dataset  string `json:"dataset" binding:"required"`
+}
+
+type StaleDatasetCleanupReq struct {
+	Datasets []StaleDatasetRef `json:"datasets" binding:"required,min=1,dive"`
+}
+
+type BulkRestoreGuestReq struct {
+	RemoteDataset string `json:"remoteDataset" binding:"required"`
+	Snapshot      string `json:"snapshot"` // empty restores the latest restore point
+}
+
+type BulkRestoreReq struct {
+	TargetID       uint                  `json:"targetId" binding:"required"`
+	Pool           string                `json:"pool"`
+	Concurrency    int                   `json:"concurrency" binding:"omitempty,min=1,max=4"`
+	RestoreNetwork *bool                 `json:"restoreNetwork"`
+	EncryptionKey  string                `json:"encryptionKey"`
+	DryRun         bool                  `json:"dryRun"`
+	Guests         []BulkRestoreGuestReq `json:"guests" binding:"required,min=1,dive"`
+}
+
+// BackupJobDatasetRenamer keeps backup job source paths in step when a local
+// dataset is renamed.
+type BackupJobDatasetRenamer interface {
+	RenameBackupJobDatasetsClusterWide(from, to string) error
+}
+
+// BackupConfigDocument is a portable copy of backup targets and the jobs
+// that use them. Jobs reference their target by name. SSH keys are only
+// present when the export was given a passphrase to wrap them with.
+type BackupConfigDocument struct {
+	Version    int                  `json:"version"`
+	ExportedAt time.Time            `json:"exportedAt"`
+	KeyWrap    *BackupConfigKeyWrap `json:"keyWrap,omitempty"`
+	Targets    []BackupConfigTarget `json:"targets"`
+	Jobs       []BackupConfigJob    `json:"jobs"`
+}
+
+// BackupConfigKeyWrap describes how the wrapped SSH keys were sealed: an
+// argon2id key derived from the passphrase and Salt, used with AES-256-GCM.
+type BackupConfigKeyWrap struct {
+	KDF  string `json:"kdf"`
+	Salt string `json:"salt"`
+}
+
+type BackupConfigTarget struct {
+	Name               string `json:"name"`
+	SSHHost            string `json:"sshHost"`
+	SSHPort            int    `json:"sshPort"`
+	WrappedSSHKey      string `json:"wrappedSshKey,omitempty"`
+	BackupRoot         string `json:"backupRoot"`
+	CreateBackupRoot   bool   `json:"createBackupRoot"`
+	BandwidthLimitKBps uint64 `json:"bandwidthLimitKBps"`
+	SSHCiphers         string `json:"sshCiphers,omitempty"`
+	SSHCompression     bool   `json:"sshCompression,omitempty"`
+	SSHControlPersist  int    `json:"sshControlPersist,omitempty"`
+	Description        string `json:"description"`
+	Enabled            bool   `json:"enabled"`
+}
+
+type BackupConfigJob struct {
+	Name               string `json:"name"`
+	Target             string `json:"target"`
+	Mode               string `json:"mode"`
+	SourceDataset      string `json:"sourceDataset"`
+	JailRootDataset    string `json:"jailRootDataset"`
+	PruneKeepLast      int    `json:"pruneKeepLast"`
+	PruneTarget        bool   `json:"pruneTarget"`
+	StopBeforeBackup   bool   `json:"stopBeforeBackup"`
+	Recursive          bool   `json:"recursive"`
+	ShareQuiesce       string `json:"shareQuiesce,omitempty"`
+	VMDatasetIncludes  string `json:"vmDatasetIncludes,omitempty"`
+	VMDatasetExcludes  string `json:"vmDatasetExcludes,omitempty"`
+	PreBackupScript    string `json:"preBackupScript,omitempty"`
+	PostBackupScript   string `json:"postBackupScript,omitempty"`
+	HookTimeoutSeconds int    `json:"hookTimeoutSeconds,omitempty"`
+	HookFailurePolicy  string `json:"hookFailurePolicy,omitempty"`
+	CronExpr           string `json:"cronExpr"`
+	Enabled            bool   `json:"enabled"`
+}
+
+type BackupConfigExportReq struct {
+	TargetIDs  []uint `json:"targetIds"`
+	Passphrase string `json:"passphrase"`
+}
+
+const (
+	BackupConfigConflictSkip      = "skip"
+	BackupConfigConflictOverwrite = "overwrite"
+	BackupConfigConflictRename    = "rename"
+)
+
+// BackupConfigImportReq imports a BackupConfigDocument. SSHKeys supplies
+// keys by target name for targets exported without one. OnConflict decides
+// what happens to targets and jobs whose name is already taken.
+type BackupConfigImportReq struct {
+	Document     BackupConfigDocument `json:"document" binding:"required"`
+	Passphrase   string               `json:"passphrase"`
+	SSHKeys      map[string]string    `json:"sshKeys"`
+	OnConflict   string               `json:"onConflict"`
+	RunnerNodeID string               `json:"runnerNodeId"`
+	DryRun       bool                 `json:"dryRun"`
+}
+
+type BackupConfigImportItem struct {
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	ImportedAs string `json:"importedAs,omitempty"`
+	Action     string `json:"action"`
+	Error      string `json:"error,omitempty"`
+}
+
+type BackupConfigImportResult struct {
+	DryRun bool                     `json:"dryRun"`
+	Items  []BackupConfigImportItem `json:"items"`
+	Failed int                      `json:"failed"`
 }
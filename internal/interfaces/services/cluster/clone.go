@@ -0,0 +1,20 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterServiceInterfaces
+
+// CloneGuestRequest asks for a copy of a local guest on another cluster node.
+// The original guest is left untouched.
+type CloneGuestRequest struct {
+	TargetNodeID string `json:"targetNodeId" binding:"required"`
+	// CloneID is the RID for the copy; it must be free across the cluster.
+	CloneID uint `json:"cloneId" binding:"required,min=1"`
+	// WithNetwork keeps the source NICs. They are dropped by default so the
+	// clone does not come up with the same MAC addresses as the original.
+	WithNetwork bool `json:"withNetwork"`
+}
@@ -8,16 +8,24 @@
 
 package clusterServiceInterfaces
 
+import clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+
 type NodeHealthSync struct {
-	NodeUUID    string  `json:"nodeUuid"`
-	Hostname    string  `json:"hostname"`
-	API         string  `json:"api"`
-	Status      string  `json:"status"`
-	CPU         int     `json:"cpu"`
-	CPUUsage    float64 `json:"cpuUsage"`
-	Memory      uint64  `json:"memory"`
-	MemoryUsage float64 `json:"memoryUsage"`
-	Disk        uint64  `json:"disk"`
-	DiskUsage   float64 `json:"diskUsage"`
-	GuestIDs    []uint  `json:"guestIds"`
+	NodeUUID    string                         `json:"nodeUuid"`
+	Hostname    string                         `json:"hostname"`
+	API         string                         `json:"api"`
+	Status      string                         `json:"status"`
+	CPU         int                            `json:"cpu"`
+	CPUUsage    float64                        `json:"cpuUsage"`
+	Memory      uint64                         `json:"memory"`
+	MemoryUsage float64                        `json:"memoryUsage"`
+	Disk        uint64                         `json:"disk"`
+	DiskUsage   float64                        `json:"diskUsage"`
+	GuestIDs    []uint                         `json:"guestIds"`
+	Pools       []clusterModels.NodePoolHealth `json:"pools"`
+	ClockStatus string                         `json:"clockStatus"`
+	ClockSkewMs int64                          `json:"clockSkewMs"`
+	NTPSource   string                         `json:"ntpSource"`
+	NTPSynced   bool                           `json:"ntpSynced"`
+	NTPOffsetMs float64                        `json:"ntpOffsetMs"`
 }
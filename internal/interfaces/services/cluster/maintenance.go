@@ -0,0 +1,22 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterServiceInterfaces
+
+import "time"
+
+type MaintenanceWindowReq struct {
+	Scope     string     `json:"scope" binding:"required,oneof=node guest policy"`
+	NodeID    string     `json:"nodeId"`
+	GuestType string     `json:"guestType"`
+	GuestID   uint       `json:"guestId"`
+	PolicyID  uint       `json:"policyId"`
+	Reason    string     `json:"reason" binding:"required"`
+	StartsAt  *time.Time `json:"startsAt"` // nil starts the window now
+	EndsAt    time.Time  `json:"endsAt" binding:"required"`
+}
@@ -29,6 +29,35 @@ type ReplicationPolicyReq struct {
 	CrashRestartMax *int                         `json:"crashRestartMax"`
 	PoolHealthCheck *bool                        `json:"poolHealthCheck"`
 	PoolCapacityPct *int                         `json:"poolCapacityPct"`
+	SourceBookmarks *bool                        `json:"sourceBookmarks"`
+	ZeltaProfileID  *uint                        `json:"zeltaProfileId"`
 	Enabled         *bool                        `json:"enabled"`
 	Targets         []ReplicationPolicyTargetReq `json:"targets" binding:"required"`
+	TemplateID      uint                         `json:"-"`
+}
+
+type ReplicationPolicyTemplateReq struct {
+	Name         string                       `json:"name" binding:"required,min=2,max=64"`
+	Description  string                       `json:"description"`
+	GuestType    string                       `json:"guestType"`
+	Tags         []string                     `json:"tags"`
+	Pools        []string                     `json:"pools"`
+	Targets      []ReplicationPolicyTargetReq `json:"targets" binding:"required"`
+	CronExpr     string                       `json:"cronExpr" binding:"required"`
+	FailbackMode string                       `json:"failbackMode"`
+	FailoverMode string                       `json:"failoverMode"`
+	Enabled      *bool                        `json:"enabled"`
+}
+
+type ReplicationTemplateEnrollment struct {
+	TemplateID uint   `json:"templateId"`
+	GuestType  string `json:"guestType"`
+	GuestID    uint   `json:"guestId"`
+	Action     string `json:"action"`
+	PolicyID   uint   `json:"policyId,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+type ReplicationPolicyPauseReq struct {
+	Reason string `json:"reason" binding:"required"`
 }
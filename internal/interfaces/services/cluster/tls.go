@@ -0,0 +1,19 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterServiceInterfaces
+
+// ClusterTLSSignReq asks the leader to sign a node's certificate request.
+type ClusterTLSSignReq struct {
+	NodeUUID string `json:"nodeUUID" binding:"required"`
+	CSR      string `json:"csr" binding:"required"`
+}
+
+type ClusterTLSEnforceReq struct {
+	Enforce *bool `json:"enforce" binding:"required"`
+}
@@ -0,0 +1,15 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package clusterServiceInterfaces
+
+type ZeltaProfileReq struct {
+	Name        string            `json:"name" binding:"required,min=2,max=64"`
+	Description string            `json:"description"`
+	Env         map[string]string `json:"env"`
+}
@@ -11,6 +11,7 @@ package infoServiceInterfaces
 import (
 	"context"
 
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
 	infoModels "github.com/alchemillahq/sylve/internal/db/models/info"
 )
 
@@ -23,6 +24,26 @@ type NodeInfo struct {
 	DiskTotal    uint64  `json:"diskTotal"`
 	DiskUsage    float64 `json:"diskUsage"`
 	Guests       []uint  `json:"guestIds"`
+
+	Pools []clusterModels.NodePoolHealth `json:"pools"`
+}
+
+// NTPStatus is what the local time daemon says about the system clock.
+// Source is empty when neither ntpd nor chronyd answered.
+type NTPStatus struct {
+	Source   string  `json:"source"`
+	Synced   bool    `json:"synced"`
+	Stratum  int     `json:"stratum"`
+	OffsetMs float64 `json:"offsetMs"`
+}
+
+// ClockInfo carries the node's wall clock at the moment it received and
+// answered the request, in Unix milliseconds, so the caller can estimate
+// the offset between the two clocks the way NTP does.
+type ClockInfo struct {
+	ReceivedAt int64     `json:"receivedAt"`
+	SentAt     int64     `json:"sentAt"`
+	NTP        NTPStatus `json:"ntp"`
 }
 
 type InfoServiceInterface interface {
@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package jailServiceInterfaces
+
+type JailExecRequest struct {
+	// Command is run as an argument vector, not through a shell; wrap it in
+	// ["/bin/sh", "-c", "..."] for pipes and redirects.
+	Command []string `json:"command" binding:"required"`
+	// User inside the jail to run as, root when empty.
+	User string `json:"user"`
+	// Timeout in seconds, after which the command is killed. Zero uses the
+	// default.
+	Timeout int `json:"timeout"`
+}
+
+type JailExecStarted struct {
+	ID   string `json:"id"`
+	CTID uint   `json:"ctId"`
+}
+
+type JailExecChunk struct {
+	Stream string `json:"stream"`
+	Data   string `json:"data"`
+}
+
+type JailExecResult struct {
+	ExitCode  int    `json:"exitCode"`
+	TimedOut  bool   `json:"timedOut"`
+	Cancelled bool   `json:"cancelled"`
+	Truncated bool   `json:"truncated"`
+	Error     string `json:"error,omitempty"`
+}
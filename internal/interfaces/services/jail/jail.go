@@ -10,6 +10,7 @@ package jailServiceInterfaces
 
 import (
 	"context"
+	"time"
 
 	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
 )
@@ -43,9 +44,14 @@ type CreateJailRequest struct {
 	Pool          string `json:"pool" binding:"required"`
 	Base          string `json:"base"`
 	BootstrapName string `json:"bootstrapName"`
+	Release       string `json:"release"`
 	Fstab         string `json:"fstab"`
 	ResolvConf    string `json:"resolvConf"`
 
+	ManageEtcFiles  *bool  `json:"manageEtcFiles"`
+	DNSSearchDomain string `json:"dnsSearchDomain"`
+	HostEntries     string `json:"hostEntries"`
+
 	SwitchName string `json:"switchName"`
 
 	InheritIPv4 *bool `json:"inheritIPv4"`
@@ -86,14 +92,31 @@ type CreateJailRequest struct {
 	MetadataEnv  string `json:"metadataEnv"`
 }
 
+// CreateJailPreflightCheck is the outcome of one validation section run
+// against a CreateJailRequest.
+type CreateJailPreflightCheck struct {
+	Name  string `json:"name"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// CreateJailPreflightReport collects every blocking error and non-blocking
+// warning for a jail spec without creating anything.
+type CreateJailPreflightReport struct {
+	Valid    bool                       `json:"valid"`
+	Checks   []CreateJailPreflightCheck `json:"checks"`
+	Warnings []string                   `json:"warnings"`
+}
+
 type SimpleList struct {
-	ID             uint   `json:"id"`
-	Name           string `json:"name"`
-	CTID           uint   `json:"ctId"`
-	State          string `json:"state"`
-	ResourceLimits *bool  `json:"resourceLimits"`
-	Cores          int    `json:"cores"`
-	Memory         int    `json:"memory"`
+	ID             uint     `json:"id"`
+	Name           string   `json:"name"`
+	CTID           uint     `json:"ctId"`
+	State          string   `json:"state"`
+	ResourceLimits *bool    `json:"resourceLimits"`
+	Cores          int      `json:"cores"`
+	Memory         int      `json:"memory"`
+	Pools          []string `json:"pools"`
 }
 
 type SimpleTemplateList struct {
@@ -111,6 +134,12 @@ type State struct {
 	OverrideRequested bool    `json:"overrideRequested"`
 }
 
+type ModifyJailEtcFilesRequest struct {
+	Enabled         bool   `json:"enabled"`
+	DNSSearchDomain string `json:"dnsSearchDomain"`
+	HostEntries     string `json:"hostEntries"`
+}
+
 type AddJailNetworkRequest struct {
 	CTID           uint   `json:"ctId" binding:"required"`
 	Name           string `json:"name" binding:"required"`
@@ -128,6 +157,7 @@ type AddJailNetworkRequest struct {
 	DHCP           *bool  `json:"dhcp"`
 	SLAAC          *bool  `json:"slaac"`
 	DefaultGateway *bool  `json:"defaultGateway"`
+	FIB            *uint  `json:"fib"`
 	VLAN           *int   `json:"vlan"`
 }
 
@@ -148,6 +178,7 @@ type EditJailNetworkRequest struct {
 	DHCP           *bool  `json:"dhcp"`
 	SLAAC          *bool  `json:"slaac"`
 	DefaultGateway *bool  `json:"defaultGateway"`
+	FIB            *uint  `json:"fib"`
 	VLAN           *int   `json:"vlan"`
 }
 
@@ -156,6 +187,48 @@ type DeleteJailResult struct {
 	RetainedDatasets []string `json:"retainedDatasets"`
 }
 
+// CPUSetCore is one logical host CPU and whatever already claims it: VMs
+// pin cores exclusively, jails only share them.
+type CPUSetCore struct {
+	Core   int    `json:"core"`
+	Socket int    `json:"socket"`
+	VMs    []uint `json:"vms"`
+	Jails  []uint `json:"jails"`
+}
+
+// CPUSetTopology is the host CPU layout as the jail CPUSet picker sees it,
+// with the jail's own set and, when a core count was asked for, the set
+// auto-assign would pick.
+type CPUSetTopology struct {
+	Sockets          int          `json:"sockets"`
+	LogicalCores     int          `json:"logicalCores"`
+	LogicalPerSocket int          `json:"logicalPerSocket"`
+	ThreadsPerCore   int          `json:"threadsPerCore"`
+	Cores            []CPUSetCore `json:"cores"`
+	Current          []int        `json:"current"`
+	Suggested        []int        `json:"suggested,omitempty"`
+}
+
+// PathVersion is one distinct earlier copy of a path inside the jail, as
+// held by the oldest snapshot that still has it unchanged.
+type PathVersion struct {
+	Snapshot    string    `json:"snapshot"`
+	SnapshotID  uint      `json:"snapshotId,omitempty"`
+	Label       string    `json:"label"`
+	CreatedAt   time.Time `json:"createdAt"`
+	ModifiedAt  time.Time `json:"modifiedAt"`
+	Size        int64     `json:"size"`
+	IsDir       bool      `json:"isDir"`
+	MatchesLive bool      `json:"matchesLive"`
+}
+
+// PathVersions lists the restorable versions of a path, newest first.
+type PathVersions struct {
+	Path     string        `json:"path"`
+	Exists   bool          `json:"exists"`
+	Versions []PathVersion `json:"versions"`
+}
+
 type JailServiceInterface interface {
 	JailAction(ctid int, action string) error
 	ForceStopJail(ctID uint) error
@@ -164,6 +237,7 @@ type JailServiceInterface interface {
 	DeleteJail(ctx context.Context, ctId uint, deleteMacs bool, deleteRootFS bool) error
 	DeleteJailWithWarnings(ctx context.Context, ctId uint, deleteMacs bool, deleteRootFS bool) (DeleteJailResult, error)
 	RetireJailLocalMetadata(ctx context.Context, ctId uint, deleteMacs bool) error
+	ExecInJail(ctx context.Context, ctid uint, req JailExecRequest) (string, JailExecResult, error)
 	StartStatsMonitoring(ctx context.Context)
 
 	StoreJailUsage() error
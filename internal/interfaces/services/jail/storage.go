@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package jailServiceInterfaces
+
+type MigrateJailStorageRequest struct {
+	TargetPool string `json:"targetPool" binding:"required"`
+	// KeepSource leaves the original datasets in place after a successful
+	// move instead of destroying them.
+	KeepSource bool `json:"keepSource"`
+}
+
+type JailStorageMove struct {
+	StorageID     uint   `json:"storageId"`
+	SourceDataset string `json:"sourceDataset"`
+	TargetDataset string `json:"targetDataset"`
+}
+
+type JailStorageMigrationResult struct {
+	CTID       uint              `json:"ctId"`
+	SourcePool string            `json:"sourcePool"`
+	TargetPool string            `json:"targetPool"`
+	Moves      []JailStorageMove `json:"moves"`
+	Restarted  bool              `json:"restarted"`
+	Warnings   []string          `json:"warnings"`
+}
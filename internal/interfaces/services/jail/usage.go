@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package jailServiceInterfaces
+
+// JailProcess is one process as seen by ps inside the jail. PIDs are the
+// host's, since jails share the host's process table.
+type JailProcess struct {
+	PID     int     `json:"pid"`
+	PPID    int     `json:"ppid"`
+	User    string  `json:"user"`
+	State   string  `json:"state"`
+	PCPU    float64 `json:"pcpu"`
+	PMem    float64 `json:"pmem"`
+	RSS     int64   `json:"rss"`
+	VSZ     int64   `json:"vsz"`
+	Elapsed int64   `json:"elapsed"`
+	Command string  `json:"command"`
+}
+
+// JailResourceUsage holds the racct counters rctl reports for a jail, keyed
+// by resource name (cputime, memoryuse, maxproc, readbps and so on). Units
+// are rctl's: seconds for cputime, bytes for memory, per-second rates for
+// the bps and iops counters.
+type JailResourceUsage struct {
+	CTID     uint             `json:"ctId"`
+	Counters map[string]int64 `json:"counters"`
+}
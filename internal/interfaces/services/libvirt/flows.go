@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package libvirtServiceInterfaces
+
+import "time"
+
+// VMInterfaceCounters are reported from the guest's point of view, so
+// BytesIn is traffic the VM received (what the host sent on the tap).
+type VMInterfaceCounters struct {
+	Name         string   `json:"name"`
+	MAC          string   `json:"mac"`
+	Addresses    []string `json:"addresses"`
+	BytesIn      int64    `json:"bytesIn"`
+	BytesOut     int64    `json:"bytesOut"`
+	PacketsIn    int64    `json:"packetsIn"`
+	PacketsOut   int64    `json:"packetsOut"`
+	Errors       int64    `json:"errors"`
+	Drops        int64    `json:"drops"`
+	BytesInRate  float64  `json:"bytesInRate"`
+	BytesOutRate float64  `json:"bytesOutRate"`
+}
+
+type VMFlow struct {
+	Protocol      string  `json:"protocol"`
+	Direction     string  `json:"direction"` // inbound|outbound
+	LocalAddress  string  `json:"localAddress"`
+	LocalPort     int     `json:"localPort"`
+	RemoteAddress string  `json:"remoteAddress"`
+	RemotePort    int     `json:"remotePort"`
+	State         string  `json:"state"`
+	BytesIn       uint64  `json:"bytesIn"`
+	BytesOut      uint64  `json:"bytesOut"`
+	PacketsIn     uint64  `json:"packetsIn"`
+	PacketsOut    uint64  `json:"packetsOut"`
+	BytesInRate   float64 `json:"bytesInRate"`
+	BytesOutRate  float64 `json:"bytesOutRate"`
+}
+
+type VMTalker struct {
+	RemoteAddress string  `json:"remoteAddress"`
+	Flows         int     `json:"flows"`
+	BytesIn       uint64  `json:"bytesIn"`
+	BytesOut      uint64  `json:"bytesOut"`
+	BytesRate     float64 `json:"bytesRate"`
+}
+
+type VMFlowStats struct {
+	RID               uint                  `json:"rid"`
+	SampledAt         time.Time             `json:"sampledAt"`
+	Interfaces        []VMInterfaceCounters `json:"interfaces"`
+	ActiveConnections int                   `json:"activeConnections"`
+	TopTalkers        []VMTalker            `json:"topTalkers"`
+	Flows             []VMFlow              `json:"flows"`
+}
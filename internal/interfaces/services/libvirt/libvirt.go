@@ -36,16 +36,23 @@ type LibvirtServiceInterface interface {
 	ModifySerial(rid uint, enabled bool) error
 	ModifyShutdownWaitTime(rid uint, waitTime int) error
 	ModifyCloudInitData(rid uint, data string, metadata string, networkConfig string) error
+	ModifyGuestMetadata(rid uint, sshKeys []string, tags []string) error
+	GuestMetadataForAddress(remoteIP string) (GuestMetadata, error)
 	ModifyBootROM(rid uint, bootROM string) error
 	ModifyExtraBhyveOptions(rid uint, options []string) error
 	ModifyIgnoreUMSRs(rid uint, ignore bool) error
+	ModifyCPUFeatures(rid uint, features []string) error
+	GetCPUCapabilities(vcpus int) CPUCapabilities
 	ModifyQemuGuestAgent(rid uint, enabled bool) error
 	GetQemuGuestAgentInfo(rid uint) (QemuGuestAgentInfo, error)
+	ExecInGuest(ctx context.Context, rid uint, req VMExecRequest) (VMExecResult, error)
 
 	PruneOrphanedVMStats() error
 	ApplyVMStatsRetention() error
 	StoreVMUsage() error
 	GetVMUsage(vmId int, step db.GFSStep) ([]vmModels.VMStats, error)
+	SampleVMFlows() error
+	GetVMFlows(rid uint, limit int) (*VMFlowStats, error)
 
 	CreateVMDisk(rid uint, storage vmModels.Storage, ctx context.Context) error
 	SyncVMDisks(rid uint) error
@@ -124,6 +131,8 @@ type SimpleList struct {
 	VNCPort                     uint                    `json:"vncPort"`
 	CPUPinning                  []vmModels.VMCPUPinning `json:"cpuPinning"`
 	HasEnabledFilesystemStorage bool                    `json:"hasEnabledFilesystemStorage"`
+	Tags                        []string                `json:"tags"`
+	Pools                       []string                `json:"pools"`
 }
 
 type SimpleTemplateList struct {
@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package libvirtServiceInterfaces
+
+import "time"
+
+// VMDiskLiveStats are the per-second rates of one ZFS dataset backing a disk.
+type VMDiskLiveStats struct {
+	Dataset        string  `json:"dataset"`
+	ReadIOPS       float64 `json:"readIops"`
+	WriteIOPS      float64 `json:"writeIops"`
+	ReadBytesRate  float64 `json:"readBytesRate"`
+	WriteBytesRate float64 `json:"writeBytesRate"`
+}
+
+// VMNICLiveStats follow VMInterfaceCounters: RX is what the guest received.
+type VMNICLiveStats struct {
+	Name        string  `json:"name"`
+	MAC         string  `json:"mac"`
+	RxBytesRate float64 `json:"rxBytesRate"`
+	TxBytesRate float64 `json:"txBytesRate"`
+}
+
+// VMLiveStats is one sample pushed over the live stats websocket. Rates are
+// zero on the first sample since they need a previous one to compare with.
+type VMLiveStats struct {
+	RID           uint              `json:"rid"`
+	SampledAt     time.Time         `json:"sampledAt"`
+	CPUPercent    float64           `json:"cpuPercent"`
+	MemoryRSS     uint64            `json:"memoryRss"` // bytes
+	MemoryPercent float64           `json:"memoryPercent"`
+	Disks         []VMDiskLiveStats `json:"disks"`
+	NICs          []VMNICLiveStats  `json:"nics"`
+}
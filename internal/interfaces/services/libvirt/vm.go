@@ -15,6 +15,13 @@ const (
 	TimeOffsetLocal TimeOffset = "localtime"
 )
 
+type VMPreset string
+
+const (
+	VMPresetNone    VMPreset = ""
+	VMPresetWindows VMPreset = "windows"
+)
+
 type CPUPinning struct {
 	Socket int   `json:"socket" binding:"required,min=0"`
 	Cores  []int `json:"cores"  binding:"required,min=1"`
@@ -61,6 +68,7 @@ type CreateVMRequest struct {
 	CloudInitNetworkConfig string   `json:"cloudInitNetworkConfig"`
 	BootROM                string   `json:"bootRom"`
 	ExtraBhyveOptions      []string `json:"extraBhyveOptions"`
+	CPUFeatures            []string `json:"cpuFeatures"`
 
 	APIC           *bool `json:"apic"`
 	ACPI           *bool `json:"acpi"`
@@ -70,6 +78,24 @@ type CreateVMRequest struct {
 	StartAtBoot *bool      `json:"startAtBoot"`
 	StartOrder  int        `json:"startOrder"`
 	TimeOffset  TimeOffset `json:"timeOffset" binding:"required"`
+
+	Preset VMPreset `json:"preset"`
+}
+
+// CreateVMPreflightCheck is the outcome of one validation section run
+// against a CreateVMRequest.
+type CreateVMPreflightCheck struct {
+	Name  string `json:"name"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// CreateVMPreflightReport collects every blocking error and non-blocking
+// warning for a VM spec without creating anything.
+type CreateVMPreflightReport struct {
+	Valid    bool                     `json:"valid"`
+	Checks   []CreateVMPreflightCheck `json:"checks"`
+	Warnings []string                 `json:"warnings"`
 }
 
 type ModifyCPURequest struct {
@@ -89,11 +115,94 @@ type ModifyVNCRequest struct {
 	VNCWait       *bool  `json:"vncWait"`
 }
 
+// VMPresetDevice documents a device model a preset selects and why.
+type VMPresetDevice struct {
+	Device string `json:"device"`
+	Model  string `json:"model"`
+	Notes  string `json:"notes"`
+}
+
+// VMPresetMedia is the managed media a preset attaches on creation.
+type VMPresetMedia struct {
+	URL          string `json:"url"`
+	DownloadUUID string `json:"downloadUuid,omitempty"`
+	Status       string `json:"status"`
+}
+
+// VMPresetInfo describes the settings a preset applies to a CreateVMRequest.
+type VMPresetInfo struct {
+	Name                 VMPreset             `json:"name"`
+	Description          string               `json:"description"`
+	BootROM              string               `json:"bootRom"`
+	TimeOffset           TimeOffset           `json:"timeOffset"`
+	TPMEmulation         bool                 `json:"tpmEmulation"`
+	ACPI                 bool                 `json:"acpi"`
+	APIC                 bool                 `json:"apic"`
+	IgnoreUMSRs          bool                 `json:"ignoreUMSR"`
+	StorageEmulationType StorageEmulationType `json:"storageEmulationType"`
+	SwitchEmulationType  string               `json:"switchEmulationType"`
+	Devices              []VMPresetDevice     `json:"devices"`
+	DriverMedia          *VMPresetMedia       `json:"driverMedia,omitempty"`
+}
+
+// CPUTopology is a sockets/cores/threads layout for a guest.
+type CPUTopology struct {
+	Sockets int `json:"sockets"`
+	Cores   int `json:"cores"`
+	Threads int `json:"threads"`
+}
+
+// CPUFeatureInfo describes a guest CPU feature toggle and whether the host
+// can provide it.
+type CPUFeatureInfo struct {
+	Name        string `json:"name"`
+	BhyveArg    string `json:"bhyveArg"`
+	Description string `json:"description"`
+	Supported   bool   `json:"supported"`
+}
+
+// CPUCapabilities is the host CPU as seen through cpuid, the feature toggles
+// a VM may use, and an optional topology hint for a requested vCPU count.
+type CPUCapabilities struct {
+	Vendor         string           `json:"vendor"`
+	Brand          string           `json:"brand"`
+	Sockets        int              `json:"sockets"`
+	PhysicalCores  int              `json:"physicalCores"`
+	LogicalCores   int              `json:"logicalCores"`
+	ThreadsPerCore int              `json:"threadsPerCore"`
+	Virtualization bool             `json:"virtualization"`
+	Features       []CPUFeatureInfo `json:"features"`
+	Suggested      *CPUTopology     `json:"suggested,omitempty"`
+}
+
 type QemuGuestAgentInfo struct {
 	OSInfo     QGAOSInfo             `json:"osInfo"`
 	Interfaces []QGANetworkInterface `json:"interfaces"`
 }
 
+type VMExecRequest struct {
+	// Command is run as an argument vector by the guest agent, not through a
+	// shell.
+	Command []string `json:"command" binding:"required"`
+	// Input is written to the command's standard input.
+	Input string `json:"input"`
+	// Timeout in seconds to wait for the command. Zero uses the default.
+	Timeout int `json:"timeout"`
+}
+
+type VMExecResult struct {
+	PID             int    `json:"pid"`
+	ExitCode        int    `json:"exitCode"`
+	Signal          int    `json:"signal"`
+	Stdout          string `json:"stdout"`
+	Stderr          string `json:"stderr"`
+	StdoutTruncated bool   `json:"stdoutTruncated"`
+	StderrTruncated bool   `json:"stderrTruncated"`
+	// TimedOut is set when the command was still running at the deadline.
+	// The guest agent cannot kill it, so it may still be running.
+	TimedOut bool `json:"timedOut"`
+}
+
 type QGAOSInfo struct {
 	Name          string `json:"name"`
 	KernelRelease string `json:"kernel-release"`
@@ -166,3 +275,17 @@ type CreateFromTemplateRequest struct {
 	RewriteCloudInitIdentity bool   `json:"rewriteCloudInitIdentity"`
 	CloudInitPrefix          string `json:"cloudInitPrefix"`
 }
+
+// GuestMetadata is what the guest metadata service hands to a VM that asked
+// for its own configuration over one of its switches.
+type GuestMetadata struct {
+	RID        uint     `json:"rid"`
+	InstanceID string   `json:"instanceId"`
+	Hostname   string   `json:"hostname"`
+	SSHKeys    []string `json:"sshKeys"`
+	Tags       []string `json:"tags"`
+
+	UserData      string `json:"-"`
+	MetaData      string `json:"-"`
+	NetworkConfig string `json:"-"`
+}
@@ -10,6 +10,7 @@ package migration
 
 import (
 	"context"
+	"time"
 )
 
 type MigrateRequest struct {
@@ -25,8 +26,35 @@ type ValidateResult struct {
 	Warnings []string `json:"warnings,omitempty"`
 }
 
+const (
+	CompatibilityOK      = "ok"
+	CompatibilityWarning = "warning"
+	CompatibilityBlocked = "blocked"
+)
+
+// CompatibilityCheck is one of the guest's requirements measured against the
+// target node. Code is the preflight reason the check reports when it is not
+// ok, so blocked checks refuse the migration the same way other reasons do.
+type CompatibilityCheck struct {
+	Category string `json:"category"`
+	Code     string `json:"code"`
+	Status   string `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Remedy   string `json:"remedy,omitempty"`
+}
+
+type CompatibilityReport struct {
+	GuestType      string               `json:"guestType"`
+	GuestID        uint                 `json:"guestId"`
+	TargetNodeUUID string               `json:"targetNodeUuid"`
+	Compatible     bool                 `json:"compatible"`
+	Checks         []CompatibilityCheck `json:"checks"`
+	CheckedAt      time.Time            `json:"checkedAt"`
+}
+
 type MigrationServiceInterface interface {
 	ValidateMigration(ctx context.Context, req MigrateRequest) (*ValidateResult, error)
+	CheckCompatibility(ctx context.Context, req MigrateRequest) (*CompatibilityReport, error)
 	ExecuteMigration(ctx context.Context, taskID uint) error
 	CancelMigration(ctx context.Context, taskID uint) error
 }
@@ -0,0 +1,17 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package networkServiceInterfaces
+
+type StartPacketCaptureRequest struct {
+	Interface       string `json:"interface" binding:"required"`
+	Filter          string `json:"filter"`
+	DurationSeconds int    `json:"durationSeconds"`
+	MaxSizeBytes    int64  `json:"maxSizeBytes"`
+	MaxPackets      int    `json:"maxPackets"`
+}
@@ -0,0 +1,20 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package networkServiceInterfaces
+
+type UpsertHostInterfaceRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description"`
+	MTU         int      `json:"mtu"`
+	IPv4        []string `json:"ipv4"`
+	IPv6        []string `json:"ipv6"`
+	Media       string   `json:"media"`
+	MediaOpt    string   `json:"mediaOpt"`
+	Up          *bool    `json:"up"`
+}
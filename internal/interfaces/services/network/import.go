@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package networkServiceInterfaces
+
+type NetworkImportRequest struct {
+	Format string `json:"format" binding:"required,oneof=csv json"`
+	Data   string `json:"data" binding:"required"`
+	DryRun bool   `json:"dryRun"`
+}
+
+// NetworkImportItem is one imported object or switch and what the import did
+// with it. For switches, Values holds the member ports.
+type NetworkImportItem struct {
+	Line        int      `json:"line"`
+	Name        string   `json:"name"`
+	Type        string   `json:"type,omitempty"`
+	Values      []string `json:"values,omitempty"`
+	Action      string   `json:"action"` // create|exists|duplicate|invalid|failed
+	ID          uint     `json:"id,omitempty"`
+	DuplicateOf string   `json:"duplicateOf,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+type NetworkImportReport struct {
+	DryRun  bool                `json:"dryRun"`
+	Items   []NetworkImportItem `json:"items"`
+	Created int                 `json:"created"`
+	Skipped int                 `json:"skipped"`
+	Failed  int                 `json:"failed"`
+}
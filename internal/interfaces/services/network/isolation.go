@@ -0,0 +1,18 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package networkServiceInterfaces
+
+import networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+
+type UpsertIsolationGroupRequest struct {
+	SwitchID    uint                                 `json:"switchId" binding:"required"`
+	Name        string                               `json:"name" binding:"required"`
+	Description string                               `json:"description"`
+	Members     []networkModels.IsolationGroupMember `json:"members"`
+}
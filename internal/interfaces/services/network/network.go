@@ -53,6 +53,7 @@ type NetworkServiceInterface interface {
 	IsObjectUsed(id uint) (bool, string, error)
 	GetObjectEntryByID(id uint) (string, error)
 	GetBridgeNameByIDType(id uint, swType string) (string, error)
+	GetSwitchMTUByIDType(id uint, swType string) (int, error)
 	CreateEpair(name string) error
 	SyncEpairs(forceStart bool) error
 	DeleteEpair(name string) error
@@ -60,5 +61,7 @@ type NetworkServiceInterface interface {
 	EnableWireGuardService(ctx context.Context) error
 	DisableWireGuardService(ctx context.Context) error
 	ReconcileManagedRoutes() error
+	ReconcileHostInterfaces() error
+	RestoreSRIOV() error
 	RegisterOnJailObjectUpdateCallback(cb func(jailIDs []uint))
 }
@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package networkServiceInterfaces
+
+import networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+
+type SRIOVVFConfig struct {
+	MAC         string `json:"mac"`
+	VLAN        int    `json:"vlan"`
+	Passthrough bool   `json:"passthrough"`
+}
+
+type ConfigureSRIOVRequest struct {
+	Interface        string          `json:"interface" binding:"required"`
+	VirtualFunctions []SRIOVVFConfig `json:"virtualFunctions" binding:"required"`
+}
+
+// SRIOVInterface is a NIC that exposes /dev/iov/<name>, along with the VF
+// layout Sylve manages on it, if any.
+type SRIOVInterface struct {
+	Interface  string                               `json:"interface"`
+	PCIAddress string                               `json:"pciAddress"`
+	MaxVFs     int                                  `json:"maxVfs"`
+	Configured *networkModels.SRIOVPhysicalFunction `json:"configured"`
+}
@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package networkServiceInterfaces
+
+type SwitchMTUMember struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"` // port|jail|guest
+	MTU  int    `json:"mtu"`
+	// ConfiguredMTU is the host interface MTU saved for an uplink port, or 0.
+	ConfiguredMTU int `json:"configuredMtu"`
+}
+
+type SwitchMTUReport struct {
+	SwitchID   uint              `json:"switchId"`
+	SwitchName string            `json:"switchName"`
+	BridgeName string            `json:"bridgeName"`
+	MTU        int               `json:"mtu"`
+	BridgeMTU  int               `json:"bridgeMtu"`
+	Members    []SwitchMTUMember `json:"members"`
+	VMCount    int               `json:"vmCount"`
+	JailCount  int               `json:"jailCount"`
+	Mismatches []string          `json:"mismatches"`
+}
+
+type PropagateSwitchMTURequest struct {
+	MTU int `json:"mtu" binding:"required"`
+}
@@ -0,0 +1,13 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package systemServiceInterfaces
+
+type ConsistencyFixRequest struct {
+	IssueIDs []string `json:"issueIds" binding:"required,min=1"`
+}
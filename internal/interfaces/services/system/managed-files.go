@@ -0,0 +1,28 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package systemServiceInterfaces
+
+import "time"
+
+// ManagedFileRoot names one of the Sylve-managed directories the scoped file
+// browser is allowed to reach. CTID is only used by jail roots.
+type ManagedFileRoot struct {
+	Kind string `json:"kind"`
+	CTID uint   `json:"ctId,omitempty"`
+}
+
+// ManagedFileEntry is a file or folder inside a managed root. Path is relative
+// to the root and is what the browse, download and upload calls take.
+type ManagedFileEntry struct {
+	Name string    `json:"name"`
+	Path string    `json:"path"`
+	Type string    `json:"type"`
+	Size int64     `json:"size,omitempty"`
+	Date time.Time `json:"date"`
+}
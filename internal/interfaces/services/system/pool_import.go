@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package systemServiceInterfaces
+
+// AdoptablePool is a pool Sylve could take over: either one that is visible
+// to `zpool import` but not imported, or one that is imported but not yet in
+// the usable pool list.
+type AdoptablePool struct {
+	Name        string `json:"name"`
+	ID          string `json:"id"`
+	State       string `json:"state"`
+	Status      string `json:"status"`
+	Action      string `json:"action"`
+	Imported    bool   `json:"imported"`
+	HasSkeleton bool   `json:"hasSkeleton"`
+}
+
+type AdoptPoolRequest struct {
+	Name           string `json:"name" binding:"required"`
+	CreateSkeleton bool   `json:"createSkeleton"`
+	AllowDegraded  bool   `json:"allowDegraded"`
+}
+
+type AdoptPoolResult struct {
+	Name            string   `json:"name"`
+	State           string   `json:"state"`
+	Imported        bool     `json:"imported"`
+	CreatedDatasets []string `json:"createdDatasets"`
+}
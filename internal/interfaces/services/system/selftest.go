@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package systemServiceInterfaces
+
+import "time"
+
+type SelfTestStatus string
+
+const (
+	SelfTestPass SelfTestStatus = "pass"
+	SelfTestFail SelfTestStatus = "fail"
+	SelfTestSkip SelfTestStatus = "skip"
+)
+
+type SelfTestRequest struct {
+	Pool string `json:"pool"`
+}
+
+// SelfTestResult is the outcome of one subsystem check.
+type SelfTestResult struct {
+	Subsystem  string         `json:"subsystem"`
+	Status     SelfTestStatus `json:"status"`
+	Detail     string         `json:"detail,omitempty"`
+	DurationMs int64          `json:"durationMs"`
+}
+
+// SelfTestReport is the outcome of a full self-test run. Passed is false if
+// any subsystem failed; skipped subsystems do not count against it.
+type SelfTestReport struct {
+	Pool       string           `json:"pool"`
+	Dataset    string           `json:"dataset"`
+	Passed     bool             `json:"passed"`
+	StartedAt  time.Time        `json:"startedAt"`
+	FinishedAt time.Time        `json:"finishedAt"`
+	Results    []SelfTestResult `json:"results"`
+}
@@ -46,4 +46,10 @@ type SystemServiceInterface interface {
 	PreparePPTDevice(domain string, id string) error
 	ImportPPTDevice(domain string, id string) error
 	RemovePPTDevice(id string) error
+	CheckPPTReservations() ([]models.PassedThroughIDs, error)
+	VerifyPPTDevices(ids []int) error
+	ReclaimPPTDevice(id string) error
+	ListUSBDevices() ([]USBDevice, error)
+
+	RunSelfTest(ctx context.Context, req SelfTestRequest) (SelfTestReport, error)
 }
@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package systemServiceInterfaces
+
+// USBDevice is a device attached to one of the host's USB buses. bhyve can
+// not hand single USB devices to a guest, so each device carries the PCI
+// address of the controller it hangs off; passing that controller through
+// moves every device on it to the VM.
+type USBDevice struct {
+	Ugen         string `json:"ugen"`
+	Bus          int    `json:"bus"`
+	Address      int    `json:"address"`
+	Description  string `json:"description"`
+	VendorID     string `json:"vendorId"`
+	ProductID    string `json:"productId"`
+	Manufacturer string `json:"manufacturer"`
+	Product      string `json:"product"`
+	Serial       string `json:"serial"`
+	Speed        string `json:"speed"`
+
+	Controller         string `json:"controller"`
+	ControllerDomain   int    `json:"controllerDomain"`
+	ControllerDeviceID string `json:"controllerDeviceId"`
+}
@@ -17,6 +17,24 @@ type DownloadFileRequest struct {
 	AutomaticExtraction    *bool                         `json:"automaticExtraction"`
 	AutomaticRawConversion *bool                         `json:"automaticRawConversion"`
 	DownloadType           utilitiesModels.DownloadUType `json:"downloadType"`
+	Verification           *DownloadVerificationRequest  `json:"verification"`
+}
+
+// DownloadVerificationRequest is what a download is checked against. SHA256
+// is compared directly; ChecksumURL is a published checksum list (sha256sum
+// or BSD style) looked up by file name. SignatureURL is a detached signature
+// made with `openssl dgst -sha256 -sign` by the key in SignaturePublicKey
+// (PEM, RSA or ECDSA); it covers the checksum list when one is given and the
+// file itself otherwise.
+type DownloadVerificationRequest struct {
+	SHA256             string `json:"sha256"`
+	ChecksumURL        string `json:"checksumUrl"`
+	SignatureURL       string `json:"signatureUrl"`
+	SignaturePublicKey string `json:"signaturePublicKey"`
+}
+
+type DownloadVerifyPayload struct {
+	ID uint `json:"id"`
 }
 
 type UTypeGroupedDownload struct {
@@ -46,6 +64,7 @@ type UtilitiesServiceInterface interface {
 	GetMagnetDownloadAndFile(uuid, name string) (*utilitiesModels.Downloads, *utilitiesModels.DownloadedFile, error)
 	SyncDownloadProgress() error
 	DeleteDownload(id int) error
+	VerifyDownload(id uint, req DownloadVerificationRequest) error
 
 	RegisterJobs()
 
@@ -55,6 +55,8 @@ type CreatePeriodicSnapshotJobRequest struct {
 	KeepWeekly  *int `json:"keepWeekly"`
 	KeepMonthly *int `json:"keepMonthly"`
 	KeepYearly  *int `json:"keepYearly"`
+
+	BookmarkPruned *bool `json:"bookmarkPruned"`
 }
 
 type ModifyPeriodicSnapshotRetentionRequest struct {
@@ -68,6 +70,8 @@ type ModifyPeriodicSnapshotRetentionRequest struct {
 	KeepWeekly  *int `json:"keepWeekly"`
 	KeepMonthly *int `json:"keepMonthly"`
 	KeepYearly  *int `json:"keepYearly"`
+
+	BookmarkPruned *bool `json:"bookmarkPruned"`
 }
 
 type PaginatedSnapshotsResponse struct {
@@ -94,3 +98,9 @@ type PaginatedDatasetsResponse struct {
 	LastPage int             `json:"last_page"`
 	Data     []*gzfs.Dataset `json:"data"`
 }
+
+type DelegationRequest struct {
+	Dataset     string   `json:"dataset" binding:"required"`
+	User        string   `json:"user" binding:"required"`
+	Permissions []string `json:"permissions"`
+}
@@ -8,6 +8,8 @@
 
 package zfsServiceInterfaces
 
+import "time"
+
 type RaidType string
 
 const (
@@ -48,6 +50,28 @@ type ReplaceDevice struct {
 	New string `json:"new" binding:"required,min=1,max=24"`
 }
 
+type HotSpare struct {
+	Name  string `json:"name"`
+	Path  string `json:"path"`
+	GUID  string `json:"guid"`
+	State string `json:"state"`
+}
+
+type PoolHotSpares struct {
+	Pool        string     `json:"pool"`
+	AutoReplace bool       `json:"autoReplace"`
+	Spares      []HotSpare `json:"spares"`
+
+	LastReplacedAt *time.Time `json:"lastReplacedAt"`
+	LastFaulted    string     `json:"lastFaulted"`
+	LastSpare      string     `json:"lastSpare"`
+	LastError      string     `json:"lastError"`
+}
+
+type SetHotSparePolicyRequest struct {
+	AutoReplace *bool `json:"autoReplace" binding:"required"`
+}
+
 type PoolStatPoint struct {
 	Time       int64   `json:"time"`
 	Allocated  uint64  `json:"allocated"`
@@ -49,6 +49,7 @@ type ZfsServiceInterface interface {
 	GetDatasets(ctx context.Context, t gzfs.DatasetType) ([]*gzfs.Dataset, error)
 	BulkDeleteDataset(ctx context.Context, guids []string) error
 	IsDatasetInUse(guid string, failEarly bool) bool
+	RenameDataset(ctx context.Context, guid string, newName string) error
 
 	GetPoolStatus(ctx context.Context, guid string) (*gzfs.ZPoolStatusPool, error)
 	ScrubPool(ctx context.Context, guid string) error
@@ -57,6 +58,8 @@ type ZfsServiceInterface interface {
 	DeletePool(ctx context.Context, guid string) error
 	ReplaceDevice(ctx context.Context, guid, old, latest string) error
 	DetachDevice(ctx context.Context, guid, device string) error
+	GetHotSpares(ctx context.Context, guid string) (*PoolHotSpares, error)
+	SetHotSparePolicy(ctx context.Context, guid string, autoReplace bool) (*PoolHotSpares, error)
 	GetZpoolHistoricalStats(intervalMinutes int, limit int) (map[string][]PoolStatPoint, int, error)
 
 	CreateSnapshot(ctx context.Context, guid string, name string, recursive bool) error
@@ -68,6 +71,7 @@ type ZfsServiceInterface interface {
 	StartSnapshotScheduler(ctx context.Context)
 	RollbackSnapshot(ctx context.Context, guid string, destroyMoreRecent bool) error
 	RollbackSnapshotByName(ctx context.Context, snapshotName string, destroyMoreRecent bool) error
+	RenameSnapshot(ctx context.Context, guid string, newName string) error
 
 	PoolFromDataset(ctx context.Context, name string) (string, error)
 	GetUsablePools(ctx context.Context) ([]*gzfs.ZPool, error)
@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package layout
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/alchemillahq/gzfs"
+)
+
+type dataset struct {
+	path  string
+	props map[string]string
+}
+
+func (l Layout) datasets() []dataset {
+	return []dataset{
+		{path: l.Root},
+		{path: l.Root + "/" + l.VirtualMachines.Name, props: l.VirtualMachines.Properties()},
+		{path: l.Root + "/" + l.Jails.Name, props: l.Jails.Properties()},
+		{path: l.Root + "/" + l.Bootstraps.Name, props: l.Bootstraps.Properties()},
+	}
+}
+
+// Paths are the layout's datasets relative to a pool, parents first.
+func Paths() []string {
+	var paths []string
+	for _, ds := range Current().datasets() {
+		paths = append(paths, ds.path)
+	}
+	return paths
+}
+
+// EnsurePool creates the layout's datasets on a pool that lacks them and
+// returns the ones it created. Class properties only apply on creation, so
+// an operator's later changes to an existing dataset are left alone; a
+// mountpoint that drifted is put back.
+func EnsurePool(ctx context.Context, client *gzfs.Client, pool string) ([]*gzfs.Dataset, error) {
+	if client == nil || client.ZFS == nil {
+		return nil, fmt.Errorf("gzfs_not_initialized")
+	}
+
+	var created []*gzfs.Dataset
+	for _, ds := range Current().datasets() {
+		name := pool + "/" + ds.path
+		mountpoint := Mountpoint(name)
+
+		found, err := client.ZFS.Get(ctx, name, false)
+		if err != nil && !strings.Contains(strings.ToLower(err.Error()), "does not exist") {
+			return nil, fmt.Errorf("error_checking_dataset_%s: %w", name, err)
+		}
+
+		if found != nil {
+			if found.Mountpoint != mountpoint {
+				if err := client.ZFS.EditFilesystem(ctx, name, map[string]string{
+					"mountpoint": mountpoint,
+				}); err != nil {
+					return nil, fmt.Errorf("error_fixing_mountpoint_%s: %w", name, err)
+				}
+			}
+			continue
+		}
+
+		props := map[string]string{"mountpoint": mountpoint}
+		for key, value := range ds.props {
+			props[key] = value
+		}
+
+		newDataset, err := client.ZFS.CreateFilesystem(ctx, name, props)
+		if err != nil {
+			return nil, fmt.Errorf("error_creating_dataset_%s: %w", name, err)
+		}
+		created = append(created, newDataset)
+	}
+
+	return created, nil
+}
@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+// Package layout owns the names of the datasets Sylve manages on each pool:
+// <pool>/<root>/{<virtual-machines>,<jails>,<bootstraps>}. Services build and
+// parse guest dataset paths through it rather than spelling them out.
+package layout
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/config"
+)
+
+const (
+	DefaultRoot            = "sylve"
+	DefaultVirtualMachines = "virtual-machines"
+	DefaultJails           = "jails"
+	DefaultBootstraps      = "bootstraps"
+)
+
+var (
+	componentPattern   = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+	compressionPattern = regexp.MustCompile(`^(on|off|lz4|lzjb|zle|gzip(-[1-9])?|zstd(-([1-9]|1[0-9]))?|zstd-fast(-[0-9]+)?)$`)
+	sizePattern        = regexp.MustCompile(`^([0-9]+)([KMkm]?)$`)
+)
+
+// Class is one kind of dataset kept under the Sylve root.
+type Class struct {
+	Name        string
+	Compression string
+	Recordsize  string
+}
+
+// Properties are the ZFS properties the class dataset is created with.
+func (c Class) Properties() map[string]string {
+	props := map[string]string{}
+	if c.Compression != "" {
+		props["compression"] = c.Compression
+	}
+	if c.Recordsize != "" {
+		props["recordsize"] = c.Recordsize
+	}
+	return props
+}
+
+type Layout struct {
+	Root            string
+	VirtualMachines Class
+	Jails           Class
+	Bootstraps      Class
+}
+
+func Default() Layout {
+	return Layout{
+		Root:            DefaultRoot,
+		VirtualMachines: Class{Name: DefaultVirtualMachines},
+		Jails:           Class{Name: DefaultJails},
+		Bootstraps:      Class{Name: DefaultBootstraps},
+	}
+}
+
+// Resolve fills unset names with the defaults and validates the result.
+func Resolve(cfg internal.DatasetLayoutConfig) (Layout, error) {
+	l := Default()
+	if name := strings.TrimSpace(cfg.Root); name != "" {
+		l.Root = name
+	}
+	if !componentPattern.MatchString(l.Root) {
+		return Layout{}, fmt.Errorf("invalid_dataset_layout_root: %q", l.Root)
+	}
+
+	classes := []struct {
+		key string
+		cfg internal.DatasetClassConfig
+		dst *Class
+	}{
+		{"virtualMachines", cfg.VirtualMachines, &l.VirtualMachines},
+		{"jails", cfg.Jails, &l.Jails},
+		{"bootstraps", cfg.Bootstraps, &l.Bootstraps},
+	}
+
+	seen := map[string]string{}
+	for _, c := range classes {
+		if name := strings.TrimSpace(c.cfg.Name); name != "" {
+			c.dst.Name = name
+		}
+		if !componentPattern.MatchString(c.dst.Name) {
+			return Layout{}, fmt.Errorf("invalid_dataset_layout_%s_name: %q", c.key, c.dst.Name)
+		}
+		if other, ok := seen[c.dst.Name]; ok {
+			return Layout{}, fmt.Errorf("duplicate_dataset_layout_name: %s and %s are both %q", other, c.key, c.dst.Name)
+		}
+		seen[c.dst.Name] = c.key
+
+		c.dst.Compression = strings.ToLower(strings.TrimSpace(c.cfg.Compression))
+		if c.dst.Compression != "" && !compressionPattern.MatchString(c.dst.Compression) {
+			return Layout{}, fmt.Errorf("invalid_dataset_layout_%s_compression: %q", c.key, c.dst.Compression)
+		}
+
+		recordsize, err := normalizeRecordsize(c.cfg.Recordsize)
+		if err != nil {
+			return Layout{}, fmt.Errorf("invalid_dataset_layout_%s_recordsize: %w", c.key, err)
+		}
+		c.dst.Recordsize = recordsize
+	}
+
+	return l, nil
+}
+
+// normalizeRecordsize accepts a power of two between 512 bytes and 16M, in
+// bytes or with a K/M suffix.
+func normalizeRecordsize(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+
+	match := sizePattern.FindStringSubmatch(value)
+	if match == nil {
+		return "", fmt.Errorf("%q", value)
+	}
+	size, err := strconv.ParseUint(match[1], 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("%q", value)
+	}
+	switch strings.ToUpper(match[2]) {
+	case "K":
+		size <<= 10
+	case "M":
+		size <<= 20
+	}
+	if size < 512 || size > 16<<20 || size&(size-1) != 0 {
+		return "", fmt.Errorf("%q is not a power of two between 512 and 16M", value)
+	}
+
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%dM", size>>20), nil
+	case size >= 1<<10:
+		return fmt.Sprintf("%dK", size>>10), nil
+	}
+	return strconv.FormatUint(size, 10), nil
+}
+
+// Current is the layout from the parsed config. The config is validated at
+// startup (see Validate), so an invalid layout here only happens in tools
+// that skipped it; they get the defaults.
+func Current() Layout {
+	if config.ParsedConfig == nil {
+		return Default()
+	}
+	l, err := Resolve(config.ParsedConfig.Datasets)
+	if err != nil {
+		return Default()
+	}
+	return l
+}
+
+// Validate checks the layout section of a parsed config.
+func Validate(cfg *internal.SylveConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	_, err := Resolve(cfg.Datasets)
+	return err
+}
+
+func RootName() string            { return Current().Root }
+func VirtualMachinesName() string { return Current().VirtualMachines.Name }
+func JailsName() string           { return Current().Jails.Name }
+func BootstrapsName() string      { return Current().Bootstraps.Name }
+
+// VMsPath, JailsPath and BootstrapsPath are the class datasets relative to
+// their pool, e.g. "sylve/jails".
+func VMsPath() string        { return RootName() + "/" + VirtualMachinesName() }
+func JailsPath() string      { return RootName() + "/" + JailsName() }
+func BootstrapsPath() string { return RootName() + "/" + BootstrapsName() }
+
+func RootDataset(pool string) string       { return pool + "/" + RootName() }
+func VMsDataset(pool string) string        { return pool + "/" + VMsPath() }
+func JailsDataset(pool string) string      { return pool + "/" + JailsPath() }
+func BootstrapsDataset(pool string) string { return pool + "/" + BootstrapsPath() }
+
+// VMDataset is the root of one VM's datasets, <pool>/sylve/virtual-machines/<rid>.
+func VMDataset(pool string, rid uint) string {
+	return fmt.Sprintf("%s/%d", VMsDataset(pool), rid)
+}
+
+// JailDataset is a jail's root dataset, <pool>/sylve/jails/<ctid>.
+func JailDataset(pool string, ctID uint) string {
+	return fmt.Sprintf("%s/%d", JailsDataset(pool), ctID)
+}
+
+func BootstrapDataset(pool, name string) string {
+	return BootstrapsDataset(pool) + "/" + name
+}
+
+// Mountpoint is where Sylve mounts a managed dataset.
+func Mountpoint(dataset string) string {
+	return "/" + strings.Trim(dataset, "/")
+}
+
+// GuestClass names the class directory a guest kind lives under, or "" for
+// anything else. Callers that deal in "vm"/"jail" use it to match path
+// components without caring about the configured names.
+func GuestClass(guestType string) string {
+	switch guestType {
+	case "vm":
+		return VirtualMachinesName()
+	case "jail":
+		return JailsName()
+	}
+	return ""
+}
+
+// IsGuestClass reports whether a path component is the VM or jail class.
+func IsGuestClass(component string) bool {
+	return component == VirtualMachinesName() || component == JailsName()
+}
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2026 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package layout
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/config"
+)
+
+func TestResolveDefaultsWhenUnset(t *testing.T) {
+	got, err := Resolve(internal.DatasetLayoutConfig{})
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if got != Default() {
+		t.Fatalf("layout = %+v, want %+v", got, Default())
+	}
+	if props := got.VirtualMachines.Properties(); len(props) != 0 {
+		t.Fatalf("default class properties = %v, want none", props)
+	}
+}
+
+func TestResolveAppliesOverrides(t *testing.T) {
+	got, err := Resolve(internal.DatasetLayoutConfig{
+		Root: "guests",
+		VirtualMachines: internal.DatasetClassConfig{
+			Name:        "vm",
+			Compression: "ZSTD-3",
+			Recordsize:  "64k",
+		},
+		Bootstraps: internal.DatasetClassConfig{Recordsize: "1048576"},
+	})
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+
+	if got.Root != "guests" || got.VirtualMachines.Name != "vm" || got.Jails.Name != DefaultJails {
+		t.Fatalf("unexpected names: %+v", got)
+	}
+	props := got.VirtualMachines.Properties()
+	if props["compression"] != "zstd-3" || props["recordsize"] != "64K" {
+		t.Fatalf("vm properties = %v", props)
+	}
+	if got.Bootstraps.Recordsize != "1M" {
+		t.Fatalf("bootstraps recordsize = %q, want 1M", got.Bootstraps.Recordsize)
+	}
+}
+
+func TestResolveRejectsInvalidLayouts(t *testing.T) {
+	cases := map[string]internal.DatasetLayoutConfig{
+		"nested root":      {Root: "a/b"},
+		"duplicate names":  {Jails: internal.DatasetClassConfig{Name: DefaultVirtualMachines}},
+		"bad compression":  {Jails: internal.DatasetClassConfig{Compression: "brotli"}},
+		"odd recordsize":   {Jails: internal.DatasetClassConfig{Recordsize: "96K"}},
+		"small recordsize": {Jails: internal.DatasetClassConfig{Recordsize: "256"}},
+		"large recordsize": {Jails: internal.DatasetClassConfig{Recordsize: "32M"}},
+	}
+	for name, cfg := range cases {
+		if _, err := Resolve(cfg); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestDatasetPathsFollowConfig(t *testing.T) {
+	previous := config.ParsedConfig
+	t.Cleanup(func() { config.ParsedConfig = previous })
+
+	config.ParsedConfig = &internal.SylveConfig{
+		Datasets: internal.DatasetLayoutConfig{
+			Root: "managed",
+			Jails: internal.DatasetClassConfig{
+				Name: "containers",
+			},
+		},
+	}
+
+	if got := JailDataset("tank", 105); got != "tank/managed/containers/105" {
+		t.Fatalf("jail dataset = %q", got)
+	}
+	if got := VMDataset("tank", 100); got != "tank/managed/virtual-machines/100" {
+		t.Fatalf("vm dataset = %q", got)
+	}
+	if got := Mountpoint(JailsDataset("tank")); got != "/tank/managed/containers" {
+		t.Fatalf("mountpoint = %q", got)
+	}
+	if !IsGuestClass("containers") || IsGuestClass(DefaultJails) {
+		t.Fatal("guest class should follow the configured jail name")
+	}
+
+	want := "managed,managed/virtual-machines,managed/containers,managed/bootstraps"
+	if got := strings.Join(Paths(), ","); got != want {
+		t.Fatalf("datasets = %q, want %q", got, want)
+	}
+}
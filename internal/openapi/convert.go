@@ -0,0 +1,255 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package openapi
+
+import (
+	"regexp"
+	"strings"
+)
+
+const openAPIVersion = "3.0.3"
+
+var colonParam = regexp.MustCompile(`:([A-Za-z0-9_]+)`)
+
+// Keys a swagger 2 non-body parameter carries that belong in an OpenAPI 3
+// parameter schema.
+var parameterSchemaKeys = []string{
+	"type", "format", "items", "enum", "default",
+	"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum",
+	"minLength", "maxLength", "pattern", "minItems", "maxItems", "uniqueItems",
+}
+
+// convertSwagger2 rewrites the swag output into an OpenAPI 3 document. Only
+// the constructs swag emits are handled.
+func convertSwagger2(in document) map[string]any {
+	out := map[string]any{
+		"openapi": openAPIVersion,
+	}
+	if info, ok := in["info"]; ok {
+		out["info"] = info
+	}
+
+	basePath, _ := in["basePath"].(string)
+	if basePath == "" {
+		basePath = "/"
+	}
+	out["servers"] = []any{map[string]any{"url": basePath}}
+
+	components := map[string]any{}
+	if defs, ok := in["definitions"].(map[string]any); ok {
+		components["schemas"] = rewriteRefs(defs)
+	}
+	if secDefs, ok := in["securityDefinitions"].(map[string]any); ok {
+		components["securitySchemes"] = convertSecuritySchemes(secDefs)
+	}
+	out["components"] = components
+
+	paths := map[string]any{}
+	if inPaths, ok := in["paths"].(map[string]any); ok {
+		for path, rawItem := range inPaths {
+			item, ok := rawItem.(map[string]any)
+			if !ok {
+				continue
+			}
+			convertedPath := colonParam.ReplaceAllString(path, "{$1}")
+			pathItem, _ := paths[convertedPath].(map[string]any)
+			if pathItem == nil {
+				pathItem = map[string]any{}
+			}
+			for method, rawOp := range item {
+				op, ok := rawOp.(map[string]any)
+				if !ok {
+					continue
+				}
+				pathItem[method] = convertOperation(op)
+			}
+			paths[convertedPath] = pathItem
+		}
+	}
+	out["paths"] = paths
+
+	return out
+}
+
+func convertSecuritySchemes(in map[string]any) map[string]any {
+	out := make(map[string]any, len(in))
+	for name, raw := range in {
+		def, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		scheme := map[string]any{}
+		for key, value := range def {
+			scheme[key] = value
+		}
+		if t, _ := def["type"].(string); t == "basic" {
+			scheme["type"] = "http"
+			scheme["scheme"] = "basic"
+		}
+		out[name] = scheme
+	}
+	return out
+}
+
+func convertOperation(op map[string]any) map[string]any {
+	out := map[string]any{}
+	for key, value := range op {
+		switch key {
+		case "consumes", "produces", "parameters", "responses":
+		default:
+			out[key] = rewriteRefs(value)
+		}
+	}
+
+	consumes := stringList(op["consumes"], "application/json")
+	produces := stringList(op["produces"], "application/json")
+
+	var params []any
+	formSchema := map[string]any{"type": "object", "properties": map[string]any{}}
+	var formRequired []any
+	hasForm := false
+
+	rawParams, _ := op["parameters"].([]any)
+	for _, rawParam := range rawParams {
+		param, ok := rawParam.(map[string]any)
+		if !ok {
+			continue
+		}
+		switch param["in"] {
+		case "body":
+			body := map[string]any{
+				"content": contentFor(consumes, rewriteRefs(param["schema"])),
+			}
+			if desc, ok := param["description"]; ok {
+				body["description"] = desc
+			}
+			if required, ok := param["required"].(bool); ok {
+				body["required"] = required
+			}
+			out["requestBody"] = body
+		case "formData":
+			hasForm = true
+			schema := parameterSchema(param)
+			if schema["type"] == "file" {
+				schema = map[string]any{"type": "string", "format": "binary"}
+			}
+			formSchema["properties"].(map[string]any)[param["name"].(string)] = schema
+			if required, _ := param["required"].(bool); required {
+				formRequired = append(formRequired, param["name"])
+			}
+		default:
+			converted := map[string]any{
+				"name":   param["name"],
+				"in":     param["in"],
+				"schema": parameterSchema(param),
+			}
+			if desc, ok := param["description"]; ok {
+				converted["description"] = desc
+			}
+			if required, ok := param["required"].(bool); ok {
+				converted["required"] = required
+			}
+			if param["in"] == "path" {
+				converted["required"] = true
+			}
+			params = append(params, converted)
+		}
+	}
+	if hasForm {
+		if len(formRequired) > 0 {
+			formSchema["required"] = formRequired
+		}
+		out["requestBody"] = map[string]any{
+			"required": true,
+			"content":  map[string]any{"multipart/form-data": map[string]any{"schema": formSchema}},
+		}
+	}
+	if len(params) > 0 {
+		out["parameters"] = params
+	}
+
+	responses := map[string]any{}
+	if rawResponses, ok := op["responses"].(map[string]any); ok {
+		for code, rawResponse := range rawResponses {
+			response, ok := rawResponse.(map[string]any)
+			if !ok {
+				continue
+			}
+			converted := map[string]any{"description": response["description"]}
+			if converted["description"] == nil {
+				converted["description"] = ""
+			}
+			if schema, ok := response["schema"]; ok {
+				converted["content"] = contentFor(produces, rewriteRefs(schema))
+			}
+			responses[code] = converted
+		}
+	}
+	out["responses"] = responses
+
+	return out
+}
+
+func parameterSchema(param map[string]any) map[string]any {
+	schema := map[string]any{}
+	for _, key := range parameterSchemaKeys {
+		if value, ok := param[key]; ok {
+			schema[key] = rewriteRefs(value)
+		}
+	}
+	return schema
+}
+
+func contentFor(mediaTypes []string, schema any) map[string]any {
+	content := make(map[string]any, len(mediaTypes))
+	for _, mediaType := range mediaTypes {
+		content[mediaType] = map[string]any{"schema": schema}
+	}
+	return content
+}
+
+func stringList(raw any, fallback string) []string {
+	list, _ := raw.([]any)
+	out := make([]string, 0, len(list))
+	for _, value := range list {
+		if s, ok := value.(string); ok && s != "" {
+			out = append(out, s)
+		}
+	}
+	if len(out) == 0 {
+		out = append(out, fallback)
+	}
+	return out
+}
+
+// rewriteRefs copies v with definition refs pointed at components.
+func rewriteRefs(v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(value))
+		for key, inner := range value {
+			if key == "$ref" {
+				if ref, ok := inner.(string); ok {
+					out[key] = strings.Replace(ref, "#/definitions/", "#/components/schemas/", 1)
+					continue
+				}
+			}
+			out[key] = rewriteRefs(inner)
+		}
+		return out
+	case []any:
+		out := make([]any, len(value))
+		for i, inner := range value {
+			out[i] = rewriteRefs(inner)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/alchemillahq/sylve/docs/swagger"
+)
+
+type document map[string]any
+
+var (
+	loadOnce   sync.Once
+	swaggerDoc document
+	spec       []byte
+	operations *operationIndex
+	loadErr    error
+)
+
+func load() {
+	if err := json.Unmarshal(swagger.JSON, &swaggerDoc); err != nil {
+		loadErr = fmt.Errorf("swagger_spec_parse_failed: %w", err)
+		return
+	}
+
+	converted := convertSwagger2(swaggerDoc)
+	spec, loadErr = json.Marshal(converted)
+	if loadErr != nil {
+		loadErr = fmt.Errorf("openapi_spec_encode_failed: %w", loadErr)
+		return
+	}
+
+	operations = indexOperations(swaggerDoc)
+}
+
+// Spec returns the OpenAPI 3 document for the API.
+func Spec() ([]byte, error) {
+	loadOnce.Do(load)
+	return spec, loadErr
+}
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package openapi
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestSpecIsOpenAPI3(t *testing.T) {
+	raw, err := Spec()
+	if err != nil {
+		t.Fatalf("Spec: %v", err)
+	}
+	if strings.Contains(string(raw), "#/definitions/") {
+		t.Fatalf("spec still references swagger 2 definitions")
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		t.Fatalf("decode spec: %v", err)
+	}
+	if doc["openapi"] != openAPIVersion {
+		t.Fatalf("unexpected version: %v", doc["openapi"])
+	}
+
+	paths := doc["paths"].(map[string]any)
+	group, ok := paths["/auth/groups/{id}"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected colon path params to be converted")
+	}
+	params := group["delete"].(map[string]any)["parameters"].([]any)
+	param := params[0].(map[string]any)
+	if param["in"] != "path" || param["schema"].(map[string]any)["type"] != "integer" {
+		t.Fatalf("unexpected parameter: %#v", param)
+	}
+
+	create := paths["/auth/groups"].(map[string]any)["post"].(map[string]any)
+	body := create["requestBody"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)
+	if ref := body["schema"].(map[string]any)["$ref"]; ref != "#/components/schemas/internal_handlers_auth.CreateGroupRequest" {
+		t.Fatalf("unexpected body ref: %v", ref)
+	}
+}
+
+func TestLookupPrefersLiteralSegments(t *testing.T) {
+	if op := Lookup("GET", "/api/vm/simple/4"); op == nil || op.segments[1] != "simple" {
+		t.Fatalf("expected the literal route, got %#v", op)
+	}
+	if op := Lookup("POST", "/api/system/apply"); op == nil {
+		t.Fatalf("expected routes added after the last swag run to be documented")
+	}
+	if op := Lookup("GET", "/other/vm/4"); op != nil {
+		t.Fatalf("paths outside the base path must not match")
+	}
+	if op := Lookup("PATCH", "/api/auth/groups"); op != nil {
+		t.Fatalf("undocumented methods must not match")
+	}
+}
+
+func TestValidateReportsFieldErrors(t *testing.T) {
+	op := Lookup("POST", "/api/auth/groups")
+	if op == nil {
+		t.Fatal("expected group creation to be documented")
+	}
+
+	if errs := op.Validate("/api/auth/groups", nil, []byte(`{"name":"ops","members":["a"],"extra":1}`)); len(errs) != 0 {
+		t.Fatalf("expected a valid body, got %#v", errs)
+	}
+
+	errs := op.Validate("/api/auth/groups", nil, []byte(`{"name":5,"members":["a",2]}`))
+	want := map[string]string{"body.name": "invalid_type", "body.members[1]": "invalid_type"}
+	if len(errs) != len(want) {
+		t.Fatalf("unexpected errors: %#v", errs)
+	}
+	for _, e := range errs {
+		if want[e.Field] != e.Code {
+			t.Fatalf("unexpected error: %#v", e)
+		}
+	}
+
+	errs = op.Validate("/api/auth/groups", nil, []byte(`{"name":null}`))
+	if len(errs) != 2 || errs[0].Field != "body.members" || errs[1].Field != "body.name" || errs[0].Code != "required" {
+		t.Fatalf("expected missing fields, got %#v", errs)
+	}
+
+	errs = op.Validate("/api/auth/groups", nil, []byte(`{"name":`))
+	if len(errs) != 1 || errs[0].Code != "invalid_json" {
+		t.Fatalf("expected malformed json, got %#v", errs)
+	}
+
+	errs = op.Validate("/api/auth/groups", nil, nil)
+	if len(errs) != 1 || errs[0].Field != "body" || errs[0].Code != "required" {
+		t.Fatalf("expected a required body, got %#v", errs)
+	}
+}
+
+func TestValidateParams(t *testing.T) {
+	op := Lookup("DELETE", "/api/auth/groups/x")
+	if errs := op.ValidateParams("/api/auth/groups/x", nil); len(errs) != 1 || errs[0].Field != "path.id" {
+		t.Fatalf("expected an invalid path id, got %#v", errs)
+	}
+	if errs := op.ValidateParams("/api/auth/groups/12", nil); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %#v", errs)
+	}
+
+	op = Lookup("GET", "/api/vm/simple/4")
+	errs := op.ValidateParams("/api/vm/simple/4", url.Values{"type": {"uuid"}})
+	if len(errs) != 1 || errs[0].Code != "invalid_enum" {
+		t.Fatalf("expected an enum error, got %#v", errs)
+	}
+}
@@ -0,0 +1,435 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxSchemaDepth bounds $ref expansion for self-referencing definitions.
+const maxSchemaDepth = 32
+
+// FieldError describes one field that failed validation. Field is a dotted
+// path rooted at body, path or query, e.g. "body.networks[1].switchId".
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Operation is a documented endpoint the validator can check requests for.
+type Operation struct {
+	segments     []string
+	params       []map[string]any
+	bodySchema   any
+	bodyRequired bool
+	definitions  map[string]any
+}
+
+type operationIndex struct {
+	basePath string
+	byMethod map[string][]*Operation
+}
+
+func indexOperations(doc document) *operationIndex {
+	idx := &operationIndex{byMethod: map[string][]*Operation{}}
+	idx.basePath, _ = doc["basePath"].(string)
+	idx.basePath = strings.TrimSuffix(idx.basePath, "/")
+	definitions, _ := doc["definitions"].(map[string]any)
+
+	paths, _ := doc["paths"].(map[string]any)
+	for path, rawItem := range paths {
+		item, ok := rawItem.(map[string]any)
+		if !ok {
+			continue
+		}
+		for method, rawOp := range item {
+			op, ok := rawOp.(map[string]any)
+			if !ok {
+				continue
+			}
+			operation := &Operation{
+				segments:    splitPath(path),
+				definitions: definitions,
+			}
+			rawParams, _ := op["parameters"].([]any)
+			for _, rawParam := range rawParams {
+				param, ok := rawParam.(map[string]any)
+				if !ok {
+					continue
+				}
+				switch param["in"] {
+				case "body":
+					operation.bodySchema = param["schema"]
+					operation.bodyRequired, _ = param["required"].(bool)
+				case "path", "query":
+					operation.params = append(operation.params, param)
+				}
+			}
+			method = strings.ToUpper(method)
+			idx.byMethod[method] = append(idx.byMethod[method], operation)
+		}
+	}
+	return idx
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+func isTemplateSegment(segment string) bool {
+	return strings.HasPrefix(segment, ":") ||
+		(strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"))
+}
+
+func templateName(segment string) string {
+	return strings.Trim(segment, ":{}")
+}
+
+// Lookup finds the documented operation for a request. Literal segments win
+// over templated ones when several operations match.
+func Lookup(method, requestPath string) *Operation {
+	loadOnce.Do(load)
+	if operations == nil {
+		return nil
+	}
+
+	if operations.basePath != "" {
+		if !strings.HasPrefix(requestPath, operations.basePath+"/") {
+			return nil
+		}
+		requestPath = strings.TrimPrefix(requestPath, operations.basePath)
+	}
+	segments := splitPath(requestPath)
+
+	var best *Operation
+	bestLiterals := -1
+	for _, op := range operations.byMethod[strings.ToUpper(method)] {
+		if len(op.segments) != len(segments) {
+			continue
+		}
+		literals := 0
+		matched := true
+		for i, segment := range op.segments {
+			if isTemplateSegment(segment) {
+				continue
+			}
+			if segment != segments[i] {
+				matched = false
+				break
+			}
+			literals++
+		}
+		if matched && literals > bestLiterals {
+			best = op
+			bestLiterals = literals
+		}
+	}
+	return best
+}
+
+// HasBody reports whether the operation documents a JSON body.
+func (op *Operation) HasBody() bool {
+	return op != nil && op.bodySchema != nil
+}
+
+// Validate checks path and query parameters and the JSON body against the
+// documented schema. Only constraints the handlers already enforce through
+// binding tags are checked; unknown fields are ignored so an older spec
+// never rejects a newer client.
+func (op *Operation) Validate(requestPath string, query url.Values, body []byte) []FieldError {
+	return append(op.ValidateParams(requestPath, query), op.ValidateBody(body)...)
+}
+
+// ValidateParams checks the path and query parameters of a request.
+func (op *Operation) ValidateParams(requestPath string, query url.Values) []FieldError {
+	if op == nil {
+		return nil
+	}
+
+	var errs []FieldError
+	segments := splitPath(requestPath)
+	offset := len(segments) - len(op.segments)
+
+	for _, param := range op.params {
+		name, _ := param["name"].(string)
+		switch param["in"] {
+		case "path":
+			for i, segment := range op.segments {
+				if !isTemplateSegment(segment) || templateName(segment) != name || offset+i < 0 {
+					continue
+				}
+				value, err := url.PathUnescape(segments[offset+i])
+				if err != nil {
+					value = segments[offset+i]
+				}
+				errs = append(errs, validateScalar("path."+name, value, param)...)
+			}
+		case "query":
+			values, present := query[name]
+			if !present || len(values) == 0 {
+				if required, _ := param["required"].(bool); required {
+					errs = append(errs, FieldError{Field: "query." + name, Code: "required", Message: "is required"})
+				}
+				continue
+			}
+			errs = append(errs, validateScalar("query."+name, values[0], param)...)
+		}
+	}
+	return errs
+}
+
+// ValidateBody checks a JSON request body against the documented schema.
+func (op *Operation) ValidateBody(body []byte) []FieldError {
+	if !op.HasBody() {
+		return nil
+	}
+
+	var errs []FieldError
+	if len(bytes.TrimSpace(body)) == 0 {
+		if op.bodyRequired {
+			errs = append(errs, FieldError{Field: "body", Code: "required", Message: "request body is required"})
+		}
+		return errs
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return append(errs, FieldError{Field: "body", Code: "invalid_json", Message: jsonErrorMessage(err)})
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return append(errs, FieldError{Field: "body", Code: "invalid_json", Message: "unexpected data after the JSON value"})
+	}
+
+	return append(errs, op.validateValue("body", value, op.bodySchema, 0)...)
+}
+
+func jsonErrorMessage(err error) string {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return fmt.Sprintf("%s at offset %d", syntaxErr.Error(), syntaxErr.Offset)
+	}
+	return err.Error()
+}
+
+// validateScalar checks a path or query string against its declared type.
+func validateScalar(field, raw string, param map[string]any) []FieldError {
+	var value any = raw
+	switch param["type"] {
+	case "integer", "number":
+		value = json.Number(raw)
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			return []FieldError{typeError(field, param["type"].(string))}
+		}
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return []FieldError{typeError(field, "boolean")}
+		}
+		value = b
+	}
+	return checkConstraints(field, value, param)
+}
+
+func (op *Operation) resolve(schema any, depth int) (map[string]any, bool) {
+	s, ok := schema.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	for depth < maxSchemaDepth {
+		ref, hasRef := s["$ref"].(string)
+		if !hasRef {
+			break
+		}
+		def, ok := op.definitions[strings.TrimPrefix(ref, "#/definitions/")].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		s = def
+		depth++
+	}
+	return s, depth < maxSchemaDepth
+}
+
+func (op *Operation) validateValue(field string, value any, schema any, depth int) []FieldError {
+	s, ok := op.resolve(schema, depth)
+	if !ok || depth >= maxSchemaDepth {
+		return nil
+	}
+
+	var errs []FieldError
+	if allOf, ok := s["allOf"].([]any); ok {
+		for _, sub := range allOf {
+			errs = append(errs, op.validateValue(field, value, sub, depth+1)...)
+		}
+	}
+
+	if value == nil {
+		// Optional pointer fields decode from null; required ones are
+		// reported by the parent object.
+		return errs
+	}
+
+	switch s["type"] {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return append(errs, typeError(field, "object"))
+		}
+		required, _ := s["required"].([]any)
+		for _, rawName := range required {
+			name, _ := rawName.(string)
+			if v, present := obj[name]; !present || v == nil {
+				errs = append(errs, FieldError{Field: field + "." + name, Code: "required", Message: "is required"})
+			}
+		}
+		properties, _ := s["properties"].(map[string]any)
+		names := make([]string, 0, len(obj))
+		for name := range obj {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if propSchema, ok := properties[name]; ok {
+				errs = append(errs, op.validateValue(field+"."+name, obj[name], propSchema, depth+1)...)
+			} else if additional, ok := s["additionalProperties"].(map[string]any); ok {
+				errs = append(errs, op.validateValue(field+"."+name, obj[name], additional, depth+1)...)
+			}
+		}
+		return errs
+	case "array":
+		list, ok := value.([]any)
+		if !ok {
+			return append(errs, typeError(field, "array"))
+		}
+		errs = append(errs, checkConstraints(field, value, s)...)
+		for i, item := range list {
+			errs = append(errs, op.validateValue(fmt.Sprintf("%s[%d]", field, i), item, s["items"], depth+1)...)
+		}
+		return errs
+	case "string":
+		if _, ok := value.(string); !ok {
+			return append(errs, typeError(field, "string"))
+		}
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok {
+			return append(errs, typeError(field, "integer"))
+		}
+		f, err := n.Float64()
+		if err != nil || f != math.Trunc(f) {
+			return append(errs, typeError(field, "integer"))
+		}
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			return append(errs, typeError(field, "number"))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return append(errs, typeError(field, "boolean"))
+		}
+	}
+
+	return append(errs, checkConstraints(field, value, s)...)
+}
+
+func typeError(field, want string) FieldError {
+	return FieldError{Field: field, Code: "invalid_type", Message: "must be " + article(want) + " " + want}
+}
+
+func article(word string) string {
+	if word != "" && strings.ContainsRune("aeiou", rune(word[0])) {
+		return "an"
+	}
+	return "a"
+}
+
+func checkConstraints(field string, value any, s map[string]any) []FieldError {
+	var errs []FieldError
+
+	if enum, ok := s["enum"].([]any); ok && len(enum) > 0 {
+		if !enumContains(enum, value) {
+			allowed := make([]string, 0, len(enum))
+			for _, e := range enum {
+				allowed = append(allowed, fmt.Sprint(e))
+			}
+			errs = append(errs, FieldError{Field: field, Code: "invalid_enum", Message: "must be one of: " + strings.Join(allowed, ", ")})
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		length := float64(utf8.RuneCountInString(v))
+		if min, ok := number(s["minLength"]); ok && length < min {
+			errs = append(errs, FieldError{Field: field, Code: "too_short", Message: fmt.Sprintf("must be at least %d characters", int(min))})
+		}
+		if max, ok := number(s["maxLength"]); ok && length > max {
+			errs = append(errs, FieldError{Field: field, Code: "too_long", Message: fmt.Sprintf("must be at most %d characters", int(max))})
+		}
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			break
+		}
+		if min, ok := number(s["minimum"]); ok && f < min {
+			errs = append(errs, FieldError{Field: field, Code: "below_minimum", Message: fmt.Sprintf("must be at least %v", min)})
+		}
+		if max, ok := number(s["maximum"]); ok && f > max {
+			errs = append(errs, FieldError{Field: field, Code: "above_maximum", Message: fmt.Sprintf("must be at most %v", max)})
+		}
+	case []any:
+		if min, ok := number(s["minItems"]); ok && float64(len(v)) < min {
+			errs = append(errs, FieldError{Field: field, Code: "too_few_items", Message: fmt.Sprintf("must have at least %d items", int(min))})
+		}
+		if max, ok := number(s["maxItems"]); ok && float64(len(v)) > max {
+			errs = append(errs, FieldError{Field: field, Code: "too_many_items", Message: fmt.Sprintf("must have at most %d items", int(max))})
+		}
+	}
+
+	return errs
+}
+
+func enumContains(enum []any, value any) bool {
+	if n, ok := value.(json.Number); ok {
+		f, err := n.Float64()
+		if err != nil {
+			return false
+		}
+		value = f
+	}
+	for _, e := range enum {
+		if e == value {
+			return true
+		}
+	}
+	return false
+}
+
+func number(raw any) (float64, bool) {
+	switch v := raw.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
@@ -10,6 +10,7 @@ package auth
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"strings"
@@ -19,6 +20,7 @@ import (
 	"github.com/alchemillahq/sylve/internal/config"
 	"github.com/alchemillahq/sylve/internal/db/models"
 	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
 	serviceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services"
 	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/pkg/utils"
@@ -31,7 +33,7 @@ import (
 var _ serviceInterfaces.AuthServiceInterface = (*Service)(nil)
 
 const (
-	maxLoginAttempts  = 5
+	maxLoginAttempts   = 5
 	loginBlockDuration = 15 * time.Minute
 )
 
@@ -41,9 +43,9 @@ type loginAttempt struct {
 }
 
 type Service struct {
-	DB             *gorm.DB
-	loginMu        sync.Mutex
-	loginAttempts  map[string]*loginAttempt
+	DB            *gorm.DB
+	loginMu       sync.Mutex
+	loginAttempts map[string]*loginAttempt
 }
 type JWT struct {
 	jwt.RegisteredClaims
@@ -77,7 +79,7 @@ func (s *Service) GetJWTSecret() (string, error) {
 		return "", fmt.Errorf("jwt_secret_not_found")
 	}
 
-	return secret.Data, nil
+	return string(secret.Data), nil
 }
 
 func (s *Service) GetClusterKey() (string, error) {
@@ -86,7 +88,7 @@ func (s *Service) GetClusterKey() (string, error) {
 		return "", fmt.Errorf("cluster_key_not_found")
 	}
 
-	return c.Key, nil
+	return string(c.Key), nil
 }
 
 func (s *Service) getTokenExpiry(remember bool) time.Time {
@@ -112,12 +114,7 @@ func (s *Service) issueJWT(user models.User, authType string, remember bool) (st
 		},
 	}
 
-	secret, err := s.GetJWTSecret()
-	if err != nil {
-		return "", fmt.Errorf("jwt_secret_not_found")
-	}
-
-	token, err := (jwt.NewWithClaims(jwt.SigningMethodHS256, data)).SignedString([]byte(secret))
+	token, err := s.signToken(SigningKeyPurposeLocal, data, s.GetJWTSecret)
 	if err != nil {
 		return "", fmt.Errorf("jwt_signing_failed")
 	}
@@ -232,6 +229,46 @@ func (s *Service) recordFailedLogin(username string) {
 	}
 }
 
+// VerifyUserPassword re-checks an already signed-in user's password, for
+// actions that ask for a fresh re-authentication. PAM sessions are checked
+// against PAM; every other session against the stored Sylve password. It
+// shares the login rate limit.
+func (s *Service) VerifyUserPassword(userID uint, authType, password string) error {
+	var user models.User
+	if err := s.DB.First(&user, userID).Error; err != nil {
+		return fmt.Errorf("invalid_credentials")
+	}
+
+	s.loginMu.Lock()
+	attempt, exists := s.loginAttempts[user.Username]
+	if exists && time.Now().Before(attempt.blockedUntil) {
+		s.loginMu.Unlock()
+		return fmt.Errorf("too_many_attempts: try again in %s", time.Until(attempt.blockedUntil).Round(time.Second))
+	}
+	s.loginMu.Unlock()
+
+	if authType == "pam" {
+		if !config.IsPAMEnabled() {
+			return fmt.Errorf("pam_auth_disabled")
+		}
+
+		valid, err := s.AuthenticatePAM(user.Username, password)
+		if err != nil || !valid {
+			s.recordFailedLogin(user.Username)
+			return fmt.Errorf("invalid_credentials")
+		}
+	} else if user.Password == "" || !utils.CheckPasswordHash(password, user.Password) {
+		s.recordFailedLogin(user.Username)
+		return fmt.Errorf("invalid_credentials")
+	}
+
+	s.loginMu.Lock()
+	delete(s.loginAttempts, user.Username)
+	s.loginMu.Unlock()
+
+	return nil
+}
+
 func (s *Service) createClusterJWTWithUse(
 	userId uint,
 	username string,
@@ -240,18 +277,6 @@ func (s *Service) createClusterJWTWithUse(
 	forceSecret string,
 	ttl time.Duration,
 ) (string, error) {
-	var clusterKey string
-
-	if forceSecret != "" {
-		clusterKey = forceSecret
-	} else {
-		var err error
-
-		clusterKey, err = s.GetClusterKey()
-		if err != nil {
-			return "", fmt.Errorf("failed_to_get_cluster_key: %w", err)
-		}
-	}
 	tokenUse = strings.TrimSpace(strings.ToLower(tokenUse))
 	if tokenUse == "" {
 		tokenUse = ClusterTokenUseUserProxy
@@ -278,7 +303,23 @@ func (s *Service) createClusterJWTWithUse(
 		},
 	}
 
-	token, err := (jwt.NewWithClaims(jwt.SigningMethodHS256, data)).SignedString([]byte(clusterKey))
+	// A forced secret is used while joining, before the peer can know this
+	// node's published keys, so it always signs with the shared key.
+	if forceSecret != "" {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, data).SignedString([]byte(forceSecret))
+		if err != nil {
+			return "", fmt.Errorf("failed_to_sign_jwt: %w", err)
+		}
+		return token, nil
+	}
+
+	token, err := s.signToken(SigningKeyPurposeCluster, data, func() (string, error) {
+		clusterKey, err := s.GetClusterKey()
+		if err != nil {
+			return "", fmt.Errorf("failed_to_get_cluster_key: %w", err)
+		}
+		return clusterKey, nil
+	})
 	if err != nil {
 		return "", fmt.Errorf("failed_to_sign_jwt: %w", err)
 	}
@@ -321,11 +362,6 @@ func (s *Service) CreateScopedJWT(userID uint, username, authType, scope string,
 		expiresInSeconds = 120
 	}
 
-	secret, err := s.GetJWTSecret()
-	if err != nil {
-		return "", fmt.Errorf("jwt_secret_not_found")
-	}
-
 	data := ScopedJWT{
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(expiresInSeconds) * time.Second)),
@@ -339,7 +375,7 @@ func (s *Service) CreateScopedJWT(userID uint, username, authType, scope string,
 		},
 	}
 
-	token, err := (jwt.NewWithClaims(jwt.SigningMethodHS256, data)).SignedString([]byte(secret))
+	token, err := s.signToken(SigningKeyPurposeLocal, data, s.GetJWTSecret)
 	if err != nil {
 		return "", fmt.Errorf("jwt_signing_failed")
 	}
@@ -354,7 +390,7 @@ func (s *Service) VerifyClusterJWT(tokenString string) (serviceInterfaces.Custom
 	}
 
 	token, err := jwt.ParseWithClaims(tokenString, &JWT{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(clusterKey), nil
+		return s.clusterVerificationKey(token, clusterKey)
 	})
 
 	if err != nil {
@@ -431,15 +467,7 @@ func (s *Service) VerifyTokenInDb(token string) bool {
 }
 
 func (s *Service) ValidateToken(tokenString string) (serviceInterfaces.CustomClaims, error) {
-	secret, err := s.GetJWTSecret()
-
-	if err != nil {
-		return serviceInterfaces.CustomClaims{}, fmt.Errorf("jwt_secret_not_found")
-	}
-
-	token, err := jwt.ParseWithClaims(tokenString, &JWT{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(secret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &JWT{}, s.localVerificationKey)
 
 	if err != nil {
 		return serviceInterfaces.CustomClaims{}, fmt.Errorf("jwt_invalid")
@@ -467,14 +495,7 @@ func (s *Service) ValidateScopedJWT(tokenString, expectedScope string) (serviceI
 		return serviceInterfaces.CustomClaims{}, fmt.Errorf("scope_required")
 	}
 
-	secret, err := s.GetJWTSecret()
-	if err != nil {
-		return serviceInterfaces.CustomClaims{}, fmt.Errorf("jwt_secret_not_found")
-	}
-
-	token, err := jwt.ParseWithClaims(tokenString, &ScopedJWT{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(secret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &ScopedJWT{}, s.localVerificationKey)
 	if err != nil {
 		return serviceInterfaces.CustomClaims{}, fmt.Errorf("jwt_invalid")
 	}
@@ -509,7 +530,7 @@ func (s *Service) InitSecret(name string, shaRounds int) error {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			newSecret := models.SystemSecrets{
 				Name: name,
-				Data: secret,
+				Data: secrets.String(secret),
 			}
 			if err := s.DB.Create(&newSecret).Error; err != nil {
 				return fmt.Errorf("failed to create %s: %w", name, err)
@@ -519,8 +540,8 @@ func (s *Service) InitSecret(name string, shaRounds int) error {
 			return fmt.Errorf("error fetching %s: %w", name, err)
 		}
 	} else {
-		if systemSecret.Data != secret {
-			if err := s.DB.Model(&systemSecret).Update("data", secret).Error; err != nil {
+		if string(systemSecret.Data) != secret {
+			if err := s.DB.Model(&systemSecret).Update("data", secrets.String(secret)).Error; err != nil {
 				return fmt.Errorf("failed to update %s: %w", name, err)
 			}
 			logger.L.Debug().Msgf("Updated existing %s", name)
@@ -547,7 +568,7 @@ func (s *Service) GetSecret(name string) (string, error) {
 		return "", fmt.Errorf("failed_to_get_secret")
 	}
 
-	return secret.Data, nil
+	return string(secret.Data), nil
 }
 
 func (s *Service) UpsertSecret(name string, data string) error {
@@ -559,7 +580,7 @@ func (s *Service) UpsertSecret(name string, data string) error {
 	if count == 0 {
 		newSecret := models.SystemSecrets{
 			Name: name,
-			Data: data,
+			Data: secrets.String(data),
 		}
 		if err := s.DB.Create(&newSecret).Error; err != nil {
 			return fmt.Errorf("failed_to_create")
@@ -573,8 +594,8 @@ func (s *Service) UpsertSecret(name string, data string) error {
 		return fmt.Errorf("failed_to_fetch")
 	}
 
-	if secret.Data != data {
-		if err := s.DB.Model(&secret).Update("data", data).Error; err != nil {
+	if string(secret.Data) != data {
+		if err := s.DB.Model(&secret).Update("data", secrets.String(data)).Error; err != nil {
 			return fmt.Errorf("failed_to_update")
 		}
 	} else {
@@ -644,10 +665,23 @@ func (s *Service) GetTokenBySHA256(hash string) (string, error) {
 	return "", fmt.Errorf("token_not_found")
 }
 
+// IsValidClusterKey compares in Go rather than SQL, since the stored key is
+// sealed with a random nonce and never equals the plaintext.
 func (s *Service) IsValidClusterKey(clusterKey string) bool {
-	var count int64
-	s.DB.Model(&clusterModels.Cluster{}).Where("key = ?", clusterKey).Count(&count)
-	return count > 0
+	if clusterKey == "" {
+		return false
+	}
+
+	var clusters []clusterModels.Cluster
+	if err := s.DB.Select("id", "key").Find(&clusters).Error; err != nil {
+		return false
+	}
+	for _, c := range clusters {
+		if subtle.ConstantTimeCompare([]byte(c.Key), []byte(clusterKey)) == 1 {
+			return true
+		}
+	}
+	return false
 }
 
 func (s *Service) GetBasicSettings() (models.BasicSettings, error) {
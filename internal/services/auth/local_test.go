@@ -27,10 +27,12 @@ func newLocalTestService(t *testing.T) *Service {
 		&models.Group{},
 		&models.Token{},
 		&models.SystemSecrets{},
+		&models.JWTSigningKey{},
 		&models.BasicSettings{},
 		&models.WebAuthnCredential{},
 		&models.WebAuthnChallenge{},
 		&models.PAMIdentity{},
+		&models.LoginNotices{},
 	)
 
 	// Prevent real system command execution during tests.
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/db/models"
+	"gorm.io/gorm"
+)
+
+const loginNoticeMaxLen = 8192
+
+// LoginHistory is what a user is told about their account when they log in:
+// the previous successful login, and any failures since.
+type LoginHistory struct {
+	LastSuccessAt *time.Time `json:"lastSuccessAt"`
+	LastSuccessIP string     `json:"lastSuccessIp"`
+	LastFailureAt *time.Time `json:"lastFailureAt"`
+	LastFailureIP string     `json:"lastFailureIp"`
+	FailedCount   int        `json:"failedCount"`
+}
+
+func (s *Service) GetLoginNotices() (models.LoginNotices, error) {
+	var notices models.LoginNotices
+	err := s.DB.Order("id ASC").First(&notices).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return notices, fmt.Errorf("failed_to_get_login_notices: %w", err)
+	}
+
+	return notices, nil
+}
+
+func (s *Service) UpdateLoginNotices(banner, motd string) (models.LoginNotices, error) {
+	banner = strings.TrimSpace(banner)
+	motd = strings.TrimSpace(motd)
+	if len(banner) > loginNoticeMaxLen || len(motd) > loginNoticeMaxLen {
+		return models.LoginNotices{}, fmt.Errorf("login_notice_too_long")
+	}
+
+	notices, err := s.GetLoginNotices()
+	if err != nil {
+		return notices, err
+	}
+
+	notices.Banner = banner
+	notices.MOTD = motd
+	if err := s.DB.Save(&notices).Error; err != nil {
+		return notices, fmt.Errorf("failed_to_save_login_notices: %w", err)
+	}
+
+	return notices, nil
+}
+
+// RecordLoginFailure notes a failed login against username. Attempts on
+// names Sylve does not know leave no trace here; the rate limiter still
+// sees them.
+func (s *Service) RecordLoginFailure(username, clientIP string) error {
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return nil
+	}
+
+	if err := s.DB.
+		Model(&models.User{}).
+		Where("username = ?", username).
+		Updates(map[string]any{
+			"last_failed_login_at": time.Now(),
+			"last_failed_login_ip": strings.TrimSpace(clientIP),
+			"failed_login_count":   gorm.Expr("failed_login_count + 1"),
+		}).Error; err != nil {
+		return fmt.Errorf("failed_to_record_login_failure: %w", err)
+	}
+
+	return nil
+}
+
+// RecordLoginSuccess stamps a successful login on userID and returns the
+// history as it stood before, which is what the user should be shown.
+func (s *Service) RecordLoginSuccess(userID uint, clientIP string) (LoginHistory, error) {
+	var user models.User
+	if err := s.DB.First(&user, userID).Error; err != nil {
+		return LoginHistory{}, fmt.Errorf("user_not_found: %w", err)
+	}
+
+	history := LoginHistory{
+		LastSuccessAt: user.LastSuccessfulLoginAt,
+		LastSuccessIP: user.LastSuccessfulLoginIP,
+		LastFailureAt: user.LastFailedLoginAt,
+		LastFailureIP: user.LastFailedLoginIP,
+		FailedCount:   user.FailedLoginCount,
+	}
+
+	if err := s.DB.
+		Model(&models.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]any{
+			"last_successful_login_at": time.Now(),
+			"last_successful_login_ip": strings.TrimSpace(clientIP),
+			"failed_login_count":       0,
+		}).Error; err != nil {
+		return history, fmt.Errorf("failed_to_record_login_success: %w", err)
+	}
+
+	return history, nil
+}
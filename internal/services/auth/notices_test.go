@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alchemillahq/sylve/internal/db/models"
+)
+
+func TestLoginNoticesRoundTrip(t *testing.T) {
+	svc := newLocalTestService(t)
+
+	notices, err := svc.GetLoginNotices()
+	if err != nil {
+		t.Fatalf("failed to get empty notices: %v", err)
+	}
+	if notices.Banner != "" || notices.MOTD != "" {
+		t.Fatalf("expected empty notices, got %#v", notices)
+	}
+
+	if _, err := svc.UpdateLoginNotices("  Authorized use only  ", "Maintenance on Friday"); err != nil {
+		t.Fatalf("failed to update notices: %v", err)
+	}
+	if _, err := svc.UpdateLoginNotices("Authorized use only", ""); err != nil {
+		t.Fatalf("failed to update notices again: %v", err)
+	}
+
+	var count int64
+	svc.DB.Model(&models.LoginNotices{}).Count(&count)
+	if count != 1 {
+		t.Fatalf("expected a single notices row, got %d", count)
+	}
+
+	notices, err = svc.GetLoginNotices()
+	if err != nil {
+		t.Fatalf("failed to get notices: %v", err)
+	}
+	if notices.Banner != "Authorized use only" || notices.MOTD != "" {
+		t.Fatalf("unexpected notices: %#v", notices)
+	}
+
+	if _, err := svc.UpdateLoginNotices(strings.Repeat("x", loginNoticeMaxLen+1), ""); err == nil {
+		t.Fatalf("expected oversized banner to be rejected")
+	}
+}
+
+func TestLoginHistoryReportsPreviousLogin(t *testing.T) {
+	svc := newLocalTestService(t)
+
+	user := models.User{Username: "alice", Admin: true}
+	if err := svc.DB.Create(&user).Error; err != nil {
+		t.Fatalf("failed to seed user: %v", err)
+	}
+
+	history, err := svc.RecordLoginSuccess(user.ID, "192.0.2.1")
+	if err != nil {
+		t.Fatalf("failed to record first login: %v", err)
+	}
+	if history.LastSuccessAt != nil || history.FailedCount != 0 {
+		t.Fatalf("expected empty history on first login, got %#v", history)
+	}
+
+	for range 2 {
+		if err := svc.RecordLoginFailure("alice", "198.51.100.7"); err != nil {
+			t.Fatalf("failed to record failure: %v", err)
+		}
+	}
+	if err := svc.RecordLoginFailure("nobody", "198.51.100.7"); err != nil {
+		t.Fatalf("unknown users should be ignored, got %v", err)
+	}
+
+	history, err = svc.RecordLoginSuccess(user.ID, "192.0.2.2")
+	if err != nil {
+		t.Fatalf("failed to record second login: %v", err)
+	}
+	if history.LastSuccessAt == nil || history.LastSuccessIP != "192.0.2.1" {
+		t.Fatalf("expected previous success from 192.0.2.1, got %#v", history)
+	}
+	if history.LastFailureAt == nil || history.LastFailureIP != "198.51.100.7" || history.FailedCount != 2 {
+		t.Fatalf("expected two failures from 198.51.100.7, got %#v", history)
+	}
+
+	history, err = svc.RecordLoginSuccess(user.ID, "192.0.2.3")
+	if err != nil {
+		t.Fatalf("failed to record third login: %v", err)
+	}
+	if history.LastSuccessIP != "192.0.2.2" || history.FailedCount != 0 {
+		t.Fatalf("expected failure count reset after success, got %#v", history)
+	}
+	if history.LastFailureIP != "198.51.100.7" {
+		t.Fatalf("expected last failure to be kept, got %#v", history)
+	}
+}
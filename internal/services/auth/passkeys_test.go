@@ -27,6 +27,7 @@ func newPasskeyTestService(t *testing.T) *Service {
 		&models.User{},
 		&models.Token{},
 		&models.SystemSecrets{},
+		&models.JWTSigningKey{},
 		&models.WebAuthnCredential{},
 		&models.WebAuthnChallenge{},
 	)
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package auth
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/db/models"
+)
+
+const (
+	sessionLastSeenInterval = 30 * time.Second
+	sessionUserAgentMaxLen  = 512
+)
+
+// Session is a token record as shown to its owner. The token itself is never
+// returned; sessions are addressed by their record ID.
+type Session struct {
+	ID         uint       `json:"id"`
+	AuthType   string     `json:"authType"`
+	ClientIP   string     `json:"clientIp"`
+	UserAgent  string     `json:"userAgent"`
+	IssuedAt   time.Time  `json:"issuedAt"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	LastSeenAt *time.Time `json:"lastSeenAt"`
+	LastSeenIP string     `json:"lastSeenIp"`
+	Current    bool       `json:"current"`
+}
+
+// RecordSessionClient stores where a freshly issued token was requested from.
+func (s *Service) RecordSessionClient(token, clientIP, userAgent string) error {
+	userAgent = strings.TrimSpace(userAgent)
+	if len(userAgent) > sessionUserAgentMaxLen {
+		userAgent = userAgent[:sessionUserAgentMaxLen]
+	}
+
+	now := time.Now()
+	if err := s.DB.
+		Model(&models.Token{}).
+		Where("token = ?", token).
+		Updates(map[string]any{
+			"client_ip":    strings.TrimSpace(clientIP),
+			"user_agent":   userAgent,
+			"last_seen_at": now,
+			"last_seen_ip": strings.TrimSpace(clientIP),
+		}).Error; err != nil {
+		return fmt.Errorf("failed_to_record_session_client: %w", err)
+	}
+
+	return nil
+}
+
+// TouchSession refreshes the last seen time and address of a token. Like
+// UpdateLastUsageTime it skips the write when the previous one is recent.
+func (s *Service) TouchSession(token, clientIP string) error {
+	now := time.Now()
+	if err := s.DB.
+		Model(&models.Token{}).
+		Where("token = ? AND (last_seen_at IS NULL OR last_seen_at < ?)", token, now.Add(-sessionLastSeenInterval)).
+		Updates(map[string]any{
+			"last_seen_at": now,
+			"last_seen_ip": strings.TrimSpace(clientIP),
+		}).Error; err != nil {
+		return fmt.Errorf("failed_to_touch_session: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) ListSessions(userID uint, currentToken string) ([]Session, error) {
+	var tokens []models.Token
+	if err := s.DB.
+		Where("user_id = ? AND expiry > ?", userID, time.Now()).
+		Order("created_at DESC").
+		Find(&tokens).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_list_sessions: %w", err)
+	}
+
+	sessions := make([]Session, 0, len(tokens))
+	for _, token := range tokens {
+		sessions = append(sessions, Session{
+			ID:         token.ID,
+			AuthType:   token.AuthType,
+			ClientIP:   token.ClientIP,
+			UserAgent:  token.UserAgent,
+			IssuedAt:   token.CreatedAt,
+			ExpiresAt:  token.Expiry,
+			LastSeenAt: token.LastSeenAt,
+			LastSeenIP: token.LastSeenIP,
+			Current:    currentToken != "" && token.Token == currentToken,
+		})
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession deletes one of userID's tokens. Sessions belonging to other
+// users are reported as not found.
+func (s *Service) RevokeSession(userID, sessionID uint) error {
+	result := s.DB.
+		Where("id = ? AND user_id = ?", sessionID, userID).
+		Delete(&models.Token{})
+	if result.Error != nil {
+		return fmt.Errorf("session_delete_failed: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("session_not_found")
+	}
+
+	return nil
+}
+
+// RevokeAllSessions logs userID out everywhere. When keepToken is set, that
+// token (normally the caller's own) survives.
+func (s *Service) RevokeAllSessions(userID uint, keepToken string) (int64, error) {
+	query := s.DB.Where("user_id = ?", userID)
+	if keepToken != "" {
+		query = query.Where("token <> ?", keepToken)
+	}
+
+	result := query.Delete(&models.Token{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("session_delete_failed: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/db/models"
+)
+
+func seedSessionToken(t *testing.T, svc *Service, userID uint, token string, expiry time.Time) models.Token {
+	t.Helper()
+	record := models.Token{UserID: userID, Token: token, AuthType: "sylve", Expiry: expiry}
+	if err := svc.DB.Create(&record).Error; err != nil {
+		t.Fatalf("failed to seed token: %v", err)
+	}
+	return record
+}
+
+func TestListSessionsHidesTokensAndExpiredRecords(t *testing.T) {
+	svc := newLocalTestService(t)
+	future := time.Now().Add(time.Hour)
+
+	current := seedSessionToken(t, svc, 1, "tok-current", future)
+	seedSessionToken(t, svc, 1, "tok-other", future)
+	seedSessionToken(t, svc, 1, "tok-expired", time.Now().Add(-time.Hour))
+	seedSessionToken(t, svc, 2, "tok-foreign", future)
+
+	if err := svc.RecordSessionClient("tok-current", "192.0.2.10", "test-agent"); err != nil {
+		t.Fatalf("failed to record session client: %v", err)
+	}
+
+	sessions, err := svc.ListSessions(1, "tok-current")
+	if err != nil {
+		t.Fatalf("failed to list sessions: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("expected two active sessions, got %#v", sessions)
+	}
+
+	found := false
+	for _, session := range sessions {
+		if session.ID != current.ID {
+			if session.Current {
+				t.Fatalf("unexpected current session: %#v", session)
+			}
+			continue
+		}
+		found = true
+		if !session.Current || session.ClientIP != "192.0.2.10" || session.UserAgent != "test-agent" || session.LastSeenAt == nil {
+			t.Fatalf("unexpected current session: %#v", session)
+		}
+	}
+	if !found {
+		t.Fatal("expected the current session to be listed")
+	}
+}
+
+func TestRevokeSessionRequiresOwnership(t *testing.T) {
+	svc := newLocalTestService(t)
+	foreign := seedSessionToken(t, svc, 2, "tok-foreign", time.Now().Add(time.Hour))
+
+	if err := svc.RevokeSession(1, foreign.ID); err == nil || err.Error() != "session_not_found" {
+		t.Fatalf("expected session_not_found, got %v", err)
+	}
+	if err := svc.RevokeSession(2, foreign.ID); err != nil {
+		t.Fatalf("expected owner to revoke session, got %v", err)
+	}
+	if svc.VerifyTokenInDb("tok-foreign") {
+		t.Fatal("expected revoked token to be gone")
+	}
+}
+
+func TestRevokeAllSessionsCanKeepCurrent(t *testing.T) {
+	svc := newLocalTestService(t)
+	future := time.Now().Add(time.Hour)
+	seedSessionToken(t, svc, 1, "tok-a", future)
+	seedSessionToken(t, svc, 1, "tok-b", future)
+	seedSessionToken(t, svc, 1, "tok-c", future)
+	seedSessionToken(t, svc, 2, "tok-foreign", future)
+
+	revoked, err := svc.RevokeAllSessions(1, "tok-a")
+	if err != nil || revoked != 2 {
+		t.Fatalf("expected two sessions revoked, got %d (%v)", revoked, err)
+	}
+
+	revoked, err = svc.RevokeAllSessions(1, "")
+	if err != nil || revoked != 1 {
+		t.Fatalf("expected the kept session to be revoked, got %d (%v)", revoked, err)
+	}
+
+	var remaining int64
+	if err := svc.DB.Model(&models.Token{}).Count(&remaining).Error; err != nil {
+		t.Fatalf("failed to count tokens: %v", err)
+	}
+	if remaining != 1 {
+		t.Fatalf("expected only the other user's token to remain, got %d", remaining)
+	}
+}
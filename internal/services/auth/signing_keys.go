@@ -0,0 +1,397 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/db/models"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
+	"github.com/alchemillahq/sylve/internal/logger"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Local keys sign the sessions and scoped tokens this node hands out;
+// cluster keys sign tokens for peers. Until a purpose is rotated for the
+// first time its tokens stay on the legacy shared secrets (the JWTSecret
+// and the cluster key) and carry no kid.
+const (
+	SigningKeyPurposeLocal   = "local"
+	SigningKeyPurposeCluster = "cluster"
+
+	SigningAlgorithmHS256 = "HS256"
+	SigningAlgorithmES256 = "ES256"
+
+	// A retired key keeps verifying for as long as the longest-lived token
+	// it can have signed, a remembered session.
+	signingKeyGrace = 7 * 24 * time.Hour
+
+	legacyLocalSigningKeyID = "legacy-local"
+)
+
+type RotateSigningKeyRequest struct {
+	Purpose   string `json:"purpose" binding:"required"`
+	Algorithm string `json:"algorithm" binding:"required"`
+}
+
+func generateSigningKey(purpose, algorithm string) (models.JWTSigningKey, error) {
+	key := models.JWTSigningKey{
+		KID:       uuid.NewString(),
+		Purpose:   purpose,
+		Algorithm: algorithm,
+		Active:    true,
+	}
+
+	switch algorithm {
+	case SigningAlgorithmHS256:
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return key, fmt.Errorf("signing_key_generation_failed: %w", err)
+		}
+		key.PrivateKey = secrets.String(base64.StdEncoding.EncodeToString(secret))
+	case SigningAlgorithmES256:
+		private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return key, fmt.Errorf("signing_key_generation_failed: %w", err)
+		}
+		privateDER, err := x509.MarshalECPrivateKey(private)
+		if err != nil {
+			return key, fmt.Errorf("signing_key_generation_failed: %w", err)
+		}
+		publicDER, err := x509.MarshalPKIXPublicKey(&private.PublicKey)
+		if err != nil {
+			return key, fmt.Errorf("signing_key_generation_failed: %w", err)
+		}
+		key.PrivateKey = secrets.String(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privateDER}))
+		key.PublicKey = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	default:
+		return key, fmt.Errorf("invalid_signing_algorithm: %s", algorithm)
+	}
+
+	return key, nil
+}
+
+// RotateSigningKey makes a fresh key the one new tokens of purpose are
+// signed with. The previous key, or the legacy secret on the first
+// rotation, keeps verifying for the grace period. Cluster keys must be
+// ES256: rotating a shared HMAC secret would need every peer to follow.
+func (s *Service) RotateSigningKey(purpose, algorithm string) (*models.JWTSigningKey, error) {
+	purpose = strings.TrimSpace(strings.ToLower(purpose))
+	algorithm = strings.TrimSpace(strings.ToUpper(algorithm))
+
+	switch purpose {
+	case SigningKeyPurposeLocal:
+	case SigningKeyPurposeCluster:
+		if algorithm != SigningAlgorithmES256 {
+			return nil, fmt.Errorf("cluster_signing_key_must_be_es256")
+		}
+	default:
+		return nil, fmt.Errorf("invalid_signing_key_purpose: %s", purpose)
+	}
+
+	next, err := generateSigningKey(purpose, algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	retiresAt := now.Add(signingKeyGrace)
+
+	err = s.DB.Transaction(func(tx *gorm.DB) error {
+		// The legacy row stays behind once retired: it is what tells a
+		// kid-less token apart from one issued before any rotation.
+		if err := tx.Where("active = ? AND retires_at < ? AND kid <> ?", false, now, legacyLocalSigningKeyID).
+			Delete(&models.JWTSigningKey{}).Error; err != nil {
+			return err
+		}
+
+		var active int64
+		if err := tx.Model(&models.JWTSigningKey{}).
+			Where("purpose = ? AND active = ?", purpose, true).
+			Count(&active).Error; err != nil {
+			return err
+		}
+
+		if active == 0 && purpose == SigningKeyPurposeLocal {
+			var legacy int64
+			if err := tx.Model(&models.JWTSigningKey{}).
+				Where("kid = ?", legacyLocalSigningKeyID).
+				Count(&legacy).Error; err != nil {
+				return err
+			}
+			if legacy == 0 {
+				secret, err := s.GetJWTSecret()
+				if err != nil {
+					return err
+				}
+				if err := tx.Create(&models.JWTSigningKey{
+					KID:        legacyLocalSigningKeyID,
+					Purpose:    purpose,
+					Algorithm:  SigningAlgorithmHS256,
+					PrivateKey: secrets.String(secret),
+					RetiresAt:  &retiresAt,
+				}).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := tx.Model(&models.JWTSigningKey{}).
+			Where("purpose = ? AND active = ?", purpose, true).
+			Updates(map[string]any{"active": false, "retires_at": retiresAt}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&next).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("signing_key_rotation_failed: %w", err)
+	}
+
+	logger.L.Info().
+		Str("purpose", purpose).
+		Str("algorithm", algorithm).
+		Str("kid", next.KID).
+		Msg("jwt_signing_key_rotated")
+
+	return &next, nil
+}
+
+func (s *Service) ListSigningKeys() ([]models.JWTSigningKey, error) {
+	var keys []models.JWTSigningKey
+	if err := s.DB.Order("purpose ASC, created_at DESC").Find(&keys).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_list_signing_keys: %w", err)
+	}
+	return keys, nil
+}
+
+// ClusterTokenKeys returns the public halves of this node's cluster keys
+// that can still verify a token, for publishing to peers.
+func (s *Service) ClusterTokenKeys() ([]clusterModels.ClusterTokenKey, error) {
+	var keys []models.JWTSigningKey
+	if err := s.DB.Where("purpose = ? AND algorithm = ?", SigningKeyPurposeCluster, SigningAlgorithmES256).
+		Where("active = ? OR retires_at > ?", true, time.Now()).
+		Order("created_at DESC").
+		Find(&keys).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_list_cluster_token_keys: %w", err)
+	}
+
+	out := make([]clusterModels.ClusterTokenKey, 0, len(keys))
+	for _, key := range keys {
+		out = append(out, clusterModels.ClusterTokenKey{
+			KID:       key.KID,
+			PublicKey: key.PublicKey,
+			ExpiresAt: key.RetiresAt,
+		})
+	}
+	return out, nil
+}
+
+func (s *Service) activeSigningKey(purpose string) (*models.JWTSigningKey, error) {
+	var key models.JWTSigningKey
+	result := s.DB.Where("purpose = ? AND active = ?", purpose, true).Limit(1).Find(&key)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &key, nil
+}
+
+// signToken signs claims with the active key for purpose, or with the
+// legacy HMAC secret when the purpose has never been rotated.
+func (s *Service) signToken(purpose string, claims jwt.Claims, legacySecret func() (string, error)) (string, error) {
+	key, err := s.activeSigningKey(purpose)
+	if err != nil {
+		return "", err
+	}
+
+	if key == nil {
+		secret, err := legacySecret()
+		if err != nil {
+			return "", err
+		}
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	}
+
+	material, err := signingKeyMaterial(key, true)
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(key.Algorithm), claims)
+	token.Header["kid"] = key.KID
+	return token.SignedString(material)
+}
+
+// signingKeyMaterial decodes the key for signing (private) or verifying.
+func signingKeyMaterial(key *models.JWTSigningKey, private bool) (any, error) {
+	switch key.Algorithm {
+	case SigningAlgorithmHS256:
+		if key.KID == legacyLocalSigningKeyID {
+			return []byte(key.PrivateKey), nil
+		}
+		return base64.StdEncoding.DecodeString(string(key.PrivateKey))
+	case SigningAlgorithmES256:
+		if private {
+			return jwt.ParseECPrivateKeyFromPEM([]byte(key.PrivateKey))
+		}
+		return jwt.ParseECPublicKeyFromPEM([]byte(key.PublicKey))
+	}
+	return nil, fmt.Errorf("invalid_signing_algorithm: %s", key.Algorithm)
+}
+
+func tokenKeyID(token *jwt.Token) string {
+	kid, _ := token.Header["kid"].(string)
+	return strings.TrimSpace(kid)
+}
+
+// requireTokenAlgorithm rejects a token whose alg header does not match the
+// key it names, so an HMAC token can never be checked against a public key.
+func requireTokenAlgorithm(token *jwt.Token, algorithm string) error {
+	if token.Method == nil || token.Method.Alg() != algorithm {
+		return fmt.Errorf("jwt_algorithm_mismatch")
+	}
+	return nil
+}
+
+// localVerificationKey resolves the key a locally issued token was signed
+// with. Tokens without a kid predate the keyring and are checked against
+// the legacy secret, which stops working once its grace period ends.
+func (s *Service) localVerificationKey(token *jwt.Token) (any, error) {
+	kid := tokenKeyID(token)
+	if kid == "" {
+		kid = legacyLocalSigningKeyID
+	}
+
+	key, err := s.verifiableSigningKey(SigningKeyPurposeLocal, kid)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		if kid != legacyLocalSigningKeyID {
+			return nil, fmt.Errorf("jwt_unknown_kid")
+		}
+		if err := requireTokenAlgorithm(token, SigningAlgorithmHS256); err != nil {
+			return nil, err
+		}
+		secret, err := s.GetJWTSecret()
+		if err != nil {
+			return nil, err
+		}
+		return []byte(secret), nil
+	}
+
+	if err := requireTokenAlgorithm(token, key.Algorithm); err != nil {
+		return nil, err
+	}
+	return signingKeyMaterial(key, false)
+}
+
+// clusterVerificationKey resolves the key a cluster token was signed with:
+// the shared cluster key for tokens without a kid, otherwise an ES256 key of
+// this node or one published by a peer. The shared key stops verifying once
+// this node has had an ES256 cluster key for the grace period, so a leaked
+// cluster key does not mint valid tokens forever.
+func (s *Service) clusterVerificationKey(token *jwt.Token, clusterKey string) (any, error) {
+	kid := tokenKeyID(token)
+	if kid == "" {
+		if err := requireTokenAlgorithm(token, SigningAlgorithmHS256); err != nil {
+			return nil, err
+		}
+		retired, err := s.sharedClusterKeyRetired()
+		if err != nil {
+			return nil, err
+		}
+		if retired {
+			return nil, fmt.Errorf("jwt_shared_cluster_key_retired")
+		}
+		return []byte(clusterKey), nil
+	}
+
+	if err := requireTokenAlgorithm(token, SigningAlgorithmES256); err != nil {
+		return nil, err
+	}
+
+	key, err := s.verifiableSigningKey(SigningKeyPurposeCluster, kid)
+	if err != nil {
+		return nil, err
+	}
+	if key != nil {
+		return signingKeyMaterial(key, false)
+	}
+
+	var identities []clusterModels.ClusterSSHIdentity
+	if err := s.DB.Find(&identities).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_list_cluster_token_keys: %w", err)
+	}
+	now := time.Now()
+	for _, identity := range identities {
+		for _, published := range identity.TokenKeys {
+			if published.KID != kid {
+				continue
+			}
+			if published.ExpiresAt != nil && now.After(*published.ExpiresAt) {
+				return nil, fmt.Errorf("jwt_key_retired")
+			}
+			return jwt.ParseECPublicKeyFromPEM([]byte(published.PublicKey))
+		}
+	}
+
+	return nil, fmt.Errorf("jwt_unknown_kid")
+}
+
+// sharedClusterKeyRetired reports whether kid-less cluster tokens are past
+// their grace period: the first ES256 cluster key was created longer than
+// signingKeyGrace ago. Peers have had that long to publish their own keys.
+func (s *Service) sharedClusterKeyRetired() (bool, error) {
+	var first models.JWTSigningKey
+	result := s.DB.Where("purpose = ? AND algorithm = ?", SigningKeyPurposeCluster, SigningAlgorithmES256).
+		Order("created_at ASC").
+		Limit(1).
+		Find(&first)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed_to_list_cluster_signing_keys: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	return time.Now().After(first.CreatedAt.Add(signingKeyGrace)), nil
+}
+
+// verifiableSigningKey returns the key with kid while it may still verify
+// tokens, nil when no such key exists.
+func (s *Service) verifiableSigningKey(purpose, kid string) (*models.JWTSigningKey, error) {
+	var key models.JWTSigningKey
+	err := s.DB.Where("purpose = ? AND kid = ?", purpose, kid).First(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !key.Active && key.RetiresAt != nil && time.Now().After(*key.RetiresAt) {
+		return nil, fmt.Errorf("jwt_key_retired")
+	}
+	return &key, nil
+}
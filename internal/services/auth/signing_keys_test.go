@@ -0,0 +1,234 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/db/models"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/testutil"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func newSigningKeyTestService(t *testing.T) *Service {
+	t.Helper()
+
+	db := testutil.NewSQLiteTestDB(
+		t,
+		&models.SystemSecrets{},
+		&models.JWTSigningKey{},
+		&clusterModels.Cluster{},
+		&clusterModels.ClusterSSHIdentity{},
+	)
+	if err := db.Create(&models.SystemSecrets{Name: "JWTSecret", Data: "legacy-secret"}).Error; err != nil {
+		t.Fatalf("failed to seed jwt secret: %v", err)
+	}
+	if err := db.Create(&clusterModels.Cluster{Enabled: true, Key: "cluster-key"}).Error; err != nil {
+		t.Fatalf("failed to seed cluster: %v", err)
+	}
+
+	return &Service{DB: db}
+}
+
+func tokenHeader(t *testing.T, token string) map[string]any {
+	t.Helper()
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &ScopedJWT{})
+	if err != nil {
+		t.Fatalf("failed to parse token: %v", err)
+	}
+	return parsed.Header
+}
+
+func TestRotateLocalSigningKeyKeepsOldTokensDuringGrace(t *testing.T) {
+	svc := newSigningKeyTestService(t)
+
+	legacy, err := svc.CreateScopedJWT(1, "admin", "sylve", "sse", 60)
+	if err != nil {
+		t.Fatalf("failed to sign legacy token: %v", err)
+	}
+	if _, ok := tokenHeader(t, legacy)["kid"]; ok {
+		t.Fatal("tokens signed before any rotation must not carry a kid")
+	}
+
+	key, err := svc.RotateSigningKey("local", "hs256")
+	if err != nil {
+		t.Fatalf("failed to rotate: %v", err)
+	}
+
+	rotated, err := svc.CreateScopedJWT(1, "admin", "sylve", "sse", 60)
+	if err != nil {
+		t.Fatalf("failed to sign rotated token: %v", err)
+	}
+	header := tokenHeader(t, rotated)
+	if header["kid"] != key.KID || header["alg"] != SigningAlgorithmHS256 {
+		t.Fatalf("unexpected header %v", header)
+	}
+
+	for _, token := range []string{legacy, rotated} {
+		if _, err := svc.ValidateScopedJWT(token, "sse"); err != nil {
+			t.Fatalf("expected token to validate during grace: %v", err)
+		}
+	}
+
+	es, err := svc.RotateSigningKey("local", "es256")
+	if err != nil {
+		t.Fatalf("failed to rotate to es256: %v", err)
+	}
+	signed, err := svc.CreateScopedJWT(1, "admin", "sylve", "sse", 60)
+	if err != nil {
+		t.Fatalf("failed to sign es256 token: %v", err)
+	}
+	if header := tokenHeader(t, signed); header["kid"] != es.KID || header["alg"] != SigningAlgorithmES256 {
+		t.Fatalf("unexpected header %v", header)
+	}
+	if _, err := svc.ValidateScopedJWT(rotated, "sse"); err != nil {
+		t.Fatalf("expected the hs256 token to outlive its rotation: %v", err)
+	}
+
+	past := time.Now().Add(-time.Minute)
+	if err := svc.DB.Model(&models.JWTSigningKey{}).Where("active = ?", false).
+		Update("retires_at", past).Error; err != nil {
+		t.Fatalf("failed to expire keys: %v", err)
+	}
+	for _, token := range []string{legacy, rotated} {
+		if _, err := svc.ValidateScopedJWT(token, "sse"); err == nil {
+			t.Fatal("expected tokens of retired keys to be rejected")
+		}
+	}
+	if _, err := svc.ValidateScopedJWT(signed, "sse"); err != nil {
+		t.Fatalf("expected the active key to keep validating: %v", err)
+	}
+
+	if _, err := svc.RotateSigningKey("local", "es256"); err != nil {
+		t.Fatalf("failed to rotate again: %v", err)
+	}
+	if _, err := svc.ValidateScopedJWT(legacy, "sse"); err == nil {
+		t.Fatal("pruning retired keys must not bring the legacy secret back")
+	}
+}
+
+func TestSigningKeyRejectsAlgorithmMismatch(t *testing.T) {
+	svc := newSigningKeyTestService(t)
+
+	key, err := svc.RotateSigningKey("local", "es256")
+	if err != nil {
+		t.Fatalf("failed to rotate: %v", err)
+	}
+
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, ScopedJWT{
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))},
+		Scope:            "sse",
+	})
+	forged.Header["kid"] = key.KID
+	signed, err := forged.SignedString([]byte(key.PublicKey))
+	if err != nil {
+		t.Fatalf("failed to sign forged token: %v", err)
+	}
+	if _, err := svc.ValidateScopedJWT(signed, "sse"); err == nil {
+		t.Fatal("expected an hs256 token naming an es256 key to be rejected")
+	}
+
+	if _, err := svc.RotateSigningKey("cluster", "hs256"); err == nil {
+		t.Fatal("expected cluster keys to require es256")
+	}
+	if _, err := svc.RotateSigningKey("peer", "es256"); err == nil {
+		t.Fatal("expected an unknown purpose to be rejected")
+	}
+}
+
+func TestClusterTokensVerifyAgainstPublishedKeys(t *testing.T) {
+	issuer := newSigningKeyTestService(t)
+	peer := newSigningKeyTestService(t)
+
+	shared, err := issuer.CreateInternalClusterJWT("node-a", "")
+	if err != nil {
+		t.Fatalf("failed to sign shared-key token: %v", err)
+	}
+	if _, err := peer.VerifyClusterJWT(shared); err != nil {
+		t.Fatalf("expected shared-key tokens to keep verifying: %v", err)
+	}
+
+	if _, err := issuer.RotateSigningKey("cluster", "es256"); err != nil {
+		t.Fatalf("failed to rotate cluster key: %v", err)
+	}
+	token, err := issuer.CreateInternalClusterJWT("node-a", "")
+	if err != nil {
+		t.Fatalf("failed to sign cluster token: %v", err)
+	}
+	if tokenHeader(t, token)["alg"] != SigningAlgorithmES256 {
+		t.Fatal("expected cluster tokens to switch to es256")
+	}
+	if _, err := issuer.VerifyClusterJWT(token); err != nil {
+		t.Fatalf("expected the issuer to verify its own token: %v", err)
+	}
+	if _, err := peer.VerifyClusterJWT(token); err == nil {
+		t.Fatal("expected the peer to reject a token from an unpublished key")
+	}
+
+	published, err := issuer.ClusterTokenKeys()
+	if err != nil || len(published) != 1 {
+		t.Fatalf("unexpected published keys %v (%v)", published, err)
+	}
+	if err := peer.DB.Create(&clusterModels.ClusterSSHIdentity{
+		NodeUUID:  "node-a",
+		SSHHost:   "192.0.2.10",
+		PublicKey: "ssh-ed25519 AAAA",
+		TokenKeys: published,
+	}).Error; err != nil {
+		t.Fatalf("failed to publish identity: %v", err)
+	}
+	claims, err := peer.VerifyClusterJWT(token)
+	if err != nil || claims.Username != "node-a" || claims.TokenUse != ClusterTokenUseInternalControl {
+		t.Fatalf("unexpected peer verification %+v (%v)", claims, err)
+	}
+
+	forced, err := issuer.CreateInternalClusterJWT("node-a", "cluster-key")
+	if err != nil {
+		t.Fatalf("failed to sign forced token: %v", err)
+	}
+	if tokenHeader(t, forced)["alg"] != SigningAlgorithmHS256 {
+		t.Fatal("expected a forced secret to keep signing with the shared key")
+	}
+}
+
+func TestSharedClusterKeyRetiresAfterGrace(t *testing.T) {
+	svc := newSigningKeyTestService(t)
+
+	shared, err := svc.CreateInternalClusterJWT("node-a", "cluster-key")
+	if err != nil {
+		t.Fatalf("failed to sign shared-key token: %v", err)
+	}
+
+	key, err := svc.RotateSigningKey("cluster", "es256")
+	if err != nil {
+		t.Fatalf("failed to rotate cluster key: %v", err)
+	}
+	if _, err := svc.VerifyClusterJWT(shared); err != nil {
+		t.Fatalf("expected shared-key tokens to verify during the grace period: %v", err)
+	}
+
+	created := time.Now().Add(-signingKeyGrace - time.Minute)
+	if err := svc.DB.Model(&models.JWTSigningKey{}).Where("id = ?", key.ID).
+		Update("created_at", created).Error; err != nil {
+		t.Fatalf("failed to age cluster key: %v", err)
+	}
+	if _, err := svc.VerifyClusterJWT(shared); err == nil {
+		t.Fatal("expected shared-key tokens to be rejected after the grace period")
+	}
+
+	token, err := svc.CreateInternalClusterJWT("node-a", "")
+	if err != nil {
+		t.Fatalf("failed to sign cluster token: %v", err)
+	}
+	if _, err := svc.VerifyClusterJWT(token); err != nil {
+		t.Fatalf("expected es256 tokens to keep verifying: %v", err)
+	}
+}
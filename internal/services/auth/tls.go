@@ -15,6 +15,7 @@ import (
 
 	"github.com/alchemillahq/sylve/internal/config"
 	"github.com/alchemillahq/sylve/internal/db/models"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
 	"github.com/alchemillahq/sylve/pkg/crypto"
 	"github.com/alchemillahq/sylve/pkg/utils"
 )
@@ -66,10 +67,10 @@ func (s *Service) GetSylveCertificate() (*tls.Config, error) {
 		var certRecord, keyRecord models.SystemSecrets
 
 		certRecord.Name = "tls_cert"
-		certRecord.Data = string(certPEM)
+		certRecord.Data = secrets.String(certPEM)
 
 		keyRecord.Name = "tls_key"
-		keyRecord.Data = string(keyPEM)
+		keyRecord.Data = secrets.String(keyPEM)
 
 		if err := s.DB.Create(&certRecord).Error; err != nil {
 			return nil, fmt.Errorf("failed to save certificate: %w", err)
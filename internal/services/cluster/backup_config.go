@@ -0,0 +1,525 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package cluster
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	BackupConfigDocumentVersion = 1
+
+	backupConfigKDF           = "argon2id"
+	backupConfigMinPassphrase = 12
+
+	backupConfigActionCreate   = "create"
+	backupConfigActionUpdate   = "update"
+	backupConfigActionSkip     = "skip"
+	backupConfigActionRejected = "rejected"
+)
+
+func deriveBackupConfigKey(passphrase string, salt []byte) []byte {
+	return argon2.IDKey([]byte(passphrase), salt, 1, 64*1024, 4, 32)
+}
+
+func newBackupConfigAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// sealBackupConfigSecret returns base64(nonce || ciphertext). The target
+// name is bound as additional data so wrapped keys cannot be swapped
+// between targets in the document.
+func sealBackupConfigSecret(aead cipher.AEAD, name, secret string) (string, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(secret), []byte(name))
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func openBackupConfigSecret(aead cipher.AEAD, name, wrapped string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(wrapped)
+	if err != nil || len(raw) < aead.NonceSize() {
+		return "", fmt.Errorf("backup_config_wrapped_key_invalid: %s", name)
+	}
+	plain, err := aead.Open(nil, raw[:aead.NonceSize()], raw[aead.NonceSize():], []byte(name))
+	if err != nil {
+		return "", fmt.Errorf("backup_config_passphrase_invalid")
+	}
+	return string(plain), nil
+}
+
+// ExportBackupConfig builds a portable document for the given targets (all
+// when none are given) and their jobs. SSH keys are wrapped with passphrase
+// when one is given and left out otherwise.
+func (s *Service) ExportBackupConfig(req clusterServiceInterfaces.BackupConfigExportReq) (*clusterServiceInterfaces.BackupConfigDocument, error) {
+	passphrase := req.Passphrase
+	if passphrase != "" && len(passphrase) < backupConfigMinPassphrase {
+		return nil, fmt.Errorf("backup_config_passphrase_too_short")
+	}
+
+	var targets []clusterModels.BackupTarget
+	query := s.DB.Order("name ASC")
+	if len(req.TargetIDs) > 0 {
+		query = query.Where("id IN ?", req.TargetIDs)
+	}
+	if err := query.Find(&targets).Error; err != nil {
+		return nil, err
+	}
+	if len(req.TargetIDs) > 0 && len(targets) != len(req.TargetIDs) {
+		return nil, fmt.Errorf("backup_target_not_found")
+	}
+
+	doc := &clusterServiceInterfaces.BackupConfigDocument{
+		Version:    BackupConfigDocumentVersion,
+		ExportedAt: time.Now().UTC(),
+		Targets:    make([]clusterServiceInterfaces.BackupConfigTarget, 0, len(targets)),
+		Jobs:       []clusterServiceInterfaces.BackupConfigJob{},
+	}
+
+	var aead cipher.AEAD
+	if passphrase != "" {
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, fmt.Errorf("backup_config_salt_failed: %w", err)
+		}
+		var err error
+		if aead, err = newBackupConfigAEAD(deriveBackupConfigKey(passphrase, salt)); err != nil {
+			return nil, fmt.Errorf("backup_config_cipher_failed: %w", err)
+		}
+		doc.KeyWrap = &clusterServiceInterfaces.BackupConfigKeyWrap{
+			KDF:  backupConfigKDF,
+			Salt: base64.StdEncoding.EncodeToString(salt),
+		}
+	}
+
+	targetNames := make(map[uint]string, len(targets))
+	targetIDs := make([]uint, 0, len(targets))
+	for _, target := range targets {
+		targetNames[target.ID] = target.Name
+		targetIDs = append(targetIDs, target.ID)
+
+		exported := clusterServiceInterfaces.BackupConfigTarget{
+			Name:               target.Name,
+			SSHHost:            target.SSHHost,
+			SSHPort:            target.SSHPort,
+			BackupRoot:         target.BackupRoot,
+			CreateBackupRoot:   target.CreateBackupRoot,
+			BandwidthLimitKBps: target.BandwidthLimitKBps,
+			SSHCiphers:         target.SSHCiphers,
+			SSHCompression:     target.SSHCompression,
+			SSHControlPersist:  target.SSHControlPersist,
+			Description:        target.Description,
+			Enabled:            target.Enabled,
+		}
+		if aead != nil && strings.TrimSpace(string(target.SSHKey)) != "" {
+			wrapped, err := sealBackupConfigSecret(aead, target.Name, string(target.SSHKey))
+			if err != nil {
+				return nil, fmt.Errorf("backup_config_wrap_key_failed: %w", err)
+			}
+			exported.WrappedSSHKey = wrapped
+		}
+		doc.Targets = append(doc.Targets, exported)
+	}
+
+	if len(targetIDs) == 0 {
+		return doc, nil
+	}
+
+	var jobs []clusterModels.BackupJob
+	if err := s.DB.Where("target_id IN ?", targetIDs).Order("name ASC, id ASC").Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		doc.Jobs = append(doc.Jobs, clusterServiceInterfaces.BackupConfigJob{
+			Name:               job.Name,
+			Target:             targetNames[job.TargetID],
+			Mode:               job.Mode,
+			SourceDataset:      job.SourceDataset,
+			JailRootDataset:    job.JailRootDataset,
+			PruneKeepLast:      job.PruneKeepLast,
+			PruneTarget:        job.PruneTarget,
+			StopBeforeBackup:   job.StopBeforeBackup,
+			Recursive:          job.Recursive,
+			ShareQuiesce:       job.ShareQuiesce,
+			VMDatasetIncludes:  job.VMDatasetIncludes,
+			VMDatasetExcludes:  job.VMDatasetExcludes,
+			PreBackupScript:    job.PreBackupScript,
+			PostBackupScript:   job.PostBackupScript,
+			HookTimeoutSeconds: job.HookTimeoutSeconds,
+			HookFailurePolicy:  job.HookFailurePolicy,
+			CronExpr:           job.CronExpr,
+			Enabled:            job.Enabled,
+		})
+	}
+
+	return doc, nil
+}
+
+// unwrapBackupConfigKeys opens every wrapped key up front so a wrong
+// passphrase fails the import before anything is written.
+func unwrapBackupConfigKeys(doc clusterServiceInterfaces.BackupConfigDocument, passphrase string) (map[string]string, error) {
+	keys := make(map[string]string)
+
+	var aead cipher.AEAD
+	for _, target := range doc.Targets {
+		if target.WrappedSSHKey == "" {
+			continue
+		}
+		if aead == nil {
+			if doc.KeyWrap == nil || doc.KeyWrap.KDF != backupConfigKDF {
+				return nil, fmt.Errorf("backup_config_key_wrap_unsupported")
+			}
+			if passphrase == "" {
+				return nil, fmt.Errorf("backup_config_passphrase_required")
+			}
+			salt, err := base64.StdEncoding.DecodeString(doc.KeyWrap.Salt)
+			if err != nil || len(salt) == 0 {
+				return nil, fmt.Errorf("backup_config_key_wrap_salt_invalid")
+			}
+			if aead, err = newBackupConfigAEAD(deriveBackupConfigKey(passphrase, salt)); err != nil {
+				return nil, fmt.Errorf("backup_config_cipher_failed: %w", err)
+			}
+		}
+		key, err := openBackupConfigSecret(aead, target.Name, target.WrappedSSHKey)
+		if err != nil {
+			return nil, err
+		}
+		keys[target.Name] = key
+	}
+
+	return keys, nil
+}
+
+// uniqueBackupConfigName returns the first "-imported" variant of name that
+// is not taken.
+func uniqueBackupConfigName(name string, taken map[string]struct{}) string {
+	candidate := name + "-imported"
+	for i := 2; ; i++ {
+		if _, exists := taken[candidate]; !exists {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s-imported-%d", name, i)
+	}
+}
+
+type backupConfigImport struct {
+	s          *Service
+	req        clusterServiceInterfaces.BackupConfigImportReq
+	bypassRaft bool
+	keys       map[string]string
+	result     *clusterServiceInterfaces.BackupConfigImportResult
+}
+
+func (r *backupConfigImport) record(item clusterServiceInterfaces.BackupConfigImportItem) {
+	if item.Error != "" {
+		item.Action = backupConfigActionRejected
+		r.result.Failed++
+	}
+	r.result.Items = append(r.result.Items, item)
+}
+
+// ImportBackupConfig creates or updates the targets and jobs in a document
+// exported by ExportBackupConfig. Targets are matched by name and jobs by
+// name within their target; OnConflict decides whether a match is skipped,
+// overwritten or imported under a new name. A failure on one entry is
+// recorded without stopping the others.
+func (s *Service) ImportBackupConfig(req clusterServiceInterfaces.BackupConfigImportReq) (*clusterServiceInterfaces.BackupConfigImportResult, error) {
+	doc := req.Document
+	if doc.Version != BackupConfigDocumentVersion {
+		return nil, fmt.Errorf("unsupported_backup_config_version: %d", doc.Version)
+	}
+
+	req.OnConflict = strings.TrimSpace(strings.ToLower(req.OnConflict))
+	switch req.OnConflict {
+	case "":
+		req.OnConflict = clusterServiceInterfaces.BackupConfigConflictSkip
+	case clusterServiceInterfaces.BackupConfigConflictSkip,
+		clusterServiceInterfaces.BackupConfigConflictOverwrite,
+		clusterServiceInterfaces.BackupConfigConflictRename:
+	default:
+		return nil, fmt.Errorf("invalid_conflict_mode")
+	}
+
+	seen := make(map[string]struct{}, len(doc.Targets))
+	for _, target := range doc.Targets {
+		name := strings.TrimSpace(target.Name)
+		if name == "" {
+			return nil, fmt.Errorf("backup_config_target_name_required")
+		}
+		if _, dup := seen[name]; dup {
+			return nil, fmt.Errorf("duplicate_backup_target: %s", name)
+		}
+		seen[name] = struct{}{}
+	}
+
+	keys, err := unwrapBackupConfigKeys(doc, req.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &backupConfigImport{
+		s:          s,
+		req:        req,
+		bypassRaft: s.Raft == nil,
+		keys:       keys,
+		result: &clusterServiceInterfaces.BackupConfigImportResult{
+			DryRun: req.DryRun,
+			Items:  []clusterServiceInterfaces.BackupConfigImportItem{},
+		},
+	}
+
+	targetIDs, err := run.targets()
+	if err != nil {
+		return nil, err
+	}
+	if err := run.jobs(targetIDs); err != nil {
+		return nil, err
+	}
+
+	return run.result, nil
+}
+
+// targets imports the document's targets and returns the ID each document
+// target name resolved to. Targets planned for creation in a dry run map to
+// 0; rejected targets are absent.
+func (r *backupConfigImport) targets() (map[string]uint, error) {
+	var existing []clusterModels.BackupTarget
+	if err := r.s.DB.Find(&existing).Error; err != nil {
+		return nil, err
+	}
+	byName := make(map[string]clusterModels.BackupTarget, len(existing))
+	taken := make(map[string]struct{}, len(existing))
+	for _, target := range existing {
+		byName[target.Name] = target
+		taken[target.Name] = struct{}{}
+	}
+
+	resolved := make(map[string]uint, len(r.req.Document.Targets))
+	for _, spec := range r.req.Document.Targets {
+		name := strings.TrimSpace(spec.Name)
+		key := r.keys[spec.Name]
+		if key == "" {
+			key = strings.TrimSpace(r.req.SSHKeys[spec.Name])
+		}
+
+		input := clusterServiceInterfaces.BackupTargetReq{
+			Name:               name,
+			SSHHost:            spec.SSHHost,
+			SSHPort:            spec.SSHPort,
+			SSHKey:             key,
+			BackupRoot:         spec.BackupRoot,
+			CreateBackupRoot:   &spec.CreateBackupRoot,
+			BandwidthLimitKBps: spec.BandwidthLimitKBps,
+			SSHCiphers:         spec.SSHCiphers,
+			SSHCompression:     spec.SSHCompression,
+			SSHControlPersist:  spec.SSHControlPersist,
+			Description:        spec.Description,
+			Enabled:            &spec.Enabled,
+		}
+		item := clusterServiceInterfaces.BackupConfigImportItem{Kind: "target", Name: name, ImportedAs: name}
+
+		current, conflict := byName[name]
+		if conflict {
+			switch r.req.OnConflict {
+			case clusterServiceInterfaces.BackupConfigConflictSkip:
+				item.Action = backupConfigActionSkip
+				resolved[spec.Name] = current.ID
+				r.record(item)
+				continue
+			case clusterServiceInterfaces.BackupConfigConflictOverwrite:
+				item.Action = backupConfigActionUpdate
+				input.ID = current.ID
+				if input.SSHKey == "" {
+					input.SSHKey = string(current.SSHKey)
+					input.SSHKeyPath = current.SSHKeyPath
+				}
+				if err := validateBackupTargetInput(input); err != nil {
+					item.Error = err.Error()
+				} else if !r.req.DryRun {
+					if err := r.s.ProposeBackupTargetUpdate(input, r.bypassRaft); err != nil {
+						item.Error = err.Error()
+					}
+				}
+				if item.Error == "" {
+					resolved[spec.Name] = current.ID
+				}
+				r.record(item)
+				continue
+			case clusterServiceInterfaces.BackupConfigConflictRename:
+				input.Name = uniqueBackupConfigName(name, taken)
+				item.ImportedAs = input.Name
+			}
+		}
+
+		item.Action = backupConfigActionCreate
+		if input.SSHKey == "" {
+			item.Error = "backup_config_ssh_key_required"
+		} else if err := validateBackupTargetInput(input); err != nil {
+			item.Error = err.Error()
+		} else if !r.req.DryRun {
+			if err := r.s.ProposeBackupTargetCreate(input, r.bypassRaft); err != nil {
+				item.Error = err.Error()
+			} else {
+				var created clusterModels.BackupTarget
+				if err := r.s.DB.Where("name = ?", input.Name).First(&created).Error; err != nil {
+					item.Error = fmt.Sprintf("backup_target_lookup_failed: %v", err)
+				} else {
+					resolved[spec.Name] = created.ID
+				}
+			}
+		} else {
+			resolved[spec.Name] = 0
+		}
+		if item.Error == "" {
+			taken[input.Name] = struct{}{}
+		}
+		r.record(item)
+	}
+
+	return resolved, nil
+}
+
+// backupConfigJobScope keys job names by target. Targets only planned in a
+// dry run have no ID yet, so they are keyed by their document name.
+func backupConfigJobScope(targetID uint, targetName string) string {
+	if targetID == 0 {
+		return "planned:" + targetName
+	}
+	return fmt.Sprint(targetID)
+}
+
+func (r *backupConfigImport) jobs(targetIDs map[string]uint) error {
+	var existing []clusterModels.BackupJob
+	if err := r.s.DB.Select("id", "name", "target_id").Find(&existing).Error; err != nil {
+		return err
+	}
+	taken := make(map[string]map[string]uint)
+	for _, job := range existing {
+		scope := backupConfigJobScope(job.TargetID, "")
+		if taken[scope] == nil {
+			taken[scope] = make(map[string]uint)
+		}
+		taken[scope][job.Name] = job.ID
+	}
+
+	inDocument := make(map[string]struct{}, len(r.req.Document.Targets))
+	for _, target := range r.req.Document.Targets {
+		inDocument[target.Name] = struct{}{}
+	}
+
+	for _, spec := range r.req.Document.Jobs {
+		name := strings.TrimSpace(spec.Name)
+		item := clusterServiceInterfaces.BackupConfigImportItem{Kind: "job", Name: name, ImportedAs: name}
+
+		targetID, ok := targetIDs[spec.Target]
+		if _, documented := inDocument[spec.Target]; !documented {
+			// Jobs may point at a target that already exists on this
+			// cluster and was left out of the export.
+			var target clusterModels.BackupTarget
+			if err := r.s.DB.Where("name = ?", spec.Target).First(&target).Error; err == nil {
+				targetID, ok = target.ID, true
+			}
+		}
+		if !ok {
+			item.Error = fmt.Sprintf("backup_config_target_unavailable: %s", spec.Target)
+			r.record(item)
+			continue
+		}
+
+		input := clusterServiceInterfaces.BackupJobReq{
+			Name:               name,
+			TargetID:           targetID,
+			RunnerNodeID:       r.req.RunnerNodeID,
+			Mode:               spec.Mode,
+			SourceDataset:      spec.SourceDataset,
+			JailRootDataset:    spec.JailRootDataset,
+			PruneKeepLast:      spec.PruneKeepLast,
+			PruneTarget:        spec.PruneTarget,
+			StopBeforeBackup:   spec.StopBeforeBackup,
+			Recursive:          spec.Recursive,
+			ShareQuiesce:       spec.ShareQuiesce,
+			VMDatasetIncludes:  spec.VMDatasetIncludes,
+			VMDatasetExcludes:  spec.VMDatasetExcludes,
+			PreBackupScript:    spec.PreBackupScript,
+			PostBackupScript:   spec.PostBackupScript,
+			HookTimeoutSeconds: spec.HookTimeoutSeconds,
+			HookFailurePolicy:  spec.HookFailurePolicy,
+			CronExpr:           spec.CronExpr,
+			Enabled:            &spec.Enabled,
+		}
+
+		scope := backupConfigJobScope(targetID, spec.Target)
+		names := taken[scope]
+		if names == nil {
+			names = make(map[string]uint)
+			taken[scope] = names
+		}
+
+		if currentID, conflict := names[name]; conflict {
+			switch {
+			case currentID == 0:
+				// Created earlier from this same document.
+				item.Error = fmt.Sprintf("duplicate_backup_job: %s", name)
+				r.record(item)
+				continue
+			case r.req.OnConflict == clusterServiceInterfaces.BackupConfigConflictSkip:
+				item.Action = backupConfigActionSkip
+				r.record(item)
+				continue
+			case r.req.OnConflict == clusterServiceInterfaces.BackupConfigConflictOverwrite:
+				item.Action = backupConfigActionUpdate
+				if !r.req.DryRun {
+					if err := r.s.ProposeBackupJobUpdate(currentID, input, r.bypassRaft); err != nil {
+						item.Error = err.Error()
+					}
+				}
+				r.record(item)
+				continue
+			default:
+				used := make(map[string]struct{}, len(names))
+				for n := range names {
+					used[n] = struct{}{}
+				}
+				input.Name = uniqueBackupConfigName(name, used)
+				item.ImportedAs = input.Name
+			}
+		}
+
+		item.Action = backupConfigActionCreate
+		if input.Name == "" {
+			item.Error = "name_required"
+		} else if !r.req.DryRun {
+			if err := r.s.ProposeBackupJobCreate(input, r.bypassRaft); err != nil {
+				item.Error = err.Error()
+			}
+		}
+		if item.Error == "" {
+			names[input.Name] = 0
+		}
+		r.record(item)
+	}
+
+	return nil
+}
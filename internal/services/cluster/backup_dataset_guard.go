@@ -18,6 +18,7 @@ import (
 	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
 	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"gorm.io/gorm"
 )
 
@@ -67,7 +68,7 @@ func backupSourceMayContainManagedGuest(sourceDataset string) bool {
 		// A whole-pool backup could contain any managed guest namespace.
 		return true
 	}
-	if parts[1] != "sylve" {
+	if parts[1] != layout.RootName() {
 		return false
 	}
 	if len(parts) == 2 {
@@ -75,11 +76,11 @@ func backupSourceMayContainManagedGuest(sourceDataset string) bool {
 	}
 
 	switch parts[2] {
-	case "jails":
+	case layout.JailsName():
 		// Jail inventory currently owns the canonical jail root only. A
 		// descendant cannot contain that root.
 		return len(parts) <= 4
-	case "virtual-machines":
+	case layout.VirtualMachinesName():
 		// VM storage datasets can live below the canonical VM root, so
 		// inventory is required for every path in this namespace.
 		return true
@@ -93,13 +94,13 @@ func isReservedManagedBackupScope(sourceDataset string) bool {
 	if len(parts) == 1 && parts[0] != "" {
 		return true
 	}
-	if len(parts) < 2 || parts[0] == "" || parts[1] != "sylve" {
+	if len(parts) < 2 || parts[0] == "" || parts[1] != layout.RootName() {
 		return false
 	}
 	if len(parts) == 2 {
 		return true
 	}
-	if parts[2] != "jails" && parts[2] != "virtual-machines" {
+	if parts[2] != layout.JailsName() && parts[2] != layout.VirtualMachinesName() {
 		return false
 	}
 	if len(parts) == 3 {
@@ -115,7 +116,7 @@ func isReservedManagedBackupScope(sourceDataset string) bool {
 
 func canonicalManagedGuestRootID(dataset, namespace string) (uint, bool) {
 	parts := strings.Split(normalizeManagedGuestDatasetPath(dataset), "/")
-	if len(parts) != 4 || parts[0] == "" || parts[1] != "sylve" || parts[2] != namespace {
+	if len(parts) != 4 || parts[0] == "" || parts[1] != layout.RootName() || parts[2] != namespace {
 		return 0, false
 	}
 
@@ -172,7 +173,7 @@ func (s *Service) managedGuestDatasets(ctx context.Context) ([]managedGuestDatas
 				entries,
 				clusterModels.BackupJobModeJail,
 				jail.CTID,
-				fmt.Sprintf("%s/sylve/jails/%d", pool, jail.CTID),
+				fmt.Sprintf("%s/%d", layout.JailsDataset(pool), jail.CTID),
 			)
 		}
 		if !resolvedRoot {
@@ -203,7 +204,7 @@ func (s *Service) managedGuestDatasets(ctx context.Context) ([]managedGuestDatas
 					entries,
 					clusterModels.BackupJobModeVM,
 					vm.RID,
-					fmt.Sprintf("%s/sylve/virtual-machines/%d", pool, vm.RID),
+					fmt.Sprintf("%s/%d", layout.VMsDataset(pool), vm.RID),
 				)
 			}
 			addManagedGuestDataset(
@@ -278,7 +279,7 @@ func (s *Service) ValidateJailBackupRoot(ctx context.Context, jailRootDataset st
 	if jailRootDataset == "" {
 		return fmt.Errorf("jail_root_dataset_required")
 	}
-	jailID, canonical := canonicalManagedGuestRootID(jailRootDataset, "jails")
+	jailID, canonical := canonicalManagedGuestRootID(jailRootDataset, layout.JailsName())
 	if !canonical {
 		return fmt.Errorf(
 			"jail_backup_requires_registered_canonical_root: dataset=%s",
@@ -310,7 +311,7 @@ func (s *Service) ValidateJailBackupRoot(ctx context.Context, jailRootDataset st
 			continue
 		}
 		pool := normalizeManagedGuestDatasetPath(storage.Pool)
-		if pool != "" && fmt.Sprintf("%s/sylve/jails/%d", pool, jail.CTID) == jailRootDataset {
+		if pool != "" && fmt.Sprintf("%s/%d", layout.JailsDataset(pool), jail.CTID) == jailRootDataset {
 			return nil
 		}
 	}
@@ -329,7 +330,7 @@ func (s *Service) ValidateVMBackupRoot(ctx context.Context, sourceDataset string
 	}
 
 	sourceDataset = normalizeManagedGuestDatasetPath(sourceDataset)
-	vmID, canonical := canonicalManagedGuestRootID(sourceDataset, "virtual-machines")
+	vmID, canonical := canonicalManagedGuestRootID(sourceDataset, layout.VirtualMachinesName())
 	if !canonical {
 		return fmt.Errorf(
 			"vm_backup_requires_registered_canonical_root: dataset=%s",
@@ -362,7 +363,7 @@ func (s *Service) ValidateVMBackupRoot(ctx context.Context, sourceDataset string
 		if pool == "" {
 			pool = normalizeManagedGuestDatasetPath(storage.Dataset.Pool)
 		}
-		if pool != "" && fmt.Sprintf("%s/sylve/virtual-machines/%d", pool, vm.RID) == sourceDataset {
+		if pool != "" && fmt.Sprintf("%s/%d", layout.VMsDataset(pool), vm.RID) == sourceDataset {
 			return nil
 		}
 	}
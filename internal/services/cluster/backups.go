@@ -16,6 +16,8 @@ import (
 	"math/big"
 	"net"
 	"os"
+	"path"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -23,7 +25,9 @@ import (
 	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
 	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
 	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/pkg/utils"
 	"github.com/hashicorp/raft"
@@ -102,6 +106,10 @@ func (s *Service) ProposeBackupTargetCreate(input clusterServiceInterfaces.Backu
 	if err := validateBackupTargetInput(input); err != nil {
 		return err
 	}
+	sshCiphers, err := clusterModels.NormalizeBackupTargetSSHCiphers(input.SSHCiphers)
+	if err != nil {
+		return err
+	}
 
 	resolvedSSHKey, err := resolveSSHKeyMaterial(input.SSHKey, input.SSHKeyPath)
 	if err != nil {
@@ -109,15 +117,19 @@ func (s *Service) ProposeBackupTargetCreate(input clusterServiceInterfaces.Backu
 	}
 
 	target := clusterModels.BackupTarget{
-		Name:             strings.TrimSpace(input.Name),
-		SSHHost:          strings.TrimSpace(input.SSHHost),
-		SSHPort:          input.SSHPort,
-		SSHKeyPath:       strings.TrimSpace(input.SSHKeyPath),
-		SSHKey:           resolvedSSHKey,
-		BackupRoot:       strings.TrimSpace(input.BackupRoot),
-		CreateBackupRoot: utils.PtrToBool(input.CreateBackupRoot),
-		Description:      strings.TrimSpace(input.Description),
-		Enabled:          boolPtrDefaultTrue(input.Enabled),
+		Name:               strings.TrimSpace(input.Name),
+		SSHHost:            strings.TrimSpace(input.SSHHost),
+		SSHPort:            input.SSHPort,
+		SSHKeyPath:         strings.TrimSpace(input.SSHKeyPath),
+		SSHKey:             secrets.String(resolvedSSHKey),
+		BackupRoot:         strings.TrimSpace(input.BackupRoot),
+		CreateBackupRoot:   utils.PtrToBool(input.CreateBackupRoot),
+		BandwidthLimitKBps: input.BandwidthLimitKBps,
+		SSHCiphers:         sshCiphers,
+		SSHCompression:     input.SSHCompression,
+		SSHControlPersist:  input.SSHControlPersist,
+		Description:        strings.TrimSpace(input.Description),
+		Enabled:            boolPtrDefaultTrue(input.Enabled),
 	}
 
 	if target.SSHPort == 0 {
@@ -158,6 +170,10 @@ func (s *Service) ProposeBackupTargetUpdate(input clusterServiceInterfaces.Backu
 	if err := validateBackupTargetInput(input); err != nil {
 		return err
 	}
+	sshCiphers, err := clusterModels.NormalizeBackupTargetSSHCiphers(input.SSHCiphers)
+	if err != nil {
+		return err
+	}
 
 	resolvedSSHKey, err := resolveSSHKeyMaterial(input.SSHKey, input.SSHKeyPath)
 	if err != nil {
@@ -172,16 +188,20 @@ func (s *Service) ProposeBackupTargetUpdate(input clusterServiceInterfaces.Backu
 	}
 
 	target := clusterModels.BackupTarget{
-		ID:               input.ID,
-		Name:             strings.TrimSpace(input.Name),
-		SSHHost:          strings.TrimSpace(input.SSHHost),
-		SSHPort:          input.SSHPort,
-		SSHKeyPath:       strings.TrimSpace(input.SSHKeyPath),
-		SSHKey:           resolvedSSHKey,
-		BackupRoot:       strings.TrimSpace(input.BackupRoot),
-		CreateBackupRoot: utils.PtrToBool(input.CreateBackupRoot),
-		Description:      strings.TrimSpace(input.Description),
-		Enabled:          enabled,
+		ID:                 input.ID,
+		Name:               strings.TrimSpace(input.Name),
+		SSHHost:            strings.TrimSpace(input.SSHHost),
+		SSHPort:            input.SSHPort,
+		SSHKeyPath:         strings.TrimSpace(input.SSHKeyPath),
+		SSHKey:             secrets.String(resolvedSSHKey),
+		BackupRoot:         strings.TrimSpace(input.BackupRoot),
+		CreateBackupRoot:   utils.PtrToBool(input.CreateBackupRoot),
+		BandwidthLimitKBps: input.BandwidthLimitKBps,
+		SSHCiphers:         sshCiphers,
+		SSHCompression:     input.SSHCompression,
+		SSHControlPersist:  input.SSHControlPersist,
+		Description:        strings.TrimSpace(input.Description),
+		Enabled:            enabled,
 	}
 
 	if target.SSHPort == 0 {
@@ -190,15 +210,19 @@ func (s *Service) ProposeBackupTargetUpdate(input clusterServiceInterfaces.Backu
 
 	if bypassRaft {
 		return s.DB.Model(&clusterModels.BackupTarget{}).Where("id = ?", input.ID).Updates(map[string]any{
-			"name":               target.Name,
-			"ssh_host":           target.SSHHost,
-			"ssh_port":           target.SSHPort,
-			"ssh_key_path":       target.SSHKeyPath,
-			"ssh_key":            target.SSHKey,
-			"backup_root":        target.BackupRoot,
-			"create_backup_root": target.CreateBackupRoot,
-			"description":        target.Description,
-			"enabled":            target.Enabled,
+			"name":                 target.Name,
+			"ssh_host":             target.SSHHost,
+			"ssh_port":             target.SSHPort,
+			"ssh_key_path":         target.SSHKeyPath,
+			"ssh_key":              target.SSHKey,
+			"backup_root":          target.BackupRoot,
+			"create_backup_root":   target.CreateBackupRoot,
+			"bandwidth_limit_kbps": target.BandwidthLimitKBps,
+			"ssh_ciphers":          target.SSHCiphers,
+			"ssh_compression":      target.SSHCompression,
+			"ssh_control_persist":  target.SSHControlPersist,
+			"description":          target.Description,
+			"enabled":              target.Enabled,
 		}).Error
 	}
 
@@ -469,6 +493,12 @@ func (s *Service) matchBackupJobIDsForGuest(guestType string, guestID uint) ([]u
 }
 
 func (s *Service) forwardBackupJobFriendlySourceToLeader(update BackupJobFriendlySourceUpdate) error {
+	return s.forwardBackupJobCommandToLeader("backup-job-friendly-source", "backup_job_friendly_source", update)
+}
+
+// forwardBackupJobCommandToLeader posts payload to the leader's intra-cluster
+// endpoint, which proposes it through raft on the follower's behalf.
+func (s *Service) forwardBackupJobCommandToLeader(endpoint string, errPrefix string, payload any) error {
 	if s == nil {
 		return fmt.Errorf("cluster_service_unavailable")
 	}
@@ -501,13 +531,13 @@ func (s *Service) forwardBackupJobFriendlySourceToLeader(update BackupJobFriendl
 		return fmt.Errorf("create_cluster_token_failed: %w", err)
 	}
 
-	body, err := json.Marshal(update)
+	body, err := json.Marshal(payload)
 	if err != nil {
-		return fmt.Errorf("marshal_backup_job_friendly_source_payload_failed: %w", err)
+		return fmt.Errorf("marshal_%s_payload_failed: %w", errPrefix, err)
 	}
 
 	_, statusCode, err := utils.HTTPPostJSONWithTimeout(
-		fmt.Sprintf("https://%s/api/intra-cluster/backup-job-friendly-source", targetAPI),
+		fmt.Sprintf("https://%s/api/intra-cluster/%s", targetAPI, endpoint),
 		body,
 		map[string]string{
 			"Accept":          "application/json",
@@ -517,12 +547,65 @@ func (s *Service) forwardBackupJobFriendlySourceToLeader(update BackupJobFriendl
 		5*time.Second,
 	)
 	if err != nil {
-		return fmt.Errorf("forward_backup_job_friendly_source_failed_status_%d: %w", statusCode, err)
+		return fmt.Errorf("forward_%s_failed_status_%d: %w", errPrefix, statusCode, err)
 	}
 
 	return nil
 }
 
+func (s *Service) RenameBackupJobDatasets(rename clusterModels.BackupJobDatasetRename, bypassRaft bool) error {
+	rename.RunnerNodeID = strings.TrimSpace(rename.RunnerNodeID)
+	rename.From = strings.Trim(strings.TrimSpace(rename.From), "/")
+	rename.To = strings.Trim(strings.TrimSpace(rename.To), "/")
+	if rename.From == "" || rename.To == "" {
+		return fmt.Errorf("backup_job_dataset_rename_names_required")
+	}
+
+	if bypassRaft {
+		return clusterModels.RenameBackupJobDatasets(s.DB, rename)
+	}
+
+	if s.Raft == nil {
+		return fmt.Errorf("raft_not_initialized")
+	}
+	if s.Raft.State() != raft.Leader {
+		return fmt.Errorf("not_leader")
+	}
+
+	data, err := json.Marshal(rename)
+	if err != nil {
+		return fmt.Errorf("failed_to_marshal_backup_job_dataset_rename_payload: %w", err)
+	}
+
+	return s.applyRaftCommand(clusterModels.Command{
+		Type:   "backup_job_dataset",
+		Action: "rename",
+		Data:   data,
+	})
+}
+
+// RenameBackupJobDatasetsClusterWide points this node's backup jobs at a
+// dataset's new name, proposing through the leader when clustered.
+func (s *Service) RenameBackupJobDatasetsClusterWide(from, to string) error {
+	rename := clusterModels.BackupJobDatasetRename{
+		RunnerNodeID:      s.LocalNodeID(),
+		IncludeUnassigned: s.Raft == nil || s.LocalNodeIsLeader(),
+		From:              from,
+		To:                to,
+	}
+
+	err := s.RenameBackupJobDatasets(rename, s.Raft == nil)
+	if err == nil {
+		return nil
+	}
+
+	if s.Raft != nil && strings.Contains(strings.ToLower(err.Error()), "not_leader") {
+		return s.forwardBackupJobCommandToLeader("backup-job-dataset-rename", "backup_job_dataset_rename", rename)
+	}
+
+	return err
+}
+
 func (s *Service) resolveClusterNodeAPIByNodeID(nodeID string) (string, error) {
 	nodeID = strings.TrimSpace(nodeID)
 	if nodeID == "" {
@@ -603,6 +686,39 @@ func (s *Service) ProposeBackupJobCreate(input clusterServiceInterfaces.BackupJo
 		return fmt.Errorf("new_backup_job_id_failed: %w", err)
 	}
 
+	return s.proposeBackupJobCreate(id, input, bypassRaft)
+}
+
+// ProposeBackupJobCreateWithID creates a job under a caller-chosen ID. Target
+// dataset paths and snapshot names both encode the ID of the job that wrote
+// them, so reattaching a lineage found on a target has to reuse that ID.
+func (s *Service) ProposeBackupJobCreateWithID(id uint, input clusterServiceInterfaces.BackupJobReq, bypassRaft bool) error {
+	if id == 0 || int64(id) >= maxBackupJobIDRange.Int64() {
+		return fmt.Errorf("invalid_job_id")
+	}
+
+	var count int64
+	if err := s.DB.Model(&clusterModels.BackupJob{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return fmt.Errorf("backup_job_id_check_failed: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("backup_job_id_in_use: %d", id)
+	}
+
+	return s.proposeBackupJobCreate(id, input, bypassRaft)
+}
+
+// ReattachBackupJobClusterWide creates a job under a chosen ID, proposing
+// through the leader when this node is a follower.
+func (s *Service) ReattachBackupJobClusterWide(req clusterServiceInterfaces.BackupJobReattachReq) error {
+	if s.Raft != nil && !s.LocalNodeIsLeader() {
+		return s.forwardBackupJobCommandToLeader("backup-job-reattach", "backup_job_reattach", req)
+	}
+
+	return s.ProposeBackupJobCreateWithID(req.ID, req.Job, s.Raft == nil)
+}
+
+func (s *Service) proposeBackupJobCreate(id uint, input clusterServiceInterfaces.BackupJobReq, bypassRaft bool) error {
 	job, err := s.buildBackupJob(id, input)
 	if err != nil {
 		return err
@@ -640,21 +756,29 @@ func (s *Service) ProposeBackupJobUpdate(id uint, input clusterServiceInterfaces
 
 	if bypassRaft {
 		return s.DB.Model(&clusterModels.BackupJob{}).Where("id = ?", id).Updates(map[string]any{
-			"name":               job.Name,
-			"target_id":          job.TargetID,
-			"runner_node_id":     job.RunnerNodeID,
-			"mode":               job.Mode,
-			"source_dataset":     job.SourceDataset,
-			"jail_root_dataset":  job.JailRootDataset,
-			"friendly_src":       job.FriendlySrc,
-			"dest_suffix":        job.DestSuffix,
-			"prune_keep_last":    job.PruneKeepLast,
-			"prune_target":       job.PruneTarget,
-			"stop_before_backup": job.StopBeforeBackup,
-			"recursive":          job.Recursive,
-			"cron_expr":          job.CronExpr,
-			"enabled":            job.Enabled,
-			"next_run_at":        job.NextRunAt,
+			"name":                 job.Name,
+			"target_id":            job.TargetID,
+			"runner_node_id":       job.RunnerNodeID,
+			"mode":                 job.Mode,
+			"source_dataset":       job.SourceDataset,
+			"jail_root_dataset":    job.JailRootDataset,
+			"friendly_src":         job.FriendlySrc,
+			"dest_suffix":          job.DestSuffix,
+			"prune_keep_last":      job.PruneKeepLast,
+			"prune_target":         job.PruneTarget,
+			"stop_before_backup":   job.StopBeforeBackup,
+			"recursive":            job.Recursive,
+			"share_quiesce":        job.ShareQuiesce,
+			"vm_dataset_includes":  job.VMDatasetIncludes,
+			"vm_dataset_excludes":  job.VMDatasetExcludes,
+			"pre_backup_script":    job.PreBackupScript,
+			"post_backup_script":   job.PostBackupScript,
+			"hook_timeout_seconds": job.HookTimeoutSeconds,
+			"hook_failure_policy":  job.HookFailurePolicy,
+			"zelta_profile_id":     job.ZeltaProfileID,
+			"cron_expr":            job.CronExpr,
+			"enabled":              job.Enabled,
+			"next_run_at":          job.NextRunAt,
 		}).Error
 	}
 
@@ -781,21 +905,29 @@ func (s *Service) buildBackupJob(id uint, input clusterServiceInterfaces.BackupJ
 	}
 
 	job := &clusterModels.BackupJob{
-		ID:               id,
-		Name:             strings.TrimSpace(input.Name),
-		TargetID:         input.TargetID,
-		RunnerNodeID:     runnerNodeID,
-		Mode:             mode,
-		SourceDataset:    normalizeManagedGuestDatasetPath(input.SourceDataset),
-		JailRootDataset:  normalizeManagedGuestDatasetPath(input.JailRootDataset),
-		FriendlySrc:      "",
-		DestSuffix:       "",
-		PruneKeepLast:    input.PruneKeepLast,
-		PruneTarget:      input.PruneTarget,
-		StopBeforeBackup: input.StopBeforeBackup,
-		Recursive:        input.Recursive,
-		CronExpr:         cronExpr,
-		Enabled:          enabled,
+		ID:                 id,
+		Name:               strings.TrimSpace(input.Name),
+		TargetID:           input.TargetID,
+		RunnerNodeID:       runnerNodeID,
+		Mode:               mode,
+		SourceDataset:      normalizeManagedGuestDatasetPath(input.SourceDataset),
+		JailRootDataset:    normalizeManagedGuestDatasetPath(input.JailRootDataset),
+		FriendlySrc:        "",
+		DestSuffix:         "",
+		PruneKeepLast:      input.PruneKeepLast,
+		PruneTarget:        input.PruneTarget,
+		StopBeforeBackup:   input.StopBeforeBackup,
+		Recursive:          input.Recursive,
+		ShareQuiesce:       strings.TrimSpace(input.ShareQuiesce),
+		VMDatasetIncludes:  strings.Join(clusterModels.SplitBackupDatasetPatterns(input.VMDatasetIncludes), ","),
+		VMDatasetExcludes:  strings.Join(clusterModels.SplitBackupDatasetPatterns(input.VMDatasetExcludes), ","),
+		PreBackupScript:    strings.TrimSpace(input.PreBackupScript),
+		PostBackupScript:   strings.TrimSpace(input.PostBackupScript),
+		HookTimeoutSeconds: input.HookTimeoutSeconds,
+		HookFailurePolicy:  strings.TrimSpace(strings.ToLower(input.HookFailurePolicy)),
+		ZeltaProfileID:     input.ZeltaProfileID,
+		CronExpr:           cronExpr,
+		Enabled:            enabled,
 	}
 
 	if job.PruneKeepLast < 0 {
@@ -830,6 +962,22 @@ func (s *Service) buildBackupJob(id uint, input clusterServiceInterfaces.BackupJ
 		return nil, fmt.Errorf("stop_before_backup_not_supported_for_dataset_mode")
 	}
 
+	if !clusterModels.ValidBackupShareQuiesce(job.ShareQuiesce) {
+		return nil, fmt.Errorf("invalid_share_quiesce")
+	}
+	if job.ShareQuiesce != clusterModels.BackupShareQuiesceNone && mode == clusterModels.BackupJobModeVM {
+		return nil, fmt.Errorf("share_quiesce_not_supported_for_vm_mode")
+	}
+	if err := s.requireZeltaProfile(job.ZeltaProfileID); err != nil {
+		return nil, err
+	}
+	if err := validateBackupJobHooks(job); err != nil {
+		return nil, err
+	}
+	if err := validateBackupJobVMDatasetPatterns(job); err != nil {
+		return nil, err
+	}
+
 	job.DestSuffix = autoBackupJobDestSuffix(job.ID, job.Mode, job.SourceDataset, job.JailRootDataset)
 
 	// Ensure no other job writes to the same target path.
@@ -854,6 +1002,60 @@ func (s *Service) buildBackupJob(id uint, input clusterServiceInterfaces.BackupJ
 	return job, nil
 }
 
+// validateBackupJobHooks checks a job's hook settings. The scripts live on
+// the runner node, which may not be this one, so only the path is checked
+// here; the runner checks ownership and permissions before every run.
+func validateBackupJobHooks(job *clusterModels.BackupJob) error {
+	for _, script := range []*string{&job.PreBackupScript, &job.PostBackupScript} {
+		if *script == "" {
+			continue
+		}
+		if !filepath.IsAbs(*script) {
+			return fmt.Errorf("invalid_backup_hook_script: path must be absolute")
+		}
+		*script = filepath.Clean(*script)
+	}
+
+	if job.HookTimeoutSeconds < 0 || job.HookTimeoutSeconds > clusterModels.BackupHookMaxTimeoutSeconds {
+		return fmt.Errorf("invalid_backup_hook_timeout")
+	}
+
+	if job.HookFailurePolicy == "" {
+		job.HookFailurePolicy = clusterModels.BackupHookFailurePolicyFail
+	}
+	if !clusterModels.ValidBackupHookFailurePolicy(job.HookFailurePolicy) {
+		return fmt.Errorf("invalid_backup_hook_failure_policy")
+	}
+
+	return nil
+}
+
+// validateBackupJobVMDatasetPatterns rejects dataset patterns on jobs that
+// do not back up a VM and patterns path.Match would refuse at run time.
+func validateBackupJobVMDatasetPatterns(job *clusterModels.BackupJob) error {
+	if job.VMDatasetIncludes == "" && job.VMDatasetExcludes == "" {
+		return nil
+	}
+	if job.Mode != clusterModels.BackupJobModeVM {
+		return fmt.Errorf("vm_dataset_patterns_not_supported_for_%s_mode", job.Mode)
+	}
+
+	patterns := append(
+		clusterModels.SplitBackupDatasetPatterns(job.VMDatasetIncludes),
+		clusterModels.SplitBackupDatasetPatterns(job.VMDatasetExcludes)...,
+	)
+	for _, pattern := range patterns {
+		if strings.ContainsAny(pattern, "@ \t") {
+			return fmt.Errorf("invalid_vm_dataset_pattern: %s", pattern)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid_vm_dataset_pattern: %s", pattern)
+		}
+	}
+
+	return nil
+}
+
 func autoBackupJobDestSuffix(jobID uint, mode, sourceDataset, jailRootDataset string) string {
 	source := strings.TrimSpace(sourceDataset)
 	if strings.TrimSpace(mode) == clusterModels.BackupJobModeJail {
@@ -888,7 +1090,7 @@ func autoBackupDestBase(source string) string {
 	parts := strings.Split(source, "/")
 	for i := len(parts) - 1; i >= 0; i-- {
 		switch parts[i] {
-		case "jails", "virtual-machines":
+		case layout.JailsName(), layout.VirtualMachinesName():
 			return strings.Join(parts[i:], "/")
 		}
 	}
@@ -984,7 +1186,7 @@ func parseVMRIDFromDataset(dataset string) (uint, bool) {
 
 	parts := strings.Split(strings.Trim(dataset, "/"), "/")
 	for idx := 0; idx+1 < len(parts); idx++ {
-		if parts[idx] != "virtual-machines" {
+		if parts[idx] != layout.VirtualMachinesName() {
 			continue
 		}
 
@@ -1029,6 +1231,10 @@ func validateBackupTargetInput(input clusterServiceInterfaces.BackupTargetReq) e
 		return fmt.Errorf("invalid_ssh_host: should be user@host or just hostname")
 	}
 
+	if input.SSHControlPersist < 0 || input.SSHControlPersist > clusterModels.BackupTargetSSHControlPersistMax {
+		return fmt.Errorf("invalid_ssh_control_persist")
+	}
+
 	return nil
 }
 
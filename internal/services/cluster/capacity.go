@@ -0,0 +1,299 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package cluster
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+)
+
+const (
+	CapacityResourceCPU    = "cpu"
+	CapacityResourceMemory = "memory"
+	CapacityResourceDisk   = "disk"
+
+	maxSimulatedGuests = 10000
+)
+
+var capacityResources = []string{CapacityResourceCPU, CapacityResourceMemory, CapacityResourceDisk}
+
+type CapacityProfile struct {
+	Name   string `json:"name"`
+	Count  int    `json:"count" binding:"required,min=1"`
+	VCPUs  int    `json:"vcpus" binding:"required,min=1"`
+	Memory uint64 `json:"memory" binding:"required,min=1"`
+	Disk   uint64 `json:"disk"`
+}
+
+type CapacitySimulationRequest struct {
+	Profiles []CapacityProfile `json:"profiles" binding:"required,min=1,dive"`
+	// vCPUs allowed per physical core; 0 means no overcommit.
+	CPUOvercommit float64 `json:"cpuOvercommit" binding:"omitempty,min=0"`
+	// Share of every resource kept free on each node.
+	ReservePercent float64 `json:"reservePercent" binding:"omitempty,min=0,max=100"`
+}
+
+type CapacityResources struct {
+	CPU    float64 `json:"cpu"`
+	Memory uint64  `json:"memory"`
+	Disk   uint64  `json:"disk"`
+}
+
+type CapacityNodeResult struct {
+	NodeUUID   string            `json:"nodeUUID"`
+	Hostname   string            `json:"hostname"`
+	Capacity   CapacityResources `json:"capacity"`
+	FreeBefore CapacityResources `json:"freeBefore"`
+	FreeAfter  CapacityResources `json:"freeAfter"`
+	Placed     map[string]int    `json:"placed"`
+	Bottleneck string            `json:"bottleneck"`
+}
+
+type CapacityUnplaced struct {
+	Profile  string `json:"profile"`
+	Count    int    `json:"count"`
+	Resource string `json:"resource"`
+}
+
+type CapacitySimulation struct {
+	Fits         bool                 `json:"fits"`
+	Requested    int                  `json:"requested"`
+	Placed       int                  `json:"placed"`
+	Bottleneck   string               `json:"bottleneck"`
+	Nodes        []CapacityNodeResult `json:"nodes"`
+	Unplaced     []CapacityUnplaced   `json:"unplaced"`
+	SkippedNodes []string             `json:"skippedNodes"`
+}
+
+// placementNode tracks what is left on a node while guests are placed.
+type placementNode struct {
+	result   *CapacityNodeResult
+	capacity [3]float64
+	free     [3]float64
+}
+
+// scorePlacement rates putting a guest needing req onto a node. It returns
+// the smallest share of any resource left after placement (higher spreads
+// load better) and, when the guest does not fit, the first resource short.
+func scorePlacement(capacity, free, req [3]float64) (float64, string, bool) {
+	score := math.Inf(1)
+	for i, resource := range capacityResources {
+		if req[i] == 0 {
+			continue
+		}
+		if req[i] > free[i] {
+			return 0, resource, false
+		}
+		if capacity[i] > 0 {
+			score = min(score, (free[i]-req[i])/capacity[i])
+		}
+	}
+	if math.IsInf(score, 1) {
+		score = 1
+	}
+	return score, "", true
+}
+
+// SimulateCapacity places the requested hypothetical guests onto the online
+// cluster nodes without touching them and reports fit, per-node headroom and
+// which resource runs out first. Free capacity is derived from the last
+// health sync of each node.
+func (s *Service) SimulateCapacity(req CapacitySimulationRequest) (*CapacitySimulation, error) {
+	total := 0
+	for i, profile := range req.Profiles {
+		if profile.Count <= 0 || profile.VCPUs <= 0 || profile.Memory == 0 {
+			return nil, fmt.Errorf("invalid_capacity_profile: %d", i)
+		}
+		total += profile.Count
+	}
+	if total > maxSimulatedGuests {
+		return nil, fmt.Errorf("too_many_simulated_guests: %d", total)
+	}
+
+	nodes, err := s.Nodes()
+	if err != nil {
+		return nil, err
+	}
+
+	return simulateCapacity(nodes, req), nil
+}
+
+func simulateCapacity(nodes []clusterModels.ClusterNode, req CapacitySimulationRequest) *CapacitySimulation {
+	overcommit := req.CPUOvercommit
+	if overcommit <= 0 {
+		overcommit = 1
+	}
+	keep := 1 - min(max(req.ReservePercent, 0), 100)/100
+
+	sim := &CapacitySimulation{
+		Nodes:        []CapacityNodeResult{},
+		Unplaced:     []CapacityUnplaced{},
+		SkippedNodes: []string{},
+	}
+
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Hostname < nodes[j].Hostname })
+
+	var pool []*placementNode
+	for _, node := range nodes {
+		if node.Status != nodeStatusOnline {
+			sim.SkippedNodes = append(sim.SkippedNodes, node.Hostname)
+			continue
+		}
+
+		capacity := [3]float64{
+			float64(node.CPU) * overcommit,
+			float64(node.Memory),
+			float64(node.Disk),
+		}
+		used := [3]float64{
+			capacity[0] * clampUsage(node.CPUUsage),
+			capacity[1] * clampUsage(node.MemoryUsage),
+			capacity[2] * clampUsage(node.DiskUsage),
+		}
+
+		var free [3]float64
+		for i := range free {
+			free[i] = max(capacity[i]*keep-used[i], 0)
+		}
+
+		pool = append(pool, &placementNode{
+			result: &CapacityNodeResult{
+				NodeUUID:   node.NodeUUID,
+				Hostname:   node.Hostname,
+				Capacity:   toCapacityResources(capacity),
+				FreeBefore: toCapacityResources(free),
+				Placed:     map[string]int{},
+			},
+			capacity: capacity,
+			free:     free,
+		})
+	}
+
+	// Place the largest guests first so small ones fill the gaps.
+	profiles := make([]CapacityProfile, len(req.Profiles))
+	copy(profiles, req.Profiles)
+	for i := range profiles {
+		if strings.TrimSpace(profiles[i].Name) == "" {
+			profiles[i].Name = fmt.Sprintf("profile-%d", i+1)
+		}
+	}
+	sort.SliceStable(profiles, func(i, j int) bool {
+		if profiles[i].Memory != profiles[j].Memory {
+			return profiles[i].Memory > profiles[j].Memory
+		}
+		return profiles[i].VCPUs > profiles[j].VCPUs
+	})
+
+	unplaced := map[string]*CapacityUnplaced{}
+	var unplacedOrder []string
+
+	for _, profile := range profiles {
+		need := [3]float64{float64(profile.VCPUs), float64(profile.Memory), float64(profile.Disk)}
+		sim.Requested += profile.Count
+
+		for n := 0; n < profile.Count; n++ {
+			var best *placementNode
+			bestScore := -1.0
+			shortBy := map[string]int{}
+
+			for _, candidate := range pool {
+				score, short, fits := scorePlacement(candidate.capacity, candidate.free, need)
+				if !fits {
+					shortBy[short]++
+					continue
+				}
+				if score > bestScore {
+					best, bestScore = candidate, score
+				}
+			}
+
+			if best == nil {
+				entry, ok := unplaced[profile.Name]
+				if !ok {
+					entry = &CapacityUnplaced{Profile: profile.Name, Resource: mostShort(shortBy)}
+					unplaced[profile.Name] = entry
+					unplacedOrder = append(unplacedOrder, profile.Name)
+				}
+				entry.Count++
+				continue
+			}
+
+			for i := range best.free {
+				best.free[i] -= need[i]
+			}
+			best.result.Placed[profile.Name]++
+			sim.Placed++
+		}
+	}
+
+	var clusterCapacity, clusterFree [3]float64
+	for _, node := range pool {
+		node.result.FreeAfter = toCapacityResources(node.free)
+		node.result.Bottleneck = tightestResource(node.capacity, node.free)
+		for i := range clusterCapacity {
+			clusterCapacity[i] += node.capacity[i]
+			clusterFree[i] += node.free[i]
+		}
+		sim.Nodes = append(sim.Nodes, *node.result)
+	}
+
+	for _, name := range unplacedOrder {
+		sim.Unplaced = append(sim.Unplaced, *unplaced[name])
+	}
+
+	sim.Fits = len(pool) > 0 && sim.Placed == sim.Requested
+	if len(sim.Unplaced) > 0 {
+		sim.Bottleneck = sim.Unplaced[0].Resource
+	} else if len(pool) > 0 {
+		sim.Bottleneck = tightestResource(clusterCapacity, clusterFree)
+	}
+
+	return sim
+}
+
+func clampUsage(percent float64) float64 {
+	return min(max(percent, 0), 100) / 100
+}
+
+func toCapacityResources(v [3]float64) CapacityResources {
+	return CapacityResources{
+		CPU:    math.Round(v[0]*100) / 100,
+		Memory: uint64(max(v[1], 0)),
+		Disk:   uint64(max(v[2], 0)),
+	}
+}
+
+// tightestResource is the resource with the smallest share left.
+func tightestResource(capacity, free [3]float64) string {
+	tightest := ""
+	lowest := math.Inf(1)
+	for i, resource := range capacityResources {
+		if capacity[i] <= 0 {
+			continue
+		}
+		if share := free[i] / capacity[i]; share < lowest {
+			tightest, lowest = resource, share
+		}
+	}
+	return tightest
+}
+
+func mostShort(shortBy map[string]int) string {
+	best := ""
+	for _, resource := range capacityResources {
+		if shortBy[resource] > shortBy[best] {
+			best = resource
+		}
+	}
+	return best
+}
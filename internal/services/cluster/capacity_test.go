@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package cluster
+
+import (
+	"testing"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+)
+
+const testGiB = 1 << 30
+
+func capacityTestNodes() []clusterModels.ClusterNode {
+	return []clusterModels.ClusterNode{
+		{NodeUUID: "b", Hostname: "node-b", Status: nodeStatusOnline, CPU: 8, CPUUsage: 50, Memory: 32 * testGiB, MemoryUsage: 50, Disk: 1000 * testGiB, DiskUsage: 10},
+		{NodeUUID: "a", Hostname: "node-a", Status: nodeStatusOnline, CPU: 16, Memory: 64 * testGiB, Disk: 1000 * testGiB},
+		{NodeUUID: "c", Hostname: "node-c", Status: nodeStatusOffline, CPU: 64, Memory: 512 * testGiB, Disk: 1000 * testGiB},
+	}
+}
+
+func TestSimulateCapacityPrefersHeadroomAndFits(t *testing.T) {
+	sim := simulateCapacity(capacityTestNodes(), CapacitySimulationRequest{
+		Profiles: []CapacityProfile{{Name: "small", Count: 4, VCPUs: 2, Memory: 4 * testGiB, Disk: 20 * testGiB}},
+	})
+
+	if !sim.Fits || sim.Requested != 4 || sim.Placed != 4 {
+		t.Fatalf("expected all guests to fit, got %+v", sim)
+	}
+	if len(sim.SkippedNodes) != 1 || sim.SkippedNodes[0] != "node-c" {
+		t.Fatalf("expected offline node to be skipped, got %v", sim.SkippedNodes)
+	}
+	if len(sim.Nodes) != 2 || sim.Nodes[0].Hostname != "node-a" {
+		t.Fatalf("unexpected node order %+v", sim.Nodes)
+	}
+	if sim.Nodes[0].Placed["small"] != 4 || sim.Nodes[1].Placed["small"] != 0 {
+		t.Fatalf("expected guests to land on the node with most headroom, got %+v", sim.Nodes)
+	}
+	if sim.Nodes[1].FreeBefore.CPU != 4 || sim.Nodes[1].FreeBefore.Memory != 16*testGiB {
+		t.Fatalf("unexpected free capacity on busy node %+v", sim.Nodes[1].FreeBefore)
+	}
+	if sim.Bottleneck != CapacityResourceCPU {
+		t.Fatalf("expected cpu to be the tightest resource, got %q", sim.Bottleneck)
+	}
+}
+
+func TestSimulateCapacityReportsBottleneck(t *testing.T) {
+	sim := simulateCapacity(capacityTestNodes(), CapacitySimulationRequest{
+		Profiles: []CapacityProfile{
+			{Name: "big", Count: 3, VCPUs: 2, Memory: 40 * testGiB},
+			{Count: 1, VCPUs: 1, Memory: testGiB},
+		},
+	})
+
+	if sim.Fits {
+		t.Fatalf("expected simulation not to fit, got %+v", sim)
+	}
+	if sim.Placed != 2 || len(sim.Unplaced) != 1 {
+		t.Fatalf("expected one big guest and the small one placed, got %+v", sim)
+	}
+	if sim.Unplaced[0].Profile != "big" || sim.Unplaced[0].Count != 2 || sim.Unplaced[0].Resource != CapacityResourceMemory {
+		t.Fatalf("unexpected unplaced entry %+v", sim.Unplaced[0])
+	}
+	if sim.Bottleneck != CapacityResourceMemory {
+		t.Fatalf("expected memory bottleneck, got %q", sim.Bottleneck)
+	}
+	if sim.Nodes[0].Placed["profile-2"]+sim.Nodes[1].Placed["profile-2"] != 1 {
+		t.Fatalf("expected unnamed profile to get a generated name, got %+v", sim.Nodes)
+	}
+}
+
+func TestSimulateCapacityOvercommitAndReserve(t *testing.T) {
+	nodes := []clusterModels.ClusterNode{
+		{NodeUUID: "a", Hostname: "node-a", Status: nodeStatusOnline, CPU: 4, Memory: 100 * testGiB, Disk: 100 * testGiB},
+	}
+
+	sim := simulateCapacity(nodes, CapacitySimulationRequest{
+		Profiles: []CapacityProfile{{Name: "vm", Count: 8, VCPUs: 1, Memory: testGiB}},
+	})
+	if sim.Placed != 4 || sim.Unplaced[0].Resource != CapacityResourceCPU {
+		t.Fatalf("expected four guests without overcommit, got %+v", sim)
+	}
+
+	sim = simulateCapacity(nodes, CapacitySimulationRequest{
+		Profiles:       []CapacityProfile{{Name: "vm", Count: 8, VCPUs: 1, Memory: testGiB}},
+		CPUOvercommit:  2,
+		ReservePercent: 25,
+	})
+	if sim.Placed != 6 {
+		t.Fatalf("expected six guests with 2x overcommit and 25%% reserve, got %d", sim.Placed)
+	}
+}
+
+func TestSimulateCapacityWithoutOnlineNodes(t *testing.T) {
+	sim := simulateCapacity(nil, CapacitySimulationRequest{
+		Profiles: []CapacityProfile{{Name: "vm", Count: 1, VCPUs: 1, Memory: testGiB}},
+	})
+	if sim.Fits || sim.Placed != 0 || len(sim.Unplaced) != 1 {
+		t.Fatalf("expected nothing to fit, got %+v", sim)
+	}
+}
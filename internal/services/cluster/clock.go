@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/config"
+	"github.com/alchemillahq/sylve/internal/db/models"
+	infoServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/info"
+	"github.com/alchemillahq/sylve/internal/logger"
+	notifier "github.com/alchemillahq/sylve/internal/notifications"
+	"github.com/alchemillahq/sylve/pkg/utils"
+)
+
+const (
+	ClockStatusOK       = "ok"
+	ClockStatusWarning  = "warning"
+	ClockStatusCritical = "critical"
+	ClockStatusUnknown  = "unknown"
+
+	defaultClockSkewWarnMs     = 500
+	defaultClockSkewCriticalMs = 2000
+
+	// A steady clock jitters by a few milliseconds between probes; only a
+	// real move is worth a cluster node write.
+	clockSkewChangeThresholdMs = 100
+
+	clockSkewKindPrefix = "cluster.clock_skew."
+)
+
+func clockSkewThresholds() (warn, critical time.Duration) {
+	warnMs, criticalMs := defaultClockSkewWarnMs, defaultClockSkewCriticalMs
+	if config.ParsedConfig != nil {
+		if v := config.ParsedConfig.Cluster.ClockSkewWarnMs; v > 0 {
+			warnMs = v
+		}
+		if v := config.ParsedConfig.Cluster.ClockSkewCriticalMs; v > 0 {
+			criticalMs = v
+		}
+	}
+	if criticalMs < warnMs {
+		criticalMs = warnMs
+	}
+	return time.Duration(warnMs) * time.Millisecond, time.Duration(criticalMs) * time.Millisecond
+}
+
+// ClockOffset estimates how far a peer's clock is ahead of ours from the
+// four NTP timestamps: t0 when we sent, t1 and t2 when the peer received
+// and replied, t3 when we got the answer. Assumes a symmetric path, so the
+// error is at most half the network round trip.
+func ClockOffset(t0, t1, t2, t3 time.Time) time.Duration {
+	return (t1.Sub(t0) + t2.Sub(t3)) / 2
+}
+
+// ClockSkewStatus grades an offset against the configured thresholds.
+func ClockSkewStatus(offset time.Duration) string {
+	if offset < 0 {
+		offset = -offset
+	}
+	warn, critical := clockSkewThresholds()
+	switch {
+	case offset >= critical:
+		return ClockStatusCritical
+	case offset >= warn:
+		return ClockStatusWarning
+	default:
+		return ClockStatusOK
+	}
+}
+
+// RefuseJoinOnClockSkew reports whether this node is configured to stay out
+// of a cluster whose leader disagrees with it about the time.
+func RefuseJoinOnClockSkew() bool {
+	return config.ParsedConfig != nil && config.ParsedConfig.Cluster.RefuseJoinOnClockSkew
+}
+
+// nodeClockStatus grades a probed node. A node whose time daemon is not
+// synchronised is a warning even while its skew is small, since nothing
+// keeps it from drifting.
+func nodeClockStatus(cur curInfo) string {
+	if !cur.clockMeasured {
+		return ClockStatusUnknown
+	}
+	status := ClockSkewStatus(time.Duration(cur.clockSkewMs) * time.Millisecond)
+	if status == ClockStatusOK && !cur.ntpSynced {
+		return ClockStatusWarning
+	}
+	return status
+}
+
+func (s *Service) GetNodeClock(host string, port int, clusterToken string) (infoServiceInterfaces.ClockInfo, time.Duration, error) {
+	var clock infoServiceInterfaces.ClockInfo
+
+	url := fmt.Sprintf("https://%s/api/info/clock", net.JoinHostPort(unbracketHost(host), strconv.Itoa(port)))
+	sentAt := time.Now()
+	body, _, err := utils.HTTPGetJSONRead(
+		url,
+		map[string]string{
+			"Accept":          "application/json",
+			"X-Cluster-Token": fmt.Sprintf("Bearer %s", clusterToken),
+		},
+	)
+	receivedAt := time.Now()
+	if err != nil {
+		return clock, 0, err
+	}
+
+	var resp internal.APIResponse[infoServiceInterfaces.ClockInfo]
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return clock, 0, err
+	}
+	if resp.Status != "success" || resp.Data.ReceivedAt == 0 || resp.Data.SentAt == 0 {
+		return clock, 0, fmt.Errorf("failed_to_fetch_node_clock")
+	}
+
+	clock = resp.Data
+	offset := ClockOffset(sentAt, time.UnixMilli(clock.ReceivedAt), time.UnixMilli(clock.SentAt), receivedAt)
+	return clock, offset, nil
+}
+
+// reportClockStatus logs and notifies when a node's clock status changes,
+// so a drifting member is loud once instead of on every health cycle.
+func (s *Service) reportClockStatus(current map[string]curInfo) {
+	s.clockStatusMu.Lock()
+	defer s.clockStatusMu.Unlock()
+
+	if s.clockStatusByNode == nil {
+		s.clockStatusByNode = make(map[string]string, len(current))
+	}
+
+	for nodeUUID, cur := range current {
+		status := nodeClockStatus(cur)
+		previous, seen := s.clockStatusByNode[nodeUUID]
+		if status == ClockStatusUnknown {
+			continue
+		}
+		s.clockStatusByNode[nodeUUID] = status
+		if status == previous || (!seen && status == ClockStatusOK) {
+			continue
+		}
+
+		event := logger.L.Warn()
+		if status == ClockStatusOK {
+			event = logger.L.Info()
+		} else if status == ClockStatusCritical {
+			event = logger.L.Error()
+		}
+		event.
+			Str("node_uuid", nodeUUID).
+			Str("host", preferredHostname(cur)).
+			Int64("clock_skew_ms", cur.clockSkewMs).
+			Str("ntp_source", cur.ntpSource).
+			Bool("ntp_synced", cur.ntpSynced).
+			Str("clock_status", status).
+			Msg("cluster node clock status changed")
+
+		if _, err := notifier.Emit(context.Background(), clockStatusNotification(nodeUUID, cur, status)); err != nil {
+			logger.L.Debug().Err(err).Str("node_uuid", nodeUUID).Msg("failed to emit clock status notification")
+		}
+	}
+}
+
+func clockStatusNotification(nodeUUID string, cur curInfo, status string) notifier.EventInput {
+	host := preferredHostname(cur)
+	_, critical := clockSkewThresholds()
+
+	title := fmt.Sprintf("Clock on %s is off by %dms", host, cur.clockSkewMs)
+	body := fmt.Sprintf(
+		"Node %s's clock differs from the leader's by %dms. Raft, cluster tokens and snapshot ordering assume clocks within %dms.",
+		host, cur.clockSkewMs, critical.Milliseconds(),
+	)
+	severity := models.NotificationSeverityWarning
+	switch {
+	case status == ClockStatusCritical:
+		severity = models.NotificationSeverityCritical
+	case status == ClockStatusOK:
+		title = fmt.Sprintf("Clock on %s is back in sync", host)
+		body = fmt.Sprintf("Node %s's clock is within %dms of the leader's again.", host, cur.clockSkewMs)
+		severity = models.NotificationSeverityInfo
+	case !cur.ntpSynced && ClockSkewStatus(time.Duration(cur.clockSkewMs)*time.Millisecond) == ClockStatusOK:
+		title = fmt.Sprintf("Clock on %s is not synchronised", host)
+		body = fmt.Sprintf("Node %s has no synchronised NTP source, so its clock is free to drift from the rest of the cluster.", host)
+	}
+
+	return notifier.EventInput{
+		Kind:        clockSkewKindPrefix + nodeUUID,
+		Title:       title,
+		Body:        body,
+		Severity:    string(severity),
+		Source:      "cluster.clock",
+		Fingerprint: fmt.Sprintf("%s|%s", nodeUUID, status),
+		Metadata: map[string]string{
+			"node_uuid":     nodeUUID,
+			"hostname":      host,
+			"clock_skew_ms": strconv.FormatInt(cur.clockSkewMs, 10),
+			"ntp_source":    cur.ntpSource,
+			"ntp_synced":    strconv.FormatBool(cur.ntpSynced),
+			"status":        status,
+		},
+	}
+}
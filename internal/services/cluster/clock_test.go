@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/config"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+)
+
+func TestClockOffsetCancelsSymmetricDelay(t *testing.T) {
+	t0 := time.UnixMilli(1_000_000)
+	// The peer runs 300ms ahead; each leg takes 40ms and it spends 500ms
+	// answering, none of which should show up as skew.
+	t1 := t0.Add(40*time.Millisecond + 300*time.Millisecond)
+	t2 := t1.Add(500 * time.Millisecond)
+	t3 := t0.Add(580 * time.Millisecond)
+
+	if got := ClockOffset(t0, t1, t2, t3); got != 300*time.Millisecond {
+		t.Fatalf("offset = %v, want 300ms", got)
+	}
+	if got := ClockOffset(t0, t0.Add(-960*time.Millisecond), t0.Add(-960*time.Millisecond), t0.Add(80*time.Millisecond)); got != -time.Second {
+		t.Fatalf("offset = %v, want -1s", got)
+	}
+}
+
+func TestNodeClockStatusFollowsThresholds(t *testing.T) {
+	previous := config.ParsedConfig
+	t.Cleanup(func() { config.ParsedConfig = previous })
+	config.ParsedConfig = &internal.SylveConfig{
+		Cluster: internal.ClusterConfig{ClockSkewWarnMs: 100, ClockSkewCriticalMs: 1000},
+	}
+
+	cases := []struct {
+		name string
+		cur  curInfo
+		want string
+	}{
+		{"not probed", curInfo{}, ClockStatusUnknown},
+		{"in sync", curInfo{clockMeasured: true, clockSkewMs: 20, ntpSynced: true}, ClockStatusOK},
+		{"unsynchronised daemon", curInfo{clockMeasured: true, clockSkewMs: 20}, ClockStatusWarning},
+		{"behind", curInfo{clockMeasured: true, clockSkewMs: -150, ntpSynced: true}, ClockStatusWarning},
+		{"far ahead", curInfo{clockMeasured: true, clockSkewMs: 1500, ntpSynced: true}, ClockStatusCritical},
+	}
+	for _, tc := range cases {
+		if got := nodeClockStatus(tc.cur); got != tc.want {
+			t.Errorf("%s: status = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+
+	config.ParsedConfig = &internal.SylveConfig{}
+	if got := ClockSkewStatus(1500 * time.Millisecond); got != ClockStatusWarning {
+		t.Fatalf("default thresholds: status = %q, want warning", got)
+	}
+}
+
+func TestHasSignificantChangeOnClock(t *testing.T) {
+	cur := curInfo{
+		api: "10.0.0.1:8184", canonHost: "host", healthOK: true,
+		clockMeasured: true, clockSkewMs: 40, ntpSource: "ntpd", ntpSynced: true,
+	}
+	ex := clusterModels.ClusterNode{
+		Status: "online", API: "10.0.0.1:8184", Hostname: "host",
+		ClockStatus: ClockStatusOK, ClockSkewMs: 10, NTPSource: "ntpd", NTPSynced: true,
+	}
+
+	if hasSignificantChange(cur, ex) {
+		t.Fatal("clock jitter should not be significant")
+	}
+
+	drifted := cur
+	drifted.clockSkewMs = 200
+	if !hasSignificantChange(drifted, ex) {
+		t.Fatal("a real clock move should be significant")
+	}
+
+	unsynced := cur
+	unsynced.ntpSynced = false
+	if !hasSignificantChange(unsynced, ex) {
+		t.Fatal("losing NTP sync should be significant")
+	}
+}
@@ -9,14 +9,19 @@
 package cluster
 
 import (
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/alchemillahq/sylve/internal/config"
 	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
 	serviceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services"
 	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
 	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
@@ -24,6 +29,7 @@ import (
 	"github.com/alchemillahq/sylve/pkg/network"
 	"github.com/alchemillahq/sylve/pkg/utils"
 	"github.com/hashicorp/raft"
+	"golang.org/x/crypto/ssh"
 	"gorm.io/gorm"
 )
 
@@ -43,9 +49,35 @@ type Service struct {
 	peerProbeMu            sync.Mutex
 	peerProbeFailureStreak map[string]int
 
+	clockStatusMu     sync.Mutex
+	clockStatusByNode map[string]string
+
+	configDriftMu     sync.Mutex
+	configDriftReport *ConfigDriftReport
+	configDriftByNode map[string]string
+
 	embeddedSSHOnce sync.Once
 	monitorOnce     sync.Once
 
+	embeddedSSHMu            sync.Mutex
+	embeddedSSHCtx           context.Context
+	embeddedSSHConfig        *ssh.ServerConfig
+	embeddedSSHReplicationLn net.Listener
+
+	// sshIdentityMu keeps a routine identity republish from publishing the
+	// old key while a rotation is swapping it.
+	sshIdentityMu sync.Mutex
+
+	// replicationTemplateMu serializes template enrollment so a periodic
+	// pass and one kicked off by a template edit never race to create the
+	// same guest's policy.
+	replicationTemplateMu sync.Mutex
+
+	// clusterTLSMu serializes CA creation, rotation and certificate
+	// renewal; nodeTLSCert is what the intra-cluster listener serves.
+	clusterTLSMu sync.Mutex
+	nodeTLSCert  atomic.Pointer[tls.Certificate]
+
 	clusterStartHook func(ip string) error
 
 	guestIdentityInventoryAPIForNode func(string, raft.ServerAddress) (string, error)
@@ -209,9 +241,10 @@ func (s *Service) backfillPreClusterState() error {
 
 		for _, o := range opts {
 			payloadStruct := struct {
-				ID             uint   `json:"id"`
-				KeyboardLayout string `json:"keyboardLayout"`
-			}{ID: o.ID, KeyboardLayout: o.KeyboardLayout}
+				ID                    uint   `json:"id"`
+				KeyboardLayout        string `json:"keyboardLayout"`
+				RestoreOverwriteGroup string `json:"restoreOverwriteGroup"`
+			}{ID: o.ID, KeyboardLayout: o.KeyboardLayout, RestoreOverwriteGroup: o.RestoreOverwriteGroup}
 
 			data, _ := json.Marshal(payloadStruct)
 			cmd := clusterModels.Command{Type: "options", Action: "set", Data: data}
@@ -244,39 +277,53 @@ func (s *Service) backfillPreClusterState() error {
 
 		for _, j := range jobs {
 			payloadStruct := struct {
-				ID               uint       `json:"id"`
-				Name             string     `json:"name"`
-				TargetID         uint       `json:"targetId"`
-				RunnerNodeID     string     `json:"runnerNodeId"`
-				Mode             string     `json:"mode"`
-				SourceDataset    string     `json:"sourceDataset"`
-				JailRootDataset  string     `json:"jailRootDataset"`
-				FriendlySrc      string     `json:"friendlySrc"`
-				DestSuffix       string     `json:"destSuffix"`
-				PruneKeepLast    int        `json:"pruneKeepLast"`
-				PruneTarget      bool       `json:"pruneTarget"`
-				StopBeforeBackup bool       `json:"stopBeforeBackup"`
-				Recursive        bool       `json:"recursive"`
-				CronExpr         string     `json:"cronExpr"`
-				Enabled          bool       `json:"enabled"`
-				NextRunAt        *time.Time `json:"nextRunAt"`
+				ID                 uint       `json:"id"`
+				Name               string     `json:"name"`
+				TargetID           uint       `json:"targetId"`
+				RunnerNodeID       string     `json:"runnerNodeId"`
+				Mode               string     `json:"mode"`
+				SourceDataset      string     `json:"sourceDataset"`
+				JailRootDataset    string     `json:"jailRootDataset"`
+				FriendlySrc        string     `json:"friendlySrc"`
+				DestSuffix         string     `json:"destSuffix"`
+				PruneKeepLast      int        `json:"pruneKeepLast"`
+				PruneTarget        bool       `json:"pruneTarget"`
+				StopBeforeBackup   bool       `json:"stopBeforeBackup"`
+				Recursive          bool       `json:"recursive"`
+				ShareQuiesce       string     `json:"shareQuiesce"`
+				VMDatasetIncludes  string     `json:"vmDatasetIncludes"`
+				VMDatasetExcludes  string     `json:"vmDatasetExcludes"`
+				PreBackupScript    string     `json:"preBackupScript"`
+				PostBackupScript   string     `json:"postBackupScript"`
+				HookTimeoutSeconds int        `json:"hookTimeoutSeconds"`
+				HookFailurePolicy  string     `json:"hookFailurePolicy"`
+				CronExpr           string     `json:"cronExpr"`
+				Enabled            bool       `json:"enabled"`
+				NextRunAt          *time.Time `json:"nextRunAt"`
 			}{
-				ID:               j.ID,
-				Name:             j.Name,
-				TargetID:         j.TargetID,
-				RunnerNodeID:     j.RunnerNodeID,
-				Mode:             j.Mode,
-				SourceDataset:    j.SourceDataset,
-				JailRootDataset:  j.JailRootDataset,
-				FriendlySrc:      j.FriendlySrc,
-				DestSuffix:       j.DestSuffix,
-				PruneKeepLast:    j.PruneKeepLast,
-				PruneTarget:      j.PruneTarget,
-				StopBeforeBackup: j.StopBeforeBackup,
-				Recursive:        j.Recursive,
-				CronExpr:         j.CronExpr,
-				Enabled:          j.Enabled,
-				NextRunAt:        j.NextRunAt,
+				ID:                 j.ID,
+				Name:               j.Name,
+				TargetID:           j.TargetID,
+				RunnerNodeID:       j.RunnerNodeID,
+				Mode:               j.Mode,
+				SourceDataset:      j.SourceDataset,
+				JailRootDataset:    j.JailRootDataset,
+				FriendlySrc:        j.FriendlySrc,
+				DestSuffix:         j.DestSuffix,
+				PruneKeepLast:      j.PruneKeepLast,
+				PruneTarget:        j.PruneTarget,
+				StopBeforeBackup:   j.StopBeforeBackup,
+				Recursive:          j.Recursive,
+				ShareQuiesce:       j.ShareQuiesce,
+				VMDatasetIncludes:  j.VMDatasetIncludes,
+				VMDatasetExcludes:  j.VMDatasetExcludes,
+				PreBackupScript:    j.PreBackupScript,
+				PostBackupScript:   j.PostBackupScript,
+				HookTimeoutSeconds: j.HookTimeoutSeconds,
+				HookFailurePolicy:  j.HookFailurePolicy,
+				CronExpr:           j.CronExpr,
+				Enabled:            j.Enabled,
+				NextRunAt:          j.NextRunAt,
 			}
 
 			data, _ := json.Marshal(payloadStruct)
@@ -350,6 +397,21 @@ func (s *Service) backfillPreClusterState() error {
 		}
 	}
 
+	{
+		var windows []clusterModels.MaintenanceWindow
+		if err := s.DB.Order("id ASC").Find(&windows).Error; err != nil {
+			return fmt.Errorf("scan_existing_maintenance_windows: %w", err)
+		}
+
+		for _, w := range windows {
+			data, _ := json.Marshal(w)
+			cmd := clusterModels.Command{Type: "maintenance_window", Action: "create", Data: data}
+			if err := s.Raft.Apply(utils.MustJSON(cmd), 5*time.Second).Error(); err != nil {
+				return fmt.Errorf("apply_synth_create_maintenance_window id=%d: %w", w.ID, err)
+			}
+		}
+	}
+
 	{
 		var events []clusterModels.ReplicationEvent
 		if err := s.DB.Order("id ASC").Find(&events).Error; err != nil {
@@ -415,6 +477,10 @@ func (s *Service) CreateCluster(ip string, fsm raft.FSM) error {
 	if s.Raft != nil {
 		return errors.New("raft_already_initialized")
 	}
+	ip, err := NormalizeClusterIP(ip)
+	if err != nil {
+		return err
+	}
 	localNodeID := s.guestIdentityInventoryLocalNodeID()
 	if localNodeID == "" {
 		return errors.New("local_node_id_unavailable")
@@ -447,7 +513,7 @@ func (s *Service) CreateCluster(ip string, fsm raft.FSM) error {
 	bootstrap := true
 	newKey := c.Key
 	if newKey == "" {
-		newKey = utils.GenerateRandomString(32)
+		newKey = secrets.String(utils.GenerateRandomString(32))
 	}
 
 	if _, err := s.setupRaftAtIP(true, fsm, ip); err != nil {
@@ -488,6 +554,10 @@ func (s *Service) CreateCluster(ip string, fsm raft.FSM) error {
 		logger.L.Warn().Err(err).Msg("Cluster SSH identity publish deferred during cluster creation")
 	}
 
+	if err := s.RefreshClusterTLS(); err != nil {
+		logger.L.Warn().Err(err).Msg("Cluster CA setup deferred during cluster creation")
+	}
+
 	if err := s.triggerClusterStart(ip); err != nil {
 		logger.L.Error().Err(err).Str("ip", ip).Msg("cluster_listener_start_failed")
 	}
@@ -514,8 +584,9 @@ func (s *Service) rollbackJoinPreparation(
 }
 
 func (s *Service) StartAsJoiner(fsm raft.FSM, ip, clusterKey string) error {
-	if !utils.IsValidIP(ip) {
-		return errors.New("invalid_ip_address")
+	ip, err := NormalizeClusterIP(ip)
+	if err != nil {
+		return err
 	}
 
 	port := ClusterRaftPort
@@ -526,7 +597,7 @@ func (s *Service) StartAsJoiner(fsm raft.FSM, ip, clusterKey string) error {
 	if c.Enabled {
 		if c.RaftIP == ip &&
 			c.RaftPort == port &&
-			c.Key == clusterKey &&
+			string(c.Key) == clusterKey &&
 			s.Raft != nil &&
 			s.Raft.State() != raft.Shutdown {
 			if err := s.triggerClusterStart(ip); err != nil {
@@ -566,7 +637,7 @@ func (s *Service) StartAsJoiner(fsm raft.FSM, ip, clusterKey string) error {
 	c.RaftIP = ip
 	c.RaftPort = port
 	c.Enabled = true
-	c.Key = clusterKey
+	c.Key = secrets.String(clusterKey)
 	if err := s.DB.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Save(&c).Error; err != nil {
 			return err
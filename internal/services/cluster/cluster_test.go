@@ -228,6 +228,9 @@ func TestResyncClusterStateErrors(t *testing.T) {
 			&clusterModels.ReplicationPolicy{}, &clusterModels.ReplicationPolicyTarget{},
 			&clusterModels.ReplicationLease{}, &clusterModels.ClusterSSHIdentity{},
 			&clusterModels.EncryptionKey{}, &clusterModels.ReplicationEvent{},
+			&clusterModels.MaintenanceWindow{}, &clusterModels.ZeltaProfile{},
+			&clusterModels.ReplicationPolicyTemplate{},
+			&clusterModels.ClusterCA{}, &clusterModels.ClusterNodeCertificate{},
 		)
 		defer cleanupClusterRaftTestNodes(t, nodes)
 
@@ -256,6 +259,11 @@ func TestBackfillPreClusterState(t *testing.T) {
 		&clusterModels.ClusterSSHIdentity{},
 		&clusterModels.EncryptionKey{},
 		&clusterModels.ReplicationEvent{},
+		&clusterModels.MaintenanceWindow{},
+		&clusterModels.ZeltaProfile{},
+		&clusterModels.ReplicationPolicyTemplate{},
+		&clusterModels.ClusterCA{},
+		&clusterModels.ClusterNodeCertificate{},
 	}
 
 	nodes := setupClusterRaftTestNodes(t, 2, allModels...)
@@ -0,0 +1,750 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package cluster
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/config"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	clusterTLSDirName      = "cluster/tls"
+	clusterTLSKeyFileName  = "node.key"
+	clusterTLSCertFileName = "node.crt"
+
+	clusterCAValidity          = 10 * 365 * 24 * time.Hour
+	clusterNodeCertValidity    = 365 * 24 * time.Hour
+	clusterNodeCertRenewBefore = 30 * 24 * time.Hour
+	clusterTLSCheckInterval    = time.Minute
+
+	// clusterCertBackdate absorbs clock skew between the leader that signs
+	// a certificate and the peers that verify it.
+	clusterCertBackdate = 5 * time.Minute
+)
+
+type ClusterTLSNodeStatus struct {
+	NodeUUID      string     `json:"nodeUUID"`
+	Hostname      string     `json:"hostname"`
+	Issued        bool       `json:"issued"`
+	Current       bool       `json:"current"`
+	CAFingerprint string     `json:"caFingerprint"`
+	NotAfter      *time.Time `json:"notAfter"`
+}
+
+type ClusterTLSStatus struct {
+	Configured          bool                   `json:"configured"`
+	Fingerprint         string                 `json:"fingerprint"`
+	NotAfter            *time.Time             `json:"notAfter"`
+	PreviousFingerprint string                 `json:"previousFingerprint"`
+	RotatedAt           *time.Time             `json:"rotatedAt"`
+	Enforce             bool                   `json:"enforce"`
+	Ready               bool                   `json:"ready"`
+	Nodes               []ClusterTLSNodeStatus `json:"nodes"`
+}
+
+func certFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return "SHA256:" + hex.EncodeToString(sum[:])
+}
+
+func randomCertSerial() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 127))
+}
+
+func encodeECKeyPEM(key *ecdsa.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), nil
+}
+
+func parseCertPEM(certPEM string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(certPEM))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("invalid_certificate_pem")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// generateClusterCA creates a self-signed CA that may only sign leaf
+// certificates.
+func generateClusterCA(now time.Time) (*clusterModels.ClusterCA, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("cluster_ca_keygen_failed: %w", err)
+	}
+
+	serial, err := randomCertSerial()
+	if err != nil {
+		return nil, fmt.Errorf("cluster_ca_serial_failed: %w", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "Sylve Cluster CA", Organization: []string{"Sylve"}},
+		NotBefore:             now.Add(-clusterCertBackdate),
+		NotAfter:              now.Add(clusterCAValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("cluster_ca_create_failed: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("cluster_ca_create_failed: %w", err)
+	}
+
+	keyPEM, err := encodeECKeyPEM(key)
+	if err != nil {
+		return nil, fmt.Errorf("cluster_ca_create_failed: %w", err)
+	}
+
+	return &clusterModels.ClusterCA{
+		ID:          clusterModels.ClusterCAID,
+		CertPEM:     string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		KeyPEM:      secrets.String(keyPEM),
+		Fingerprint: certFingerprint(cert),
+		NotAfter:    cert.NotAfter,
+	}, nil
+}
+
+func parseClusterCA(ca *clusterModels.ClusterCA) (*x509.Certificate, crypto.Signer, error) {
+	cert, err := parseCertPEM(ca.CertPEM)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cluster_ca_invalid: %w", err)
+	}
+
+	block, _ := pem.Decode([]byte(ca.KeyPEM))
+	if block == nil {
+		return nil, nil, fmt.Errorf("cluster_ca_key_invalid")
+	}
+	key, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cluster_ca_key_invalid: %w", err)
+	}
+
+	return cert, key, nil
+}
+
+// clusterTrustPool holds the current CA and, during a rotation, the one it
+// replaced so nodes that have not renewed yet are still reachable.
+func clusterTrustPool(ca *clusterModels.ClusterCA) *x509.CertPool {
+	pool := x509.NewCertPool()
+	for _, certPEM := range []string{ca.CertPEM, ca.PreviousCertPEM} {
+		if cert, err := parseCertPEM(certPEM); err == nil {
+			pool.AddCert(cert)
+		}
+	}
+	return pool
+}
+
+func newNodeCSR(key *ecdsa.PrivateKey, nodeUUID string) (string, error) {
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: nodeUUID},
+	}, key)
+	if err != nil {
+		return "", fmt.Errorf("cluster_csr_create_failed: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})), nil
+}
+
+// signNodeCSR issues a node certificate for the key in csrPEM. The SAN is the
+// address the node has in the Raft configuration; whatever the request asks
+// for beyond its public key and node ID is ignored.
+func signNodeCSR(
+	caCert *x509.Certificate,
+	caKey crypto.Signer,
+	csrPEM string,
+	nodeUUID string,
+	ip net.IP,
+	now time.Time,
+) (*clusterModels.ClusterNodeCertificate, error) {
+	block, _ := pem.Decode([]byte(csrPEM))
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return nil, fmt.Errorf("invalid_csr")
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid_csr: %w", err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("invalid_csr_signature: %w", err)
+	}
+	if csr.Subject.CommonName != nodeUUID {
+		return nil, fmt.Errorf("csr_node_mismatch")
+	}
+
+	serial, err := randomCertSerial()
+	if err != nil {
+		return nil, fmt.Errorf("cluster_cert_serial_failed: %w", err)
+	}
+
+	notAfter := now.Add(clusterNodeCertValidity)
+	if notAfter.After(caCert.NotAfter) {
+		notAfter = caCert.NotAfter
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: nodeUUID, Organization: []string{"Sylve"}},
+		NotBefore:    now.Add(-clusterCertBackdate),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{ip},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, csr.PublicKey, caKey)
+	if err != nil {
+		return nil, fmt.Errorf("cluster_cert_sign_failed: %w", err)
+	}
+
+	return &clusterModels.ClusterNodeCertificate{
+		NodeUUID:      nodeUUID,
+		CertPEM:       string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		Serial:        serial.Text(16),
+		CAFingerprint: certFingerprint(caCert),
+		NotAfter:      notAfter,
+		IssuedAt:      now,
+	}, nil
+}
+
+// nodeCertCurrent reports whether cert can keep serving: it must chain to the
+// current CA, name ip, and not be inside the renewal window.
+func nodeCertCurrent(cert, caCert *x509.Certificate, ip string, now time.Time) bool {
+	if cert == nil || caCert == nil || now.After(cert.NotAfter) {
+		return false
+	}
+	if cert.CheckSignatureFrom(caCert) != nil {
+		return false
+	}
+	// A certificate capped at the CA's own expiry cannot be renewed any
+	// further; only the CA rotation replaces it.
+	if now.Add(clusterNodeCertRenewBefore).After(cert.NotAfter) && caCert.NotAfter.After(cert.NotAfter) {
+		return false
+	}
+
+	want := net.ParseIP(ip)
+	for _, addr := range cert.IPAddresses {
+		if want != nil && addr.Equal(want) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Service) clusterTLSDir() (string, error) {
+	dataPath, err := config.GetDataPath()
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dataPath, clusterTLSDirName)
+	if err := os.MkdirAll(path, 0700); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func (s *Service) ensureLocalClusterTLSKey(dir string) (*ecdsa.PrivateKey, error) {
+	keyPath := filepath.Join(dir, clusterTLSKeyFileName)
+	if raw, err := os.ReadFile(keyPath); err == nil {
+		if block, _ := pem.Decode(raw); block != nil {
+			if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+				return key, nil
+			}
+		}
+		logger.L.Warn().Str("path", keyPath).Msg("Replacing unreadable cluster TLS key")
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("cluster_tls_keygen_failed: %w", err)
+	}
+	keyPEM, err := encodeECKeyPEM(key)
+	if err != nil {
+		return nil, fmt.Errorf("cluster_tls_keygen_failed: %w", err)
+	}
+	if err := os.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		return nil, fmt.Errorf("cluster_tls_key_write_failed: %w", err)
+	}
+	return key, nil
+}
+
+func (s *Service) loadClusterCA() (*clusterModels.ClusterCA, error) {
+	var ca clusterModels.ClusterCA
+	if err := s.DB.First(&ca, clusterModels.ClusterCAID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ca, nil
+}
+
+func (s *Service) proposeClusterCA(ca *clusterModels.ClusterCA) error {
+	data, err := json.Marshal(ca)
+	if err != nil {
+		return fmt.Errorf("failed_to_marshal_cluster_ca_payload: %w", err)
+	}
+	if err := s.applyRaftCommand(clusterModels.Command{
+		Type:   "cluster_ca",
+		Action: "upsert",
+		Data:   data,
+	}); err != nil {
+		return err
+	}
+
+	s.applyClusterTrust(ca)
+	return nil
+}
+
+func (s *Service) applyClusterTrust(ca *clusterModels.ClusterCA) {
+	if ca == nil {
+		utils.SetIntraClusterTrust(nil, false)
+		return
+	}
+	utils.SetIntraClusterTrust(clusterTrustPool(ca), ca.Enforce)
+}
+
+// raftMemberIPs maps each voter's node ID to the address peers reach it on.
+func (s *Service) raftMemberIPs() (map[string]string, error) {
+	if s.Raft == nil {
+		return nil, fmt.Errorf("raft_not_initialized")
+	}
+	future := s.Raft.GetConfiguration()
+	if err := future.Error(); err != nil {
+		return nil, err
+	}
+
+	members := make(map[string]string)
+	for _, server := range future.Configuration().Servers {
+		members[string(server.ID)] = raftAddressHost(string(server.Address))
+	}
+	return members, nil
+}
+
+// staleClusterNodeCertificates returns the members without a certificate
+// from the current CA.
+func (s *Service) staleClusterNodeCertificates(ca *clusterModels.ClusterCA) ([]string, error) {
+	members, err := s.raftMemberIPs()
+	if err != nil {
+		return nil, err
+	}
+
+	var certs []clusterModels.ClusterNodeCertificate
+	if err := s.DB.Find(&certs).Error; err != nil {
+		return nil, err
+	}
+	current := make(map[string]bool, len(certs))
+	for _, cert := range certs {
+		current[cert.NodeUUID] = cert.CAFingerprint == ca.Fingerprint
+	}
+
+	var stale []string
+	for nodeID := range members {
+		if !current[nodeID] {
+			stale = append(stale, nodeID)
+		}
+	}
+	sort.Strings(stale)
+	return stale, nil
+}
+
+// IssueNodeCertificate signs a member's certificate request with the
+// cluster CA and records the result through Raft. Only the leader signs.
+func (s *Service) IssueNodeCertificate(req clusterServiceInterfaces.ClusterTLSSignReq) (*clusterModels.ClusterNodeCertificate, error) {
+	if !s.LocalNodeIsLeader() {
+		return nil, fmt.Errorf("not_leader")
+	}
+
+	nodeUUID := strings.TrimSpace(req.NodeUUID)
+	members, err := s.raftMemberIPs()
+	if err != nil {
+		return nil, err
+	}
+	ip := net.ParseIP(unbracketHost(members[nodeUUID]))
+	if ip == nil {
+		return nil, fmt.Errorf("node_not_cluster_member")
+	}
+
+	ca, err := s.loadClusterCA()
+	if err != nil {
+		return nil, err
+	}
+	if ca == nil {
+		return nil, fmt.Errorf("cluster_ca_not_configured")
+	}
+	caCert, caKey, err := parseClusterCA(ca)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := signNodeCSR(caCert, caKey, req.CSR, nodeUUID, ip, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(cert)
+	if err != nil {
+		return nil, fmt.Errorf("failed_to_marshal_cluster_node_certificate_payload: %w", err)
+	}
+	if err := s.applyRaftCommand(clusterModels.Command{
+		Type:   "cluster_node_certificate",
+		Action: "upsert",
+		Data:   data,
+	}); err != nil {
+		return nil, err
+	}
+
+	return cert, nil
+}
+
+func (s *Service) requestNodeCertificate(req clusterServiceInterfaces.ClusterTLSSignReq) (string, error) {
+	if s.LocalNodeIsLeader() {
+		cert, err := s.IssueNodeCertificate(req)
+		if err != nil {
+			return "", err
+		}
+		return cert.CertPEM, nil
+	}
+
+	leaderAddr, _ := s.Raft.LeaderWithID()
+	host := strings.TrimSpace(raftAddressHost(string(leaderAddr)))
+	if host == "" {
+		return "", fmt.Errorf("leader_unknown")
+	}
+
+	hostname, err := utils.GetSystemHostname()
+	if err != nil || strings.TrimSpace(hostname) == "" {
+		hostname = "cluster"
+	}
+
+	clusterToken, err := s.AuthService.CreateInternalClusterJWT(hostname, "")
+	if err != nil {
+		return "", fmt.Errorf("create_cluster_token_failed: %w", err)
+	}
+
+	body, _, err := utils.HTTPPostJSONRead(
+		ClusterAPIURL(host, "/api/intra-cluster/tls/sign"),
+		req,
+		map[string]string{
+			"Accept":          "application/json",
+			"Content-Type":    "application/json",
+			"X-Cluster-Token": fmt.Sprintf("Bearer %s", clusterToken),
+		},
+	)
+	if err != nil {
+		return "", fmt.Errorf("request_node_certificate_failed: %w", err)
+	}
+
+	var resp internal.APIResponse[clusterModels.ClusterNodeCertificate]
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", fmt.Errorf("request_node_certificate_failed: %w", err)
+	}
+	if resp.Status != "success" || resp.Data.CertPEM == "" {
+		return "", fmt.Errorf("request_node_certificate_failed: %s", resp.Error)
+	}
+	return resp.Data.CertPEM, nil
+}
+
+// ensureLocalNodeCertificate loads this node's certificate, and renews it
+// when it is missing, close to expiry, or from a CA that was rotated out.
+func (s *Service) ensureLocalNodeCertificate(ca *clusterModels.ClusterCA, clusterIP string) error {
+	caCert, err := parseCertPEM(ca.CertPEM)
+	if err != nil {
+		return fmt.Errorf("cluster_ca_invalid: %w", err)
+	}
+
+	dir, err := s.clusterTLSDir()
+	if err != nil {
+		return fmt.Errorf("cluster_tls_dir_failed: %w", err)
+	}
+	key, err := s.ensureLocalClusterTLSKey(dir)
+	if err != nil {
+		return err
+	}
+
+	certPath := filepath.Join(dir, clusterTLSCertFileName)
+	certPEM, _ := os.ReadFile(certPath)
+	cert, _ := parseCertPEM(string(certPEM))
+
+	if cert == nil || !nodeCertCurrent(cert, caCert, clusterIP, time.Now()) {
+		nodeID := s.LocalNodeID()
+		if nodeID == "" {
+			return fmt.Errorf("node_id_unavailable")
+		}
+
+		csr, err := newNodeCSR(key, nodeID)
+		if err != nil {
+			return err
+		}
+		issued, err := s.requestNodeCertificate(clusterServiceInterfaces.ClusterTLSSignReq{
+			NodeUUID: nodeID,
+			CSR:      csr,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(certPath, []byte(issued), 0644); err != nil {
+			return fmt.Errorf("cluster_tls_cert_write_failed: %w", err)
+		}
+		certPEM = []byte(issued)
+		logger.L.Info().Str("ca", ca.Fingerprint).Msg("Installed new cluster node certificate")
+	}
+
+	keyPEM, err := encodeECKeyPEM(key)
+	if err != nil {
+		return err
+	}
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return fmt.Errorf("cluster_tls_cert_invalid: %w", err)
+	}
+	s.nodeTLSCert.Store(&pair)
+	return nil
+}
+
+// RefreshClusterTLS brings this node's trust and certificate in line with the
+// replicated CA. The leader also creates the CA on first use and ends a
+// rotation once every member has renewed.
+func (s *Service) RefreshClusterTLS() error {
+	s.clusterTLSMu.Lock()
+	defer s.clusterTLSMu.Unlock()
+
+	var c clusterModels.Cluster
+	if err := s.DB.First(&c).Error; err != nil || !c.Enabled || s.Raft == nil {
+		s.applyClusterTrust(nil)
+		s.nodeTLSCert.Store(nil)
+		return nil
+	}
+
+	ca, err := s.loadClusterCA()
+	if err != nil {
+		return err
+	}
+
+	if s.LocalNodeIsLeader() {
+		if ca == nil {
+			if ca, err = generateClusterCA(time.Now()); err != nil {
+				return err
+			}
+			if err := s.proposeClusterCA(ca); err != nil {
+				return err
+			}
+			logger.L.Info().Str("fingerprint", ca.Fingerprint).Msg("Created cluster CA")
+		} else if ca.PreviousCertPEM != "" {
+			if stale, err := s.staleClusterNodeCertificates(ca); err == nil && len(stale) == 0 {
+				ca.PreviousCertPEM = ""
+				ca.PreviousFingerprint = ""
+				if err := s.proposeClusterCA(ca); err != nil {
+					return err
+				}
+				logger.L.Info().Str("fingerprint", ca.Fingerprint).Msg("Cluster CA rotation complete")
+			}
+		}
+	}
+
+	s.applyClusterTrust(ca)
+	if ca == nil {
+		return nil
+	}
+
+	return s.ensureLocalNodeCertificate(ca, c.RaftIP)
+}
+
+// RotateClusterCA replaces the cluster CA. The old CA stays trusted until
+// every member has a certificate from the new one, so peers never reject a
+// node that has not caught up yet.
+func (s *Service) RotateClusterCA() (*ClusterTLSStatus, error) {
+	if !s.LocalNodeIsLeader() {
+		return nil, fmt.Errorf("not_leader")
+	}
+
+	s.clusterTLSMu.Lock()
+	ca, err := s.loadClusterCA()
+	if err == nil && ca == nil {
+		err = fmt.Errorf("cluster_ca_not_configured")
+	}
+	if err == nil && ca.PreviousCertPEM != "" {
+		err = fmt.Errorf("cluster_ca_rotation_in_progress")
+	}
+	if err != nil {
+		s.clusterTLSMu.Unlock()
+		return nil, err
+	}
+
+	next, err := generateClusterCA(time.Now())
+	if err != nil {
+		s.clusterTLSMu.Unlock()
+		return nil, err
+	}
+	rotatedAt := time.Now().UTC()
+	next.PreviousCertPEM = ca.CertPEM
+	next.PreviousFingerprint = ca.Fingerprint
+	next.Enforce = ca.Enforce
+	next.RotatedAt = &rotatedAt
+
+	err = s.proposeClusterCA(next)
+	s.clusterTLSMu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.RefreshClusterTLS(); err != nil {
+		logger.L.Warn().Err(err).Msg("Leader certificate renewal deferred after cluster CA rotation")
+	}
+	return s.ClusterTLSStatus()
+}
+
+// SetClusterTLSEnforce turns strict peer verification on or off. It can only
+// be turned on once every member holds a certificate from the current CA.
+func (s *Service) SetClusterTLSEnforce(enforce bool) (*ClusterTLSStatus, error) {
+	if !s.LocalNodeIsLeader() {
+		return nil, fmt.Errorf("not_leader")
+	}
+
+	s.clusterTLSMu.Lock()
+	err := func() error {
+		ca, err := s.loadClusterCA()
+		if err != nil {
+			return err
+		}
+		if ca == nil {
+			return fmt.Errorf("cluster_ca_not_configured")
+		}
+
+		if enforce {
+			stale, err := s.staleClusterNodeCertificates(ca)
+			if err != nil {
+				return err
+			}
+			if len(stale) > 0 {
+				return fmt.Errorf("cluster_tls_nodes_not_ready: %s", strings.Join(stale, ", "))
+			}
+		}
+
+		ca.Enforce = enforce
+		return s.proposeClusterCA(ca)
+	}()
+	s.clusterTLSMu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ClusterTLSStatus()
+}
+
+func (s *Service) ClusterTLSStatus() (*ClusterTLSStatus, error) {
+	status := &ClusterTLSStatus{Nodes: []ClusterTLSNodeStatus{}}
+
+	ca, err := s.loadClusterCA()
+	if err != nil {
+		return nil, err
+	}
+	if ca == nil {
+		return status, nil
+	}
+
+	notAfter := ca.NotAfter
+	status.Configured = true
+	status.Fingerprint = ca.Fingerprint
+	status.NotAfter = &notAfter
+	status.PreviousFingerprint = ca.PreviousFingerprint
+	status.RotatedAt = ca.RotatedAt
+	status.Enforce = ca.Enforce
+
+	var nodes []clusterModels.ClusterNode
+	if err := s.DB.Order("hostname ASC").Find(&nodes).Error; err != nil {
+		return nil, err
+	}
+	var certs []clusterModels.ClusterNodeCertificate
+	if err := s.DB.Find(&certs).Error; err != nil {
+		return nil, err
+	}
+	byNode := make(map[string]clusterModels.ClusterNodeCertificate, len(certs))
+	for _, cert := range certs {
+		byNode[cert.NodeUUID] = cert
+	}
+
+	status.Ready = len(nodes) > 0
+	for _, node := range nodes {
+		entry := ClusterTLSNodeStatus{NodeUUID: node.NodeUUID, Hostname: node.Hostname}
+		if cert, ok := byNode[node.NodeUUID]; ok {
+			certNotAfter := cert.NotAfter
+			entry.Issued = true
+			entry.Current = cert.CAFingerprint == ca.Fingerprint
+			entry.CAFingerprint = cert.CAFingerprint
+			entry.NotAfter = &certNotAfter
+		}
+		if !entry.Current {
+			status.Ready = false
+		}
+		status.Nodes = append(status.Nodes, entry)
+	}
+
+	return status, nil
+}
+
+// ClusterServerTLSConfig serves this node's CA-issued certificate on the
+// intra-cluster listener, falling back to the certificate of fallback until
+// one has been issued. Peers connect by IP and send no SNI, so the fallback
+// cannot stay in Certificates: crypto/tls would never consult GetCertificate.
+func (s *Service) ClusterServerTLSConfig(fallback *tls.Config) *tls.Config {
+	cfg := fallback.Clone()
+	var fallbackCert *tls.Certificate
+	if len(cfg.Certificates) > 0 {
+		fallbackCert = &cfg.Certificates[0]
+	}
+	cfg.Certificates = nil
+	cfg.GetCertificate = func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+		if cert := s.nodeTLSCert.Load(); cert != nil {
+			return cert, nil
+		}
+		if fallbackCert == nil {
+			return nil, fmt.Errorf("no_server_certificate")
+		}
+		return fallbackCert, nil
+	}
+	return cfg
+}
@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package cluster
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"testing"
+	"time"
+)
+
+func issueTestNodeCert(t *testing.T, now time.Time, nodeUUID, ip string) (*x509.Certificate, *x509.Certificate) {
+	t.Helper()
+
+	ca, err := generateClusterCA(now)
+	if err != nil {
+		t.Fatalf("generateClusterCA: %v", err)
+	}
+	caCert, caKey, err := parseClusterCA(ca)
+	if err != nil {
+		t.Fatalf("parseClusterCA: %v", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	csr, err := newNodeCSR(key, nodeUUID)
+	if err != nil {
+		t.Fatalf("newNodeCSR: %v", err)
+	}
+
+	issued, err := signNodeCSR(caCert, caKey, csr, nodeUUID, net.ParseIP(ip), now)
+	if err != nil {
+		t.Fatalf("signNodeCSR: %v", err)
+	}
+	if issued.CAFingerprint != ca.Fingerprint {
+		t.Fatalf("issued cert records CA %q, want %q", issued.CAFingerprint, ca.Fingerprint)
+	}
+
+	cert, err := parseCertPEM(issued.CertPEM)
+	if err != nil {
+		t.Fatalf("parseCertPEM: %v", err)
+	}
+	return cert, caCert
+}
+
+func TestSignNodeCSRChainsToClusterCA(t *testing.T) {
+	now := time.Now()
+	cert, caCert := issueTestNodeCert(t, now, "node-1", "10.0.0.5")
+
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}); err != nil {
+		t.Fatalf("node cert does not verify against the cluster CA: %v", err)
+	}
+
+	if len(cert.IPAddresses) != 1 || !cert.IPAddresses[0].Equal(net.ParseIP("10.0.0.5")) {
+		t.Fatalf("expected only the Raft address as SAN, got %v", cert.IPAddresses)
+	}
+	if cert.Subject.CommonName != "node-1" {
+		t.Fatalf("unexpected subject %q", cert.Subject.CommonName)
+	}
+}
+
+func TestSignNodeCSRRejectsOtherNode(t *testing.T) {
+	now := time.Now()
+	ca, err := generateClusterCA(now)
+	if err != nil {
+		t.Fatalf("generateClusterCA: %v", err)
+	}
+	caCert, caKey, err := parseClusterCA(ca)
+	if err != nil {
+		t.Fatalf("parseClusterCA: %v", err)
+	}
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	csr, err := newNodeCSR(key, "node-2")
+	if err != nil {
+		t.Fatalf("newNodeCSR: %v", err)
+	}
+
+	if _, err := signNodeCSR(caCert, caKey, csr, "node-1", net.ParseIP("10.0.0.5"), now); err == nil {
+		t.Fatal("expected a CSR for another node to be rejected")
+	}
+	if _, err := signNodeCSR(caCert, caKey, "garbage", "node-1", net.ParseIP("10.0.0.5"), now); err == nil {
+		t.Fatal("expected a malformed CSR to be rejected")
+	}
+}
+
+func TestNodeCertCurrent(t *testing.T) {
+	now := time.Now()
+	cert, caCert := issueTestNodeCert(t, now, "node-1", "10.0.0.5")
+
+	if !nodeCertCurrent(cert, caCert, "10.0.0.5", now) {
+		t.Fatal("expected a fresh certificate to be current")
+	}
+	if nodeCertCurrent(cert, caCert, "10.0.0.6", now) {
+		t.Fatal("expected a certificate for another address to need renewal")
+	}
+	if nodeCertCurrent(cert, caCert, "10.0.0.5", cert.NotAfter.Add(-time.Hour)) {
+		t.Fatal("expected a certificate inside the renewal window to need renewal")
+	}
+
+	_, rotatedCA := issueTestNodeCert(t, now, "node-1", "10.0.0.5")
+	if nodeCertCurrent(cert, rotatedCA, "10.0.0.5", now) {
+		t.Fatal("expected a certificate from a rotated-out CA to need renewal")
+	}
+}
+
+func TestClusterTrustPoolKeepsPreviousCADuringRotation(t *testing.T) {
+	now := time.Now()
+	oldCert, oldCA := issueTestNodeCert(t, now, "node-1", "10.0.0.5")
+
+	next, err := generateClusterCA(now)
+	if err != nil {
+		t.Fatalf("generateClusterCA: %v", err)
+	}
+	next.PreviousCertPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: oldCA.Raw}))
+
+	opts := x509.VerifyOptions{
+		Roots:     clusterTrustPool(next),
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	if _, err := oldCert.Verify(opts); err != nil {
+		t.Fatalf("expected a node still on the previous CA to be trusted: %v", err)
+	}
+
+	next.PreviousCertPEM = ""
+	opts.Roots = clusterTrustPool(next)
+	if _, err := oldCert.Verify(opts); err == nil {
+		t.Fatal("expected the previous CA to be dropped once the rotation completes")
+	}
+}
@@ -0,0 +1,448 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package cluster
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/config"
+	"github.com/alchemillahq/sylve/internal/db/models"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/logger"
+	notifier "github.com/alchemillahq/sylve/internal/notifications"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	"github.com/hashicorp/raft"
+)
+
+const (
+	configDriftCheckInterval = 15 * time.Minute
+	configDriftKindPrefix    = "cluster.config_drift."
+)
+
+// configDriftSysctls are the tunables a guest notices when it lands on
+// another node: bridge and tap behaviour, forwarding, bhyve and jail limits.
+var configDriftSysctls = []string{
+	"hw.vmm.maxcpu",
+	"hw.vmm.iommu.enable",
+	"kern.racct.enable",
+	"kern.securelevel",
+	"net.inet.ip.forwarding",
+	"net.inet6.ip6.forwarding",
+	"net.link.bridge.pfil_bridge",
+	"net.link.bridge.pfil_member",
+	"net.link.bridge.pfil_onlyip",
+	"net.link.tap.up_on_open",
+	"security.jail.allow_raw_sockets",
+	"security.jail.enforce_statfs",
+	"security.jail.mount_allowed",
+	"vfs.zfs.arc.max",
+	"vm.max_user_wired",
+}
+
+// configDriftSSHDKeys are the sshd settings replication and migration
+// sessions depend on, as printed by sshd -T.
+var configDriftSSHDKeys = []string{
+	"allowtcpforwarding",
+	"ciphers",
+	"kexalgorithms",
+	"macs",
+	"maxsessions",
+	"maxstartups",
+	"passwordauthentication",
+	"permitrootlogin",
+	"pubkeyauthentication",
+}
+
+var configDriftLoaderFiles = []string{"/boot/loader.conf", "/boot/loader.conf.local"}
+
+var runConfigDriftCommand = utils.RunCommand
+
+// NodeConfigSnapshot is the slice of a node's effective configuration that is
+// compared across the cluster. Settings keys are namespaced, such as
+// "sysctl.net.link.tap.up_on_open" or "loader.vmm_load"; a key is missing
+// when the node does not set it or it could not be read.
+type NodeConfigSnapshot struct {
+	NodeID   string            `json:"nodeId"`
+	Settings map[string]string `json:"settings"`
+}
+
+type ConfigDriftEntry struct {
+	Key      string `json:"key"`
+	Baseline string `json:"baseline"`
+	Value    string `json:"value"`
+}
+
+// NodeConfigDrift lists where one node differs from the cluster baseline.
+// Error is set instead when the node could not be asked.
+type NodeConfigDrift struct {
+	NodeID   string             `json:"nodeId"`
+	Hostname string             `json:"hostname"`
+	Error    string             `json:"error,omitempty"`
+	Drift    []ConfigDriftEntry `json:"drift"`
+}
+
+// ConfigDriftReport is the outcome of one drift check. The baseline holds,
+// per key, the value most reachable nodes agree on, with ties going to the
+// leader.
+type ConfigDriftReport struct {
+	CheckedAt time.Time         `json:"checkedAt"`
+	Baseline  map[string]string `json:"baseline"`
+	Nodes     []NodeConfigDrift `json:"nodes"`
+}
+
+func parseSysctlValues(out string) map[string]string {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		name, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			continue
+		}
+		values[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return values
+}
+
+// parseLoaderConfModules returns the *_load entries of a loader.conf, with
+// quotes stripped and the value lowercased since the loader ignores case.
+func parseLoaderConfModules(content string) map[string]string {
+	modules := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = strings.TrimSpace(line[:idx])
+		}
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		name = strings.TrimSpace(name)
+		if !strings.HasSuffix(name, "_load") {
+			continue
+		}
+		modules[name] = strings.ToLower(strings.Trim(strings.TrimSpace(value), `"'`))
+	}
+	return modules
+}
+
+// parseSSHDConfig keeps the wanted keys of `sshd -T` output.
+func parseSSHDConfig(out string, keys []string) map[string]string {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), " ")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(key)
+		for _, wanted := range keys {
+			if key == wanted {
+				values[key] = strings.TrimSpace(value)
+				break
+			}
+		}
+	}
+	return values
+}
+
+// LocalNodeConfig collects this node's side of the drift comparison.
+// Sources that cannot be read are left out rather than failing the whole
+// snapshot.
+func (s *Service) LocalNodeConfig() NodeConfigSnapshot {
+	snapshot := NodeConfigSnapshot{
+		NodeID:   s.guestIdentityInventoryLocalNodeID(),
+		Settings: make(map[string]string),
+	}
+
+	args := append([]string{"-i", "-e"}, configDriftSysctls...)
+	if out, err := runConfigDriftCommand("/sbin/sysctl", args...); err == nil {
+		for name, value := range parseSysctlValues(out) {
+			snapshot.Settings["sysctl."+name] = value
+		}
+	}
+
+	for _, path := range configDriftLoaderFiles {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		for name, value := range parseLoaderConfModules(string(content)) {
+			snapshot.Settings["loader."+name] = value
+		}
+	}
+
+	if dataPath, err := config.GetDataPath(); err == nil {
+		zelta := filepath.Join(dataPath, "zelta", "bin", "zelta")
+		if out, err := runConfigDriftCommand(zelta, "version"); err == nil {
+			snapshot.Settings["zelta.version"] = strings.TrimSpace(out)
+		}
+	}
+
+	if out, err := runConfigDriftCommand("/usr/sbin/sshd", "-T"); err == nil {
+		for key, value := range parseSSHDConfig(out, configDriftSSHDKeys) {
+			snapshot.Settings["sshd."+key] = value
+		}
+	}
+	if out, err := runConfigDriftCommand("/usr/bin/ssh", "-V"); err == nil {
+		snapshot.Settings["openssh.version"] = strings.TrimSpace(out)
+	}
+
+	return snapshot
+}
+
+// computeConfigDrift picks the baseline value of every key seen on any node
+// and lists, per node, the keys that differ from it. A key a node lacks
+// counts as the empty value.
+func computeConfigDrift(settings map[string]map[string]string, leaderID string) (map[string]string, map[string][]ConfigDriftEntry) {
+	keys := make(map[string]struct{})
+	for _, nodeSettings := range settings {
+		for key := range nodeSettings {
+			keys[key] = struct{}{}
+		}
+	}
+
+	leader, hasLeader := settings[leaderID]
+	baseline := make(map[string]string, len(keys))
+	for key := range keys {
+		counts := make(map[string]int)
+		for _, nodeSettings := range settings {
+			counts[nodeSettings[key]]++
+		}
+
+		values := make([]string, 0, len(counts))
+		for value := range counts {
+			values = append(values, value)
+		}
+		sort.Slice(values, func(i, j int) bool {
+			if counts[values[i]] != counts[values[j]] {
+				return counts[values[i]] > counts[values[j]]
+			}
+			if hasLeader && (values[i] == leader[key]) != (values[j] == leader[key]) {
+				return values[i] == leader[key]
+			}
+			return values[i] < values[j]
+		})
+		baseline[key] = values[0]
+	}
+
+	drift := make(map[string][]ConfigDriftEntry, len(settings))
+	for nodeID, nodeSettings := range settings {
+		entries := []ConfigDriftEntry{}
+		for key, want := range baseline {
+			if got := nodeSettings[key]; got != want {
+				entries = append(entries, ConfigDriftEntry{Key: key, Baseline: want, Value: got})
+			}
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+		drift[nodeID] = entries
+	}
+	return baseline, drift
+}
+
+func (s *Service) fetchRemoteNodeConfig(ctx context.Context, nodeID string, address raft.ServerAddress, clusterToken string) (NodeConfigSnapshot, error) {
+	endpoint, err := s.guestIdentityInventoryRemoteAPI(nodeID, address)
+	if err != nil {
+		return NodeConfigSnapshot{}, err
+	}
+
+	body, statusCode, err := utils.HTTPGetJSONReadContext(
+		ctx,
+		fmt.Sprintf("https://%s/api/intra-cluster/node-config", endpoint),
+		map[string]string{
+			"Accept":          "application/json",
+			"X-Cluster-Token": fmt.Sprintf("Bearer %s", clusterToken),
+		},
+	)
+	if err != nil {
+		return NodeConfigSnapshot{}, fmt.Errorf("node_config_request_failed: status=%d: %w", statusCode, err)
+	}
+
+	var resp internal.APIResponse[NodeConfigSnapshot]
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return NodeConfigSnapshot{}, fmt.Errorf("node_config_decode_failed: %w", err)
+	}
+	if resp.Status != "success" {
+		return NodeConfigSnapshot{}, fmt.Errorf("node_config_non_success: %s", resp.Error)
+	}
+	if resp.Data.NodeID != nodeID {
+		return NodeConfigSnapshot{}, fmt.Errorf("node_config_node_id_mismatch: expected=%s actual=%s", nodeID, resp.Data.NodeID)
+	}
+	return resp.Data, nil
+}
+
+// CheckConfigDrift asks every cluster member for its configuration and
+// compares them. Only the leader runs it; the result is kept for
+// ConfigDrift and drift changes are notified once per node.
+func (s *Service) CheckConfigDrift(ctx context.Context) (*ConfigDriftReport, error) {
+	if s.Raft == nil {
+		return nil, fmt.Errorf("raft_not_initialized")
+	}
+	if s.Raft.State() != raft.Leader {
+		return nil, fmt.Errorf("not_leader")
+	}
+
+	cfgFuture := s.Raft.GetConfiguration()
+	if err := cfgFuture.Error(); err != nil {
+		return nil, fmt.Errorf("failed_to_get_raft_configuration: %w", err)
+	}
+
+	localNodeID := s.guestIdentityInventoryLocalNodeID()
+	clusterToken, err := s.AuthService.CreateInternalClusterJWT(localNodeID, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed_to_create_cluster_token: %w", err)
+	}
+
+	hostnames := make(map[string]string)
+	var nodes []clusterModels.ClusterNode
+	if err := s.DB.Select("node_uuid", "hostname").Find(&nodes).Error; err == nil {
+		for _, node := range nodes {
+			hostnames[node.NodeUUID] = node.Hostname
+		}
+	}
+
+	settings := make(map[string]map[string]string)
+	errs := make(map[string]string)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	for _, server := range cfgFuture.Configuration().Servers {
+		nodeID := string(server.ID)
+		if nodeID == localNodeID {
+			settings[nodeID] = s.LocalNodeConfig().Settings
+			continue
+		}
+
+		wg.Add(1)
+		go func(nodeID string, address raft.ServerAddress) {
+			defer wg.Done()
+
+			reqCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+			defer cancel()
+			snapshot, err := s.fetchRemoteNodeConfig(reqCtx, nodeID, address, clusterToken)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[nodeID] = err.Error()
+				return
+			}
+			settings[nodeID] = snapshot.Settings
+		}(nodeID, server.Address)
+	}
+	wg.Wait()
+
+	baseline, drift := computeConfigDrift(settings, localNodeID)
+	report := &ConfigDriftReport{
+		CheckedAt: time.Now().UTC(),
+		Baseline:  baseline,
+		Nodes:     make([]NodeConfigDrift, 0, len(settings)+len(errs)),
+	}
+	for nodeID, entries := range drift {
+		report.Nodes = append(report.Nodes, NodeConfigDrift{NodeID: nodeID, Hostname: hostnames[nodeID], Drift: entries})
+	}
+	for nodeID, msg := range errs {
+		report.Nodes = append(report.Nodes, NodeConfigDrift{NodeID: nodeID, Hostname: hostnames[nodeID], Error: msg, Drift: []ConfigDriftEntry{}})
+	}
+	sort.Slice(report.Nodes, func(i, j int) bool { return report.Nodes[i].NodeID < report.Nodes[j].NodeID })
+
+	s.configDriftMu.Lock()
+	s.configDriftReport = report
+	s.configDriftMu.Unlock()
+
+	s.reportConfigDrift(report)
+	return report, nil
+}
+
+// ConfigDrift returns the last drift report, running a check first when
+// asked to or when none has run yet.
+func (s *Service) ConfigDrift(ctx context.Context, refresh bool) (*ConfigDriftReport, error) {
+	if !refresh {
+		s.configDriftMu.Lock()
+		report := s.configDriftReport
+		s.configDriftMu.Unlock()
+		if report != nil {
+			return report, nil
+		}
+	}
+	return s.CheckConfigDrift(ctx)
+}
+
+// reportConfigDrift notifies when the set of drifted keys on a node changes,
+// so a node that stays drifted is reported once.
+func (s *Service) reportConfigDrift(report *ConfigDriftReport) {
+	s.configDriftMu.Lock()
+	defer s.configDriftMu.Unlock()
+
+	if s.configDriftByNode == nil {
+		s.configDriftByNode = make(map[string]string, len(report.Nodes))
+	}
+
+	for _, node := range report.Nodes {
+		if node.Error != "" {
+			continue
+		}
+
+		keys := make([]string, 0, len(node.Drift))
+		for _, entry := range node.Drift {
+			keys = append(keys, entry.Key)
+		}
+		fingerprint := strings.Join(keys, ",")
+
+		previous, seen := s.configDriftByNode[node.NodeID]
+		s.configDriftByNode[node.NodeID] = fingerprint
+		if fingerprint == previous || (!seen && fingerprint == "") {
+			continue
+		}
+
+		host := node.Hostname
+		if host == "" {
+			host = node.NodeID
+		}
+
+		event := notifier.EventInput{
+			Kind:        configDriftKindPrefix + node.NodeID,
+			Title:       fmt.Sprintf("Configuration on %s differs from the cluster", host),
+			Body:        fmt.Sprintf("Node %s differs from the cluster baseline in %s. Guests may behave differently after failing over to it.", host, strings.Join(keys, ", ")),
+			Severity:    string(models.NotificationSeverityWarning),
+			Source:      "cluster.config_drift",
+			Fingerprint: fmt.Sprintf("%s|%s", node.NodeID, fingerprint),
+			Metadata: map[string]string{
+				"node_uuid": node.NodeID,
+				"hostname":  host,
+				"keys":      fingerprint,
+			},
+		}
+		if fingerprint == "" {
+			event.Title = fmt.Sprintf("Configuration on %s matches the cluster again", host)
+			event.Body = fmt.Sprintf("Node %s no longer differs from the cluster baseline.", host)
+			event.Severity = string(models.NotificationSeverityInfo)
+		}
+
+		logger.L.Warn().
+			Str("node_uuid", node.NodeID).
+			Str("host", host).
+			Str("drifted_keys", fingerprint).
+			Msg("cluster node configuration drift changed")
+
+		if _, err := notifier.Emit(context.Background(), event); err != nil {
+			logger.L.Debug().Err(err).Str("node_uuid", node.NodeID).Msg("failed to emit config drift notification")
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package cluster
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLoaderConfModules(t *testing.T) {
+	got := parseLoaderConfModules(`# bhyve
+vmm_load="YES"
+if_bridge_load=yes # bridges
+kern.racct.enable=1
+nmdm_load = "NO"
+`)
+	want := map[string]string{
+		"vmm_load":       "yes",
+		"if_bridge_load": "yes",
+		"nmdm_load":      "no",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected modules %v, want %v", got, want)
+	}
+}
+
+func TestParseSSHDConfig(t *testing.T) {
+	got := parseSSHDConfig("port 22\nPermitRootLogin prohibit-password\nciphers aes128-gcm@openssh.com,chacha20-poly1305@openssh.com\n", configDriftSSHDKeys)
+	want := map[string]string{
+		"permitrootlogin": "prohibit-password",
+		"ciphers":         "aes128-gcm@openssh.com,chacha20-poly1305@openssh.com",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected sshd settings %v, want %v", got, want)
+	}
+}
+
+func TestComputeConfigDriftUsesMajorityBaseline(t *testing.T) {
+	settings := map[string]map[string]string{
+		"a": {"sysctl.net.link.tap.up_on_open": "1", "loader.vmm_load": "yes", "zelta.version": "Zelta 1.1.0"},
+		"b": {"sysctl.net.link.tap.up_on_open": "1", "loader.vmm_load": "yes", "zelta.version": "Zelta 1.1.0"},
+		"c": {"sysctl.net.link.tap.up_on_open": "0", "zelta.version": "Zelta 1.1.0"},
+	}
+
+	baseline, drift := computeConfigDrift(settings, "c")
+	if baseline["sysctl.net.link.tap.up_on_open"] != "1" || baseline["loader.vmm_load"] != "yes" {
+		t.Fatalf("unexpected baseline %v", baseline)
+	}
+	if len(drift["a"]) != 0 || len(drift["b"]) != 0 {
+		t.Fatalf("expected no drift on a and b, got %v and %v", drift["a"], drift["b"])
+	}
+
+	want := []ConfigDriftEntry{
+		{Key: "loader.vmm_load", Baseline: "yes", Value: ""},
+		{Key: "sysctl.net.link.tap.up_on_open", Baseline: "1", Value: "0"},
+	}
+	if !reflect.DeepEqual(drift["c"], want) {
+		t.Fatalf("unexpected drift on c %v, want %v", drift["c"], want)
+	}
+}
+
+func TestComputeConfigDriftPrefersLeaderOnTie(t *testing.T) {
+	settings := map[string]map[string]string{
+		"a": {"sshd.maxsessions": "10"},
+		"b": {"sshd.maxsessions": "50"},
+	}
+
+	baseline, drift := computeConfigDrift(settings, "b")
+	if baseline["sshd.maxsessions"] != "50" {
+		t.Fatalf("expected the leader's value as baseline, got %q", baseline["sshd.maxsessions"])
+	}
+	if len(drift["a"]) != 1 || len(drift["b"]) != 0 {
+		t.Fatalf("unexpected drift %v", drift)
+	}
+
+	baseline, _ = computeConfigDrift(settings, "missing")
+	if baseline["sshd.maxsessions"] != "10" {
+		t.Fatalf("expected the lowest value without a leader, got %q", baseline["sshd.maxsessions"])
+	}
+}
@@ -17,8 +17,11 @@ import (
 	"net"
 	"os"
 	"os/exec"
+	"os/user"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/alchemillahq/sylve/internal/logger"
 	"golang.org/x/crypto/ssh"
@@ -50,30 +53,74 @@ func (s *Service) StartEmbeddedSSHServer(ctx context.Context, ip string) error {
 		}
 		serverConfig.AddHostKey(hostSigner)
 
-		listenAddr := fmt.Sprintf("%s:%d", ip, ClusterEmbeddedSSHPort)
-		listener, err := net.Listen("tcp", listenAddr)
-		if err != nil {
-			startErr = fmt.Errorf("embedded_ssh_listen_failed: %w", err)
+		if _, err := s.listenEmbeddedSSH(ctx, serverConfig, ip); err != nil {
+			startErr = err
 			return
 		}
 
-		logger.L.Info().
-			Str("addr", listenAddr).
-			Msg("Embedded SSH server started")
-
-		go func() {
-			<-ctx.Done()
-			_ = listener.Close()
-		}()
+		s.embeddedSSHMu.Lock()
+		s.embeddedSSHCtx = ctx
+		s.embeddedSSHConfig = serverConfig
+		s.embeddedSSHMu.Unlock()
 
-		go s.embeddedSSHAcceptLoop(ctx, listener, serverConfig)
+		if replicationIP := s.localReplicationIP(); replicationIP != "" && replicationIP != ip {
+			if err := s.rebindEmbeddedSSHReplicationListener(replicationIP); err != nil {
+				logger.L.Warn().Err(err).Str("ip", replicationIP).Msg("embedded_ssh_replication_listen_failed")
+			}
+		}
 	})
 
 	return startErr
 }
 
+func (s *Service) listenEmbeddedSSH(ctx context.Context, serverConfig *ssh.ServerConfig, ip string) (net.Listener, error) {
+	listenAddr := net.JoinHostPort(ip, fmt.Sprintf("%d", ClusterEmbeddedSSHPort))
+	listener, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return nil, fmt.Errorf("embedded_ssh_listen_failed: %w", err)
+	}
+
+	logger.L.Info().
+		Str("addr", listenAddr).
+		Msg("Embedded SSH server started")
+
+	go func() {
+		<-ctx.Done()
+		_ = listener.Close()
+	}()
+
+	go s.embeddedSSHAcceptLoop(ctx, listener, serverConfig)
+	return listener, nil
+}
+
+// rebindEmbeddedSSHReplicationListener moves the extra listener kept for the
+// dedicated replication network to ip, or closes it when ip is empty. It is a
+// no-op until the embedded SSH server has been started.
+func (s *Service) rebindEmbeddedSSHReplicationListener(ip string) error {
+	s.embeddedSSHMu.Lock()
+	defer s.embeddedSSHMu.Unlock()
+
+	if s.embeddedSSHConfig == nil {
+		return nil
+	}
+	if s.embeddedSSHReplicationLn != nil {
+		_ = s.embeddedSSHReplicationLn.Close()
+		s.embeddedSSHReplicationLn = nil
+	}
+	if ip == "" {
+		return nil
+	}
+
+	listener, err := s.listenEmbeddedSSH(s.embeddedSSHCtx, s.embeddedSSHConfig, ip)
+	if err != nil {
+		return err
+	}
+	s.embeddedSSHReplicationLn = listener
+	return nil
+}
+
 func (s *Service) embeddedSSHPublicKeyCallback(conn ssh.ConnMetadata, presentedKey ssh.PublicKey) (*ssh.Permissions, error) {
-	if strings.TrimSpace(conn.User()) != "root" {
+	if strings.TrimSpace(conn.User()) != s.localReplicationSSHUser() {
 		return nil, fmt.Errorf("invalid_user")
 	}
 
@@ -82,23 +129,21 @@ func (s *Service) embeddedSSHPublicKeyCallback(conn ssh.ConnMetadata, presentedK
 		return nil, fmt.Errorf("list_cluster_identities_failed: %w", err)
 	}
 
+	now := time.Now()
 	for _, identity := range identities {
-		pub := strings.TrimSpace(identity.PublicKey)
-		if pub == "" {
-			continue
-		}
-
-		parsedKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(pub + "\n"))
-		if err != nil {
-			continue
-		}
+		for _, pub := range identity.AcceptedPublicKeys(now) {
+			parsedKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(pub + "\n"))
+			if err != nil {
+				continue
+			}
 
-		if bytes.Equal(parsedKey.Marshal(), presentedKey.Marshal()) {
-			return &ssh.Permissions{
-				Extensions: map[string]string{
-					"node_uuid": identity.NodeUUID,
-				},
-			}, nil
+			if bytes.Equal(parsedKey.Marshal(), presentedKey.Marshal()) {
+				return &ssh.Permissions{
+					Extensions: map[string]string{
+						"node_uuid": identity.NodeUUID,
+					},
+				}, nil
+			}
 		}
 	}
 
@@ -123,7 +168,7 @@ func (s *Service) embeddedSSHAcceptLoop(ctx context.Context, listener net.Listen
 func (s *Service) handleEmbeddedSSHConn(ctx context.Context, rawConn net.Conn, serverConfig *ssh.ServerConfig) {
 	defer rawConn.Close()
 
-	_, chans, reqs, err := ssh.NewServerConn(rawConn, serverConfig)
+	conn, chans, reqs, err := ssh.NewServerConn(rawConn, serverConfig)
 	if err != nil {
 		logger.L.Warn().Err(err).Msg("embedded_ssh_handshake_failed")
 		return
@@ -142,7 +187,7 @@ func (s *Service) handleEmbeddedSSHConn(ctx context.Context, rawConn net.Conn, s
 			continue
 		}
 
-		go s.handleEmbeddedSSHSession(ctx, channel, requests)
+		go s.handleEmbeddedSSHSession(ctx, strings.TrimSpace(conn.User()), channel, requests)
 	}
 }
 
@@ -191,7 +236,49 @@ func exitCodeFromErr(err error) uint32 {
 	return 1
 }
 
-func (s *Service) handleEmbeddedSSHSession(ctx context.Context, channel ssh.Channel, requests <-chan *ssh.Request) {
+// embeddedSSHRunAs drops a session's command to the published non-root
+// replication user. Root sessions keep running as the Sylve process.
+func embeddedSSHRunAs(cmd *exec.Cmd, username string) error {
+	if username == "" || username == "root" {
+		return nil
+	}
+
+	u, err := user.Lookup(username)
+	if err != nil {
+		return fmt.Errorf("embedded_ssh_user_lookup_failed: %w", err)
+	}
+	uid, err := strconv.ParseUint(u.Uid, 10, 32)
+	if err != nil {
+		return fmt.Errorf("embedded_ssh_invalid_uid: %w", err)
+	}
+	gid, err := strconv.ParseUint(u.Gid, 10, 32)
+	if err != nil {
+		return fmt.Errorf("embedded_ssh_invalid_gid: %w", err)
+	}
+
+	var groups []uint32
+	if groupIDs, err := u.GroupIds(); err == nil {
+		for _, g := range groupIDs {
+			if id, err := strconv.ParseUint(g, 10, 32); err == nil {
+				groups = append(groups, uint32(id))
+			}
+		}
+	}
+
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Credential: &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid), Groups: groups},
+	}
+	cmd.Env = []string{
+		"HOME=" + u.HomeDir,
+		"USER=" + u.Username,
+		"LOGNAME=" + u.Username,
+		"SHELL=/bin/sh",
+		"PATH=/sbin:/bin:/usr/sbin:/usr/bin:/usr/local/sbin:/usr/local/bin",
+	}
+	return nil
+}
+
+func (s *Service) handleEmbeddedSSHSession(ctx context.Context, sessionUser string, channel ssh.Channel, requests <-chan *ssh.Request) {
 	defer channel.Close()
 
 	execReceived := false
@@ -222,6 +309,11 @@ func (s *Service) handleEmbeddedSSHSession(ctx context.Context, channel ssh.Chan
 			cmd.Stdin = channel
 			cmd.Stdout = channel
 			cmd.Stderr = channel.Stderr()
+			if err := embeddedSSHRunAs(cmd, sessionUser); err != nil {
+				_, _ = fmt.Fprintln(channel.Stderr(), err.Error())
+				_, _ = channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{Status: 1}))
+				return
+			}
 
 			runErr := cmd.Run()
 			exitCode := exitCodeFromErr(runErr)
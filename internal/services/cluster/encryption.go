@@ -15,6 +15,7 @@ import (
 	"strings"
 
 	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
 	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/pkg/utils"
 	"github.com/hashicorp/raft"
@@ -35,7 +36,7 @@ func (s *Service) ProposeEncryptionKeyUpsert(uuid, keyData, keyFormat string, by
 
 	key := clusterModels.EncryptionKey{
 		UUID:      uuid,
-		KeyData:   keyData,
+		KeyData:   secrets.String(keyData),
 		KeyFormat: keyFormat,
 	}
 
@@ -107,7 +108,7 @@ func (s *Service) UpsertEncryptionKeyLocally(uuid, keyData, keyFormat string) er
 
 	key := clusterModels.EncryptionKey{
 		UUID:      uuid,
-		KeyData:   keyData,
+		KeyData:   secrets.String(keyData),
 		KeyFormat: keyFormat,
 	}
 
@@ -152,7 +153,7 @@ func (s *Service) ForwardEncryptionKeyToLeader(uuid, keyData, keyFormat string)
 		KeyFormat string `json:"keyFormat"`
 	}{UUID: uuid, KeyData: keyData, KeyFormat: keyFormat}
 
-	url := fmt.Sprintf("https://%s:%d/api/intra-cluster/encryption-key/discover", host, ClusterEmbeddedHTTPSPort)
+	url := ClusterAPIURL(host, "/api/intra-cluster/encryption-key/discover")
 	headers := map[string]string{
 		"Accept":          "application/json",
 		"Content-Type":    "application/json",
@@ -102,11 +102,7 @@ func (s *Service) EmitLeftPanelRefreshClusterWide(reason string) {
 			continue
 		}
 
-		url := fmt.Sprintf(
-			"https://%s:%d/api/intra-cluster/events/left-panel-refresh",
-			host,
-			ClusterEmbeddedHTTPSPort,
-		)
+		url := ClusterAPIURL(host, "/api/intra-cluster/events/left-panel-refresh")
 
 		go func(nodeID, endpoint string) {
 			_, statusCode, err := utils.HTTPPostJSONWithTimeout(
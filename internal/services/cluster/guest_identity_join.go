@@ -127,7 +127,7 @@ func (s *Service) checkJoinInventory(
 	if details.Cluster == nil {
 		return GuestIdentityInventoryReport{}, false, fmt.Errorf("cluster_not_found")
 	}
-	if details.Cluster.Key != providedKey {
+	if string(details.Cluster.Key) != providedKey {
 		return GuestIdentityInventoryReport{}, false, fmt.Errorf("invalid_cluster_key")
 	}
 	if s.Raft == nil {
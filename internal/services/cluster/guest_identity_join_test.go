@@ -40,6 +40,11 @@ func guestIdentityJoinTestModels() []any {
 		&clusterModels.ReplicationEvent{},
 		&clusterModels.ClusterSSHIdentity{},
 		&clusterModels.EncryptionKey{},
+		&clusterModels.MaintenanceWindow{},
+		&clusterModels.ZeltaProfile{},
+		&clusterModels.ReplicationPolicyTemplate{},
+		&clusterModels.ClusterCA{},
+		&clusterModels.ClusterNodeCertificate{},
 		&vmModels.VM{},
 		&jailModels.Jail{},
 	}
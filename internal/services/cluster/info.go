@@ -43,6 +43,12 @@ func (s *Service) SyncClusterHealth(payload []clusterServiceInterfaces.NodeHealt
 				Disk:        node.Disk,
 				DiskUsage:   node.DiskUsage,
 				GuestIDs:    node.GuestIDs,
+				Pools:       node.Pools,
+				ClockStatus: node.ClockStatus,
+				ClockSkewMs: node.ClockSkewMs,
+				NTPSource:   node.NTPSource,
+				NTPSynced:   node.NTPSynced,
+				NTPOffsetMs: node.NTPOffsetMs,
 			})
 		}
 
@@ -51,7 +57,8 @@ func (s *Service) SyncClusterHealth(payload []clusterServiceInterfaces.NodeHealt
 				Columns: []clause.Column{{Name: "node_uuid"}},
 				DoUpdates: clause.AssignmentColumns([]string{
 					"hostname", "api", "status", "cpu", "cpu_usage",
-					"memory", "memory_usage", "disk", "disk_usage", "guest_ids", "updated_at",
+					"memory", "memory_usage", "disk", "disk_usage", "guest_ids", "pools",
+					"clock_status", "clock_skew_ms", "ntp_source", "ntp_synced", "ntp_offset_ms", "updated_at",
 				}),
 			}).Create(&insertRows).Error; err != nil {
 				return err
@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"math"
 	"net"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -24,6 +25,7 @@ import (
 	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
 	infoServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/info"
 	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/internal/webhooks"
 	"github.com/alchemillahq/sylve/pkg/utils"
 	"github.com/hashicorp/raft"
 	"github.com/rs/zerolog"
@@ -48,6 +50,14 @@ type curInfo struct {
 	diskUsage float64
 
 	guestIDs []uint
+
+	pools []clusterModels.NodePoolHealth
+
+	clockMeasured bool
+	clockSkewMs   int64
+	ntpSource     string
+	ntpSynced     bool
+	ntpOffsetMs   float64
 }
 
 /*
@@ -132,6 +142,12 @@ func currentToClusterNode(cur curInfo) clusterModels.ClusterNode {
 		Disk:        cur.disk,
 		DiskUsage:   cur.diskUsage,
 		GuestIDs:    cur.guestIDs,
+		Pools:       cur.pools,
+		ClockStatus: nodeClockStatus(cur),
+		ClockSkewMs: cur.clockSkewMs,
+		NTPSource:   cur.ntpSource,
+		NTPSynced:   cur.ntpSynced,
+		NTPOffsetMs: cur.ntpOffsetMs,
 	}
 }
 
@@ -153,6 +169,16 @@ func currentToClusterNodeUpdates(cur curInfo) map[string]any {
 		updates["guest_ids"] = "[]"
 	}
 
+	if cur.healthOK {
+		safePools := cur.pools
+		if safePools == nil {
+			safePools = make([]clusterModels.NodePoolHealth, 0)
+		}
+		if b, err := json.Marshal(safePools); err == nil {
+			updates["pools"] = string(b)
+		}
+	}
+
 	if cur.canonHost != "" {
 		updates["hostname"] = cur.canonHost
 	}
@@ -178,6 +204,14 @@ func currentToClusterNodeUpdates(cur curInfo) map[string]any {
 		updates["disk_usage"] = cur.diskUsage
 	}
 
+	updates["clock_status"] = nodeClockStatus(cur)
+	if cur.clockMeasured {
+		updates["clock_skew_ms"] = cur.clockSkewMs
+		updates["ntp_source"] = cur.ntpSource
+		updates["ntp_synced"] = cur.ntpSynced
+		updates["ntp_offset_ms"] = cur.ntpOffsetMs
+	}
+
 	return updates
 }
 
@@ -198,6 +232,24 @@ func hasSignificantChange(cur curInfo, ex clusterModels.ClusterNode) bool {
 		return true
 	}
 
+	exClockStatus := ex.ClockStatus
+	if exClockStatus == "" {
+		exClockStatus = ClockStatusUnknown
+	}
+	if exClockStatus != nodeClockStatus(cur) {
+		return true
+	}
+
+	if cur.clockMeasured {
+		if ex.NTPSource != cur.ntpSource || ex.NTPSynced != cur.ntpSynced {
+			return true
+		}
+
+		if skewDelta := ex.ClockSkewMs - cur.clockSkewMs; skewDelta >= clockSkewChangeThresholdMs || skewDelta <= -clockSkewChangeThresholdMs {
+			return true
+		}
+	}
+
 	if len(cur.guestIDs) != len(ex.GuestIDs) {
 		return true
 	}
@@ -215,6 +267,10 @@ func hasSignificantChange(cur curInfo, ex clusterModels.ClusterNode) bool {
 		}
 	}
 
+	if cur.healthOK && poolHealthChanged(cur.pools, ex.Pools) {
+		return true
+	}
+
 	if cur.healthOK {
 		if cur.cpu > 0 && ex.CPU != cur.cpu {
 			return true
@@ -244,6 +300,37 @@ func hasSignificantChange(cur curInfo, ex clusterModels.ClusterNode) bool {
 	return false
 }
 
+// poolHealthChanged reports a pool coming or going, changing state or
+// picking up device errors, or its usage moving past the resource threshold.
+func poolHealthChanged(cur, ex []clusterModels.NodePoolHealth) bool {
+	if len(cur) != len(ex) {
+		return true
+	}
+
+	existing := make(map[string]clusterModels.NodePoolHealth, len(ex))
+	for _, pool := range ex {
+		existing[pool.Name] = pool
+	}
+
+	for _, pool := range cur {
+		old, ok := existing[pool.Name]
+		if !ok {
+			return true
+		}
+		if old.State != pool.State ||
+			old.ReadErrors != pool.ReadErrors ||
+			old.WriteErrors != pool.WriteErrors ||
+			old.ChecksumErrors != pool.ChecksumErrors {
+			return true
+		}
+		if math.Abs(old.UsedPct-pool.UsedPct) >= resourceUsageThreshold {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (s *Service) getClusterToken(hostname string) (string, error) {
 	return s.AuthService.CreateClusterJWT(0, hostname, "", "")
 }
@@ -251,7 +338,7 @@ func (s *Service) getClusterToken(hostname string) (string, error) {
 func (s *Service) GetNodeInfo(host string, port int, clusterToken string) (infoServiceInterfaces.NodeInfo, error) {
 	var nodeInfo infoServiceInterfaces.NodeInfo
 
-	url := fmt.Sprintf("https://%s:%d/api/info/node", host, port)
+	url := fmt.Sprintf("https://%s/api/info/node", net.JoinHostPort(unbracketHost(host), strconv.Itoa(port)))
 	body, _, err := utils.HTTPGetJSONRead(
 		url,
 		map[string]string{
@@ -288,7 +375,7 @@ func (s *Service) collectCurrentClusterInfo(cfg raft.Configuration, clusterToken
 
 			uuid := serverID
 			host := raftAddressHost(serverAddr)
-			api := fmt.Sprintf("%s:%d", host, ClusterEmbeddedHTTPSPort)
+			api := ClusterAPIHost(host)
 
 			ci := curInfo{
 				nodeUUID: uuid,
@@ -308,6 +395,21 @@ func (s *Service) collectCurrentClusterInfo(cfg raft.Configuration, clusterToken
 				ci.disk = nodeInfo.DiskTotal
 				ci.diskUsage = nodeInfo.DiskUsage
 				ci.guestIDs = nodeInfo.Guests
+				ci.pools = nodeInfo.Pools
+
+				if clock, offset, err := s.GetNodeClock(host, ClusterEmbeddedHTTPSPort, clusterToken); err == nil {
+					ci.clockMeasured = true
+					ci.clockSkewMs = offset.Milliseconds()
+					ci.ntpSource = clock.NTP.Source
+					ci.ntpSynced = clock.NTP.Synced
+					ci.ntpOffsetMs = clock.NTP.OffsetMs
+				} else {
+					logger.L.Debug().
+						Str("node_uuid", uuid).
+						Str("host", host).
+						Err(err).
+						Msg("PopulateClusterNodes: node clock probe failed")
+				}
 			} else {
 				logger.L.Debug().
 					Str("node_uuid", uuid).
@@ -431,6 +533,7 @@ func (s *Service) PopulateClusterNodes() error {
 	cfg := cfgFuture.Configuration()
 
 	current := s.collectCurrentClusterInfo(cfg, clusterToken)
+	s.reportClockStatus(current)
 
 	changed, err := s.persistCurrentClusterNodes(current)
 	if err != nil {
@@ -464,6 +567,12 @@ func (s *Service) PopulateClusterNodes() error {
 					Disk:        node.Disk,
 					DiskUsage:   node.DiskUsage,
 					GuestIDs:    node.GuestIDs,
+					Pools:       node.Pools,
+					ClockStatus: node.ClockStatus,
+					ClockSkewMs: node.ClockSkewMs,
+					NTPSource:   node.NTPSource,
+					NTPSynced:   node.NTPSynced,
+					NTPOffsetMs: node.NTPOffsetMs,
 				})
 			}
 		}
@@ -487,6 +596,12 @@ func (s *Service) PopulateClusterNodes() error {
 				Disk:        cur.disk,
 				DiskUsage:   cur.diskUsage,
 				GuestIDs:    cur.guestIDs,
+				Pools:       cur.pools,
+				ClockStatus: nodeClockStatus(cur),
+				ClockSkewMs: cur.clockSkewMs,
+				NTPSource:   cur.ntpSource,
+				NTPSynced:   cur.ntpSynced,
+				NTPOffsetMs: cur.ntpOffsetMs,
 			})
 		}
 	}
@@ -519,7 +634,7 @@ func (s *Service) classifyPeerStatuses(results map[string]string) ([]string, []s
 
 func (s *Service) probePeerStatus(raftAddr string, headers map[string]string) string {
 	host := raftAddressHost(raftAddr)
-	url := fmt.Sprintf("https://%s:%d/api/health/http", host, ClusterEmbeddedHTTPSPort)
+	url := ClusterAPIURL(host, "/api/health/http")
 	if _, err := utils.HTTPGetStatus(url, headers); err == nil {
 		return nodeStatusOnline
 	}
@@ -693,6 +808,60 @@ func (s *Service) setPeersOfflineWithHysteresis(peerIDs []string, now time.Time)
 	}
 }
 
+type nodeStatusTransition struct {
+	NodeID   string
+	Hostname string
+	From     string
+	To       string
+}
+
+// peerStatusTransitions compares probe results with the stored node statuses
+// so the changes can be reported once they are applied.
+func (s *Service) peerStatusTransitions(results map[string]string) []nodeStatusTransition {
+	if len(results) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(results))
+	for id := range results {
+		ids = append(ids, id)
+	}
+
+	var nodes []clusterModels.ClusterNode
+	if err := s.DB.Select("node_uuid", "hostname", "status").Where("node_uuid IN ?", ids).Find(&nodes).Error; err != nil {
+		logger.L.Debug().Err(err).Msg("FastStatusCheck: failed to load node statuses for transitions")
+		return nil
+	}
+
+	transitions := make([]nodeStatusTransition, 0, len(nodes))
+	for _, node := range nodes {
+		next := results[node.NodeUUID]
+		if next == "" || next == node.Status {
+			continue
+		}
+		transitions = append(transitions, nodeStatusTransition{
+			NodeID:   node.NodeUUID,
+			Hostname: node.Hostname,
+			From:     node.Status,
+			To:       next,
+		})
+	}
+	return transitions
+}
+
+// publishNodeStatusWebhooks is only called from the verified leader so each
+// transition is reported once per cluster rather than once per observer.
+func publishNodeStatusWebhooks(transitions []nodeStatusTransition) {
+	for _, transition := range transitions {
+		webhooks.Publish(webhooks.EventNodeStatusChanged, map[string]any{
+			"nodeId":         transition.NodeID,
+			"hostname":       transition.Hostname,
+			"previousStatus": transition.From,
+			"status":         transition.To,
+		})
+	}
+}
+
 func (s *Service) fastStatusCheckLeader(peerIDs []string, peerAddrs map[string]string, now time.Time) {
 	if err := s.Raft.VerifyLeader().Error(); err != nil {
 		s.setPeersOfflineWithHysteresis(peerIDs, now)
@@ -717,6 +886,7 @@ func (s *Service) fastStatusCheckLeader(peerIDs []string, peerAddrs map[string]s
 		"X-Cluster-Token": fmt.Sprintf("Bearer %s", clusterToken),
 	})
 	onlinePeerIDs, offlinePeerIDs := s.classifyPeerStatuses(results)
+	transitions := s.peerStatusTransitions(results)
 
 	changed, onlineRows, offlineRows, err := s.applyLeaderPeerStatuses(onlinePeerIDs, offlinePeerIDs, now)
 	if err != nil {
@@ -728,6 +898,8 @@ func (s *Service) fastStatusCheckLeader(peerIDs []string, peerAddrs map[string]s
 		return
 	}
 
+	publishNodeStatusWebhooks(transitions)
+
 	logger.L.Debug().
 		Int64("online_rows", onlineRows).
 		Int64("offline_rows", offlineRows).
@@ -818,6 +990,12 @@ func (s *Service) syncClusterHealthToFollowers() {
 			Disk:        node.Disk,
 			DiskUsage:   node.DiskUsage,
 			GuestIDs:    node.GuestIDs,
+			Pools:       node.Pools,
+			ClockStatus: node.ClockStatus,
+			ClockSkewMs: node.ClockSkewMs,
+			NTPSource:   node.NTPSource,
+			NTPSynced:   node.NTPSynced,
+			NTPOffsetMs: node.NTPOffsetMs,
 		})
 	}
 
@@ -844,7 +1022,7 @@ func (s *Service) fanOutHealthSync(payload []clusterServiceInterfaces.NodeHealth
 
 		go func(addr string) {
 			host := raftAddressHost(addr)
-			url := fmt.Sprintf("https://%s:%d/api/intra-cluster/sync-health", host, ClusterEmbeddedHTTPSPort)
+			url := ClusterAPIURL(host, "/api/intra-cluster/sync-health")
 			_, statusCode, err := utils.HTTPPostJSONWithTimeout(url, payloadBytes, headers, 5*time.Second)
 			if err != nil {
 				logger.L.Debug().
@@ -897,5 +1075,62 @@ func (s *Service) StartClusterMonitors(ctx context.Context) {
 				}
 			}
 		}()
+
+		go func() {
+			ticker := time.NewTicker(configDriftCheckInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if s.Raft == nil || s.Raft.State() != raft.Leader {
+						continue
+					}
+					if _, err := s.CheckConfigDrift(ctx); err != nil {
+						logger.L.Warn().Err(err).Msg("Failed to check cluster config drift")
+					}
+				}
+			}
+		}()
+
+		go func() {
+			ticker := time.NewTicker(replicationTemplateEnrollInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if s.Raft == nil || s.Raft.State() != raft.Leader {
+						continue
+					}
+					s.enrollReplicationTemplatesLogged()
+				}
+			}
+		}()
+
+		go func() {
+			runRefreshClusterTLS := func() {
+				if err := s.RefreshClusterTLS(); err != nil {
+					logger.L.Warn().Err(err).Msg("Failed to refresh cluster TLS")
+				}
+			}
+
+			runRefreshClusterTLS()
+			ticker := time.NewTicker(clusterTLSCheckInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					runRefreshClusterTLS()
+				}
+			}
+		}()
 	})
 }
@@ -137,6 +137,42 @@ func TestHasSignificantChange(t *testing.T) {
 	}
 }
 
+func TestHasSignificantChangeOnPools(t *testing.T) {
+	pool := clusterModels.NodePoolHealth{Name: "tank", State: "ONLINE", Size: 1000, Alloc: 400, UsedPct: 40}
+	cur := curInfo{api: "10.0.0.1:8184", canonHost: "host", healthOK: true, pools: []clusterModels.NodePoolHealth{pool}}
+	ex := clusterModels.ClusterNode{Status: "online", API: "10.0.0.1:8184", Hostname: "host", Pools: []clusterModels.NodePoolHealth{pool}}
+
+	if hasSignificantChange(cur, ex) {
+		t.Fatal("identical pools should not be significant")
+	}
+
+	small := pool
+	small.UsedPct = 42
+	cur.pools = []clusterModels.NodePoolHealth{small}
+	if hasSignificantChange(cur, ex) {
+		t.Fatal("small usage change should not be significant")
+	}
+
+	for name, mutate := range map[string]func(*clusterModels.NodePoolHealth){
+		"state":    func(p *clusterModels.NodePoolHealth) { p.State = "DEGRADED" },
+		"checksum": func(p *clusterModels.NodePoolHealth) { p.ChecksumErrors = 1 },
+		"usage":    func(p *clusterModels.NodePoolHealth) { p.UsedPct = 91 },
+		"renamed":  func(p *clusterModels.NodePoolHealth) { p.Name = "fast" },
+	} {
+		changed := pool
+		mutate(&changed)
+		cur.pools = []clusterModels.NodePoolHealth{changed}
+		if !hasSignificantChange(cur, ex) {
+			t.Fatalf("%s change should be significant", name)
+		}
+	}
+
+	cur.pools = nil
+	if !hasSignificantChange(cur, ex) {
+		t.Fatal("a pool disappearing should be significant")
+	}
+}
+
 func TestEmitLeftPanelRefreshLocal(t *testing.T) {
 	db := newClusterServiceTestDB(t)
 	service := &Service{DB: db}
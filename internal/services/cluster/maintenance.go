@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+)
+
+// A window long enough to forget about is a silenced alarm, not maintenance.
+const maintenanceWindowMaxDuration = 7 * 24 * time.Hour
+
+func buildMaintenanceWindow(
+	req clusterServiceInterfaces.MaintenanceWindowReq,
+	localNodeID string,
+	now time.Time,
+) (clusterModels.MaintenanceWindow, error) {
+	window := clusterModels.MaintenanceWindow{
+		Scope:  strings.TrimSpace(req.Scope),
+		Reason: strings.TrimSpace(req.Reason),
+		EndsAt: req.EndsAt.UTC(),
+	}
+	if window.Reason == "" {
+		return window, fmt.Errorf("maintenance_reason_required")
+	}
+	if len(window.Reason) > 1024 {
+		return window, fmt.Errorf("maintenance_reason_too_long")
+	}
+
+	switch window.Scope {
+	case clusterModels.MaintenanceScopeNode:
+		window.NodeID = strings.TrimSpace(req.NodeID)
+		if window.NodeID == "" {
+			window.NodeID = strings.TrimSpace(localNodeID)
+		}
+		if window.NodeID == "" {
+			return window, fmt.Errorf("maintenance_node_required")
+		}
+	case clusterModels.MaintenanceScopeGuest:
+		window.GuestType = strings.TrimSpace(req.GuestType)
+		window.GuestID = req.GuestID
+		if window.GuestType != clusterModels.ReplicationGuestTypeVM &&
+			window.GuestType != clusterModels.ReplicationGuestTypeJail {
+			return window, fmt.Errorf("maintenance_guest_type_invalid")
+		}
+		if window.GuestID == 0 {
+			return window, fmt.Errorf("maintenance_guest_required")
+		}
+	case clusterModels.MaintenanceScopePolicy:
+		window.PolicyID = req.PolicyID
+		if window.PolicyID == 0 {
+			return window, fmt.Errorf("maintenance_policy_required")
+		}
+	default:
+		return window, fmt.Errorf("maintenance_scope_invalid")
+	}
+
+	window.StartsAt = now.UTC()
+	if req.StartsAt != nil && req.StartsAt.After(now) {
+		window.StartsAt = req.StartsAt.UTC()
+	}
+	if !window.EndsAt.After(now) {
+		return window, fmt.Errorf("maintenance_window_already_ended")
+	}
+	if !window.EndsAt.After(window.StartsAt) {
+		return window, fmt.Errorf("maintenance_window_ends_before_start")
+	}
+	if window.EndsAt.Sub(window.StartsAt) > maintenanceWindowMaxDuration {
+		return window, fmt.Errorf("maintenance_window_too_long")
+	}
+
+	return window, nil
+}
+
+func (s *Service) ListMaintenanceWindows(limit int) ([]clusterModels.MaintenanceWindow, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+
+	var windows []clusterModels.MaintenanceWindow
+	err := s.DB.Order("ends_at DESC, id DESC").Limit(limit).Find(&windows).Error
+	return windows, err
+}
+
+func (s *Service) ActiveMaintenanceWindows(now time.Time) ([]clusterModels.MaintenanceWindow, error) {
+	return clusterModels.ListActiveMaintenanceWindows(s.DB, now.UTC())
+}
+
+func (s *Service) ListMaintenanceSuppressions(windowID uint, limit int) ([]clusterModels.MaintenanceSuppression, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 200
+	}
+
+	query := s.DB.Order("last_at DESC").Limit(limit)
+	if windowID != 0 {
+		query = query.Where("window_id = ?", windowID)
+	}
+
+	var suppressions []clusterModels.MaintenanceSuppression
+	err := query.Find(&suppressions).Error
+	return suppressions, err
+}
+
+func (s *Service) ProposeMaintenanceWindowCreate(
+	req clusterServiceInterfaces.MaintenanceWindowReq,
+	createdBy string,
+	bypassRaft bool,
+) error {
+	window, err := buildMaintenanceWindow(req, s.LocalNodeID(), time.Now())
+	if err != nil {
+		return err
+	}
+	window.CreatedBy = strings.TrimSpace(createdBy)
+
+	if window.Scope == clusterModels.MaintenanceScopePolicy {
+		var policy clusterModels.ReplicationPolicy
+		if err := s.DB.First(&policy, window.PolicyID).Error; err != nil {
+			return fmt.Errorf("replication_policy_not_found: %w", err)
+		}
+	}
+
+	if bypassRaft {
+		return clusterModels.UpsertMaintenanceWindow(s.DB, &window)
+	}
+
+	data, err := json.Marshal(window)
+	if err != nil {
+		return fmt.Errorf("failed_to_marshal_maintenance_window: %w", err)
+	}
+
+	return s.applyRaftCommand(clusterModels.Command{
+		Type:   "maintenance_window",
+		Action: "create",
+		Data:   data,
+	})
+}
+
+// ProposeMaintenanceWindowEnd closes a window now and keeps it, with its
+// suppression audit, in the history.
+func (s *Service) ProposeMaintenanceWindowEnd(id uint, bypassRaft bool) error {
+	var window clusterModels.MaintenanceWindow
+	if err := s.DB.First(&window, id).Error; err != nil {
+		return fmt.Errorf("maintenance_window_not_found: %w", err)
+	}
+
+	now := time.Now().UTC()
+	if !now.Before(window.EndsAt) {
+		return fmt.Errorf("maintenance_window_already_ended")
+	}
+
+	if bypassRaft {
+		return clusterModels.EndMaintenanceWindow(s.DB, id, now)
+	}
+
+	data, err := json.Marshal(struct {
+		ID uint      `json:"id"`
+		At time.Time `json:"at"`
+	}{ID: id, At: now})
+	if err != nil {
+		return fmt.Errorf("failed_to_marshal_maintenance_window_end: %w", err)
+	}
+
+	return s.applyRaftCommand(clusterModels.Command{
+		Type:   "maintenance_window",
+		Action: "end",
+		Data:   data,
+	})
+}
+
+func (s *Service) ProposeMaintenanceWindowDelete(id uint, bypassRaft bool) error {
+	if bypassRaft {
+		return clusterModels.DeleteMaintenanceWindow(s.DB, id)
+	}
+
+	data, err := json.Marshal(struct {
+		ID uint `json:"id"`
+	}{ID: id})
+	if err != nil {
+		return fmt.Errorf("failed_to_marshal_delete_payload: %w", err)
+	}
+
+	return s.applyRaftCommand(clusterModels.Command{
+		Type:   "maintenance_window",
+		Action: "delete",
+		Data:   data,
+	})
+}
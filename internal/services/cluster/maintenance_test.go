@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package cluster
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+)
+
+func TestBuildMaintenanceWindow(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC)
+	later := now.Add(2 * time.Hour)
+
+	window, err := buildMaintenanceWindow(clusterServiceInterfaces.MaintenanceWindowReq{
+		Scope:  clusterModels.MaintenanceScopeNode,
+		Reason: " firmware update ",
+		EndsAt: later,
+	}, "node-1", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if window.NodeID != "node-1" || window.Reason != "firmware update" ||
+		!window.StartsAt.Equal(now) || !window.EndsAt.Equal(later) {
+		t.Fatalf("expected a window on the local node starting now, got %+v", window)
+	}
+
+	past := now.Add(-time.Hour)
+	window, err = buildMaintenanceWindow(clusterServiceInterfaces.MaintenanceWindowReq{
+		Scope:     clusterModels.MaintenanceScopeGuest,
+		GuestType: clusterModels.ReplicationGuestTypeVM,
+		GuestID:   104,
+		Reason:    "resize disk",
+		StartsAt:  &past,
+		EndsAt:    later,
+	}, "node-1", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if window.NodeID != "" || window.GuestID != 104 || !window.StartsAt.Equal(now) {
+		t.Fatalf("expected a past start to be clamped to now, got %+v", window)
+	}
+
+	for name, req := range map[string]clusterServiceInterfaces.MaintenanceWindowReq{
+		"maintenance_reason_required":          {Scope: clusterModels.MaintenanceScopeNode, EndsAt: later},
+		"maintenance_scope_invalid":            {Scope: "cluster", Reason: "x", EndsAt: later},
+		"maintenance_guest_type_invalid":       {Scope: clusterModels.MaintenanceScopeGuest, GuestType: "bhyve", GuestID: 1, Reason: "x", EndsAt: later},
+		"maintenance_guest_required":           {Scope: clusterModels.MaintenanceScopeGuest, GuestType: "jail", Reason: "x", EndsAt: later},
+		"maintenance_policy_required":          {Scope: clusterModels.MaintenanceScopePolicy, Reason: "x", EndsAt: later},
+		"maintenance_window_already_ended":     {Scope: clusterModels.MaintenanceScopeNode, Reason: "x", EndsAt: past},
+		"maintenance_window_ends_before_start": {Scope: clusterModels.MaintenanceScopeNode, Reason: "x", StartsAt: &later, EndsAt: now.Add(time.Hour)},
+		"maintenance_window_too_long":          {Scope: clusterModels.MaintenanceScopeNode, Reason: "x", EndsAt: now.Add(8 * 24 * time.Hour)},
+	} {
+		if _, err := buildMaintenanceWindow(req, "node-1", now); err == nil || err.Error() != name {
+			t.Fatalf("expected %s, got %v", name, err)
+		}
+	}
+}
+
+func TestProposeMaintenanceWindowLifecycleBypassRaft(t *testing.T) {
+	db := newClusterServiceTestDB(t,
+		&clusterModels.MaintenanceWindow{},
+		&clusterModels.MaintenanceSuppression{},
+		&clusterModels.ReplicationPolicy{},
+	)
+	s := &Service{DB: db}
+
+	if err := s.ProposeMaintenanceWindowCreate(clusterServiceInterfaces.MaintenanceWindowReq{
+		Scope:    clusterModels.MaintenanceScopePolicy,
+		PolicyID: 9,
+		Reason:   "migrate storage",
+		EndsAt:   time.Now().Add(time.Hour),
+	}, "admin", true); err == nil || !strings.Contains(err.Error(), "replication_policy_not_found") {
+		t.Fatalf("expected a missing policy to be rejected, got %v", err)
+	}
+
+	if err := s.ProposeMaintenanceWindowCreate(clusterServiceInterfaces.MaintenanceWindowReq{
+		Scope:  clusterModels.MaintenanceScopeNode,
+		NodeID: "node-2",
+		Reason: "replace PSU",
+		EndsAt: time.Now().Add(time.Hour),
+	}, "admin", true); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	active, err := s.ActiveMaintenanceWindows(time.Now())
+	if err != nil || len(active) != 1 || active[0].CreatedBy != "admin" {
+		t.Fatalf("expected one active window, got %+v (%v)", active, err)
+	}
+	id := active[0].ID
+
+	if err := clusterModels.RecordMaintenanceSuppression(db, clusterModels.MaintenanceSuppression{
+		WindowID: id, Action: clusterModels.MaintenanceActionNotification, Key: "system.zfs.pool_state.tank",
+	}, time.Now()); err != nil {
+		t.Fatalf("record suppression failed: %v", err)
+	}
+
+	if err := s.ProposeMaintenanceWindowEnd(id, true); err != nil {
+		t.Fatalf("end failed: %v", err)
+	}
+	if active, _ := s.ActiveMaintenanceWindows(time.Now()); len(active) != 0 {
+		t.Fatalf("expected no active window after ending it, got %+v", active)
+	}
+	if err := s.ProposeMaintenanceWindowEnd(id, true); err == nil || err.Error() != "maintenance_window_already_ended" {
+		t.Fatalf("expected a second end to be rejected, got %v", err)
+	}
+
+	suppressions, err := s.ListMaintenanceSuppressions(id, 0)
+	if err != nil || len(suppressions) != 1 {
+		t.Fatalf("expected the audit to survive ending the window, got %+v (%v)", suppressions, err)
+	}
+
+	if err := s.ProposeMaintenanceWindowDelete(id, true); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	windows, _ := s.ListMaintenanceWindows(0)
+	suppressions, _ = s.ListMaintenanceSuppressions(id, 0)
+	if len(windows) != 0 || len(suppressions) != 0 {
+		t.Fatalf("expected delete to drop the window and its audit, got %+v %+v", windows, suppressions)
+	}
+}
@@ -9,21 +9,101 @@
 package cluster
 
 import (
+	"fmt"
 	"net"
 	"strconv"
 	"strings"
+
+	"github.com/alchemillahq/sylve/internal/config"
 )
 
+var clusterLookupIP = net.LookupIP
+
 const (
 	ClusterRaftPort          = 8180
 	ClusterEmbeddedSSHPort   = 8183
 	ClusterEmbeddedHTTPSPort = 8184
 )
 
+// unbracketHost strips the brackets an IPv6 literal carries in host:port
+// form so it can be passed back through net.JoinHostPort.
+func unbracketHost(host string) string {
+	host = strings.TrimSpace(host)
+	if strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]") {
+		return host[1 : len(host)-1]
+	}
+	return host
+}
+
 func ClusterAPIHost(ip string) string {
-	return net.JoinHostPort(strings.TrimSpace(ip), strconv.Itoa(ClusterEmbeddedHTTPSPort))
+	return net.JoinHostPort(unbracketHost(ip), strconv.Itoa(ClusterEmbeddedHTTPSPort))
+}
+
+// ClusterAPIURL builds an intra-cluster HTTPS URL for path on host, which may
+// be an IPv4 or (bracketed or bare) IPv6 literal.
+func ClusterAPIURL(host, path string) string {
+	return "https://" + ClusterAPIHost(host) + path
 }
 
 func RaftServerAddress(ip string) string {
-	return net.JoinHostPort(strings.TrimSpace(ip), strconv.Itoa(ClusterRaftPort))
+	return net.JoinHostPort(unbracketHost(ip), strconv.Itoa(ClusterRaftPort))
+}
+
+// NormalizeClusterIP validates an address used for Raft or the cluster API
+// and returns its canonical spelling, so "2001:DB8::01" and "[2001:db8::1]"
+// are recorded as the same node. Zoned link-local addresses are rejected
+// because peers cannot route to them.
+func NormalizeClusterIP(ip string) (string, error) {
+	host := unbracketHost(ip)
+	if strings.Contains(host, "%") {
+		return "", fmt.Errorf("invalid_ip_address: zoned addresses are not supported")
+	}
+
+	parsed := net.ParseIP(host)
+	if parsed == nil || parsed.IsUnspecified() || parsed.IsMulticast() || parsed.IsLinkLocalUnicast() {
+		return "", fmt.Errorf("invalid_ip_address")
+	}
+	return parsed.String(), nil
+}
+
+// ResolveClusterHost turns a literal or hostname into a single cluster IP.
+// Hostnames with both A and AAAA records resolve to the family selected by
+// the cluster.preferIPv6 config option.
+func ResolveClusterHost(host string) (string, error) {
+	if ip, err := NormalizeClusterIP(host); err == nil {
+		return ip, nil
+	}
+
+	host = unbracketHost(host)
+	if host == "" || net.ParseIP(host) != nil {
+		return "", fmt.Errorf("invalid_ip_address")
+	}
+
+	ips, err := clusterLookupIP(host)
+	if err != nil {
+		return "", fmt.Errorf("cluster_host_lookup_failed: %w", err)
+	}
+
+	preferV6 := config.ParsedConfig != nil && config.ParsedConfig.Cluster.PreferIPv6
+	if ip := pickClusterIP(ips, preferV6); ip != "" {
+		return ip, nil
+	}
+	return "", fmt.Errorf("cluster_host_no_usable_address: %s", host)
+}
+
+func pickClusterIP(ips []net.IP, preferV6 bool) string {
+	var fallback string
+	for _, ip := range ips {
+		normalized, err := NormalizeClusterIP(ip.String())
+		if err != nil {
+			continue
+		}
+		if (ip.To4() == nil) == preferV6 {
+			return normalized
+		}
+		if fallback == "" {
+			fallback = normalized
+		}
+	}
+	return fallback
 }
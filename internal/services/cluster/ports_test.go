@@ -9,9 +9,14 @@
 package cluster
 
 import (
+	"fmt"
 	"net"
 	"strconv"
+	"strings"
 	"testing"
+
+	"github.com/alchemillahq/sylve/internal"
+	"github.com/alchemillahq/sylve/internal/config"
 )
 
 func TestRaftServerAddressUsesFixedPort(t *testing.T) {
@@ -21,6 +26,7 @@ func TestRaftServerAddressUsesFixedPort(t *testing.T) {
 	}{
 		{name: "ipv4", ip: "10.20.30.40"},
 		{name: "ipv6", ip: "::1"},
+		{name: "bracketed ipv6", ip: "[2001:db8::1]"},
 		{name: "trimmed", ip: " 192.168.1.50 "},
 	}
 
@@ -67,3 +73,76 @@ func TestClusterAPIHostUsesFixedHTTPSPort(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterAPIURLBracketsIPv6(t *testing.T) {
+	tests := map[string]string{
+		"10.0.0.1":      "https://10.0.0.1:8184/api/health/http",
+		"2001:db8::1":   "https://[2001:db8::1]:8184/api/health/http",
+		"[2001:db8::1]": "https://[2001:db8::1]:8184/api/health/http",
+		" fd00::abcd ":  "https://[fd00::abcd]:8184/api/health/http",
+	}
+	for host, want := range tests {
+		if got := ClusterAPIURL(host, "/api/health/http"); got != want {
+			t.Fatalf("ClusterAPIURL(%q) = %q, want %q", host, got, want)
+		}
+	}
+}
+
+func TestNormalizeClusterIP(t *testing.T) {
+	valid := map[string]string{
+		"10.0.0.1":        "10.0.0.1",
+		"2001:DB8::01":    "2001:db8::1",
+		"[2001:db8:0::1]": "2001:db8::1",
+		"::ffff:10.0.0.1": "10.0.0.1",
+	}
+	for in, want := range valid {
+		got, err := NormalizeClusterIP(in)
+		if err != nil || got != want {
+			t.Fatalf("NormalizeClusterIP(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+
+	for _, in := range []string{"", "::", "0.0.0.0", "fe80::1", "fe80::1%em0", "ff02::1", "node-1", "10.0.0.1:8180"} {
+		if got, err := NormalizeClusterIP(in); err == nil {
+			t.Fatalf("NormalizeClusterIP(%q) = %q, want error", in, got)
+		}
+	}
+}
+
+func TestResolveClusterHostHonoursPreferIPv6(t *testing.T) {
+	prevLookup, prevConfig := clusterLookupIP, config.ParsedConfig
+	t.Cleanup(func() { clusterLookupIP, config.ParsedConfig = prevLookup, prevConfig })
+
+	clusterLookupIP = func(host string) ([]net.IP, error) {
+		switch host {
+		case "dual.example":
+			return []net.IP{net.ParseIP("192.0.2.10"), net.ParseIP("fe80::1"), net.ParseIP("2001:db8::10")}, nil
+		case "v6only.example":
+			return []net.IP{net.ParseIP("2001:db8::20")}, nil
+		}
+		return nil, fmt.Errorf("no such host")
+	}
+
+	config.ParsedConfig = &internal.SylveConfig{}
+	if got, _ := ResolveClusterHost("dual.example"); got != "192.0.2.10" {
+		t.Fatalf("default resolution = %q, want IPv4", got)
+	}
+	if got, _ := ResolveClusterHost("v6only.example"); got != "2001:db8::20" {
+		t.Fatalf("v6-only resolution = %q", got)
+	}
+
+	config.ParsedConfig.Cluster.PreferIPv6 = true
+	if got, _ := ResolveClusterHost("dual.example"); got != "2001:db8::10" {
+		t.Fatalf("preferIPv6 resolution = %q, want global IPv6", got)
+	}
+	if got, _ := ResolveClusterHost("[2001:db8::30]"); got != "2001:db8::30" {
+		t.Fatalf("literal resolution = %q", got)
+	}
+
+	if _, err := ResolveClusterHost("missing.example"); err == nil || !strings.HasPrefix(err.Error(), "cluster_host_lookup_failed") {
+		t.Fatalf("missing host err = %v", err)
+	}
+	if _, err := ResolveClusterHost("fe80::1"); err == nil {
+		t.Fatal("link-local literal should not resolve")
+	}
+}
@@ -204,6 +204,78 @@ func (s *Service) ProposeReplicationPolicyStateUpdate(update ReplicationPolicyRu
 	})
 }
 
+// ProposeReplicationPolicyPause pauses or resumes a policy. A paused policy
+// stays enabled: runs already in flight finish, but the scheduler and the
+// failover controller ignore it until it is resumed. Resuming re-baselines
+// NextRunAt from now so a long pause does not trigger an immediate catch-up
+// run.
+func (s *Service) ProposeReplicationPolicyPause(id uint, paused bool, reason string, bypassRaft bool) error {
+	if id == 0 {
+		return fmt.Errorf("invalid_policy_id")
+	}
+
+	reason = strings.TrimSpace(reason)
+	if paused && reason == "" {
+		return fmt.Errorf("pause_reason_required")
+	}
+	if len(reason) > 1024 {
+		return fmt.Errorf("pause_reason_too_long")
+	}
+
+	var policy clusterModels.ReplicationPolicy
+	if err := s.DB.First(&policy, id).Error; err != nil {
+		return fmt.Errorf("replication_policy_not_found: %w", err)
+	}
+	if !policy.Enabled {
+		return fmt.Errorf("replication_policy_disabled")
+	}
+	if policy.ProtectionState == clusterModels.ReplicationProtectionStateDeleting {
+		return fmt.Errorf("replication_policy_deleting")
+	}
+	if replicationPolicyTransitionInProgress(policy.TransitionState) {
+		return fmt.Errorf("replication_policy_transition_in_progress")
+	}
+	if paused && policy.Paused {
+		return fmt.Errorf("replication_policy_already_paused")
+	}
+	if !paused && !policy.Paused {
+		return fmt.Errorf("replication_policy_not_paused")
+	}
+
+	now := time.Now().UTC()
+	payload := clusterModels.ReplicationPolicyPausePayload{
+		ID:     id,
+		Paused: paused,
+		Reason: reason,
+		At:     now,
+	}
+	if !paused {
+		if cronExpr := strings.TrimSpace(policy.CronExpr); cronExpr != "" {
+			schedule, err := cron.ParseStandard(cronExpr)
+			if err != nil {
+				return fmt.Errorf("invalid_cron_expr")
+			}
+			next := schedule.Next(now)
+			payload.NextRunAt = &next
+		}
+	}
+
+	if bypassRaft {
+		return clusterModels.SetReplicationPolicyPausedTxn(s.DB, &payload)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed_to_marshal_replication_policy_pause_payload: %w", err)
+	}
+
+	return s.applyRaftCommand(clusterModels.Command{
+		Type:   "replication_policy",
+		Action: "pause",
+		Data:   data,
+	})
+}
+
 // guestExistsInCluster checks whether a guest (VM or jail) exists on any
 // node in the cluster by aggregating per-node resources.
 func (s *Service) guestExistsInCluster(guestType string, guestID uint) (bool, error) {
@@ -421,6 +493,11 @@ func (s *Service) buildReplicationPolicy(
 	crashRestartMax := resolveOptional(existingByIDFound, existingByID.CrashRestartMax, input.CrashRestartMax, 3)
 	poolHealthCheck := resolveOptional(existingByIDFound, existingByID.PoolHealthCheck, input.PoolHealthCheck, true)
 	poolCapacityPct := resolveOptional(existingByIDFound, existingByID.PoolCapacityPct, input.PoolCapacityPct, 90)
+	sourceBookmarks := resolveOptional(existingByIDFound, existingByID.SourceBookmarks, input.SourceBookmarks, false)
+	zeltaProfileID := resolveOptional(existingByIDFound, existingByID.ZeltaProfileID, input.ZeltaProfileID, 0)
+	if err := s.requireZeltaProfile(zeltaProfileID); err != nil {
+		return nil, nil, err
+	}
 
 	policy := &clusterModels.ReplicationPolicy{
 		ID:              id,
@@ -441,8 +518,14 @@ func (s *Service) buildReplicationPolicy(
 		CrashRestartMax: crashRestartMax,
 		PoolHealthCheck: poolHealthCheck,
 		PoolCapacityPct: poolCapacityPct,
+		SourceBookmarks: sourceBookmarks,
+		ZeltaProfileID:  zeltaProfileID,
+		TemplateID:      input.TemplateID,
 		NextRunAt:       next,
 	}
+	if existingByIDFound {
+		policy.TemplateID = existingByID.TemplateID
+	}
 
 	// Preserve transition state from the existing row.
 	// The OnConflict.DoUpdates list already excludes transition columns
@@ -1019,6 +1102,30 @@ func (s *Service) GetReplicationEventByID(id uint) (*clusterModels.ReplicationEv
 	return &event, nil
 }
 
+// ReplicationEventTimeline is a replication run with its per-transfer
+// breakdown, ordered by when each target/dataset transfer started.
+type ReplicationEventTimeline struct {
+	Event     clusterModels.ReplicationEvent           `json:"event"`
+	Transfers []clusterModels.ReplicationEventTransfer `json:"transfers"`
+}
+
+func (s *Service) GetReplicationEventTimeline(id uint) (*ReplicationEventTimeline, error) {
+	event, err := s.GetReplicationEventByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline := &ReplicationEventTimeline{Event: *event, Transfers: []clusterModels.ReplicationEventTransfer{}}
+	if err := s.DB.
+		Where("event_id = ?", event.ID).
+		Order("started_at ASC").
+		Order("id ASC").
+		Find(&timeline.Transfers).Error; err != nil {
+		return nil, err
+	}
+	return timeline, nil
+}
+
 func (s *Service) ListClusterSSHIdentities() ([]clusterModels.ClusterSSHIdentity, error) {
 	var identities []clusterModels.ClusterSSHIdentity
 	if err := s.DB.Order("node_uuid ASC").Find(&identities).Error; err != nil {
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package cluster
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+)
+
+var replicationInterfaceAddrs = net.InterfaceAddrs
+
+type ReplicationNetwork struct {
+	IP        string `json:"ip"`
+	Interface string `json:"interface"`
+	Subnet    string `json:"subnet"`
+}
+
+// localReplicationIP is the address this node dedicates to replication and
+// backup transfers, or "" when transfers share the cluster address.
+func (s *Service) localReplicationIP() string {
+	var c clusterModels.Cluster
+	if err := s.DB.First(&c).Error; err != nil {
+		return ""
+	}
+	return strings.TrimSpace(c.ReplicationIP)
+}
+
+// replicationAddrSubnet finds the local interface subnet ip is assigned to.
+func replicationAddrSubnet(ip net.IP) (*net.IPNet, bool) {
+	addrs, err := replicationInterfaceAddrs()
+	if err != nil {
+		return nil, false
+	}
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if ok && ipNet.IP.Equal(ip) {
+			return &net.IPNet{IP: ipNet.IP.Mask(ipNet.Mask), Mask: ipNet.Mask}, true
+		}
+	}
+	return nil, false
+}
+
+func (s *Service) GetReplicationNetwork() (ReplicationNetwork, error) {
+	out := ReplicationNetwork{IP: s.localReplicationIP()}
+	if out.IP == "" {
+		return out, nil
+	}
+
+	parsed := net.ParseIP(out.IP)
+	if parsed == nil {
+		return out, nil
+	}
+	if subnet, ok := replicationAddrSubnet(parsed); ok {
+		out.Subnet = subnet.String()
+	}
+
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return out, nil
+	}
+	for _, iface := range ifaces {
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.Equal(parsed) {
+				out.Interface = iface.Name
+				return out, nil
+			}
+		}
+	}
+	return out, nil
+}
+
+// SetReplicationNetwork tags a local address as this node's replication and
+// backup network. Peers learn it through the published SSH identity and
+// connect to it for transfers; outgoing transfers bind to it. An empty ip
+// moves transfers back onto the cluster address.
+func (s *Service) SetReplicationNetwork(ip string) error {
+	ip = strings.TrimSpace(ip)
+	if ip != "" {
+		parsed := net.ParseIP(ip)
+		if parsed == nil || parsed.IsUnspecified() || parsed.IsLoopback() || parsed.IsMulticast() {
+			return fmt.Errorf("invalid_replication_ip")
+		}
+		if _, ok := replicationAddrSubnet(parsed); !ok {
+			return fmt.Errorf("replication_ip_not_assigned_locally: %s", ip)
+		}
+		ip = parsed.String()
+	}
+
+	var c clusterModels.Cluster
+	if err := s.DB.First(&c).Error; err != nil || !c.Enabled {
+		return fmt.Errorf("cluster_not_enabled")
+	}
+
+	if err := s.DB.Model(&clusterModels.Cluster{}).
+		Where("id = ?", c.ID).
+		Update("replication_ip", ip).Error; err != nil {
+		return fmt.Errorf("failed_to_save_replication_network: %w", err)
+	}
+
+	listenIP := ip
+	if listenIP == strings.TrimSpace(c.RaftIP) {
+		listenIP = ""
+	}
+	if err := s.rebindEmbeddedSSHReplicationListener(listenIP); err != nil {
+		return err
+	}
+
+	return s.publishLocalSSHIdentity(s.localReplicationSSHUser())
+}
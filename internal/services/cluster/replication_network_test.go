@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package cluster
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/testutil"
+)
+
+func TestSetReplicationNetworkRequiresLocalAddress(t *testing.T) {
+	prev := replicationInterfaceAddrs
+	replicationInterfaceAddrs = func() ([]net.Addr, error) {
+		_, mgmt, _ := net.ParseCIDR("192.168.1.10/24")
+		mgmt.IP = net.ParseIP("192.168.1.10")
+		_, repl, _ := net.ParseCIDR("10.99.0.10/24")
+		repl.IP = net.ParseIP("10.99.0.10")
+		return []net.Addr{mgmt, repl}, nil
+	}
+	t.Cleanup(func() { replicationInterfaceAddrs = prev })
+
+	db := testutil.NewSQLiteTestDB(t, &clusterModels.Cluster{})
+	svc := &Service{DB: db}
+
+	cases := map[string]string{
+		"not-an-ip":    "invalid_replication_ip",
+		"127.0.0.1":    "invalid_replication_ip",
+		"10.99.0.11":   "replication_ip_not_assigned_locally",
+		"192.168.1.10": "cluster_not_enabled",
+	}
+	for ip, want := range cases {
+		if err := svc.SetReplicationNetwork(ip); err == nil || !strings.HasPrefix(err.Error(), want) {
+			t.Fatalf("SetReplicationNetwork(%q) = %v, want %s", ip, err, want)
+		}
+	}
+
+	if subnet, ok := replicationAddrSubnet(net.ParseIP("10.99.0.10")); !ok || subnet.String() != "10.99.0.0/24" {
+		t.Fatalf("replicationAddrSubnet = %v, %v", subnet, ok)
+	}
+}
+
+func TestLocalClusterSSHHostPrefersReplicationIP(t *testing.T) {
+	db := testutil.NewSQLiteTestDB(t, &clusterModels.Cluster{})
+	svc := &Service{DB: db}
+
+	if err := db.Create(&clusterModels.Cluster{Enabled: true, RaftIP: "192.168.1.10"}).Error; err != nil {
+		t.Fatalf("seed cluster: %v", err)
+	}
+	if got := svc.localClusterSSHHost(); got != "192.168.1.10" {
+		t.Fatalf("host without replication network = %s", got)
+	}
+
+	if err := db.Model(&clusterModels.Cluster{}).Where("1 = 1").Update("replication_ip", "10.99.0.10").Error; err != nil {
+		t.Fatalf("set replication ip: %v", err)
+	}
+	if got := svc.localClusterSSHHost(); got != "10.99.0.10" {
+		t.Fatalf("host with replication network = %s, want 10.99.0.10", got)
+	}
+}
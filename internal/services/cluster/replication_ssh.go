@@ -12,12 +12,14 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"os/user"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/alchemillahq/sylve/internal/config"
 	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/pkg/utils"
 	"github.com/hashicorp/raft"
 )
@@ -113,18 +115,65 @@ func (s *Service) ensureLocalClusterSSHKeyPair() (string, string, string, error)
 func (s *Service) localClusterSSHHost() string {
 	var c clusterModels.Cluster
 	if err := s.DB.First(&c).Error; err == nil {
+		if strings.TrimSpace(c.ReplicationIP) != "" {
+			return strings.TrimSpace(c.ReplicationIP)
+		}
 		if strings.TrimSpace(c.RaftIP) != "" {
 			return strings.TrimSpace(c.RaftIP)
 		}
 	}
 
 	if detail := s.Detail(); detail != nil && strings.TrimSpace(detail.Hostname) != "" {
+		if ip, err := ResolveClusterHost(detail.Hostname); err == nil {
+			return ip
+		}
 		return strings.TrimSpace(detail.Hostname)
 	}
 
 	return "127.0.0.1"
 }
 
+// localReplicationSSHUser is the account peers log in as on this node's
+// embedded SSH server, as published in this node's SSH identity.
+func (s *Service) localReplicationSSHUser() string {
+	detail := s.Detail()
+	if detail == nil || strings.TrimSpace(detail.NodeID) == "" {
+		return "root"
+	}
+
+	var identity clusterModels.ClusterSSHIdentity
+	if err := s.DB.Where("node_uuid = ?", strings.TrimSpace(detail.NodeID)).Limit(1).Find(&identity).Error; err != nil {
+		return "root"
+	}
+	if name := strings.TrimSpace(identity.SSHUser); name != "" {
+		return name
+	}
+	return "root"
+}
+
+// SetReplicationSSHUser changes the account that replication sessions into
+// this node run as and publishes it so peers switch to it. A non-root user
+// needs `zfs allow` grants (receive, create, mount) on the datasets it
+// receives into, and vfs.usermount=1 to mount them.
+func (s *Service) SetReplicationSSHUser(username string) error {
+	username = strings.TrimSpace(username)
+	if username == "" {
+		username = "root"
+	}
+	if username != "root" {
+		if _, err := user.Lookup(username); err != nil {
+			return fmt.Errorf("replication_ssh_user_not_found: %s", username)
+		}
+	}
+
+	var c clusterModels.Cluster
+	if err := s.DB.First(&c).Error; err != nil || !c.Enabled {
+		return fmt.Errorf("cluster_not_enabled")
+	}
+
+	return s.publishLocalSSHIdentity(username)
+}
+
 func (s *Service) EnsureAndPublishLocalSSHIdentity() error {
 	var c clusterModels.Cluster
 	if err := s.DB.First(&c).Error; err == nil {
@@ -133,6 +182,13 @@ func (s *Service) EnsureAndPublishLocalSSHIdentity() error {
 		}
 	}
 
+	return s.publishLocalSSHIdentity(s.localReplicationSSHUser())
+}
+
+func (s *Service) publishLocalSSHIdentity(sshUser string) error {
+	s.sshIdentityMu.Lock()
+	defer s.sshIdentityMu.Unlock()
+
 	_, _, pubKey, err := s.ensureLocalClusterSSHKeyPair()
 	if err != nil {
 		return err
@@ -143,14 +199,37 @@ func (s *Service) EnsureAndPublishLocalSSHIdentity() error {
 		return fmt.Errorf("node_id_unavailable")
 	}
 
-	identity := clusterModels.ClusterSSHIdentity{
+	return s.publishSSHIdentity(clusterModels.ClusterSSHIdentity{
 		NodeUUID:  strings.TrimSpace(detail.NodeID),
-		SSHUser:   "root",
+		SSHUser:   sshUser,
 		SSHHost:   s.localClusterSSHHost(),
 		SSHPort:   ClusterEmbeddedSSHPort,
 		PublicKey: pubKey,
+		TokenKeys: s.localClusterTokenKeys(),
+	})
+}
+
+type clusterTokenKeySource interface {
+	ClusterTokenKeys() ([]clusterModels.ClusterTokenKey, error)
+}
+
+// localClusterTokenKeys returns the public keys this node signs ES256
+// cluster tokens with. A nil result leaves the published keys untouched.
+func (s *Service) localClusterTokenKeys() []clusterModels.ClusterTokenKey {
+	source, ok := s.AuthService.(clusterTokenKeySource)
+	if !ok {
+		return nil
 	}
 
+	keys, err := source.ClusterTokenKeys()
+	if err != nil {
+		logger.L.Warn().Err(err).Msg("cluster_token_keys_unavailable")
+		return nil
+	}
+	return keys
+}
+
+func (s *Service) publishSSHIdentity(identity clusterModels.ClusterSSHIdentity) error {
 	if s.Raft != nil && s.Raft.State() != raft.Leader {
 		leaderAddr, _ := s.Raft.LeaderWithID()
 		if leaderAddr == "" {
@@ -208,7 +287,7 @@ func (s *Service) forwardSSHIdentityToLeader(identity clusterModels.ClusterSSHId
 		return fmt.Errorf("create_cluster_token_failed: %w", err)
 	}
 
-	url := fmt.Sprintf("https://%s:%d/api/intra-cluster/ssh-identity", host, ClusterEmbeddedHTTPSPort)
+	url := ClusterAPIURL(host, "/api/intra-cluster/ssh-identity")
 	headers := map[string]string{
 		"Accept":          "application/json",
 		"Content-Type":    "application/json",
@@ -0,0 +1,499 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/robfig/cron/v3"
+)
+
+const replicationTemplateEnrollInterval = 2 * time.Minute
+
+const (
+	replicationEnrollCreate = "create"
+	replicationEnrollUpdate = "update"
+	replicationEnrollFailed = "failed"
+)
+
+// replicationTemplateGuest is a guest as seen by template enrollment. NodeID
+// is the node currently running it.
+type replicationTemplateGuest struct {
+	NodeID string
+	Type   string
+	ID     uint
+	Name   string
+	Tags   []string
+	Pools  []string
+}
+
+type replicationTemplatePlan struct {
+	Template clusterModels.ReplicationPolicyTemplate
+	Guest    replicationTemplateGuest
+	PolicyID uint
+	Action   string
+	Targets  []clusterServiceInterfaces.ReplicationPolicyTargetReq
+	Error    string
+}
+
+func normalizeReplicationTemplateValues(values []string) []string {
+	normalized := []string{}
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" || slices.Contains(normalized, value) {
+			continue
+		}
+		normalized = append(normalized, value)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+func (s *Service) ListReplicationPolicyTemplates() ([]clusterModels.ReplicationPolicyTemplate, error) {
+	var templates []clusterModels.ReplicationPolicyTemplate
+	err := s.DB.Order("name ASC").Find(&templates).Error
+	return templates, err
+}
+
+func (s *Service) buildReplicationPolicyTemplate(
+	id uint,
+	req clusterServiceInterfaces.ReplicationPolicyTemplateReq,
+	existing *clusterModels.ReplicationPolicyTemplate,
+) (*clusterModels.ReplicationPolicyTemplate, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("replication_template_name_required")
+	}
+
+	var taken int64
+	if err := s.DB.Model(&clusterModels.ReplicationPolicyTemplate{}).
+		Where("name = ? AND id != ?", name, id).
+		Count(&taken).Error; err != nil {
+		return nil, err
+	}
+	if taken > 0 {
+		return nil, fmt.Errorf("replication_template_name_in_use")
+	}
+
+	guestType := strings.TrimSpace(strings.ToLower(req.GuestType))
+	if guestType != "" && guestType != clusterModels.ReplicationGuestTypeVM && guestType != clusterModels.ReplicationGuestTypeJail {
+		return nil, fmt.Errorf("replication_template_invalid_guest_type")
+	}
+
+	cronExpr := strings.TrimSpace(req.CronExpr)
+	if _, err := cron.ParseStandard(cronExpr); err != nil {
+		return nil, fmt.Errorf("replication_template_invalid_cron_expr")
+	}
+
+	failbackMode := strings.TrimSpace(strings.ToLower(req.FailbackMode))
+	if failbackMode == "" {
+		failbackMode = clusterModels.ReplicationFailbackManual
+	}
+	if failbackMode != clusterModels.ReplicationFailbackManual && failbackMode != clusterModels.ReplicationFailbackAuto {
+		return nil, fmt.Errorf("replication_template_invalid_failback_mode")
+	}
+
+	failoverMode := strings.TrimSpace(strings.ToLower(req.FailoverMode))
+	if failoverMode == "" {
+		failoverMode = clusterModels.ReplicationFailoverManual
+	}
+	if failoverMode != clusterModels.ReplicationFailoverManual &&
+		failoverMode != clusterModels.ReplicationFailoverAutoSafe &&
+		failoverMode != clusterModels.ReplicationFailoverAutoForce {
+		return nil, fmt.Errorf("replication_template_invalid_failover_mode")
+	}
+
+	if len(req.Targets) == 0 {
+		return nil, fmt.Errorf("replication_template_targets_required")
+	}
+	targets := make([]clusterModels.ReplicationTemplateTarget, 0, len(req.Targets))
+	for _, t := range req.Targets {
+		nodeID := strings.TrimSpace(t.NodeID)
+		if !s.backupRunnerNodeExists(nodeID) {
+			return nil, fmt.Errorf("replication_template_target_node_not_found: %s", nodeID)
+		}
+		if slices.ContainsFunc(targets, func(existing clusterModels.ReplicationTemplateTarget) bool {
+			return existing.NodeID == nodeID
+		}) {
+			return nil, fmt.Errorf("replication_template_duplicate_target_node: %s", nodeID)
+		}
+		weight := t.Weight
+		if weight == 0 {
+			weight = 100
+		}
+		targets = append(targets, clusterModels.ReplicationTemplateTarget{NodeID: nodeID, Weight: weight})
+	}
+
+	enabled := true
+	if existing != nil {
+		enabled = existing.Enabled
+	}
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	return &clusterModels.ReplicationPolicyTemplate{
+		ID:           id,
+		Name:         name,
+		Description:  strings.TrimSpace(req.Description),
+		GuestType:    guestType,
+		Tags:         normalizeReplicationTemplateValues(req.Tags),
+		Pools:        normalizeReplicationTemplateValues(req.Pools),
+		Targets:      targets,
+		CronExpr:     cronExpr,
+		FailbackMode: failbackMode,
+		FailoverMode: failoverMode,
+		Enabled:      enabled,
+	}, nil
+}
+
+func (s *Service) ProposeReplicationPolicyTemplateCreate(req clusterServiceInterfaces.ReplicationPolicyTemplateReq, bypassRaft bool) error {
+	id, err := s.newRaftObjectID("replication_policy_templates")
+	if err != nil {
+		return fmt.Errorf("new_replication_template_id_failed: %w", err)
+	}
+	return s.proposeReplicationPolicyTemplateUpsert(id, req, nil, bypassRaft)
+}
+
+func (s *Service) ProposeReplicationPolicyTemplateUpdate(id uint, req clusterServiceInterfaces.ReplicationPolicyTemplateReq, bypassRaft bool) error {
+	var existing clusterModels.ReplicationPolicyTemplate
+	if err := s.DB.First(&existing, id).Error; err != nil {
+		return fmt.Errorf("replication_template_not_found: %w", err)
+	}
+	return s.proposeReplicationPolicyTemplateUpsert(id, req, &existing, bypassRaft)
+}
+
+func (s *Service) proposeReplicationPolicyTemplateUpsert(
+	id uint,
+	req clusterServiceInterfaces.ReplicationPolicyTemplateReq,
+	existing *clusterModels.ReplicationPolicyTemplate,
+	bypassRaft bool,
+) error {
+	template, err := s.buildReplicationPolicyTemplate(id, req, existing)
+	if err != nil {
+		return err
+	}
+
+	if bypassRaft {
+		return clusterModels.UpsertReplicationPolicyTemplate(s.DB, template)
+	}
+
+	data, err := json.Marshal(template)
+	if err != nil {
+		return fmt.Errorf("failed_to_marshal_replication_template: %w", err)
+	}
+
+	if err := s.applyRaftCommand(clusterModels.Command{
+		Type:   "replication_policy_template",
+		Action: "upsert",
+		Data:   data,
+	}); err != nil {
+		return err
+	}
+
+	// Enrolling fans out to every node, so the edit returns without
+	// waiting for it.
+	go s.enrollReplicationTemplatesLogged()
+	return nil
+}
+
+func (s *Service) ProposeReplicationPolicyTemplateDelete(id uint, bypassRaft bool) error {
+	if bypassRaft {
+		return clusterModels.DeleteReplicationPolicyTemplate(s.DB, id)
+	}
+
+	data, err := json.Marshal(struct {
+		ID uint `json:"id"`
+	}{ID: id})
+	if err != nil {
+		return fmt.Errorf("failed_to_marshal_delete_payload: %w", err)
+	}
+
+	return s.applyRaftCommand(clusterModels.Command{
+		Type:   "replication_policy_template",
+		Action: "delete",
+		Data:   data,
+	})
+}
+
+func replicationTemplateMatches(template clusterModels.ReplicationPolicyTemplate, guest replicationTemplateGuest) bool {
+	if template.GuestType != "" && template.GuestType != guest.Type {
+		return false
+	}
+	if len(template.Tags) > 0 && !slices.ContainsFunc(guest.Tags, func(tag string) bool {
+		return slices.Contains(template.Tags, strings.TrimSpace(tag))
+	}) {
+		return false
+	}
+	if len(template.Pools) > 0 && !slices.ContainsFunc(guest.Pools, func(pool string) bool {
+		return slices.Contains(template.Pools, pool)
+	}) {
+		return false
+	}
+	return true
+}
+
+// replicationTemplateTargets drops the node the guest runs on, since a
+// replica next to the source protects nothing.
+func replicationTemplateTargets(
+	template clusterModels.ReplicationPolicyTemplate,
+	guest replicationTemplateGuest,
+) []clusterServiceInterfaces.ReplicationPolicyTargetReq {
+	targets := make([]clusterServiceInterfaces.ReplicationPolicyTargetReq, 0, len(template.Targets))
+	for _, t := range template.Targets {
+		if t.NodeID == guest.NodeID {
+			continue
+		}
+		targets = append(targets, clusterServiceInterfaces.ReplicationPolicyTargetReq{NodeID: t.NodeID, Weight: t.Weight})
+	}
+	return targets
+}
+
+func replicationTemplatePolicyDrifted(
+	template clusterModels.ReplicationPolicyTemplate,
+	policy clusterModels.ReplicationPolicy,
+	targets []clusterServiceInterfaces.ReplicationPolicyTargetReq,
+) bool {
+	if policy.CronExpr != template.CronExpr ||
+		policy.FailbackMode != template.FailbackMode ||
+		policy.FailoverMode != template.FailoverMode ||
+		len(policy.Targets) != len(targets) {
+		return true
+	}
+	for _, want := range targets {
+		if !slices.ContainsFunc(policy.Targets, func(have clusterModels.ReplicationPolicyTarget) bool {
+			return have.NodeID == want.NodeID && have.Weight == want.Weight
+		}) {
+			return true
+		}
+	}
+	return false
+}
+
+// planReplicationTemplateEnrollment decides, for every guest, which enabled
+// template covers it and whether its policy needs creating or updating. The
+// lowest template ID wins when several match. Guests protected by hand or by
+// another template are left alone, and a guest that stops matching keeps its
+// policy so that editing a tag never silently drops protection.
+func planReplicationTemplateEnrollment(
+	templates []clusterModels.ReplicationPolicyTemplate,
+	guests []replicationTemplateGuest,
+	policies []clusterModels.ReplicationPolicy,
+) []replicationTemplatePlan {
+	sort.SliceStable(templates, func(i, j int) bool { return templates[i].ID < templates[j].ID })
+
+	byGuest := make(map[string]clusterModels.ReplicationPolicy, len(policies))
+	for _, policy := range policies {
+		byGuest[fmt.Sprintf("%s/%d", policy.GuestType, policy.GuestID)] = policy
+	}
+
+	var plans []replicationTemplatePlan
+	for _, guest := range guests {
+		idx := slices.IndexFunc(templates, func(template clusterModels.ReplicationPolicyTemplate) bool {
+			return template.Enabled && replicationTemplateMatches(template, guest)
+		})
+		if idx < 0 {
+			continue
+		}
+		template := templates[idx]
+		targets := replicationTemplateTargets(template, guest)
+
+		plan := replicationTemplatePlan{Template: template, Guest: guest, Targets: targets}
+		policy, exists := byGuest[fmt.Sprintf("%s/%d", guest.Type, guest.ID)]
+		switch {
+		case !exists:
+			plan.Action = replicationEnrollCreate
+		case policy.TemplateID != template.ID:
+			continue
+		case replicationTemplatePolicyDrifted(template, policy, targets):
+			plan.Action = replicationEnrollUpdate
+			plan.PolicyID = policy.ID
+		default:
+			continue
+		}
+
+		if len(targets) == 0 {
+			plan.Action = replicationEnrollFailed
+			plan.Error = "replication_template_no_target_besides_owner"
+		}
+		plans = append(plans, plan)
+	}
+
+	return plans
+}
+
+// replicationTemplateGuests flattens the cluster's resources. A guest that
+// shows up on more than one node is skipped until its owner is unambiguous.
+func replicationTemplateGuests(resources []clusterServiceInterfaces.NodeResources) []replicationTemplateGuest {
+	var guests []replicationTemplateGuest
+	seen := make(map[string]int)
+
+	add := func(guest replicationTemplateGuest) {
+		key := fmt.Sprintf("%s/%d", guest.Type, guest.ID)
+		seen[key]++
+		if seen[key] == 1 {
+			guests = append(guests, guest)
+		}
+	}
+
+	for _, node := range resources {
+		nodeID := strings.TrimSpace(node.NodeUUID)
+		if nodeID == "" {
+			continue
+		}
+		for _, vm := range node.VMs {
+			add(replicationTemplateGuest{
+				NodeID: nodeID,
+				Type:   clusterModels.ReplicationGuestTypeVM,
+				ID:     vm.RID,
+				Name:   vm.Name,
+				Tags:   vm.Tags,
+				Pools:  vm.Pools,
+			})
+		}
+		for _, jail := range node.Jails {
+			add(replicationTemplateGuest{
+				NodeID: nodeID,
+				Type:   clusterModels.ReplicationGuestTypeJail,
+				ID:     jail.CTID,
+				Name:   jail.Name,
+				Pools:  jail.Pools,
+			})
+		}
+	}
+
+	return slices.DeleteFunc(guests, func(guest replicationTemplateGuest) bool {
+		return seen[fmt.Sprintf("%s/%d", guest.Type, guest.ID)] > 1
+	})
+}
+
+// EnrollReplicationTemplates creates or refreshes the policies of every guest
+// an enabled template covers. It only runs on the raft leader.
+func (s *Service) EnrollReplicationTemplates() ([]clusterServiceInterfaces.ReplicationTemplateEnrollment, error) {
+	if err := s.requireReplicationRaftLeader(); err != nil {
+		return nil, err
+	}
+
+	s.replicationTemplateMu.Lock()
+	defer s.replicationTemplateMu.Unlock()
+
+	var templates []clusterModels.ReplicationPolicyTemplate
+	if err := s.DB.Where("enabled = ?", true).Find(&templates).Error; err != nil {
+		return nil, err
+	}
+	if len(templates) == 0 {
+		return []clusterServiceInterfaces.ReplicationTemplateEnrollment{}, nil
+	}
+
+	var policies []clusterModels.ReplicationPolicy
+	if err := s.DB.Preload("Targets").Find(&policies).Error; err != nil {
+		return nil, err
+	}
+
+	resources, err := s.Resources()
+	if err != nil {
+		return nil, fmt.Errorf("failed_to_list_cluster_guests: %w", err)
+	}
+
+	plans := planReplicationTemplateEnrollment(templates, replicationTemplateGuests(resources), policies)
+	results := make([]clusterServiceInterfaces.ReplicationTemplateEnrollment, 0, len(plans))
+	for _, plan := range plans {
+		result := clusterServiceInterfaces.ReplicationTemplateEnrollment{
+			TemplateID: plan.Template.ID,
+			GuestType:  plan.Guest.Type,
+			GuestID:    plan.Guest.ID,
+			Action:     plan.Action,
+			PolicyID:   plan.PolicyID,
+			Error:      plan.Error,
+		}
+
+		if plan.Action != replicationEnrollFailed {
+			if err := s.applyReplicationTemplatePlan(plan, policies); err != nil {
+				result.Action = replicationEnrollFailed
+				result.Error = err.Error()
+			}
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (s *Service) applyReplicationTemplatePlan(plan replicationTemplatePlan, policies []clusterModels.ReplicationPolicy) error {
+	req := clusterServiceInterfaces.ReplicationPolicyReq{
+		GuestType:    plan.Guest.Type,
+		GuestID:      plan.Guest.ID,
+		FailbackMode: plan.Template.FailbackMode,
+		FailoverMode: plan.Template.FailoverMode,
+		CronExpr:     plan.Template.CronExpr,
+		Targets:      plan.Targets,
+		TemplateID:   plan.Template.ID,
+	}
+
+	if plan.Action == replicationEnrollCreate {
+		label := plan.Guest.Name
+		if label == "" {
+			label = fmt.Sprintf("%s-%d", plan.Guest.Type, plan.Guest.ID)
+		}
+		req.Name = fmt.Sprintf("%s %s", plan.Template.Name, label)
+		req.Description = fmt.Sprintf("Enrolled by replication template %s", plan.Template.Name)
+		req.SourceMode = clusterModels.ReplicationSourceModeFollowActive
+		return s.ProposeReplicationPolicyCreate(req, false)
+	}
+
+	idx := slices.IndexFunc(policies, func(policy clusterModels.ReplicationPolicy) bool {
+		return policy.ID == plan.PolicyID
+	})
+	if idx < 0 {
+		return fmt.Errorf("replication_policy_not_found")
+	}
+	policy := policies[idx]
+	req.Name = policy.Name
+	req.Description = policy.Description
+	req.SourceMode = policy.SourceMode
+	req.SourceNodeID = policy.SourceNodeID
+	req.Enabled = &policy.Enabled
+	return s.ProposeReplicationPolicyUpdate(policy.ID, req, false)
+}
+
+func (s *Service) enrollReplicationTemplatesLogged() {
+	results, err := s.EnrollReplicationTemplates()
+	if err != nil {
+		if !strings.Contains(err.Error(), "not_leader") && !strings.Contains(err.Error(), "raft_not_initialized") {
+			logger.L.Warn().Err(err).Msg("Failed to enroll guests from replication templates")
+		}
+		return
+	}
+
+	for _, result := range results {
+		if result.Action == replicationEnrollFailed {
+			logger.L.Warn().
+				Uint("template_id", result.TemplateID).
+				Str("guest_type", result.GuestType).
+				Uint("guest_id", result.GuestID).
+				Str("error", result.Error).
+				Msg("Replication template could not enroll guest")
+			continue
+		}
+		logger.L.Info().
+			Uint("template_id", result.TemplateID).
+			Str("guest_type", result.GuestType).
+			Uint("guest_id", result.GuestID).
+			Str("action", result.Action).
+			Msg("Replication template enrolled guest")
+	}
+}
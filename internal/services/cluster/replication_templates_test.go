@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package cluster
+
+import (
+	"fmt"
+	"testing"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
+	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+)
+
+func TestReplicationTemplateGuests(t *testing.T) {
+	resources := []clusterServiceInterfaces.NodeResources{
+		{
+			NodeUUID: "node-a",
+			VMs: []libvirtServiceInterfaces.SimpleList{
+				{RID: 101, Name: "web", Tags: []string{"prod"}, Pools: []string{"tank"}},
+				{RID: 102, Name: "stray"},
+			},
+			Jails: []jailServiceInterfaces.SimpleList{{CTID: 201, Name: "dns", Pools: []string{"zroot"}}},
+		},
+		{
+			NodeUUID: "node-b",
+			VMs:      []libvirtServiceInterfaces.SimpleList{{RID: 102, Name: "stray"}},
+		},
+	}
+
+	guests := replicationTemplateGuests(resources)
+	if len(guests) != 2 {
+		t.Fatalf("expected the ambiguous guest to be skipped, got %+v", guests)
+	}
+	if guests[0].Type != clusterModels.ReplicationGuestTypeVM || guests[0].ID != 101 || guests[0].NodeID != "node-a" {
+		t.Fatalf("unexpected vm guest %+v", guests[0])
+	}
+	if guests[1].Type != clusterModels.ReplicationGuestTypeJail || guests[1].ID != 201 {
+		t.Fatalf("unexpected jail guest %+v", guests[1])
+	}
+}
+
+func TestPlanReplicationTemplateEnrollment(t *testing.T) {
+	prod := clusterModels.ReplicationPolicyTemplate{
+		ID:           2,
+		Name:         "prod",
+		GuestType:    clusterModels.ReplicationGuestTypeVM,
+		Tags:         []string{"prod"},
+		Targets:      []clusterModels.ReplicationTemplateTarget{{NodeID: "node-a", Weight: 100}, {NodeID: "node-b", Weight: 50}},
+		CronExpr:     "*/15 * * * *",
+		FailbackMode: clusterModels.ReplicationFailbackManual,
+		FailoverMode: clusterModels.ReplicationFailoverAutoSafe,
+		Enabled:      true,
+	}
+	tank := clusterModels.ReplicationPolicyTemplate{
+		ID:           3,
+		Name:         "tank",
+		Pools:        []string{"tank"},
+		Targets:      []clusterModels.ReplicationTemplateTarget{{NodeID: "node-a", Weight: 100}},
+		CronExpr:     "0 * * * *",
+		FailbackMode: clusterModels.ReplicationFailbackManual,
+		FailoverMode: clusterModels.ReplicationFailoverManual,
+		Enabled:      true,
+	}
+	disabled := clusterModels.ReplicationPolicyTemplate{ID: 1, Name: "all", Enabled: false}
+
+	guests := []replicationTemplateGuest{
+		{NodeID: "node-a", Type: "vm", ID: 101, Tags: []string{"prod"}, Pools: []string{"tank"}},
+		{NodeID: "node-c", Type: "vm", ID: 102, Tags: []string{"prod"}},
+		{NodeID: "node-c", Type: "vm", ID: 103, Tags: []string{"prod"}},
+		{NodeID: "node-c", Type: "vm", ID: 104, Tags: []string{"prod"}},
+		{NodeID: "node-c", Type: "jail", ID: 201, Pools: []string{"tank"}},
+		{NodeID: "node-a", Type: "jail", ID: 202, Pools: []string{"tank"}},
+		{NodeID: "node-c", Type: "jail", ID: 203, Pools: []string{"zroot"}},
+	}
+
+	policies := []clusterModels.ReplicationPolicy{
+		{
+			ID: 10, GuestType: "vm", GuestID: 102, TemplateID: 2,
+			CronExpr: prod.CronExpr, FailbackMode: prod.FailbackMode, FailoverMode: prod.FailoverMode,
+			Targets: []clusterModels.ReplicationPolicyTarget{{NodeID: "node-a", Weight: 100}, {NodeID: "node-b", Weight: 50}},
+		},
+		{
+			ID: 11, GuestType: "vm", GuestID: 103, TemplateID: 2,
+			CronExpr: "0 0 * * *", FailbackMode: prod.FailbackMode, FailoverMode: prod.FailoverMode,
+			Targets: []clusterModels.ReplicationPolicyTarget{{NodeID: "node-a", Weight: 100}, {NodeID: "node-b", Weight: 50}},
+		},
+		{ID: 12, GuestType: "vm", GuestID: 104, TemplateID: 0, CronExpr: "0 0 * * *"},
+	}
+
+	plans := planReplicationTemplateEnrollment(
+		[]clusterModels.ReplicationPolicyTemplate{tank, prod, disabled},
+		guests,
+		policies,
+	)
+
+	type outcome struct {
+		template uint
+		action   string
+		policy   uint
+		targets  int
+	}
+	want := map[string]outcome{
+		"vm/101":   {template: 2, action: replicationEnrollCreate, targets: 1},
+		"vm/103":   {template: 2, action: replicationEnrollUpdate, policy: 11, targets: 2},
+		"jail/201": {template: 3, action: replicationEnrollCreate, targets: 1},
+		"jail/202": {template: 3, action: replicationEnrollFailed, targets: 0},
+	}
+
+	if len(plans) != len(want) {
+		t.Fatalf("expected %d plans, got %+v", len(want), plans)
+	}
+	for _, plan := range plans {
+		key := fmt.Sprintf("%s/%d", plan.Guest.Type, plan.Guest.ID)
+		expected, ok := want[key]
+		if !ok {
+			t.Fatalf("unexpected plan for %s: %+v", key, plan)
+		}
+		if plan.Template.ID != expected.template || plan.Action != expected.action ||
+			plan.PolicyID != expected.policy || len(plan.Targets) != expected.targets {
+			t.Fatalf("plan for %s = template %d action %s policy %d targets %v, want %+v",
+				key, plan.Template.ID, plan.Action, plan.PolicyID, plan.Targets, expected)
+		}
+		for _, target := range plan.Targets {
+			if target.NodeID == plan.Guest.NodeID {
+				t.Fatalf("plan for %s targets the guest's own node", key)
+			}
+		}
+	}
+}
@@ -0,0 +1,222 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package cluster
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"gorm.io/gorm"
+)
+
+const (
+	ReplicationTimelineRun        = "run"
+	ReplicationTimelineFailover   = "failover"
+	ReplicationTimelineLease      = "lease"
+	ReplicationTimelineRotation   = "rotation"
+	ReplicationTimelineDivergence = "divergence"
+)
+
+// ReplicationTimelineEntry is one mark on a policy's timeline. Runs and
+// failovers span StartedAt to EndedAt; lease, rotation and divergence
+// entries are points and leave EndedAt nil.
+type ReplicationTimelineEntry struct {
+	Kind            string     `json:"kind"`
+	StartedAt       time.Time  `json:"startedAt"`
+	EndedAt         *time.Time `json:"endedAt"`
+	Status          string     `json:"status"`
+	Message         string     `json:"message"`
+	Error           string     `json:"error,omitempty"`
+	EventID         uint       `json:"eventId,omitempty"`
+	TransitionRunID string     `json:"transitionRunId,omitempty"`
+	SourceNodeID    string     `json:"sourceNodeId,omitempty"`
+	TargetNodeID    string     `json:"targetNodeId,omitempty"`
+	OwnerEpoch      uint64     `json:"ownerEpoch,omitempty"`
+}
+
+// ReplicationPolicyTimeline is a policy's history in the order it happened,
+// with the lease as it stands now.
+type ReplicationPolicyTimeline struct {
+	PolicyID        uint                            `json:"policyId"`
+	GuestType       string                          `json:"guestType"`
+	GuestID         uint                            `json:"guestId"`
+	ActiveNodeID    string                          `json:"activeNodeId"`
+	OwnerEpoch      uint64                          `json:"ownerEpoch"`
+	TransitionState string                          `json:"transitionState"`
+	Lease           *clusterModels.ReplicationLease `json:"lease"`
+	Entries         []ReplicationTimelineEntry      `json:"entries"`
+}
+
+var replicationTimelineKindOrder = map[string]int{
+	ReplicationTimelineFailover:   0,
+	ReplicationTimelineLease:      1,
+	ReplicationTimelineRotation:   2,
+	ReplicationTimelineRun:        3,
+	ReplicationTimelineDivergence: 4,
+}
+
+// replicationEventDiverged spots the markers the transfer path leaves when a
+// target no longer shares history with the source and had to be reseeded or
+// force-received.
+func replicationEventDiverged(event clusterModels.ReplicationEvent) bool {
+	text := strings.ToLower(event.Error + "\n" + event.Output)
+	return strings.Contains(text, "diverged") || strings.Contains(text, "proven_staging_reset")
+}
+
+func (s *Service) GetReplicationPolicyTimeline(policyID uint, limit int) (*ReplicationPolicyTimeline, error) {
+	policy, err := s.GetReplicationPolicyByID(policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.ListReplicationEvents(limit, policy.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline := &ReplicationPolicyTimeline{
+		PolicyID:        policy.ID,
+		GuestType:       policy.GuestType,
+		GuestID:         policy.GuestID,
+		ActiveNodeID:    policy.ActiveNodeID,
+		OwnerEpoch:      policy.OwnerEpoch,
+		TransitionState: policy.TransitionState,
+		Entries:         buildReplicationTimelineEntries(policy, events),
+	}
+
+	lease, err := s.GetReplicationLeaseByPolicyID(policy.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	timeline.Lease = lease
+
+	return timeline, nil
+}
+
+func buildReplicationTimelineEntries(
+	policy *clusterModels.ReplicationPolicy,
+	events []clusterModels.ReplicationEvent,
+) []ReplicationTimelineEntry {
+	entries := make([]ReplicationTimelineEntry, 0, len(events)+4)
+
+	for _, event := range events {
+		kind := ReplicationTimelineRun
+		if event.EventType == "failover" {
+			kind = ReplicationTimelineFailover
+		}
+		entries = append(entries, ReplicationTimelineEntry{
+			Kind:            kind,
+			StartedAt:       event.StartedAt,
+			EndedAt:         event.CompletedAt,
+			Status:          event.Status,
+			Message:         event.Message,
+			Error:           event.Error,
+			EventID:         event.ID,
+			TransitionRunID: event.TransitionRunID,
+			SourceNodeID:    event.SourceNodeID,
+			TargetNodeID:    event.TargetNodeID,
+		})
+
+		at := event.StartedAt
+		if event.CompletedAt != nil {
+			at = *event.CompletedAt
+		}
+
+		if kind == ReplicationTimelineRun && replicationEventDiverged(event) {
+			entries = append(entries, ReplicationTimelineEntry{
+				Kind:         ReplicationTimelineDivergence,
+				StartedAt:    at,
+				Status:       event.Status,
+				Message:      "target_diverged",
+				Error:        event.Error,
+				EventID:      event.ID,
+				SourceNodeID: event.SourceNodeID,
+				TargetNodeID: event.TargetNodeID,
+			})
+		}
+
+		// A finished failover hands the lease to the target, and the old
+		// owner is rotated into the target set to follow it.
+		if kind == ReplicationTimelineFailover && event.Status == "active" && event.CompletedAt != nil {
+			entries = append(entries,
+				ReplicationTimelineEntry{
+					Kind:            ReplicationTimelineLease,
+					StartedAt:       at,
+					Status:          "acquired",
+					Message:         "lease_owner_changed",
+					EventID:         event.ID,
+					TransitionRunID: event.TransitionRunID,
+					SourceNodeID:    event.SourceNodeID,
+					TargetNodeID:    event.TargetNodeID,
+				},
+				ReplicationTimelineEntry{
+					Kind:            ReplicationTimelineRotation,
+					StartedAt:       at,
+					Status:          "rotated",
+					Message:         "targets_rotated",
+					EventID:         event.ID,
+					TransitionRunID: event.TransitionRunID,
+					SourceNodeID:    event.TargetNodeID,
+					TargetNodeID:    event.SourceNodeID,
+				},
+			)
+		}
+	}
+
+	entries = append(entries, replicationTransitionLeaseEntries(policy)...)
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].StartedAt.Equal(entries[j].StartedAt) {
+			return entries[i].StartedAt.Before(entries[j].StartedAt)
+		}
+		return replicationTimelineKindOrder[entries[i].Kind] < replicationTimelineKindOrder[entries[j].Kind]
+	})
+
+	return entries
+}
+
+// replicationTransitionLeaseEntries reads the phases of the policy's latest
+// ownership transition. Only the latest is kept on the policy, so older
+// transitions show up through their failover events alone.
+func replicationTransitionLeaseEntries(policy *clusterModels.ReplicationPolicy) []ReplicationTimelineEntry {
+	if policy == nil || policy.TransitionRequestedAt == nil {
+		return nil
+	}
+
+	phases := []struct {
+		at      *time.Time
+		status  string
+		message string
+	}{
+		{policy.TransitionDemotedAt, "released", "lease_released_by_source"},
+		{policy.TransitionCatchupAt, "catchup", "final_catchup_complete"},
+		{policy.TransitionPromotedAt, "acquired", "lease_acquired_by_target"},
+	}
+
+	var entries []ReplicationTimelineEntry
+	for _, phase := range phases {
+		if phase.at == nil {
+			continue
+		}
+		entries = append(entries, ReplicationTimelineEntry{
+			Kind:            ReplicationTimelineLease,
+			StartedAt:       *phase.at,
+			Status:          phase.status,
+			Message:         phase.message,
+			TransitionRunID: policy.TransitionRunID,
+			SourceNodeID:    policy.TransitionSourceNodeID,
+			TargetNodeID:    policy.TransitionTargetNodeID,
+			OwnerEpoch:      policy.TransitionOwnerEpoch,
+		})
+	}
+	return entries
+}
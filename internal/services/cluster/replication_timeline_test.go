@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package cluster
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+)
+
+func TestBuildReplicationTimelineEntriesOrdersAndDerivesMarks(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) *time.Time {
+		ts := base.Add(time.Duration(minutes) * time.Minute)
+		return &ts
+	}
+
+	policy := &clusterModels.ReplicationPolicy{
+		ID:                     7,
+		TransitionRunID:        "failover-7-1",
+		TransitionSourceNodeID: "node-a",
+		TransitionTargetNodeID: "node-b",
+		TransitionOwnerEpoch:   3,
+		TransitionRequestedAt:  at(20),
+		TransitionDemotedAt:    at(21),
+		TransitionPromotedAt:   at(23),
+		TransitionCompletedAt:  at(24),
+	}
+
+	// Newest first, as ListReplicationEvents returns them.
+	events := []clusterModels.ReplicationEvent{
+		{ID: 4, EventType: "replication", Status: "success", SourceNodeID: "node-b", TargetNodeID: "node-a", StartedAt: *at(30), CompletedAt: at(31)},
+		{ID: 3, EventType: "failover", Status: "active", TransitionRunID: "failover-7-1", SourceNodeID: "node-a", TargetNodeID: "node-b", StartedAt: *at(20), CompletedAt: at(24)},
+		{ID: 2, EventType: "replication", Status: "failed", Error: "replication_target_diverged_requires_staged_reseed", SourceNodeID: "node-a", TargetNodeID: "node-b", StartedAt: *at(10), CompletedAt: at(12)},
+		{ID: 1, EventType: "replication", Status: "success", SourceNodeID: "node-a", TargetNodeID: "node-b", StartedAt: *at(0), CompletedAt: at(2)},
+	}
+
+	entries := buildReplicationTimelineEntries(policy, events)
+
+	var got []string
+	for _, entry := range entries {
+		got = append(got, entry.Kind+":"+entry.Status)
+	}
+	want := "run:success,run:failed,divergence:failed,failover:active,lease:released,lease:acquired,lease:acquired,rotation:rotated,run:success"
+	if strings.Join(got, ",") != want {
+		t.Fatalf("entries = %s\nwant      %s", strings.Join(got, ","), want)
+	}
+
+	rotation := entries[7]
+	if rotation.SourceNodeID != "node-b" || rotation.TargetNodeID != "node-a" || !rotation.StartedAt.Equal(*at(24)) {
+		t.Fatalf("rotation = %+v", rotation)
+	}
+	if promoted := entries[5]; promoted.OwnerEpoch != 3 || promoted.Message != "lease_acquired_by_target" {
+		t.Fatalf("promotion = %+v", promoted)
+	}
+}
+
+func TestBuildReplicationTimelineEntriesSkipsUnfinishedFailover(t *testing.T) {
+	started := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	entries := buildReplicationTimelineEntries(&clusterModels.ReplicationPolicy{ID: 1}, []clusterModels.ReplicationEvent{
+		{ID: 1, EventType: "failover", Status: "promoting", StartedAt: started},
+	})
+
+	if len(entries) != 1 || entries[0].Kind != ReplicationTimelineFailover || entries[0].EndedAt != nil {
+		t.Fatalf("entries = %+v", entries)
+	}
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package cluster
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	"gorm.io/gorm"
+)
+
+// RestoreOverwriteGroup returns the local group allowed to run restores that
+// overwrite an existing guest, or "" when any admin may run them.
+func (s *Service) RestoreOverwriteGroup() (string, error) {
+	var opt clusterModels.ClusterOption
+	if err := s.DB.First(&opt, 1).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(opt.RestoreOverwriteGroup), nil
+}
+
+func (s *Service) ProposeRestoreOverwriteGroup(group string, bypassRaft bool) error {
+	var opt clusterModels.ClusterOption
+	if err := s.DB.First(&opt, 1).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed_to_load_cluster_options: %w", err)
+	}
+
+	opt.ID = 1
+	opt.RestoreOverwriteGroup = strings.TrimSpace(group)
+
+	if bypassRaft {
+		return s.DB.Save(&opt).Error
+	}
+
+	if s.Raft == nil {
+		return fmt.Errorf("raft_not_initialized")
+	}
+
+	data, err := json.Marshal(struct {
+		ID                    uint   `json:"id"`
+		KeyboardLayout        string `json:"keyboardLayout"`
+		RestoreOverwriteGroup string `json:"restoreOverwriteGroup"`
+	}{
+		ID:                    opt.ID,
+		KeyboardLayout:        opt.KeyboardLayout,
+		RestoreOverwriteGroup: opt.RestoreOverwriteGroup,
+	})
+	if err != nil {
+		return fmt.Errorf("failed_to_marshal_options_payload: %w", err)
+	}
+
+	payload, err := json.Marshal(clusterModels.Command{
+		Type:   "options",
+		Action: "set",
+		Data:   data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed_to_marshal_command: %w", err)
+	}
+
+	applyFuture := s.Raft.Apply(payload, 5*time.Second)
+	if err := applyFuture.Error(); err != nil {
+		return fmt.Errorf("raft_apply_failed: %w", err)
+	}
+
+	if resp, ok := applyFuture.Response().(error); ok && resp != nil {
+		return fmt.Errorf("fsm_apply_failed: %w", resp)
+	}
+
+	return nil
+}
+
+// LocalGuestName looks up a guest registered on this node by its VM RID or
+// jail CTID. A restore onto such a guest overwrites it.
+func (s *Service) LocalGuestName(guestType string, guestID uint) (string, bool, error) {
+	var names []string
+	var err error
+	switch guestType {
+	case clusterModels.ReplicationGuestTypeVM:
+		err = s.DB.Model(&vmModels.VM{}).Where("rid = ?", guestID).Limit(1).Pluck("name", &names).Error
+	case clusterModels.ReplicationGuestTypeJail:
+		err = s.DB.Model(&jailModels.Jail{}).Where("ct_id = ?", guestID).Limit(1).Pluck("name", &names).Error
+	default:
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	if len(names) == 0 {
+		return "", false, nil
+	}
+	return names[0], true, nil
+}
@@ -0,0 +1,234 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package cluster
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/hashicorp/raft"
+	"golang.org/x/crypto/ssh"
+)
+
+// clusterSSHKeyRotationGrace is how long peers keep accepting the key a
+// rotation replaced. It only has to outlast sessions that were already being
+// set up and the Raft apply reaching every follower.
+const clusterSSHKeyRotationGrace = time.Hour
+
+const maxSSHKeyRotationDays = 3650
+
+func generateClusterSSHKeyPair(comment string) ([]byte, string, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, "", fmt.Errorf("cluster_ssh_keygen_failed: %w", err)
+	}
+
+	block, err := ssh.MarshalPrivateKey(priv, comment)
+	if err != nil {
+		return nil, "", fmt.Errorf("cluster_ssh_keygen_failed: %w", err)
+	}
+
+	sshPub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		return nil, "", fmt.Errorf("cluster_ssh_keygen_failed: %w", err)
+	}
+
+	publicKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshPub))) + " " + comment
+	return pem.EncodeToMemory(block), publicKey, nil
+}
+
+func sshKeyFingerprint(authorizedKey string) string {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(strings.TrimSpace(authorizedKey) + "\n"))
+	if err != nil {
+		return ""
+	}
+	return ssh.FingerprintSHA256(key)
+}
+
+// RotateLocalSSHIdentity replaces this node's cluster SSH key. The new key is
+// staged and published through Raft with the old key as the grace-window key;
+// only once that is committed does this node switch to the new key, so there
+// is no point at which peers reject both.
+func (s *Service) RotateLocalSSHIdentity(reason, requestedBy string) (*clusterModels.ClusterSSHKeyRotation, error) {
+	var c clusterModels.Cluster
+	if err := s.DB.First(&c).Error; err != nil || !c.Enabled {
+		return nil, fmt.Errorf("cluster_not_enabled")
+	}
+
+	detail := s.Detail()
+	if detail == nil || strings.TrimSpace(detail.NodeID) == "" {
+		return nil, fmt.Errorf("node_id_unavailable")
+	}
+	nodeID := strings.TrimSpace(detail.NodeID)
+
+	// publishSSHIdentity treats an unknown leader as "retry later", which is
+	// fine for a republish but would strand a rotated key.
+	if s.Raft != nil && s.Raft.State() != raft.Leader {
+		if leaderAddr, _ := s.Raft.LeaderWithID(); leaderAddr == "" {
+			return nil, fmt.Errorf("leader_unknown")
+		}
+	}
+
+	s.sshIdentityMu.Lock()
+	defer s.sshIdentityMu.Unlock()
+
+	privatePath, publicPath, oldPub, err := s.ensureLocalClusterSSHKeyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	newPriv, newPub, err := generateClusterSSHKeyPair("sylve-cluster-" + nodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	graceUntil := time.Now().UTC().Add(clusterSSHKeyRotationGrace)
+	record := clusterModels.ClusterSSHKeyRotation{
+		NodeUUID:       nodeID,
+		Reason:         reason,
+		RequestedBy:    requestedBy,
+		OldFingerprint: sshKeyFingerprint(oldPub),
+		NewFingerprint: sshKeyFingerprint(newPub),
+		GraceUntil:     graceUntil,
+	}
+
+	fail := func(err error) (*clusterModels.ClusterSSHKeyRotation, error) {
+		record.Error = err.Error()
+		if dbErr := s.DB.Create(&record).Error; dbErr != nil {
+			logger.L.Warn().Err(dbErr).Msg("cluster_ssh_key_rotation_record_failed")
+		}
+		return nil, err
+	}
+
+	nextPrivatePath := privatePath + ".next"
+	nextPublicPath := publicPath + ".next"
+	removeStaged := func() {
+		_ = os.Remove(nextPrivatePath)
+		_ = os.Remove(nextPublicPath)
+	}
+
+	if err := os.WriteFile(nextPrivatePath, newPriv, 0600); err != nil {
+		removeStaged()
+		return fail(fmt.Errorf("cluster_ssh_key_stage_failed: %w", err))
+	}
+	if err := os.WriteFile(nextPublicPath, []byte(newPub+"\n"), 0644); err != nil {
+		removeStaged()
+		return fail(fmt.Errorf("cluster_ssh_key_stage_failed: %w", err))
+	}
+
+	if err := s.publishSSHIdentity(clusterModels.ClusterSSHIdentity{
+		NodeUUID:             nodeID,
+		SSHUser:              s.localReplicationSSHUser(),
+		SSHHost:              s.localClusterSSHHost(),
+		SSHPort:              ClusterEmbeddedSSHPort,
+		PublicKey:            newPub,
+		PreviousPublicKey:    oldPub,
+		PreviousKeyExpiresAt: &graceUntil,
+		TokenKeys:            s.localClusterTokenKeys(),
+	}); err != nil {
+		removeStaged()
+		return fail(fmt.Errorf("cluster_ssh_identity_publish_failed: %w", err))
+	}
+
+	// Peers now accept both keys. A failed swap leaves this node on the old
+	// key, which the next routine republish puts back as the current one.
+	if err := os.Rename(nextPrivatePath, privatePath); err != nil {
+		removeStaged()
+		return fail(fmt.Errorf("cluster_ssh_key_swap_failed: %w", err))
+	}
+	if err := os.Rename(nextPublicPath, publicPath); err != nil {
+		removeStaged()
+		return fail(fmt.Errorf("cluster_ssh_key_swap_failed: %w", err))
+	}
+
+	if err := s.DB.Create(&record).Error; err != nil {
+		logger.L.Warn().Err(err).Msg("cluster_ssh_key_rotation_record_failed")
+	}
+
+	logger.L.Info().
+		Str("reason", reason).
+		Str("old_fingerprint", record.OldFingerprint).
+		Str("new_fingerprint", record.NewFingerprint).
+		Msg("cluster_ssh_key_rotated")
+
+	return &record, nil
+}
+
+// RotateLocalSSHIdentityIfDue rotates the key when the node's rotation
+// schedule says it is older than allowed. Without any recorded rotation the
+// key file's age is used.
+func (s *Service) RotateLocalSSHIdentityIfDue() error {
+	var c clusterModels.Cluster
+	if err := s.DB.First(&c).Error; err != nil || !c.Enabled || c.SSHKeyRotationDays <= 0 {
+		return nil
+	}
+
+	detail := s.Detail()
+	if detail == nil || strings.TrimSpace(detail.NodeID) == "" {
+		return nil
+	}
+
+	var last clusterModels.ClusterSSHKeyRotation
+	if err := s.DB.Where("node_uuid = ? AND error = ?", strings.TrimSpace(detail.NodeID), "").
+		Order("created_at DESC").Limit(1).Find(&last).Error; err != nil {
+		return err
+	}
+
+	lastRotated := last.CreatedAt
+	if last.ID == 0 {
+		privatePath, _, _, err := s.ensureLocalClusterSSHKeyPair()
+		if err != nil {
+			return err
+		}
+		info, err := os.Stat(privatePath)
+		if err != nil {
+			return err
+		}
+		lastRotated = info.ModTime()
+	}
+
+	if time.Since(lastRotated) < time.Duration(c.SSHKeyRotationDays)*24*time.Hour {
+		return nil
+	}
+
+	_, err := s.RotateLocalSSHIdentity(clusterModels.SSHKeyRotationReasonScheduled, "system")
+	return err
+}
+
+func (s *Service) SetSSHKeyRotationSchedule(days int) error {
+	if days < 0 || days > maxSSHKeyRotationDays {
+		return fmt.Errorf("invalid_ssh_key_rotation_days")
+	}
+
+	var c clusterModels.Cluster
+	if err := s.DB.First(&c).Error; err != nil {
+		return fmt.Errorf("cluster_not_enabled")
+	}
+
+	return s.DB.Model(&clusterModels.Cluster{}).Where("id = ?", c.ID).Update("ssh_key_rotation_days", days).Error
+}
+
+func (s *Service) ListSSHKeyRotations(limit int) ([]clusterModels.ClusterSSHKeyRotation, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+
+	var rotations []clusterModels.ClusterSSHKeyRotation
+	if err := s.DB.Order("created_at DESC").Limit(limit).Find(&rotations).Error; err != nil {
+		return nil, err
+	}
+	return rotations, nil
+}
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+)
+
+func (s *Service) ListZeltaProfiles() ([]clusterModels.ZeltaProfile, error) {
+	var profiles []clusterModels.ZeltaProfile
+	err := s.DB.Order("name ASC").Find(&profiles).Error
+	return profiles, err
+}
+
+func (s *Service) buildZeltaProfile(id uint, req clusterServiceInterfaces.ZeltaProfileReq) (*clusterModels.ZeltaProfile, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("zelta_profile_name_required")
+	}
+
+	var taken int64
+	if err := s.DB.Model(&clusterModels.ZeltaProfile{}).
+		Where("name = ? AND id != ?", name, id).
+		Count(&taken).Error; err != nil {
+		return nil, err
+	}
+	if taken > 0 {
+		return nil, fmt.Errorf("zelta_profile_name_in_use")
+	}
+
+	env, err := clusterModels.NormalizeZeltaProfileEnv(req.Env)
+	if err != nil {
+		return nil, err
+	}
+
+	return &clusterModels.ZeltaProfile{
+		ID:          id,
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		Env:         env,
+	}, nil
+}
+
+func (s *Service) ProposeZeltaProfileCreate(req clusterServiceInterfaces.ZeltaProfileReq, bypassRaft bool) error {
+	id, err := s.newRaftObjectID("zelta_profiles")
+	if err != nil {
+		return fmt.Errorf("new_zelta_profile_id_failed: %w", err)
+	}
+	return s.proposeZeltaProfileUpsert(id, req, bypassRaft)
+}
+
+func (s *Service) ProposeZeltaProfileUpdate(id uint, req clusterServiceInterfaces.ZeltaProfileReq, bypassRaft bool) error {
+	var existing clusterModels.ZeltaProfile
+	if err := s.DB.First(&existing, id).Error; err != nil {
+		return fmt.Errorf("zelta_profile_not_found: %w", err)
+	}
+	return s.proposeZeltaProfileUpsert(id, req, bypassRaft)
+}
+
+func (s *Service) proposeZeltaProfileUpsert(id uint, req clusterServiceInterfaces.ZeltaProfileReq, bypassRaft bool) error {
+	profile, err := s.buildZeltaProfile(id, req)
+	if err != nil {
+		return err
+	}
+
+	if bypassRaft {
+		return clusterModels.UpsertZeltaProfile(s.DB, profile)
+	}
+
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return fmt.Errorf("failed_to_marshal_zelta_profile: %w", err)
+	}
+
+	return s.applyRaftCommand(clusterModels.Command{
+		Type:   "zelta_profile",
+		Action: "upsert",
+		Data:   data,
+	})
+}
+
+func (s *Service) ProposeZeltaProfileDelete(id uint, bypassRaft bool) error {
+	if bypassRaft {
+		return clusterModels.DeleteZeltaProfile(s.DB, id)
+	}
+
+	data, err := json.Marshal(struct {
+		ID uint `json:"id"`
+	}{ID: id})
+	if err != nil {
+		return fmt.Errorf("failed_to_marshal_delete_payload: %w", err)
+	}
+
+	return s.applyRaftCommand(clusterModels.Command{
+		Type:   "zelta_profile",
+		Action: "delete",
+		Data:   data,
+	})
+}
+
+// requireZeltaProfile checks a profile selected by a job or a policy. Zero
+// selects zelta's defaults.
+func (s *Service) requireZeltaProfile(id uint) error {
+	if id == 0 {
+		return nil
+	}
+	var count int64
+	if err := s.DB.Model(&clusterModels.ZeltaProfile{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("zelta_profile_not_found")
+	}
+	return nil
+}
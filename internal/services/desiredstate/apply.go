@@ -0,0 +1,880 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package desiredstate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/services/cluster"
+	"github.com/alchemillahq/sylve/internal/services/jail"
+	"github.com/alchemillahq/sylve/internal/services/libvirt"
+	"github.com/alchemillahq/sylve/internal/services/network"
+
+	"github.com/hashicorp/raft"
+	"gorm.io/gorm"
+)
+
+const (
+	KindObject            = "object"
+	KindManualSwitch      = "manual_switch"
+	KindStandardSwitch    = "standard_switch"
+	KindVM                = "vm"
+	KindJail              = "jail"
+	KindBackupJob         = "backup_job"
+	KindReplicationPolicy = "replication_policy"
+)
+
+const (
+	ActionCreate    = "create"
+	ActionUpdate    = "update"
+	ActionUnchanged = "unchanged"
+	ActionRejected  = "rejected"
+)
+
+// Change is the planned (and, outside dry-run, applied) outcome for one
+// resource in the document.
+type Change struct {
+	Kind    string   `json:"kind"`
+	Key     string   `json:"key"`
+	Action  string   `json:"action"`
+	Fields  []string `json:"fields,omitempty"`
+	Applied bool     `json:"applied"`
+	Error   string   `json:"error,omitempty"`
+}
+
+type Result struct {
+	DryRun  bool     `json:"dryRun"`
+	Changes []Change `json:"changes"`
+	Failed  int      `json:"failed"`
+}
+
+type networkApplier interface {
+	CreateObject(name string, oType string, values []string) (uint, error)
+	EditObject(id uint, name string, oType string, values []string) error
+	CreateManualSwitch(name, bridge string) (*networkModels.ManualSwitch, error)
+	UpdateManualSwitch(id uint, name, bridge string) (*networkModels.ManualSwitch, error)
+	NewStandardSwitch(
+		name string,
+		mtu int,
+		vlan int,
+		network4Id uint,
+		network6Id uint,
+		gateway4Id uint,
+		gateway6Id uint,
+		ports []string,
+		private bool,
+		dhcp bool,
+		disableIPv6 bool,
+		slaac bool,
+		defaultRoute bool,
+		manual networkModels.StandardSwitchManualAddresses,
+	) error
+	EditStandardSwitch(
+		id uint,
+		mtu int,
+		vlan int,
+		network4Id uint,
+		network6Id uint,
+		gateway4Id uint,
+		gateway6Id uint,
+		ports []string,
+		private bool,
+		dhcp bool,
+		disableIPv6 bool,
+		slaac bool,
+		defaultRoute bool,
+		manual networkModels.StandardSwitchManualAddresses,
+	) error
+}
+
+type vmApplier interface {
+	ModifyRAM(rid uint, ram int) error
+	ModifyBootOrder(rid uint, startAtBoot bool, bootOrder int) error
+	ModifyShutdownWaitTime(rid uint, waitTime int) error
+}
+
+type jailApplier interface {
+	ModifyBootOrder(ctId uint, startAtBoot bool, bootOrder int) error
+}
+
+type clusterApplier interface {
+	ProposeBackupJobCreate(input clusterServiceInterfaces.BackupJobReq, bypassRaft bool) error
+	ProposeBackupJobUpdate(id uint, input clusterServiceInterfaces.BackupJobReq, bypassRaft bool) error
+	ProposeReplicationPolicyCreate(input clusterServiceInterfaces.ReplicationPolicyReq, bypassRaft bool) error
+	ProposeReplicationPolicyUpdate(id uint, input clusterServiceInterfaces.ReplicationPolicyReq, bypassRaft bool) error
+}
+
+// Service plans and applies desired-state documents through the same
+// service methods the individual API handlers use, so every change goes
+// through the regular validation and side effects.
+type Service struct {
+	DB *gorm.DB
+
+	network networkApplier
+	vms     vmApplier
+	jails   jailApplier
+	cluster clusterApplier
+
+	// clusterWriteMode reports whether cluster resources can be written on
+	// this node and whether the write should bypass raft.
+	clusterWriteMode func() (bool, error)
+}
+
+func NewService(
+	db *gorm.DB,
+	networkService *network.Service,
+	libvirtService *libvirt.Service,
+	jailService *jail.Service,
+	clusterService *cluster.Service,
+) *Service {
+	s := &Service{DB: db}
+	if networkService != nil {
+		s.network = networkService
+	}
+	if libvirtService != nil {
+		s.vms = libvirtService
+	}
+	if jailService != nil {
+		s.jails = jailService
+	}
+	if clusterService != nil {
+		s.cluster = clusterService
+		s.clusterWriteMode = func() (bool, error) {
+			if clusterService.Raft == nil {
+				return true, nil
+			}
+			if clusterService.Raft.State() != raft.Leader {
+				return false, fmt.Errorf("cluster_resources_require_leader")
+			}
+			return false, nil
+		}
+	}
+	return s
+}
+
+type applyRun struct {
+	s      *Service
+	dryRun bool
+	result *Result
+
+	// Objects created earlier in the same document, so switches can
+	// reference them before they exist during a dry-run.
+	plannedObjects map[string]struct{}
+}
+
+// Apply diffs doc against the current state and, unless dryRun is set,
+// applies the differences. Resources are processed in dependency order and a
+// failure on one resource is recorded without stopping the others. Only
+// failures to read the current state abort the run.
+func (s *Service) Apply(ctx context.Context, doc *Document, dryRun bool) (*Result, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("document_required")
+	}
+	if err := doc.Validate(); err != nil {
+		return nil, err
+	}
+
+	run := &applyRun{
+		s:              s,
+		dryRun:         dryRun,
+		result:         &Result{DryRun: dryRun, Changes: []Change{}},
+		plannedObjects: make(map[string]struct{}),
+	}
+
+	steps := []func() error{
+		func() error { return run.objects(doc.Objects) },
+		func() error { return run.manualSwitches(doc.ManualSwitches) },
+		func() error { return run.standardSwitches(doc.StandardSwitches) },
+		func() error { return run.vms(doc.VMs) },
+		func() error { return run.jails(doc.Jails) },
+		func() error { return run.backupJobs(doc.BackupJobs) },
+		func() error { return run.replicationPolicies(doc.ReplicationPolicies) },
+	}
+	for _, step := range steps {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := step(); err != nil {
+			return nil, err
+		}
+	}
+
+	return run.result, nil
+}
+
+// record appends change to the result and runs apply for pending creates and
+// updates outside of dry-run.
+func (r *applyRun) record(change Change, apply func() error) {
+	pending := change.Action == ActionCreate || change.Action == ActionUpdate
+	if pending && change.Error == "" && !r.dryRun {
+		if err := apply(); err != nil {
+			change.Error = err.Error()
+		} else {
+			change.Applied = true
+		}
+	}
+	if change.Error != "" {
+		r.result.Failed++
+	}
+	r.result.Changes = append(r.result.Changes, change)
+}
+
+func (r *applyRun) reject(kind, key string, err error) {
+	r.record(Change{Kind: kind, Key: key, Action: ActionRejected, Error: err.Error()}, nil)
+}
+
+// fieldDiff collects the names of declared fields that differ from the
+// current state.
+type fieldDiff struct {
+	fields []string
+}
+
+func (d *fieldDiff) changed(field string) bool {
+	return slices.Contains(d.fields, field)
+}
+
+func (d *fieldDiff) action() string {
+	if len(d.fields) == 0 {
+		return ActionUnchanged
+	}
+	return ActionUpdate
+}
+
+func desiredValue[T comparable](d *fieldDiff, field string, current T, desired *T) T {
+	if desired == nil || *desired == current {
+		return current
+	}
+	d.fields = append(d.fields, field)
+	return *desired
+}
+
+// desiredSet compares lists whose order carries no meaning.
+func desiredSet(d *fieldDiff, field string, current, desired []string) []string {
+	if desired == nil {
+		return current
+	}
+	a := slices.Clone(current)
+	b := slices.Clone(desired)
+	slices.Sort(a)
+	slices.Sort(b)
+	if slices.Equal(a, b) {
+		return current
+	}
+	d.fields = append(d.fields, field)
+	return desired
+}
+
+func (r *applyRun) objects(specs []ObjectSpec) error {
+	if len(specs) == 0 {
+		return nil
+	}
+
+	var current []networkModels.Object
+	if err := r.s.DB.Preload("Entries").Find(&current).Error; err != nil {
+		return fmt.Errorf("failed_to_load_objects: %w", err)
+	}
+	byName := make(map[string]networkModels.Object, len(current))
+	for _, obj := range current {
+		byName[obj.Name] = obj
+	}
+
+	for _, spec := range specs {
+		name := strings.TrimSpace(spec.Name)
+		existing, ok := byName[name]
+		if !ok {
+			r.plannedObjects[name] = struct{}{}
+			r.record(Change{Kind: KindObject, Key: name, Action: ActionCreate}, func() error {
+				_, err := r.s.network.CreateObject(name, spec.Type, spec.Values)
+				return err
+			})
+			continue
+		}
+
+		values := make([]string, 0, len(existing.Entries))
+		for _, entry := range existing.Entries {
+			values = append(values, entry.Value)
+		}
+
+		var diff fieldDiff
+		oType := desiredValue(&diff, "type", existing.Type, &spec.Type)
+		values = desiredSet(&diff, "values", values, spec.Values)
+
+		r.record(Change{Kind: KindObject, Key: name, Action: diff.action(), Fields: diff.fields}, func() error {
+			return r.s.network.EditObject(existing.ID, name, oType, values)
+		})
+	}
+
+	return nil
+}
+
+func (r *applyRun) manualSwitches(specs []ManualSwitchSpec) error {
+	if len(specs) == 0 {
+		return nil
+	}
+
+	var current []networkModels.ManualSwitch
+	if err := r.s.DB.Find(&current).Error; err != nil {
+		return fmt.Errorf("failed_to_load_manual_switches: %w", err)
+	}
+	byName := make(map[string]networkModels.ManualSwitch, len(current))
+	for _, sw := range current {
+		byName[sw.Name] = sw
+	}
+
+	for _, spec := range specs {
+		name := strings.TrimSpace(spec.Name)
+		bridge := strings.TrimSpace(spec.Bridge)
+		existing, ok := byName[name]
+		if !ok {
+			r.record(Change{Kind: KindManualSwitch, Key: name, Action: ActionCreate}, func() error {
+				_, err := r.s.network.CreateManualSwitch(name, bridge)
+				return err
+			})
+			continue
+		}
+
+		var diff fieldDiff
+		bridge = desiredValue(&diff, "bridge", existing.Bridge, &bridge)
+
+		r.record(Change{Kind: KindManualSwitch, Key: name, Action: diff.action(), Fields: diff.fields}, func() error {
+			_, err := r.s.network.UpdateManualSwitch(existing.ID, name, bridge)
+			return err
+		})
+	}
+
+	return nil
+}
+
+// standardSwitchState is a standard switch with its object references
+// expressed by name, which is how documents refer to them.
+type standardSwitchState struct {
+	MTU          int
+	VLAN         int
+	Ports        []string
+	Network4     string
+	Gateway4     string
+	Network6     string
+	Gateway6     string
+	Private      bool
+	DHCP         bool
+	DisableIPv6  bool
+	SLAAC        bool
+	DefaultRoute bool
+	Manual       networkModels.StandardSwitchManualAddresses
+}
+
+func objectName(obj *networkModels.Object) string {
+	if obj == nil {
+		return ""
+	}
+	return obj.Name
+}
+
+func currentStandardSwitchState(sw networkModels.StandardSwitch) standardSwitchState {
+	ports := make([]string, 0, len(sw.Ports))
+	for _, port := range sw.Ports {
+		ports = append(ports, port.Name)
+	}
+
+	return standardSwitchState{
+		MTU:          sw.MTU,
+		VLAN:         sw.VLAN,
+		Ports:        ports,
+		Network4:     objectName(sw.NetworkObj),
+		Gateway4:     objectName(sw.GatewayAddressObj),
+		Network6:     objectName(sw.Network6Obj),
+		Gateway6:     objectName(sw.Gateway6AddressObj),
+		Private:      sw.Private,
+		DHCP:         sw.DHCP,
+		DisableIPv6:  sw.DisableIPv6,
+		SLAAC:        sw.SLAAC,
+		DefaultRoute: sw.DefaultRoute,
+		Manual: networkModels.StandardSwitchManualAddresses{
+			Network4: sw.NetworkManual,
+			Gateway4: sw.GatewayManual,
+			Network6: sw.Network6Manual,
+			Gateway6: sw.Gateway6Manual,
+		},
+	}
+}
+
+func mergeStandardSwitch(current standardSwitchState, spec StandardSwitchSpec) (standardSwitchState, fieldDiff) {
+	var diff fieldDiff
+	next := current
+	next.MTU = desiredValue(&diff, "mtu", current.MTU, spec.MTU)
+	next.VLAN = desiredValue(&diff, "vlan", current.VLAN, spec.VLAN)
+	next.Ports = desiredSet(&diff, "ports", current.Ports, spec.Ports)
+	next.Network4 = desiredValue(&diff, "network4", current.Network4, spec.Network4)
+	next.Gateway4 = desiredValue(&diff, "gateway4", current.Gateway4, spec.Gateway4)
+	next.Network6 = desiredValue(&diff, "network6", current.Network6, spec.Network6)
+	next.Gateway6 = desiredValue(&diff, "gateway6", current.Gateway6, spec.Gateway6)
+	next.Private = desiredValue(&diff, "private", current.Private, spec.Private)
+	next.DHCP = desiredValue(&diff, "dhcp", current.DHCP, spec.DHCP)
+	next.DisableIPv6 = desiredValue(&diff, "disableIPv6", current.DisableIPv6, spec.DisableIPv6)
+	next.SLAAC = desiredValue(&diff, "slaac", current.SLAAC, spec.SLAAC)
+	next.DefaultRoute = desiredValue(&diff, "defaultRoute", current.DefaultRoute, spec.DefaultRoute)
+
+	// An object reference and a manual address for the same slot are
+	// mutually exclusive, so declaring an object drops the manual value.
+	if next.Network4 != "" {
+		next.Manual.Network4 = ""
+	}
+	if next.Gateway4 != "" {
+		next.Manual.Gateway4 = ""
+	}
+	if next.Network6 != "" {
+		next.Manual.Network6 = ""
+	}
+	if next.Gateway6 != "" {
+		next.Manual.Gateway6 = ""
+	}
+
+	return next, diff
+}
+
+// checkObjectRefs verifies that every referenced object exists or is created
+// earlier in the same document.
+func (r *applyRun) checkObjectRefs(state standardSwitchState, known map[string]struct{}) error {
+	for _, name := range []string{state.Network4, state.Gateway4, state.Network6, state.Gateway6} {
+		if name == "" {
+			continue
+		}
+		if _, ok := known[name]; ok {
+			continue
+		}
+		if _, ok := r.plannedObjects[name]; ok {
+			continue
+		}
+		return fmt.Errorf("object_not_found: %s", name)
+	}
+	return nil
+}
+
+// objectIDs resolves object names at apply time, after objects declared in
+// the same document have been created.
+func (r *applyRun) objectIDs(names ...string) ([]uint, error) {
+	ids := make([]uint, len(names))
+	for i, name := range names {
+		if name == "" {
+			continue
+		}
+		var obj networkModels.Object
+		if err := r.s.DB.Select("id").Where("name = ?", name).First(&obj).Error; err != nil {
+			return nil, fmt.Errorf("object_not_found: %s", name)
+		}
+		ids[i] = obj.ID
+	}
+	return ids, nil
+}
+
+func (r *applyRun) standardSwitches(specs []StandardSwitchSpec) error {
+	if len(specs) == 0 {
+		return nil
+	}
+
+	var current []networkModels.StandardSwitch
+	if err := r.s.DB.
+		Preload("Ports").
+		Preload("NetworkObj").
+		Preload("Network6Obj").
+		Preload("GatewayAddressObj").
+		Preload("Gateway6AddressObj").
+		Find(&current).Error; err != nil {
+		return fmt.Errorf("failed_to_load_standard_switches: %w", err)
+	}
+	byName := make(map[string]networkModels.StandardSwitch, len(current))
+	for _, sw := range current {
+		byName[sw.Name] = sw
+	}
+
+	var objectNames []string
+	if err := r.s.DB.Model(&networkModels.Object{}).Pluck("name", &objectNames).Error; err != nil {
+		return fmt.Errorf("failed_to_load_objects: %w", err)
+	}
+	knownObjects := make(map[string]struct{}, len(objectNames))
+	for _, name := range objectNames {
+		knownObjects[name] = struct{}{}
+	}
+
+	for _, spec := range specs {
+		name := strings.TrimSpace(spec.Name)
+		existing, ok := byName[name]
+
+		change := Change{Kind: KindStandardSwitch, Key: name, Action: ActionCreate}
+		base := standardSwitchState{MTU: 1500}
+		if ok {
+			base = currentStandardSwitchState(existing)
+		}
+
+		next, diff := mergeStandardSwitch(base, spec)
+		if ok {
+			change.Action = diff.action()
+			change.Fields = diff.fields
+		}
+		if change.Action != ActionUnchanged {
+			if err := r.checkObjectRefs(next, knownObjects); err != nil {
+				change.Error = err.Error()
+			}
+		}
+
+		r.record(change, func() error {
+			ids, err := r.objectIDs(next.Network4, next.Network6, next.Gateway4, next.Gateway6)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return r.s.network.NewStandardSwitch(
+					name, next.MTU, next.VLAN,
+					ids[0], ids[1], ids[2], ids[3],
+					next.Ports, next.Private, next.DHCP, next.DisableIPv6, next.SLAAC, next.DefaultRoute,
+					next.Manual,
+				)
+			}
+			return r.s.network.EditStandardSwitch(
+				existing.ID, next.MTU, next.VLAN,
+				ids[0], ids[1], ids[2], ids[3],
+				next.Ports, next.Private, next.DHCP, next.DisableIPv6, next.SLAAC, next.DefaultRoute,
+				next.Manual,
+			)
+		})
+	}
+
+	return nil
+}
+
+func (r *applyRun) vms(specs []VMSpec) error {
+	if len(specs) == 0 {
+		return nil
+	}
+
+	for _, spec := range specs {
+		key := fmt.Sprint(spec.RID)
+
+		var vm vmModels.VM
+		if err := r.s.DB.Where("rid = ?", spec.RID).First(&vm).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				r.reject(KindVM, key, fmt.Errorf("vm_not_found"))
+				continue
+			}
+			return fmt.Errorf("failed_to_load_vm: %w", err)
+		}
+
+		var diff fieldDiff
+		ram := desiredValue(&diff, "ram", vm.RAM, spec.RAM)
+		startAtBoot := desiredValue(&diff, "startAtBoot", vm.StartAtBoot, spec.StartAtBoot)
+		startOrder := desiredValue(&diff, "startOrder", vm.StartOrder, spec.StartOrder)
+		waitTime := desiredValue(&diff, "shutdownWaitTime", vm.ShutdownWaitTime, spec.ShutdownWaitTime)
+
+		r.record(Change{Kind: KindVM, Key: key, Action: diff.action(), Fields: diff.fields}, func() error {
+			if diff.changed("ram") {
+				if err := r.s.vms.ModifyRAM(spec.RID, ram); err != nil {
+					return err
+				}
+			}
+			if diff.changed("startAtBoot") || diff.changed("startOrder") {
+				if err := r.s.vms.ModifyBootOrder(spec.RID, startAtBoot, startOrder); err != nil {
+					return err
+				}
+			}
+			if diff.changed("shutdownWaitTime") {
+				if err := r.s.vms.ModifyShutdownWaitTime(spec.RID, waitTime); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	return nil
+}
+
+func (r *applyRun) jails(specs []JailSpec) error {
+	if len(specs) == 0 {
+		return nil
+	}
+
+	for _, spec := range specs {
+		key := fmt.Sprint(spec.CTID)
+
+		var j jailModels.Jail
+		if err := r.s.DB.Where("ct_id = ?", spec.CTID).First(&j).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				r.reject(KindJail, key, fmt.Errorf("jail_not_found"))
+				continue
+			}
+			return fmt.Errorf("failed_to_load_jail: %w", err)
+		}
+
+		currentStartAtBoot := j.StartAtBoot != nil && *j.StartAtBoot
+
+		var diff fieldDiff
+		startAtBoot := desiredValue(&diff, "startAtBoot", currentStartAtBoot, spec.StartAtBoot)
+		startOrder := desiredValue(&diff, "startOrder", j.StartOrder, spec.StartOrder)
+
+		r.record(Change{Kind: KindJail, Key: key, Action: diff.action(), Fields: diff.fields}, func() error {
+			return r.s.jails.ModifyBootOrder(spec.CTID, startAtBoot, startOrder)
+		})
+	}
+
+	return nil
+}
+
+// clusterBypass resolves the raft mode for cluster resources once per run.
+// Planning still works on followers; only applying needs the leader.
+func (r *applyRun) clusterBypass() (bool, error) {
+	if r.s.cluster == nil || r.s.clusterWriteMode == nil {
+		return false, fmt.Errorf("cluster_service_unavailable")
+	}
+	return r.s.clusterWriteMode()
+}
+
+func (r *applyRun) backupJobs(specs []BackupJobSpec) error {
+	if len(specs) == 0 {
+		return nil
+	}
+
+	var current []clusterModels.BackupJob
+	if err := r.s.DB.Preload("Target").Find(&current).Error; err != nil {
+		return fmt.Errorf("failed_to_load_backup_jobs: %w", err)
+	}
+	byName := make(map[string][]clusterModels.BackupJob, len(current))
+	for _, job := range current {
+		byName[job.Name] = append(byName[job.Name], job)
+	}
+
+	var targets []clusterModels.BackupTarget
+	if err := r.s.DB.Select("id", "name").Find(&targets).Error; err != nil {
+		return fmt.Errorf("failed_to_load_backup_targets: %w", err)
+	}
+	targetIDs := make(map[string]uint, len(targets))
+	for _, target := range targets {
+		targetIDs[target.Name] = target.ID
+	}
+
+	for _, spec := range specs {
+		name := strings.TrimSpace(spec.Name)
+		matches := byName[name]
+		if len(matches) > 1 {
+			r.reject(KindBackupJob, name, fmt.Errorf("ambiguous_backup_job_name"))
+			continue
+		}
+
+		change := Change{Kind: KindBackupJob, Key: name, Action: ActionCreate}
+		var existing clusterModels.BackupJob
+		var currentTarget string
+		enabled := true
+		req := clusterServiceInterfaces.BackupJobReq{Name: name}
+		if len(matches) == 1 {
+			existing = matches[0]
+			currentTarget = existing.Target.Name
+			enabled = existing.Enabled
+			req = clusterServiceInterfaces.BackupJobReq{
+				Name:               existing.Name,
+				TargetID:           existing.TargetID,
+				RunnerNodeID:       existing.RunnerNodeID,
+				Mode:               existing.Mode,
+				SourceDataset:      existing.SourceDataset,
+				JailRootDataset:    existing.JailRootDataset,
+				PruneKeepLast:      existing.PruneKeepLast,
+				PruneTarget:        existing.PruneTarget,
+				StopBeforeBackup:   existing.StopBeforeBackup,
+				Recursive:          existing.Recursive,
+				ShareQuiesce:       existing.ShareQuiesce,
+				VMDatasetIncludes:  existing.VMDatasetIncludes,
+				VMDatasetExcludes:  existing.VMDatasetExcludes,
+				PreBackupScript:    existing.PreBackupScript,
+				PostBackupScript:   existing.PostBackupScript,
+				HookTimeoutSeconds: existing.HookTimeoutSeconds,
+				HookFailurePolicy:  existing.HookFailurePolicy,
+				ZeltaProfileID:     existing.ZeltaProfileID,
+				CronExpr:           existing.CronExpr,
+			}
+		}
+
+		var diff fieldDiff
+		target := desiredValue(&diff, "target", currentTarget, spec.Target)
+		req.RunnerNodeID = desiredValue(&diff, "runnerNodeId", req.RunnerNodeID, spec.RunnerNodeID)
+		req.Mode = desiredValue(&diff, "mode", req.Mode, spec.Mode)
+		req.SourceDataset = desiredValue(&diff, "sourceDataset", req.SourceDataset, spec.SourceDataset)
+		req.JailRootDataset = desiredValue(&diff, "jailRootDataset", req.JailRootDataset, spec.JailRootDataset)
+		req.PruneKeepLast = desiredValue(&diff, "pruneKeepLast", req.PruneKeepLast, spec.PruneKeepLast)
+		req.PruneTarget = desiredValue(&diff, "pruneTarget", req.PruneTarget, spec.PruneTarget)
+		req.StopBeforeBackup = desiredValue(&diff, "stopBeforeBackup", req.StopBeforeBackup, spec.StopBeforeBackup)
+		req.Recursive = desiredValue(&diff, "recursive", req.Recursive, spec.Recursive)
+		req.ShareQuiesce = desiredValue(&diff, "shareQuiesce", req.ShareQuiesce, spec.ShareQuiesce)
+		req.VMDatasetIncludes = desiredValue(&diff, "vmDatasetIncludes", req.VMDatasetIncludes, spec.VMDatasetIncludes)
+		req.VMDatasetExcludes = desiredValue(&diff, "vmDatasetExcludes", req.VMDatasetExcludes, spec.VMDatasetExcludes)
+		req.PreBackupScript = desiredValue(&diff, "preBackupScript", req.PreBackupScript, spec.PreBackupScript)
+		req.PostBackupScript = desiredValue(&diff, "postBackupScript", req.PostBackupScript, spec.PostBackupScript)
+		req.HookTimeoutSeconds = desiredValue(&diff, "hookTimeoutSeconds", req.HookTimeoutSeconds, spec.HookTimeoutSeconds)
+		req.HookFailurePolicy = desiredValue(&diff, "hookFailurePolicy", req.HookFailurePolicy, spec.HookFailurePolicy)
+		req.CronExpr = desiredValue(&diff, "cronExpr", req.CronExpr, spec.CronExpr)
+		enabled = desiredValue(&diff, "enabled", enabled, spec.Enabled)
+		req.Enabled = &enabled
+
+		if len(matches) == 1 {
+			change.Action = diff.action()
+			change.Fields = diff.fields
+		}
+		if change.Action != ActionUnchanged {
+			if id, ok := targetIDs[target]; ok {
+				req.TargetID = id
+			} else {
+				change.Error = fmt.Sprintf("backup_target_not_found: %s", target)
+			}
+		}
+
+		r.record(change, func() error {
+			bypassRaft, err := r.clusterBypass()
+			if err != nil {
+				return err
+			}
+			if len(matches) == 0 {
+				return r.s.cluster.ProposeBackupJobCreate(req, bypassRaft)
+			}
+			return r.s.cluster.ProposeBackupJobUpdate(existing.ID, req, bypassRaft)
+		})
+	}
+
+	return nil
+}
+
+// normalizeReplicationTargets renders targets in a comparable form, applying
+// the same default weight the cluster service does.
+func normalizeReplicationTargets(targets []clusterServiceInterfaces.ReplicationPolicyTargetReq) []string {
+	out := make([]string, 0, len(targets))
+	for _, target := range targets {
+		weight := target.Weight
+		if weight == 0 {
+			weight = 100
+		}
+		out = append(out, fmt.Sprintf("%s:%d", strings.TrimSpace(target.NodeID), weight))
+	}
+	slices.Sort(out)
+	return out
+}
+
+func (r *applyRun) replicationPolicies(specs []ReplicationPolicySpec) error {
+	if len(specs) == 0 {
+		return nil
+	}
+
+	var current []clusterModels.ReplicationPolicy
+	if err := r.s.DB.Preload("Targets").Find(&current).Error; err != nil {
+		return fmt.Errorf("failed_to_load_replication_policies: %w", err)
+	}
+	byName := make(map[string][]clusterModels.ReplicationPolicy, len(current))
+	for _, policy := range current {
+		byName[policy.Name] = append(byName[policy.Name], policy)
+	}
+
+	for _, spec := range specs {
+		name := strings.TrimSpace(spec.Name)
+		matches := byName[name]
+		if len(matches) > 1 {
+			r.reject(KindReplicationPolicy, name, fmt.Errorf("ambiguous_replication_policy_name"))
+			continue
+		}
+
+		change := Change{Kind: KindReplicationPolicy, Key: name, Action: ActionCreate}
+		var existing clusterModels.ReplicationPolicy
+		req := clusterServiceInterfaces.ReplicationPolicyReq{Name: name}
+		crashRecovery, crashRestartMax := true, 3
+		poolHealthCheck, poolCapacityPct := true, 90
+		sourceBookmarks := false
+		enabled := true
+		if len(matches) == 1 {
+			existing = matches[0]
+			req = clusterServiceInterfaces.ReplicationPolicyReq{
+				Name:         existing.Name,
+				Description:  existing.Description,
+				GuestType:    existing.GuestType,
+				GuestID:      existing.GuestID,
+				SourceNodeID: existing.SourceNodeID,
+				SourceMode:   existing.SourceMode,
+				FailbackMode: existing.FailbackMode,
+				FailoverMode: existing.FailoverMode,
+				CronExpr:     existing.CronExpr,
+			}
+			for _, target := range existing.Targets {
+				req.Targets = append(req.Targets, clusterServiceInterfaces.ReplicationPolicyTargetReq{
+					NodeID: target.NodeID,
+					Weight: target.Weight,
+				})
+			}
+			crashRecovery, crashRestartMax = existing.CrashRecovery, existing.CrashRestartMax
+			poolHealthCheck, poolCapacityPct = existing.PoolHealthCheck, existing.PoolCapacityPct
+			sourceBookmarks = existing.SourceBookmarks
+			enabled = existing.Enabled
+		}
+
+		var diff fieldDiff
+		req.Description = desiredValue(&diff, "description", req.Description, spec.Description)
+		req.GuestType = desiredValue(&diff, "guestType", req.GuestType, spec.GuestType)
+		req.GuestID = desiredValue(&diff, "guestId", req.GuestID, spec.GuestID)
+		req.SourceNodeID = desiredValue(&diff, "sourceNodeId", req.SourceNodeID, spec.SourceNodeID)
+		req.SourceMode = desiredValue(&diff, "sourceMode", req.SourceMode, spec.SourceMode)
+		req.FailbackMode = desiredValue(&diff, "failbackMode", req.FailbackMode, spec.FailbackMode)
+		req.FailoverMode = desiredValue(&diff, "failoverMode", req.FailoverMode, spec.FailoverMode)
+		req.CronExpr = desiredValue(&diff, "cronExpr", req.CronExpr, spec.CronExpr)
+		crashRecovery = desiredValue(&diff, "crashRecovery", crashRecovery, spec.CrashRecovery)
+		crashRestartMax = desiredValue(&diff, "crashRestartMax", crashRestartMax, spec.CrashRestartMax)
+		poolHealthCheck = desiredValue(&diff, "poolHealthCheck", poolHealthCheck, spec.PoolHealthCheck)
+		poolCapacityPct = desiredValue(&diff, "poolCapacityPct", poolCapacityPct, spec.PoolCapacityPct)
+		sourceBookmarks = desiredValue(&diff, "sourceBookmarks", sourceBookmarks, spec.SourceBookmarks)
+		enabled = desiredValue(&diff, "enabled", enabled, spec.Enabled)
+		req.CrashRecovery = &crashRecovery
+		req.CrashRestartMax = &crashRestartMax
+		req.PoolHealthCheck = &poolHealthCheck
+		req.PoolCapacityPct = &poolCapacityPct
+		req.SourceBookmarks = &sourceBookmarks
+		req.Enabled = &enabled
+
+		if spec.Targets != nil {
+			desired := make([]clusterServiceInterfaces.ReplicationPolicyTargetReq, 0, len(spec.Targets))
+			for _, target := range spec.Targets {
+				desired = append(desired, clusterServiceInterfaces.ReplicationPolicyTargetReq{
+					NodeID: target.NodeID,
+					Weight: target.Weight,
+				})
+			}
+			if !slices.Equal(normalizeReplicationTargets(req.Targets), normalizeReplicationTargets(desired)) {
+				diff.fields = append(diff.fields, "targets")
+				req.Targets = desired
+			}
+		}
+
+		if len(matches) == 1 {
+			change.Action = diff.action()
+			change.Fields = diff.fields
+		}
+
+		r.record(change, func() error {
+			bypassRaft, err := r.clusterBypass()
+			if err != nil {
+				return err
+			}
+			if len(matches) == 0 {
+				return r.s.cluster.ProposeReplicationPolicyCreate(req, bypassRaft)
+			}
+			return r.s.cluster.ProposeReplicationPolicyUpdate(existing.ID, req, bypassRaft)
+		})
+	}
+
+	return nil
+}
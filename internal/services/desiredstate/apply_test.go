@@ -0,0 +1,376 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package desiredstate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/testutil"
+	"gorm.io/gorm"
+)
+
+type fakeNetwork struct {
+	db    *gorm.DB
+	calls []string
+}
+
+func (f *fakeNetwork) CreateObject(name string, oType string, values []string) (uint, error) {
+	f.calls = append(f.calls, "create_object:"+name)
+	obj := networkModels.Object{Name: name, Type: oType}
+	for _, value := range values {
+		obj.Entries = append(obj.Entries, networkModels.ObjectEntry{Value: value})
+	}
+	return obj.ID, f.db.Create(&obj).Error
+}
+
+func (f *fakeNetwork) EditObject(id uint, name string, oType string, values []string) error {
+	f.calls = append(f.calls, "edit_object:"+name)
+	return nil
+}
+
+func (f *fakeNetwork) CreateManualSwitch(name, bridge string) (*networkModels.ManualSwitch, error) {
+	f.calls = append(f.calls, "create_manual_switch:"+name)
+	return &networkModels.ManualSwitch{Name: name, Bridge: bridge}, nil
+}
+
+func (f *fakeNetwork) UpdateManualSwitch(id uint, name, bridge string) (*networkModels.ManualSwitch, error) {
+	f.calls = append(f.calls, "update_manual_switch:"+name)
+	return &networkModels.ManualSwitch{ID: id, Name: name, Bridge: bridge}, nil
+}
+
+func (f *fakeNetwork) NewStandardSwitch(
+	name string,
+	mtu int,
+	vlan int,
+	network4Id uint,
+	network6Id uint,
+	gateway4Id uint,
+	gateway6Id uint,
+	ports []string,
+	private bool,
+	dhcp bool,
+	disableIPv6 bool,
+	slaac bool,
+	defaultRoute bool,
+	manual networkModels.StandardSwitchManualAddresses,
+) error {
+	f.calls = append(f.calls, fmt.Sprintf("new_standard_switch:%s:%d:%d", name, mtu, network4Id))
+	return nil
+}
+
+func (f *fakeNetwork) EditStandardSwitch(
+	id uint,
+	mtu int,
+	vlan int,
+	network4Id uint,
+	network6Id uint,
+	gateway4Id uint,
+	gateway6Id uint,
+	ports []string,
+	private bool,
+	dhcp bool,
+	disableIPv6 bool,
+	slaac bool,
+	defaultRoute bool,
+	manual networkModels.StandardSwitchManualAddresses,
+) error {
+	f.calls = append(f.calls, fmt.Sprintf("edit_standard_switch:%d:%d", id, mtu))
+	return nil
+}
+
+type fakeVMs struct {
+	calls []string
+}
+
+func (f *fakeVMs) ModifyRAM(rid uint, ram int) error {
+	f.calls = append(f.calls, fmt.Sprintf("ram:%d:%d", rid, ram))
+	return nil
+}
+
+func (f *fakeVMs) ModifyBootOrder(rid uint, startAtBoot bool, bootOrder int) error {
+	f.calls = append(f.calls, fmt.Sprintf("boot:%d:%t:%d", rid, startAtBoot, bootOrder))
+	return nil
+}
+
+func (f *fakeVMs) ModifyShutdownWaitTime(rid uint, waitTime int) error {
+	f.calls = append(f.calls, fmt.Sprintf("wait:%d:%d", rid, waitTime))
+	return nil
+}
+
+type fakeCluster struct {
+	jobs []clusterServiceInterfaces.BackupJobReq
+}
+
+func (f *fakeCluster) ProposeBackupJobCreate(input clusterServiceInterfaces.BackupJobReq, bypassRaft bool) error {
+	f.jobs = append(f.jobs, input)
+	return nil
+}
+
+func (f *fakeCluster) ProposeBackupJobUpdate(id uint, input clusterServiceInterfaces.BackupJobReq, bypassRaft bool) error {
+	f.jobs = append(f.jobs, input)
+	return nil
+}
+
+func (f *fakeCluster) ProposeReplicationPolicyCreate(input clusterServiceInterfaces.ReplicationPolicyReq, bypassRaft bool) error {
+	return nil
+}
+
+func (f *fakeCluster) ProposeReplicationPolicyUpdate(id uint, input clusterServiceInterfaces.ReplicationPolicyReq, bypassRaft bool) error {
+	return nil
+}
+
+func newTestDesiredStateService(t *testing.T) (*Service, *fakeNetwork, *fakeVMs, *fakeCluster) {
+	t.Helper()
+
+	db := testutil.NewSQLiteTestDB(
+		t,
+		&networkModels.Object{},
+		&networkModels.ObjectEntry{},
+		&networkModels.ManualSwitch{},
+		&networkModels.StandardSwitch{},
+		&networkModels.NetworkPort{},
+		&vmModels.VM{},
+		&clusterModels.BackupTarget{},
+		&clusterModels.BackupJob{},
+	)
+
+	network := &fakeNetwork{db: db}
+	vms := &fakeVMs{}
+	cluster := &fakeCluster{}
+	s := &Service{
+		DB:               db,
+		network:          network,
+		vms:              vms,
+		cluster:          cluster,
+		clusterWriteMode: func() (bool, error) { return true, nil },
+	}
+	return s, network, vms, cluster
+}
+
+func findChange(t *testing.T, result *Result, kind, key string) Change {
+	t.Helper()
+	for _, change := range result.Changes {
+		if change.Kind == kind && change.Key == key {
+			return change
+		}
+	}
+	t.Fatalf("no change for %s %s in %#v", kind, key, result.Changes)
+	return Change{}
+}
+
+func TestParseDocumentRejectsInvalidInput(t *testing.T) {
+	for name, doc := range map[string]string{
+		"empty":         "",
+		"version":       "version: 2\n",
+		"unknown field": "version: 1\nvms:\n  - rid: 1\n    memory: 2\n",
+		"duplicate switch": `version: 1
+manualSwitches:
+  - name: lan
+    bridge: bridge0
+standardSwitches:
+  - name: lan
+`,
+		"object without values": "version: 1\nobjects:\n  - name: net\n    type: Network\n",
+		"vm without rid":        "version: 1\nvms:\n  - ram: 1024\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseDocument([]byte(doc)); err == nil {
+				t.Fatal("expected document to be rejected")
+			}
+		})
+	}
+}
+
+func TestParseDocumentAcceptsJSON(t *testing.T) {
+	doc, err := ParseDocument([]byte(`{"version":1,"vms":[{"rid":101,"ram":2048}]}`))
+	if err != nil {
+		t.Fatalf("parse json document: %v", err)
+	}
+	if len(doc.VMs) != 1 || doc.VMs[0].RID != 101 || doc.VMs[0].RAM == nil || *doc.VMs[0].RAM != 2048 {
+		t.Fatalf("unexpected document: %#v", doc)
+	}
+}
+
+func TestApplyDryRunPlansWithoutChanges(t *testing.T) {
+	s, network, vms, _ := newTestDesiredStateService(t)
+	if err := s.DB.Create(&vmModels.VM{Name: "web", RID: 101, RAM: 1024, ShutdownWaitTime: 10}).Error; err != nil {
+		t.Fatalf("seed vm: %v", err)
+	}
+
+	doc, err := ParseDocument([]byte(`version: 1
+objects:
+  - name: lan-net
+    type: Network
+    values: ["10.0.0.0/24"]
+standardSwitches:
+  - name: lan
+    network4: lan-net
+  - name: wan
+    network4: missing-net
+vms:
+  - rid: 101
+    ram: 2048
+    shutdownWaitTime: 10
+  - rid: 202
+    ram: 512
+`))
+	if err != nil {
+		t.Fatalf("parse document: %v", err)
+	}
+
+	result, err := s.Apply(context.Background(), doc, true)
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if change := findChange(t, result, KindObject, "lan-net"); change.Action != ActionCreate || change.Applied {
+		t.Fatalf("unexpected object change: %#v", change)
+	}
+	// A switch may reference an object created earlier in the same document.
+	if change := findChange(t, result, KindStandardSwitch, "lan"); change.Action != ActionCreate || change.Error != "" {
+		t.Fatalf("unexpected switch change: %#v", change)
+	}
+	if change := findChange(t, result, KindStandardSwitch, "wan"); !strings.HasPrefix(change.Error, "object_not_found") {
+		t.Fatalf("expected missing object to be reported, got %#v", change)
+	}
+	vmChange := findChange(t, result, KindVM, "101")
+	if vmChange.Action != ActionUpdate || len(vmChange.Fields) != 1 || vmChange.Fields[0] != "ram" {
+		t.Fatalf("unexpected vm change: %#v", vmChange)
+	}
+	if change := findChange(t, result, KindVM, "202"); change.Action != ActionRejected || change.Error != "vm_not_found" {
+		t.Fatalf("unexpected missing vm change: %#v", change)
+	}
+	if result.Failed != 2 {
+		t.Fatalf("expected 2 failures, got %d", result.Failed)
+	}
+
+	if len(network.calls) != 0 || len(vms.calls) != 0 {
+		t.Fatalf("dry run must not apply, got network=%v vms=%v", network.calls, vms.calls)
+	}
+}
+
+func TestApplyCreatesDependenciesInOrderAndSkipsUnchanged(t *testing.T) {
+	s, network, vms, _ := newTestDesiredStateService(t)
+	if err := s.DB.Create(&vmModels.VM{Name: "web", RID: 101, RAM: 1024, StartOrder: 1}).Error; err != nil {
+		t.Fatalf("seed vm: %v", err)
+	}
+
+	doc, err := ParseDocument([]byte(`version: 1
+objects:
+  - name: lan-net
+    type: Network
+    values: ["10.0.0.0/24"]
+standardSwitches:
+  - name: lan
+    mtu: 9000
+    network4: lan-net
+vms:
+  - rid: 101
+    ram: 1024
+    startOrder: 5
+`))
+	if err != nil {
+		t.Fatalf("parse document: %v", err)
+	}
+
+	result, err := s.Apply(context.Background(), doc, false)
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if result.Failed != 0 {
+		t.Fatalf("unexpected failures: %#v", result.Changes)
+	}
+
+	var obj networkModels.Object
+	if err := s.DB.Where("name = ?", "lan-net").First(&obj).Error; err != nil {
+		t.Fatalf("load created object: %v", err)
+	}
+	wantNetwork := []string{
+		"create_object:lan-net",
+		fmt.Sprintf("new_standard_switch:lan:9000:%d", obj.ID),
+	}
+	if strings.Join(network.calls, ",") != strings.Join(wantNetwork, ",") {
+		t.Fatalf("unexpected network calls: %v", network.calls)
+	}
+	// RAM already matches, so only the boot order is touched.
+	if strings.Join(vms.calls, ",") != "boot:101:false:5" {
+		t.Fatalf("unexpected vm calls: %v", vms.calls)
+	}
+
+	network.calls = nil
+	result, err = s.Apply(context.Background(), &Document{
+		Version: DocumentVersion,
+		Objects: []ObjectSpec{{Name: "lan-net", Type: "Network", Values: []string{"10.0.0.0/24"}}},
+	}, false)
+	if err != nil {
+		t.Fatalf("reapply: %v", err)
+	}
+	if change := findChange(t, result, KindObject, "lan-net"); change.Action != ActionUnchanged || change.Applied {
+		t.Fatalf("expected object to be unchanged, got %#v", change)
+	}
+	if len(network.calls) != 0 {
+		t.Fatalf("unchanged object must not be edited, got %v", network.calls)
+	}
+}
+
+func TestApplyBackupJobMergesDeclaredFields(t *testing.T) {
+	s, _, _, cluster := newTestDesiredStateService(t)
+	targets := []clusterModels.BackupTarget{{Name: "offsite"}, {Name: "archive"}}
+	if err := s.DB.Create(&targets).Error; err != nil {
+		t.Fatalf("seed targets: %v", err)
+	}
+	if err := s.DB.Create(&clusterModels.BackupJob{
+		Name:          "nightly",
+		TargetID:      targets[0].ID,
+		Mode:          "dataset",
+		SourceDataset: "tank/data",
+		PruneKeepLast: 7,
+		CronExpr:      "0 2 * * *",
+		Enabled:       true,
+	}).Error; err != nil {
+		t.Fatalf("seed job: %v", err)
+	}
+
+	target := "archive"
+	keep := 14
+	result, err := s.Apply(context.Background(), &Document{
+		Version: DocumentVersion,
+		BackupJobs: []BackupJobSpec{
+			{Name: "nightly", Target: &target, PruneKeepLast: &keep},
+			{Name: "weekly", Target: new(string)},
+		},
+	}, false)
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	change := findChange(t, result, KindBackupJob, "nightly")
+	if change.Action != ActionUpdate || strings.Join(change.Fields, ",") != "target,pruneKeepLast" {
+		t.Fatalf("unexpected job change: %#v", change)
+	}
+	if change := findChange(t, result, KindBackupJob, "weekly"); !strings.HasPrefix(change.Error, "backup_target_not_found") {
+		t.Fatalf("expected unknown target to be reported, got %#v", change)
+	}
+
+	if len(cluster.jobs) != 1 {
+		t.Fatalf("expected one proposed job, got %d", len(cluster.jobs))
+	}
+	got := cluster.jobs[0]
+	if got.TargetID != targets[1].ID || got.PruneKeepLast != 14 || got.SourceDataset != "tank/data" ||
+		got.CronExpr != "0 2 * * *" || got.Enabled == nil || !*got.Enabled {
+		t.Fatalf("undeclared fields must be preserved, got %#v", got)
+	}
+}
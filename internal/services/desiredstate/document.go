@@ -0,0 +1,230 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package desiredstate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const DocumentVersion = 1
+
+// Document is a declarative description of the resources an operator wants
+// to exist. Resources are matched by their natural key (name, RID or CTID)
+// and scalar fields left out of a spec are not managed, so a document can
+// own as much or as little of a resource as it needs. Nothing is ever
+// deleted because it is missing from the document.
+type Document struct {
+	Version             int                     `yaml:"version" json:"version"`
+	Objects             []ObjectSpec            `yaml:"objects" json:"objects,omitempty"`
+	ManualSwitches      []ManualSwitchSpec      `yaml:"manualSwitches" json:"manualSwitches,omitempty"`
+	StandardSwitches    []StandardSwitchSpec    `yaml:"standardSwitches" json:"standardSwitches,omitempty"`
+	VMs                 []VMSpec                `yaml:"vms" json:"vms,omitempty"`
+	Jails               []JailSpec              `yaml:"jails" json:"jails,omitempty"`
+	BackupJobs          []BackupJobSpec         `yaml:"backupJobs" json:"backupJobs,omitempty"`
+	ReplicationPolicies []ReplicationPolicySpec `yaml:"replicationPolicies" json:"replicationPolicies,omitempty"`
+}
+
+type ObjectSpec struct {
+	Name   string   `yaml:"name" json:"name"`
+	Type   string   `yaml:"type" json:"type"`
+	Values []string `yaml:"values" json:"values"`
+}
+
+type ManualSwitchSpec struct {
+	Name   string `yaml:"name" json:"name"`
+	Bridge string `yaml:"bridge" json:"bridge"`
+}
+
+// StandardSwitchSpec references addressing by network object name. An empty
+// string clears the reference.
+type StandardSwitchSpec struct {
+	Name         string   `yaml:"name" json:"name"`
+	MTU          *int     `yaml:"mtu" json:"mtu,omitempty"`
+	VLAN         *int     `yaml:"vlan" json:"vlan,omitempty"`
+	Ports        []string `yaml:"ports" json:"ports,omitempty"`
+	Network4     *string  `yaml:"network4" json:"network4,omitempty"`
+	Gateway4     *string  `yaml:"gateway4" json:"gateway4,omitempty"`
+	Network6     *string  `yaml:"network6" json:"network6,omitempty"`
+	Gateway6     *string  `yaml:"gateway6" json:"gateway6,omitempty"`
+	Private      *bool    `yaml:"private" json:"private,omitempty"`
+	DHCP         *bool    `yaml:"dhcp" json:"dhcp,omitempty"`
+	DisableIPv6  *bool    `yaml:"disableIPv6" json:"disableIPv6,omitempty"`
+	SLAAC        *bool    `yaml:"slaac" json:"slaac,omitempty"`
+	DefaultRoute *bool    `yaml:"defaultRoute" json:"defaultRoute,omitempty"`
+}
+
+// VMSpec and JailSpec only tune existing guests. Guests carry disks, media
+// and hardware that do not round-trip through a document, so creating them
+// stays with the regular create APIs.
+type VMSpec struct {
+	RID              uint  `yaml:"rid" json:"rid"`
+	RAM              *int  `yaml:"ram" json:"ram,omitempty"`
+	StartAtBoot      *bool `yaml:"startAtBoot" json:"startAtBoot,omitempty"`
+	StartOrder       *int  `yaml:"startOrder" json:"startOrder,omitempty"`
+	ShutdownWaitTime *int  `yaml:"shutdownWaitTime" json:"shutdownWaitTime,omitempty"`
+}
+
+type JailSpec struct {
+	CTID        uint  `yaml:"ctid" json:"ctid"`
+	StartAtBoot *bool `yaml:"startAtBoot" json:"startAtBoot,omitempty"`
+	StartOrder  *int  `yaml:"startOrder" json:"startOrder,omitempty"`
+}
+
+// BackupJobSpec references its backup target by name.
+type BackupJobSpec struct {
+	Name               string  `yaml:"name" json:"name"`
+	Target             *string `yaml:"target" json:"target,omitempty"`
+	RunnerNodeID       *string `yaml:"runnerNodeId" json:"runnerNodeId,omitempty"`
+	Mode               *string `yaml:"mode" json:"mode,omitempty"`
+	SourceDataset      *string `yaml:"sourceDataset" json:"sourceDataset,omitempty"`
+	JailRootDataset    *string `yaml:"jailRootDataset" json:"jailRootDataset,omitempty"`
+	PruneKeepLast      *int    `yaml:"pruneKeepLast" json:"pruneKeepLast,omitempty"`
+	PruneTarget        *bool   `yaml:"pruneTarget" json:"pruneTarget,omitempty"`
+	StopBeforeBackup   *bool   `yaml:"stopBeforeBackup" json:"stopBeforeBackup,omitempty"`
+	Recursive          *bool   `yaml:"recursive" json:"recursive,omitempty"`
+	ShareQuiesce       *string `yaml:"shareQuiesce" json:"shareQuiesce,omitempty"`
+	VMDatasetIncludes  *string `yaml:"vmDatasetIncludes" json:"vmDatasetIncludes,omitempty"`
+	VMDatasetExcludes  *string `yaml:"vmDatasetExcludes" json:"vmDatasetExcludes,omitempty"`
+	PreBackupScript    *string `yaml:"preBackupScript" json:"preBackupScript,omitempty"`
+	PostBackupScript   *string `yaml:"postBackupScript" json:"postBackupScript,omitempty"`
+	HookTimeoutSeconds *int    `yaml:"hookTimeoutSeconds" json:"hookTimeoutSeconds,omitempty"`
+	HookFailurePolicy  *string `yaml:"hookFailurePolicy" json:"hookFailurePolicy,omitempty"`
+	CronExpr           *string `yaml:"cronExpr" json:"cronExpr,omitempty"`
+	Enabled            *bool   `yaml:"enabled" json:"enabled,omitempty"`
+}
+
+type ReplicationTargetSpec struct {
+	NodeID string `yaml:"nodeId" json:"nodeId"`
+	Weight int    `yaml:"weight" json:"weight"`
+}
+
+type ReplicationPolicySpec struct {
+	Name            string                  `yaml:"name" json:"name"`
+	Description     *string                 `yaml:"description" json:"description,omitempty"`
+	GuestType       *string                 `yaml:"guestType" json:"guestType,omitempty"`
+	GuestID         *uint                   `yaml:"guestId" json:"guestId,omitempty"`
+	SourceNodeID    *string                 `yaml:"sourceNodeId" json:"sourceNodeId,omitempty"`
+	SourceMode      *string                 `yaml:"sourceMode" json:"sourceMode,omitempty"`
+	FailbackMode    *string                 `yaml:"failbackMode" json:"failbackMode,omitempty"`
+	FailoverMode    *string                 `yaml:"failoverMode" json:"failoverMode,omitempty"`
+	CronExpr        *string                 `yaml:"cronExpr" json:"cronExpr,omitempty"`
+	CrashRecovery   *bool                   `yaml:"crashRecovery" json:"crashRecovery,omitempty"`
+	CrashRestartMax *int                    `yaml:"crashRestartMax" json:"crashRestartMax,omitempty"`
+	PoolHealthCheck *bool                   `yaml:"poolHealthCheck" json:"poolHealthCheck,omitempty"`
+	PoolCapacityPct *int                    `yaml:"poolCapacityPct" json:"poolCapacityPct,omitempty"`
+	SourceBookmarks *bool                   `yaml:"sourceBookmarks" json:"sourceBookmarks,omitempty"`
+	Enabled         *bool                   `yaml:"enabled" json:"enabled,omitempty"`
+	Targets         []ReplicationTargetSpec `yaml:"targets" json:"targets,omitempty"`
+}
+
+// ParseDocument decodes a YAML or JSON document. Unknown fields are rejected
+// so a misspelt key fails loudly instead of silently being unmanaged.
+func ParseDocument(data []byte) (*Document, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var doc Document
+	if err := decoder.Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("empty_document")
+		}
+		return nil, fmt.Errorf("invalid_document: %w", err)
+	}
+
+	if err := doc.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &doc, nil
+}
+
+func checkUniqueKey(kind string, seen map[string]struct{}, key string) error {
+	if key == "" {
+		return fmt.Errorf("%s_key_required", kind)
+	}
+	if _, dup := seen[key]; dup {
+		return fmt.Errorf("duplicate_%s: %s", kind, key)
+	}
+	seen[key] = struct{}{}
+	return nil
+}
+
+func (d *Document) Validate() error {
+	if d.Version != DocumentVersion {
+		return fmt.Errorf("unsupported_document_version: %d", d.Version)
+	}
+
+	seen := make(map[string]struct{})
+	for _, spec := range d.Objects {
+		if err := checkUniqueKey("object", seen, strings.TrimSpace(spec.Name)); err != nil {
+			return err
+		}
+		if strings.TrimSpace(spec.Type) == "" || len(spec.Values) == 0 {
+			return fmt.Errorf("object_type_and_values_required: %s", spec.Name)
+		}
+	}
+
+	// Manual and standard switches share one name space.
+	seen = make(map[string]struct{})
+	for _, spec := range d.ManualSwitches {
+		if err := checkUniqueKey("switch", seen, strings.TrimSpace(spec.Name)); err != nil {
+			return err
+		}
+		if strings.TrimSpace(spec.Bridge) == "" {
+			return fmt.Errorf("manual_switch_bridge_required: %s", spec.Name)
+		}
+	}
+	for _, spec := range d.StandardSwitches {
+		if err := checkUniqueKey("switch", seen, strings.TrimSpace(spec.Name)); err != nil {
+			return err
+		}
+	}
+
+	seen = make(map[string]struct{})
+	for _, spec := range d.VMs {
+		if spec.RID == 0 {
+			return fmt.Errorf("vm_rid_required")
+		}
+		if err := checkUniqueKey("vm", seen, fmt.Sprint(spec.RID)); err != nil {
+			return err
+		}
+	}
+
+	seen = make(map[string]struct{})
+	for _, spec := range d.Jails {
+		if spec.CTID == 0 {
+			return fmt.Errorf("jail_ctid_required")
+		}
+		if err := checkUniqueKey("jail", seen, fmt.Sprint(spec.CTID)); err != nil {
+			return err
+		}
+	}
+
+	seen = make(map[string]struct{})
+	for _, spec := range d.BackupJobs {
+		if err := checkUniqueKey("backup_job", seen, strings.TrimSpace(spec.Name)); err != nil {
+			return err
+		}
+	}
+
+	seen = make(map[string]struct{})
+	for _, spec := range d.ReplicationPolicies {
+		if err := checkUniqueKey("replication_policy", seen, strings.TrimSpace(spec.Name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,575 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package disk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alchemillahq/gzfs"
+	"github.com/alchemillahq/sylve/internal/db/models"
+	"github.com/alchemillahq/sylve/internal/layout"
+	"github.com/alchemillahq/sylve/internal/logger"
+	notifier "github.com/alchemillahq/sylve/internal/notifications"
+)
+
+const (
+	BenchmarkTargetPool = "pool"
+	BenchmarkTargetZvol = "zvol"
+
+	BenchmarkSeqWrite  = "seq_write"
+	BenchmarkSeqRead   = "seq_read"
+	BenchmarkRandWrite = "rand_write"
+	BenchmarkRandRead  = "rand_read"
+
+	BenchmarkStatusRunning   = "running"
+	BenchmarkStatusCompleted = "completed"
+	BenchmarkStatusFailed    = "failed"
+
+	defaultBenchmarkSizeMB = 256
+	minBenchmarkSizeMB     = 16
+	maxBenchmarkSizeMB     = 4096
+
+	benchmarkSeqBlockSize  = 1 << 20
+	benchmarkRandBlockSize = 4 << 10
+
+	// Each test stops at its size cap or this long, whichever comes first,
+	// so a slow pool cannot hold the scratch dataset for hours.
+	benchmarkTestTimeLimit = 60 * time.Second
+	benchmarkRunTimeout    = 15 * time.Minute
+
+	// A metric that drops by more than this against the previous run of
+	// the same shape counts as a regression.
+	benchmarkRegressionThreshold = 0.2
+
+	benchmarkRegressionKindPrefix = "system.disk.benchmark.regression."
+)
+
+var benchmarkTestOrder = []string{BenchmarkSeqWrite, BenchmarkSeqRead, BenchmarkRandWrite, BenchmarkRandRead}
+
+var (
+	ErrInvalidBenchmark = errors.New("invalid benchmark")
+	ErrBenchmarkRunning = errors.New("benchmark already running")
+)
+
+type BenchmarkInput struct {
+	Pool   string   `json:"pool" binding:"required"`
+	Target string   `json:"target"`
+	SizeMB int      `json:"sizeMB"`
+	Tests  []string `json:"tests"`
+}
+
+type benchmarkResults struct {
+	seqWriteMBps  float64
+	seqReadMBps   float64
+	randWriteIOPS float64
+	randReadIOPS  float64
+}
+
+// benchmarkFile is what the tests need from the scratch file or zvol.
+type benchmarkFile interface {
+	io.ReaderAt
+	io.WriterAt
+	Sync() error
+}
+
+func normalizeBenchmarkInput(input BenchmarkInput) (BenchmarkInput, error) {
+	input.Pool = strings.TrimSpace(input.Pool)
+	if input.Pool == "" {
+		return input, fmt.Errorf("%w: pool is required", ErrInvalidBenchmark)
+	}
+
+	input.Target = strings.ToLower(strings.TrimSpace(input.Target))
+	if input.Target == "" {
+		input.Target = BenchmarkTargetPool
+	}
+	if input.Target != BenchmarkTargetPool && input.Target != BenchmarkTargetZvol {
+		return input, fmt.Errorf("%w: target must be pool or zvol", ErrInvalidBenchmark)
+	}
+
+	if input.SizeMB == 0 {
+		input.SizeMB = defaultBenchmarkSizeMB
+	}
+	if input.SizeMB < minBenchmarkSizeMB || input.SizeMB > maxBenchmarkSizeMB {
+		return input, fmt.Errorf("%w: size must be between %d and %d MB", ErrInvalidBenchmark, minBenchmarkSizeMB, maxBenchmarkSizeMB)
+	}
+
+	if len(input.Tests) == 0 {
+		input.Tests = slices.Clone(benchmarkTestOrder)
+		return input, nil
+	}
+	tests := make([]string, 0, len(benchmarkTestOrder))
+	for _, test := range input.Tests {
+		test = strings.ToLower(strings.TrimSpace(test))
+		if !slices.Contains(benchmarkTestOrder, test) {
+			return input, fmt.Errorf("%w: unknown test %q", ErrInvalidBenchmark, test)
+		}
+		if !slices.Contains(tests, test) {
+			tests = append(tests, test)
+		}
+	}
+	sort.Slice(tests, func(i, j int) bool {
+		return slices.Index(benchmarkTestOrder, tests[i]) < slices.Index(benchmarkTestOrder, tests[j])
+	})
+	input.Tests = tests
+	return input, nil
+}
+
+func (s *Service) ListBenchmarks(ctx context.Context, pool string) ([]models.DiskBenchmark, error) {
+	query := s.DB.WithContext(ctx).Order("started_at DESC, id DESC")
+	if pool = strings.TrimSpace(pool); pool != "" {
+		query = query.Where("pool = ?", pool)
+	}
+
+	benchmarks := []models.DiskBenchmark{}
+	if err := query.Find(&benchmarks).Error; err != nil {
+		return nil, err
+	}
+	return benchmarks, nil
+}
+
+// StartBenchmark validates the request, records the run and starts it in
+// the background. Only one benchmark runs at a time; anything still marked
+// running when a new one starts was cut short by a restart.
+func (s *Service) StartBenchmark(ctx context.Context, input BenchmarkInput) (*models.DiskBenchmark, error) {
+	input, err := normalizeBenchmarkInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var basicSettings models.BasicSettings
+	if err := s.DB.WithContext(ctx).First(&basicSettings).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_get_basic_settings: %w", err)
+	}
+	if !slices.Contains(basicSettings.Pools, input.Pool) {
+		return nil, fmt.Errorf("%w: pool %s is not used by Sylve", ErrInvalidBenchmark, input.Pool)
+	}
+
+	if s.GZFS == nil {
+		return nil, fmt.Errorf("zfs_client_not_initialized")
+	}
+
+	if !s.benchmarkMu.TryLock() {
+		return nil, ErrBenchmarkRunning
+	}
+
+	now := time.Now().UTC()
+	if err := s.DB.WithContext(ctx).
+		Model(&models.DiskBenchmark{}).
+		Where("status = ?", BenchmarkStatusRunning).
+		Updates(map[string]any{"status": BenchmarkStatusFailed, "error": "interrupted", "completed_at": now}).Error; err != nil {
+		s.benchmarkMu.Unlock()
+		return nil, err
+	}
+
+	fingerprint, devices := s.poolHardwareFingerprint(ctx, input.Pool)
+	record := models.DiskBenchmark{
+		Pool:                input.Pool,
+		Target:              input.Target,
+		SizeBytes:           int64(input.SizeMB) << 20,
+		Tests:               input.Tests,
+		Status:              BenchmarkStatusRunning,
+		HardwareFingerprint: fingerprint,
+		Devices:             devices,
+		StartedAt:           now,
+	}
+	if err := s.DB.WithContext(ctx).Create(&record).Error; err != nil {
+		s.benchmarkMu.Unlock()
+		return nil, err
+	}
+
+	go func(record models.DiskBenchmark) {
+		defer s.benchmarkMu.Unlock()
+		s.runBenchmark(record)
+	}(record)
+
+	return &record, nil
+}
+
+// poolHardwareFingerprint identifies the leaf devices under a pool by GUID
+// and path, so a replaced or re-cabled disk changes it.
+func (s *Service) poolHardwareFingerprint(ctx context.Context, poolName string) (string, []string) {
+	pool, err := s.GZFS.Zpool.Get(ctx, poolName)
+	if err != nil || pool == nil {
+		return "", nil
+	}
+	status, err := pool.Status(ctx)
+	if err != nil || status == nil {
+		return "", nil
+	}
+
+	var devices, keys []string
+	var walk func(vdev *gzfs.ZPoolStatusVDEV)
+	walk = func(vdev *gzfs.ZPoolStatusVDEV) {
+		if vdev == nil {
+			return
+		}
+		if len(vdev.Vdevs) == 0 {
+			devices = append(devices, strings.TrimSpace(vdev.Path))
+			keys = append(keys, strings.TrimSpace(vdev.GUID)+"|"+strings.TrimSpace(vdev.Path))
+			return
+		}
+		for _, child := range vdev.Vdevs {
+			walk(child)
+		}
+	}
+	for _, group := range []map[string]*gzfs.ZPoolStatusVDEV{status.Vdevs, status.Logs, status.L2Cache} {
+		for _, vdev := range group {
+			walk(vdev)
+		}
+	}
+
+	sort.Strings(devices)
+	return benchmarkFingerprint(keys), devices
+}
+
+func benchmarkFingerprint(keys []string) string {
+	if len(keys) == 0 {
+		return ""
+	}
+	sorted := slices.Clone(keys)
+	sort.Strings(sorted)
+	sum := sha256.Sum256([]byte(strings.Join(sorted, "\n")))
+	return hex.EncodeToString(sum[:8])
+}
+
+func (s *Service) runBenchmark(record models.DiskBenchmark) {
+	ctx, cancel := context.WithTimeout(context.Background(), benchmarkRunTimeout)
+	defer cancel()
+
+	results, err := s.benchmarkScratch(ctx, record)
+
+	completedAt := time.Now().UTC()
+	record.CompletedAt = &completedAt
+	if err != nil {
+		record.Status = BenchmarkStatusFailed
+		record.Error = err.Error()
+		logger.L.Warn().Err(err).Str("pool", record.Pool).Msg("disk_benchmark_failed")
+	} else {
+		record.Status = BenchmarkStatusCompleted
+		record.SeqWriteMBps = results.seqWriteMBps
+		record.SeqReadMBps = results.seqReadMBps
+		record.RandWriteIOPS = results.randWriteIOPS
+		record.RandReadIOPS = results.randReadIOPS
+		s.compareBenchmarkBaseline(&record)
+	}
+
+	if err := s.DB.Save(&record).Error; err != nil {
+		logger.L.Error().Err(err).Uint("benchmark_id", record.ID).Msg("disk_benchmark_save_failed")
+		return
+	}
+
+	if len(record.Regressions) > 0 && record.HardwareChanged {
+		if _, err := notifier.Emit(context.Background(), benchmarkRegressionNotification(record)); err != nil {
+			logger.L.Debug().Err(err).Str("pool", record.Pool).Msg("disk_benchmark_notification_failed")
+		}
+	}
+}
+
+// benchmarkScratch creates a throwaway dataset or zvol under the pool's
+// Sylve root with compression and data caching off, so the numbers reflect
+// the disks rather than the ARC, runs the tests and destroys it again.
+func (s *Service) benchmarkScratch(ctx context.Context, record models.DiskBenchmark) (benchmarkResults, error) {
+	name := fmt.Sprintf("%s/benchmark-%d", layout.RootDataset(record.Pool), record.StartedAt.Unix())
+	props := map[string]string{
+		"compression":  "off",
+		"primarycache": "metadata",
+	}
+
+	defer func() {
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if ds, err := s.GZFS.ZFS.Get(cleanupCtx, name, false); err == nil && ds != nil {
+			if err := ds.Destroy(cleanupCtx, true, false); err != nil {
+				logger.L.Warn().Err(err).Str("dataset", name).Msg("disk_benchmark_cleanup_failed")
+			}
+		}
+	}()
+
+	var path string
+	switch record.Target {
+	case BenchmarkTargetZvol:
+		props["volmode"] = "dev"
+		if _, err := s.GZFS.ZFS.CreateVolume(ctx, name, uint64(record.SizeBytes), props); err != nil {
+			return benchmarkResults{}, fmt.Errorf("failed_to_create_benchmark_zvol: %w", err)
+		}
+		path = "/dev/zvol/" + name
+		if err := waitForPath(ctx, path, 10*time.Second); err != nil {
+			return benchmarkResults{}, fmt.Errorf("benchmark_zvol_device_missing: %w", err)
+		}
+	default:
+		ds, err := s.GZFS.ZFS.CreateFilesystem(ctx, name, props)
+		if err != nil {
+			return benchmarkResults{}, fmt.Errorf("failed_to_create_benchmark_dataset: %w", err)
+		}
+		if ds == nil || !filepath.IsAbs(ds.Mountpoint) {
+			return benchmarkResults{}, fmt.Errorf("benchmark_dataset_not_mounted")
+		}
+		path = filepath.Join(ds.Mountpoint, "benchmark.dat")
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return benchmarkResults{}, fmt.Errorf("failed_to_open_benchmark_target: %w", err)
+	}
+	defer f.Close()
+
+	return runBenchmarkTests(ctx, f, record.SizeBytes, record.Tests, benchmarkTestTimeLimit)
+}
+
+func waitForPath(ctx context.Context, path string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		if _, err := os.Stat(path); err == nil {
+			return nil
+		} else if time.Now().After(deadline) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// runBenchmarkTests runs the requested tests in a fixed order over the
+// first size bytes of f. Later tests work over whatever the sequential
+// write got through; without one, the region is filled untimed first.
+func runBenchmarkTests(ctx context.Context, f benchmarkFile, size int64, tests []string, limit time.Duration) (benchmarkResults, error) {
+	var results benchmarkResults
+	size -= size % benchmarkSeqBlockSize
+	if size < benchmarkSeqBlockSize {
+		return results, fmt.Errorf("%w: size too small", ErrInvalidBenchmark)
+	}
+
+	block := make([]byte, benchmarkSeqBlockSize)
+	rng := rand.New(rand.NewPCG(uint64(time.Now().UnixNano()), 0x5eed))
+	for i := range block {
+		block[i] = byte(rng.Uint32())
+	}
+
+	filled := int64(0)
+	for _, test := range benchmarkTestOrder {
+		if !slices.Contains(tests, test) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
+		if test != BenchmarkSeqWrite && filled == 0 {
+			if _, _, err := seqWrite(ctx, f, block, size, 0); err != nil {
+				return results, fmt.Errorf("benchmark_prefill_failed: %w", err)
+			}
+			filled = size
+		}
+
+		switch test {
+		case BenchmarkSeqWrite:
+			written, elapsed, err := seqWrite(ctx, f, block, size, limit)
+			if err != nil {
+				return results, fmt.Errorf("%s_failed: %w", test, err)
+			}
+			filled = written
+			results.seqWriteMBps = perSecond(float64(written)/1e6, elapsed)
+		case BenchmarkSeqRead:
+			read, elapsed, err := seqRead(ctx, f, block, filled, limit)
+			if err != nil {
+				return results, fmt.Errorf("%s_failed: %w", test, err)
+			}
+			results.seqReadMBps = perSecond(float64(read)/1e6, elapsed)
+		case BenchmarkRandWrite:
+			ops, elapsed, err := randomIO(ctx, f, block[:benchmarkRandBlockSize], filled, limit, rng, true)
+			if err != nil {
+				return results, fmt.Errorf("%s_failed: %w", test, err)
+			}
+			results.randWriteIOPS = perSecond(float64(ops), elapsed)
+		case BenchmarkRandRead:
+			ops, elapsed, err := randomIO(ctx, f, block[:benchmarkRandBlockSize], filled, limit, rng, false)
+			if err != nil {
+				return results, fmt.Errorf("%s_failed: %w", test, err)
+			}
+			results.randReadIOPS = perSecond(float64(ops), elapsed)
+		}
+	}
+
+	return results, nil
+}
+
+func perSecond(amount float64, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return amount / elapsed.Seconds()
+}
+
+// seqWrite writes block after block from the start of f and syncs, timing
+// both. A zero limit writes the whole size.
+func seqWrite(ctx context.Context, f benchmarkFile, block []byte, size int64, limit time.Duration) (int64, time.Duration, error) {
+	started := time.Now()
+	var off int64
+	for off < size {
+		if err := ctx.Err(); err != nil {
+			return off, time.Since(started), err
+		}
+		if limit > 0 && time.Since(started) > limit {
+			break
+		}
+		if _, err := f.WriteAt(block, off); err != nil {
+			return off, time.Since(started), err
+		}
+		off += int64(len(block))
+	}
+	if err := f.Sync(); err != nil {
+		return off, time.Since(started), err
+	}
+	return off, time.Since(started), nil
+}
+
+func seqRead(ctx context.Context, f benchmarkFile, block []byte, size int64, limit time.Duration) (int64, time.Duration, error) {
+	started := time.Now()
+	var off int64
+	for off < size {
+		if err := ctx.Err(); err != nil {
+			return off, time.Since(started), err
+		}
+		if time.Since(started) > limit {
+			break
+		}
+		n, err := f.ReadAt(block, off)
+		off += int64(n)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return off, time.Since(started), err
+		}
+		if n == 0 {
+			break
+		}
+	}
+	return off, time.Since(started), nil
+}
+
+// randomIO does one block-sized read or write per aligned block of the
+// region, at random offsets. Writes are synced before the clock stops.
+func randomIO(ctx context.Context, f benchmarkFile, block []byte, size int64, limit time.Duration, rng *rand.Rand, write bool) (int64, time.Duration, error) {
+	blocks := size / int64(len(block))
+	if blocks == 0 {
+		return 0, 0, fmt.Errorf("%w: size too small", ErrInvalidBenchmark)
+	}
+
+	started := time.Now()
+	var ops int64
+	for ops < blocks {
+		if ops%256 == 0 {
+			if err := ctx.Err(); err != nil {
+				return ops, time.Since(started), err
+			}
+			if time.Since(started) > limit {
+				break
+			}
+		}
+		off := rng.Int64N(blocks) * int64(len(block))
+		var err error
+		if write {
+			_, err = f.WriteAt(block, off)
+		} else {
+			_, err = f.ReadAt(block, off)
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return ops, time.Since(started), err
+		}
+		ops++
+	}
+	if write {
+		if err := f.Sync(); err != nil {
+			return ops, time.Since(started), err
+		}
+	}
+	return ops, time.Since(started), nil
+}
+
+// compareBenchmarkBaseline checks a finished run against the previous
+// completed run of the same pool, target and size.
+func (s *Service) compareBenchmarkBaseline(record *models.DiskBenchmark) {
+	var baseline models.DiskBenchmark
+	if err := s.DB.
+		Where("pool = ? AND target = ? AND size_bytes = ? AND status = ? AND id <> ?",
+			record.Pool, record.Target, record.SizeBytes, BenchmarkStatusCompleted, record.ID).
+		Order("started_at DESC, id DESC").
+		First(&baseline).Error; err != nil {
+		return
+	}
+
+	record.BaselineID = &baseline.ID
+	record.HardwareChanged = baseline.HardwareFingerprint != "" &&
+		record.HardwareFingerprint != "" &&
+		baseline.HardwareFingerprint != record.HardwareFingerprint
+	record.Regressions = benchmarkRegressions(baseline, *record, benchmarkRegressionThreshold)
+}
+
+// benchmarkRegressions lists the metrics both runs measured that fell by
+// more than threshold, as "test: before -> after (-N%)".
+func benchmarkRegressions(baseline, current models.DiskBenchmark, threshold float64) []string {
+	metrics := []struct {
+		test   string
+		unit   string
+		before float64
+		after  float64
+	}{
+		{BenchmarkSeqWrite, "MB/s", baseline.SeqWriteMBps, current.SeqWriteMBps},
+		{BenchmarkSeqRead, "MB/s", baseline.SeqReadMBps, current.SeqReadMBps},
+		{BenchmarkRandWrite, "IOPS", baseline.RandWriteIOPS, current.RandWriteIOPS},
+		{BenchmarkRandRead, "IOPS", baseline.RandReadIOPS, current.RandReadIOPS},
+	}
+
+	var regressions []string
+	for _, m := range metrics {
+		if m.before <= 0 || m.after <= 0 {
+			continue
+		}
+		drop := (m.before - m.after) / m.before
+		if drop <= threshold {
+			continue
+		}
+		regressions = append(regressions, fmt.Sprintf("%s: %.1f %s -> %.1f %s (-%.0f%%)",
+			m.test, m.before, m.unit, m.after, m.unit, drop*100))
+	}
+	return regressions
+}
+
+func benchmarkRegressionNotification(record models.DiskBenchmark) notifier.EventInput {
+	return notifier.EventInput{
+		Kind:  benchmarkRegressionKindPrefix + strings.ToLower(record.Pool),
+		Title: fmt.Sprintf("Pool %s is slower after a hardware change", record.Pool),
+		Body: fmt.Sprintf(
+			"The %s benchmark on pool %s fell behind the run before its devices changed: %s.",
+			record.Target, record.Pool, strings.Join(record.Regressions, "; "),
+		),
+		Severity:    string(models.NotificationSeverityWarning),
+		Source:      "system.disk.benchmark",
+		Fingerprint: fmt.Sprintf("%s|%s|%s", record.Pool, record.Target, record.HardwareFingerprint),
+		Metadata: map[string]string{
+			"pool":         record.Pool,
+			"target":       record.Target,
+			"benchmark_id": fmt.Sprintf("%d", record.ID),
+			"fingerprint":  record.HardwareFingerprint,
+		},
+	}
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package disk
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/db/models"
+)
+
+func TestNormalizeBenchmarkInput(t *testing.T) {
+	got, err := normalizeBenchmarkInput(BenchmarkInput{Pool: " tank "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Pool != "tank" || got.Target != BenchmarkTargetPool || got.SizeMB != defaultBenchmarkSizeMB {
+		t.Fatalf("unexpected defaults: %+v", got)
+	}
+	if !reflect.DeepEqual(got.Tests, benchmarkTestOrder) {
+		t.Fatalf("expected every test by default, got %v", got.Tests)
+	}
+
+	got, err = normalizeBenchmarkInput(BenchmarkInput{
+		Pool:   "tank",
+		Target: "ZVOL",
+		SizeMB: 64,
+		Tests:  []string{"rand_read", "seq_write", "rand_read"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Target != BenchmarkTargetZvol || !reflect.DeepEqual(got.Tests, []string{BenchmarkSeqWrite, BenchmarkRandRead}) {
+		t.Fatalf("unexpected normalized input: %+v", got)
+	}
+
+	for _, input := range []BenchmarkInput{
+		{},
+		{Pool: "tank", Target: "disk"},
+		{Pool: "tank", SizeMB: 8},
+		{Pool: "tank", SizeMB: maxBenchmarkSizeMB + 1},
+		{Pool: "tank", Tests: []string{"mixed"}},
+	} {
+		if _, err := normalizeBenchmarkInput(input); !errors.Is(err, ErrInvalidBenchmark) {
+			t.Fatalf("expected ErrInvalidBenchmark for %+v, got %v", input, err)
+		}
+	}
+}
+
+func TestRunBenchmarkTestsAgainstFile(t *testing.T) {
+	f, err := os.OpenFile(filepath.Join(t.TempDir(), "benchmark.dat"), os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	size := int64(4 * benchmarkSeqBlockSize)
+	results, err := runBenchmarkTests(context.Background(), f, size, benchmarkTestOrder, 5*time.Second)
+	if err != nil {
+		t.Fatalf("benchmark failed: %v", err)
+	}
+	if results.seqWriteMBps <= 0 || results.seqReadMBps <= 0 || results.randWriteIOPS <= 0 || results.randReadIOPS <= 0 {
+		t.Fatalf("expected every metric to be measured, got %+v", results)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() != size {
+		t.Fatalf("expected the file to stay within %d bytes, got %d", size, info.Size())
+	}
+}
+
+func TestRunBenchmarkTestsPrefillsForReadOnlyRuns(t *testing.T) {
+	f, err := os.OpenFile(filepath.Join(t.TempDir(), "benchmark.dat"), os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	results, err := runBenchmarkTests(context.Background(), f, 2*benchmarkSeqBlockSize, []string{BenchmarkSeqRead}, 5*time.Second)
+	if err != nil {
+		t.Fatalf("benchmark failed: %v", err)
+	}
+	if results.seqReadMBps <= 0 || results.seqWriteMBps != 0 {
+		t.Fatalf("expected only the read metric, got %+v", results)
+	}
+}
+
+func TestBenchmarkRegressions(t *testing.T) {
+	baseline := models.DiskBenchmark{SeqWriteMBps: 500, SeqReadMBps: 800, RandWriteIOPS: 10000, RandReadIOPS: 20000}
+	current := models.DiskBenchmark{SeqWriteMBps: 450, SeqReadMBps: 400, RandWriteIOPS: 0, RandReadIOPS: 21000}
+
+	got := benchmarkRegressions(baseline, current, benchmarkRegressionThreshold)
+	if len(got) != 1 || !strings.HasPrefix(got[0], "seq_read: 800.0 MB/s -> 400.0 MB/s (-50%)") {
+		t.Fatalf("expected only the sequential read regression, got %v", got)
+	}
+}
+
+func TestBenchmarkFingerprintIgnoresDeviceOrder(t *testing.T) {
+	a := benchmarkFingerprint([]string{"1|/dev/ada0", "2|/dev/ada1"})
+	b := benchmarkFingerprint([]string{"2|/dev/ada1", "1|/dev/ada0"})
+	if a == "" || a != b {
+		t.Fatalf("expected equal fingerprints, got %q and %q", a, b)
+	}
+	if c := benchmarkFingerprint([]string{"1|/dev/ada0", "3|/dev/ada1"}); c == a {
+		t.Fatalf("expected a replaced disk to change the fingerprint")
+	}
+	if benchmarkFingerprint(nil) != "" {
+		t.Fatalf("expected no fingerprint without devices")
+	}
+}
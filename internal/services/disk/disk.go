@@ -78,6 +78,7 @@ type Service struct {
 	physicalDiskCacheMu       sync.Mutex
 	physicalDiskSource        func() ([]diskServiceInterfaces.DiskInfo, error)
 	smartDataSource           func(diskServiceInterfaces.DiskInfo) (any, *diskServiceInterfaces.DiskSelfTestLog, error)
+	benchmarkMu               sync.Mutex
 	ataPowerModeSource        func(string) (smart.ATAPowerMode, error)
 	scsiPowerModeSource       func(string) (smart.SCSIPowerMode, error)
 	diskGPTSource             func(string) bool
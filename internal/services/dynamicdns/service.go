@@ -18,6 +18,7 @@ import (
 	"time"
 
 	dynamicDNSModels "github.com/alchemillahq/sylve/internal/db/models/dynamicdns"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
 	"github.com/alchemillahq/sylve/internal/logger"
 	"gorm.io/gorm"
 )
@@ -217,7 +218,7 @@ func (s *Service) prepareEntry(ctx context.Context, input EntryInput, existing *
 	providerSettings := cloneSettings(input.ProviderSettings)
 	if existing != nil && providerID == existing.Provider {
 		if secret == "" {
-			secret = existing.ProviderSecret
+			secret = string(existing.ProviderSecret)
 		}
 		if len(providerSettings) == 0 {
 			providerSettings = cloneSettings(existing.ProviderSettings)
@@ -243,7 +244,7 @@ func (s *Service) prepareEntry(ctx context.Context, input EntryInput, existing *
 		Enabled:          input.Enabled,
 		Provider:         providerID,
 		ProviderSettings: providerSettings,
-		ProviderSecret:   secret,
+		ProviderSecret:   secrets.String(secret),
 		Hostname:         hostname,
 		RecordType:       recordType,
 		IntervalMinutes:  interval,
@@ -338,7 +339,7 @@ func (s *Service) syncEntry(ctx context.Context, entry *dynamicDNSModels.Entry)
 		entry.IPv4Status = status
 		entry.LastIPv4 = address
 		if err != nil {
-			entry.IPv4Error = redactSecret(err.Error(), entry.ProviderSecret)
+			entry.IPv4Error = redactSecret(err.Error(), string(entry.ProviderSecret))
 			failures = append(failures, "IPv4: "+entry.IPv4Error)
 		} else {
 			succeeded++
@@ -350,7 +351,7 @@ func (s *Service) syncEntry(ctx context.Context, entry *dynamicDNSModels.Entry)
 		entry.IPv6Status = status
 		entry.LastIPv6 = address
 		if err != nil {
-			entry.IPv6Error = redactSecret(err.Error(), entry.ProviderSecret)
+			entry.IPv6Error = redactSecret(err.Error(), string(entry.ProviderSecret))
 			failures = append(failures, "IPv6: "+entry.IPv6Error)
 		} else {
 			succeeded++
@@ -389,7 +390,7 @@ func syncFamily(ctx context.Context, provider DNSProvider, providerKnown bool, e
 	if !providerKnown {
 		return "error", address.String(), fmt.Errorf("DNS provider is unavailable")
 	}
-	if err := provider.Upsert(ctx, entry.ProviderSecret, entry.ProviderSettings, entry.Hostname, recordType, address); err != nil {
+	if err := provider.Upsert(ctx, string(entry.ProviderSecret), entry.ProviderSettings, entry.Hostname, recordType, address); err != nil {
 		return "error", address.String(), err
 	}
 	return "success", address.String(), nil
@@ -0,0 +1,678 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package guestalerts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/db/models"
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	"github.com/alchemillahq/sylve/internal/logger"
+	notifier "github.com/alchemillahq/sylve/internal/notifications"
+
+	"gorm.io/gorm"
+)
+
+const (
+	GuestTypeVM   = "vm"
+	GuestTypeJail = "jail"
+
+	// KindPrefix is followed by the metric, so each metric can be muted or
+	// routed separately in the notification rules.
+	KindPrefix = "guest.resource."
+
+	EvaluateInterval = time.Minute
+	MaxForMinutes    = 24 * 60
+
+	// A window counts as covered when its oldest sample is at most this far
+	// from the window start, and the newest one at most this old.
+	sampleSlack = 2 * EvaluateInterval
+	// Used when a rule leaves Hysteresis at 0.
+	defaultHysteresisFraction = 0.1
+)
+
+type Service struct {
+	DB *gorm.DB
+
+	now           func() time.Time
+	poolLatencyFn func(ctx context.Context, pools []string) (map[string]float64, error)
+
+	mu      sync.Mutex
+	active  map[string]*alertState
+	latency []latencySample
+}
+
+// ActiveAlert is a rule that is currently firing for one guest.
+type ActiveAlert struct {
+	RuleID    uint      `json:"ruleId"`
+	RuleName  string    `json:"ruleName"`
+	GuestType string    `json:"guestType"`
+	GuestID   uint      `json:"guestId"`
+	GuestName string    `json:"guestName"`
+	Metric    string    `json:"metric"`
+	Threshold float64   `json:"threshold"`
+	Value     float64   `json:"value"`
+	Since     time.Time `json:"since"`
+}
+
+type RuleInput struct {
+	Name       string  `json:"name"`
+	GuestType  string  `json:"guestType" binding:"required,oneof=vm jail"`
+	GuestID    uint    `json:"guestId"`
+	Metric     string  `json:"metric" binding:"required,oneof=cpu memory disk_latency"`
+	Threshold  float64 `json:"threshold" binding:"required,gt=0"`
+	Hysteresis float64 `json:"hysteresis"`
+	ForMinutes int     `json:"forMinutes"`
+	Severity   string  `json:"severity"`
+	Enabled    *bool   `json:"enabled"`
+}
+
+type alertState struct {
+	ActiveAlert
+	seen bool
+}
+
+type sample struct {
+	at    time.Time
+	value float64
+}
+
+type latencySample struct {
+	at    time.Time
+	pools map[string]float64
+}
+
+type guest struct {
+	guestType string
+	guestID   uint
+	dbID      uint
+	name      string
+	pools     []string
+}
+
+func NewService(db *gorm.DB) *Service {
+	return &Service{
+		DB:            db,
+		now:           func() time.Time { return time.Now().UTC() },
+		poolLatencyFn: samplePoolLatency,
+		active:        make(map[string]*alertState),
+	}
+}
+
+func (s *Service) ListRules() ([]models.GuestAlertRule, error) {
+	var rules []models.GuestAlertRule
+	if err := s.DB.Order("id ASC").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_list_guest_alert_rules: %w", err)
+	}
+	return rules, nil
+}
+
+func (s *Service) CreateRule(input RuleInput) (*models.GuestAlertRule, error) {
+	rule := models.GuestAlertRule{Enabled: true}
+	if err := s.applyRuleInput(&rule, input); err != nil {
+		return nil, err
+	}
+	if err := s.DB.Create(&rule).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_create_guest_alert_rule: %w", err)
+	}
+	return &rule, nil
+}
+
+func (s *Service) UpdateRule(id uint, input RuleInput) (*models.GuestAlertRule, error) {
+	var rule models.GuestAlertRule
+	if err := s.DB.First(&rule, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("guest_alert_rule_not_found")
+		}
+		return nil, err
+	}
+	if err := s.applyRuleInput(&rule, input); err != nil {
+		return nil, err
+	}
+	if err := s.DB.Save(&rule).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_update_guest_alert_rule: %w", err)
+	}
+	// Thresholds may have moved; let the next pass decide afresh.
+	s.forgetRule(rule.ID)
+	return &rule, nil
+}
+
+func (s *Service) DeleteRule(id uint) error {
+	result := s.DB.Delete(&models.GuestAlertRule{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed_to_delete_guest_alert_rule: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("guest_alert_rule_not_found")
+	}
+	s.forgetRule(id)
+	return nil
+}
+
+func (s *Service) applyRuleInput(rule *models.GuestAlertRule, input RuleInput) error {
+	guestType := strings.ToLower(strings.TrimSpace(input.GuestType))
+	if guestType != GuestTypeVM && guestType != GuestTypeJail {
+		return fmt.Errorf("invalid_guest_type")
+	}
+
+	metric := strings.ToLower(strings.TrimSpace(input.Metric))
+	switch metric {
+	case models.GuestAlertMetricCPU, models.GuestAlertMetricMemory:
+		if input.Threshold <= 0 || input.Threshold > 100 {
+			return fmt.Errorf("invalid_threshold: percentage must be between 0 and 100")
+		}
+	case models.GuestAlertMetricDiskLatency:
+		if input.Threshold <= 0 {
+			return fmt.Errorf("invalid_threshold: latency must be positive")
+		}
+	default:
+		return fmt.Errorf("invalid_metric")
+	}
+
+	if input.Hysteresis < 0 || input.Hysteresis >= input.Threshold {
+		return fmt.Errorf("invalid_hysteresis: must be below the threshold")
+	}
+	if input.ForMinutes < 0 || input.ForMinutes > MaxForMinutes {
+		return fmt.Errorf("invalid_for_minutes: must be between 0 and %d", MaxForMinutes)
+	}
+
+	severity := strings.ToLower(strings.TrimSpace(input.Severity))
+	switch models.NotificationSeverity(severity) {
+	case "":
+		severity = string(models.NotificationSeverityWarning)
+	case models.NotificationSeverityWarning, models.NotificationSeverityError, models.NotificationSeverityCritical:
+	default:
+		return fmt.Errorf("invalid_severity")
+	}
+
+	if input.GuestID != 0 {
+		if _, err := s.loadGuest(guestType, input.GuestID); err != nil {
+			return err
+		}
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		name = fmt.Sprintf("%s above %g%s", metricLabel(metric), input.Threshold, metricUnit(metric))
+	}
+
+	rule.Name = name
+	rule.GuestType = guestType
+	rule.GuestID = input.GuestID
+	rule.Metric = metric
+	rule.Threshold = input.Threshold
+	rule.Hysteresis = input.Hysteresis
+	rule.ForMinutes = input.ForMinutes
+	rule.Severity = severity
+	if input.Enabled != nil {
+		rule.Enabled = *input.Enabled
+	}
+	return nil
+}
+
+// ActiveAlerts lists the rules currently firing on this node.
+func (s *Service) ActiveAlerts() []ActiveAlert {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	alerts := make([]ActiveAlert, 0, len(s.active))
+	for _, st := range s.active {
+		alerts = append(alerts, st.ActiveAlert)
+	}
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].Since.Before(alerts[j].Since) })
+	return alerts
+}
+
+func (s *Service) forgetRule(ruleID uint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, st := range s.active {
+		if st.RuleID == ruleID {
+			delete(s.active, key)
+		}
+	}
+}
+
+// Run evaluates the rules every EvaluateInterval until ctx is cancelled.
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(EvaluateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Evaluate(ctx); err != nil {
+				logger.L.Warn().Err(err).Msg("failed_to_evaluate_guest_alert_rules")
+			}
+		}
+	}
+}
+
+// Evaluate checks every enabled rule against the stats history and emits a
+// notification whenever a guest starts or stops breaching one.
+func (s *Service) Evaluate(ctx context.Context) error {
+	var rules []models.GuestAlertRule
+	if err := s.DB.Where("enabled = ?", true).Order("id ASC").Find(&rules).Error; err != nil {
+		return fmt.Errorf("failed_to_load_guest_alert_rules: %w", err)
+	}
+
+	now := s.now()
+	s.mu.Lock()
+	for _, st := range s.active {
+		st.seen = false
+	}
+	s.mu.Unlock()
+
+	guests := map[string][]guest{}
+	for _, rule := range rules {
+		if _, ok := guests[rule.GuestType]; !ok {
+			loaded, err := s.loadGuests(rule.GuestType)
+			if err != nil {
+				return err
+			}
+			guests[rule.GuestType] = loaded
+		}
+	}
+	s.recordPoolLatency(ctx, rules, guests, now)
+
+	for _, rule := range rules {
+		for _, g := range guests[rule.GuestType] {
+			if rule.GuestID != 0 && rule.GuestID != g.guestID {
+				continue
+			}
+			samples, err := s.series(rule, g, now)
+			if err != nil {
+				logger.L.Debug().Err(err).Uint("rule_id", rule.ID).Str("guest_type", g.guestType).
+					Uint("guest_id", g.guestID).Msg("failed_to_load_guest_alert_series")
+				continue
+			}
+			s.apply(ctx, rule, g, samples, now)
+		}
+	}
+
+	// Rules that were disabled or deleted, and guests that went away, drop
+	// their state without a resolve notification.
+	s.mu.Lock()
+	for key, st := range s.active {
+		if !st.seen {
+			delete(s.active, key)
+		}
+	}
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *Service) apply(ctx context.Context, rule models.GuestAlertRule, g guest, samples []sample, now time.Time) {
+	key := fmt.Sprintf("%d|%s|%d", rule.ID, g.guestType, g.guestID)
+
+	s.mu.Lock()
+	st, alerting := s.active[key]
+	if alerting {
+		st.seen = true
+	}
+	s.mu.Unlock()
+
+	window := windowSamples(samples, now, time.Duration(rule.ForMinutes)*time.Minute)
+	next, changed := nextAlertState(rule, window, alerting)
+	if len(window) == 0 {
+		return
+	}
+	value := window[len(window)-1].value
+
+	if !changed {
+		if alerting {
+			s.mu.Lock()
+			st.Value = value
+			s.mu.Unlock()
+		}
+		return
+	}
+
+	alert := ActiveAlert{
+		RuleID:    rule.ID,
+		RuleName:  rule.Name,
+		GuestType: g.guestType,
+		GuestID:   g.guestID,
+		GuestName: g.name,
+		Metric:    rule.Metric,
+		Threshold: rule.Threshold,
+		Value:     value,
+		Since:     now,
+	}
+	if !emitAlert(ctx, rule, alert, next) {
+		// Try again on the next pass.
+		return
+	}
+
+	s.mu.Lock()
+	if next {
+		s.active[key] = &alertState{ActiveAlert: alert, seen: true}
+	} else {
+		delete(s.active, key)
+	}
+	s.mu.Unlock()
+}
+
+// windowSamples returns the samples a rule looks at, or nil when they do
+// not cover its window, for instance right after the guest started. A rule
+// without a window only looks at the newest sample.
+func windowSamples(samples []sample, now time.Time, window time.Duration) []sample {
+	if len(samples) == 0 {
+		return nil
+	}
+	last := samples[len(samples)-1]
+	if now.Sub(last.at) > sampleSlack {
+		return nil
+	}
+	if window <= 0 {
+		return []sample{last}
+	}
+
+	start := now.Add(-window)
+	first := sort.Search(len(samples), func(i int) bool { return !samples[i].at.Before(start) })
+	if first == len(samples) || samples[first].at.Sub(start) > sampleSlack {
+		return nil
+	}
+	return samples[first:]
+}
+
+// nextAlertState fires when every sample is above the threshold and clears
+// when every sample is below the threshold minus the hysteresis, so a guest
+// hovering around the threshold does not flap.
+func nextAlertState(rule models.GuestAlertRule, window []sample, alerting bool) (bool, bool) {
+	if len(window) == 0 {
+		return alerting, false
+	}
+
+	if !alerting {
+		for _, s := range window {
+			if s.value <= rule.Threshold {
+				return false, false
+			}
+		}
+		return true, true
+	}
+
+	clearBelow := rule.Threshold - ruleHysteresis(rule)
+	for _, s := range window {
+		if s.value >= clearBelow {
+			return true, false
+		}
+	}
+	return false, true
+}
+
+func ruleHysteresis(rule models.GuestAlertRule) float64 {
+	if rule.Hysteresis > 0 {
+		return rule.Hysteresis
+	}
+	return rule.Threshold * defaultHysteresisFraction
+}
+
+func (s *Service) series(rule models.GuestAlertRule, g guest, now time.Time) ([]sample, error) {
+	since := now.Add(-time.Duration(rule.ForMinutes)*time.Minute - sampleSlack)
+
+	if rule.Metric == models.GuestAlertMetricDiskLatency {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return poolLatencySeries(s.latency, g.pools, since), nil
+	}
+
+	column := "cpu_usage"
+	if rule.Metric == models.GuestAlertMetricMemory {
+		column = "memory_usage"
+	}
+
+	var rows []struct {
+		CreatedAt time.Time
+		Value     float64
+	}
+	query := s.DB.Select("created_at", column+" AS value").Where("created_at >= ?", since).Order("created_at ASC")
+	switch g.guestType {
+	case GuestTypeVM:
+		query = query.Model(&vmModels.VMStats{}).Where("vm_id = ?", g.dbID)
+	case GuestTypeJail:
+		query = query.Model(&jailModels.JailStats{}).Where("jid = ?", g.dbID)
+	}
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	samples := make([]sample, 0, len(rows))
+	for _, row := range rows {
+		samples = append(samples, sample{at: row.CreatedAt.UTC(), value: row.Value})
+	}
+	return samples, nil
+}
+
+// poolLatencySeries reduces the pool samples to the worst pool a guest has
+// storage on. ZFS does not account latency per zvol or dataset, so this is
+// the closest per-guest figure available.
+func poolLatencySeries(history []latencySample, pools []string, since time.Time) []sample {
+	var samples []sample
+	for _, entry := range history {
+		if entry.at.Before(since) {
+			continue
+		}
+		worst, found := 0.0, false
+		for _, pool := range pools {
+			if ms, ok := entry.pools[pool]; ok {
+				worst, found = max(worst, ms), true
+			}
+		}
+		if found {
+			samples = append(samples, sample{at: entry.at, value: worst})
+		}
+	}
+	return samples
+}
+
+func (s *Service) recordPoolLatency(ctx context.Context, rules []models.GuestAlertRule, guests map[string][]guest, now time.Time) {
+	longest := -1
+	pools := map[string]struct{}{}
+	for _, rule := range rules {
+		if rule.Metric != models.GuestAlertMetricDiskLatency {
+			continue
+		}
+		longest = max(longest, rule.ForMinutes)
+		for _, g := range guests[rule.GuestType] {
+			if rule.GuestID == 0 || rule.GuestID == g.guestID {
+				for _, pool := range g.pools {
+					pools[pool] = struct{}{}
+				}
+			}
+		}
+	}
+
+	s.mu.Lock()
+	if longest < 0 {
+		s.latency = nil
+	} else {
+		keepFrom := now.Add(-time.Duration(longest)*time.Minute - 2*sampleSlack)
+		kept := s.latency[:0]
+		for _, entry := range s.latency {
+			if !entry.at.Before(keepFrom) {
+				kept = append(kept, entry)
+			}
+		}
+		s.latency = kept
+	}
+	s.mu.Unlock()
+
+	if len(pools) == 0 || s.poolLatencyFn == nil {
+		return
+	}
+
+	names := make([]string, 0, len(pools))
+	for pool := range pools {
+		names = append(names, pool)
+	}
+	sort.Strings(names)
+
+	latency, err := s.poolLatencyFn(ctx, names)
+	if err != nil {
+		logger.L.Debug().Err(err).Strs("pools", names).Msg("failed_to_sample_pool_latency")
+		return
+	}
+
+	s.mu.Lock()
+	s.latency = append(s.latency, latencySample{at: now, pools: latency})
+	s.mu.Unlock()
+}
+
+func (s *Service) loadGuests(guestType string) ([]guest, error) {
+	var guests []guest
+
+	switch guestType {
+	case GuestTypeVM:
+		var vms []vmModels.VM
+		if err := s.DB.Preload("Storages").Find(&vms).Error; err != nil {
+			return nil, fmt.Errorf("failed_to_load_vms: %w", err)
+		}
+		for _, vm := range vms {
+			g := guest{guestType: GuestTypeVM, guestID: vm.RID, dbID: vm.ID, name: vm.Name}
+			for _, storage := range vm.Storages {
+				g.pools = appendPool(g.pools, storage.Pool)
+			}
+			guests = append(guests, g)
+		}
+	case GuestTypeJail:
+		var jails []jailModels.Jail
+		if err := s.DB.Preload("Storages").Find(&jails).Error; err != nil {
+			return nil, fmt.Errorf("failed_to_load_jails: %w", err)
+		}
+		for _, jl := range jails {
+			g := guest{guestType: GuestTypeJail, guestID: jl.CTID, dbID: jl.ID, name: jl.Name}
+			for _, storage := range jl.Storages {
+				g.pools = appendPool(g.pools, storage.Pool)
+			}
+			guests = append(guests, g)
+		}
+	}
+
+	return guests, nil
+}
+
+func (s *Service) loadGuest(guestType string, guestID uint) (*guest, error) {
+	guests, err := s.loadGuests(guestType)
+	if err != nil {
+		return nil, err
+	}
+	for i := range guests {
+		if guests[i].guestID == guestID {
+			return &guests[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%s_not_found: %d", guestType, guestID)
+}
+
+func appendPool(pools []string, pool string) []string {
+	pool = strings.TrimSpace(pool)
+	if pool == "" {
+		return pools
+	}
+	for _, existing := range pools {
+		if existing == pool {
+			return pools
+		}
+	}
+	return append(pools, pool)
+}
+
+func metricLabel(metric string) string {
+	switch metric {
+	case models.GuestAlertMetricCPU:
+		return "CPU"
+	case models.GuestAlertMetricMemory:
+		return "Memory"
+	case models.GuestAlertMetricDiskLatency:
+		return "Disk latency"
+	}
+	return metric
+}
+
+func metricUnit(metric string) string {
+	if metric == models.GuestAlertMetricDiskLatency {
+		return " ms"
+	}
+	return "%"
+}
+
+func guestLabel(alert ActiveAlert) string {
+	kind := "VM"
+	if alert.GuestType == GuestTypeJail {
+		kind = "Jail"
+	}
+	if alert.GuestName == "" {
+		return fmt.Sprintf("%s %d", kind, alert.GuestID)
+	}
+	return fmt.Sprintf("%s %s (%d)", kind, alert.GuestName, alert.GuestID)
+}
+
+func alertNotification(rule models.GuestAlertRule, alert ActiveAlert, firing bool) notifier.EventInput {
+	label, unit := metricLabel(rule.Metric), metricUnit(rule.Metric)
+	input := notifier.EventInput{
+		Kind:   KindPrefix + rule.Metric,
+		Source: "guest.alerts",
+		// Firing and resolved share a fingerprint so the resolve replaces
+		// the alert instead of piling up next to it.
+		Fingerprint: fmt.Sprintf("guest-alert|%d|%s|%d", rule.ID, alert.GuestType, alert.GuestID),
+		Metadata: map[string]string{
+			"rule_id":    fmt.Sprintf("%d", rule.ID),
+			"guest_type": alert.GuestType,
+			"guest_id":   fmt.Sprintf("%d", alert.GuestID),
+			"metric":     rule.Metric,
+			"value":      fmt.Sprintf("%.1f", alert.Value),
+			"threshold":  fmt.Sprintf("%g", rule.Threshold),
+		},
+	}
+
+	if firing {
+		input.Severity = rule.Severity
+		input.Title = fmt.Sprintf("%s: %s above %g%s", guestLabel(alert), label, rule.Threshold, unit)
+		if rule.ForMinutes > 0 {
+			input.Body = fmt.Sprintf("%s of %s has stayed above %g%s for %d minutes and is now at %.1f%s (rule %q).",
+				label, guestLabel(alert), rule.Threshold, unit, rule.ForMinutes, alert.Value, unit, rule.Name)
+		} else {
+			input.Body = fmt.Sprintf("%s of %s is at %.1f%s, above %g%s (rule %q).",
+				label, guestLabel(alert), alert.Value, unit, rule.Threshold, unit, rule.Name)
+		}
+		input.Metadata["condition"] = "firing"
+		return input
+	}
+
+	input.Severity = string(models.NotificationSeverityInfo)
+	input.Title = fmt.Sprintf("%s: %s back to normal", guestLabel(alert), label)
+	input.Body = fmt.Sprintf("%s of %s dropped to %.1f%s, below %g%s (rule %q).",
+		label, guestLabel(alert), alert.Value, unit, rule.Threshold-ruleHysteresis(rule), unit, rule.Name)
+	input.Metadata["condition"] = "resolved"
+	return input
+}
+
+func emitAlert(ctx context.Context, rule models.GuestAlertRule, alert ActiveAlert, firing bool) bool {
+	_, err := notifier.Emit(ctx, alertNotification(rule, alert, firing))
+	if err == nil {
+		return true
+	}
+	if !errors.Is(err, notifier.ErrEmitterNotConfigured) {
+		logger.L.Error().Err(err).Uint("rule_id", rule.ID).Str("guest_type", alert.GuestType).
+			Uint("guest_id", alert.GuestID).Msg("failed_to_emit_guest_alert")
+	}
+	return false
+}
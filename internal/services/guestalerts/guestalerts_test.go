@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package guestalerts
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/db/models"
+)
+
+func minuteSamples(now time.Time, values ...float64) []sample {
+	samples := make([]sample, 0, len(values))
+	for i, v := range values {
+		samples = append(samples, sample{at: now.Add(-time.Duration(len(values)-1-i) * time.Minute), value: v})
+	}
+	return samples
+}
+
+func TestWindowSamples(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	full := minuteSamples(now, 95, 95, 95, 95, 95, 95)
+	if got := windowSamples(full, now, 5*time.Minute); len(got) != 6 {
+		t.Fatalf("expected the whole covered window, got %d samples", len(got))
+	}
+
+	// A guest that started three minutes ago cannot have been busy for 15.
+	short := minuteSamples(now, 95, 95, 95)
+	if got := windowSamples(short, now, 15*time.Minute); got != nil {
+		t.Fatalf("expected an uncovered window to be ignored, got %+v", got)
+	}
+
+	stale := minuteSamples(now.Add(-10*time.Minute), 95, 95)
+	if got := windowSamples(stale, now, 0); got != nil {
+		t.Fatalf("expected stale samples of a stopped guest to be ignored, got %+v", got)
+	}
+
+	if got := windowSamples(full, now, 0); len(got) != 1 || !got[0].at.Equal(now) {
+		t.Fatalf("expected only the newest sample without a window, got %+v", got)
+	}
+}
+
+func TestNextAlertStateHysteresis(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	rule := models.GuestAlertRule{Metric: models.GuestAlertMetricCPU, Threshold: 90, ForMinutes: 3}
+
+	cases := []struct {
+		name     string
+		values   []float64
+		alerting bool
+		next     bool
+		changed  bool
+	}{
+		{"fires when sustained", []float64{92, 95, 91, 99}, false, true, true},
+		{"single dip keeps it quiet", []float64{92, 85, 91, 99}, false, false, false},
+		{"stays firing just under threshold", []float64{88, 86, 85, 87}, true, true, false},
+		{"clears below hysteresis band", []float64{70, 75, 80, 60}, true, false, true},
+	}
+
+	for _, tc := range cases {
+		window := windowSamples(minuteSamples(now, tc.values...), now, time.Duration(rule.ForMinutes)*time.Minute)
+		next, changed := nextAlertState(rule, window, tc.alerting)
+		if next != tc.next || changed != tc.changed {
+			t.Fatalf("%s: got next=%v changed=%v, want next=%v changed=%v", tc.name, next, changed, tc.next, tc.changed)
+		}
+	}
+
+	if next, changed := nextAlertState(rule, nil, true); !next || changed {
+		t.Fatalf("expected missing data to keep the current state")
+	}
+
+	rule.Hysteresis = 2
+	window := windowSamples(minuteSamples(now, 87, 87, 87, 87), now, 3*time.Minute)
+	if next, changed := nextAlertState(rule, window, true); next || !changed {
+		t.Fatalf("expected an explicit hysteresis of 2 to clear at 87")
+	}
+}
+
+func TestParsePoolLatency(t *testing.T) {
+	output := "tank\t100\t200\t10\t20\t4096\t8192\t2500000\t12000000\t1\t1\t1\t1\t1\t1\t-\t-\n" +
+		"zroot\t100\t200\t0\t0\t0\t0\t-\t-\t-\t-\t-\t-\t-\t-\t-\t-\n" +
+		"garbage\n"
+
+	latency := parsePoolLatency(output)
+	if len(latency) != 2 {
+		t.Fatalf("expected two pools, got %+v", latency)
+	}
+	if latency["tank"] != 12 {
+		t.Fatalf("expected the worse wait of tank in ms, got %v", latency["tank"])
+	}
+	if latency["zroot"] != 0 {
+		t.Fatalf("expected an idle pool to report 0, got %v", latency["zroot"])
+	}
+}
+
+func TestPoolLatencySeries(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	history := []latencySample{
+		{at: now.Add(-30 * time.Minute), pools: map[string]float64{"tank": 500}},
+		{at: now.Add(-time.Minute), pools: map[string]float64{"tank": 4, "fast": 1}},
+		{at: now, pools: map[string]float64{"fast": 40}},
+	}
+
+	series := poolLatencySeries(history, []string{"tank", "fast"}, now.Add(-5*time.Minute))
+	if len(series) != 2 || series[0].value != 4 || series[1].value != 40 {
+		t.Fatalf("expected the worst pool per sample inside the window, got %+v", series)
+	}
+
+	if got := poolLatencySeries(history, []string{"other"}, now.Add(-time.Hour)); len(got) != 0 {
+		t.Fatalf("expected no samples for unrelated pools, got %+v", got)
+	}
+}
+
+func TestAlertNotification(t *testing.T) {
+	rule := models.GuestAlertRule{
+		ID:         4,
+		Name:       "busy",
+		Metric:     models.GuestAlertMetricCPU,
+		Threshold:  90,
+		ForMinutes: 15,
+		Severity:   string(models.NotificationSeverityCritical),
+	}
+	alert := ActiveAlert{RuleID: 4, GuestType: GuestTypeVM, GuestID: 101, GuestName: "web", Value: 97.25}
+
+	firing := alertNotification(rule, alert, true)
+	resolved := alertNotification(rule, alert, false)
+
+	if firing.Kind != "guest.resource.cpu" || firing.Severity != "critical" {
+		t.Fatalf("unexpected firing notification %+v", firing)
+	}
+	if !strings.Contains(firing.Title, "VM web (101)") || !strings.Contains(firing.Body, "15 minutes") {
+		t.Fatalf("unexpected firing text %q / %q", firing.Title, firing.Body)
+	}
+	if resolved.Severity != string(models.NotificationSeverityInfo) || resolved.Metadata["condition"] != "resolved" {
+		t.Fatalf("unexpected resolved notification %+v", resolved)
+	}
+	if firing.Fingerprint != resolved.Fingerprint {
+		t.Fatalf("expected firing and resolved to share a fingerprint")
+	}
+}
@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package guestalerts
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/alchemillahq/sylve/pkg/utils"
+)
+
+// Column positions in `zpool iostat -Hpl`: name, alloc, free, read and
+// write ops, read and write bandwidth, then total_wait read and write.
+const (
+	iostatTotalWaitRead  = 7
+	iostatTotalWaitWrite = 8
+)
+
+// samplePoolLatency measures each pool's average total wait over one
+// second. -y skips the since-boot averages, which would hide a spike.
+func samplePoolLatency(ctx context.Context, pools []string) (map[string]float64, error) {
+	args := append([]string{"iostat", "-Hpl", "-y"}, pools...)
+	args = append(args, "1", "1")
+
+	output, err := utils.RunCommandWithContext(ctx, "zpool", args...)
+	if err != nil {
+		return nil, fmt.Errorf("zpool_iostat_failed: %s: %w", strings.TrimSpace(output), err)
+	}
+	return parsePoolLatency(output), nil
+}
+
+// parsePoolLatency returns the worse of the read and write wait per pool in
+// milliseconds. With -p the waits are printed in nanoseconds, and as "-"
+// when the pool saw no I/O of that kind.
+func parsePoolLatency(output string) map[string]float64 {
+	latency := make(map[string]float64)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(strings.TrimSpace(line), "\t")
+		if len(fields) <= iostatTotalWaitWrite || fields[0] == "" {
+			continue
+		}
+
+		worst := 0.0
+		for _, column := range []int{iostatTotalWaitRead, iostatTotalWaitWrite} {
+			ns, err := strconv.ParseFloat(strings.TrimSpace(fields[column]), 64)
+			if err != nil {
+				continue
+			}
+			worst = max(worst, ns/1e6)
+		}
+		latency[fields[0]] = worst
+	}
+	return latency
+}
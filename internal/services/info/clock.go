@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package info
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	infoServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/info"
+	"github.com/alchemillahq/sylve/pkg/utils"
+)
+
+// ntpStatusTTL keeps the cluster's clock probes from shelling out on every
+// request; the daemons only re-evaluate sync every few poll intervals anyway.
+const ntpStatusTTL = 30 * time.Second
+
+var runClockCommand = utils.RunCommand
+
+// GetNTPStatus reports the local time daemon's view of the system clock,
+// preferring chronyd when both are installed.
+func (s *Service) GetNTPStatus() infoServiceInterfaces.NTPStatus {
+	s.clockMu.Lock()
+	defer s.clockMu.Unlock()
+
+	if !s.ntpCheckedAt.IsZero() && time.Since(s.ntpCheckedAt) < ntpStatusTTL {
+		return s.ntpStatus
+	}
+
+	status := infoServiceInterfaces.NTPStatus{}
+	if out, err := runClockCommand("/usr/local/bin/chronyc", "-c", "tracking"); err == nil {
+		if parsed, ok := parseChronyTracking(out); ok {
+			status = parsed
+		}
+	} else if out, err := runClockCommand("/usr/bin/ntpq", "-c", "rv"); err == nil {
+		if parsed, ok := parseNtpqReadvar(out); ok {
+			status = parsed
+		}
+	}
+
+	s.ntpStatus = status
+	s.ntpCheckedAt = time.Now()
+	return status
+}
+
+// parseChronyTracking reads the CSV form of `chronyc tracking`: reference
+// ID, name, stratum, reference time, system time offset in seconds, and so
+// on through the leap status in the last field.
+func parseChronyTracking(out string) (infoServiceInterfaces.NTPStatus, bool) {
+	fields := strings.Split(strings.TrimSpace(out), ",")
+	if len(fields) < 14 {
+		return infoServiceInterfaces.NTPStatus{}, false
+	}
+
+	stratum, err := strconv.Atoi(strings.TrimSpace(fields[2]))
+	if err != nil {
+		return infoServiceInterfaces.NTPStatus{}, false
+	}
+	offset, err := strconv.ParseFloat(strings.TrimSpace(fields[4]), 64)
+	if err != nil {
+		return infoServiceInterfaces.NTPStatus{}, false
+	}
+
+	leap := strings.TrimSpace(fields[len(fields)-1])
+	return infoServiceInterfaces.NTPStatus{
+		Source:   "chronyd",
+		Synced:   stratum > 0 && stratum < 16 && !strings.EqualFold(leap, "Not synchronised"),
+		Stratum:  stratum,
+		OffsetMs: offset * 1000,
+	}, true
+}
+
+// parseNtpqReadvar reads the system variables printed by `ntpq -c rv`,
+// whose offset is already in milliseconds. ntpd marks an unsynchronised
+// clock with leap=11, stratum 16 or a sync_unspec status word.
+func parseNtpqReadvar(out string) (infoServiceInterfaces.NTPStatus, bool) {
+	vars := make(map[string]string)
+	for _, token := range strings.FieldsFunc(out, func(r rune) bool {
+		return r == ',' || r == '\n'
+	}) {
+		key, value, ok := strings.Cut(strings.TrimSpace(token), "=")
+		if !ok {
+			continue
+		}
+		vars[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+
+	stratum, err := strconv.Atoi(vars["stratum"])
+	if err != nil {
+		return infoServiceInterfaces.NTPStatus{}, false
+	}
+	offset, _ := strconv.ParseFloat(vars["offset"], 64)
+
+	return infoServiceInterfaces.NTPStatus{
+		Source:   "ntpd",
+		Synced:   vars["leap"] != "11" && stratum > 0 && stratum < 16 && !strings.Contains(out, "sync_unspec"),
+		Stratum:  stratum,
+		OffsetMs: offset,
+	}, true
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package info
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseNtpqReadvar(t *testing.T) {
+	synced := `associd=0 status=0615 leap_none, sync_ntp, 1 event, clock_sync,
+version="ntpd 4.2.8p17-a (1)", processor="amd64",
+system="FreeBSD/14.2-RELEASE", leap=00, stratum=3, precision=-24,
+rootdelay=14.012, rootdisp=21.527, refid=192.0.2.1,
+tc=10, mintc=3, offset=-0.734512, frequency=-11.204,
+sys_jitter=0.213, clk_jitter=0.167, clk_wander=0.011`
+
+	status, ok := parseNtpqReadvar(synced)
+	if !ok || status.Source != "ntpd" || !status.Synced || status.Stratum != 3 || status.OffsetMs != -0.734512 {
+		t.Fatalf("synced: ok=%v status=%+v", ok, status)
+	}
+
+	unsynced := `associd=0 status=c016 leap_alarm, sync_unspec, 1 event, restart,
+leap=11, stratum=16, precision=-24, offset=0.000000`
+	if status, ok := parseNtpqReadvar(unsynced); !ok || status.Synced {
+		t.Fatalf("unsynced: ok=%v status=%+v", ok, status)
+	}
+
+	if _, ok := parseNtpqReadvar("ntpq: read: Connection refused"); ok {
+		t.Fatal("daemon error output should not parse")
+	}
+}
+
+func TestParseChronyTracking(t *testing.T) {
+	status, ok := parseChronyTracking("C0000201,ntp.example.org,2,1760600000.123456789,-0.001250000,0.000020,0.000100,-12.345,0.001,0.050,0.010000,0.002000,64.4,Normal\n")
+	if !ok || status.Source != "chronyd" || !status.Synced || status.Stratum != 2 || math.Abs(status.OffsetMs+1.25) > 1e-9 {
+		t.Fatalf("synced: ok=%v status=%+v", ok, status)
+	}
+
+	status, ok = parseChronyTracking("00000000,,0,0.000000000,0.000000000,0.000000,0.000000,0.000,0.000,0.000,1.000000,1.000000,0.0,Not synchronised")
+	if !ok || status.Synced {
+		t.Fatalf("unsynced: ok=%v status=%+v", ok, status)
+	}
+}
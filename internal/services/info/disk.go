@@ -10,9 +10,13 @@ package info
 
 import (
 	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
 
 	"github.com/alchemillahq/gzfs"
 	"github.com/alchemillahq/sylve/internal/db/models"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
 	zfsServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/zfs"
 	"github.com/alchemillahq/sylve/internal/logger"
 )
@@ -69,3 +73,78 @@ func (s *Service) GetDisksUsage() (zfsServiceInterfaces.SimpleZFSDiskUsage, erro
 		Usage: usage,
 	}, nil
 }
+
+// GetPoolHealth reports state, capacity and device error counters for each
+// Sylve pool, for the cluster heartbeat.
+func (s *Service) GetPoolHealth(ctx context.Context) []clusterModels.NodePoolHealth {
+	pools, err := s.GetUsablePools(ctx)
+	if err != nil {
+		return []clusterModels.NodePoolHealth{}
+	}
+
+	health := make([]clusterModels.NodePoolHealth, 0, len(pools))
+	for _, pool := range pools {
+		entry := clusterModels.NodePoolHealth{
+			Name:  pool.Name,
+			State: string(pool.State),
+			Size:  pool.Size,
+			Alloc: pool.Alloc,
+		}
+		if pool.Size > 0 {
+			entry.UsedPct = float64(pool.Alloc) / float64(pool.Size) * 100
+		}
+
+		if status, err := pool.Status(ctx); err == nil && status != nil {
+			if raw, err := json.Marshal(status); err == nil {
+				var tree any
+				if json.Unmarshal(raw, &tree) == nil {
+					sumLeafVdevErrors(tree, &entry)
+				}
+			}
+		} else if err != nil {
+			logger.L.Debug().Err(err).Str("pool", pool.Name).Msg("pool_health_status_failed")
+		}
+
+		health = append(health, entry)
+	}
+
+	return health
+}
+
+// sumLeafVdevErrors walks a pool status tree and adds up the counters of
+// devices with no children, so a mirror's errors are not counted twice.
+func sumLeafVdevErrors(node any, entry *clusterModels.NodePoolHealth) {
+	switch v := node.(type) {
+	case map[string]any:
+		if _, isVdev := v["vdev_type"]; isVdev {
+			children, _ := v["vdevs"].(map[string]any)
+			if len(children) == 0 {
+				entry.ReadErrors += vdevErrorCount(v["read_errors"])
+				entry.WriteErrors += vdevErrorCount(v["write_errors"])
+				entry.ChecksumErrors += vdevErrorCount(v["checksum_errors"])
+				return
+			}
+		}
+		for _, child := range v {
+			sumLeafVdevErrors(child, entry)
+		}
+	case []any:
+		for _, child := range v {
+			sumLeafVdevErrors(child, entry)
+		}
+	}
+}
+
+func vdevErrorCount(value any) uint64 {
+	switch v := value.(type) {
+	case float64:
+		if v > 0 {
+			return uint64(v)
+		}
+	case string:
+		if n, err := strconv.ParseUint(strings.TrimSpace(v), 10, 64); err == nil {
+			return n
+		}
+	}
+	return 0
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package info
+
+import (
+	"encoding/json"
+	"testing"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+)
+
+func TestSumLeafVdevErrorsCountsOnlyLeaves(t *testing.T) {
+	raw := `{
+		"name": "tank",
+		"vdevs": {
+			"tank": {
+				"vdev_type": "root", "read_errors": "3", "write_errors": "0", "checksum_errors": "5",
+				"vdevs": {
+					"mirror-0": {
+						"vdev_type": "mirror", "read_errors": "3", "write_errors": "0", "checksum_errors": "5",
+						"vdevs": {
+							"ada0p3": {"vdev_type": "disk", "read_errors": "1", "write_errors": "0", "checksum_errors": "5"},
+							"ada1p3": {"vdev_type": "disk", "read_errors": 2, "write_errors": 0, "checksum_errors": 0}
+						}
+					}
+				}
+			}
+		}
+	}`
+
+	var tree any
+	if err := json.Unmarshal([]byte(raw), &tree); err != nil {
+		t.Fatal(err)
+	}
+
+	var entry clusterModels.NodePoolHealth
+	sumLeafVdevErrors(tree, &entry)
+
+	if entry.ReadErrors != 3 || entry.WriteErrors != 0 || entry.ChecksumErrors != 5 {
+		t.Fatalf("unexpected counters: %+v", entry)
+	}
+}
@@ -9,6 +9,7 @@
 package info
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -36,6 +37,10 @@ type Service struct {
 	lastNet           map[string]netCounter
 	lastNetSampleTime time.Time
 	netMu             sync.Mutex
+
+	clockMu      sync.Mutex
+	ntpStatus    infoServiceInterfaces.NTPStatus
+	ntpCheckedAt time.Time
 }
 
 func NewInfoService(db *gorm.DB, telemetryDB *gorm.DB, gzfs *gzfs.Client) infoServiceInterfaces.InfoServiceInterface {
@@ -107,6 +112,8 @@ func (s *Service) GetNodeInfo() (infoServiceInterfaces.NodeInfo, error) {
 		nodeInfo.DiskUsage = (disksUsage.Usage)
 	}
 
+	nodeInfo.Pools = s.GetPoolHealth(context.Background())
+
 	var resourceIds []uint
 
 	err = s.DB.Raw(`SELECT ct_id AS id FROM jails UNION ALL SELECT rid AS id FROM vms`).Scan(&resourceIds).Error
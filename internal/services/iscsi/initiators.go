@@ -12,6 +12,7 @@ import (
 	"fmt"
 
 	iscsiModels "github.com/alchemillahq/sylve/internal/db/models/iscsi"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
 	"github.com/alchemillahq/sylve/pkg/utils"
 )
 
@@ -94,9 +95,9 @@ func (s *Service) CreateInitiator(nickname, targetAddress, targetName, initiator
 		InitiatorName: initiatorName,
 		AuthMethod:    authMethod,
 		CHAPName:      chapName,
-		CHAPSecret:    chapSecret,
+		CHAPSecret:    secrets.String(chapSecret),
 		TgtCHAPName:   tgtChapName,
-		TgtCHAPSecret: tgtChapSecret,
+		TgtCHAPSecret: secrets.String(tgtChapSecret),
 	}
 
 	if err := s.DB.Create(&initiator).Error; err != nil {
@@ -142,9 +143,9 @@ func (s *Service) UpdateInitiator(id uint, nickname, targetAddress, targetName,
 	initiator.InitiatorName = initiatorName
 	initiator.AuthMethod = authMethod
 	initiator.CHAPName = chapName
-	initiator.CHAPSecret = chapSecret
+	initiator.CHAPSecret = secrets.String(chapSecret)
 	initiator.TgtCHAPName = tgtChapName
-	initiator.TgtCHAPSecret = tgtChapSecret
+	initiator.TgtCHAPSecret = secrets.String(tgtChapSecret)
 
 	if err := s.DB.Save(&initiator).Error; err != nil {
 		return fmt.Errorf("failed_to_update_initiator: %w", err)
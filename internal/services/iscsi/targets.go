@@ -12,6 +12,7 @@ import (
 	"fmt"
 
 	iscsiModels "github.com/alchemillahq/sylve/internal/db/models/iscsi"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
 )
 
 func validateTargetAuthMethod(authMethod, chapName, chapSecret, mutualChapName, mutualChapSecret string) error {
@@ -75,9 +76,9 @@ func (s *Service) CreateTarget(targetName, alias, authMethod, chapName, chapSecr
 		Alias:            alias,
 		AuthMethod:       authMethod,
 		CHAPName:         chapName,
-		CHAPSecret:       chapSecret,
+		CHAPSecret:       secrets.String(chapSecret),
 		MutualCHAPName:   mutualChapName,
-		MutualCHAPSecret: mutualChapSecret,
+		MutualCHAPSecret: secrets.String(mutualChapSecret),
 	}
 
 	if err := s.DB.Create(&target).Error; err != nil {
@@ -115,9 +116,9 @@ func (s *Service) UpdateTarget(id uint, targetName, alias, authMethod, chapName,
 	target.Alias = alias
 	target.AuthMethod = authMethod
 	target.CHAPName = chapName
-	target.CHAPSecret = chapSecret
+	target.CHAPSecret = secrets.String(chapSecret)
 	target.MutualCHAPName = mutualChapName
-	target.MutualCHAPSecret = mutualChapSecret
+	target.MutualCHAPSecret = secrets.String(mutualChapSecret)
 
 	if err := s.DB.Save(&target).Error; err != nil {
 		return fmt.Errorf("failed_to_update_target: %w", err)
@@ -0,0 +1,391 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package jail
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/config"
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	sysctl "github.com/alchemillahq/sylve/pkg/utils/sysctl"
+)
+
+const (
+	baseCacheStatusDownloading = "downloading"
+	baseCacheStatusReady       = "ready"
+	baseCacheStatusFailed      = "failed"
+
+	defaultBaseCacheMirror   = "https://download.freebsd.org/releases"
+	baseCacheDownloadTimeout = 30 * time.Minute
+	baseCacheArchiveName     = "base.txz"
+
+	// minBaseCacheReleaseMajor is the oldest release line still published
+	// with a MANIFEST we can verify base.txz against.
+	minBaseCacheReleaseMajor = 13
+)
+
+var baseReleasePattern = regexp.MustCompile(`^(\d+)\.(\d+)-RELEASE$`)
+
+func validateBaseRelease(release string) error {
+	m := baseReleasePattern.FindStringSubmatch(release)
+	if m == nil {
+		return fmt.Errorf("invalid_release: %s", release)
+	}
+
+	major, _ := strconv.Atoi(m[1])
+	if major < minBaseCacheReleaseMajor {
+		return fmt.Errorf("unsupported_release: %s", release)
+	}
+
+	return nil
+}
+
+// parseManifestChecksum returns the SHA256 listed for file in a release
+// MANIFEST, whose lines are tab separated as "name sha256 count ...".
+func parseManifestChecksum(manifest []byte, file string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(manifest))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != file {
+			continue
+		}
+
+		sum := strings.ToLower(fields[1])
+		if len(sum) != sha256.Size*2 {
+			return "", fmt.Errorf("invalid_manifest_checksum: %s", file)
+		}
+		if _, err := hex.DecodeString(sum); err != nil {
+			return "", fmt.Errorf("invalid_manifest_checksum: %s", file)
+		}
+
+		return sum, nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed_to_read_manifest: %w", err)
+	}
+
+	return "", fmt.Errorf("manifest_entry_not_found: %s", file)
+}
+
+func httpGetBody(ctx context.Context, client *http.Client, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected_status_%d: %s", resp.StatusCode, url)
+	}
+
+	return resp.Body, nil
+}
+
+// fetchVerifiedArchive downloads archiveURL next to dest and only moves it
+// into place once its SHA256 matches the entry in manifestURL, so a bad or
+// truncated archive never reaches extraction.
+func fetchVerifiedArchive(
+	ctx context.Context,
+	client *http.Client,
+	archiveURL, manifestURL, dest string,
+) (string, int64, error) {
+	manifestBody, err := httpGetBody(ctx, client, manifestURL)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed_to_fetch_manifest: %w", err)
+	}
+	manifest, err := io.ReadAll(io.LimitReader(manifestBody, 1<<20))
+	manifestBody.Close()
+	if err != nil {
+		return "", 0, fmt.Errorf("failed_to_read_manifest: %w", err)
+	}
+
+	expected, err := parseManifestChecksum(manifest, filepath.Base(dest))
+	if err != nil {
+		return "", 0, err
+	}
+
+	body, err := httpGetBody(ctx, client, archiveURL)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed_to_fetch_archive: %w", err)
+	}
+	defer body.Close()
+
+	partPath := dest + ".part"
+	out, err := os.Create(partPath)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed_to_create_archive_file: %w", err)
+	}
+
+	hasher := sha256.New()
+	size, copyErr := io.Copy(io.MultiWriter(out, hasher), body)
+	closeErr := out.Close()
+	if copyErr != nil || closeErr != nil {
+		_ = os.Remove(partPath)
+		if copyErr == nil {
+			copyErr = closeErr
+		}
+		return "", 0, fmt.Errorf("failed_to_download_archive: %w", copyErr)
+	}
+
+	actual := hex.EncodeToString(hasher.Sum(nil))
+	if actual != expected {
+		_ = os.Remove(partPath)
+		return "", 0, fmt.Errorf("base_archive_checksum_mismatch: expected %s, got %s", expected, actual)
+	}
+
+	if err := os.Rename(partPath, dest); err != nil {
+		_ = os.Remove(partPath)
+		return "", 0, fmt.Errorf("failed_to_store_archive: %w", err)
+	}
+
+	return actual, size, nil
+}
+
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+func dirSize(root string) int64 {
+	var total int64
+	_ = filepath.WalkDir(root, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, iErr := d.Info(); iErr == nil && info.Mode().IsRegular() {
+			total += info.Size()
+		}
+		return nil
+	})
+	return total
+}
+
+// removeBaseCacheTree clears schg flags first; extracted FreeBSD bases carry
+// them on a handful of system binaries.
+func removeBaseCacheTree(path string) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+	_, _ = utils.RunCommand("chflags", "-R", "noschg", path)
+	return os.RemoveAll(path)
+}
+
+func (s *Service) baseCacheMirrorURL() string {
+	if s.baseCacheMirror != "" {
+		return strings.TrimRight(s.baseCacheMirror, "/")
+	}
+	return defaultBaseCacheMirror
+}
+
+func (s *Service) releaseBaseURL(release string) (string, string, error) {
+	machine, err := sysctl.GetString("hw.machine")
+	if err != nil {
+		return "", "", fmt.Errorf("failed_to_get_machine: %w", err)
+	}
+	arch, err := sysctl.GetString("hw.machine_arch")
+	if err != nil {
+		return "", "", fmt.Errorf("failed_to_get_arch: %w", err)
+	}
+	arch = strings.TrimSpace(arch)
+
+	return fmt.Sprintf("%s/%s/%s/%s", s.baseCacheMirrorURL(), strings.TrimSpace(machine), arch, release), arch, nil
+}
+
+func (s *Service) getBaseCacheRecord(release string) (jailModels.JailBaseCache, error) {
+	var record jailModels.JailBaseCache
+	if err := s.DB.Where("release = ?", release).Limit(1).Find(&record).Error; err != nil {
+		return record, fmt.Errorf("failed_to_get_base_cache: %w", err)
+	}
+	return record, nil
+}
+
+func (s *Service) ListBaseCache() ([]jailModels.JailBaseCache, error) {
+	var records []jailModels.JailBaseCache
+	if err := s.DB.Order("release ASC").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_list_base_cache: %w", err)
+	}
+	return records, nil
+}
+
+// EnsureReleaseBase returns the extracted root of release's base.txz,
+// downloading and verifying the archive only when no ready copy exists. A
+// kept archive whose checksum still matches is re-extracted without a fetch.
+func (s *Service) EnsureReleaseBase(ctx context.Context, release string) (string, error) {
+	if err := validateBaseRelease(release); err != nil {
+		return "", err
+	}
+
+	s.baseCacheMu.Lock()
+	defer s.baseCacheMu.Unlock()
+
+	record, err := s.getBaseCacheRecord(release)
+	if err != nil {
+		return "", err
+	}
+	if record.ID != 0 && record.Status == baseCacheStatusReady {
+		if isDir, _ := utils.IsDir(record.RootPath); isDir {
+			return record.RootPath, nil
+		}
+		logger.L.Warn().Msgf("base cache %s: extracted root missing, rebuilding", release)
+	}
+
+	releaseURL, arch, err := s.releaseBaseURL(release)
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(config.GetDownloadsPath("jail-bases"), release)
+	record.Release = release
+	record.Arch = arch
+	record.URL = releaseURL + "/" + baseCacheArchiveName
+	record.ArchivePath = filepath.Join(dir, baseCacheArchiveName)
+	record.RootPath = filepath.Join(dir, "rootfs")
+	record.Status = baseCacheStatusDownloading
+	record.Error = ""
+	if err := s.DB.Save(&record).Error; err != nil {
+		return "", fmt.Errorf("failed_to_save_base_cache: %w", err)
+	}
+
+	fail := func(err error) (string, error) {
+		logger.L.Error().Err(err).Msgf("base cache %s: failed", release)
+		if uErr := s.DB.Model(&record).Updates(map[string]any{
+			"status": baseCacheStatusFailed,
+			"error":  err.Error(),
+		}).Error; uErr != nil {
+			logger.L.Warn().Err(uErr).Msgf("base cache %s: failed to record failure", release)
+		}
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fail(fmt.Errorf("failed_to_create_base_cache_dir: %w", err))
+	}
+
+	sum, size := "", int64(0)
+	if record.SHA256 != "" {
+		if actual, hErr := sha256File(record.ArchivePath); hErr == nil && actual == record.SHA256 {
+			sum, size = actual, record.Size
+		}
+	}
+
+	if sum == "" {
+		dCtx, cancel := context.WithTimeout(ctx, baseCacheDownloadTimeout)
+		sum, size, err = fetchVerifiedArchive(dCtx, http.DefaultClient, record.URL, releaseURL+"/MANIFEST", record.ArchivePath)
+		cancel()
+		if err != nil {
+			return fail(err)
+		}
+	}
+
+	staging := record.RootPath + ".staging"
+	if err := utils.ResetDir(staging); err != nil {
+		return fail(fmt.Errorf("failed_to_reset_base_staging_dir: %w", err))
+	}
+	if out, err := utils.RunCommandWithContext(ctx, "/usr/bin/tar", "-C", staging, "-xf", record.ArchivePath); err != nil {
+		_ = removeBaseCacheTree(staging)
+		return fail(fmt.Errorf("base_archive_extract_failed: %w (%s)", err, strings.TrimSpace(out)))
+	}
+
+	if err := removeBaseCacheTree(record.RootPath); err != nil {
+		_ = removeBaseCacheTree(staging)
+		return fail(fmt.Errorf("failed_to_remove_previous_base_root: %w", err))
+	}
+	if err := os.Rename(staging, record.RootPath); err != nil {
+		_ = removeBaseCacheTree(staging)
+		return fail(fmt.Errorf("failed_to_activate_base_root: %w", err))
+	}
+
+	now := time.Now().UTC()
+	if err := s.DB.Model(&record).Updates(map[string]any{
+		"status":      baseCacheStatusReady,
+		"error":       "",
+		"sha256":      sum,
+		"size":        size,
+		"root_size":   dirSize(record.RootPath),
+		"verified_at": &now,
+	}).Error; err != nil {
+		return "", fmt.Errorf("failed_to_update_base_cache: %w", err)
+	}
+
+	logger.L.Info().Msgf("base cache %s: ready (sha256 %s)", release, sum)
+	return record.RootPath, nil
+}
+
+// PrefetchReleaseBase validates release and fills the cache in the
+// background so a later jail create finds it ready.
+func (s *Service) PrefetchReleaseBase(release string) error {
+	if err := validateBaseRelease(release); err != nil {
+		return err
+	}
+
+	go func() {
+		if _, err := s.EnsureReleaseBase(context.Background(), release); err != nil {
+			logger.L.Warn().Err(err).Msgf("base cache %s: prefetch failed", release)
+		}
+	}()
+
+	return nil
+}
+
+func (s *Service) DeleteBaseCache(release string) error {
+	if err := validateBaseRelease(release); err != nil {
+		return err
+	}
+
+	s.baseCacheMu.Lock()
+	defer s.baseCacheMu.Unlock()
+
+	record, err := s.getBaseCacheRecord(release)
+	if err != nil {
+		return err
+	}
+
+	if err := removeBaseCacheTree(filepath.Join(config.GetDownloadsPath("jail-bases"), release)); err != nil {
+		return fmt.Errorf("failed_to_remove_base_cache: %w", err)
+	}
+
+	if record.ID != 0 {
+		if err := s.DB.Delete(&record).Error; err != nil {
+			return fmt.Errorf("failed_to_delete_base_cache_record: %w", err)
+		}
+	}
+
+	return nil
+}
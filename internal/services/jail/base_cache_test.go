@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package jail
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateBaseRelease(t *testing.T) {
+	for _, release := range []string{"14.3-RELEASE", "15.0-RELEASE"} {
+		if err := validateBaseRelease(release); err != nil {
+			t.Fatalf("validateBaseRelease(%q) = %v, want nil", release, err)
+		}
+	}
+
+	cases := map[string]string{
+		"14.3":            "invalid_release",
+		"14.3-STABLE":     "invalid_release",
+		"../14.3-RELEASE": "invalid_release",
+		"12.4-RELEASE":    "unsupported_release",
+	}
+	for release, want := range cases {
+		err := validateBaseRelease(release)
+		if err == nil || !strings.HasPrefix(err.Error(), want) {
+			t.Fatalf("validateBaseRelease(%q) = %v, want %s", release, err, want)
+		}
+	}
+}
+
+func TestParseManifestChecksum(t *testing.T) {
+	sum := strings.Repeat("ab", sha256.Size)
+	manifest := []byte(fmt.Sprintf(
+		"base-dbg.txz\t%s\t100\tbase_dbg\t\"Base system (Debugging)\"\toff\nbase.txz\t%s\t200\tbase\t\"Base system (MANDATORY)\"\ton\n",
+		strings.Repeat("cd", sha256.Size), strings.ToUpper(sum),
+	))
+
+	got, err := parseManifestChecksum(manifest, "base.txz")
+	if err != nil {
+		t.Fatalf("parseManifestChecksum: %v", err)
+	}
+	if got != sum {
+		t.Fatalf("checksum = %s, want %s", got, sum)
+	}
+
+	if _, err := parseManifestChecksum(manifest, "kernel.txz"); err == nil || !strings.HasPrefix(err.Error(), "manifest_entry_not_found") {
+		t.Fatalf("missing entry error = %v", err)
+	}
+	if _, err := parseManifestChecksum([]byte("base.txz\tnothex\t1\n"), "base.txz"); err == nil || !strings.HasPrefix(err.Error(), "invalid_manifest_checksum") {
+		t.Fatalf("malformed checksum error = %v", err)
+	}
+}
+
+func newBaseArchiveTestServer(archive []byte, manifestSum string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/MANIFEST":
+			fmt.Fprintf(w, "base.txz\t%s\t1\tbase\t\"Base system\"\ton\n", manifestSum)
+		case "/base.txz":
+			_, _ = w.Write(archive)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestFetchVerifiedArchive_StoresArchiveWhenChecksumMatches(t *testing.T) {
+	archive := []byte("pretend this is a base archive")
+	digest := sha256.Sum256(archive)
+	srv := newBaseArchiveTestServer(archive, hex.EncodeToString(digest[:]))
+	defer srv.Close()
+
+	dest := filepath.Join(t.TempDir(), "base.txz")
+	sum, size, err := fetchVerifiedArchive(context.Background(), srv.Client(), srv.URL+"/base.txz", srv.URL+"/MANIFEST", dest)
+	if err != nil {
+		t.Fatalf("fetchVerifiedArchive: %v", err)
+	}
+	if sum != hex.EncodeToString(digest[:]) || size != int64(len(archive)) {
+		t.Fatalf("sum=%s size=%d, want %x and %d", sum, size, digest, len(archive))
+	}
+
+	got, err := os.ReadFile(dest)
+	if err != nil || string(got) != string(archive) {
+		t.Fatalf("stored archive = %q, %v", got, err)
+	}
+	if _, err := os.Stat(dest + ".part"); !os.IsNotExist(err) {
+		t.Fatalf("partial file left behind: %v", err)
+	}
+}
+
+func TestFetchVerifiedArchive_DiscardsArchiveOnChecksumMismatch(t *testing.T) {
+	srv := newBaseArchiveTestServer([]byte("truncated"), strings.Repeat("00", sha256.Size))
+	defer srv.Close()
+
+	dest := filepath.Join(t.TempDir(), "base.txz")
+	_, _, err := fetchVerifiedArchive(context.Background(), srv.Client(), srv.URL+"/base.txz", srv.URL+"/MANIFEST", dest)
+	if err == nil || !strings.HasPrefix(err.Error(), "base_archive_checksum_mismatch") {
+		t.Fatalf("expected checksum mismatch, got %v", err)
+	}
+
+	for _, path := range []string{dest, dest + ".part"} {
+		if _, statErr := os.Stat(path); !os.IsNotExist(statErr) {
+			t.Fatalf("%s should not exist after a failed verification: %v", path, statErr)
+		}
+	}
+}
+
+func TestValidateDevfsRulesetBody(t *testing.T) {
+	valid := "# bpf for dhclient\nadd path 'bpf*' unhide\nadd 100 path pf unhide\ninclude $devfsrules_hide_all\n"
+	if err := validateDevfsRulesetBody(valid); err != nil {
+		t.Fatalf("validateDevfsRulesetBody(valid) = %v", err)
+	}
+
+	cases := map[string]string{
+		"[devfsrules_custom=10]\nadd path pf unhide": "devfs_ruleset_header_not_allowed",
+		"add path pf unhide\nunhide pf":              "invalid_devfs_ruleset_line: 2",
+		"include devfsrules_hide_all":                "invalid_devfs_ruleset_line: 1",
+		"add 5":                                      "invalid_devfs_ruleset_line: 1",
+	}
+	for rules, want := range cases {
+		err := validateDevfsRulesetBody(rules)
+		if err == nil || !strings.HasPrefix(err.Error(), want) {
+			t.Fatalf("validateDevfsRulesetBody(%q) = %v, want %s", rules, err, want)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package jail
+
+import (
+	"fmt"
+	"sort"
+
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
+	"github.com/alchemillahq/sylve/pkg/utils"
+
+	cpuid "github.com/klauspost/cpuid/v2"
+)
+
+type cpuLayout struct {
+	logical   int
+	sockets   int
+	perSocket int
+}
+
+// hostCPULayout splits the logical CPUs into sockets the same way VM
+// pinning does, so a core number means the same thing on both sides.
+func hostCPULayout() cpuLayout {
+	logical := utils.GetLogicalCores()
+	if logical <= 0 {
+		logical = cpuid.CPU.LogicalCores
+	}
+
+	sockets := utils.GetSocketCount(cpuid.CPU.PhysicalCores, cpuid.CPU.ThreadsPerCore)
+	if sockets <= 0 {
+		sockets = 1
+	}
+
+	perSocket := logical / sockets
+	if perSocket <= 0 {
+		perSocket = logical
+	}
+
+	return cpuLayout{logical: logical, sockets: sockets, perSocket: perSocket}
+}
+
+// cpuClaims maps logical cores to the VM RIDs pinned to them and the jail
+// CTIDs whose CPUSet includes them.
+type cpuClaims struct {
+	vms   map[int][]uint
+	jails map[int][]uint
+}
+
+func (s *Service) loadCPUClaims(skipCTID uint, layout cpuLayout) (cpuClaims, error) {
+	claims := cpuClaims{
+		vms:   make(map[int][]uint),
+		jails: make(map[int][]uint),
+	}
+
+	var vms []vmModels.VM
+	if err := s.DB.Preload("CPUPinning").Find(&vms).Error; err != nil {
+		return claims, fmt.Errorf("failed_to_fetch_vms: %w", err)
+	}
+	for _, vm := range vms {
+		for _, pin := range vm.CPUPinning {
+			for _, c := range pin.HostCPU {
+				core := pin.HostSocket*layout.perSocket + c
+				if core < 0 || core >= layout.logical {
+					continue
+				}
+				claims.vms[core] = append(claims.vms[core], uint(vm.RID))
+			}
+		}
+	}
+
+	var jails []jailModels.Jail
+	if err := s.DB.Find(&jails).Error; err != nil {
+		return claims, fmt.Errorf("failed_to_fetch_current_jails: %w", err)
+	}
+	for _, j := range jails {
+		if j.CTID == skipCTID {
+			continue
+		}
+		for _, core := range j.CPUSet {
+			claims.jails[core] = append(claims.jails[core], j.CTID)
+		}
+	}
+
+	return claims, nil
+}
+
+// suggestCPUSet picks cores for a jail. Cores pinned by a VM are never
+// used; among the rest, the ones shared with the fewest other jails win,
+// and a set that fits on one socket at no extra sharing is kept there.
+func suggestCPUSet(claims cpuClaims, layout cpuLayout, cores int) ([]int, error) {
+	if cores <= 0 {
+		return nil, fmt.Errorf("invalid_core_count: %d", cores)
+	}
+
+	free := make([]int, 0, layout.logical)
+	for core := 0; core < layout.logical; core++ {
+		if len(claims.vms[core]) == 0 {
+			free = append(free, core)
+		}
+	}
+	if len(free) < cores {
+		return nil, fmt.Errorf("insufficient_unpinned_cores: requested=%d available=%d", cores, len(free))
+	}
+
+	sort.SliceStable(free, func(i, j int) bool {
+		return len(claims.jails[free[i]]) < len(claims.jails[free[j]])
+	})
+
+	sharing := func(set []int) int {
+		total := 0
+		for _, core := range set {
+			total += len(claims.jails[core])
+		}
+		return total
+	}
+
+	best := free[:cores]
+	least := sharing(best)
+
+	bySocket := make(map[int][]int, layout.sockets)
+	for _, core := range free {
+		socket := core / layout.perSocket
+		bySocket[socket] = append(bySocket[socket], core)
+	}
+	for socket := 0; socket < layout.sockets; socket++ {
+		candidate := bySocket[socket]
+		if len(candidate) < cores {
+			continue
+		}
+		if candidate = candidate[:cores]; sharing(candidate) == least {
+			best = candidate
+			break
+		}
+	}
+
+	selected := append([]int(nil), best...)
+	sort.Ints(selected)
+	return selected, nil
+}
+
+// validateCPUSet checks a hand-picked set against the host and the VM pins.
+// Sharing cores with other jails is allowed, as cpuset itself allows it.
+func validateCPUSet(claims cpuClaims, layout cpuLayout, set []int) ([]int, error) {
+	if len(set) == 0 {
+		return nil, fmt.Errorf("empty_cpu_set")
+	}
+
+	seen := make(map[int]struct{}, len(set))
+	for _, core := range set {
+		if core < 0 || core >= layout.logical {
+			return nil, fmt.Errorf("core_index_out_of_range: core=%d max=%d", core, layout.logical-1)
+		}
+		if _, dup := seen[core]; dup {
+			return nil, fmt.Errorf("duplicate_core_in_cpu_set: core=%d", core)
+		}
+		seen[core] = struct{}{}
+
+		if owners := claims.vms[core]; len(owners) > 0 {
+			return nil, fmt.Errorf("core_conflict: core=%d already_pinned_by_rid=%d", core, owners[0])
+		}
+	}
+
+	selected := append([]int(nil), set...)
+	sort.Ints(selected)
+	return selected, nil
+}
+
+// GetCPUSetTopology lays out the host CPUs with their VM and jail claims.
+// ctId may be 0 for a jail that does not exist yet; cores > 0 adds the set
+// auto-assign would pick.
+func (s *Service) GetCPUSetTopology(ctId uint, cores int) (jailServiceInterfaces.CPUSetTopology, error) {
+	layout := hostCPULayout()
+	topology := jailServiceInterfaces.CPUSetTopology{
+		Sockets:          layout.sockets,
+		LogicalCores:     layout.logical,
+		LogicalPerSocket: layout.perSocket,
+		ThreadsPerCore:   cpuid.CPU.ThreadsPerCore,
+		Cores:            make([]jailServiceInterfaces.CPUSetCore, 0, layout.logical),
+		Current:          []int{},
+	}
+
+	claims, err := s.loadCPUClaims(ctId, layout)
+	if err != nil {
+		return topology, err
+	}
+
+	for core := 0; core < layout.logical; core++ {
+		entry := jailServiceInterfaces.CPUSetCore{
+			Core:   core,
+			Socket: core / layout.perSocket,
+			VMs:    claims.vms[core],
+			Jails:  claims.jails[core],
+		}
+		if entry.VMs == nil {
+			entry.VMs = []uint{}
+		}
+		if entry.Jails == nil {
+			entry.Jails = []uint{}
+		}
+		topology.Cores = append(topology.Cores, entry)
+	}
+
+	if ctId != 0 {
+		jail, err := s.GetJailByCTID(ctId)
+		if err != nil {
+			return topology, err
+		}
+		if len(jail.CPUSet) > 0 {
+			topology.Current = append([]int(nil), jail.CPUSet...)
+			sort.Ints(topology.Current)
+		}
+	}
+
+	if cores > 0 {
+		suggested, err := suggestCPUSet(claims, layout, cores)
+		if err != nil {
+			return topology, err
+		}
+		topology.Suggested = suggested
+	}
+
+	return topology, nil
+}
@@ -0,0 +1,96 @@
+package jail
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testCPUClaims(vms, jails map[int][]uint) cpuClaims {
+	if vms == nil {
+		vms = map[int][]uint{}
+	}
+	if jails == nil {
+		jails = map[int][]uint{}
+	}
+	return cpuClaims{vms: vms, jails: jails}
+}
+
+func TestSuggestCPUSetSkipsVMPinnedCores(t *testing.T) {
+	layout := cpuLayout{logical: 4, sockets: 1, perSocket: 4}
+	claims := testCPUClaims(map[int][]uint{0: {100}, 1: {100}}, nil)
+
+	got, err := suggestCPUSet(claims, layout, 2)
+	if err != nil {
+		t.Fatalf("suggest failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, []int{2, 3}) {
+		t.Fatalf("expected cores 2,3, got %v", got)
+	}
+
+	if _, err := suggestCPUSet(claims, layout, 3); err == nil || !strings.Contains(err.Error(), "insufficient_unpinned_cores") {
+		t.Fatalf("expected insufficient_unpinned_cores, got %v", err)
+	}
+}
+
+func TestSuggestCPUSetPrefersUnsharedCores(t *testing.T) {
+	layout := cpuLayout{logical: 4, sockets: 1, perSocket: 4}
+	claims := testCPUClaims(nil, map[int][]uint{0: {101}, 1: {101, 102}, 3: {103}})
+
+	got, err := suggestCPUSet(claims, layout, 2)
+	if err != nil {
+		t.Fatalf("suggest failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, []int{0, 2}) {
+		t.Fatalf("expected cores 0,2, got %v", got)
+	}
+}
+
+func TestSuggestCPUSetKeepsToOneSocket(t *testing.T) {
+	layout := cpuLayout{logical: 8, sockets: 2, perSocket: 4}
+	// Socket 0 has two free cores, socket 1 has all four.
+	claims := testCPUClaims(map[int][]uint{1: {100}, 2: {100}}, nil)
+
+	got, err := suggestCPUSet(claims, layout, 3)
+	if err != nil {
+		t.Fatalf("suggest failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, []int{4, 5, 6}) {
+		t.Fatalf("expected cores on socket 1, got %v", got)
+	}
+
+	// Staying on one socket never costs extra sharing.
+	claims = testCPUClaims(nil, map[int][]uint{4: {101}, 5: {101}, 6: {101}, 7: {101}, 2: {102}, 3: {102}})
+	got, err = suggestCPUSet(claims, layout, 3)
+	if err != nil {
+		t.Fatalf("suggest failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, []int{0, 1, 2}) {
+		t.Fatalf("expected least shared cores, got %v", got)
+	}
+}
+
+func TestValidateCPUSet(t *testing.T) {
+	layout := cpuLayout{logical: 4, sockets: 1, perSocket: 4}
+	claims := testCPUClaims(map[int][]uint{1: {100}}, map[int][]uint{2: {101}})
+
+	got, err := validateCPUSet(claims, layout, []int{3, 2, 0})
+	if err != nil {
+		t.Fatalf("expected shared jail core to be allowed, got %v", err)
+	}
+	if !reflect.DeepEqual(got, []int{0, 2, 3}) {
+		t.Fatalf("expected sorted set, got %v", got)
+	}
+
+	cases := map[string][]int{
+		"empty_cpu_set":                                   {},
+		"core_index_out_of_range":                         {4},
+		"duplicate_core_in_cpu_set":                       {0, 0},
+		"core_conflict: core=1 already_pinned_by_rid=100": {0, 1},
+	}
+	for want, set := range cases {
+		if _, err := validateCPUSet(claims, layout, set); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("set %v: expected %s, got %v", set, want, err)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package jail
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/utils"
+)
+
+const jailEtcFilesHeader = "# This file is managed by Sylve. Manual changes will be overwritten.\n"
+
+type jailHostEntry struct {
+	IP    string
+	Names []string
+}
+
+// parseJailHostEntries reads static /etc/hosts lines of the form
+// "address name [alias...]". Blank lines and # comments are skipped.
+func parseJailHostEntries(entries string) ([]jailHostEntry, error) {
+	var parsed []jailHostEntry
+	for _, line := range strings.Split(entries, "\n") {
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 2 || net.ParseIP(fields[0]) == nil {
+			return nil, fmt.Errorf("invalid_host_entry: %s", strings.TrimSpace(line))
+		}
+		for _, name := range fields[1:] {
+			if !utils.IsValidHostname(name) && !utils.IsValidFQDN(name) {
+				return nil, fmt.Errorf("invalid_host_entry: %s", strings.TrimSpace(line))
+			}
+		}
+		parsed = append(parsed, jailHostEntry{IP: fields[0], Names: fields[1:]})
+	}
+	return parsed, nil
+}
+
+func validateJailEtcFilesInput(searchDomain, hostEntries string) error {
+	if searchDomain != "" && !utils.IsValidFQDN(searchDomain) && !utils.IsValidHostname(searchDomain) {
+		return fmt.Errorf("invalid_search_domain")
+	}
+	if _, err := parseJailHostEntries(hostEntries); err != nil {
+		return err
+	}
+	return nil
+}
+
+func appendUniqueAddress(list []string, addr string) []string {
+	addr = strings.TrimSpace(addr)
+	if i := strings.Index(addr, "/"); i >= 0 {
+		addr = addr[:i]
+	}
+	if net.ParseIP(addr) == nil || slices.Contains(list, addr) {
+		return list
+	}
+	return append(list, addr)
+}
+
+func objectAddresses(list []string, obj *networkModels.Object) []string {
+	if obj == nil {
+		return list
+	}
+	for _, entry := range obj.Entries {
+		list = appendUniqueAddress(list, entry.Value)
+	}
+	return list
+}
+
+// jailNetworkAddresses returns the jail's own static addresses and the
+// gateways of those networks, which are used as its resolvers. Networks on
+// DHCP or SLAAC are skipped, their client already manages resolv.conf.
+func jailNetworkAddresses(jail jailModels.Jail) (addrs []string, nameservers []string) {
+	for _, n := range jail.Networks {
+		for _, family := range []struct {
+			dynamic bool
+			ip, gw  *networkModels.Object
+			version int
+		}{
+			{n.DHCP, n.IPv4Obj, n.IPv4GwObj, 4},
+			{n.SLAAC, n.IPv6Obj, n.IPv6GwObj, 6},
+		} {
+			own := objectAddresses(nil, family.ip)
+			if family.dynamic || len(own) == 0 {
+				continue
+			}
+			for _, addr := range own {
+				addrs = appendUniqueAddress(addrs, addr)
+			}
+
+			gateways := objectAddresses(nil, family.gw)
+			if len(gateways) == 0 && n.StandardSwitch != nil {
+				gateways = appendUniqueAddress(gateways, n.StandardSwitch.Gateway(family.version))
+			}
+			for _, gw := range gateways {
+				nameservers = appendUniqueAddress(nameservers, gw)
+			}
+		}
+	}
+	return addrs, nameservers
+}
+
+func renderJailResolvConf(nameservers []string, searchDomain string) string {
+	var b strings.Builder
+	b.WriteString(jailEtcFilesHeader)
+	if searchDomain != "" {
+		fmt.Fprintf(&b, "search %s\n", searchDomain)
+	}
+	for _, ns := range nameservers {
+		fmt.Fprintf(&b, "nameserver %s\n", ns)
+	}
+	return b.String()
+}
+
+func renderJailHosts(hostname, domain string, addrs []string, entries []jailHostEntry) string {
+	names := hostname
+	if hostname != "" && domain != "" && !strings.HasSuffix(hostname, "."+domain) {
+		names = fmt.Sprintf("%s.%s %s", hostname, domain, hostname)
+	}
+
+	var b strings.Builder
+	b.WriteString(jailEtcFilesHeader)
+	b.WriteString("::1\t\t\tlocalhost localhost.my.domain\n")
+	b.WriteString("127.0.0.1\t\tlocalhost localhost.my.domain\n")
+	if names != "" {
+		for _, addr := range addrs {
+			fmt.Fprintf(&b, "%s\t\t%s\n", addr, names)
+		}
+	}
+	for _, entry := range entries {
+		fmt.Fprintf(&b, "%s\t\t%s\n", entry.IP, strings.Join(entry.Names, " "))
+	}
+	return b.String()
+}
+
+// dhcpSearchDomain returns the domain of Sylve's DHCP server when it serves a
+// switch the jail is attached to.
+func (s *Service) dhcpSearchDomain(jail jailModels.Jail) string {
+	var dhcpConfig networkModels.DHCPConfig
+	if err := s.DB.
+		Preload("StandardSwitches").
+		Preload("ManualSwitches").
+		First(&dhcpConfig).Error; err != nil || dhcpConfig.Domain == "" {
+		return ""
+	}
+
+	for _, n := range jail.Networks {
+		if n.SwitchType == "manual" {
+			for _, sw := range dhcpConfig.ManualSwitches {
+				if sw.ID == n.SwitchID {
+					return dhcpConfig.Domain
+				}
+			}
+			continue
+		}
+		for _, sw := range dhcpConfig.StandardSwitches {
+			if sw.ID == n.SwitchID {
+				return dhcpConfig.Domain
+			}
+		}
+	}
+	return ""
+}
+
+// SyncJailEtcFiles renders /etc/resolv.conf and /etc/hosts inside a jail from
+// its networks when the jail has managed /etc files enabled. resolv.conf is
+// left alone when no static network yields a resolver.
+func (s *Service) SyncJailEtcFiles(ctId uint) error {
+	var jail jailModels.Jail
+	if err := s.DB.
+		Preload("Networks").
+		Preload("Networks.IPv4Obj.Entries").
+		Preload("Networks.IPv4GwObj.Entries").
+		Preload("Networks.IPv6Obj.Entries").
+		Preload("Networks.IPv6GwObj.Entries").
+		Where("ct_id = ?", ctId).
+		First(&jail).Error; err != nil {
+		return fmt.Errorf("failed_to_fetch_jail: %w", err)
+	}
+
+	if !jail.ManageEtcFiles {
+		return nil
+	}
+
+	entries, err := parseJailHostEntries(jail.HostEntries)
+	if err != nil {
+		return err
+	}
+
+	searchDomain := jail.DNSSearchDomain
+	if searchDomain == "" {
+		searchDomain = s.dhcpSearchDomain(jail)
+	}
+
+	hostname := jail.Hostname
+	if hostname == "" {
+		hostname = utils.MakeValidHostname(jail.Name)
+	}
+
+	mountPoint, err := s.GetJailBaseMountPoint(ctId)
+	if err != nil {
+		return fmt.Errorf("failed_to_get_jail_mount_point: %w", err)
+	}
+	etcDir := filepath.Join(mountPoint, "etc")
+	if err := os.MkdirAll(etcDir, 0755); err != nil {
+		return fmt.Errorf("failed_to_prepare_etc_path: %w", err)
+	}
+
+	addrs, nameservers := jailNetworkAddresses(jail)
+
+	hosts := renderJailHosts(hostname, searchDomain, addrs, entries)
+	if err := utils.AtomicWriteFile(filepath.Join(etcDir, "hosts"), []byte(hosts), 0644); err != nil {
+		return fmt.Errorf("failed_to_write_hosts_file: %w", err)
+	}
+
+	if len(nameservers) == 0 {
+		return nil
+	}
+
+	resolvConf := renderJailResolvConf(nameservers, searchDomain)
+	if err := utils.AtomicWriteFile(filepath.Join(etcDir, "resolv.conf"), []byte(resolvConf), 0644); err != nil {
+		return fmt.Errorf("failed_to_write_resolv_conf_file: %w", err)
+	}
+	if resolvConf == jail.ResolvConf {
+		return nil
+	}
+
+	if err := s.DB.
+		Model(&jailModels.Jail{}).
+		Where("ct_id = ?", ctId).
+		Update("resolv_conf", resolvConf).
+		Error; err != nil {
+		return fmt.Errorf("failed_to_update_resolv_conf_in_db: %w", err)
+	}
+
+	return nil
+}
+
+// ModifyEtcFiles turns managed /etc/resolv.conf and /etc/hosts on or off for a
+// jail and re-renders them. Turning it off leaves the files as they are.
+func (s *Service) ModifyEtcFiles(ctId uint, req jailServiceInterfaces.ModifyJailEtcFilesRequest) error {
+	allowed, leaseErr := s.canMutateProtectedJail(ctId)
+	if leaseErr != nil {
+		return fmt.Errorf("replication_lease_check_failed: %w", leaseErr)
+	}
+	if !allowed {
+		return fmt.Errorf("replication_lease_not_owned")
+	}
+
+	searchDomain := strings.TrimSpace(req.DNSSearchDomain)
+	if err := validateJailEtcFilesInput(searchDomain, req.HostEntries); err != nil {
+		return err
+	}
+
+	if err := s.DB.
+		Model(&jailModels.Jail{}).
+		Where("ct_id = ?", ctId).
+		Updates(map[string]any{
+			"manage_etc_files":  req.Enabled,
+			"dns_search_domain": searchDomain,
+			"host_entries":      req.HostEntries,
+		}).Error; err != nil {
+		return fmt.Errorf("failed_to_update_etc_files_in_db: %w", err)
+	}
+
+	if err := s.SyncJailEtcFiles(ctId); err != nil {
+		return err
+	}
+
+	if err := s.WriteJailJSON(ctId); err != nil {
+		logger.L.Error().Err(err).Msg("Failed to write jail JSON after etc files update")
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package jail
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+)
+
+func etcFilesTestObject(values ...string) *networkModels.Object {
+	obj := &networkModels.Object{}
+	for _, value := range values {
+		obj.Entries = append(obj.Entries, networkModels.ObjectEntry{Value: value})
+	}
+	return obj
+}
+
+func TestParseJailHostEntries(t *testing.T) {
+	entries, err := parseJailHostEntries("# databases\n192.168.1.20 db db.example.org\n\nfd00::5\tcache # local\n")
+	if err != nil {
+		t.Fatalf("parse host entries: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].IP != "192.168.1.20" || !slices.Equal(entries[0].Names, []string{"db", "db.example.org"}) {
+		t.Fatalf("unexpected first entry: %+v", entries[0])
+	}
+	if entries[1].IP != "fd00::5" || !slices.Equal(entries[1].Names, []string{"cache"}) {
+		t.Fatalf("unexpected second entry: %+v", entries[1])
+	}
+
+	for _, bad := range []string{"192.168.1.20", "db 192.168.1.20", "192.168.1.20 bad_name!"} {
+		if _, err := parseJailHostEntries(bad); err == nil {
+			t.Fatalf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestJailNetworkAddressesSkipsDynamicNetworks(t *testing.T) {
+	jail := jailModels.Jail{
+		Networks: []jailModels.Network{
+			{
+				IPv4Obj:   etcFilesTestObject("10.0.0.5/24"),
+				IPv4GwObj: etcFilesTestObject("10.0.0.1"),
+				IPv6Obj:   etcFilesTestObject("fd00::5/64"),
+				IPv6GwObj: etcFilesTestObject("fd00::1"),
+			},
+			{
+				DHCP:      true,
+				IPv4Obj:   etcFilesTestObject("10.1.0.5/24"),
+				IPv4GwObj: etcFilesTestObject("10.1.0.1"),
+			},
+			{
+				IPv4Obj:        etcFilesTestObject("10.2.0.5/24"),
+				StandardSwitch: &networkModels.StandardSwitch{GatewayManual: "10.2.0.1"},
+			},
+		},
+	}
+
+	addrs, nameservers := jailNetworkAddresses(jail)
+	if want := []string{"10.0.0.5", "fd00::5", "10.2.0.5"}; !slices.Equal(addrs, want) {
+		t.Fatalf("unexpected addresses %q, want %q", addrs, want)
+	}
+	if want := []string{"10.0.0.1", "fd00::1", "10.2.0.1"}; !slices.Equal(nameservers, want) {
+		t.Fatalf("unexpected nameservers %q, want %q", nameservers, want)
+	}
+}
+
+func TestRenderJailEtcFiles(t *testing.T) {
+	resolvConf := renderJailResolvConf([]string{"10.0.0.1", "fd00::1"}, "example.org")
+	if !strings.HasPrefix(resolvConf, jailEtcFilesHeader) ||
+		!strings.Contains(resolvConf, "search example.org\nnameserver 10.0.0.1\nnameserver fd00::1\n") {
+		t.Fatalf("unexpected resolv.conf:\n%s", resolvConf)
+	}
+
+	hosts := renderJailHosts("web", "example.org", []string{"10.0.0.5"}, []jailHostEntry{
+		{IP: "10.0.0.20", Names: []string{"db", "db.example.org"}},
+	})
+	for _, line := range []string{
+		"127.0.0.1\t\tlocalhost localhost.my.domain\n",
+		"10.0.0.5\t\tweb.example.org web\n",
+		"10.0.0.20\t\tdb db.example.org\n",
+	} {
+		if !strings.Contains(hosts, line) {
+			t.Fatalf("expected %q in hosts:\n%s", line, hosts)
+		}
+	}
+
+	hosts = renderJailHosts("web.example.org", "example.org", []string{"10.0.0.5"}, nil)
+	if !strings.Contains(hosts, "10.0.0.5\t\tweb.example.org\n") {
+		t.Fatalf("expected a fully qualified hostname to be kept as is:\n%s", hosts)
+	}
+}
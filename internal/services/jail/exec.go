@@ -0,0 +1,313 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package jail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	JailExecDefaultTimeout = time.Minute
+	JailExecMaxTimeout     = time.Hour
+
+	// jailExecOutputLimit bounds what is kept of a command's output; anything
+	// past it is dropped and the result is marked truncated.
+	jailExecOutputLimit = 4 << 20
+	// jailExecRetention is how long a finished exec stays around for a
+	// client that attaches late.
+	jailExecRetention = 5 * time.Minute
+)
+
+var jailExecUserRe = regexp.MustCompile(`^[a-z_][a-z0-9_.-]{0,31}$`)
+
+// JailExec is a command running inside a jail. Its output is kept so that a
+// stream attached after the command started still sees all of it.
+type JailExec struct {
+	ID   string
+	CTID uint
+
+	mu        sync.Mutex
+	chunks    []jailServiceInterfaces.JailExecChunk
+	size      int
+	done      bool
+	cancelled bool
+	result    jailServiceInterfaces.JailExecResult
+	notify    chan struct{}
+	finished  chan struct{}
+	cancel    context.CancelFunc
+}
+
+func newJailExec(ctid uint) *JailExec {
+	return &JailExec{
+		ID:       uuid.NewString(),
+		CTID:     ctid,
+		notify:   make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+}
+
+// wake releases everyone waiting on the current notify channel. Callers hold
+// e.mu.
+func (e *JailExec) wake() {
+	close(e.notify)
+	e.notify = make(chan struct{})
+}
+
+func (e *JailExec) appendOutput(stream string, p []byte) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	room := jailExecOutputLimit - e.size
+	if room <= 0 {
+		e.result.Truncated = true
+		return
+	}
+	if len(p) > room {
+		p = p[:room]
+		e.result.Truncated = true
+	}
+
+	e.chunks = append(e.chunks, jailServiceInterfaces.JailExecChunk{Stream: stream, Data: string(p)})
+	e.size += len(p)
+	e.wake()
+}
+
+func (e *JailExec) finish(result jailServiceInterfaces.JailExecResult) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	result.Truncated = e.result.Truncated
+	result.Cancelled = e.cancelled
+	e.result = result
+	e.done = true
+	e.wake()
+	close(e.finished)
+}
+
+// Done is closed once the command has exited.
+func (e *JailExec) Done() <-chan struct{} {
+	return e.finished
+}
+
+// Next returns the output after the first from chunks. When there is none yet
+// and the command is still running, wait is closed once there is more.
+func (e *JailExec) Next(from int) (
+	chunks []jailServiceInterfaces.JailExecChunk,
+	done bool,
+	result jailServiceInterfaces.JailExecResult,
+	wait <-chan struct{},
+) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if from < len(e.chunks) {
+		chunks = append(chunks, e.chunks[from:]...)
+	}
+	return chunks, e.done, e.result, e.notify
+}
+
+// Output returns everything the command wrote so far along with its result.
+// The result is only meaningful once Done is closed.
+func (e *JailExec) Output() (string, jailServiceInterfaces.JailExecResult) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var out strings.Builder
+	for _, chunk := range e.chunks {
+		out.WriteString(chunk.Data)
+	}
+	return out.String(), e.result
+}
+
+// Cancel kills the command if it is still running.
+func (e *JailExec) Cancel() {
+	e.mu.Lock()
+	if !e.done {
+		e.cancelled = true
+	}
+	cancel := e.cancel
+	e.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+}
+
+type jailExecWriter struct {
+	exec   *JailExec
+	stream string
+}
+
+func (w jailExecWriter) Write(p []byte) (int, error) {
+	w.exec.appendOutput(w.stream, p)
+	return len(p), nil
+}
+
+// run starts cmd and records its output and exit status on e. ctx is the
+// context cmd was built with; cancel is released once the command exits.
+func (e *JailExec) run(ctx context.Context, cancel context.CancelFunc, cmd *exec.Cmd) error {
+	cmd.Stdout = jailExecWriter{exec: e, stream: "stdout"}
+	cmd.Stderr = jailExecWriter{exec: e, stream: "stderr"}
+	// A background child keeping the pipes open must not hold Wait forever.
+	cmd.WaitDelay = 5 * time.Second
+
+	e.mu.Lock()
+	e.cancel = cancel
+	e.mu.Unlock()
+
+	if err := cmd.Start(); err != nil {
+		cancel()
+		return err
+	}
+
+	go func() {
+		defer cancel()
+
+		err := cmd.Wait()
+		result := jailServiceInterfaces.JailExecResult{}
+		if cmd.ProcessState != nil {
+			result.ExitCode = cmd.ProcessState.ExitCode()
+		}
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			result.TimedOut = true
+		}
+
+		var exitErr *exec.ExitError
+		if err != nil && !errors.As(err, &exitErr) {
+			result.Error = err.Error()
+		}
+
+		e.finish(result)
+	}()
+
+	return nil
+}
+
+func validateJailExecRequest(req jailServiceInterfaces.JailExecRequest) (time.Duration, error) {
+	if len(req.Command) == 0 || strings.TrimSpace(req.Command[0]) == "" {
+		return 0, fmt.Errorf("exec_command_required")
+	}
+	for _, arg := range req.Command {
+		if strings.ContainsRune(arg, 0) {
+			return 0, fmt.Errorf("exec_command_invalid")
+		}
+	}
+
+	if req.User != "" && !jailExecUserRe.MatchString(req.User) {
+		return 0, fmt.Errorf("exec_user_invalid")
+	}
+
+	if req.Timeout < 0 {
+		return 0, fmt.Errorf("exec_timeout_invalid")
+	}
+	timeout := time.Duration(req.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = JailExecDefaultTimeout
+	}
+	if timeout > JailExecMaxTimeout {
+		return 0, fmt.Errorf("exec_timeout_too_long")
+	}
+
+	return timeout, nil
+}
+
+// StartJailExec runs req.Command inside a running jail through jexec. The
+// command keeps running when nobody is streaming it, until it exits, times
+// out, or is cancelled.
+func (s *Service) StartJailExec(ctid uint, req jailServiceInterfaces.JailExecRequest) (*JailExec, error) {
+	timeout, err := validateJailExecRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var jail jailModels.Jail
+	if err := s.DB.Select("id").Where("ct_id = ?", ctid).First(&jail).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("jail_not_found")
+		}
+		return nil, err
+	}
+
+	running, err := s.IsJailRunning(ctid)
+	if err != nil {
+		return nil, fmt.Errorf("failed_to_check_jail_state: %w", err)
+	}
+	if !running {
+		return nil, fmt.Errorf("jail_not_running")
+	}
+
+	args := []string{"-l"}
+	if req.User != "" {
+		args = append(args, "-U", req.User)
+	}
+	args = append(args, s.GetCTIDHash(ctid))
+	args = append(args, req.Command...)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	cmd := exec.CommandContext(ctx, "jexec", args...)
+
+	e := newJailExec(ctid)
+	if err := e.run(ctx, cancel, cmd); err != nil {
+		return nil, fmt.Errorf("failed_to_start_exec: %w", err)
+	}
+
+	s.jailExecs.Store(e.ID, e)
+	go func() {
+		<-e.Done()
+		time.AfterFunc(jailExecRetention, func() {
+			s.jailExecs.CompareAndDelete(e.ID, e)
+		})
+	}()
+
+	return e, nil
+}
+
+// ExecInJail runs req.Command inside a running jail and waits for it,
+// returning stdout and stderr interleaved as they were written. Cancelling ctx
+// kills the command.
+func (s *Service) ExecInJail(
+	ctx context.Context,
+	ctid uint,
+	req jailServiceInterfaces.JailExecRequest,
+) (string, jailServiceInterfaces.JailExecResult, error) {
+	e, err := s.StartJailExec(ctid, req)
+	if err != nil {
+		return "", jailServiceInterfaces.JailExecResult{}, err
+	}
+
+	select {
+	case <-e.Done():
+	case <-ctx.Done():
+		e.Cancel()
+		<-e.Done()
+	}
+
+	out, result := e.Output()
+	return out, result, nil
+}
+
+func (s *Service) GetJailExec(id string) (*JailExec, bool) {
+	value, ok := s.jailExecs.Load(id)
+	if !ok {
+		return nil, false
+	}
+	return value.(*JailExec), true
+}
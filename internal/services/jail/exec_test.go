@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package jail
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
+)
+
+func waitJailExec(t *testing.T, e *JailExec) (string, jailServiceInterfaces.JailExecResult) {
+	t.Helper()
+
+	select {
+	case <-e.Done():
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for exec to finish")
+	}
+
+	chunks, done, result, _ := e.Next(0)
+	if !done {
+		t.Fatal("expected exec to be done")
+	}
+	var out strings.Builder
+	for _, chunk := range chunks {
+		out.WriteString(chunk.Stream + ":" + chunk.Data)
+	}
+	return out.String(), result
+}
+
+func TestJailExecRecordsOutputAndExitCode(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	e := newJailExec(1)
+	if err := e.run(ctx, cancel, exec.CommandContext(ctx, "sh", "-c", "echo out; echo err >&2; exit 3")); err != nil {
+		t.Fatalf("unexpected start error: %v", err)
+	}
+
+	out, result := waitJailExec(t, e)
+	if !strings.Contains(out, "stdout:out\n") || !strings.Contains(out, "stderr:err\n") {
+		t.Fatalf("expected both streams, got %q", out)
+	}
+	if result.ExitCode != 3 || result.TimedOut || result.Cancelled || result.Error != "" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if combined, _ := e.Output(); !strings.Contains(combined, "out\n") || !strings.Contains(combined, "err\n") {
+		t.Fatalf("expected combined output, got %q", combined)
+	}
+}
+
+func TestJailExecTimesOutAndCancels(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	e := newJailExec(1)
+	if err := e.run(ctx, cancel, exec.CommandContext(ctx, "sleep", "5")); err != nil {
+		t.Fatalf("unexpected start error: %v", err)
+	}
+	if _, result := waitJailExec(t, e); !result.TimedOut {
+		t.Fatalf("expected a timeout, got %+v", result)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	e = newJailExec(1)
+	if err := e.run(ctx, cancel, exec.CommandContext(ctx, "sleep", "5")); err != nil {
+		t.Fatalf("unexpected start error: %v", err)
+	}
+	e.Cancel()
+	if _, result := waitJailExec(t, e); !result.Cancelled || result.TimedOut {
+		t.Fatalf("expected a cancellation, got %+v", result)
+	}
+}
+
+func TestValidateJailExecRequest(t *testing.T) {
+	if _, err := validateJailExecRequest(jailServiceInterfaces.JailExecRequest{}); err == nil {
+		t.Fatal("expected an empty command to be refused")
+	}
+	if _, err := validateJailExecRequest(jailServiceInterfaces.JailExecRequest{Command: []string{"id"}, User: "root; rm"}); err == nil {
+		t.Fatal("expected an invalid user to be refused")
+	}
+	if _, err := validateJailExecRequest(jailServiceInterfaces.JailExecRequest{Command: []string{"id"}, Timeout: 7200}); err == nil {
+		t.Fatal("expected a timeout above the maximum to be refused")
+	}
+
+	timeout, err := validateJailExecRequest(jailServiceInterfaces.JailExecRequest{Command: []string{"id"}})
+	if err != nil || timeout != JailExecDefaultTimeout {
+		t.Fatalf("expected the default timeout, got %v, %v", timeout, err)
+	}
+}
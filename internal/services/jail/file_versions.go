@@ -0,0 +1,467 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package jail
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/alchemillahq/gzfs"
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
+	"github.com/alchemillahq/sylve/internal/logger"
+)
+
+// jailSnapshotRef is one snapshot of the jail root dataset, whether Sylve
+// took it for the jail or a periodic snapshot job did.
+type jailSnapshotRef struct {
+	name       string
+	snapshotID uint
+	label      string
+	createdAt  time.Time
+}
+
+// cleanJailPath turns a path as a user inside the jail would type it into
+// one relative to the jail root. Cleaning it as an absolute path first means
+// no amount of ".." can climb above the root.
+func cleanJailPath(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", fmt.Errorf("path_required")
+	}
+	if strings.ContainsRune(raw, 0) {
+		return "", fmt.Errorf("invalid_path")
+	}
+
+	cleaned := path.Clean("/" + raw)
+	if cleaned == "/" {
+		return "", fmt.Errorf("path_is_jail_root")
+	}
+	if cleaned == "/.zfs" || strings.HasPrefix(cleaned, "/.zfs/") {
+		return "", fmt.Errorf("invalid_path")
+	}
+
+	return strings.TrimPrefix(cleaned, "/"), nil
+}
+
+// resolveUnderRoot joins rel onto root, refusing to follow a symlink in any
+// directory on the way. The jail owns those links and may point them at
+// anything on the host. The last component is not followed either; it is
+// returned as is for the caller to Lstat.
+func resolveUnderRoot(root, rel string) (string, error) {
+	parts := strings.Split(rel, "/")
+	current := root
+	for _, part := range parts[:len(parts)-1] {
+		current = filepath.Join(current, part)
+		info, err := os.Lstat(current)
+		if err != nil {
+			return "", err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return "", fmt.Errorf("path_traverses_symlink")
+		}
+		if !info.IsDir() {
+			return "", fs.ErrNotExist
+		}
+	}
+	return filepath.Join(current, parts[len(parts)-1]), nil
+}
+
+func snapshotDirPath(mountPoint, snapshot string) string {
+	return filepath.Join(mountPoint, ".zfs", "snapshot", snapshot)
+}
+
+func (s *Service) listJailSnapshotRefs(ctx context.Context, jail *jailModels.Jail, rootDataset string) ([]jailSnapshotRef, error) {
+	datasets, err := s.GZFS.ZFS.ListWithPrefix(ctx, gzfs.DatasetTypeSnapshot, rootDataset, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed_to_list_jail_root_snapshots: %w", err)
+	}
+
+	var records []jailModels.JailSnapshot
+	if err := s.DB.Where("jid = ?", jail.ID).Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_list_jail_snapshots: %w", err)
+	}
+	byName := make(map[string]jailModels.JailSnapshot, len(records))
+	for _, record := range records {
+		byName[record.SnapshotName] = record
+	}
+
+	refs := make([]jailSnapshotRef, 0, len(datasets))
+	for _, ds := range datasets {
+		if ds == nil || !strings.HasPrefix(ds.Name, rootDataset+"@") {
+			continue
+		}
+
+		ref := jailSnapshotRef{name: strings.TrimPrefix(ds.Name, rootDataset+"@")}
+		ref.label = ref.name
+		if prop, ok := ds.Properties["creation"]; ok {
+			if epoch, err := strconv.ParseInt(strings.TrimSpace(prop.Value), 10, 64); err == nil {
+				ref.createdAt = time.Unix(epoch, 0).UTC()
+			}
+		}
+		if record, ok := byName[ref.name]; ok {
+			ref.snapshotID = record.ID
+			ref.label = record.Name
+			if ref.createdAt.IsZero() {
+				ref.createdAt = record.CreatedAt.UTC()
+			}
+		}
+		refs = append(refs, ref)
+	}
+
+	sort.SliceStable(refs, func(i, j int) bool {
+		return refs[i].createdAt.Before(refs[j].createdAt)
+	})
+
+	return refs, nil
+}
+
+func sameFileVersion(a, b os.FileInfo) bool {
+	return a.Mode() == b.Mode() && a.Size() == b.Size() && a.ModTime().Equal(b.ModTime())
+}
+
+// collapsePathVersions keeps one entry per distinct version of a path, the
+// oldest snapshot that holds it, the way a Previous Versions list does.
+// refs and infos run oldest first; the result is newest first.
+func collapsePathVersions(refs []jailSnapshotRef, infos []os.FileInfo, live os.FileInfo) []jailServiceInterfaces.PathVersion {
+	versions := []jailServiceInterfaces.PathVersion{}
+	var previous os.FileInfo
+	for i, ref := range refs {
+		info := infos[i]
+		if info == nil {
+			previous = nil
+			continue
+		}
+		if previous != nil && sameFileVersion(previous, info) {
+			continue
+		}
+		previous = info
+
+		size := info.Size()
+		if info.IsDir() {
+			size = 0
+		}
+		versions = append(versions, jailServiceInterfaces.PathVersion{
+			Snapshot:    ref.name,
+			SnapshotID:  ref.snapshotID,
+			Label:       ref.label,
+			CreatedAt:   ref.createdAt,
+			ModifiedAt:  info.ModTime().UTC(),
+			Size:        size,
+			IsDir:       info.IsDir(),
+			MatchesLive: live != nil && sameFileVersion(live, info),
+		})
+	}
+
+	for i, j := 0, len(versions)-1; i < j; i, j = i+1, j-1 {
+		versions[i], versions[j] = versions[j], versions[i]
+	}
+	return versions
+}
+
+// ListJailPathVersions lists the earlier versions of a file or directory in
+// the jail that its root dataset's snapshots still hold, so users can pick
+// one to restore without rolling the whole jail back.
+func (s *Service) ListJailPathVersions(ctx context.Context, ctID uint, rawPath string) (*jailServiceInterfaces.PathVersions, error) {
+	if ctID == 0 {
+		return nil, fmt.Errorf("invalid_ct_id")
+	}
+
+	rel, err := cleanJailPath(rawPath)
+	if err != nil {
+		return nil, err
+	}
+
+	jail, err := s.GetJailByCTID(ctID)
+	if err != nil {
+		return nil, fmt.Errorf("failed_to_get_jail: %w", err)
+	}
+
+	rootDataset, mountPoint, err := resolveJailRootDataset(jail)
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := s.listJailSnapshotRefs(ctx, jail, rootDataset)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &jailServiceInterfaces.PathVersions{Path: "/" + rel}
+
+	var live os.FileInfo
+	if livePath, err := resolveUnderRoot(mountPoint, rel); err == nil {
+		if info, err := os.Lstat(livePath); err == nil {
+			live = info
+			result.Exists = true
+		}
+	}
+
+	infos := make([]os.FileInfo, len(refs))
+	for i, ref := range refs {
+		snapPath, err := resolveUnderRoot(snapshotDirPath(mountPoint, ref.name), rel)
+		if err != nil {
+			continue
+		}
+		if info, err := os.Lstat(snapPath); err == nil {
+			infos[i] = info
+		}
+	}
+
+	result.Versions = collapsePathVersions(refs, infos, live)
+	return result, nil
+}
+
+// restoredCopyName names the side-by-side copy for a restore that must not
+// overwrite the live path: "report.txt" becomes "report.restored-<when>.txt".
+func restoredCopyName(rel string, at time.Time) string {
+	dir, base := path.Split(rel)
+	ext := path.Ext(base)
+	if ext == base {
+		ext = ""
+	}
+	stem := strings.TrimSuffix(base, ext)
+	return dir + fmt.Sprintf("%s.restored-%s%s", stem, at.UTC().Format("20060102-150405"), ext)
+}
+
+// RestoreJailPathFromSnapshot copies one file or directory out of a
+// snapshot back into the running jail. The copy is staged next to the
+// target and renamed into place, so a failed restore leaves the live path
+// alone. With asCopy the live path is kept and the old version lands
+// beside it. Returns the jail path that was written.
+func (s *Service) RestoreJailPathFromSnapshot(
+	ctx context.Context,
+	ctID uint,
+	snapshot string,
+	rawPath string,
+	asCopy bool,
+) (string, error) {
+	s.crudMutex.Lock()
+	defer s.crudMutex.Unlock()
+
+	if ctID == 0 {
+		return "", fmt.Errorf("invalid_ct_id")
+	}
+	allowed, leaseErr := s.canMutateProtectedJail(ctID)
+	if leaseErr != nil {
+		return "", fmt.Errorf("replication_lease_check_failed: %w", leaseErr)
+	}
+	if !allowed {
+		return "", fmt.Errorf("replication_lease_not_owned")
+	}
+
+	rel, err := cleanJailPath(rawPath)
+	if err != nil {
+		return "", err
+	}
+
+	jail, err := s.GetJailByCTID(ctID)
+	if err != nil {
+		return "", fmt.Errorf("failed_to_get_jail: %w", err)
+	}
+
+	rootDataset, mountPoint, err := resolveJailRootDataset(jail)
+	if err != nil {
+		return "", err
+	}
+
+	refs, err := s.listJailSnapshotRefs(ctx, jail, rootDataset)
+	if err != nil {
+		return "", err
+	}
+	var ref *jailSnapshotRef
+	for i := range refs {
+		if refs[i].name == snapshot {
+			ref = &refs[i]
+			break
+		}
+	}
+	if ref == nil {
+		return "", fmt.Errorf("snapshot_not_found")
+	}
+
+	source, err := resolveUnderRoot(snapshotDirPath(mountPoint, ref.name), rel)
+	if err != nil {
+		return "", fmt.Errorf("path_not_in_snapshot: %w", err)
+	}
+	if _, err := os.Lstat(source); err != nil {
+		return "", fmt.Errorf("path_not_in_snapshot: %w", err)
+	}
+
+	targetRel := rel
+	if asCopy {
+		targetRel = restoredCopyName(rel, ref.createdAt)
+	}
+
+	target, err := resolveUnderRoot(mountPoint, targetRel)
+	if err != nil {
+		return "", fmt.Errorf("restore_parent_missing: %w", err)
+	}
+	if asCopy {
+		if _, err := os.Lstat(target); err == nil {
+			return "", fmt.Errorf("restore_target_exists")
+		}
+	}
+
+	staging, err := stagingPathFor(target)
+	if err != nil {
+		return "", err
+	}
+	if err := copyTree(source, staging); err != nil {
+		_ = os.RemoveAll(staging)
+		return "", fmt.Errorf("failed_to_copy_from_snapshot: %w", err)
+	}
+
+	if err := swapIntoPlace(staging, target); err != nil {
+		_ = os.RemoveAll(staging)
+		return "", fmt.Errorf("failed_to_restore_path: %w", err)
+	}
+
+	logger.L.Info().
+		Uint("ctid", ctID).
+		Str("snapshot", ref.name).
+		Str("path", "/"+rel).
+		Str("restored_to", "/"+targetRel).
+		Msg("Restored jail path from snapshot")
+
+	return "/" + targetRel, nil
+}
+
+func stagingPathFor(target string) (string, error) {
+	suffix := make([]byte, 6)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(target), fmt.Sprintf(".%s.sylve-restore-%s", filepath.Base(target), hex.EncodeToString(suffix))), nil
+}
+
+// swapIntoPlace renames staging over target. A rename can replace a file
+// but not a directory, so an existing directory (or a file about to become
+// one) is moved aside first and only dropped once the new copy is in.
+func swapIntoPlace(staging, target string) error {
+	existing, err := os.Lstat(target)
+	if errors.Is(err, fs.ErrNotExist) {
+		return os.Rename(staging, target)
+	}
+	if err != nil {
+		return err
+	}
+
+	stagedInfo, err := os.Lstat(staging)
+	if err != nil {
+		return err
+	}
+	if !existing.IsDir() && !stagedInfo.IsDir() {
+		return os.Rename(staging, target)
+	}
+
+	aside := staging + ".old"
+	if err := os.Rename(target, aside); err != nil {
+		return err
+	}
+	if err := os.Rename(staging, target); err != nil {
+		_ = os.Rename(aside, target)
+		return err
+	}
+	return os.RemoveAll(aside)
+}
+
+// copyTree copies src to dst keeping mode, owner and modification time.
+// Symlinks are recreated rather than followed; sockets, devices and pipes
+// inside a directory are skipped.
+func copyTree(src, dst string) error {
+	info, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		link, err := os.Readlink(src)
+		if err != nil {
+			return err
+		}
+		if err := os.Symlink(link, dst); err != nil {
+			return err
+		}
+		return copyOwner(info, dst, true)
+	case info.IsDir():
+		if err := os.Mkdir(dst, info.Mode().Perm()); err != nil {
+			return err
+		}
+		entries, err := os.ReadDir(src)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if !entry.Type().IsRegular() && !entry.IsDir() && entry.Type()&os.ModeSymlink == 0 {
+				continue
+			}
+			if err := copyTree(filepath.Join(src, entry.Name()), filepath.Join(dst, entry.Name())); err != nil {
+				return err
+			}
+		}
+	case info.Mode().IsRegular():
+		if err := copyFileContents(src, dst, info.Mode().Perm()); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported_file_type")
+	}
+
+	if err := copyOwner(info, dst, false); err != nil {
+		return err
+	}
+	if err := os.Chmod(dst, info.Mode()&(os.ModePerm|os.ModeSetuid|os.ModeSetgid|os.ModeSticky)); err != nil {
+		return err
+	}
+	return os.Chtimes(dst, info.ModTime(), info.ModTime())
+}
+
+func copyFileContents(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func copyOwner(info os.FileInfo, dst string, link bool) error {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+	if link {
+		return os.Lchown(dst, int(st.Uid), int(st.Gid))
+	}
+	return os.Chown(dst, int(st.Uid), int(st.Gid))
+}
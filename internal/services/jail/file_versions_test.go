@@ -0,0 +1,175 @@
+package jail
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCleanJailPathStaysInsideRoot(t *testing.T) {
+	cases := map[string]string{
+		"etc/rc.conf":                "etc/rc.conf",
+		"/usr/local/www/index.php":   "usr/local/www/index.php",
+		"../../../etc/master.passwd": "etc/master.passwd",
+		"/home/./alice//notes.txt":   "home/alice/notes.txt",
+		"home/alice/../bob/":         "home/bob",
+	}
+	for raw, want := range cases {
+		got, err := cleanJailPath(raw)
+		if err != nil {
+			t.Fatalf("%q: unexpected error %v", raw, err)
+		}
+		if got != want {
+			t.Fatalf("%q: expected %q, got %q", raw, want, got)
+		}
+	}
+
+	for _, raw := range []string{"", "  ", "/", "..", "/.zfs/snapshot/x/etc", "a\x00b"} {
+		if _, err := cleanJailPath(raw); err == nil {
+			t.Fatalf("%q: expected error", raw)
+		}
+	}
+}
+
+func TestResolveUnderRootRefusesSymlinkedDirectories(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outside, "secret"), []byte("x"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join(root, "escape")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := resolveUnderRoot(root, "escape/secret"); err == nil || !strings.Contains(err.Error(), "path_traverses_symlink") {
+		t.Fatalf("expected path_traverses_symlink, got %v", err)
+	}
+
+	got, err := resolveUnderRoot(root, "escape")
+	if err != nil {
+		t.Fatalf("final symlink component should resolve: %v", err)
+	}
+	if got != filepath.Join(root, "escape") {
+		t.Fatalf("unexpected path %q", got)
+	}
+}
+
+func TestCollapsePathVersionsKeepsOldestSnapshotPerVersion(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, body string, mtime time.Time) os.FileInfo {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(p, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+		info, err := os.Lstat(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return info
+	}
+
+	t1 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	v1 := write("v1", "one", t1)
+	v1again := write("v1again", "one", t1)
+	v2 := write("v2", "two!", t2)
+
+	refs := []jailSnapshotRef{
+		{name: "auto-1", createdAt: t1},
+		{name: "auto-2", createdAt: t1.Add(30 * time.Minute)},
+		{name: "auto-3", createdAt: t2},
+		{name: "auto-4", createdAt: t2.Add(time.Hour)},
+	}
+	infos := []os.FileInfo{v1, v1again, nil, v2}
+
+	versions := collapsePathVersions(refs, infos, v2)
+	if len(versions) != 2 {
+		t.Fatalf("expected 2 versions, got %+v", versions)
+	}
+	if versions[0].Snapshot != "auto-4" || !versions[0].MatchesLive {
+		t.Fatalf("expected newest version from auto-4 matching live, got %+v", versions[0])
+	}
+	if versions[1].Snapshot != "auto-1" || versions[1].MatchesLive || versions[1].Size != 3 {
+		t.Fatalf("expected oldest version from auto-1, got %+v", versions[1])
+	}
+}
+
+func TestRestoredCopyName(t *testing.T) {
+	at := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	cases := map[string]string{
+		"home/alice/report.txt": "home/alice/report.restored-20260304-050607.txt",
+		"etc/rc.conf":           "etc/rc.restored-20260304-050607.conf",
+		"srv/www":               "srv/www.restored-20260304-050607",
+		".profile":              ".profile.restored-20260304-050607",
+	}
+	for rel, want := range cases {
+		if got := restoredCopyName(rel, at); got != want {
+			t.Fatalf("%q: expected %q, got %q", rel, want, got)
+		}
+	}
+}
+
+func TestCopyTreeAndSwapReplaceDirectory(t *testing.T) {
+	snap := t.TempDir()
+	live := t.TempDir()
+
+	src := filepath.Join(snap, "site")
+	if err := os.MkdirAll(filepath.Join(src, "css"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "index.html"), []byte("old"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("index.html", filepath.Join(src, "home.html")); err != nil {
+		t.Fatal(err)
+	}
+
+	target := filepath.Join(live, "site")
+	if err := os.MkdirAll(target, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(target, "index.html"), []byte("broken"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(target, "stray.tmp"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	staging, err := stagingPathFor(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := copyTree(src, staging); err != nil {
+		t.Fatalf("copy failed: %v", err)
+	}
+	if err := swapIntoPlace(staging, target); err != nil {
+		t.Fatalf("swap failed: %v", err)
+	}
+
+	body, err := os.ReadFile(filepath.Join(target, "index.html"))
+	if err != nil || string(body) != "old" {
+		t.Fatalf("expected restored content, got %q (%v)", body, err)
+	}
+	if info, err := os.Stat(filepath.Join(target, "index.html")); err != nil || info.Mode().Perm() != 0640 {
+		t.Fatalf("expected mode 0640 to survive, got %v (%v)", info.Mode(), err)
+	}
+	if link, err := os.Readlink(filepath.Join(target, "home.html")); err != nil || link != "index.html" {
+		t.Fatalf("expected symlink to be recreated, got %q (%v)", link, err)
+	}
+	if _, err := os.Stat(filepath.Join(target, "stray.tmp")); !os.IsNotExist(err) {
+		t.Fatalf("expected directory to be replaced, stray file still there: %v", err)
+	}
+
+	entries, err := os.ReadDir(live)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected only the restored directory to remain, got %d entries", len(entries))
+	}
+}
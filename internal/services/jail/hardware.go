@@ -11,10 +11,8 @@ package jail
 import (
 	"fmt"
 	"os"
-	"sort"
 	"strings"
 
-	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
 	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/pkg/utils"
 
@@ -94,7 +92,9 @@ func (s *Service) UpdateMemory(ctId uint, memoryBytes int64) error {
 	return nil
 }
 
-func (s *Service) UpdateCPU(ctId uint, cores int64) error {
+// UpdateCPU confines a jail to a set of host CPUs. An explicit cpuSet is
+// checked against VM pins and used as is; otherwise cores are auto-assigned.
+func (s *Service) UpdateCPU(ctId uint, cores int64, cpuSet []int) error {
 	allowed, leaseErr := s.canMutateProtectedJail(ctId)
 	if leaseErr != nil {
 		return fmt.Errorf("replication_lease_check_failed: %w", leaseErr)
@@ -103,15 +103,6 @@ func (s *Service) UpdateCPU(ctId uint, cores int64) error {
 		return fmt.Errorf("replication_lease_not_owned")
 	}
 
-	if cores <= 0 {
-		return fmt.Errorf("invalid cores value: %d (must be >= 1)", cores)
-	}
-
-	numLogical := int64(cpuid.CPU.LogicalCores)
-	if cores > numLogical {
-		return fmt.Errorf("requested cores (%d) exceed logical cores available (%d)", cores, numLogical)
-	}
-
 	cfg, err := s.GetJailConfig(ctId)
 	if err != nil {
 		return err
@@ -120,39 +111,30 @@ func (s *Service) UpdateCPU(ctId uint, cores int64) error {
 		return fmt.Errorf("jail config not found for CTID: %d", ctId)
 	}
 
-	var currentJails []jailModels.Jail
-	if err := s.DB.Find(&currentJails).Error; err != nil {
-		return fmt.Errorf("failed_to_fetch_current_jails: %w", err)
+	layout := hostCPULayout()
+	claims, err := s.loadCPUClaims(ctId, layout)
+	if err != nil {
+		return err
 	}
 
-	coreUsage := map[int]int{}
-	for _, j := range currentJails {
-		if j.CTID == ctId {
-			continue
+	var selected []int
+	if len(cpuSet) > 0 {
+		selected, err = validateCPUSet(claims, layout, cpuSet)
+		if err != nil {
+			return err
 		}
-		for _, c := range j.CPUSet {
-			coreUsage[c]++
+	} else {
+		if cores <= 0 {
+			return fmt.Errorf("invalid cores value: %d (must be >= 1)", cores)
+		}
+		if cores > int64(layout.logical) {
+			return fmt.Errorf("requested cores (%d) exceed logical cores available (%d)", cores, layout.logical)
 		}
-	}
-
-	type coreCount struct {
-		Core  int
-		Count int
-	}
-
-	all := make([]coreCount, 0, cpuid.CPU.LogicalCores)
-	for i := 0; i < cpuid.CPU.LogicalCores; i++ {
-		all = append(all, coreCount{Core: i, Count: coreUsage[i]})
-	}
-
-	sort.Slice(all, func(i, j int) bool { return all[i].Count < all[j].Count })
-	selected := make([]int, 0, cores)
 
-	for i := 0; i < int(cores) && i < len(all); i++ {
-		selected = append(selected, all[i].Core)
-	}
-	if len(selected) == 0 {
-		return fmt.Errorf("no CPU cores selected")
+		selected, err = suggestCPUSet(claims, layout, int(cores))
+		if err != nil {
+			return err
+		}
 	}
 
 	coreListStr := strings.Trim(strings.Replace(fmt.Sprint(selected), " ", ",", -1), "[]")
@@ -195,7 +177,7 @@ func (s *Service) UpdateCPU(ctId uint, cores int64) error {
 		return err
 	}
 
-	jail.Cores = int(cores)
+	jail.Cores = len(selected)
 	jail.CPUSet = selected
 
 	if err := s.DB.Save(&jail).Error; err != nil {
@@ -250,7 +232,7 @@ func (s *Service) UpdateResourceLimits(ctId uint, enabled bool) error {
 			return fmt.Errorf("failed to set default memory limit: %w", err)
 		}
 
-		if err := s.UpdateCPU(ctId, 1); err != nil {
+		if err := s.UpdateCPU(ctId, 1, nil); err != nil {
 			return fmt.Errorf("failed to set default cpu limit: %w", err)
 		}
 
@@ -17,6 +17,7 @@ import (
 	"path/filepath"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -26,15 +27,17 @@ import (
 	"github.com/alchemillahq/sylve/internal/db/models"
 	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
 	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	utilitiesModels "github.com/alchemillahq/sylve/internal/db/models/utilities"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
 	"github.com/alchemillahq/sylve/internal/db/replicationguard"
 	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
 	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
 	networkServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/network"
 	systemServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/system"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/internal/services/snapshotschedule"
 	"github.com/alchemillahq/sylve/pkg/utils"
-	cpuid "github.com/klauspost/cpuid/v2"
 
 	"gorm.io/gorm"
 )
@@ -69,7 +72,13 @@ type Service struct {
 	usageRetentionQueue chan struct{}
 	monitorOnce         sync.Once
 
+	snapshotSchedules snapshotschedule.Reservations
+
 	bootstrapActiveMu sync.Map
+	jailExecs         sync.Map
+
+	baseCacheMu     sync.Mutex
+	baseCacheMirror string
 }
 
 func (s *Service) SetGuestIdentityAvailabilityChecker(
@@ -233,6 +242,7 @@ func (s *Service) GetJailsSimple() ([]jailServiceInterfaces.SimpleList, error) {
 	var jails []jailModels.Jail
 
 	if err := s.DB.Model(&jailModels.Jail{}).
+		Preload("Storages").
 		Select("id, name, ct_id, resource_limits, cores, memory").
 		Find(&jails).Error; err != nil {
 		logger.L.Error().Err(err).Msg("get_jails_simple: failed to fetch jails")
@@ -276,6 +286,7 @@ func (s *Service) GetSimpleJail(identifier int, byCTID bool) (jailServiceInterfa
 
 	query := s.DB.
 		Model(&jailModels.Jail{}).
+		Preload("Storages").
 		Select("id", "name", "ct_id", "resource_limits", "cores", "memory")
 
 	if byCTID {
@@ -307,7 +318,21 @@ func simpleJailListItem(jail jailModels.Jail, state string) jailServiceInterface
 		ResourceLimits: jail.ResourceLimits,
 		Cores:          jail.Cores,
 		Memory:         jail.Memory,
+		Pools:          jailStoragePools(jail.Storages),
+	}
+}
+
+// jailStoragePools lists the pools a jail's datasets live on.
+func jailStoragePools(storages []jailModels.Storage) []string {
+	pools := []string{}
+	for _, storage := range storages {
+		if storage.Pool == "" || slices.Contains(pools, storage.Pool) {
+			continue
+		}
+		pools = append(pools, storage.Pool)
 	}
+	sort.Strings(pools)
+	return pools
 }
 
 func (s *Service) GetJailType(ctId uint) (jailModels.JailType, error) {
@@ -326,7 +351,44 @@ func (s *Service) GetJailType(ctId uint) (jailModels.JailType, error) {
 	return jail.Type, nil
 }
 
+// jailCreateMinFreeBytes is the least free pool space a new jail root needs
+// when the size of its source is unknown.
+const jailCreateMinFreeBytes int64 = 512 * 1024 * 1024
+
+// devfsRuleActions are the first words devfs(8) accepts after "add" and an
+// optional rule number.
+var devfsRuleActions = []string{"path", "type", "hide", "unhide", "include", "group", "mode", "user"}
+
+// jailCreateCheck is one independent section of jail creation validation.
+// ValidateCreate stops at the first failing section while the pre-flight
+// report runs all of them.
+type jailCreateCheck struct {
+	name string
+	run  func(ctx context.Context, data jailServiceInterfaces.CreateJailRequest) error
+}
+
+func (s *Service) jailCreateChecks() []jailCreateCheck {
+	return []jailCreateCheck{
+		{name: "identity", run: s.validateCreateIdentity},
+		{name: "storage", run: s.validateCreateStorage},
+		{name: "source", run: s.validateCreateSource},
+		{name: "network", run: s.validateCreateNetwork},
+		{name: "options", run: s.validateCreateOptions},
+		{name: "cluster", run: s.validateCreateClusterIdentity},
+	}
+}
+
 func (s *Service) ValidateCreate(ctx context.Context, data jailServiceInterfaces.CreateJailRequest) error {
+	for _, check := range s.jailCreateChecks() {
+		if err := check.run(ctx, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) validateCreateIdentity(ctx context.Context, data jailServiceInterfaces.CreateJailRequest) error {
 	if data.Name == "" || !utils.IsValidVMName(data.Name) {
 		return fmt.Errorf("invalid_vm_name")
 	}
@@ -335,6 +397,12 @@ func (s *Service) ValidateCreate(ctx context.Context, data jailServiceInterfaces
 		return fmt.Errorf("invalid_hostname")
 	}
 
+	if data.ManageEtcFiles != nil && *data.ManageEtcFiles {
+		if err := validateJailEtcFilesInput(strings.TrimSpace(data.DNSSearchDomain), data.HostEntries); err != nil {
+			return err
+		}
+	}
+
 	if data.CTID == nil || *data.CTID <= 0 || *data.CTID > 9999 {
 		return fmt.Errorf("invalid_ct_id")
 	}
@@ -371,26 +439,83 @@ func (s *Service) ValidateCreate(ctx context.Context, data jailServiceInterfaces
 		return fmt.Errorf("resource_limits_require_cores_and_memory")
 	}
 
+	if data.StartOrder < 0 {
+		return fmt.Errorf("start_order_must_be_greater_than_or_equal_to_0")
+	}
+
+	return nil
+}
+
+func (s *Service) findUsableCreatePool(ctx context.Context, name string) (*gzfs.ZPool, error) {
 	pools, err := s.System.GetUsablePools(ctx)
 	if err != nil {
-		return fmt.Errorf("failed_to_get_usable_pools: %w", err)
+		return nil, fmt.Errorf("failed_to_get_usable_pools: %w", err)
 	}
 
-	foundPool := ""
 	for _, pool := range pools {
-		if pool.Name == data.Pool {
-			foundPool = pool.Name
-			break
+		if pool.Name == name {
+			return pool, nil
 		}
 	}
 
-	if foundPool == "" {
-		return fmt.Errorf("pool_not_found")
+	return nil, fmt.Errorf("pool_not_found")
+}
+
+func (s *Service) validateCreateStorage(ctx context.Context, data jailServiceInterfaces.CreateJailRequest) error {
+	pool, err := s.findUsableCreatePool(ctx, data.Pool)
+	if err != nil {
+		return err
 	}
 
+	if data.CTID == nil {
+		return fmt.Errorf("invalid_ct_id")
+	}
+
+	existingDataset, err := s.GZFS.ZFS.Get(ctx, fmt.Sprintf("%s/%d", layout.JailsDataset(pool.Name), *data.CTID), false)
+	if err != nil {
+		if !strings.Contains(err.Error(), "dataset does not exist") {
+			return fmt.Errorf("failed_to_get_existing_datasets: %w", err)
+		}
+	}
+
+	if existingDataset != nil {
+		return fmt.Errorf("jail_base_fs_with_ctid_already_exists")
+	}
+
+	return nil
+}
+
+// createSourceSizeHint is the best known size of the root a jail will be
+// copied from; zero when it cannot be told without walking the tree.
+func (s *Service) createSourceSizeHint(data jailServiceInterfaces.CreateJailRequest) int64 {
+	switch {
+	case data.Release != "":
+		if record, err := s.getBaseCacheRecord(data.Release); err == nil && record.Status == baseCacheStatusReady {
+			return record.RootSize
+		}
+	case data.Base != "":
+		var download utilitiesModels.Downloads
+		if err := s.DB.Select("size").Where("uuid = ?", data.Base).First(&download).Error; err == nil {
+			return download.Size
+		}
+	}
+
+	return 0
+}
+
+func (s *Service) validateCreateSource(ctx context.Context, data jailServiceInterfaces.CreateJailRequest) error {
+	sources := 0
+	for _, v := range []string{data.Base, data.BootstrapName, data.Release} {
+		if v != "" {
+			sources++
+		}
+	}
 	if data.Base != "" && data.BootstrapName != "" {
 		return fmt.Errorf("base_and_bootstrap_name_are_mutually_exclusive")
 	}
+	if sources > 1 {
+		return fmt.Errorf("multiple_jail_sources_specified")
+	}
 
 	if data.Base != "" {
 		ex, err := s.FindBaseByUUID(data.Base)
@@ -412,8 +537,8 @@ func (s *Service) ValidateCreate(ctx context.Context, data jailServiceInterfaces
 			return fmt.Errorf("base_is_not_a_directory")
 		}
 	} else if data.BootstrapName != "" {
-		bootstrapDataset := fmt.Sprintf("%s/sylve/bootstraps/%s", data.Pool, data.BootstrapName)
-		bootstrapMount := fmt.Sprintf("/%s/sylve/bootstraps/%s", data.Pool, data.BootstrapName)
+		bootstrapDataset := fmt.Sprintf("%s/%s", layout.BootstrapsDataset(data.Pool), data.BootstrapName)
+		bootstrapMount := fmt.Sprintf("/%s/%s", layout.BootstrapsDataset(data.Pool), data.BootstrapName)
 
 		var bRecord jailModels.JailBootstrap
 		if err := s.DB.Where("pool = ? AND name = ?", data.Pool, data.BootstrapName).First(&bRecord).Error; err != nil {
@@ -431,21 +556,35 @@ func (s *Service) ValidateCreate(ctx context.Context, data jailServiceInterfaces
 		if _, err := os.Stat(bootstrapMount); os.IsNotExist(err) {
 			return fmt.Errorf("bootstrap_mount_does_not_exist")
 		}
+	} else if data.Release != "" {
+		if err := validateBaseRelease(data.Release); err != nil {
+			return err
+		}
+		if data.Type != jailModels.JailTypeFreeBSD {
+			return fmt.Errorf("release_base_requires_freebsd_jail")
+		}
 	} else {
 		return fmt.Errorf("download_uuid_or_bootstrap_name_required")
 	}
 
-	existingDataset, err := s.GZFS.ZFS.Get(ctx, fmt.Sprintf("%s/sylve/jails/%d", foundPool, *data.CTID), false)
+	pool, err := s.findUsableCreatePool(ctx, data.Pool)
 	if err != nil {
-		if !strings.Contains(err.Error(), "dataset does not exist") {
-			return fmt.Errorf("failed_to_get_existing_datasets: %w", err)
-		}
+		return err
 	}
 
-	if existingDataset != nil {
-		return fmt.Errorf("jail_base_fs_with_ctid_already_exists")
+	// Pools that report no size come from callers without zpool stats; there
+	// is nothing to compare against.
+	if pool.Size > 0 {
+		need := max(s.createSourceSizeHint(data), jailCreateMinFreeBytes)
+		if pool.Free < uint64(need) {
+			return fmt.Errorf("insufficient_pool_space: need %d bytes, %d free", need, pool.Free)
+		}
 	}
 
+	return nil
+}
+
+func (s *Service) validateCreateNetwork(_ context.Context, data jailServiceInterfaces.CreateJailRequest) error {
 	swAvailable := true
 	mac := uint(0)
 	dhcp := false
@@ -568,6 +707,48 @@ func (s *Service) ValidateCreate(ctx context.Context, data jailServiceInterfaces
 		}
 	}
 
+	return nil
+}
+
+// validateDevfsRulesetBody checks the rule lines a create request wants in
+// its generated devfs.rules section. Sylve writes the section header itself,
+// so only add/include lines are accepted.
+func validateDevfsRulesetBody(rules string) error {
+	for i, raw := range strings.Split(rules, "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			return fmt.Errorf("devfs_ruleset_header_not_allowed: line %d", i+1)
+		}
+
+		fields := strings.Fields(line)
+		switch fields[0] {
+		case "include":
+			if len(fields) != 2 || !strings.HasPrefix(fields[1], "$") {
+				return fmt.Errorf("invalid_devfs_ruleset_line: %d", i+1)
+			}
+		case "add":
+			rest := fields[1:]
+			if len(rest) > 0 {
+				if _, err := strconv.Atoi(rest[0]); err == nil {
+					rest = rest[1:]
+				}
+			}
+			if len(rest) == 0 || !slices.Contains(devfsRuleActions, rest[0]) {
+				return fmt.Errorf("invalid_devfs_ruleset_line: %d", i+1)
+			}
+		default:
+			return fmt.Errorf("invalid_devfs_ruleset_line: %d", i+1)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) validateCreateOptions(_ context.Context, data jailServiceInterfaces.CreateJailRequest) error {
 	if !utils.IsValidJailAllowedOpts(data.AllowedOptions) {
 		return fmt.Errorf("invalid_jail_allowed_options")
 	}
@@ -576,11 +757,20 @@ func (s *Service) ValidateCreate(ctx context.Context, data jailServiceInterfaces
 		return fmt.Errorf("devfs_management_disabled")
 	}
 
-	if data.StartOrder < 0 {
-		return fmt.Errorf("start_order_must_be_greater_than_or_equal_to_0")
+	if strings.TrimSpace(data.DevFSRuleset) != "" {
+		if config.IsDevFSDisabled() {
+			return fmt.Errorf("devfs_management_disabled")
+		}
+		if err := validateDevfsRulesetBody(data.DevFSRuleset); err != nil {
+			return err
+		}
 	}
 
-	if s.guestIdentityChecker != nil {
+	return nil
+}
+
+func (s *Service) validateCreateClusterIdentity(ctx context.Context, data jailServiceInterfaces.CreateJailRequest) error {
+	if s.guestIdentityChecker != nil && data.CTID != nil {
 		if err := s.guestIdentityChecker.RequireGuestIDAvailable(ctx, *data.CTID); err != nil {
 			return err
 		}
@@ -813,7 +1003,7 @@ func (s *Service) hasStaleJailRootDatasetForCreate(ctx context.Context, ctid uin
 			continue
 		}
 
-		datasetName := fmt.Sprintf("%s/sylve/jails/%d", poolName, ctid)
+		datasetName := fmt.Sprintf("%s/%d", layout.JailsDataset(poolName), ctid)
 		ds, getErr := s.GZFS.ZFS.Get(ctx, datasetName, false)
 		if getErr != nil {
 			if isZFSDatasetMissingError(getErr) {
@@ -921,7 +1111,7 @@ func (s *Service) forceRemoveJailZFSDatasets(ctx context.Context, ctid uint, fal
 	}
 
 	for poolName := range poolNames {
-		datasetName := fmt.Sprintf("%s/sylve/jails/%d", poolName, ctid)
+		datasetName := fmt.Sprintf("%s/%d", layout.JailsDataset(poolName), ctid)
 
 		ds, getErr := s.GZFS.ZFS.Get(ctx, datasetName, false)
 		if getErr != nil {
@@ -1120,42 +1310,28 @@ func (s *Service) CreateHardwareConfig(data jailModels.Jail) (string, string, er
 
 	ctidHash := s.GetCTIDHash(data.CTID)
 
-	jails, err := s.GetJails()
-	if err != nil {
-		return "", "", fmt.Errorf("failed_to_get_jails: %w", err)
-	}
-
-	numLogicalCores := cpuid.CPU.LogicalCores
-	coreUsage := map[int]int{}
-	for _, jail := range jails {
-		for _, core := range jail.CPUSet {
-			coreUsage[core]++
+	if cpuCores > 0 {
+		hostCPUs := hostCPULayout()
+		claims, err := s.loadCPUClaims(data.CTID, hostCPUs)
+		if err != nil {
+			return "", "", err
 		}
-	}
 
-	type CoreCount struct {
-		Core  int
-		Count int
-	}
-
-	var allCores []CoreCount
-	for i := 0; i < numLogicalCores; i++ {
-		allCores = append(allCores, CoreCount{Core: i, Count: coreUsage[i]})
-	}
-
-	sort.Slice(allCores, func(i, j int) bool {
-		return allCores[i].Count < allCores[j].Count
-	})
-
-	if cpuCores > 0 && len(allCores) > 0 {
-		selectedCores := []int{}
-		for i := 0; i < cpuCores && i < len(allCores); i++ {
-			selectedCores = append(selectedCores, allCores[i].Core)
+		selectedCores, err := suggestCPUSet(claims, hostCPUs, min(cpuCores, hostCPUs.logical))
+		if err != nil {
+			return "", "", err
 		}
-		if len(selectedCores) > 0 {
-			coreListStr := strings.Trim(strings.Replace(fmt.Sprint(selectedCores), " ", ",", -1), "[]")
-			cpuCfg = fmt.Sprintf("cpuset -l %s -j %s", coreListStr, ctidHash)
+
+		// Record the set so later assignments and the topology view see it.
+		if err := s.DB.Model(&jailModels.Jail{}).
+			Where("ct_id = ?", data.CTID).
+			Select("cpu_set").
+			Updates(&jailModels.Jail{CPUSet: selectedCores}).Error; err != nil {
+			return "", "", fmt.Errorf("failed_to_store_cpu_set: %w", err)
 		}
+
+		coreListStr := strings.Trim(strings.Replace(fmt.Sprint(selectedCores), " ", ",", -1), "[]")
+		cpuCfg = fmt.Sprintf("cpuset -l %s -j %s", coreListStr, ctidHash)
 	}
 
 	if memory > 0 {
@@ -1324,6 +1500,15 @@ func (s *Service) CreateJailConfig(data jailModels.Jail, mountPoint string, mac
 
 			epairA := fmt.Sprintf("%s_%sa", ctidHash, networkId)
 
+			switchMTU, err := s.NetworkService.GetSwitchMTUByIDType(network.SwitchID, network.SwitchType)
+			if err != nil {
+				return "", fmt.Errorf("failed to get switch mtu: %w", err)
+			}
+			if switchMTU > 0 && switchMTU != 1500 {
+				preStartCfg += fmt.Sprintf("ifconfig %s mtu %d\n", epairA, switchMTU)
+				preStartCfg += fmt.Sprintf("ifconfig %s_%sb mtu %d\n", ctidHash, networkId, switchMTU)
+			}
+
 			if network.VLAN != nil && *network.VLAN > 0 {
 				vlanIface := fmt.Sprintf("%s.%d", epairA, *network.VLAN)
 				preStartCfg += fmt.Sprintf("if ! ifconfig %s > /dev/null 2>&1; then\n", vlanIface)
@@ -1344,9 +1529,14 @@ func (s *Service) CreateJailConfig(data jailModels.Jail, mountPoint string, mac
 		// DHCP / SLAAC / static config inside jail — FreeBSD JAILS ONLY
 		if data.Type == jailModels.JailTypeFreeBSD {
 			ifName := fmt.Sprintf("ifconfig_%s_%sb", ctidHash, networkId)
-			lineDHCP := fmt.Sprintf("%s=\"SYNCDHCP\"\n", ifName)
+			lineDHCP := fmt.Sprintf("%s=\"%s\"\n", ifName, withJailFIB("SYNCDHCP", network.FIB))
 			ipv6Name := fmt.Sprintf("%s_ipv6", ifName)
-			lineSLAAC := fmt.Sprintf("%s=\"inet6 accept_rtadv\"\n", ipv6Name)
+			hasIPv4Config := network.DHCP || (network.IPv4ID != nil && *network.IPv4ID > 0 && network.IPv4GwID != nil && *network.IPv4GwID > 0)
+			ipv6FIB := network.FIB
+			if hasIPv4Config {
+				ipv6FIB = 0
+			}
+			lineSLAAC := fmt.Sprintf("%s=\"%s\"\n", ipv6Name, withJailFIB("inet6 accept_rtadv", ipv6FIB))
 			lineRTSold := "rtsold_enable=\"YES\"\n"
 
 			rcF, err := os.OpenFile(rcConfPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
@@ -1380,7 +1570,7 @@ func (s *Service) CreateJailConfig(data jailModels.Jail, mountPoint string, mac
 						return "", fmt.Errorf("failed_to_split_ipv4_address_and_mask: %w", err)
 					}
 
-					lineIPv4 := fmt.Sprintf("%s=\"inet %s netmask %s\"\n", ifName, ip, mask)
+					lineIPv4 := fmt.Sprintf("%s=\"%s\"\n", ifName, withJailFIB(fmt.Sprintf("inet %s netmask %s", ip, mask), network.FIB))
 					if !strings.Contains(existing, lineIPv4) {
 						rcToAppend.WriteString(lineIPv4)
 					}
@@ -1391,7 +1581,7 @@ func (s *Service) CreateJailConfig(data jailModels.Jail, mountPoint string, mac
 							return "", fmt.Errorf("failed_to_get_ipv4_gateway_object: %w", err)
 						}
 
-						lineGw4 := fmt.Sprintf("defaultrouter=\"%s\"\n", ipv4GwAddr)
+						lineGw4 := jailDefaultRouterRCLine(ipv4GwAddr, false, network.FIB) + "\n"
 						if !strings.Contains(existing, lineGw4) {
 							rcToAppend.WriteString(lineGw4)
 						}
@@ -1415,7 +1605,7 @@ func (s *Service) CreateJailConfig(data jailModels.Jail, mountPoint string, mac
 					return "", fmt.Errorf("failed_to_get_ipv6_address_object: %w", err)
 				}
 
-				lineIPv6 := fmt.Sprintf("%s=\"inet6 %s\"\n", ipv6Name, ipv6Addr)
+				lineIPv6 := fmt.Sprintf("%s=\"%s\"\n", ipv6Name, withJailFIB("inet6 "+ipv6Addr, ipv6FIB))
 				if !strings.Contains(existing, lineIPv6) {
 					rcToAppend.WriteString(lineIPv6)
 				}
@@ -1427,7 +1617,7 @@ func (s *Service) CreateJailConfig(data jailModels.Jail, mountPoint string, mac
 					return "", fmt.Errorf("failed_to_get_ipv6_gateway_object: %w", err)
 				}
 
-				lineGw6 := fmt.Sprintf("ipv6_defaultrouter=\"%s\"\n", ipv6GwAddr)
+				lineGw6 := jailDefaultRouterRCLine(ipv6GwAddr, true, network.FIB) + "\n"
 				if !strings.Contains(existing, lineGw6) {
 					rcToAppend.WriteString(lineGw6)
 				}
@@ -1576,9 +1766,9 @@ func (s *Service) CreateJailConfig(data jailModels.Jail, mountPoint string, mac
 	}
 
 	// fstab if any (mount.fstab, host-side path)
-	if data.Fstab != "" {
+	if fstab := renderJailFstab(mountPoint, data.Mounts, data.Fstab); fstab != "" {
 		fstabPath := filepath.Join(jailDir, "fstab")
-		if err := os.WriteFile(fstabPath, []byte(data.Fstab), 0644); err != nil {
+		if err := os.WriteFile(fstabPath, []byte(fstab), 0644); err != nil {
 			return "", fmt.Errorf("failed_to_write_fstab_file: %w", err)
 		}
 		cfg += fmt.Sprintf("\tmount.fstab = \"%s\";\n\n", fstabPath)
@@ -1693,6 +1883,15 @@ func resolveRawIP(ptr *int, raw string, tx *gorm.DB, objType, baseName string, a
 }
 
 func (s *Service) CreateJail(ctx context.Context, data jailServiceInterfaces.CreateJailRequest) (err error) {
+	// The release base is fetched before taking the create lock: a download
+	// can take minutes, and a bad archive has to fail before anything exists.
+	releaseRoot := ""
+	if data.Release != "" {
+		if releaseRoot, err = s.EnsureReleaseBase(ctx, data.Release); err != nil {
+			return fmt.Errorf("failed_to_prepare_release_base: %w", err)
+		}
+	}
+
 	s.createMutex.Lock()
 	defer s.createMutex.Unlock()
 
@@ -1721,8 +1920,8 @@ func (s *Service) CreateJail(ctx context.Context, data jailServiceInterfaces.Cre
 		s.cleanupFailedJailCreate(ctid, data.Pool, autoCreatedIDs)
 	}()
 
-	datasetName := fmt.Sprintf("%s/sylve/jails/%d", data.Pool, ctid)
-	mountPoint := fmt.Sprintf("/%s/sylve/jails/%d", data.Pool, ctid)
+	datasetName := fmt.Sprintf("%s/%d", layout.JailsDataset(data.Pool), ctid)
+	mountPoint := fmt.Sprintf("/%s/%d", layout.JailsDataset(data.Pool), ctid)
 
 	var dataset *gzfs.Dataset
 	dataset, err = s.GZFS.ZFS.CreateFilesystem(ctx, datasetName, map[string]string{})
@@ -1748,6 +1947,11 @@ func (s *Service) CreateJail(ctx context.Context, data jailServiceInterfaces.Cre
 	jail.Fstab = data.Fstab
 
 	jail.ResolvConf = data.ResolvConf
+	if data.ManageEtcFiles != nil {
+		jail.ManageEtcFiles = *data.ManageEtcFiles
+	}
+	jail.DNSSearchDomain = strings.TrimSpace(data.DNSSearchDomain)
+	jail.HostEntries = data.HostEntries
 
 	jail.AllowedOptions = data.AllowedOptions
 	jail.Type = data.Type
@@ -2028,11 +2232,16 @@ func (s *Service) CreateJail(ctx context.Context, data jailServiceInterfaces.Cre
 	txCommitted = true
 
 	if data.BootstrapName != "" {
-		bootstrapMount := fmt.Sprintf("/%s/sylve/bootstraps/%s", data.Pool, data.BootstrapName)
+		bootstrapMount := fmt.Sprintf("/%s/%s", layout.BootstrapsDataset(data.Pool), data.BootstrapName)
 		if err = utils.CopyDirContents(bootstrapMount, mountPoint); err != nil {
 			err = fmt.Errorf("failed_to_copy_bootstrap: %w", err)
 			return
 		}
+	} else if releaseRoot != "" {
+		if err = utils.CopyDirContents(releaseRoot, mountPoint); err != nil {
+			err = fmt.Errorf("failed_to_copy_release_base: %w", err)
+			return
+		}
 	} else {
 		var base string
 		base, err = s.FindBaseByUUID(data.Base)
@@ -2115,6 +2324,11 @@ func (s *Service) CreateJail(ctx context.Context, data jailServiceInterfaces.Cre
 		return
 	}
 
+	if err = s.SyncJailEtcFiles(ctid); err != nil {
+		err = fmt.Errorf("failed_to_write_jail_etc_files: %w", err)
+		return
+	}
+
 	err = s.WriteJailJSON(ctid)
 	if err != nil {
 		logger.L.Error().Err(err).Msg("Failed to write jail metadata")
@@ -2150,7 +2364,7 @@ func (s *Service) loadJailDeletePlan(ctx context.Context, ctID uint) (jailDelete
 		if pool == "" {
 			continue
 		}
-		dataset := fmt.Sprintf("%s/sylve/jails/%d", pool, ctID)
+		dataset := fmt.Sprintf("%s/%d", layout.JailsDataset(pool), ctID)
 		if _, exists := seenDatasets[dataset]; exists {
 			continue
 		}
@@ -2205,6 +2419,9 @@ func (s *Service) deleteJailDatabaseGraph(
 		if err := tx.Where("jid = ?", plan.jailID).Delete(&jailModels.JailSnapshot{}).Error; err != nil {
 			return fmt.Errorf("failed_to_delete_jail_snapshots: %w", err)
 		}
+		if err := tx.Where("jid = ?", plan.jailID).Delete(&jailModels.JailSnapshotSchedule{}).Error; err != nil {
+			return fmt.Errorf("failed_to_delete_jail_snapshot_schedule: %w", err)
+		}
 		storageDB := tx
 		if allowReplicationPolicy {
 			// Replication/migration retirement removes only stale local metadata.
@@ -2593,7 +2810,7 @@ func (s *Service) WriteJailJSON(ctId uint) error {
 	var mountPoints []string
 	for _, storage := range jail.Storages {
 		if storage.IsBase {
-			mountPoints = append(mountPoints, fmt.Sprintf("/%s/sylve/jails/%d", storage.Pool, ctId))
+			mountPoints = append(mountPoints, fmt.Sprintf("/%s/%d", layout.JailsDataset(storage.Pool), ctId))
 		}
 	}
 
@@ -15,6 +15,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -601,6 +602,7 @@ func TestCreateJail_LinuxPersistsResolvConf(t *testing.T) {
 		&jailModels.JailHooks{},
 		&jailModels.JailStats{},
 		&jailModels.JailSnapshot{},
+		&jailModels.JailSnapshotSchedule{},
 		&utilitiesModels.Downloads{},
 	)
 
@@ -668,6 +670,7 @@ func TestCreateJailSerializesConcurrentSameCTIDRequests(t *testing.T) {
 		&jailModels.JailHooks{},
 		&jailModels.JailStats{},
 		&jailModels.JailSnapshot{},
+		&jailModels.JailSnapshotSchedule{},
 		&utilitiesModels.Downloads{},
 	)
 	tmp := t.TempDir()
@@ -844,6 +847,7 @@ func TestCleanupFailedJailCreate_RemovesArtifactsAndOnlyAutoCreatedMACs(t *testi
 		&jailModels.JailHooks{},
 		&jailModels.JailStats{},
 		&jailModels.JailSnapshot{},
+		&jailModels.JailSnapshotSchedule{},
 		&networkModels.Object{},
 		&networkModels.ObjectEntry{},
 		&networkModels.ObjectResolution{},
@@ -980,6 +984,7 @@ func TestCreateJail_PostCommitFailureCleansResidualArtifacts(t *testing.T) {
 		&jailModels.JailHooks{},
 		&jailModels.JailStats{},
 		&jailModels.JailSnapshot{},
+		&jailModels.JailSnapshotSchedule{},
 		&networkModels.Object{},
 		&networkModels.ObjectEntry{},
 		&networkModels.ObjectResolution{},
@@ -1065,3 +1070,60 @@ func TestValidateCreateRejectsInvalidVLAN(t *testing.T) {
 		t.Fatalf("expected invalid_vlan error, got %q", err.Error())
 	}
 }
+
+func TestPreflightCreateJail_ReportsEveryFailingSection(t *testing.T) {
+	db := testutil.NewSQLiteTestDB(
+		t,
+		&jailModels.Jail{},
+		&jailModels.JailBaseCache{},
+		&networkModels.Object{},
+		&networkModels.ObjectEntry{},
+		&networkModels.StandardSwitch{},
+		&networkModels.ManualSwitch{},
+		&utilitiesModels.Downloads{},
+	)
+
+	runner := newJailCreateTestZFSRunner(nil)
+	svc := newJailCreateTestService(db, runner, "tank")
+	svc.System = jailCreateTestSystemService{pools: []*gzfs.ZPool{{Name: "tank", Size: 1 << 30, Free: 1 << 20}}}
+
+	const ctid uint = 790
+	if err := db.Create(&jailModels.Jail{Name: "existing-790", CTID: ctid, Type: jailModels.JailTypeFreeBSD}).Error; err != nil {
+		t.Fatalf("failed to seed existing jail row: %v", err)
+	}
+
+	req := jailCreateRequest(ctid, "tank", "")
+	req.Release = "14.3-RELEASE"
+	req.SwitchName = ""
+	req.DevFSRuleset = "[devfsrules_custom=10]"
+
+	report := svc.PreflightCreateJail(context.Background(), req)
+	if report.Valid {
+		t.Fatal("expected an invalid report")
+	}
+
+	failed := map[string]string{}
+	for _, check := range report.Checks {
+		if !check.OK {
+			failed[check.Name] = check.Error
+		}
+	}
+
+	want := map[string]string{
+		"identity": "jail_with_ctid_already_exists",
+		"source":   "insufficient_pool_space",
+		"network":  "switch_name_required",
+		"options":  "devfs_ruleset_header_not_allowed",
+	}
+	for name, code := range want {
+		if !strings.HasPrefix(failed[name], code) {
+			t.Fatalf("check %s error = %q, want prefix %q (all failures: %v)", name, failed[name], code, failed)
+		}
+	}
+	if _, ok := failed["storage"]; ok {
+		t.Fatalf("storage check should pass, got %q", failed["storage"])
+	}
+	if !slices.Contains(report.Warnings, "release_base_not_cached: 14.3-RELEASE") {
+		t.Fatalf("warnings = %v, want release_base_not_cached", report.Warnings)
+	}
+}
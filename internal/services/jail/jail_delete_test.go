@@ -37,6 +37,7 @@ func newJailDeleteTestDB(t *testing.T) *gorm.DB {
 		&jailModels.JailHooks{},
 		&jailModels.JailStats{},
 		&jailModels.JailSnapshot{},
+		&jailModels.JailSnapshotSchedule{},
 		&networkModels.Object{},
 		&networkModels.ObjectEntry{},
 		&networkModels.ObjectResolution{},
@@ -121,6 +122,11 @@ func seedJailDeleteGraph(t *testing.T, db *gorm.DB, ctID uint, pool string, with
 	}).Error; err != nil {
 		t.Fatalf("seed jail snapshot: %v", err)
 	}
+	if err := db.Create(&jailModels.JailSnapshotSchedule{
+		JailID: jail.ID, CTID: ctID, Enabled: true, CronExpr: "0 * * * *", MaxKeep: 3,
+	}).Error; err != nil {
+		t.Fatalf("seed jail snapshot schedule: %v", err)
+	}
 
 	return jail.ID, macID
 }
@@ -169,6 +175,7 @@ func assertJailDeleteGraphAbsent(t *testing.T, db *gorm.DB, jailID, ctID uint) {
 		{&jailModels.JailHooks{}, "jid = ?", jailID},
 		{&jailModels.JailStats{}, "jid = ?", jailID},
 		{&jailModels.JailSnapshot{}, "jid = ?", jailID},
+		{&jailModels.JailSnapshotSchedule{}, "jid = ?", jailID},
 	}
 	for _, check := range checks {
 		if count := countJailDeleteRows(t, db, check.model, check.query, check.arg); count != 0 {
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package jail
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
+)
+
+// PreflightCreateJail runs every creation check against a spec and reports
+// all failures at once, plus warnings that would not block creation. Nothing
+// is provisioned and no release base is downloaded.
+func (s *Service) PreflightCreateJail(
+	ctx context.Context,
+	data jailServiceInterfaces.CreateJailRequest,
+) jailServiceInterfaces.CreateJailPreflightReport {
+	report := jailServiceInterfaces.CreateJailPreflightReport{
+		Valid:    true,
+		Checks:   []jailServiceInterfaces.CreateJailPreflightCheck{},
+		Warnings: []string{},
+	}
+
+	for _, check := range s.jailCreateChecks() {
+		result := jailServiceInterfaces.CreateJailPreflightCheck{Name: check.name, OK: true}
+		if err := check.run(ctx, data); err != nil {
+			result.OK = false
+			result.Error = err.Error()
+			report.Valid = false
+		}
+		report.Checks = append(report.Checks, result)
+	}
+
+	report.Warnings = append(report.Warnings, s.preflightCreateWarnings(data)...)
+	return report
+}
+
+func (s *Service) preflightCreateWarnings(data jailServiceInterfaces.CreateJailRequest) []string {
+	var warnings []string
+
+	if data.Release != "" && validateBaseRelease(data.Release) == nil {
+		record, err := s.getBaseCacheRecord(data.Release)
+		if err == nil && record.Status != baseCacheStatusReady {
+			warnings = append(warnings, fmt.Sprintf("release_base_not_cached: %s", data.Release))
+		}
+	}
+
+	switch strings.ToLower(data.SwitchName) {
+	case "none":
+		warnings = append(warnings, "no_network_configured")
+	case "inherit":
+		warnings = append(warnings, "jail_shares_host_network")
+	}
+
+	return warnings
+}
@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package jail
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal/config"
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	"github.com/alchemillahq/sylve/internal/logger"
+)
+
+const (
+	jailMountsBegin = "# BEGIN sylve mounts"
+	jailMountsEnd   = "# END sylve mounts"
+)
+
+func isValidJailMountType(t jailModels.JailMountType) bool {
+	switch t {
+	case jailModels.JailMountTypeNullFS,
+		jailModels.JailMountTypeTmpFS,
+		jailModels.JailMountTypeProcFS,
+		jailModels.JailMountTypeFdescFS,
+		jailModels.JailMountTypeLinProcFS,
+		jailModels.JailMountTypeLinSysFS:
+		return true
+	}
+	return false
+}
+
+// pathWithin reports whether path is root or below it. Both must be clean
+// absolute paths.
+func pathWithin(root, path string) bool {
+	if root == "/" {
+		return true
+	}
+	return path == root || strings.HasPrefix(path, root+"/")
+}
+
+// validateJailMountHostPath checks that a nullfs source is a directory and,
+// with symlinks resolved, sits under one of roots.
+func validateJailMountHostPath(hostPath string, roots []string) error {
+	if !filepath.IsAbs(hostPath) || filepath.Clean(hostPath) != hostPath {
+		return fmt.Errorf("host_path_not_absolute: %s", hostPath)
+	}
+
+	resolved, err := filepath.EvalSymlinks(hostPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("host_path_not_found: %s", hostPath)
+		}
+		return fmt.Errorf("failed_to_resolve_host_path: %w", err)
+	}
+	if info, err := os.Stat(resolved); err != nil || !info.IsDir() {
+		return fmt.Errorf("host_path_not_directory: %s", hostPath)
+	}
+
+	for _, root := range roots {
+		root = filepath.Clean(strings.TrimSpace(root))
+		if !filepath.IsAbs(root) {
+			continue
+		}
+		if resolvedRoot, err := filepath.EvalSymlinks(root); err == nil {
+			root = resolvedRoot
+		}
+		if pathWithin(root, resolved) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("host_path_outside_allowed_roots: %s", hostPath)
+}
+
+// validateJailMountTarget rejects jail paths that would land outside the
+// jail root. mount(8) runs on the host and follows symlinks, so any symlink
+// along an existing path inside the jail is refused outright.
+func validateJailMountTarget(jailRoot, jailPath string) error {
+	if !filepath.IsAbs(jailPath) || filepath.Clean(jailPath) != jailPath || jailPath == "/" {
+		return fmt.Errorf("invalid_jail_path: %s", jailPath)
+	}
+
+	current := jailRoot
+	for _, part := range strings.Split(strings.TrimPrefix(jailPath, "/"), "/") {
+		current = filepath.Join(current, part)
+		info, err := os.Lstat(current)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed_to_stat_jail_path: %w", err)
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("jail_path_is_symlink: %s", jailPath)
+		}
+	}
+
+	return nil
+}
+
+func validateJailMounts(mounts []jailModels.JailMount, roots []string, jailRoot string) error {
+	seen := make(map[string]struct{}, len(mounts))
+	for _, mount := range mounts {
+		if !isValidJailMountType(mount.Type) {
+			return fmt.Errorf("invalid_mount_type: %s", mount.Type)
+		}
+		if mount.Type == jailModels.JailMountTypeNullFS {
+			if err := validateJailMountHostPath(mount.HostPath, roots); err != nil {
+				return err
+			}
+		}
+		if err := validateJailMountTarget(jailRoot, mount.JailPath); err != nil {
+			return err
+		}
+		if _, ok := seen[mount.JailPath]; ok {
+			return fmt.Errorf("duplicate_jail_path: %s", mount.JailPath)
+		}
+		seen[mount.JailPath] = struct{}{}
+	}
+
+	return nil
+}
+
+// renderJailFstab renders mounts for a jail rooted at mountPoint, followed by
+// the jail's raw fstab entries.
+func renderJailFstab(mountPoint string, mounts []jailModels.JailMount, extra string) string {
+	var b strings.Builder
+	if len(mounts) > 0 {
+		b.WriteString(jailMountsBegin + "\n")
+		for _, mount := range mounts {
+			source := mount.HostPath
+			if mount.Type != jailModels.JailMountTypeNullFS {
+				source = string(mount.Type)
+			}
+			mode := "rw"
+			if mount.ReadOnly {
+				mode = "ro"
+			}
+			fmt.Fprintf(&b, "%s\t%s\t%s\t%s\t0\t0\n",
+				source, filepath.Join(mountPoint, mount.JailPath), mount.Type, mode)
+		}
+		b.WriteString(jailMountsEnd + "\n")
+	}
+
+	if extra = strings.TrimSpace(extra); extra != "" {
+		b.WriteString(extra + "\n")
+	}
+
+	return b.String()
+}
+
+func (s *Service) ModifyMounts(ctId uint, mounts []jailModels.JailMount) error {
+	allowed, leaseErr := s.canMutateProtectedJail(ctId)
+	if leaseErr != nil {
+		return fmt.Errorf("replication_lease_check_failed: %w", leaseErr)
+	}
+	if !allowed {
+		return fmt.Errorf("replication_lease_not_owned")
+	}
+
+	var jail jailModels.Jail
+	if err := s.DB.Where("ct_id = ?", ctId).First(&jail).Error; err != nil {
+		return fmt.Errorf("failed_to_get_jail: %w", err)
+	}
+
+	mountPoint, err := s.GetJailBaseMountPoint(ctId)
+	if err != nil {
+		return fmt.Errorf("failed_to_get_jail_mount_point: %w", err)
+	}
+
+	for i := range mounts {
+		mounts[i].HostPath = strings.TrimSpace(mounts[i].HostPath)
+		mounts[i].JailPath = strings.TrimSpace(mounts[i].JailPath)
+		if mounts[i].Type != jailModels.JailMountTypeNullFS {
+			mounts[i].HostPath = ""
+		}
+	}
+
+	if err := validateJailMounts(mounts, config.GetJailMountRoots(), mountPoint); err != nil {
+		return err
+	}
+
+	for _, mount := range mounts {
+		if err := os.MkdirAll(filepath.Join(mountPoint, mount.JailPath), 0755); err != nil {
+			return fmt.Errorf("failed_to_create_jail_mount_point: %w", err)
+		}
+	}
+
+	if err := s.writeJailFstab(ctId, renderJailFstab(mountPoint, mounts, jail.Fstab)); err != nil {
+		return err
+	}
+
+	if err := s.DB.
+		Model(&jailModels.Jail{}).
+		Where("ct_id = ?", ctId).
+		Select("mounts").
+		Updates(&jailModels.Jail{Mounts: mounts}).
+		Error; err != nil {
+		return fmt.Errorf("failed_to_update_mounts_in_db: %w", err)
+	}
+
+	if err := s.WriteJailJSON(ctId); err != nil {
+		logger.L.Error().Err(err).Msg("Failed to write jail JSON after mounts update")
+	}
+
+	return nil
+}
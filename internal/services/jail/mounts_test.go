@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package jail
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+)
+
+func TestRenderJailFstab(t *testing.T) {
+	mounts := []jailModels.JailMount{
+		{HostPath: "/mnt/media", JailPath: "/media", Type: jailModels.JailMountTypeNullFS, ReadOnly: true},
+		{JailPath: "/tmp", Type: jailModels.JailMountTypeTmpFS},
+	}
+
+	got := renderJailFstab("/zroot/sylve/jails/105", mounts, "  devfs /zroot/sylve/jails/105/dev devfs rw 0 0\n")
+	want := jailMountsBegin + "\n" +
+		"/mnt/media\t/zroot/sylve/jails/105/media\tnullfs\tro\t0\t0\n" +
+		"tmpfs\t/zroot/sylve/jails/105/tmp\ttmpfs\trw\t0\t0\n" +
+		jailMountsEnd + "\n" +
+		"devfs /zroot/sylve/jails/105/dev devfs rw 0 0\n"
+	if got != want {
+		t.Fatalf("unexpected fstab:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := renderJailFstab("/zroot/sylve/jails/105", nil, " \n"); got != "" {
+		t.Fatalf("expected empty fstab, got %q", got)
+	}
+}
+
+func TestValidateJailMounts(t *testing.T) {
+	base := t.TempDir()
+	allowed := filepath.Join(base, "allowed")
+	outside := filepath.Join(base, "outside")
+	jailRoot := filepath.Join(base, "jail")
+	for _, dir := range []string{filepath.Join(allowed, "data"), outside, filepath.Join(jailRoot, "etc")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(outside, filepath.Join(allowed, "escape")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("/etc", filepath.Join(jailRoot, "hostetc")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(allowed, "file"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	roots := []string{allowed}
+	nullfs := func(host, jail string) jailModels.JailMount {
+		return jailModels.JailMount{HostPath: host, JailPath: jail, Type: jailModels.JailMountTypeNullFS}
+	}
+
+	tests := []struct {
+		name    string
+		mounts  []jailModels.JailMount
+		wantErr string
+	}{
+		{name: "valid", mounts: []jailModels.JailMount{
+			nullfs(filepath.Join(allowed, "data"), "/data"),
+			{JailPath: "/tmp", Type: jailModels.JailMountTypeTmpFS},
+		}},
+		{name: "missing host path", mounts: []jailModels.JailMount{nullfs(filepath.Join(allowed, "nope"), "/data")}, wantErr: "host_path_not_found"},
+		{name: "outside roots", mounts: []jailModels.JailMount{nullfs(outside, "/data")}, wantErr: "host_path_outside_allowed_roots"},
+		{name: "symlink escapes root", mounts: []jailModels.JailMount{nullfs(filepath.Join(allowed, "escape"), "/data")}, wantErr: "host_path_outside_allowed_roots"},
+		{name: "relative host path", mounts: []jailModels.JailMount{nullfs("data", "/data")}, wantErr: "host_path_not_absolute"},
+		{name: "host path is a file", mounts: []jailModels.JailMount{nullfs(filepath.Join(allowed, "file"), "/data")}, wantErr: "host_path_not_directory"},
+		{name: "jail path escapes", mounts: []jailModels.JailMount{nullfs(filepath.Join(allowed, "data"), "/../etc")}, wantErr: "invalid_jail_path"},
+		{name: "jail root", mounts: []jailModels.JailMount{nullfs(filepath.Join(allowed, "data"), "/")}, wantErr: "invalid_jail_path"},
+		{name: "jail path through symlink", mounts: []jailModels.JailMount{nullfs(filepath.Join(allowed, "data"), "/hostetc/rc.d")}, wantErr: "jail_path_is_symlink"},
+		{name: "duplicate jail path", mounts: []jailModels.JailMount{
+			nullfs(filepath.Join(allowed, "data"), "/data"),
+			{JailPath: "/data", Type: jailModels.JailMountTypeTmpFS},
+		}, wantErr: "duplicate_jail_path"},
+		{name: "unknown type", mounts: []jailModels.JailMount{{JailPath: "/data", Type: "ext4"}}, wantErr: "invalid_mount_type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateJailMounts(tt.mounts, roots, jailRoot)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected %s, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
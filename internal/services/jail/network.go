@@ -250,6 +250,11 @@ func (s *Service) AddNetwork(req jailServiceInterfaces.AddJailNetworkRequest) er
 		defaultGateway = *req.DefaultGateway
 	}
 
+	fib := uint(0)
+	if req.FIB != nil {
+		fib = *req.FIB
+	}
+
 	vlan := 0
 	if req.VLAN != nil {
 		vlan = *req.VLAN
@@ -282,6 +287,12 @@ func (s *Service) AddNetwork(req jailServiceInterfaces.AddJailNetworkRequest) er
 		return fmt.Errorf("cannot_add_network_when_inheriting_network")
 	}
 
+	routing := append([]jailModels.Network{}, jail.Networks...)
+	routing = append(routing, jailModels.Network{Name: req.Name, DefaultGateway: defaultGateway, FIB: fib})
+	if err := validateJailRouting(routing); err != nil {
+		return err
+	}
+
 	switchId := uint(0)
 	switchType := ""
 	dbSwName := ""
@@ -452,6 +463,8 @@ func (s *Service) AddNetwork(req jailServiceInterfaces.AddJailNetworkRequest) er
 	network.Name = req.Name
 	network.JailID = jail.ID
 	network.VLAN = &vlan
+	network.DefaultGateway = defaultGateway
+	network.FIB = fib
 
 	if err := s.DB.Create(&network).Error; err != nil {
 		return fmt.Errorf("failed_to_create_network: %w", err)
@@ -691,6 +704,17 @@ func (s *Service) SyncNetwork(ctId uint, jail jailModels.Jail) error {
 						return fmt.Errorf("failed to get bridge name: %w", err)
 					}
 
+					// Jumbo frames need both epair ends raised before the host end
+					// joins the bridge, or the bridge refuses the member.
+					switchMTU, err := s.NetworkService.GetSwitchMTUByIDType(n.SwitchID, n.SwitchType)
+					if err != nil {
+						return fmt.Errorf("failed to get switch mtu: %w", err)
+					}
+					if switchMTU > 0 && switchMTU != 1500 {
+						preStartBuilder.WriteString(fmt.Sprintf("ifconfig %s mtu %d\n", epairA, switchMTU))
+						preStartBuilder.WriteString(fmt.Sprintf("ifconfig %s mtu %d\n", epairB, switchMTU))
+					}
+
 					if n.VLAN != nil && *n.VLAN > 0 {
 						vlanIface := fmt.Sprintf("%s.%d", epairA, *n.VLAN)
 						preStartBuilder.WriteString(fmt.Sprintf("if ! ifconfig %s > /dev/null 2>&1; then\n", vlanIface))
@@ -712,6 +736,10 @@ func (s *Service) SyncNetwork(ctId uint, jail jailModels.Jail) error {
 					hasIPv4Static := n.IPv4ID != nil && *n.IPv4ID > 0 && n.IPv4GwID != nil && *n.IPv4GwID > 0
 					hasIPv6Static := n.IPv6ID != nil && *n.IPv6ID > 0 && n.IPv6GwID != nil && *n.IPv6GwID > 0
 
+					if n.FIB > 0 {
+						postStartBuilder.WriteString(fmt.Sprintf("ifconfig -j %s %s fib %d\n", ctidHash, epairB, n.FIB))
+					}
+
 					if hasIPv4Static {
 						ipv4, err := s.NetworkService.GetObjectEntryByID(*n.IPv4ID)
 						if err != nil {
@@ -726,7 +754,7 @@ func (s *Service) SyncNetwork(ctId uint, jail jailModels.Jail) error {
 								return fmt.Errorf("failed to get ipv4 gateway: %w", err)
 							}
 
-							postStartBuilder.WriteString(fmt.Sprintf("route -j %s add default %s\n", ctidHash, ipv4Gw))
+							postStartBuilder.WriteString(jailDefaultRouteCommand(ctidHash, ipv4Gw, false, n.FIB) + "\n")
 						}
 					}
 
@@ -744,7 +772,7 @@ func (s *Service) SyncNetwork(ctId uint, jail jailModels.Jail) error {
 								return fmt.Errorf("failed to get ipv6 gateway: %w", err)
 							}
 
-							postStartBuilder.WriteString(fmt.Sprintf("route -6 -j %s add default %s\n", ctidHash, ipv6Gw))
+							postStartBuilder.WriteString(jailDefaultRouteCommand(ctidHash, ipv6Gw, true, n.FIB) + "\n")
 						}
 					}
 
@@ -752,8 +780,13 @@ func (s *Service) SyncNetwork(ctId uint, jail jailModels.Jail) error {
 						postStartBuilder.WriteString("\n")
 					}
 				} else {
+					// The interface FIB rides on the first ifconfig line
+					// written for it.
+					ipv6FIB := n.FIB
+
 					if n.DHCP {
-						rcConfLines = append(rcConfLines, fmt.Sprintf("ifconfig_%s_%sb=\"SYNCDHCP\"", ctidHash, networkId))
+						rcConfLines = append(rcConfLines, fmt.Sprintf("ifconfig_%s_%sb=\"%s\"", ctidHash, networkId, withJailFIB("SYNCDHCP", n.FIB)))
+						ipv6FIB = 0
 					} else if n.IPv4ID != nil && *n.IPv4ID > 0 && n.IPv4GwID != nil && *n.IPv4GwID > 0 {
 						ipv4, err := s.NetworkService.GetObjectEntryByID(*n.IPv4ID)
 						if err != nil {
@@ -768,15 +801,16 @@ func (s *Service) SyncNetwork(ctId uint, jail jailModels.Jail) error {
 							return fmt.Errorf("failed to split ipv4 address and mask: %w", err)
 						}
 
-						rcConfLines = append(rcConfLines, fmt.Sprintf("ifconfig_%s_%sb=\"inet %s netmask %s\"", ctidHash, networkId, ip, mask))
+						rcConfLines = append(rcConfLines, fmt.Sprintf("ifconfig_%s_%sb=\"%s\"", ctidHash, networkId, withJailFIB(fmt.Sprintf("inet %s netmask %s", ip, mask), n.FIB)))
+						ipv6FIB = 0
 
 						if n.DefaultGateway {
-							rcConfLines = append(rcConfLines, fmt.Sprintf("defaultrouter=\"%s\"", ipv4Gw))
+							rcConfLines = append(rcConfLines, jailDefaultRouterRCLine(ipv4Gw, false, n.FIB))
 						}
 					}
 
 					if n.SLAAC {
-						rcConfLines = append(rcConfLines, fmt.Sprintf("ifconfig_%s_%sb_ipv6=\"inet6 accept_rtadv\"", ctidHash, networkId))
+						rcConfLines = append(rcConfLines, fmt.Sprintf("ifconfig_%s_%sb_ipv6=\"%s\"", ctidHash, networkId, withJailFIB("inet6 accept_rtadv", ipv6FIB)))
 						rcConfLines = append(rcConfLines, "rtsold_enable=\"YES\"")
 					} else if n.IPv6ID != nil && *n.IPv6ID > 0 && n.IPv6GwID != nil && *n.IPv6GwID > 0 {
 						ipv6, err := s.NetworkService.GetObjectEntryByID(*n.IPv6ID)
@@ -788,10 +822,12 @@ func (s *Service) SyncNetwork(ctId uint, jail jailModels.Jail) error {
 							return fmt.Errorf("failed to get ipv6 gateway: %w", err)
 						}
 
-						rcConfLines = append(rcConfLines, fmt.Sprintf("ifconfig_%s_%sb_ipv6=\"inet6 %s\"", ctidHash, networkId, ipv6))
+						rcConfLines = append(rcConfLines, fmt.Sprintf("ifconfig_%s_%sb_ipv6=\"%s\"", ctidHash, networkId, withJailFIB("inet6 "+ipv6, ipv6FIB)))
 						if n.DefaultGateway {
-							rcConfLines = append(rcConfLines, fmt.Sprintf("ipv6_defaultrouter=\"%s\"", ipv6Gw))
+							rcConfLines = append(rcConfLines, jailDefaultRouterRCLine(ipv6Gw, true, n.FIB))
 						}
+					} else if ipv6FIB > 0 {
+						rcConfLines = append(rcConfLines, fmt.Sprintf("ifconfig_%s_%sb=\"fib %d\"", ctidHash, networkId, ipv6FIB))
 					}
 				}
 			}
@@ -868,6 +904,10 @@ func (s *Service) SyncNetwork(ctId uint, jail jailModels.Jail) error {
 		return err
 	}
 
+	if err := s.SyncJailEtcFiles(ctId); err != nil {
+		logger.L.Error().Err(err).Uint("ctid", ctId).Msg("Failed to sync jail /etc files after network update")
+	}
+
 	err = s.WriteJailJSON(ctId)
 	if err != nil {
 		logger.L.Error().Err(err).Msg("Failed to write jail JSON after network update")
@@ -918,6 +958,11 @@ func (s *Service) EditNetwork(req jailServiceInterfaces.EditJailNetworkRequest)
 		defaultGateway = *req.DefaultGateway
 	}
 
+	fib := uint(0)
+	if req.FIB != nil {
+		fib = *req.FIB
+	}
+
 	vlan := 0
 	if req.VLAN != nil {
 		vlan = *req.VLAN
@@ -959,6 +1004,19 @@ func (s *Service) EditNetwork(req jailServiceInterfaces.EditJailNetworkRequest)
 		return fmt.Errorf("cannot_edit_network_when_inheriting_network")
 	}
 
+	routing := make([]jailModels.Network, 0, len(jail.Networks))
+	for _, n := range jail.Networks {
+		if n.ID == network.ID {
+			n.Name = req.Name
+			n.DefaultGateway = defaultGateway
+			n.FIB = fib
+		}
+		routing = append(routing, n)
+	}
+	if err := validateJailRouting(routing); err != nil {
+		return err
+	}
+
 	switchId := uint(0)
 	switchType := ""
 	dbSwName := ""
@@ -995,6 +1053,7 @@ func (s *Service) EditNetwork(req jailServiceInterfaces.EditJailNetworkRequest)
 	network.DHCP = false
 	network.SLAAC = false
 	network.DefaultGateway = defaultGateway
+	network.FIB = fib
 
 	if macId == 0 {
 		if req.MACRaw != "" {
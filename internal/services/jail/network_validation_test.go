@@ -123,6 +123,18 @@ func (f *jailNetworkValidationFakeNetworkService) ReconcileManagedRoutes() error
 	return nil
 }
 
+func (f *jailNetworkValidationFakeNetworkService) ReconcileHostInterfaces() error {
+	return nil
+}
+
+func (f *jailNetworkValidationFakeNetworkService) RestoreSRIOV() error {
+	return nil
+}
+
+func (f *jailNetworkValidationFakeNetworkService) GetSwitchMTUByIDType(_ uint, _ string) (int, error) {
+	return 0, nil
+}
+
 func (f *jailNetworkValidationFakeNetworkService) RegisterOnJailObjectUpdateCallback(_ func(jailIDs []uint)) {
 }
 
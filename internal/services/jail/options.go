@@ -88,6 +88,40 @@ func (s *Service) ModifyFstab(ctId uint, fstab string) error {
 		return fmt.Errorf("replication_lease_not_owned")
 	}
 
+	var jail jailModels.Jail
+	if err := s.DB.Where("ct_id = ?", ctId).First(&jail).Error; err != nil {
+		return fmt.Errorf("failed_to_get_jail: %w", err)
+	}
+
+	mountPoint, err := s.GetJailBaseMountPoint(ctId)
+	if err != nil {
+		return fmt.Errorf("failed_to_get_jail_mount_point: %w", err)
+	}
+
+	if err := s.writeJailFstab(ctId, renderJailFstab(mountPoint, jail.Mounts, fstab)); err != nil {
+		return err
+	}
+
+	err = s.DB.
+		Model(&jailModels.Jail{}).
+		Where("ct_id = ?", ctId).
+		Update("fstab", fstab).
+		Error
+	if err != nil {
+		return fmt.Errorf("failed_to_update_fstab_in_db: %w", err)
+	}
+
+	err = s.WriteJailJSON(ctId)
+	if err != nil {
+		logger.L.Error().Err(err).Msg("Failed to write jail JSON after fstab update")
+	}
+
+	return nil
+}
+
+// writeJailFstab writes the jail's fstab file and points mount.fstab at it,
+// or removes both when content is empty.
+func (s *Service) writeJailFstab(ctId uint, content string) error {
 	jailsPath, err := config.GetJailsPath()
 	if err != nil {
 		return fmt.Errorf("failed_to_get_jails_path: %w", err)
@@ -107,7 +141,7 @@ func (s *Service) ModifyFstab(ctId uint, fstab string) error {
 
 	for _, line := range lines {
 		if strings.Contains(line, "mount.fstab") {
-			if fstab != "" {
+			if content != "" {
 				newLines = append(newLines, fmt.Sprintf(`	mount.fstab = "%s";`, fstabPath))
 				found = true
 			}
@@ -116,12 +150,12 @@ func (s *Service) ModifyFstab(ctId uint, fstab string) error {
 		newLines = append(newLines, line)
 	}
 
-	if fstab == "" {
+	if content == "" {
 		if err := utils.DeleteFileIfExists(fstabPath); err != nil {
 			return fmt.Errorf("failed_to_delete_fstab_file: %w", err)
 		}
 	} else {
-		if err := utils.AtomicWriteFile(fstabPath, []byte(fstab), 0644); err != nil {
+		if err := utils.AtomicWriteFile(fstabPath, []byte(content), 0644); err != nil {
 			return fmt.Errorf("failed_to_write_fstab_file: %w", err)
 		}
 
@@ -141,20 +175,6 @@ func (s *Service) ModifyFstab(ctId uint, fstab string) error {
 		return fmt.Errorf("failed_to_save_jail_config: %w", err)
 	}
 
-	err = s.DB.
-		Model(&jailModels.Jail{}).
-		Where("ct_id = ?", ctId).
-		Update("fstab", fstab).
-		Error
-	if err != nil {
-		return fmt.Errorf("failed_to_update_fstab_in_db: %w", err)
-	}
-
-	err = s.WriteJailJSON(ctId)
-	if err != nil {
-		logger.L.Error().Err(err).Msg("Failed to write jail JSON after fstab update")
-	}
-
 	return nil
 }
 
@@ -167,6 +187,16 @@ func (s *Service) ModifyResolvConf(ctId uint, resolvConf string) error {
 		return fmt.Errorf("replication_lease_not_owned")
 	}
 
+	var managed int64
+	if err := s.DB.Model(&jailModels.Jail{}).
+		Where("ct_id = ? AND manage_etc_files = ?", ctId, true).
+		Count(&managed).Error; err != nil {
+		return fmt.Errorf("failed_to_fetch_jail: %w", err)
+	}
+	if managed > 0 {
+		return fmt.Errorf("resolv_conf_managed_by_sylve")
+	}
+
 	if strings.TrimSpace(resolvConf) != "" {
 		mountPoint, err := s.GetJailBaseMountPoint(ctId)
 		if err != nil {
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package jail
+
+import (
+	"fmt"
+
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+)
+
+// maxJailFIBs matches the net.fibs value raised at startup; every VNET jail
+// gets its own copy of each routing table.
+const maxJailFIBs = 8
+
+// validateJailRouting checks the routing profile of a jail's interfaces. Each
+// interface may pin itself to a FIB, and every FIB may carry at most one
+// default gateway, so a jail straddling management and data networks can keep
+// a separate default route per network.
+func validateJailRouting(networks []jailModels.Network) error {
+	defaults := make(map[uint]string)
+
+	for _, n := range networks {
+		if n.FIB >= maxJailFIBs {
+			return fmt.Errorf("invalid_jail_fib: %d", n.FIB)
+		}
+
+		if !n.DefaultGateway {
+			continue
+		}
+
+		if other, ok := defaults[n.FIB]; ok {
+			return fmt.Errorf("multiple_default_gateways_in_fib: fib %d (%s, %s)", n.FIB, other, n.Name)
+		}
+		defaults[n.FIB] = n.Name
+	}
+
+	return nil
+}
+
+// withJailFIB appends the interface FIB to an rc.conf ifconfig value so
+// traffic arriving on the interface is routed through its own table.
+func withJailFIB(value string, fib uint) string {
+	if fib == 0 {
+		return value
+	}
+	return fmt.Sprintf("%s fib %d", value, fib)
+}
+
+// jailDefaultRouterRCLine renders the rc.conf default route for a FIB. FIB 0
+// keeps the classic defaultrouter knobs; other tables use the _fibN variants
+// understood by rc.d/routing.
+func jailDefaultRouterRCLine(gateway string, ipv6 bool, fib uint) string {
+	key := "defaultrouter"
+	if ipv6 {
+		key = "ipv6_defaultrouter"
+	}
+	if fib > 0 {
+		key = fmt.Sprintf("%s_fib%d", key, fib)
+	}
+	return fmt.Sprintf("%s=\"%s\"", key, gateway)
+}
+
+// jailDefaultRouteCommand renders the host-side command that installs a
+// default route inside a jail, used where rc.conf is not available.
+func jailDefaultRouteCommand(ctidHash string, gateway string, ipv6 bool, fib uint) string {
+	cmd := "route"
+	if ipv6 {
+		cmd += " -6"
+	}
+	cmd += fmt.Sprintf(" -j %s add default %s", ctidHash, gateway)
+	if fib > 0 {
+		cmd += fmt.Sprintf(" -fib %d", fib)
+	}
+	return cmd
+}
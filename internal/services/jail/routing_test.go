@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package jail
+
+import (
+	"strings"
+	"testing"
+
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+)
+
+func TestValidateJailRouting(t *testing.T) {
+	tests := []struct {
+		name     string
+		networks []jailModels.Network
+		wantErr  string
+	}{
+		{
+			name: "default gateway per fib",
+			networks: []jailModels.Network{
+				{Name: "mgmt", DefaultGateway: true},
+				{Name: "data", DefaultGateway: true, FIB: 1},
+				{Name: "backup", FIB: 1},
+			},
+		},
+		{
+			name: "two defaults in one fib",
+			networks: []jailModels.Network{
+				{Name: "mgmt", DefaultGateway: true},
+				{Name: "data", DefaultGateway: true},
+			},
+			wantErr: "multiple_default_gateways_in_fib",
+		},
+		{
+			name:     "fib out of range",
+			networks: []jailModels.Network{{Name: "data", FIB: maxJailFIBs}},
+			wantErr:  "invalid_jail_fib",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateJailRouting(tc.networks)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestJailRoutingRendering(t *testing.T) {
+	if got := withJailFIB("SYNCDHCP", 0); got != "SYNCDHCP" {
+		t.Fatalf("unexpected fib 0 value %q", got)
+	}
+	if got := withJailFIB("inet 10.0.0.2 netmask 255.255.255.0", 2); got != "inet 10.0.0.2 netmask 255.255.255.0 fib 2" {
+		t.Fatalf("unexpected fib value %q", got)
+	}
+
+	if got := jailDefaultRouterRCLine("10.0.0.1", false, 0); got != `defaultrouter="10.0.0.1"` {
+		t.Fatalf("unexpected fib 0 router %q", got)
+	}
+	if got := jailDefaultRouterRCLine("fd00::1", true, 3); got != `ipv6_defaultrouter_fib3="fd00::1"` {
+		t.Fatalf("unexpected fib 3 router %q", got)
+	}
+
+	if got := jailDefaultRouteCommand("abc", "10.0.0.1", false, 0); got != "route -j abc add default 10.0.0.1" {
+		t.Fatalf("unexpected route command %q", got)
+	}
+	if got := jailDefaultRouteCommand("abc", "fd00::1", true, 1); got != "route -6 -j abc add default fd00::1 -fib 1" {
+		t.Fatalf("unexpected fib route command %q", got)
+	}
+}
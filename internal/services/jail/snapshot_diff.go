@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package jail
+
+import (
+	"context"
+	"fmt"
+
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	"github.com/alchemillahq/sylve/pkg/zfsdiff"
+)
+
+// DiffJailSnapshot runs `zfs diff` on the jail root dataset between a
+// snapshot and the live dataset, or a later snapshot when toSnapshotID is
+// non-zero.
+func (s *Service) DiffJailSnapshot(
+	ctx context.Context,
+	ctID uint,
+	snapshotID uint,
+	toSnapshotID uint,
+) (*zfsdiff.DatasetDiff, error) {
+	if ctID == 0 || snapshotID == 0 {
+		return nil, fmt.Errorf("invalid_request")
+	}
+
+	var from jailModels.JailSnapshot
+	if err := s.DB.
+		Where("ct_id = ? AND id = ?", ctID, snapshotID).
+		First(&from).Error; err != nil {
+		return nil, fmt.Errorf("snapshot_not_found: %w", err)
+	}
+
+	diff := zfsdiff.DatasetDiff{
+		Dataset: from.RootDataset,
+		From:    from.RootDataset + "@" + from.SnapshotName,
+		To:      from.RootDataset,
+	}
+
+	if toSnapshotID != 0 {
+		if toSnapshotID == snapshotID {
+			return nil, fmt.Errorf("diff_snapshots_must_differ")
+		}
+
+		var to jailModels.JailSnapshot
+		if err := s.DB.
+			Where("ct_id = ? AND id = ?", ctID, toSnapshotID).
+			First(&to).Error; err != nil {
+			return nil, fmt.Errorf("target_snapshot_not_found: %w", err)
+		}
+		if to.RootDataset != from.RootDataset {
+			return nil, fmt.Errorf("snapshots_on_different_datasets")
+		}
+		if to.CreatedAt.Before(from.CreatedAt) {
+			return nil, fmt.Errorf("target_snapshot_older_than_source")
+		}
+		diff.To = to.RootDataset + "@" + to.SnapshotName
+	}
+
+	changes, truncated, err := zfsdiff.Diff(ctx, diff.From, diff.To, zfsdiff.DefaultLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed_to_diff_jail_snapshot: %w", err)
+	}
+	diff.Changes = changes
+	diff.Truncated = truncated
+
+	return &diff, nil
+}
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package jail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	"github.com/alchemillahq/sylve/internal/services/snapshotschedule"
+	"gorm.io/gorm"
+)
+
+const jailSnapshotScheduleQueueName = "jail-snapshot-schedule-run"
+
+type JailSnapshotScheduleInput = snapshotschedule.Input
+
+func (s *Service) GetJailSnapshotSchedule(ctID uint) (*jailModels.JailSnapshotSchedule, error) {
+	if ctID == 0 {
+		return nil, fmt.Errorf("invalid_ct_id")
+	}
+
+	var schedule jailModels.JailSnapshotSchedule
+	if err := s.DB.Where("ct_id = ?", ctID).First(&schedule).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed_to_get_jail_snapshot_schedule: %w", err)
+	}
+
+	return &schedule, nil
+}
+
+// SetJailSnapshotSchedule creates or replaces the snapshot schedule of a jail.
+func (s *Service) SetJailSnapshotSchedule(ctID uint, input JailSnapshotScheduleInput) (*jailModels.JailSnapshotSchedule, error) {
+	if ctID == 0 {
+		return nil, fmt.Errorf("invalid_ct_id")
+	}
+	if err := snapshotschedule.ValidateInput(input); err != nil {
+		return nil, err
+	}
+
+	var jail jailModels.Jail
+	if err := s.DB.Select("id", "ct_id").Where("ct_id = ?", ctID).First(&jail).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_get_jail: %w", err)
+	}
+
+	var schedule jailModels.JailSnapshotSchedule
+	if err := s.DB.Where("jid = ?", jail.ID).First(&schedule).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed_to_get_jail_snapshot_schedule: %w", err)
+		}
+		schedule = jailModels.JailSnapshotSchedule{JailID: jail.ID, CTID: jail.CTID}
+	}
+
+	cronExpr := strings.TrimSpace(input.CronExpr)
+	if schedule.CronExpr != cronExpr || !input.Enabled {
+		schedule.NextRunAt = nil
+	}
+
+	schedule.CronExpr = cronExpr
+	schedule.MaxKeep = input.MaxKeep
+	schedule.NamePattern = strings.TrimSpace(input.NamePattern)
+	schedule.Enabled = input.Enabled
+
+	if err := s.DB.Save(&schedule).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_save_jail_snapshot_schedule: %w", err)
+	}
+
+	return &schedule, nil
+}
+
+// DeleteJailSnapshotSchedule removes a jail's schedule. Snapshots it already
+// took are kept and can be deleted like manual ones.
+func (s *Service) DeleteJailSnapshotSchedule(ctID uint) error {
+	if ctID == 0 {
+		return fmt.Errorf("invalid_ct_id")
+	}
+
+	result := s.DB.Where("ct_id = ?", ctID).Delete(&jailModels.JailSnapshotSchedule{})
+	if result.Error != nil {
+		return fmt.Errorf("failed_to_delete_jail_snapshot_schedule: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("jail_snapshot_schedule_not_found")
+	}
+
+	return nil
+}
+
+// snapshotScheduler runs jail schedules through the shared scheduler; the
+// jail-specific part is resolving a schedule to its jail's snapshot hooks.
+func (s *Service) snapshotScheduler() *snapshotschedule.Scheduler {
+	return &snapshotschedule.Scheduler{
+		DB:           s.DB,
+		Model:        &jailModels.JailSnapshotSchedule{},
+		QueueName:    jailSnapshotScheduleQueueName,
+		Kind:         "jail",
+		Lookup:       s.snapshotScheduleGuest,
+		Reservations: &s.snapshotSchedules,
+	}
+}
+
+func (s *Service) snapshotScheduleGuest(scheduleID uint) (*snapshotschedule.Guest, error) {
+	var schedule jailModels.JailSnapshotSchedule
+	if err := s.DB.First(&schedule, scheduleID).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_get_jail_snapshot_schedule: %w", err)
+	}
+
+	var jail jailModels.Jail
+	if err := s.DB.Select("id", "ct_id", "name").Where("id = ?", schedule.JailID).First(&jail).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_get_jail: %w", err)
+	}
+
+	return &snapshotschedule.Guest{
+		Tokens: []string{"{jail}", jail.Name, "{ctid}", strconv.FormatUint(uint64(jail.CTID), 10)},
+		Create: func(ctx context.Context, name string) error {
+			_, err := s.createJailSnapshot(ctx, jail.CTID, name, "Scheduled snapshot", &scheduleID)
+			return err
+		},
+		Snapshots: func() ([]uint, error) {
+			var ids []uint
+			err := s.DB.Model(&jailModels.JailSnapshot{}).
+				Where("jid = ? AND schedule_id = ?", jail.ID, scheduleID).
+				Order("created_at ASC, id ASC").
+				Pluck("id", &ids).Error
+			return ids, err
+		},
+		Delete: func(ctx context.Context, snapshotID uint) error {
+			return s.DeleteJailSnapshot(ctx, jail.CTID, snapshotID)
+		},
+	}, nil
+}
+
+func (s *Service) RegisterJobs() {
+	s.snapshotScheduler().RegisterJobs()
+}
+
+func (s *Service) StartSnapshotScheduler(ctx context.Context) {
+	s.snapshotScheduler().Start(ctx)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/alchemillahq/sylve/internal/config"
 	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
 	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/internal/logger"
 	"gorm.io/gorm"
 )
@@ -56,6 +57,18 @@ func (s *Service) CreateJailSnapshot(
 	ctID uint,
 	name string,
 	description string,
+) (*jailModels.JailSnapshot, error) {
+	return s.createJailSnapshot(ctx, ctID, name, description, nil)
+}
+
+// createJailSnapshot backs CreateJailSnapshot; scheduleID is set when a
+// snapshot schedule takes the snapshot.
+func (s *Service) createJailSnapshot(
+	ctx context.Context,
+	ctID uint,
+	name string,
+	description string,
+	scheduleID *uint,
 ) (*jailModels.JailSnapshot, error) {
 	s.crudMutex.Lock()
 	defer s.crudMutex.Unlock()
@@ -131,6 +144,7 @@ func (s *Service) CreateJailSnapshot(
 		JailID:           jail.ID,
 		CTID:             jail.CTID,
 		ParentSnapshotID: parentID,
+		ScheduleID:       scheduleID,
 		Name:             name,
 		Description:      description,
 		SnapshotName:     snapshotName,
@@ -378,6 +392,7 @@ func (s *Service) restoreJailDatabaseFromSnapshotJSON(ctID uint, mountPoint stri
 		CPUSet:            restored.CPUSet,
 		Memory:            restored.Memory,
 		DevFSRuleset:      restored.DevFSRuleset,
+		Mounts:            restored.Mounts,
 		Fstab:             restored.Fstab,
 		CleanEnvironment:  restored.CleanEnvironment,
 		AdditionalOptions: restored.AdditionalOptions,
@@ -403,6 +418,7 @@ func (s *Service) restoreJailDatabaseFromSnapshotJSON(ctID uint, mountPoint stri
 			"CPUSet",
 			"Memory",
 			"DevFSRuleset",
+			"Mounts",
 			"Fstab",
 			"CleanEnvironment",
 			"AdditionalOptions",
@@ -549,8 +565,8 @@ func resolveJailRootDataset(jail *jailModels.Jail) (string, string, error) {
 		return "", "", fmt.Errorf("jail_base_pool_not_found")
 	}
 
-	rootDataset := fmt.Sprintf("%s/sylve/jails/%d", basePool, jail.CTID)
-	mountPoint := fmt.Sprintf("/%s/sylve/jails/%d", basePool, jail.CTID)
+	rootDataset := fmt.Sprintf("%s/%d", layout.JailsDataset(basePool), jail.CTID)
+	mountPoint := fmt.Sprintf("/%s/%d", layout.JailsDataset(basePool), jail.CTID)
 	return rootDataset, mountPoint, nil
 }
 
@@ -0,0 +1,500 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package jail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/config"
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	storageMigrationRun      = utils.RunCommandWithContext
+	storageMigrationSendRecv = sendRecvLocalDataset
+)
+
+type jailStorageMove struct {
+	storage       jailModels.Storage
+	sourceDataset string
+	targetDataset string
+	sourceMount   string
+	targetMount   string
+	// nested moves travel inside the base dataset's replication stream.
+	nested bool
+}
+
+// MigrateJailStorage moves a jail's base dataset and any extra storages on
+// other datasets to targetPool with a local zfs send/recv. The jail is
+// stopped for the copy so a single full stream is consistent, and restarted
+// afterwards if it was running. The source is only destroyed once records,
+// config and fstab point at the new datasets.
+func (s *Service) MigrateJailStorage(
+	ctx context.Context,
+	ctID uint,
+	req jailServiceInterfaces.MigrateJailStorageRequest,
+) (*jailServiceInterfaces.JailStorageMigrationResult, error) {
+	s.crudMutex.Lock()
+	defer s.crudMutex.Unlock()
+
+	targetPool := strings.TrimSpace(req.TargetPool)
+	if ctID == 0 || targetPool == "" || strings.ContainsAny(targetPool, "/@# ") {
+		return nil, fmt.Errorf("invalid_request")
+	}
+
+	allowed, leaseErr := s.canMutateProtectedJail(ctID)
+	if leaseErr != nil {
+		return nil, fmt.Errorf("replication_lease_check_failed: %w", leaseErr)
+	}
+	if !allowed {
+		return nil, fmt.Errorf("replication_lease_not_owned")
+	}
+
+	jail, err := s.GetJailByCTID(ctID)
+	if err != nil {
+		return nil, fmt.Errorf("jail_not_found: %w", err)
+	}
+
+	sourceRoot, _, err := resolveJailRootDataset(jail)
+	if err != nil {
+		return nil, err
+	}
+	sourcePool := strings.SplitN(sourceRoot, "/", 2)[0]
+	if sourcePool == targetPool {
+		return nil, fmt.Errorf("jail_storage_already_on_pool: %s", targetPool)
+	}
+
+	if _, err := s.GZFS.ZFS.Get(ctx, targetPool, false); err != nil {
+		return nil, fmt.Errorf("target_pool_not_found: %w", err)
+	}
+
+	moves, err := s.planJailStorageMoves(ctx, jail, sourceRoot, targetPool)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkJailStorageMigrationSpace(ctx, moves, targetPool); err != nil {
+		return nil, err
+	}
+
+	result := &jailServiceInterfaces.JailStorageMigrationResult{
+		CTID:       ctID,
+		SourcePool: sourcePool,
+		TargetPool: targetPool,
+		Moves:      make([]jailServiceInterfaces.JailStorageMove, 0, len(moves)),
+		Warnings:   []string{},
+	}
+	for _, move := range moves {
+		result.Moves = append(result.Moves, jailServiceInterfaces.JailStorageMove{
+			StorageID:     move.storage.ID,
+			SourceDataset: move.sourceDataset,
+			TargetDataset: move.targetDataset,
+		})
+	}
+
+	wasActive, err := s.IsJailActive(ctID)
+	if err != nil {
+		return nil, fmt.Errorf("failed_to_get_jail_state: %w", err)
+	}
+	if wasActive {
+		if err := s.JailAction(int(ctID), "stop"); err != nil {
+			return nil, fmt.Errorf("failed_to_stop_jail_before_storage_migration: %w", err)
+		}
+		if err := s.waitForJailActiveState(ctID, false, 30*time.Second); err != nil {
+			return nil, err
+		}
+	}
+
+	// Whether or not the move succeeded the jail has a consistent root to
+	// start from: the new one on success, the untouched source otherwise.
+	defer func() {
+		if !wasActive {
+			return
+		}
+		if err := s.JailAction(int(ctID), "start"); err != nil {
+			logger.L.Warn().Err(err).Uint("ctid", ctID).Msg("failed_to_start_jail_after_storage_migration")
+			result.Warnings = append(result.Warnings, "jail_restart_failed: "+err.Error())
+			return
+		}
+		if err := s.waitForJailActiveState(ctID, true, 45*time.Second); err != nil {
+			result.Warnings = append(result.Warnings, err.Error())
+			return
+		}
+		result.Restarted = true
+	}()
+
+	snapshotName := fmt.Sprintf("sylve-storage-migrate-%d", time.Now().Unix())
+	received := make([]string, 0, len(moves))
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Minute)
+		defer cancel()
+		for i := len(received) - 1; i >= 0; i-- {
+			if _, err := storageMigrationRun(cleanupCtx, "zfs", "destroy", "-r", received[i]); err != nil {
+				logger.L.Warn().Err(err).Str("dataset", received[i]).Msg("failed_to_destroy_partial_jail_storage_copy")
+			}
+		}
+		for _, move := range moves {
+			if !move.nested {
+				_, _ = storageMigrationRun(cleanupCtx, "zfs", "destroy", "-r", move.sourceDataset+"@"+snapshotName)
+			}
+		}
+	}()
+
+	for _, move := range moves {
+		if move.nested {
+			continue
+		}
+		if _, err := storageMigrationRun(ctx, "zfs", "create", "-p", filepath.Dir(move.targetDataset)); err != nil {
+			return nil, fmt.Errorf("failed_to_create_target_parent: %w", err)
+		}
+		if _, err := storageMigrationRun(ctx, "zfs", "snapshot", "-r", move.sourceDataset+"@"+snapshotName); err != nil {
+			return nil, fmt.Errorf("failed_to_snapshot_jail_storage: %w", err)
+		}
+		if err := storageMigrationSendRecv(ctx, move.sourceDataset+"@"+snapshotName, move.targetDataset); err != nil {
+			return nil, err
+		}
+		received = append(received, move.targetDataset)
+		if err := s.mountJailStorageTree(ctx, move.targetDataset); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range moves {
+		dataset, err := s.GZFS.ZFS.Get(ctx, moves[i].targetDataset, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed_to_get_migrated_dataset: %w", err)
+		}
+		moves[i].storage.Pool = targetPool
+		moves[i].storage.GUID = dataset.GUID
+		moves[i].targetMount = strings.TrimSpace(dataset.Mountpoint)
+	}
+
+	restoreFiles, err := s.rewriteJailHostFiles(ctID, moves)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.DB.Transaction(func(tx *gorm.DB) error {
+		for _, move := range moves {
+			if err := tx.Model(&jailModels.Storage{}).
+				Where("id = ?", move.storage.ID).
+				Updates(map[string]any{"pool": move.storage.Pool, "guid": move.storage.GUID}).Error; err != nil {
+				return fmt.Errorf("failed_to_update_jail_storage: %w", err)
+			}
+			if err := tx.Model(&jailModels.JailSnapshot{}).
+				Where("jid = ? AND root_dataset = ?", jail.ID, move.sourceDataset).
+				Update("root_dataset", move.targetDataset).Error; err != nil {
+				return fmt.Errorf("failed_to_update_jail_snapshot_records: %w", err)
+			}
+		}
+		mounts := append([]jailModels.JailMount(nil), jail.Mounts...)
+		for i := range mounts {
+			mounts[i].HostPath = rewriteJailMountPaths(mounts[i].HostPath, moves)
+		}
+		return tx.Model(&jailModels.Jail{}).
+			Where("id = ?", jail.ID).
+			Select("fstab", "mounts").
+			Updates(&jailModels.Jail{
+				Fstab:  rewriteJailMountPaths(jail.Fstab, moves),
+				Mounts: mounts,
+			}).Error
+	}); err != nil {
+		restoreFiles()
+		return nil, err
+	}
+	committed = true
+
+	if err := s.WriteJailJSON(ctID); err != nil {
+		result.Warnings = append(result.Warnings, "failed_to_write_jail_json: "+err.Error())
+	}
+
+	for _, move := range moves {
+		if move.nested {
+			continue
+		}
+		if _, err := storageMigrationRun(ctx, "zfs", "destroy", "-r", move.targetDataset+"@"+snapshotName); err != nil {
+			result.Warnings = append(result.Warnings, "failed_to_destroy_migration_snapshot: "+move.targetDataset)
+		}
+		if req.KeepSource {
+			_, _ = storageMigrationRun(ctx, "zfs", "destroy", "-r", move.sourceDataset+"@"+snapshotName)
+			continue
+		}
+		if _, err := storageMigrationRun(ctx, "zfs", "destroy", "-r", move.sourceDataset); err != nil {
+			logger.L.Warn().Err(err).Str("dataset", move.sourceDataset).Msg("failed_to_destroy_jail_storage_source")
+			result.Warnings = append(result.Warnings, "failed_to_destroy_source_dataset: "+move.sourceDataset)
+		}
+	}
+
+	return result, nil
+}
+
+// planJailStorageMoves maps every jail storage to its dataset on targetPool.
+// Storages already on targetPool are left alone; storages nested under
+// another moved dataset ride along in that dataset's -R stream.
+func (s *Service) planJailStorageMoves(
+	ctx context.Context,
+	jail *jailModels.Jail,
+	sourceRoot string,
+	targetPool string,
+) ([]jailStorageMove, error) {
+	var moves []jailStorageMove
+	for _, storage := range jail.Storages {
+		var name, mount string
+		if storage.IsBase {
+			dataset, err := s.GZFS.ZFS.Get(ctx, sourceRoot, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed_to_get_jail_base_dataset: %w", err)
+			}
+			name, mount = dataset.Name, dataset.Mountpoint
+		} else {
+			dataset, err := s.GZFS.ZFS.GetByGUID(ctx, storage.GUID, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed_to_get_jail_storage_dataset_%d: %w", storage.ID, err)
+			}
+			name, mount = dataset.Name, dataset.Mountpoint
+		}
+
+		pool, rest, _ := strings.Cut(name, "/")
+		if pool == targetPool {
+			continue
+		}
+		if rest == "" {
+			return nil, fmt.Errorf("jail_storage_is_pool_root: %s", name)
+		}
+
+		target := targetPool + "/" + rest
+		if _, err := s.GZFS.ZFS.Get(ctx, target, false); err == nil {
+			return nil, fmt.Errorf("target_dataset_exists: %s", target)
+		}
+
+		moves = append(moves, jailStorageMove{
+			storage:       storage,
+			sourceDataset: name,
+			targetDataset: target,
+			sourceMount:   strings.TrimSpace(mount),
+		})
+	}
+
+	// Parents first, so nesting can be detected in one pass.
+	sort.Slice(moves, func(i, j int) bool { return moves[i].sourceDataset < moves[j].sourceDataset })
+	for i := range moves {
+		for j := 0; j < i; j++ {
+			if !moves[j].nested && strings.HasPrefix(moves[i].sourceDataset, moves[j].sourceDataset+"/") {
+				moves[i].nested = true
+				break
+			}
+		}
+	}
+
+	return moves, nil
+}
+
+func (s *Service) checkJailStorageMigrationSpace(ctx context.Context, moves []jailStorageMove, targetPool string) error {
+	var needed uint64
+	for _, move := range moves {
+		if move.nested {
+			continue
+		}
+		out, err := storageMigrationRun(ctx, "zfs", "get", "-Hp", "-o", "value", "used", move.sourceDataset)
+		if err != nil {
+			return fmt.Errorf("failed_to_get_dataset_usage: %w", err)
+		}
+		used, err := strconv.ParseUint(strings.TrimSpace(out), 10, 64)
+		if err != nil {
+			return fmt.Errorf("failed_to_parse_dataset_usage: %w", err)
+		}
+		needed += used
+	}
+
+	out, err := storageMigrationRun(ctx, "zfs", "get", "-Hp", "-o", "value", "available", targetPool)
+	if err != nil {
+		return fmt.Errorf("failed_to_get_target_pool_space: %w", err)
+	}
+	available, err := strconv.ParseUint(strings.TrimSpace(out), 10, 64)
+	if err != nil {
+		return fmt.Errorf("failed_to_parse_target_pool_space: %w", err)
+	}
+	if needed > available {
+		return fmt.Errorf("insufficient_space_on_target_pool: need %d bytes, %d available", needed, available)
+	}
+	return nil
+}
+
+func (s *Service) mountJailStorageTree(ctx context.Context, root string) error {
+	out, err := storageMigrationRun(ctx, "zfs", "list", "-H", "-o", "name", "-t", "filesystem", "-r", root)
+	if err != nil {
+		return fmt.Errorf("failed_to_list_migrated_datasets: %w", err)
+	}
+	for _, name := range strings.Split(strings.TrimSpace(out), "\n") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if output, err := storageMigrationRun(ctx, "zfs", "mount", name); err != nil &&
+			!strings.Contains(output, "already mounted") {
+			return fmt.Errorf("failed_to_mount_migrated_dataset_%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// rewriteJailHostFiles points the jail conf, fstab and host hook scripts at
+// the new mountpoints. The returned func puts the original contents back.
+func (s *Service) rewriteJailHostFiles(ctID uint, moves []jailStorageMove) (func(), error) {
+	jailsPath, err := config.GetJailsPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed_to_get_jails_path: %w", err)
+	}
+
+	jailDir := filepath.Join(jailsPath, fmt.Sprintf("%d", ctID))
+	paths := []string{
+		filepath.Join(jailDir, fmt.Sprintf("%d.conf", ctID)),
+		filepath.Join(jailDir, "fstab"),
+	}
+	if scripts, err := filepath.Glob(filepath.Join(jailDir, "scripts", "*.sh")); err == nil {
+		paths = append(paths, scripts...)
+	}
+
+	originals := map[string][]byte{}
+	restore := func() {
+		for path, content := range originals {
+			if err := os.WriteFile(path, content, 0644); err != nil {
+				logger.L.Warn().Err(err).Str("path", path).Msg("failed_to_restore_jail_file_after_storage_migration")
+			}
+		}
+	}
+
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			restore()
+			return nil, fmt.Errorf("failed_to_read_jail_file: %w", err)
+		}
+
+		updated := rewriteJailMountPaths(string(content), moves)
+		if updated == string(content) {
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			restore()
+			return nil, fmt.Errorf("failed_to_stat_jail_file: %w", err)
+		}
+		originals[path] = content
+		if err := os.WriteFile(path, []byte(updated), info.Mode().Perm()); err != nil {
+			restore()
+			return nil, fmt.Errorf("failed_to_write_jail_file: %w", err)
+		}
+	}
+
+	return restore, nil
+}
+
+// rewriteJailMountPaths replaces each moved mountpoint with its new location,
+// longest first so nested mountpoints are not clobbered by their parent.
+func rewriteJailMountPaths(content string, moves []jailStorageMove) string {
+	type pair struct{ from, to string }
+	var pairs []pair
+	for _, move := range moves {
+		from := strings.TrimRight(move.sourceMount, "/")
+		to := strings.TrimRight(move.targetMount, "/")
+		if from == "" || to == "" || from == to || !strings.HasPrefix(from, "/") {
+			continue
+		}
+		pairs = append(pairs, pair{from: from, to: to})
+	}
+	sort.Slice(pairs, func(i, j int) bool { return len(pairs[i].from) > len(pairs[j].from) })
+
+	for _, p := range pairs {
+		var out strings.Builder
+		rest := content
+		for {
+			idx := strings.Index(rest, p.from)
+			if idx < 0 {
+				out.WriteString(rest)
+				break
+			}
+			end := idx + len(p.from)
+			// Only whole path components: /tank/sylve/jails/10 must not
+			// rewrite /tank/sylve/jails/100.
+			if end < len(rest) && !isJailPathBoundary(rest[end]) {
+				out.WriteString(rest[:end])
+				rest = rest[end:]
+				continue
+			}
+			out.WriteString(rest[:idx])
+			out.WriteString(p.to)
+			rest = rest[end:]
+		}
+		content = out.String()
+	}
+	return content
+}
+
+func isJailPathBoundary(c byte) bool {
+	switch c {
+	case '/', '"', '\'', ' ', '\t', '\n', '\r', ';':
+		return true
+	}
+	return false
+}
+
+func sendRecvLocalDataset(ctx context.Context, snapshot, target string) error {
+	send := exec.CommandContext(ctx, "zfs", "send", "-R", snapshot)
+	recv := exec.CommandContext(ctx, "zfs", "recv", "-u", "-x", "mountpoint", target)
+
+	pipeReader, pipeWriter := io.Pipe()
+	var sendErr, recvErr bytes.Buffer
+	send.Stdout = pipeWriter
+	send.Stderr = &sendErr
+	recv.Stdin = pipeReader
+	recv.Stderr = &recvErr
+
+	if err := recv.Start(); err != nil {
+		return fmt.Errorf("failed_to_start_zfs_recv: %w", err)
+	}
+	if err := send.Start(); err != nil {
+		_ = pipeWriter.Close()
+		_ = recv.Wait()
+		return fmt.Errorf("failed_to_start_zfs_send: %w", err)
+	}
+
+	sendWaitErr := send.Wait()
+	_ = pipeWriter.CloseWithError(sendWaitErr)
+	recvWaitErr := recv.Wait()
+
+	if sendWaitErr != nil {
+		return fmt.Errorf("zfs_send_failed: %s: %w", strings.TrimSpace(sendErr.String()), sendWaitErr)
+	}
+	if recvWaitErr != nil {
+		return fmt.Errorf("zfs_recv_failed: %s: %w", strings.TrimSpace(recvErr.String()), recvWaitErr)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package jail
+
+import "testing"
+
+func TestRewriteJailMountPathsOnlyReplacesWholeComponents(t *testing.T) {
+	moves := []jailStorageMove{
+		{sourceMount: "/tank/sylve/jails/10", targetMount: "/fast/sylve/jails/10"},
+		{sourceMount: "/tank/data/10-extra/", targetMount: "/fast/data/10-extra"},
+	}
+
+	input := "path = \"/tank/sylve/jails/10\";\n" +
+		"mount.fstab = \"/var/sylve/jails/10/fstab\";\n" +
+		"/tank/data/10-extra /tank/sylve/jails/10/mnt/extra nullfs rw 0 0\n" +
+		"/tank/sylve/jails/100/etc nullfs ro 0 0\n"
+	want := "path = \"/fast/sylve/jails/10\";\n" +
+		"mount.fstab = \"/var/sylve/jails/10/fstab\";\n" +
+		"/fast/data/10-extra /fast/sylve/jails/10/mnt/extra nullfs rw 0 0\n" +
+		"/tank/sylve/jails/100/etc nullfs ro 0 0\n"
+
+	if got := rewriteJailMountPaths(input, moves); got != want {
+		t.Fatalf("rewriteJailMountPaths() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRewriteJailMountPathsSkipsUnmountedStorages(t *testing.T) {
+	moves := []jailStorageMove{{sourceMount: "none", targetMount: "none"}, {sourceMount: "-", targetMount: ""}}
+	input := "path = \"/tank/sylve/jails/10\";\n"
+	if got := rewriteJailMountPaths(input, moves); got != input {
+		t.Fatalf("expected content unchanged, got %q", got)
+	}
+}
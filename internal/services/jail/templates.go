@@ -23,6 +23,7 @@ import (
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
 	"github.com/alchemillahq/sylve/internal/db/replicationguard"
 	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/pkg/utils"
 	"gorm.io/gorm"
 )
@@ -181,6 +182,7 @@ func (s *Service) buildTemplateNetworks(networks []jailModels.Network) []jailMod
 			DHCP:           n.DHCP,
 			SLAAC:          n.SLAAC,
 			DefaultGateway: n.DefaultGateway,
+			FIB:            n.FIB,
 		})
 	}
 	return out
@@ -273,7 +275,7 @@ func (s *Service) PreflightConvertJailToTemplate(ctx context.Context, ctID uint,
 		return fmt.Errorf("jail_base_pool_not_found")
 	}
 
-	sourceDataset := fmt.Sprintf("%s/sylve/jails/%d", pool, ctID)
+	sourceDataset := fmt.Sprintf("%s/%d", layout.JailsDataset(pool), ctID)
 	srcDS, err := s.GZFS.ZFS.Get(ctx, sourceDataset, false)
 	if err != nil {
 		return fmt.Errorf("failed_to_get_source_jail_dataset: %w", err)
@@ -332,8 +334,8 @@ func (s *Service) ConvertJailToTemplate(ctx context.Context, ctID uint, req Conv
 		return fmt.Errorf("jail_base_pool_not_found")
 	}
 
-	sourceDataset := fmt.Sprintf("%s/sylve/jails/%d", pool, ctID)
-	templateParentDataset := fmt.Sprintf("%s/sylve/jails/templates", pool)
+	sourceDataset := fmt.Sprintf("%s/%d", layout.JailsDataset(pool), ctID)
+	templateParentDataset := fmt.Sprintf("%s/templates", layout.JailsDataset(pool))
 	templateToken := sanitizeTemplateDatasetToken(req.Name)
 	templateDataset := fmt.Sprintf(
 		"%s/%s-%d",
@@ -399,6 +401,7 @@ func (s *Service) ConvertJailToTemplate(ctx context.Context, ctID uint, req Conv
 		Memory:            jail.Memory,
 		InheritIPv4:       jail.InheritIPv4,
 		InheritIPv6:       jail.InheritIPv6,
+		Mounts:            append([]jailModels.JailMount{}, jail.Mounts...),
 		Fstab:             jail.Fstab,
 		ResolvConf:        jail.ResolvConf,
 		DevFSRuleset:      jail.DevFSRuleset,
@@ -582,7 +585,7 @@ func (s *Service) preflightTemplateTargets(ctx context.Context, template jailMod
 	requiredByPool := make(map[string]uint64)
 
 	for _, target := range targets {
-		datasetName := fmt.Sprintf("%s/sylve/jails/%d", target.Pool, target.CTID)
+		datasetName := fmt.Sprintf("%s/%d", layout.JailsDataset(target.Pool), target.CTID)
 		if existing, getErr := s.GZFS.ZFS.Get(ctx, datasetName, false); getErr != nil {
 			if !strings.Contains(strings.ToLower(getErr.Error()), "does not exist") {
 				return fmt.Errorf("failed_to_check_target_dataset: %w", getErr)
@@ -650,8 +653,8 @@ func (s *Service) createJailFromTemplateTarget(
 		return fmt.Errorf("template_dataset_not_found")
 	}
 
-	datasetName := fmt.Sprintf("%s/sylve/jails/%d", target.Pool, target.CTID)
-	mountPoint := fmt.Sprintf("/%s/sylve/jails/%d", target.Pool, target.CTID)
+	datasetName := fmt.Sprintf("%s/%d", layout.JailsDataset(target.Pool), target.CTID)
+	mountPoint := fmt.Sprintf("/%s/%d", layout.JailsDataset(target.Pool), target.CTID)
 
 	if existing, getErr := s.GZFS.ZFS.Get(ctx, datasetName, false); getErr != nil {
 		if !strings.Contains(strings.ToLower(getErr.Error()), "does not exist") {
@@ -712,6 +715,7 @@ func (s *Service) createJailFromTemplateTarget(
 			Cores:             template.Cores,
 			Memory:            template.Memory,
 			DevFSRuleset:      template.DevFSRuleset,
+			Mounts:            append([]jailModels.JailMount{}, template.Mounts...),
 			Fstab:             template.Fstab,
 			ResolvConf:        template.ResolvConf,
 			CleanEnvironment:  template.CleanEnvironment,
@@ -773,6 +777,7 @@ func (s *Service) createJailFromTemplateTarget(
 				DHCP:           n.DHCP,
 				SLAAC:          n.SLAAC,
 				DefaultGateway: n.DefaultGateway,
+				FIB:            n.FIB,
 			}
 			if err := tx.Create(&network).Error; err != nil {
 				return fmt.Errorf("failed_to_create_template_network: %w", err)
@@ -408,6 +408,7 @@ func TestPreflightConvertJailToTemplateInsufficientPoolSpace(t *testing.T) {
 		&jailModels.Storage{},
 		&jailModels.JailHooks{},
 		&jailModels.JailSnapshot{},
+		&jailModels.JailSnapshotSchedule{},
 		&jailModels.Network{},
 		&networkModels.Object{},
 		&networkModels.ObjectEntry{},
@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package jail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const jailUsageCommandTimeout = 10 * time.Second
+
+// jailPSFields is the ps column list run inside the jail. The trailing "="
+// drops the headers, and command comes last so that it may contain spaces.
+const jailPSFields = "pid=,ppid=,user=,state=,pcpu=,pmem=,rss=,vsz=,etimes=,command="
+
+var jailUsageRunCommand = utils.RunCommandWithContext
+
+// parseJailProcesses parses the output of ps -o jailPSFields. Lines that do
+// not parse, such as a warning ps printed, are skipped. rss and vsz are
+// turned from kilobytes into bytes.
+func parseJailProcesses(out string) []jailServiceInterfaces.JailProcess {
+	processes := make([]jailServiceInterfaces.JailProcess, 0)
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 10 {
+			continue
+		}
+
+		pid, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		ppid, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+
+		pcpu, _ := strconv.ParseFloat(fields[4], 64)
+		pmem, _ := strconv.ParseFloat(fields[5], 64)
+		rss, _ := strconv.ParseInt(fields[6], 10, 64)
+		vsz, _ := strconv.ParseInt(fields[7], 10, 64)
+		elapsed, _ := strconv.ParseInt(fields[8], 10, 64)
+
+		processes = append(processes, jailServiceInterfaces.JailProcess{
+			PID:     pid,
+			PPID:    ppid,
+			User:    fields[2],
+			State:   fields[3],
+			PCPU:    pcpu,
+			PMem:    pmem,
+			RSS:     rss * 1024,
+			VSZ:     vsz * 1024,
+			Elapsed: elapsed,
+			Command: strings.Join(fields[9:], " "),
+		})
+	}
+
+	return processes
+}
+
+// parseRctlUsage parses rctl -u output, one resource=value per line.
+func parseRctlUsage(out string) (map[string]int64, error) {
+	counters := make(map[string]int64)
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		name, raw, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("unexpected_rctl_output: %s", line)
+		}
+		value, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected_rctl_output: %s", line)
+		}
+		counters[strings.TrimSpace(name)] = value
+	}
+
+	return counters, nil
+}
+
+func (s *Service) requireRunningJail(ctid uint) error {
+	var jail jailModels.Jail
+	if err := s.DB.Select("id").Where("ct_id = ?", ctid).First(&jail).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("jail_not_found")
+		}
+		return err
+	}
+
+	running, err := s.IsJailRunning(ctid)
+	if err != nil {
+		return fmt.Errorf("failed_to_check_jail_state: %w", err)
+	}
+	if !running {
+		return fmt.Errorf("jail_not_running")
+	}
+
+	return nil
+}
+
+// ListJailProcesses runs ps inside a running jail through jexec, leaving out
+// the ps process itself.
+func (s *Service) ListJailProcesses(ctx context.Context, ctid uint) ([]jailServiceInterfaces.JailProcess, error) {
+	if err := s.requireRunningJail(ctid); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, jailUsageCommandTimeout)
+	defer cancel()
+
+	psArgs := []string{"/bin/ps", "-axww", "-o", jailPSFields}
+	out, err := jailUsageRunCommand(ctx, "jexec", append([]string{s.GetCTIDHash(ctid)}, psArgs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed_to_list_jail_processes: %w", err)
+	}
+
+	self := strings.Join(psArgs, " ")
+	processes := parseJailProcesses(out)
+	filtered := processes[:0]
+	for _, p := range processes {
+		if p.Command != self {
+			filtered = append(filtered, p)
+		}
+	}
+
+	return filtered, nil
+}
+
+// GetJailResourceUsage reads the jail's racct counters with rctl -u. They are
+// only kept when the kern.racct.enable tunable is set.
+func (s *Service) GetJailResourceUsage(ctx context.Context, ctid uint) (jailServiceInterfaces.JailResourceUsage, error) {
+	if err := s.requireRunningJail(ctid); err != nil {
+		return jailServiceInterfaces.JailResourceUsage{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, jailUsageCommandTimeout)
+	defer cancel()
+
+	out, err := jailUsageRunCommand(ctx, "/usr/bin/rctl", "-u", "jail:"+s.GetCTIDHash(ctid))
+	if err != nil {
+		if strings.Contains(out, "kern.racct.enable") {
+			return jailServiceInterfaces.JailResourceUsage{}, fmt.Errorf("racct_disabled")
+		}
+		return jailServiceInterfaces.JailResourceUsage{}, fmt.Errorf("failed_to_read_jail_usage: %w", err)
+	}
+
+	counters, err := parseRctlUsage(out)
+	if err != nil {
+		return jailServiceInterfaces.JailResourceUsage{}, err
+	}
+
+	return jailServiceInterfaces.JailResourceUsage{CTID: ctid, Counters: counters}, nil
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+
+package jail
+
+import (
+	"testing"
+)
+
+func TestParseJailProcesses(t *testing.T) {
+	out := `    1     0 root     SsJ   0.0  0.1  1024  13000 3600 /sbin/init --
+  812     1 www      SJ    2.5  1.4 24576 131072   42 nginx: worker process (nginx)
+ps: warning: something odd
+ 4411     0 root     R+J   0.0  0.0  2048  14000    0 /bin/ps -axww -o pid=,ppid=,user=,state=,pcpu=,pmem=,rss=,vsz=,etimes=,command=
+`
+
+	processes := parseJailProcesses(out)
+	if len(processes) != 3 {
+		t.Fatalf("expected 3 processes, got %d: %+v", len(processes), processes)
+	}
+
+	worker := processes[1]
+	if worker.PID != 812 || worker.PPID != 1 || worker.User != "www" || worker.State != "SJ" {
+		t.Fatalf("unexpected process fields: %+v", worker)
+	}
+	if worker.PCPU != 2.5 || worker.PMem != 1.4 || worker.Elapsed != 42 {
+		t.Fatalf("unexpected usage fields: %+v", worker)
+	}
+	if worker.RSS != 24576*1024 || worker.VSZ != 131072*1024 {
+		t.Fatalf("expected rss and vsz in bytes, got %d %d", worker.RSS, worker.VSZ)
+	}
+	if worker.Command != "nginx: worker process (nginx)" {
+		t.Fatalf("unexpected command %q", worker.Command)
+	}
+}
+
+func TestParseRctlUsage(t *testing.T) {
+	counters, err := parseRctlUsage("cputime=12\nmemoryuse=52428800\nmaxproc=7\nreadbps=0\n")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if counters["cputime"] != 12 || counters["memoryuse"] != 52428800 || counters["maxproc"] != 7 {
+		t.Fatalf("unexpected counters: %v", counters)
+	}
+
+	if _, err := parseRctlUsage("RACCT/RCTL present, but disabled\n"); err == nil {
+		t.Fatal("expected an error for unexpected output")
+	}
+}
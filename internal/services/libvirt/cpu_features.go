@@ -0,0 +1,277 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package libvirt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	"github.com/beevik/etree"
+	"github.com/klauspost/cpuid/v2"
+)
+
+// bhyve hands every guest the host CPUID leaves filtered through vmm(4); there
+// is no per-guest CPU model or CPUID mask. The toggles below are the knobs
+// bhyve does expose, so an empty feature list means plain host passthrough.
+const (
+	CPUFeatureX2APIC      = "x2apic"
+	CPUFeatureNoMPTable   = "no-mptable"
+	CPUFeatureYieldOnHLT  = "yield-on-hlt"
+	CPUFeatureExitOnPause = "exit-on-pause"
+)
+
+type cpuFeatureSpec struct {
+	name        string
+	arg         string
+	description string
+}
+
+var cpuFeatureSpecs = []cpuFeatureSpec{
+	{CPUFeatureX2APIC, "-x", "Start the local APIC in x2APIC mode"},
+	{CPUFeatureNoMPTable, "-Y", "Do not build an MP table for the guest"},
+	{CPUFeatureYieldOnHLT, "-H", "Yield the vCPU thread when the guest executes HLT"},
+	{CPUFeatureExitOnPause, "-P", "Force a VM exit when the guest executes PAUSE"},
+}
+
+// Overridden in tests.
+var hostSupportsVirtualization = func() bool {
+	return cpuid.CPU.Supports(cpuid.VMX) || cpuid.CPU.Supports(cpuid.SVM)
+}
+
+func lookupCPUFeature(name string) (cpuFeatureSpec, bool) {
+	for _, spec := range cpuFeatureSpecs {
+		if spec.name == name {
+			return spec, true
+		}
+	}
+
+	return cpuFeatureSpec{}, false
+}
+
+func normalizeCPUFeatures(features []string) []string {
+	normalized := make([]string, 0, len(features))
+	seen := make(map[string]struct{}, len(features))
+
+	for _, feature := range features {
+		name := strings.ToLower(strings.TrimSpace(feature))
+		if name == "" {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+
+		seen[name] = struct{}{}
+		normalized = append(normalized, name)
+	}
+
+	return normalized
+}
+
+func validateCPUFeatures(features []string) error {
+	features = normalizeCPUFeatures(features)
+	if len(features) == 0 {
+		return nil
+	}
+
+	if !hostSupportsVirtualization() {
+		return fmt.Errorf("host_cpu_lacks_hardware_virtualization")
+	}
+
+	for _, name := range features {
+		if _, ok := lookupCPUFeature(name); !ok {
+			return fmt.Errorf("unsupported_cpu_feature: %s", name)
+		}
+	}
+
+	return nil
+}
+
+// cpuFeatureBhyveArgs maps the VM's CPU features to bhyve flags, skipping any
+// flag the user already passes through the extra bhyve options.
+func cpuFeatureBhyveArgs(features []string, extra []string) []string {
+	existing := make(map[string]struct{})
+	for _, arg := range normalizeExtraBhyveOptions(extra) {
+		existing[arg] = struct{}{}
+	}
+
+	args := make([]string, 0, len(features))
+	for _, name := range normalizeCPUFeatures(features) {
+		spec, ok := lookupCPUFeature(name)
+		if !ok {
+			continue
+		}
+		if _, ok := existing[spec.arg]; ok {
+			continue
+		}
+
+		existing[spec.arg] = struct{}{}
+		args = append(args, spec.arg)
+	}
+
+	return args
+}
+
+// suggestCPUTopology lays out vcpus the way Windows licensing prefers: a
+// single socket, with SMT siblings only when the host itself has them.
+func suggestCPUTopology(vcpus int, hostThreadsPerCore int) libvirtServiceInterfaces.CPUTopology {
+	if vcpus < 1 {
+		vcpus = 1
+	}
+
+	threads := 1
+	if hostThreadsPerCore > 1 && vcpus%hostThreadsPerCore == 0 {
+		threads = hostThreadsPerCore
+	}
+
+	return libvirtServiceInterfaces.CPUTopology{
+		Sockets: 1,
+		Cores:   vcpus / threads,
+		Threads: threads,
+	}
+}
+
+func (s *Service) GetCPUCapabilities(vcpus int) libvirtServiceInterfaces.CPUCapabilities {
+	virtualization := hostSupportsVirtualization()
+
+	caps := libvirtServiceInterfaces.CPUCapabilities{
+		Vendor:         cpuid.CPU.VendorString,
+		Brand:          cpuid.CPU.BrandName,
+		Sockets:        utils.GetSocketCount(cpuid.CPU.PhysicalCores, cpuid.CPU.ThreadsPerCore),
+		PhysicalCores:  cpuid.CPU.PhysicalCores,
+		LogicalCores:   cpuid.CPU.LogicalCores,
+		ThreadsPerCore: cpuid.CPU.ThreadsPerCore,
+		Virtualization: virtualization,
+		Features:       make([]libvirtServiceInterfaces.CPUFeatureInfo, 0, len(cpuFeatureSpecs)),
+	}
+
+	for _, spec := range cpuFeatureSpecs {
+		caps.Features = append(caps.Features, libvirtServiceInterfaces.CPUFeatureInfo{
+			Name:        spec.name,
+			BhyveArg:    spec.arg,
+			Description: spec.description,
+			Supported:   virtualization,
+		})
+	}
+
+	if vcpus > 0 {
+		suggested := suggestCPUTopology(vcpus, cpuid.CPU.ThreadsPerCore)
+		caps.Suggested = &suggested
+	}
+
+	return caps
+}
+
+func (s *Service) ModifyCPUFeatures(rid uint, features []string) error {
+	if err := s.requireVMMutationOwnership(rid); err != nil {
+		return err
+	}
+	if err := s.requireConnection(); err != nil {
+		return err
+	}
+
+	normalized := normalizeCPUFeatures(features)
+	if err := validateCPUFeatures(normalized); err != nil {
+		return err
+	}
+
+	var vm vmModels.VM
+	if err := s.DB.Where("rid = ?", rid).First(&vm).Error; err != nil {
+		return fmt.Errorf("failed_to_fetch_vm_from_db: %w", err)
+	}
+
+	domain, err := s.conn().DomainLookupByName(strconv.Itoa(int(rid)))
+	if err != nil {
+		return fmt.Errorf("failed_to_lookup_domain_by_name: %w", err)
+	}
+
+	state, _, err := s.conn().DomainGetState(domain, 0)
+	if err != nil {
+		return fmt.Errorf("failed_to_get_domain_state: %w", err)
+	}
+
+	if state != 5 {
+		return fmt.Errorf("domain_state_not_shutoff: %d", rid)
+	}
+
+	xml, err := s.conn().DomainGetXMLDesc(domain, 0)
+	if err != nil {
+		return fmt.Errorf("failed_to_get_domain_xml_desc: %w", err)
+	}
+
+	doc := etree.NewDocument()
+	if err := doc.ReadFromString(xml); err != nil {
+		return fmt.Errorf("failed_to_parse_xml: %w", err)
+	}
+
+	root := doc.Root()
+	if root == nil {
+		return fmt.Errorf("invalid_domain_xml: root_missing")
+	}
+
+	// Flags the user pinned in the extra bhyve options are left alone.
+	managed := make(map[string]struct{}, len(cpuFeatureSpecs))
+	for _, spec := range cpuFeatureSpecs {
+		managed[spec.arg] = struct{}{}
+	}
+	for _, extra := range normalizeExtraBhyveOptions(vm.ExtraBhyveOptions) {
+		delete(managed, extra)
+	}
+
+	bhyveCL := doc.FindElement("//commandline")
+	if bhyveCL != nil && bhyveCL.Space == "bhyve" {
+		for _, arg := range bhyveCL.ChildElements() {
+			if _, ok := managed[arg.SelectAttrValue("value", "")]; ok {
+				bhyveCL.RemoveChild(arg)
+			}
+		}
+	}
+
+	args := cpuFeatureBhyveArgs(normalized, vm.ExtraBhyveOptions)
+	if len(args) > 0 && (bhyveCL == nil || bhyveCL.Space != "bhyve") {
+		bhyveCL = root.CreateElement("bhyve:commandline")
+	}
+
+	for _, arg := range args {
+		argEl := bhyveCL.CreateElement("bhyve:arg")
+		argEl.CreateAttr("value", arg)
+	}
+
+	out, err := doc.WriteToString()
+	if err != nil {
+		return fmt.Errorf("failed_to_serialize_xml: %w", err)
+	}
+
+	if err := s.conn().DomainUndefineFlags(domain, 0); err != nil {
+		return fmt.Errorf("failed_to_undefine_domain: %w", err)
+	}
+
+	if _, err := s.conn().DomainDefineXML(out); err != nil {
+		return fmt.Errorf("failed_to_define_domain_with_modified_xml: %w", err)
+	}
+
+	if err := s.DB.
+		Model(&vmModels.VM{}).
+		Where("rid = ?", rid).
+		Select("CPUFeatures").
+		Updates(vmModels.VM{CPUFeatures: normalized}).Error; err != nil {
+		return fmt.Errorf("failed_to_update_cpu_features_in_db: %w", err)
+	}
+
+	if err := s.WriteVMJson(rid); err != nil {
+		logger.L.Error().Err(err).Msg("Failed to write VM JSON after CPU feature modification")
+	}
+
+	return nil
+}
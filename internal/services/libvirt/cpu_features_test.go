@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package libvirt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+)
+
+func withHostVirtualization(t *testing.T, supported bool) {
+	t.Helper()
+	prev := hostSupportsVirtualization
+	hostSupportsVirtualization = func() bool { return supported }
+	t.Cleanup(func() { hostSupportsVirtualization = prev })
+}
+
+func TestValidateCPUFeatures(t *testing.T) {
+	withHostVirtualization(t, true)
+
+	if err := validateCPUFeatures(nil); err != nil {
+		t.Fatalf("expected empty feature list to be valid, got %v", err)
+	}
+	if err := validateCPUFeatures([]string{" X2APIC ", "no-mptable"}); err != nil {
+		t.Fatalf("expected known features to be valid, got %v", err)
+	}
+
+	err := validateCPUFeatures([]string{"avx512"})
+	if err == nil || !strings.Contains(err.Error(), "unsupported_cpu_feature") {
+		t.Fatalf("expected unsupported_cpu_feature, got %v", err)
+	}
+}
+
+func TestValidateCPUFeatures_RequiresHardwareVirtualization(t *testing.T) {
+	withHostVirtualization(t, false)
+
+	if err := validateCPUFeatures(nil); err != nil {
+		t.Fatalf("expected empty feature list to be valid, got %v", err)
+	}
+
+	err := validateCPUFeatures([]string{CPUFeatureX2APIC})
+	if err == nil || err.Error() != "host_cpu_lacks_hardware_virtualization" {
+		t.Fatalf("expected host_cpu_lacks_hardware_virtualization, got %v", err)
+	}
+}
+
+func TestCPUFeatureBhyveArgs_SkipsDuplicatesAndExtraOptions(t *testing.T) {
+	got := cpuFeatureBhyveArgs(
+		[]string{"x2apic", "X2APIC", "yield-on-hlt", "no-mptable", "bogus"},
+		[]string{"-H"},
+	)
+	want := []string{"-x", "-Y"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected args: got=%#v want=%#v", got, want)
+	}
+}
+
+func TestSuggestCPUTopology(t *testing.T) {
+	tests := []struct {
+		vcpus   int
+		threads int
+		want    libvirtServiceInterfaces.CPUTopology
+	}{
+		{vcpus: 8, threads: 2, want: libvirtServiceInterfaces.CPUTopology{Sockets: 1, Cores: 4, Threads: 2}},
+		{vcpus: 3, threads: 2, want: libvirtServiceInterfaces.CPUTopology{Sockets: 1, Cores: 3, Threads: 1}},
+		{vcpus: 4, threads: 1, want: libvirtServiceInterfaces.CPUTopology{Sockets: 1, Cores: 4, Threads: 1}},
+		{vcpus: 0, threads: 2, want: libvirtServiceInterfaces.CPUTopology{Sockets: 1, Cores: 1, Threads: 1}},
+	}
+
+	for _, tt := range tests {
+		if got := suggestCPUTopology(tt.vcpus, tt.threads); got != tt.want {
+			t.Fatalf("suggestCPUTopology(%d, %d) = %+v, want %+v", tt.vcpus, tt.threads, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,347 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package libvirt
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/utils"
+
+	"golang.org/x/crypto/ssh"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+const maxGuestTagLength = 64
+
+// GuestMetadataAddress is the link-local address the metadata service
+// listens on. It is aliased onto every standard switch bridge so guests
+// reach it on-link, and nothing else on the host answers the port.
+const GuestMetadataAddress = "169.254.169.254"
+
+type arpEntry struct {
+	MAC       string
+	Interface string
+}
+
+// lookupARPEntry is swapped out in tests; the host ARP table is the only
+// place that ties a guest's source address to the bridge it arrived on.
+var lookupARPEntry = func(ip string) (arpEntry, error) {
+	output, err := utils.RunCommand("/usr/sbin/arp", "-n", ip)
+	if err != nil {
+		return arpEntry{}, fmt.Errorf("arp_lookup_failed: %w", err)
+	}
+
+	return parseARPOutput(output)
+}
+
+// parseARPOutput reads a line such as
+// "? (10.0.0.5) at 58:9c:fc:00:00:01 on bridge0 expires in 1195 seconds [ethernet]".
+func parseARPOutput(output string) (arpEntry, error) {
+	for _, line := range utils.SplitLines(output) {
+		fields := strings.Fields(line)
+
+		var entry arpEntry
+		for i := 0; i+1 < len(fields); i++ {
+			switch fields[i] {
+			case "at":
+				entry.MAC = strings.ToLower(fields[i+1])
+			case "on":
+				entry.Interface = fields[i+1]
+			}
+		}
+
+		if _, err := net.ParseMAC(entry.MAC); err == nil && entry.Interface != "" {
+			return entry, nil
+		}
+	}
+
+	return arpEntry{}, fmt.Errorf("arp_entry_not_found")
+}
+
+// lookupBridgeMember is swapped out in tests; it reports the bridge member
+// a MAC address was learned on.
+var lookupBridgeMember = func(bridge, mac string) (string, error) {
+	output, err := utils.RunCommand("/sbin/ifconfig", bridge, "addr")
+	if err != nil {
+		return "", fmt.Errorf("bridge_addr_lookup_failed: %w", err)
+	}
+
+	return parseBridgeAddrOutput(output, mac)
+}
+
+// parseBridgeAddrOutput finds mac in the forwarding table printed by
+// "ifconfig bridgeN addr", whose lines read
+// "58:9c:fc:00:00:01 Vlan1 tap0 1188 flags=0<>".
+func parseBridgeAddrOutput(output, mac string) (string, error) {
+	for _, line := range utils.SplitLines(output) {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !strings.EqualFold(fields[0], mac) {
+			continue
+		}
+
+		member := fields[1]
+		if strings.HasPrefix(member, "Vlan") && len(fields) > 2 {
+			member = fields[2]
+		}
+		return member, nil
+	}
+
+	return "", fmt.Errorf("bridge_addr_entry_not_found")
+}
+
+// EnsureGuestMetadataAddress aliases GuestMetadataAddress onto the bridge
+// of every standard switch, so the metadata listener can bind to it alone
+// instead of every address on the host.
+func (s *Service) EnsureGuestMetadataAddress() error {
+	var switches []networkModels.StandardSwitch
+	if err := s.DB.Select("id", "bridge_name").Find(&switches).Error; err != nil {
+		return fmt.Errorf("failed_to_list_standard_switches: %w", err)
+	}
+
+	for _, sw := range switches {
+		output, err := utils.RunCommand("/sbin/ifconfig", sw.BridgeName, "inet", GuestMetadataAddress+"/32", "alias")
+		if err != nil && !strings.Contains(output+err.Error(), "File exists") {
+			return fmt.Errorf("failed_to_alias_guest_metadata_address on %s: %w", sw.BridgeName, err)
+		}
+	}
+
+	return nil
+}
+
+func normalizeGuestSSHKeys(keys []string) ([]string, error) {
+	normalized := make([]string, 0, len(keys))
+	seen := make(map[string]struct{}, len(keys))
+
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+
+		if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key + "\n")); err != nil {
+			return nil, fmt.Errorf("invalid_ssh_public_key: %w", err)
+		}
+
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		normalized = append(normalized, key)
+	}
+
+	return normalized, nil
+}
+
+func normalizeGuestTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+
+		if len(tag) > maxGuestTagLength || strings.ContainsAny(tag, "\r\n") {
+			return nil, fmt.Errorf("invalid_guest_tag: %s", tag)
+		}
+
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+
+	return normalized, nil
+}
+
+// guestHostname turns a VM name into something usable as local-hostname.
+func guestHostname(vm vmModels.VM) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(vm.Name)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+
+	hostname := strings.Trim(b.String(), "-")
+	if len(hostname) > 63 {
+		hostname = strings.TrimRight(hostname[:63], "-")
+	}
+	if hostname == "" {
+		hostname = fmt.Sprintf("vm-%d", vm.RID)
+	}
+
+	return hostname
+}
+
+// renderGuestMetaData fills in the keys the service knows about without
+// overriding anything the operator put in the VM's own cloud-init meta-data.
+func renderGuestMetaData(meta libvirtServiceInterfaces.GuestMetadata, userMetaData string) (string, error) {
+	doc := make(map[string]any)
+	if strings.TrimSpace(userMetaData) != "" {
+		if err := yaml.Unmarshal([]byte(userMetaData), &doc); err != nil {
+			return "", fmt.Errorf("invalid_yaml_in_cloud_init_meta_data: %w", err)
+		}
+		if doc == nil {
+			doc = make(map[string]any)
+		}
+	}
+
+	setDefault := func(key string, value any) {
+		if _, ok := doc[key]; !ok {
+			doc[key] = value
+		}
+	}
+
+	setDefault("instance-id", meta.InstanceID)
+	setDefault("local-hostname", meta.Hostname)
+	if len(meta.SSHKeys) > 0 {
+		setDefault("public-keys", meta.SSHKeys)
+	}
+	if len(meta.Tags) > 0 {
+		setDefault("tags", meta.Tags)
+	}
+
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("failed_to_render_guest_meta_data: %w", err)
+	}
+
+	return string(out), nil
+}
+
+func (s *Service) ModifyGuestMetadata(rid uint, sshKeys []string, tags []string) error {
+	if err := s.requireVMMutationOwnership(rid); err != nil {
+		return err
+	}
+
+	keys, err := normalizeGuestSSHKeys(sshKeys)
+	if err != nil {
+		return err
+	}
+
+	normalizedTags, err := normalizeGuestTags(tags)
+	if err != nil {
+		return err
+	}
+
+	if err := s.DB.
+		Model(&vmModels.VM{}).
+		Where("rid = ?", rid).
+		Select("SSHKeys", "Tags").
+		Updates(&vmModels.VM{SSHKeys: keys, Tags: normalizedTags}).Error; err != nil {
+		return fmt.Errorf("failed_to_update_guest_metadata_in_db: %w", err)
+	}
+
+	if err := s.WriteVMJson(rid); err != nil {
+		logger.L.Error().Err(err).Msg("Failed to write VM JSON after guest metadata modification")
+	}
+
+	return nil
+}
+
+// GuestMetadataForAddress identifies the VM behind a metadata request. The
+// source address must resolve, through the ARP table, to a MAC that belongs
+// to one of the VM's NICs on a standard switch, and the entry must have been
+// learned on that switch's bridge so a guest cannot read another guest's data
+// by spoofing its address from a different segment. The bridge must also
+// have learned the MAC on a guest port: a standard switch bridges its uplink,
+// so a LAN host spoofing a guest's address and MAC shows up on an uplink
+// member and is refused.
+func (s *Service) GuestMetadataForAddress(remoteIP string) (libvirtServiceInterfaces.GuestMetadata, error) {
+	var meta libvirtServiceInterfaces.GuestMetadata
+
+	ip := net.ParseIP(strings.TrimSpace(remoteIP))
+	if ip == nil || ip.To4() == nil {
+		return meta, fmt.Errorf("guest_metadata_requires_ipv4_source")
+	}
+
+	entry, err := lookupARPEntry(ip.String())
+	if err != nil {
+		return meta, err
+	}
+
+	var networks []vmModels.Network
+	if err := s.DB.
+		Session(&gorm.Session{SkipHooks: true}).
+		Model(&vmModels.Network{}).
+		Joins("LEFT JOIN objects ON vm_networks.mac_id = objects.id").
+		Joins("LEFT JOIN object_entries ON object_entries.object_id = objects.id").
+		Where("LOWER(object_entries.value) = ? OR LOWER(vm_networks.mac) = ?", entry.MAC, entry.MAC).
+		Where("vm_networks.switch_type = ?", "standard").
+		Distinct("vm_networks.id", "vm_networks.vm_id", "vm_networks.switch_id").
+		Find(&networks).Error; err != nil {
+		return meta, fmt.Errorf("failed_to_find_vm_networks: %w", err)
+	}
+
+	var vmID uint
+	var vmSwitch networkModels.StandardSwitch
+	for _, network := range networks {
+		var sw networkModels.StandardSwitch
+		if err := s.DB.Preload("Ports").Select("id", "bridge_name", "vlan").First(&sw, network.SwitchID).Error; err != nil {
+			continue
+		}
+		if sw.BridgeName != entry.Interface {
+			continue
+		}
+		if vmID != 0 && vmID != network.VMID {
+			return meta, fmt.Errorf("guest_metadata_mac_ambiguous")
+		}
+		vmID = network.VMID
+		vmSwitch = sw
+	}
+
+	if vmID == 0 {
+		return meta, fmt.Errorf("guest_not_found")
+	}
+
+	member, err := lookupBridgeMember(vmSwitch.BridgeName, entry.MAC)
+	if err != nil {
+		return meta, err
+	}
+	for _, port := range vmSwitch.Ports {
+		if member == port.Name || (vmSwitch.VLAN > 0 && member == fmt.Sprintf("%s.%d", port.Name, vmSwitch.VLAN)) {
+			return meta, fmt.Errorf("guest_metadata_source_on_uplink")
+		}
+	}
+
+	var vm vmModels.VM
+	if err := s.DB.First(&vm, vmID).Error; err != nil {
+		return meta, fmt.Errorf("failed_to_find_vm: %w", err)
+	}
+
+	meta = libvirtServiceInterfaces.GuestMetadata{
+		RID:           vm.RID,
+		InstanceID:    fmt.Sprintf("sylve-%d", vm.RID),
+		Hostname:      guestHostname(vm),
+		SSHKeys:       append([]string{}, vm.SSHKeys...),
+		Tags:          append([]string{}, vm.Tags...),
+		UserData:      vm.CloudInitData,
+		NetworkConfig: vm.CloudInitNetworkConfig,
+	}
+
+	meta.MetaData, err = renderGuestMetaData(meta, vm.CloudInitMetaData)
+	if err != nil {
+		return meta, err
+	}
+
+	return meta, nil
+}
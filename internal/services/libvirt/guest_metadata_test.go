@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package libvirt
+
+import (
+	"strings"
+	"testing"
+
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+	"github.com/alchemillahq/sylve/internal/testutil"
+)
+
+func TestParseARPOutput(t *testing.T) {
+	entry, err := parseARPOutput("? (10.0.0.5) at 58:9C:FC:00:00:01 on bridge0 expires in 1195 seconds [ethernet]\n")
+	if err != nil {
+		t.Fatalf("parseARPOutput returned error: %v", err)
+	}
+	if entry.MAC != "58:9c:fc:00:00:01" || entry.Interface != "bridge0" {
+		t.Fatalf("unexpected entry %+v", entry)
+	}
+
+	if _, err := parseARPOutput("? (10.0.0.6) at (incomplete) on bridge0 expired [ethernet]"); err == nil {
+		t.Fatal("expected incomplete entries to be rejected")
+	}
+	if _, err := parseARPOutput("10.0.0.7 (10.0.0.7) -- no entry"); err == nil {
+		t.Fatal("expected missing entries to be rejected")
+	}
+}
+
+func TestParseBridgeAddrOutput(t *testing.T) {
+	output := "58:9c:fc:00:00:02 Vlan1 em0 1100 flags=0<>\n58:9c:fc:00:00:01 Vlan1 tap0 1188 flags=0<>\n"
+
+	member, err := parseBridgeAddrOutput(output, "58:9C:FC:00:00:01")
+	if err != nil {
+		t.Fatalf("parseBridgeAddrOutput returned error: %v", err)
+	}
+	if member != "tap0" {
+		t.Fatalf("expected tap0, got %q", member)
+	}
+
+	if _, err := parseBridgeAddrOutput(output, "58:9c:fc:00:00:03"); err == nil {
+		t.Fatal("expected unknown MACs to be rejected")
+	}
+}
+
+func TestRenderGuestMetaDataKeepsOperatorValues(t *testing.T) {
+	meta := libvirtServiceInterfaces.GuestMetadata{
+		InstanceID: "sylve-100",
+		Hostname:   "web-01",
+		SSHKeys:    []string{"ssh-ed25519 AAAA"},
+		Tags:       []string{"prod"},
+	}
+
+	out, err := renderGuestMetaData(meta, "instance-id: custom\n")
+	if err != nil {
+		t.Fatalf("renderGuestMetaData returned error: %v", err)
+	}
+
+	for _, want := range []string{"instance-id: custom", "local-hostname: web-01", "public-keys:", "- prod"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in rendered meta-data:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "sylve-100") {
+		t.Fatalf("operator instance-id must win:\n%s", out)
+	}
+}
+
+func TestGuestMetadataForAddressRequiresMatchingBridge(t *testing.T) {
+	db := testutil.NewSQLiteTestDB(
+		t,
+		&vmModels.VM{},
+		&vmModels.Network{},
+		&networkModels.Object{},
+		&networkModels.ObjectEntry{},
+		&networkModels.StandardSwitch{},
+		&networkModels.NetworkPort{},
+	)
+
+	vm := vmModels.VM{Name: "Web 01", RID: 100, Tags: []string{"prod"}}
+	if err := db.Create(&vm).Error; err != nil {
+		t.Fatalf("failed to seed VM: %v", err)
+	}
+	sw := networkModels.StandardSwitch{Name: "lan", BridgeName: "bridge1"}
+	if err := db.Create(&sw).Error; err != nil {
+		t.Fatalf("failed to seed switch: %v", err)
+	}
+	if err := db.Create(&networkModels.NetworkPort{Name: "em0", SwitchID: sw.ID}).Error; err != nil {
+		t.Fatalf("failed to seed switch port: %v", err)
+	}
+	if err := db.Create(&vmModels.Network{
+		VMID:       vm.ID,
+		MAC:        "58:9c:fc:00:00:01",
+		SwitchID:   sw.ID,
+		SwitchType: "standard",
+	}).Error; err != nil {
+		t.Fatalf("failed to seed VM network: %v", err)
+	}
+
+	previous := lookupARPEntry
+	previousMember := lookupBridgeMember
+	t.Cleanup(func() {
+		lookupARPEntry = previous
+		lookupBridgeMember = previousMember
+	})
+
+	svc := &Service{DB: db}
+
+	member := "tap0"
+	lookupBridgeMember = func(bridge, mac string) (string, error) {
+		if bridge != "bridge1" || mac != "58:9c:fc:00:00:01" {
+			t.Fatalf("unexpected bridge lookup %s %s", bridge, mac)
+		}
+		return member, nil
+	}
+
+	lookupARPEntry = func(ip string) (arpEntry, error) {
+		return arpEntry{MAC: "58:9c:fc:00:00:01", Interface: "bridge1"}, nil
+	}
+	meta, err := svc.GuestMetadataForAddress("10.0.0.5")
+	if err != nil {
+		t.Fatalf("GuestMetadataForAddress returned error: %v", err)
+	}
+	if meta.RID != 100 || meta.Hostname != "web-01" || meta.InstanceID != "sylve-100" {
+		t.Fatalf("unexpected metadata %+v", meta)
+	}
+
+	// A LAN host spoofing the guest's address and MAC is learned on the
+	// uplink, even though the ARP entry still names the switch's bridge.
+	member = "em0"
+	if _, err := svc.GuestMetadataForAddress("10.0.0.5"); err == nil || err.Error() != "guest_metadata_source_on_uplink" {
+		t.Fatalf("expected a request from the uplink member to be rejected, got %v", err)
+	}
+	member = "tap0"
+
+	lookupARPEntry = func(ip string) (arpEntry, error) {
+		return arpEntry{MAC: "58:9c:fc:00:00:01", Interface: "bridge2"}, nil
+	}
+	if _, err := svc.GuestMetadataForAddress("10.0.0.5"); err == nil {
+		t.Fatal("expected a MAC seen on another bridge to be rejected")
+	}
+
+	if _, err := svc.GuestMetadataForAddress("fe80::1"); err == nil {
+		t.Fatal("expected ipv6 sources to be rejected")
+	}
+}
+
+func TestNormalizeGuestSSHKeysRejectsGarbage(t *testing.T) {
+	if _, err := normalizeGuestSSHKeys([]string{"not a key"}); err == nil {
+		t.Fatal("expected invalid keys to be rejected")
+	}
+
+	tags, err := normalizeGuestTags([]string{" prod ", "", "prod", "web"})
+	if err != nil {
+		t.Fatalf("normalizeGuestTags returned error: %v", err)
+	}
+	if strings.Join(tags, ",") != "prod,web" {
+		t.Fatalf("unexpected tags %v", tags)
+	}
+}
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package libvirt
+
+import (
+	"os"
+	"slices"
+
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+)
+
+const swtpmBinaryPath = "/usr/local/bin/swtpm"
+
+// Overridden in tests.
+var hostFileExists = func(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// BootROMAvailable reports whether this host can boot a guest with the given
+// boot ROM: the architecture has to offer it and its firmware has to be
+// installed.
+func BootROMAvailable(bootROM vmModels.VMBootROM) bool {
+	normalized := normalizeBootROMValue(bootROM)
+	if !slices.Contains(availableBootROMs(), normalized) {
+		return false
+	}
+
+	switch normalized {
+	case vmModels.VMBootROMUEFI:
+		return hostFileExists(uefiFirmwarePath)
+	case vmModels.VMBootROMUBoot:
+		return hostFileExists(ubootFirmwarePath)
+	default:
+		return true
+	}
+}
+
+// TPMAvailable reports whether swtpm is installed, which every guest with TPM
+// emulation needs to start.
+func TPMAvailable() bool {
+	return hostFileExists(swtpmBinaryPath)
+}
+
+// UnsupportedCPUFeatures returns the features this host cannot give a guest,
+// either because bhyve has no such toggle or because the CPU lacks hardware
+// virtualization.
+func UnsupportedCPUFeatures(features []string) []string {
+	unsupported := make([]string, 0)
+	virtualization := hostSupportsVirtualization()
+
+	for _, name := range normalizeCPUFeatures(features) {
+		if _, ok := lookupCPUFeature(name); !ok || !virtualization {
+			unsupported = append(unsupported, name)
+		}
+	}
+
+	return unsupported
+}
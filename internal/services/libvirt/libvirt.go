@@ -13,6 +13,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -26,7 +27,9 @@ import (
 	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
 	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
 	systemServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/system"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/internal/services/snapshotschedule"
 
 	"github.com/digitalocean/go-libvirt"
 	"gorm.io/gorm"
@@ -54,6 +57,15 @@ type Service struct {
 
 	guestIdentityAvailabilityChecker clusterServiceInterfaces.GuestIdentityAvailabilityChecker
 
+	flowMu      sync.Mutex
+	flowSamples map[uint]*vmFlowSample
+
+	liveStatsMu          sync.Mutex
+	liveStatsHubs        map[uint]*vmLiveStatsHub
+	liveStatsSubscribers int
+
+	snapshotSchedules snapshotschedule.Reservations
+
 	preflightCreateVMTemplateFn func(
 		ctx context.Context,
 		templateID uint,
@@ -113,6 +125,20 @@ func (s *Service) CheckVersion() error {
 	return err
 }
 
+// libvirtSocketPath is the control socket behind bhyve:///system.
+const libvirtSocketPath = "/var/run/libvirt/libvirt-sock"
+
+// PingSocket dials libvirtd's control socket without touching the shared
+// connection, so health probes never reconnect underneath VM operations.
+func (s *Service) PingSocket(ctx context.Context) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", libvirtSocketPath)
+	if err != nil {
+		return fmt.Errorf("libvirt_socket_unreachable: %w", err)
+	}
+	return conn.Close()
+}
+
 func validateLibvirtVersion(version uint64) error {
 	if version >= minimumLibvirtVersion {
 		return nil
@@ -318,7 +344,7 @@ func (s *Service) WriteVMJson(rid uint) error {
 	}
 
 	for _, pool := range vmJSONOutputPools(vm.Storages) {
-		sylveDir := fmt.Sprintf("/%s/sylve/virtual-machines/%d/.sylve", pool, rid)
+		sylveDir := fmt.Sprintf("/%s/%d/.sylve", layout.VMsDataset(pool), rid)
 		vmJsonPath := filepath.Join(sylveDir, "vm.json")
 
 		if err := os.MkdirAll(sylveDir, 0755); err != nil {
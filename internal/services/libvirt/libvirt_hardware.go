@@ -17,6 +17,7 @@ import (
 
 	"github.com/alchemillahq/sylve/internal/db/models"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
 	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
 	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/pkg/utils"
@@ -602,7 +603,7 @@ func (s *Service) ModifyVNC(rid uint, req libvirtServiceInterfaces.ModifyVNCRequ
 	if vm.VNCPort == vncPort &&
 		vm.VNCBind == vncBind &&
 		vm.VNCResolution == req.VNCResolution &&
-		vm.VNCPassword == req.VNCPassword &&
+		string(vm.VNCPassword) == req.VNCPassword &&
 		vm.VNCWait == vncWait &&
 		vm.VNCEnabled == vncEnabled {
 		return fmt.Errorf("no_changes_detected: %d", rid)
@@ -625,7 +626,7 @@ func (s *Service) ModifyVNC(rid uint, req libvirtServiceInterfaces.ModifyVNCRequ
 	vm.VNCPort = vncPort
 	vm.VNCBind = vncBind
 	vm.VNCResolution = req.VNCResolution
-	vm.VNCPassword = req.VNCPassword
+	vm.VNCPassword = secrets.String(req.VNCPassword)
 	vm.VNCWait = vncWait
 
 	if err := s.DB.Save(&vm).Error; err != nil {
@@ -21,6 +21,7 @@ import (
 	"github.com/alchemillahq/gzfs"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
 	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/pkg/utils"
 
@@ -156,9 +157,9 @@ func (s *Service) CreateVMDisk(rid uint, storage vmModels.Storage, ctx context.C
 		if datasetName == "" {
 			switch storage.Type {
 			case vmModels.VMStorageTypeRaw:
-				datasetName = fmt.Sprintf("%s/sylve/virtual-machines/%d/raw-%d", target.Name, rid, storage.ID)
+				datasetName = fmt.Sprintf("%s/%d/raw-%d", layout.VMsDataset(target.Name), rid, storage.ID)
 			case vmModels.VMStorageTypeZVol:
-				datasetName = fmt.Sprintf("%s/sylve/virtual-machines/%d/zvol-%d", target.Name, rid, storage.ID)
+				datasetName = fmt.Sprintf("%s/%d/zvol-%d", layout.VMsDataset(target.Name), rid, storage.ID)
 			}
 		}
 		switch storage.Type {
@@ -444,8 +445,8 @@ func (s *Service) syncVMDisksWithDB(db *gorm.DB, rid uint) error {
 				rawID := storageIDFromDataset(storage.Dataset.Name, "raw")
 				diskValue = fmt.Sprintf("/%s/%d.img", storage.Dataset.Name, rawID)
 			} else {
-				diskValue = fmt.Sprintf("/%s/sylve/virtual-machines/%d/raw-%d/%d.img",
-					storage.Pool,
+				diskValue = fmt.Sprintf("/%s/%d/raw-%d/%d.img",
+					layout.VMsDataset(storage.Pool),
 					rid,
 					storage.ID,
 					storage.ID,
@@ -455,8 +456,8 @@ func (s *Service) syncVMDisksWithDB(db *gorm.DB, rid uint) error {
 			if storage.Dataset.Name != "" {
 				diskValue = "/dev/zvol/" + storage.Dataset.Name
 			} else {
-				diskValue = fmt.Sprintf("/dev/zvol/%s/sylve/virtual-machines/%d/zvol-%d",
-					storage.Pool,
+				diskValue = fmt.Sprintf("/dev/zvol/%s/%d/zvol-%d",
+					layout.VMsDataset(storage.Pool),
 					rid,
 					storage.ID,
 				)
@@ -562,15 +563,15 @@ func (s *Service) RemoveStorageXML(rid uint, storage vmModels.Storage) error {
 			return fmt.Errorf("failed_to_find_iso_by_uuid: %w", err)
 		}
 	} else if storage.Type == vmModels.VMStorageTypeRaw {
-		filePath = fmt.Sprintf("%s/sylve/virtual-machines/%d/raw-%d/%d.img",
-			storage.Pool,
+		filePath = fmt.Sprintf("%s/%d/raw-%d/%d.img",
+			layout.VMsDataset(storage.Pool),
 			rid,
 			storage.ID,
 			storage.ID,
 		)
 	} else if storage.Type == vmModels.VMStorageTypeZVol {
-		filePath = fmt.Sprintf("%s/sylve/virtual-machines/%d/zvol-%d",
-			storage.Pool,
+		filePath = fmt.Sprintf("%s/%d/zvol-%d",
+			layout.VMsDataset(storage.Pool),
 			rid,
 			storage.ID,
 		)
@@ -678,10 +679,10 @@ func (s *Service) destroyManagedStorageDataset(ctx context.Context, rid uint, st
 		switch storage.Type {
 		case vmModels.VMStorageTypeRaw:
 			datasetType = gzfs.DatasetTypeFilesystem
-			datasetPath = fmt.Sprintf("%s/sylve/virtual-machines/%d/raw-%d", storage.Pool, rid, storage.ID)
+			datasetPath = fmt.Sprintf("%s/%d/raw-%d", layout.VMsDataset(storage.Pool), rid, storage.ID)
 		case vmModels.VMStorageTypeZVol:
 			datasetType = gzfs.DatasetTypeVolume
-			datasetPath = fmt.Sprintf("%s/sylve/virtual-machines/%d/zvol-%d", storage.Pool, rid, storage.ID)
+			datasetPath = fmt.Sprintf("%s/%d/zvol-%d", layout.VMsDataset(storage.Pool), rid, storage.ID)
 		default:
 			return nil
 		}
@@ -944,8 +945,8 @@ func (s *Service) storageImportTx(
 		}
 		createdManagedDataset = true
 
-		datasetPath := fmt.Sprintf("/%s/sylve/virtual-machines/%d/raw-%d/%d.img",
-			storage.Pool,
+		datasetPath := fmt.Sprintf("/%s/%d/raw-%d/%d.img",
+			layout.VMsDataset(storage.Pool),
 			vm.RID,
 			storage.ID,
 			storage.ID,
@@ -1017,8 +1018,8 @@ func (s *Service) storageImportTx(
 		createdStorageRecord = true
 
 		if sourcePool == *req.Pool {
-			targetDatasetPath := fmt.Sprintf("%s/sylve/virtual-machines/%d/zvol-%d",
-				*req.Pool,
+			targetDatasetPath := fmt.Sprintf("%s/%d/zvol-%d",
+				layout.VMsDataset(*req.Pool),
 				vm.RID,
 				storage.ID,
 			)
@@ -1068,8 +1069,8 @@ func (s *Service) storageImportTx(
 				}
 			}()
 
-			targetDatasetPath := fmt.Sprintf("%s/sylve/virtual-machines/%d/zvol-%d",
-				*req.Pool,
+			targetDatasetPath := fmt.Sprintf("%s/%d/zvol-%d",
+				layout.VMsDataset(*req.Pool),
 				vm.RID,
 				storage.ID,
 			)
@@ -1197,8 +1198,8 @@ func (s *Service) storageNewTx(
 		}
 		createdManagedDataset = true
 
-		diskPath := fmt.Sprintf("/%s/sylve/virtual-machines/%d/raw-%d/%d.img",
-			storage.Pool,
+		diskPath := fmt.Sprintf("/%s/%d/raw-%d/%d.img",
+			layout.VMsDataset(storage.Pool),
 			vm.RID,
 			storage.ID,
 			storage.ID,
@@ -1358,6 +1359,12 @@ func (s *Service) StorageAttach(req libvirtServiceInterfaces.StorageAttachReques
 		}
 	}
 
+	if strings.TrimSpace(req.UUID) != "" {
+		if err := s.requireAttachableImage(req.UUID); err != nil {
+			return err
+		}
+	}
+
 	if req.StorageType != libvirtServiceInterfaces.StorageTypeDiskImage {
 		if req.StorageType == libvirtServiceInterfaces.StorageTypeRaw ||
 			req.StorageType == libvirtServiceInterfaces.StorageTypeZVOL {
@@ -1456,8 +1463,8 @@ func (s *Service) StorageUpdate(req libvirtServiceInterfaces.StorageUpdateReques
 
 		switch current.Type {
 		case vmModels.VMStorageTypeRaw:
-			imagePath := fmt.Sprintf("/%s/sylve/virtual-machines/%d/raw-%d/%d.img",
-				current.Pool,
+			imagePath := fmt.Sprintf("/%s/%d/raw-%d/%d.img",
+				layout.VMsDataset(current.Pool),
 				vm.RID,
 				current.ID,
 				current.ID,
@@ -1560,7 +1567,7 @@ func (s *Service) CreateStorageParent(rid uint, poolName string, ctx context.Con
 			continue
 		}
 
-		target := fmt.Sprintf("%s/sylve/virtual-machines/%d", pool.Name, rid)
+		target := fmt.Sprintf("%s/%d", layout.VMsDataset(pool.Name), rid)
 		datasets, _ := s.GZFS.ZFS.ListByType(
 			ctx,
 			gzfs.DatasetTypeFilesystem,
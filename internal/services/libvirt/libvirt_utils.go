@@ -21,6 +21,7 @@ import (
 	utilitiesModels "github.com/alchemillahq/sylve/internal/db/models/utilities"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
 	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/internal/logger"
 	qemuimg "github.com/alchemillahq/sylve/pkg/qemu-img"
 	"github.com/alchemillahq/sylve/pkg/utils"
@@ -115,6 +116,31 @@ func domainReasonToString(state libvirt.DomainState, reason int32) libvirtServic
 	}
 }
 
+// requireAttachableImage refuses to attach a download whose verification
+// failed, and with guests.requireVerifiedImages set, one that was never
+// verified. Images already attached keep booting; this only guards new ones.
+func (s *Service) requireAttachableImage(uuid string) error {
+	var download utilitiesModels.Downloads
+	if err := s.DB.
+		Select("id", "verification", "verification_error").
+		Where("uuid = ?", uuid).
+		First(&download).Error; err != nil {
+		return fmt.Errorf("failed_to_find_download: %w", err)
+	}
+
+	switch download.Verification {
+	case utilitiesModels.DownloadVerificationVerified:
+		return nil
+	case utilitiesModels.DownloadVerificationFailed:
+		return fmt.Errorf("image_verification_failed: %s", download.VerificationError)
+	}
+
+	if config.ParsedConfig != nil && config.ParsedConfig.Guests.RequireVerifiedImages {
+		return fmt.Errorf("image_not_verified: %s", uuid)
+	}
+	return nil
+}
+
 func (s *Service) FindISOByUUID(uuid string, includeImg bool) (string, error) {
 	var download utilitiesModels.Downloads
 	if err := s.DB.
@@ -371,6 +397,51 @@ func (s *Service) IsDomainShutOff(rid uint) (bool, error) {
 	return false, nil
 }
 
+// UndefineUnmanagedDomain removes a stopped libvirt domain that no VM record
+// owns, typically one left behind by an interrupted delete or restore.
+func (s *Service) UndefineUnmanagedDomain(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("domain_name_required")
+	}
+
+	if err := s.requireConnection(); err != nil {
+		return err
+	}
+
+	if rid, err := strconv.ParseUint(name, 10, 64); err == nil {
+		var count int64
+		if err := s.DB.Model(&vmModels.VM{}).Where("rid = ?", rid).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed_to_check_domain_owner: %w", err)
+		}
+		if count > 0 {
+			return fmt.Errorf("domain_owned_by_vm: %s", name)
+		}
+	}
+
+	domain, err := s.conn().DomainLookupByName(name)
+	if err != nil {
+		if libvirt.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed_to_lookup_domain_by_name: %w", err)
+	}
+
+	state, _, err := s.conn().DomainGetState(domain, 0)
+	if err != nil {
+		return fmt.Errorf("failed_to_get_domain_state: %w", err)
+	}
+	if state != int32(libvirt.DomainShutoff) {
+		return fmt.Errorf("domain_not_shut_off: %s", name)
+	}
+
+	if err := s.conn().DomainUndefine(domain); err != nil {
+		return fmt.Errorf("failed_to_undefine_domain: %w", err)
+	}
+
+	return nil
+}
+
 func (s *Service) IsDomainShutOffByID(id uint) (bool, error) {
 	var rid uint
 	if err := s.DB.Model(&vmModels.VM{}).
@@ -725,8 +796,8 @@ func (s *Service) FlashCloudInitMediaToDisk(vm vmModels.VM) error {
 
 	if diskStorage.Type == vmModels.VMStorageTypeRaw {
 		storagePath = fmt.Sprintf(
-			"/%s/sylve/virtual-machines/%d/raw-%d/%d.img",
-			diskStorage.Dataset.Pool,
+			"/%s/%d/raw-%d/%d.img",
+			layout.VMsDataset(diskStorage.Dataset.Pool),
 			vm.RID,
 			diskStorage.ID,
 			diskStorage.ID,
@@ -737,8 +808,8 @@ func (s *Service) FlashCloudInitMediaToDisk(vm vmModels.VM) error {
 		}
 	} else if diskStorage.Type == vmModels.VMStorageTypeZVol {
 		storagePath = fmt.Sprintf(
-			"/dev/zvol/%s/sylve/virtual-machines/%d/zvol-%d",
-			diskStorage.Dataset.Pool,
+			"/dev/zvol/%s/%d/zvol-%d",
+			layout.VMsDataset(diskStorage.Dataset.Pool),
 			vm.RID,
 			diskStorage.ID,
 		)
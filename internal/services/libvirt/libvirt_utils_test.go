@@ -16,6 +16,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/alchemillahq/sylve/internal"
 	"github.com/alchemillahq/sylve/internal/config"
 	utilitiesModels "github.com/alchemillahq/sylve/internal/db/models/utilities"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
@@ -23,6 +24,41 @@ import (
 	qemuimg "github.com/alchemillahq/sylve/pkg/qemu-img"
 )
 
+func TestRequireAttachableImage_StrictModeRefusesUnverified(t *testing.T) {
+	db := testutil.NewSQLiteTestDB(t, &utilitiesModels.Downloads{}, &utilitiesModels.DownloadedFile{})
+	svc := &Service{DB: db}
+
+	prevConfig := config.ParsedConfig
+	t.Cleanup(func() { config.ParsedConfig = prevConfig })
+	config.ParsedConfig = &internal.SylveConfig{}
+
+	for _, d := range []utilitiesModels.Downloads{
+		{UUID: "plain", Verification: utilitiesModels.DownloadVerificationUnverified},
+		{UUID: "checked", Verification: utilitiesModels.DownloadVerificationVerified},
+		{UUID: "bad", Verification: utilitiesModels.DownloadVerificationFailed, VerificationError: "checksum_mismatch"},
+	} {
+		d.Path, d.Name, d.URL, d.Type = "/downloads/"+d.UUID, d.UUID, "https://example.com/"+d.UUID, utilitiesModels.DownloadTypeHTTP
+		if err := db.Create(&d).Error; err != nil {
+			t.Fatalf("failed to seed download: %v", err)
+		}
+	}
+
+	if err := svc.requireAttachableImage("plain"); err != nil {
+		t.Fatalf("expected an unverified image to attach outside strict mode, got %v", err)
+	}
+	if err := svc.requireAttachableImage("bad"); err == nil || !strings.HasPrefix(err.Error(), "image_verification_failed") {
+		t.Fatalf("expected a failed image to be refused, got %v", err)
+	}
+
+	config.ParsedConfig.Guests.RequireVerifiedImages = true
+	if err := svc.requireAttachableImage("plain"); err == nil || !strings.HasPrefix(err.Error(), "image_not_verified") {
+		t.Fatalf("expected strict mode to refuse an unverified image, got %v", err)
+	}
+	if err := svc.requireAttachableImage("checked"); err != nil {
+		t.Fatalf("expected a verified image to attach in strict mode, got %v", err)
+	}
+}
+
 func TestFindISOByUUID_HTTPType_ResolvesRawImageWithoutExtensionUsingProbe(t *testing.T) {
 	t.Setenv("SYLVE_DATA_PATH", t.TempDir())
 
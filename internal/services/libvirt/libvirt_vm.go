@@ -30,6 +30,7 @@ import (
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
 	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
 	systemServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/system"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/internal/logger"
 	clusterService "github.com/alchemillahq/sylve/internal/services/cluster"
 	"github.com/alchemillahq/sylve/pkg/utils"
@@ -91,6 +92,14 @@ func (s *Service) CreateVmXML(vm vmModels.VM, vmPath string) (string, error) {
 		})
 	}
 
+	for _, arg := range cpuFeatureBhyveArgs(vm.CPUFeatures, vm.ExtraBhyveOptions) {
+		bhyveArgs = append(bhyveArgs, []libvirtServiceInterfaces.BhyveArg{
+			{
+				Value: arg,
+			},
+		})
+	}
+
 	/* Why does this fail with:
 	bhyve: invalid lpc device configuration ' tpm,swtpm,/root/Projects/Sylve/data/vms/100/100_tpm.socket'
 	when I have a space between "-l" and "tpm"
@@ -124,13 +133,13 @@ func (s *Service) CreateVmXML(vm vmModels.VM, vmPath string) (string, error) {
 					rawID := storageIDFromDataset(storage.Dataset.Name, "raw")
 					disk = fmt.Sprintf("/%s/%d.img", storage.Dataset.Name, rawID)
 				} else {
-					disk = fmt.Sprintf("/%s/sylve/virtual-machines/%d/raw-%d/%d.img", storage.Pool, vm.RID, storage.ID, storage.ID)
+					disk = fmt.Sprintf("/%s/%d/raw-%d/%d.img", layout.VMsDataset(storage.Pool), vm.RID, storage.ID, storage.ID)
 				}
 			} else if storage.Type == vmModels.VMStorageTypeZVol {
 				if storage.Dataset.Name != "" {
 					disk = "/dev/zvol/" + storage.Dataset.Name
 				} else {
-					disk = fmt.Sprintf("/dev/zvol/%s/sylve/virtual-machines/%d/zvol-%d", storage.Pool, vm.RID, storage.ID)
+					disk = fmt.Sprintf("/dev/zvol/%s/%d/zvol-%d", layout.VMsDataset(storage.Pool), vm.RID, storage.ID)
 				}
 			} else if storage.Type == vmModels.VMStorageTypeDiskImage {
 				var err error
@@ -369,7 +378,7 @@ func (s *Service) CreateVmXML(vm vmModels.VM, vmPath string) (string, error) {
 		domain.Devices.Graphics = &libvirtServiceInterfaces.Graphics{
 			Type:     "vnc",
 			Port:     fmt.Sprintf("%d", vm.VNCPort),
-			Password: vm.VNCPassword,
+			Password: string(vm.VNCPassword),
 			Wait:     vncWait,
 			Listen: libvirtServiceInterfaces.GraphicsListen{
 				Type:    "address",
@@ -833,6 +842,12 @@ func (s *Service) lvVMAction(vm vmModels.VM, action, transitionRunID string) err
 	}
 
 	if action == "start" || action == "reboot" {
+		if s.System != nil && len(vm.PCIDevices) > 0 {
+			if err := s.System.VerifyPPTDevices(vm.PCIDevices); err != nil {
+				return err
+			}
+		}
+
 		if err := s.CheckPCIDevicesInUse(vm); err != nil {
 			return err
 		}
@@ -1550,7 +1565,7 @@ func (s *Service) MigrateVNCToNativeFormat() error {
 			continue
 		}
 
-		newXML, err := updateVNC(xml, vm.VNCPort, vm.VNCBind, vm.VNCResolution, vm.VNCPassword, vm.VNCWait, true)
+		newXML, err := updateVNC(xml, vm.VNCPort, vm.VNCBind, vm.VNCResolution, string(vm.VNCPassword), vm.VNCWait, true)
 		if err != nil {
 			logger.L.Warn().Uint("rid", vm.RID).Err(err).Msg("vnc_migration: failed to update VNC XML")
 			continue
@@ -78,6 +78,10 @@ func qgaCallRaw(conn net.Conn, enc *json.Encoder, dec *json.Decoder, cmd string,
 }
 
 func (s *Service) RunQemuGuestAgentCommand(rid uint, cmd string) (json.RawMessage, error) {
+	return s.runQemuGuestAgentCommand(rid, cmd, nil)
+}
+
+func (s *Service) runQemuGuestAgentCommand(rid uint, cmd string, args any) (json.RawMessage, error) {
 	command := strings.TrimSpace(cmd)
 	if command == "" {
 		return nil, fmt.Errorf("qga_command_required")
@@ -101,7 +105,7 @@ func (s *Service) RunQemuGuestAgentCommand(rid uint, cmd string) (json.RawMessag
 		return nil, fmt.Errorf("failed_to_lookup_domain_for_qga: %w", err)
 	}
 
-	request, err := json.Marshal(qgaRequest{Execute: command})
+	request, err := json.Marshal(qgaRequest{Execute: command, Arguments: args})
 	if err != nil {
 		return nil, fmt.Errorf("failed_to_encode_qga_command: %w", err)
 	}
@@ -117,10 +121,10 @@ func (s *Service) RunQemuGuestAgentCommand(rid uint, cmd string) (json.RawMessag
 		return nil, fmt.Errorf("failed_to_run_qga_command: %w", err)
 	}
 
-	return s.runLegacyQemuGuestAgentCommand(vm.RID, command)
+	return s.runLegacyQemuGuestAgentCommand(vm.RID, command, args)
 }
 
-func (s *Service) runLegacyQemuGuestAgentCommand(rid uint, command string) (json.RawMessage, error) {
+func (s *Service) runLegacyQemuGuestAgentCommand(rid uint, command string, args any) (json.RawMessage, error) {
 	dataPath, err := s.GetVMConfigDirectory(rid)
 	if err != nil {
 		return nil, fmt.Errorf("failed_to_get_vm_data_path: %w", err)
@@ -136,7 +140,7 @@ func (s *Service) runLegacyQemuGuestAgentCommand(rid uint, command string) (json
 	enc := json.NewEncoder(conn)
 	dec := json.NewDecoder(conn)
 
-	return qgaCallRaw(conn, enc, dec, command, nil)
+	return qgaCallRaw(conn, enc, dec, command, args)
 }
 
 func (s *Service) GetQemuGuestAgentInfo(rid uint) (libvirtServiceInterfaces.QemuGuestAgentInfo, error) {
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package libvirt
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+	"github.com/alchemillahq/sylve/internal/logger"
+)
+
+const (
+	VMExecDefaultTimeout = time.Minute
+	VMExecMaxTimeout     = time.Hour
+
+	qgaExecPollInterval = 500 * time.Millisecond
+)
+
+type qgaCallFunc func(command string, args any) (json.RawMessage, error)
+
+type qgaExecArgs struct {
+	Path          string   `json:"path"`
+	Arg           []string `json:"arg,omitempty"`
+	InputData     string   `json:"input-data,omitempty"`
+	CaptureOutput bool     `json:"capture-output"`
+}
+
+type qgaExecStarted struct {
+	PID int `json:"pid"`
+}
+
+type qgaExecStatus struct {
+	Exited       bool   `json:"exited"`
+	ExitCode     int    `json:"exitcode"`
+	Signal       int    `json:"signal"`
+	OutData      string `json:"out-data"`
+	ErrData      string `json:"err-data"`
+	OutTruncated bool   `json:"out-truncated"`
+	ErrTruncated bool   `json:"err-truncated"`
+}
+
+func validateVMExecRequest(req libvirtServiceInterfaces.VMExecRequest) (time.Duration, error) {
+	if len(req.Command) == 0 || strings.TrimSpace(req.Command[0]) == "" {
+		return 0, fmt.Errorf("exec_command_required")
+	}
+	if req.Timeout < 0 {
+		return 0, fmt.Errorf("exec_timeout_invalid")
+	}
+
+	timeout := time.Duration(req.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = VMExecDefaultTimeout
+	}
+	if timeout > VMExecMaxTimeout {
+		return 0, fmt.Errorf("exec_timeout_too_long")
+	}
+
+	return timeout, nil
+}
+
+func decodeQGAExecOutput(data string) (string, error) {
+	if data == "" {
+		return "", nil
+	}
+	out, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return "", fmt.Errorf("invalid_qga_exec_output: %w", err)
+	}
+	return string(out), nil
+}
+
+// runQGAExec starts a command with guest-exec and polls guest-exec-status
+// until it exits or timeout passes.
+func runQGAExec(
+	ctx context.Context,
+	call qgaCallFunc,
+	req libvirtServiceInterfaces.VMExecRequest,
+	timeout time.Duration,
+	poll time.Duration,
+) (libvirtServiceInterfaces.VMExecResult, error) {
+	var result libvirtServiceInterfaces.VMExecResult
+
+	args := qgaExecArgs{
+		Path:          req.Command[0],
+		Arg:           req.Command[1:],
+		CaptureOutput: true,
+	}
+	if req.Input != "" {
+		args.InputData = base64.StdEncoding.EncodeToString([]byte(req.Input))
+	}
+
+	raw, err := call("guest-exec", args)
+	if err != nil {
+		return result, fmt.Errorf("failed_to_start_guest_exec: %w", err)
+	}
+	var started qgaExecStarted
+	if err := json.Unmarshal(raw, &started); err != nil {
+		return result, fmt.Errorf("failed_to_decode_guest_exec: %w", err)
+	}
+	result.PID = started.PID
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+
+	for {
+		raw, err := call("guest-exec-status", qgaExecStarted{PID: started.PID})
+		if err != nil {
+			return result, fmt.Errorf("failed_to_get_guest_exec_status: %w", err)
+		}
+		var status qgaExecStatus
+		if err := json.Unmarshal(raw, &status); err != nil {
+			return result, fmt.Errorf("failed_to_decode_guest_exec_status: %w", err)
+		}
+
+		if status.Exited {
+			result.ExitCode = status.ExitCode
+			result.Signal = status.Signal
+			result.StdoutTruncated = status.OutTruncated
+			result.StderrTruncated = status.ErrTruncated
+			if result.Stdout, err = decodeQGAExecOutput(status.OutData); err != nil {
+				return result, err
+			}
+			if result.Stderr, err = decodeQGAExecOutput(status.ErrData); err != nil {
+				return result, err
+			}
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				result.TimedOut = true
+				return result, nil
+			}
+			return result, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// ExecInGuest runs a command inside a VM through its guest agent and waits
+// for it to finish. Every run is logged with its outcome, whether it came
+// from the API or from an internal caller such as the restore pipeline.
+func (s *Service) ExecInGuest(
+	ctx context.Context,
+	rid uint,
+	req libvirtServiceInterfaces.VMExecRequest,
+) (libvirtServiceInterfaces.VMExecResult, error) {
+	timeout, err := validateVMExecRequest(req)
+	if err != nil {
+		return libvirtServiceInterfaces.VMExecResult{}, err
+	}
+
+	started := time.Now()
+	call := func(command string, args any) (json.RawMessage, error) {
+		return s.runQemuGuestAgentCommand(rid, command, args)
+	}
+	result, err := runQGAExec(ctx, call, req, timeout, qgaExecPollInterval)
+
+	event := logger.L.Info()
+	if err != nil {
+		event = logger.L.Warn().Err(err)
+	}
+	event.Uint("rid", rid).
+		Strs("command", req.Command).
+		Int("pid", result.PID).
+		Int("exit_code", result.ExitCode).
+		Bool("timed_out", result.TimedOut).
+		Dur("duration", time.Since(started)).
+		Msg("vm_guest_exec")
+
+	return result, err
+}
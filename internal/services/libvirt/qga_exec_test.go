@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package libvirt
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+)
+
+func TestRunQGAExecPollsUntilExit(t *testing.T) {
+	polls := 0
+	call := func(command string, args any) (json.RawMessage, error) {
+		switch command {
+		case "guest-exec":
+			a := args.(qgaExecArgs)
+			if a.Path != "/bin/sh" || len(a.Arg) != 2 || !a.CaptureOutput {
+				t.Fatalf("unexpected guest-exec arguments %+v", a)
+			}
+			if input, _ := base64.StdEncoding.DecodeString(a.InputData); string(input) != "y\n" {
+				t.Fatalf("unexpected input %q", input)
+			}
+			return json.RawMessage(`{"pid":42}`), nil
+		case "guest-exec-status":
+			if args.(qgaExecStarted).PID != 42 {
+				t.Fatalf("unexpected status arguments %+v", args)
+			}
+			polls++
+			if polls < 3 {
+				return json.RawMessage(`{"exited":false}`), nil
+			}
+			return json.Marshal(qgaExecStatus{
+				Exited:   true,
+				ExitCode: 1,
+				OutData:  base64.StdEncoding.EncodeToString([]byte("out")),
+				ErrData:  base64.StdEncoding.EncodeToString([]byte("err")),
+			})
+		}
+		t.Fatalf("unexpected command %s", command)
+		return nil, nil
+	}
+
+	result, err := runQGAExec(context.Background(), call, libvirtServiceInterfaces.VMExecRequest{
+		Command: []string{"/bin/sh", "-c", "ssh-keygen -A"},
+		Input:   "y\n",
+	}, time.Minute, time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if polls != 3 || result.PID != 42 || result.ExitCode != 1 || result.Stdout != "out" || result.Stderr != "err" || result.TimedOut {
+		t.Fatalf("unexpected result %+v after %d polls", result, polls)
+	}
+}
+
+func TestRunQGAExecTimesOut(t *testing.T) {
+	call := func(command string, args any) (json.RawMessage, error) {
+		if command == "guest-exec" {
+			return json.RawMessage(`{"pid":7}`), nil
+		}
+		return json.RawMessage(`{"exited":false}`), nil
+	}
+
+	result, err := runQGAExec(context.Background(), call, libvirtServiceInterfaces.VMExecRequest{
+		Command: []string{"sleep", "600"},
+	}, 20*time.Millisecond, time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.TimedOut || result.PID != 7 {
+		t.Fatalf("expected a timed out result, got %+v", result)
+	}
+}
+
+func TestValidateVMExecRequest(t *testing.T) {
+	if _, err := validateVMExecRequest(libvirtServiceInterfaces.VMExecRequest{Command: []string{" "}}); err == nil {
+		t.Fatal("expected an empty command to be refused")
+	}
+	if _, err := validateVMExecRequest(libvirtServiceInterfaces.VMExecRequest{Command: []string{"id"}, Timeout: 7200}); err == nil {
+		t.Fatal("expected a timeout above the maximum to be refused")
+	}
+	if timeout, err := validateVMExecRequest(libvirtServiceInterfaces.VMExecRequest{Command: []string{"id"}}); err != nil || timeout != VMExecDefaultTimeout {
+		t.Fatalf("expected the default timeout, got %v, %v", timeout, err)
+	}
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package libvirt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	"github.com/alchemillahq/sylve/pkg/zfsdiff"
+)
+
+// DiffVMSnapshot runs `zfs diff` for every filesystem captured by a VM
+// snapshot, against either the live datasets or a later snapshot when
+// toSnapshotID is non-zero. Zvols cannot be diffed and are reported as
+// skipped.
+func (s *Service) DiffVMSnapshot(
+	ctx context.Context,
+	rid uint,
+	snapshotID uint,
+	toSnapshotID uint,
+) ([]zfsdiff.DatasetDiff, error) {
+	if rid == 0 || snapshotID == 0 {
+		return nil, fmt.Errorf("invalid_request")
+	}
+	if s == nil || s.GZFS == nil || s.GZFS.ZFS == nil {
+		return nil, fmt.Errorf("gzfs_not_initialized")
+	}
+
+	var from vmModels.VMSnapshot
+	if err := s.DB.
+		Where("rid = ? AND id = ?", rid, snapshotID).
+		First(&from).Error; err != nil {
+		return nil, fmt.Errorf("snapshot_not_found: %w", err)
+	}
+
+	toSnapshotName := ""
+	if toSnapshotID != 0 {
+		if toSnapshotID == snapshotID {
+			return nil, fmt.Errorf("diff_snapshots_must_differ")
+		}
+
+		var to vmModels.VMSnapshot
+		if err := s.DB.
+			Where("rid = ? AND id = ?", rid, toSnapshotID).
+			First(&to).Error; err != nil {
+			return nil, fmt.Errorf("target_snapshot_not_found: %w", err)
+		}
+		if to.CreatedAt.Before(from.CreatedAt) {
+			return nil, fmt.Errorf("target_snapshot_older_than_source")
+		}
+		toSnapshotName = to.SnapshotName
+	}
+
+	diffs := make([]zfsdiff.DatasetDiff, 0)
+	for _, root := range from.RootDatasets {
+		targets, err := s.listRecursiveRollbackTargets(ctx, root, from.SnapshotName)
+		if err != nil {
+			return nil, err
+		}
+
+		volumes, err := s.GZFS.ZFS.ListWithPrefix(ctx, "volume", root, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed_to_list_vm_volumes: %w", err)
+		}
+		isVolume := make(map[string]bool, len(volumes))
+		for _, volume := range volumes {
+			if volume != nil {
+				isVolume[volume.Name] = true
+			}
+		}
+
+		var toTargets map[string]bool
+		if toSnapshotName != "" {
+			names, err := s.listRecursiveRollbackTargets(ctx, root, toSnapshotName)
+			if err != nil {
+				return nil, err
+			}
+			toTargets = make(map[string]bool, len(names))
+			for _, name := range names {
+				toTargets[name] = true
+			}
+		}
+
+		for _, target := range targets {
+			dataset := strings.TrimSuffix(target, "@"+from.SnapshotName)
+			diff := zfsdiff.DatasetDiff{Dataset: dataset, From: target, To: dataset}
+			if toSnapshotName != "" {
+				diff.To = dataset + "@" + toSnapshotName
+			}
+
+			switch {
+			case isVolume[dataset]:
+				diff.Skipped = "volume_not_diffable"
+			case toTargets != nil && !toTargets[diff.To]:
+				diff.Skipped = "target_snapshot_missing"
+			default:
+				changes, truncated, err := zfsdiff.Diff(ctx, diff.From, diff.To, zfsdiff.DefaultLimit)
+				if err != nil {
+					return nil, fmt.Errorf("failed_to_diff_dataset %s: %w", dataset, err)
+				}
+				diff.Changes = changes
+				diff.Truncated = truncated
+			}
+
+			diffs = append(diffs, diff)
+		}
+	}
+
+	return diffs, nil
+}
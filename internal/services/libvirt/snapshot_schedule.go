@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package libvirt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	"github.com/alchemillahq/sylve/internal/services/snapshotschedule"
+	"gorm.io/gorm"
+)
+
+const vmSnapshotScheduleQueueName = "libvirt-vm-snapshot-schedule-run"
+
+type VMSnapshotScheduleInput = snapshotschedule.Input
+
+func (s *Service) GetVMSnapshotSchedule(rid uint) (*vmModels.VMSnapshotSchedule, error) {
+	if rid == 0 {
+		return nil, fmt.Errorf("invalid_rid")
+	}
+
+	var schedule vmModels.VMSnapshotSchedule
+	if err := s.DB.Where("rid = ?", rid).First(&schedule).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed_to_get_vm_snapshot_schedule: %w", err)
+	}
+
+	return &schedule, nil
+}
+
+// SetVMSnapshotSchedule creates or replaces the snapshot schedule of a VM.
+func (s *Service) SetVMSnapshotSchedule(rid uint, input VMSnapshotScheduleInput) (*vmModels.VMSnapshotSchedule, error) {
+	if rid == 0 {
+		return nil, fmt.Errorf("invalid_rid")
+	}
+	if err := snapshotschedule.ValidateInput(input); err != nil {
+		return nil, err
+	}
+
+	var vm vmModels.VM
+	if err := s.DB.Select("id", "rid").Where("rid = ?", rid).First(&vm).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_get_vm: %w", err)
+	}
+
+	var schedule vmModels.VMSnapshotSchedule
+	if err := s.DB.Where("vm_id = ?", vm.ID).First(&schedule).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed_to_get_vm_snapshot_schedule: %w", err)
+		}
+		schedule = vmModels.VMSnapshotSchedule{VMID: vm.ID, RID: vm.RID}
+	}
+
+	cronExpr := strings.TrimSpace(input.CronExpr)
+	if schedule.CronExpr != cronExpr || !input.Enabled {
+		schedule.NextRunAt = nil
+	}
+
+	schedule.CronExpr = cronExpr
+	schedule.MaxKeep = input.MaxKeep
+	schedule.NamePattern = strings.TrimSpace(input.NamePattern)
+	schedule.Enabled = input.Enabled
+
+	if err := s.DB.Save(&schedule).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_save_vm_snapshot_schedule: %w", err)
+	}
+
+	return &schedule, nil
+}
+
+// DeleteVMSnapshotSchedule removes a VM's schedule. Snapshots it already took
+// are kept and can be deleted like manual ones.
+func (s *Service) DeleteVMSnapshotSchedule(rid uint) error {
+	if rid == 0 {
+		return fmt.Errorf("invalid_rid")
+	}
+
+	result := s.DB.Where("rid = ?", rid).Delete(&vmModels.VMSnapshotSchedule{})
+	if result.Error != nil {
+		return fmt.Errorf("failed_to_delete_vm_snapshot_schedule: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("vm_snapshot_schedule_not_found")
+	}
+
+	return nil
+}
+
+// snapshotScheduler runs VM schedules through the shared scheduler; the
+// VM-specific part is resolving a schedule to its VM's snapshot hooks.
+func (s *Service) snapshotScheduler() *snapshotschedule.Scheduler {
+	return &snapshotschedule.Scheduler{
+		DB:           s.DB,
+		Model:        &vmModels.VMSnapshotSchedule{},
+		QueueName:    vmSnapshotScheduleQueueName,
+		Kind:         "vm",
+		Lookup:       s.snapshotScheduleGuest,
+		Reservations: &s.snapshotSchedules,
+	}
+}
+
+func (s *Service) snapshotScheduleGuest(scheduleID uint) (*snapshotschedule.Guest, error) {
+	var schedule vmModels.VMSnapshotSchedule
+	if err := s.DB.First(&schedule, scheduleID).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_get_vm_snapshot_schedule: %w", err)
+	}
+
+	var vm vmModels.VM
+	if err := s.DB.Select("id", "rid", "name").Where("id = ?", schedule.VMID).First(&vm).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_get_vm: %w", err)
+	}
+
+	return &snapshotschedule.Guest{
+		Tokens: []string{"{vm}", vm.Name, "{rid}", strconv.FormatUint(uint64(vm.RID), 10)},
+		Create: func(ctx context.Context, name string) error {
+			_, err := s.createVMSnapshot(ctx, vm.RID, name, "Scheduled snapshot", &scheduleID)
+			return err
+		},
+		Snapshots: func() ([]uint, error) {
+			var ids []uint
+			err := s.DB.Model(&vmModels.VMSnapshot{}).
+				Where("vm_id = ? AND schedule_id = ?", vm.ID, scheduleID).
+				Order("created_at ASC, id ASC").
+				Pluck("id", &ids).Error
+			return ids, err
+		},
+		Delete: func(ctx context.Context, snapshotID uint) error {
+			return s.DeleteVMSnapshot(ctx, vm.RID, snapshotID)
+		},
+	}, nil
+}
+
+func (s *Service) RegisterJobs() {
+	s.snapshotScheduler().RegisterJobs()
+}
+
+func (s *Service) StartSnapshotScheduler(ctx context.Context) {
+	s.snapshotScheduler().Start(ctx)
+}
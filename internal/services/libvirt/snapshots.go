@@ -24,6 +24,7 @@ import (
 	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
 	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/pkg/utils"
 	"github.com/digitalocean/go-libvirt"
@@ -59,6 +60,18 @@ func (s *Service) CreateVMSnapshot(
 	rid uint,
 	name string,
 	description string,
+) (*vmModels.VMSnapshot, error) {
+	return s.createVMSnapshot(ctx, rid, name, description, nil)
+}
+
+// createVMSnapshot backs CreateVMSnapshot; scheduleID is set when a snapshot
+// schedule takes the snapshot.
+func (s *Service) createVMSnapshot(
+	ctx context.Context,
+	rid uint,
+	name string,
+	description string,
+	scheduleID *uint,
 ) (*vmModels.VMSnapshot, error) {
 	s.crudMutex.Lock()
 	defer s.crudMutex.Unlock()
@@ -134,6 +147,7 @@ func (s *Service) CreateVMSnapshot(
 		VMID:             vm.ID,
 		RID:              vm.RID,
 		ParentSnapshotID: parentID,
+		ScheduleID:       scheduleID,
 		Name:             name,
 		Description:      description,
 		SnapshotName:     snapshotName,
@@ -478,7 +492,7 @@ func resolveVMRootDatasets(vm *vmModels.VM) ([]string, error) {
 			continue
 		}
 
-		rootDataset := fmt.Sprintf("%s/sylve/virtual-machines/%d", pool, vm.RID)
+		rootDataset := fmt.Sprintf("%s/%d", layout.VMsDataset(pool), vm.RID)
 		rootsByName[rootDataset] = struct{}{}
 	}
 
@@ -774,8 +788,11 @@ func (s *Service) restoreVMDatabaseFromSnapshotJSON(
 		CloudInitData:          restored.CloudInitData,
 		CloudInitMetaData:      restored.CloudInitMetaData,
 		CloudInitNetworkConfig: restored.CloudInitNetworkConfig,
+		SSHKeys:                append([]string(nil), restored.SSHKeys...),
+		Tags:                   append([]string(nil), restored.Tags...),
 		ExtraBhyveOptions:      append([]string(nil), restored.ExtraBhyveOptions...),
 		IgnoreUMSR:             restored.IgnoreUMSR,
+		CPUFeatures:            append([]string(nil), restored.CPUFeatures...),
 		QemuGuestAgent:         restored.QemuGuestAgent,
 	}
 
@@ -808,8 +825,11 @@ func (s *Service) restoreVMDatabaseFromSnapshotJSON(
 			"CloudInitData",
 			"CloudInitMetaData",
 			"CloudInitNetworkConfig",
+			"SSHKeys",
+			"Tags",
 			"ExtraBhyveOptions",
 			"IgnoreUMSR",
+			"CPUFeatures",
 			"QemuGuestAgent",
 		).
 		Updates(vmUpdate).Error; err != nil {
@@ -1179,7 +1199,7 @@ func prepareRestoredVMStorages(tx *gorm.DB, rid uint, vmID uint, storages []vmMo
 				if cleaned.Type == vmModels.VMStorageTypeZVol {
 					prefix = "zvol"
 				}
-				datasetName = fmt.Sprintf("%s/sylve/virtual-machines/%d/%s-%d", cleaned.Pool, rid, prefix, cleaned.ID)
+				datasetName = fmt.Sprintf("%s/%d/%s-%d", layout.VMsDataset(cleaned.Pool), rid, prefix, cleaned.ID)
 			}
 
 			if cleaned.Pool == "" {
@@ -22,7 +22,9 @@ import (
 	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
 	"github.com/alchemillahq/sylve/internal/db/replicationguard"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
 	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/pkg/utils"
 	"gopkg.in/yaml.v3"
@@ -61,7 +63,7 @@ func vmTemplateStorageDatasetPath(pool string, templateID uint, storageType vmMo
 	if err != nil {
 		return "", err
 	}
-	return fmt.Sprintf("%s/sylve/virtual-machines/templates/%d/%s-%d", pool, templateID, prefix, sourceStorageID), nil
+	return fmt.Sprintf("%s/templates/%d/%s-%d", layout.VMsDataset(pool), templateID, prefix, sourceStorageID), nil
 }
 
 func vmTargetStorageDatasetPath(pool string, rid uint, storageType vmModels.VMStorageType, storageID uint) (string, error) {
@@ -69,7 +71,7 @@ func vmTargetStorageDatasetPath(pool string, rid uint, storageType vmModels.VMSt
 	if err != nil {
 		return "", err
 	}
-	return fmt.Sprintf("%s/sylve/virtual-machines/%d/%s-%d", pool, rid, prefix, storageID), nil
+	return fmt.Sprintf("%s/%d/%s-%d", layout.VMsDataset(pool), rid, prefix, storageID), nil
 }
 
 func datasetEstimatedUsed(used, referenced uint64) uint64 {
@@ -230,7 +232,7 @@ func vmStorageSourceDatasetName(storage vmModels.Storage, rid uint) (string, err
 	if err != nil {
 		return "", err
 	}
-	return fmt.Sprintf("%s/sylve/virtual-machines/%d/%s-%d", pool, rid, prefix, storage.ID), nil
+	return fmt.Sprintf("%s/%d/%s-%d", layout.VMsDataset(pool), rid, prefix, storage.ID), nil
 }
 
 func templateHasCloudInit(template vmModels.VMTemplate) bool {
@@ -599,7 +601,7 @@ func (s *Service) preflightVMTemplateResources(
 		for _, target := range targets {
 			requiredByPool[pool] += perTarget
 
-			rootDataset := fmt.Sprintf("%s/sylve/virtual-machines/%d", pool, target.RID)
+			rootDataset := fmt.Sprintf("%s/%d", layout.VMsDataset(pool), target.RID)
 			targetRootDatasets[rootDataset] = struct{}{}
 		}
 	}
@@ -794,7 +796,7 @@ func buildVMFromTemplate(
 		VNCEnabled:             template.VNCEnabled,
 		VNCBind:                NormalizeVNCBindAddress(template.VNCBind),
 		VNCPort:                vncPort,
-		VNCPassword:            utils.GenerateRandomString(16),
+		VNCPassword:            secrets.String(utils.GenerateRandomString(16)),
 		VNCResolution:          template.VNCResolution,
 		VNCWait:                template.VNCWait,
 		StartAtBoot:            false,
@@ -810,6 +812,7 @@ func buildVMFromTemplate(
 		CloudInitNetworkConfig: cloudInitNetworkConfig,
 		ExtraBhyveOptions:      normalizeExtraBhyveOptions(template.ExtraBhyveOptions),
 		IgnoreUMSR:             template.IgnoreUMSR,
+		CPUFeatures:            normalizeCPUFeatures(template.CPUFeatures),
 		QemuGuestAgent:         template.QemuGuestAgent,
 		CPUPinning:             []vmModels.VMCPUPinning{},
 		Storages:               []vmModels.Storage{},
@@ -850,6 +853,7 @@ func buildVMTemplateFromVM(
 		CloudInitNetworkConfig: vm.CloudInitNetworkConfig,
 		ExtraBhyveOptions:      normalizeExtraBhyveOptions(vm.ExtraBhyveOptions),
 		IgnoreUMSR:             vm.IgnoreUMSR,
+		CPUFeatures:            normalizeCPUFeatures(vm.CPUFeatures),
 		QemuGuestAgent:         vm.QemuGuestAgent,
 		Storages:               []vmModels.VMTemplateStorage{},
 		Networks:               templateNetworks,
@@ -1206,7 +1210,7 @@ func (s *Service) ConvertVMToTemplate(
 			return err
 		}
 
-		parentDataset := fmt.Sprintf("%s/sylve/virtual-machines/templates/%d", storage.Pool, template.ID)
+		parentDataset := fmt.Sprintf("%s/templates/%d", layout.VMsDataset(storage.Pool), template.ID)
 		if err := s.ensureDatasetPath(ctx, parentDataset); err != nil {
 			return fmt.Errorf("failed_to_prepare_template_parent_dataset: %w", err)
 		}
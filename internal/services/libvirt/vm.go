@@ -11,6 +11,8 @@ package libvirt
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -23,7 +25,9 @@ import (
 	utilitiesModels "github.com/alchemillahq/sylve/internal/db/models/utilities"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
 	"github.com/alchemillahq/sylve/internal/db/replicationguard"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
 	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/pkg/utils"
 	"github.com/digitalocean/go-libvirt"
@@ -120,7 +124,7 @@ func (s *Service) SimpleListVM() ([]libvirtServiceInterfaces.SimpleList, error)
 		Model(&vmModels.VM{}).
 		Preload("CPUPinning").
 		Preload("Storages").
-		Select("id", "name", "rid", "vnc_port").
+		Select("id", "name", "rid", "vnc_port", "tags").
 		Find(&vms).Error; err != nil {
 		return nil, fmt.Errorf("failed_to_list_vms: %w", err)
 	}
@@ -153,6 +157,8 @@ func (s *Service) SimpleListVM() ([]libvirtServiceInterfaces.SimpleList, error)
 			State:                       state,
 			CPUPinning:                  vm.CPUPinning,
 			HasEnabledFilesystemStorage: hasEnabledVMFilesystemStorage(vm.Storages),
+			Tags:                        vm.Tags,
+			Pools:                       vmStoragePools(vm.Storages),
 		})
 	}
 
@@ -169,7 +175,7 @@ func (s *Service) GetSimpleVM(identifier int, byRID bool) (libvirtServiceInterfa
 		Model(&vmModels.VM{}).
 		Preload("CPUPinning").
 		Preload("Storages").
-		Select("id", "name", "rid", "vnc_port")
+		Select("id", "name", "rid", "vnc_port", "tags")
 
 	if byRID {
 		query = query.Where("rid = ?", identifier)
@@ -194,6 +200,8 @@ func (s *Service) GetSimpleVM(identifier int, byRID bool) (libvirtServiceInterfa
 		VNCPort:                     uint(vm.VNCPort),
 		CPUPinning:                  vm.CPUPinning,
 		HasEnabledFilesystemStorage: hasEnabledVMFilesystemStorage(vm.Storages),
+		Tags:                        vm.Tags,
+		Pools:                       vmStoragePools(vm.Storages),
 	}
 
 	if simple.CPUPinning == nil {
@@ -203,6 +211,19 @@ func (s *Service) GetSimpleVM(identifier int, byRID bool) (libvirtServiceInterfa
 	return simple, nil
 }
 
+// vmStoragePools lists the pools a VM's enabled disks live on.
+func vmStoragePools(storages []vmModels.Storage) []string {
+	pools := []string{}
+	for _, storage := range storages {
+		if !storage.Enable || storage.Pool == "" || slices.Contains(pools, storage.Pool) {
+			continue
+		}
+		pools = append(pools, storage.Pool)
+	}
+	sort.Strings(pools)
+	return pools
+}
+
 func hasEnabledVMFilesystemStorage(storages []vmModels.Storage) bool {
 	for _, storage := range storages {
 		if storage.Enable && storage.Type == vmModels.VMStorageTypeFilesystem {
@@ -353,7 +374,38 @@ func validateCPUPins(
 	return nil
 }
 
+// vmCreateCheck is one independent section of VM creation validation.
+// validateCreate stops at the first failing section while the pre-flight
+// report runs all of them.
+type vmCreateCheck struct {
+	name string
+	run  func(data libvirtServiceInterfaces.CreateVMRequest, ctx context.Context) error
+}
+
+func (s *Service) vmCreateChecks() []vmCreateCheck {
+	return []vmCreateCheck{
+		{name: "identity", run: s.validateCreateIdentity},
+		{name: "storage", run: s.validateCreateStorage},
+		{name: "network", run: s.validateCreateNetwork},
+		{name: "compute", run: s.validateCreateCompute},
+		{name: "vnc", run: s.validateCreateVNC},
+		{name: "passthrough", run: s.validateCreatePassthrough},
+		{name: "media", run: s.validateCreateMedia},
+		{name: "cluster", run: s.validateCreateClusterIdentity},
+	}
+}
+
 func (s *Service) validateCreate(data libvirtServiceInterfaces.CreateVMRequest, ctx context.Context) error {
+	for _, check := range s.vmCreateChecks() {
+		if err := check.run(data, ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) validateCreateIdentity(data libvirtServiceInterfaces.CreateVMRequest, ctx context.Context) error {
 	if _, err := parseBootROMValue(data.BootROM); err != nil {
 		return err
 	}
@@ -388,6 +440,14 @@ func (s *Service) validateCreate(data libvirtServiceInterfaces.CreateVMRequest,
 		return fmt.Errorf("invalid_description")
 	}
 
+	if data.StartOrder < 0 {
+		return fmt.Errorf("start_order_must_be_greater_than_or_equal_to_0")
+	}
+
+	return nil
+}
+
+func (s *Service) validateCreateStorage(data libvirtServiceInterfaces.CreateVMRequest, ctx context.Context) error {
 	if data.StorageType == "raw" && (data.StorageSize == nil || *data.StorageSize < 1024*1024*128) {
 		return fmt.Errorf("disk_size_must_be_greater_than_128mb")
 	}
@@ -405,21 +465,9 @@ func (s *Service) validateCreate(data libvirtServiceInterfaces.CreateVMRequest,
 	}
 
 	if data.StorageType != libvirtServiceInterfaces.StorageTypeNone {
-		usable, err := s.System.GetUsablePools(ctx)
+		pool, err := s.findUsableCreatePool(ctx, data.StoragePool)
 		if err != nil {
-			return fmt.Errorf("failed_to_get_usable_pools: %w", err)
-		}
-
-		var pool *gzfs.ZPool
-		for _, p := range usable {
-			if p.Name == data.StoragePool {
-				pool = p
-				break
-			}
-		}
-
-		if pool == nil {
-			return fmt.Errorf("pool_not_found: %s", data.StoragePool)
+			return err
 		}
 
 		size := uint64(0)
@@ -436,6 +484,25 @@ func (s *Service) validateCreate(data libvirtServiceInterfaces.CreateVMRequest,
 		}
 	}
 
+	return nil
+}
+
+func (s *Service) findUsableCreatePool(ctx context.Context, name string) (*gzfs.ZPool, error) {
+	usable, err := s.System.GetUsablePools(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed_to_get_usable_pools: %w", err)
+	}
+
+	for _, p := range usable {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("pool_not_found: %s", name)
+}
+
+func (s *Service) validateCreateNetwork(data libvirtServiceInterfaces.CreateVMRequest, _ context.Context) error {
 	if data.SwitchName != "" && strings.ToLower(data.SwitchName) != "none" {
 		var macId uint
 		if data.MacId != nil {
@@ -475,12 +542,32 @@ func (s *Service) validateCreate(data libvirtServiceInterfaces.CreateVMRequest,
 		if data.SwitchEmulationType == "" {
 			return fmt.Errorf("no_switch_emulation_type_selected")
 		}
+
+		var switches int64
+		for _, model := range []any{&networkModels.StandardSwitch{}, &networkModels.ManualSwitch{}} {
+			var count int64
+			if err := s.DB.Model(model).Where("name = ?", data.SwitchName).Count(&count).Error; err != nil {
+				return fmt.Errorf("failed_to_find_switch: %w", err)
+			}
+			switches += count
+		}
+		if switches == 0 {
+			return fmt.Errorf("switch_not_found: %s", data.SwitchName)
+		}
 	}
 
+	return nil
+}
+
+func (s *Service) validateCreateCompute(data libvirtServiceInterfaces.CreateVMRequest, _ context.Context) error {
 	if data.CPUSockets < 1 || data.CPUCores < 1 || data.CPUThreads < 1 {
 		return fmt.Errorf("cpu_sockets_cores_threads_must_be_greater_than_1")
 	}
 
+	if err := validateCPUFeatures(data.CPUFeatures); err != nil {
+		return err
+	}
+
 	if len(data.CPUPinning) > 0 {
 		socketCount := utils.GetSocketCount(cpuid.CPU.PhysicalCores, cpuid.CPU.ThreadsPerCore)
 		if socketCount <= 0 {
@@ -508,6 +595,10 @@ func (s *Service) validateCreate(data libvirtServiceInterfaces.CreateVMRequest,
 		return fmt.Errorf("memory_must_be_greater_than_128mb")
 	}
 
+	return nil
+}
+
+func (s *Service) validateCreateVNC(data libvirtServiceInterfaces.CreateVMRequest, _ context.Context) error {
 	vncEnabled := true
 	if data.VNCEnabled != nil {
 		vncEnabled = *data.VNCEnabled
@@ -551,10 +642,10 @@ func (s *Service) validateCreate(data libvirtServiceInterfaces.CreateVMRequest,
 		}
 	}
 
-	if data.StartOrder < 0 {
-		return fmt.Errorf("start_order_must_be_greater_than_or_equal_to_0")
-	}
+	return nil
+}
 
+func (s *Service) validateCreatePassthrough(data libvirtServiceInterfaces.CreateVMRequest, _ context.Context) error {
 	if len(data.PCIDevices) > 0 {
 		for _, pciID := range data.PCIDevices {
 			var count int64
@@ -578,6 +669,10 @@ func (s *Service) validateCreate(data libvirtServiceInterfaces.CreateVMRequest,
 		}
 	}
 
+	return nil
+}
+
+func (s *Service) validateCreateMedia(data libvirtServiceInterfaces.CreateVMRequest, _ context.Context) error {
 	var cloudInit bool
 	if data.CloudInit != nil {
 		cloudInit = *data.CloudInit
@@ -591,6 +686,12 @@ func (s *Service) validateCreate(data libvirtServiceInterfaces.CreateVMRequest,
 		}
 	}
 
+	if data.ISO != "" {
+		if err := s.requireAttachableImage(data.ISO); err != nil {
+			return err
+		}
+	}
+
 	if cloudInit {
 		if data.StorageType == libvirtServiceInterfaces.StorageTypeNone {
 			return fmt.Errorf("cloud_init_requires_storage")
@@ -627,7 +728,11 @@ func (s *Service) validateCreate(data libvirtServiceInterfaces.CreateVMRequest,
 		}
 	}
 
-	if s.guestIdentityAvailabilityChecker != nil {
+	return nil
+}
+
+func (s *Service) validateCreateClusterIdentity(data libvirtServiceInterfaces.CreateVMRequest, ctx context.Context) error {
+	if s.guestIdentityAvailabilityChecker != nil && data.RID != nil && *data.RID > 0 {
 		if err := s.guestIdentityAvailabilityChecker.RequireGuestIDAvailable(ctx, *data.RID); err != nil {
 			return err
 		}
@@ -660,7 +765,7 @@ func (s *Service) hasStaleVMRootDatasetForCreate(ctx context.Context, rid uint)
 			continue
 		}
 
-		datasetName := fmt.Sprintf("%s/sylve/virtual-machines/%d", poolName, rid)
+		datasetName := fmt.Sprintf("%s/%d", layout.VMsDataset(poolName), rid)
 		ds, getErr := s.GZFS.ZFS.Get(ctx, datasetName, false)
 		if getErr != nil {
 			if isVMDatasetNotFoundError(getErr) {
@@ -704,8 +809,8 @@ func (s *Service) countStaleVMZFSDatasetsForCreate(ctx context.Context, rid uint
 			continue
 		}
 
-		vmPrefix := fmt.Sprintf("%s/sylve/virtual-machines", poolName)
-		vmRoot := fmt.Sprintf("%s/sylve/virtual-machines/%d", poolName, rid)
+		vmPrefix := layout.VMsDataset(poolName)
+		vmRoot := fmt.Sprintf("%s/%d", layout.VMsDataset(poolName), rid)
 
 		for _, datasetType := range []gzfs.DatasetType{gzfs.DatasetTypeFilesystem, gzfs.DatasetTypeVolume} {
 			datasets, listErr := s.GZFS.ZFS.ListByType(ctx, datasetType, true, vmPrefix)
@@ -748,10 +853,10 @@ func (s *Service) countStaleVMStorageDatasetRowsForCreate(rid uint) (int64, erro
 	var count int64
 	if err := s.DB.Model(&vmModels.VMStorageDataset{}).
 		Where("name LIKE ? OR name LIKE ? OR name LIKE ? OR name LIKE ?",
-			fmt.Sprintf("%%/sylve/virtual-machines/%d", rid),
-			fmt.Sprintf("%%/sylve/virtual-machines/%d/%%", rid),
-			fmt.Sprintf("%%/sylve/virtual-machines/%d.%%", rid),
-			fmt.Sprintf("%%/sylve/virtual-machines/%d\\_%%", rid)).
+			fmt.Sprintf("%%/%s/%d", layout.VMsPath(), rid),
+			fmt.Sprintf("%%/%s/%d/%%", layout.VMsPath(), rid),
+			fmt.Sprintf("%%/%s/%d.%%", layout.VMsPath(), rid),
+			fmt.Sprintf("%%/%s/%d\\_%%", layout.VMsPath(), rid)).
 		Count(&count).Error; err != nil {
 		return 0, fmt.Errorf("failed_to_check_stale_vm_storage_dataset_rows: %w", err)
 	}
@@ -877,6 +982,10 @@ func (s *Service) cleanupFailedVMCreate(rid uint, autoCreatedMACIDs []uint) {
 }
 
 func (s *Service) CreateVM(data libvirtServiceInterfaces.CreateVMRequest, ctx context.Context) (err error) {
+	if err := applyVMPreset(&data); err != nil {
+		return err
+	}
+
 	if err := s.validateCreate(data, ctx); err != nil {
 		logger.L.Debug().Err(err).Msg("CreateVM: validation failed")
 		return err
@@ -925,6 +1034,7 @@ func (s *Service) CreateVM(data libvirtServiceInterfaces.CreateVMRequest, ctx co
 	ignoreUMSRs := false
 	qemuGuestAgent := false
 	extraBhyveOptions := normalizeExtraBhyveOptions(data.ExtraBhyveOptions)
+	cpuFeatures := normalizeCPUFeatures(data.CPUFeatures)
 	bootROM, err := parseBootROMValue(data.BootROM)
 	if err != nil {
 		return err
@@ -1109,6 +1219,16 @@ func (s *Service) CreateVM(data libvirtServiceInterfaces.CreateVMRequest, ctx co
 		})
 	}
 
+	if driverStorage, warning := s.presetDriverStorage(data.Preset); driverStorage != nil {
+		storages = append(storages, *driverStorage)
+	} else if warning != "" {
+		logger.L.Warn().
+			Uint("rid", rid).
+			Str("preset", string(data.Preset)).
+			Str("reason", warning).
+			Msg("vm_create_preset_driver_media_skipped")
+	}
+
 	vm := &vmModels.VM{
 		Name:                   data.Name,
 		RID:                    rid,
@@ -1121,7 +1241,7 @@ func (s *Service) CreateVM(data libvirtServiceInterfaces.CreateVMRequest, ctx co
 		VNCEnabled:             vncEnabled,
 		VNCPort:                vncPort,
 		VNCBind:                vncBind,
-		VNCPassword:            data.VNCPassword,
+		VNCPassword:            secrets.String(data.VNCPassword),
 		VNCResolution:          data.VNCResolution,
 		VNCWait:                vncWait,
 		StartAtBoot:            startAtBoot,
@@ -1139,6 +1259,7 @@ func (s *Service) CreateVM(data libvirtServiceInterfaces.CreateVMRequest, ctx co
 		BootROM:                bootROM,
 		ExtraBhyveOptions:      extraBhyveOptions,
 		IgnoreUMSR:             ignoreUMSRs,
+		CPUFeatures:            cpuFeatures,
 		QemuGuestAgent:         qemuGuestAgent,
 	}
 
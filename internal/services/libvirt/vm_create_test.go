@@ -12,6 +12,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 	"testing"
 
@@ -407,3 +408,36 @@ func TestValidateCreate_FailsWhenVNCBindInvalid(t *testing.T) {
 		t.Fatalf("expected invalid_vnc_bind_ip error, got %v", err)
 	}
 }
+
+func TestPreflightCreateVM_ReportsEveryFailingSection(t *testing.T) {
+	db := testutil.NewSQLiteTestDB(t, &vmModels.VM{}, &vmModels.VMStorageDataset{})
+	svc := newVMCreatePrecheckTestService(db, nil, nil)
+
+	req := testCreateRequest(516, 59016)
+	req.Name = ""
+	req.RAM = 1024
+	req.VNCBind = "invalid-bind-value"
+
+	report := svc.PreflightCreateVM(context.Background(), req)
+	if report.Valid {
+		t.Fatal("expected invalid spec")
+	}
+
+	failed := map[string]string{}
+	for _, check := range report.Checks {
+		if !check.OK {
+			failed[check.Name] = check.Error
+		}
+	}
+	if len(report.Checks) != len(svc.vmCreateChecks()) || len(failed) != 3 {
+		t.Fatalf("unexpected checks %+v", report.Checks)
+	}
+	if !strings.Contains(failed["identity"], "invalid_vm_name") ||
+		!strings.Contains(failed["compute"], "memory_must_be_greater_than_128mb") ||
+		!strings.Contains(failed["vnc"], "invalid_vnc_bind_ip") {
+		t.Fatalf("unexpected failures %+v", failed)
+	}
+	if !slices.Contains(report.Warnings, "no_network_configured") {
+		t.Fatalf("expected missing network warning, got %v", report.Warnings)
+	}
+}
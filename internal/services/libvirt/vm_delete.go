@@ -16,6 +16,7 @@ import (
 
 	"github.com/alchemillahq/gzfs"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/pkg/utils"
 	"gorm.io/gorm"
@@ -221,6 +222,10 @@ func (s *Service) removeVMIdentityTransaction(ctx context.Context, vm vmModels.V
 			Delete(&vmModels.VMSnapshot{}).Error; err != nil {
 			return fmt.Errorf("failed_to_delete_vm_snapshots: %w", err)
 		}
+		if err := tx.Where("vm_id = ? OR rid = ?", vm.ID, vm.RID).
+			Delete(&vmModels.VMSnapshotSchedule{}).Error; err != nil {
+			return fmt.Errorf("failed_to_delete_vm_snapshot_schedule: %w", err)
+		}
 		if err := tx.Where("vm_id = ?", vm.ID).Delete(&vmModels.VMStats{}).Error; err != nil {
 			return fmt.Errorf("failed_to_delete_vm_stats: %w", err)
 		}
@@ -245,10 +250,10 @@ func (s *Service) removeVMIdentityTransaction(ctx context.Context, vm vmModels.V
 		var patternDatasetIDs []uint
 		if err := tx.Model(&vmModels.VMStorageDataset{}).
 			Where("name LIKE ? OR name LIKE ? OR name LIKE ? OR name LIKE ?",
-				fmt.Sprintf("%%/sylve/virtual-machines/%d", vm.RID),
-				fmt.Sprintf("%%/sylve/virtual-machines/%d/%%", vm.RID),
-				fmt.Sprintf("%%/sylve/virtual-machines/%d.%%", vm.RID),
-				fmt.Sprintf("%%/sylve/virtual-machines/%d\\_%%", vm.RID)).
+				fmt.Sprintf("%%/%s/%d", layout.VMsPath(), vm.RID),
+				fmt.Sprintf("%%/%s/%d/%%", layout.VMsPath(), vm.RID),
+				fmt.Sprintf("%%/%s/%d.%%", layout.VMsPath(), vm.RID),
+				fmt.Sprintf("%%/%s/%d\\_%%", layout.VMsPath(), vm.RID)).
 			Pluck("id", &patternDatasetIDs).Error; err != nil {
 			return fmt.Errorf("failed_to_lookup_vm_storage_dataset_rows: %w", err)
 		}
@@ -427,7 +432,7 @@ func (s *Service) discoverOrphanVMStorageRoots(ctx context.Context, rid uint, pl
 		if pool == nil || strings.TrimSpace(pool.Name) == "" {
 			continue
 		}
-		rootDataset := fmt.Sprintf("%s/sylve/virtual-machines/%d", strings.TrimSpace(pool.Name), rid)
+		rootDataset := fmt.Sprintf("%s/%d", layout.VMsDataset(strings.TrimSpace(pool.Name)), rid)
 		if _, known := knownRoots[rootDataset]; known {
 			continue
 		}
@@ -473,7 +478,7 @@ func vmStorageRootDatasetForRemoval(storage vmModels.Storage, rid uint) string {
 	if pool == "" || rid == 0 {
 		return ""
 	}
-	return fmt.Sprintf("%s/sylve/virtual-machines/%d", pool, rid)
+	return fmt.Sprintf("%s/%d", layout.VMsDataset(pool), rid)
 }
 
 func vmManagedStorageDatasetForRemoval(storage vmModels.Storage, rid uint) string {
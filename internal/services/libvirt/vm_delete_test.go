@@ -186,6 +186,7 @@ func newVMDeleteTestDB(t *testing.T) *gorm.DB {
 		&vmModels.VMStats{},
 		&vmModels.VMCPUPinning{},
 		&vmModels.VMSnapshot{},
+		&vmModels.VMSnapshotSchedule{},
 		&vmModels.VM{},
 	)
 }
@@ -258,6 +259,11 @@ func seedVMDeleteGraph(t *testing.T, db *gorm.DB, rid uint, pool string, include
 	}).Error; err != nil {
 		t.Fatalf("seed VM snapshot: %v", err)
 	}
+	if err := db.Create(&vmModels.VMSnapshotSchedule{
+		VMID: vm.ID, RID: rid, Enabled: true, CronExpr: "0 * * * *", MaxKeep: 3,
+	}).Error; err != nil {
+		t.Fatalf("seed VM snapshot schedule: %v", err)
+	}
 
 	return vmDeleteSeed{
 		VM: vm, RawDataset: rawDatasetName, ZVolDataset: zvolDatasetName,
@@ -279,6 +285,7 @@ func assertVMDeleteGraphCounts(t *testing.T, db *gorm.DB, seed vmDeleteSeed, wan
 		{name: "stats", model: &vmModels.VMStats{}, where: "vm_id = ?", arg: seed.VM.ID},
 		{name: "CPU pinning", model: &vmModels.VMCPUPinning{}, where: "vm_id = ?", arg: seed.VM.ID},
 		{name: "snapshot", model: &vmModels.VMSnapshot{}, where: "rid = ?", arg: seed.VM.RID},
+		{name: "snapshot schedule", model: &vmModels.VMSnapshotSchedule{}, where: "rid = ?", arg: seed.VM.RID},
 	}
 	for _, check := range checks {
 		var count int64
@@ -0,0 +1,530 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package libvirt
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	infoServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/info"
+	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/utils"
+
+	"github.com/beevik/etree"
+	"github.com/digitalocean/go-libvirt"
+)
+
+const (
+	vmFlowDefaultLimit = 50
+	vmFlowMaxLimit     = 500
+	vmFlowTopTalkers   = 10
+	// Samples older than this are refreshed on read instead of served stale.
+	vmFlowMaxSampleAge = time.Minute
+)
+
+var (
+	flowRunCommand = utils.RunCommand
+	flowNow        = time.Now
+
+	arpEntryRe = regexp.MustCompile(`\(([0-9.]+)\) at ([0-9a-fA-F:]{17}) on `)
+	ndpEntryRe = regexp.MustCompile(`^([0-9a-fA-F:]+)(?:%\S+)?\s+([0-9a-fA-F:]{17})\s`)
+	pfCountRe  = regexp.MustCompile(`(\d+):(\d+) (pkts|bytes)`)
+)
+
+type vmDomainInterface struct {
+	Name string
+	MAC  string
+}
+
+// pfState is one entry of `pfctl -ss -v`, normalised so that src is always
+// the side that opened the connection.
+type pfState struct {
+	Protocol string
+	Src      []pfEndpoint
+	Dst      []pfEndpoint
+	State    string
+	Packets  [2]uint64
+	Bytes    [2]uint64
+}
+
+type pfEndpoint struct {
+	Address string
+	Port    int
+}
+
+type vmFlowSample struct {
+	stats      *libvirtServiceInterfaces.VMFlowStats
+	flowBytes  map[string][2]uint64
+	ifaceBytes map[string][2]uint64
+}
+
+// SampleVMFlows snapshots the pf state table and tap counters once and
+// attributes them to every running VM. Rates are computed against the
+// previous sample of the same VM.
+func (s *Service) SampleVMFlows() error {
+	if err := s.requireConnection(); err != nil {
+		return err
+	}
+
+	domains, _, err := s.conn().ConnectListAllDomains(1, libvirt.ConnectListDomainsActive)
+	if err != nil {
+		return fmt.Errorf("failed_to_list_active_domains: %w", err)
+	}
+
+	ifacesByRID := make(map[uint][]vmDomainInterface, len(domains))
+	for _, domain := range domains {
+		rid, err := strconv.ParseUint(domain.Name, 10, 32)
+		if err != nil {
+			continue
+		}
+		domainXML, err := s.conn().DomainGetXMLDesc(domain, 0)
+		if err != nil {
+			logger.L.Debug().Err(err).Str("domain", domain.Name).Msg("failed_to_get_domain_xml_for_flows")
+			continue
+		}
+		ifacesByRID[uint(rid)] = parseDomainInterfaces(domainXML)
+	}
+
+	arpOut, err := flowRunCommand("/usr/sbin/arp", "-an")
+	if err != nil {
+		logger.L.Debug().Err(err).Msg("failed_to_read_arp_table")
+	}
+	ndpOut, err := flowRunCommand("/usr/sbin/ndp", "-an")
+	if err != nil {
+		logger.L.Debug().Err(err).Msg("failed_to_read_ndp_table")
+	}
+	neighbours := parseNeighbourTables(arpOut, ndpOut)
+
+	statesOut, err := flowRunCommand("/sbin/pfctl", "-ss", "-v")
+	if err != nil {
+		return fmt.Errorf("failed_to_read_pf_states: %w", err)
+	}
+	states := parsePFStates(statesOut)
+
+	counters := map[string]infoServiceInterfaces.NetworkInterface{}
+	if netstatOut, err := flowRunCommand("/usr/bin/netstat", "-ibdn", "--libxo", "json"); err == nil {
+		counters = parseLinkCounters(netstatOut)
+	} else {
+		logger.L.Debug().Err(err).Msg("failed_to_read_interface_counters")
+	}
+
+	now := flowNow()
+
+	s.flowMu.Lock()
+	defer s.flowMu.Unlock()
+
+	samples := make(map[uint]*vmFlowSample, len(ifacesByRID))
+	for rid, ifaces := range ifacesByRID {
+		samples[rid] = buildVMFlowSample(rid, ifaces, neighbours, states, counters, s.flowSamples[rid], now)
+	}
+	// VMs that stopped drop out of the cache with their old counters.
+	s.flowSamples = samples
+
+	return nil
+}
+
+func (s *Service) GetVMFlows(rid uint, limit int) (*libvirtServiceInterfaces.VMFlowStats, error) {
+	if _, err := s.GetVMByRID(rid); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case limit <= 0:
+		limit = vmFlowDefaultLimit
+	case limit > vmFlowMaxLimit:
+		limit = vmFlowMaxLimit
+	}
+
+	sample := s.cachedVMFlowSample(rid)
+	if sample == nil || flowNow().Sub(sample.stats.SampledAt) > vmFlowMaxSampleAge {
+		if err := s.SampleVMFlows(); err != nil {
+			return nil, err
+		}
+		sample = s.cachedVMFlowSample(rid)
+	}
+	if sample == nil {
+		return nil, fmt.Errorf("vm_not_running: %d", rid)
+	}
+
+	stats := *sample.stats
+	if len(stats.Flows) > limit {
+		stats.Flows = stats.Flows[:limit]
+	}
+	return &stats, nil
+}
+
+func (s *Service) cachedVMFlowSample(rid uint) *vmFlowSample {
+	s.flowMu.Lock()
+	defer s.flowMu.Unlock()
+	return s.flowSamples[rid]
+}
+
+func buildVMFlowSample(
+	rid uint,
+	ifaces []vmDomainInterface,
+	neighbours map[string][]string,
+	states []pfState,
+	counters map[string]infoServiceInterfaces.NetworkInterface,
+	prev *vmFlowSample,
+	now time.Time,
+) *vmFlowSample {
+	elapsed := 0.0
+	if prev != nil {
+		elapsed = now.Sub(prev.stats.SampledAt).Seconds()
+	}
+	rate := func(cur, old uint64) float64 {
+		if elapsed <= 0 || cur < old {
+			return 0
+		}
+		return float64(cur-old) / elapsed
+	}
+
+	sample := &vmFlowSample{
+		stats: &libvirtServiceInterfaces.VMFlowStats{
+			RID:        rid,
+			SampledAt:  now,
+			Interfaces: []libvirtServiceInterfaces.VMInterfaceCounters{},
+			TopTalkers: []libvirtServiceInterfaces.VMTalker{},
+			Flows:      []libvirtServiceInterfaces.VMFlow{},
+		},
+		flowBytes:  map[string][2]uint64{},
+		ifaceBytes: map[string][2]uint64{},
+	}
+
+	vmAddresses := map[string]struct{}{}
+	for _, ifc := range ifaces {
+		addresses := neighbours[strings.ToLower(ifc.MAC)]
+		for _, addr := range addresses {
+			vmAddresses[addr] = struct{}{}
+		}
+
+		counter := libvirtServiceInterfaces.VMInterfaceCounters{
+			Name:      ifc.Name,
+			MAC:       ifc.MAC,
+			Addresses: append([]string{}, addresses...),
+		}
+		if raw, ok := counters[ifc.Name]; ok {
+			// The tap sends what the guest receives.
+			counter.BytesIn = raw.SentBytes
+			counter.BytesOut = raw.ReceivedBytes
+			counter.PacketsIn = raw.SentPackets
+			counter.PacketsOut = raw.ReceivedPackets
+			counter.Errors = raw.ReceivedErrors + raw.SendErrors
+			counter.Drops = raw.DroppedPackets
+			current := [2]uint64{uint64(max(counter.BytesIn, 0)), uint64(max(counter.BytesOut, 0))}
+			sample.ifaceBytes[ifc.Name] = current
+			if prev != nil {
+				if old, ok := prev.ifaceBytes[ifc.Name]; ok {
+					counter.BytesInRate = rate(current[0], old[0])
+					counter.BytesOutRate = rate(current[1], old[1])
+				}
+			}
+		}
+		sample.stats.Interfaces = append(sample.stats.Interfaces, counter)
+	}
+
+	// The same connection can be tracked on the tap, the bridge and the
+	// uplink; keep the entry that saw the most traffic.
+	flows := map[string]libvirtServiceInterfaces.VMFlow{}
+	for _, state := range states {
+		flow, ok := vmFlowFromState(state, vmAddresses)
+		if !ok {
+			continue
+		}
+		key := vmFlowKey(flow)
+		if seen, ok := flows[key]; ok && seen.BytesIn+seen.BytesOut >= flow.BytesIn+flow.BytesOut {
+			continue
+		}
+		flows[key] = flow
+	}
+
+	talkers := map[string]*libvirtServiceInterfaces.VMTalker{}
+	for key, flow := range flows {
+		sample.flowBytes[key] = [2]uint64{flow.BytesIn, flow.BytesOut}
+		if prev != nil {
+			if old, ok := prev.flowBytes[key]; ok {
+				flow.BytesInRate = rate(flow.BytesIn, old[0])
+				flow.BytesOutRate = rate(flow.BytesOut, old[1])
+			}
+		}
+		sample.stats.Flows = append(sample.stats.Flows, flow)
+
+		talker := talkers[flow.RemoteAddress]
+		if talker == nil {
+			talker = &libvirtServiceInterfaces.VMTalker{RemoteAddress: flow.RemoteAddress}
+			talkers[flow.RemoteAddress] = talker
+		}
+		talker.Flows++
+		talker.BytesIn += flow.BytesIn
+		talker.BytesOut += flow.BytesOut
+		talker.BytesRate += flow.BytesInRate + flow.BytesOutRate
+	}
+
+	sample.stats.ActiveConnections = len(sample.stats.Flows)
+	sort.Slice(sample.stats.Flows, func(i, j int) bool {
+		a, b := sample.stats.Flows[i], sample.stats.Flows[j]
+		if ar, br := a.BytesInRate+a.BytesOutRate, b.BytesInRate+b.BytesOutRate; ar != br {
+			return ar > br
+		}
+		if a.BytesIn+a.BytesOut != b.BytesIn+b.BytesOut {
+			return a.BytesIn+a.BytesOut > b.BytesIn+b.BytesOut
+		}
+		return vmFlowKey(a) < vmFlowKey(b)
+	})
+
+	for _, talker := range talkers {
+		sample.stats.TopTalkers = append(sample.stats.TopTalkers, *talker)
+	}
+	sort.Slice(sample.stats.TopTalkers, func(i, j int) bool {
+		a, b := sample.stats.TopTalkers[i], sample.stats.TopTalkers[j]
+		if a.BytesRate != b.BytesRate {
+			return a.BytesRate > b.BytesRate
+		}
+		if a.BytesIn+a.BytesOut != b.BytesIn+b.BytesOut {
+			return a.BytesIn+a.BytesOut > b.BytesIn+b.BytesOut
+		}
+		return a.RemoteAddress < b.RemoteAddress
+	})
+	if len(sample.stats.TopTalkers) > vmFlowTopTalkers {
+		sample.stats.TopTalkers = sample.stats.TopTalkers[:vmFlowTopTalkers]
+	}
+
+	return sample
+}
+
+func vmFlowKey(flow libvirtServiceInterfaces.VMFlow) string {
+	return fmt.Sprintf("%s|%s|%d|%s|%d", flow.Protocol, flow.LocalAddress, flow.LocalPort, flow.RemoteAddress, flow.RemotePort)
+}
+
+// vmFlowFromState orients a pf state around the VM. Either side may carry a
+// NAT translation, so every address printed for a side is checked.
+func vmFlowFromState(state pfState, vmAddresses map[string]struct{}) (libvirtServiceInterfaces.VMFlow, bool) {
+	match := func(endpoints []pfEndpoint) (pfEndpoint, bool) {
+		for _, ep := range endpoints {
+			if _, ok := vmAddresses[ep.Address]; ok {
+				return ep, true
+			}
+		}
+		return pfEndpoint{}, false
+	}
+
+	flow := libvirtServiceInterfaces.VMFlow{Protocol: state.Protocol, State: state.State}
+	if local, ok := match(state.Src); ok && len(state.Dst) > 0 {
+		flow.Direction = "outbound"
+		flow.LocalAddress, flow.LocalPort = local.Address, local.Port
+		flow.RemoteAddress, flow.RemotePort = state.Dst[0].Address, state.Dst[0].Port
+		flow.BytesOut, flow.BytesIn = state.Bytes[0], state.Bytes[1]
+		flow.PacketsOut, flow.PacketsIn = state.Packets[0], state.Packets[1]
+		return flow, true
+	}
+	if local, ok := match(state.Dst); ok && len(state.Src) > 0 {
+		flow.Direction = "inbound"
+		flow.LocalAddress, flow.LocalPort = local.Address, local.Port
+		flow.RemoteAddress, flow.RemotePort = state.Src[0].Address, state.Src[0].Port
+		flow.BytesIn, flow.BytesOut = state.Bytes[0], state.Bytes[1]
+		flow.PacketsIn, flow.PacketsOut = state.Packets[0], state.Packets[1]
+		return flow, true
+	}
+	return flow, false
+}
+
+func parseDomainInterfaces(domainXML string) []vmDomainInterface {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromString(domainXML); err != nil {
+		return nil
+	}
+
+	var ifaces []vmDomainInterface
+	for _, el := range doc.FindElements("//devices/interface") {
+		target := el.SelectElement("target")
+		mac := el.SelectElement("mac")
+		if target == nil || mac == nil {
+			continue
+		}
+		name := target.SelectAttrValue("dev", "")
+		if name == "" {
+			continue
+		}
+		ifaces = append(ifaces, vmDomainInterface{
+			Name: name,
+			MAC:  strings.ToLower(mac.SelectAttrValue("address", "")),
+		})
+	}
+	return ifaces
+}
+
+// parseNeighbourTables maps lowercase MACs to the IPv4 and IPv6 addresses the
+// host has seen them use.
+func parseNeighbourTables(arpOut, ndpOut string) map[string][]string {
+	neighbours := map[string][]string{}
+	add := func(ip, mac string) {
+		mac = strings.ToLower(mac)
+		for _, existing := range neighbours[mac] {
+			if existing == ip {
+				return
+			}
+		}
+		neighbours[mac] = append(neighbours[mac], ip)
+	}
+
+	for _, line := range strings.Split(arpOut, "\n") {
+		if m := arpEntryRe.FindStringSubmatch(line); m != nil {
+			add(m[1], m[2])
+		}
+	}
+	for _, line := range strings.Split(ndpOut, "\n") {
+		m := ndpEntryRe.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil || net.ParseIP(m[1]) == nil {
+			continue
+		}
+		add(m[1], m[2])
+	}
+	return neighbours
+}
+
+func parsePFStates(out string) []pfState {
+	var states []pfState
+	var current *pfState
+
+	for _, line := range strings.Split(out, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if line[0] != ' ' && line[0] != '\t' {
+			if current != nil {
+				states = append(states, *current)
+			}
+			current = parsePFStateHeader(line)
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		for _, m := range pfCountRe.FindAllStringSubmatch(line, -1) {
+			a, _ := strconv.ParseUint(m[1], 10, 64)
+			b, _ := strconv.ParseUint(m[2], 10, 64)
+			if m[3] == "pkts" {
+				current.Packets = [2]uint64{a, b}
+			} else {
+				current.Bytes = [2]uint64{a, b}
+			}
+		}
+	}
+	if current != nil {
+		states = append(states, *current)
+	}
+	return states
+}
+
+// parsePFStateHeader reads lines such as
+//
+//	all tcp 10.0.0.5:22 <- 192.0.2.7:51515       ESTABLISHED:ESTABLISHED
+//	all udp 10.0.0.5:5353 (192.0.2.1:61001) -> 8.8.8.8:53       MULTIPLE:SINGLE
+//
+// "->" means the left side opened the connection, "<-" the right side.
+func parsePFStateHeader(line string) *pfState {
+	fields := strings.Fields(line)
+	if len(fields) < 5 {
+		return nil
+	}
+
+	state := &pfState{Protocol: fields[1]}
+	var left, right []pfEndpoint
+	arrow := ""
+	for _, field := range fields[2:] {
+		switch {
+		case field == "->" || field == "<-":
+			arrow = field
+		case strings.HasPrefix(field, "(") && strings.HasSuffix(field, ")"):
+			ep, ok := parsePFEndpoint(strings.Trim(field, "()"))
+			if !ok {
+				continue
+			}
+			if arrow == "" {
+				left = append(left, ep)
+			} else {
+				right = append(right, ep)
+			}
+		default:
+			ep, ok := parsePFEndpoint(field)
+			if !ok {
+				state.State = field
+				continue
+			}
+			if arrow == "" {
+				left = append(left, ep)
+			} else {
+				right = append(right, ep)
+			}
+		}
+	}
+
+	switch arrow {
+	case "->":
+		state.Src, state.Dst = left, right
+	case "<-":
+		state.Src, state.Dst = right, left
+	default:
+		return nil
+	}
+	if len(state.Src) == 0 || len(state.Dst) == 0 {
+		return nil
+	}
+	return state
+}
+
+func parsePFEndpoint(raw string) (pfEndpoint, bool) {
+	// IPv6 endpoints carry the port in brackets: 2001:db8::1[443].
+	if i := strings.LastIndex(raw, "["); i > 0 && strings.HasSuffix(raw, "]") {
+		port, err := strconv.Atoi(raw[i+1 : len(raw)-1])
+		if err != nil || net.ParseIP(raw[:i]) == nil {
+			return pfEndpoint{}, false
+		}
+		return pfEndpoint{Address: raw[:i], Port: port}, true
+	}
+	if net.ParseIP(raw) != nil {
+		return pfEndpoint{Address: raw}, true
+	}
+	host, portRaw, ok := strings.Cut(raw, ":")
+	if !ok || net.ParseIP(host) == nil {
+		return pfEndpoint{}, false
+	}
+	port, err := strconv.Atoi(portRaw)
+	if err != nil {
+		return pfEndpoint{}, false
+	}
+	return pfEndpoint{Address: host, Port: port}, true
+}
+
+// parseLinkCounters keeps the link-level row of each interface; the per-address
+// rows netstat also prints repeat the same counters.
+func parseLinkCounters(out string) map[string]infoServiceInterfaces.NetworkInterface {
+	var parsed struct {
+		Statistics struct {
+			Interfaces []infoServiceInterfaces.NetworkInterface `json:"interface"`
+		}
+	}
+	counters := map[string]infoServiceInterfaces.NetworkInterface{}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		return counters
+	}
+	for _, ifc := range parsed.Statistics.Interfaces {
+		if strings.HasPrefix(ifc.Network, "<Link") {
+			counters[ifc.Name] = ifc
+		}
+	}
+	return counters
+}
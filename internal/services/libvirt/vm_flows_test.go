@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package libvirt
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	infoServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/info"
+)
+
+const testPFStates = `all tcp 10.0.0.5:22 <- 192.0.2.7:51515       ESTABLISHED:ESTABLISHED
+   [1000 + 65535](+1) wscale 7  [2000 + 65535](+2) wscale 7
+   age 00:10:00, expires in 23:59:59, 100:200 pkts, 4000:90000 bytes, rule 3
+bridge0 tcp 10.0.0.5:22 <- 192.0.2.7:51515       ESTABLISHED:ESTABLISHED
+   age 00:10:00, expires in 23:59:59, 10:20 pkts, 400:900 bytes, rule 3
+all udp 10.0.0.5:5353 (192.0.2.1:61001) -> 198.51.100.53:53       MULTIPLE:SINGLE
+   age 00:00:02, expires in 00:00:58, 1:1 pkts, 60:120 bytes, rule 7
+all tcp 2001:db8::5[443] <- 2001:db8:ffff::9[40000]       ESTABLISHED:ESTABLISHED
+   age 00:00:05, expires in 24:00:00, 5:5 pkts, 500:5000 bytes, rule 1
+all tcp 10.0.0.99:80 <- 192.0.2.7:41000       ESTABLISHED:ESTABLISHED
+   age 00:00:05, expires in 24:00:00, 5:5 pkts, 500:5000 bytes, rule 1
+`
+
+func TestParsePFStatesOrientsInitiator(t *testing.T) {
+	states := parsePFStates(testPFStates)
+	if len(states) != 5 {
+		t.Fatalf("expected 5 states, got %d", len(states))
+	}
+
+	inbound := states[0]
+	if inbound.Src[0] != (pfEndpoint{Address: "192.0.2.7", Port: 51515}) ||
+		inbound.Dst[0] != (pfEndpoint{Address: "10.0.0.5", Port: 22}) {
+		t.Fatalf("inbound state not oriented around initiator: %#v", inbound)
+	}
+	if inbound.Bytes != [2]uint64{4000, 90000} || inbound.Packets != [2]uint64{100, 200} {
+		t.Fatalf("unexpected counters: %#v", inbound)
+	}
+
+	nat := states[2]
+	if len(nat.Src) != 2 || nat.Src[1].Address != "192.0.2.1" || nat.Dst[0].Port != 53 || nat.State != "MULTIPLE:SINGLE" {
+		t.Fatalf("unexpected NAT state: %#v", nat)
+	}
+
+	v6 := states[3]
+	if v6.Dst[0] != (pfEndpoint{Address: "2001:db8::5", Port: 443}) {
+		t.Fatalf("unexpected IPv6 endpoint: %#v", v6.Dst)
+	}
+}
+
+func TestParseNeighbourTables(t *testing.T) {
+	arp := "? (10.0.0.5) at 58:9c:fc:00:00:01 on bridge0 expires in 1180 seconds [ethernet]\n" +
+		"? (10.0.0.1) at 02:00:00:00:00:aa on bridge0 permanent [ethernet]\n"
+	ndp := "Neighbor                             Linklayer Address  Netif Expire    1s 5s\n" +
+		"2001:db8::5                          58:9C:FC:00:00:01  bridge0 23h59m50s S R\n" +
+		"fe80::5a9c:fcff:fe00:1%bridge0       58:9c:fc:00:00:01  bridge0 23h59m50s S\n"
+
+	got := parseNeighbourTables(arp, ndp)
+	want := []string{"10.0.0.5", "2001:db8::5", "fe80::5a9c:fcff:fe00:1"}
+	if !reflect.DeepEqual(got["58:9c:fc:00:00:01"], want) {
+		t.Fatalf("neighbours = %v, want %v", got["58:9c:fc:00:00:01"], want)
+	}
+}
+
+func TestParseDomainInterfaces(t *testing.T) {
+	xml := `<domain><devices>
+  <interface type="bridge"><mac address="58:9C:FC:00:00:01"/><source bridge="br0"/><target dev="vnet0"/></interface>
+  <interface type="bridge"><mac address="58:9c:fc:00:00:02"/><source bridge="br1"/></interface>
+</devices></domain>`
+
+	got := parseDomainInterfaces(xml)
+	want := []vmDomainInterface{{Name: "vnet0", MAC: "58:9c:fc:00:00:01"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("interfaces = %#v, want %#v", got, want)
+	}
+}
+
+func TestBuildVMFlowSampleAttributesFlowsAndRates(t *testing.T) {
+	ifaces := []vmDomainInterface{{Name: "vnet0", MAC: "58:9c:fc:00:00:01"}}
+	neighbours := map[string][]string{"58:9c:fc:00:00:01": {"10.0.0.5", "2001:db8::5"}}
+	counters := map[string]infoServiceInterfaces.NetworkInterface{
+		"vnet0": {Name: "vnet0", ReceivedBytes: 1000, SentBytes: 5000},
+	}
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	first := buildVMFlowSample(101, ifaces, neighbours, parsePFStates(testPFStates), counters, nil, start)
+	stats := first.stats
+	if stats.ActiveConnections != 3 {
+		t.Fatalf("expected 3 deduplicated VM flows, got %d: %#v", stats.ActiveConnections, stats.Flows)
+	}
+	if stats.Interfaces[0].BytesIn != 5000 || stats.Interfaces[0].BytesOut != 1000 {
+		t.Fatalf("tap counters must be reported from the guest view: %#v", stats.Interfaces[0])
+	}
+
+	ssh := stats.Flows[0]
+	if ssh.Direction != "inbound" || ssh.LocalPort != 22 || ssh.RemoteAddress != "192.0.2.7" ||
+		ssh.BytesIn != 4000 || ssh.BytesOut != 90000 {
+		t.Fatalf("unexpected top flow: %#v", ssh)
+	}
+	for _, flow := range stats.Flows {
+		if flow.Protocol == "udp" && (flow.Direction != "outbound" || flow.LocalAddress != "10.0.0.5" || flow.RemotePort != 53) {
+			t.Fatalf("unexpected DNS flow: %#v", flow)
+		}
+	}
+	if stats.TopTalkers[0].RemoteAddress != "192.0.2.7" || stats.TopTalkers[0].Flows != 1 {
+		t.Fatalf("unexpected top talkers: %#v", stats.TopTalkers)
+	}
+
+	later := `all tcp 10.0.0.5:22 <- 192.0.2.7:51515       ESTABLISHED:ESTABLISHED
+   age 00:10:10, expires in 23:59:59, 110:220 pkts, 5000:110000 bytes, rule 3
+`
+	counters["vnet0"] = infoServiceInterfaces.NetworkInterface{Name: "vnet0", ReceivedBytes: 2000, SentBytes: 25000}
+	second := buildVMFlowSample(101, ifaces, neighbours, parsePFStates(later), counters, first, start.Add(10*time.Second))
+
+	flow := second.stats.Flows[0]
+	if flow.BytesInRate != 100 || flow.BytesOutRate != 2000 {
+		t.Fatalf("unexpected flow rates: %#v", flow)
+	}
+	if second.stats.Interfaces[0].BytesInRate != 2000 || second.stats.Interfaces[0].BytesOutRate != 100 {
+		t.Fatalf("unexpected interface rates: %#v", second.stats.Interfaces[0])
+	}
+	if second.stats.TopTalkers[0].BytesRate != 2100 {
+		t.Fatalf("unexpected talker rate: %#v", second.stats.TopTalkers[0])
+	}
+}
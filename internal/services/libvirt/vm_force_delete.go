@@ -20,6 +20,7 @@ import (
 	"github.com/alchemillahq/sylve/internal/config"
 	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/pkg/utils"
 	"gorm.io/gorm"
@@ -186,12 +187,13 @@ func datasetBelongsToVMRID(dataset string, rid uint) bool {
 		return false
 	}
 
-	idx := strings.Index(dataset, "/sylve/virtual-machines/")
+	marker := "/" + layout.VMsPath() + "/"
+	idx := strings.Index(dataset, marker)
 	if idx < 0 {
 		return false
 	}
 
-	rest := dataset[idx+len("/sylve/virtual-machines/"):]
+	rest := dataset[idx+len(marker):]
 	if rest == "" {
 		return false
 	}
@@ -269,10 +271,10 @@ func (s *Service) forceRemoveVMDBRecords(rid uint, cleanUpMacs bool, warnings *[
 	patternDatasetIDs := make([]uint, 0)
 	if err := s.DB.Model(&vmModels.VMStorageDataset{}).
 		Where("name LIKE ? OR name LIKE ? OR name LIKE ? OR name LIKE ?",
-			fmt.Sprintf("%%/sylve/virtual-machines/%d", rid),
-			fmt.Sprintf("%%/sylve/virtual-machines/%d/%%", rid),
-			fmt.Sprintf("%%/sylve/virtual-machines/%d.%%", rid),
-			fmt.Sprintf("%%/sylve/virtual-machines/%d\\_%%", rid)).
+			fmt.Sprintf("%%/%s/%d", layout.VMsPath(), rid),
+			fmt.Sprintf("%%/%s/%d/%%", layout.VMsPath(), rid),
+			fmt.Sprintf("%%/%s/%d.%%", layout.VMsPath(), rid),
+			fmt.Sprintf("%%/%s/%d\\_%%", layout.VMsPath(), rid)).
 		Pluck("id", &patternDatasetIDs).Error; err != nil {
 		appendForceRemoveWarning(warnings, rid, "failed_to_lookup_vm_storage_dataset_rows_by_name", err)
 	} else {
@@ -0,0 +1,415 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package libvirt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	infoServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/info"
+	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+	systemServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/system"
+	"github.com/alchemillahq/sylve/internal/logger"
+
+	"gorm.io/gorm"
+)
+
+const (
+	vmLiveStatsInterval = time.Second
+	// Each subscriber is an open console page. Sampling forks netstat and
+	// sysctl every second, so the number of pages is capped.
+	vmLiveStatsMaxPerVM       = 4
+	vmLiveStatsMaxSubscribers = 32
+)
+
+// vmLiveStatsHub fans one sampler out to every console page watching a VM.
+// The sampler runs only while the hub has subscribers.
+type vmLiveStatsHub struct {
+	subscribers map[chan libvirtServiceInterfaces.VMLiveStats]struct{}
+	cancel      context.CancelFunc
+}
+
+// vmLiveCounters are the raw cumulative counters behind one sample.
+type vmLiveCounters struct {
+	at        time.Time
+	cpuTime   uint64 // ns
+	vcpus     uint16
+	rssBytes  uint64
+	maxMemKiB uint64
+	disks     map[string]zfsDatasetIO
+	nics      []vmDomainInterface
+	nicBytes  map[string][2]uint64 // guest rx, guest tx
+}
+
+type zfsDatasetIO struct {
+	Reads    uint64
+	Writes   uint64
+	NRead    uint64
+	NWritten uint64
+}
+
+// SubscribeVMLiveStats registers a listener for per-second samples of a
+// running VM. The returned function must be called to release the slot.
+func (s *Service) SubscribeVMLiveStats(rid uint) (<-chan libvirtServiceInterfaces.VMLiveStats, func(), error) {
+	vm, err := s.GetVMByRID(rid)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, fmt.Errorf("vm_not_found: %d", rid)
+		}
+		return nil, nil, fmt.Errorf("failed_to_get_vm: %w", err)
+	}
+
+	datasets := vmLiveStatsDatasets(vm.Storages)
+
+	s.liveStatsMu.Lock()
+	defer s.liveStatsMu.Unlock()
+
+	if s.liveStatsHubs == nil {
+		s.liveStatsHubs = make(map[uint]*vmLiveStatsHub)
+	}
+
+	hub := s.liveStatsHubs[rid]
+	if s.liveStatsSubscribers >= vmLiveStatsMaxSubscribers ||
+		(hub != nil && len(hub.subscribers) >= vmLiveStatsMaxPerVM) {
+		return nil, nil, fmt.Errorf("vm_live_stats_subscriber_limit")
+	}
+
+	if hub == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		hub = &vmLiveStatsHub{
+			subscribers: make(map[chan libvirtServiceInterfaces.VMLiveStats]struct{}),
+			cancel:      cancel,
+		}
+		s.liveStatsHubs[rid] = hub
+		go s.runVMLiveStats(ctx, rid, datasets)
+	}
+
+	// A buffer of one lets a slow websocket skip samples instead of
+	// holding up the others.
+	ch := make(chan libvirtServiceInterfaces.VMLiveStats, 1)
+	hub.subscribers[ch] = struct{}{}
+	s.liveStatsSubscribers++
+
+	var once bool
+	unsubscribe := func() {
+		s.liveStatsMu.Lock()
+		defer s.liveStatsMu.Unlock()
+
+		if once {
+			return
+		}
+		once = true
+
+		delete(hub.subscribers, ch)
+		close(ch)
+		s.liveStatsSubscribers--
+
+		if len(hub.subscribers) == 0 {
+			hub.cancel()
+			if s.liveStatsHubs[rid] == hub {
+				delete(s.liveStatsHubs, rid)
+			}
+		}
+	}
+
+	return ch, unsubscribe, nil
+}
+
+func (s *Service) runVMLiveStats(ctx context.Context, rid uint, datasets []string) {
+	ticker := time.NewTicker(vmLiveStatsInterval)
+	defer ticker.Stop()
+
+	var prev *vmLiveCounters
+	for {
+		cur, err := s.collectVMLiveCounters(rid, datasets)
+		if err != nil {
+			logger.L.Debug().Err(err).Uint("rid", rid).Msg("failed_to_sample_vm_live_stats")
+			prev = nil
+		} else {
+			s.publishVMLiveStats(rid, buildVMLiveStats(rid, prev, cur))
+			prev = cur
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) publishVMLiveStats(rid uint, stats libvirtServiceInterfaces.VMLiveStats) {
+	s.liveStatsMu.Lock()
+	defer s.liveStatsMu.Unlock()
+
+	hub := s.liveStatsHubs[rid]
+	if hub == nil {
+		return
+	}
+
+	for ch := range hub.subscribers {
+		select {
+		case ch <- stats:
+		default:
+		}
+	}
+}
+
+func (s *Service) collectVMLiveCounters(rid uint, datasets []string) (*vmLiveCounters, error) {
+	if err := s.requireConnection(); err != nil {
+		return nil, err
+	}
+
+	domain, err := s.conn().DomainLookupByName(strconv.FormatUint(uint64(rid), 10))
+	if err != nil {
+		return nil, fmt.Errorf("failed_to_lookup_domain: %w", err)
+	}
+
+	state, maxMem, _, vcpus, cpuTime, err := s.conn().DomainGetInfo(domain)
+	if err != nil {
+		return nil, fmt.Errorf("failed_to_get_domain_info: %w", err)
+	}
+	if state != 1 { // VIR_DOMAIN_RUNNING
+		return nil, fmt.Errorf("vm_not_running: %d", rid)
+	}
+
+	cur := &vmLiveCounters{
+		at:        flowNow(),
+		cpuTime:   cpuTime,
+		vcpus:     vcpus,
+		maxMemKiB: maxMem,
+		nicBytes:  map[string][2]uint64{},
+	}
+
+	if stats, err := s.conn().DomainMemoryStats(domain, 8, 0); err == nil {
+		for _, st := range stats {
+			switch st.Tag {
+			case 7: // VIR_DOMAIN_MEMORY_STAT_RSS
+				cur.rssBytes = st.Val * 1024
+			case 5: // VIR_DOMAIN_MEMORY_STAT_AVAILABLE
+				if st.Val > 0 {
+					cur.maxMemKiB = st.Val
+				}
+			}
+		}
+	}
+	if cur.rssBytes == 0 {
+		if psOut, err := flowRunCommand("/bin/ps", "--libxo", "json", "-aux"); err == nil {
+			cur.rssBytes = parseBhyveRSS(psOut, rid) * 1024
+		}
+	}
+
+	cur.disks = map[string]zfsDatasetIO{}
+	for _, pool := range datasetPools(datasets) {
+		out, err := flowRunCommand("/sbin/sysctl", "-q", "kstat.zfs."+pool+".dataset")
+		if err != nil {
+			logger.L.Debug().Err(err).Str("pool", pool).Msg("failed_to_read_zfs_dataset_kstats")
+			continue
+		}
+		for name, io := range parseZFSDatasetKstats(out) {
+			cur.disks[name] = io
+		}
+	}
+	for name := range cur.disks {
+		if !slices.Contains(datasets, name) {
+			delete(cur.disks, name)
+		}
+	}
+
+	if domainXML, err := s.conn().DomainGetXMLDesc(domain, 0); err == nil {
+		cur.nics = parseDomainInterfaces(domainXML)
+	}
+	if len(cur.nics) > 0 {
+		counters := map[string]infoServiceInterfaces.NetworkInterface{}
+		if out, err := flowRunCommand("/usr/bin/netstat", "-ibdn", "--libxo", "json"); err == nil {
+			counters = parseLinkCounters(out)
+		}
+		for _, ifc := range cur.nics {
+			if raw, ok := counters[ifc.Name]; ok {
+				// The tap sends what the guest receives.
+				cur.nicBytes[ifc.Name] = [2]uint64{uint64(max(raw.SentBytes, 0)), uint64(max(raw.ReceivedBytes, 0))}
+			}
+		}
+	}
+
+	return cur, nil
+}
+
+func buildVMLiveStats(rid uint, prev, cur *vmLiveCounters) libvirtServiceInterfaces.VMLiveStats {
+	stats := libvirtServiceInterfaces.VMLiveStats{
+		RID:       rid,
+		SampledAt: cur.at,
+		MemoryRSS: cur.rssBytes,
+		Disks:     []libvirtServiceInterfaces.VMDiskLiveStats{},
+		NICs:      []libvirtServiceInterfaces.VMNICLiveStats{},
+	}
+	if cur.maxMemKiB > 0 {
+		stats.MemoryPercent = float64(cur.rssBytes) / float64(cur.maxMemKiB*1024) * 100
+	}
+
+	elapsed := 0.0
+	if prev != nil {
+		elapsed = cur.at.Sub(prev.at).Seconds()
+	}
+	rate := func(cur, old uint64) float64 {
+		if elapsed <= 0 || cur < old {
+			return 0
+		}
+		return float64(cur-old) / elapsed
+	}
+
+	if prev != nil && cur.vcpus > 0 {
+		stats.CPUPercent = rate(cur.cpuTime, prev.cpuTime) / 1e9 / float64(cur.vcpus) * 100
+	}
+
+	names := make([]string, 0, len(cur.disks))
+	for name := range cur.disks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		disk := libvirtServiceInterfaces.VMDiskLiveStats{Dataset: name}
+		if prev != nil {
+			if old, ok := prev.disks[name]; ok {
+				now := cur.disks[name]
+				disk.ReadIOPS = rate(now.Reads, old.Reads)
+				disk.WriteIOPS = rate(now.Writes, old.Writes)
+				disk.ReadBytesRate = rate(now.NRead, old.NRead)
+				disk.WriteBytesRate = rate(now.NWritten, old.NWritten)
+			}
+		}
+		stats.Disks = append(stats.Disks, disk)
+	}
+
+	for _, ifc := range cur.nics {
+		nic := libvirtServiceInterfaces.VMNICLiveStats{Name: ifc.Name, MAC: ifc.MAC}
+		if prev != nil {
+			now, okNow := cur.nicBytes[ifc.Name]
+			old, okOld := prev.nicBytes[ifc.Name]
+			if okNow && okOld {
+				nic.RxBytesRate = rate(now[0], old[0])
+				nic.TxBytesRate = rate(now[1], old[1])
+			}
+		}
+		stats.NICs = append(stats.NICs, nic)
+	}
+
+	return stats
+}
+
+// vmLiveStatsDatasets lists the datasets whose ZFS kstats stand in for disk
+// I/O; bhyve itself does not export per-device counters.
+func vmLiveStatsDatasets(storages []vmModels.Storage) []string {
+	var datasets []string
+	for _, storage := range storages {
+		if !storage.Enable {
+			continue
+		}
+		if storage.Type != vmModels.VMStorageTypeZVol && storage.Type != vmModels.VMStorageTypeRaw {
+			continue
+		}
+		name := strings.TrimSpace(storage.Dataset.Name)
+		if name == "" || slices.Contains(datasets, name) {
+			continue
+		}
+		datasets = append(datasets, name)
+	}
+	return datasets
+}
+
+func datasetPools(datasets []string) []string {
+	var pools []string
+	for _, name := range datasets {
+		pool, _, _ := strings.Cut(name, "/")
+		if pool != "" && !slices.Contains(pools, pool) {
+			pools = append(pools, pool)
+		}
+	}
+	return pools
+}
+
+// parseZFSDatasetKstats reads `sysctl kstat.zfs.<pool>.dataset`, which lists
+// each objset's counters next to its dataset_name, e.g.
+// "kstat.zfs.zroot.dataset.objset-0x36.writes: 12".
+func parseZFSDatasetKstats(out string) map[string]zfsDatasetIO {
+	names := map[string]string{}
+	counters := map[string]zfsDatasetIO{}
+
+	for _, line := range strings.Split(out, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		idx := strings.LastIndex(key, ".")
+		if idx <= 0 {
+			continue
+		}
+		objset, field := key[:idx], key[idx+1:]
+		if field == "dataset_name" {
+			names[objset] = value
+			continue
+		}
+
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		io := counters[objset]
+		switch field {
+		case "reads":
+			io.Reads = n
+		case "writes":
+			io.Writes = n
+		case "nread":
+			io.NRead = n
+		case "nwritten":
+			io.NWritten = n
+		default:
+			continue
+		}
+		counters[objset] = io
+	}
+
+	byName := make(map[string]zfsDatasetIO, len(names))
+	for objset, name := range names {
+		byName[name] = counters[objset]
+	}
+	return byName
+}
+
+// parseBhyveRSS returns the RSS in KiB of the bhyve process for rid, whose
+// title is "bhyve: <rid> (bhyve)".
+func parseBhyveRSS(psOut string, rid uint) uint64 {
+	var top struct {
+		ProcessInformation systemServiceInterfaces.ProcessInformation `json:"process-information"`
+	}
+	if err := json.Unmarshal([]byte(psOut), &top); err != nil {
+		return 0
+	}
+
+	want := strconv.FormatUint(uint64(rid), 10)
+	for _, proc := range top.ProcessInformation.Process {
+		fields := strings.Fields(proc.Command)
+		if len(fields) >= 2 && fields[0] == "bhyve:" && fields[1] == want {
+			rss, _ := strconv.ParseUint(proc.RSS, 10, 64)
+			return rss
+		}
+	}
+	return 0
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package libvirt
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+)
+
+const testZFSDatasetKstats = `kstat.zfs.zroot.dataset.objset-0x36.nread: 8192
+kstat.zfs.zroot.dataset.objset-0x36.reads: 2
+kstat.zfs.zroot.dataset.objset-0x36.nwritten: 4096
+kstat.zfs.zroot.dataset.objset-0x36.writes: 1
+kstat.zfs.zroot.dataset.objset-0x36.dataset_name: zroot/sylve/virtual-machines/100/zvol-1
+kstat.zfs.zroot.dataset.objset-0x41.reads: 9
+kstat.zfs.zroot.dataset.objset-0x41.dataset_name: zroot/ROOT/default
+`
+
+func TestParseZFSDatasetKstats(t *testing.T) {
+	got := parseZFSDatasetKstats(testZFSDatasetKstats)
+
+	disk, ok := got["zroot/sylve/virtual-machines/100/zvol-1"]
+	if !ok || disk != (zfsDatasetIO{Reads: 2, Writes: 1, NRead: 8192, NWritten: 4096}) {
+		t.Fatalf("unexpected zvol counters %+v (%v)", disk, ok)
+	}
+	if got["zroot/ROOT/default"].Reads != 9 {
+		t.Fatalf("unexpected root counters %+v", got["zroot/ROOT/default"])
+	}
+}
+
+func TestParseBhyveRSSMatchesExactRID(t *testing.T) {
+	ps := `{"process-information":{"process":[
+		{"command":"bhyve: 1000 (bhyve)","rss":"999"},
+		{"command":"bhyve: 100 (bhyve)","rss":"2048"}
+	]}}`
+
+	if got := parseBhyveRSS(ps, 100); got != 2048 {
+		t.Fatalf("expected 2048 KiB, got %d", got)
+	}
+	if got := parseBhyveRSS(ps, 10); got != 0 {
+		t.Fatalf("expected no match for rid 10, got %d", got)
+	}
+}
+
+func TestBuildVMLiveStatsRates(t *testing.T) {
+	at := time.Unix(1700000000, 0)
+	prev := &vmLiveCounters{
+		at:        at,
+		cpuTime:   1e9,
+		vcpus:     2,
+		maxMemKiB: 1024 * 1024,
+		disks:     map[string]zfsDatasetIO{"zroot/vm": {Reads: 10, Writes: 20, NRead: 1000, NWritten: 2000}},
+		nics:      []vmDomainInterface{{Name: "tap0", MAC: "58:9c:fc:00:00:01"}},
+		nicBytes:  map[string][2]uint64{"tap0": {100, 200}},
+	}
+	cur := &vmLiveCounters{
+		at:        at.Add(2 * time.Second),
+		cpuTime:   3e9,
+		vcpus:     2,
+		rssBytes:  512 * 1024 * 1024,
+		maxMemKiB: 1024 * 1024,
+		disks:     map[string]zfsDatasetIO{"zroot/vm": {Reads: 30, Writes: 24, NRead: 5000, NWritten: 2000}},
+		nics:      prev.nics,
+		nicBytes:  map[string][2]uint64{"tap0": {2100, 600}},
+	}
+
+	first := buildVMLiveStats(100, nil, prev)
+	if first.CPUPercent != 0 || first.Disks[0].ReadIOPS != 0 || first.NICs[0].RxBytesRate != 0 {
+		t.Fatalf("expected zero rates without a previous sample, got %+v", first)
+	}
+
+	stats := buildVMLiveStats(100, prev, cur)
+	if stats.CPUPercent != 50 {
+		t.Fatalf("expected 50%% CPU, got %v", stats.CPUPercent)
+	}
+	if stats.MemoryPercent != 50 {
+		t.Fatalf("expected 50%% memory, got %v", stats.MemoryPercent)
+	}
+	wantDisk := libvirtServiceInterfaces.VMDiskLiveStats{
+		Dataset: "zroot/vm", ReadIOPS: 10, WriteIOPS: 2, ReadBytesRate: 2000, WriteBytesRate: 0,
+	}
+	if len(stats.Disks) != 1 || stats.Disks[0] != wantDisk {
+		t.Fatalf("unexpected disk stats %+v", stats.Disks)
+	}
+	if len(stats.NICs) != 1 || stats.NICs[0].RxBytesRate != 1000 || stats.NICs[0].TxBytesRate != 200 {
+		t.Fatalf("unexpected nic stats %+v", stats.NICs)
+	}
+}
+
+func TestVMLiveStatsDatasetsSkipsISOsAndShares(t *testing.T) {
+	got := vmLiveStatsDatasets([]vmModels.Storage{
+		{Type: vmModels.VMStorageTypeZVol, Enable: true, Dataset: vmModels.VMStorageDataset{Name: "zroot/vm/zvol-1"}},
+		{Type: vmModels.VMStorageTypeRaw, Enable: true, Dataset: vmModels.VMStorageDataset{Name: "tank/vm/raw-2"}},
+		{Type: vmModels.VMStorageTypeRaw, Enable: false, Dataset: vmModels.VMStorageDataset{Name: "tank/vm/raw-3"}},
+		{Type: vmModels.VMStorageTypeDiskImage, Enable: true, Dataset: vmModels.VMStorageDataset{Name: "zroot/iso"}},
+		{Type: vmModels.VMStorageTypeFilesystem, Enable: true, Dataset: vmModels.VMStorageDataset{Name: "zroot/share"}},
+	})
+	if strings.Join(got, ",") != "zroot/vm/zvol-1,tank/vm/raw-2" {
+		t.Fatalf("unexpected datasets %v", got)
+	}
+	if pools := datasetPools(got); strings.Join(pools, ",") != "zroot,tank" {
+		t.Fatalf("unexpected pools %v", pools)
+	}
+}
+
+func TestPublishVMLiveStatsSkipsSlowSubscribers(t *testing.T) {
+	ch := make(chan libvirtServiceInterfaces.VMLiveStats, 1)
+	svc := &Service{liveStatsHubs: map[uint]*vmLiveStatsHub{
+		100: {
+			subscribers: map[chan libvirtServiceInterfaces.VMLiveStats]struct{}{ch: {}},
+			cancel:      func() {},
+		},
+	}}
+
+	// The second sample must be dropped rather than block the sampler.
+	svc.publishVMLiveStats(100, libvirtServiceInterfaces.VMLiveStats{RID: 100, CPUPercent: 1})
+	svc.publishVMLiveStats(100, libvirtServiceInterfaces.VMLiveStats{RID: 100, CPUPercent: 2})
+	if got := <-ch; got.CPUPercent != 1 {
+		t.Fatalf("unexpected sample %+v", got)
+	}
+	if len(ch) != 0 {
+		t.Fatal("expected the second sample to be dropped")
+	}
+}
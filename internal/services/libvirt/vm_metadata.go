@@ -64,6 +64,10 @@ func (s *Service) RetireVMLocalMetadata(rid uint, cleanUpMacs bool) error {
 			return fmt.Errorf("failed_to_delete_vm_snapshots_for_retire: %w", err)
 		}
 
+		if err := tx.Where("rid = ?", rid).Delete(&vmModels.VMSnapshotSchedule{}).Error; err != nil {
+			return fmt.Errorf("failed_to_delete_vm_snapshot_schedule_for_retire: %w", err)
+		}
+
 		if len(vmIDs) == 0 {
 			return nil
 		}
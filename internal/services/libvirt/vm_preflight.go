@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package libvirt
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+)
+
+// preflightPoolHeadroomRatio is the share of a pool's free space a new disk
+// may take before the pre-flight report warns about it.
+const preflightPoolHeadroomRatio = 0.8
+
+// PreflightCreateVM runs every creation check against a spec and reports
+// all failures at once, plus warnings that would not block creation. Nothing
+// is provisioned.
+func (s *Service) PreflightCreateVM(
+	ctx context.Context,
+	data libvirtServiceInterfaces.CreateVMRequest,
+) libvirtServiceInterfaces.CreateVMPreflightReport {
+	report := libvirtServiceInterfaces.CreateVMPreflightReport{
+		Valid:    true,
+		Checks:   []libvirtServiceInterfaces.CreateVMPreflightCheck{},
+		Warnings: []string{},
+	}
+
+	if err := applyVMPreset(&data); err != nil {
+		report.Valid = false
+		report.Checks = append(report.Checks, libvirtServiceInterfaces.CreateVMPreflightCheck{
+			Name:  "preset",
+			OK:    false,
+			Error: err.Error(),
+		})
+		return report
+	}
+
+	for _, check := range s.vmCreateChecks() {
+		result := libvirtServiceInterfaces.CreateVMPreflightCheck{Name: check.name, OK: true}
+		if err := check.run(data, ctx); err != nil {
+			result.OK = false
+			result.Error = err.Error()
+			report.Valid = false
+		}
+		report.Checks = append(report.Checks, result)
+	}
+
+	report.Warnings = append(report.Warnings, s.preflightCreateWarnings(ctx, data)...)
+	return report
+}
+
+func (s *Service) preflightCreateWarnings(ctx context.Context, data libvirtServiceInterfaces.CreateVMRequest) []string {
+	var warnings []string
+
+	if data.StorageType != libvirtServiceInterfaces.StorageTypeNone && data.StorageSize != nil {
+		if pool, err := s.findUsableCreatePool(ctx, data.StoragePool); err == nil &&
+			*data.StorageSize <= pool.Free &&
+			float64(*data.StorageSize) > float64(pool.Free)*preflightPoolHeadroomRatio {
+			warnings = append(warnings, fmt.Sprintf("storage_uses_most_of_pool_free_space: %s", pool.Name))
+		}
+	}
+
+	if len(data.PCIDevices) > 0 {
+		var vms []vmModels.VM
+		if err := s.DB.Select("rid", "pci_devices").Find(&vms).Error; err == nil {
+			for _, pciID := range data.PCIDevices {
+				for _, vm := range vms {
+					if slices.Contains(vm.PCIDevices, pciID) {
+						warnings = append(warnings, fmt.Sprintf("passthrough_device_shared: device=%d rid=%d", pciID, vm.RID))
+					}
+				}
+			}
+		}
+	}
+
+	if data.VNCEnabled != nil && !*data.VNCEnabled && (data.Serial == nil || !*data.Serial) {
+		warnings = append(warnings, "no_console_configured")
+	}
+
+	if _, warning := s.presetDriverStorage(data.Preset); warning != "" {
+		warnings = append(warnings, warning)
+	}
+
+	if data.SwitchName == "" || data.SwitchName == "none" {
+		warnings = append(warnings, "no_network_configured")
+	}
+
+	return warnings
+}
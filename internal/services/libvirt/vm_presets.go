@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package libvirt
+
+import (
+	"errors"
+	"fmt"
+
+	utilitiesModels "github.com/alchemillahq/sylve/internal/db/models/utilities"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+	"gorm.io/gorm"
+)
+
+// VirtioWinISOURL is the upstream stable virtio-win driver ISO. The Windows
+// preset attaches whichever download was fetched from this URL.
+const VirtioWinISOURL = "https://fedorapeople.org/groups/virt/virtio-win/direct-downloads/stable-virtio/virtio-win.iso"
+
+func windowsVMPreset() libvirtServiceInterfaces.VMPresetInfo {
+	return libvirtServiceInterfaces.VMPresetInfo{
+		Name:                 libvirtServiceInterfaces.VMPresetWindows,
+		Description:          "Windows 10/11 and Server guests: UEFI, TPM 2.0, local-time clock and the virtio-win driver ISO",
+		BootROM:              string(vmModels.VMBootROMUEFI),
+		TimeOffset:           libvirtServiceInterfaces.TimeOffsetLocal,
+		TPMEmulation:         true,
+		ACPI:                 true,
+		APIC:                 true,
+		IgnoreUMSRs:          true,
+		StorageEmulationType: libvirtServiceInterfaces.NVMEStorageEmulation,
+		SwitchEmulationType:  "virtio",
+		Devices: []libvirtServiceInterfaces.VMPresetDevice{
+			{
+				Device: "firmware",
+				Model:  "uefi",
+				Notes:  "Windows 11 requires UEFI; the TPM and Secure Boot checks only run under UEFI",
+			},
+			{
+				Device: "tpm",
+				Model:  "swtpm",
+				Notes:  "Emulated TPM 2.0 backed by swtpm, required by the Windows 11 installer",
+			},
+			{
+				Device: "clock",
+				Model:  "localtime",
+				Notes:  "Windows keeps the RTC in local time; UTC makes the guest clock drift by the zone offset",
+			},
+			{
+				Device: "msr",
+				Model:  "ignore-unknown",
+				Notes:  "Recent Windows builds probe MSRs bhyve does not implement and crash without this",
+			},
+			{
+				Device: "disk",
+				Model:  string(libvirtServiceInterfaces.NVMEStorageEmulation),
+				Notes:  "Windows ships an inbox NVMe driver, so setup sees the disk without extra drivers",
+			},
+			{
+				Device: "network",
+				Model:  "virtio",
+				Notes:  "Install the NetKVM driver from the virtio-win ISO; use e1000 if drivers cannot be loaded",
+			},
+			{
+				Device: "driver-media",
+				Model:  string(libvirtServiceInterfaces.AHCICDStorageEmulation),
+				Notes:  "virtio-win ISO attached as a second CD-ROM for NetKVM, viostor and the guest agent",
+			},
+		},
+	}
+}
+
+// GetVMPreset describes a creation preset along with the state of the managed
+// driver media it attaches.
+func (s *Service) GetVMPreset(name libvirtServiceInterfaces.VMPreset) (libvirtServiceInterfaces.VMPresetInfo, error) {
+	if name != libvirtServiceInterfaces.VMPresetWindows {
+		return libvirtServiceInterfaces.VMPresetInfo{}, fmt.Errorf("unknown_vm_preset: %s", name)
+	}
+
+	info := windowsVMPreset()
+	media := &libvirtServiceInterfaces.VMPresetMedia{
+		URL:    VirtioWinISOURL,
+		Status: "not_downloaded",
+	}
+
+	download, err := s.findVirtioWinDownload()
+	if err != nil {
+		return info, err
+	}
+	if download != nil {
+		media.DownloadUUID = download.UUID
+		media.Status = string(download.Status)
+	}
+
+	info.DriverMedia = media
+	return info, nil
+}
+
+// applyVMPreset fills the fields a preset owns. Explicit request values for
+// device models win; firmware, TPM and clock are forced because the preset
+// exists to get those right.
+func applyVMPreset(data *libvirtServiceInterfaces.CreateVMRequest) error {
+	switch data.Preset {
+	case libvirtServiceInterfaces.VMPresetNone:
+		return nil
+	case libvirtServiceInterfaces.VMPresetWindows:
+	default:
+		return fmt.Errorf("unknown_vm_preset: %s", data.Preset)
+	}
+
+	preset := windowsVMPreset()
+	enabled := true
+
+	data.BootROM = preset.BootROM
+	data.TimeOffset = preset.TimeOffset
+	data.TPMEmulation = &enabled
+
+	if data.ACPI == nil {
+		data.ACPI = &enabled
+	}
+	if data.APIC == nil {
+		data.APIC = &enabled
+	}
+	if data.IgnoreUMSRs == nil {
+		data.IgnoreUMSRs = &enabled
+	}
+	if data.StorageType != libvirtServiceInterfaces.StorageTypeNone && data.StorageEmulationType == "" {
+		data.StorageEmulationType = preset.StorageEmulationType
+	}
+	if data.SwitchName != "" && data.SwitchName != "none" && data.SwitchEmulationType == "" {
+		data.SwitchEmulationType = preset.SwitchEmulationType
+	}
+
+	return nil
+}
+
+func (s *Service) findVirtioWinDownload() (*utilitiesModels.Downloads, error) {
+	var download utilitiesModels.Downloads
+	if err := s.DB.Where("url = ?", VirtioWinISOURL).First(&download).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed_to_find_virtio_win_download: %w", err)
+	}
+
+	return &download, nil
+}
+
+// presetDriverStorage returns the driver CD to attach for a preset, or nil
+// with a warning when the managed ISO has not finished downloading.
+func (s *Service) presetDriverStorage(preset libvirtServiceInterfaces.VMPreset) (*vmModels.Storage, string) {
+	if preset != libvirtServiceInterfaces.VMPresetWindows {
+		return nil, ""
+	}
+
+	download, err := s.findVirtioWinDownload()
+	if err != nil {
+		return nil, err.Error()
+	}
+	if download == nil {
+		return nil, "virtio_win_iso_not_downloaded"
+	}
+	if download.Status != utilitiesModels.DownloadStatusDone {
+		return nil, fmt.Sprintf("virtio_win_iso_not_ready: %s", download.Status)
+	}
+	if _, err := s.FindISOByUUID(download.UUID, false); err != nil {
+		return nil, fmt.Sprintf("virtio_win_iso_unusable: %s", err)
+	}
+
+	return &vmModels.Storage{
+		DownloadUUID: download.UUID,
+		Type:         vmModels.VMStorageTypeDiskImage,
+		Size:         0,
+		Emulation:    vmModels.AHCICDStorageEmulation,
+		Enable:       true,
+	}, ""
+}
@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package libvirt
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alchemillahq/sylve/internal/config"
+	utilitiesModels "github.com/alchemillahq/sylve/internal/db/models/utilities"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+	"github.com/alchemillahq/sylve/internal/testutil"
+)
+
+func TestApplyVMPreset_WindowsForcesFirmwareAndKeepsExplicitDevices(t *testing.T) {
+	disabled := false
+	data := libvirtServiceInterfaces.CreateVMRequest{
+		Preset:               libvirtServiceInterfaces.VMPresetWindows,
+		BootROM:              "none",
+		TimeOffset:           libvirtServiceInterfaces.TimeOffsetUTC,
+		ACPI:                 &disabled,
+		StorageType:          libvirtServiceInterfaces.StorageTypeZVOL,
+		StorageEmulationType: libvirtServiceInterfaces.AHCIHDStorageEmulation,
+		SwitchName:           "public",
+	}
+
+	if err := applyVMPreset(&data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if data.BootROM != string(vmModels.VMBootROMUEFI) {
+		t.Fatalf("expected uefi boot rom, got %q", data.BootROM)
+	}
+	if data.TimeOffset != libvirtServiceInterfaces.TimeOffsetLocal {
+		t.Fatalf("expected localtime clock, got %q", data.TimeOffset)
+	}
+	if data.TPMEmulation == nil || !*data.TPMEmulation {
+		t.Fatalf("expected tpm emulation to be enabled")
+	}
+	if data.ACPI == nil || *data.ACPI {
+		t.Fatalf("expected explicit acpi=false to be kept")
+	}
+	if data.IgnoreUMSRs == nil || !*data.IgnoreUMSRs {
+		t.Fatalf("expected ignore umsrs to default on")
+	}
+	if data.StorageEmulationType != libvirtServiceInterfaces.AHCIHDStorageEmulation {
+		t.Fatalf("expected explicit storage emulation to be kept, got %q", data.StorageEmulationType)
+	}
+	if data.SwitchEmulationType != "virtio" {
+		t.Fatalf("expected virtio switch emulation, got %q", data.SwitchEmulationType)
+	}
+}
+
+func TestApplyVMPreset_UnknownPresetFails(t *testing.T) {
+	data := libvirtServiceInterfaces.CreateVMRequest{Preset: "plan9"}
+
+	err := applyVMPreset(&data)
+	if err == nil || !strings.HasPrefix(err.Error(), "unknown_vm_preset") {
+		t.Fatalf("expected unknown_vm_preset, got %v", err)
+	}
+}
+
+func TestPresetDriverStorage_AttachesDownloadedVirtioWinISO(t *testing.T) {
+	t.Setenv("SYLVE_DATA_PATH", t.TempDir())
+
+	db := testutil.NewSQLiteTestDB(t, &utilitiesModels.Downloads{}, &utilitiesModels.DownloadedFile{})
+	svc := &Service{DB: db}
+
+	if storage, warning := svc.presetDriverStorage(libvirtServiceInterfaces.VMPresetNone); storage != nil || warning != "" {
+		t.Fatalf("expected no driver media without a preset, got %+v %q", storage, warning)
+	}
+
+	if _, warning := svc.presetDriverStorage(libvirtServiceInterfaces.VMPresetWindows); warning != "virtio_win_iso_not_downloaded" {
+		t.Fatalf("expected virtio_win_iso_not_downloaded, got %q", warning)
+	}
+
+	httpDir := config.GetDownloadsPath("http")
+	if err := os.MkdirAll(httpDir, 0o755); err != nil {
+		t.Fatalf("failed to create http downloads dir: %v", err)
+	}
+	isoPath := filepath.Join(httpDir, "virtio-win.iso")
+	if err := os.WriteFile(isoPath, []byte("iso"), 0o644); err != nil {
+		t.Fatalf("failed to create iso: %v", err)
+	}
+
+	download := utilitiesModels.Downloads{
+		UUID:   "virtio-win",
+		Path:   isoPath,
+		Name:   "virtio-win.iso",
+		Type:   utilitiesModels.DownloadTypeHTTP,
+		URL:    VirtioWinISOURL,
+		UType:  utilitiesModels.DownloadUTypeOther,
+		Status: utilitiesModels.DownloadStatusPending,
+	}
+	if err := db.Create(&download).Error; err != nil {
+		t.Fatalf("failed to seed download row: %v", err)
+	}
+
+	if _, warning := svc.presetDriverStorage(libvirtServiceInterfaces.VMPresetWindows); !strings.HasPrefix(warning, "virtio_win_iso_not_ready") {
+		t.Fatalf("expected virtio_win_iso_not_ready, got %q", warning)
+	}
+
+	if err := db.Model(&download).Update("status", utilitiesModels.DownloadStatusDone).Error; err != nil {
+		t.Fatalf("failed to mark download done: %v", err)
+	}
+
+	storage, warning := svc.presetDriverStorage(libvirtServiceInterfaces.VMPresetWindows)
+	if warning != "" || storage == nil {
+		t.Fatalf("expected driver storage, got %+v %q", storage, warning)
+	}
+	if storage.DownloadUUID != "virtio-win" || storage.Emulation != vmModels.AHCICDStorageEmulation {
+		t.Fatalf("unexpected driver storage: %+v", storage)
+	}
+
+	info, err := svc.GetVMPreset(libvirtServiceInterfaces.VMPresetWindows)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.DriverMedia == nil || info.DriverMedia.Status != string(utilitiesModels.DownloadStatusDone) {
+		t.Fatalf("expected driver media status done, got %+v", info.DriverMedia)
+	}
+}
@@ -0,0 +1,587 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package lifecycle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/config"
+	"github.com/alchemillahq/sylve/internal/db"
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
+	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/internal/services/jail"
+)
+
+const (
+	defaultHeavyOpsPerPool = 2
+
+	// HeavyOpWaitingMessage marks a queued task that is waiting for one of its
+	// pools to free up a heavy operation slot.
+	HeavyOpWaitingMessage = "waiting_for_pool_capacity"
+)
+
+// HeavyOp is one create, clone or restore that holds or waits for a slot on
+// every pool it writes to.
+type HeavyOp struct {
+	Key      string    `json:"key"`
+	Label    string    `json:"label"`
+	TaskID   uint      `json:"taskId,omitempty"`
+	Pools    []string  `json:"pools"`
+	Position int       `json:"position,omitempty"`
+	Since    time.Time `json:"since"`
+}
+
+type HeavyOpsPool struct {
+	Pool    string    `json:"pool"`
+	Limit   int       `json:"limit"`
+	Running []HeavyOp `json:"running"`
+	Waiting []HeavyOp `json:"waiting"`
+}
+
+type heavyOpWaiter struct {
+	op    HeavyOp
+	ready func()
+}
+
+// heavyOpLimiter caps heavy operations per pool. Waiters are admitted in
+// arrival order; one waiting on a busy pool holds back later arrivals that
+// share that pool, so a stream of small requests cannot starve it.
+type heavyOpLimiter struct {
+	mu      sync.Mutex
+	limit   func() int
+	held    map[string]HeavyOp
+	inUse   map[string]int
+	waiting []*heavyOpWaiter
+}
+
+func newHeavyOpLimiter(limit func() int) *heavyOpLimiter {
+	return &heavyOpLimiter{
+		limit: limit,
+		held:  make(map[string]HeavyOp),
+		inUse: make(map[string]int),
+	}
+}
+
+func heavyOpsPerPool() int {
+	if config.ParsedConfig != nil && config.ParsedConfig.Guests.HeavyOpsPerPool > 0 {
+		return config.ParsedConfig.Guests.HeavyOpsPerPool
+	}
+	return defaultHeavyOpsPerPool
+}
+
+func normalizeHeavyOpPools(pools []string) []string {
+	seen := make(map[string]struct{}, len(pools))
+	out := make([]string, 0, len(pools))
+	for _, pool := range pools {
+		pool = strings.TrimSpace(pool)
+		if pool == "" {
+			continue
+		}
+		if _, ok := seen[pool]; ok {
+			continue
+		}
+		seen[pool] = struct{}{}
+		out = append(out, pool)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func sharesPool(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// admissibleLocked reports whether op fits now. blocked holds the pools of
+// waiters ahead of it.
+func (l *heavyOpLimiter) admissibleLocked(op HeavyOp, blocked []string) bool {
+	if sharesPool(op.Pools, blocked) {
+		return false
+	}
+	limit := l.limit()
+	for _, pool := range op.Pools {
+		if l.inUse[pool] >= limit {
+			return false
+		}
+	}
+	return true
+}
+
+func (l *heavyOpLimiter) grantLocked(op HeavyOp) {
+	op.Position = 0
+	op.Since = time.Now().UTC()
+	l.held[op.Key] = op
+	for _, pool := range op.Pools {
+		l.inUse[pool]++
+	}
+}
+
+func (l *heavyOpLimiter) waitingPoolsLocked() []string {
+	var pools []string
+	for _, w := range l.waiting {
+		pools = append(pools, w.op.Pools...)
+	}
+	return pools
+}
+
+// tryReserve takes the slots for op if it can run right away.
+func (l *heavyOpLimiter) tryReserve(op HeavyOp) bool {
+	op.Pools = normalizeHeavyOpPools(op.Pools)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.held[op.Key]; ok {
+		return true
+	}
+	if !l.admissibleLocked(op, l.waitingPoolsLocked()) {
+		return false
+	}
+	l.grantLocked(op)
+	return true
+}
+
+// reserve takes the slots for op, or queues it and returns its position.
+// ready is called, outside the lock, once a queued op has been granted.
+// Reserving a key that already holds its slots succeeds again, which lets a
+// task that was granted while queued pass through on its next execution.
+func (l *heavyOpLimiter) reserve(op HeavyOp, ready func()) (bool, int) {
+	op.Pools = normalizeHeavyOpPools(op.Pools)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.held[op.Key]; ok {
+		return true, 0
+	}
+	for _, w := range l.waiting {
+		if w.op.Key == op.Key {
+			return false, l.positionLocked(op.Key)
+		}
+	}
+	if l.admissibleLocked(op, l.waitingPoolsLocked()) {
+		l.grantLocked(op)
+		return true, 0
+	}
+
+	op.Since = time.Now().UTC()
+	l.waiting = append(l.waiting, &heavyOpWaiter{op: op, ready: ready})
+	return false, l.positionLocked(op.Key)
+}
+
+// release frees the slots held by key and admits whoever now fits.
+func (l *heavyOpLimiter) release(key string) {
+	l.mu.Lock()
+	op, ok := l.held[key]
+	if !ok {
+		l.mu.Unlock()
+		return
+	}
+	delete(l.held, key)
+	for _, pool := range op.Pools {
+		l.inUse[pool]--
+		if l.inUse[pool] <= 0 {
+			delete(l.inUse, pool)
+		}
+	}
+	ready := l.admitLocked()
+	l.mu.Unlock()
+
+	for _, fn := range ready {
+		fn()
+	}
+}
+
+// cancel drops a waiter. It returns false when key is not waiting, which
+// includes a waiter that was granted in the meantime.
+func (l *heavyOpLimiter) cancel(key string) bool {
+	l.mu.Lock()
+	removed := false
+	for i, w := range l.waiting {
+		if w.op.Key == key {
+			l.waiting = append(l.waiting[:i], l.waiting[i+1:]...)
+			removed = true
+			break
+		}
+	}
+	var ready []func()
+	if removed {
+		ready = l.admitLocked()
+	}
+	l.mu.Unlock()
+
+	for _, fn := range ready {
+		fn()
+	}
+	return removed
+}
+
+func (l *heavyOpLimiter) admitLocked() []func() {
+	var ready []func()
+	var blocked []string
+	remaining := l.waiting[:0]
+	for _, w := range l.waiting {
+		if l.admissibleLocked(w.op, blocked) {
+			l.grantLocked(w.op)
+			if w.ready != nil {
+				ready = append(ready, w.ready)
+			}
+			continue
+		}
+		blocked = append(blocked, w.op.Pools...)
+		remaining = append(remaining, w)
+	}
+	l.waiting = remaining
+	return ready
+}
+
+// positionLocked is 1 for the first waiter on any of key's pools.
+func (l *heavyOpLimiter) positionLocked(key string) int {
+	var target *heavyOpWaiter
+	for _, w := range l.waiting {
+		if w.op.Key == key {
+			target = w
+			break
+		}
+	}
+	if target == nil {
+		return 0
+	}
+	position := 1
+	for _, w := range l.waiting {
+		if w == target {
+			break
+		}
+		if sharesPool(w.op.Pools, target.op.Pools) {
+			position++
+		}
+	}
+	return position
+}
+
+func (l *heavyOpLimiter) position(key string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.positionLocked(key)
+}
+
+func (l *heavyOpLimiter) snapshot() []HeavyOpsPool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limit := l.limit()
+	byPool := make(map[string]*HeavyOpsPool)
+	entry := func(pool string) *HeavyOpsPool {
+		if p, ok := byPool[pool]; ok {
+			return p
+		}
+		p := &HeavyOpsPool{Pool: pool, Limit: limit, Running: []HeavyOp{}, Waiting: []HeavyOp{}}
+		byPool[pool] = p
+		return p
+	}
+
+	for _, op := range l.held {
+		for _, pool := range op.Pools {
+			p := entry(pool)
+			p.Running = append(p.Running, op)
+		}
+	}
+	for _, w := range l.waiting {
+		op := w.op
+		op.Position = l.positionLocked(op.Key)
+		for _, pool := range op.Pools {
+			p := entry(pool)
+			p.Waiting = append(p.Waiting, op)
+		}
+	}
+
+	pools := make([]HeavyOpsPool, 0, len(byPool))
+	for _, p := range byPool {
+		sort.Slice(p.Running, func(i, j int) bool { return p.Running[i].Since.Before(p.Running[j].Since) })
+		pools = append(pools, *p)
+	}
+	sort.Slice(pools, func(i, j int) bool { return pools[i].Pool < pools[j].Pool })
+	return pools
+}
+
+func heavyOpTaskKey(taskID uint) string {
+	return fmt.Sprintf("task:%d", taskID)
+}
+
+func isHeavyAction(guestType, action string) bool {
+	switch guestType {
+	case taskModels.GuestTypeVM:
+		return action == "create" || action == "clone"
+	case taskModels.GuestTypeJail, taskModels.GuestTypeVMTemplate, taskModels.GuestTypeJailTemplate:
+		return action == "create"
+	}
+	return false
+}
+
+// heavyOpPools lists the pools a heavy task writes to.
+func (s *Service) heavyOpPools(task taskModels.GuestLifecycleTask) ([]string, error) {
+	switch task.GuestType {
+	case taskModels.GuestTypeVM:
+		if task.Action == "create" {
+			req := libvirtServiceInterfaces.CreateVMRequest{}
+			if err := json.Unmarshal([]byte(task.Payload), &req); err != nil {
+				return nil, fmt.Errorf("invalid_vm_create_payload: %w", err)
+			}
+			return []string{req.StoragePool}, nil
+		}
+
+		var storages []vmModels.Storage
+		if err := s.DB.
+			Joins("JOIN vms ON vms.id = vm_storages.vm_id").
+			Where("vms.rid = ?", task.GuestID).
+			Find(&storages).Error; err != nil {
+			return nil, fmt.Errorf("failed_to_list_vm_storages: %w", err)
+		}
+		pools := make([]string, 0, len(storages))
+		for _, storage := range storages {
+			pools = append(pools, storage.Pool)
+		}
+		return pools, nil
+
+	case taskModels.GuestTypeJail:
+		req := jailServiceInterfaces.CreateJailRequest{}
+		if err := json.Unmarshal([]byte(task.Payload), &req); err != nil {
+			return nil, fmt.Errorf("invalid_jail_create_payload: %w", err)
+		}
+		return []string{req.Pool}, nil
+
+	case taskModels.GuestTypeVMTemplate:
+		req := libvirtServiceInterfaces.CreateFromTemplateRequest{}
+		if strings.TrimSpace(task.Payload) != "" {
+			if err := json.Unmarshal([]byte(task.Payload), &req); err != nil {
+				return nil, fmt.Errorf("invalid_vm_template_create_payload: %w", err)
+			}
+		}
+		var template vmModels.VMTemplate
+		if err := s.DB.First(&template, task.GuestID).Error; err != nil {
+			return nil, fmt.Errorf("failed_to_get_vm_template: %w", err)
+		}
+		assigned := make(map[uint]string, len(req.StoragePools))
+		for _, assignment := range req.StoragePools {
+			assigned[assignment.SourceStorageID] = assignment.Pool
+		}
+		pools := make([]string, 0, len(template.Storages))
+		for _, storage := range template.Storages {
+			if pool := strings.TrimSpace(assigned[storage.SourceStorageID]); pool != "" {
+				pools = append(pools, pool)
+				continue
+			}
+			pools = append(pools, storage.Pool)
+		}
+		return pools, nil
+
+	case taskModels.GuestTypeJailTemplate:
+		req := jail.CreateFromTemplateRequest{}
+		if strings.TrimSpace(task.Payload) != "" {
+			if err := json.Unmarshal([]byte(task.Payload), &req); err != nil {
+				return nil, fmt.Errorf("invalid_template_create_payload: %w", err)
+			}
+		}
+		if strings.TrimSpace(req.Pool) != "" {
+			return []string{req.Pool}, nil
+		}
+		var template jailModels.JailTemplate
+		if err := s.DB.First(&template, task.GuestID).Error; err != nil {
+			return nil, fmt.Errorf("failed_to_get_jail_template: %w", err)
+		}
+		return []string{template.Pool}, nil
+	}
+
+	return nil, nil
+}
+
+func heavyOpLabel(task taskModels.GuestLifecycleTask) string {
+	return fmt.Sprintf("%s %s %d", task.GuestType, task.Action, task.GuestID)
+}
+
+// admitHeavyTask reserves the task's pools. A task that has to wait is left
+// queued with HeavyOpWaitingMessage and is enqueued again once admitted.
+func (s *Service) admitHeavyTask(ctx context.Context, task taskModels.GuestLifecycleTask) (bool, error) {
+	pools, err := s.heavyOpPools(task)
+	if err != nil {
+		return false, err
+	}
+
+	op := HeavyOp{Key: heavyOpTaskKey(task.ID), Label: heavyOpLabel(task), TaskID: task.ID, Pools: pools}
+	granted, position := s.heavyOps.reserve(op, func() { s.resumeHeavyTask(task.ID) })
+	if granted {
+		return true, nil
+	}
+
+	if err := s.DB.WithContext(ctx).Model(&taskModels.GuestLifecycleTask{}).
+		Where("id = ? AND status = ?", task.ID, taskModels.LifecycleTaskStatusQueued).
+		Update("message", HeavyOpWaitingMessage).Error; err != nil {
+		logger.L.Warn().Err(err).Uint("task_id", task.ID).Msg("heavy_op_waiting_message_update_failed")
+	}
+	logger.L.Info().Uint("task_id", task.ID).Strs("pools", op.Pools).Int("position", position).Msg("heavy_op_queued")
+	return false, nil
+}
+
+// resumeHeavyTask enqueues a task whose pools have been reserved for it.
+func (s *Service) resumeHeavyTask(taskID uint) {
+	if err := s.enqueueExec(context.Background(), taskID); err != nil {
+		s.heavyOps.release(heavyOpTaskKey(taskID))
+		if updateErr := s.DB.Model(&taskModels.GuestLifecycleTask{}).Where("id = ?", taskID).Updates(map[string]any{
+			"status":      taskModels.LifecycleTaskStatusFailed,
+			"error":       fmt.Sprintf("enqueue_failed: %v", err),
+			"finished_at": time.Now().UTC(),
+			"message":     "enqueue_failed",
+		}).Error; updateErr != nil {
+			logger.L.Warn().Err(updateErr).Uint("task_id", taskID).Msg("heavy_op_enqueue_failure_update_failed")
+		}
+	}
+}
+
+func (s *Service) enqueueExec(ctx context.Context, taskID uint) error {
+	if s.enqueueExecFn != nil {
+		return s.enqueueExecFn(ctx, taskID)
+	}
+	return db.EnqueueJSON(ctx, guestLifecycleExecQueueName, guestLifecycleExecPayload{TaskID: taskID})
+}
+
+// withQueuePositions fills in where each waiting task stands.
+func (s *Service) withQueuePositions(tasks []taskModels.GuestLifecycleTask) []taskModels.GuestLifecycleTask {
+	for i := range tasks {
+		if tasks[i].Status == taskModels.LifecycleTaskStatusQueued {
+			tasks[i].QueuePosition = s.heavyOps.position(heavyOpTaskKey(tasks[i].ID))
+		}
+	}
+	return tasks
+}
+
+// HeavyOps lists, per pool, the heavy operations running and waiting.
+func (s *Service) HeavyOps() []HeavyOpsPool {
+	return s.heavyOps.snapshot()
+}
+
+// AcquireHeavyOp blocks until pools have room for one more heavy operation
+// and returns the function that gives the slots back. It is used by work
+// that does not run as a lifecycle task, such as restores.
+func (s *Service) AcquireHeavyOp(ctx context.Context, key, label string, pools []string) (func(), error) {
+	op := HeavyOp{Key: key, Label: label, Pools: pools}
+	granted := make(chan struct{})
+	ok, position := s.heavyOps.reserve(op, func() { close(granted) })
+	if !ok {
+		logger.L.Info().Str("key", key).Strs("pools", pools).Int("position", position).Msg("heavy_op_waiting")
+		select {
+		case <-granted:
+		case <-ctx.Done():
+			if s.heavyOps.cancel(key) {
+				return nil, ctx.Err()
+			}
+			// Granted while we were giving up.
+			s.heavyOps.release(key)
+			return nil, ctx.Err()
+		}
+	}
+	return func() { s.heavyOps.release(key) }, nil
+}
+
+// RunOrQueueCreate runs a guest create right away when its pool has room,
+// and otherwise records it as a queued lifecycle task that runs when a slot
+// frees up. It returns the task only when the create was queued.
+func (s *Service) RunOrQueueCreate(
+	ctx context.Context,
+	guestType string,
+	guestID uint,
+	pools []string,
+	payload any,
+	requestedBy string,
+	run func() error,
+) (*taskModels.GuestLifecycleTask, error) {
+	key := fmt.Sprintf("%s-create:%d", normalizeGuestType(guestType), guestID)
+	if s.heavyOps.tryReserve(HeavyOp{Key: key, Label: fmt.Sprintf("%s create %d", guestType, guestID), Pools: pools}) {
+		defer s.heavyOps.release(key)
+		return nil, run()
+	}
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid_create_payload: %w", err)
+	}
+
+	task, _, err := s.createTask(ctx, guestType, guestID, "create", taskModels.LifecycleTaskSourceUser, requestedBy, string(raw), false)
+	if err != nil {
+		return nil, err
+	}
+
+	admitted, err := s.admitHeavyTask(ctx, *task)
+	if err != nil {
+		s.failTask(task.ID, err)
+		return nil, err
+	}
+	if admitted {
+		// A slot freed up between the two checks.
+		s.resumeHeavyTask(task.ID)
+	}
+
+	refetched, err := s.GetTask(task.ID)
+	if err != nil || refetched == nil {
+		return task, err
+	}
+	refetched.QueuePosition = s.heavyOps.position(heavyOpTaskKey(task.ID))
+	return refetched, nil
+}
+
+func (s *Service) failTask(taskID uint, cause error) {
+	if err := s.DB.Model(&taskModels.GuestLifecycleTask{}).Where("id = ?", taskID).Updates(map[string]any{
+		"status":      taskModels.LifecycleTaskStatusFailed,
+		"message":     "failed",
+		"error":       cause.Error(),
+		"finished_at": time.Now().UTC(),
+	}).Error; err != nil {
+		logger.L.Warn().Err(err).Uint("task_id", taskID).Msg("lifecycle_task_fail_update_failed")
+	}
+}
+
+// requeueWaitingTasks puts tasks that were waiting for a slot when the
+// previous process exited back in line, oldest first.
+func (s *Service) requeueWaitingTasks(ctx context.Context) error {
+	var tasks []taskModels.GuestLifecycleTask
+	if err := s.DB.WithContext(ctx).
+		Where("status = ? AND message = ?", taskModels.LifecycleTaskStatusQueued, HeavyOpWaitingMessage).
+		Order("created_at ASC").
+		Order("id ASC").
+		Find(&tasks).Error; err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		admitted, err := s.admitHeavyTask(ctx, task)
+		if err != nil {
+			s.failTask(task.ID, err)
+			continue
+		}
+		if admitted {
+			s.resumeHeavyTask(task.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
+	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+)
+
+func TestHeavyOpLimiterQueuesPerPoolInOrder(t *testing.T) {
+	l := newHeavyOpLimiter(func() int { return 1 })
+
+	if ok, _ := l.reserve(HeavyOp{Key: "a", Pools: []string{"tank"}}, nil); !ok {
+		t.Fatal("first op on tank should run")
+	}
+
+	var readied []string
+	ok, position := l.reserve(HeavyOp{Key: "b", Pools: []string{"tank", "fast"}}, func() { readied = append(readied, "b") })
+	if ok || position != 1 {
+		t.Fatalf("b: granted=%v position=%d, want queued at 1", ok, position)
+	}
+
+	// c only needs fast, which is idle, but b is ahead of it on that pool.
+	ok, position = l.reserve(HeavyOp{Key: "c", Pools: []string{"fast"}}, func() { readied = append(readied, "c") })
+	if ok || position != 2 {
+		t.Fatalf("c: granted=%v position=%d, want queued at 2", ok, position)
+	}
+
+	if !l.tryReserve(HeavyOp{Key: "d", Pools: []string{"slow"}}) {
+		t.Fatal("an op on an unrelated pool should not wait")
+	}
+	if l.tryReserve(HeavyOp{Key: "e", Pools: []string{"tank"}}) {
+		t.Fatal("tryReserve must not jump the queue")
+	}
+
+	l.release("a")
+	if len(readied) != 1 || readied[0] != "b" {
+		t.Fatalf("readied = %v, want [b]", readied)
+	}
+	if got := l.position("c"); got != 1 {
+		t.Fatalf("c position = %d, want 1", got)
+	}
+
+	l.release("b")
+	if len(readied) != 2 || readied[1] != "c" {
+		t.Fatalf("readied = %v, want [b c]", readied)
+	}
+
+	pools := l.snapshot()
+	if len(pools) != 2 || pools[0].Pool != "fast" || len(pools[0].Running) != 1 || pools[1].Pool != "slow" {
+		t.Fatalf("unexpected snapshot: %+v", pools)
+	}
+}
+
+func TestAcquireHeavyOpGivesUpWithContext(t *testing.T) {
+	s, _ := newLifecycleTestService(t)
+	s.heavyOps = newHeavyOpLimiter(func() int { return 1 })
+
+	release, err := s.AcquireHeavyOp(context.Background(), "restore-1", "restore", []string{"tank"})
+	if err != nil {
+		t.Fatalf("first acquire: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.AcquireHeavyOp(ctx, "restore-2", "restore", []string{"tank"}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if got := s.heavyOps.position("restore-2"); got != 0 {
+		t.Fatalf("cancelled waiter still queued at %d", got)
+	}
+
+	release()
+	if pools := s.HeavyOps(); len(pools) != 0 {
+		t.Fatalf("expected no pools in use, got %+v", pools)
+	}
+}
+
+func TestExecuteTaskWaitsForPoolCapacity(t *testing.T) {
+	s, _ := newLifecycleTestService(t)
+	s.heavyOps = newHeavyOpLimiter(func() int { return 1 })
+
+	var enqueued []uint
+	s.enqueueExecFn = func(_ context.Context, taskID uint) error {
+		enqueued = append(enqueued, taskID)
+		return nil
+	}
+	var created []uint
+	s.vmCreateFn = func(_ context.Context, req libvirtServiceInterfaces.CreateVMRequest) error {
+		created = append(created, *req.RID)
+		return nil
+	}
+
+	if !s.heavyOps.tryReserve(HeavyOp{Key: "busy", Pools: []string{"tank"}}) {
+		t.Fatal("failed to occupy tank")
+	}
+
+	task, _, err := s.createTask(
+		context.Background(),
+		taskModels.GuestTypeVM,
+		301,
+		"create",
+		taskModels.LifecycleTaskSourceUser,
+		"tester",
+		`{"rid":301,"name":"vm-301","storagePool":"tank"}`,
+		false,
+	)
+	if err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+
+	if err := s.ExecuteTask(context.Background(), task.ID); err != nil {
+		t.Fatalf("execute while busy: %v", err)
+	}
+	waiting, err := s.GetTask(task.ID)
+	if err != nil {
+		t.Fatalf("get task: %v", err)
+	}
+	if waiting.Status != taskModels.LifecycleTaskStatusQueued || waiting.Message != HeavyOpWaitingMessage || waiting.QueuePosition != 1 {
+		t.Fatalf("unexpected waiting task: status=%s message=%s position=%d", waiting.Status, waiting.Message, waiting.QueuePosition)
+	}
+	if len(created) != 0 {
+		t.Fatal("create ran while the pool was busy")
+	}
+
+	s.heavyOps.release("busy")
+	if len(enqueued) != 1 || enqueued[0] != task.ID {
+		t.Fatalf("enqueued = %v, want [%d]", enqueued, task.ID)
+	}
+
+	if err := s.ExecuteTask(context.Background(), task.ID); err != nil {
+		t.Fatalf("execute after release: %v", err)
+	}
+	done, err := s.GetTask(task.ID)
+	if err != nil {
+		t.Fatalf("get task: %v", err)
+	}
+	if done.Status != taskModels.LifecycleTaskStatusSuccess || len(created) != 1 || created[0] != 301 {
+		t.Fatalf("unexpected result: status=%s created=%v", done.Status, created)
+	}
+	if pools := s.HeavyOps(); len(pools) != 0 {
+		t.Fatalf("slot not released: %+v", pools)
+	}
+}
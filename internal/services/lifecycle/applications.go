@@ -0,0 +1,479 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/db"
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"gorm.io/gorm"
+)
+
+const (
+	guestApplicationQueueName = "guest-application-action"
+
+	applicationDefaultHealthTimeout = 2 * time.Minute
+	applicationMaxHealthTimeout     = 30 * time.Minute
+	applicationStopTimeout          = 2 * time.Minute
+	applicationTCPDialTimeout       = 2 * time.Second
+	applicationNameMaxLength        = 128
+)
+
+var (
+	ErrApplicationNotFound   = errors.New("application_not_found")
+	ErrApplicationInProgress = errors.New("application_action_in_progress")
+)
+
+// Overridden in tests.
+var applicationHealthPollInterval = 2 * time.Second
+
+type ApplicationMemberRequest struct {
+	GuestType            string `json:"guestType" binding:"required"`
+	GuestID              uint   `json:"guestId" binding:"required"`
+	StartOrder           int    `json:"startOrder"`
+	HealthCheck          string `json:"healthCheck"`
+	HealthTarget         string `json:"healthTarget"`
+	HealthTimeoutSeconds int    `json:"healthTimeoutSeconds"`
+}
+
+type ApplicationRequest struct {
+	Name        string                     `json:"name" binding:"required"`
+	Description string                     `json:"description"`
+	Members     []ApplicationMemberRequest `json:"members"`
+}
+
+type guestApplicationPayload struct {
+	ApplicationID uint   `json:"applicationId"`
+	Action        string `json:"action"`
+	RequestedBy   string `json:"requestedBy"`
+}
+
+func (s *Service) ListApplications() ([]taskModels.Application, error) {
+	var apps []taskModels.Application
+	if err := s.DB.Preload("Members", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("start_order ASC, id ASC")
+	}).Order("name ASC").Find(&apps).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_list_applications: %w", err)
+	}
+	return apps, nil
+}
+
+func (s *Service) GetApplication(id uint) (*taskModels.Application, error) {
+	var app taskModels.Application
+	if err := s.DB.Preload("Members", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("start_order ASC, id ASC")
+	}).First(&app, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrApplicationNotFound
+		}
+		return nil, fmt.Errorf("failed_to_get_application: %w", err)
+	}
+	return &app, nil
+}
+
+func (s *Service) CreateApplication(req ApplicationRequest) (*taskModels.Application, error) {
+	app, err := s.buildApplication(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.DB.Create(app).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_create_application: %w", err)
+	}
+	return s.GetApplication(app.ID)
+}
+
+// UpdateApplication replaces an application's name, description and members.
+func (s *Service) UpdateApplication(id uint, req ApplicationRequest) (*taskModels.Application, error) {
+	existing, err := s.GetApplication(id)
+	if err != nil {
+		return nil, err
+	}
+
+	app, err := s.buildApplication(req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&taskModels.Application{}).Where("id = ?", existing.ID).Updates(map[string]any{
+			"name":        app.Name,
+			"description": app.Description,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("application_id = ?", existing.ID).Delete(&taskModels.ApplicationMember{}).Error; err != nil {
+			return err
+		}
+		for i := range app.Members {
+			app.Members[i].ApplicationID = existing.ID
+		}
+		if len(app.Members) > 0 {
+			return tx.Create(&app.Members).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed_to_update_application: %w", err)
+	}
+	return s.GetApplication(existing.ID)
+}
+
+func (s *Service) DeleteApplication(id uint) error {
+	if s.applicationBusy(id) {
+		return ErrApplicationInProgress
+	}
+
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("application_id = ?", id).Delete(&taskModels.ApplicationMember{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&taskModels.Application{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrApplicationNotFound
+		}
+		return nil
+	})
+	if errors.Is(err, ErrApplicationNotFound) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed_to_delete_application: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) buildApplication(req ApplicationRequest) (*taskModels.Application, error) {
+	app := &taskModels.Application{
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+	}
+	if app.Name == "" || len(app.Name) > applicationNameMaxLength {
+		return nil, fmt.Errorf("invalid_application_name")
+	}
+
+	seen := make(map[string]struct{}, len(req.Members))
+	for _, m := range req.Members {
+		member := taskModels.ApplicationMember{
+			GuestType:            normalizeGuestType(m.GuestType),
+			GuestID:              m.GuestID,
+			StartOrder:           m.StartOrder,
+			HealthCheck:          strings.TrimSpace(strings.ToLower(m.HealthCheck)),
+			HealthTarget:         strings.TrimSpace(m.HealthTarget),
+			HealthTimeoutSeconds: m.HealthTimeoutSeconds,
+		}
+
+		if member.GuestType != taskModels.GuestTypeVM && member.GuestType != taskModels.GuestTypeJail {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidGuest, member.GuestType)
+		}
+		if member.GuestID == 0 {
+			return nil, fmt.Errorf("invalid_guest_id")
+		}
+
+		key := fmt.Sprintf("%s:%d", member.GuestType, member.GuestID)
+		if _, ok := seen[key]; ok {
+			return nil, fmt.Errorf("invalid_application_member_duplicate: %s", key)
+		}
+		seen[key] = struct{}{}
+
+		if member.HealthCheck == "" {
+			member.HealthCheck = taskModels.ApplicationHealthRunning
+		}
+		switch member.HealthCheck {
+		case taskModels.ApplicationHealthRunning:
+			member.HealthTarget = ""
+		case taskModels.ApplicationHealthTCP:
+			if _, _, err := net.SplitHostPort(member.HealthTarget); err != nil {
+				return nil, fmt.Errorf("invalid_application_health_target: %s", member.HealthTarget)
+			}
+		default:
+			return nil, fmt.Errorf("invalid_application_health_check: %s", member.HealthCheck)
+		}
+
+		timeout := time.Duration(member.HealthTimeoutSeconds) * time.Second
+		if member.HealthTimeoutSeconds < 0 || timeout > applicationMaxHealthTimeout {
+			return nil, fmt.Errorf("invalid_application_health_timeout")
+		}
+
+		exists, err := s.applicationGuestExists(member.GuestType, member.GuestID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, fmt.Errorf("application_member_not_found: %s", key)
+		}
+
+		app.Members = append(app.Members, member)
+	}
+
+	return app, nil
+}
+
+func (s *Service) applicationGuestExists(guestType string, guestID uint) (bool, error) {
+	var count int64
+	var err error
+	switch guestType {
+	case taskModels.GuestTypeVM:
+		err = s.DB.Model(&vmModels.VM{}).Where("rid = ?", guestID).Count(&count).Error
+	case taskModels.GuestTypeJail:
+		err = s.DB.Model(&jailModels.Jail{}).Where("ct_id = ?", guestID).Count(&count).Error
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed_to_check_application_member: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (s *Service) applicationBusy(id uint) bool {
+	s.applicationMu.Lock()
+	defer s.applicationMu.Unlock()
+	_, ok := s.runningApplications[id]
+	return ok
+}
+
+func (s *Service) claimApplication(id uint) bool {
+	s.applicationMu.Lock()
+	defer s.applicationMu.Unlock()
+	if s.runningApplications == nil {
+		s.runningApplications = make(map[uint]struct{})
+	}
+	if _, ok := s.runningApplications[id]; ok {
+		return false
+	}
+	s.runningApplications[id] = struct{}{}
+	return true
+}
+
+func (s *Service) releaseApplication(id uint) {
+	s.applicationMu.Lock()
+	defer s.applicationMu.Unlock()
+	delete(s.runningApplications, id)
+}
+
+// RequestApplicationAction queues a start or stop of every member of an
+// application. Only one action per application runs at a time.
+func (s *Service) RequestApplicationAction(ctx context.Context, id uint, action, requestedBy string) error {
+	action = normalizeAction(action)
+	if action != "start" && action != "stop" {
+		return fmt.Errorf("%w: %s", ErrInvalidAction, action)
+	}
+	if _, err := s.GetApplication(id); err != nil {
+		return err
+	}
+	if !s.claimApplication(id) {
+		return ErrApplicationInProgress
+	}
+
+	if err := db.EnqueueJSON(ctx, guestApplicationQueueName, guestApplicationPayload{
+		ApplicationID: id,
+		Action:        action,
+		RequestedBy:   strings.TrimSpace(requestedBy),
+	}); err != nil {
+		s.releaseApplication(id)
+		return fmt.Errorf("failed_to_enqueue_application_action: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) runApplicationAction(ctx context.Context, payload guestApplicationPayload) error {
+	defer s.releaseApplication(payload.ApplicationID)
+
+	app, err := s.GetApplication(payload.ApplicationID)
+	if err != nil {
+		return err
+	}
+
+	switch payload.Action {
+	case "start":
+		return s.startApplication(ctx, app, payload.RequestedBy)
+	case "stop":
+		return s.stopApplication(ctx, app, payload.RequestedBy)
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidAction, payload.Action)
+	}
+}
+
+func sortedApplicationMembers(app *taskModels.Application) []taskModels.ApplicationMember {
+	members := append([]taskModels.ApplicationMember{}, app.Members...)
+	sort.SliceStable(members, func(i, j int) bool {
+		if members[i].StartOrder != members[j].StartOrder {
+			return members[i].StartOrder < members[j].StartOrder
+		}
+		return members[i].ID < members[j].ID
+	})
+	return members
+}
+
+// startApplication starts members in order and waits for each one to pass its
+// health check before starting the next, so a tier never comes up ahead of
+// what it depends on.
+func (s *Service) startApplication(ctx context.Context, app *taskModels.Application, requestedBy string) error {
+	for _, member := range sortedApplicationMembers(app) {
+		if err := s.runApplicationMemberTask(ctx, member, "start", requestedBy); err != nil {
+			return fmt.Errorf("application_member_start_failed_%s_%d: %w", member.GuestType, member.GuestID, err)
+		}
+		if err := s.waitForApplicationMemberHealthy(ctx, member); err != nil {
+			return fmt.Errorf("application_member_unhealthy_%s_%d: %w", member.GuestType, member.GuestID, err)
+		}
+	}
+
+	logger.L.Info().Uint("application_id", app.ID).Msg("application_started")
+	return nil
+}
+
+// stopApplication stops members in reverse start order, waiting for each to
+// go down before stopping the ones it depends on.
+func (s *Service) stopApplication(ctx context.Context, app *taskModels.Application, requestedBy string) error {
+	members := sortedApplicationMembers(app)
+	for i := len(members) - 1; i >= 0; i-- {
+		member := members[i]
+		guest := shutdownGuest{guestType: member.GuestType, guestID: member.GuestID}
+		if !s.shutdownGuestRunning(guest) {
+			continue
+		}
+
+		action := "stop"
+		if member.GuestType == taskModels.GuestTypeVM {
+			action = "shutdown"
+		}
+		if err := s.runApplicationMemberTask(ctx, member, action, requestedBy); err != nil {
+			return fmt.Errorf("application_member_stop_failed_%s_%d: %w", member.GuestType, member.GuestID, err)
+		}
+		if err := s.waitForApplicationMember(ctx, applicationStopTimeout, func() bool {
+			return !s.shutdownGuestRunning(guest)
+		}); err != nil {
+			return fmt.Errorf("application_member_stop_timeout_%s_%d: %w", member.GuestType, member.GuestID, err)
+		}
+	}
+
+	logger.L.Info().Uint("application_id", app.ID).Msg("application_stopped")
+	return nil
+}
+
+func (s *Service) runApplicationMemberTask(ctx context.Context, member taskModels.ApplicationMember, action, requestedBy string) error {
+	task, _, err := s.createTask(ctx, member.GuestType, member.GuestID, action, taskModels.LifecycleTaskSourceApplication, requestedBy, "", false)
+	if err != nil {
+		return err
+	}
+
+	if err := s.ExecuteTask(ctx, task.ID); err != nil && !errors.Is(err, errGuestAlreadyRunning) {
+		return err
+	}
+	return nil
+}
+
+func applicationMemberHealthTimeout(member taskModels.ApplicationMember) time.Duration {
+	if member.HealthTimeoutSeconds <= 0 {
+		return applicationDefaultHealthTimeout
+	}
+	return time.Duration(member.HealthTimeoutSeconds) * time.Second
+}
+
+func (s *Service) applicationMemberHealthy(member taskModels.ApplicationMember) bool {
+	if !s.shutdownGuestRunning(shutdownGuest{guestType: member.GuestType, guestID: member.GuestID}) {
+		return false
+	}
+	if member.HealthCheck != taskModels.ApplicationHealthTCP {
+		return true
+	}
+
+	conn, err := net.DialTimeout("tcp", member.HealthTarget, applicationTCPDialTimeout)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
+
+func (s *Service) waitForApplicationMemberHealthy(ctx context.Context, member taskModels.ApplicationMember) error {
+	return s.waitForApplicationMember(ctx, applicationMemberHealthTimeout(member), func() bool {
+		return s.applicationMemberHealthy(member)
+	})
+}
+
+func (s *Service) waitForApplicationMember(ctx context.Context, timeout time.Duration, ready func() bool) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		if ready() {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed_out_after_%s", timeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(applicationHealthPollInterval):
+		}
+	}
+}
+
+// WaitForApplicationDependencies blocks a guest's failover activation until
+// the members ordered before it in any of its applications are healthy on
+// this node. Members that are not registered here are skipped, and a member
+// that stays unhealthy past its timeout only logs a warning, so a broken tier
+// delays activation but never blocks it.
+func (s *Service) WaitForApplicationDependencies(ctx context.Context, guestType string, guestID uint) error {
+	guestType = normalizeGuestType(guestType)
+
+	var memberships []taskModels.ApplicationMember
+	if err := s.DB.Where("guest_type = ? AND guest_id = ?", guestType, guestID).Find(&memberships).Error; err != nil {
+		return fmt.Errorf("failed_to_list_application_memberships: %w", err)
+	}
+
+	for _, membership := range memberships {
+		var predecessors []taskModels.ApplicationMember
+		if err := s.DB.
+			Where("application_id = ? AND start_order < ?", membership.ApplicationID, membership.StartOrder).
+			Order("start_order ASC, id ASC").
+			Find(&predecessors).Error; err != nil {
+			return fmt.Errorf("failed_to_list_application_members: %w", err)
+		}
+
+		for _, member := range predecessors {
+			exists, err := s.applicationGuestExists(member.GuestType, member.GuestID)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				continue
+			}
+
+			if err := s.waitForApplicationMemberHealthy(ctx, member); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				logger.L.Warn().
+					Err(err).
+					Uint("application_id", member.ApplicationID).
+					Str("guest_type", guestType).
+					Uint("guest_id", guestID).
+					Str("dependency_type", member.GuestType).
+					Uint("dependency_id", member.GuestID).
+					Msg("application_dependency_unhealthy_continuing_activation")
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,258 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	"gorm.io/gorm"
+)
+
+func seedApplicationGuests(t *testing.T, dbConn *gorm.DB) {
+	t.Helper()
+
+	if err := dbConn.Create(&jailModels.Jail{CTID: 100, Name: "db", Type: jailModels.JailTypeFreeBSD}).Error; err != nil {
+		t.Fatalf("failed to create jail: %v", err)
+	}
+	for _, vm := range []vmModels.VM{{RID: 200, Name: "app"}, {RID: 300, Name: "web"}} {
+		if err := dbConn.Create(&vm).Error; err != nil {
+			t.Fatalf("failed to create vm: %v", err)
+		}
+	}
+}
+
+func TestCreateApplicationValidatesMembers(t *testing.T) {
+	s, dbConn := newLifecycleTestService(t)
+	seedApplicationGuests(t, dbConn)
+
+	cases := map[string]ApplicationRequest{
+		"invalid_application_name": {Name: " "},
+		"invalid_guest_type":       {Name: "x", Members: []ApplicationMemberRequest{{GuestType: "bhyve", GuestID: 200}}},
+		"invalid_application_member_duplicate": {Name: "x", Members: []ApplicationMemberRequest{
+			{GuestType: "vm", GuestID: 200},
+			{GuestType: "VM", GuestID: 200},
+		}},
+		"invalid_application_health_target": {Name: "x", Members: []ApplicationMemberRequest{
+			{GuestType: "vm", GuestID: 200, HealthCheck: "tcp", HealthTarget: "no-port"},
+		}},
+		"invalid_application_health_check": {Name: "x", Members: []ApplicationMemberRequest{
+			{GuestType: "vm", GuestID: 200, HealthCheck: "http"},
+		}},
+		"application_member_not_found": {Name: "x", Members: []ApplicationMemberRequest{{GuestType: "jail", GuestID: 999}}},
+	}
+	for want, req := range cases {
+		if _, err := s.CreateApplication(req); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s, got %v", want, err)
+		}
+	}
+
+	app, err := s.CreateApplication(ApplicationRequest{
+		Name: "stack",
+		Members: []ApplicationMemberRequest{
+			{GuestType: "vm", GuestID: 300, StartOrder: 3},
+			{GuestType: "jail", GuestID: 100, StartOrder: 1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected create error: %v", err)
+	}
+	if len(app.Members) != 2 || app.Members[0].GuestID != 100 || app.Members[0].HealthCheck != taskModels.ApplicationHealthRunning {
+		t.Fatalf("unexpected members: %+v", app.Members)
+	}
+
+	updated, err := s.UpdateApplication(app.ID, ApplicationRequest{
+		Name:    "stack",
+		Members: []ApplicationMemberRequest{{GuestType: "vm", GuestID: 200}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected update error: %v", err)
+	}
+	if len(updated.Members) != 1 || updated.Members[0].GuestID != 200 {
+		t.Fatalf("expected members to be replaced, got %+v", updated.Members)
+	}
+
+	if err := s.DeleteApplication(app.ID); err != nil {
+		t.Fatalf("unexpected delete error: %v", err)
+	}
+	var remaining int64
+	dbConn.Model(&taskModels.ApplicationMember{}).Count(&remaining)
+	if remaining != 0 {
+		t.Fatalf("expected members to be deleted, %d remain", remaining)
+	}
+	if err := s.DeleteApplication(app.ID); err != ErrApplicationNotFound {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestApplicationStartAndStopOrder(t *testing.T) {
+	s, dbConn := newLifecycleTestService(t)
+	seedApplicationGuests(t, dbConn)
+
+	prevInterval := applicationHealthPollInterval
+	applicationHealthPollInterval = time.Millisecond
+	t.Cleanup(func() { applicationHealthPollInterval = prevInterval })
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	app, err := s.CreateApplication(ApplicationRequest{
+		Name: "stack",
+		Members: []ApplicationMemberRequest{
+			{GuestType: "vm", GuestID: 300, StartOrder: 3},
+			{GuestType: "vm", GuestID: 200, StartOrder: 2, HealthCheck: "tcp", HealthTarget: listener.Addr().String()},
+			{GuestType: "jail", GuestID: 100, StartOrder: 1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create application: %v", err)
+	}
+
+	running := map[string]bool{}
+	var order []string
+	s.jailActionFn = func(ctid int, action string) error {
+		order = append(order, fmt.Sprintf("jail:%d:%s", ctid, action))
+		running[fmt.Sprintf("jail:%d", ctid)] = action == "start"
+		return nil
+	}
+	s.vmActionFn = func(rid uint, action string) error {
+		order = append(order, fmt.Sprintf("vm:%d:%s", rid, action))
+		running[fmt.Sprintf("vm:%d", rid)] = action == "start"
+		return nil
+	}
+	s.jailActiveFn = func(ctid uint) (bool, error) { return running[fmt.Sprintf("jail:%d", ctid)], nil }
+	s.vmStateFn = func(rid uint) (int, error) {
+		if running[fmt.Sprintf("vm:%d", rid)] {
+			return 1, nil
+		}
+		return 5, nil
+	}
+
+	if err := s.startApplication(context.Background(), app, "tester"); err != nil {
+		t.Fatalf("start application: %v", err)
+	}
+	if err := s.stopApplication(context.Background(), app, "tester"); err != nil {
+		t.Fatalf("stop application: %v", err)
+	}
+
+	expected := []string{
+		"jail:100:start",
+		"vm:200:start",
+		"vm:300:start",
+		"vm:300:shutdown",
+		"vm:200:shutdown",
+		"jail:100:stop",
+	}
+	if !slices.Equal(order, expected) {
+		t.Fatalf("unexpected order: got %v want %v", order, expected)
+	}
+
+	var appTasks int64
+	dbConn.Model(&taskModels.GuestLifecycleTask{}).Where("source = ?", taskModels.LifecycleTaskSourceApplication).Count(&appTasks)
+	if appTasks != int64(len(expected)) {
+		t.Fatalf("expected %d application tasks, got %d", len(expected), appTasks)
+	}
+}
+
+func TestApplicationStartStopsAtUnhealthyMember(t *testing.T) {
+	s, dbConn := newLifecycleTestService(t)
+	seedApplicationGuests(t, dbConn)
+
+	prevInterval := applicationHealthPollInterval
+	applicationHealthPollInterval = time.Millisecond
+	t.Cleanup(func() { applicationHealthPollInterval = prevInterval })
+
+	app, err := s.CreateApplication(ApplicationRequest{
+		Name: "stack",
+		Members: []ApplicationMemberRequest{
+			{GuestType: "jail", GuestID: 100, StartOrder: 1, HealthTimeoutSeconds: 1},
+			{GuestType: "vm", GuestID: 200, StartOrder: 2},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create application: %v", err)
+	}
+
+	vmStarted := false
+	s.vmActionFn = func(_ uint, _ string) error {
+		vmStarted = true
+		return nil
+	}
+
+	err = s.startApplication(context.Background(), app, "tester")
+	if err == nil || !strings.Contains(err.Error(), "application_member_unhealthy_jail_100") {
+		t.Fatalf("expected unhealthy jail error, got %v", err)
+	}
+	if vmStarted {
+		t.Fatalf("vm must not start before its dependency is healthy")
+	}
+}
+
+func TestWaitForApplicationDependenciesSkipsMissingGuests(t *testing.T) {
+	s, dbConn := newLifecycleTestService(t)
+	seedApplicationGuests(t, dbConn)
+
+	prevInterval := applicationHealthPollInterval
+	applicationHealthPollInterval = time.Millisecond
+	t.Cleanup(func() { applicationHealthPollInterval = prevInterval })
+
+	if _, err := s.CreateApplication(ApplicationRequest{
+		Name: "stack",
+		Members: []ApplicationMemberRequest{
+			{GuestType: "jail", GuestID: 100, StartOrder: 1},
+			{GuestType: "vm", GuestID: 200, StartOrder: 2},
+		},
+	}); err != nil {
+		t.Fatalf("create application: %v", err)
+	}
+
+	polls := 0
+	s.jailActiveFn = func(_ uint) (bool, error) {
+		polls++
+		return polls >= 3, nil
+	}
+	if err := s.WaitForApplicationDependencies(context.Background(), "vm", 200); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if polls < 3 {
+		t.Fatalf("expected activation to wait for the jail, polled %d times", polls)
+	}
+
+	// A dependency that is not registered on this node is not waited on.
+	if err := dbConn.Delete(&jailModels.Jail{}, "ct_id = ?", 100).Error; err != nil {
+		t.Fatalf("delete jail: %v", err)
+	}
+	polls = 0
+	if err := s.WaitForApplicationDependencies(context.Background(), "vm", 200); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if polls != 0 {
+		t.Fatalf("expected missing dependency to be skipped, polled %d times", polls)
+	}
+}
@@ -0,0 +1,429 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alchemillahq/gzfs"
+	"github.com/alchemillahq/sylve/internal/config"
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	"github.com/alchemillahq/sylve/internal/layout"
+	"github.com/alchemillahq/sylve/internal/logger"
+)
+
+const (
+	ConsistencyIssueMissingDataset      = "missing_dataset"
+	ConsistencyIssueMissingDomain       = "missing_domain"
+	ConsistencyIssueUnmanagedDomain     = "unmanaged_domain"
+	ConsistencyIssueMissingJailConfig   = "missing_jail_config"
+	ConsistencyIssueStaleRestoreDataset = "stale_restore_dataset"
+	ConsistencyIssueOrphanedVNCPort     = "orphaned_vnc_port"
+	ConsistencyIssueDuplicateVNCPort    = "duplicate_vnc_port"
+)
+
+const (
+	ConsistencyFixUndefineDomain        = "undefine_domain"
+	ConsistencyFixDestroyRestoreDataset = "destroy_restore_dataset"
+	ConsistencyFixClearVNCPort          = "clear_vnc_port"
+)
+
+const consistencyRestoreStagingSuffix = ".restoring"
+
+var ErrConsistencyFixUnavailable = errors.New("consistency_fix_unavailable")
+
+// ConsistencyIssue is one mismatch between the database, libvirt, jail
+// configs on disk and ZFS. ID is stable across checks so a fix request can
+// name the issue it was shown. An empty Fix means the issue needs an
+// operator decision.
+type ConsistencyIssue struct {
+	ID        string `json:"id"`
+	Kind      string `json:"kind"`
+	GuestType string `json:"guestType,omitempty"`
+	GuestID   uint   `json:"guestId,omitempty"`
+	Subject   string `json:"subject"`
+	Detail    string `json:"detail"`
+	Fix       string `json:"fix,omitempty"`
+}
+
+type ConsistencyReport struct {
+	CheckedAt time.Time          `json:"checkedAt"`
+	Issues    []ConsistencyIssue `json:"issues"`
+}
+
+type ConsistencyFixResult struct {
+	ID      string `json:"id"`
+	Fix     string `json:"fix,omitempty"`
+	Applied bool   `json:"applied"`
+	Error   string `json:"error,omitempty"`
+}
+
+type consistencyInventory struct {
+	VMs   []vmModels.VM
+	Jails []jailModels.Jail
+
+	// DomainsKnown is false when libvirt is unavailable, in which case no
+	// domain issues are reported rather than flagging every VM.
+	DomainsKnown bool
+	Domains      []string
+
+	Datasets    map[string]struct{}
+	JailConfigs map[uint]bool
+}
+
+func consistencyIssueID(kind, subject string) string {
+	return kind + ":" + subject
+}
+
+func vmConsistencyDatasets(vm vmModels.VM) []string {
+	datasets := make([]string, 0, len(vm.Storages))
+	for _, storage := range vm.Storages {
+		if storage.DatasetID == nil {
+			continue
+		}
+		if name := strings.Trim(strings.TrimSpace(storage.Dataset.Name), "/"); name != "" {
+			datasets = append(datasets, name)
+		}
+	}
+	return datasets
+}
+
+func jailConsistencyDatasets(jail jailModels.Jail) []string {
+	datasets := make([]string, 0, 1)
+	for _, storage := range jail.Storages {
+		if !storage.IsBase || strings.TrimSpace(storage.Pool) == "" {
+			continue
+		}
+		datasets = append(datasets, fmt.Sprintf("%s/%d", layout.JailsDataset(strings.TrimSpace(storage.Pool)), jail.CTID))
+	}
+	return datasets
+}
+
+// detectConsistencyIssues compares an inventory snapshot and returns every
+// mismatch, sorted by ID.
+func detectConsistencyIssues(inv consistencyInventory) []ConsistencyIssue {
+	issues := make([]ConsistencyIssue, 0)
+	add := func(issue ConsistencyIssue) {
+		issue.ID = consistencyIssueID(issue.Kind, issue.Subject)
+		issues = append(issues, issue)
+	}
+
+	domains := make(map[string]struct{}, len(inv.Domains))
+	for _, name := range inv.Domains {
+		domains[strings.TrimSpace(name)] = struct{}{}
+	}
+
+	vmRIDs := make(map[string]struct{}, len(inv.VMs))
+	vncOwners := make(map[int][]uint)
+	for _, vm := range inv.VMs {
+		rid := strconv.FormatUint(uint64(vm.RID), 10)
+		vmRIDs[rid] = struct{}{}
+
+		for _, dataset := range vmConsistencyDatasets(vm) {
+			if _, ok := inv.Datasets[dataset]; !ok {
+				add(ConsistencyIssue{
+					Kind:      ConsistencyIssueMissingDataset,
+					GuestType: taskModels.GuestTypeVM,
+					GuestID:   vm.RID,
+					Subject:   dataset,
+					Detail:    fmt.Sprintf("vm %d references dataset %s which does not exist", vm.RID, dataset),
+				})
+			}
+		}
+
+		if inv.DomainsKnown {
+			if _, ok := domains[rid]; !ok {
+				add(ConsistencyIssue{
+					Kind:      ConsistencyIssueMissingDomain,
+					GuestType: taskModels.GuestTypeVM,
+					GuestID:   vm.RID,
+					Subject:   rid,
+					Detail:    fmt.Sprintf("vm %d has no libvirt domain", vm.RID),
+				})
+			}
+		}
+
+		if vm.VNCPort <= 0 {
+			continue
+		}
+		if !vm.VNCEnabled {
+			add(ConsistencyIssue{
+				Kind:      ConsistencyIssueOrphanedVNCPort,
+				GuestType: taskModels.GuestTypeVM,
+				GuestID:   vm.RID,
+				Subject:   rid,
+				Detail:    fmt.Sprintf("vm %d reserves vnc port %d with vnc disabled", vm.RID, vm.VNCPort),
+				Fix:       ConsistencyFixClearVNCPort,
+			})
+			continue
+		}
+		vncOwners[vm.VNCPort] = append(vncOwners[vm.VNCPort], vm.RID)
+	}
+
+	for port, owners := range vncOwners {
+		if len(owners) < 2 {
+			continue
+		}
+		sort.Slice(owners, func(i, j int) bool { return owners[i] < owners[j] })
+		rids := make([]string, 0, len(owners))
+		for _, rid := range owners {
+			rids = append(rids, strconv.FormatUint(uint64(rid), 10))
+		}
+		add(ConsistencyIssue{
+			Kind:    ConsistencyIssueDuplicateVNCPort,
+			Subject: strconv.Itoa(port),
+			Detail:  fmt.Sprintf("vnc port %d is assigned to vms %s", port, strings.Join(rids, ",")),
+		})
+	}
+
+	if inv.DomainsKnown {
+		for _, name := range inv.Domains {
+			name = strings.TrimSpace(name)
+			if _, ok := vmRIDs[name]; ok || name == "" {
+				continue
+			}
+			add(ConsistencyIssue{
+				Kind:    ConsistencyIssueUnmanagedDomain,
+				Subject: name,
+				Detail:  fmt.Sprintf("libvirt domain %s has no vm record", name),
+				Fix:     ConsistencyFixUndefineDomain,
+			})
+		}
+	}
+
+	for _, jail := range inv.Jails {
+		ctid := strconv.FormatUint(uint64(jail.CTID), 10)
+		for _, dataset := range jailConsistencyDatasets(jail) {
+			if _, ok := inv.Datasets[dataset]; !ok {
+				add(ConsistencyIssue{
+					Kind:      ConsistencyIssueMissingDataset,
+					GuestType: taskModels.GuestTypeJail,
+					GuestID:   jail.CTID,
+					Subject:   dataset,
+					Detail:    fmt.Sprintf("jail %d references dataset %s which does not exist", jail.CTID, dataset),
+				})
+			}
+		}
+		if exists, known := inv.JailConfigs[jail.CTID]; known && !exists {
+			add(ConsistencyIssue{
+				Kind:      ConsistencyIssueMissingJailConfig,
+				GuestType: taskModels.GuestTypeJail,
+				GuestID:   jail.CTID,
+				Subject:   ctid,
+				Detail:    fmt.Sprintf("jail %d has no %s.conf on disk", jail.CTID, ctid),
+			})
+		}
+	}
+
+	for dataset := range inv.Datasets {
+		if !strings.HasSuffix(dataset, consistencyRestoreStagingSuffix) {
+			continue
+		}
+		add(ConsistencyIssue{
+			Kind:    ConsistencyIssueStaleRestoreDataset,
+			Subject: dataset,
+			Detail:  fmt.Sprintf("restore staging dataset %s was left behind", dataset),
+			Fix:     ConsistencyFixDestroyRestoreDataset,
+		})
+	}
+
+	sort.Slice(issues, func(i, j int) bool { return issues[i].ID < issues[j].ID })
+	return issues
+}
+
+// SetStaleRestoreDatasetCleaner wires the restore service that owns
+// .restoring staging datasets, so cleanup honours its locks and journal.
+func (s *Service) SetStaleRestoreDatasetCleaner(fn func(ctx context.Context, name string) error) {
+	s.destroyRestoreDatasetFn = fn
+}
+
+func listGZFSDatasetNames(ctx context.Context, client *gzfs.Client) (map[string]struct{}, error) {
+	if client == nil || client.ZFS == nil {
+		return nil, fmt.Errorf("gzfs_not_initialized")
+	}
+
+	names := make(map[string]struct{})
+	for _, datasetType := range []gzfs.DatasetType{gzfs.DatasetTypeFilesystem, gzfs.DatasetTypeVolume} {
+		sets, err := client.ZFS.ListByType(ctx, datasetType, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, ds := range sets {
+			if ds == nil {
+				continue
+			}
+			if name := strings.Trim(strings.TrimSpace(ds.Name), "/"); name != "" {
+				names[name] = struct{}{}
+			}
+		}
+	}
+	return names, nil
+}
+
+func jailConfigExists(ctid uint) (bool, error) {
+	jailsPath, err := config.GetJailsPath()
+	if err != nil {
+		return false, fmt.Errorf("failed_to_get_jails_path: %w", err)
+	}
+
+	confPath := filepath.Join(jailsPath, strconv.FormatUint(uint64(ctid), 10), fmt.Sprintf("%d.conf", ctid))
+	if _, err := os.Stat(confPath); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *Service) collectConsistencyInventory(ctx context.Context) (consistencyInventory, error) {
+	inv := consistencyInventory{JailConfigs: make(map[uint]bool)}
+
+	if err := s.DB.Preload("Storages.Dataset").Find(&inv.VMs).Error; err != nil {
+		return inv, fmt.Errorf("failed_to_list_vms: %w", err)
+	}
+	if err := s.DB.Preload("Storages").Find(&inv.Jails).Error; err != nil {
+		return inv, fmt.Errorf("failed_to_list_jails: %w", err)
+	}
+
+	if s.consistencyDatasetsFn == nil {
+		return inv, fmt.Errorf("gzfs_not_initialized")
+	}
+	datasets, err := s.consistencyDatasetsFn(ctx)
+	if err != nil {
+		return inv, fmt.Errorf("failed_to_list_datasets: %w", err)
+	}
+	inv.Datasets = datasets
+
+	if s.consistencyDomainsFn != nil {
+		domains, known, err := s.consistencyDomainsFn()
+		if err != nil {
+			return inv, fmt.Errorf("failed_to_list_domains: %w", err)
+		}
+		inv.Domains = domains
+		inv.DomainsKnown = known
+	}
+
+	for _, jail := range inv.Jails {
+		exists, err := s.jailConfigExistsFn(jail.CTID)
+		if err != nil {
+			logger.L.Warn().Err(err).Uint("ctid", jail.CTID).Msg("consistency_jail_config_check_failed")
+			continue
+		}
+		inv.JailConfigs[jail.CTID] = exists
+	}
+
+	return inv, nil
+}
+
+// CheckConsistency reconciles guest records against libvirt, jail configs
+// and ZFS and keeps the result as the latest report. It only reports; fixes
+// are applied through ApplyConsistencyFixes.
+func (s *Service) CheckConsistency(ctx context.Context) (ConsistencyReport, error) {
+	inv, err := s.collectConsistencyInventory(ctx)
+	if err != nil {
+		return ConsistencyReport{}, err
+	}
+
+	report := ConsistencyReport{
+		CheckedAt: time.Now().UTC(),
+		Issues:    detectConsistencyIssues(inv),
+	}
+
+	s.consistencyMu.Lock()
+	s.lastConsistency = &report
+	s.consistencyMu.Unlock()
+
+	return report, nil
+}
+
+// LastConsistencyReport returns the most recent report, or nil if no check
+// has run yet.
+func (s *Service) LastConsistencyReport() *ConsistencyReport {
+	s.consistencyMu.Lock()
+	defer s.consistencyMu.Unlock()
+	if s.lastConsistency == nil {
+		return nil
+	}
+	report := *s.lastConsistency
+	return &report
+}
+
+// ApplyConsistencyFixes re-runs the check and applies the suggested fix for
+// each requested issue that is still present, so a stale report can never
+// trigger a fix against state that has since changed.
+func (s *Service) ApplyConsistencyFixes(ctx context.Context, issueIDs []string) ([]ConsistencyFixResult, error) {
+	report, err := s.CheckConsistency(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]ConsistencyIssue, len(report.Issues))
+	for _, issue := range report.Issues {
+		current[issue.ID] = issue
+	}
+
+	results := make([]ConsistencyFixResult, 0, len(issueIDs))
+	for _, id := range issueIDs {
+		id = strings.TrimSpace(id)
+		issue, ok := current[id]
+		if !ok {
+			results = append(results, ConsistencyFixResult{ID: id, Error: "consistency_issue_not_found"})
+			continue
+		}
+
+		result := ConsistencyFixResult{ID: id, Fix: issue.Fix}
+		if err := s.applyConsistencyFix(ctx, issue); err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Applied = true
+		}
+		results = append(results, result)
+	}
+
+	if _, err := s.CheckConsistency(ctx); err != nil {
+		logger.L.Warn().Err(err).Msg("consistency_recheck_after_fix_failed")
+	}
+
+	return results, nil
+}
+
+func (s *Service) applyConsistencyFix(ctx context.Context, issue ConsistencyIssue) error {
+	switch issue.Fix {
+	case ConsistencyFixUndefineDomain:
+		if s.undefineDomainFn == nil {
+			return ErrConsistencyFixUnavailable
+		}
+		return s.undefineDomainFn(issue.Subject)
+	case ConsistencyFixDestroyRestoreDataset:
+		if s.destroyRestoreDatasetFn == nil {
+			return ErrConsistencyFixUnavailable
+		}
+		return s.destroyRestoreDatasetFn(ctx, issue.Subject)
+	case ConsistencyFixClearVNCPort:
+		if err := s.DB.Model(&vmModels.VM{}).
+			Where("rid = ? AND vnc_enabled = ?", issue.GuestID, false).
+			Update("vnc_port", 0).Error; err != nil {
+			return fmt.Errorf("failed_to_clear_vnc_port: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrConsistencyFixUnavailable, issue.Kind)
+	}
+}
@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package lifecycle
+
+import (
+	"context"
+	"testing"
+
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	"github.com/alchemillahq/sylve/internal/testutil"
+)
+
+func consistencyIssueIDs(issues []ConsistencyIssue) []string {
+	ids := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	return ids
+}
+
+func TestDetectConsistencyIssues(t *testing.T) {
+	datasetID := uint(1)
+	inv := consistencyInventory{
+		VMs: []vmModels.VM{
+			{
+				RID:        100,
+				VNCEnabled: true,
+				VNCPort:    5900,
+				Storages: []vmModels.Storage{{
+					DatasetID: &datasetID,
+					Dataset:   vmModels.VMStorageDataset{Name: "tank/sylve/virtual-machines/100/zvol-1"},
+				}},
+			},
+			{RID: 101, VNCEnabled: true, VNCPort: 5900},
+			{RID: 102, VNCEnabled: false, VNCPort: 5901},
+		},
+		Jails: []jailModels.Jail{
+			{CTID: 5, Storages: []jailModels.Storage{{Pool: "tank", IsBase: true}}},
+			{CTID: 6, Storages: []jailModels.Storage{{Pool: "tank", IsBase: true}}},
+		},
+		DomainsKnown: true,
+		Domains:      []string{"100", "101", "102", "900"},
+		Datasets: map[string]struct{}{
+			"tank/sylve/jails/5":           {},
+			"tank/sylve/jails/6":           {},
+			"tank/sylve/jails/6.restoring": {},
+		},
+		JailConfigs: map[uint]bool{5: true, 6: false},
+	}
+
+	got := consistencyIssueIDs(detectConsistencyIssues(inv))
+	want := []string{
+		"duplicate_vnc_port:5900",
+		"missing_dataset:tank/sylve/virtual-machines/100/zvol-1",
+		"missing_jail_config:6",
+		"orphaned_vnc_port:102",
+		"stale_restore_dataset:tank/sylve/jails/6.restoring",
+		"unmanaged_domain:900",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected issues %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected issues %v, got %v", want, got)
+		}
+	}
+}
+
+func TestDetectConsistencyIssuesSkipsDomainsWhenUnknown(t *testing.T) {
+	inv := consistencyInventory{
+		VMs:      []vmModels.VM{{RID: 100}},
+		Datasets: map[string]struct{}{},
+	}
+
+	if issues := detectConsistencyIssues(inv); len(issues) != 0 {
+		t.Fatalf("expected no issues without a libvirt domain list, got %v", consistencyIssueIDs(issues))
+	}
+
+	inv.DomainsKnown = true
+	issues := detectConsistencyIssues(inv)
+	if len(issues) != 1 || issues[0].Kind != ConsistencyIssueMissingDomain || issues[0].GuestType != taskModels.GuestTypeVM {
+		t.Fatalf("expected a missing domain issue, got %#v", issues)
+	}
+}
+
+func TestApplyConsistencyFixesOnlyTouchesCurrentIssues(t *testing.T) {
+	dbConn := testutil.NewSQLiteTestDB(
+		t,
+		&vmModels.VMStorageDataset{},
+		&vmModels.Storage{},
+		&vmModels.VM{},
+		&jailModels.Storage{},
+		&jailModels.Jail{},
+	)
+	if err := dbConn.Create(&vmModels.VM{Name: "vm", RID: 102, VNCPort: 5901}).Error; err != nil {
+		t.Fatalf("failed to create vm: %v", err)
+	}
+
+	s := NewService(dbConn, nil, nil, nil)
+	s.consistencyDatasetsFn = func(context.Context) (map[string]struct{}, error) {
+		return map[string]struct{}{"tank/a.restoring": {}}, nil
+	}
+	s.consistencyDomainsFn = func() ([]string, bool, error) {
+		return []string{"102", "900"}, true, nil
+	}
+	s.jailConfigExistsFn = func(uint) (bool, error) { return true, nil }
+
+	var undefined, destroyed []string
+	s.undefineDomainFn = func(name string) error {
+		undefined = append(undefined, name)
+		return nil
+	}
+	s.SetStaleRestoreDatasetCleaner(func(_ context.Context, name string) error {
+		destroyed = append(destroyed, name)
+		return nil
+	})
+
+	results, err := s.ApplyConsistencyFixes(context.Background(), []string{
+		"orphaned_vnc_port:102",
+		"unmanaged_domain:900",
+		"stale_restore_dataset:tank/a.restoring",
+		"unmanaged_domain:901",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("expected four results, got %#v", results)
+	}
+	for _, result := range results[:3] {
+		if !result.Applied {
+			t.Fatalf("expected fix to apply, got %#v", result)
+		}
+	}
+	if results[3].Applied || results[3].Error != "consistency_issue_not_found" {
+		t.Fatalf("expected unknown issue to be rejected, got %#v", results[3])
+	}
+
+	if len(undefined) != 1 || undefined[0] != "900" {
+		t.Fatalf("unexpected undefined domains: %v", undefined)
+	}
+	if len(destroyed) != 1 || destroyed[0] != "tank/a.restoring" {
+		t.Fatalf("unexpected destroyed datasets: %v", destroyed)
+	}
+
+	var vm vmModels.VM
+	if err := dbConn.Where("rid = ?", 102).First(&vm).Error; err != nil {
+		t.Fatalf("failed to reload vm: %v", err)
+	}
+	if vm.VNCPort != 0 {
+		t.Fatalf("expected orphaned vnc port to be cleared, got %d", vm.VNCPort)
+	}
+	if report := s.LastConsistencyReport(); report == nil {
+		t.Fatal("expected a report to be recorded")
+	}
+}
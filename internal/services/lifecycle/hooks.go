@@ -0,0 +1,270 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package lifecycle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	infoModels "github.com/alchemillahq/sylve/internal/db/models/info"
+	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
+	"github.com/alchemillahq/sylve/internal/hookscript"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	guestHookDefaultTimeout = 30 * time.Second
+	guestHookMaxTimeout     = 10 * time.Minute
+	guestHookOutputLimit    = 64 << 10
+)
+
+var guestHookPhases = []string{
+	taskModels.GuestHookPhasePreStart,
+	taskModels.GuestHookPhasePostStart,
+	taskModels.GuestHookPhasePreStop,
+	taskModels.GuestHookPhasePostStop,
+}
+
+// guestHookRunCommand is swapped out in tests.
+var guestHookRunCommand = hookscript.Run
+
+type GuestHookRequest struct {
+	GuestType      string `json:"guestType" binding:"required"`
+	GuestID        uint   `json:"guestId" binding:"required"`
+	Phase          string `json:"phase" binding:"required"`
+	Script         string `json:"script" binding:"required"`
+	TimeoutSeconds int    `json:"timeoutSeconds"`
+	Enabled        *bool  `json:"enabled"`
+}
+
+func (s *Service) ListGuestHooks(guestType string, guestID uint) ([]taskModels.GuestHook, error) {
+	query := s.DB.Order("guest_type ASC, guest_id ASC, phase ASC")
+	if guestType = normalizeGuestType(guestType); guestType != "" {
+		query = query.Where("guest_type = ?", guestType)
+	}
+	if guestID != 0 {
+		query = query.Where("guest_id = ?", guestID)
+	}
+
+	var hooks []taskModels.GuestHook
+	if err := query.Find(&hooks).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_list_guest_hooks: %w", err)
+	}
+	return hooks, nil
+}
+
+// SaveGuestHook creates or replaces the hook for a guest and phase.
+func (s *Service) SaveGuestHook(req GuestHookRequest) (taskModels.GuestHook, error) {
+	hook := taskModels.GuestHook{
+		GuestType: normalizeGuestType(req.GuestType),
+		GuestID:   req.GuestID,
+		Phase:     strings.TrimSpace(strings.ToLower(req.Phase)),
+		Enabled:   req.Enabled == nil || *req.Enabled,
+	}
+
+	if hook.GuestType != taskModels.GuestTypeVM && hook.GuestType != taskModels.GuestTypeJail {
+		return hook, fmt.Errorf("%w: %s", ErrInvalidGuest, hook.GuestType)
+	}
+	if hook.GuestID == 0 {
+		return hook, fmt.Errorf("invalid_guest_id")
+	}
+	if !slices.Contains(guestHookPhases, hook.Phase) {
+		return hook, fmt.Errorf("invalid_hook_phase: %s", hook.Phase)
+	}
+
+	timeout := time.Duration(req.TimeoutSeconds) * time.Second
+	if req.TimeoutSeconds < 0 || timeout > guestHookMaxTimeout {
+		return hook, fmt.Errorf("invalid_hook_timeout")
+	}
+	hook.TimeoutSeconds = req.TimeoutSeconds
+
+	script, err := hookscript.Validate(req.Script)
+	if err != nil {
+		return hook, err
+	}
+	hook.Script = script
+
+	var existing taskModels.GuestHook
+	if err := s.DB.Where("guest_type = ? AND guest_id = ? AND phase = ?", hook.GuestType, hook.GuestID, hook.Phase).
+		Limit(1).Find(&existing).Error; err != nil {
+		return hook, fmt.Errorf("failed_to_get_guest_hook: %w", err)
+	}
+	hook.ID = existing.ID
+	hook.CreatedAt = existing.CreatedAt
+
+	if err := s.DB.Save(&hook).Error; err != nil {
+		return hook, fmt.Errorf("failed_to_save_guest_hook: %w", err)
+	}
+	return hook, nil
+}
+
+func (s *Service) DeleteGuestHook(id uint) error {
+	result := s.DB.Delete(&taskModels.GuestHook{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed_to_delete_guest_hook: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("guest_hook_not_found")
+	}
+	return nil
+}
+
+// guestHookPhasesForAction maps a lifecycle action to the hooks around it.
+// Reboots and restarts keep the guest running, so they run no hooks.
+func guestHookPhasesForAction(action string) (string, string) {
+	switch action {
+	case "start":
+		return taskModels.GuestHookPhasePreStart, taskModels.GuestHookPhasePostStart
+	case "stop", "shutdown":
+		return taskModels.GuestHookPhasePreStop, taskModels.GuestHookPhasePostStop
+	}
+	return "", ""
+}
+
+// runGuestActionWithHooks wraps a start or stop in its hooks. A failing
+// pre-hook aborts the action; a failing post-hook is only reported, since the
+// guest has already changed state by then.
+func (s *Service) runGuestActionWithHooks(ctx context.Context, task taskModels.GuestLifecycleTask, action func() error) error {
+	pre, post := guestHookPhasesForAction(task.Action)
+	if pre == "" {
+		return action()
+	}
+
+	if err := s.runGuestHook(ctx, task, pre); err != nil {
+		return err
+	}
+	if err := action(); err != nil {
+		return err
+	}
+	if err := s.runGuestHook(ctx, task, post); err != nil {
+		logger.L.Warn().Err(err).
+			Str("guest_type", task.GuestType).
+			Uint("guest_id", task.GuestID).
+			Msg("guest_post_hook_failed")
+	}
+	return nil
+}
+
+func (s *Service) runGuestHook(ctx context.Context, task taskModels.GuestLifecycleTask, phase string) error {
+	var hook taskModels.GuestHook
+	err := s.DB.Where("guest_type = ? AND guest_id = ? AND phase = ? AND enabled = ?",
+		task.GuestType, task.GuestID, phase, true).First(&hook).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed_to_get_guest_hook: %w", err)
+	}
+
+	timeout := guestHookDefaultTimeout
+	if hook.TimeoutSeconds > 0 {
+		timeout = time.Duration(hook.TimeoutSeconds) * time.Second
+	}
+	hookCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	guestID := strconv.FormatUint(uint64(task.GuestID), 10)
+	env := []string{
+		"SYLVE_HOOK_PHASE=" + phase,
+		"SYLVE_GUEST_TYPE=" + task.GuestType,
+		"SYLVE_GUEST_ID=" + guestID,
+		"SYLVE_GUEST_ACTION=" + task.Action,
+		"SYLVE_TASK_ID=" + strconv.FormatUint(uint64(task.ID), 10),
+	}
+
+	started := time.Now()
+	if _, err := hookscript.Validate(hook.Script); err != nil {
+		s.recordGuestHookRun(hook, task, started, "", err)
+		return fmt.Errorf("guest_hook_failed: %s: %w", phase, err)
+	}
+
+	output, runErr := guestHookRunCommand(hookCtx, hook.Script, []string{phase, task.GuestType, guestID}, env)
+	if len(output) > guestHookOutputLimit {
+		output = output[len(output)-guestHookOutputLimit:]
+	}
+	if runErr != nil && errors.Is(hookCtx.Err(), context.DeadlineExceeded) {
+		runErr = fmt.Errorf("timed out after %s", timeout)
+	}
+
+	s.recordGuestHookRun(hook, task, started, output, runErr)
+
+	if runErr != nil {
+		return fmt.Errorf("guest_hook_failed: %s: %w", phase, runErr)
+	}
+	return nil
+}
+
+// recordGuestHookRun adds the run and its output to the activity feed.
+func (s *Service) recordGuestHookRun(
+	hook taskModels.GuestHook,
+	task taskModels.GuestLifecycleTask,
+	started time.Time,
+	output string,
+	runErr error,
+) {
+	if s.TelemetryDB == nil {
+		return
+	}
+
+	exitCode := 0
+	status := "success"
+	errMsg := ""
+	if runErr != nil {
+		status = "failed"
+		errMsg = runErr.Error()
+		exitCode = -1
+		var exitErr *exec.ExitError
+		if errors.As(runErr, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		}
+	}
+
+	action, err := json.Marshal(map[string]any{
+		"method": "HOOK",
+		"path":   fmt.Sprintf("/%s/%d/hooks/%s", task.GuestType, task.GuestID, hook.Phase),
+		"body": map[string]any{
+			"script": hook.Script,
+			"action": task.Action,
+			"taskId": task.ID,
+		},
+		"response": map[string]any{
+			"exitCode": exitCode,
+			"output":   output,
+		},
+	})
+	if err != nil {
+		return
+	}
+
+	hostname, _ := utils.GetSystemHostname()
+	record := infoModels.AuditRecord{
+		User:     "system",
+		AuthType: "hook",
+		Node:     hostname,
+		Started:  started,
+		Ended:    time.Now(),
+		Duration: time.Since(started),
+		Action:   string(action),
+		Status:   status,
+		Error:    errMsg,
+		Version:  2,
+	}
+	if err := s.TelemetryDB.Create(&record).Error; err != nil {
+		logger.L.Warn().Err(err).Msg("guest_hook_audit_record_failed")
+	}
+}
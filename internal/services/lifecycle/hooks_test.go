@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+
+	infoModels "github.com/alchemillahq/sylve/internal/db/models/info"
+	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
+	"github.com/alchemillahq/sylve/internal/hookscript"
+	"github.com/alchemillahq/sylve/internal/testutil"
+)
+
+type fakeHookFileInfo struct {
+	fs.FileInfo
+	mode fs.FileMode
+	uid  uint32
+}
+
+func (f fakeHookFileInfo) Mode() fs.FileMode { return f.mode }
+func (f fakeHookFileInfo) Sys() any          { return &syscall.Stat_t{Uid: f.uid} }
+
+func stubGuestHookScripts(t *testing.T, modes map[string]fs.FileMode) *[]string {
+	t.Helper()
+
+	var calls []string
+	prevStat, prevRun := hookscript.Stat, guestHookRunCommand
+	hookscript.Stat = func(name string) (os.FileInfo, error) {
+		if mode, ok := modes[name]; ok {
+			return fakeHookFileInfo{mode: mode}, nil
+		}
+		return nil, fs.ErrNotExist
+	}
+	guestHookRunCommand = func(_ context.Context, script string, args []string, env []string) (string, error) {
+		calls = append(calls, script+" "+strings.Join(args, " "))
+		if strings.Contains(script, "fail") {
+			return "pf: rule load failed\n", fmt.Errorf("exit status 1")
+		}
+		return "ok\n", nil
+	}
+	t.Cleanup(func() { hookscript.Stat, guestHookRunCommand = prevStat, prevRun })
+	return &calls
+}
+
+func TestSaveGuestHookValidatesScript(t *testing.T) {
+	s, _ := newLifecycleTestService(t)
+	stubGuestHookScripts(t, map[string]fs.FileMode{
+		"/usr/local/etc/sylve/hooks/pf.sh":    0o755,
+		"/usr/local/etc/sylve/hooks/plain.sh": 0o644,
+		"/tmp/open.sh":                        0o777,
+		"/usr/local/etc/sylve/hooks/group.sh": 0o775,
+	})
+
+	cases := map[string]GuestHookRequest{
+		"invalid_hook_script: path must be absolute": {GuestType: "vm", GuestID: 1, Phase: "pre-start", Script: "pf.sh"},
+		"hook_script_not_found":                      {GuestType: "vm", GuestID: 1, Phase: "pre-start", Script: "/missing.sh"},
+		"invalid_hook_script: not executable":        {GuestType: "vm", GuestID: 1, Phase: "pre-start", Script: "/usr/local/etc/sylve/hooks/plain.sh"},
+		"invalid_hook_script: world writable":        {GuestType: "vm", GuestID: 1, Phase: "pre-start", Script: "/tmp/open.sh"},
+		"invalid_hook_script: group writable":        {GuestType: "vm", GuestID: 1, Phase: "pre-start", Script: "/usr/local/etc/sylve/hooks/group.sh"},
+		"invalid_hook_phase":                         {GuestType: "vm", GuestID: 1, Phase: "pre-reboot", Script: "/usr/local/etc/sylve/hooks/pf.sh"},
+		"invalid_hook_timeout":                       {GuestType: "vm", GuestID: 1, Phase: "pre-start", Script: "/usr/local/etc/sylve/hooks/pf.sh", TimeoutSeconds: 3600},
+		"invalid_guest_type":                         {GuestType: "vm-template", GuestID: 1, Phase: "pre-start", Script: "/usr/local/etc/sylve/hooks/pf.sh"},
+	}
+	for want, req := range cases {
+		if _, err := s.SaveGuestHook(req); err == nil || !strings.HasPrefix(err.Error(), want) {
+			t.Fatalf("SaveGuestHook(%+v) = %v, want %s", req, err, want)
+		}
+	}
+
+	prevStat := hookscript.Stat
+	hookscript.Stat = func(name string) (os.FileInfo, error) {
+		return fakeHookFileInfo{mode: 0o755, uid: 1001}, nil
+	}
+	if _, err := s.SaveGuestHook(GuestHookRequest{GuestType: "vm", GuestID: 1, Phase: "pre-start", Script: "/home/user/pf.sh"}); err == nil ||
+		err.Error() != "invalid_hook_script: not owned by root" {
+		t.Fatalf("expected a script owned by another user to be rejected, got %v", err)
+	}
+	hookscript.Stat = prevStat
+
+	first, err := s.SaveGuestHook(GuestHookRequest{GuestType: "VM", GuestID: 1, Phase: "pre-start", Script: "/usr/local/etc/sylve/hooks/pf.sh"})
+	if err != nil {
+		t.Fatalf("SaveGuestHook: %v", err)
+	}
+	disabled := false
+	second, err := s.SaveGuestHook(GuestHookRequest{GuestType: "vm", GuestID: 1, Phase: "pre-start", Script: "/usr/local/etc/sylve/hooks/pf.sh", Enabled: &disabled})
+	if err != nil {
+		t.Fatalf("SaveGuestHook replace: %v", err)
+	}
+	if second.ID != first.ID || second.Enabled {
+		t.Fatalf("replace = %+v, want same ID %d and disabled", second, first.ID)
+	}
+}
+
+func TestExecuteTaskRunsHooksAroundStart(t *testing.T) {
+	s, dbConn := newLifecycleTestService(t)
+	telemetry := testutil.NewSQLiteTestDB(t, &infoModels.AuditRecord{})
+	s.TelemetryDB = telemetry
+	calls := stubGuestHookScripts(t, map[string]fs.FileMode{
+		"/hooks/pre.sh":  0o755,
+		"/hooks/post.sh": 0o755,
+	})
+
+	for _, phase := range []string{"pre-start", "post-start"} {
+		script := "/hooks/pre.sh"
+		if phase == "post-start" {
+			script = "/hooks/post.sh"
+		}
+		if _, err := s.SaveGuestHook(GuestHookRequest{GuestType: "jail", GuestID: 7, Phase: phase, Script: script}); err != nil {
+			t.Fatalf("SaveGuestHook %s: %v", phase, err)
+		}
+	}
+
+	var order []string
+	s.jailActionFn = func(_ int, action string) error {
+		order = append(order, "action "+action)
+		return nil
+	}
+
+	task, _, err := s.createTask(context.Background(), taskModels.GuestTypeJail, 7, "start", taskModels.LifecycleTaskSourceUser, "tester", "", false)
+	if err != nil {
+		t.Fatalf("createTask: %v", err)
+	}
+	if err := s.ExecuteTask(context.Background(), task.ID); err != nil {
+		t.Fatalf("ExecuteTask: %v", err)
+	}
+
+	want := []string{"/hooks/pre.sh pre-start jail 7", "/hooks/post.sh post-start jail 7"}
+	if len(*calls) != 2 || (*calls)[0] != want[0] || (*calls)[1] != want[1] || len(order) != 1 {
+		t.Fatalf("hook calls = %v, actions = %v", *calls, order)
+	}
+
+	var records []infoModels.AuditRecord
+	if err := telemetry.Order("id ASC").Find(&records).Error; err != nil {
+		t.Fatalf("list audit records: %v", err)
+	}
+	if len(records) != 2 || records[0].Status != "success" || !strings.Contains(records[0].Action, `"output":"ok\n"`) {
+		t.Fatalf("audit records = %+v", records)
+	}
+
+	var stored taskModels.GuestLifecycleTask
+	if err := dbConn.First(&stored, task.ID).Error; err != nil || stored.Status != taskModels.LifecycleTaskStatusSuccess {
+		t.Fatalf("task = %+v, %v", stored, err)
+	}
+}
+
+func TestFailingPreStopHookAbortsStop(t *testing.T) {
+	s, _ := newLifecycleTestService(t)
+	stubGuestHookScripts(t, map[string]fs.FileMode{"/hooks/fail.sh": 0o700})
+
+	if _, err := s.SaveGuestHook(GuestHookRequest{GuestType: "vm", GuestID: 3, Phase: "pre-stop", Script: "/hooks/fail.sh", TimeoutSeconds: 5}); err != nil {
+		t.Fatalf("SaveGuestHook: %v", err)
+	}
+
+	stopped := false
+	s.vmActionFn = func(_ uint, _ string) error {
+		stopped = true
+		return nil
+	}
+
+	task := taskModels.GuestLifecycleTask{ID: 1, GuestType: taskModels.GuestTypeVM, GuestID: 3, Action: "stop"}
+	err := s.executeGuestAction(context.Background(), task)
+	if err == nil || !strings.HasPrefix(err.Error(), "guest_hook_failed: pre-stop") {
+		t.Fatalf("executeGuestAction = %v", err)
+	}
+	if stopped {
+		t.Fatal("stop ran despite failing pre-stop hook")
+	}
+
+	// Reboots keep the guest up and run no hooks.
+	task.Action = "reboot"
+	if err := s.executeGuestAction(context.Background(), task); err != nil || !stopped {
+		t.Fatalf("reboot = %v, action ran = %v", err, stopped)
+	}
+}
+
+func TestGuestHookRevalidatesScriptBeforeRunning(t *testing.T) {
+	s, _ := newLifecycleTestService(t)
+	modes := map[string]fs.FileMode{"/hooks/pre.sh": 0o755}
+	calls := stubGuestHookScripts(t, modes)
+
+	if _, err := s.SaveGuestHook(GuestHookRequest{GuestType: "vm", GuestID: 4, Phase: "pre-start", Script: "/hooks/pre.sh"}); err != nil {
+		t.Fatalf("SaveGuestHook: %v", err)
+	}
+
+	modes["/hooks/pre.sh"] = 0o775
+	started := false
+	s.vmActionFn = func(_ uint, _ string) error {
+		started = true
+		return nil
+	}
+
+	task := taskModels.GuestLifecycleTask{ID: 1, GuestType: taskModels.GuestTypeVM, GuestID: 4, Action: "start"}
+	err := s.executeGuestAction(context.Background(), task)
+	if err == nil || !strings.Contains(err.Error(), "group writable") {
+		t.Fatalf("executeGuestAction = %v", err)
+	}
+	if started || len(*calls) != 0 {
+		t.Fatalf("a script changed after saving must not run: started=%v calls=%v", started, *calls)
+	}
+}
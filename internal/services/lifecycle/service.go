@@ -21,10 +21,13 @@ import (
 	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
 	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
 	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
 	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/internal/services/jail"
 	"github.com/alchemillahq/sylve/internal/services/libvirt"
+	"github.com/alchemillahq/sylve/internal/webhooks"
 	"gorm.io/gorm"
 )
 
@@ -64,6 +67,8 @@ type guestLifecycleExecPayload struct {
 
 type MigrationExecutor func(ctx context.Context, taskID uint) error
 
+type VMCloneExecutor func(ctx context.Context, taskID uint, rid uint, req clusterServiceInterfaces.CloneGuestRequest) error
+
 type Service struct {
 	DB          *gorm.DB
 	TelemetryDB *gorm.DB
@@ -77,25 +82,51 @@ type Service struct {
 	jailActionFn func(ctid int, action string) error
 	jailActiveFn func(ctid uint) (bool, error)
 
+	vmForceStopFn   func(rid uint) error
+	jailForceStopFn func(ctid uint) error
+
 	jailTemplateConvertFn func(ctx context.Context, ctid uint, req jail.ConvertToTemplateRequest) error
 	jailTemplateCreateFn  func(ctx context.Context, templateID uint, req jail.CreateFromTemplateRequest) error
 
 	vmTemplateConvertFn func(ctx context.Context, rid uint, req libvirtServiceInterfaces.ConvertToTemplateRequest) error
 	vmTemplateCreateFn  func(ctx context.Context, templateID uint, req libvirtServiceInterfaces.CreateFromTemplateRequest) error
 
+	vmCreateFn   func(ctx context.Context, req libvirtServiceInterfaces.CreateVMRequest) error
+	jailCreateFn func(ctx context.Context, req jailServiceInterfaces.CreateJailRequest) error
+
 	migrateFn MigrationExecutor
+	vmCloneFn VMCloneExecutor
+
+	heavyOps      *heavyOpLimiter
+	enqueueExecFn func(ctx context.Context, taskID uint) error
+
+	applicationMu       sync.Mutex
+	runningApplications map[uint]struct{}
+
+	consistencyMu           sync.Mutex
+	lastConsistency         *ConsistencyReport
+	consistencyDatasetsFn   func(ctx context.Context) (map[string]struct{}, error)
+	consistencyDomainsFn    func() ([]string, bool, error)
+	jailConfigExistsFn      func(ctid uint) (bool, error)
+	undefineDomainFn        func(name string) error
+	destroyRestoreDatasetFn func(ctx context.Context, name string) error
 }
 
 func (s *Service) SetMigrationExecutor(fn MigrationExecutor) {
 	s.migrateFn = fn
 }
 
+func (s *Service) SetVMCloneExecutor(fn VMCloneExecutor) {
+	s.vmCloneFn = fn
+}
+
 func NewService(dbConn *gorm.DB, telemetryDB *gorm.DB, libvirtService *libvirt.Service, jailService *jail.Service) *Service {
 	s := &Service{
 		DB:          dbConn,
 		TelemetryDB: telemetryDB,
 		Libvirt:     libvirtService,
 		Jail:        jailService,
+		heavyOps:    newHeavyOpLimiter(heavyOpsPerPool),
 	}
 
 	if libvirtService != nil {
@@ -104,16 +135,41 @@ func NewService(dbConn *gorm.DB, telemetryDB *gorm.DB, libvirtService *libvirt.S
 			state, err := libvirtService.GetDomainState(int(rid))
 			return int(state), err
 		}
+		s.vmForceStopFn = libvirtService.ForceStopVM
 		s.vmTemplateConvertFn = libvirtService.ConvertVMToTemplate
 		s.vmTemplateCreateFn = libvirtService.CreateVMsFromTemplate
+		s.vmCreateFn = func(ctx context.Context, req libvirtServiceInterfaces.CreateVMRequest) error {
+			return libvirtService.CreateVM(req, ctx)
+		}
+		s.consistencyDomainsFn = func() ([]string, bool, error) {
+			if !libvirtService.IsVirtualizationEnabled() {
+				return nil, false, nil
+			}
+			states, err := libvirtService.GetDomainStates()
+			if err != nil {
+				return nil, false, err
+			}
+			names := make([]string, 0, len(states))
+			for _, state := range states {
+				names = append(names, state.Domain)
+			}
+			return names, true, nil
+		}
+		s.undefineDomainFn = libvirtService.UndefineUnmanagedDomain
 	}
 
 	if jailService != nil {
 		s.jailActionFn = jailService.JailAction
 		s.jailActiveFn = jailService.IsJailActive
+		s.jailForceStopFn = jailService.ForceStopJail
 		s.jailTemplateConvertFn = jailService.ConvertJailToTemplate
 		s.jailTemplateCreateFn = jailService.CreateJailsFromTemplate
+		s.jailCreateFn = jailService.CreateJail
+		s.consistencyDatasetsFn = func(ctx context.Context) (map[string]struct{}, error) {
+			return listGZFSDatasetNames(ctx, jailService.GZFS)
+		}
 	}
+	s.jailConfigExistsFn = jailConfigExists
 
 	return s
 }
@@ -138,14 +194,14 @@ func validateAction(guestType, action string) error {
 	switch guestType {
 	case taskModels.GuestTypeVM:
 		switch action {
-		case "start", "stop", "shutdown", "reboot", "migrate":
+		case "start", "stop", "shutdown", "reboot", "migrate", "clone", "create":
 			return nil
 		default:
 			return fmt.Errorf("%w: %s", ErrInvalidAction, action)
 		}
 	case taskModels.GuestTypeJail:
 		switch action {
-		case "start", "stop", "restart", "migrate":
+		case "start", "stop", "restart", "migrate", "create":
 			return nil
 		default:
 			return fmt.Errorf("%w: %s", ErrInvalidAction, action)
@@ -184,6 +240,13 @@ func (s *Service) RegisterJobs() {
 		return nil
 	})
 
+	db.QueueRegisterJSON[guestApplicationPayload](guestApplicationQueueName, func(ctx context.Context, payload guestApplicationPayload) error {
+		if err := s.runApplicationAction(ctx, payload); err != nil {
+			logger.L.Warn().Err(err).Uint("application_id", payload.ApplicationID).Str("action", payload.Action).Msg("application_action_failed")
+		}
+		return nil
+	})
+
 	db.QueueRegisterNoPayload(guestAutostartQueueName, func(ctx context.Context) error {
 		if err := s.runStartupAutostart(ctx); err != nil {
 			logger.L.Warn().Err(err).Msg("guest_autostart_sequence_failed")
@@ -214,7 +277,7 @@ func (s *Service) RecoverInterruptedTasks(ctx context.Context) error {
 	if result.RowsAffected > 0 {
 		logger.L.Warn().Int64("count", result.RowsAffected).Msg("recovered_interrupted_lifecycle_tasks")
 	}
-	return nil
+	return s.requeueWaitingTasks(ctx)
 }
 
 func (s *Service) RequestAction(
@@ -344,6 +407,18 @@ func (s *Service) ExecuteTask(ctx context.Context, taskID uint) error {
 		return nil
 	}
 
+	if isHeavyAction(task.GuestType, task.Action) {
+		admitted, err := s.admitHeavyTask(ctx, task)
+		if err != nil {
+			s.failTask(task.ID, err)
+			return err
+		}
+		if !admitted {
+			return nil
+		}
+		defer s.heavyOps.release(heavyOpTaskKey(task.ID))
+	}
+
 	now := time.Now().UTC()
 	claimed, err := s.claimTaskForExecution(ctx, task.ID, now)
 	if err != nil {
@@ -421,9 +496,35 @@ func (s *Service) ExecuteTask(ctx context.Context, taskID uint) error {
 		})
 	}
 
+	if runErr == nil {
+		publishGuestLifecycleWebhook(task)
+	}
+
 	return runErr
 }
 
+// publishGuestLifecycleWebhook reports guests that came up or went down.
+// Reboots and restarts leave the guest running, so they are not reported.
+func publishGuestLifecycleWebhook(task taskModels.GuestLifecycleTask) {
+	var eventType string
+	switch task.Action {
+	case "start":
+		eventType = webhooks.EventGuestStarted
+	case "stop", "shutdown":
+		eventType = webhooks.EventGuestStopped
+	default:
+		return
+	}
+
+	webhooks.Publish(eventType, map[string]any{
+		"taskId":    task.ID,
+		"guestType": task.GuestType,
+		"guestId":   task.GuestID,
+		"action":    task.Action,
+		"source":    task.Source,
+	})
+}
+
 func (s *Service) claimTaskForExecution(ctx context.Context, taskID uint, startedAt time.Time) (bool, error) {
 	result := s.DB.WithContext(ctx).Model(&taskModels.GuestLifecycleTask{}).
 		Where("id = ? AND status IN ?", taskID, []string{
@@ -450,6 +551,28 @@ func (s *Service) executeGuestAction(ctx context.Context, task taskModels.GuestL
 			return s.migrateFn(ctx, task.ID)
 		}
 
+		if task.Action == "create" {
+			if s.vmCreateFn == nil {
+				return fmt.Errorf("vm_create_function_not_configured")
+			}
+			req := libvirtServiceInterfaces.CreateVMRequest{}
+			if err := json.Unmarshal([]byte(task.Payload), &req); err != nil {
+				return fmt.Errorf("invalid_vm_create_payload: %w", err)
+			}
+			return s.vmCreateFn(ctx, req)
+		}
+
+		if task.Action == "clone" {
+			if s.vmCloneFn == nil {
+				return fmt.Errorf("vm_clone_executor_not_configured")
+			}
+			req := clusterServiceInterfaces.CloneGuestRequest{}
+			if err := json.Unmarshal([]byte(task.Payload), &req); err != nil {
+				return fmt.Errorf("invalid_vm_clone_payload: %w", err)
+			}
+			return s.vmCloneFn(ctx, task.ID, task.GuestID, req)
+		}
+
 		if s.vmActionFn == nil {
 			return fmt.Errorf("vm_action_function_not_configured")
 		}
@@ -463,7 +586,9 @@ func (s *Service) executeGuestAction(ctx context.Context, task taskModels.GuestL
 			}
 		}
 
-		return s.vmActionFn(task.GuestID, task.Action)
+		return s.runGuestActionWithHooks(ctx, task, func() error {
+			return s.vmActionFn(task.GuestID, task.Action)
+		})
 
 	case taskModels.GuestTypeJail:
 		if task.Action == "migrate" {
@@ -473,6 +598,17 @@ func (s *Service) executeGuestAction(ctx context.Context, task taskModels.GuestL
 			return s.migrateFn(ctx, task.ID)
 		}
 
+		if task.Action == "create" {
+			if s.jailCreateFn == nil {
+				return fmt.Errorf("jail_create_function_not_configured")
+			}
+			req := jailServiceInterfaces.CreateJailRequest{}
+			if err := json.Unmarshal([]byte(task.Payload), &req); err != nil {
+				return fmt.Errorf("invalid_jail_create_payload: %w", err)
+			}
+			return s.jailCreateFn(ctx, req)
+		}
+
 		if s.jailActionFn == nil {
 			return fmt.Errorf("jail_action_function_not_configured")
 		}
@@ -486,7 +622,9 @@ func (s *Service) executeGuestAction(ctx context.Context, task taskModels.GuestL
 			}
 		}
 
-		return s.jailActionFn(int(task.GuestID), task.Action)
+		return s.runGuestActionWithHooks(ctx, task, func() error {
+			return s.jailActionFn(int(task.GuestID), task.Action)
+		})
 
 	case taskModels.GuestTypeJailTemplate:
 		switch task.Action {
@@ -569,6 +707,7 @@ func (s *Service) GetActiveTaskForGuest(guestType string, guestID uint) (*taskMo
 		return nil, nil
 	}
 
+	task.QueuePosition = s.heavyOps.position(heavyOpTaskKey(task.ID))
 	return &task, nil
 }
 
@@ -595,7 +734,7 @@ func (s *Service) ListActiveTasks(guestType string, guestID uint) ([]taskModels.
 		return nil, err
 	}
 
-	return tasks, nil
+	return s.withQueuePositions(tasks), nil
 }
 
 func (s *Service) ListRecentTasks(guestType string, guestID uint, limit int) ([]taskModels.GuestLifecycleTask, error) {
@@ -621,7 +760,7 @@ func (s *Service) ListRecentTasks(guestType string, guestID uint, limit int) ([]
 		return nil, err
 	}
 
-	return tasks, nil
+	return s.withQueuePositions(tasks), nil
 }
 
 func (s *Service) GetTask(taskID uint) (*taskModels.GuestLifecycleTask, error) {
@@ -637,6 +776,7 @@ func (s *Service) GetTask(taskID uint) (*taskModels.GuestLifecycleTask, error) {
 		return nil, err
 	}
 
+	task.QueuePosition = s.heavyOps.position(heavyOpTaskKey(task.ID))
 	return &task, nil
 }
 
@@ -46,6 +46,9 @@ func newLifecycleTestService(t *testing.T) (*Service, *gorm.DB) {
 	dbConn := testutil.NewSQLiteTestDB(
 		t,
 		&taskModels.GuestLifecycleTask{},
+		&taskModels.GuestHook{},
+		&taskModels.Application{},
+		&taskModels.ApplicationMember{},
 		&vmModels.VM{},
 		&jailModels.Jail{},
 	)
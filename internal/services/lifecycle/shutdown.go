@@ -0,0 +1,236 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	"github.com/alchemillahq/sylve/internal/logger"
+)
+
+const (
+	// Mirrors the libvirt service, which treats a non-positive wait as 30s.
+	defaultVMShutdownWaitTime = 30 * time.Second
+	// A VM shutdown may wait once for the guest agent and once for ACPI
+	// before libvirt destroys it, so the guest budget covers both.
+	shutdownGuestGrace  = 15 * time.Second
+	jailShutdownTimeout = 60 * time.Second
+)
+
+type shutdownGuest struct {
+	guestType  string
+	guestID    uint
+	startOrder int
+	timeout    time.Duration
+}
+
+// ShutdownGuests stops every running guest in reverse boot order: VMs before
+// jails, highest start order first. Guests sharing a start order stop in
+// parallel. Anything still running after its timeout is force stopped in a
+// final pass. Guests keep their intentionally-stopped flag so replication and
+// autostart treat them as running on the next boot.
+func (s *Service) ShutdownGuests(ctx context.Context) {
+	guests, err := s.runningShutdownGuests()
+	if err != nil {
+		logger.L.Warn().Err(err).Msg("guest_shutdown_list_failed")
+		return
+	}
+	if len(guests) == 0 {
+		return
+	}
+
+	logger.L.Info().Int("guests", len(guests)).Msg("guest_shutdown_started")
+
+	for _, group := range groupShutdownGuests(guests) {
+		if ctx.Err() != nil {
+			break
+		}
+
+		var wg sync.WaitGroup
+		for _, guest := range group {
+			wg.Add(1)
+			go func(guest shutdownGuest) {
+				defer wg.Done()
+				s.shutdownGuest(ctx, guest)
+			}(guest)
+		}
+		wg.Wait()
+	}
+
+	for _, guest := range guests {
+		if !s.shutdownGuestRunning(guest) {
+			continue
+		}
+
+		logger.L.Warn().Str("guest_type", guest.guestType).Uint("guest_id", guest.guestID).Msg("guest_shutdown_force_stopping")
+		if err := s.forceStopShutdownGuest(guest); err != nil {
+			logger.L.Error().Err(err).Str("guest_type", guest.guestType).Uint("guest_id", guest.guestID).Msg("guest_shutdown_force_stop_failed")
+		}
+	}
+
+	for _, guest := range guests {
+		s.restoreShutdownGuestIntent(guest)
+	}
+
+	logger.L.Info().Msg("guest_shutdown_finished")
+}
+
+func (s *Service) runningShutdownGuests() ([]shutdownGuest, error) {
+	guests := []shutdownGuest{}
+
+	vms := []vmModels.VM{}
+	if err := s.DB.
+		Model(&vmModels.VM{}).
+		Select("rid", "start_order", "shutdown_wait_time").
+		Order("start_order DESC").
+		Order("rid DESC").
+		Find(&vms).Error; err != nil {
+		return nil, err
+	}
+	for _, vm := range vms {
+		wait := time.Duration(vm.ShutdownWaitTime) * time.Second
+		if wait <= 0 {
+			wait = defaultVMShutdownWaitTime
+		}
+		guest := shutdownGuest{
+			guestType:  taskModels.GuestTypeVM,
+			guestID:    vm.RID,
+			startOrder: vm.StartOrder,
+			timeout:    2*wait + shutdownGuestGrace,
+		}
+		if s.shutdownGuestRunning(guest) {
+			guests = append(guests, guest)
+		}
+	}
+
+	jails := []jailModels.Jail{}
+	if err := s.DB.
+		Model(&jailModels.Jail{}).
+		Select("ct_id", "start_order").
+		Order("start_order DESC").
+		Order("ct_id DESC").
+		Find(&jails).Error; err != nil {
+		return nil, err
+	}
+	for _, jl := range jails {
+		guest := shutdownGuest{
+			guestType:  taskModels.GuestTypeJail,
+			guestID:    jl.CTID,
+			startOrder: jl.StartOrder,
+			timeout:    jailShutdownTimeout,
+		}
+		if s.shutdownGuestRunning(guest) {
+			guests = append(guests, guest)
+		}
+	}
+
+	return guests, nil
+}
+
+// groupShutdownGuests splits the ordered guests into consecutive runs of the
+// same type and start order.
+func groupShutdownGuests(guests []shutdownGuest) [][]shutdownGuest {
+	groups := [][]shutdownGuest{}
+	for i, guest := range guests {
+		if i > 0 {
+			prev := guests[i-1]
+			if prev.guestType == guest.guestType && prev.startOrder == guest.startOrder {
+				groups[len(groups)-1] = append(groups[len(groups)-1], guest)
+				continue
+			}
+		}
+		groups = append(groups, []shutdownGuest{guest})
+	}
+	return groups
+}
+
+func (s *Service) shutdownGuest(ctx context.Context, guest shutdownGuest) {
+	action := "stop"
+	if guest.guestType == taskModels.GuestTypeVM {
+		action = "shutdown"
+	}
+
+	task, _, err := s.createTask(ctx, guest.guestType, guest.guestID, action, taskModels.LifecycleTaskSourceHostShutdown, "host_shutdown", "", false)
+	if err != nil {
+		// A guest with an action in flight is left to the forced pass.
+		if !errors.Is(err, ErrTaskInProgress) && !errors.Is(err, ErrMigrationActive) {
+			logger.L.Warn().Err(err).Str("guest_type", guest.guestType).Uint("guest_id", guest.guestID).Msg("guest_shutdown_task_create_failed")
+		}
+		return
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.ExecuteTask(ctx, task.ID)
+	}()
+
+	timer := time.NewTimer(guest.timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			logger.L.Warn().Err(err).Uint("task_id", task.ID).Msg("guest_shutdown_task_failed")
+		}
+	case <-timer.C:
+		logger.L.Warn().Str("guest_type", guest.guestType).Uint("guest_id", guest.guestID).Dur("timeout", guest.timeout).Msg("guest_shutdown_timed_out")
+	case <-ctx.Done():
+	}
+}
+
+func (s *Service) shutdownGuestRunning(guest shutdownGuest) bool {
+	switch guest.guestType {
+	case taskModels.GuestTypeVM:
+		if s.vmStateFn == nil {
+			return false
+		}
+		state, err := s.vmStateFn(guest.guestID)
+		return err == nil && state == 1
+	case taskModels.GuestTypeJail:
+		if s.jailActiveFn == nil {
+			return false
+		}
+		active, err := s.jailActiveFn(guest.guestID)
+		return err == nil && active
+	}
+	return false
+}
+
+func (s *Service) forceStopShutdownGuest(guest shutdownGuest) error {
+	switch guest.guestType {
+	case taskModels.GuestTypeVM:
+		if s.vmForceStopFn != nil {
+			return s.vmForceStopFn(guest.guestID)
+		}
+	case taskModels.GuestTypeJail:
+		if s.jailForceStopFn != nil {
+			return s.jailForceStopFn(guest.guestID)
+		}
+	}
+	return nil
+}
+
+func (s *Service) restoreShutdownGuestIntent(guest shutdownGuest) {
+	var err error
+	switch guest.guestType {
+	case taskModels.GuestTypeVM:
+		err = s.DB.Model(&vmModels.VM{}).Where("rid = ?", guest.guestID).Update("intentionally_stopped", false).Error
+	case taskModels.GuestTypeJail:
+		err = s.DB.Model(&jailModels.Jail{}).Where("ct_id = ?", guest.guestID).Update("intentionally_stopped", false).Error
+	}
+	if err != nil {
+		logger.L.Warn().Err(err).Str("guest_type", guest.guestType).Uint("guest_id", guest.guestID).Msg("guest_shutdown_restore_intent_failed")
+	}
+}
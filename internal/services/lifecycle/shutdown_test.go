@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+)
+
+func TestShutdownGuestsReverseOrderAndForcedPass(t *testing.T) {
+	s, dbConn := newLifecycleTestService(t)
+
+	jails := []jailModels.Jail{
+		{CTID: 100, Name: "j1", Type: jailModels.JailTypeFreeBSD, StartOrder: 1},
+		{CTID: 200, Name: "j2", Type: jailModels.JailTypeFreeBSD, StartOrder: 2},
+	}
+	for _, j := range jails {
+		if err := dbConn.Create(&j).Error; err != nil {
+			t.Fatalf("failed to create jail: %v", err)
+		}
+	}
+
+	vms := []vmModels.VM{
+		{RID: 100, Name: "vm1", StartOrder: 1},
+		{RID: 200, Name: "vm2", StartOrder: 2},
+		{RID: 300, Name: "vm3", StartOrder: 3},
+		{RID: 400, Name: "vm4", StartOrder: 4},
+	}
+	for _, vm := range vms {
+		if err := dbConn.Create(&vm).Error; err != nil {
+			t.Fatalf("failed to create vm: %v", err)
+		}
+	}
+
+	var mu sync.Mutex
+	running := map[string]bool{"vm:100": true, "vm:200": true, "vm:300": true, "jail:100": true, "jail:200": true}
+	var order []string
+
+	s.vmStateFn = func(rid uint) (int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if running[fmt.Sprintf("vm:%d", rid)] {
+			return 1, nil
+		}
+		return 5, nil
+	}
+	s.jailActiveFn = func(ctid uint) (bool, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return running[fmt.Sprintf("jail:%d", ctid)], nil
+	}
+	s.vmActionFn = func(rid uint, action string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, fmt.Sprintf("vm:%d:%s", rid, action))
+		if rid == 200 {
+			return fmt.Errorf("guest_ignored_acpi")
+		}
+		running[fmt.Sprintf("vm:%d", rid)] = false
+		return nil
+	}
+	s.jailActionFn = func(ctid int, action string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, fmt.Sprintf("jail:%d:%s", ctid, action))
+		running[fmt.Sprintf("jail:%d", ctid)] = false
+		return nil
+	}
+	s.vmForceStopFn = func(rid uint) error {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, fmt.Sprintf("vm:%d:force", rid))
+		running[fmt.Sprintf("vm:%d", rid)] = false
+		return nil
+	}
+	s.jailForceStopFn = func(ctid uint) error {
+		t.Fatalf("unexpected forced jail stop for %d", ctid)
+		return nil
+	}
+
+	if err := dbConn.Model(&vmModels.VM{}).Where("rid = ?", 300).Update("intentionally_stopped", true).Error; err != nil {
+		t.Fatalf("failed to mark vm: %v", err)
+	}
+
+	s.ShutdownGuests(context.Background())
+
+	expected := []string{
+		"vm:300:shutdown",
+		"vm:200:shutdown",
+		"vm:100:shutdown",
+		"jail:200:stop",
+		"jail:100:stop",
+		"vm:200:force",
+	}
+	if !slices.Equal(order, expected) {
+		t.Fatalf("unexpected shutdown order: got %v want %v", order, expected)
+	}
+
+	var stopped int64
+	if err := dbConn.Model(&vmModels.VM{}).Where("intentionally_stopped = ?", true).Count(&stopped).Error; err != nil {
+		t.Fatalf("failed to count vms: %v", err)
+	}
+	if stopped != 0 {
+		t.Fatalf("expected shut down guests to keep their running intent, got %d stopped", stopped)
+	}
+
+	var taskCount int64
+	if err := dbConn.Model(&taskModels.GuestLifecycleTask{}).
+		Where("source = ?", taskModels.LifecycleTaskSourceHostShutdown).
+		Count(&taskCount).Error; err != nil {
+		t.Fatalf("failed to count shutdown tasks: %v", err)
+	}
+	if taskCount != 5 {
+		t.Fatalf("unexpected shutdown task count: got %d want 5", taskCount)
+	}
+}
+
+func TestGroupShutdownGuests(t *testing.T) {
+	groups := groupShutdownGuests([]shutdownGuest{
+		{guestType: taskModels.GuestTypeVM, guestID: 3, startOrder: 2},
+		{guestType: taskModels.GuestTypeVM, guestID: 2, startOrder: 2},
+		{guestType: taskModels.GuestTypeVM, guestID: 1, startOrder: 1},
+		{guestType: taskModels.GuestTypeJail, guestID: 1, startOrder: 1},
+	})
+	if len(groups) != 3 || len(groups[0]) != 2 || len(groups[1]) != 1 || len(groups[2]) != 1 {
+		t.Fatalf("unexpected groups: %+v", groups)
+	}
+}
@@ -0,0 +1,312 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package migration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	migrationIface "github.com/alchemillahq/sylve/internal/interfaces/services/migration"
+	"github.com/alchemillahq/sylve/internal/layout"
+	"github.com/alchemillahq/sylve/internal/logger"
+)
+
+const (
+	CompatibilityCategoryCPU         = "cpu"
+	CompatibilityCategoryFirmware    = "firmware"
+	CompatibilityCategoryTPM         = "tpm"
+	CompatibilityCategoryPassthrough = "passthrough"
+	CompatibilityCategoryNetwork     = "network"
+	CompatibilityCategoryStorage     = "storage"
+	CompatibilityCategoryMedia       = "media"
+	CompatibilityCategoryConsole     = "console"
+	CompatibilityCategoryTarget      = "target"
+)
+
+// CheckCompatibility measures a VM's requirements against the target node
+// without touching either side. Failover targets can be checked the same way
+// ahead of time, while the source is still reachable.
+func (s *Service) CheckCompatibility(ctx context.Context, req migrationIface.MigrateRequest) (*migrationIface.CompatibilityReport, error) {
+	if req.GuestType != taskModels.GuestTypeVM {
+		return nil, fmt.Errorf("compatibility_check_vm_only")
+	}
+
+	req.TargetNodeUUID = strings.TrimSpace(req.TargetNodeUUID)
+	var targetNode clusterModels.ClusterNode
+	if err := s.DB.Where("node_uuid = ?", req.TargetNodeUUID).First(&targetNode).Error; err != nil {
+		return nil, fmt.Errorf("target_node_not_found")
+	}
+
+	var vm vmModels.VM
+	if err := s.DB.
+		Preload("Storages").
+		Preload("Storages.Dataset").
+		Preload("Networks").
+		Where("rid = ?", req.GuestID).First(&vm).Error; err != nil {
+		return nil, fmt.Errorf("vm_not_found: %w", err)
+	}
+
+	checks := s.vmCompatibility(ctx, vm, targetNode)
+	return &migrationIface.CompatibilityReport{
+		GuestType:      req.GuestType,
+		GuestID:        req.GuestID,
+		TargetNodeUUID: targetNode.NodeUUID,
+		Compatible:     compatibilityAllowed(checks),
+		Checks:         checks,
+		CheckedAt:      time.Now().UTC(),
+	}, nil
+}
+
+func (s *Service) vmCompatibility(ctx context.Context, vm vmModels.VM, targetNode clusterModels.ClusterNode) []migrationIface.CompatibilityCheck {
+	probe, nameByUUID := s.buildVMTargetProbe(vm)
+	poolUsage := s.vmPoolUsage(ctx, vm)
+
+	result, unsupported, err := s.remoteCheckVMTarget(ctx, targetNode, probe)
+	if err != nil {
+		return append(poolSpaceChecks(targetNode, poolUsage), migrationIface.CompatibilityCheck{
+			Category: CompatibilityCategoryTarget,
+			Code:     "target_check_failed",
+			Status:   migrationIface.CompatibilityBlocked,
+			Detail:   err.Error(),
+			Remedy:   "Make sure the target node is reachable from this node, then retry.",
+		})
+	}
+	if unsupported {
+		check := migrationIface.CompatibilityCheck{
+			Category: CompatibilityCategoryTarget,
+			Code:     "target_check_unsupported",
+			Status:   migrationIface.CompatibilityWarning,
+			Remedy:   "Update the target node to verify this VM's requirements before moving it.",
+		}
+		if probe.hasTargetDependencies() {
+			check.Status = migrationIface.CompatibilityBlocked
+		}
+		return append(poolSpaceChecks(targetNode, poolUsage), check)
+	}
+
+	return buildVMCompatibilityChecks(vm, targetNode, probe, result, nameByUUID, poolUsage)
+}
+
+// hasTargetDependencies reports whether the VM references anything that has
+// to exist on the target itself, which an older target cannot confirm.
+func (p vmTargetProbe) hasTargetDependencies() bool {
+	return len(p.MediaUUIDs) > 0 || len(p.Switches) > 0 || len(p.FsDatasets) > 0 ||
+		len(p.PassthroughDevices) > 0 || p.VNCPort > 0
+}
+
+// buildVMCompatibilityChecks turns the target's answer to a probe into one
+// check per requirement.
+func buildVMCompatibilityChecks(
+	vm vmModels.VM,
+	targetNode clusterModels.ClusterNode,
+	probe vmTargetProbe,
+	result vmTargetResult,
+	nameByUUID map[string]string,
+	poolUsage map[string]uint64,
+) []migrationIface.CompatibilityCheck {
+	var checks []migrationIface.CompatibilityCheck
+	add := func(category, code, status, detail, remedy string) {
+		checks = append(checks, migrationIface.CompatibilityCheck{
+			Category: category,
+			Code:     code,
+			Status:   status,
+			Detail:   detail,
+			Remedy:   remedy,
+		})
+	}
+
+	// Targets from before capability reporting answer with zero cores.
+	if result.LogicalCores <= 0 {
+		add(CompatibilityCategoryCPU, "target_capabilities_unreported", migrationIface.CompatibilityWarning,
+			"", "Update the target node to verify CPU, firmware, TPM and passthrough requirements.")
+	} else {
+		if !result.Virtualization {
+			add(CompatibilityCategoryCPU, "target_virtualization_unavailable", migrationIface.CompatibilityBlocked,
+				"", "Enable VT-x/AMD-V in the target node's firmware settings.")
+		}
+
+		if probe.VCPUs > result.LogicalCores {
+			add(CompatibilityCategoryCPU, "target_insufficient_vcpus", migrationIface.CompatibilityBlocked,
+				fmt.Sprintf("needs %d vCPUs, target has %d logical cores", probe.VCPUs, result.LogicalCores),
+				fmt.Sprintf("Reduce the VM to at most %d vCPUs or pick a target with more cores.", result.LogicalCores))
+		} else {
+			add(CompatibilityCategoryCPU, "target_insufficient_vcpus", migrationIface.CompatibilityOK,
+				fmt.Sprintf("%d vCPUs, target has %d logical cores", probe.VCPUs, result.LogicalCores), "")
+		}
+
+		if len(result.UnsupportedCPUFeatures) > 0 {
+			add(CompatibilityCategoryCPU, "target_cpu_features_unsupported", migrationIface.CompatibilityBlocked,
+				strings.Join(result.UnsupportedCPUFeatures, ", "),
+				"Remove these CPU features from the VM or pick a target that supports them.")
+		} else if len(probe.CPUFeatures) > 0 {
+			add(CompatibilityCategoryCPU, "target_cpu_features_unsupported", migrationIface.CompatibilityOK,
+				strings.Join(probe.CPUFeatures, ", "), "")
+		}
+
+		if !result.BootROMAvailable {
+			add(CompatibilityCategoryFirmware, "target_boot_rom_unavailable", migrationIface.CompatibilityBlocked,
+				probe.BootROM, "Install the bhyve firmware package on the target node.")
+		} else {
+			add(CompatibilityCategoryFirmware, "target_boot_rom_unavailable", migrationIface.CompatibilityOK,
+				probe.BootROM, "")
+		}
+
+		if vm.TPMEmulation {
+			if !result.TPMAvailable {
+				add(CompatibilityCategoryTPM, "target_tpm_unavailable", migrationIface.CompatibilityBlocked,
+					"", "Install swtpm on the target node.")
+			} else {
+				add(CompatibilityCategoryTPM, "target_tpm_unavailable", migrationIface.CompatibilityOK, "", "")
+			}
+		}
+
+		missingPassthrough := make(map[string]struct{}, len(result.MissingPassthrough))
+		for _, deviceID := range result.MissingPassthrough {
+			missingPassthrough[deviceID] = struct{}{}
+		}
+		for _, deviceID := range probe.PassthroughDevices {
+			if _, missing := missingPassthrough[deviceID]; missing {
+				add(CompatibilityCategoryPassthrough, "target_missing_passthrough_device", migrationIface.CompatibilityBlocked,
+					deviceID, "Reserve the device for passthrough on the target node, or detach it from the VM before moving it.")
+			} else {
+				add(CompatibilityCategoryPassthrough, "target_missing_passthrough_device", migrationIface.CompatibilityOK,
+					deviceID, "")
+			}
+		}
+	}
+
+	missingSwitches := make(map[string]struct{}, len(result.MissingSwitches))
+	for _, sw := range result.MissingSwitches {
+		missingSwitches[sw] = struct{}{}
+		add(CompatibilityCategoryNetwork, "target_missing_switch", migrationIface.CompatibilityWarning,
+			sw, "Create the switch on the target node, or the NIC is dropped and has to be added again.")
+	}
+	for _, sw := range probe.Switches {
+		label := sw.Name
+		if label == "" {
+			label = sw.Bridge
+		}
+		if _, missing := missingSwitches[label]; !missing {
+			add(CompatibilityCategoryNetwork, "target_missing_switch", migrationIface.CompatibilityOK, label, "")
+		}
+	}
+
+	for _, uuid := range result.MissingMedia {
+		name := strings.TrimSpace(nameByUUID[uuid])
+		if name == "" {
+			name = uuid
+		}
+		add(CompatibilityCategoryMedia, "target_missing_iso", migrationIface.CompatibilityWarning,
+			name, "Make the ISO available on the target node and attach it again after the move.")
+	}
+	for _, ds := range result.MissingFsDatasets {
+		add(CompatibilityCategoryStorage, "9p_share_not_migrated", migrationIface.CompatibilityWarning,
+			ds, "Create the shared dataset on the target node and attach it again after the move.")
+	}
+	if result.VNCPortInUse {
+		add(CompatibilityCategoryConsole, "target_vnc_port_in_use", migrationIface.CompatibilityWarning,
+			fmt.Sprintf("%d", probe.VNCPort), "")
+	}
+
+	return append(checks, poolSpaceChecks(targetNode, poolUsage)...)
+}
+
+// poolSpaceChecks compares the space the VM's datasets use with the free
+// space the target last reported for the same pools. Pools the target has not
+// reported are left to the SSH pool check.
+func poolSpaceChecks(targetNode clusterModels.ClusterNode, poolUsage map[string]uint64) []migrationIface.CompatibilityCheck {
+	var checks []migrationIface.CompatibilityCheck
+	for _, pool := range targetNode.Pools {
+		needed, ok := poolUsage[pool.Name]
+		if !ok {
+			continue
+		}
+
+		var free uint64
+		if pool.Size > pool.Alloc {
+			free = pool.Size - pool.Alloc
+		}
+
+		check := migrationIface.CompatibilityCheck{
+			Category: CompatibilityCategoryStorage,
+			Code:     "target_insufficient_pool_space",
+			Status:   migrationIface.CompatibilityOK,
+			Detail:   fmt.Sprintf("%s needs %d bytes, %d free", pool.Name, needed, free),
+		}
+		if needed > free {
+			check.Status = migrationIface.CompatibilityBlocked
+			check.Remedy = fmt.Sprintf("Free up space in %s on the target node or pick another target.", pool.Name)
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+func (s *Service) vmPoolUsage(ctx context.Context, vm vmModels.VM) map[string]uint64 {
+	usage := make(map[string]uint64)
+	if s.GZFS == nil || s.GZFS.ZFS == nil {
+		return usage
+	}
+
+	for _, st := range vm.Storages {
+		pool := strings.TrimSpace(st.Pool)
+		if pool == "" || st.Type == vmModels.VMStorageTypeDiskImage {
+			continue
+		}
+		if _, ok := usage[pool]; ok {
+			continue
+		}
+
+		root := fmt.Sprintf("%s/%d", layout.VMsDataset(pool), vm.RID)
+		ds, err := s.GZFS.ZFS.Get(ctx, root, false)
+		if err != nil || ds == nil {
+			logger.L.Debug().Err(err).Str("dataset", root).Msg("failed_to_read_vm_dataset_usage_for_preflight")
+			continue
+		}
+		usage[pool] = ds.Used
+	}
+
+	return usage
+}
+
+func compatibilityAllowed(checks []migrationIface.CompatibilityCheck) bool {
+	for _, check := range checks {
+		if check.Status == migrationIface.CompatibilityBlocked {
+			return false
+		}
+	}
+	return true
+}
+
+// compatibilityReasons renders checks as preflight reasons: warnings get the
+// warning_ prefix and blocked checks refuse the migration.
+func compatibilityReasons(checks []migrationIface.CompatibilityCheck) []string {
+	var reasons []string
+	for _, check := range checks {
+		var reason string
+		switch check.Status {
+		case migrationIface.CompatibilityWarning:
+			reason = "warning_" + check.Code
+		case migrationIface.CompatibilityBlocked:
+			reason = check.Code
+		default:
+			continue
+		}
+		if check.Detail != "" {
+			reason += ": " + check.Detail
+		}
+		reasons = append(reasons, reason)
+	}
+	return reasons
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package migration
+
+import (
+	"testing"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	migrationIface "github.com/alchemillahq/sylve/internal/interfaces/services/migration"
+)
+
+func findCheck(checks []migrationIface.CompatibilityCheck, code, detail string) (migrationIface.CompatibilityCheck, bool) {
+	for _, check := range checks {
+		if check.Code == code && (detail == "" || check.Detail == detail) {
+			return check, true
+		}
+	}
+	return migrationIface.CompatibilityCheck{}, false
+}
+
+func TestBuildVMCompatibilityChecksBlocksUnsafeTarget(t *testing.T) {
+	vm := vmModels.VM{RID: 100, TPMEmulation: true}
+	probe := vmTargetProbe{
+		RID:                100,
+		VCPUs:              8,
+		CPUFeatures:        []string{"x2apic"},
+		BootROM:            "uefi",
+		PassthroughDevices: []string{"pci0:3:0:0", "pci0:4:0:0"},
+		Switches:           []vmTargetSwitch{{Name: "WAN", Type: "standard", Bridge: "bridge0"}},
+	}
+	result := vmTargetResult{
+		LogicalCores:           4,
+		Virtualization:         true,
+		UnsupportedCPUFeatures: []string{"x2apic"},
+		BootROMAvailable:       false,
+		TPMAvailable:           false,
+		MissingPassthrough:     []string{"pci0:4:0:0"},
+		MissingSwitches:        []string{"WAN"},
+	}
+	target := clusterModels.ClusterNode{
+		Pools: []clusterModels.NodePoolHealth{{Name: "zroot", Size: 100, Alloc: 90}},
+	}
+
+	checks := buildVMCompatibilityChecks(vm, target, probe, result, nil, map[string]uint64{"zroot": 50})
+
+	for _, code := range []string{
+		"target_insufficient_vcpus",
+		"target_cpu_features_unsupported",
+		"target_boot_rom_unavailable",
+		"target_tpm_unavailable",
+		"target_insufficient_pool_space",
+	} {
+		check, ok := findCheck(checks, code, "")
+		if !ok || check.Status != migrationIface.CompatibilityBlocked {
+			t.Fatalf("expected %s to be blocked, got %+v", code, checks)
+		}
+		if check.Remedy == "" {
+			t.Fatalf("expected %s to carry a remedy", code)
+		}
+	}
+
+	if check, ok := findCheck(checks, "target_missing_passthrough_device", "pci0:3:0:0"); !ok || check.Status != migrationIface.CompatibilityOK {
+		t.Fatalf("expected reserved passthrough device to pass, got %+v", check)
+	}
+	if check, ok := findCheck(checks, "target_missing_passthrough_device", "pci0:4:0:0"); !ok || check.Status != migrationIface.CompatibilityBlocked {
+		t.Fatalf("expected missing passthrough device to block, got %+v", check)
+	}
+	if check, ok := findCheck(checks, "target_missing_switch", "WAN"); !ok || check.Status != migrationIface.CompatibilityWarning {
+		t.Fatalf("expected missing switch to stay a warning, got %+v", check)
+	}
+
+	if compatibilityAllowed(checks) {
+		t.Fatal("expected the report to refuse the move")
+	}
+}
+
+func TestBuildVMCompatibilityChecksCompatibleTarget(t *testing.T) {
+	vm := vmModels.VM{RID: 100}
+	probe := vmTargetProbe{RID: 100, VCPUs: 2, BootROM: "uefi"}
+	result := vmTargetResult{LogicalCores: 8, Virtualization: true, BootROMAvailable: true}
+	target := clusterModels.ClusterNode{
+		Pools: []clusterModels.NodePoolHealth{{Name: "zroot", Size: 100, Alloc: 10}},
+	}
+
+	checks := buildVMCompatibilityChecks(vm, target, probe, result, nil, map[string]uint64{"zroot": 50})
+	if !compatibilityAllowed(checks) {
+		t.Fatalf("expected a compatible report, got %+v", checks)
+	}
+	if reasons := compatibilityReasons(checks); len(reasons) != 0 {
+		t.Fatalf("expected no preflight reasons, got %v", reasons)
+	}
+	if _, ok := findCheck(checks, "target_tpm_unavailable", ""); ok {
+		t.Fatal("expected no TPM check for a VM without TPM emulation")
+	}
+}
+
+func TestBuildVMCompatibilityChecksOlderTarget(t *testing.T) {
+	probe := vmTargetProbe{RID: 100, VCPUs: 64, BootROM: "uefi"}
+
+	checks := buildVMCompatibilityChecks(vmModels.VM{RID: 100}, clusterModels.ClusterNode{}, probe, vmTargetResult{}, nil, nil)
+	if !compatibilityAllowed(checks) {
+		t.Fatalf("expected a target without capability reporting not to block, got %+v", checks)
+	}
+
+	reasons := compatibilityReasons(checks)
+	if len(reasons) != 1 || reasons[0] != "warning_target_capabilities_unreported" {
+		t.Fatalf("expected a single capability warning, got %v", reasons)
+	}
+}
+
+func TestCompatibilityReasons(t *testing.T) {
+	reasons := compatibilityReasons([]migrationIface.CompatibilityCheck{
+		{Code: "target_missing_iso", Status: migrationIface.CompatibilityWarning, Detail: "Ubuntu ISO"},
+		{Code: "target_tpm_unavailable", Status: migrationIface.CompatibilityBlocked},
+		{Code: "target_insufficient_vcpus", Status: migrationIface.CompatibilityOK, Detail: "2 vCPUs"},
+	})
+
+	want := []string{"warning_target_missing_iso: Ubuntu ISO", "target_tpm_unavailable"}
+	if len(reasons) != len(want) {
+		t.Fatalf("expected %v, got %v", want, reasons)
+	}
+	for i := range want {
+		if reasons[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, reasons)
+		}
+	}
+}
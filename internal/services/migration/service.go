@@ -21,12 +21,14 @@ import (
 	"time"
 
 	"github.com/alchemillahq/gzfs"
+	"github.com/alchemillahq/sylve/internal/db/models"
 	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
 	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
 	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
 	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
 	migrationIface "github.com/alchemillahq/sylve/internal/interfaces/services/migration"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/internal/services/cluster"
 	"github.com/alchemillahq/sylve/internal/services/jail"
@@ -299,7 +301,7 @@ func (s *Service) validateVMPreflight(ctx context.Context, rid uint, targetNode
 			continue
 		}
 
-		guestDataset := fmt.Sprintf("%s/sylve/virtual-machines/%d", pool, rid)
+		guestDataset := fmt.Sprintf("%s/%d", layout.VMsDataset(pool), rid)
 		datasetExists, dsErr := s.remoteDatasetExists(ctx, identity, privateKeyPath, guestDataset)
 		if dsErr != nil {
 			reasons = append(reasons, fmt.Sprintf("target_guest_check_failed_%s: %v", pool, dsErr))
@@ -404,6 +406,11 @@ type vmTargetProbe struct {
 	VNCPort    int              `json:"vncPort"`
 	Switches   []vmTargetSwitch `json:"switches"`
 	FsDatasets []string         `json:"fsDatasets"`
+
+	VCPUs              int      `json:"vcpus"`
+	CPUFeatures        []string `json:"cpuFeatures"`
+	BootROM            string   `json:"bootRom"`
+	PassthroughDevices []string `json:"passthroughDevices"`
 }
 
 type vmTargetResult struct {
@@ -411,6 +418,13 @@ type vmTargetResult struct {
 	VNCPortInUse      bool     `json:"vncPortInUse"`
 	MissingSwitches   []string `json:"missingSwitches"`
 	MissingFsDatasets []string `json:"missingFsDatasets"`
+
+	LogicalCores           int      `json:"logicalCores"`
+	Virtualization         bool     `json:"virtualization"`
+	UnsupportedCPUFeatures []string `json:"unsupportedCpuFeatures"`
+	BootROMAvailable       bool     `json:"bootRomAvailable"`
+	TPMAvailable           bool     `json:"tpmAvailable"`
+	MissingPassthrough     []string `json:"missingPassthrough"`
 }
 
 func (s *Service) requireTargetGuestRecordAbsent(
@@ -528,13 +542,26 @@ func (s *Service) buildVMTargetProbe(vm vmModels.VM) (vmTargetProbe, map[string]
 	uuids, nameByUUID := collectVMISOUUIDs(vm.Storages)
 
 	probe := vmTargetProbe{
-		RID:        vm.RID,
-		MediaUUIDs: uuids,
+		RID:         vm.RID,
+		MediaUUIDs:  uuids,
+		VCPUs:       max(vm.CPUSockets, 1) * max(vm.CPUCores, 1) * max(vm.CPUThreads, 1),
+		CPUFeatures: vm.CPUFeatures,
+		BootROM:     string(vm.BootROM),
 	}
 	if vm.VNCEnabled {
 		probe.VNCPort = vm.VNCPort
 	}
 
+	if len(vm.PCIDevices) > 0 {
+		var passthrough []models.PassedThroughIDs
+		if err := s.DB.Where("id IN ?", vm.PCIDevices).Find(&passthrough).Error; err != nil {
+			logger.L.Debug().Err(err).Uint("rid", vm.RID).Msg("failed_to_resolve_passthrough_devices_for_preflight")
+		}
+		for _, ppt := range passthrough {
+			probe.PassthroughDevices = append(probe.PassthroughDevices, ppt.DeviceID)
+		}
+	}
+
 	seenSwitch := make(map[string]struct{})
 	for _, net := range vm.Networks {
 		if !net.Enable {
@@ -580,38 +607,7 @@ func (s *Service) buildVMTargetProbe(vm vmModels.VM) (vmTargetProbe, map[string]
 }
 
 func (s *Service) vmTargetPreflightReasons(ctx context.Context, vm vmModels.VM, targetNode clusterModels.ClusterNode) []string {
-	probe, nameByUUID := s.buildVMTargetProbe(vm)
-	if len(probe.MediaUUIDs) == 0 && len(probe.Switches) == 0 && len(probe.FsDatasets) == 0 && probe.VNCPort == 0 {
-		return nil
-	}
-
-	result, unsupported, err := s.remoteCheckVMTarget(ctx, targetNode, probe)
-	if err != nil {
-		return []string{fmt.Sprintf("target_check_failed: %v", err)}
-	}
-	if unsupported {
-		return []string{"target_check_unsupported"}
-	}
-
-	var reasons []string
-	for _, uuid := range result.MissingMedia {
-		name := strings.TrimSpace(nameByUUID[uuid])
-		if name == "" {
-			name = uuid
-		}
-		reasons = append(reasons, fmt.Sprintf("warning_target_missing_iso: %s", name))
-	}
-	for _, sw := range result.MissingSwitches {
-		reasons = append(reasons, fmt.Sprintf("warning_target_missing_switch: %s", sw))
-	}
-	for _, ds := range result.MissingFsDatasets {
-		reasons = append(reasons, fmt.Sprintf("warning_9p_share_not_migrated: %s", ds))
-	}
-	if result.VNCPortInUse {
-		reasons = append(reasons, fmt.Sprintf("warning_target_vnc_port_in_use: %d", probe.VNCPort))
-	}
-
-	return reasons
+	return compatibilityReasons(s.vmCompatibility(ctx, vm, targetNode))
 }
 
 func (s *Service) remoteCheckVMTarget(ctx context.Context, targetNode clusterModels.ClusterNode, probe vmTargetProbe) (vmTargetResult, bool, error) {
@@ -728,7 +724,7 @@ func (s *Service) validateJailPreflight(ctx context.Context, ctID uint, targetNo
 			continue
 		}
 
-		guestDataset := fmt.Sprintf("%s/sylve/jails/%d", pool, ctID)
+		guestDataset := fmt.Sprintf("%s/%d", layout.JailsDataset(pool), ctID)
 		datasetExists, dsErr := s.remoteDatasetExists(ctx, identity, privateKeyPath, guestDataset)
 		if dsErr != nil {
 			reasons = append(reasons, fmt.Sprintf("target_guest_check_failed_%s: %v", pool, dsErr))
@@ -1487,9 +1483,9 @@ func isCanonicalMigrationGuestDataset(name string, guestType string, guestID uin
 	guestDir := ""
 	switch strings.ToLower(strings.TrimSpace(guestType)) {
 	case taskModels.GuestTypeVM:
-		guestDir = "virtual-machines"
+		guestDir = layout.VirtualMachinesName()
 	case taskModels.GuestTypeJail:
-		guestDir = "jails"
+		guestDir = layout.JailsName()
 	default:
 		return false
 	}
@@ -1499,7 +1495,7 @@ func isCanonicalMigrationGuestDataset(name string, guestType string, guestID uin
 		datasetName = datasetName[:snapshotAt]
 	}
 	parts := strings.Split(datasetName, "/")
-	if len(parts) < 4 || parts[0] == "" || parts[1] != "sylve" || parts[2] != guestDir {
+	if len(parts) < 4 || parts[0] == "" || parts[1] != layout.RootName() || parts[2] != guestDir {
 		return false
 	}
 	if parts[3] != strconv.FormatUint(uint64(guestID), 10) {
@@ -1620,7 +1616,7 @@ func (s *Service) resolveVMDatasets(ctx context.Context, rid uint) ([]string, er
 		if pool == "" {
 			continue
 		}
-		root := fmt.Sprintf("%s/sylve/virtual-machines/%d", pool, rid)
+		root := fmt.Sprintf("%s/%d", layout.VMsDataset(pool), rid)
 		if seen[root] {
 			continue
 		}
@@ -1663,7 +1659,7 @@ func (s *Service) resolveJailDatasets(ctx context.Context, ctID uint) ([]string,
 		if pool == "" {
 			continue
 		}
-		root := fmt.Sprintf("%s/sylve/jails/%d", pool, ctID)
+		root := fmt.Sprintf("%s/%d", layout.JailsDataset(pool), ctID)
 		if seen[root] {
 			continue
 		}
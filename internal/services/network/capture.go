@@ -0,0 +1,394 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package network
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/config"
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	networkServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/network"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/network/iface"
+	utils "github.com/alchemillahq/sylve/pkg/utils"
+)
+
+const (
+	packetCaptureDefaultDurationSeconds = 30
+	packetCaptureMaxDurationSeconds     = 600
+	packetCaptureDefaultSizeBytes       = 16 << 20
+	packetCaptureMaxSizeBytes           = 256 << 20
+	packetCaptureDefaultPackets         = 100000
+	packetCaptureMaxPackets             = 1000000
+	packetCaptureMaxFilterLength        = 512
+	packetCaptureMaxConcurrent          = 2
+	packetCaptureRetention              = 7 * 24 * time.Hour
+	packetCaptureSizePollInterval       = time.Second
+	packetCaptureStopGrace              = 5 * time.Second
+)
+
+var (
+	ErrInvalidPacketCapture  = errors.New("invalid_packet_capture")
+	ErrPacketCaptureNotFound = errors.New("packet_capture_not_found")
+	ErrPacketCaptureRunning  = errors.New("packet_capture_running")
+	ErrPacketCaptureLimit    = errors.New("packet_capture_limit_reached")
+
+	errPacketCaptureDuration = errors.New("duration")
+	errPacketCaptureSize     = errors.New("size")
+	errPacketCaptureUser     = errors.New("user")
+)
+
+var (
+	captureCommand       = exec.CommandContext
+	captureRunCommand    = utils.RunCommand
+	captureInterfaceList = iface.List
+	captureDataPath      = config.GetDataPath
+	captureNow           = time.Now
+)
+
+// StartPacketCapture runs a bounded tcpdump on a Sylve-managed bridge or guest
+// epair. The capture stops at whichever of the duration, size or packet caps
+// is hit first and the pcap stays under DataPath until it is deleted.
+func (s *Service) StartPacketCapture(req networkServiceInterfaces.StartPacketCaptureRequest) (*networkModels.PacketCapture, error) {
+	if err := normalizePacketCaptureRequest(&req); err != nil {
+		return nil, err
+	}
+	if err := s.validatePacketCaptureInterface(req.Interface); err != nil {
+		return nil, err
+	}
+	if req.Filter != "" {
+		// tcpdump -d compiles the filter without capturing, so syntax errors
+		// surface here instead of in a failed background capture.
+		if out, err := captureRunCommand("/usr/sbin/tcpdump", "-d", "-i", req.Interface, req.Filter); err != nil {
+			return nil, fmt.Errorf("%w: invalid filter: %s", ErrInvalidPacketCapture, strings.TrimSpace(out))
+		}
+	}
+
+	dataPath, err := captureDataPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed_to_get_data_path: %w", err)
+	}
+	captureDir := filepath.Join(dataPath, "captures")
+	if err := os.MkdirAll(captureDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed_to_create_capture_dir: %w", err)
+	}
+
+	s.captureMutex.Lock()
+	defer s.captureMutex.Unlock()
+
+	if s.captureCancels == nil {
+		s.captureCancels = make(map[uint]context.CancelCauseFunc)
+	}
+	if len(s.captureCancels) >= packetCaptureMaxConcurrent {
+		return nil, fmt.Errorf("%w: at most %d captures may run at once", ErrPacketCaptureLimit, packetCaptureMaxConcurrent)
+	}
+
+	s.prunePacketCaptures()
+
+	now := captureNow()
+	capture := networkModels.PacketCapture{
+		Interface:       req.Interface,
+		Filter:          req.Filter,
+		DurationSeconds: req.DurationSeconds,
+		MaxSizeBytes:    req.MaxSizeBytes,
+		MaxPackets:      req.MaxPackets,
+		Status:          networkModels.PacketCaptureStatusRunning,
+		FilePath:        filepath.Join(captureDir, fmt.Sprintf("%s-%d.pcap", req.Interface, now.UnixNano())),
+		StartedAt:       now,
+	}
+	if err := s.DB.Create(&capture).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_create_packet_capture: %w", err)
+	}
+
+	args := []string{"-i", capture.Interface, "-n", "-U", "-c", strconv.Itoa(capture.MaxPackets), "-w", capture.FilePath}
+	if capture.Filter != "" {
+		args = append(args, capture.Filter)
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	runCtx, runCancel := context.WithTimeoutCause(ctx, time.Duration(capture.DurationSeconds)*time.Second, errPacketCaptureDuration)
+
+	var output bytes.Buffer
+	cmd := captureCommand(runCtx, "/usr/sbin/tcpdump", args...)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	// SIGINT lets tcpdump flush and close the pcap cleanly.
+	cmd.Cancel = func() error { return cmd.Process.Signal(os.Interrupt) }
+	cmd.WaitDelay = packetCaptureStopGrace
+
+	if err := cmd.Start(); err != nil {
+		runCancel()
+		cancel(nil)
+		s.finishPacketCapture(&capture, networkModels.PacketCaptureStatusFailed, "", err.Error())
+		return nil, fmt.Errorf("failed_to_start_tcpdump: %w", err)
+	}
+
+	s.captureCancels[capture.ID] = cancel
+
+	go s.watchPacketCaptureSize(runCtx, cancel, capture.FilePath, capture.MaxSizeBytes)
+	go func() {
+		waitErr := cmd.Wait()
+		cause := context.Cause(runCtx)
+		runCancel()
+		cancel(nil)
+
+		switch {
+		case errors.Is(cause, errPacketCaptureUser):
+			s.finishPacketCapture(&capture, networkModels.PacketCaptureStatusStopped, cause.Error(), "")
+		case errors.Is(cause, errPacketCaptureDuration), errors.Is(cause, errPacketCaptureSize):
+			s.finishPacketCapture(&capture, networkModels.PacketCaptureStatusCompleted, cause.Error(), "")
+		case waitErr != nil:
+			s.finishPacketCapture(&capture, networkModels.PacketCaptureStatusFailed, "", strings.TrimSpace(waitErr.Error()+": "+output.String()))
+		default:
+			s.finishPacketCapture(&capture, networkModels.PacketCaptureStatusCompleted, "packets", "")
+		}
+
+		// Released only after the final status is stored so listings never
+		// mistake a finishing capture for one orphaned by a restart.
+		s.captureMutex.Lock()
+		delete(s.captureCancels, capture.ID)
+		s.captureMutex.Unlock()
+	}()
+
+	return &capture, nil
+}
+
+func (s *Service) ListPacketCaptures() ([]networkModels.PacketCapture, error) {
+	s.captureMutex.Lock()
+	defer s.captureMutex.Unlock()
+
+	var captures []networkModels.PacketCapture
+	if err := s.DB.Order("id DESC").Find(&captures).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_list_packet_captures: %w", err)
+	}
+
+	// A running row without a live process was left behind by a restart.
+	for i := range captures {
+		if captures[i].Status != networkModels.PacketCaptureStatusRunning {
+			continue
+		}
+		if _, ok := s.captureCancels[captures[i].ID]; ok {
+			continue
+		}
+		s.finishPacketCapture(&captures[i], networkModels.PacketCaptureStatusFailed, "", "capture_interrupted")
+	}
+
+	return captures, nil
+}
+
+func (s *Service) StopPacketCapture(id uint) error {
+	if _, err := s.getPacketCapture(id); err != nil {
+		return err
+	}
+
+	s.captureMutex.Lock()
+	defer s.captureMutex.Unlock()
+
+	cancel, ok := s.captureCancels[id]
+	if !ok {
+		return fmt.Errorf("%w: capture %d is not running", ErrInvalidPacketCapture, id)
+	}
+	cancel(errPacketCaptureUser)
+	return nil
+}
+
+func (s *Service) DeletePacketCapture(id uint) error {
+	capture, err := s.getPacketCapture(id)
+	if err != nil {
+		return err
+	}
+
+	s.captureMutex.Lock()
+	defer s.captureMutex.Unlock()
+
+	if _, ok := s.captureCancels[id]; ok {
+		return ErrPacketCaptureRunning
+	}
+	if err := os.Remove(capture.FilePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed_to_remove_capture_file: %w", err)
+	}
+	if err := s.DB.Delete(&networkModels.PacketCapture{}, id).Error; err != nil {
+		return fmt.Errorf("failed_to_delete_packet_capture: %w", err)
+	}
+	return nil
+}
+
+// PacketCaptureFile returns the pcap path of a finished capture.
+func (s *Service) PacketCaptureFile(id uint) (string, error) {
+	capture, err := s.getPacketCapture(id)
+	if err != nil {
+		return "", err
+	}
+	if capture.Status == networkModels.PacketCaptureStatusRunning {
+		return "", ErrPacketCaptureRunning
+	}
+	if _, err := os.Stat(capture.FilePath); err != nil {
+		return "", fmt.Errorf("%w: capture file missing", ErrPacketCaptureNotFound)
+	}
+	return capture.FilePath, nil
+}
+
+func (s *Service) getPacketCapture(id uint) (*networkModels.PacketCapture, error) {
+	var capture networkModels.PacketCapture
+	if err := s.DB.First(&capture, id).Error; err != nil {
+		return nil, fmt.Errorf("%w: %d", ErrPacketCaptureNotFound, id)
+	}
+	return &capture, nil
+}
+
+func (s *Service) finishPacketCapture(capture *networkModels.PacketCapture, status, reason, errMsg string) {
+	now := captureNow()
+	capture.Status = status
+	capture.StopReason = reason
+	capture.Error = errMsg
+	capture.FinishedAt = &now
+	if info, err := os.Stat(capture.FilePath); err == nil {
+		capture.SizeBytes = info.Size()
+	}
+
+	if err := s.DB.Model(&networkModels.PacketCapture{}).Where("id = ?", capture.ID).Updates(map[string]any{
+		"status":      capture.Status,
+		"stop_reason": capture.StopReason,
+		"error":       capture.Error,
+		"finished_at": capture.FinishedAt,
+		"size_bytes":  capture.SizeBytes,
+	}).Error; err != nil {
+		logger.L.Warn().Err(err).Uint("capture_id", capture.ID).Msg("failed_to_update_packet_capture")
+	}
+}
+
+func (s *Service) watchPacketCaptureSize(ctx context.Context, cancel context.CancelCauseFunc, path string, maxSize int64) {
+	ticker := time.NewTicker(packetCaptureSizePollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if info, err := os.Stat(path); err == nil && info.Size() >= maxSize {
+				cancel(errPacketCaptureSize)
+				return
+			}
+		}
+	}
+}
+
+// prunePacketCaptures drops finished captures past retention. Callers hold
+// captureMutex.
+func (s *Service) prunePacketCaptures() {
+	var expired []networkModels.PacketCapture
+	cutoff := captureNow().Add(-packetCaptureRetention)
+	if err := s.DB.Where("status <> ? AND created_at < ?", networkModels.PacketCaptureStatusRunning, cutoff).
+		Find(&expired).Error; err != nil {
+		logger.L.Warn().Err(err).Msg("failed_to_list_expired_packet_captures")
+		return
+	}
+
+	for _, capture := range expired {
+		if err := os.Remove(capture.FilePath); err != nil && !os.IsNotExist(err) {
+			logger.L.Warn().Err(err).Str("path", capture.FilePath).Msg("failed_to_remove_expired_capture_file")
+			continue
+		}
+		s.DB.Delete(&networkModels.PacketCapture{}, capture.ID)
+	}
+}
+
+// validatePacketCaptureInterface only allows switch bridges and Sylve-owned
+// guest epairs so the API cannot be pointed at arbitrary host uplinks.
+func (s *Service) validatePacketCaptureInterface(name string) error {
+	ifaces, err := captureInterfaceList()
+	if err != nil {
+		return fmt.Errorf("failed_to_list_interfaces: %w", err)
+	}
+
+	var found *iface.Interface
+	for _, ifc := range ifaces {
+		if ifc.Name == name {
+			found = ifc
+			break
+		}
+	}
+	if found == nil {
+		return fmt.Errorf("%w: interface %s not found", ErrInvalidPacketCapture, name)
+	}
+	if slices.Contains(found.Groups, sylveEpairGroup) {
+		return nil
+	}
+
+	var count int64
+	if err := s.DB.Model(&networkModels.StandardSwitch{}).Where("bridge_name = ?", name).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed_to_lookup_switch: %w", err)
+	}
+	if count > 0 {
+		return nil
+	}
+	if err := s.DB.Model(&networkModels.ManualSwitch{}).Where("bridge = ?", name).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed_to_lookup_switch: %w", err)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s is not a switch bridge or guest epair", ErrInvalidPacketCapture, name)
+}
+
+func normalizePacketCaptureRequest(req *networkServiceInterfaces.StartPacketCaptureRequest) error {
+	req.Interface = strings.TrimSpace(req.Interface)
+	req.Filter = strings.TrimSpace(req.Filter)
+
+	if req.Interface == "" {
+		return fmt.Errorf("%w: interface is required", ErrInvalidPacketCapture)
+	}
+	if len(req.Filter) > packetCaptureMaxFilterLength {
+		return fmt.Errorf("%w: filter exceeds %d characters", ErrInvalidPacketCapture, packetCaptureMaxFilterLength)
+	}
+	if strings.HasPrefix(req.Filter, "-") {
+		return fmt.Errorf("%w: filter must not start with '-'", ErrInvalidPacketCapture)
+	}
+	for _, r := range req.Filter {
+		if r < 0x20 || r > 0x7e {
+			return fmt.Errorf("%w: filter contains non-printable characters", ErrInvalidPacketCapture)
+		}
+	}
+
+	var err error
+	if req.DurationSeconds, err = packetCaptureLimit(req.DurationSeconds, packetCaptureDefaultDurationSeconds, packetCaptureMaxDurationSeconds, "durationSeconds"); err != nil {
+		return err
+	}
+	if req.MaxSizeBytes, err = packetCaptureLimit(req.MaxSizeBytes, packetCaptureDefaultSizeBytes, packetCaptureMaxSizeBytes, "maxSizeBytes"); err != nil {
+		return err
+	}
+	if req.MaxPackets, err = packetCaptureLimit(req.MaxPackets, packetCaptureDefaultPackets, packetCaptureMaxPackets, "maxPackets"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func packetCaptureLimit[T int | int64](value, def, max T, field string) (T, error) {
+	switch {
+	case value < 0:
+		return 0, fmt.Errorf("%w: %s must not be negative", ErrInvalidPacketCapture, field)
+	case value == 0:
+		return def, nil
+	case value > max:
+		return 0, fmt.Errorf("%w: %s exceeds %d", ErrInvalidPacketCapture, field, max)
+	}
+	return value, nil
+}
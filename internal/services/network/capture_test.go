@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package network
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	networkServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/network"
+	"github.com/alchemillahq/sylve/pkg/network/iface"
+)
+
+func setCaptureTestHooks(t *testing.T, script string) *[]string {
+	t.Helper()
+
+	var filterChecks []string
+	originalCommand := captureCommand
+	originalRun := captureRunCommand
+	originalList := captureInterfaceList
+	originalDataPath := captureDataPath
+	dataPath := t.TempDir()
+
+	captureInterfaceList = func() ([]*iface.Interface, error) {
+		return []*iface.Interface{
+			{Name: "em0"},
+			{Name: "br-lan"},
+			{Name: "abcde_net0a", Groups: []string{"epair", sylveEpairGroup}},
+		}, nil
+	}
+	captureRunCommand = func(command string, args ...string) (string, error) {
+		filterChecks = append(filterChecks, strings.Join(args, " "))
+		if args[len(args)-1] == "bogus" {
+			return "tcpdump: syntax error", errors.New("exit status 1")
+		}
+		return "", nil
+	}
+	captureDataPath = func() (string, error) { return dataPath, nil }
+	captureCommand = func(ctx context.Context, _ string, args ...string) *exec.Cmd {
+		var pcap string
+		for i, arg := range args {
+			if arg == "-w" {
+				pcap = args[i+1]
+			}
+		}
+		return exec.CommandContext(ctx, "sh", "-c", script, "sh", pcap)
+	}
+
+	t.Cleanup(func() {
+		captureCommand = originalCommand
+		captureRunCommand = originalRun
+		captureInterfaceList = originalList
+		captureDataPath = originalDataPath
+	})
+	return &filterChecks
+}
+
+func waitForPacketCapture(t *testing.T, svc *Service, id uint) *networkModels.PacketCapture {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		capture, err := svc.getPacketCapture(id)
+		if err != nil {
+			t.Fatalf("getPacketCapture: %v", err)
+		}
+		if capture.Status != networkModels.PacketCaptureStatusRunning {
+			return capture
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("capture %d did not finish", id)
+	return nil
+}
+
+func TestStartPacketCaptureRejectsUnmanagedInterfacesAndBadInput(t *testing.T) {
+	svc, db := newNetworkServiceForTest(t, &networkModels.PacketCapture{}, &networkModels.StandardSwitch{}, &networkModels.ManualSwitch{})
+	setCaptureTestHooks(t, "exit 0")
+	if err := db.Create(&networkModels.ManualSwitch{Name: "lan", Bridge: "br-lan"}).Error; err != nil {
+		t.Fatalf("seed manual switch: %v", err)
+	}
+
+	cases := map[string]networkServiceInterfaces.StartPacketCaptureRequest{
+		"host uplink":      {Interface: "em0"},
+		"missing iface":    {Interface: "br-gone"},
+		"option injection": {Interface: "br-lan", Filter: "-w /etc/passwd"},
+		"too long":         {Interface: "br-lan", DurationSeconds: packetCaptureMaxDurationSeconds + 1},
+		"too large":        {Interface: "br-lan", MaxSizeBytes: packetCaptureMaxSizeBytes + 1},
+		"negative packets": {Interface: "br-lan", MaxPackets: -1},
+		"bad filter":       {Interface: "br-lan", Filter: "bogus"},
+	}
+	for name, req := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := svc.StartPacketCapture(req); !errors.Is(err, ErrInvalidPacketCapture) {
+				t.Fatalf("expected invalid capture error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestStartPacketCaptureWritesPcapAndAppliesDefaults(t *testing.T) {
+	svc, _ := newNetworkServiceForTest(t, &networkModels.PacketCapture{}, &networkModels.StandardSwitch{}, &networkModels.ManualSwitch{})
+	checks := setCaptureTestHooks(t, `printf 'pcap' > "$1"`)
+
+	capture, err := svc.StartPacketCapture(networkServiceInterfaces.StartPacketCaptureRequest{
+		Interface: "abcde_net0a",
+		Filter:    "tcp port 22",
+	})
+	if err != nil {
+		t.Fatalf("StartPacketCapture: %v", err)
+	}
+	if capture.DurationSeconds != packetCaptureDefaultDurationSeconds ||
+		capture.MaxSizeBytes != packetCaptureDefaultSizeBytes ||
+		capture.MaxPackets != packetCaptureDefaultPackets {
+		t.Fatalf("defaults not applied: %#v", capture)
+	}
+	if len(*checks) != 1 || (*checks)[0] != "-d -i abcde_net0a tcp port 22" {
+		t.Fatalf("filter compile checks = %v", *checks)
+	}
+
+	finished := waitForPacketCapture(t, svc, capture.ID)
+	if finished.Status != networkModels.PacketCaptureStatusCompleted || finished.StopReason != "packets" || finished.SizeBytes != 4 {
+		t.Fatalf("unexpected finished capture: %#v", finished)
+	}
+
+	path, err := svc.PacketCaptureFile(capture.ID)
+	if err != nil {
+		t.Fatalf("PacketCaptureFile: %v", err)
+	}
+	if err := svc.DeletePacketCapture(capture.ID); err != nil {
+		t.Fatalf("DeletePacketCapture: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected pcap to be removed, stat err = %v", err)
+	}
+}
+
+func TestStopPacketCaptureInterruptsRunningCapture(t *testing.T) {
+	svc, _ := newNetworkServiceForTest(t, &networkModels.PacketCapture{}, &networkModels.StandardSwitch{}, &networkModels.ManualSwitch{})
+	setCaptureTestHooks(t, `: > "$1"; exec sleep 30`)
+
+	capture, err := svc.StartPacketCapture(networkServiceInterfaces.StartPacketCaptureRequest{Interface: "abcde_net0a"})
+	if err != nil {
+		t.Fatalf("StartPacketCapture: %v", err)
+	}
+
+	if _, err := svc.PacketCaptureFile(capture.ID); !errors.Is(err, ErrPacketCaptureRunning) {
+		t.Fatalf("expected running capture to refuse download, got %v", err)
+	}
+	if err := svc.DeletePacketCapture(capture.ID); !errors.Is(err, ErrPacketCaptureRunning) {
+		t.Fatalf("expected running capture to refuse delete, got %v", err)
+	}
+	if err := svc.StopPacketCapture(capture.ID); err != nil {
+		t.Fatalf("StopPacketCapture: %v", err)
+	}
+
+	finished := waitForPacketCapture(t, svc, capture.ID)
+	if finished.Status != networkModels.PacketCaptureStatusStopped || finished.StopReason != "user" {
+		t.Fatalf("unexpected stopped capture: %#v", finished)
+	}
+}
+
+func TestListPacketCapturesMarksOrphanedRunsFailed(t *testing.T) {
+	svc, db := newNetworkServiceForTest(t, &networkModels.PacketCapture{})
+	orphan := networkModels.PacketCapture{
+		Interface: "br-lan",
+		Status:    networkModels.PacketCaptureStatusRunning,
+		FilePath:  "/nonexistent/capture.pcap",
+		StartedAt: time.Now(),
+	}
+	if err := db.Create(&orphan).Error; err != nil {
+		t.Fatalf("seed capture: %v", err)
+	}
+
+	captures, err := svc.ListPacketCaptures()
+	if err != nil {
+		t.Fatalf("ListPacketCaptures: %v", err)
+	}
+	if len(captures) != 1 || captures[0].Status != networkModels.PacketCaptureStatusFailed || captures[0].Error != "capture_interrupted" {
+		t.Fatalf("unexpected captures: %#v", captures)
+	}
+}
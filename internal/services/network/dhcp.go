@@ -152,6 +152,9 @@ func (s *Service) SaveConfig(req *networkServiceInterfaces.ModifyDHCPConfigReque
 		return fmt.Errorf("no_changes_detected")
 	}
 
+	previousStandard := current.StandardSwitches
+	previousManual := current.ManualSwitches
+
 	var stdSwitches []networkModels.StandardSwitch
 	if len(req.StandardSwitches) > 0 {
 		if err := s.DB.Where("id IN ?", req.StandardSwitches).Find(&stdSwitches).Error; err != nil {
@@ -185,7 +188,23 @@ func (s *Service) SaveConfig(req *networkServiceInterfaces.ModifyDHCPConfigReque
 		return err
 	}
 
-	return s.WriteDHCPConfig()
+	if err := s.WriteDHCPConfig(); err != nil {
+		return err
+	}
+
+	// Jails on switches entering or leaving DHCP pick up the new search domain.
+	standardIDs := append([]uint(nil), req.StandardSwitches...)
+	for _, sw := range previousStandard {
+		standardIDs = append(standardIDs, sw.ID)
+	}
+	manualIDs := append([]uint(nil), req.ManualSwitches...)
+	for _, sw := range previousManual {
+		manualIDs = append(manualIDs, sw.ID)
+	}
+	s.notifyJailsOnSwitches("standard", standardIDs)
+	s.notifyJailsOnSwitches("manual", manualIDs)
+
+	return nil
 }
 
 func (s *Service) WriteDHCPConfig() error {
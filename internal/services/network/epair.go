@@ -16,6 +16,7 @@ import (
 
 	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
 	networkServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/network"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/internal/logger"
 	utils "github.com/alchemillahq/sylve/pkg/utils"
 
@@ -113,7 +114,7 @@ func (s *Service) SyncEpairs(_ bool) error {
 		lines := strings.Split(strings.TrimSpace(jls), "\n")
 		for _, line := range lines {
 			path := strings.TrimSpace(line)
-			if strings.Contains(path, "/sylve/jails/") {
+			if strings.Contains(path, "/"+layout.JailsPath()+"/") {
 				activePaths = append(activePaths, path)
 			}
 		}
@@ -132,7 +133,7 @@ func (s *Service) SyncEpairs(_ bool) error {
 
 	for _, j := range jails {
 		hash := utils.HashIntToNLetters(int(j.CTID), 5)
-		jailSuffix := fmt.Sprintf("/sylve/jails/%d", j.CTID)
+		jailSuffix := fmt.Sprintf("/%s/%d", layout.JailsPath(), j.CTID)
 		isActive := false
 
 		for _, p := range activePaths {
@@ -1551,6 +1551,11 @@ func (s *Service) renderCurrentConfig(tmpDir, preRules, preNatDecl, postNatDecl,
 
 	objectTablesRendered := renderFirewallObjectTables(objectTables)
 
+	isolationRendered, err := s.renderIsolationGroupRules()
+	if err != nil {
+		return nil, err
+	}
+
 	natPath := filepath.Join(tmpDir, "nat-rules.conf")
 	trafficPath := filepath.Join(tmpDir, "traffic-rules.conf")
 
@@ -1561,7 +1566,18 @@ func (s *Service) renderCurrentConfig(tmpDir, preRules, preNatDecl, postNatDecl,
 		return nil, err
 	}
 
-	pfConf := buildPFMainConfig(preRules, preNatDecl, postNatDecl, preTrafficAnchor, postTrafficAnchor, postRules, objectTablesRendered, natPath, trafficPath)
+	pfConf := buildPFMainConfig(pfMainConfigOptions{
+		PreRules:          preRules,
+		PreNatDecl:        preNatDecl,
+		PostNatDecl:       postNatDecl,
+		PreTrafficAnchor:  preTrafficAnchor,
+		PostTrafficAnchor: postTrafficAnchor,
+		PostRules:         postRules,
+		ObjectTables:      objectTablesRendered,
+		IsolationRules:    isolationRendered,
+		NatPath:           natPath,
+		TrafficPath:       trafficPath,
+	})
 
 	return &networkServiceInterfaces.RenderedConfigResponse{
 		PfConf:       pfConf,
@@ -1788,6 +1804,11 @@ func (s *Service) ApplyFirewallConfig() error {
 		}
 	}
 
+	isolationRendered, err := s.renderIsolationGroupRules()
+	if err != nil {
+		return err
+	}
+
 	tmpDir, err := os.MkdirTemp("", "sylve-pf-*")
 	if err != nil {
 		return err
@@ -1823,7 +1844,19 @@ func (s *Service) ApplyFirewallConfig() error {
 		return err
 	}
 
-	mainCandidate := buildPFMainConfig(advanced.PreRules, advanced.PreNatDecl, advanced.PostNatDecl, advanced.PreTrafficAnchor, advanced.PostTrafficAnchor, advanced.PostRules, objectTablesRendered, tmpNatPath, tmpTrafficPath)
+	mainOpts := pfMainConfigOptions{
+		PreRules:          advanced.PreRules,
+		PreNatDecl:        advanced.PreNatDecl,
+		PostNatDecl:       advanced.PostNatDecl,
+		PreTrafficAnchor:  advanced.PreTrafficAnchor,
+		PostTrafficAnchor: advanced.PostTrafficAnchor,
+		PostRules:         advanced.PostRules,
+		ObjectTables:      objectTablesRendered,
+		IsolationRules:    isolationRendered,
+		NatPath:           tmpNatPath,
+		TrafficPath:       tmpTrafficPath,
+	}
+	mainCandidate := buildPFMainConfig(mainOpts)
 	if err := os.WriteFile(tmpMainPath, []byte(mainCandidate), 0644); err != nil {
 		return err
 	}
@@ -1851,7 +1884,12 @@ func (s *Service) ApplyFirewallConfig() error {
 
 	objectTablesRenderedFinal := renderFirewallObjectTables(objectTables)
 
-	finalMain := buildPFMainConfig(advanced.PreRules, advanced.PreNatDecl, advanced.PostNatDecl, advanced.PreTrafficAnchor, advanced.PostTrafficAnchor, advanced.PostRules, objectTablesRenderedFinal, pfNatRulesPath, pfTrafficRulesPath)
+	// The candidate was validated against the temporary anchor files; the
+	// installed config loads the final ones.
+	mainOpts.ObjectTables = objectTablesRenderedFinal
+	mainOpts.NatPath = pfNatRulesPath
+	mainOpts.TrafficPath = pfTrafficRulesPath
+	finalMain := buildPFMainConfig(mainOpts)
 
 	if err := atomicWriteFile(pfObjectTablesPath, []byte(objectTablesRenderedFinal), 0644); err != nil {
 		return err
@@ -1980,25 +2018,51 @@ func formatPFValidationError(err error, candidates map[string]string) error {
 	return fmt.Errorf("pf_validation_failed: %s | hint: %s", detailText, strings.Join(hints, " | "))
 }
 
-func buildPFMainConfig(preRules string, preNatDecl string, postNatDecl string, preTrafficAnchor string, postTrafficAnchor string, postRules string, objectTablesContent string, natPath string, trafficPath string) string {
+// pfMainConfigOptions is what the main pf.conf is rendered from. Sections
+// left empty are omitted.
+type pfMainConfigOptions struct {
+	// Operator sections from the advanced firewall settings.
+	PreRules          string
+	PreNatDecl        string
+	PostNatDecl       string
+	PreTrafficAnchor  string
+	PostTrafficAnchor string
+	PostRules         string
+
+	ObjectTables   string
+	IsolationRules string
+
+	// Files the generated NAT and traffic anchors are loaded from.
+	NatPath     string
+	TrafficPath string
+}
+
+func buildPFMainConfig(opts pfMainConfigOptions) string {
 	var b strings.Builder
 	b.WriteString(pfManagedHeader)
 	b.WriteString("\n\n")
 
-	if strings.TrimSpace(preRules) != "" {
+	if strings.TrimSpace(opts.PreRules) != "" {
 		b.WriteString("# --- sylve: pre rules ---\n")
-		b.WriteString(strings.TrimSpace(preRules))
+		b.WriteString(strings.TrimSpace(opts.PreRules))
 		b.WriteString("\n\n")
 	}
 
-	if trimmedTables := strings.TrimSpace(objectTablesContent); trimmedTables != "" {
+	if trimmedTables := strings.TrimSpace(opts.ObjectTables); trimmedTables != "" {
 		b.WriteString(trimmedTables)
 		b.WriteString("\n\n")
 	}
 
-	if strings.TrimSpace(preNatDecl) != "" {
+	// Ethernet rules have to come before normalization and translation.
+	if strings.TrimSpace(opts.IsolationRules) != "" {
+		b.WriteString("# --- sylve: isolation groups ---\n")
+		b.WriteString(strings.TrimSpace(opts.IsolationRules))
+		b.WriteString("\n\n")
+	}
+
+	if strings.TrimSpace(opts.PreNatDecl) != "" {
 		b.WriteString("# --- sylve: pre nat declarations ---\n")
-		b.WriteString(strings.TrimSpace(preNatDecl))
+		b.WriteString(strings.TrimSpace(opts.PreNatDecl))
 		b.WriteString("\n\n")
 	}
 
@@ -2006,34 +2070,34 @@ func buildPFMainConfig(preRules string, preNatDecl string, postNatDecl string, p
 	b.WriteString("rdr-anchor \"sylve/nat-rules\" all\n")
 	b.WriteString("binat-anchor \"sylve/nat-rules\" all\n")
 
-	if strings.TrimSpace(postNatDecl) != "" {
+	if strings.TrimSpace(opts.PostNatDecl) != "" {
 		b.WriteString("\n# --- sylve: post nat declarations ---\n")
-		b.WriteString(strings.TrimSpace(postNatDecl))
+		b.WriteString(strings.TrimSpace(opts.PostNatDecl))
 		b.WriteString("\n")
 	}
 
 	b.WriteString("\n")
 	b.WriteString("anchor \"sylve/nat-rules\"\n")
-	b.WriteString(fmt.Sprintf("load anchor \"sylve/nat-rules\" from \"%s\"\n\n", natPath))
+	b.WriteString(fmt.Sprintf("load anchor \"sylve/nat-rules\" from \"%s\"\n\n", opts.NatPath))
 
-	if strings.TrimSpace(preTrafficAnchor) != "" {
+	if strings.TrimSpace(opts.PreTrafficAnchor) != "" {
 		b.WriteString("# --- sylve: pre traffic anchor ---\n")
-		b.WriteString(strings.TrimSpace(preTrafficAnchor))
+		b.WriteString(strings.TrimSpace(opts.PreTrafficAnchor))
 		b.WriteString("\n\n")
 	}
 
 	b.WriteString("anchor \"sylve/traffic-rules\"\n")
-	b.WriteString(fmt.Sprintf("load anchor \"sylve/traffic-rules\" from \"%s\"\n", trafficPath))
+	b.WriteString(fmt.Sprintf("load anchor \"sylve/traffic-rules\" from \"%s\"\n", opts.TrafficPath))
 
-	if strings.TrimSpace(postTrafficAnchor) != "" {
+	if strings.TrimSpace(opts.PostTrafficAnchor) != "" {
 		b.WriteString("\n# --- sylve: post traffic anchor ---\n")
-		b.WriteString(strings.TrimSpace(postTrafficAnchor))
+		b.WriteString(strings.TrimSpace(opts.PostTrafficAnchor))
 		b.WriteString("\n")
 	}
 
-	if strings.TrimSpace(postRules) != "" {
+	if strings.TrimSpace(opts.PostRules) != "" {
 		b.WriteString("\n# --- sylve: post rules ---\n")
-		b.WriteString(strings.TrimSpace(postRules))
+		b.WriteString(strings.TrimSpace(opts.PostRules))
 		b.WriteString("\n")
 	}
 
@@ -1109,7 +1109,7 @@ func TestGetFirewallLiveHitsAppliesFilters(t *testing.T) {
 
 func TestBuildPFMainConfigIncludesObjectTablesInline(t *testing.T) {
 	inlineTables := "table <sylve_obj_1_inet> persist { 10.0.0.0/8 }"
-	rendered := buildPFMainConfig("", "", "", "", "", "", inlineTables, "/tmp/nat.conf", "/tmp/traffic.conf")
+	rendered := buildPFMainConfig(pfMainConfigOptions{ObjectTables: inlineTables, NatPath: "/tmp/nat.conf", TrafficPath: "/tmp/traffic.conf"})
 
 	if !strings.Contains(rendered, `table <sylve_obj_1_inet> persist { 10.0.0.0/8 }`) {
 		t.Fatalf("expected inline table definition, got:\n%s", rendered)
@@ -1135,8 +1135,8 @@ func TestBuildPFMainConfigIncludesObjectTablesInline(t *testing.T) {
 }
 
 func TestBuildPFMainConfigOmitsEmptyTablesBlock(t *testing.T) {
-		tablesRendered := renderFirewallObjectTables(map[uint]firewallObjectTable{})
-	rendered := buildPFMainConfig("", "", "", "", "", "", tablesRendered, "/tmp/nat.conf", "/tmp/traffic.conf")
+	tablesRendered := renderFirewallObjectTables(map[uint]firewallObjectTable{})
+	rendered := buildPFMainConfig(pfMainConfigOptions{ObjectTables: tablesRendered, NatPath: "/tmp/nat.conf", TrafficPath: "/tmp/traffic.conf"})
 
 	if strings.Contains(rendered, `sylve/object-tables`) {
 		t.Fatalf("did not expect object-tables anchor when tables are empty, got:\n%s", rendered)
@@ -1150,7 +1150,7 @@ func TestBuildPFMainConfigOmitsEmptyTablesBlock(t *testing.T) {
 }
 
 func TestBuildPFMainConfigPlacesPreRulesBeforeTranslationHooks(t *testing.T) {
-	rendered := buildPFMainConfig("pass in all keep state", "", "", "", "", "", "", "/tmp/nat.conf", "/tmp/traffic.conf")
+	rendered := buildPFMainConfig(pfMainConfigOptions{PreRules: "pass in all keep state", NatPath: "/tmp/nat.conf", TrafficPath: "/tmp/traffic.conf"})
 
 	natHook := strings.Index(rendered, `nat-anchor "sylve/nat-rules" all`)
 	preRule := strings.Index(rendered, "pass in all keep state")
@@ -1256,24 +1256,24 @@ func TestBuildFirewallObjectTablesDeterministicAndFiltered(t *testing.T) {
 }
 
 func TestRenderFirewallObjectTablesSortedOutput(t *testing.T) {
-		rendered := renderFirewallObjectTables(map[uint]firewallObjectTable{
-			10: {
-				ObjectID:    10,
-				ObjectName:  "Portal IPv4/IPv6",
-				InetName:    "sylve_obj_10_inet",
-				InetValues:  []string{"10.0.0.2", "10.0.0.1"},
-				Inet6Name:   "sylve_obj_10_inet6",
-				Inet6Values: []string{"2001:db8::2"},
-			},
-			2: {
-				ObjectID:    2,
-				ObjectName:  "LAN Allow",
-				InetName:    "sylve_obj_2_inet",
-				InetValues:  []string{"192.168.1.1"},
-				Inet6Name:   "",
-				Inet6Values: nil,
-			},
-			},)
+	rendered := renderFirewallObjectTables(map[uint]firewallObjectTable{
+		10: {
+			ObjectID:    10,
+			ObjectName:  "Portal IPv4/IPv6",
+			InetName:    "sylve_obj_10_inet",
+			InetValues:  []string{"10.0.0.2", "10.0.0.1"},
+			Inet6Name:   "sylve_obj_10_inet6",
+			Inet6Values: []string{"2001:db8::2"},
+		},
+		2: {
+			ObjectID:    2,
+			ObjectName:  "LAN Allow",
+			InetName:    "sylve_obj_2_inet",
+			InetValues:  []string{"192.168.1.1"},
+			Inet6Name:   "",
+			Inet6Values: nil,
+		},
+	})
 
 	firstObjectIdx := strings.Index(rendered, "table <sylve_obj_2_inet>")
 	secondObjectIdx := strings.Index(rendered, "table <sylve_obj_10_inet>")
@@ -1327,7 +1327,7 @@ func TestWriteFirewallObjectTableEntriesCleansUpStale(t *testing.T) {
 	tmpDir := t.TempDir()
 	entriesDir := filepath.Join(tmpDir, "entries")
 
-		initial := map[uint]firewallObjectTable{
+	initial := map[uint]firewallObjectTable{
 		1: {
 			ObjectID:   1,
 			InetName:   "sylve_obj_1_inet",
@@ -1343,7 +1343,7 @@ func TestWriteFirewallObjectTableEntriesCleansUpStale(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-		updated := map[uint]firewallObjectTable{
+	updated := map[uint]firewallObjectTable{
 		2: {
 			ObjectID:   2,
 			InetName:   "sylve_obj_2_inet",
@@ -1359,11 +1359,11 @@ func TestWriteFirewallObjectTableEntriesCleansUpStale(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-		if _, err := os.Stat(filepath.Join(entriesDir, "sylve_obj_1_inet")); !os.IsNotExist(err) {
+	if _, err := os.Stat(filepath.Join(entriesDir, "sylve_obj_1_inet")); !os.IsNotExist(err) {
 		t.Fatalf("expected sylve_obj_1_inet to be removed, got err: %v", err)
 	}
 
-		data, err := os.ReadFile(filepath.Join(entriesDir, "sylve_obj_2_inet"))
+	data, err := os.ReadFile(filepath.Join(entriesDir, "sylve_obj_2_inet"))
 	if err != nil {
 		t.Fatalf("failed to read sylve_obj_2_inet: %v", err)
 	}
@@ -1381,7 +1381,7 @@ func TestWriteFirewallObjectTableEntriesEmptyTablesCleansUp(t *testing.T) {
 	tmpDir := t.TempDir()
 	entriesDir := filepath.Join(tmpDir, "entries")
 
-		if err := os.MkdirAll(entriesDir, 0755); err != nil {
+	if err := os.MkdirAll(entriesDir, 0755); err != nil {
 		t.Fatalf("failed to create entries dir: %v", err)
 	}
 	stalePath := filepath.Join(entriesDir, "stale_file")
@@ -1389,7 +1389,7 @@ func TestWriteFirewallObjectTableEntriesEmptyTablesCleansUp(t *testing.T) {
 		t.Fatalf("failed to write stale file: %v", err)
 	}
 
-		if err := writeFirewallObjectTableEntries(map[uint]firewallObjectTable{}, entriesDir); err != nil {
+	if err := writeFirewallObjectTableEntries(map[uint]firewallObjectTable{}, entriesDir); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -3278,15 +3278,15 @@ func TestPFConfigValidation(t *testing.T) {
 		}
 		trafficRules := []networkModels.FirewallTrafficRule{
 			{
-				ID:          70,
-				Name:        "Pass LAN out",
-				Enabled:     true,
-				Priority:    100,
-				Action:      "pass",
-				Quick:       true,
-				Family:      "inet",
-				Protocol:    "any",
-				Direction:   "out",
+				ID:               70,
+				Name:             "Pass LAN out",
+				Enabled:          true,
+				Priority:         100,
+				Action:           "pass",
+				Quick:            true,
+				Family:           "inet",
+				Protocol:         "any",
+				Direction:        "out",
 				EgressInterfaces: []string{"igb0"},
 				SourceObj: &networkModels.Object{
 					ID:   30,
@@ -3403,7 +3403,7 @@ func TestPFConfigValidation(t *testing.T) {
 		}
 		tables := buildFirewallObjectTables(nil, rules)
 		tablesRendered := renderFirewallObjectTables(tables)
-		config := buildPFMainConfig("", "", "", "", "", "", tablesRendered, "/tmp/nat.conf", "/tmp/traffic.conf")
+		config := buildPFMainConfig(pfMainConfigOptions{ObjectTables: tablesRendered, NatPath: "/tmp/nat.conf", TrafficPath: "/tmp/traffic.conf"})
 		if !strings.Contains(config, `table <sylve_obj_8_inet> persist`) {
 			t.Fatal("expected table definition in pf.conf before " +
 				"nat-anchor (tables must be top-level for pf section ordering)")
@@ -3429,9 +3429,9 @@ func validateGeneratedConfig(t *testing.T, svc *Service, natRules []networkModel
 	if err != nil {
 		t.Fatalf("failed to create temp dir: %v", err)
 	}
-		defer os.RemoveAll(tmpDir)
+	defer os.RemoveAll(tmpDir)
 
-		tablesRendered := renderFirewallObjectTables(tables)
+	tablesRendered := renderFirewallObjectTables(tables)
 
 	natPath := filepath.Join(tmpDir, "nat-rules.conf")
 	trafficPath := filepath.Join(tmpDir, "traffic-rules.conf")
@@ -3443,7 +3443,7 @@ func validateGeneratedConfig(t *testing.T, svc *Service, natRules []networkModel
 		t.Fatalf("failed to write traffic-rules.conf: %v", err)
 	}
 
-	config := buildPFMainConfig(preRules, "", "", "", "", postRules, tablesRendered, natPath, trafficPath)
+	config := buildPFMainConfig(pfMainConfigOptions{PreRules: preRules, PostRules: postRules, ObjectTables: tablesRendered, NatPath: natPath, TrafficPath: trafficPath})
 	configPath := filepath.Join(tmpDir, "pf.conf")
 	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
 		t.Fatalf("failed to write pf.conf: %v", err)
@@ -0,0 +1,413 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package network
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	networkServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/network"
+	"github.com/alchemillahq/sylve/internal/logger"
+	iface "github.com/alchemillahq/sylve/pkg/network/iface"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// Overridden in tests.
+var (
+	hostIfaceGet        = iface.Get
+	hostIfaceRunCommand = utils.RunCommand
+)
+
+// Interface groups created by Sylve itself or by other subsystems; these are
+// managed through switches, guests and WireGuard instead.
+var hostIfaceUnmanagedGroups = []string{"lo", "bridge", "epair", "tap", "vnet", "svm-vlan", "wg", "vlan", "lagg"}
+
+func isManageableHostInterface(name string, info *iface.Interface) bool {
+	if strings.HasPrefix(name, "lo") {
+		return false
+	}
+	if info == nil {
+		return false
+	}
+	if strings.Contains(info.Driver, "tap") {
+		return false
+	}
+	for _, group := range info.Groups {
+		if slices.Contains(hostIfaceUnmanagedGroups, group) {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeHostInterfaceAddresses(values []string, v4 bool) ([]string, error) {
+	out := make([]string, 0, len(values))
+	seen := make(map[string]struct{}, len(values))
+	for _, raw := range values {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		ip, network, err := net.ParseCIDR(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid_host_interface_address: %s", raw)
+		}
+		if (ip.To4() != nil) != v4 {
+			return nil, fmt.Errorf("host_interface_address_family_mismatch: %s", raw)
+		}
+
+		ones, _ := network.Mask.Size()
+		cidr := ip.String() + "/" + strconv.Itoa(ones)
+		if _, ok := seen[ip.String()]; ok {
+			return nil, fmt.Errorf("duplicate_host_interface_address: %s", raw)
+		}
+		seen[ip.String()] = struct{}{}
+		out = append(out, cidr)
+	}
+	return out, nil
+}
+
+func (s *Service) validateHostInterfaceRequest(req *networkServiceInterfaces.UpsertHostInterfaceRequest) (networkModels.HostInterface, *iface.Interface, error) {
+	if req == nil {
+		return networkModels.HostInterface{}, nil, fmt.Errorf("invalid_host_interface_request")
+	}
+
+	hi := networkModels.HostInterface{
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+		MTU:         req.MTU,
+		Media:       strings.TrimSpace(req.Media),
+		MediaOpt:    strings.TrimSpace(req.MediaOpt),
+		Up:          req.Up == nil || *req.Up,
+	}
+
+	if hi.Name == "" {
+		return hi, nil, fmt.Errorf("host_interface_name_required")
+	}
+	if strings.ContainsAny(hi.Description, "\"\n\r") {
+		return hi, nil, fmt.Errorf("invalid_host_interface_description")
+	}
+	if hi.MTU != 0 && !utils.IsValidMTU(hi.MTU) {
+		return hi, nil, fmt.Errorf("invalid_mtu")
+	}
+	if hi.Media == "" && hi.MediaOpt != "" {
+		return hi, nil, fmt.Errorf("host_interface_mediaopt_requires_media")
+	}
+	for _, v := range []string{hi.Media, hi.MediaOpt} {
+		if strings.ContainsAny(v, " \t\"") {
+			return hi, nil, fmt.Errorf("invalid_host_interface_media")
+		}
+	}
+
+	var err error
+	if hi.IPv4, err = normalizeHostInterfaceAddresses(req.IPv4, true); err != nil {
+		return hi, nil, err
+	}
+	if hi.IPv6, err = normalizeHostInterfaceAddresses(req.IPv6, false); err != nil {
+		return hi, nil, err
+	}
+
+	info, err := hostIfaceGet(hi.Name)
+	if err != nil {
+		return hi, nil, fmt.Errorf("host_interface_not_found: %s", hi.Name)
+	}
+	if !isManageableHostInterface(hi.Name, info) {
+		return hi, nil, fmt.Errorf("host_interface_not_manageable: %s", hi.Name)
+	}
+
+	// A switch uplink takes its MTU from the switch; a different value here
+	// would be undone the next time the switch syncs.
+	if hi.MTU != 0 {
+		var port networkModels.NetworkPort
+		if err := s.DB.Preload("Switch").Where("name = ?", hi.Name).Limit(1).Find(&port).Error; err != nil {
+			return hi, nil, fmt.Errorf("failed_to_check_switch_ports: %w", err)
+		}
+		if port.ID != 0 && port.Switch.MTU != 0 && port.Switch.MTU != hi.MTU {
+			return hi, nil, fmt.Errorf("host_interface_mtu_conflicts_with_switch: %s mtu=%d", port.Switch.Name, port.Switch.MTU)
+		}
+	}
+
+	return hi, info, nil
+}
+
+func runHostIfconfig(args ...string) error {
+	output, err := hostIfaceRunCommand("/sbin/ifconfig", args...)
+	if err != nil {
+		if strings.Contains(output, "File exists") {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func hostInterfaceAddressIP(cidr string) string {
+	ip, _, _ := strings.Cut(cidr, "/")
+	return ip
+}
+
+// applyHostInterface moves the live interface from current (nil when the
+// interface was not managed before) to next. Addresses that current added
+// and next drops are removed; anything configured outside Sylve is left
+// alone.
+func applyHostInterface(current *networkModels.HostInterface, next *networkModels.HostInterface) error {
+	name := next.Name
+
+	if next.Description != "" {
+		if err := runHostIfconfig(name, "description", next.Description); err != nil {
+			return fmt.Errorf("failed_to_set_description: %w", err)
+		}
+	} else if current != nil && current.Description != "" {
+		if err := runHostIfconfig(name, "-description"); err != nil {
+			return fmt.Errorf("failed_to_clear_description: %w", err)
+		}
+	}
+
+	if next.MTU > 0 {
+		if err := runHostIfconfig(name, "mtu", strconv.Itoa(next.MTU)); err != nil {
+			return fmt.Errorf("failed_to_set_mtu: %w", err)
+		}
+	}
+
+	if next.Media != "" {
+		args := []string{name, "media", next.Media}
+		if next.MediaOpt != "" {
+			args = append(args, "mediaopt", next.MediaOpt)
+		}
+		if err := runHostIfconfig(args...); err != nil {
+			return fmt.Errorf("failed_to_set_media: %w", err)
+		}
+	} else if current != nil && current.Media != "" {
+		if err := runHostIfconfig(name, "media", "autoselect"); err != nil {
+			return fmt.Errorf("failed_to_reset_media: %w", err)
+		}
+	}
+
+	for _, family := range []struct {
+		name    string
+		current []string
+		next    []string
+	}{
+		{"inet", hostInterfaceAddresses(current, true), next.IPv4},
+		{"inet6", hostInterfaceAddresses(current, false), next.IPv6},
+	} {
+		for _, cidr := range family.current {
+			if slices.Contains(family.next, cidr) {
+				continue
+			}
+			if err := runHostIfconfig(name, family.name, hostInterfaceAddressIP(cidr), "-alias"); err != nil {
+				return fmt.Errorf("failed_to_remove_address_%s: %w", cidr, err)
+			}
+		}
+		for _, cidr := range family.next {
+			if err := runHostIfconfig(name, family.name, cidr, "alias"); err != nil {
+				return fmt.Errorf("failed_to_add_address_%s: %w", cidr, err)
+			}
+		}
+	}
+
+	state := "up"
+	if !next.Up {
+		state = "down"
+	}
+	if err := runHostIfconfig(name, state); err != nil {
+		return fmt.Errorf("failed_to_set_%s: %w", state, err)
+	}
+
+	return nil
+}
+
+// liveHostInterfaceState captures what the interface looks like right now in
+// the shape applyHostInterface understands, so a failed change can be walked
+// back to it.
+func liveHostInterfaceState(info *iface.Interface) networkModels.HostInterface {
+	hi := networkModels.HostInterface{
+		Name:        info.Name,
+		Description: info.Description,
+		MTU:         info.MTU,
+		Up:          info.Flags.Raw&0x1 != 0,
+	}
+
+	if info.Media != nil && info.Media.Subtype != "" && info.Media.Subtype != "autoselect" {
+		hi.Media = info.Media.Subtype
+		hi.MediaOpt = strings.Join(info.Media.Options, ",")
+	}
+
+	for _, addr := range info.IPv4 {
+		mask := net.IPMask(net.ParseIP(addr.Netmask).To4())
+		if mask == nil {
+			continue
+		}
+		ones, _ := mask.Size()
+		hi.IPv4 = append(hi.IPv4, addr.IP.String()+"/"+strconv.Itoa(ones))
+	}
+	for _, addr := range info.IPv6 {
+		if addr.IP.IsLinkLocalUnicast() {
+			continue
+		}
+		hi.IPv6 = append(hi.IPv6, addr.IP.String()+"/"+strconv.Itoa(addr.PrefixLength))
+	}
+
+	return hi
+}
+
+// checkHostInterfaceLockout refuses changes that would take the Raft or
+// replication address off the wire: bringing its interface down, or dropping
+// it from the addresses Sylve manages there. Either would cut the node off
+// from its peers and from the UI reached through them.
+func (s *Service) checkHostInterfaceLockout(info *iface.Interface, current *networkModels.HostInterface, next *networkModels.HostInterface) error {
+	var cluster clusterModels.Cluster
+	if err := s.DB.Limit(1).Find(&cluster).Error; err != nil {
+		return fmt.Errorf("failed_to_check_cluster_addresses: %w", err)
+	}
+
+	live := liveHostInterfaceState(info)
+	liveAddrs := slices.Concat(live.IPv4, live.IPv6)
+	managed := slices.Concat(hostInterfaceAddresses(current, true), hostInterfaceAddresses(current, false))
+	wanted := slices.Concat(next.IPv4, next.IPv6)
+
+	for _, raw := range []string{cluster.RaftIP, cluster.ReplicationIP} {
+		ip := net.ParseIP(strings.TrimSpace(raw))
+		if ip == nil {
+			continue
+		}
+
+		matches := func(cidr string) bool { return net.ParseIP(hostInterfaceAddressIP(cidr)).Equal(ip) }
+		if !slices.ContainsFunc(liveAddrs, matches) {
+			continue
+		}
+		if !next.Up {
+			return fmt.Errorf("host_interface_carries_cluster_address: %s", ip)
+		}
+		if slices.ContainsFunc(managed, matches) && !slices.ContainsFunc(wanted, matches) {
+			return fmt.Errorf("host_interface_cluster_address_removal: %s", ip)
+		}
+	}
+
+	return nil
+}
+
+func hostInterfaceAddresses(hi *networkModels.HostInterface, v4 bool) []string {
+	if hi == nil {
+		return nil
+	}
+	if v4 {
+		return hi.IPv4
+	}
+	return hi.IPv6
+}
+
+func (s *Service) GetHostInterfaces() ([]networkModels.HostInterface, error) {
+	var interfaces []networkModels.HostInterface
+	if err := s.DB.Order("name asc").Find(&interfaces).Error; err != nil {
+		return nil, err
+	}
+	return interfaces, nil
+}
+
+// UpsertHostInterface saves the configuration for a host NIC and applies it
+// live. The row is only written once the interface accepted the change; if
+// applying fails part way, the interface is put back the way it was found.
+func (s *Service) UpsertHostInterface(req *networkServiceInterfaces.UpsertHostInterfaceRequest) (*networkModels.HostInterface, error) {
+	next, info, err := s.validateHostInterfaceRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.syncMutex.Lock()
+	defer s.syncMutex.Unlock()
+
+	previous := liveHostInterfaceState(info)
+	applied := false
+
+	err = s.DB.Transaction(func(tx *gorm.DB) error {
+		var current *networkModels.HostInterface
+		var existing networkModels.HostInterface
+		if err := tx.Where("name = ?", next.Name).First(&existing).Error; err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+		} else {
+			current = &existing
+			next.ID = existing.ID
+			next.CreatedAt = existing.CreatedAt
+		}
+
+		if err := s.checkHostInterfaceLockout(info, current, &next); err != nil {
+			return err
+		}
+
+		applied = true
+		if err := applyHostInterface(current, &next); err != nil {
+			return fmt.Errorf("failed_to_apply_host_interface: %w", err)
+		}
+		if current == nil {
+			return tx.Create(&next).Error
+		}
+		return tx.Save(&next).Error
+	})
+	if err != nil {
+		if applied {
+			if rbErr := applyHostInterface(&next, &previous); rbErr != nil {
+				logger.L.Error().
+					Err(rbErr).
+					Str("interface", next.Name).
+					Msg("failed_to_restore_host_interface")
+			}
+		}
+		return nil, err
+	}
+
+	return &next, nil
+}
+
+// DeleteHostInterface stops managing a host NIC. The live configuration is
+// left in place so removing the entry cannot cut off the management address.
+func (s *Service) DeleteHostInterface(id uint) error {
+	result := s.DB.Delete(&networkModels.HostInterface{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (s *Service) ReconcileHostInterfaces() error {
+	interfaces, err := s.GetHostInterfaces()
+	if err != nil {
+		return err
+	}
+
+	var errs []string
+	for _, hi := range interfaces {
+		if err := applyHostInterface(nil, &hi); err != nil {
+			logger.L.Error().
+				Err(err).
+				Str("interface", hi.Name).
+				Msg("failed_to_reconcile_host_interface")
+			errs = append(errs, fmt.Sprintf("interface=%s: %v", hi.Name, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("host_interface_reconcile_failed: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
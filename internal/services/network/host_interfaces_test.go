@@ -0,0 +1,220 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"testing"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	networkServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/network"
+	iface "github.com/alchemillahq/sylve/pkg/network/iface"
+)
+
+func mockHostInterfaceCommands(t *testing.T, groups map[string][]string) *[]string {
+	t.Helper()
+
+	prevGet := hostIfaceGet
+	prevRun := hostIfaceRunCommand
+	t.Cleanup(func() {
+		hostIfaceGet = prevGet
+		hostIfaceRunCommand = prevRun
+	})
+
+	hostIfaceGet = func(name string) (*iface.Interface, error) {
+		g, ok := groups[name]
+		if !ok {
+			return nil, fmt.Errorf("no such interface")
+		}
+		return &iface.Interface{Name: name, Groups: g}, nil
+	}
+
+	var calls []string
+	hostIfaceRunCommand = func(command string, args ...string) (string, error) {
+		calls = append(calls, strings.Join(args, " "))
+		return "", nil
+	}
+	return &calls
+}
+
+func TestUpsertHostInterfaceAppliesAndReplacesAddresses(t *testing.T) {
+	svc, db := newNetworkServiceForTest(t, &networkModels.HostInterface{}, &networkModels.StandardSwitch{}, &networkModels.NetworkPort{}, &clusterModels.Cluster{})
+	calls := mockHostInterfaceCommands(t, map[string][]string{"em0": nil})
+
+	hi, err := svc.UpsertHostInterface(&networkServiceInterfaces.UpsertHostInterfaceRequest{
+		Name:        "em0",
+		Description: "uplink",
+		MTU:         9000,
+		IPv4:        []string{"192.0.2.10/24", "192.0.2.11/24"},
+		IPv6:        []string{"2001:db8::10/64"},
+		Media:       "1000baseT",
+		MediaOpt:    "full-duplex",
+	})
+	if err != nil {
+		t.Fatalf("unexpected upsert error: %v", err)
+	}
+	if hi.ID == 0 || !hi.Up {
+		t.Fatalf("expected saved, up interface, got %+v", hi)
+	}
+
+	want := []string{
+		"em0 description uplink",
+		"em0 mtu 9000",
+		"em0 media 1000baseT mediaopt full-duplex",
+		"em0 inet 192.0.2.10/24 alias",
+		"em0 inet 192.0.2.11/24 alias",
+		"em0 inet6 2001:db8::10/64 alias",
+		"em0 up",
+	}
+	if !slices.Equal(*calls, want) {
+		t.Fatalf("unexpected commands:\n got=%v\nwant=%v", *calls, want)
+	}
+
+	*calls = nil
+	if _, err := svc.UpsertHostInterface(&networkServiceInterfaces.UpsertHostInterfaceRequest{
+		Name: "em0",
+		IPv4: []string{"192.0.2.11/24"},
+	}); err != nil {
+		t.Fatalf("unexpected second upsert error: %v", err)
+	}
+
+	want = []string{
+		"em0 -description",
+		"em0 media autoselect",
+		"em0 inet 192.0.2.10 -alias",
+		"em0 inet 192.0.2.11/24 alias",
+		"em0 inet6 2001:db8::10 -alias",
+		"em0 up",
+	}
+	if !slices.Equal(*calls, want) {
+		t.Fatalf("unexpected commands:\n got=%v\nwant=%v", *calls, want)
+	}
+
+	var count int64
+	db.Model(&networkModels.HostInterface{}).Count(&count)
+	if count != 1 {
+		t.Fatalf("expected one managed interface, got %d", count)
+	}
+}
+
+func TestUpsertHostInterfaceRejectsInvalidRequests(t *testing.T) {
+	svc, db := newNetworkServiceForTest(t, &networkModels.HostInterface{}, &networkModels.StandardSwitch{}, &networkModels.NetworkPort{}, &clusterModels.Cluster{})
+	calls := mockHostInterfaceCommands(t, map[string][]string{
+		"em0":     nil,
+		"bridge0": {"bridge"},
+	})
+
+	sw := networkModels.StandardSwitch{Name: "lan", BridgeName: "bridge0", MTU: 1500}
+	if err := db.Create(&sw).Error; err != nil {
+		t.Fatalf("create switch: %v", err)
+	}
+	if err := db.Create(&networkModels.NetworkPort{Name: "em0", SwitchID: sw.ID}).Error; err != nil {
+		t.Fatalf("create port: %v", err)
+	}
+
+	cases := map[string]networkServiceInterfaces.UpsertHostInterfaceRequest{
+		"host_interface_not_found":                 {Name: "ix9"},
+		"host_interface_not_manageable":            {Name: "bridge0"},
+		"invalid_mtu":                              {Name: "em0", MTU: 20},
+		"invalid_host_interface_address":           {Name: "em0", IPv4: []string{"192.0.2.1"}},
+		"host_interface_address_family_mismatch":   {Name: "em0", IPv6: []string{"192.0.2.1/24"}},
+		"duplicate_host_interface_address":         {Name: "em0", IPv4: []string{"192.0.2.1/24", "192.0.2.1/32"}},
+		"host_interface_mediaopt_requires_media":   {Name: "em0", MediaOpt: "full-duplex"},
+		"host_interface_mtu_conflicts_with_switch": {Name: "em0", MTU: 9000},
+	}
+	for want, req := range cases {
+		if _, err := svc.UpsertHostInterface(&req); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s, got %v", want, err)
+		}
+	}
+	if len(*calls) != 0 {
+		t.Fatalf("rejected requests must not touch the interface, got %v", *calls)
+	}
+}
+
+func TestUpsertHostInterfaceGuardsClusterAddressAndRollsBack(t *testing.T) {
+	svc, db := newNetworkServiceForTest(t, &networkModels.HostInterface{}, &networkModels.StandardSwitch{}, &networkModels.NetworkPort{}, &clusterModels.Cluster{})
+	calls := mockHostInterfaceCommands(t, nil)
+
+	hostIfaceGet = func(name string) (*iface.Interface, error) {
+		return &iface.Interface{
+			Name:  name,
+			MTU:   1500,
+			Flags: iface.Flags{Raw: 0x1},
+			IPv4:  []iface.IPv4{{IP: net.ParseIP("192.0.2.10"), Netmask: "255.255.255.0"}},
+		}, nil
+	}
+	hostIfaceRunCommand = func(command string, args ...string) (string, error) {
+		call := strings.Join(args, " ")
+		*calls = append(*calls, call)
+		switch call {
+		case "em0 inet 192.0.2.10/24 alias":
+			return "ifconfig: ioctl (SIOCAIFADDR): File exists", fmt.Errorf("exit status 1")
+		case "em0 inet 192.0.2.30/24 alias":
+			return "ifconfig: ioctl (SIOCAIFADDR): Invalid argument", fmt.Errorf("exit status 1")
+		}
+		return "", nil
+	}
+
+	if err := db.Create(&clusterModels.Cluster{Enabled: true, RaftIP: "192.0.2.10"}).Error; err != nil {
+		t.Fatalf("create cluster: %v", err)
+	}
+	if err := db.Create(&networkModels.HostInterface{Name: "em0", IPv4: []string{"192.0.2.10/24"}, Up: true}).Error; err != nil {
+		t.Fatalf("create host interface: %v", err)
+	}
+
+	down := false
+	for want, req := range map[string]networkServiceInterfaces.UpsertHostInterfaceRequest{
+		"host_interface_carries_cluster_address": {Name: "em0", IPv4: []string{"192.0.2.10/24"}, Up: &down},
+		"host_interface_cluster_address_removal": {Name: "em0", IPv4: []string{"192.0.2.20/24"}},
+	} {
+		if _, err := svc.UpsertHostInterface(&req); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %s, got %v", want, err)
+		}
+	}
+	if len(*calls) != 0 {
+		t.Fatalf("guarded requests must not touch the interface, got %v", *calls)
+	}
+
+	_, err := svc.UpsertHostInterface(&networkServiceInterfaces.UpsertHostInterfaceRequest{
+		Name: "em0",
+		MTU:  9000,
+		IPv4: []string{"192.0.2.10/24", "192.0.2.20/24", "192.0.2.30/24"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "failed_to_add_address_192.0.2.30/24") {
+		t.Fatalf("expected the third address to fail, got %v", err)
+	}
+
+	want := []string{
+		"em0 mtu 9000",
+		"em0 inet 192.0.2.10/24 alias",
+		"em0 inet 192.0.2.20/24 alias",
+		"em0 inet 192.0.2.30/24 alias",
+		"em0 mtu 1500",
+		"em0 inet 192.0.2.20 -alias",
+		"em0 inet 192.0.2.30 -alias",
+		"em0 inet 192.0.2.10/24 alias",
+		"em0 up",
+	}
+	if !slices.Equal(*calls, want) {
+		t.Fatalf("unexpected commands:\n got=%v\nwant=%v", *calls, want)
+	}
+
+	var saved networkModels.HostInterface
+	if err := db.Where("name = ?", "em0").First(&saved).Error; err != nil {
+		t.Fatalf("load host interface: %v", err)
+	}
+	if saved.MTU != 0 || !slices.Equal(saved.IPv4, []string{"192.0.2.10/24"}) {
+		t.Fatalf("failed change must not be saved, got %+v", saved)
+	}
+}
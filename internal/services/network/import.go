@@ -0,0 +1,782 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package network
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	networkServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/network"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	importActionCreate    = "create"
+	importActionExists    = "exists"
+	importActionDuplicate = "duplicate"
+	importActionInvalid   = "invalid"
+	importActionFailed    = "failed"
+)
+
+var objectTypes = []string{"Host", "Network", "Port", "Country", "List", "Mac", "FQDN", "DUID"}
+
+type importRow struct {
+	Line   int
+	Fields map[string]string
+}
+
+// normalizeImportKey folds header and field names so "Disable IPv6",
+// "disable_ipv6" and "disableIPv6" all match.
+func normalizeImportKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '_' || r == '-' {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, strings.TrimSpace(key))
+}
+
+func (r importRow) get(keys ...string) string {
+	for _, key := range keys {
+		if value, ok := r.Fields[key]; ok && strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// readImportCSV reads a CSV export that starts with a header row. Blank rows
+// and lines starting with # are skipped.
+func readImportCSV(data string) ([]importRow, error) {
+	reader := csv.NewReader(strings.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("empty_import")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid_csv: %w", err)
+	}
+	for i := range header {
+		header[i] = normalizeImportKey(strings.TrimPrefix(header[i], "\ufeff"))
+	}
+
+	rows := []importRow{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid_csv: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := importRow{Line: line, Fields: make(map[string]string, len(header))}
+		blank := true
+		for i, value := range record {
+			if i >= len(header) {
+				break
+			}
+			if strings.TrimSpace(value) != "" {
+				blank = false
+			}
+			row.Fields[header[i]] = value
+		}
+		if !blank {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, nil
+}
+
+// splitImportList splits a cell holding several values, separated by
+// semicolons, pipes, commas or whitespace.
+func splitImportList(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ';' || r == '|' || r == ',' || unicode.IsSpace(r)
+	})
+}
+
+func parseImportBool(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "0", "false", "no", "n", "off":
+		return false, nil
+	case "1", "true", "yes", "y", "on":
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid_boolean: %s", value)
+	}
+}
+
+func parseImportInt(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid_number: %s", value)
+	}
+	return n, nil
+}
+
+func summarizeImport(report *networkServiceInterfaces.NetworkImportReport) {
+	report.Created, report.Skipped, report.Failed = 0, 0, 0
+	for _, item := range report.Items {
+		switch item.Action {
+		case importActionCreate:
+			report.Created++
+		case importActionExists, importActionDuplicate:
+			report.Skipped++
+		default:
+			report.Failed++
+		}
+	}
+}
+
+type objectImportRow struct {
+	Line   int
+	Name   string
+	Type   string
+	Values []string
+	Err    string
+}
+
+type importedObject struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Values  []string `json:"values"`
+	Value   string   `json:"value"`
+	Entries []struct {
+		Value string `json:"value"`
+	} `json:"entries"`
+}
+
+// canonicalObjectType maps an imported type onto the object type it names,
+// ignoring case. Unknown types are returned as they are and fail validation.
+func canonicalObjectType(oType string) string {
+	oType = strings.TrimSpace(oType)
+	for _, known := range objectTypes {
+		if strings.EqualFold(oType, known) {
+			return known
+		}
+	}
+	return oType
+}
+
+// parseObjectImport reads objects from a JSON array or from CSV with name,
+// type and value columns. CSV rows that share a name are merged, so an IP
+// plan can list one address per row.
+func parseObjectImport(format, data string) ([]objectImportRow, error) {
+	rows := []objectImportRow{}
+
+	if format == "json" {
+		var objects []importedObject
+		if err := json.Unmarshal([]byte(data), &objects); err != nil {
+			return nil, fmt.Errorf("invalid_json: %w", err)
+		}
+		for i, object := range objects {
+			values := append([]string{}, object.Values...)
+			if object.Value != "" {
+				values = append(values, object.Value)
+			}
+			for _, entry := range object.Entries {
+				values = append(values, entry.Value)
+			}
+			rows = append(rows, objectImportRow{
+				Line:   i + 1,
+				Name:   strings.TrimSpace(object.Name),
+				Type:   canonicalObjectType(object.Type),
+				Values: values,
+			})
+		}
+		return rows, nil
+	}
+
+	records, err := readImportCSV(data)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]int)
+	for _, record := range records {
+		row := objectImportRow{
+			Line:   record.Line,
+			Name:   record.get("name"),
+			Type:   canonicalObjectType(record.get("type")),
+			Values: splitImportList(record.get("values", "value", "entries", "address")),
+		}
+
+		if idx, ok := byName[row.Name]; ok && row.Name != "" {
+			if rows[idx].Type != row.Type {
+				rows[idx].Err = fmt.Sprintf("conflicting_types_for_name: line %d", row.Line)
+			}
+			rows[idx].Values = append(rows[idx].Values, row.Values...)
+			continue
+		}
+
+		byName[row.Name] = len(rows)
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func cleanImportValues(values []string) []string {
+	cleaned := make([]string, 0, len(values))
+	seen := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		cleaned = append(cleaned, value)
+	}
+	return cleaned
+}
+
+func objectContentKey(oType string, values []string) string {
+	normalized := make([]string, len(values))
+	for i, value := range values {
+		normalized[i] = strings.ToLower(strings.TrimSpace(value))
+	}
+	sort.Strings(normalized)
+	return oType + "\x00" + strings.Join(normalized, "\n")
+}
+
+// planObjectImport validates imported objects and matches them against the
+// existing ones. An object whose name is taken is skipped when its content
+// is identical and rejected otherwise; one whose content matches an object
+// under another name is reported as a duplicate of it.
+func planObjectImport(rows []objectImportRow, existing []networkModels.Object) []networkServiceInterfaces.NetworkImportItem {
+	byName := make(map[string]networkModels.Object, len(existing))
+	byContent := make(map[string]networkModels.Object, len(existing))
+	for _, object := range existing {
+		byName[object.Name] = object
+		key := objectContentKey(object.Type, entryValues(object))
+		if _, ok := byContent[key]; !ok {
+			byContent[key] = object
+		}
+	}
+
+	items := make([]networkServiceInterfaces.NetworkImportItem, 0, len(rows))
+	importedNames := make(map[string]struct{}, len(rows))
+	importedContent := make(map[string]string, len(rows))
+
+	for _, row := range rows {
+		values := cleanImportValues(row.Values)
+		item := networkServiceInterfaces.NetworkImportItem{
+			Line:   row.Line,
+			Name:   row.Name,
+			Type:   row.Type,
+			Values: values,
+			Action: importActionCreate,
+		}
+
+		err := func() error {
+			if row.Err != "" {
+				return errors.New(row.Err)
+			}
+			if row.Name == "" {
+				return fmt.Errorf("name_required")
+			}
+			if err := validateType(row.Type); err != nil {
+				return err
+			}
+			if err := validateValues(row.Type, values); err != nil {
+				return err
+			}
+			if _, ok := importedNames[row.Name]; ok {
+				return fmt.Errorf("duplicate_name_in_import")
+			}
+			return nil
+		}()
+		if err != nil {
+			item.Action = importActionInvalid
+			item.Error = err.Error()
+			items = append(items, item)
+			continue
+		}
+		importedNames[row.Name] = struct{}{}
+
+		key := objectContentKey(row.Type, values)
+		if object, ok := byName[row.Name]; ok {
+			item.ID = object.ID
+			if objectContentKey(object.Type, entryValues(object)) == key {
+				item.Action = importActionExists
+			} else {
+				item.Action = importActionInvalid
+				item.Error = fmt.Sprintf("object_with_name_already_exists: %s", row.Name)
+			}
+		} else if object, ok := byContent[key]; ok {
+			item.Action = importActionDuplicate
+			item.ID = object.ID
+			item.DuplicateOf = object.Name
+		} else if name, ok := importedContent[key]; ok {
+			item.Action = importActionDuplicate
+			item.DuplicateOf = name
+		} else {
+			importedContent[key] = row.Name
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+func entryValues(object networkModels.Object) []string {
+	values := make([]string, 0, len(object.Entries))
+	for _, entry := range object.Entries {
+		values = append(values, entry.Value)
+	}
+	return values
+}
+
+// ImportObjects creates network objects from a CSV or JSON export. Nothing
+// is written on a dry run; otherwise every planned object is created
+// together and the firewall is reloaded once.
+func (s *Service) ImportObjects(req *networkServiceInterfaces.NetworkImportRequest) (*networkServiceInterfaces.NetworkImportReport, error) {
+	rows, err := parseObjectImport(req.Format, req.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	var existing []networkModels.Object
+	if err := s.DB.Preload("Entries").Find(&existing).Error; err != nil {
+		return nil, err
+	}
+
+	report := &networkServiceInterfaces.NetworkImportReport{
+		DryRun: req.DryRun,
+		Items:  planObjectImport(rows, existing),
+	}
+
+	if req.DryRun {
+		summarizeImport(report)
+		return report, nil
+	}
+
+	if err := s.DB.Transaction(func(tx *gorm.DB) error {
+		for i := range report.Items {
+			item := &report.Items[i]
+			if item.Action != importActionCreate {
+				continue
+			}
+
+			entries := make([]networkModels.ObjectEntry, len(item.Values))
+			for j, value := range item.Values {
+				entries[j] = networkModels.ObjectEntry{Value: value}
+			}
+
+			autoUpdate, refreshInterval := objectRefreshSettings(item.Type)
+			object := networkModels.Object{
+				Name:                   item.Name,
+				Type:                   item.Type,
+				AutoUpdate:             autoUpdate,
+				RefreshIntervalSeconds: refreshInterval,
+				Entries:                entries,
+			}
+			if err := tx.Create(&object).Error; err != nil {
+				return fmt.Errorf("failed_to_create_object %s: %w", item.Name, err)
+			}
+			item.ID = object.ID
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var created []uint
+	for i := range report.Items {
+		item := &report.Items[i]
+		if item.Action != importActionCreate {
+			continue
+		}
+
+		if item.Type == "FQDN" || item.Type == "List" {
+			var object networkModels.Object
+			err := s.DB.Preload("Entries").First(&object, item.ID).Error
+			if err == nil {
+				_, err = s.refreshObjectResolutions(&object)
+			}
+			if err != nil {
+				s.deleteObjectRows(item.ID)
+				item.Action = importActionFailed
+				item.Error = err.Error()
+				item.ID = 0
+				continue
+			}
+		}
+
+		created = append(created, item.ID)
+	}
+
+	if err := s.ApplyFirewallIfEnabled(); err != nil {
+		for _, id := range created {
+			s.deleteObjectRows(id)
+		}
+		return nil, err
+	}
+
+	summarizeImport(report)
+	return report, nil
+}
+
+type switchImportRow struct {
+	Line         int
+	Name         string
+	MTU          int
+	VLAN         int
+	Ports        []string
+	Private      bool
+	DHCP         bool
+	DisableIPv6  bool
+	SLAAC        bool
+	DefaultRoute bool
+	Network4     string
+	Gateway4     string
+	Network6     string
+	Gateway6     string
+	Err          string
+}
+
+type importedSwitch struct {
+	Name         string   `json:"name"`
+	MTU          int      `json:"mtu"`
+	VLAN         int      `json:"vlan"`
+	Ports        []string `json:"ports"`
+	Private      bool     `json:"private"`
+	DHCP         bool     `json:"dhcp"`
+	DisableIPv6  bool     `json:"disableIPv6"`
+	SLAAC        bool     `json:"slaac"`
+	DefaultRoute bool     `json:"defaultRoute"`
+	Network4     string   `json:"network4"`
+	Gateway4     string   `json:"gateway4"`
+	Network6     string   `json:"network6"`
+	Gateway6     string   `json:"gateway6"`
+}
+
+// parseSwitchImport reads standard switches from a JSON array or from CSV.
+// Addresses are either the name of an existing object or a literal
+// CIDR/address, which the switch then stores as a manual address.
+func parseSwitchImport(format, data string) ([]switchImportRow, error) {
+	rows := []switchImportRow{}
+
+	if format == "json" {
+		var switches []importedSwitch
+		if err := json.Unmarshal([]byte(data), &switches); err != nil {
+			return nil, fmt.Errorf("invalid_json: %w", err)
+		}
+		for i, sw := range switches {
+			rows = append(rows, switchImportRow{
+				Line:         i + 1,
+				Name:         strings.TrimSpace(sw.Name),
+				MTU:          sw.MTU,
+				VLAN:         sw.VLAN,
+				Ports:        cleanImportValues(sw.Ports),
+				Private:      sw.Private,
+				DHCP:         sw.DHCP,
+				DisableIPv6:  sw.DisableIPv6,
+				SLAAC:        sw.SLAAC,
+				DefaultRoute: sw.DefaultRoute,
+				Network4:     strings.TrimSpace(sw.Network4),
+				Gateway4:     strings.TrimSpace(sw.Gateway4),
+				Network6:     strings.TrimSpace(sw.Network6),
+				Gateway6:     strings.TrimSpace(sw.Gateway6),
+			})
+		}
+		return rows, nil
+	}
+
+	records, err := readImportCSV(data)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		row := switchImportRow{
+			Line:     record.Line,
+			Name:     record.get("name"),
+			Ports:    cleanImportValues(splitImportList(record.get("ports", "port", "members"))),
+			Network4: record.get("network4", "network", "subnet"),
+			Gateway4: record.get("gateway4", "gateway"),
+			Network6: record.get("network6", "subnet6"),
+			Gateway6: record.get("gateway6"),
+		}
+
+		var errs []string
+		parseInt := func(dst *int, keys ...string) {
+			n, err := parseImportInt(record.get(keys...))
+			if err != nil {
+				errs = append(errs, err.Error())
+			}
+			*dst = n
+		}
+		parseBool := func(dst *bool, keys ...string) {
+			b, err := parseImportBool(record.get(keys...))
+			if err != nil {
+				errs = append(errs, err.Error())
+			}
+			*dst = b
+		}
+
+		parseInt(&row.MTU, "mtu")
+		parseInt(&row.VLAN, "vlan", "vlanid")
+		parseBool(&row.Private, "private")
+		parseBool(&row.DHCP, "dhcp")
+		parseBool(&row.DisableIPv6, "disableipv6")
+		parseBool(&row.SLAAC, "slaac")
+		parseBool(&row.DefaultRoute, "defaultroute")
+		row.Err = strings.Join(errs, ", ")
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// portsOverlap mirrors conflictingPortsForVLAN: a port can only be shared by
+// switches that both tag it, with different VLANs.
+func portsOverlap(vlan, other int) bool {
+	return vlan == 0 || other == 0 || vlan == other
+}
+
+type plannedSwitch struct {
+	row                    switchImportRow
+	network4ID, gateway4ID uint
+	network6ID, gateway6ID uint
+	manual                 networkModels.StandardSwitchManualAddresses
+}
+
+// resolveImportAddress turns an imported address into an object ID when it
+// names an existing object of the expected type, or a manual value
+// otherwise.
+func resolveImportAddress(value, field, oType string, objects map[string]networkModels.Object) (uint, string, error) {
+	if value == "" {
+		return 0, "", nil
+	}
+
+	object, ok := objects[value]
+	if !ok {
+		return 0, value, nil
+	}
+	if object.Type != oType {
+		return 0, "", fmt.Errorf("%s_object must be Type=%s", field, oType)
+	}
+	if len(object.Entries) != 1 {
+		return 0, "", fmt.Errorf("%s_object must have only one entry", field)
+	}
+
+	return object.ID, "", nil
+}
+
+func (s *Service) planSwitchImport(rows []switchImportRow) ([]networkServiceInterfaces.NetworkImportItem, []plannedSwitch, error) {
+	var standard []networkModels.StandardSwitch
+	if err := s.DB.Select("id", "name").Find(&standard).Error; err != nil {
+		return nil, nil, err
+	}
+	var manualSwitches []networkModels.ManualSwitch
+	if err := s.DB.Select("id", "name").Find(&manualSwitches).Error; err != nil {
+		return nil, nil, err
+	}
+	var objects []networkModels.Object
+	if err := s.DB.Preload("Entries").Where("type IN ?", []string{"Host", "Network"}).Find(&objects).Error; err != nil {
+		return nil, nil, err
+	}
+
+	standardByName := make(map[string]uint, len(standard))
+	for _, sw := range standard {
+		standardByName[sw.Name] = sw.ID
+	}
+	manualNames := make(map[string]struct{}, len(manualSwitches))
+	for _, sw := range manualSwitches {
+		manualNames[sw.Name] = struct{}{}
+	}
+	objectsByName := make(map[string]networkModels.Object, len(objects))
+	for _, object := range objects {
+		objectsByName[object.Name] = object
+	}
+
+	items := make([]networkServiceInterfaces.NetworkImportItem, 0, len(rows))
+	planned := make([]plannedSwitch, 0, len(rows))
+	importedNames := make(map[string]struct{}, len(rows))
+	importedPorts := make(map[string]int)
+
+	for _, row := range rows {
+		item := networkServiceInterfaces.NetworkImportItem{
+			Line:   row.Line,
+			Name:   row.Name,
+			Type:   "standard",
+			Values: row.Ports,
+			Action: importActionCreate,
+		}
+
+		if id, ok := standardByName[row.Name]; ok {
+			item.Action = importActionExists
+			item.ID = id
+			items = append(items, item)
+			continue
+		}
+
+		plan := plannedSwitch{row: row}
+		err := func() error {
+			if row.Err != "" {
+				return errors.New(row.Err)
+			}
+			if row.Name == "" {
+				return fmt.Errorf("name_required")
+			}
+			if _, ok := manualNames[row.Name]; ok {
+				return fmt.Errorf("switch_name_in_use")
+			}
+			if _, ok := importedNames[row.Name]; ok {
+				return fmt.Errorf("duplicate_name_in_import")
+			}
+			if !utils.IsValidMTU(row.MTU) && row.MTU != 0 {
+				return fmt.Errorf("invalid_mtu")
+			}
+			if !utils.IsValidVLAN(row.VLAN) && row.VLAN != 0 {
+				return fmt.Errorf("invalid_vlan")
+			}
+
+			var err error
+			if plan.network4ID, plan.manual.Network4, err = resolveImportAddress(row.Network4, "network4", "Network", objectsByName); err != nil {
+				return err
+			}
+			if plan.gateway4ID, plan.manual.Gateway4, err = resolveImportAddress(row.Gateway4, "gateway4", "Host", objectsByName); err != nil {
+				return err
+			}
+			if plan.network6ID, plan.manual.Network6, err = resolveImportAddress(row.Network6, "network6", "Network", objectsByName); err != nil {
+				return err
+			}
+			if plan.gateway6ID, plan.manual.Gateway6, err = resolveImportAddress(row.Gateway6, "gateway6", "Host", objectsByName); err != nil {
+				return err
+			}
+			if _, err := validateStandardSwitchManual(plan.network4ID, plan.gateway4ID, plan.network6ID, plan.gateway6ID, plan.manual); err != nil {
+				return err
+			}
+
+			conflicts, err := s.conflictingPortsForVLAN(row.Ports, row.VLAN, nil)
+			if err != nil {
+				return err
+			}
+			if len(conflicts) > 0 {
+				var msgs []string
+				for _, c := range conflicts {
+					msgs = append(msgs, fmt.Sprintf("%s (used by switch %q vlan=%d)", c.Name, c.Switch.Name, c.Switch.VLAN))
+				}
+				return fmt.Errorf("port_overlap: %s", strings.Join(msgs, ", "))
+			}
+			for _, port := range row.Ports {
+				if other, ok := importedPorts[port]; ok && portsOverlap(row.VLAN, other) {
+					return fmt.Errorf("port_overlap_in_import: %s", port)
+				}
+			}
+
+			return s.checkPortHostInterfaceMTU(row.Ports, row.MTU)
+		}()
+		if err != nil {
+			item.Action = importActionInvalid
+			item.Error = err.Error()
+			items = append(items, item)
+			continue
+		}
+
+		importedNames[row.Name] = struct{}{}
+		for _, port := range row.Ports {
+			importedPorts[port] = row.VLAN
+		}
+		items = append(items, item)
+		planned = append(planned, plan)
+	}
+
+	return items, planned, nil
+}
+
+// ImportStandardSwitches creates standard switches from a CSV or JSON
+// export. Switches are created one at a time, so a switch that fails to come
+// up is reported without undoing the ones before it.
+func (s *Service) ImportStandardSwitches(req *networkServiceInterfaces.NetworkImportRequest) (*networkServiceInterfaces.NetworkImportReport, error) {
+	rows, err := parseSwitchImport(req.Format, req.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	items, planned, err := s.planSwitchImport(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &networkServiceInterfaces.NetworkImportReport{DryRun: req.DryRun, Items: items}
+	if req.DryRun {
+		summarizeImport(report)
+		return report, nil
+	}
+
+	byLine := make(map[int]plannedSwitch, len(planned))
+	for _, plan := range planned {
+		byLine[plan.row.Line] = plan
+	}
+
+	for i := range report.Items {
+		item := &report.Items[i]
+		if item.Action != importActionCreate {
+			continue
+		}
+
+		plan := byLine[item.Line]
+		if err := s.NewStandardSwitch(
+			plan.row.Name,
+			plan.row.MTU,
+			plan.row.VLAN,
+			plan.network4ID,
+			plan.network6ID,
+			plan.gateway4ID,
+			plan.gateway6ID,
+			plan.row.Ports,
+			plan.row.Private,
+			plan.row.DHCP,
+			plan.row.DisableIPv6,
+			plan.row.SLAAC,
+			plan.row.DefaultRoute,
+			plan.manual,
+		); err != nil {
+			item.Action = importActionFailed
+			item.Error = err.Error()
+			continue
+		}
+
+		var sw networkModels.StandardSwitch
+		if err := s.DB.Select("id").Where("name = ?", plan.row.Name).First(&sw).Error; err == nil {
+			item.ID = sw.ID
+		}
+	}
+
+	summarizeImport(report)
+	return report, nil
+}
@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package network
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+)
+
+func TestParseObjectImportCSVMergesRowsByName(t *testing.T) {
+	data := "\ufeffName,Type,Value\n" +
+		"# exported from the old firewall\n" +
+		"web,host,10.0.0.10\n" +
+		"web,Host,10.0.0.11\n" +
+		"\n" +
+		"lan,network,\"10.0.0.0/24; 10.0.1.0/24\"\n" +
+		"mixed,host,10.0.0.12\n" +
+		"mixed,network,10.0.2.0/24\n"
+
+	rows, err := parseObjectImport("csv", data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 objects, got %d: %+v", len(rows), rows)
+	}
+
+	if rows[0].Name != "web" || rows[0].Type != "Host" || !reflect.DeepEqual(rows[0].Values, []string{"10.0.0.10", "10.0.0.11"}) {
+		t.Fatalf("unexpected merged row: %+v", rows[0])
+	}
+	if rows[0].Line != 3 {
+		t.Fatalf("expected line 3, got %d", rows[0].Line)
+	}
+	if !reflect.DeepEqual(rows[1].Values, []string{"10.0.0.0/24", "10.0.1.0/24"}) {
+		t.Fatalf("unexpected list values: %+v", rows[1])
+	}
+	if !strings.Contains(rows[2].Err, "conflicting_types_for_name") {
+		t.Fatalf("expected type conflict, got %+v", rows[2])
+	}
+}
+
+func TestParseObjectImportJSONAcceptsEntries(t *testing.T) {
+	data := `[
+		{"name": "dns", "type": "HOST", "values": ["1.1.1.1"], "value": "1.0.0.1"},
+		{"name": "mac", "type": "Mac", "entries": [{"value": "58:9c:fc:00:00:01"}]}
+	]`
+
+	rows, err := parseObjectImport("json", data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rows[0].Type != "Host" || !reflect.DeepEqual(rows[0].Values, []string{"1.1.1.1", "1.0.0.1"}) {
+		t.Fatalf("unexpected first row: %+v", rows[0])
+	}
+	if rows[1].Line != 2 || !reflect.DeepEqual(rows[1].Values, []string{"58:9c:fc:00:00:01"}) {
+		t.Fatalf("unexpected second row: %+v", rows[1])
+	}
+
+	if _, err := parseObjectImport("json", `{"name": "x"}`); err == nil {
+		t.Fatal("expected error for non-array JSON")
+	}
+}
+
+func TestPlanObjectImport(t *testing.T) {
+	existing := []networkModels.Object{
+		{ID: 1, Name: "dns", Type: "Host", Entries: []networkModels.ObjectEntry{{Value: "1.1.1.1"}, {Value: "1.0.0.1"}}},
+		{ID: 2, Name: "lan", Type: "Network", Entries: []networkModels.ObjectEntry{{Value: "10.0.0.0/24"}}},
+	}
+	rows := []objectImportRow{
+		{Line: 1, Name: "dns", Type: "Host", Values: []string{"1.0.0.1", "1.1.1.1"}},
+		{Line: 2, Name: "lan", Type: "Network", Values: []string{"10.9.0.0/24"}},
+		{Line: 3, Name: "office", Type: "Network", Values: []string{"10.0.0.0/24"}},
+		{Line: 4, Name: "web", Type: "Host", Values: []string{"10.0.0.10", " 10.0.0.10 "}},
+		{Line: 5, Name: "web-copy", Type: "Host", Values: []string{"10.0.0.10"}},
+		{Line: 6, Name: "web", Type: "Host", Values: []string{"10.0.0.11"}},
+		{Line: 7, Name: "bad", Type: "Host", Values: []string{"10.0.0.300"}},
+		{Line: 8, Name: "odd", Type: "Printer", Values: []string{"10.0.0.1"}},
+		{Line: 9, Type: "Host", Values: []string{"10.0.0.1"}},
+	}
+
+	items := planObjectImport(rows, existing)
+
+	want := []struct {
+		action      string
+		duplicateOf string
+		id          uint
+	}{
+		{importActionExists, "", 1},
+		{importActionInvalid, "", 2},
+		{importActionDuplicate, "lan", 2},
+		{importActionCreate, "", 0},
+		{importActionDuplicate, "web", 0},
+		{importActionInvalid, "", 0},
+		{importActionInvalid, "", 0},
+		{importActionInvalid, "", 0},
+		{importActionInvalid, "", 0},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("expected %d items, got %d", len(want), len(items))
+	}
+	for i, w := range want {
+		if items[i].Action != w.action || items[i].DuplicateOf != w.duplicateOf || items[i].ID != w.id {
+			t.Fatalf("item %d: got %+v, want %+v", i, items[i], w)
+		}
+	}
+
+	if !reflect.DeepEqual(items[3].Values, []string{"10.0.0.10"}) {
+		t.Fatalf("expected values to be deduplicated, got %v", items[3].Values)
+	}
+	if !strings.Contains(items[5].Error, "duplicate_name_in_import") {
+		t.Fatalf("expected duplicate name error, got %q", items[5].Error)
+	}
+}
+
+func TestParseSwitchImportCSV(t *testing.T) {
+	data := "name,ports,vlan,mtu,private,disable_ipv6,network,gateway\n" +
+		"lan,em0;em1,,9000,yes,true,10.0.0.0/24,10.0.0.1\n" +
+		"bad,em2,ten,,maybe,,,\n"
+
+	rows, err := parseSwitchImport("csv", data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 switches, got %d", len(rows))
+	}
+
+	lan := rows[0]
+	if lan.Name != "lan" || lan.MTU != 9000 || lan.VLAN != 0 || !lan.Private || !lan.DisableIPv6 {
+		t.Fatalf("unexpected switch: %+v", lan)
+	}
+	if !reflect.DeepEqual(lan.Ports, []string{"em0", "em1"}) || lan.Network4 != "10.0.0.0/24" || lan.Gateway4 != "10.0.0.1" {
+		t.Fatalf("unexpected switch addressing: %+v", lan)
+	}
+
+	if !strings.Contains(rows[1].Err, "invalid_number") || !strings.Contains(rows[1].Err, "invalid_boolean") {
+		t.Fatalf("expected parse errors, got %q", rows[1].Err)
+	}
+}
+
+func TestResolveImportAddress(t *testing.T) {
+	objects := map[string]networkModels.Object{
+		"lan-net": {ID: 4, Name: "lan-net", Type: "Network", Entries: []networkModels.ObjectEntry{{Value: "10.0.0.0/24"}}},
+		"lan-gw":  {ID: 5, Name: "lan-gw", Type: "Host", Entries: []networkModels.ObjectEntry{{Value: "10.0.0.1"}}},
+	}
+
+	if id, manual, err := resolveImportAddress("lan-net", "network4", "Network", objects); err != nil || id != 4 || manual != "" {
+		t.Fatalf("expected object reference, got (%d, %q, %v)", id, manual, err)
+	}
+	if id, manual, err := resolveImportAddress("10.1.0.0/24", "network4", "Network", objects); err != nil || id != 0 || manual != "10.1.0.0/24" {
+		t.Fatalf("expected manual address, got (%d, %q, %v)", id, manual, err)
+	}
+	if _, _, err := resolveImportAddress("lan-gw", "network4", "Network", objects); err == nil {
+		t.Fatal("expected type mismatch error")
+	}
+}
@@ -0,0 +1,346 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	networkServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/network"
+	"github.com/alchemillahq/sylve/internal/logger"
+)
+
+type isolationGroupMACs struct {
+	Name string
+	MACs []string
+}
+
+type isolationSwitch struct {
+	Name   string
+	Groups []isolationGroupMACs
+}
+
+func normalizeIsolationMembers(members []networkModels.IsolationGroupMember) ([]networkModels.IsolationGroupMember, error) {
+	normalized := make([]networkModels.IsolationGroupMember, 0, len(members))
+	seen := make(map[networkModels.IsolationGroupMember]struct{}, len(members))
+
+	for _, member := range members {
+		member.GuestType = strings.TrimSpace(strings.ToLower(member.GuestType))
+		if member.GuestType != networkModels.IsolationGuestVM && member.GuestType != networkModels.IsolationGuestJail {
+			return nil, fmt.Errorf("invalid_isolation_guest_type: %s", member.GuestType)
+		}
+		if member.GuestID == 0 {
+			return nil, fmt.Errorf("invalid_isolation_guest_id")
+		}
+		if _, ok := seen[member]; ok {
+			return nil, fmt.Errorf("duplicate_isolation_member: %s %d", member.GuestType, member.GuestID)
+		}
+		seen[member] = struct{}{}
+		normalized = append(normalized, member)
+	}
+
+	return normalized, nil
+}
+
+// renderIsolationRules renders layer 2 block rules between every pair of
+// groups on a switch, in both directions. Broadcasts still go through, so
+// guests see each other's ARP requests but never get a unicast reply.
+func renderIsolationRules(switches []isolationSwitch) string {
+	var b strings.Builder
+
+	for _, sw := range switches {
+		var rules []string
+		for i, from := range sw.Groups {
+			for j, to := range sw.Groups {
+				if i == j {
+					continue
+				}
+				for _, src := range from.MACs {
+					for _, dst := range to.MACs {
+						rules = append(rules, fmt.Sprintf("ether block quick from %s to %s", src, dst))
+					}
+				}
+			}
+		}
+
+		if len(rules) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&b, "# switch %s\n", sw.Name)
+		for _, rule := range rules {
+			b.WriteString(rule)
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+func (s *Service) GetIsolationGroups() ([]networkModels.IsolationGroup, error) {
+	var groups []networkModels.IsolationGroup
+	if err := s.DB.Order("switch_id asc, id asc").Find(&groups).Error; err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (s *Service) buildIsolationGroup(id uint, req *networkServiceInterfaces.UpsertIsolationGroupRequest) (networkModels.IsolationGroup, error) {
+	group := networkModels.IsolationGroup{
+		ID:          id,
+		SwitchID:    req.SwitchID,
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+	}
+
+	if group.Name == "" {
+		return group, fmt.Errorf("isolation_group_name_required")
+	}
+
+	var sw networkModels.StandardSwitch
+	if err := s.DB.Select("id").First(&sw, req.SwitchID).Error; err != nil {
+		return group, fmt.Errorf("switch_not_found: %d", req.SwitchID)
+	}
+
+	var nameCount int64
+	if err := s.DB.Model(&networkModels.IsolationGroup{}).
+		Where("switch_id = ? AND name = ? AND id <> ?", group.SwitchID, group.Name, id).
+		Count(&nameCount).Error; err != nil {
+		return group, err
+	}
+	if nameCount > 0 {
+		return group, fmt.Errorf("isolation_group_name_in_use")
+	}
+
+	members, err := normalizeIsolationMembers(req.Members)
+	if err != nil {
+		return group, err
+	}
+	group.Members = members
+
+	for _, member := range members {
+		var count int64
+		var err error
+		if member.GuestType == networkModels.IsolationGuestVM {
+			err = s.DB.Model(&vmModels.VM{}).Where("rid = ?", member.GuestID).Count(&count).Error
+		} else {
+			err = s.DB.Model(&jailModels.Jail{}).Where("ct_id = ?", member.GuestID).Count(&count).Error
+		}
+		if err != nil {
+			return group, err
+		}
+		if count == 0 {
+			return group, fmt.Errorf("%s_not_found: %d", member.GuestType, member.GuestID)
+		}
+	}
+
+	var others []networkModels.IsolationGroup
+	if err := s.DB.Where("switch_id = ? AND id <> ?", group.SwitchID, id).Find(&others).Error; err != nil {
+		return group, err
+	}
+	for _, other := range others {
+		for _, member := range other.Members {
+			for _, wanted := range members {
+				if member == wanted {
+					return group, fmt.Errorf("guest_in_another_isolation_group: %s %d (%s)", member.GuestType, member.GuestID, other.Name)
+				}
+			}
+		}
+	}
+
+	return group, nil
+}
+
+func (s *Service) CreateIsolationGroup(req *networkServiceInterfaces.UpsertIsolationGroupRequest) (uint, error) {
+	if !s.IsFirewallServiceEnabled() {
+		return 0, fmt.Errorf("isolation_requires_firewall")
+	}
+
+	group, err := s.buildIsolationGroup(0, req)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.DB.Create(&group).Error; err != nil {
+		return 0, err
+	}
+
+	if err := s.ApplyFirewallIfEnabled(); err != nil {
+		_ = s.DB.Delete(&networkModels.IsolationGroup{}, group.ID).Error
+		return 0, err
+	}
+
+	return group.ID, nil
+}
+
+func (s *Service) EditIsolationGroup(id uint, req *networkServiceInterfaces.UpsertIsolationGroupRequest) error {
+	if !s.IsFirewallServiceEnabled() {
+		return fmt.Errorf("isolation_requires_firewall")
+	}
+
+	var existing networkModels.IsolationGroup
+	if err := s.DB.First(&existing, id).Error; err != nil {
+		return err
+	}
+
+	group, err := s.buildIsolationGroup(id, req)
+	if err != nil {
+		return err
+	}
+	group.CreatedAt = existing.CreatedAt
+
+	if err := s.DB.Save(&group).Error; err != nil {
+		return err
+	}
+
+	if err := s.ApplyFirewallIfEnabled(); err != nil {
+		_ = s.DB.Save(&existing).Error
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteIsolationGroup(id uint) error {
+	var group networkModels.IsolationGroup
+	if err := s.DB.First(&group, id).Error; err != nil {
+		return err
+	}
+
+	if err := s.DB.Delete(&group).Error; err != nil {
+		return err
+	}
+
+	if err := s.ApplyFirewallIfEnabled(); err != nil {
+		_ = s.DB.Create(&group).Error
+		return err
+	}
+
+	return nil
+}
+
+// SyncIsolationGroups reloads the firewall so isolation rules follow guests
+// that were attached to, moved between or detached from switches.
+func (s *Service) SyncIsolationGroups() error {
+	var count int64
+	if err := s.DB.Model(&networkModels.IsolationGroup{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return nil
+	}
+
+	return s.ApplyFirewallIfEnabled()
+}
+
+func normalizeIsolationMAC(value string) string {
+	mac, err := net.ParseMAC(strings.TrimSpace(value))
+	if err != nil || len(mac) != 6 {
+		return ""
+	}
+	return mac.String()
+}
+
+// guestMACsOnSwitch returns the MACs a guest currently has on a standard
+// switch; guests that are not attached to it yet have none.
+func (s *Service) guestMACsOnSwitch(member networkModels.IsolationGroupMember, switchID uint) ([]string, error) {
+	var candidates []string
+
+	switch member.GuestType {
+	case networkModels.IsolationGuestVM:
+		var vm vmModels.VM
+		if err := s.DB.Select("id").Where("rid = ?", member.GuestID).First(&vm).Error; err != nil {
+			return nil, nil
+		}
+		var networks []vmModels.Network
+		if err := s.DB.Preload("AddressObj.Entries").
+			Where("vm_id = ? AND switch_id = ? AND switch_type = ?", vm.ID, switchID, "standard").
+			Find(&networks).Error; err != nil {
+			return nil, err
+		}
+		for _, n := range networks {
+			if n.AddressObj != nil && len(n.AddressObj.Entries) > 0 {
+				candidates = append(candidates, n.AddressObj.Entries[0].Value)
+			} else {
+				candidates = append(candidates, n.MAC)
+			}
+		}
+	case networkModels.IsolationGuestJail:
+		var jail jailModels.Jail
+		if err := s.DB.Select("id").Where("ct_id = ?", member.GuestID).First(&jail).Error; err != nil {
+			return nil, nil
+		}
+		var networks []jailModels.Network
+		if err := s.DB.Preload("MacAddressObj.Entries").
+			Where("jid = ? AND switch_id = ? AND switch_type = ?", jail.ID, switchID, "standard").
+			Find(&networks).Error; err != nil {
+			return nil, err
+		}
+		for _, n := range networks {
+			if n.MacAddressObj != nil && len(n.MacAddressObj.Entries) > 0 {
+				candidates = append(candidates, n.MacAddressObj.Entries[0].Value)
+			}
+		}
+	}
+
+	macs := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		if mac := normalizeIsolationMAC(candidate); mac != "" {
+			macs = append(macs, mac)
+		} else {
+			logger.L.Warn().Msgf("Skipping invalid MAC %q of %s %d in isolation group", candidate, member.GuestType, member.GuestID)
+		}
+	}
+
+	return macs, nil
+}
+
+func (s *Service) renderIsolationGroupRules() (string, error) {
+	groups, err := s.GetIsolationGroups()
+	if err != nil {
+		return "", err
+	}
+	if len(groups) == 0 {
+		return "", nil
+	}
+
+	var switches []isolationSwitch
+	bySwitch := make(map[uint]int)
+	for _, group := range groups {
+		idx, ok := bySwitch[group.SwitchID]
+		if !ok {
+			var sw networkModels.StandardSwitch
+			if err := s.DB.Select("id", "name").First(&sw, group.SwitchID).Error; err != nil {
+				continue
+			}
+			switches = append(switches, isolationSwitch{Name: sw.Name})
+			idx = len(switches) - 1
+			bySwitch[group.SwitchID] = idx
+		}
+
+		rendered := isolationGroupMACs{Name: group.Name}
+		for _, member := range group.Members {
+			macs, err := s.guestMACsOnSwitch(member, group.SwitchID)
+			if err != nil {
+				return "", err
+			}
+			rendered.MACs = append(rendered.MACs, macs...)
+		}
+		sort.Strings(rendered.MACs)
+		switches[idx].Groups = append(switches[idx].Groups, rendered)
+	}
+
+	return renderIsolationRules(switches), nil
+}
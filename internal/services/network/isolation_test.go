@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package network
+
+import (
+	"strings"
+	"testing"
+
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+)
+
+func TestRenderIsolationRules(t *testing.T) {
+	rendered := renderIsolationRules([]isolationSwitch{
+		{
+			Name: "lan",
+			Groups: []isolationGroupMACs{
+				{Name: "web", MACs: []string{"58:9c:fc:00:00:01"}},
+				{Name: "db", MACs: []string{"58:9c:fc:00:00:02", "58:9c:fc:00:00:03"}},
+			},
+		},
+		{
+			Name:   "dmz",
+			Groups: []isolationGroupMACs{{Name: "only", MACs: []string{"58:9c:fc:00:00:04"}}},
+		},
+		{
+			Name: "empty",
+			Groups: []isolationGroupMACs{
+				{Name: "a", MACs: []string{"58:9c:fc:00:00:05"}},
+				{Name: "b"},
+			},
+		},
+	})
+
+	want := "# switch lan\n" +
+		"ether block quick from 58:9c:fc:00:00:01 to 58:9c:fc:00:00:02\n" +
+		"ether block quick from 58:9c:fc:00:00:01 to 58:9c:fc:00:00:03\n" +
+		"ether block quick from 58:9c:fc:00:00:02 to 58:9c:fc:00:00:01\n" +
+		"ether block quick from 58:9c:fc:00:00:03 to 58:9c:fc:00:00:01\n"
+	if rendered != want {
+		t.Fatalf("unexpected isolation rules:\n%s\nwant:\n%s", rendered, want)
+	}
+}
+
+func TestBuildPFMainConfigPlacesIsolationRulesBeforeNAT(t *testing.T) {
+	rules := "ether block quick from 58:9c:fc:00:00:01 to 58:9c:fc:00:00:02"
+	rendered := buildPFMainConfig(pfMainConfigOptions{PreRules: "set skip on lo0", IsolationRules: rules, NatPath: "/tmp/nat.conf", TrafficPath: "/tmp/traffic.conf"})
+
+	ruleIdx := strings.Index(rendered, rules)
+	if ruleIdx == -1 {
+		t.Fatalf("expected isolation rules in config:\n%s", rendered)
+	}
+	if ruleIdx < strings.Index(rendered, "set skip on lo0") || ruleIdx > strings.Index(rendered, "nat-anchor") {
+		t.Fatalf("expected isolation rules between options and translation:\n%s", rendered)
+	}
+
+	if strings.Contains(buildPFMainConfig(pfMainConfigOptions{NatPath: "/tmp/nat.conf", TrafficPath: "/tmp/traffic.conf"}), "isolation groups") {
+		t.Fatal("expected no isolation section without rules")
+	}
+}
+
+func TestNormalizeIsolationMembers(t *testing.T) {
+	members, err := normalizeIsolationMembers([]networkModels.IsolationGroupMember{
+		{GuestType: " VM ", GuestID: 100},
+		{GuestType: "jail", GuestID: 100},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if members[0].GuestType != networkModels.IsolationGuestVM {
+		t.Fatalf("expected normalized guest type, got %q", members[0].GuestType)
+	}
+
+	if _, err := normalizeIsolationMembers([]networkModels.IsolationGroupMember{
+		{GuestType: "vm", GuestID: 100},
+		{GuestType: "vm", GuestID: 100},
+	}); err == nil || !strings.Contains(err.Error(), "duplicate_isolation_member") {
+		t.Fatalf("expected duplicate member error, got %v", err)
+	}
+
+	if _, err := normalizeIsolationMembers([]networkModels.IsolationGroupMember{{GuestType: "host", GuestID: 1}}); err == nil {
+		t.Fatal("expected invalid guest type error")
+	}
+}
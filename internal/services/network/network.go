@@ -81,6 +81,9 @@ type Service struct {
 	wgClientMetricsCache       map[uint]*wgClientMetricsCache
 	listSnapshotMigrationOnce  sync.Once
 	wireGuardUDPPortInUse      func(port int) bool
+	captureMutex               sync.Mutex
+	captureCancels             map[uint]context.CancelCauseFunc
+	sriovMutex                 sync.Mutex
 
 	LibVirt            libvirtServiceInterfaces.LibvirtServiceInterface
 	OnJailObjectUpdate func(jailIDs []uint)
@@ -532,23 +532,26 @@ func (s *Service) CreateObject(name string, oType string, values []string) (uint
 
 	if oType == "FQDN" || oType == "List" {
 		if err := s.RefreshObjectByID(object.ID); err != nil {
-			_ = s.DB.Where("object_id = ?", object.ID).Delete(&networkModels.ObjectListSnapshot{}).Error
-			_ = s.DB.Where("object_id = ?", object.ID).Delete(&networkModels.ObjectResolution{}).Error
-			_ = s.DB.Where("object_id = ?", object.ID).Delete(&networkModels.ObjectEntry{}).Error
-			_ = s.DB.Delete(&networkModels.Object{}, object.ID).Error
+			s.deleteObjectRows(object.ID)
 			return 0, err
 		}
 	} else if err := s.ApplyFirewallIfEnabled(); err != nil {
-		_ = s.DB.Where("object_id = ?", object.ID).Delete(&networkModels.ObjectListSnapshot{}).Error
-		_ = s.DB.Where("object_id = ?", object.ID).Delete(&networkModels.ObjectResolution{}).Error
-		_ = s.DB.Where("object_id = ?", object.ID).Delete(&networkModels.ObjectEntry{}).Error
-		_ = s.DB.Delete(&networkModels.Object{}, object.ID).Error
+		s.deleteObjectRows(object.ID)
 		return 0, err
 	}
 
 	return object.ID, nil
 }
 
+// deleteObjectRows removes an object that was never used, along with its
+// entries and resolutions.
+func (s *Service) deleteObjectRows(id uint) {
+	_ = s.DB.Where("object_id = ?", id).Delete(&networkModels.ObjectListSnapshot{}).Error
+	_ = s.DB.Where("object_id = ?", id).Delete(&networkModels.ObjectResolution{}).Error
+	_ = s.DB.Where("object_id = ?", id).Delete(&networkModels.ObjectEntry{}).Error
+	_ = s.DB.Delete(&networkModels.Object{}, id).Error
+}
+
 func (s *Service) DeleteObject(id uint) error {
 	used, _, err := s.IsObjectUsed(id)
 	if err != nil {
@@ -0,0 +1,554 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package network
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal/db/models"
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	networkServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/network"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/system/pciconf"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// Overridden in tests.
+var (
+	sriovRunCommand = utils.RunCommand
+	sriovPCIDevices = pciconf.GetPCIDevices
+	sriovIOVDir     = "/dev/iov"
+	sriovConfigDir  = "/etc/iov"
+)
+
+var (
+	sriovSupportedRe = regexp.MustCompile(`(\d+) VFs configured out of (\d+) supported`)
+	sriovRIDRe       = regexp.MustCompile(`First VF RID Offset 0x([0-9a-fA-F]+), VF RID Stride 0x([0-9a-fA-F]+)`)
+)
+
+type sriovCapability struct {
+	Configured int
+	Supported  int
+	Offset     int
+	Stride     int
+}
+
+// parseSRIOVCapability reads the SR-IOV extended capability from
+// "pciconf -lc" output.
+func parseSRIOVCapability(output string) (sriovCapability, bool) {
+	var iov sriovCapability
+
+	m := sriovSupportedRe.FindStringSubmatch(output)
+	if m == nil {
+		return iov, false
+	}
+	iov.Configured, _ = strconv.Atoi(m[1])
+	iov.Supported, _ = strconv.Atoi(m[2])
+
+	if r := sriovRIDRe.FindStringSubmatch(output); r != nil {
+		offset, _ := strconv.ParseInt(r[1], 16, 32)
+		stride, _ := strconv.ParseInt(r[2], 16, 32)
+		iov.Offset = int(offset)
+		iov.Stride = int(stride)
+	}
+
+	return iov, true
+}
+
+// parseIOVSchemaVFParams returns the parameter names "iovctl -S" lists for
+// VFs; the set differs per driver.
+func parseIOVSchemaVFParams(output string) map[string]struct{} {
+	params := make(map[string]struct{})
+	inVF := false
+
+	for _, line := range utils.SplitLines(output) {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "The following configuration parameters") {
+			inVF = strings.Contains(trimmed, "on a VF")
+			continue
+		}
+		if !inVF || trimmed == "" {
+			continue
+		}
+
+		name, _, ok := strings.Cut(trimmed, ":")
+		if ok {
+			params[strings.TrimSpace(name)] = struct{}{}
+		}
+	}
+
+	return params
+}
+
+// sriovVFDeviceID derives the bus/slot/function of a VF from the PF address
+// and the routing ID offset and stride in its SR-IOV capability.
+func sriovVFDeviceID(pf pciconf.PCIDevice, iov sriovCapability, index int) string {
+	rid := pf.Bus<<8 | pf.Device<<3 | pf.Function
+	vf := rid + iov.Offset + index*iov.Stride
+	return fmt.Sprintf("%d/%d/%d", vf>>8&0xff, vf>>3&0x1f, vf&0x7)
+}
+
+func renderIOVConfig(pf string, vfs []networkModels.SRIOVVirtualFunction) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "PF {\n\tdevice : %q;\n\tnum_vfs : %d;\n}\n\n", pf, len(vfs))
+	b.WriteString("DEFAULT {\n\tpassthrough : false;\n}\n")
+
+	for _, vf := range vfs {
+		fmt.Fprintf(&b, "\nVF-%d {\n\tpassthrough : %t;\n", vf.Index, vf.Passthrough)
+		if vf.MAC != "" {
+			fmt.Fprintf(&b, "\tmac-addr : %q;\n", vf.MAC)
+		}
+		if vf.VLAN != 0 {
+			fmt.Fprintf(&b, "\tvlan : %d;\n", vf.VLAN)
+		}
+		b.WriteString("}\n")
+	}
+
+	return b.String()
+}
+
+func sriovConfigPath(pf string) string {
+	return filepath.Join(sriovConfigDir, pf+".conf")
+}
+
+func sriovSupported(name string) bool {
+	_, err := os.Stat(filepath.Join(sriovIOVDir, name))
+	return err == nil
+}
+
+func findPFDevice(name string) (pciconf.PCIDevice, error) {
+	devices, err := sriovPCIDevices()
+	if err != nil {
+		return pciconf.PCIDevice{}, fmt.Errorf("getting PCI devices: %w", err)
+	}
+
+	for _, device := range devices {
+		if device.Name+strconv.Itoa(device.Unit) == name {
+			return device, nil
+		}
+	}
+
+	return pciconf.PCIDevice{}, fmt.Errorf("sriov_pf_pci_device_not_found: %s", name)
+}
+
+func readSRIOVCapability(pf pciconf.PCIDevice) (sriovCapability, error) {
+	addr := fmt.Sprintf("pci%d:%d:%d:%d", pf.Domain, pf.Bus, pf.Device, pf.Function)
+	output, err := sriovRunCommand("/usr/sbin/pciconf", "-lc", addr)
+	if err != nil {
+		return sriovCapability{}, fmt.Errorf("reading_sriov_capability_failed: %w", err)
+	}
+
+	iov, ok := parseSRIOVCapability(output)
+	if !ok {
+		return sriovCapability{}, fmt.Errorf("sriov_capability_not_found: %s", addr)
+	}
+
+	return iov, nil
+}
+
+func (s *Service) ListSRIOVInterfaces() ([]networkServiceInterfaces.SRIOVInterface, error) {
+	entries, err := os.ReadDir(sriovIOVDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("reading %s: %w", sriovIOVDir, err)
+	}
+
+	var configured []networkModels.SRIOVPhysicalFunction
+	if err := s.DB.Preload("VirtualFunctions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("\"index\" ASC")
+	}).Find(&configured).Error; err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*networkModels.SRIOVPhysicalFunction, len(configured))
+	for i := range configured {
+		byName[configured[i].Interface] = &configured[i]
+	}
+
+	out := make([]networkServiceInterfaces.SRIOVInterface, 0, len(entries))
+	for _, entry := range entries {
+		item := networkServiceInterfaces.SRIOVInterface{
+			Interface:  entry.Name(),
+			Configured: byName[entry.Name()],
+		}
+
+		if pf, err := findPFDevice(entry.Name()); err == nil {
+			item.PCIAddress = fmt.Sprintf("pci%d:%d:%d:%d", pf.Domain, pf.Bus, pf.Device, pf.Function)
+			if iov, err := readSRIOVCapability(pf); err == nil {
+				item.MaxVFs = iov.Supported
+			}
+		}
+
+		out = append(out, item)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Interface < out[j].Interface })
+	return out, nil
+}
+
+func normalizeSRIOVVFs(req []networkServiceInterfaces.SRIOVVFConfig, maxVFs int, schema map[string]struct{}) ([]networkModels.SRIOVVirtualFunction, error) {
+	if len(req) == 0 {
+		return nil, fmt.Errorf("invalid_sriov_vf_count: at least one VF is required")
+	}
+	if maxVFs > 0 && len(req) > maxVFs {
+		return nil, fmt.Errorf("invalid_sriov_vf_count: %d exceeds the %d supported", len(req), maxVFs)
+	}
+
+	seenMACs := make(map[string]struct{}, len(req))
+	vfs := make([]networkModels.SRIOVVirtualFunction, 0, len(req))
+
+	for i, cfg := range req {
+		vf := networkModels.SRIOVVirtualFunction{
+			Index:       i,
+			VLAN:        cfg.VLAN,
+			Passthrough: cfg.Passthrough,
+		}
+
+		if mac := strings.TrimSpace(cfg.MAC); mac != "" {
+			hw, err := net.ParseMAC(mac)
+			if err != nil || len(hw) != 6 || hw[0]&1 == 1 {
+				return nil, fmt.Errorf("invalid_sriov_vf_mac: %s", mac)
+			}
+			vf.MAC = hw.String()
+			if _, ok := seenMACs[vf.MAC]; ok {
+				return nil, fmt.Errorf("duplicate_sriov_vf_mac: %s", vf.MAC)
+			}
+			seenMACs[vf.MAC] = struct{}{}
+			if _, ok := schema["mac-addr"]; !ok {
+				return nil, fmt.Errorf("sriov_mac_not_supported_by_driver")
+			}
+		}
+
+		if vf.VLAN < 0 || vf.VLAN > 4094 {
+			return nil, fmt.Errorf("invalid_sriov_vf_vlan: %d", vf.VLAN)
+		}
+		if vf.VLAN != 0 {
+			if _, ok := schema["vlan"]; !ok {
+				return nil, fmt.Errorf("sriov_vlan_not_supported_by_driver")
+			}
+		}
+
+		vfs = append(vfs, vf)
+	}
+
+	return vfs, nil
+}
+
+// sriovVFsInUse reports the first VM that has one of the given VFs assigned.
+func (s *Service) sriovVFsInUse(vfs []networkModels.SRIOVVirtualFunction) (string, error) {
+	pptIDs := make([]int, 0, len(vfs))
+	for _, vf := range vfs {
+		if vf.PPTID != nil {
+			pptIDs = append(pptIDs, *vf.PPTID)
+		}
+	}
+	if len(pptIDs) == 0 {
+		return "", nil
+	}
+
+	var vms []vmModels.VM
+	if err := s.DB.Select("id", "name", "pci_devices").Find(&vms).Error; err != nil {
+		return "", err
+	}
+
+	for _, vm := range vms {
+		for _, id := range vm.PCIDevices {
+			if slices.Contains(pptIDs, id) {
+				return vm.Name, nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+func destroySRIOVVFs(pf string) {
+	if output, err := sriovRunCommand("/usr/sbin/iovctl", "-D", "-d", pf); err != nil {
+		logger.L.Debug().Err(err).Str("interface", pf).Str("output", strings.TrimSpace(output)).Msg("sriov_destroy_vfs_failed")
+	}
+}
+
+// createSRIOVVFs writes the iovctl config for pf and (re)creates its VFs,
+// returning the VF device IDs in index order.
+func createSRIOVVFs(pf string, vfs []networkModels.SRIOVVirtualFunction) ([]string, error) {
+	if err := os.MkdirAll(sriovConfigDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed_to_create_iov_config_dir: %w", err)
+	}
+
+	path := sriovConfigPath(pf)
+	if err := os.WriteFile(path, []byte(renderIOVConfig(pf, vfs)), 0644); err != nil {
+		return nil, fmt.Errorf("failed_to_write_iov_config: %w", err)
+	}
+
+	if output, err := sriovRunCommand("/usr/sbin/iovctl", "-C", "-f", path); err != nil {
+		return nil, fmt.Errorf("sriov_create_vfs_failed: %s: %w", strings.TrimSpace(output), err)
+	}
+
+	device, err := findPFDevice(pf)
+	if err != nil {
+		return nil, err
+	}
+	iov, err := readSRIOVCapability(device)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(vfs))
+	for i, vf := range vfs {
+		ids[i] = sriovVFDeviceID(device, iov, vf.Index)
+	}
+
+	return ids, nil
+}
+
+// linkSRIOVPassthrough points each passthrough VF at a PassedThroughIDs row
+// so it can be picked like any other PCI device when editing a VM.
+func linkSRIOVPassthrough(tx *gorm.DB, domain int, vfs []networkModels.SRIOVVirtualFunction) error {
+	for i := range vfs {
+		vfs[i].PPTID = nil
+		if !vfs[i].Passthrough || vfs[i].DeviceID == "" {
+			continue
+		}
+
+		var ppt models.PassedThroughIDs
+		err := tx.Where("device_id = ? AND domain = ?", vfs[i].DeviceID, domain).First(&ppt).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ppt = models.PassedThroughIDs{DeviceID: vfs[i].DeviceID, Domain: domain}
+			err = tx.Create(&ppt).Error
+		}
+		if err != nil {
+			return fmt.Errorf("failed_to_register_sriov_passthrough: %w", err)
+		}
+
+		id := ppt.ID
+		vfs[i].PPTID = &id
+	}
+
+	return nil
+}
+
+func unlinkSRIOVPassthrough(tx *gorm.DB, vfs []networkModels.SRIOVVirtualFunction) error {
+	for _, vf := range vfs {
+		if vf.PPTID == nil {
+			continue
+		}
+		if err := tx.Delete(&models.PassedThroughIDs{}, *vf.PPTID).Error; err != nil {
+			return fmt.Errorf("failed_to_remove_sriov_passthrough: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) ConfigureSRIOV(req networkServiceInterfaces.ConfigureSRIOVRequest) (*networkModels.SRIOVPhysicalFunction, error) {
+	s.sriovMutex.Lock()
+	defer s.sriovMutex.Unlock()
+
+	name := strings.TrimSpace(req.Interface)
+	if name == "" || !sriovSupported(name) {
+		return nil, fmt.Errorf("sriov_not_supported: %s", name)
+	}
+
+	device, err := findPFDevice(name)
+	if err != nil {
+		return nil, err
+	}
+	iov, err := readSRIOVCapability(device)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaOutput, err := sriovRunCommand("/usr/sbin/iovctl", "-S", "-d", name)
+	if err != nil {
+		return nil, fmt.Errorf("reading_iov_schema_failed: %w", err)
+	}
+
+	vfs, err := normalizeSRIOVVFs(req.VirtualFunctions, iov.Supported, parseIOVSchemaVFParams(schemaOutput))
+	if err != nil {
+		return nil, err
+	}
+
+	var pf networkModels.SRIOVPhysicalFunction
+	err = s.DB.Preload("VirtualFunctions").Where("interface = ?", name).First(&pf).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if vm, err := s.sriovVFsInUse(pf.VirtualFunctions); err != nil {
+		return nil, err
+	} else if vm != "" {
+		return nil, fmt.Errorf("sriov_vf_in_use_by_vm: %s", vm)
+	}
+
+	if iov.Configured > 0 {
+		destroySRIOVVFs(name)
+	}
+
+	ids, err := createSRIOVVFs(name, vfs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range vfs {
+		vfs[i].DeviceID = ids[i]
+	}
+
+	if err := s.DB.Transaction(func(tx *gorm.DB) error {
+		if pf.ID != 0 {
+			if err := unlinkSRIOVPassthrough(tx, pf.VirtualFunctions); err != nil {
+				return err
+			}
+			if err := tx.Where("physical_function_id = ?", pf.ID).Delete(&networkModels.SRIOVVirtualFunction{}).Error; err != nil {
+				return err
+			}
+		} else {
+			pf.Interface = name
+			if err := tx.Create(&pf).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := linkSRIOVPassthrough(tx, device.Domain, vfs); err != nil {
+			return err
+		}
+		for i := range vfs {
+			vfs[i].PhysicalFunctionID = pf.ID
+		}
+
+		return tx.Create(&vfs).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	pf.VirtualFunctions = vfs
+	return &pf, nil
+}
+
+func (s *Service) DeleteSRIOV(id uint) error {
+	s.sriovMutex.Lock()
+	defer s.sriovMutex.Unlock()
+
+	var pf networkModels.SRIOVPhysicalFunction
+	if err := s.DB.Preload("VirtualFunctions").First(&pf, id).Error; err != nil {
+		return err
+	}
+
+	if vm, err := s.sriovVFsInUse(pf.VirtualFunctions); err != nil {
+		return err
+	} else if vm != "" {
+		return fmt.Errorf("sriov_vf_in_use_by_vm: %s", vm)
+	}
+
+	destroySRIOVVFs(pf.Interface)
+	if err := os.Remove(sriovConfigPath(pf.Interface)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.L.Warn().Err(err).Str("interface", pf.Interface).Msg("failed_to_remove_iov_config")
+	}
+
+	return s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := unlinkSRIOVPassthrough(tx, pf.VirtualFunctions); err != nil {
+			return err
+		}
+		if err := tx.Where("physical_function_id = ?", pf.ID).Delete(&networkModels.SRIOVVirtualFunction{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&pf).Error
+	})
+}
+
+// RestoreSRIOV recreates managed VFs after a reboot, before passthrough
+// devices are synced and guests autostart.
+func (s *Service) RestoreSRIOV() error {
+	s.sriovMutex.Lock()
+	defer s.sriovMutex.Unlock()
+
+	var pfs []networkModels.SRIOVPhysicalFunction
+	if err := s.DB.Preload("VirtualFunctions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("\"index\" ASC")
+	}).Find(&pfs).Error; err != nil {
+		return err
+	}
+
+	var errs []string
+	for _, pf := range pfs {
+		if len(pf.VirtualFunctions) == 0 {
+			continue
+		}
+
+		if err := s.restoreSRIOVPF(pf); err != nil {
+			logger.L.Error().Err(err).Str("interface", pf.Interface).Msg("failed_to_restore_sriov_vfs")
+			errs = append(errs, fmt.Sprintf("interface=%s: %v", pf.Interface, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("sriov_restore_failed: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+func (s *Service) restoreSRIOVPF(pf networkModels.SRIOVPhysicalFunction) error {
+	if !sriovSupported(pf.Interface) {
+		return fmt.Errorf("sriov_not_supported: %s", pf.Interface)
+	}
+
+	device, err := findPFDevice(pf.Interface)
+	if err != nil {
+		return err
+	}
+	iov, err := readSRIOVCapability(device)
+	if err != nil {
+		return err
+	}
+	if iov.Configured == len(pf.VirtualFunctions) {
+		return nil
+	}
+	if iov.Configured > 0 {
+		destroySRIOVVFs(pf.Interface)
+	}
+
+	ids, err := createSRIOVVFs(pf.Interface, pf.VirtualFunctions)
+	if err != nil {
+		return err
+	}
+
+	return s.DB.Transaction(func(tx *gorm.DB) error {
+		for i, vf := range pf.VirtualFunctions {
+			if vf.DeviceID == ids[i] {
+				continue
+			}
+
+			// The VF moved (e.g. the card changed slots); keep VMs pointing at
+			// the same PassedThroughIDs row but update its address.
+			if vf.PPTID != nil {
+				if err := tx.Model(&models.PassedThroughIDs{}).
+					Where("id = ?", *vf.PPTID).
+					Updates(map[string]any{"device_id": ids[i], "domain": device.Domain}).Error; err != nil {
+					return err
+				}
+			}
+			if err := tx.Model(&networkModels.SRIOVVirtualFunction{}).
+				Where("id = ?", vf.ID).
+				Update("device_id", ids[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package network
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alchemillahq/sylve/internal/db/models"
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	networkServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/network"
+	"github.com/alchemillahq/sylve/pkg/system/pciconf"
+)
+
+const testSRIOVCapability = `ixl0@pci0:3:0:0:	class=0x020000 rev=0x02 hdr=0x00 vendor=0x8086 device=0x1572
+    ecap 0010[1a0] = SR-IOV 1 IOV enabled, Memory Space enabled, ARI enabled
+                     %s VFs configured out of 64 supported
+                     First VF RID Offset 0x0010, VF RID Stride 0x0001
+                     VF Device ID 0x154c
+`
+
+const testIOVSchema = `The following configuration parameters may be configured on the PF:
+	num_vfs : uint16_t (required)
+	device : string (required)
+The following configuration parameters may be configured on a VF:
+	passthrough : bool (default = false)
+	mac-addr : unicast-mac (optional)
+	allow-set-mac : bool (default = false)
+`
+
+func mockSRIOV(t *testing.T, configured *string) *[]string {
+	t.Helper()
+
+	prevRun, prevPCI, prevIOV, prevConf := sriovRunCommand, sriovPCIDevices, sriovIOVDir, sriovConfigDir
+	t.Cleanup(func() {
+		sriovRunCommand, sriovPCIDevices, sriovIOVDir, sriovConfigDir = prevRun, prevPCI, prevIOV, prevConf
+	})
+
+	sriovIOVDir = t.TempDir()
+	sriovConfigDir = t.TempDir()
+	if err := os.WriteFile(filepath.Join(sriovIOVDir, "ixl0"), nil, 0600); err != nil {
+		t.Fatalf("failed to create iov node: %v", err)
+	}
+
+	sriovPCIDevices = func() ([]pciconf.PCIDevice, error) {
+		return []pciconf.PCIDevice{{Name: "ixl", Unit: 0, Bus: 3}}, nil
+	}
+
+	var calls []string
+	sriovRunCommand = func(command string, args ...string) (string, error) {
+		calls = append(calls, filepath.Base(command)+" "+strings.Join(args, " "))
+		switch filepath.Base(command) {
+		case "pciconf":
+			return strings.Replace(testSRIOVCapability, "%s", *configured, 1), nil
+		case "iovctl":
+			if args[0] == "-S" {
+				return testIOVSchema, nil
+			}
+		}
+		return "", nil
+	}
+
+	return &calls
+}
+
+func TestParseSRIOVCapabilityAndVFAddresses(t *testing.T) {
+	iov, ok := parseSRIOVCapability(strings.Replace(testSRIOVCapability, "%s", "2", 1))
+	if !ok || iov.Configured != 2 || iov.Supported != 64 || iov.Offset != 0x10 || iov.Stride != 1 {
+		t.Fatalf("unexpected capability %+v (%v)", iov, ok)
+	}
+
+	pf := pciconf.PCIDevice{Bus: 3}
+	if got := sriovVFDeviceID(pf, iov, 0); got != "3/2/0" {
+		t.Fatalf("unexpected first VF address %s", got)
+	}
+	if got := sriovVFDeviceID(pf, iov, 9); got != "3/3/1" {
+		t.Fatalf("unexpected tenth VF address %s", got)
+	}
+
+	params := parseIOVSchemaVFParams(testIOVSchema)
+	if _, ok := params["mac-addr"]; !ok {
+		t.Fatalf("expected mac-addr in VF params, got %v", params)
+	}
+	if _, ok := params["num_vfs"]; ok {
+		t.Fatal("PF parameters must not leak into the VF set")
+	}
+}
+
+func TestConfigureSRIOVRegistersPassthroughVFs(t *testing.T) {
+	svc, db := newNetworkServiceForTest(t,
+		&networkModels.SRIOVPhysicalFunction{},
+		&networkModels.SRIOVVirtualFunction{},
+		&models.PassedThroughIDs{},
+		&vmModels.VM{},
+	)
+	configured := "0"
+	calls := mockSRIOV(t, &configured)
+
+	if _, err := svc.ConfigureSRIOV(networkServiceInterfaces.ConfigureSRIOVRequest{
+		Interface:        "ixl0",
+		VirtualFunctions: []networkServiceInterfaces.SRIOVVFConfig{{VLAN: 10}},
+	}); err == nil || !strings.Contains(err.Error(), "sriov_vlan_not_supported_by_driver") {
+		t.Fatalf("expected the vlan to be rejected for this driver, got %v", err)
+	}
+
+	pf, err := svc.ConfigureSRIOV(networkServiceInterfaces.ConfigureSRIOVRequest{
+		Interface: "ixl0",
+		VirtualFunctions: []networkServiceInterfaces.SRIOVVFConfig{
+			{MAC: "02:00:00:00:00:01", Passthrough: true},
+			{},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ConfigureSRIOV returned error: %v", err)
+	}
+	if len(pf.VirtualFunctions) != 2 || pf.VirtualFunctions[0].DeviceID != "3/2/0" || pf.VirtualFunctions[0].PPTID == nil {
+		t.Fatalf("unexpected VFs %+v", pf.VirtualFunctions)
+	}
+	if pf.VirtualFunctions[1].PPTID != nil {
+		t.Fatal("non-passthrough VFs must not be registered for passthrough")
+	}
+
+	conf, err := os.ReadFile(filepath.Join(sriovConfigDir, "ixl0.conf"))
+	if err != nil {
+		t.Fatalf("expected iovctl config: %v", err)
+	}
+	for _, want := range []string{`device : "ixl0";`, "num_vfs : 2;", `mac-addr : "02:00:00:00:00:01";`} {
+		if !strings.Contains(string(conf), want) {
+			t.Fatalf("expected %q in config:\n%s", want, conf)
+		}
+	}
+	if !strings.Contains(strings.Join(*calls, "\n"), "iovctl -C -f") {
+		t.Fatalf("expected VFs to be created, calls=%v", *calls)
+	}
+
+	if err := db.Create(&vmModels.VM{Name: "fast", RID: 100, PCIDevices: []int{*pf.VirtualFunctions[0].PPTID}}).Error; err != nil {
+		t.Fatalf("failed to seed VM: %v", err)
+	}
+	if err := svc.DeleteSRIOV(pf.ID); err == nil || !strings.Contains(err.Error(), "sriov_vf_in_use_by_vm") {
+		t.Fatalf("expected an assigned VF to block deletion, got %v", err)
+	}
+
+	configured = "0"
+	*calls = nil
+	if err := svc.RestoreSRIOV(); err != nil {
+		t.Fatalf("RestoreSRIOV returned error: %v", err)
+	}
+	if !strings.Contains(strings.Join(*calls, "\n"), "iovctl -C -f") {
+		t.Fatalf("expected VFs to be recreated after a reboot, calls=%v", *calls)
+	}
+
+	configured = "2"
+	*calls = nil
+	if err := svc.RestoreSRIOV(); err != nil {
+		t.Fatalf("RestoreSRIOV returned error: %v", err)
+	}
+	if strings.Contains(strings.Join(*calls, "\n"), "iovctl") {
+		t.Fatalf("expected existing VFs to be left alone, calls=%v", *calls)
+	}
+}
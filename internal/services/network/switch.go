@@ -11,9 +11,32 @@ package network
 import (
 	"fmt"
 
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
 	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	"github.com/alchemillahq/sylve/internal/logger"
 )
 
+// notifyJailsOnSwitches hands every jail with a network on one of the given
+// switches to the jail service, so their network config and managed /etc
+// files follow switch and DHCP changes.
+func (s *Service) notifyJailsOnSwitches(switchType string, switchIDs []uint) {
+	if s.OnJailObjectUpdate == nil || len(switchIDs) == 0 {
+		return
+	}
+
+	var jailIDs []uint
+	if err := s.DB.Model(&jailModels.Network{}).
+		Where("switch_id IN ? AND switch_type = ?", switchIDs, switchType).
+		Distinct().Pluck("jid", &jailIDs).Error; err != nil {
+		logger.L.Warn().Err(err).Str("switch_type", switchType).Msg("failed_to_list_jails_for_switch_update")
+		return
+	}
+
+	if len(jailIDs) > 0 {
+		s.OnJailObjectUpdate(jailIDs)
+	}
+}
+
 func (s *Service) GetBridgeNameByIDType(id uint, swType string) (string, error) {
 	if swType == "manual" {
 		var manualSwitches []networkModels.ManualSwitch
@@ -0,0 +1,264 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package network
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	networkServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/network"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const defaultSwitchMTU = 1500
+
+// jailEpairHostSide matches the host end of a jail epair, <ctidhash>_net<id>a.
+// The jail end has the same name ending in b and lives in the jail <ctidhash>.
+var jailEpairHostSide = regexp.MustCompile(`^([0-9a-zA-Z]+)_net[0-9]+a$`)
+
+func effectiveSwitchMTU(mtu int) int {
+	if mtu <= 0 {
+		return defaultSwitchMTU
+	}
+	return mtu
+}
+
+// checkPortHostInterfaceMTU rejects a switch MTU that disagrees with an MTU
+// saved for one of its uplinks, since the next host interface reconcile
+// would put the uplink back out of step with the bridge.
+func (s *Service) checkPortHostInterfaceMTU(ports []string, mtu int) error {
+	if len(ports) == 0 {
+		return nil
+	}
+
+	var configured []networkModels.HostInterface
+	if err := s.DB.Where("name IN ? AND mtu <> 0", ports).Find(&configured).Error; err != nil {
+		return fmt.Errorf("failed_to_check_host_interface_mtu: %w", err)
+	}
+
+	mtu = effectiveSwitchMTU(mtu)
+	for _, hi := range configured {
+		if hi.MTU != mtu {
+			return fmt.Errorf("switch_mtu_conflicts_with_host_interface: %s mtu=%d switch_mtu=%d", hi.Name, hi.MTU, mtu)
+		}
+	}
+	return nil
+}
+
+// GetSwitchMTUByIDType returns the MTU guests attached to a switch should
+// use: the configured MTU of a standard switch, or the live MTU of a manual
+// switch's bridge.
+func (s *Service) GetSwitchMTUByIDType(id uint, swType string) (int, error) {
+	switch swType {
+	case "standard":
+		var sw networkModels.StandardSwitch
+		if err := s.DB.First(&sw, id).Error; err != nil {
+			return 0, err
+		}
+		return effectiveSwitchMTU(sw.MTU), nil
+	case "manual":
+		var sw networkModels.ManualSwitch
+		if err := s.DB.First(&sw, id).Error; err != nil {
+			return 0, err
+		}
+		br, err := syncIfaceGet(sw.Bridge)
+		if err != nil {
+			return 0, err
+		}
+		return effectiveSwitchMTU(br.MTU), nil
+	}
+	return 0, fmt.Errorf("switch/bridge with ID %d not found", id)
+}
+
+func (s *Service) GetStandardSwitchMTUReport(id uint) (*networkServiceInterfaces.SwitchMTUReport, error) {
+	var sw networkModels.StandardSwitch
+	if err := s.DB.Preload("Ports").First(&sw, id).Error; err != nil {
+		return nil, err
+	}
+
+	report := &networkServiceInterfaces.SwitchMTUReport{
+		SwitchID:   sw.ID,
+		SwitchName: sw.Name,
+		BridgeName: sw.BridgeName,
+		MTU:        effectiveSwitchMTU(sw.MTU),
+		Members:    []networkServiceInterfaces.SwitchMTUMember{},
+		Mismatches: []string{},
+	}
+
+	var vmCount, jailCount int64
+	if err := s.DB.Model(&vmModels.Network{}).
+		Where("switch_id = ? AND switch_type = ?", sw.ID, "standard").
+		Distinct("vm_id").Count(&vmCount).Error; err != nil {
+		return nil, err
+	}
+	if err := s.DB.Model(&jailModels.Network{}).
+		Where("switch_id = ? AND switch_type = ?", sw.ID, "standard").
+		Distinct("jid").Count(&jailCount).Error; err != nil {
+		return nil, err
+	}
+	report.VMCount = int(vmCount)
+	report.JailCount = int(jailCount)
+
+	portNames := make([]string, 0, len(sw.Ports))
+	for _, p := range sw.Ports {
+		portNames = append(portNames, p.Name)
+	}
+	var configured []networkModels.HostInterface
+	if len(portNames) > 0 {
+		if err := s.DB.Where("name IN ?", portNames).Find(&configured).Error; err != nil {
+			return nil, err
+		}
+	}
+	configuredMTU := make(map[string]int, len(configured))
+	for _, hi := range configured {
+		configuredMTU[hi.Name] = hi.MTU
+	}
+
+	br, err := syncIfaceGet(sw.BridgeName)
+	if err != nil {
+		report.Mismatches = append(report.Mismatches, fmt.Sprintf("bridge_not_found: %s", sw.BridgeName))
+		return report, nil
+	}
+	report.BridgeMTU = br.MTU
+	if br.MTU != report.MTU {
+		report.Mismatches = append(report.Mismatches, fmt.Sprintf("bridge_mtu_mismatch: %s mtu=%d", sw.BridgeName, br.MTU))
+	}
+
+	for _, m := range br.BridgeMembers {
+		member := networkServiceInterfaces.SwitchMTUMember{Name: m.Name, Kind: "guest"}
+
+		parent := m.Name
+		if sw.VLAN > 0 {
+			parent = strings.TrimSuffix(m.Name, fmt.Sprintf(".%d", sw.VLAN))
+		}
+		switch {
+		case slices.Contains(portNames, parent):
+			member.Kind = "port"
+			member.ConfiguredMTU = configuredMTU[parent]
+		case jailEpairHostSide.MatchString(parent):
+			member.Kind = "jail"
+		}
+
+		if info, err := syncIfaceGet(m.Name); err == nil {
+			member.MTU = info.MTU
+		}
+		if member.MTU != report.MTU {
+			report.Mismatches = append(report.Mismatches, fmt.Sprintf("member_mtu_mismatch: %s mtu=%d", m.Name, member.MTU))
+		}
+		if member.ConfiguredMTU != 0 && member.ConfiguredMTU != report.MTU {
+			report.Mismatches = append(report.Mismatches, fmt.Sprintf("host_interface_mtu_mismatch: %s mtu=%d", parent, member.ConfiguredMTU))
+		}
+
+		report.Members = append(report.Members, member)
+	}
+
+	return report, nil
+}
+
+// PropagateStandardSwitchMTU sets one MTU on a switch and everything attached
+// to it: the bridge, its uplinks and their saved host interface config, and
+// the host and jail ends of guest interfaces. Jail configs are regenerated so
+// the MTU survives a restart; VM guests pick it up from the tap on their next
+// boot or DHCP renewal.
+func (s *Service) PropagateStandardSwitchMTU(id uint, mtu int) (*networkServiceInterfaces.SwitchMTUReport, error) {
+	if !utils.IsValidMTU(mtu) {
+		return nil, fmt.Errorf("invalid_mtu")
+	}
+
+	s.syncMutex.Lock()
+
+	var sw networkModels.StandardSwitch
+	if err := s.DB.Preload("Ports").First(&sw, id).Error; err != nil {
+		s.syncMutex.Unlock()
+		return nil, err
+	}
+
+	portNames := make([]string, 0, len(sw.Ports))
+	for _, p := range sw.Ports {
+		portNames = append(portNames, p.Name)
+	}
+
+	if err := s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&networkModels.StandardSwitch{}).Where("id = ?", sw.ID).Update("mtu", mtu).Error; err != nil {
+			return err
+		}
+		if len(portNames) > 0 {
+			if err := tx.Model(&networkModels.HostInterface{}).
+				Where("name IN ? AND mtu <> 0", portNames).
+				Update("mtu", mtu).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		s.syncMutex.Unlock()
+		return nil, fmt.Errorf("failed_to_save_switch_mtu: %w", err)
+	}
+
+	applyErr := applySwitchMTU(sw, portNames, mtu)
+	s.syncMutex.Unlock()
+	if applyErr != nil {
+		return nil, applyErr
+	}
+
+	s.notifyJailsOnSwitches("standard", []uint{sw.ID})
+
+	return s.GetStandardSwitchMTUReport(sw.ID)
+}
+
+func applySwitchMTU(sw networkModels.StandardSwitch, portNames []string, mtu int) error {
+	value := strconv.Itoa(mtu)
+
+	// Uplinks go first: a VLAN sub-interface cannot be raised above its
+	// parent, and the bridge cannot exceed its members.
+	for _, port := range portNames {
+		if _, err := syncRunCommand("/sbin/ifconfig", port, "mtu", value); err != nil {
+			return fmt.Errorf("failed_to_set_port_mtu_%s: %w", port, err)
+		}
+	}
+
+	br, err := syncIfaceGet(sw.BridgeName)
+	if err != nil {
+		return fmt.Errorf("failed_to_get_bridge_%s: %w", sw.BridgeName, err)
+	}
+
+	for _, m := range br.BridgeMembers {
+		if slices.Contains(portNames, m.Name) {
+			continue
+		}
+		if _, err := syncRunCommand("/sbin/ifconfig", m.Name, "mtu", value); err != nil {
+			return fmt.Errorf("failed_to_set_member_mtu_%s: %w", m.Name, err)
+		}
+
+		parent := m.Name
+		if sw.VLAN > 0 {
+			parent = strings.TrimSuffix(m.Name, fmt.Sprintf(".%d", sw.VLAN))
+		}
+		if match := jailEpairHostSide.FindStringSubmatch(parent); match != nil {
+			jailSide := strings.TrimSuffix(parent, "a") + "b"
+			if _, err := syncRunCommand("/sbin/ifconfig", "-j", match[1], jailSide, "mtu", value); err != nil {
+				logger.L.Warn().Err(err).Str("interface", jailSide).Msg("failed_to_set_jail_interface_mtu")
+			}
+		}
+	}
+
+	if _, err := syncRunCommand("/sbin/ifconfig", sw.BridgeName, "mtu", value); err != nil {
+		return fmt.Errorf("failed_to_set_bridge_mtu: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package network
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	iface "github.com/alchemillahq/sylve/pkg/network/iface"
+	"gorm.io/gorm"
+)
+
+func seedMTUSwitch(t *testing.T, db *gorm.DB) networkModels.StandardSwitch {
+	t.Helper()
+
+	sw := networkModels.StandardSwitch{Name: "lan", BridgeName: "bridge0", MTU: 1500}
+	if err := db.Create(&sw).Error; err != nil {
+		t.Fatalf("create switch: %v", err)
+	}
+	if err := db.Create(&networkModels.NetworkPort{Name: "em0", SwitchID: sw.ID}).Error; err != nil {
+		t.Fatalf("create port: %v", err)
+	}
+	if err := db.Create(&jailModels.Network{JailID: 7, Name: "net0", SwitchID: sw.ID, SwitchType: "standard"}).Error; err != nil {
+		t.Fatalf("create jail network: %v", err)
+	}
+	if err := db.Create(&vmModels.Network{VMID: 3, SwitchID: sw.ID, SwitchType: "standard"}).Error; err != nil {
+		t.Fatalf("create vm network: %v", err)
+	}
+	return sw
+}
+
+func stubMTUInterfaces(t *testing.T, mtus map[string]int) *[]string {
+	t.Helper()
+
+	var calls []string
+	stubSyncFunctions(t, syncStubSet{
+		ifaceGet: func(name string) (*iface.Interface, error) {
+			mtu, ok := mtus[name]
+			if !ok {
+				return nil, fmt.Errorf("no such interface")
+			}
+			info := &iface.Interface{Name: name, MTU: mtu}
+			if name == "bridge0" {
+				info.BridgeMembers = []iface.BridgeMember{{Name: "em0"}, {Name: "abc_net1a"}, {Name: "tap0"}}
+			}
+			return info, nil
+		},
+		runCommand: func(_ string, args ...string) (string, error) {
+			calls = append(calls, strings.Join(args, " "))
+			if len(args) == 3 && args[1] == "mtu" {
+				mtus[args[0]], _ = strconv.Atoi(args[2])
+			}
+			return "", nil
+		},
+	})
+	return &calls
+}
+
+func TestStandardSwitchMTUReportFlagsMismatches(t *testing.T) {
+	svc, db := newNetworkServiceForTest(t, &networkModels.StandardSwitch{}, &networkModels.NetworkPort{}, &jailModels.Network{}, &vmModels.Network{})
+	sw := seedMTUSwitch(t, db)
+	stubMTUInterfaces(t, map[string]int{"bridge0": 1500, "em0": 1500, "abc_net1a": 1500, "tap0": 9000})
+
+	report, err := svc.GetStandardSwitchMTUReport(sw.ID)
+	if err != nil {
+		t.Fatalf("unexpected report error: %v", err)
+	}
+	if report.VMCount != 1 || report.JailCount != 1 {
+		t.Fatalf("unexpected guest counts: %+v", report)
+	}
+
+	kinds := map[string]string{}
+	for _, m := range report.Members {
+		kinds[m.Name] = m.Kind
+	}
+	if kinds["em0"] != "port" || kinds["abc_net1a"] != "jail" || kinds["tap0"] != "guest" {
+		t.Fatalf("unexpected member kinds: %v", kinds)
+	}
+	if !slices.Equal(report.Mismatches, []string{"member_mtu_mismatch: tap0 mtu=9000"}) {
+		t.Fatalf("unexpected mismatches: %v", report.Mismatches)
+	}
+}
+
+func TestPropagateStandardSwitchMTUUpdatesEverything(t *testing.T) {
+	svc, db := newNetworkServiceForTest(t, &networkModels.StandardSwitch{}, &networkModels.NetworkPort{}, &jailModels.Network{}, &vmModels.Network{})
+	sw := seedMTUSwitch(t, db)
+	if err := db.Create(&networkModels.HostInterface{Name: "em0", MTU: 1500, Up: true}).Error; err != nil {
+		t.Fatalf("create host interface: %v", err)
+	}
+	calls := stubMTUInterfaces(t, map[string]int{"bridge0": 1500, "em0": 1500, "abc_net1a": 1500, "tap0": 1500})
+
+	var updatedJails []uint
+	svc.RegisterOnJailObjectUpdateCallback(func(ids []uint) { updatedJails = ids })
+
+	report, err := svc.PropagateStandardSwitchMTU(sw.ID, 9000)
+	if err != nil {
+		t.Fatalf("unexpected propagate error: %v", err)
+	}
+
+	want := []string{
+		"em0 mtu 9000",
+		"abc_net1a mtu 9000",
+		"-j abc abc_net1b mtu 9000",
+		"tap0 mtu 9000",
+		"bridge0 mtu 9000",
+	}
+	if !slices.Equal(*calls, want) {
+		t.Fatalf("unexpected commands:\n got=%v\nwant=%v", *calls, want)
+	}
+	if len(report.Mismatches) != 0 {
+		t.Fatalf("expected no mismatches after propagation, got %v", report.Mismatches)
+	}
+	if !slices.Equal(updatedJails, []uint{7}) {
+		t.Fatalf("expected jail 7 config to be regenerated, got %v", updatedJails)
+	}
+
+	var saved networkModels.StandardSwitch
+	db.First(&saved, sw.ID)
+	var hi networkModels.HostInterface
+	db.Where("name = ?", "em0").First(&hi)
+	if saved.MTU != 9000 || hi.MTU != 9000 {
+		t.Fatalf("expected switch and host interface MTU 9000, got %d and %d", saved.MTU, hi.MTU)
+	}
+
+	if _, err := svc.PropagateStandardSwitchMTU(sw.ID, 20); err == nil || err.Error() != "invalid_mtu" {
+		t.Fatalf("expected invalid_mtu, got %v", err)
+	}
+}
+
+func TestStandardSwitchRejectsMTUConflictingWithHostInterface(t *testing.T) {
+	svc, db := newNetworkServiceForTest(t)
+	if err := db.Create(&networkModels.HostInterface{Name: "em0", MTU: 9000, Up: true}).Error; err != nil {
+		t.Fatalf("create host interface: %v", err)
+	}
+
+	err := svc.checkPortHostInterfaceMTU([]string{"em0"}, 0)
+	if err == nil || !strings.HasPrefix(err.Error(), "switch_mtu_conflicts_with_host_interface") {
+		t.Fatalf("expected conflict, got %v", err)
+	}
+	if err := svc.checkPortHostInterfaceMTU([]string{"em0"}, 9000); err != nil {
+		t.Fatalf("unexpected error for matching mtu: %v", err)
+	}
+}
@@ -211,6 +211,10 @@ func (s *Service) NewStandardSwitch(
 		return fmt.Errorf("port_overlap: %s", strings.Join(msgs, ", "))
 	}
 
+	if err := s.checkPortHostInterfaceMTU(ports, mtu); err != nil {
+		return err
+	}
+
 	if network4Id != 0 {
 		var o4 networkModels.Object
 		if err := s.DB.Preload("Entries").First(&o4, network4Id).Error; err != nil {
@@ -444,6 +448,10 @@ func (s *Service) DeleteStandardSwitch(id int) error {
 		return fmt.Errorf("failed_to_delete_switch: %v", err)
 	}
 
+	if err := s.DB.Where("switch_id = ?", sw.ID).Delete(&networkModels.IsolationGroup{}).Error; err != nil {
+		return fmt.Errorf("failed_to_delete_isolation_groups: %v", err)
+	}
+
 	if err := s.DB.Where("switch_id = ?", id).
 		Delete(&networkModels.NetworkPort{}).Error; err != nil {
 		return fmt.Errorf("failed_to_delete_ports: %v", err)
@@ -505,6 +513,10 @@ func (s *Service) EditStandardSwitch(
 		return fmt.Errorf("port_overlap: %s", strings.Join(msgs, ", "))
 	}
 
+	if err := s.checkPortHostInterfaceMTU(ports, mtu); err != nil {
+		return err
+	}
+
 	if network4Id != 0 {
 		var o4 networkModels.Object
 		if err := s.DB.Preload("Entries").First(&o4, network4Id).Error; err != nil {
@@ -661,7 +673,12 @@ func (s *Service) EditStandardSwitch(
 		}
 	}
 
-	return s.SyncStandardSwitches(&before, "edit")
+	if err := s.SyncStandardSwitches(&before, "edit"); err != nil {
+		return err
+	}
+
+	s.notifyJailsOnSwitches("standard", []uint{id})
+	return nil
 }
 
 func (s *Service) SyncStandardSwitches(sw *networkModels.StandardSwitch, action string) error {
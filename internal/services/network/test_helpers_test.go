@@ -21,6 +21,7 @@ func newNetworkServiceTestDB(t *testing.T, migrateModels ...any) *gorm.DB {
 	models := append([]any{}, migrateModels...)
 	models = append(models,
 		&networkModels.ObjectListSnapshot{},
+		&networkModels.HostInterface{},
 		&infoModels.FirewallRuleDelta{},
 		&infoModels.FirewallRuleCounterTotal{},
 	)
@@ -16,6 +16,7 @@ import (
 	"time"
 
 	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
 	"github.com/alchemillahq/sylve/internal/logger"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 	"gorm.io/gorm"
@@ -123,10 +124,10 @@ func (s *Service) CreateWireGuardClient(req *WireGuardClientRequest) error {
 		EndpointHost:        strings.TrimSpace(req.EndpointHost),
 		EndpointPort:        req.EndpointPort,
 		ListenPort:          listenPort,
-		PrivateKey:          privateKey,
+		PrivateKey:          secrets.String(privateKey),
 		PublicKey:           publicKey,
 		PeerPublicKey:       peerPublicKey,
-		PreSharedKey:        preSharedKey,
+		PreSharedKey:        secrets.String(preSharedKey),
 		AllowedIPs:          allowedIPs,
 		Addresses:           addresses,
 		RouteAllowedIPs:     routeAllowedIPs,
@@ -183,7 +184,7 @@ func (s *Service) EditWireGuardClient(req *WireGuardClientRequest) error {
 		return err
 	}
 
-	client.PrivateKey = privateKey
+	client.PrivateKey = secrets.String(privateKey)
 	client.PublicKey = publicKey
 
 	if strings.TrimSpace(req.Name) != "" {
@@ -220,7 +221,7 @@ func (s *Service) EditWireGuardClient(req *WireGuardClientRequest) error {
 		if err := validateWireGuardPSK(preSharedKey); err != nil {
 			return err
 		}
-		client.PreSharedKey = preSharedKey
+		client.PreSharedKey = secrets.String(preSharedKey)
 	}
 
 	if req.AllowedIPs != nil {
@@ -371,8 +372,8 @@ func buildWireGuardClientPeer(client *networkModels.WireGuardClient) (wgtypes.Pe
 		ReplaceAllowedIPs: true,
 	}
 
-	if strings.TrimSpace(client.PreSharedKey) != "" {
-		preSharedKey, err := wgtypes.ParseKey(strings.TrimSpace(client.PreSharedKey))
+	if strings.TrimSpace(string(client.PreSharedKey)) != "" {
+		preSharedKey, err := wgtypes.ParseKey(strings.TrimSpace(string(client.PreSharedKey)))
 		if err != nil {
 			return wgtypes.PeerConfig{}, fmt.Errorf("invalid_wireguard_preshared_key: %w", err)
 		}
@@ -429,7 +430,7 @@ func (s *Service) applyWireGuardClientRuntime(client *networkModels.WireGuardCli
 		return err
 	}
 
-	if err = configureWireGuardDevice(interfaceName, client.PrivateKey, client.ListenPort, []wgtypes.PeerConfig{peerConfig}); err != nil {
+	if err = configureWireGuardDevice(interfaceName, string(client.PrivateKey), client.ListenPort, []wgtypes.PeerConfig{peerConfig}); err != nil {
 		return err
 	}
 
@@ -15,6 +15,7 @@ import (
 
 	"github.com/alchemillahq/sylve/internal/db/models"
 	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 	"gorm.io/gorm"
 )
@@ -73,7 +74,7 @@ func TestEditWireGuardClientRequiresPrivateKey(t *testing.T) {
 		Name:          "client-edit-no-key",
 		EndpointHost:  "198.51.100.10",
 		EndpointPort:  51820,
-		PrivateKey:    privateKey.String(),
+		PrivateKey:    secrets.String(privateKey.String()),
 		PublicKey:     privateKey.PublicKey().String(),
 		PeerPublicKey: mustGenerateWireGuardPrivateKey(t).PublicKey().String(),
 		AllowedIPs:    []string{"10.10.0.0/16"},
@@ -102,7 +103,7 @@ func TestEditWireGuardClientRejectsInvalidPrivateKey(t *testing.T) {
 		Name:          "client-edit-invalid-key",
 		EndpointHost:  "198.51.100.10",
 		EndpointPort:  51820,
-		PrivateKey:    privateKey.String(),
+		PrivateKey:    secrets.String(privateKey.String()),
 		PublicKey:     privateKey.PublicKey().String(),
 		PeerPublicKey: mustGenerateWireGuardPrivateKey(t).PublicKey().String(),
 		AllowedIPs:    []string{"10.10.0.0/16"},
@@ -152,7 +153,7 @@ func TestCreateWireGuardClientUsesProvidedPrivateKey(t *testing.T) {
 		t.Fatalf("failed to load created client: %v", err)
 	}
 
-	if created.PrivateKey != privateKey.String() {
+	if string(created.PrivateKey) != privateKey.String() {
 		t.Fatalf("expected private key to be stored as provided")
 	}
 	if created.PublicKey != privateKey.PublicKey().String() {
@@ -205,7 +206,7 @@ func TestEditWireGuardClientUpdatesDerivedPublicKey(t *testing.T) {
 		Name:          "client-key-rotate",
 		EndpointHost:  "198.51.100.12",
 		EndpointPort:  51820,
-		PrivateKey:    oldPrivateKey.String(),
+		PrivateKey:    secrets.String(oldPrivateKey.String()),
 		PublicKey:     oldPrivateKey.PublicKey().String(),
 		PeerPublicKey: mustGenerateWireGuardPrivateKey(t).PublicKey().String(),
 		AllowedIPs:    []string{},
@@ -229,7 +230,7 @@ func TestEditWireGuardClientUpdatesDerivedPublicKey(t *testing.T) {
 		t.Fatalf("failed to load updated client: %v", err)
 	}
 
-	if updated.PrivateKey != newPrivateKey.String() {
+	if string(updated.PrivateKey) != newPrivateKey.String() {
 		t.Fatalf("expected updated private key to match request")
 	}
 	if updated.PublicKey != newPrivateKey.PublicKey().String() {
@@ -248,7 +249,7 @@ func TestEditWireGuardClientUpdatesFIB(t *testing.T) {
 		Name:          "client-fib-edit",
 		EndpointHost:  "198.51.100.12",
 		EndpointPort:  51820,
-		PrivateKey:    privateKey.String(),
+		PrivateKey:    secrets.String(privateKey.String()),
 		PublicKey:     privateKey.PublicKey().String(),
 		PeerPublicKey: mustGenerateWireGuardPrivateKey(t).PublicKey().String(),
 		AllowedIPs:    []string{},
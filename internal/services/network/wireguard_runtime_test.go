@@ -18,6 +18,7 @@ import (
 	"time"
 
 	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 )
 
@@ -411,7 +412,7 @@ func TestApplyWireGuardServerRuntimeFailureRollsBackInterface(t *testing.T) {
 		Enabled:    true,
 		Port:       51820,
 		Addresses:  []string{"10.210.0.1/24"},
-		PrivateKey: mustGenerateWireGuardPrivateKey(t).String(),
+		PrivateKey: secrets.String(mustGenerateWireGuardPrivateKey(t).String()),
 	}
 
 	svc := &Service{}
@@ -452,7 +453,7 @@ func TestApplyWireGuardClientRuntimeFailureRollsBackInterface(t *testing.T) {
 		Enabled:       true,
 		EndpointHost:  "198.51.100.10",
 		EndpointPort:  51820,
-		PrivateKey:    mustGenerateWireGuardPrivateKey(t).String(),
+		PrivateKey:    secrets.String(mustGenerateWireGuardPrivateKey(t).String()),
 		PeerPublicKey: mustGenerateWireGuardPrivateKey(t).PublicKey().String(),
 		AllowedIPs:    []string{"10.10.0.0/16"},
 		Addresses:     []string{"10.210.1.2/32"},
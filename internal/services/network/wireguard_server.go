@@ -16,6 +16,7 @@ import (
 	"time"
 
 	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
 	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/pkg/utils"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
@@ -433,7 +434,7 @@ func (s *Service) InitWireGuardServer(req *InitWireGuardServerRequest) error {
 		Enabled:                 true,
 		Port:                    req.Port,
 		Addresses:               addresses,
-		PrivateKey:              privateKey,
+		PrivateKey:              secrets.String(privateKey),
 		PublicKey:               publicKey,
 		MTU:                     mtu,
 		AllowWireGuardPort:      req.AllowWireGuardPort,
@@ -486,10 +487,10 @@ func (s *Service) EditWireGuardServer(req InitWireGuardServerRequest) error {
 		if _, parseErr := wgtypes.ParseKey(provided); parseErr != nil {
 			return fmt.Errorf("wireguard_invalid_private_key: %w", parseErr)
 		}
-		privateKey = provided
+		privateKey = secrets.String(provided)
 	}
 
-	publicKey, err := wireGuardPublicKeyFromPrivate(privateKey)
+	publicKey, err := wireGuardPublicKeyFromPrivate(string(privateKey))
 	if err != nil {
 		return err
 	}
@@ -697,9 +698,9 @@ func (s *Service) AddWireGuardServerPeer(req WireGuardServerPeerRequest) error {
 		Name:                req.Name,
 		Enabled:             enabled,
 		WireGuardServerID:   server.ID,
-		PrivateKey:          privateKey,
+		PrivateKey:          secrets.String(privateKey),
 		PublicKey:           publicKey,
-		PreSharedKey:        preSharedKey,
+		PreSharedKey:        secrets.String(preSharedKey),
 		ClientIPs:           clientIPs,
 		RoutableIPs:         routableIPs,
 		RouteIPs:            routeIPs,
@@ -758,7 +759,7 @@ func (s *Service) EditWireGuardServerPeer(req WireGuardServerPeerRequest) error
 		if err := validateWireGuardPSK(preSharedKey); err != nil {
 			return err
 		}
-		peer.PreSharedKey = preSharedKey
+		peer.PreSharedKey = secrets.String(preSharedKey)
 	}
 
 	if req.ClientIPs != nil {
@@ -793,7 +794,7 @@ func (s *Service) EditWireGuardServerPeer(req WireGuardServerPeerRequest) error
 		if pkErr != nil {
 			return pkErr
 		}
-		peer.PrivateKey = provided
+		peer.PrivateKey = secrets.String(provided)
 		peer.PublicKey = newPublicKey
 	}
 
@@ -963,8 +964,8 @@ func buildWireGuardServerPeers(peers []networkModels.WireGuardServerPeer) ([]wgt
 			ReplaceAllowedIPs: true,
 		}
 
-		if strings.TrimSpace(peer.PreSharedKey) != "" {
-			preSharedKey, err := wgtypes.ParseKey(strings.TrimSpace(peer.PreSharedKey))
+		if strings.TrimSpace(string(peer.PreSharedKey)) != "" {
+			preSharedKey, err := wgtypes.ParseKey(strings.TrimSpace(string(peer.PreSharedKey)))
 			if err != nil {
 				return nil, fmt.Errorf("invalid_wireguard_peer_preshared_key_%d: %w", peer.ID, err)
 			}
@@ -1023,7 +1024,7 @@ func (s *Service) applyWireGuardServerRuntime(server *networkModels.WireGuardSer
 		return err
 	}
 
-	if err = configureWireGuardDevice(wireGuardServerInterfaceName, server.PrivateKey, server.Port, peerConfigs); err != nil {
+	if err = configureWireGuardDevice(wireGuardServerInterfaceName, string(server.PrivateKey), server.Port, peerConfigs); err != nil {
 		return err
 	}
 
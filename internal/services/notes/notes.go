@@ -0,0 +1,369 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package notes
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alchemillahq/sylve/internal/config"
+	"github.com/alchemillahq/sylve/internal/db/models"
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	"github.com/alchemillahq/sylve/internal/logger"
+
+	"gorm.io/gorm"
+)
+
+const (
+	GuestTypeVM   = "vm"
+	GuestTypeJail = "jail"
+
+	MaxNoteBytes           = 64 * 1024
+	MaxAttachmentBytes     = 5 * 1024 * 1024
+	MaxAttachmentsPerGuest = 20
+	// Older revisions beyond this are dropped on save.
+	MaxRevisionsPerGuest = 100
+)
+
+type Service struct {
+	DB *gorm.DB
+
+	rootDir func() (string, error)
+}
+
+// GuestNotes is what the guest detail API returns for a VM or jail.
+type GuestNotes struct {
+	Note        models.GuestNote             `json:"note"`
+	Attachments []models.GuestNoteAttachment `json:"attachments"`
+}
+
+func NewService(db *gorm.DB) *Service {
+	return &Service{
+		DB: db,
+		rootDir: func() (string, error) {
+			dataPath, err := config.GetDataPath()
+			if err != nil {
+				return "", err
+			}
+			return filepath.Join(dataPath, "notes"), nil
+		},
+	}
+}
+
+func (s *Service) requireGuest(guestType string, guestID uint) error {
+	if guestID == 0 {
+		return fmt.Errorf("invalid_guest_id")
+	}
+
+	var count int64
+	switch guestType {
+	case GuestTypeVM:
+		if err := s.DB.Model(&vmModels.VM{}).Where("rid = ?", guestID).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed_to_find_vm: %w", err)
+		}
+		if count == 0 {
+			return fmt.Errorf("vm_not_found: %d", guestID)
+		}
+	case GuestTypeJail:
+		if err := s.DB.Model(&jailModels.Jail{}).Where("ct_id = ?", guestID).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed_to_find_jail: %w", err)
+		}
+		if count == 0 {
+			return fmt.Errorf("jail_not_found: %d", guestID)
+		}
+	default:
+		return fmt.Errorf("invalid_guest_type: %s", guestType)
+	}
+
+	return nil
+}
+
+func (s *Service) attachmentDir(guestType string, guestID uint) (string, error) {
+	root, err := s.rootDir()
+	if err != nil {
+		return "", fmt.Errorf("failed_to_get_notes_path: %w", err)
+	}
+	return filepath.Join(root, guestType, strconv.FormatUint(uint64(guestID), 10)), nil
+}
+
+func (s *Service) GetNotes(guestType string, guestID uint) (GuestNotes, error) {
+	out := GuestNotes{Attachments: []models.GuestNoteAttachment{}}
+	if err := s.requireGuest(guestType, guestID); err != nil {
+		return out, err
+	}
+
+	if err := s.DB.Where("guest_type = ? AND guest_id = ?", guestType, guestID).
+		Limit(1).Find(&out.Note).Error; err != nil {
+		return out, fmt.Errorf("failed_to_get_note: %w", err)
+	}
+	out.Note.GuestType = guestType
+	out.Note.GuestID = guestID
+
+	if err := s.DB.Where("guest_type = ? AND guest_id = ?", guestType, guestID).
+		Order("id ASC").Find(&out.Attachments).Error; err != nil {
+		return out, fmt.Errorf("failed_to_list_attachments: %w", err)
+	}
+
+	return out, nil
+}
+
+// SaveNote replaces the notes body. baseRevision is the revision the editor
+// started from; a mismatch means someone else saved in between and the call
+// is rejected rather than silently overwriting their text.
+func (s *Service) SaveNote(
+	guestType string,
+	guestID uint,
+	body string,
+	baseRevision int,
+	author string,
+) (models.GuestNote, error) {
+	var note models.GuestNote
+	if err := s.requireGuest(guestType, guestID); err != nil {
+		return note, err
+	}
+	if len(body) > MaxNoteBytes {
+		return note, fmt.Errorf("note_too_large: max %d bytes", MaxNoteBytes)
+	}
+	if !utf8.ValidString(body) {
+		return note, fmt.Errorf("invalid_note_encoding")
+	}
+
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("guest_type = ? AND guest_id = ?", guestType, guestID).
+			Limit(1).Find(&note).Error; err != nil {
+			return fmt.Errorf("failed_to_get_note: %w", err)
+		}
+		if note.Revision != baseRevision {
+			return fmt.Errorf("note_revision_conflict: current %d, base %d", note.Revision, baseRevision)
+		}
+
+		note.GuestType = guestType
+		note.GuestID = guestID
+		note.Body = body
+		note.Revision++
+		note.UpdatedBy = author
+		if err := tx.Save(&note).Error; err != nil {
+			return fmt.Errorf("failed_to_save_note: %w", err)
+		}
+
+		if err := tx.Create(&models.GuestNoteRevision{
+			GuestType: guestType,
+			GuestID:   guestID,
+			Revision:  note.Revision,
+			Body:      body,
+			Author:    author,
+		}).Error; err != nil {
+			return fmt.Errorf("failed_to_save_note_revision: %w", err)
+		}
+
+		if note.Revision > MaxRevisionsPerGuest {
+			if err := tx.Where("guest_type = ? AND guest_id = ? AND revision <= ?",
+				guestType, guestID, note.Revision-MaxRevisionsPerGuest).
+				Delete(&models.GuestNoteRevision{}).Error; err != nil {
+				return fmt.Errorf("failed_to_trim_note_revisions: %w", err)
+			}
+		}
+
+		return nil
+	})
+
+	return note, err
+}
+
+func (s *Service) ListRevisions(guestType string, guestID uint) ([]models.GuestNoteRevision, error) {
+	if err := s.requireGuest(guestType, guestID); err != nil {
+		return nil, err
+	}
+
+	revisions := []models.GuestNoteRevision{}
+	if err := s.DB.Where("guest_type = ? AND guest_id = ?", guestType, guestID).
+		Order("revision DESC").Find(&revisions).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_list_note_revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func sanitizeAttachmentName(name string) (string, error) {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("invalid_attachment_name")
+	}
+	if len(name) > 255 || !utf8.ValidString(name) {
+		return "", fmt.Errorf("invalid_attachment_name")
+	}
+	return name, nil
+}
+
+// AddAttachment stores r under the guest's notes directory. The file is
+// written to a temporary name first and only kept once the record exists.
+func (s *Service) AddAttachment(
+	guestType string,
+	guestID uint,
+	name string,
+	r io.Reader,
+	uploadedBy string,
+) (models.GuestNoteAttachment, error) {
+	var attachment models.GuestNoteAttachment
+	if err := s.requireGuest(guestType, guestID); err != nil {
+		return attachment, err
+	}
+
+	name, err := sanitizeAttachmentName(name)
+	if err != nil {
+		return attachment, err
+	}
+
+	var count int64
+	if err := s.DB.Model(&models.GuestNoteAttachment{}).
+		Where("guest_type = ? AND guest_id = ?", guestType, guestID).
+		Count(&count).Error; err != nil {
+		return attachment, fmt.Errorf("failed_to_count_attachments: %w", err)
+	}
+	if count >= MaxAttachmentsPerGuest {
+		return attachment, fmt.Errorf("too_many_attachments: max %d", MaxAttachmentsPerGuest)
+	}
+
+	dir, err := s.attachmentDir(guestType, guestID)
+	if err != nil {
+		return attachment, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return attachment, fmt.Errorf("failed_to_create_attachment_dir: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".upload-*")
+	if err != nil {
+		return attachment, fmt.Errorf("failed_to_create_attachment_file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	keep := false
+	defer func() {
+		if !keep {
+			_ = os.Remove(tmpPath)
+		}
+	}()
+
+	hasher := sha256.New()
+	size, copyErr := io.Copy(io.MultiWriter(tmp, hasher), io.LimitReader(r, MaxAttachmentBytes+1))
+	closeErr := tmp.Close()
+	if copyErr != nil {
+		return attachment, fmt.Errorf("failed_to_write_attachment: %w", copyErr)
+	}
+	if closeErr != nil {
+		return attachment, fmt.Errorf("failed_to_write_attachment: %w", closeErr)
+	}
+	if size > MaxAttachmentBytes {
+		return attachment, fmt.Errorf("attachment_too_large: max %d bytes", MaxAttachmentBytes)
+	}
+
+	attachment = models.GuestNoteAttachment{
+		GuestType:  guestType,
+		GuestID:    guestID,
+		Name:       name,
+		Size:       size,
+		SHA256:     hex.EncodeToString(hasher.Sum(nil)),
+		Path:       tmpPath,
+		UploadedBy: uploadedBy,
+	}
+
+	err = s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&attachment).Error; err != nil {
+			return fmt.Errorf("failed_to_create_attachment: %w", err)
+		}
+
+		finalPath := filepath.Join(dir, strconv.FormatUint(uint64(attachment.ID), 10))
+		if err := os.Rename(tmpPath, finalPath); err != nil {
+			return fmt.Errorf("failed_to_store_attachment: %w", err)
+		}
+		attachment.Path = finalPath
+
+		if err := tx.Model(&attachment).Update("path", finalPath).Error; err != nil {
+			_ = os.Rename(finalPath, tmpPath)
+			return fmt.Errorf("failed_to_update_attachment: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.GuestNoteAttachment{}, err
+	}
+
+	keep = true
+	return attachment, nil
+}
+
+func (s *Service) GetAttachment(guestType string, guestID uint, id uint) (models.GuestNoteAttachment, error) {
+	var attachment models.GuestNoteAttachment
+	if err := s.DB.Where("id = ? AND guest_type = ? AND guest_id = ?", id, guestType, guestID).
+		First(&attachment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return attachment, fmt.Errorf("attachment_not_found")
+		}
+		return attachment, fmt.Errorf("failed_to_get_attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+func (s *Service) DeleteAttachment(guestType string, guestID uint, id uint) error {
+	attachment, err := s.GetAttachment(guestType, guestID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.DB.Delete(&attachment).Error; err != nil {
+		return fmt.Errorf("failed_to_delete_attachment: %w", err)
+	}
+	if err := os.Remove(attachment.Path); err != nil && !os.IsNotExist(err) {
+		logger.L.Warn().Err(err).Uint("attachment_id", id).Msg("failed_to_remove_attachment_file")
+	}
+
+	return nil
+}
+
+// PruneOrphans drops notes, revisions and attachments of guests that no
+// longer exist on this node.
+func (s *Service) PruneOrphans() error {
+	orphanFilter := func(tx *gorm.DB) *gorm.DB {
+		return tx.Where(
+			"(guest_type = ? AND guest_id NOT IN (?)) OR (guest_type = ? AND guest_id NOT IN (?))",
+			GuestTypeVM, s.DB.Model(&vmModels.VM{}).Select("rid"),
+			GuestTypeJail, s.DB.Model(&jailModels.Jail{}).Select("ct_id"),
+		)
+	}
+
+	var attachments []models.GuestNoteAttachment
+	if err := orphanFilter(s.DB).Find(&attachments).Error; err != nil {
+		return fmt.Errorf("failed_to_find_orphan_attachments: %w", err)
+	}
+
+	for _, model := range []any{&models.GuestNote{}, &models.GuestNoteRevision{}, &models.GuestNoteAttachment{}} {
+		if err := orphanFilter(s.DB).Delete(model).Error; err != nil {
+			return fmt.Errorf("failed_to_prune_orphan_notes: %w", err)
+		}
+	}
+
+	for _, attachment := range attachments {
+		if err := os.Remove(attachment.Path); err != nil && !os.IsNotExist(err) {
+			logger.L.Warn().Err(err).Uint("attachment_id", attachment.ID).Msg("failed_to_remove_orphan_attachment_file")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package notes
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/alchemillahq/sylve/internal/db/models"
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	"github.com/alchemillahq/sylve/internal/testutil"
+)
+
+func newNotesTestService(t *testing.T) *Service {
+	t.Helper()
+
+	db := testutil.NewSQLiteTestDB(t,
+		&vmModels.VM{},
+		&jailModels.Jail{},
+		&models.GuestNote{},
+		&models.GuestNoteRevision{},
+		&models.GuestNoteAttachment{},
+	)
+
+	root := t.TempDir()
+	return &Service{
+		DB:      db,
+		rootDir: func() (string, error) { return root, nil },
+	}
+}
+
+func TestSaveNoteKeepsRevisionsAndRejectsStaleBase(t *testing.T) {
+	svc := newNotesTestService(t)
+	if err := svc.DB.Create(&vmModels.VM{Name: "web", RID: 101}).Error; err != nil {
+		t.Fatalf("seed vm: %v", err)
+	}
+
+	first, err := svc.SaveNote(GuestTypeVM, 101, "# Runbook\nrestart nginx", 0, "alice")
+	if err != nil {
+		t.Fatalf("first save: %v", err)
+	}
+	if first.Revision != 1 {
+		t.Fatalf("first revision = %d, want 1", first.Revision)
+	}
+
+	if _, err := svc.SaveNote(GuestTypeVM, 101, "overwrite", 0, "bob"); err == nil ||
+		!strings.HasPrefix(err.Error(), "note_revision_conflict") {
+		t.Fatalf("stale save error = %v, want note_revision_conflict", err)
+	}
+
+	if _, err := svc.SaveNote(GuestTypeVM, 101, "# Runbook\nrestart nginx and php-fpm", 1, "bob"); err != nil {
+		t.Fatalf("second save: %v", err)
+	}
+
+	revisions, err := svc.ListRevisions(GuestTypeVM, 101)
+	if err != nil {
+		t.Fatalf("ListRevisions: %v", err)
+	}
+	if len(revisions) != 2 || revisions[0].Revision != 2 || revisions[1].Author != "alice" {
+		t.Fatalf("revisions = %+v", revisions)
+	}
+
+	if _, err := svc.SaveNote(GuestTypeJail, 101, "nope", 0, "alice"); err == nil ||
+		!strings.HasPrefix(err.Error(), "jail_not_found") {
+		t.Fatalf("missing jail error = %v", err)
+	}
+}
+
+func TestAttachmentsAreStoredAndPrunedWithGuest(t *testing.T) {
+	svc := newNotesTestService(t)
+	if err := svc.DB.Create(&jailModels.Jail{Name: "db", CTID: 202, Type: jailModels.JailTypeFreeBSD}).Error; err != nil {
+		t.Fatalf("seed jail: %v", err)
+	}
+
+	if _, err := svc.AddAttachment(GuestTypeJail, 202, "../../etc/passwd", bytes.NewReader([]byte("x")), "alice"); err != nil {
+		t.Fatalf("AddAttachment with path components: %v", err)
+	}
+	if _, err := svc.AddAttachment(GuestTypeJail, 202, ".hidden", bytes.NewReader([]byte("x")), "alice"); err == nil {
+		t.Fatal("expected dotfile name to be rejected")
+	}
+	big := bytes.NewReader(make([]byte, MaxAttachmentBytes+1))
+	if _, err := svc.AddAttachment(GuestTypeJail, 202, "big.bin", big, "alice"); err == nil ||
+		!strings.HasPrefix(err.Error(), "attachment_too_large") {
+		t.Fatalf("oversized attachment error = %v", err)
+	}
+
+	out, err := svc.GetNotes(GuestTypeJail, 202)
+	if err != nil {
+		t.Fatalf("GetNotes: %v", err)
+	}
+	if len(out.Attachments) != 1 || out.Attachments[0].Name != "passwd" {
+		t.Fatalf("attachments = %+v", out.Attachments)
+	}
+
+	stored, err := svc.GetAttachment(GuestTypeJail, 202, out.Attachments[0].ID)
+	if err != nil {
+		t.Fatalf("GetAttachment: %v", err)
+	}
+	if data, err := os.ReadFile(stored.Path); err != nil || string(data) != "x" {
+		t.Fatalf("stored attachment = %q, %v", data, err)
+	}
+
+	if err := svc.DB.Where("ct_id = ?", 202).Delete(&jailModels.Jail{}).Error; err != nil {
+		t.Fatalf("delete jail: %v", err)
+	}
+	if err := svc.PruneOrphans(); err != nil {
+		t.Fatalf("PruneOrphans: %v", err)
+	}
+
+	var count int64
+	svc.DB.Model(&models.GuestNoteAttachment{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("attachments after prune = %d, want 0", count)
+	}
+	if _, err := os.Stat(stored.Path); !os.IsNotExist(err) {
+		t.Fatalf("attachment file should be removed, stat err = %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package notifications
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/db/models"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	notifier "github.com/alchemillahq/sylve/internal/notifications"
+)
+
+func TestEmitIsSilencedDuringMaintenance(t *testing.T) {
+	svc := newTestService(t)
+	now := time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	if err := svc.DB.Create(&[]clusterModels.MaintenanceWindow{
+		{ID: 1, Scope: clusterModels.MaintenanceScopeNode, NodeID: "node-test", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+		{ID: 2, Scope: clusterModels.MaintenanceScopePolicy, PolicyID: 5, StartsAt: now.Add(-time.Hour), EndsAt: now.Add(-time.Minute)},
+	}).Error; err != nil {
+		t.Fatalf("failed to seed windows: %v", err)
+	}
+
+	input := notifier.EventInput{
+		Kind:        "system.zfs.pool_state.tank",
+		Title:       "Pool tank degraded",
+		Fingerprint: "tank-degraded",
+	}
+	for i := 0; i < 2; i++ {
+		result, err := svc.Emit(context.Background(), input)
+		if err != nil || !result.Suppressed || result.NotificationID != 0 {
+			t.Fatalf("expected emit %d to be silenced, got %+v (%v)", i, result, err)
+		}
+	}
+
+	var count int64
+	svc.DB.Model(&models.Notification{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("expected no notifications during maintenance, got %d", count)
+	}
+
+	var audit []clusterModels.MaintenanceSuppression
+	svc.DB.Find(&audit)
+	if len(audit) != 1 || audit[0].WindowID != 1 || audit[0].Key != input.Kind ||
+		audit[0].Action != clusterModels.MaintenanceActionNotification || audit[0].Occurrences != 2 {
+		t.Fatalf("expected one audit row for both emits, got %+v", audit)
+	}
+
+	test := input
+	test.Fingerprint = "tank-test"
+	test.Metadata = map[string]string{"test": "true"}
+	if result, err := svc.Emit(context.Background(), test); err != nil || result.Suppressed || result.NotificationID == 0 {
+		t.Fatalf("expected test events to bypass maintenance, got %+v (%v)", result, err)
+	}
+
+	svc.nodeID = func() string { return "node-other" }
+	policy := notifier.EventInput{
+		Kind:        "replication.policy.5",
+		Title:       "Replication lagging",
+		Fingerprint: "policy-5-lag",
+		Metadata:    map[string]string{"policy_id": "5"},
+	}
+	if result, err := svc.Emit(context.Background(), policy); err != nil || result.Suppressed {
+		t.Fatalf("expected an expired window not to apply, got %+v (%v)", result, err)
+	}
+
+	remote := notifier.EventInput{
+		Kind:        "cluster.clock.node-test",
+		Title:       "Clock skew",
+		Fingerprint: "clock-node-test",
+		Metadata:    map[string]string{"node_uuid": "node-test"},
+	}
+	if result, err := svc.Emit(context.Background(), remote); err != nil || !result.Suppressed {
+		t.Fatalf("expected an alert about a node in maintenance to be silenced, got %+v (%v)", result, err)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/alchemillahq/sylve/internal/db/models"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
 	hub "github.com/alchemillahq/sylve/internal/events"
 	diskServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/disk"
 	"github.com/alchemillahq/sylve/internal/logger"
@@ -97,6 +98,7 @@ type Service struct {
 	DiskService diskServiceInterfaces.DiskServiceInterface
 	httpClient  *http.Client
 	now         func() time.Time
+	nodeID      func() string
 
 	ntfySender    NtfySender
 	emailSender   EmailSender
@@ -273,7 +275,8 @@ func NewService(db *gorm.DB) *Service {
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		now: time.Now,
+		now:    time.Now,
+		nodeID: localNodeID,
 	}
 
 	s.ntfySender = s.sendNtfy
@@ -406,6 +409,11 @@ func (s *Service) Emit(ctx context.Context, input notifier.EventInput) (notifier
 
 	now := s.now().UTC()
 
+	if window := s.maintenanceWindowFor(ctx, normalized, now); window != nil {
+		s.recordMaintenanceSuppression(ctx, window, normalized, now)
+		return notifier.EmitResult{Suppressed: true}, nil
+	}
+
 	result := notifier.EmitResult{}
 	var kindRule models.NotificationKindRule
 	canSuppress := shouldPersistSuppressionForKind(normalized.Kind)
@@ -532,7 +540,7 @@ func (s *Service) Emit(ctx context.Context, input notifier.EventInput) (notifier
 			if !ntfySelected || !cfg.NtfyEnabled || !kindRule.NtfyEnabled {
 				continue
 			}
-			token := strings.TrimSpace(cfg.NtfyAuthToken)
+			token := strings.TrimSpace(string(cfg.NtfyAuthToken))
 			result.AttemptedNtfy = true
 			if err := s.ntfySender(ctx, cfg, normalized, token); err == nil {
 				result.SentNtfy = true
@@ -544,7 +552,7 @@ func (s *Service) Emit(ctx context.Context, input notifier.EventInput) (notifier
 			if !emailSelected || !cfg.EmailEnabled || !kindRule.EmailEnabled || len(cfg.EmailRecipients) == 0 {
 				continue
 			}
-			password := strings.TrimSpace(cfg.SMTPPassword)
+			password := strings.TrimSpace(string(cfg.SMTPPassword))
 			result.AttemptedEmail = true
 			if err := s.emailSender(ctx, cfg, normalized, password); err == nil {
 				result.SentEmail = true
@@ -852,10 +860,10 @@ func (s *Service) TestTransport(ctx context.Context, id uint) error {
 
 	switch normalizeTransportType(cfg.Type) {
 	case TransportTypeNtfy:
-		token := strings.TrimSpace(cfg.NtfyAuthToken)
+		token := strings.TrimSpace(string(cfg.NtfyAuthToken))
 		return s.ntfySender(ctx, cfg, input, token)
 	case TransportTypeSMTP:
-		password := strings.TrimSpace(cfg.SMTPPassword)
+		password := strings.TrimSpace(string(cfg.SMTPPassword))
 		return s.emailSender(ctx, cfg, input, password)
 	case TransportTypeDiscord:
 		webhookURL := strings.TrimSpace(cfg.DiscordWebhookURL)
@@ -1122,7 +1130,7 @@ func (s *Service) UpdateTransportConfig(ctx context.Context, input TransportConf
 				cfg.DiscordWebhookURL = ""
 
 				if entry.Ntfy.AuthToken != nil {
-					cfg.NtfyAuthToken = strings.TrimSpace(*entry.Ntfy.AuthToken)
+					cfg.NtfyAuthToken = secrets.String(strings.TrimSpace(*entry.Ntfy.AuthToken))
 				}
 			case TransportTypeSMTP:
 				if entry.Email == nil {
@@ -1154,7 +1162,7 @@ func (s *Service) UpdateTransportConfig(ctx context.Context, input TransportConf
 				cfg.DiscordWebhookURL = ""
 
 				if entry.Email.SMTPPassword != nil {
-					cfg.SMTPPassword = strings.TrimSpace(*entry.Email.SMTPPassword)
+					cfg.SMTPPassword = secrets.String(strings.TrimSpace(*entry.Email.SMTPPassword))
 				}
 			case TransportTypeDiscord:
 				if entry.Discord == nil {
@@ -2669,7 +2677,7 @@ func (s *Service) toNtfyTransportConfigView(cfg models.NotificationTransportConf
 	return NtfyTransportConfigView{
 		BaseURL:      normalizeNtfyBaseURL(cfg.NtfyBaseURL),
 		Topic:        strings.TrimSpace(cfg.NtfyTopic),
-		HasAuthToken: strings.TrimSpace(cfg.NtfyAuthToken) != "",
+		HasAuthToken: strings.TrimSpace(string(cfg.NtfyAuthToken)) != "",
 	}
 }
 
@@ -2681,7 +2689,7 @@ func (s *Service) toEmailTransportConfigView(cfg models.NotificationTransportCon
 		SMTPFrom:     strings.TrimSpace(cfg.SMTPFrom),
 		SMTPUseTLS:   cfg.SMTPUseTLS,
 		Recipients:   append([]string{}, cfg.EmailRecipients...),
-		HasPassword:  strings.TrimSpace(cfg.SMTPPassword) != "",
+		HasPassword:  strings.TrimSpace(string(cfg.SMTPPassword)) != "",
 	}
 }
 
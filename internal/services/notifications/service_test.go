@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/alchemillahq/sylve/internal/db/models"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
 	diskServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/disk"
 	notifier "github.com/alchemillahq/sylve/internal/notifications"
 	"github.com/alchemillahq/sylve/internal/testutil"
@@ -32,9 +33,12 @@ func newTestService(t *testing.T) *Service {
 		&models.NotificationTransportConfig{},
 		&models.BasicSettings{},
 		&models.SystemSecrets{},
+		&clusterModels.MaintenanceWindow{},
+		&clusterModels.MaintenanceSuppression{},
 	)
 
 	svc := NewService(db)
+	svc.nodeID = func() string { return "node-test" }
 	notifier.SetEmitter(svc)
 	return svc
 }
@@ -9,6 +9,9 @@
 package services
 
 import (
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/config"
 	serviceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services"
 	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
 	diskServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/disk"
@@ -22,6 +25,7 @@ import (
 	systemServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/system"
 	utilitiesServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/utilities"
 	zfsServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/zfs"
+	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/internal/services/auth"
 	"github.com/alchemillahq/sylve/internal/services/cluster"
 	"github.com/alchemillahq/sylve/internal/services/disk"
@@ -37,9 +41,12 @@ import (
 	"github.com/alchemillahq/sylve/internal/services/startup"
 	"github.com/alchemillahq/sylve/internal/services/system"
 	"github.com/alchemillahq/sylve/internal/services/utilities"
+	"github.com/alchemillahq/sylve/internal/services/webhooks"
 	"github.com/alchemillahq/sylve/internal/services/zelta"
 	"github.com/alchemillahq/sylve/internal/services/zfs"
 
+	"github.com/alchemillahq/sylve/pkg/zfstrace"
+
 	"github.com/alchemillahq/gzfs"
 	"gorm.io/gorm"
 )
@@ -60,6 +67,7 @@ type ServiceRegistry struct {
 	ClusterService    clusterServiceInterfaces.ClusterServiceInterface
 	MdnsService       mdnsServiceInterfaces.MdnsServiceInterface
 	DynamicDNSService *dynamicdns.Service
+	WebhookService    *webhooks.Service
 	ZeltaService      *zelta.Service
 	MigrationService  *migration.Service
 	GzfsClient        *gzfs.Client
@@ -162,8 +170,21 @@ func NewServiceRegistry(db *gorm.DB, telemetryDB *gorm.DB) *ServiceRegistry {
 		panic("service registry requires a non-nil telemetry database")
 	}
 
+	if config.ParsedConfig != nil && config.ParsedConfig.ZFS.SlowCommandMs > 0 {
+		zfstrace.Default.SetSlowThreshold(time.Duration(config.ParsedConfig.ZFS.SlowCommandMs) * time.Millisecond)
+	}
+	zfstrace.Default.OnSlow = func(e zfstrace.Entry) {
+		logger.L.Warn().
+			Str("command", e.Command).
+			Strs("args", e.Args).
+			Int64("durationMs", e.DurationMs).
+			Bool("ok", e.OK).
+			Msg("slow_zfs_command")
+	}
+
 	gzfs := gzfs.NewClient(gzfs.Options{
 		Sudo:               false,
+		Runner:             zfstrace.Default,
 		ZDBCacheTTLSeconds: 0,
 	})
 
@@ -178,6 +199,7 @@ func NewServiceRegistry(db *gorm.DB, telemetryDB *gorm.DB) *ServiceRegistry {
 	sambaService := NewService[samba.Service](db, telemetryDB, zfsService, gzfs)
 	mdnsService := NewService[mdns.Service](db)
 	dynamicDNSService := dynamicdns.NewService(db)
+	webhookService := webhooks.NewService(db)
 	iscsiService := NewService[iscsi.Service](db)
 	clusterService := NewService[cluster.Service](db, authService, jailService)
 	libvirtService.(*libvirt.Service).SetGuestIdentityAvailabilityChecker(
@@ -186,6 +208,9 @@ func NewServiceRegistry(db *gorm.DB, telemetryDB *gorm.DB) *ServiceRegistry {
 	jailService.(*jail.Service).SetGuestIdentityAvailabilityChecker(
 		clusterService.(*cluster.Service),
 	)
+	zfsService.(*zfs.Service).SetBackupJobDatasetRenamer(
+		clusterService.(*cluster.Service),
+	)
 	diskService := NewService[disk.Service](db, zfsService, gzfs)
 	zeltaService := NewService[zelta.Service](db, telemetryDB, clusterService, jailService, networkService, libvirtService, gzfs)
 
@@ -224,6 +249,7 @@ func NewServiceRegistry(db *gorm.DB, telemetryDB *gorm.DB) *ServiceRegistry {
 		ClusterService:    clusterService.(clusterServiceInterfaces.ClusterServiceInterface),
 		MdnsService:       mdnsSvc,
 		DynamicDNSService: dynamicDNSService,
+		WebhookService:    webhookService,
 		ZeltaService:      zeltaService.(*zelta.Service),
 		MigrationService:  migrationService,
 		GzfsClient:        gzfs,
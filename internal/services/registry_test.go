@@ -46,6 +46,9 @@ func TestNewServiceRegistryReusesNetworkServiceInstance(t *testing.T) {
 	if registry.DynamicDNSService == nil {
 		t.Fatal("expected dynamic DNS service to be registered")
 	}
+	if registry.WebhookService == nil {
+		t.Fatal("expected webhook service to be registered")
+	}
 
 	apiNetwork, ok := registry.NetworkService.(*networkService.Service)
 	if !ok {
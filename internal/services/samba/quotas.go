@@ -0,0 +1,219 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package samba
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os/user"
+	"strconv"
+	"strings"
+
+	sambaModels "github.com/alchemillahq/sylve/internal/db/models/samba"
+)
+
+const (
+	ShareQuotaUser  = "user"
+	ShareQuotaGroup = "group"
+)
+
+var (
+	sambaLookupUser  = user.Lookup
+	sambaLookupGroup = user.LookupGroup
+)
+
+// ShareQuota is one user's or group's space on a share's dataset, as
+// reported by zfs userspace and zfs groupspace. Zero quotas mean none is set.
+type ShareQuota struct {
+	Type             string  `json:"type"`
+	Name             string  `json:"name"`
+	UsedBytes        uint64  `json:"usedBytes"`
+	QuotaBytes       uint64  `json:"quotaBytes"`
+	UsedObjects      uint64  `json:"usedObjects"`
+	QuotaObjects     uint64  `json:"quotaObjects"`
+	QuotaUsedPercent float64 `json:"quotaUsedPercent"`
+}
+
+type ShareQuotaRequest struct {
+	Type         string  `json:"type"`
+	Name         string  `json:"name"`
+	QuotaBytes   uint64  `json:"quotaBytes"`
+	QuotaObjects *uint64 `json:"quotaObjects"`
+}
+
+func parseShareQuotaValue(value string) (uint64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "-" || value == "none" {
+		return 0, nil
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
+
+// parseShareQuotas parses `zfs userspace -H -p -o name,used,quota,objused,objquota`
+// or its groupspace counterpart.
+func parseShareQuotas(output, kind string) ([]ShareQuota, error) {
+	var quotas []ShareQuota
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("invalid_share_quota_output: %s", line)
+		}
+
+		quota := ShareQuota{Type: kind, Name: fields[0]}
+		values := []*uint64{&quota.UsedBytes, &quota.QuotaBytes, &quota.UsedObjects, &quota.QuotaObjects}
+		for i, value := range values {
+			parsed, err := parseShareQuotaValue(fields[i+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid_share_quota_output: %s", line)
+			}
+			*value = parsed
+		}
+		if quota.QuotaBytes > 0 {
+			quota.QuotaUsedPercent = float64(quota.UsedBytes) / float64(quota.QuotaBytes) * 100
+		}
+		quotas = append(quotas, quota)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return quotas, nil
+}
+
+// shareQuotaPrincipal checks that the user or group exists on this host.
+// Samba maps share users onto local accounts, so a quota for anyone else
+// could never be charged.
+func shareQuotaPrincipal(kind, name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.ContainsAny(name, "=@ \t\r\n") {
+		return "", fmt.Errorf("invalid_share_quota_name")
+	}
+
+	switch kind {
+	case ShareQuotaUser:
+		if _, err := sambaLookupUser(name); err != nil {
+			if _, idErr := user.LookupId(name); idErr != nil {
+				return "", fmt.Errorf("user_not_found: %s", name)
+			}
+		}
+	case ShareQuotaGroup:
+		if _, err := sambaLookupGroup(name); err != nil {
+			if _, idErr := user.LookupGroupId(name); idErr != nil {
+				return "", fmt.Errorf("group_not_found: %s", name)
+			}
+		}
+	default:
+		return "", fmt.Errorf("invalid_share_quota_type")
+	}
+	return name, nil
+}
+
+// shareQuotaSetArgs builds the zfs set for a quota. The object quota is only
+// touched when asked for, as it needs the userobj_accounting pool feature.
+func shareQuotaSetArgs(kind, name string, quotaBytes uint64, quotaObjects *uint64, dataset string) []string {
+	format := func(value uint64) string {
+		if value == 0 {
+			return "none"
+		}
+		return strconv.FormatUint(value, 10)
+	}
+
+	args := []string{"set", fmt.Sprintf("%squota@%s=%s", kind, name, format(quotaBytes))}
+	if quotaObjects != nil {
+		args = append(args, fmt.Sprintf("%sobjquota@%s=%s", kind, name, format(*quotaObjects)))
+	}
+	return append(args, dataset)
+}
+
+func (s *Service) shareDatasetName(ctx context.Context, shareID uint) (string, error) {
+	var share sambaModels.SambaShare
+	if err := s.DB.First(&share, shareID).Error; err != nil {
+		return "", fmt.Errorf("share_not_found")
+	}
+
+	dataset, err := s.GZFS.ZFS.GetByGUID(ctx, share.Dataset, false)
+	if err != nil {
+		return "", fmt.Errorf("failed_to_fetch_dataset: %v", err)
+	}
+	if dataset == nil {
+		return "", fmt.Errorf("dataset_not_found")
+	}
+	return dataset.Name, nil
+}
+
+// GetShareQuotas reports per-user and per-group usage on a share's dataset
+// together with any quotas set on it.
+func (s *Service) GetShareQuotas(ctx context.Context, shareID uint) ([]ShareQuota, error) {
+	dataset, err := s.shareDatasetName(ctx, shareID)
+	if err != nil {
+		return nil, err
+	}
+
+	quotas := []ShareQuota{}
+	for _, kind := range []string{ShareQuotaUser, ShareQuotaGroup} {
+		output, err := sambaRunCommand("/sbin/zfs", kind+"space", "-H", "-p",
+			"-o", "name,used,quota,objused,objquota", dataset)
+		if err != nil {
+			return nil, fmt.Errorf("failed_to_get_share_quotas: %s", strings.TrimSpace(output))
+		}
+		parsed, err := parseShareQuotas(output, kind)
+		if err != nil {
+			return nil, err
+		}
+		quotas = append(quotas, parsed...)
+	}
+	return quotas, nil
+}
+
+// SetShareQuota sets or, with zero, clears a user or group quota on a share's
+// dataset.
+func (s *Service) SetShareQuota(ctx context.Context, shareID uint, req ShareQuotaRequest) error {
+	name, err := shareQuotaPrincipal(req.Type, req.Name)
+	if err != nil {
+		return err
+	}
+
+	dataset, err := s.shareDatasetName(ctx, shareID)
+	if err != nil {
+		return err
+	}
+
+	args := shareQuotaSetArgs(req.Type, name, req.QuotaBytes, req.QuotaObjects, dataset)
+	if output, err := sambaRunCommand("/sbin/zfs", args...); err != nil {
+		return fmt.Errorf("failed_to_set_share_quota: %s", strings.TrimSpace(output))
+	}
+	return nil
+}
+
+// RemoveShareQuota clears both the space and the object quota of a user or
+// group. Their usage keeps being reported.
+func (s *Service) RemoveShareQuota(ctx context.Context, shareID uint, kind, name string) error {
+	quotas, err := s.GetShareQuotas(ctx, shareID)
+	if err != nil {
+		return err
+	}
+
+	var noObjects *uint64
+	for _, quota := range quotas {
+		if quota.Type == kind && quota.Name == name && quota.QuotaObjects > 0 {
+			noObjects = new(uint64)
+		}
+	}
+
+	return s.SetShareQuota(ctx, shareID, ShareQuotaRequest{
+		Type:         kind,
+		Name:         name,
+		QuotaObjects: noObjects,
+	})
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package samba
+
+import (
+	"fmt"
+	"os/user"
+	"slices"
+	"testing"
+)
+
+func TestParseShareQuotas(t *testing.T) {
+	quotas, err := parseShareQuotas("alice\t1073741824\t4294967296\t120\tnone\nbob\t512\tnone\t3\t1000\n", ShareQuotaUser)
+	if err != nil {
+		t.Fatalf("parse quotas: %v", err)
+	}
+	if len(quotas) != 2 {
+		t.Fatalf("expected 2 quotas, got %d", len(quotas))
+	}
+
+	alice := quotas[0]
+	if alice.Type != ShareQuotaUser || alice.Name != "alice" || alice.UsedBytes != 1<<30 ||
+		alice.QuotaBytes != 4<<30 || alice.UsedObjects != 120 || alice.QuotaObjects != 0 {
+		t.Fatalf("unexpected quota for alice: %+v", alice)
+	}
+	if alice.QuotaUsedPercent != 25 {
+		t.Fatalf("expected alice at 25%% of her quota, got %v", alice.QuotaUsedPercent)
+	}
+
+	bob := quotas[1]
+	if bob.QuotaBytes != 0 || bob.QuotaObjects != 1000 || bob.QuotaUsedPercent != 0 {
+		t.Fatalf("unexpected quota for bob: %+v", bob)
+	}
+
+	if _, err := parseShareQuotas("alice\t1024\n", ShareQuotaUser); err == nil {
+		t.Fatal("expected short output to be rejected")
+	}
+	if _, err := parseShareQuotas("alice\t1G\tnone\t1\tnone\n", ShareQuotaUser); err == nil {
+		t.Fatal("expected non-numeric usage to be rejected")
+	}
+}
+
+func TestShareQuotaSetArgs(t *testing.T) {
+	args := shareQuotaSetArgs(ShareQuotaUser, "alice", 4<<30, nil, "tank/shares/home")
+	want := []string{"set", "userquota@alice=4294967296", "tank/shares/home"}
+	if !slices.Equal(args, want) {
+		t.Fatalf("unexpected args %q", args)
+	}
+
+	objects := uint64(0)
+	args = shareQuotaSetArgs(ShareQuotaGroup, "staff", 0, &objects, "tank/shares/home")
+	want = []string{"set", "groupquota@staff=none", "groupobjquota@staff=none", "tank/shares/home"}
+	if !slices.Equal(args, want) {
+		t.Fatalf("unexpected args %q", args)
+	}
+}
+
+func TestShareQuotaPrincipal(t *testing.T) {
+	prevUser, prevGroup := sambaLookupUser, sambaLookupGroup
+	sambaLookupUser = func(name string) (*user.User, error) {
+		if name == "alice" {
+			return &user.User{Username: name, Uid: "1001"}, nil
+		}
+		return nil, fmt.Errorf("unknown user")
+	}
+	sambaLookupGroup = func(name string) (*user.Group, error) {
+		if name == "staff" {
+			return &user.Group{Name: name, Gid: "20"}, nil
+		}
+		return nil, fmt.Errorf("unknown group")
+	}
+	t.Cleanup(func() { sambaLookupUser, sambaLookupGroup = prevUser, prevGroup })
+
+	if name, err := shareQuotaPrincipal(ShareQuotaUser, " alice "); err != nil || name != "alice" {
+		t.Fatalf("expected alice to be accepted, got %q %v", name, err)
+	}
+	if _, err := shareQuotaPrincipal(ShareQuotaGroup, "staff"); err != nil {
+		t.Fatalf("expected staff to be accepted: %v", err)
+	}
+
+	for _, tc := range []struct{ kind, name string }{
+		{ShareQuotaUser, "mallory-nobody"},
+		{ShareQuotaGroup, "alice"},
+		{ShareQuotaUser, "alice=1"},
+		{ShareQuotaUser, ""},
+		{"project", "alice"},
+	} {
+		if _, err := shareQuotaPrincipal(tc.kind, tc.name); err == nil {
+			t.Fatalf("expected %s %q to be rejected", tc.kind, tc.name)
+		}
+	}
+}
@@ -0,0 +1,717 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alchemillahq/gzfs"
+	"github.com/alchemillahq/sylve/internal"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	zfsServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/zfs"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/internal/services/cluster"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	"golang.org/x/sync/errgroup"
+
+	"gorm.io/gorm"
+)
+
+const (
+	TypeVM                = "vm"
+	TypeJail              = "jail"
+	TypeSwitch            = "switch"
+	TypeDataset           = "dataset"
+	TypeBackupJob         = "backup_job"
+	TypeReplicationPolicy = "replication_policy"
+	TypeBackupEvent       = "backup_event"
+	TypeReplicationEvent  = "replication_event"
+	TypeNode              = "node"
+
+	DefaultLimit = 50
+	MaxLimit     = 200
+
+	// Only the most recent events are searched; older ones are pruned anyway.
+	eventScanLimit = 500
+
+	remoteTimeout = 10 * time.Second
+)
+
+// nodeLocalTypes are the kinds of result taken from peers. Everything else
+// is replicated through raft, so this node's copy is already complete and a
+// fan-out would only return it once per node.
+var nodeLocalTypes = map[string]bool{
+	TypeVM:               true,
+	TypeJail:             true,
+	TypeSwitch:           true,
+	TypeDataset:          true,
+	TypeBackupEvent:      true,
+	TypeReplicationEvent: true,
+}
+
+// typeOrder breaks score ties so guests list ahead of the things that
+// reference them.
+var typeOrder = map[string]int{
+	TypeVM:                0,
+	TypeJail:              1,
+	TypeNode:              2,
+	TypeSwitch:            3,
+	TypeDataset:           4,
+	TypeBackupJob:         5,
+	TypeReplicationPolicy: 6,
+	TypeBackupEvent:       7,
+	TypeReplicationEvent:  8,
+}
+
+// Result is one matching resource. Match names the field that matched and
+// Detail carries its value when that is not the name itself.
+type Result struct {
+	Type     string `json:"type"`
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Match    string `json:"match"`
+	Detail   string `json:"detail,omitempty"`
+	NodeID   string `json:"nodeId,omitempty"`
+	Hostname string `json:"hostname,omitempty"`
+	Score    int    `json:"score"`
+}
+
+type Response struct {
+	Query   string   `json:"query"`
+	Results []Result `json:"results"`
+	// Unreachable lists the hostnames of nodes that did not answer, so a
+	// missing guest is not mistaken for one that does not exist.
+	Unreachable []string `json:"unreachable"`
+}
+
+type Service struct {
+	DB      *gorm.DB
+	Cluster *cluster.Service
+	ZFS     zfsServiceInterfaces.ZfsServiceInterface
+
+	listDatasets func(ctx context.Context) ([]*gzfs.Dataset, error)
+	searchRemote func(ctx context.Context, node clusterModels.ClusterNode, token, query string, limit int) ([]Result, error)
+	localNode    func() (nodeID, hostname string)
+}
+
+func NewService(db *gorm.DB, clusterService *cluster.Service, zfsService zfsServiceInterfaces.ZfsServiceInterface) *Service {
+	s := &Service{DB: db, Cluster: clusterService, ZFS: zfsService}
+	s.listDatasets = s.zfsDatasets
+	s.searchRemote = s.fetchRemote
+	s.localNode = s.clusterLocalNode
+	return s
+}
+
+// scoreMatch ranks how well value matches the lower-cased query: exact
+// matches beat prefixes, which beat substrings. Secondary fields such as
+// addresses and paths rank just below the same kind of match on a name.
+func scoreMatch(query, value string, secondary bool) int {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return 0
+	}
+
+	score := 0
+	switch {
+	case value == query:
+		score = 100
+	case strings.HasPrefix(value, query):
+		score = 70
+	case strings.Contains(value, query):
+		score = 40
+	default:
+		return 0
+	}
+	if secondary {
+		score -= 10
+	}
+	return score
+}
+
+// normalizeMAC lets 58-9c-fc, 58:9C:FC and 589cfc find the same address.
+func normalizeMAC(value string) string {
+	return strings.NewReplacer(":", "", "-", "", ".", "").Replace(strings.ToLower(value))
+}
+
+type field struct {
+	name      string
+	value     string
+	secondary bool
+	mac       bool
+	exactOnly bool
+}
+
+// best returns the highest-scoring field, if any matched.
+func best(query string, fields ...field) (field, int) {
+	var (
+		top      field
+		topScore int
+	)
+	macQuery := normalizeMAC(query)
+	for _, f := range fields {
+		var score int
+		switch {
+		case f.mac:
+			if len(macQuery) >= 4 {
+				score = scoreMatch(macQuery, normalizeMAC(f.value), true)
+			}
+		case f.exactOnly:
+			if strings.EqualFold(strings.TrimSpace(f.value), query) {
+				score = 100
+			}
+		default:
+			score = scoreMatch(query, f.value, f.secondary)
+		}
+		if score > topScore {
+			top, topScore = f, score
+		}
+	}
+	return top, topScore
+}
+
+func (s *Service) clusterLocalNode() (string, string) {
+	if s.Cluster == nil {
+		return "", ""
+	}
+	detail := s.Cluster.Detail()
+	if detail == nil {
+		return "", ""
+	}
+	return detail.NodeID, detail.Hostname
+}
+
+// Search looks the query up on this node and, when clustered, on every other
+// node. Resources replicated through raft are only taken from this node.
+func (s *Service) Search(ctx context.Context, query string, limit int, clusterWide bool) (*Response, error) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil, fmt.Errorf("query_required")
+	}
+	if _, err := strconv.ParseUint(query, 10, 64); err != nil && len(query) < 2 {
+		return nil, fmt.Errorf("query_too_short")
+	}
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	results, err := s.SearchLocal(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &Response{Query: query, Unreachable: []string{}}
+	if clusterWide {
+		remote, unreachable, err := s.searchPeers(ctx, query, limit)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, remote...)
+		response.Unreachable = unreachable
+	}
+
+	if results == nil {
+		results = []Result{}
+	}
+	sortResults(results)
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	response.Results = results
+
+	return response, nil
+}
+
+// searchPeers runs the query on every other node with a cluster token and
+// returns their node-local results along with the nodes that did not answer.
+func (s *Service) searchPeers(ctx context.Context, query string, limit int) ([]Result, []string, error) {
+	var nodes []clusterModels.ClusterNode
+	if err := s.DB.Find(&nodes).Error; err != nil {
+		return nil, nil, fmt.Errorf("cluster_node_lookup_failed: %w", err)
+	}
+
+	localID, localHostname := s.localNode()
+	var peers []clusterModels.ClusterNode
+	for _, node := range nodes {
+		if node.NodeUUID != localID && strings.TrimSpace(node.API) != "" {
+			peers = append(peers, node)
+		}
+	}
+	if len(peers) == 0 {
+		return nil, []string{}, nil
+	}
+
+	token := ""
+	if s.Cluster != nil && s.Cluster.AuthService != nil {
+		var err error
+		token, err = s.Cluster.AuthService.CreateClusterJWT(0, localHostname, "", "")
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed_to_create_cluster_token: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, remoteTimeout)
+	defer cancel()
+
+	var (
+		mu          sync.Mutex
+		results     []Result
+		unreachable = []string{}
+		g           errgroup.Group
+	)
+	for _, node := range peers {
+		node := node
+		g.Go(func() error {
+			remote, err := s.searchRemote(ctx, node, token, query, limit)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				logger.L.Debug().Err(err).Str("node", node.Hostname).Msg("search_remote_node_failed")
+				unreachable = append(unreachable, node.Hostname)
+				return nil
+			}
+			for _, result := range remote {
+				if !nodeLocalTypes[result.Type] {
+					continue
+				}
+				result.NodeID, result.Hostname = node.NodeUUID, node.Hostname
+				results = append(results, result)
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	sort.Strings(unreachable)
+	return results, unreachable, nil
+}
+
+func (s *Service) fetchRemote(ctx context.Context, node clusterModels.ClusterNode, token, query string, limit int) ([]Result, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("scope", "local")
+	params.Set("limit", strconv.Itoa(limit))
+
+	headers := map[string]string{
+		"Accept":          "application/json",
+		"X-Cluster-Token": fmt.Sprintf("Bearer %s", token),
+	}
+
+	body, _, err := utils.HTTPGetJSONReadContext(ctx, fmt.Sprintf("https://%s/api/search?%s", node.API, params.Encode()), headers)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp internal.APIResponse[Response]
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("invalid_search_response: %w", err)
+	}
+	return resp.Data.Results, nil
+}
+
+func sortResults(results []Result) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if typeOrder[a.Type] != typeOrder[b.Type] {
+			return typeOrder[a.Type] < typeOrder[b.Type]
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	})
+}
+
+// SearchLocal searches this node's database and pools.
+func (s *Service) SearchLocal(ctx context.Context, query string) ([]Result, error) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	nodeID, hostname := s.localNode()
+
+	searchers := []func(string) ([]Result, error){
+		s.searchVMs,
+		s.searchJails,
+		s.searchSwitches,
+		s.searchBackupEvents,
+		s.searchReplicationEvents,
+		s.searchBackupJobs,
+		s.searchReplicationPolicies,
+		s.searchNodes,
+	}
+
+	var results []Result
+	for _, search := range searchers {
+		found, err := search(query)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, found...)
+	}
+
+	datasets, err := s.searchDatasets(ctx, query)
+	if err != nil {
+		// Datasets come from ZFS rather than the database; a slow or failing
+		// zfs list should not hide every other result.
+		logger.L.Warn().Err(err).Msg("search_dataset_listing_failed")
+	}
+	results = append(results, datasets...)
+
+	for i := range results {
+		if results[i].Type != TypeNode {
+			results[i].NodeID, results[i].Hostname = nodeID, hostname
+		}
+	}
+
+	return results, nil
+}
+
+func add(results []Result, query, resultType, id, name string, fields ...field) []Result {
+	matched, score := best(query, fields...)
+	if score == 0 {
+		return results
+	}
+	result := Result{Type: resultType, ID: id, Name: name, Match: matched.name, Score: score}
+	if matched.name != "name" {
+		result.Detail = matched.value
+	}
+	return append(results, result)
+}
+
+// objectValues maps network object IDs to their entry values.
+func (s *Service) objectValues(ids []uint) (map[uint][]string, error) {
+	values := make(map[uint][]string)
+	if len(ids) == 0 {
+		return values, nil
+	}
+	var entries []networkModels.ObjectEntry
+	if err := s.DB.Where("object_id IN ?", ids).Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("network_object_lookup_failed: %w", err)
+	}
+	for _, entry := range entries {
+		values[entry.ObjectID] = append(values[entry.ObjectID], entry.Value)
+	}
+	return values, nil
+}
+
+// leaseIPsByMAC maps normalized MACs to the addresses of their DHCP static
+// leases, which is where a VM's address is recorded.
+func (s *Service) leaseIPsByMAC() (map[string][]string, error) {
+	var leases []networkModels.DHCPStaticLease
+	if err := s.DB.Where("mac_object_id IS NOT NULL AND ip_object_id IS NOT NULL").Find(&leases).Error; err != nil {
+		return nil, fmt.Errorf("dhcp_lease_lookup_failed: %w", err)
+	}
+
+	ids := make([]uint, 0, len(leases)*2)
+	for _, lease := range leases {
+		ids = append(ids, *lease.MACObjectID, *lease.IPObjectID)
+	}
+	values, err := s.objectValues(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	ips := make(map[string][]string)
+	for _, lease := range leases {
+		for _, mac := range values[*lease.MACObjectID] {
+			key := normalizeMAC(mac)
+			ips[key] = append(ips[key], values[*lease.IPObjectID]...)
+		}
+	}
+	return ips, nil
+}
+
+func (s *Service) searchVMs(query string) ([]Result, error) {
+	var vms []vmModels.VM
+	if err := s.DB.Preload("Networks").Find(&vms).Error; err != nil {
+		return nil, fmt.Errorf("vm_lookup_failed: %w", err)
+	}
+
+	var macIDs []uint
+	for _, vm := range vms {
+		for _, network := range vm.Networks {
+			if network.MacID != nil {
+				macIDs = append(macIDs, *network.MacID)
+			}
+		}
+	}
+	macObjects, err := s.objectValues(macIDs)
+	if err != nil {
+		return nil, err
+	}
+	leaseIPs, err := s.leaseIPsByMAC()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	for _, vm := range vms {
+		rid := strconv.FormatUint(uint64(vm.RID), 10)
+		fields := []field{
+			{name: "name", value: vm.Name},
+			{name: "rid", value: rid, exactOnly: true},
+			{name: "description", value: vm.Description, secondary: true},
+		}
+		for _, network := range vm.Networks {
+			macs := []string{network.MAC}
+			if network.MacID != nil {
+				macs = append(macs, macObjects[*network.MacID]...)
+			}
+			for _, mac := range macs {
+				fields = append(fields, field{name: "mac", value: mac, mac: true})
+				for _, ip := range leaseIPs[normalizeMAC(mac)] {
+					fields = append(fields, field{name: "ip", value: ip, secondary: true})
+				}
+			}
+		}
+		results = add(results, query, TypeVM, rid, vm.Name, fields...)
+	}
+	return results, nil
+}
+
+func (s *Service) searchJails(query string) ([]Result, error) {
+	var jails []jailModels.Jail
+	if err := s.DB.Preload("Networks").Find(&jails).Error; err != nil {
+		return nil, fmt.Errorf("jail_lookup_failed: %w", err)
+	}
+
+	var objectIDs []uint
+	for _, jail := range jails {
+		for _, network := range jail.Networks {
+			for _, id := range []*uint{network.MacID, network.IPv4ID, network.IPv6ID} {
+				if id != nil {
+					objectIDs = append(objectIDs, *id)
+				}
+			}
+		}
+	}
+	objects, err := s.objectValues(objectIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	for _, jail := range jails {
+		ctid := strconv.FormatUint(uint64(jail.CTID), 10)
+		fields := []field{
+			{name: "name", value: jail.Name},
+			{name: "ctid", value: ctid, exactOnly: true},
+			{name: "hostname", value: jail.Hostname, secondary: true},
+			{name: "description", value: jail.Description, secondary: true},
+		}
+		for _, network := range jail.Networks {
+			if network.MacID != nil {
+				for _, mac := range objects[*network.MacID] {
+					fields = append(fields, field{name: "mac", value: mac, mac: true})
+				}
+			}
+			for _, id := range []*uint{network.IPv4ID, network.IPv6ID} {
+				if id == nil {
+					continue
+				}
+				for _, ip := range objects[*id] {
+					fields = append(fields, field{name: "ip", value: ip, secondary: true})
+				}
+			}
+		}
+		results = add(results, query, TypeJail, ctid, jail.Name, fields...)
+	}
+	return results, nil
+}
+
+func (s *Service) searchSwitches(query string) ([]Result, error) {
+	var standard []networkModels.StandardSwitch
+	if err := s.DB.Find(&standard).Error; err != nil {
+		return nil, fmt.Errorf("switch_lookup_failed: %w", err)
+	}
+	var manual []networkModels.ManualSwitch
+	if err := s.DB.Find(&manual).Error; err != nil {
+		return nil, fmt.Errorf("switch_lookup_failed: %w", err)
+	}
+
+	var results []Result
+	for _, sw := range standard {
+		results = add(results, query, TypeSwitch, "standard:"+strconv.FormatUint(uint64(sw.ID), 10), sw.Name,
+			field{name: "name", value: sw.Name},
+			field{name: "bridge", value: sw.BridgeName, secondary: true},
+			field{name: "ip", value: sw.Address, secondary: true},
+			field{name: "ip", value: sw.Address6, secondary: true},
+		)
+	}
+	for _, sw := range manual {
+		results = add(results, query, TypeSwitch, "manual:"+strconv.FormatUint(uint64(sw.ID), 10), sw.Name,
+			field{name: "name", value: sw.Name},
+			field{name: "bridge", value: sw.Bridge, secondary: true},
+		)
+	}
+	return results, nil
+}
+
+func (s *Service) zfsDatasets(ctx context.Context) ([]*gzfs.Dataset, error) {
+	if s.ZFS == nil {
+		return nil, nil
+	}
+	var datasets []*gzfs.Dataset
+	for _, t := range []gzfs.DatasetType{gzfs.DatasetTypeFilesystem, gzfs.DatasetTypeVolume} {
+		found, err := s.ZFS.GetDatasets(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		datasets = append(datasets, found...)
+	}
+	return datasets, nil
+}
+
+func (s *Service) searchDatasets(ctx context.Context, query string) ([]Result, error) {
+	datasets, err := s.listDatasets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	for _, dataset := range datasets {
+		if dataset == nil {
+			continue
+		}
+		base := dataset.Name
+		if slash := strings.LastIndex(base, "/"); slash >= 0 {
+			base = base[slash+1:]
+		}
+		// The last path component ranks like a name; a match elsewhere in
+		// the path (e.g. the pool) is secondary.
+		matched, score := best(query,
+			field{name: "name", value: base},
+			field{name: "guid", value: dataset.GUID, exactOnly: true},
+			field{name: "path", value: dataset.Name, secondary: true},
+		)
+		if score == 0 {
+			continue
+		}
+		result := Result{Type: TypeDataset, ID: dataset.GUID, Name: dataset.Name, Match: matched.name, Score: score}
+		if matched.name == "guid" {
+			result.Detail = dataset.GUID
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (s *Service) searchBackupJobs(query string) ([]Result, error) {
+	var jobs []clusterModels.BackupJob
+	if err := s.DB.Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("backup_job_lookup_failed: %w", err)
+	}
+
+	var results []Result
+	for _, job := range jobs {
+		id := strconv.FormatUint(uint64(job.ID), 10)
+		results = add(results, query, TypeBackupJob, id, job.Name,
+			field{name: "name", value: job.Name},
+			field{name: "id", value: id, exactOnly: true},
+			field{name: "source", value: job.FriendlySrc, secondary: true},
+			field{name: "source", value: job.SourceDataset, secondary: true},
+			field{name: "source", value: job.JailRootDataset, secondary: true},
+		)
+	}
+	return results, nil
+}
+
+func (s *Service) searchReplicationPolicies(query string) ([]Result, error) {
+	var policies []clusterModels.ReplicationPolicy
+	if err := s.DB.Find(&policies).Error; err != nil {
+		return nil, fmt.Errorf("replication_policy_lookup_failed: %w", err)
+	}
+
+	var results []Result
+	for _, policy := range policies {
+		id := strconv.FormatUint(uint64(policy.ID), 10)
+		results = add(results, query, TypeReplicationPolicy, id, policy.Name,
+			field{name: "name", value: policy.Name},
+			field{name: "id", value: id, exactOnly: true},
+			field{name: "guest", value: strconv.FormatUint(uint64(policy.GuestID), 10), exactOnly: true},
+			field{name: "description", value: policy.Description, secondary: true},
+		)
+	}
+	return results, nil
+}
+
+func (s *Service) searchBackupEvents(query string) ([]Result, error) {
+	var events []clusterModels.BackupEvent
+	if err := s.DB.Select("id", "source_dataset", "target_endpoint", "status").
+		Order("id DESC").Limit(eventScanLimit).Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("backup_event_lookup_failed: %w", err)
+	}
+
+	var results []Result
+	for _, event := range events {
+		id := strconv.FormatUint(uint64(event.ID), 10)
+		results = add(results, query, TypeBackupEvent, id, event.SourceDataset,
+			field{name: "id", value: id, exactOnly: true},
+			field{name: "source", value: event.SourceDataset, secondary: true},
+			field{name: "target", value: event.TargetEndpoint, secondary: true},
+		)
+	}
+	return results, nil
+}
+
+func (s *Service) searchReplicationEvents(query string) ([]Result, error) {
+	var events []clusterModels.ReplicationEvent
+	if err := s.DB.Select("id", "event_type", "message", "guest_type", "guest_id").
+		Order("id DESC").Limit(eventScanLimit).Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("replication_event_lookup_failed: %w", err)
+	}
+
+	var results []Result
+	for _, event := range events {
+		id := strconv.FormatUint(uint64(event.ID), 10)
+		name := fmt.Sprintf("%s %s %d", event.EventType, event.GuestType, event.GuestID)
+		results = add(results, query, TypeReplicationEvent, id, name,
+			field{name: "id", value: id, exactOnly: true},
+			field{name: "message", value: event.Message, secondary: true},
+		)
+	}
+	return results, nil
+}
+
+func (s *Service) searchNodes(query string) ([]Result, error) {
+	var nodes []clusterModels.ClusterNode
+	if err := s.DB.Find(&nodes).Error; err != nil {
+		return nil, fmt.Errorf("cluster_node_lookup_failed: %w", err)
+	}
+
+	var results []Result
+	for _, node := range nodes {
+		address := node.API
+		if colon := strings.LastIndex(address, ":"); colon > 0 {
+			address = address[:colon]
+		}
+		before := len(results)
+		results = add(results, query, TypeNode, node.NodeUUID, node.Hostname,
+			field{name: "name", value: node.Hostname},
+			field{name: "id", value: node.NodeUUID, exactOnly: true},
+			field{name: "ip", value: address, secondary: true},
+		)
+		if len(results) > before {
+			results[before].NodeID, results[before].Hostname = node.NodeUUID, node.Hostname
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: BSD-2-Clause
+
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alchemillahq/gzfs"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	networkModels "github.com/alchemillahq/sylve/internal/db/models/network"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	"github.com/alchemillahq/sylve/internal/testutil"
+)
+
+func newSearchTestService(t *testing.T) *Service {
+	t.Helper()
+	db := testutil.NewSQLiteTestDB(t,
+		&vmModels.VM{},
+		&vmModels.Network{},
+		&jailModels.Jail{},
+		&jailModels.Network{},
+		&networkModels.Object{},
+		&networkModels.ObjectEntry{},
+		&networkModels.DHCPStaticLease{},
+		&networkModels.StandardSwitch{},
+		&networkModels.ManualSwitch{},
+		&clusterModels.ClusterNode{},
+		&clusterModels.BackupJob{},
+		&clusterModels.BackupEvent{},
+		&clusterModels.ReplicationPolicy{},
+		&clusterModels.ReplicationEvent{},
+	)
+
+	s := &Service{DB: db}
+	s.listDatasets = func(context.Context) ([]*gzfs.Dataset, error) {
+		return []*gzfs.Dataset{
+			{Name: "tank/web-data", GUID: "1111"},
+			{Name: "tank/archive", GUID: "2222"},
+		}, nil
+	}
+	s.localNode = func() (string, string) { return "node-a", "alpha" }
+	s.searchRemote = func(context.Context, clusterModels.ClusterNode, string, string, int) ([]Result, error) {
+		return nil, errors.New("unreachable")
+	}
+	return s
+}
+
+func createObject(t *testing.T, s *Service, name, objectType, value string) uint {
+	t.Helper()
+	object := networkModels.Object{Name: name, Type: objectType}
+	if err := s.DB.Create(&object).Error; err != nil {
+		t.Fatalf("create object: %v", err)
+	}
+	if err := s.DB.Create(&networkModels.ObjectEntry{ObjectID: object.ID, Value: value}).Error; err != nil {
+		t.Fatalf("create object entry: %v", err)
+	}
+	return object.ID
+}
+
+func TestSearchRanksNameMatchesFirst(t *testing.T) {
+	s := newSearchTestService(t)
+
+	if err := s.DB.Create(&vmModels.VM{RID: 100, Name: "web"}).Error; err != nil {
+		t.Fatalf("create vm: %v", err)
+	}
+	if err := s.DB.Create(&jailModels.Jail{CTID: 101, Name: "proxy", Hostname: "web-proxy"}).Error; err != nil {
+		t.Fatalf("create jail: %v", err)
+	}
+
+	resp, err := s.Search(context.Background(), "WEB", 0, false)
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(resp.Results) != 3 {
+		t.Fatalf("results = %+v", resp.Results)
+	}
+	if got := resp.Results[0]; got.Type != TypeVM || got.Score != 100 || got.NodeID != "node-a" {
+		t.Fatalf("top result = %+v", got)
+	}
+	if got := resp.Results[1]; got.Type != TypeDataset || got.Name != "tank/web-data" {
+		t.Fatalf("second result = %+v", got)
+	}
+	if got := resp.Results[2]; got.Type != TypeJail || got.Match != "hostname" || got.Detail != "web-proxy" {
+		t.Fatalf("third result = %+v", got)
+	}
+
+	if _, err := s.Search(context.Background(), "w", 0, false); err == nil || err.Error() != "query_too_short" {
+		t.Fatalf("short query error = %v", err)
+	}
+	if resp, err := s.Search(context.Background(), "101", 0, false); err != nil || len(resp.Results) != 1 ||
+		resp.Results[0].Type != TypeJail {
+		t.Fatalf("ctid lookup = %+v, %v", resp, err)
+	}
+}
+
+func TestSearchFindsGuestsByAddress(t *testing.T) {
+	s := newSearchTestService(t)
+
+	macID := createObject(t, s, "vm-mac", "Mac", "58:9c:fc:00:00:01")
+	ipID := createObject(t, s, "vm-ip", "Host", "10.0.0.20")
+	if err := s.DB.Create(&networkModels.DHCPStaticLease{MACObjectID: &macID, IPObjectID: &ipID}).Error; err != nil {
+		t.Fatalf("create lease: %v", err)
+	}
+	vm := vmModels.VM{RID: 100, Name: "db"}
+	if err := s.DB.Create(&vm).Error; err != nil {
+		t.Fatalf("create vm: %v", err)
+	}
+	if err := s.DB.Create(&vmModels.Network{VMID: vm.ID, MacID: &macID}).Error; err != nil {
+		t.Fatalf("create vm network: %v", err)
+	}
+
+	jailIP := createObject(t, s, "jail-ip", "Host", "10.0.0.30")
+	jail := jailModels.Jail{CTID: 105, Name: "dns"}
+	if err := s.DB.Create(&jail).Error; err != nil {
+		t.Fatalf("create jail: %v", err)
+	}
+	if err := s.DB.Create(&jailModels.Network{JailID: jail.ID, IPv4ID: &jailIP}).Error; err != nil {
+		t.Fatalf("create jail network: %v", err)
+	}
+
+	resp, err := s.Search(context.Background(), "10.0.0.20", 0, false)
+	if err != nil || len(resp.Results) != 1 || resp.Results[0].Name != "db" || resp.Results[0].Match != "ip" {
+		t.Fatalf("vm ip lookup = %+v, %v", resp, err)
+	}
+	resp, err = s.Search(context.Background(), "58-9C-FC-00-00-01", 0, false)
+	if err != nil || len(resp.Results) != 1 || resp.Results[0].Match != "mac" {
+		t.Fatalf("vm mac lookup = %+v, %v", resp, err)
+	}
+	resp, err = s.Search(context.Background(), "10.0.0.30", 0, false)
+	if err != nil || len(resp.Results) != 1 || resp.Results[0].Name != "dns" {
+		t.Fatalf("jail ip lookup = %+v, %v", resp, err)
+	}
+}
+
+func TestSearchClusterKeepsReplicatedResultsLocal(t *testing.T) {
+	s := newSearchTestService(t)
+
+	for _, node := range []clusterModels.ClusterNode{
+		{NodeUUID: "node-a", Hostname: "alpha", API: "10.0.0.1:8181"},
+		{NodeUUID: "node-b", Hostname: "beta", API: "10.0.0.2:8181"},
+		{NodeUUID: "node-c", Hostname: "gamma", API: "10.0.0.3:8181"},
+	} {
+		if err := s.DB.Create(&node).Error; err != nil {
+			t.Fatalf("create node: %v", err)
+		}
+	}
+	if err := s.DB.Create(&clusterModels.BackupJob{Name: "nightly"}).Error; err != nil {
+		t.Fatalf("create backup job: %v", err)
+	}
+
+	s.searchRemote = func(_ context.Context, node clusterModels.ClusterNode, _, query string, _ int) ([]Result, error) {
+		if node.NodeUUID == "node-c" {
+			return nil, errors.New("connection refused")
+		}
+		return []Result{
+			{Type: TypeVM, ID: "200", Name: "nightly-runner", Match: "name", Score: 70},
+			{Type: TypeBackupJob, ID: "1", Name: "nightly", Match: "name", Score: 100},
+		}, nil
+	}
+
+	resp, err := s.Search(context.Background(), "nightly", 0, true)
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(resp.Unreachable) != 1 || resp.Unreachable[0] != "gamma" {
+		t.Fatalf("unreachable = %v", resp.Unreachable)
+	}
+	if len(resp.Results) != 2 {
+		t.Fatalf("results = %+v", resp.Results)
+	}
+	if got := resp.Results[0]; got.Type != TypeBackupJob || got.NodeID != "node-a" {
+		t.Fatalf("backup job result = %+v", got)
+	}
+	if got := resp.Results[1]; got.Type != TypeVM || got.NodeID != "node-b" || got.Hostname != "beta" {
+		t.Fatalf("remote vm result = %+v", got)
+	}
+}
@@ -0,0 +1,305 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+// Package snapshotschedule runs cron-driven snapshots with retention for
+// guests. The VM and jail services own the schedule tables and know how to
+// snapshot their guests; the timing, naming, queueing and pruning live here.
+package snapshotschedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/db"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/robfig/cron/v3"
+	"gorm.io/gorm"
+)
+
+const TickInterval = 30 * time.Second
+
+// CatchUpWindow bounds how late a run may start. Runs missed for longer,
+// e.g. while the node was down, are skipped rather than all taken at once.
+const CatchUpWindow = 2 * time.Hour
+
+const DefaultNamePattern = "auto-{date}-{time}"
+
+const MaxNamePatternLength = 96
+
+type Input struct {
+	CronExpr    string `json:"cronExpr" binding:"required"`
+	MaxKeep     int    `json:"maxKeep"`
+	NamePattern string `json:"namePattern"`
+	Enabled     bool   `json:"enabled"`
+}
+
+type payload struct {
+	ScheduleID uint `json:"scheduleId"`
+}
+
+// Guest is what a service resolves a schedule to: the placeholders its name
+// pattern may use and the hooks that snapshot the guest.
+type Guest struct {
+	// Tokens are placeholder and value pairs, e.g. "{vm}", "web".
+	Tokens []string
+	Create func(ctx context.Context, name string) error
+	// Snapshots lists the IDs of the snapshots the schedule took, oldest
+	// first; manual snapshots are never included.
+	Snapshots func() ([]uint, error)
+	Delete    func(ctx context.Context, snapshotID uint) error
+}
+
+// Reservations keeps a schedule from being queued again while a run is
+// pending. The zero value is ready to use.
+type Reservations struct {
+	mu     sync.Mutex
+	queued map[uint]struct{}
+}
+
+func (r *Reservations) reserve(scheduleID uint) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.queued == nil {
+		r.queued = make(map[uint]struct{})
+	}
+	if _, queued := r.queued[scheduleID]; queued {
+		return false
+	}
+	r.queued[scheduleID] = struct{}{}
+	return true
+}
+
+func (r *Reservations) release(scheduleID uint) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.queued, scheduleID)
+}
+
+// Scheduler drives the schedules stored in one table. Model is a pointer to
+// the schedule model; the table must have the id, enabled, cron_expr,
+// max_keep, name_pattern, next_run_at, last_run_at, last_status and
+// last_error columns.
+type Scheduler struct {
+	DB        *gorm.DB
+	Model     any
+	QueueName string
+	// Kind names the guest type in log messages.
+	Kind         string
+	Lookup       func(scheduleID uint) (*Guest, error)
+	Reservations *Reservations
+}
+
+type scheduleRow struct {
+	ID          uint
+	CronExpr    string
+	MaxKeep     int
+	NamePattern string
+	NextRunAt   *time.Time
+}
+
+func NextRunTime(cronExpr string, now time.Time) (time.Time, error) {
+	spec := strings.TrimSpace(cronExpr)
+	if spec == "" {
+		return time.Time{}, errors.New("cron_expr_required")
+	}
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return schedule.Next(now), nil
+}
+
+// ExpandNamePattern fills in the placeholders of a schedule's name pattern.
+// {date} and {time} use the node's local time; tokens add the guest's own.
+func ExpandNamePattern(pattern string, at time.Time, tokens ...string) string {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		pattern = DefaultNamePattern
+	}
+
+	local := at.In(time.Local)
+	pairs := append([]string{
+		"{date}", local.Format("2006-01-02"),
+		"{time}", local.Format("15-04"),
+	}, tokens...)
+	return strings.NewReplacer(pairs...).Replace(pattern)
+}
+
+// ToPrune returns the oldest of ids past maxKeep. ids must be ordered oldest
+// first. A maxKeep of zero keeps them all.
+func ToPrune(ids []uint, maxKeep int) []uint {
+	if maxKeep <= 0 || len(ids) <= maxKeep {
+		return []uint{}
+	}
+	return append([]uint{}, ids[:len(ids)-maxKeep]...)
+}
+
+func ValidateInput(input Input) error {
+	if _, err := NextRunTime(input.CronExpr, time.Now()); err != nil {
+		return fmt.Errorf("invalid_cron_expr: %w", err)
+	}
+	if input.MaxKeep < 0 {
+		return fmt.Errorf("invalid_max_keep")
+	}
+	if len(strings.TrimSpace(input.NamePattern)) > MaxNamePatternLength {
+		return fmt.Errorf("name_pattern_too_long")
+	}
+	return nil
+}
+
+func (s *Scheduler) RegisterJobs() {
+	db.QueueRegisterJSONWithPolicy(s.QueueName, db.QueueHandlerErrorConsume, func(ctx context.Context, p payload) error {
+		defer s.Reservations.release(p.ScheduleID)
+
+		if p.ScheduleID == 0 {
+			logger.L.Warn().Str("kind", s.Kind).Msg("queued_snapshot_schedule_invalid_payload")
+			return nil
+		}
+
+		var schedule scheduleRow
+		if err := s.DB.Model(s.Model).Where("id = ?", p.ScheduleID).Take(&schedule).Error; err != nil {
+			logger.L.Warn().Err(err).Str("kind", s.Kind).Uint("schedule_id", p.ScheduleID).Msg("queued_snapshot_schedule_not_found")
+			return nil
+		}
+
+		if err := s.run(ctx, schedule); err != nil {
+			logger.L.Warn().Err(err).Str("kind", s.Kind).Uint("schedule_id", schedule.ID).Msg("scheduled_snapshot_failed")
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (s *Scheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(TickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.tick(ctx); err != nil {
+				logger.L.Warn().Err(err).Str("kind", s.Kind).Msg("snapshot_scheduler_tick_failed")
+			}
+		}
+	}
+}
+
+func (s *Scheduler) tick(ctx context.Context) error {
+	if s.DB == nil {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	var schedules []scheduleRow
+	if err := s.DB.Model(s.Model).Where("enabled = ? AND COALESCE(cron_expr, '') != ''", true).Find(&schedules).Error; err != nil {
+		return err
+	}
+
+	for _, schedule := range schedules {
+		nextAt, err := NextRunTime(schedule.CronExpr, now)
+		if err != nil {
+			_ = s.update(schedule.ID, map[string]any{
+				"last_status": "failed",
+				"last_error":  "invalid_cron_expr",
+				"next_run_at": nil,
+			})
+			continue
+		}
+
+		if schedule.NextRunAt == nil {
+			_ = s.update(schedule.ID, map[string]any{"next_run_at": nextAt})
+			continue
+		}
+
+		if now.Before(*schedule.NextRunAt) {
+			continue
+		}
+
+		if now.Sub(*schedule.NextRunAt) > CatchUpWindow {
+			logger.L.Warn().
+				Str("kind", s.Kind).
+				Uint("schedule_id", schedule.ID).
+				Time("next_run", *schedule.NextRunAt).
+				Msg("scheduled_snapshot_too_far_past_due_skipping")
+			_ = s.update(schedule.ID, map[string]any{"next_run_at": nextAt})
+			continue
+		}
+
+		if !s.Reservations.reserve(schedule.ID) {
+			continue
+		}
+
+		if err := s.update(schedule.ID, map[string]any{"next_run_at": nextAt}); err != nil {
+			s.Reservations.release(schedule.ID)
+			logger.L.Warn().Err(err).Str("kind", s.Kind).Uint("schedule_id", schedule.ID).Msg("failed_to_update_snapshot_next_run_at")
+			continue
+		}
+
+		enqueueCtx, enqueueCancel := context.WithTimeout(ctx, 5*time.Second)
+		if err := db.EnqueueJSON(enqueueCtx, s.QueueName, payload{ScheduleID: schedule.ID}); err != nil {
+			s.Reservations.release(schedule.ID)
+			logger.L.Warn().Err(err).Str("kind", s.Kind).Uint("schedule_id", schedule.ID).Msg("failed_to_enqueue_scheduled_snapshot")
+		}
+		enqueueCancel()
+	}
+
+	return nil
+}
+
+// run takes one scheduled snapshot and then prunes the schedule's oldest
+// snapshots past MaxKeep. Manual snapshots are left alone.
+func (s *Scheduler) run(ctx context.Context, schedule scheduleRow) (runErr error) {
+	startedAt := time.Now().UTC()
+	defer func() {
+		status, lastError := "success", ""
+		if runErr != nil {
+			status, lastError = "failed", runErr.Error()
+		}
+		_ = s.update(schedule.ID, map[string]any{
+			"last_run_at": startedAt,
+			"last_status": status,
+			"last_error":  lastError,
+		})
+	}()
+
+	guest, err := s.Lookup(schedule.ID)
+	if err != nil {
+		return err
+	}
+
+	name := ExpandNamePattern(schedule.NamePattern, startedAt, guest.Tokens...)
+	if err := guest.Create(ctx, name); err != nil {
+		return err
+	}
+
+	snapshots, err := guest.Snapshots()
+	if err != nil {
+		return fmt.Errorf("failed_to_list_scheduled_snapshots: %w", err)
+	}
+
+	for _, snapshotID := range ToPrune(snapshots, schedule.MaxKeep) {
+		if err := guest.Delete(ctx, snapshotID); err != nil {
+			return fmt.Errorf("failed_to_prune_snapshot: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Scheduler) update(scheduleID uint, values map[string]any) error {
+	return s.DB.Model(s.Model).Where("id = ?", scheduleID).Updates(values).Error
+}
@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package snapshotschedule
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/testutil"
+)
+
+func TestExpandNamePattern(t *testing.T) {
+	at := time.Date(2026, 3, 14, 9, 26, 0, 0, time.Local)
+
+	if got := ExpandNamePattern("", at, "{vm}", "web"); got != "auto-2026-03-14-09-26" {
+		t.Fatalf("default pattern expanded to %q", got)
+	}
+	if got := ExpandNamePattern("{vm}-{rid}-{date}", at, "{vm}", "web", "{rid}", "101"); got != "web-101-2026-03-14" {
+		t.Fatalf("custom pattern expanded to %q", got)
+	}
+	if got := ExpandNamePattern("nightly", at); got != "nightly" {
+		t.Fatalf("plain pattern expanded to %q", got)
+	}
+}
+
+func TestToPrune(t *testing.T) {
+	ids := []uint{4, 7, 9, 12}
+
+	if got := ToPrune(ids, 2); !slices.Equal(got, []uint{4, 7}) {
+		t.Fatalf("expected the two oldest to be pruned, got %v", got)
+	}
+	if got := ToPrune(ids, 4); len(got) != 0 {
+		t.Fatalf("expected nothing pruned at the limit, got %v", got)
+	}
+	if got := ToPrune(ids, 0); len(got) != 0 {
+		t.Fatalf("expected max keep 0 to keep everything, got %v", got)
+	}
+}
+
+func TestValidateInput(t *testing.T) {
+	if err := ValidateInput(Input{CronExpr: "0 3 * * *", MaxKeep: 7}); err != nil {
+		t.Fatalf("expected a valid schedule, got %v", err)
+	}
+	if err := ValidateInput(Input{CronExpr: "every day"}); err == nil {
+		t.Fatal("expected an invalid cron expression to be rejected")
+	}
+	if err := ValidateInput(Input{CronExpr: "@hourly", MaxKeep: -1}); err == nil {
+		t.Fatal("expected a negative max keep to be rejected")
+	}
+}
+
+type testSchedule struct {
+	ID          uint
+	Enabled     bool
+	CronExpr    string
+	MaxKeep     int
+	NamePattern string
+	LastRunAt   *time.Time
+	NextRunAt   *time.Time
+	LastStatus  string
+	LastError   string
+	UpdatedAt   time.Time
+}
+
+func TestRunSnapshotsThenPrunesAndRecordsStatus(t *testing.T) {
+	db := testutil.NewSQLiteTestDB(t, &testSchedule{})
+	schedule := testSchedule{Enabled: true, CronExpr: "@hourly", MaxKeep: 2, NamePattern: "{vm}-nightly"}
+	if err := db.Create(&schedule).Error; err != nil {
+		t.Fatalf("failed to seed schedule: %v", err)
+	}
+
+	snapshots := []uint{3, 5}
+	var created []string
+	var deleted []uint
+	failCreate := false
+	s := &Scheduler{
+		DB:           db,
+		Model:        &testSchedule{},
+		Kind:         "vm",
+		Reservations: &Reservations{},
+		Lookup: func(scheduleID uint) (*Guest, error) {
+			return &Guest{
+				Tokens: []string{"{vm}", "web"},
+				Create: func(ctx context.Context, name string) error {
+					if failCreate {
+						return errors.New("pool_busy")
+					}
+					created = append(created, name)
+					snapshots = append(snapshots, 8)
+					return nil
+				},
+				Snapshots: func() ([]uint, error) { return snapshots, nil },
+				Delete: func(ctx context.Context, snapshotID uint) error {
+					deleted = append(deleted, snapshotID)
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	if err := s.run(context.Background(), scheduleRow{ID: schedule.ID, MaxKeep: 2, NamePattern: schedule.NamePattern}); err != nil {
+		t.Fatalf("run returned error: %v", err)
+	}
+	if !slices.Equal(created, []string{"web-nightly"}) || !slices.Equal(deleted, []uint{3}) {
+		t.Fatalf("unexpected run: created %v, deleted %v", created, deleted)
+	}
+
+	var stored testSchedule
+	if err := db.First(&stored, schedule.ID).Error; err != nil {
+		t.Fatalf("failed to reload schedule: %v", err)
+	}
+	if stored.LastStatus != "success" || stored.LastRunAt == nil {
+		t.Fatalf("expected a recorded success, got %+v", stored)
+	}
+
+	failCreate = true
+	if err := s.run(context.Background(), scheduleRow{ID: schedule.ID, MaxKeep: 2}); err == nil {
+		t.Fatal("expected a failed snapshot to fail the run")
+	}
+	if err := db.First(&stored, schedule.ID).Error; err != nil {
+		t.Fatalf("failed to reload schedule: %v", err)
+	}
+	if stored.LastStatus != "failed" || stored.LastError != "pool_busy" {
+		t.Fatalf("expected a recorded failure, got %+v", stored)
+	}
+}
+
+func TestReservationsKeepOneRunQueued(t *testing.T) {
+	var r Reservations
+
+	if !r.reserve(1) {
+		t.Fatal("expected the first reservation to succeed")
+	}
+	if r.reserve(1) {
+		t.Fatal("expected a queued schedule not to be queued again")
+	}
+	r.release(1)
+	if !r.reserve(1) {
+		t.Fatal("expected a released schedule to be queued again")
+	}
+}
+
+func TestTickSchedulesAndSkipsRunsPastCatchUp(t *testing.T) {
+	db := testutil.NewSQLiteTestDB(t, &testSchedule{})
+	fresh := testSchedule{Enabled: true, CronExpr: "@hourly"}
+	stale := time.Now().UTC().Add(-CatchUpWindow - time.Hour)
+	missed := testSchedule{Enabled: true, CronExpr: "@hourly", NextRunAt: &stale}
+	if err := db.Create(&fresh).Error; err != nil {
+		t.Fatalf("failed to seed schedule: %v", err)
+	}
+	if err := db.Create(&missed).Error; err != nil {
+		t.Fatalf("failed to seed schedule: %v", err)
+	}
+
+	s := &Scheduler{
+		DB:           db,
+		Model:        &testSchedule{},
+		Kind:         "jail",
+		Reservations: &Reservations{},
+		Lookup: func(scheduleID uint) (*Guest, error) {
+			t.Fatalf("schedule %d should not run", scheduleID)
+			return nil, nil
+		},
+	}
+	if err := s.tick(context.Background()); err != nil {
+		t.Fatalf("tick returned error: %v", err)
+	}
+
+	for _, id := range []uint{fresh.ID, missed.ID} {
+		var stored testSchedule
+		if err := db.First(&stored, id).Error; err != nil {
+			t.Fatalf("failed to reload schedule: %v", err)
+		}
+		if stored.NextRunAt == nil || !stored.NextRunAt.After(time.Now()) {
+			t.Fatalf("expected schedule %d to move to its next run, got %+v", id, stored)
+		}
+	}
+	if !s.Reservations.reserve(missed.ID) {
+		t.Fatal("expected a skipped run to leave no reservation behind")
+	}
+}
@@ -201,10 +201,18 @@ func (s *Service) Initialize(authService serviceInterfaces.AuthServiceInterface,
 		}
 	}
 
+	if err := s.Network.ReconcileHostInterfaces(); err != nil {
+		logger.L.Error().Err(err).Msg("failed_to_reconcile_host_interfaces_on_startup")
+	}
+
 	if err := s.Network.ReconcileManagedRoutes(); err != nil {
 		logger.L.Error().Err(err).Msg("failed_to_reconcile_managed_routes_on_startup")
 	}
 
+	if err := s.Network.RestoreSRIOV(); err != nil {
+		logger.L.Error().Err(err).Msg("failed_to_restore_sriov_vfs_on_startup")
+	}
+
 	if err := s.System.ReconcilePreparedPPTDevices(); err != nil {
 		return fmt.Errorf("failed to reconcile prepared passthrough devices: %w", err)
 	}
@@ -213,6 +221,10 @@ func (s *Service) Initialize(authService serviceInterfaces.AuthServiceInterface,
 		return fmt.Errorf("failed to sync passthrough devices: %w", err)
 	}
 
+	if _, err := s.System.CheckPPTReservations(); err != nil {
+		logger.L.Error().Err(err).Msg("failed_to_check_ppt_reservations_on_startup")
+	}
+
 	if slices.Contains(basicSettings.Services, models.SambaServer) {
 		if err := s.InitSamba(ctx); err != nil {
 			return fmt.Errorf("failed to initialize Samba: %w", err)
@@ -267,6 +279,22 @@ func (s *Service) Initialize(authService serviceInterfaces.AuthServiceInterface,
 				}
 			}
 		}()
+
+		go func() {
+			ticker := time.NewTicker(15 * time.Second)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-dCtx.Done():
+					return
+				case <-ticker.C:
+					if err := s.Libvirt.SampleVMFlows(); err != nil {
+						logger.L.Debug().Err(err).Msg("failed_to_sample_vm_flows")
+					}
+				}
+			}
+		}()
 	}
 
 	s.Cluster.StartClusterMonitors(dCtx)
@@ -0,0 +1,302 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package system
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/alchemillahq/sylve/internal/config"
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	systemServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/system"
+	"gorm.io/gorm"
+)
+
+// Unlike the file explorer, the managed file browser only reaches directories
+// Sylve owns: the local-path downloads directory, where an uploaded ISO can be
+// imported from, and jail roots. Jail roots are written by root inside the
+// jail, so every access goes through an os.Root opened on the managed root:
+// symlinks are followed only while they stay inside it, and a path component
+// swapped for a link between a check and its use cannot redirect the access
+// to the host.
+
+const (
+	ManagedFileRootISOs = "isos"
+	ManagedFileRootJail = "jail"
+
+	// ManagedFileMaxUploadBytes caps a single upload; large enough for an
+	// installer DVD, small enough that a runaway client cannot fill the pool.
+	ManagedFileMaxUploadBytes int64 = 16 << 30
+)
+
+func (s *Service) managedFileRootPath(root systemServiceInterfaces.ManagedFileRoot) (string, error) {
+	switch root.Kind {
+	case ManagedFileRootISOs:
+		path := config.GetDownloadsPath("path")
+		if err := os.MkdirAll(path, 0755); err != nil {
+			return "", fmt.Errorf("managed_file_root_unavailable: %w", err)
+		}
+		return filepath.EvalSymlinks(path)
+	case ManagedFileRootJail:
+		if root.CTID == 0 {
+			return "", fmt.Errorf("managed_file_root_invalid")
+		}
+		if s.DB != nil {
+			var jail jailModels.Jail
+			if err := s.DB.Select("id").Where("ct_id = ?", root.CTID).First(&jail).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return "", fmt.Errorf("jail_not_found")
+				}
+				return "", err
+			}
+		}
+		jailsPath, err := config.GetJailsPath()
+		if err != nil {
+			return "", err
+		}
+		path, err := filepath.EvalSymlinks(filepath.Join(jailsPath, strconv.FormatUint(uint64(root.CTID), 10)))
+		if err != nil {
+			return "", fmt.Errorf("managed_file_root_unavailable: %w", err)
+		}
+		return path, nil
+	}
+	return "", fmt.Errorf("managed_file_root_invalid")
+}
+
+// openManagedFileRoot opens the directory of a managed root. The root itself
+// is a Sylve-owned path; everything under it is reached through the
+// returned os.Root only.
+func (s *Service) openManagedFileRoot(root systemServiceInterfaces.ManagedFileRoot) (*os.Root, error) {
+	rootPath, err := s.managedFileRootPath(root)
+	if err != nil {
+		return nil, err
+	}
+	r, err := os.OpenRoot(rootPath)
+	if err != nil {
+		return nil, fmt.Errorf("managed_file_root_unavailable: %w", err)
+	}
+	return r, nil
+}
+
+// managedFileRel turns a client path into one relative to the root. ".."
+// is clamped at the root rather than walking out of it.
+func managedFileRel(rel string) (string, error) {
+	if strings.ContainsRune(rel, 0) {
+		return "", fmt.Errorf("managed_file_path_invalid")
+	}
+	cleaned := strings.TrimPrefix(filepath.Clean("/"+rel), "/")
+	if cleaned == "" {
+		return ".", nil
+	}
+	return cleaned, nil
+}
+
+// managedFileError maps os.Root errors onto the managed file API errors.
+func managedFileError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case strings.Contains(err.Error(), "path escapes from parent"):
+		return fmt.Errorf("managed_file_path_outside_root")
+	case os.IsNotExist(err):
+		return fmt.Errorf("managed_file_not_found")
+	}
+	return err
+}
+
+func managedFileEntry(rel string, info os.FileInfo) systemServiceInterfaces.ManagedFileEntry {
+	entry := systemServiceInterfaces.ManagedFileEntry{
+		Name: info.Name(),
+		Path: filepath.ToSlash(rel),
+		Date: info.ModTime(),
+	}
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		entry.Type = "link"
+	case info.IsDir():
+		entry.Type = "folder"
+	default:
+		entry.Type = "file"
+		entry.Size = info.Size()
+	}
+	return entry
+}
+
+func (s *Service) ListManagedFiles(root systemServiceInterfaces.ManagedFileRoot, rel string) ([]systemServiceInterfaces.ManagedFileEntry, error) {
+	dirRel, err := managedFileRel(rel)
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.openManagedFileRoot(root)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	dir, err := r.OpenRoot(dirRel)
+	if err != nil {
+		return nil, managedFileError(err)
+	}
+	defer dir.Close()
+
+	f, err := dir.Open(".")
+	if err != nil {
+		return nil, managedFileError(err)
+	}
+	names, err := f.Readdirnames(-1)
+	f.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]systemServiceInterfaces.ManagedFileEntry, 0, len(names))
+	for _, name := range names {
+		info, err := dir.Lstat(name)
+		if err != nil {
+			continue
+		}
+		nodes = append(nodes, managedFileEntry(filepath.Join(dirRel, name), info))
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if (nodes[i].Type == "folder") != (nodes[j].Type == "folder") {
+			return nodes[i].Type == "folder"
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+
+	return nodes, nil
+}
+
+// OpenManagedFile opens a regular file inside a managed root for the
+// download handler to stream. The caller closes the file.
+func (s *Service) OpenManagedFile(root systemServiceInterfaces.ManagedFileRoot, rel string) (*os.File, os.FileInfo, error) {
+	fileRel, err := managedFileRel(rel)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := s.openManagedFileRoot(root)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer r.Close()
+
+	// O_NONBLOCK keeps a FIFO planted in a jail from hanging the open; it is
+	// refused below like any other non-regular file.
+	f, err := r.OpenFile(fileRel, os.O_RDONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, nil, managedFileError(err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	if !info.Mode().IsRegular() {
+		f.Close()
+		return nil, nil, fmt.Errorf("managed_file_not_regular")
+	}
+	return f, info, nil
+}
+
+// SaveManagedFile writes src as name into the folder rel of a managed root.
+// Existing files are never replaced, and an upload larger than maxBytes is
+// discarded rather than left behind truncated.
+func (s *Service) SaveManagedFile(
+	root systemServiceInterfaces.ManagedFileRoot,
+	rel string,
+	name string,
+	src io.Reader,
+	maxBytes int64,
+) (systemServiceInterfaces.ManagedFileEntry, error) {
+	var entry systemServiceInterfaces.ManagedFileEntry
+
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\x00") {
+		return entry, fmt.Errorf("managed_file_name_invalid")
+	}
+	dirRel, err := managedFileRel(rel)
+	if err != nil {
+		return entry, err
+	}
+
+	rootPath, err := s.managedFileRootPath(root)
+	if err != nil {
+		return entry, err
+	}
+	if err := s.EnsureFileExplorerMutationAllowed(filepath.Join(rootPath, dirRel, name)); err != nil {
+		return entry, err
+	}
+
+	r, err := s.openManagedFileRoot(root)
+	if err != nil {
+		return entry, err
+	}
+	defer r.Close()
+
+	// The folder is pinned once; the temporary file, the link and the final
+	// stat all resolve relative to it.
+	dir, err := r.OpenRoot(dirRel)
+	if err != nil {
+		if info, statErr := r.Stat(dirRel); statErr == nil && !info.IsDir() {
+			return entry, fmt.Errorf("managed_file_parent_not_folder")
+		}
+		return entry, managedFileError(err)
+	}
+	defer dir.Close()
+
+	if _, err := dir.Lstat(name); err == nil {
+		return entry, fmt.Errorf("managed_file_exists")
+	} else if !os.IsNotExist(err) {
+		return entry, managedFileError(err)
+	}
+
+	tmpName := ".sylve-upload-" + rand.Text()
+	tmp, err := dir.OpenFile(tmpName, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return entry, managedFileError(err)
+	}
+	defer dir.Remove(tmpName)
+
+	written, err := io.Copy(tmp, io.LimitReader(src, maxBytes+1))
+	if err == nil {
+		err = tmp.Chmod(0644)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return entry, err
+	}
+	if written > maxBytes {
+		return entry, fmt.Errorf("managed_file_too_large")
+	}
+
+	// A hard link fails if the name appeared in the meantime, where a rename
+	// would silently replace it.
+	if err := dir.Link(tmpName, name); err != nil {
+		if os.IsExist(err) {
+			return entry, fmt.Errorf("managed_file_exists")
+		}
+		return entry, managedFileError(err)
+	}
+
+	info, err := dir.Lstat(name)
+	if err != nil {
+		return entry, err
+	}
+	return managedFileEntry(filepath.Join(dirRel, name), info), nil
+}
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alchemillahq/sylve/internal/config"
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	systemServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/system"
+	"github.com/alchemillahq/sylve/internal/testutil"
+)
+
+func TestManagedFilesStayInsideRoot(t *testing.T) {
+	t.Setenv("SYLVE_DATA_PATH", t.TempDir())
+	service := &Service{}
+	isos := systemServiceInterfaces.ManagedFileRoot{Kind: ManagedFileRootISOs}
+
+	entry, err := service.SaveManagedFile(isos, "", "install.iso", strings.NewReader("iso"), 16)
+	if err != nil {
+		t.Fatalf("unexpected upload error: %v", err)
+	}
+	if entry.Path != "install.iso" || entry.Size != 3 {
+		t.Fatalf("unexpected uploaded entry %+v", entry)
+	}
+
+	if _, err := service.SaveManagedFile(isos, "", "install.iso", strings.NewReader("again"), 16); err == nil || err.Error() != "managed_file_exists" {
+		t.Fatalf("expected an existing file to be kept, got %v", err)
+	}
+	if _, err := service.SaveManagedFile(isos, "", "big.iso", strings.NewReader("0123456789"), 4); err == nil || err.Error() != "managed_file_too_large" {
+		t.Fatalf("expected an oversized upload to be refused, got %v", err)
+	}
+	if _, err := service.SaveManagedFile(isos, "", "../escape.iso", strings.NewReader("x"), 16); err == nil {
+		t.Fatal("expected a name with a separator to be refused")
+	}
+
+	// ".." is clamped to the root rather than walking out of it.
+	file, info, err := service.OpenManagedFile(isos, "../../install.iso")
+	if err != nil {
+		t.Fatalf("unexpected download error: %v", err)
+	}
+	file.Close()
+	if info.Name() != "install.iso" {
+		t.Fatalf("unexpected download file %q", info.Name())
+	}
+
+	outside := filepath.Join(t.TempDir(), "passwd")
+	if err := os.WriteFile(outside, []byte("root"), 0o644); err != nil {
+		t.Fatalf("failed to write outside file: %v", err)
+	}
+	if err := os.Symlink(outside, filepath.Join(config.GetDownloadsPath("path"), "passwd")); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+	if _, _, err := service.OpenManagedFile(isos, "passwd"); err == nil || err.Error() != "managed_file_path_outside_root" {
+		t.Fatalf("expected a symlink out of the root to be refused, got %v", err)
+	}
+
+	// A folder swapped for a link to the host is refused for both listing
+	// and uploads, not followed.
+	if err := os.Symlink(filepath.Dir(outside), filepath.Join(config.GetDownloadsPath("path"), "etc")); err != nil {
+		t.Fatalf("failed to create folder symlink: %v", err)
+	}
+	if _, err := service.ListManagedFiles(isos, "etc"); err == nil || err.Error() != "managed_file_path_outside_root" {
+		t.Fatalf("expected listing through a folder link to be refused, got %v", err)
+	}
+	if _, err := service.SaveManagedFile(isos, "etc", "dropped", strings.NewReader("x"), 16); err == nil || err.Error() != "managed_file_path_outside_root" {
+		t.Fatalf("expected an upload through a folder link to be refused, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(outside), "dropped")); !os.IsNotExist(err) {
+		t.Fatalf("expected nothing written outside the root, got %v", err)
+	}
+
+	entries, err := service.ListManagedFiles(isos, "")
+	if err != nil {
+		t.Fatalf("unexpected list error: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected the upload and the two links, got %+v", entries)
+	}
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name, ".sylve-upload-") {
+			t.Fatalf("expected no leftover temporary upload, got %+v", entries)
+		}
+	}
+}
+
+func TestManagedFilesJailRootRequiresKnownJail(t *testing.T) {
+	t.Setenv("SYLVE_DATA_PATH", t.TempDir())
+	db := testutil.NewSQLiteTestDB(t, &jailModels.Jail{})
+	service := &Service{DB: db}
+
+	if _, err := service.ListManagedFiles(systemServiceInterfaces.ManagedFileRoot{Kind: ManagedFileRootJail, CTID: 42}, ""); err == nil || err.Error() != "jail_not_found" {
+		t.Fatalf("expected an unknown jail to be refused, got %v", err)
+	}
+	if _, err := service.ListManagedFiles(systemServiceInterfaces.ManagedFileRoot{Kind: "pool"}, ""); err == nil || err.Error() != "managed_file_root_invalid" {
+		t.Fatalf("expected an unknown root to be refused, got %v", err)
+	}
+}
@@ -18,8 +18,8 @@ import (
 	"strings"
 
 	"github.com/alchemillahq/sylve/internal/db/models"
-	"github.com/alchemillahq/sylve/internal/logger"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/pkg/system/pciconf"
 	"github.com/alchemillahq/sylve/pkg/utils"
 
@@ -221,6 +221,10 @@ func (s *Service) writeLoaderConf(lines []string, perm os.FileMode) error {
 		out = strings.Join(lines, "\n") + "\n"
 	}
 
+	if current, err := os.ReadFile(loaderConfPath); err == nil && string(current) == out {
+		return nil
+	}
+
 	if err := os.WriteFile(loaderConfPath, []byte(out), perm); err != nil {
 		return fmt.Errorf("writing %s: %w", loaderConfPath, err)
 	}
@@ -278,6 +282,27 @@ func pciAddress(domain int, parts [3]int) string {
 	return fmt.Sprintf("pci%d:%d:%d:%d", domain, parts[0], parts[1], parts[2])
 }
 
+// bindPPTDriver detaches whatever driver currently owns pciAddr and hands the
+// device to ppt so bhyve can pass it through.
+func bindPPTDriver(pciAddr string) error {
+	detach, err := utils.RunCommand("/usr/sbin/devctl", "detach", "-f", pciAddr)
+	if err != nil && !strings.HasSuffix(strings.TrimSpace(detach), "Device not configured") {
+		return fmt.Errorf("detach failed %s: %w", detach, err)
+	}
+
+	clearDriver, err := utils.RunCommand("/usr/sbin/devctl", "clear", "driver", "-f", pciAddr)
+	if err != nil {
+		return fmt.Errorf("clearing driver failed %s: %w", clearDriver, err)
+	}
+
+	setDriver, err := utils.RunCommand("/usr/sbin/devctl", "set", "driver", pciAddr, "ppt")
+	if err != nil {
+		return fmt.Errorf("setting driver failed %s: %w", setDriver, err)
+	}
+
+	return nil
+}
+
 func (s *Service) addLoaderPPTDevice(id string) error {
 	s.syncMutex.Lock()
 	defer s.syncMutex.Unlock()
@@ -351,16 +376,16 @@ func (s *Service) SyncPPTDevices() error {
 	}
 
 	for _, rec := range ids {
-		if rec.Domain == 0 {
-			if _, ok := known[rec.DeviceID]; ok {
-				continue
-			}
-			parts = append(parts, rec.DeviceID)
-			known[rec.DeviceID] = struct{}{}
-		} else {
-			// Todo: Please do MANUAL SYNC!
-			fmt.Printf("Warning: Device %s is on domain %d. Skipping loader.conf sync.\n", rec.DeviceID, rec.Domain)
+		// pptdevs only addresses domain 0; devices on other domains are
+		// re-bound with devctl by CheckPPTReservations instead.
+		if rec.Domain != 0 {
+			continue
+		}
+		if _, ok := known[rec.DeviceID]; ok {
+			continue
 		}
+		parts = append(parts, rec.DeviceID)
+		known[rec.DeviceID] = struct{}{}
 	}
 
 	lines = rewriteLoaderPPTIDs(lines, parts)
@@ -467,41 +492,8 @@ func (s *Service) AddPPTDevice(domain string, id string) error {
 	driver := device.Name
 	pciAddr := pciAddress(intDomain, parts)
 
-	detach, err := utils.RunCommand(
-		"/usr/sbin/devctl",
-		"detach",
-		"-f",
-		pciAddr,
-	)
-
-	if err != nil {
-		if !strings.HasSuffix(strings.TrimSpace(detach), "Device not configured") {
-			return fmt.Errorf("detaching device %s on root bus %s failed %s: %w", id, domain, detach, err)
-		}
-	}
-
-	clearDriver, err := utils.RunCommand(
-		"/usr/sbin/devctl",
-		"clear",
-		"driver",
-		"-f",
-		pciAddr,
-	)
-
-	if err != nil {
-		return fmt.Errorf("clearing driver for device %s on root bus %s failed %s: %w", id, domain, clearDriver, err)
-	}
-
-	setDriver, err := utils.RunCommand(
-		"/usr/sbin/devctl",
-		"set",
-		"driver",
-		pciAddr,
-		"ppt",
-	)
-
-	if err != nil {
-		return fmt.Errorf("setting driver for device %s on root bus %s failed %s: %w", id, domain, setDriver, err)
+	if err := bindPPTDriver(pciAddr); err != nil {
+		return fmt.Errorf("binding device %s on root bus %s to ppt failed: %w", id, domain, err)
 	}
 
 	newID := models.PassedThroughIDs{
@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package system
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/db/models"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/system/pciconf"
+
+	"gorm.io/gorm"
+)
+
+const (
+	pptReasonNotPresent = "device_not_present"
+	pptReasonBadAddress = "invalid_device_address"
+)
+
+// pptReservationState reports whether a reserved device is usable right now.
+// A device is usable when it is present at its address and bound to ppt.
+func pptReservationState(rec models.PassedThroughIDs, devices []pciconf.PCIDevice) (pciconf.PCIDevice, bool, string) {
+	parts, err := parsePPTAddress(rec.DeviceID)
+	if err != nil {
+		return pciconf.PCIDevice{}, false, pptReasonBadAddress
+	}
+
+	device, found := findPCIDeviceByDomainAndAddress(devices, rec.Domain, parts)
+	if !found {
+		return pciconf.PCIDevice{}, false, pptReasonNotPresent
+	}
+
+	if !strings.HasPrefix(device.Name, "ppt") {
+		name := strings.TrimSpace(device.Name)
+		if name == "" {
+			name = "none"
+		}
+		return device, false, fmt.Sprintf("device_attached_to_%s", name)
+	}
+
+	return device, true, ""
+}
+
+func (s *Service) recordPPTReservationState(rec *models.PassedThroughIDs, ok bool, reason string) {
+	now := time.Now().UTC()
+	if err := s.DB.Model(rec).Updates(map[string]any{
+		"missing":        !ok,
+		"missing_reason": reason,
+		"checked_at":     &now,
+	}).Error; err != nil {
+		logger.L.Warn().Err(err).Str("device_id", rec.DeviceID).Msg("failed_to_record_ppt_reservation_state")
+	}
+}
+
+// CheckPPTReservations walks every reserved device after boot. Devices on
+// non-zero domains, which pptdevs cannot express, are re-bound to ppt with
+// devctl; anything still unusable afterwards is flagged as missing.
+func (s *Service) CheckPPTReservations() ([]models.PassedThroughIDs, error) {
+	s.achMutex.Lock()
+	defer s.achMutex.Unlock()
+
+	var records []models.PassedThroughIDs
+	if err := s.DB.Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("loading PassedThroughIDs: %w", err)
+	}
+	if len(records) == 0 {
+		return records, nil
+	}
+
+	devices, err := pciconf.GetPCIDevices()
+	if err != nil {
+		return nil, fmt.Errorf("getting PCI devices: %w", err)
+	}
+
+	for i := range records {
+		rec := &records[i]
+		device, ok, reason := pptReservationState(*rec, devices)
+
+		if !ok && rec.Domain != 0 && reason != pptReasonNotPresent && reason != pptReasonBadAddress {
+			parts, _ := parsePPTAddress(rec.DeviceID)
+			if err := bindPPTDriver(pciAddress(rec.Domain, parts)); err != nil {
+				logger.L.Warn().Err(err).Str("device_id", rec.DeviceID).Str("driver", device.Name).
+					Msg("failed_to_rebind_ppt_device")
+			} else {
+				ok, reason = true, ""
+			}
+		}
+
+		if !ok {
+			logger.L.Warn().Str("device_id", rec.DeviceID).Int("domain", rec.Domain).Str("reason", reason).
+				Msg("reserved_ppt_device_missing")
+		}
+
+		s.recordPPTReservationState(rec, ok, reason)
+		rec.Missing = !ok
+		rec.MissingReason = reason
+	}
+
+	return records, nil
+}
+
+// VerifyPPTDevices is the VM start guard: every referenced reservation must
+// exist and be bound to ppt, otherwise bhyve would fail half way through
+// booting the guest.
+func (s *Service) VerifyPPTDevices(ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var records []models.PassedThroughIDs
+	if err := s.DB.Where("id IN ?", ids).Find(&records).Error; err != nil {
+		return fmt.Errorf("loading PassedThroughIDs: %w", err)
+	}
+
+	found := make(map[int]struct{}, len(records))
+	for _, rec := range records {
+		found[rec.ID] = struct{}{}
+	}
+	for _, id := range ids {
+		if _, ok := found[id]; !ok {
+			return fmt.Errorf("ppt_device_%d_not_reserved", id)
+		}
+	}
+
+	devices, err := pciconf.GetPCIDevices()
+	if err != nil {
+		return fmt.Errorf("getting PCI devices: %w", err)
+	}
+
+	for i := range records {
+		rec := &records[i]
+		_, ok, reason := pptReservationState(*rec, devices)
+		if rec.Missing == ok || rec.MissingReason != reason {
+			s.recordPPTReservationState(rec, ok, reason)
+		}
+		if !ok {
+			return fmt.Errorf("ppt_device_%d_missing: %s is %s, reclaim or remove the reservation", rec.ID, rec.DeviceID, reason)
+		}
+	}
+
+	return nil
+}
+
+// ReclaimPPTDevice re-binds a reserved device that came back under another
+// driver (or none) to ppt and clears its missing flag.
+func (s *Service) ReclaimPPTDevice(id string) error {
+	s.achMutex.Lock()
+	defer s.achMutex.Unlock()
+
+	var rec models.PassedThroughIDs
+	if err := s.DB.Where("id = ?", id).First(&rec).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return fmt.Errorf("device ID %s not found", id)
+		}
+		return fmt.Errorf("checking PassedThroughIDs: %w", err)
+	}
+
+	devices, err := pciconf.GetPCIDevices()
+	if err != nil {
+		return fmt.Errorf("getting PCI devices: %w", err)
+	}
+
+	device, ok, reason := pptReservationState(rec, devices)
+	if reason == pptReasonNotPresent || reason == pptReasonBadAddress {
+		s.recordPPTReservationState(&rec, false, reason)
+		return fmt.Errorf("ppt_device_%d_not_present: %s", rec.ID, rec.DeviceID)
+	}
+
+	if !ok {
+		parts, _ := parsePPTAddress(rec.DeviceID)
+		if err := bindPPTDriver(pciAddress(rec.Domain, parts)); err != nil {
+			return fmt.Errorf("reclaiming device %s failed: %w", rec.DeviceID, err)
+		}
+		if strings.TrimSpace(rec.OldDriver) == "" && strings.TrimSpace(device.Name) != "" {
+			if err := s.DB.Model(&rec).Update("old_driver", device.Name).Error; err != nil {
+				logger.L.Warn().Err(err).Str("device_id", rec.DeviceID).Msg("failed_to_record_ppt_old_driver")
+			}
+		}
+	}
+
+	s.recordPPTReservationState(&rec, true, "")
+
+	if rec.Domain == 0 {
+		return s.SyncPPTDevices()
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package system
+
+import (
+	"testing"
+
+	"github.com/alchemillahq/sylve/internal/db/models"
+	"github.com/alchemillahq/sylve/pkg/system/pciconf"
+)
+
+func TestPPTReservationState(t *testing.T) {
+	devices := []pciconf.PCIDevice{
+		{Name: "ppt", Domain: 0, Bus: 3, Device: 0, Function: 0},
+		{Name: "em", Domain: 0, Bus: 4, Device: 0, Function: 1},
+		{Name: "ppt", Domain: 1, Bus: 5, Device: 0, Function: 0},
+	}
+
+	tests := []struct {
+		rec    models.PassedThroughIDs
+		ok     bool
+		reason string
+	}{
+		{models.PassedThroughIDs{DeviceID: "3/0/0"}, true, ""},
+		{models.PassedThroughIDs{DeviceID: "5/0/0", Domain: 1}, true, ""},
+		{models.PassedThroughIDs{DeviceID: "4/0/1"}, false, "device_attached_to_em"},
+		{models.PassedThroughIDs{DeviceID: "5/0/0"}, false, pptReasonNotPresent},
+		{models.PassedThroughIDs{DeviceID: "bogus"}, false, pptReasonBadAddress},
+	}
+
+	for _, tt := range tests {
+		_, ok, reason := pptReservationState(tt.rec, devices)
+		if ok != tt.ok || reason != tt.reason {
+			t.Fatalf("%s@%d: got ok=%v reason=%q, want ok=%v reason=%q",
+				tt.rec.DeviceID, tt.rec.Domain, ok, reason, tt.ok, tt.reason)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package system
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/alchemillahq/gzfs"
+	"github.com/alchemillahq/sylve/internal/db"
+	"github.com/alchemillahq/sylve/internal/db/models"
+	zfsModels "github.com/alchemillahq/sylve/internal/db/models/zfs"
+	systemServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/system"
+	"github.com/alchemillahq/sylve/internal/layout"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	"gorm.io/gorm"
+)
+
+var poolImportRunCommand = utils.RunCommandWithContext
+
+// parseImportablePools reads the human readable output of a bare
+// `zpool import`, which has no scripted mode. Multi-line status and action
+// texts are folded into one line; the config tree is skipped.
+func parseImportablePools(output string) []systemServiceInterfaces.AdoptablePool {
+	var pools []systemServiceInterfaces.AdoptablePool
+	var current *systemServiceInterfaces.AdoptablePool
+	lastKey := ""
+
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		key, value, hasKey := strings.Cut(trimmed, ":")
+		if hasKey && !strings.Contains(key, " ") {
+			value = strings.TrimSpace(value)
+			switch key {
+			case "pool":
+				pools = append(pools, systemServiceInterfaces.AdoptablePool{Name: value})
+				current = &pools[len(pools)-1]
+			case "id":
+				if current != nil {
+					current.ID = value
+				}
+			case "state":
+				if current != nil {
+					current.State = value
+				}
+			case "status":
+				if current != nil {
+					current.Status = value
+				}
+			case "action":
+				if current != nil {
+					current.Action = value
+				}
+			}
+			lastKey = key
+			continue
+		}
+
+		if current == nil || trimmed == "" {
+			continue
+		}
+		switch lastKey {
+		case "status":
+			current.Status = strings.TrimSpace(current.Status + " " + trimmed)
+		case "action":
+			current.Action = strings.TrimSpace(current.Action + " " + trimmed)
+		}
+	}
+
+	return pools
+}
+
+func checkAdoptPoolHealth(state string, allowDegraded bool) error {
+	switch gzfs.ZPoolState(strings.ToUpper(strings.TrimSpace(state))) {
+	case gzfs.ZPoolStateOnline:
+		return nil
+	case gzfs.ZPoolStateDegraded:
+		if allowDegraded {
+			return nil
+		}
+		return fmt.Errorf("pool_degraded_confirmation_required")
+	}
+	return fmt.Errorf("pool_unhealthy: %s", state)
+}
+
+func (s *Service) hasSylveSkeleton(ctx context.Context, poolName string) bool {
+	for _, dataset := range layout.Paths() {
+		found, err := s.GZFS.ZFS.Get(ctx, fmt.Sprintf("%s/%s", poolName, dataset), false)
+		if err != nil || found == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// ListAdoptablePools returns pools that exist on this host but are not yet
+// usable by Sylve, whether or not they are currently imported.
+func (s *Service) ListAdoptablePools(ctx context.Context) ([]systemServiceInterfaces.AdoptablePool, error) {
+	var basicSettings models.BasicSettings
+	if err := s.DB.First(&basicSettings).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_get_basic_settings: %w", err)
+	}
+
+	pools := []systemServiceInterfaces.AdoptablePool{}
+
+	imported, err := s.GZFS.Zpool.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed_to_list_pools: %w", err)
+	}
+	for _, pool := range imported {
+		if slices.Contains(basicSettings.Pools, pool.Name) {
+			continue
+		}
+		pools = append(pools, systemServiceInterfaces.AdoptablePool{
+			Name:        pool.Name,
+			ID:          pool.PoolGUID,
+			State:       string(pool.State),
+			Imported:    true,
+			HasSkeleton: s.hasSylveSkeleton(ctx, pool.Name),
+		})
+	}
+
+	// zpool import exits non-zero when nothing is importable; only its
+	// output matters here.
+	output, _ := poolImportRunCommand(ctx, "zpool", "import")
+	pools = append(pools, parseImportablePools(output)...)
+
+	return pools, nil
+}
+
+// AdoptPool imports a pool created outside Sylve if needed, checks that it is
+// healthy, optionally lays down the sylve dataset skeleton and adds it to the
+// usable pools so new guests can be placed on it. A pool that this call
+// imported is exported again if any later step fails.
+func (s *Service) AdoptPool(
+	ctx context.Context,
+	req systemServiceInterfaces.AdoptPoolRequest,
+) (systemServiceInterfaces.AdoptPoolResult, error) {
+	name := strings.TrimSpace(req.Name)
+	result := systemServiceInterfaces.AdoptPoolResult{Name: name, CreatedDatasets: []string{}}
+
+	if !utils.IsValidZFSPoolName(name) {
+		return result, fmt.Errorf("invalid_pool_name")
+	}
+
+	s.serviceSettingsMutex.Lock()
+	defer s.serviceSettingsMutex.Unlock()
+
+	var basicSettings models.BasicSettings
+	if err := s.DB.First(&basicSettings).Error; err != nil {
+		return result, fmt.Errorf("failed_to_get_basic_settings: %w", err)
+	}
+	if slices.Contains(basicSettings.Pools, name) {
+		return result, fmt.Errorf("pool_already_usable")
+	}
+
+	names, err := s.GZFS.Zpool.GetPoolNames(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed_to_get_existing_pools: %w", err)
+	}
+
+	if !slices.Contains(names, name) {
+		if err := s.importAdoptedPool(ctx, name, req.AllowDegraded); err != nil {
+			return result, err
+		}
+		result.Imported = true
+	}
+
+	success := false
+	defer func() {
+		if success || !result.Imported {
+			return
+		}
+		if out, err := poolImportRunCommand(context.WithoutCancel(ctx), "zpool", "export", name); err != nil {
+			logger.L.Warn().Err(err).Str("pool", name).Str("output", out).Msg("adopt_pool_export_after_failure_failed")
+		}
+	}()
+
+	pool, err := s.GZFS.Zpool.Get(ctx, name)
+	if err != nil {
+		return result, fmt.Errorf("failed_to_get_pool: %w", err)
+	}
+	result.State = string(pool.State)
+	if err := checkAdoptPoolHealth(result.State, req.AllowDegraded); err != nil {
+		return result, err
+	}
+
+	if req.CreateSkeleton {
+		created, err := s.ensureSylveDatasetsOnPool(ctx, name)
+		if err != nil {
+			return result, err
+		}
+		for _, ds := range created {
+			result.CreatedDatasets = append(result.CreatedDatasets, ds.Name)
+		}
+	} else if !s.hasSylveSkeleton(ctx, name) {
+		return result, fmt.Errorf("pool_missing_sylve_datasets")
+	}
+
+	basicSettings.Pools = append(basicSettings.Pools, name)
+	if err := s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&basicSettings).Error; err != nil {
+			return err
+		}
+		return db.InvalidateZFSCaches(tx)
+	}); err != nil {
+		return result, fmt.Errorf("failed_to_update_basic_settings: %w", err)
+	}
+
+	success = true
+	return result, nil
+}
+
+func (s *Service) importAdoptedPool(ctx context.Context, name string, allowDegraded bool) error {
+	// Fenced shared-storage pools are imported through the fence so the peer
+	// node can never have it imported at the same time.
+	var fences int64
+	if err := s.DB.Model(&zfsModels.StorageFence{}).Where("pool = ?", name).Count(&fences).Error; err != nil {
+		return fmt.Errorf("failed_to_check_storage_fence: %w", err)
+	}
+	if fences > 0 {
+		return fmt.Errorf("pool_has_storage_fence")
+	}
+
+	output, _ := poolImportRunCommand(ctx, "zpool", "import")
+	var candidate *systemServiceInterfaces.AdoptablePool
+	for _, pool := range parseImportablePools(output) {
+		if pool.Name == name {
+			if candidate != nil {
+				return fmt.Errorf("pool_name_ambiguous")
+			}
+			candidate = &pool
+		}
+	}
+	if candidate == nil {
+		return fmt.Errorf("pool_not_found: %s", name)
+	}
+	if strings.Contains(strings.ToLower(candidate.Status), "imported by another system") {
+		return fmt.Errorf("pool_imported_by_another_system")
+	}
+	if err := checkAdoptPoolHealth(candidate.State, allowDegraded); err != nil {
+		return err
+	}
+
+	// Never -f: a pool last used by another host needs an explicit export
+	// there (or a storage fence) before it is safe to take over.
+	if out, err := poolImportRunCommand(ctx, "zpool", "import", name); err != nil {
+		return fmt.Errorf("pool_import_failed: %s", strings.TrimSpace(out))
+	}
+	return nil
+}
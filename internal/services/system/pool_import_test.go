@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package system
+
+import (
+	"strings"
+	"testing"
+)
+
+const zpoolImportOutput = `   pool: archive
+     id: 1473829012384719283
+  state: ONLINE
+ status: Some supported features are not enabled on the pool.
+	(Note that they may be intentionally disabled if the
+	'compatibility' property is set.)
+ action: The pool can be imported using its name or numeric identifier, though
+	some features will not be available without an explicit 'zpool upgrade'.
+ config:
+
+	archive     ONLINE
+	  mirror-0  ONLINE
+	    ada2    ONLINE
+	    ada3    ONLINE
+
+   pool: shared
+     id: 9918273645
+  state: ONLINE
+ status: The pool is currently imported by another system.
+ action: The pool must be exported from nodeb (hostid=2a3b4c5d)
+	before it can be safely imported.
+ config:
+
+	shared      ONLINE
+	  da1       ONLINE
+`
+
+func TestParseImportablePools(t *testing.T) {
+	pools := parseImportablePools(zpoolImportOutput)
+	if len(pools) != 2 {
+		t.Fatalf("parsed %d pools, want 2: %+v", len(pools), pools)
+	}
+
+	if pools[0].Name != "archive" || pools[0].ID != "1473829012384719283" || pools[0].State != "ONLINE" {
+		t.Fatalf("first pool = %+v", pools[0])
+	}
+	if !strings.HasSuffix(pools[0].Action, "without an explicit 'zpool upgrade'.") {
+		t.Fatalf("continuation lines not folded into action: %q", pools[0].Action)
+	}
+	if strings.Contains(pools[0].Action, "mirror-0") {
+		t.Fatalf("config tree leaked into action: %q", pools[0].Action)
+	}
+
+	if !strings.Contains(pools[1].Status, "imported by another system") || pools[1].Imported {
+		t.Fatalf("second pool = %+v", pools[1])
+	}
+
+	if got := parseImportablePools("no pools available to import\n"); len(got) != 0 {
+		t.Fatalf("expected no pools, got %+v", got)
+	}
+}
+
+func TestCheckAdoptPoolHealth(t *testing.T) {
+	if err := checkAdoptPoolHealth("ONLINE", false); err != nil {
+		t.Fatalf("online pool rejected: %v", err)
+	}
+	if err := checkAdoptPoolHealth("DEGRADED", false); err == nil ||
+		err.Error() != "pool_degraded_confirmation_required" {
+		t.Fatalf("degraded pool without confirmation = %v", err)
+	}
+	if err := checkAdoptPoolHealth("degraded", true); err != nil {
+		t.Fatalf("confirmed degraded pool rejected: %v", err)
+	}
+	if err := checkAdoptPoolHealth("FAULTED", true); err == nil || !strings.HasPrefix(err.Error(), "pool_unhealthy") {
+		t.Fatalf("faulted pool = %v", err)
+	}
+}
@@ -0,0 +1,306 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/db/models"
+	systemServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/system"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/utils"
+)
+
+const (
+	selfTestZvolSize   = 16 * 1024 * 1024
+	selfTestMarkerFile = "sylve-selftest"
+	selfTestSnapshot   = "selftest"
+)
+
+// Overridden in tests.
+var (
+	selfTestRunCommand = utils.RunCommandWithContext
+	selfTestNow        = time.Now
+)
+
+var errSelfTestScratchUnavailable = errors.New("scratch_dataset_unavailable")
+
+type selfTestStep struct {
+	subsystem string
+	// run returns a non-empty skip reason when the subsystem is not in use
+	// on this host.
+	run func(ctx context.Context) (string, error)
+}
+
+// runSelfTestSteps runs steps in order. Once the scratch step fails every
+// later step is skipped, since they all work inside the scratch dataset.
+func runSelfTestSteps(ctx context.Context, steps []selfTestStep) []systemServiceInterfaces.SelfTestResult {
+	results := make([]systemServiceInterfaces.SelfTestResult, 0, len(steps))
+	scratchFailed := false
+
+	for _, step := range steps {
+		result := systemServiceInterfaces.SelfTestResult{Subsystem: step.subsystem}
+		if scratchFailed {
+			result.Status = systemServiceInterfaces.SelfTestSkip
+			result.Detail = errSelfTestScratchUnavailable.Error()
+			results = append(results, result)
+			continue
+		}
+
+		started := selfTestNow()
+		skip, err := step.run(ctx)
+		result.DurationMs = selfTestNow().Sub(started).Milliseconds()
+
+		switch {
+		case err != nil:
+			result.Status = systemServiceInterfaces.SelfTestFail
+			result.Detail = err.Error()
+			if errors.Is(err, errSelfTestScratchUnavailable) {
+				scratchFailed = true
+			}
+		case skip != "":
+			result.Status = systemServiceInterfaces.SelfTestSkip
+			result.Detail = skip
+		default:
+			result.Status = systemServiceInterfaces.SelfTestPass
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func selfTestPassed(results []systemServiceInterfaces.SelfTestResult) bool {
+	for _, result := range results {
+		if result.Status == systemServiceInterfaces.SelfTestFail {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *Service) selfTestSettings(pool string) (string, bool, error) {
+	var basicSettings models.BasicSettings
+	if err := s.DB.First(&basicSettings).Error; err != nil {
+		return "", false, fmt.Errorf("failed_to_get_basic_settings: %w", err)
+	}
+
+	jailsEnabled := slices.Contains(basicSettings.Services, models.Jails)
+
+	pool = strings.TrimSpace(pool)
+	if pool == "" {
+		if len(basicSettings.Pools) == 0 {
+			return "", jailsEnabled, fmt.Errorf("no_usable_pools")
+		}
+		return basicSettings.Pools[0], jailsEnabled, nil
+	}
+
+	if !slices.Contains(basicSettings.Pools, pool) {
+		return "", jailsEnabled, fmt.Errorf("pool_not_usable: %s", pool)
+	}
+
+	return pool, jailsEnabled, nil
+}
+
+// RunSelfTest exercises the storage, jail and send/receive paths inside a
+// throwaway dataset under <pool>/sylve and reports each subsystem. The
+// scratch datasets are destroyed whatever the outcome.
+func (s *Service) RunSelfTest(
+	ctx context.Context,
+	req systemServiceInterfaces.SelfTestRequest,
+) (systemServiceInterfaces.SelfTestReport, error) {
+	if !s.selfTestMutex.TryLock() {
+		return systemServiceInterfaces.SelfTestReport{}, fmt.Errorf("selftest_already_running")
+	}
+	defer s.selfTestMutex.Unlock()
+
+	if s.GZFS == nil {
+		return systemServiceInterfaces.SelfTestReport{}, fmt.Errorf("zfs_client_not_initialized")
+	}
+
+	pool, jailsEnabled, err := s.selfTestSettings(req.Pool)
+	if err != nil {
+		return systemServiceInterfaces.SelfTestReport{}, err
+	}
+
+	startedAt := selfTestNow()
+	root := fmt.Sprintf("%s/sylve/selftest-%d", pool, startedAt.Unix())
+	restored := root + "-restore"
+	mountpoint := ""
+
+	defer func() {
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		for _, name := range []string{restored, root} {
+			s.destroySelfTestDataset(cleanupCtx, name)
+		}
+	}()
+
+	steps := []selfTestStep{
+		{
+			subsystem: "zfs-scratch",
+			run: func(ctx context.Context) (string, error) {
+				ds, err := s.GZFS.ZFS.CreateFilesystem(ctx, root, map[string]string{})
+				if err != nil {
+					return "", fmt.Errorf("%w: %v", errSelfTestScratchUnavailable, err)
+				}
+				if ds == nil || !filepath.IsAbs(ds.Mountpoint) {
+					return "", fmt.Errorf("%w: scratch_dataset_not_mounted", errSelfTestScratchUnavailable)
+				}
+				mountpoint = ds.Mountpoint
+				return "", nil
+			},
+		},
+		{
+			subsystem: "zfs-zvol",
+			run: func(ctx context.Context) (string, error) {
+				return "", s.selfTestZvol(ctx, root+"/zvol")
+			},
+		},
+		{
+			subsystem: "jail",
+			run: func(ctx context.Context) (string, error) {
+				if !jailsEnabled {
+					return "jails_service_disabled", nil
+				}
+				if err := s.CheckJails(); err != nil {
+					return "", err
+				}
+				return "", selfTestJail(ctx, fmt.Sprintf("sylve-selftest-%d", startedAt.Unix()), mountpoint)
+			},
+		},
+		{
+			subsystem: "backup-restore",
+			run: func(ctx context.Context) (string, error) {
+				return "", s.selfTestSendReceive(ctx, root, mountpoint, restored)
+			},
+		},
+	}
+
+	results := runSelfTestSteps(ctx, steps)
+	report := systemServiceInterfaces.SelfTestReport{
+		Pool:       pool,
+		Dataset:    root,
+		Passed:     selfTestPassed(results),
+		StartedAt:  startedAt,
+		FinishedAt: selfTestNow(),
+		Results:    results,
+	}
+
+	logger.L.Info().
+		Str("pool", pool).
+		Bool("passed", report.Passed).
+		Msg("selftest_finished")
+
+	return report, nil
+}
+
+func (s *Service) selfTestZvol(ctx context.Context, name string) error {
+	if _, err := s.GZFS.ZFS.CreateVolume(ctx, name, selfTestZvolSize, map[string]string{"volmode": "dev"}); err != nil {
+		return fmt.Errorf("failed_to_create_zvol: %w", err)
+	}
+
+	ds, err := s.GZFS.ZFS.Get(ctx, name, false)
+	if err != nil || ds == nil {
+		return fmt.Errorf("zvol_not_visible_after_create: %v", err)
+	}
+
+	if err := ds.Destroy(ctx, false, false); err != nil {
+		return fmt.Errorf("failed_to_destroy_zvol: %w", err)
+	}
+
+	if ds, err := s.GZFS.ZFS.Get(ctx, name, false); err == nil && ds != nil {
+		return fmt.Errorf("zvol_still_present_after_destroy")
+	}
+
+	return nil
+}
+
+// selfTestJail starts an empty persistent jail rooted at the scratch dataset
+// and removes it again. No userland is needed, so this checks jail(8) and the
+// kernel side without a downloaded base.
+func selfTestJail(ctx context.Context, name, path string) error {
+	if _, err := selfTestRunCommand(ctx, "jail", "-c", "name="+name, "path="+path, "persist"); err != nil {
+		return fmt.Errorf("failed_to_start_jail: %w", err)
+	}
+
+	jid, err := selfTestRunCommand(ctx, "jls", "-j", name, "jid")
+	if err != nil || strings.TrimSpace(jid) == "" {
+		_, _ = selfTestRunCommand(context.Background(), "jail", "-r", name)
+		return fmt.Errorf("jail_not_running_after_start: %v", err)
+	}
+
+	if _, err := selfTestRunCommand(ctx, "jail", "-r", name); err != nil {
+		return fmt.Errorf("failed_to_stop_jail: %w", err)
+	}
+
+	return nil
+}
+
+// selfTestSendReceive writes a marker, snapshots the scratch dataset and
+// receives it into a sibling the same way backups and restores move data,
+// then checks the marker survived the round trip.
+func (s *Service) selfTestSendReceive(ctx context.Context, root, mountpoint, restored string) error {
+	marker := fmt.Sprintf("sylve selftest %d\n", selfTestNow().UnixNano())
+	if err := os.WriteFile(filepath.Join(mountpoint, selfTestMarkerFile), []byte(marker), 0o644); err != nil {
+		return fmt.Errorf("failed_to_write_marker: %w", err)
+	}
+
+	if _, err := s.GZFS.ZFS.Snapshot(ctx, root, selfTestSnapshot, false); err != nil {
+		return fmt.Errorf("failed_to_snapshot_scratch_dataset: %w", err)
+	}
+
+	ds, err := s.GZFS.ZFS.SendToDataset(ctx, root+"@"+selfTestSnapshot, restored, false)
+	if err != nil {
+		return fmt.Errorf("failed_to_send_receive: %w", err)
+	}
+
+	restoredMountpoint := ""
+	if ds != nil {
+		restoredMountpoint = ds.Mountpoint
+	}
+	if !filepath.IsAbs(restoredMountpoint) {
+		if ds, err := s.GZFS.ZFS.Get(ctx, restored, false); err == nil && ds != nil {
+			restoredMountpoint = ds.Mountpoint
+		}
+	}
+	if !filepath.IsAbs(restoredMountpoint) {
+		return fmt.Errorf("restored_dataset_not_mounted")
+	}
+
+	got, err := os.ReadFile(filepath.Join(restoredMountpoint, selfTestMarkerFile))
+	if err != nil {
+		return fmt.Errorf("failed_to_read_restored_marker: %w", err)
+	}
+	if string(got) != marker {
+		return fmt.Errorf("restored_marker_mismatch")
+	}
+
+	return nil
+}
+
+func (s *Service) destroySelfTestDataset(ctx context.Context, name string) {
+	ds, err := s.GZFS.ZFS.Get(ctx, name, false)
+	if err != nil || ds == nil {
+		return
+	}
+
+	if err := ds.Destroy(ctx, true, false); err != nil {
+		logger.L.Warn().Err(err).Str("dataset", name).Msg("selftest_cleanup_failed")
+	}
+}
@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/alchemillahq/sylve/internal/db/models"
+	systemServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/system"
+	"github.com/alchemillahq/sylve/internal/testutil"
+)
+
+func TestRunSelfTestSteps_ScratchFailureSkipsRemainingSteps(t *testing.T) {
+	ran := false
+	results := runSelfTestSteps(context.Background(), []selfTestStep{
+		{subsystem: "zfs-scratch", run: func(context.Context) (string, error) {
+			return "", fmt.Errorf("%w: pool is read-only", errSelfTestScratchUnavailable)
+		}},
+		{subsystem: "zfs-zvol", run: func(context.Context) (string, error) {
+			ran = true
+			return "", nil
+		}},
+	})
+
+	if ran {
+		t.Fatalf("expected steps after a scratch failure not to run")
+	}
+	if results[0].Status != systemServiceInterfaces.SelfTestFail {
+		t.Fatalf("expected scratch step to fail, got %+v", results[0])
+	}
+	if results[1].Status != systemServiceInterfaces.SelfTestSkip || results[1].Detail != "scratch_dataset_unavailable" {
+		t.Fatalf("expected zvol step to be skipped, got %+v", results[1])
+	}
+	if selfTestPassed(results) {
+		t.Fatalf("expected report to fail")
+	}
+}
+
+func TestRunSelfTestSteps_SkipDoesNotFailReport(t *testing.T) {
+	results := runSelfTestSteps(context.Background(), []selfTestStep{
+		{subsystem: "zfs-scratch", run: func(context.Context) (string, error) { return "", nil }},
+		{subsystem: "jail", run: func(context.Context) (string, error) { return "jails_service_disabled", nil }},
+		{subsystem: "backup-restore", run: func(context.Context) (string, error) { return "", errors.New("boom") }},
+	})
+
+	want := []systemServiceInterfaces.SelfTestStatus{
+		systemServiceInterfaces.SelfTestPass,
+		systemServiceInterfaces.SelfTestSkip,
+		systemServiceInterfaces.SelfTestFail,
+	}
+	for i, status := range want {
+		if results[i].Status != status {
+			t.Fatalf("result %d: expected %s, got %+v", i, status, results[i])
+		}
+	}
+
+	if selfTestPassed(results[:2]) != true {
+		t.Fatalf("expected pass and skip to count as passed")
+	}
+}
+
+func TestSelfTestSettings_PicksUsablePool(t *testing.T) {
+	db := testutil.NewSQLiteTestDB(t, &models.BasicSettings{})
+	if err := db.Create(&models.BasicSettings{
+		Pools:    []string{"zroot", "tank"},
+		Services: []models.AvailableService{models.Jails},
+	}).Error; err != nil {
+		t.Fatalf("failed to create basic settings: %v", err)
+	}
+
+	service := &Service{DB: db}
+
+	pool, jails, err := service.selfTestSettings("")
+	if err != nil || pool != "zroot" || !jails {
+		t.Fatalf("expected default pool zroot with jails, got %q %v %v", pool, jails, err)
+	}
+
+	if pool, _, err := service.selfTestSettings("tank"); err != nil || pool != "tank" {
+		t.Fatalf("expected tank, got %q %v", pool, err)
+	}
+
+	if _, _, err := service.selfTestSettings("scratch"); err == nil || !strings.HasPrefix(err.Error(), "pool_not_usable") {
+		t.Fatalf("expected pool_not_usable, got %v", err)
+	}
+}
+
+func TestSelfTestJail_StopsJailWhenNotVisible(t *testing.T) {
+	var calls []string
+	prev := selfTestRunCommand
+	selfTestRunCommand = func(_ context.Context, command string, args ...string) (string, error) {
+		calls = append(calls, command+" "+strings.Join(args, " "))
+		if command == "jls" {
+			return "", errors.New("jls: jail not found")
+		}
+		return "", nil
+	}
+	t.Cleanup(func() { selfTestRunCommand = prev })
+
+	err := selfTestJail(context.Background(), "sylve-selftest-1", "/zroot/sylve/selftest-1")
+	if err == nil || !strings.HasPrefix(err.Error(), "jail_not_running_after_start") {
+		t.Fatalf("expected jail_not_running_after_start, got %v", err)
+	}
+
+	if len(calls) != 3 || calls[2] != "jail -r sylve-selftest-1" {
+		t.Fatalf("expected cleanup jail -r, got %v", calls)
+	}
+}
@@ -39,6 +39,8 @@ type Service struct {
 	tunCachedAt time.Time
 
 	MdnsRebuild func() error
+
+	selfTestMutex sync.Mutex
 }
 
 func NewSystemService(db *gorm.DB, gzfs *gzfs.Client) systemServiceInterfaces.SystemServiceInterface {
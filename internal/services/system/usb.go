@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package system
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	systemServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/system"
+	"github.com/alchemillahq/sylve/pkg/system/pciconf"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	sysctl "github.com/alchemillahq/sylve/pkg/utils/sysctl"
+)
+
+var (
+	usbDeviceHeader = regexp.MustCompile(`^ugen(\d+)\.(\d+): <(.*)> at usbus(\d+),.*\bspd=(\S+)`)
+	usbDescField    = regexp.MustCompile(`^\s+(\w+)\s*=\s*0x([0-9a-fA-F]+)\s*(?:<(.*)>)?\s*$`)
+)
+
+// parseUSBDeviceDump parses `usbconfig dump_device_desc`. Root hubs (address
+// 1) belong to the controller itself and are left out.
+func parseUSBDeviceDump(output string) []systemServiceInterfaces.USBDevice {
+	devices := []systemServiceInterfaces.USBDevice{}
+	var current *systemServiceInterfaces.USBDevice
+
+	flush := func() {
+		if current != nil && current.Address != 1 {
+			devices = append(devices, *current)
+		}
+		current = nil
+	}
+
+	for _, line := range utils.SplitLines(output) {
+		if match := usbDeviceHeader.FindStringSubmatch(line); match != nil {
+			flush()
+			address, _ := strconv.Atoi(match[2])
+			bus, _ := strconv.Atoi(match[4])
+			current = &systemServiceInterfaces.USBDevice{
+				Ugen:        fmt.Sprintf("ugen%s.%s", match[1], match[2]),
+				Bus:         bus,
+				Address:     address,
+				Description: strings.TrimSpace(match[3]),
+				Speed:       strings.ToLower(match[5]),
+			}
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		match := usbDescField.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		text := strings.TrimSpace(match[3])
+		if text == "no string" {
+			text = ""
+		}
+
+		switch match[1] {
+		case "idVendor":
+			current.VendorID = strings.ToLower(match[2])
+		case "idProduct":
+			current.ProductID = strings.ToLower(match[2])
+		case "iManufacturer":
+			current.Manufacturer = text
+		case "iProduct":
+			current.Product = text
+		case "iSerialNumber":
+			current.Serial = text
+		}
+	}
+	flush()
+
+	return devices
+}
+
+// ListUSBDevices lists the host's USB devices along with the PCI address of
+// the controller each one is attached to, which is what has to be reserved
+// as a ppt device to hand the device to a VM.
+func (s *Service) ListUSBDevices() ([]systemServiceInterfaces.USBDevice, error) {
+	output, err := utils.RunCommand("/usr/sbin/usbconfig", "dump_device_desc")
+	if err != nil {
+		// usbconfig exits non-zero when the host has no USB buses at all.
+		if strings.Contains(output, "No device match") {
+			return []systemServiceInterfaces.USBDevice{}, nil
+		}
+		return nil, fmt.Errorf("failed_to_list_usb_devices: %w", err)
+	}
+
+	devices := parseUSBDeviceDump(output)
+	if len(devices) == 0 {
+		return devices, nil
+	}
+
+	pciDevices, err := pciconf.GetPCIDevices()
+	if err != nil {
+		return nil, fmt.Errorf("getting PCI devices: %w", err)
+	}
+
+	byName := make(map[string]pciconf.PCIDevice, len(pciDevices))
+	for _, device := range pciDevices {
+		byName[fmt.Sprintf("%s%d", device.Name, device.Unit)] = device
+	}
+
+	controllers := make(map[int]string)
+	for i := range devices {
+		controller, ok := controllers[devices[i].Bus]
+		if !ok {
+			controller, _ = sysctl.GetString(fmt.Sprintf("dev.usbus.%d.%%parent", devices[i].Bus))
+			controller = strings.TrimSpace(controller)
+			controllers[devices[i].Bus] = controller
+		}
+
+		devices[i].Controller = controller
+		if device, ok := byName[controller]; ok {
+			devices[i].ControllerDomain = device.Domain
+			devices[i].ControllerDeviceID = fmt.Sprintf("%d/%d/%d", device.Bus, device.Device, device.Function)
+		}
+	}
+
+	return devices, nil
+}
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package system
+
+import "testing"
+
+const usbDeviceDumpFixture = `ugen0.1: <0x8086 XHCI root HUB> at usbus0, cfg=0 md=HOST spd=SUPER (5.0Gbps) pwr=SAVE (0mA)
+
+  bLength = 0x0012 
+  idVendor = 0x8086 
+  idProduct = 0x0000 
+  iManufacturer = 0x0001  <0x8086>
+  iProduct = 0x0002  <XHCI root HUB>
+  iSerialNumber = 0x0000  <no string>
+
+ugen0.2: <Logitech USB Receiver> at usbus0, cfg=0 md=HOST spd=FULL (12Mbps) pwr=ON (98mA)
+
+  bLength = 0x0012 
+  bDeviceClass = 0x0000  <Probed by interface class>
+  idVendor = 0x046D 
+  idProduct = 0xc52b 
+  iManufacturer = 0x0001  <Logitech>
+  iProduct = 0x0002  <USB Receiver>
+  iSerialNumber = 0x0000  <no string>
+
+ugen1.3: <SanDisk Cruzer Blade> at usbus1, cfg=0 md=HOST spd=HIGH (480Mbps) pwr=ON (200mA)
+
+  idVendor = 0x0781 
+  idProduct = 0x5567 
+  iManufacturer = 0x0001  <SanDisk>
+  iProduct = 0x0002  <Cruzer Blade>
+  iSerialNumber = 0x0003  <4C530001230101117094>
+`
+
+func TestParseUSBDeviceDump(t *testing.T) {
+	devices := parseUSBDeviceDump(usbDeviceDumpFixture)
+	if len(devices) != 2 {
+		t.Fatalf("expected 2 devices without root hubs, got %d: %+v", len(devices), devices)
+	}
+
+	receiver := devices[0]
+	if receiver.Ugen != "ugen0.2" || receiver.Bus != 0 || receiver.Address != 2 {
+		t.Fatalf("unexpected receiver location: %+v", receiver)
+	}
+	if receiver.VendorID != "046d" || receiver.ProductID != "c52b" || receiver.Speed != "full" {
+		t.Fatalf("unexpected receiver ids: %+v", receiver)
+	}
+	if receiver.Manufacturer != "Logitech" || receiver.Product != "USB Receiver" || receiver.Serial != "" {
+		t.Fatalf("unexpected receiver strings: %+v", receiver)
+	}
+
+	stick := devices[1]
+	if stick.Ugen != "ugen1.3" || stick.Bus != 1 || stick.Serial != "4C530001230101117094" {
+		t.Fatalf("unexpected stick: %+v", stick)
+	}
+	if stick.Description != "SanDisk Cruzer Blade" {
+		t.Fatalf("unexpected description: %q", stick.Description)
+	}
+
+	if got := parseUSBDeviceDump(""); len(got) != 0 {
+		t.Fatalf("expected no devices, got %+v", got)
+	}
+}
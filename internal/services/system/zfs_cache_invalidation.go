@@ -41,6 +41,7 @@ func (s *Service) consumeZFSEvents(ctx context.Context, events <-chan *zfsEvent,
 			}
 			if shouldHandleZFSStateChangeEvent(ev) {
 				s.emitPoolStateNotification(ctx, ev)
+				s.autoReplaceFaultedVdev(ctx, ev)
 			}
 		case <-ticker.C:
 			s.flushZFSCacheInvalidations(ctx, pending)
@@ -0,0 +1,237 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alchemillahq/gzfs"
+	"github.com/alchemillahq/sylve/internal/db/models"
+	zfsModels "github.com/alchemillahq/sylve/internal/db/models/zfs"
+	"github.com/alchemillahq/sylve/internal/logger"
+	notifier "github.com/alchemillahq/sylve/internal/notifications"
+)
+
+const zfsVdevTypeSpare = "spare"
+
+// autoReplaceFaultedVdev swaps a FAULTED pool member for the first available
+// hot spare when the pool's spare policy allows it.
+func (s *Service) autoReplaceFaultedVdev(ctx context.Context, ev *zfsEvent) {
+	if s == nil || s.DB == nil || s.GZFS == nil || !shouldHandleZFSStateChangeEvent(ev) {
+		return
+	}
+
+	poolName := strings.TrimSpace(ev.Attrs["pool"])
+	if poolName == "" {
+		return
+	}
+
+	var policy zfsModels.HotSparePolicy
+	if err := s.DB.Where("pool = ?", poolName).First(&policy).Error; err != nil || !policy.AutoReplace {
+		return
+	}
+
+	pool, err := s.GZFS.Zpool.Get(ctx, poolName)
+	if err != nil || pool == nil {
+		return
+	}
+
+	before, err := pool.Status(ctx)
+	if err != nil || before == nil {
+		return
+	}
+
+	leaf, parent := findStatusVdevWithParent(before.Vdevs, nil,
+		strings.TrimSpace(ev.Attrs["vdev_guid"]), strings.TrimSpace(ev.Attrs["vdev_path"]))
+	if leaf == nil || normalizePoolState(leaf.State) != string(gzfs.ZPoolStateFaulted) {
+		return
+	}
+	if parent != nil && parent.VdevType == zfsVdevTypeSpare {
+		// Already being covered by a spare.
+		return
+	}
+
+	spare := availableHotSpare(before.Spares)
+	if spare == nil {
+		s.recordHotSpareReplacement(&policy, leaf, nil, fmt.Errorf("no_available_hot_spare"))
+		return
+	}
+
+	oldDevice := leaf.Path
+	if oldDevice == "" {
+		oldDevice = leaf.GUID
+	}
+	newDevice := spare.Path
+	if newDevice == "" {
+		newDevice = spare.Name
+	}
+
+	beforeTopology := renderPoolTopology(before)
+	replaceErr := pool.ReplaceDevice(ctx, oldDevice, newDevice, false)
+	s.recordHotSpareReplacement(&policy, leaf, spare, replaceErr)
+
+	afterTopology := ""
+	if after, err := pool.Status(ctx); err == nil && after != nil {
+		afterTopology = renderPoolTopology(after)
+	}
+
+	input := buildHotSpareNotificationInput(poolName, oldDevice, newDevice, beforeTopology, afterTopology, replaceErr)
+	if _, err := notifier.Emit(ctx, input); err != nil && !errors.Is(err, notifier.ErrEmitterNotConfigured) {
+		logger.L.Error().
+			Err(err).
+			Str("kind", input.Kind).
+			Interface("metadata", input.Metadata).
+			Msg("failed_to_emit_zfs_hot_spare_notification")
+	}
+}
+
+func (s *Service) recordHotSpareReplacement(policy *zfsModels.HotSparePolicy, faulted, spare *gzfs.ZPoolStatusVDEV, replaceErr error) {
+	now := time.Now().UTC()
+	policy.LastReplacedAt = &now
+	policy.LastFaulted = vdevLabel(faulted)
+	policy.LastSpare = vdevLabel(spare)
+	policy.LastError = ""
+	if replaceErr != nil {
+		policy.LastError = replaceErr.Error()
+	}
+
+	if err := s.DB.Save(policy).Error; err != nil {
+		logger.L.Warn().Err(err).Str("pool", policy.Pool).Msg("failed_to_record_hot_spare_replacement")
+	}
+}
+
+func findStatusVdevWithParent(vdevs map[string]*gzfs.ZPoolStatusVDEV, parent *gzfs.ZPoolStatusVDEV, guid, path string) (*gzfs.ZPoolStatusVDEV, *gzfs.ZPoolStatusVDEV) {
+	for _, vdev := range vdevs {
+		if vdev == nil {
+			continue
+		}
+		if (guid != "" && strings.EqualFold(strings.TrimSpace(vdev.GUID), guid)) ||
+			(path != "" && strings.EqualFold(strings.TrimSpace(vdev.Path), path)) {
+			return vdev, parent
+		}
+		if found, foundParent := findStatusVdevWithParent(vdev.Vdevs, vdev, guid, path); found != nil {
+			return found, foundParent
+		}
+	}
+
+	return nil, nil
+}
+
+// availableHotSpare picks the first AVAIL spare by name so the choice is
+// stable across events.
+func availableHotSpare(spares map[string]*gzfs.ZPoolStatusVDEV) *gzfs.ZPoolStatusVDEV {
+	names := make([]string, 0, len(spares))
+	for name, spare := range spares {
+		if spare != nil && normalizePoolState(spare.State) == "AVAIL" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	sort.Strings(names)
+	return spares[names[0]]
+}
+
+func renderPoolTopology(status *gzfs.ZPoolStatusPool) string {
+	if status == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n", status.Name, normalizePoolState(status.State))
+	for _, group := range []struct {
+		label string
+		vdevs map[string]*gzfs.ZPoolStatusVDEV
+	}{
+		{"", status.Vdevs},
+		{"logs", status.Logs},
+		{"cache", status.L2Cache},
+		{"spares", status.Spares},
+	} {
+		if len(group.vdevs) == 0 {
+			continue
+		}
+		depth := 1
+		if group.label != "" {
+			fmt.Fprintf(&b, "  %s\n", group.label)
+			depth = 2
+		}
+		renderTopologyVdevs(&b, group.vdevs, depth)
+	}
+
+	return b.String()
+}
+
+func renderTopologyVdevs(b *strings.Builder, vdevs map[string]*gzfs.ZPoolStatusVDEV, depth int) {
+	names := make([]string, 0, len(vdevs))
+	for name := range vdevs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		vdev := vdevs[name]
+		if vdev == nil {
+			continue
+		}
+		fmt.Fprintf(b, "%s%s %s\n", strings.Repeat("  ", depth), name, normalizePoolState(vdev.State))
+		renderTopologyVdevs(b, vdev.Vdevs, depth+1)
+	}
+}
+
+func buildHotSpareNotificationInput(poolName, faulted, spare, before, after string, replaceErr error) notifier.EventInput {
+	pool := strings.TrimSpace(strings.ToLower(poolName))
+
+	title := fmt.Sprintf("ZFS pool %s: replaced %s with hot spare %s", pool, faulted, spare)
+	body := fmt.Sprintf("Vdev %s FAULTED and was replaced with hot spare %s.", faulted, spare)
+	severity := string(models.NotificationSeverityWarning)
+	if replaceErr != nil {
+		title = fmt.Sprintf("ZFS pool %s: hot spare replacement of %s failed", pool, faulted)
+		body = fmt.Sprintf("Replacing FAULTED vdev %s with hot spare %s failed: %v", faulted, spare, replaceErr)
+		severity = string(models.NotificationSeverityCritical)
+	}
+	body += "\n\nBefore:\n" + before
+	if after != "" {
+		body += "\nAfter:\n" + after
+	}
+
+	return notifier.EventInput{
+		Kind:        notifier.KindForZFSPoolState(pool),
+		Title:       title,
+		Body:        body,
+		Severity:    severity,
+		Source:      "system.zfs.hot_spare",
+		Fingerprint: fmt.Sprintf("%s|hot_spare|%s|%s", pool, strings.ToLower(faulted), strings.ToLower(spare)),
+		Metadata: map[string]string{
+			"pool":    pool,
+			"faulted": faulted,
+			"spare":   spare,
+		},
+	}
+}
+
+func vdevLabel(vdev *gzfs.ZPoolStatusVDEV) string {
+	if vdev == nil {
+		return ""
+	}
+	if vdev.Path != "" {
+		return vdev.Path
+	}
+	if vdev.Name != "" {
+		return vdev.Name
+	}
+	return vdev.GUID
+}
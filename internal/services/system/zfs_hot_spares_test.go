@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package system
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alchemillahq/gzfs"
+)
+
+func hotSpareTestStatus() *gzfs.ZPoolStatusPool {
+	return &gzfs.ZPoolStatusPool{
+		Name:  "tank",
+		State: "DEGRADED",
+		Vdevs: map[string]*gzfs.ZPoolStatusVDEV{
+			"tank": {
+				Name:  "tank",
+				State: "DEGRADED",
+				Vdevs: map[string]*gzfs.ZPoolStatusVDEV{
+					"mirror-0": {
+						Name:     "mirror-0",
+						VdevType: "mirror",
+						State:    "DEGRADED",
+						Vdevs: map[string]*gzfs.ZPoolStatusVDEV{
+							"ada1": {Name: "ada1", GUID: "111", Path: "/dev/ada1", State: "ONLINE"},
+							"ada2": {Name: "ada2", GUID: "222", Path: "/dev/ada2", State: "FAULTED"},
+						},
+					},
+				},
+			},
+		},
+		Spares: map[string]*gzfs.ZPoolStatusVDEV{
+			"ada4": {Name: "ada4", Path: "/dev/ada4", State: "INUSE"},
+			"ada5": {Name: "ada5", Path: "/dev/ada5", State: "AVAIL"},
+			"ada3": {Name: "ada3", Path: "/dev/ada3", State: "AVAIL"},
+		},
+	}
+}
+
+func TestFindStatusVdevWithParent(t *testing.T) {
+	status := hotSpareTestStatus()
+
+	leaf, parent := findStatusVdevWithParent(status.Vdevs, nil, "222", "")
+	if leaf == nil || leaf.Name != "ada2" {
+		t.Fatalf("expected_ada2 got: %+v", leaf)
+	}
+	if parent == nil || parent.Name != "mirror-0" {
+		t.Fatalf("expected_mirror_parent got: %+v", parent)
+	}
+
+	if leaf, _ := findStatusVdevWithParent(status.Vdevs, nil, "", "/dev/ada1"); leaf == nil || leaf.Name != "ada1" {
+		t.Fatalf("expected_lookup_by_path got: %+v", leaf)
+	}
+	if leaf, _ := findStatusVdevWithParent(status.Vdevs, nil, "999", ""); leaf != nil {
+		t.Fatalf("expected_no_match got: %+v", leaf)
+	}
+}
+
+func TestAvailableHotSpare(t *testing.T) {
+	spare := availableHotSpare(hotSpareTestStatus().Spares)
+	if spare == nil || spare.Name != "ada3" {
+		t.Fatalf("expected_first_available_spare got: %+v", spare)
+	}
+
+	if spare := availableHotSpare(map[string]*gzfs.ZPoolStatusVDEV{
+		"ada4": {Name: "ada4", State: "INUSE"},
+	}); spare != nil {
+		t.Fatalf("expected_no_available_spare got: %+v", spare)
+	}
+}
+
+func TestRenderPoolTopology(t *testing.T) {
+	topology := renderPoolTopology(hotSpareTestStatus())
+	for _, line := range []string{
+		"tank DEGRADED\n",
+		"      ada2 FAULTED\n",
+		"  spares\n",
+		"    ada3 AVAIL\n",
+	} {
+		if !strings.Contains(topology, line) {
+			t.Fatalf("expected %q in topology:\n%s", line, topology)
+		}
+	}
+}
+
+func TestBuildHotSpareNotificationInput(t *testing.T) {
+	input := buildHotSpareNotificationInput("Tank", "/dev/ada2", "/dev/ada3", "before\n", "after\n", nil)
+	if input.Severity != "warning" {
+		t.Fatalf("expected_warning_severity got: %s", input.Severity)
+	}
+	if input.Fingerprint != "tank|hot_spare|/dev/ada2|/dev/ada3" {
+		t.Fatalf("unexpected_fingerprint: %s", input.Fingerprint)
+	}
+	if !strings.Contains(input.Body, "Before:\nbefore\n") || !strings.Contains(input.Body, "After:\nafter\n") {
+		t.Fatalf("expected_topologies_in_body got: %s", input.Body)
+	}
+
+	failed := buildHotSpareNotificationInput("tank", "/dev/ada2", "/dev/ada3", "before\n", "", errors.New("busy"))
+	if failed.Severity != "critical" || !strings.Contains(failed.Body, "busy") {
+		t.Fatalf("expected_critical_failure got: %+v", failed)
+	}
+}
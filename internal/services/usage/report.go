@@ -0,0 +1,201 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package usage
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	infoModels "github.com/alchemillahq/sylve/internal/db/models/info"
+)
+
+const (
+	GroupByGuest = "guest"
+	GroupByPool  = "pool"
+
+	bytesPerGiB = 1 << 30
+)
+
+type ReportRow struct {
+	Key            string  `json:"key"`
+	GuestType      string  `json:"guestType,omitempty"`
+	GuestID        uint    `json:"guestId,omitempty"`
+	Name           string  `json:"name,omitempty"`
+	Pool           string  `json:"pool"`
+	Guests         int     `json:"guests"`
+	RunningHours   float64 `json:"runningHours"`
+	CPUHours       float64 `json:"cpuHours"`
+	CPUUsedHours   float64 `json:"cpuUsedHours"`
+	RAMGiBHours    float64 `json:"ramGibHours"`
+	StorageGiBDays float64 `json:"storageGibDays"`
+	NetBytesIn     uint64  `json:"netBytesIn"`
+	NetBytesOut    uint64  `json:"netBytesOut"`
+}
+
+type Report struct {
+	Period  string      `json:"period"`
+	From    string      `json:"from"`
+	To      string      `json:"to"`
+	GroupBy string      `json:"groupBy"`
+	Rows    []ReportRow `json:"rows"`
+	Totals  ReportRow   `json:"totals"`
+}
+
+// ParsePeriod accepts a billing month as YYYY-MM and returns its first and
+// last day. An empty period means the current month.
+func ParsePeriod(period string, now time.Time) (string, time.Time, time.Time, error) {
+	period = strings.TrimSpace(period)
+	if period == "" {
+		period = now.UTC().Format("2006-01")
+	}
+	start, err := time.Parse("2006-01", period)
+	if err != nil {
+		return "", time.Time{}, time.Time{}, fmt.Errorf("invalid_period: expected YYYY-MM")
+	}
+	end := start.AddDate(0, 1, -1)
+	return period, start, end, nil
+}
+
+// Report aggregates the ledger for a billing month per guest or per pool.
+func (s *Service) Report(period, groupBy string) (*Report, error) {
+	groupBy = strings.TrimSpace(strings.ToLower(groupBy))
+	if groupBy == "" {
+		groupBy = GroupByGuest
+	}
+	if groupBy != GroupByGuest && groupBy != GroupByPool {
+		return nil, fmt.Errorf("invalid_group_by: expected guest or pool")
+	}
+
+	period, start, end, err := ParsePeriod(period, s.now())
+	if err != nil {
+		return nil, err
+	}
+	from := start.Format(time.DateOnly)
+	to := end.Format(time.DateOnly)
+
+	var entries []infoModels.GuestUsage
+	if err := s.DB.Where("day >= ? AND day <= ?", from, to).
+		Order("day ASC").Order("id ASC").
+		Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_load_guest_usage: %w", err)
+	}
+
+	report := &Report{
+		Period:  period,
+		From:    from,
+		To:      to,
+		GroupBy: groupBy,
+		Rows:    []ReportRow{},
+		Totals:  ReportRow{Key: "total"},
+	}
+
+	byKey := map[string]*ReportRow{}
+	guestsPerKey := map[string]map[string]struct{}{}
+	allGuests := map[string]struct{}{}
+
+	for _, entry := range entries {
+		guestKey := fmt.Sprintf("%s:%d", entry.GuestType, entry.GuestID)
+		key := guestKey
+		if groupBy == GroupByPool {
+			key = entry.Pool
+		}
+
+		row, ok := byKey[key]
+		if !ok {
+			row = &ReportRow{Key: key, Pool: entry.Pool}
+			byKey[key] = row
+			guestsPerKey[key] = map[string]struct{}{}
+		}
+		if groupBy == GroupByGuest {
+			// Later days carry the current name and pool.
+			row.GuestType = entry.GuestType
+			row.GuestID = entry.GuestID
+			row.Name = entry.Name
+			row.Pool = entry.Pool
+		}
+		guestsPerKey[key][guestKey] = struct{}{}
+		allGuests[guestKey] = struct{}{}
+
+		addUsage(row, entry)
+		addUsage(&report.Totals, entry)
+	}
+
+	for key, row := range byKey {
+		row.Guests = len(guestsPerKey[key])
+		report.Rows = append(report.Rows, *row)
+	}
+	report.Totals.Guests = len(allGuests)
+
+	sort.Slice(report.Rows, func(i, j int) bool {
+		if report.Rows[i].Pool != report.Rows[j].Pool {
+			return report.Rows[i].Pool < report.Rows[j].Pool
+		}
+		return report.Rows[i].Key < report.Rows[j].Key
+	})
+
+	return report, nil
+}
+
+func addUsage(row *ReportRow, entry infoModels.GuestUsage) {
+	row.RunningHours += entry.RunningSeconds / 3600
+	row.CPUHours += entry.CPUSeconds / 3600
+	row.CPUUsedHours += entry.CPUUsedSeconds / 3600
+	row.RAMGiBHours += entry.RAMByteSeconds / bytesPerGiB / 3600
+	row.StorageGiBDays += entry.StorageByteSeconds / bytesPerGiB / 86400
+	row.NetBytesIn += entry.NetBytesIn
+	row.NetBytesOut += entry.NetBytesOut
+}
+
+var reportCSVHeader = []string{
+	"key", "guest_type", "guest_id", "name", "pool", "guests",
+	"running_hours", "cpu_hours", "cpu_used_hours", "ram_gib_hours",
+	"storage_gib_days", "net_bytes_in", "net_bytes_out",
+}
+
+// WriteCSV writes the report rows followed by a totals row.
+func WriteCSV(w io.Writer, report *Report) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(reportCSVHeader); err != nil {
+		return err
+	}
+	for _, row := range append(report.Rows, report.Totals) {
+		guestID := ""
+		if row.GuestID != 0 {
+			guestID = strconv.FormatUint(uint64(row.GuestID), 10)
+		}
+		if err := cw.Write([]string{
+			row.Key,
+			row.GuestType,
+			guestID,
+			row.Name,
+			row.Pool,
+			strconv.Itoa(row.Guests),
+			formatQuantity(row.RunningHours),
+			formatQuantity(row.CPUHours),
+			formatQuantity(row.CPUUsedHours),
+			formatQuantity(row.RAMGiBHours),
+			formatQuantity(row.StorageGiBDays),
+			strconv.FormatUint(row.NetBytesIn, 10),
+			strconv.FormatUint(row.NetBytesOut, 10),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatQuantity(v float64) string {
+	return strconv.FormatFloat(v, 'f', 4, 64)
+}
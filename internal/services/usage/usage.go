@@ -0,0 +1,404 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	infoModels "github.com/alchemillahq/sylve/internal/db/models/info"
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	infoServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/info"
+	"github.com/alchemillahq/sylve/internal/layout"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/internal/services/jail"
+	"github.com/alchemillahq/sylve/internal/services/libvirt"
+	"github.com/alchemillahq/sylve/pkg/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	GuestTypeVM   = "vm"
+	GuestTypeJail = "jail"
+
+	SampleInterval = time.Minute
+	// A longer gap means the daemon or host was down; only one interval is
+	// billed for it rather than guessing what ran in between.
+	maxAccrualGap = 3 * SampleInterval
+	// Stats older than this are not trusted for measured CPU.
+	statsFreshness = 2 * time.Minute
+)
+
+type Service struct {
+	DB      *gorm.DB
+	Libvirt *libvirt.Service
+	Jail    *jail.Service
+
+	now            func() time.Time
+	vmRunningFn    func(rid uint) (bool, error)
+	vmNetFn        func(rid uint) (in, out uint64, ok bool)
+	jailActiveFn   func(ctid uint) (bool, error)
+	jailHashFn     func(ctid uint) string
+	linkCountersFn func() (map[string]infoServiceInterfaces.NetworkInterface, error)
+	datasetUsedFn  func(ctx context.Context, name string) (uint64, bool, error)
+
+	mu         sync.Mutex
+	lastSample time.Time
+	lastNet    map[string]netCounter
+}
+
+type netCounter struct {
+	in  uint64
+	out uint64
+}
+
+type guestSample struct {
+	guestType string
+	guestID   uint
+	name      string
+	pool      string
+	running   bool
+	cpus      float64
+	ramBytes  float64
+	usedCores float64
+	datasets  []string
+	netKnown  bool
+	netIn     uint64
+	netOut    uint64
+}
+
+func NewService(dbConn *gorm.DB, libvirtService *libvirt.Service, jailService *jail.Service) *Service {
+	s := &Service{
+		DB:             dbConn,
+		Libvirt:        libvirtService,
+		Jail:           jailService,
+		now:            func() time.Time { return time.Now().UTC() },
+		lastNet:        make(map[string]netCounter),
+		linkCountersFn: readLinkCounters,
+	}
+
+	if libvirtService != nil {
+		s.vmRunningFn = func(rid uint) (bool, error) {
+			off, err := libvirtService.IsDomainShutOff(rid)
+			return err == nil && !off, err
+		}
+		s.vmNetFn = func(rid uint) (uint64, uint64, bool) {
+			stats, err := libvirtService.GetVMFlows(rid, 1)
+			if err != nil || stats == nil {
+				return 0, 0, false
+			}
+			var in, out uint64
+			for _, iface := range stats.Interfaces {
+				in += uint64(max(iface.BytesIn, 0))
+				out += uint64(max(iface.BytesOut, 0))
+			}
+			return in, out, true
+		}
+	}
+
+	if jailService != nil {
+		s.jailActiveFn = jailService.IsJailActive
+		s.jailHashFn = jailService.GetCTIDHash
+		s.datasetUsedFn = func(ctx context.Context, name string) (uint64, bool, error) {
+			if jailService.GZFS == nil || jailService.GZFS.ZFS == nil {
+				return 0, false, fmt.Errorf("gzfs_not_initialized")
+			}
+			ds, err := jailService.GZFS.ZFS.Get(ctx, name, false)
+			if err != nil || ds == nil {
+				return 0, false, nil
+			}
+			return ds.Used, true, nil
+		}
+	}
+
+	return s
+}
+
+// Run samples usage every SampleInterval until ctx is cancelled.
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(SampleInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Sample(ctx); err != nil {
+			logger.L.Warn().Err(err).Msg("failed_to_sample_guest_usage")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sample accrues usage since the previous sample into the daily ledger. The
+// first sample after start only records counter baselines.
+func (s *Service) Sample(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	elapsed := now.Sub(s.lastSample)
+	first := s.lastSample.IsZero()
+	if elapsed > maxAccrualGap {
+		elapsed = SampleInterval
+	}
+	s.lastSample = now
+
+	guests, err := s.collectGuests(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]struct{}, len(guests))
+	rows := make([]infoModels.GuestUsage, 0, len(guests))
+	day := now.Format(time.DateOnly)
+	seconds := elapsed.Seconds()
+
+	for _, g := range guests {
+		key := fmt.Sprintf("%s:%d", g.guestType, g.guestID)
+		seen[key] = struct{}{}
+
+		var netIn, netOut uint64
+		if g.netKnown {
+			prev, had := s.lastNet[key]
+			if had {
+				netIn = counterDelta(prev.in, g.netIn)
+				netOut = counterDelta(prev.out, g.netOut)
+			}
+			s.lastNet[key] = netCounter{in: g.netIn, out: g.netOut}
+		} else {
+			delete(s.lastNet, key)
+		}
+
+		if first {
+			continue
+		}
+
+		row := infoModels.GuestUsage{
+			Day:         day,
+			GuestType:   g.guestType,
+			GuestID:     g.guestID,
+			Name:        g.name,
+			Pool:        g.pool,
+			NetBytesIn:  netIn,
+			NetBytesOut: netOut,
+		}
+		if g.running {
+			row.RunningSeconds = seconds
+			row.CPUSeconds = g.cpus * seconds
+			row.CPUUsedSeconds = g.usedCores * seconds
+			row.RAMByteSeconds = g.ramBytes * seconds
+		}
+		for _, dataset := range g.datasets {
+			if s.datasetUsedFn == nil {
+				break
+			}
+			used, ok, err := s.datasetUsedFn(ctx, dataset)
+			if err != nil {
+				logger.L.Debug().Err(err).Str("dataset", dataset).Msg("failed_to_read_guest_dataset_usage")
+				continue
+			}
+			if ok {
+				row.StorageByteSeconds += float64(used) * seconds
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	for key := range s.lastNet {
+		if _, ok := seen[key]; !ok {
+			delete(s.lastNet, key)
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	return s.DB.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "day"}, {Name: "guest_type"}, {Name: "guest_id"}},
+		DoUpdates: clause.Set{
+			clause.Assignment{Column: clause.Column{Name: "name"}, Value: gorm.Expr("excluded.name")},
+			clause.Assignment{Column: clause.Column{Name: "pool"}, Value: gorm.Expr("excluded.pool")},
+			clause.Assignment{Column: clause.Column{Name: "running_seconds"}, Value: gorm.Expr("running_seconds + excluded.running_seconds")},
+			clause.Assignment{Column: clause.Column{Name: "cpu_seconds"}, Value: gorm.Expr("cpu_seconds + excluded.cpu_seconds")},
+			clause.Assignment{Column: clause.Column{Name: "cpu_used_seconds"}, Value: gorm.Expr("cpu_used_seconds + excluded.cpu_used_seconds")},
+			clause.Assignment{Column: clause.Column{Name: "ram_byte_seconds"}, Value: gorm.Expr("ram_byte_seconds + excluded.ram_byte_seconds")},
+			clause.Assignment{Column: clause.Column{Name: "storage_byte_seconds"}, Value: gorm.Expr("storage_byte_seconds + excluded.storage_byte_seconds")},
+			clause.Assignment{Column: clause.Column{Name: "net_bytes_in"}, Value: gorm.Expr("net_bytes_in + excluded.net_bytes_in")},
+			clause.Assignment{Column: clause.Column{Name: "net_bytes_out"}, Value: gorm.Expr("net_bytes_out + excluded.net_bytes_out")},
+			clause.Assignment{Column: clause.Column{Name: "updated_at"}, Value: gorm.Expr("excluded.updated_at")},
+		},
+	}).CreateInBatches(&rows, 200).Error
+}
+
+func counterDelta(prev, cur uint64) uint64 {
+	if cur < prev {
+		// Counter reset (guest restarted or interface recreated).
+		return cur
+	}
+	return cur - prev
+}
+
+func (s *Service) collectGuests(ctx context.Context, now time.Time) ([]guestSample, error) {
+	var guests []guestSample
+
+	var vms []vmModels.VM
+	if err := s.DB.Preload("Storages", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).Find(&vms).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_load_vms: %w", err)
+	}
+	for _, vm := range vms {
+		g := guestSample{
+			guestType: GuestTypeVM,
+			guestID:   vm.RID,
+			name:      vm.Name,
+			cpus:      float64(max(vm.CPUSockets, 1) * max(vm.CPUCores, 1) * max(vm.CPUThreads, 1)),
+			ramBytes:  float64(vm.RAM),
+		}
+		pools := make([]string, 0, len(vm.Storages))
+		for _, storage := range vm.Storages {
+			if storage.Pool != "" {
+				pools = append(pools, storage.Pool)
+			}
+		}
+		pools = utils.RemoveDuplicates(pools)
+		if len(pools) > 0 {
+			g.pool = pools[0]
+		}
+		for _, pool := range pools {
+			g.datasets = append(g.datasets, fmt.Sprintf("%s/%d", layout.VMsDataset(pool), vm.RID))
+		}
+
+		if s.vmRunningFn != nil {
+			g.running, _ = s.vmRunningFn(vm.RID)
+		}
+		if g.running {
+			var stat vmModels.VMStats
+			if err := s.DB.Where("vm_id = ? AND created_at >= ?", vm.ID, now.Add(-statsFreshness)).
+				Order("created_at DESC").First(&stat).Error; err == nil {
+				g.usedCores = g.cpus * clampPercent(stat.CPUUsage) / 100
+			}
+			if s.vmNetFn != nil {
+				g.netIn, g.netOut, g.netKnown = s.vmNetFn(vm.RID)
+			}
+		}
+		guests = append(guests, g)
+	}
+
+	var jails []jailModels.Jail
+	if err := s.DB.Preload("Storages").Preload("Networks").Find(&jails).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_load_jails: %w", err)
+	}
+
+	var counters map[string]infoServiceInterfaces.NetworkInterface
+	for _, jl := range jails {
+		g := guestSample{
+			guestType: GuestTypeJail,
+			guestID:   jl.CTID,
+			name:      jl.Name,
+			cpus:      float64(max(jl.Cores, 0)),
+			ramBytes:  float64(max(jl.Memory, 0)),
+		}
+		if jl.ResourceLimits != nil && !*jl.ResourceLimits {
+			g.cpus, g.ramBytes = 0, 0
+		}
+		sort.SliceStable(jl.Storages, func(i, j int) bool { return jl.Storages[i].IsBase && !jl.Storages[j].IsBase })
+		pools := make([]string, 0, len(jl.Storages))
+		for _, storage := range jl.Storages {
+			if storage.Pool != "" {
+				pools = append(pools, storage.Pool)
+			}
+		}
+		pools = utils.RemoveDuplicates(pools)
+		if len(pools) > 0 {
+			g.pool = pools[0]
+		}
+		for _, pool := range pools {
+			g.datasets = append(g.datasets, fmt.Sprintf("%s/%d", layout.JailsDataset(pool), jl.CTID))
+		}
+
+		if s.jailActiveFn != nil {
+			g.running, _ = s.jailActiveFn(jl.CTID)
+		}
+		if g.running {
+			var stat jailModels.JailStats
+			if err := s.DB.Where("jid = ? AND created_at >= ?", jl.ID, now.Add(-statsFreshness)).
+				Order("created_at DESC").First(&stat).Error; err == nil {
+				g.usedCores = clampPercent(stat.CPUUsage) / 100
+			}
+
+			if len(jl.Networks) > 0 && s.jailHashFn != nil && s.linkCountersFn != nil {
+				if counters == nil {
+					var err error
+					if counters, err = s.linkCountersFn(); err != nil {
+						logger.L.Debug().Err(err).Msg("failed_to_read_interface_counters")
+						counters = map[string]infoServiceInterfaces.NetworkInterface{}
+					}
+				}
+				hash := s.jailHashFn(jl.CTID)
+				for _, network := range jl.Networks {
+					// The host keeps the "a" side of the epair, so what it
+					// receives is what the jail sent.
+					ifc, ok := counters[fmt.Sprintf("%s_net%da", hash, network.ID)]
+					if !ok {
+						continue
+					}
+					g.netKnown = true
+					g.netIn += uint64(max(ifc.SentBytes, 0))
+					g.netOut += uint64(max(ifc.ReceivedBytes, 0))
+				}
+			}
+		}
+		guests = append(guests, g)
+	}
+
+	return guests, nil
+}
+
+func clampPercent(v float64) float64 {
+	return min(max(v, 0), 100)
+}
+
+func readLinkCounters() (map[string]infoServiceInterfaces.NetworkInterface, error) {
+	out, err := utils.RunCommand("/usr/bin/netstat", "-ibdn", "--libxo", "json")
+	if err != nil {
+		return nil, err
+	}
+	return parseLinkCounters(out)
+}
+
+func parseLinkCounters(out string) (map[string]infoServiceInterfaces.NetworkInterface, error) {
+	var parsed struct {
+		Statistics struct {
+			Interfaces []infoServiceInterfaces.NetworkInterface `json:"interface"`
+		}
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		return nil, err
+	}
+	counters := make(map[string]infoServiceInterfaces.NetworkInterface, len(parsed.Statistics.Interfaces))
+	for _, ifc := range parsed.Statistics.Interfaces {
+		if strings.HasPrefix(ifc.Network, "<Link") {
+			counters[ifc.Name] = ifc
+		}
+	}
+	return counters, nil
+}
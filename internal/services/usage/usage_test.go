@@ -0,0 +1,234 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package usage
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"math"
+	"testing"
+	"time"
+
+	infoModels "github.com/alchemillahq/sylve/internal/db/models/info"
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	"github.com/alchemillahq/sylve/internal/testutil"
+)
+
+const gib = 1 << 30
+
+type usageFixture struct {
+	svc   *Service
+	clock time.Time
+	netIn uint64
+}
+
+func newUsageFixture(t *testing.T) *usageFixture {
+	t.Helper()
+
+	db := testutil.NewSQLiteTestDB(t,
+		&infoModels.GuestUsage{},
+		&vmModels.VM{},
+		&vmModels.Storage{},
+		&vmModels.VMStats{},
+		&jailModels.Jail{},
+		&jailModels.Storage{},
+		&jailModels.Network{},
+		&jailModels.JailStats{},
+	)
+
+	vm := vmModels.VM{Name: "web", RID: 101, CPUSockets: 1, CPUCores: 2, CPUThreads: 1, RAM: 2 * gib}
+	if err := db.Create(&vm).Error; err != nil {
+		t.Fatalf("failed to create vm: %v", err)
+	}
+	if err := db.Create(&vmModels.Storage{VMID: vm.ID, Pool: "tank", Name: "disk0"}).Error; err != nil {
+		t.Fatalf("failed to create vm storage: %v", err)
+	}
+
+	jl := jailModels.Jail{Name: "db", CTID: 7, Cores: 1, Memory: gib}
+	if err := db.Create(&jl).Error; err != nil {
+		t.Fatalf("failed to create jail: %v", err)
+	}
+	if err := db.Create(&jailModels.Storage{JailID: jl.ID, Pool: "zroot", GUID: "g1", IsBase: true}).Error; err != nil {
+		t.Fatalf("failed to create jail storage: %v", err)
+	}
+
+	f := &usageFixture{clock: time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC)}
+	f.svc = &Service{
+		DB:           db,
+		now:          func() time.Time { return f.clock },
+		lastNet:      make(map[string]netCounter),
+		vmRunningFn:  func(uint) (bool, error) { return true, nil },
+		vmNetFn:      func(uint) (uint64, uint64, bool) { return f.netIn, 0, true },
+		jailActiveFn: func(uint) (bool, error) { return false, nil },
+		datasetUsedFn: func(_ context.Context, name string) (uint64, bool, error) {
+			return 10 * gib, true, nil
+		},
+	}
+	return f
+}
+
+func (f *usageFixture) sample(t *testing.T, step time.Duration) {
+	t.Helper()
+	f.clock = f.clock.Add(step)
+	if err := f.svc.Sample(context.Background()); err != nil {
+		t.Fatalf("sample failed: %v", err)
+	}
+}
+
+func (f *usageFixture) ledger(t *testing.T, guestType string, guestID uint) infoModels.GuestUsage {
+	t.Helper()
+	var row infoModels.GuestUsage
+	if err := f.svc.DB.Where("guest_type = ? AND guest_id = ?", guestType, guestID).First(&row).Error; err != nil {
+		t.Fatalf("failed to load ledger row: %v", err)
+	}
+	return row
+}
+
+func TestSampleFirstRunOnlyRecordsBaseline(t *testing.T) {
+	f := newUsageFixture(t)
+	f.netIn = 5000
+
+	f.sample(t, 0)
+
+	var count int64
+	f.svc.DB.Model(&infoModels.GuestUsage{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("expected no ledger rows after first sample, got %d", count)
+	}
+	if got := f.svc.lastNet["vm:101"].in; got != 5000 {
+		t.Fatalf("expected baseline 5000, got %d", got)
+	}
+}
+
+func TestSampleAccruesAcrossSamples(t *testing.T) {
+	f := newUsageFixture(t)
+	f.netIn = 1000
+	f.sample(t, 0)
+
+	f.netIn = 1500
+	f.sample(t, time.Minute)
+	f.netIn = 2500
+	f.sample(t, time.Minute)
+
+	vm := f.ledger(t, GuestTypeVM, 101)
+	if vm.RunningSeconds != 120 {
+		t.Fatalf("expected 120 running seconds, got %v", vm.RunningSeconds)
+	}
+	if vm.CPUSeconds != 240 {
+		t.Fatalf("expected 240 vCPU seconds, got %v", vm.CPUSeconds)
+	}
+	if vm.RAMByteSeconds != 2*gib*120 {
+		t.Fatalf("unexpected ram byte seconds %v", vm.RAMByteSeconds)
+	}
+	if vm.NetBytesIn != 1500 {
+		t.Fatalf("expected 1500 bytes in, got %d", vm.NetBytesIn)
+	}
+	if vm.Pool != "tank" {
+		t.Fatalf("expected pool tank, got %q", vm.Pool)
+	}
+
+	jail := f.ledger(t, GuestTypeJail, 7)
+	if jail.RunningSeconds != 0 || jail.CPUSeconds != 0 {
+		t.Fatalf("stopped jail should not accrue compute, got %+v", jail)
+	}
+	if jail.StorageByteSeconds != 10*gib*120 {
+		t.Fatalf("stopped jail should still accrue storage, got %v", jail.StorageByteSeconds)
+	}
+}
+
+func TestSampleCounterResetAndGapClamp(t *testing.T) {
+	f := newUsageFixture(t)
+	f.netIn = 9000
+	f.sample(t, 0)
+
+	// Guest restarted: counters start over.
+	f.netIn = 300
+	f.sample(t, time.Hour)
+
+	vm := f.ledger(t, GuestTypeVM, 101)
+	if vm.NetBytesIn != 300 {
+		t.Fatalf("expected reset counter to bill 300 bytes, got %d", vm.NetBytesIn)
+	}
+	if vm.RunningSeconds != SampleInterval.Seconds() {
+		t.Fatalf("expected long gap to be clamped to one interval, got %v", vm.RunningSeconds)
+	}
+}
+
+func TestReportGroupsByPoolAndExportsCSV(t *testing.T) {
+	f := newUsageFixture(t)
+	db := f.svc.DB
+
+	rows := []infoModels.GuestUsage{
+		{Day: "2025-09-01", GuestType: GuestTypeVM, GuestID: 101, Name: "web", Pool: "tank", RunningSeconds: 3600, CPUSeconds: 7200, RAMByteSeconds: 2 * gib * 3600, StorageByteSeconds: 10 * gib * 86400, NetBytesIn: 10},
+		{Day: "2025-09-02", GuestType: GuestTypeVM, GuestID: 102, Name: "api", Pool: "tank", RunningSeconds: 3600, CPUSeconds: 3600, NetBytesOut: 20},
+		{Day: "2025-09-02", GuestType: GuestTypeJail, GuestID: 7, Name: "db", Pool: "zroot", CPUSeconds: 1800},
+		{Day: "2025-10-01", GuestType: GuestTypeVM, GuestID: 101, Name: "web", Pool: "tank", CPUSeconds: 99999},
+	}
+	if err := db.Create(&rows).Error; err != nil {
+		t.Fatalf("failed to seed ledger: %v", err)
+	}
+
+	report, err := f.svc.Report("2025-09", GroupByPool)
+	if err != nil {
+		t.Fatalf("report failed: %v", err)
+	}
+	if report.From != "2025-09-01" || report.To != "2025-09-30" {
+		t.Fatalf("unexpected range %s..%s", report.From, report.To)
+	}
+	if len(report.Rows) != 2 || report.Rows[0].Key != "tank" || report.Rows[1].Key != "zroot" {
+		t.Fatalf("unexpected rows %+v", report.Rows)
+	}
+
+	tank := report.Rows[0]
+	if tank.Guests != 2 || tank.CPUHours != 3 || tank.RAMGiBHours != 2 || tank.StorageGiBDays != 10 {
+		t.Fatalf("unexpected tank aggregate %+v", tank)
+	}
+	if tank.NetBytesIn != 10 || tank.NetBytesOut != 20 {
+		t.Fatalf("unexpected tank network %+v", tank)
+	}
+	if math.Abs(report.Totals.CPUHours-3.5) > 1e-9 || report.Totals.Guests != 3 {
+		t.Fatalf("unexpected totals %+v", report.Totals)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, report); err != nil {
+		t.Fatalf("csv failed: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("csv parse failed: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("expected header, two rows and totals, got %d records", len(records))
+	}
+	if records[1][0] != "tank" || records[1][7] != "3.0000" || records[3][0] != "total" {
+		t.Fatalf("unexpected csv %v", records)
+	}
+}
+
+func TestReportRejectsBadInput(t *testing.T) {
+	f := newUsageFixture(t)
+
+	if _, err := f.svc.Report("2025-13", ""); err == nil {
+		t.Fatal("expected invalid period error")
+	}
+	if _, err := f.svc.Report("2025-09", "tag"); err == nil {
+		t.Fatal("expected invalid group error")
+	}
+
+	report, err := f.svc.Report("", "")
+	if err != nil {
+		t.Fatalf("default period failed: %v", err)
+	}
+	if report.Period != "2025-09" || report.GroupBy != GroupByGuest {
+		t.Fatalf("unexpected defaults %+v", report)
+	}
+}
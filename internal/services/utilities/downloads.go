@@ -243,6 +243,11 @@ func (s *Service) DownloadFile(req utilitiesServiceInterfaces.DownloadFileReques
 	tmpUUID := utils.GenerateDeterministicUUID(url)
 
 	if utils.IsMagnetURI(url) {
+		// Torrents are checked piece by piece against the torrent itself.
+		if req.Verification != nil {
+			return 0, fmt.Errorf("verification_not_supported_for_torrents")
+		}
+
 		download := utilitiesModels.Downloads{
 			URL:                    url,
 			UUID:                   tmpUUID,
@@ -325,6 +330,9 @@ func (s *Service) DownloadFile(req utilitiesServiceInterfaces.DownloadFileReques
 			AutomaticRawConversion: automaticRawConversion,
 			IgnoreTLS:              ignoreTLS,
 		}
+		if err := applyVerificationRequest(&download, req.Verification); err != nil {
+			return 0, err
+		}
 
 		if err := s.DB.Create(&download).Error; err != nil {
 			logger.L.Error().Msgf("Failed to create download record: %v", err)
@@ -392,6 +400,9 @@ func (s *Service) DownloadFile(req utilitiesServiceInterfaces.DownloadFileReques
 			UType:                  downloadType,
 			IgnoreTLS:              ignoreTLS,
 		}
+		if err := applyVerificationRequest(&download, req.Verification); err != nil {
+			return 0, err
+		}
 
 		if err := s.DB.Create(&download).Error; err != nil {
 			logger.L.Error().Msgf("Failed to create download record: %v", err)
@@ -540,6 +551,11 @@ func (s *Service) StartDownload(id *uint) error {
 		download.Progress = 100
 		download.Path = destPath
 
+		if err := s.verifyDownload(context.Background(), download); err != nil {
+			logger.L.Error().Uint("download_id", *id).Err(err).Msg("download_verification_failed")
+			return s.failDownload(download, err)
+		}
+
 		needPostProc := download.AutomaticExtraction || download.AutomaticRawConversion
 
 		if needPostProc {
@@ -602,6 +618,11 @@ func (s *Service) StartPostProcess(id *uint) error {
 		return nil
 	}
 
+	if err := s.verifyDownload(context.Background(), &d); err != nil {
+		logger.L.Error().Uint("download_id", d.ID).Err(err).Msg("download_verification_failed")
+		return s.failDownload(&d, err)
+	}
+
 	if !d.AutomaticExtraction && !d.AutomaticRawConversion {
 		return s.finishDownload(&d, "")
 	}
@@ -1028,7 +1049,8 @@ func (s *Service) syncHTTP(download *utilitiesModels.Downloads) {
 			download.Size = info.Size()
 		}
 
-		if !download.AutomaticExtraction && !download.AutomaticRawConversion {
+		if !download.AutomaticExtraction && !download.AutomaticRawConversion &&
+			download.Verification != utilitiesModels.DownloadVerificationPending {
 			download.Progress = 100
 			download.Status = utilitiesModels.DownloadStatusDone
 			if err := s.DB.Model(download).Select("Progress", "Size", "Status").Updates(download).Error; err != nil {
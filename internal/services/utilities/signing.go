@@ -15,6 +15,7 @@ import (
 	"strings"
 
 	"github.com/alchemillahq/sylve/internal/db/models"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
 	"github.com/alchemillahq/sylve/pkg/crypto"
 	"github.com/alchemillahq/sylve/pkg/utils"
 	"gorm.io/gorm"
@@ -59,13 +60,13 @@ func (s *Service) getOrCreateDownloadSigningSecret() (string, error) {
 	var secret models.SystemSecrets
 	err := s.DB.Where("name = ?", downloadSigningSecretName).First(&secret).Error
 	if err == nil {
-		if strings.TrimSpace(secret.Data) != "" {
-			s.setCachedDownloadSigningSecret(secret.Data)
-			return strings.TrimSpace(secret.Data), nil
+		if strings.TrimSpace(string(secret.Data)) != "" {
+			s.setCachedDownloadSigningSecret(string(secret.Data))
+			return strings.TrimSpace(string(secret.Data)), nil
 		}
 
 		fresh := utils.GenerateRandomString(64)
-		if updateErr := s.DB.Model(&secret).Update("data", fresh).Error; updateErr != nil {
+		if updateErr := s.DB.Model(&secret).Update("data", secrets.String(fresh)).Error; updateErr != nil {
 			return "", fmt.Errorf("download_signing_secret_update_failed: %w", updateErr)
 		}
 		s.setCachedDownloadSigningSecret(fresh)
@@ -78,13 +79,13 @@ func (s *Service) getOrCreateDownloadSigningSecret() (string, error) {
 	fresh := utils.GenerateRandomString(64)
 	created := models.SystemSecrets{
 		Name: downloadSigningSecretName,
-		Data: fresh,
+		Data: secrets.String(fresh),
 	}
 	if createErr := s.DB.Create(&created).Error; createErr != nil {
 		// Handle concurrent creation on startup races.
-		if lookupErr := s.DB.Where("name = ?", downloadSigningSecretName).First(&secret).Error; lookupErr == nil && strings.TrimSpace(secret.Data) != "" {
-			s.setCachedDownloadSigningSecret(secret.Data)
-			return strings.TrimSpace(secret.Data), nil
+		if lookupErr := s.DB.Where("name = ?", downloadSigningSecretName).First(&secret).Error; lookupErr == nil && strings.TrimSpace(string(secret.Data)) != "" {
+			s.setCachedDownloadSigningSecret(string(secret.Data))
+			return strings.TrimSpace(string(secret.Data)), nil
 		}
 		return "", fmt.Errorf("download_signing_secret_create_failed: %w", createErr)
 	}
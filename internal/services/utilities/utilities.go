@@ -19,6 +19,7 @@ import (
 
 	"github.com/alchemillahq/sylve/internal/config"
 	"github.com/alchemillahq/sylve/internal/db"
+	utilitiesModels "github.com/alchemillahq/sylve/internal/db/models/utilities"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
 	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
 	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
@@ -173,6 +174,19 @@ func (s *Service) RegisterJobs() {
 		return nil
 	})
 
+	db.QueueRegisterJSON("utils-download-verify", func(ctx context.Context, payload utilitiesServiceInterfaces.DownloadVerifyPayload) error {
+		var d utilitiesModels.Downloads
+		if err := s.DB.First(&d, "id = ?", payload.ID).Error; err != nil {
+			logger.L.Error().Uint("download_id", payload.ID).Err(err).Msg("VerifyDownload lookup failed")
+			return nil
+		}
+		if err := s.verifyDownload(ctx, &d); err != nil {
+			logger.L.Warn().Uint("download_id", payload.ID).Err(err).Msg("VerifyDownload failed")
+		}
+
+		return nil
+	})
+
 	s.registerWoLJobs()
 }
 
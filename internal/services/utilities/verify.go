@@ -0,0 +1,326 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package utilities
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/db"
+	utilitiesModels "github.com/alchemillahq/sylve/internal/db/models/utilities"
+	utilitiesServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/utilities"
+	"github.com/alchemillahq/sylve/internal/logger"
+
+	valid "github.com/asaskevich/govalidator"
+)
+
+// verificationFetchLimit caps checksum lists and signatures; both are a few
+// kilobytes at most.
+const verificationFetchLimit = 1 << 20
+
+func normalizeSHA256(value string) (string, error) {
+	sum := strings.ToLower(strings.TrimSpace(value))
+	if len(sum) != sha256.Size*2 {
+		return "", fmt.Errorf("invalid_sha256")
+	}
+	if _, err := hex.DecodeString(sum); err != nil {
+		return "", fmt.Errorf("invalid_sha256")
+	}
+	return sum, nil
+}
+
+func parseSignaturePublicKey(pemText string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(pemText)))
+	if block == nil {
+		return nil, fmt.Errorf("invalid_signature_public_key")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid_signature_public_key: %w", err)
+	}
+	switch pub.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+		return pub, nil
+	}
+	return nil, fmt.Errorf("unsupported_signature_public_key")
+}
+
+// verifyDigestSignature checks a signature as written by
+// `openssl dgst -sha256 -sign`, which signs the SHA256 digest of the input.
+func verifyDigestSignature(pub crypto.PublicKey, digest, sig []byte) bool {
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, sig) == nil
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, digest, sig)
+	}
+	return false
+}
+
+func isHTTPURL(value string) bool {
+	lower := strings.ToLower(value)
+	return valid.IsURL(value) && (strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://"))
+}
+
+// applyVerificationRequest validates req and records it on d. A download with
+// something to check against is left pending until the file is hashed.
+func applyVerificationRequest(d *utilitiesModels.Downloads, req *utilitiesServiceInterfaces.DownloadVerificationRequest) error {
+	d.ExpectedSHA256, d.ChecksumURL, d.SignatureURL, d.SignaturePublicKey = "", "", "", ""
+	d.Verification = utilitiesModels.DownloadVerificationUnverified
+	if req == nil {
+		return nil
+	}
+
+	sum := strings.TrimSpace(req.SHA256)
+	if sum != "" {
+		var err error
+		if sum, err = normalizeSHA256(sum); err != nil {
+			return err
+		}
+	}
+
+	checksumURL := strings.TrimSpace(req.ChecksumURL)
+	if checksumURL != "" && !isHTTPURL(checksumURL) {
+		return fmt.Errorf("invalid_checksum_url")
+	}
+
+	signatureURL := strings.TrimSpace(req.SignatureURL)
+	publicKey := strings.TrimSpace(req.SignaturePublicKey)
+	if signatureURL != "" && !isHTTPURL(signatureURL) {
+		return fmt.Errorf("invalid_signature_url")
+	}
+	if signatureURL != "" && publicKey == "" {
+		return fmt.Errorf("signature_public_key_required")
+	}
+	if publicKey != "" && signatureURL == "" {
+		return fmt.Errorf("signature_url_required")
+	}
+	if publicKey != "" {
+		if _, err := parseSignaturePublicKey(publicKey); err != nil {
+			return err
+		}
+	}
+
+	d.ExpectedSHA256 = sum
+	d.ChecksumURL = checksumURL
+	d.SignatureURL = signatureURL
+	d.SignaturePublicKey = publicKey
+	if sum != "" || checksumURL != "" || signatureURL != "" {
+		d.Verification = utilitiesModels.DownloadVerificationPending
+	}
+	return nil
+}
+
+// parseChecksumList returns the SHA256 listed for name, in either the
+// sha256sum format ("<hash>  <name>", "*" marking binary mode) or the BSD one
+// ("SHA256 (<name>) = <hash>") that FreeBSD publishes its images with.
+func parseChecksumList(list []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(list))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if rest, ok := strings.CutPrefix(line, "SHA256 ("); ok {
+			listed, sum, found := strings.Cut(rest, ") = ")
+			if found && listed == name {
+				return normalizeSHA256(sum)
+			}
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return normalizeSHA256(fields[0])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed_to_read_checksum_list: %w", err)
+	}
+	return "", fmt.Errorf("checksum_entry_not_found: %s", name)
+}
+
+func (s *Service) fetchVerificationFile(ctx context.Context, d *utilitiesModels.Downloads, url string) ([]byte, error) {
+	client := s.GrabClient
+	if d.IgnoreTLS {
+		client = s.GrabInsecure
+	}
+	if client == nil || client.HTTPClient == nil {
+		return nil, fmt.Errorf("download_client_unavailable")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected_status_%d: %s", resp.StatusCode, url)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, verificationFetchLimit))
+}
+
+func sha256File(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return nil, err
+	}
+	return hasher.Sum(nil), nil
+}
+
+func (s *Service) checkDownloadExpectations(ctx context.Context, d *utilitiesModels.Downloads, digest []byte) error {
+	sum := hex.EncodeToString(digest)
+	if d.ExpectedSHA256 != "" && d.ExpectedSHA256 != sum {
+		return fmt.Errorf("checksum_mismatch: expected %s, got %s", d.ExpectedSHA256, sum)
+	}
+
+	var list []byte
+	if d.ChecksumURL != "" {
+		var err error
+		if list, err = s.fetchVerificationFile(ctx, d, d.ChecksumURL); err != nil {
+			return fmt.Errorf("failed_to_fetch_checksum_list: %w", err)
+		}
+		listed, err := parseChecksumList(list, d.Name)
+		if err != nil {
+			return err
+		}
+		if listed != sum {
+			return fmt.Errorf("checksum_mismatch: expected %s, got %s", listed, sum)
+		}
+	}
+
+	if d.SignatureURL != "" {
+		pub, err := parseSignaturePublicKey(d.SignaturePublicKey)
+		if err != nil {
+			return err
+		}
+		sig, err := s.fetchVerificationFile(ctx, d, d.SignatureURL)
+		if err != nil {
+			return fmt.Errorf("failed_to_fetch_signature: %w", err)
+		}
+		signed := digest
+		if list != nil {
+			listDigest := sha256.Sum256(list)
+			signed = listDigest[:]
+		}
+		if !verifyDigestSignature(pub, signed, sig) {
+			return fmt.Errorf("signature_invalid")
+		}
+	}
+
+	return nil
+}
+
+// verifyDownload hashes a pending download and checks it against what was
+// supplied with it. It runs before post-processing, while d.Path is still
+// the file that was fetched, and does nothing for downloads that are not
+// pending.
+func (s *Service) verifyDownload(ctx context.Context, d *utilitiesModels.Downloads) error {
+	if d.Verification != utilitiesModels.DownloadVerificationPending {
+		return nil
+	}
+
+	digest, err := sha256File(d.Path)
+	if err == nil {
+		d.SHA256 = hex.EncodeToString(digest)
+		err = s.checkDownloadExpectations(ctx, d, digest)
+	} else {
+		err = fmt.Errorf("failed_to_hash_download: %w", err)
+	}
+
+	d.VerifiedAt = nil
+	if err != nil {
+		d.Verification = utilitiesModels.DownloadVerificationFailed
+		d.VerificationError = err.Error()
+	} else {
+		now := time.Now().UTC()
+		d.Verification = utilitiesModels.DownloadVerificationVerified
+		d.VerificationError = ""
+		d.VerifiedAt = &now
+	}
+
+	if saveErr := s.DB.Model(&utilitiesModels.Downloads{}).
+		Where("id = ?", d.ID).
+		Updates(map[string]any{
+			"sha256":             d.SHA256,
+			"verification":       d.Verification,
+			"verification_error": d.VerificationError,
+			"verified_at":        d.VerifiedAt,
+		}).Error; saveErr != nil {
+		logger.L.Error().Uint("download_id", d.ID).Err(saveErr).Msg("failed_to_save_download_verification")
+	}
+
+	return err
+}
+
+// VerifyDownload checks a finished download against new expectations, for
+// files that were imported or downloaded without them.
+func (s *Service) VerifyDownload(id uint, req utilitiesServiceInterfaces.DownloadVerificationRequest) error {
+	var d utilitiesModels.Downloads
+	if err := s.DB.First(&d, "id = ?", id).Error; err != nil {
+		return fmt.Errorf("download_not_found: %w", err)
+	}
+
+	switch {
+	case d.Type == utilitiesModels.DownloadTypeTorrent:
+		return fmt.Errorf("verification_not_supported_for_torrents")
+	case d.Status != utilitiesModels.DownloadStatusDone:
+		return fmt.Errorf("download_not_done")
+	case d.AutomaticRawConversion:
+		// The fetched file was replaced by its raw conversion.
+		return fmt.Errorf("download_converted_cannot_verify")
+	}
+
+	if err := applyVerificationRequest(&d, &req); err != nil {
+		return err
+	}
+	if d.Verification != utilitiesModels.DownloadVerificationPending {
+		return fmt.Errorf("verification_expectation_required")
+	}
+
+	if err := s.DB.Model(&d).Updates(map[string]any{
+		"expected_sha256":      d.ExpectedSHA256,
+		"checksum_url":         d.ChecksumURL,
+		"signature_url":        d.SignatureURL,
+		"signature_public_key": d.SignaturePublicKey,
+		"verification":         d.Verification,
+		"verification_error":   "",
+		"verified_at":          nil,
+	}).Error; err != nil {
+		return fmt.Errorf("failed_to_update_download_record: %w", err)
+	}
+
+	return db.EnqueueJSON(context.Background(), "utils-download-verify", &utilitiesServiceInterfaces.DownloadVerifyPayload{
+		ID: d.ID,
+	})
+}
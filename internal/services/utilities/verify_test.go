@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package utilities
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	utilitiesModels "github.com/alchemillahq/sylve/internal/db/models/utilities"
+	utilitiesServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/utilities"
+	"github.com/alchemillahq/sylve/internal/testutil"
+
+	"github.com/cavaliergopher/grab/v3"
+)
+
+func TestParseChecksumListFormats(t *testing.T) {
+	sum := strings.Repeat("ab", sha256.Size)
+	list := []byte("SHA256 (FreeBSD-14.3-RELEASE-amd64-disc1.iso) = " + sum + "\n" +
+		strings.Repeat("cd", sha256.Size) + " *ubuntu-24.04-live-server-amd64.iso\n")
+
+	if got, err := parseChecksumList(list, "FreeBSD-14.3-RELEASE-amd64-disc1.iso"); err != nil || got != sum {
+		t.Fatalf("expected BSD style entry, got %q, %v", got, err)
+	}
+	if got, err := parseChecksumList(list, "ubuntu-24.04-live-server-amd64.iso"); err != nil || got != strings.Repeat("cd", sha256.Size) {
+		t.Fatalf("expected sha256sum style entry, got %q, %v", got, err)
+	}
+	if _, err := parseChecksumList(list, "other.iso"); err == nil {
+		t.Fatal("expected a missing entry to fail")
+	}
+}
+
+func TestApplyVerificationRequestValidates(t *testing.T) {
+	var d utilitiesModels.Downloads
+	if err := applyVerificationRequest(&d, nil); err != nil || d.Verification != utilitiesModels.DownloadVerificationUnverified {
+		t.Fatalf("expected no request to leave the download unverified, got %q, %v", d.Verification, err)
+	}
+	if err := applyVerificationRequest(&d, &utilitiesServiceInterfaces.DownloadVerificationRequest{SHA256: "abc"}); err == nil {
+		t.Fatal("expected a short SHA256 to be refused")
+	}
+	if err := applyVerificationRequest(&d, &utilitiesServiceInterfaces.DownloadVerificationRequest{SignatureURL: "https://example.com/a.sig"}); err == nil {
+		t.Fatal("expected a signature without a public key to be refused")
+	}
+	if err := applyVerificationRequest(&d, &utilitiesServiceInterfaces.DownloadVerificationRequest{SHA256: strings.Repeat("AB", sha256.Size)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Verification != utilitiesModels.DownloadVerificationPending || d.ExpectedSHA256 != strings.Repeat("ab", sha256.Size) {
+		t.Fatalf("expected a pending lower-case expectation, got %+v", d)
+	}
+}
+
+func TestVerifyDownloadChecksumListAndSignature(t *testing.T) {
+	db := testutil.NewSQLiteTestDB(t, &utilitiesModels.Downloads{}, &utilitiesModels.DownloadedFile{})
+
+	image := []byte("not really an installer")
+	imagePath := filepath.Join(t.TempDir(), "disc1.iso")
+	if err := os.WriteFile(imagePath, image, 0o644); err != nil {
+		t.Fatalf("failed to write image: %v", err)
+	}
+	imageSum := sha256.Sum256(image)
+	list := []byte("SHA256 (disc1.iso) = " + hex.EncodeToString(imageSum[:]) + "\n")
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	listSum := sha256.Sum256(list)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, listSum[:])
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/CHECKSUM.SHA256":
+			w.Write(list)
+		case "/CHECKSUM.SHA256.sig":
+			w.Write(sig)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	service := &Service{DB: db, GrabClient: grab.NewClient()}
+	download := utilitiesModels.Downloads{
+		UUID: "verify-uuid",
+		Path: imagePath,
+		Name: "disc1.iso",
+		Type: utilitiesModels.DownloadTypePath,
+		URL:  imagePath,
+	}
+	if err := applyVerificationRequest(&download, &utilitiesServiceInterfaces.DownloadVerificationRequest{
+		ChecksumURL:        srv.URL + "/CHECKSUM.SHA256",
+		SignatureURL:       srv.URL + "/CHECKSUM.SHA256.sig",
+		SignaturePublicKey: publicKey,
+	}); err != nil {
+		t.Fatalf("unexpected request error: %v", err)
+	}
+	if err := db.Create(&download).Error; err != nil {
+		t.Fatalf("failed to seed download: %v", err)
+	}
+
+	if err := service.verifyDownload(context.Background(), &download); err != nil {
+		t.Fatalf("expected the download to verify, got %v", err)
+	}
+
+	var stored utilitiesModels.Downloads
+	if err := db.First(&stored, download.ID).Error; err != nil {
+		t.Fatalf("failed to reload download: %v", err)
+	}
+	if stored.Verification != utilitiesModels.DownloadVerificationVerified || stored.VerifiedAt == nil ||
+		stored.SHA256 != hex.EncodeToString(imageSum[:]) {
+		t.Fatalf("expected a verified record, got %+v", stored)
+	}
+
+	// A file that changed since the list was signed no longer matches.
+	if err := os.WriteFile(imagePath, []byte("tampered"), 0o644); err != nil {
+		t.Fatalf("failed to rewrite image: %v", err)
+	}
+	stored.Verification = utilitiesModels.DownloadVerificationPending
+	if err := service.verifyDownload(context.Background(), &stored); err == nil || !strings.HasPrefix(err.Error(), "checksum_mismatch") {
+		t.Fatalf("expected a checksum mismatch, got %v", err)
+	}
+	if err := db.First(&stored, download.ID).Error; err != nil {
+		t.Fatalf("failed to reload download: %v", err)
+	}
+	if stored.Verification != utilitiesModels.DownloadVerificationFailed || stored.VerifiedAt != nil {
+		t.Fatalf("expected a failed record, got %+v", stored)
+	}
+}
@@ -0,0 +1,556 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	webhookModels "github.com/alchemillahq/sylve/internal/db/models/webhooks"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
+	"github.com/alchemillahq/sylve/internal/logger"
+	webhookFacade "github.com/alchemillahq/sylve/internal/webhooks"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidWebhook   = errors.New("invalid webhook")
+	ErrWebhookNotFound  = errors.New("webhook not found")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+)
+
+const (
+	deliveryBatchSize   = 50
+	deliveryRetention   = 30 * 24 * time.Hour
+	maximumRetryDelay   = time.Hour
+	workerInterval      = 15 * time.Second
+	maxResponseBodyRead = 64 * 1024
+)
+
+type Service struct {
+	DB *gorm.DB
+
+	client   *http.Client
+	now      func() time.Time
+	hostname func() (string, error)
+
+	wake      chan struct{}
+	deliverMu sync.Mutex
+}
+
+func NewService(db *gorm.DB) *Service {
+	return &Service{
+		DB: db,
+		client: &http.Client{
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now:      time.Now,
+		hostname: utils.GetSystemHostname,
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+func invalidWebhook(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidWebhook, fmt.Sprintf(format, args...))
+}
+
+func (s *Service) ListWebhooks(ctx context.Context) ([]WebhookView, error) {
+	var hooks []webhookModels.Webhook
+	if err := s.DB.WithContext(ctx).Order("id ASC").Find(&hooks).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	views := make([]WebhookView, len(hooks))
+	for index, hook := range hooks {
+		views[index] = webhookView(hook)
+	}
+	return views, nil
+}
+
+func (s *Service) CreateWebhook(ctx context.Context, input WebhookInput) (*WebhookView, error) {
+	hook, err := prepareWebhook(input, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.DB.WithContext(ctx).Create(&hook).Error; err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	view := webhookView(hook)
+	return &view, nil
+}
+
+func (s *Service) UpdateWebhook(ctx context.Context, id uint, input WebhookInput) (*WebhookView, error) {
+	existing, err := s.getWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	hook, err := prepareWebhook(input, existing)
+	if err != nil {
+		return nil, err
+	}
+	hook.ID = existing.ID
+	hook.CreatedAt = existing.CreatedAt
+
+	if err := s.DB.WithContext(ctx).Save(&hook).Error; err != nil {
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	view := webhookView(hook)
+	return &view, nil
+}
+
+func (s *Service) DeleteWebhook(ctx context.Context, id uint) error {
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&webhookModels.Webhook{}, id)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete webhook: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrWebhookNotFound
+		}
+		if err := tx.Where("webhook_id = ?", id).Delete(&webhookModels.Delivery{}).Error; err != nil {
+			return fmt.Errorf("failed to delete webhook deliveries: %w", err)
+		}
+		return nil
+	})
+}
+
+func (s *Service) ListDeliveries(ctx context.Context, webhookID uint, limit int) ([]webhookModels.Delivery, error) {
+	if _, err := s.getWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+
+	var deliveries []webhookModels.Delivery
+	if err := s.DB.WithContext(ctx).
+		Where("webhook_id = ?", webhookID).
+		Order("id DESC").
+		Limit(limit).
+		Find(&deliveries).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// TestWebhook queues a ping event for one webhook regardless of its event
+// filter or enabled state.
+func (s *Service) TestWebhook(ctx context.Context, id uint) (*webhookModels.Delivery, error) {
+	hook, err := s.getWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, eventID, err := s.buildEnvelope(webhookFacade.EventPing, map[string]any{"webhookId": hook.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.queueDelivery(ctx, hook.ID, eventID, webhookFacade.EventPing, payload)
+	if err != nil {
+		return nil, err
+	}
+	s.notify()
+	return delivery, nil
+}
+
+// RedeliverDelivery queues a fresh attempt of a past delivery with the same
+// event ID and body, keeping the original in the history.
+func (s *Service) RedeliverDelivery(ctx context.Context, webhookID, deliveryID uint) (*webhookModels.Delivery, error) {
+	if _, err := s.getWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	var original webhookModels.Delivery
+	if err := s.DB.WithContext(ctx).
+		Where("id = ? AND webhook_id = ?", deliveryID, webhookID).
+		First(&original).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve webhook delivery: %w", err)
+	}
+
+	delivery, err := s.queueDelivery(ctx, webhookID, original.EventID, original.EventType, original.Payload)
+	if err != nil {
+		return nil, err
+	}
+	s.notify()
+	return delivery, nil
+}
+
+// Publish queues a delivery of the event for every enabled webhook whose
+// filter selects it. Delivery happens on the worker, so this only costs a
+// few inserts on the caller's path.
+func (s *Service) Publish(eventType string, data map[string]any) {
+	var hooks []webhookModels.Webhook
+	if err := s.DB.Where("enabled = ?", true).Find(&hooks).Error; err != nil {
+		logger.L.Warn().Err(err).Str("event", eventType).Msg("webhook_publish_list_failed")
+		return
+	}
+
+	var payload, eventID string
+	queued := 0
+	for _, hook := range hooks {
+		if !webhookFacade.MatchesFilter(hook.Events, eventType) {
+			continue
+		}
+		if payload == "" {
+			var err error
+			payload, eventID, err = s.buildEnvelope(eventType, data)
+			if err != nil {
+				logger.L.Warn().Err(err).Str("event", eventType).Msg("webhook_payload_encode_failed")
+				return
+			}
+		}
+		if _, err := s.queueDelivery(context.Background(), hook.ID, eventID, eventType, payload); err != nil {
+			logger.L.Warn().Err(err).Uint("webhook_id", hook.ID).Str("event", eventType).Msg("webhook_enqueue_failed")
+			continue
+		}
+		queued++
+	}
+
+	if queued > 0 {
+		s.notify()
+	}
+}
+
+func (s *Service) StartWorker(ctx context.Context) {
+	if err := s.ProcessDue(ctx); err != nil {
+		logger.L.Error().Err(err).Msg("webhook_initial_delivery_failed")
+	}
+
+	ticker := time.NewTicker(workerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.L.Info().Msg("stopping_webhook_worker")
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+
+		if err := s.ProcessDue(ctx); err != nil {
+			logger.L.Error().Err(err).Msg("webhook_worker_failed")
+		}
+	}
+}
+
+// ProcessDue attempts every pending delivery whose retry time has come and
+// prunes finished history past the retention window.
+func (s *Service) ProcessDue(ctx context.Context) error {
+	s.deliverMu.Lock()
+	defer s.deliverMu.Unlock()
+
+	now := s.now().UTC()
+	var deliveries []webhookModels.Delivery
+	if err := s.DB.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", webhookModels.DeliveryStatusPending, now).
+		Order("next_attempt_at ASC, id ASC").
+		Limit(deliveryBatchSize).
+		Find(&deliveries).Error; err != nil {
+		return fmt.Errorf("failed to list due webhook deliveries: %w", err)
+	}
+
+	hooks := make(map[uint]*webhookModels.Webhook)
+	for i := range deliveries {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		delivery := &deliveries[i]
+		hook, ok := hooks[delivery.WebhookID]
+		if !ok {
+			loaded, err := s.getWebhook(ctx, delivery.WebhookID)
+			if err != nil && !errors.Is(err, ErrWebhookNotFound) {
+				return err
+			}
+			hook = loaded
+			hooks[delivery.WebhookID] = hook
+		}
+
+		s.attemptDelivery(ctx, hook, delivery)
+	}
+
+	if err := s.DB.WithContext(ctx).
+		Where("status <> ? AND created_at < ?", webhookModels.DeliveryStatusPending, now.Add(-deliveryRetention)).
+		Delete(&webhookModels.Delivery{}).Error; err != nil {
+		logger.L.Warn().Err(err).Msg("webhook_delivery_prune_failed")
+	}
+
+	return nil
+}
+
+func (s *Service) attemptDelivery(ctx context.Context, hook *webhookModels.Webhook, delivery *webhookModels.Delivery) {
+	now := s.now().UTC()
+	updates := map[string]any{
+		"attempts":        delivery.Attempts + 1,
+		"last_attempt_at": now,
+	}
+
+	if hook == nil {
+		updates["status"] = webhookModels.DeliveryStatusFailed
+		updates["error"] = "webhook_not_found"
+		updates["next_attempt_at"] = nil
+	} else {
+		status, err := s.send(ctx, hook, delivery)
+		updates["response_status"] = status
+		attempts := delivery.Attempts + 1
+
+		switch {
+		case err == nil:
+			updates["status"] = webhookModels.DeliveryStatusSuccess
+			updates["error"] = ""
+			updates["delivered_at"] = now
+			updates["next_attempt_at"] = nil
+		case attempts >= hook.MaxAttempts:
+			updates["status"] = webhookModels.DeliveryStatusFailed
+			updates["error"] = err.Error()
+			updates["next_attempt_at"] = nil
+		default:
+			updates["error"] = err.Error()
+			updates["next_attempt_at"] = now.Add(retryDelay(hook.RetryBackoffSeconds, attempts))
+		}
+	}
+
+	if err := s.DB.WithContext(ctx).Model(delivery).Updates(updates).Error; err != nil {
+		logger.L.Warn().Err(err).Uint("delivery_id", delivery.ID).Msg("webhook_delivery_update_failed")
+	}
+}
+
+func (s *Service) send(ctx context.Context, hook *webhookModels.Webhook, delivery *webhookModels.Delivery) (int, error) {
+	timeout := time.Duration(hook.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = time.Duration(DefaultTimeoutSeconds) * time.Second
+	}
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("webhook_request_build_failed: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Sylve-Webhook/1")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, delivery.EventID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	if hook.Secret != "" {
+		req.Header.Set(HeaderSignature, Sign(string(hook.Secret), timestamp, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("webhook_request_failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBodyRead))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook_unexpected_status: %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature header value for body. Receivers recompute it
+// over "<timestamp>.<body>" with the shared secret and should reject stale
+// timestamps to prevent replays.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// retryDelay doubles the base backoff for every failed attempt, capped at an
+// hour.
+func retryDelay(baseSeconds uint, attempts uint) time.Duration {
+	delay := time.Duration(baseSeconds) * time.Second
+	if delay <= 0 {
+		delay = time.Duration(DefaultRetryBackoffSeconds) * time.Second
+	}
+	for i := uint(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= maximumRetryDelay {
+			return maximumRetryDelay
+		}
+	}
+	return delay
+}
+
+func (s *Service) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Service) buildEnvelope(eventType string, data map[string]any) (string, string, error) {
+	if data == nil {
+		data = map[string]any{}
+	}
+
+	envelope := Envelope{
+		ID:        uuid.NewString(),
+		Event:     eventType,
+		Timestamp: s.now().UTC(),
+		Data:      data,
+	}
+	if s.hostname != nil {
+		if hostname, err := s.hostname(); err == nil {
+			envelope.Hostname = hostname
+		}
+	}
+
+	encoded, err := json.Marshal(envelope)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+	return string(encoded), envelope.ID, nil
+}
+
+func (s *Service) queueDelivery(ctx context.Context, webhookID uint, eventID, eventType, payload string) (*webhookModels.Delivery, error) {
+	now := s.now().UTC()
+	delivery := webhookModels.Delivery{
+		WebhookID:     webhookID,
+		EventID:       eventID,
+		EventType:     eventType,
+		Payload:       payload,
+		Status:        webhookModels.DeliveryStatusPending,
+		NextAttemptAt: &now,
+	}
+	if err := s.DB.WithContext(ctx).Create(&delivery).Error; err != nil {
+		return nil, fmt.Errorf("failed to queue webhook delivery: %w", err)
+	}
+	return &delivery, nil
+}
+
+func (s *Service) getWebhook(ctx context.Context, id uint) (*webhookModels.Webhook, error) {
+	var hook webhookModels.Webhook
+	if err := s.DB.WithContext(ctx).First(&hook, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve webhook: %w", err)
+	}
+	return &hook, nil
+}
+
+func prepareWebhook(input WebhookInput, existing *webhookModels.Webhook) (webhookModels.Webhook, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return webhookModels.Webhook{}, invalidWebhook("name is required")
+	}
+
+	rawURL := strings.TrimSpace(input.URL)
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return webhookModels.Webhook{}, invalidWebhook("url must be an absolute http or https URL")
+	}
+
+	secret := input.Secret
+	if secret == "" && existing != nil {
+		secret = string(existing.Secret)
+	}
+	if len(secret) < MinimumSecretLength {
+		return webhookModels.Webhook{}, invalidWebhook("secret must be at least %d characters", MinimumSecretLength)
+	}
+
+	events := make([]string, 0, len(input.Events))
+	seen := make(map[string]struct{}, len(input.Events))
+	for _, event := range input.Events {
+		event = strings.TrimSpace(event)
+		if event == "" {
+			continue
+		}
+		if _, dup := seen[event]; dup {
+			continue
+		}
+		if !isValidEventFilter(event) {
+			return webhookModels.Webhook{}, invalidWebhook("unknown event %q", event)
+		}
+		seen[event] = struct{}{}
+		events = append(events, event)
+	}
+
+	maxAttempts := input.MaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	if maxAttempts > MaximumMaxAttempts {
+		return webhookModels.Webhook{}, invalidWebhook("max attempts must be between 1 and %d", MaximumMaxAttempts)
+	}
+
+	backoff := input.RetryBackoffSeconds
+	if backoff == 0 {
+		backoff = DefaultRetryBackoffSeconds
+	}
+	if backoff > MaximumRetryBackoffSeconds {
+		return webhookModels.Webhook{}, invalidWebhook("retry backoff must be between 1 and %d seconds", MaximumRetryBackoffSeconds)
+	}
+
+	timeout := input.TimeoutSeconds
+	if timeout == 0 {
+		timeout = DefaultTimeoutSeconds
+	}
+	if timeout > MaximumTimeoutSeconds {
+		return webhookModels.Webhook{}, invalidWebhook("timeout must be between 1 and %d seconds", MaximumTimeoutSeconds)
+	}
+
+	return webhookModels.Webhook{
+		Name:                name,
+		URL:                 rawURL,
+		Secret:              secrets.String(secret),
+		Events:              events,
+		Enabled:             input.Enabled,
+		MaxAttempts:         maxAttempts,
+		RetryBackoffSeconds: backoff,
+		TimeoutSeconds:      timeout,
+	}, nil
+}
+
+func isValidEventFilter(event string) bool {
+	if event == "*" || webhookFacade.IsKnownEvent(event) {
+		return true
+	}
+	if group, ok := strings.CutSuffix(event, ".*"); ok {
+		for _, known := range webhookFacade.Events {
+			if strings.HasPrefix(known, group+".") {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	webhookModels "github.com/alchemillahq/sylve/internal/db/models/webhooks"
+	"github.com/alchemillahq/sylve/internal/testutil"
+	webhookFacade "github.com/alchemillahq/sylve/internal/webhooks"
+)
+
+const testSecret = "0123456789abcdef"
+
+type recordedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+type testReceiver struct {
+	mu       sync.Mutex
+	status   int
+	requests []recordedRequest
+}
+
+func (r *testReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	r.requests = append(r.requests, recordedRequest{header: req.Header.Clone(), body: body})
+	status := r.status
+	r.mu.Unlock()
+	w.WriteHeader(status)
+}
+
+func newTestService(t *testing.T) (*Service, *time.Time) {
+	t.Helper()
+
+	db := testutil.NewSQLiteTestDB(t, &webhookModels.Webhook{}, &webhookModels.Delivery{})
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	s := NewService(db)
+	s.now = func() time.Time { return now }
+	s.hostname = func() (string, error) { return "node-a", nil }
+	return s, &now
+}
+
+func createTestWebhook(t *testing.T, s *Service, url string, events []string, maxAttempts uint) *WebhookView {
+	t.Helper()
+
+	hook, err := s.CreateWebhook(context.Background(), WebhookInput{
+		Name:        "cmdb",
+		URL:         url,
+		Secret:      testSecret,
+		Events:      events,
+		Enabled:     true,
+		MaxAttempts: maxAttempts,
+	})
+	if err != nil {
+		t.Fatalf("create webhook: %v", err)
+	}
+	return hook
+}
+
+func TestCreateWebhookValidatesInput(t *testing.T) {
+	s, _ := newTestService(t)
+
+	for name, input := range map[string]WebhookInput{
+		"missing name":  {URL: "https://example.com/hook", Secret: testSecret},
+		"relative url":  {Name: "a", URL: "/hook", Secret: testSecret},
+		"ftp url":       {Name: "a", URL: "ftp://example.com/hook", Secret: testSecret},
+		"short secret":  {Name: "a", URL: "https://example.com/hook", Secret: "short"},
+		"unknown event": {Name: "a", URL: "https://example.com/hook", Secret: testSecret, Events: []string{"guest.exploded"}},
+		"unknown group": {Name: "a", URL: "https://example.com/hook", Secret: testSecret, Events: []string{"pool.*"}},
+		"max attempts":  {Name: "a", URL: "https://example.com/hook", Secret: testSecret, MaxAttempts: MaximumMaxAttempts + 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := s.CreateWebhook(context.Background(), input); !errors.Is(err, ErrInvalidWebhook) {
+				t.Fatalf("expected invalid webhook error, got %v", err)
+			}
+		})
+	}
+
+	hook, err := s.CreateWebhook(context.Background(), WebhookInput{
+		Name:   "chatops",
+		URL:    "https://example.com/hook",
+		Secret: testSecret,
+		Events: []string{"guest.*", webhookFacade.EventBackupCompleted},
+	})
+	if err != nil {
+		t.Fatalf("create webhook: %v", err)
+	}
+	if hook.MaxAttempts != DefaultMaxAttempts || hook.RetryBackoffSeconds != DefaultRetryBackoffSeconds || !hook.SecretConfigured {
+		t.Fatalf("unexpected defaults: %#v", hook)
+	}
+
+	// An empty secret on update keeps the stored one.
+	updated, err := s.UpdateWebhook(context.Background(), hook.ID, WebhookInput{
+		Name: "chatops",
+		URL:  "https://example.com/other",
+	})
+	if err != nil {
+		t.Fatalf("update webhook: %v", err)
+	}
+	if updated.Secret != testSecret || updated.URL != "https://example.com/other" {
+		t.Fatalf("unexpected update result: %#v", updated)
+	}
+}
+
+func TestPublishDeliversSignedPayloadToMatchingWebhooks(t *testing.T) {
+	s, _ := newTestService(t)
+	receiver := &testReceiver{status: http.StatusNoContent}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	matching := createTestWebhook(t, s, server.URL, []string{"guest.*"}, 0)
+	if _, err := s.CreateWebhook(context.Background(), WebhookInput{
+		Name:    "backups-only",
+		URL:     server.URL,
+		Secret:  testSecret,
+		Events:  []string{webhookFacade.EventBackupCompleted},
+		Enabled: true,
+	}); err != nil {
+		t.Fatalf("create filtered webhook: %v", err)
+	}
+
+	s.Publish(webhookFacade.EventGuestStarted, map[string]any{"guestType": "vm", "guestId": 101})
+	if err := s.ProcessDue(context.Background()); err != nil {
+		t.Fatalf("process due: %v", err)
+	}
+
+	if len(receiver.requests) != 1 {
+		t.Fatalf("expected exactly one delivery, got %d", len(receiver.requests))
+	}
+	req := receiver.requests[0]
+	if got := req.header.Get(HeaderEvent); got != webhookFacade.EventGuestStarted {
+		t.Fatalf("unexpected event header %q", got)
+	}
+	if want := Sign(testSecret, req.header.Get(HeaderTimestamp), req.body); req.header.Get(HeaderSignature) != want {
+		t.Fatalf("signature mismatch: got %q want %q", req.header.Get(HeaderSignature), want)
+	}
+
+	var envelope Envelope
+	if err := json.Unmarshal(req.body, &envelope); err != nil {
+		t.Fatalf("decode envelope: %v", err)
+	}
+	if envelope.ID != req.header.Get(HeaderDelivery) || envelope.Hostname != "node-a" || envelope.Data["guestType"] != "vm" {
+		t.Fatalf("unexpected envelope: %#v", envelope)
+	}
+
+	deliveries, err := s.ListDeliveries(context.Background(), matching.ID, 0)
+	if err != nil {
+		t.Fatalf("list deliveries: %v", err)
+	}
+	if len(deliveries) != 1 || deliveries[0].Status != webhookModels.DeliveryStatusSuccess ||
+		deliveries[0].Attempts != 1 || deliveries[0].ResponseStatus != http.StatusNoContent {
+		t.Fatalf("unexpected delivery history: %#v", deliveries)
+	}
+}
+
+func TestFailedDeliveriesRetryWithBackoffUntilExhausted(t *testing.T) {
+	s, now := newTestService(t)
+	receiver := &testReceiver{status: http.StatusInternalServerError}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	hook := createTestWebhook(t, s, server.URL, nil, 2)
+	s.Publish(webhookFacade.EventNodeStatusChanged, map[string]any{"nodeId": "n1"})
+
+	if err := s.ProcessDue(context.Background()); err != nil {
+		t.Fatalf("first attempt: %v", err)
+	}
+	deliveries, _ := s.ListDeliveries(context.Background(), hook.ID, 0)
+	first := deliveries[0]
+	if first.Status != webhookModels.DeliveryStatusPending || first.NextAttemptAt == nil ||
+		!first.NextAttemptAt.Equal(now.Add(time.Duration(DefaultRetryBackoffSeconds)*time.Second)) {
+		t.Fatalf("expected a scheduled retry, got %#v", first)
+	}
+
+	// Not yet due.
+	if err := s.ProcessDue(context.Background()); err != nil {
+		t.Fatalf("early pass: %v", err)
+	}
+	if len(receiver.requests) != 1 {
+		t.Fatalf("retry must wait for its backoff, got %d requests", len(receiver.requests))
+	}
+
+	*now = now.Add(time.Hour)
+	if err := s.ProcessDue(context.Background()); err != nil {
+		t.Fatalf("second attempt: %v", err)
+	}
+	deliveries, _ = s.ListDeliveries(context.Background(), hook.ID, 0)
+	if deliveries[0].Status != webhookModels.DeliveryStatusFailed || deliveries[0].Attempts != 2 ||
+		deliveries[0].Error != "webhook_unexpected_status: 500" {
+		t.Fatalf("expected delivery to fail after max attempts, got %#v", deliveries[0])
+	}
+
+	redelivery, err := s.RedeliverDelivery(context.Background(), hook.ID, deliveries[0].ID)
+	if err != nil {
+		t.Fatalf("redeliver: %v", err)
+	}
+	if redelivery.EventID != deliveries[0].EventID || redelivery.Status != webhookModels.DeliveryStatusPending {
+		t.Fatalf("unexpected redelivery: %#v", redelivery)
+	}
+}
+
+func TestRetryDelayDoublesAndCaps(t *testing.T) {
+	if got := retryDelay(30, 1); got != 30*time.Second {
+		t.Fatalf("first retry = %s", got)
+	}
+	if got := retryDelay(30, 3); got != 2*time.Minute {
+		t.Fatalf("third retry = %s", got)
+	}
+	if got := retryDelay(3600, 5); got != maximumRetryDelay {
+		t.Fatalf("capped retry = %s", got)
+	}
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package webhooks
+
+import (
+	"time"
+
+	webhookModels "github.com/alchemillahq/sylve/internal/db/models/webhooks"
+)
+
+const (
+	DefaultMaxAttempts         uint = 5
+	MaximumMaxAttempts         uint = 20
+	DefaultRetryBackoffSeconds uint = 30
+	MaximumRetryBackoffSeconds uint = 3600
+	DefaultTimeoutSeconds      uint = 10
+	MaximumTimeoutSeconds      uint = 60
+	MinimumSecretLength             = 16
+
+	HeaderEvent     = "X-Sylve-Event"
+	HeaderDelivery  = "X-Sylve-Delivery"
+	HeaderTimestamp = "X-Sylve-Timestamp"
+	HeaderSignature = "X-Sylve-Signature"
+)
+
+type WebhookInput struct {
+	Name                string   `json:"name"`
+	URL                 string   `json:"url"`
+	Secret              string   `json:"secret"`
+	Events              []string `json:"events"`
+	Enabled             bool     `json:"enabled"`
+	MaxAttempts         uint     `json:"maxAttempts"`
+	RetryBackoffSeconds uint     `json:"retryBackoffSeconds"`
+	TimeoutSeconds      uint     `json:"timeoutSeconds"`
+}
+
+type WebhookView struct {
+	webhookModels.Webhook
+	SecretConfigured bool `json:"secretConfigured"`
+}
+
+// Envelope is the JSON body POSTed to a webhook. ID is shared by every
+// delivery of the same event so receivers can deduplicate retries.
+type Envelope struct {
+	ID        string         `json:"id"`
+	Event     string         `json:"event"`
+	Timestamp time.Time      `json:"timestamp"`
+	Hostname  string         `json:"hostname,omitempty"`
+	Data      map[string]any `json:"data"`
+}
+
+func webhookView(hook webhookModels.Webhook) WebhookView {
+	if hook.Events == nil {
+		hook.Events = []string{}
+	}
+	return WebhookView{
+		Webhook:          hook,
+		SecretConfigured: hook.Secret != "",
+	}
+}
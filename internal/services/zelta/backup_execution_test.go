@@ -394,7 +394,7 @@ func TestRunBackupJobVMStopBeforeBackupInvalidRID(t *testing.T) {
 
 func TestResolveVMBackupSourceDatasetsNilVM(t *testing.T) {
 	svc := &Service{VM: nil, DB: nil}
-	sources, err := svc.resolveVMBackupSourceDatasets(context.Background(), 42, "zroot/virtual-machines/42")
+	sources, _, err := svc.resolveVMBackupSourceDatasets(context.Background(), 42, "zroot/virtual-machines/42", vmDatasetRules{})
 	if err == nil {
 		t.Logf("resolveVMBackupSourceDatasets with nil VM/DB returned %v sources (no error)", len(sources))
 		return
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+
+package zelta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/hookscript"
+	"github.com/alchemillahq/sylve/internal/logger"
+)
+
+const (
+	backupHookPhasePre  = "pre-backup"
+	backupHookPhasePost = "post-backup"
+
+	backupHookOutputLimit = 16 << 10
+)
+
+// backupHookRunCommand is swapped out in tests.
+var backupHookRunCommand = hookscript.Run
+
+func backupHookScript(job *clusterModels.BackupJob, phase string) string {
+	if phase == backupHookPhasePre {
+		return strings.TrimSpace(job.PreBackupScript)
+	}
+	return strings.TrimSpace(job.PostBackupScript)
+}
+
+// runBackupHook runs one of the job's hook scripts with the job and its
+// source in the environment. It returns the output to append to the event
+// and an error only when the failure should fail the run.
+func (s *Service) runBackupHook(ctx context.Context, job *clusterModels.BackupJob, eventID uint, phase, sourceDataset string) (string, error) {
+	script := backupHookScript(job, phase)
+	if script == "" {
+		return "", nil
+	}
+
+	timeout := time.Duration(clusterModels.BackupHookDefaultTimeoutSeconds) * time.Second
+	if job.HookTimeoutSeconds > 0 {
+		timeout = time.Duration(job.HookTimeoutSeconds) * time.Second
+	}
+
+	// Backup hooks follow the same rules as guest lifecycle hooks.
+	script, runErr := hookscript.Validate(script)
+	var out string
+	if runErr == nil {
+		hookCtx, cancel := context.WithTimeout(ctx, timeout)
+		env := []string{
+			"SYLVE_HOOK_PHASE=" + phase,
+			"SYLVE_BACKUP_JOB_ID=" + strconv.FormatUint(uint64(job.ID), 10),
+			"SYLVE_BACKUP_EVENT_ID=" + strconv.FormatUint(uint64(eventID), 10),
+			"SYLVE_BACKUP_MODE=" + job.Mode,
+			"SYLVE_BACKUP_SOURCE=" + sourceDataset,
+		}
+		out, runErr = backupHookRunCommand(hookCtx, script, []string{phase, sourceDataset}, env)
+		if runErr != nil && errors.Is(hookCtx.Err(), context.DeadlineExceeded) {
+			runErr = fmt.Errorf("timed out after %s", timeout)
+		}
+		cancel()
+	}
+
+	out = strings.TrimSpace(out)
+	if len(out) > backupHookOutputLimit {
+		out = out[len(out)-backupHookOutputLimit:]
+	}
+
+	output := phase + "_hook: ok"
+	if runErr != nil {
+		output = fmt.Sprintf("%s_hook: failed: %v", phase, runErr)
+	}
+	if out != "" {
+		output += "\n" + out
+	}
+
+	if runErr == nil {
+		return output, nil
+	}
+
+	logger.L.Warn().Err(runErr).Uint("job_id", job.ID).Str("phase", phase).Msg("backup_hook_failed")
+	if job.HookFailurePolicy == clusterModels.BackupHookFailurePolicyIgnore {
+		return output, nil
+	}
+	return output, fmt.Errorf("backup_hook_failed: %s: %w", phase, runErr)
+}
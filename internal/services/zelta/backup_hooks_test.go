@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+
+package zelta
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/hookscript"
+)
+
+type hookFileInfo struct {
+	mode fs.FileMode
+	uid  uint32
+}
+
+func (f hookFileInfo) Name() string       { return "hook.sh" }
+func (f hookFileInfo) Size() int64        { return 0 }
+func (f hookFileInfo) Mode() fs.FileMode  { return f.mode }
+func (f hookFileInfo) ModTime() time.Time { return time.Time{} }
+func (f hookFileInfo) IsDir() bool        { return f.mode.IsDir() }
+func (f hookFileInfo) Sys() any           { return &syscall.Stat_t{Uid: f.uid} }
+
+func stubBackupHook(t *testing.T, info hookFileInfo, run func(context.Context) (string, error)) *[]string {
+	t.Helper()
+
+	origStat, origRun := hookscript.Stat, backupHookRunCommand
+	t.Cleanup(func() {
+		hookscript.Stat, backupHookRunCommand = origStat, origRun
+	})
+
+	hookscript.Stat = func(string) (os.FileInfo, error) { return info, nil }
+
+	var env []string
+	backupHookRunCommand = func(ctx context.Context, _ string, _ []string, e []string) (string, error) {
+		env = e
+		return run(ctx)
+	}
+	return &env
+}
+
+func TestRunBackupHookRefusesUnsafeScript(t *testing.T) {
+	stubBackupHook(t, hookFileInfo{mode: 0o775}, func(context.Context) (string, error) {
+		t.Fatal("unexpected hook run")
+		return "", nil
+	})
+
+	job := &clusterModels.BackupJob{ID: 7, PreBackupScript: "/root/pre.sh"}
+	out, err := (&Service{}).runBackupHook(context.Background(), job, 42, backupHookPhasePre, "tank/data")
+	if err == nil || !strings.Contains(out, "invalid_hook_script: group writable") {
+		t.Fatalf("expected a group writable script to be refused, got %q %v", out, err)
+	}
+}
+
+func TestRunBackupHookPassesJobEnvironment(t *testing.T) {
+	env := stubBackupHook(t, hookFileInfo{mode: 0o755}, func(context.Context) (string, error) {
+		return "flushed\n", nil
+	})
+
+	job := &clusterModels.BackupJob{ID: 7, Mode: clusterModels.BackupJobModeDataset, PreBackupScript: "/root/pre.sh"}
+	out, err := (&Service{}).runBackupHook(context.Background(), job, 42, backupHookPhasePre, "tank/data")
+	if err != nil {
+		t.Fatalf("expected hook to succeed, got %v", err)
+	}
+	if out != "pre-backup_hook: ok\nflushed" {
+		t.Fatalf("unexpected output %q", out)
+	}
+
+	joined := strings.Join(*env, " ")
+	for _, want := range []string{"SYLVE_HOOK_PHASE=pre-backup", "SYLVE_BACKUP_JOB_ID=7", "SYLVE_BACKUP_EVENT_ID=42", "SYLVE_BACKUP_SOURCE=tank/data"} {
+		if !strings.Contains(joined, want) {
+			t.Fatalf("expected %s in hook environment, got %v", want, *env)
+		}
+	}
+}
+
+func TestRunBackupHookFailurePolicy(t *testing.T) {
+	stubBackupHook(t, hookFileInfo{mode: 0o755}, func(context.Context) (string, error) {
+		return "", errors.New("exit status 1")
+	})
+
+	job := &clusterModels.BackupJob{ID: 7, PostBackupScript: "/root/post.sh"}
+	out, err := (&Service{}).runBackupHook(context.Background(), job, 42, backupHookPhasePost, "tank/data")
+	if err == nil || !strings.Contains(err.Error(), "backup_hook_failed: post-backup") {
+		t.Fatalf("expected fail policy to fail the run, got %v", err)
+	}
+	if !strings.Contains(out, "post-backup_hook: failed: exit status 1") {
+		t.Fatalf("expected failure in event output, got %q", out)
+	}
+
+	job.HookFailurePolicy = clusterModels.BackupHookFailurePolicyIgnore
+	if _, err := (&Service{}).runBackupHook(context.Background(), job, 42, backupHookPhasePost, "tank/data"); err != nil {
+		t.Fatalf("expected ignore policy to keep the run going, got %v", err)
+	}
+}
+
+func TestRunBackupHookTimeout(t *testing.T) {
+	stubBackupHook(t, hookFileInfo{mode: 0o755}, func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+
+	job := &clusterModels.BackupJob{ID: 7, PreBackupScript: "/root/pre.sh", HookTimeoutSeconds: 1}
+	_, err := (&Service{}).runBackupHook(context.Background(), job, 42, backupHookPhasePre, "tank/data")
+	if err == nil || !strings.Contains(err.Error(), "timed out after 1s") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+}
+
+func TestRunBackupHookSkipsEmptyScript(t *testing.T) {
+	stubBackupHook(t, hookFileInfo{mode: 0o755}, func(context.Context) (string, error) {
+		t.Fatal("unexpected hook run")
+		return "", nil
+	})
+
+	out, err := (&Service{}).runBackupHook(context.Background(), &clusterModels.BackupJob{}, 1, backupHookPhasePre, "tank/data")
+	if out != "" || err != nil {
+		t.Fatalf("expected no-op, got %q %v", out, err)
+	}
+}
@@ -22,6 +22,7 @@ import (
 type backupScope struct {
 	sourceDataset string
 	destSuffix    string
+	exclude       []string // datasets below sourceDataset left out of the transfer
 }
 
 func (s *Service) backupRunScopes(job *clusterModels.BackupJob, sourceDataset, destSuffix string, vmSourceDatasets []string) []backupScope {
@@ -0,0 +1,533 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/utils"
+)
+
+// A backup seed carries the initial full stream of a job on removable media.
+// The seed snapshot uses the job's own snapshot prefix, so once it has been
+// received on the target and adopted, Zelta finds it as the common base and
+// the job continues incrementally without a full send over the WAN.
+const (
+	backupSeedVersion      = 1
+	backupSeedManifestFile = "sylve-seed.json"
+	backupSeedDirPrefix    = "sylve-seed-"
+)
+
+type BackupSeedStream struct {
+	SourceDataset string `json:"sourceDataset"`
+	DestSuffix    string `json:"destSuffix"`
+	File          string `json:"file"`
+	Bytes         int64  `json:"bytes"`
+	SHA256        string `json:"sha256"`
+	Encrypted     bool   `json:"encrypted"`
+}
+
+type BackupSeedManifest struct {
+	Version      int                `json:"version"`
+	JobID        uint               `json:"jobId"`
+	JobName      string             `json:"jobName"`
+	SnapshotName string             `json:"snapshotName"`
+	Recursive    bool               `json:"recursive"`
+	CreatedAt    time.Time          `json:"createdAt"`
+	Streams      []BackupSeedStream `json:"streams"`
+
+	// Directory is where the seed lives on the local filesystem. It is not
+	// part of the archive because removable media moves between hosts.
+	Directory string `json:"-"`
+}
+
+func backupSeedStreamFileName(index int) string {
+	return fmt.Sprintf("stream-%02d.zfs", index)
+}
+
+func backupSeedSendArgs(sourceDataset, snapshotName string, recursive, encrypted bool, exclude ...string) []string {
+	streamFlag := "-p"
+	if recursive {
+		streamFlag = "-R"
+	}
+
+	var args []string
+	if encrypted {
+		args = append(args, "send", "--raw", streamFlag)
+	} else {
+		args = append(args, "send", streamFlag, "-L", "-c", "-e")
+	}
+	if recursive && len(exclude) > 0 {
+		args = append(args, "-X", strings.Join(exclude, ","))
+	}
+	return append(args, normalizeDatasetPath(sourceDataset)+"@"+snapshotName)
+}
+
+func validateBackupSeedManifest(manifest BackupSeedManifest) error {
+	if manifest.Version != backupSeedVersion {
+		return fmt.Errorf("unsupported_backup_seed_version: %d", manifest.Version)
+	}
+	if manifest.JobID == 0 {
+		return fmt.Errorf("backup_seed_job_id_required")
+	}
+	snapshotName, err := normalizeBackupSnapshotName(manifest.SnapshotName)
+	if err != nil {
+		return fmt.Errorf("invalid_backup_seed_snapshot: %w", err)
+	}
+	if !backupSnapshotRequiresCommit(manifest.JobID, snapshotName) {
+		return fmt.Errorf("backup_seed_snapshot_not_owned_by_job")
+	}
+	if len(manifest.Streams) == 0 {
+		return fmt.Errorf("backup_seed_streams_required")
+	}
+
+	seenFiles := make(map[string]struct{}, len(manifest.Streams))
+	seenSuffixes := make(map[string]struct{}, len(manifest.Streams))
+	for _, stream := range manifest.Streams {
+		file := strings.TrimSpace(stream.File)
+		if file == "" || file != filepath.Base(file) || file == "." || file == ".." {
+			return fmt.Errorf("invalid_backup_seed_stream_file: %q", stream.File)
+		}
+		if _, ok := seenFiles[file]; ok {
+			return fmt.Errorf("duplicate_backup_seed_stream_file: %s", file)
+		}
+		seenFiles[file] = struct{}{}
+
+		suffix := normalizeDatasetPath(stream.DestSuffix)
+		if strings.HasPrefix(suffix, "/") || strings.Contains(suffix, "..") || strings.ContainsAny(suffix, "@#") {
+			return fmt.Errorf("invalid_backup_seed_dest_suffix: %q", stream.DestSuffix)
+		}
+		if _, ok := seenSuffixes[suffix]; ok {
+			return fmt.Errorf("duplicate_backup_seed_dest_suffix: %s", suffix)
+		}
+		seenSuffixes[suffix] = struct{}{}
+
+		if normalizeDatasetPath(stream.SourceDataset) == "" {
+			return fmt.Errorf("backup_seed_stream_source_required")
+		}
+		if _, err := hex.DecodeString(stream.SHA256); err != nil || len(stream.SHA256) != sha256.Size*2 {
+			return fmt.Errorf("invalid_backup_seed_stream_checksum: %s", file)
+		}
+	}
+	return nil
+}
+
+func readBackupSeedManifest(seedDir string) (*BackupSeedManifest, error) {
+	data, err := os.ReadFile(filepath.Join(seedDir, backupSeedManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("read_backup_seed_manifest_failed: %w", err)
+	}
+
+	var manifest BackupSeedManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parse_backup_seed_manifest_failed: %w", err)
+	}
+	if err := validateBackupSeedManifest(manifest); err != nil {
+		return nil, err
+	}
+	manifest.Directory = seedDir
+	return &manifest, nil
+}
+
+// backupSeedScopes resolves the same source/destination pairs a job run
+// would use, so the seeded datasets land exactly where Zelta expects them.
+func (s *Service) backupSeedScopes(ctx context.Context, job *clusterModels.BackupJob) ([]backupScope, error) {
+	switch job.Mode {
+	case clusterModels.BackupJobModeDataset:
+		source := normalizeDatasetPath(job.SourceDataset)
+		if source == "" {
+			return nil, fmt.Errorf("source_dataset_required")
+		}
+		destSuffix := s.backupDestSuffixForMode(job.Mode, strings.TrimSpace(job.DestSuffix), source)
+		return s.backupRunScopes(job, source, destSuffix, nil), nil
+	case clusterModels.BackupJobModeJail:
+		source := normalizeDatasetPath(job.JailRootDataset)
+		if source == "" {
+			return nil, fmt.Errorf("jail_root_dataset_required")
+		}
+		destSuffix := s.backupDestSuffixForJailSource(strings.TrimSpace(job.DestSuffix), source)
+		return s.backupRunScopes(job, source, destSuffix, nil), nil
+	case clusterModels.BackupJobModeVM:
+		source := normalizeDatasetPath(job.SourceDataset)
+		_, vmRID := inferRestoreDatasetKind(source)
+		if vmRID == 0 {
+			return nil, fmt.Errorf("invalid_vm_source_dataset")
+		}
+		sources, excluded, err := s.resolveVMBackupSourceDatasets(ctx, vmRID, source, vmDatasetRulesForJob(job))
+		if err != nil {
+			return nil, fmt.Errorf("resolve_vm_backup_sources_failed: %w", err)
+		}
+		for _, vmSource := range sources {
+			exists, err := s.localDatasetExists(ctx, vmSource)
+			if err != nil {
+				return nil, fmt.Errorf("failed_to_check_vm_backup_source_dataset_%s: %w", vmSource, err)
+			}
+			if !exists {
+				return nil, fmt.Errorf("vm_backup_source_dataset_not_found: %s", vmSource)
+			}
+		}
+		if len(sources) == 0 {
+			return nil, fmt.Errorf("vm_source_datasets_not_found")
+		}
+		scopes := s.backupRunScopes(job, source, "", sources)
+		for i := range scopes {
+			scopes[i].exclude = excluded
+		}
+		return scopes, nil
+	default:
+		return nil, fmt.Errorf("invalid_backup_job_mode")
+	}
+}
+
+// ExportBackupSeed snapshots the job's sources with a job-owned snapshot
+// name and writes one full stream per source into a new seed directory
+// under directory, which is expected to be a mounted removable disk. The
+// job should stay disabled until the seed has been imported and adopted,
+// otherwise a scheduled run would start the full send anyway.
+func (s *Service) ExportBackupSeed(ctx context.Context, jobID uint, directory string) (*BackupSeedManifest, error) {
+	directory = strings.TrimSpace(directory)
+	if directory == "" || !filepath.IsAbs(directory) {
+		return nil, fmt.Errorf("backup_seed_directory_must_be_absolute")
+	}
+	directory = filepath.Clean(directory)
+	info, err := os.Stat(directory)
+	if err != nil {
+		return nil, fmt.Errorf("backup_seed_directory_unavailable: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("backup_seed_directory_not_a_directory")
+	}
+
+	var job clusterModels.BackupJob
+	if err := s.DB.Preload("Target").First(&job, jobID).Error; err != nil {
+		return nil, fmt.Errorf("backup_job_not_found: %w", err)
+	}
+	if !s.isLocalBackupJobRunner(&job, s.localNodeID()) {
+		return nil, fmt.Errorf("backup_seed_must_run_on_job_runner")
+	}
+
+	if !s.beginJob(job.ID) {
+		return nil, fmt.Errorf("backup_job_already_running")
+	}
+	defer s.releaseJob(job.ID)
+
+	scopes, err := s.backupSeedScopes(ctx, &job)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		roots = append(roots, scope.sourceDataset)
+	}
+	acquired, holder, heldRoots := s.acquireDatasetOperations(roots)
+	if !acquired {
+		return nil, fmt.Errorf("backup_dataset_operation_conflict: holder=%s", holder)
+	}
+	defer s.releaseDatasetOperations(heldRoots)
+
+	snapshotName := backupSnapshotNameForJob(job.ID)
+	seedDir := filepath.Join(directory, backupSeedDirPrefix+snapshotName)
+	if err := os.Mkdir(seedDir, 0700); err != nil {
+		return nil, fmt.Errorf("create_backup_seed_directory_failed: %w", err)
+	}
+
+	snapshotted := make([]string, 0, len(scopes))
+	success := false
+	defer func() {
+		if success {
+			return
+		}
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+		defer cancel()
+		for _, source := range snapshotted {
+			args := []string{"destroy"}
+			if job.Recursive {
+				args = append(args, "-r")
+			}
+			args = append(args, source+"@"+snapshotName)
+			if output, err := utils.RunCommandWithContext(cleanupCtx, "zfs", args...); err != nil {
+				logger.L.Warn().
+					Err(err).
+					Str("snapshot", source+"@"+snapshotName).
+					Str("output", strings.TrimSpace(output)).
+					Msg("backup_seed_snapshot_cleanup_failed")
+			}
+		}
+		if err := os.RemoveAll(seedDir); err != nil {
+			logger.L.Warn().Err(err).Str("dir", seedDir).Msg("backup_seed_directory_cleanup_failed")
+		}
+	}()
+
+	for _, scope := range scopes {
+		args := []string{"snapshot"}
+		if job.Recursive {
+			args = append(args, "-r")
+		}
+		args = append(args, scope.sourceDataset+"@"+snapshotName)
+		if output, err := utils.RunCommandWithContext(ctx, "zfs", args...); err != nil {
+			return nil, fmt.Errorf("backup_seed_snapshot_failed: %s: %w", strings.TrimSpace(output), err)
+		}
+		snapshotted = append(snapshotted, scope.sourceDataset)
+	}
+
+	manifest := &BackupSeedManifest{
+		Version:      backupSeedVersion,
+		JobID:        job.ID,
+		JobName:      job.Name,
+		SnapshotName: snapshotName,
+		Recursive:    job.Recursive,
+		CreatedAt:    time.Now().UTC(),
+		Streams:      make([]BackupSeedStream, 0, len(scopes)),
+	}
+
+	for i, scope := range scopes {
+		encrypted, err := s.isDatasetEncrypted(ctx, scope.sourceDataset)
+		if err != nil {
+			return nil, fmt.Errorf("backup_seed_encryption_check_failed: %w", err)
+		}
+
+		file := backupSeedStreamFileName(i)
+		logger.L.Info().
+			Uint("job_id", job.ID).
+			Str("source", scope.sourceDataset).
+			Str("file", filepath.Join(seedDir, file)).
+			Msg("backup_seed_stream_export_started")
+
+		written, checksum, err := writeBackupSeedStream(
+			ctx,
+			backupSeedSendArgs(scope.sourceDataset, snapshotName, job.Recursive, encrypted, scope.exclude...),
+			filepath.Join(seedDir, file),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		manifest.Streams = append(manifest.Streams, BackupSeedStream{
+			SourceDataset: scope.sourceDataset,
+			DestSuffix:    normalizeDatasetPath(scope.destSuffix),
+			File:          file,
+			Bytes:         written,
+			SHA256:        checksum,
+			Encrypted:     encrypted,
+		})
+	}
+
+	// The manifest is written last so an interrupted export never looks
+	// like a complete seed.
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode_backup_seed_manifest_failed: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(seedDir, backupSeedManifestFile), data, 0600); err != nil {
+		return nil, fmt.Errorf("write_backup_seed_manifest_failed: %w", err)
+	}
+
+	success = true
+	manifest.Directory = seedDir
+
+	logger.L.Info().
+		Uint("job_id", job.ID).
+		Str("snapshot", snapshotName).
+		Str("dir", seedDir).
+		Msg("backup_seed_exported")
+
+	return manifest, nil
+}
+
+func writeBackupSeedStream(ctx context.Context, sendArgs []string, path string) (int64, string, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return 0, "", fmt.Errorf("create_backup_seed_stream_failed: %w", err)
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	counter := &countingWriter{}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "zfs", sendArgs...)
+	cmd.Stdout = io.MultiWriter(file, hasher, counter)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return 0, "", fmt.Errorf("backup_seed_send_failed: %s: %w", strings.TrimSpace(stderr.String()), err)
+	}
+	if err := file.Sync(); err != nil {
+		return 0, "", fmt.Errorf("sync_backup_seed_stream_failed: %w", err)
+	}
+
+	return counter.n, hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// ImportBackupSeed receives every stream of a seed into backupRoot on the
+// local host. It is meant to run on the backup target, which does not need
+// a running Sylve daemon. Each stream is verified against its checksum
+// while it is received; on any failure all datasets created by this import
+// are destroyed again.
+func ImportBackupSeed(ctx context.Context, seedDir, backupRoot string) (*BackupSeedManifest, error) {
+	seedDir = filepath.Clean(strings.TrimSpace(seedDir))
+	manifest, err := readBackupSeedManifest(seedDir)
+	if err != nil {
+		return nil, err
+	}
+
+	backupRoot = normalizeDatasetPath(backupRoot)
+	if backupRoot == "" || strings.ContainsAny(backupRoot, "@#") {
+		return nil, fmt.Errorf("invalid_backup_root")
+	}
+	if output, err := utils.RunCommandWithContext(ctx, "zfs", "list", "-H", "-o", "name", backupRoot); err != nil {
+		return nil, fmt.Errorf("backup_root_not_found: %s: %w", strings.TrimSpace(output), err)
+	}
+
+	for _, stream := range manifest.Streams {
+		target := remoteActiveDatasetForSuffix(backupRoot, stream.DestSuffix)
+		if _, err := utils.RunCommandWithContext(ctx, "zfs", "list", "-H", "-o", "name", target); err == nil {
+			return nil, fmt.Errorf("backup_seed_target_dataset_exists: %s", target)
+		}
+	}
+
+	imported := make([]string, 0, len(manifest.Streams))
+	success := false
+	defer func() {
+		if success {
+			return
+		}
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+		defer cancel()
+		for i := len(imported) - 1; i >= 0; i-- {
+			_, _ = utils.RunCommandWithContext(cleanupCtx, "zfs", "destroy", "-r", imported[i])
+		}
+	}()
+
+	for _, stream := range manifest.Streams {
+		target := remoteActiveDatasetForSuffix(backupRoot, stream.DestSuffix)
+		if idx := strings.LastIndex(target, "/"); idx > len(backupRoot) {
+			parent := target[:idx]
+			if output, err := utils.RunCommandWithContext(ctx, "zfs", "create", "-p", "-o", "canmount=noauto", parent); err != nil &&
+				!strings.Contains(strings.ToLower(output), "already exists") {
+				return nil, fmt.Errorf("create_backup_seed_parent_failed: %s: %w", strings.TrimSpace(output), err)
+			}
+		}
+
+		checksum, err := receiveBackupSeedStream(ctx, filepath.Join(seedDir, stream.File), target)
+		if err != nil {
+			return nil, err
+		}
+		imported = append(imported, target)
+		if checksum != stream.SHA256 {
+			return nil, fmt.Errorf("backup_seed_stream_checksum_mismatch: %s", stream.File)
+		}
+
+		snapshot := target + "@" + manifest.SnapshotName
+		if output, err := utils.RunCommandWithContext(ctx, "zfs", "list", "-H", "-o", "name", "-t", "snapshot", snapshot); err != nil {
+			return nil, fmt.Errorf("backup_seed_snapshot_missing_after_receive: %s: %w", strings.TrimSpace(output), err)
+		}
+	}
+
+	success = true
+	return manifest, nil
+}
+
+func receiveBackupSeedStream(ctx context.Context, path, target string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("open_backup_seed_stream_failed: %w", err)
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "zfs", "recv", "-u", "-x", "mountpoint", target)
+	cmd.Stdin = io.TeeReader(file, hasher)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("backup_seed_receive_failed: %s: %w", strings.TrimSpace(stderr.String()), err)
+	}
+
+	// zfs recv stops at the end of the stream; hash any trailing bytes so a
+	// padded or truncated file cannot pass verification.
+	if _, err := io.Copy(hasher, file); err != nil {
+		return "", fmt.Errorf("read_backup_seed_stream_failed: %w", err)
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// AdoptBackupSeed makes an imported seed part of the job's lineage. The
+// target copy is verified against the local seed snapshot GUIDs and then
+// committed exactly like a normal run, so it is restorable and the next
+// run sends an incremental from it.
+func (s *Service) AdoptBackupSeed(ctx context.Context, jobID uint, snapshotName string) error {
+	var job clusterModels.BackupJob
+	if err := s.DB.Preload("Target").First(&job, jobID).Error; err != nil {
+		return fmt.Errorf("backup_job_not_found: %w", err)
+	}
+	if !s.isLocalBackupJobRunner(&job, s.localNodeID()) {
+		return fmt.Errorf("backup_seed_must_run_on_job_runner")
+	}
+
+	snapshotName, err := normalizeBackupSnapshotName(snapshotName)
+	if err != nil {
+		return err
+	}
+	if !backupSnapshotRequiresCommit(job.ID, snapshotName) {
+		return fmt.Errorf("backup_seed_snapshot_not_owned_by_job")
+	}
+
+	if err := s.ensureBackupTargetSSHKeyMaterialized(&job.Target); err != nil {
+		return fmt.Errorf("backup_target_ssh_key_materialize_failed: %w", err)
+	}
+
+	if !s.beginJob(job.ID) {
+		return fmt.Errorf("backup_job_already_running")
+	}
+	defer s.releaseJob(job.ID)
+
+	scopes, err := s.backupSeedScopes(ctx, &job)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.commitBackupSnapshot(ctx, &job, snapshotName, scopes); err != nil {
+		return fmt.Errorf("backup_seed_commit_failed: %w", err)
+	}
+	for _, scope := range scopes {
+		if err := s.syncTargetBackupJobMetadata(ctx, &job, scope.sourceDataset, scope.destSuffix); err != nil {
+			return fmt.Errorf("backup_target_metadata_sync_failed: source=%s: %w", scope.sourceDataset, err)
+		}
+	}
+
+	logger.L.Info().
+		Uint("job_id", job.ID).
+		Str("snapshot", snapshotName).
+		Msg("backup_seed_adopted")
+
+	return nil
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+
+package zelta
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func validBackupSeedManifest() BackupSeedManifest {
+	return BackupSeedManifest{
+		Version:      backupSeedVersion,
+		JobID:        7,
+		SnapshotName: backupSnapshotPrefixForJob(7) + "_" + backupCommitProtocolToken + "_abc",
+		Streams: []BackupSeedStream{{
+			SourceDataset: "tank/data",
+			DestSuffix:    "data",
+			File:          backupSeedStreamFileName(0),
+			SHA256:        strings.Repeat("a", 64),
+		}},
+	}
+}
+
+func TestBackupSeedSendArgs(t *testing.T) {
+	got := strings.Join(backupSeedSendArgs("tank/data/", "bk_j7_c1_x", false, false), " ")
+	if got != "send -p -L -c -e tank/data@bk_j7_c1_x" {
+		t.Fatalf("unexpected plain seed args: %q", got)
+	}
+
+	got = strings.Join(backupSeedSendArgs("tank/data", "bk_j7_c1_x", true, true), " ")
+	if got != "send --raw -R tank/data@bk_j7_c1_x" {
+		t.Fatalf("unexpected raw recursive seed args: %q", got)
+	}
+}
+
+func TestValidateBackupSeedManifest(t *testing.T) {
+	if err := validateBackupSeedManifest(validBackupSeedManifest()); err != nil {
+		t.Fatalf("expected valid manifest, got %v", err)
+	}
+
+	for name, mutate := range map[string]func(*BackupSeedManifest){
+		"version":        func(m *BackupSeedManifest) { m.Version = 2 },
+		"foreign job":    func(m *BackupSeedManifest) { m.SnapshotName = backupSnapshotNameForJob(8) },
+		"no streams":     func(m *BackupSeedManifest) { m.Streams = nil },
+		"path traversal": func(m *BackupSeedManifest) { m.Streams[0].File = "../stream.zfs" },
+		"nested file":    func(m *BackupSeedManifest) { m.Streams[0].File = "a/stream.zfs" },
+		"suffix escape":  func(m *BackupSeedManifest) { m.Streams[0].DestSuffix = "../other" },
+		"bad checksum":   func(m *BackupSeedManifest) { m.Streams[0].SHA256 = "zz" },
+		"duplicate suffix": func(m *BackupSeedManifest) {
+			dup := m.Streams[0]
+			dup.File = backupSeedStreamFileName(1)
+			m.Streams = append(m.Streams, dup)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			manifest := validBackupSeedManifest()
+			mutate(&manifest)
+			if err := validateBackupSeedManifest(manifest); err == nil {
+				t.Fatal("expected manifest to be rejected")
+			}
+		})
+	}
+}
+
+func TestReadBackupSeedManifestSetsDirectory(t *testing.T) {
+	dir := t.TempDir()
+	data, err := json.Marshal(validBackupSeedManifest())
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, backupSeedManifestFile), data, 0600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	manifest, err := readBackupSeedManifest(dir)
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	if manifest.Directory != dir || manifest.JobID != 7 {
+		t.Fatalf("unexpected manifest: %#v", manifest)
+	}
+
+	if _, err := readBackupSeedManifest(t.TempDir()); err == nil {
+		t.Fatal("expected a directory without a manifest to be rejected")
+	}
+}
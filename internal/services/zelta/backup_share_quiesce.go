@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+
+package zelta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	sambaModels "github.com/alchemillahq/sylve/internal/db/models/samba"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/utils"
+)
+
+var shareQuiesceRunCommand = utils.RunCommandWithContext
+
+// shareQuiesceDataset resolves the GUID a share is stored under to the
+// dataset's name and mountpoint, which is also the share's path.
+var shareQuiesceDataset = func(ctx context.Context, s *Service, guid string) (string, string, error) {
+	if s.GZFS == nil || s.GZFS.ZFS == nil {
+		return "", "", fmt.Errorf("gzfs_not_initialized")
+	}
+	ds, err := s.GZFS.ZFS.GetByGUID(ctx, guid, false)
+	if err != nil {
+		return "", "", err
+	}
+	if ds == nil {
+		return "", "", nil
+	}
+	return ds.Name, ds.Mountpoint, nil
+}
+
+type backupShareQuiesceResult struct {
+	status string
+	detail string
+}
+
+// smbstatusOpenFiles is the part of `smbstatus --locks --json` read here.
+type smbstatusOpenFiles struct {
+	OpenFiles map[string]struct {
+		ServicePath string `json:"service_path"`
+		Filename    string `json:"filename"`
+		Opens       map[string]struct {
+			AccessMask map[string]any `json:"access_mask"`
+		} `json:"opens"`
+	} `json:"open_files"`
+}
+
+// backupSharesInScope maps the Samba shares stored on one of the backup
+// scopes, or below one when the job is recursive, to their paths.
+func (s *Service) backupSharesInScope(ctx context.Context, job *clusterModels.BackupJob, scopes []backupScope) (map[string]string, error) {
+	var shares []sambaModels.SambaShare
+	if err := s.DB.Select("name", "dataset").Find(&shares).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_list_samba_shares: %w", err)
+	}
+
+	paths := make(map[string]string)
+	for _, share := range shares {
+		dataset, mountpoint, err := shareQuiesceDataset(ctx, s, share.Dataset)
+		if err != nil {
+			return nil, fmt.Errorf("failed_to_resolve_share_dataset_%s: %w", share.Name, err)
+		}
+		dataset = normalizeDatasetPath(dataset)
+		if dataset == "" {
+			continue
+		}
+		for _, scope := range scopes {
+			root := normalizeDatasetPath(scope.sourceDataset)
+			if dataset == root || (job.Recursive && strings.HasPrefix(dataset, root+"/")) {
+				paths[share.Name] = mountpoint
+				break
+			}
+		}
+	}
+
+	return paths, nil
+}
+
+// filesOpenForWriting lists share-relative paths that a client holds open
+// with write or append access.
+func filesOpenForWriting(raw []byte, sharePaths map[string]string) ([]string, error) {
+	var status smbstatusOpenFiles
+	if err := json.Unmarshal(raw, &status); err != nil {
+		return nil, fmt.Errorf("invalid_smbstatus_output: %w", err)
+	}
+
+	byPath := make(map[string]string, len(sharePaths))
+	for share, path := range sharePaths {
+		if path != "" && path != "-" {
+			byPath[filepath.Clean(path)] = share
+		}
+	}
+
+	var files []string
+	for _, file := range status.OpenFiles {
+		share, ok := byPath[filepath.Clean(file.ServicePath)]
+		if !ok {
+			continue
+		}
+		for _, open := range file.Opens {
+			if accessMaskWrites(open.AccessMask) {
+				files = append(files, share+"/"+strings.TrimPrefix(file.Filename, "/"))
+				break
+			}
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+func accessMaskWrites(mask map[string]any) bool {
+	for _, key := range []string{"WRITE_DATA", "APPEND_DATA"} {
+		if set, ok := mask[key].(bool); ok && set {
+			return true
+		}
+	}
+	text, _ := mask["text"].(string)
+	return strings.Contains(text, "W")
+}
+
+// quiesceBackupShares prepares the Samba shares hosted on the backup source
+// for the snapshot the transfer is about to take. It never fails the backup:
+// a share that could not be quiesced is recorded as such on the event.
+func (s *Service) quiesceBackupShares(ctx context.Context, job *clusterModels.BackupJob, scopes []backupScope) backupShareQuiesceResult {
+	if job == nil || job.ShareQuiesce == clusterModels.BackupShareQuiesceNone {
+		return backupShareQuiesceResult{}
+	}
+
+	failed := func(err error) backupShareQuiesceResult {
+		logger.L.Warn().Err(err).Uint("job_id", job.ID).Msg("backup_share_quiesce_failed")
+		return backupShareQuiesceResult{status: clusterModels.BackupQuiesceFailed, detail: err.Error()}
+	}
+
+	sharePaths, err := s.backupSharesInScope(ctx, job, scopes)
+	if err != nil {
+		return failed(err)
+	}
+	if len(sharePaths) == 0 {
+		return backupShareQuiesceResult{status: clusterModels.BackupQuiesceNoShares}
+	}
+	shares := make([]string, 0, len(sharePaths))
+	for share := range sharePaths {
+		shares = append(shares, share)
+	}
+	sort.Strings(shares)
+
+	raw, err := shareQuiesceRunCommand(ctx, "smbstatus", "--locks", "--json")
+	if err != nil {
+		return failed(fmt.Errorf("smbstatus_failed: %w", err))
+	}
+	openFiles, err := filesOpenForWriting([]byte(raw), sharePaths)
+	if err != nil {
+		return failed(err)
+	}
+
+	detail := "shares: " + strings.Join(shares, ", ")
+	if len(openFiles) > 0 {
+		detail += "\nopen for writing: " + strings.Join(openFiles, ", ")
+	}
+
+	if job.ShareQuiesce == clusterModels.BackupShareQuiesceFlush {
+		// Closing a share drops its connections, which makes clients write
+		// back cached data and release their leases. They reconnect on their
+		// own once the snapshot has been taken.
+		for _, share := range shares {
+			if _, err := shareQuiesceRunCommand(ctx, "smbcontrol", "smbd", "close-share", share); err != nil {
+				result := failed(fmt.Errorf("smbcontrol_close_share_failed_%s: %w", share, err))
+				result.detail += "\n" + detail
+				return result
+			}
+		}
+		return backupShareQuiesceResult{status: clusterModels.BackupQuiesceFlushed, detail: detail}
+	}
+
+	if len(openFiles) > 0 {
+		return backupShareQuiesceResult{status: clusterModels.BackupQuiesceOpenFiles, detail: detail}
+	}
+	return backupShareQuiesceResult{status: clusterModels.BackupQuiesceClean, detail: detail}
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+
+package zelta
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	sambaModels "github.com/alchemillahq/sylve/internal/db/models/samba"
+)
+
+const smbstatusLocksFixture = `{
+  "open_files": {
+    "/mnt/tank/office/budget.xlsx": {
+      "service_path": "/mnt/tank/office",
+      "filename": "budget.xlsx",
+      "opens": {"1/1": {"access_mask": {"READ_DATA": true, "WRITE_DATA": true, "text": "RW"}}}
+    },
+    "/mnt/tank/office/readme.txt": {
+      "service_path": "/mnt/tank/office",
+      "filename": "readme.txt",
+      "opens": {"1/2": {"access_mask": {"READ_DATA": true, "WRITE_DATA": false, "text": "R"}}}
+    },
+    "/mnt/tank/other/notes.txt": {
+      "service_path": "/mnt/tank/other",
+      "filename": "notes.txt",
+      "opens": {"1/3": {"access_mask": {"WRITE_DATA": true, "text": "W"}}}
+    }
+  }
+}`
+
+func stubShareQuiesce(t *testing.T, fail map[string]bool) *[]string {
+	t.Helper()
+
+	origRun, origDataset := shareQuiesceRunCommand, shareQuiesceDataset
+	t.Cleanup(func() {
+		shareQuiesceRunCommand, shareQuiesceDataset = origRun, origDataset
+	})
+
+	datasets := map[string][2]string{
+		"100": {"tank/office", "/mnt/tank/office"},
+		"200": {"tank/office/archive", "/mnt/tank/office/archive"},
+		"300": {"tank/other", "/mnt/tank/other"},
+	}
+	shareQuiesceDataset = func(_ context.Context, _ *Service, guid string) (string, string, error) {
+		ds := datasets[guid]
+		return ds[0], ds[1], nil
+	}
+
+	var calls []string
+	shareQuiesceRunCommand = func(_ context.Context, command string, args ...string) (string, error) {
+		call := strings.Join(append([]string{command}, args...), " ")
+		calls = append(calls, call)
+		if fail[command] {
+			return "", context.DeadlineExceeded
+		}
+		if command == "smbstatus" {
+			return smbstatusLocksFixture, nil
+		}
+		return "", nil
+	}
+	return &calls
+}
+
+func newShareQuiesceTestService(t *testing.T) *Service {
+	t.Helper()
+	db := newZeltaServiceTestDB(t, &sambaModels.SambaShare{})
+	for _, share := range []sambaModels.SambaShare{
+		{Name: "office", Dataset: "100"},
+		{Name: "archive", Dataset: "200"},
+		{Name: "other", Dataset: "300"},
+	} {
+		if err := db.Create(&share).Error; err != nil {
+			t.Fatalf("create share: %v", err)
+		}
+	}
+	return &Service{DB: db}
+}
+
+func TestQuiesceBackupSharesShadowCopyRecordsOpenWriters(t *testing.T) {
+	calls := stubShareQuiesce(t, nil)
+	s := newShareQuiesceTestService(t)
+	scopes := []backupScope{{sourceDataset: "tank/office"}}
+
+	job := &clusterModels.BackupJob{ID: 1, ShareQuiesce: clusterModels.BackupShareQuiesceShadowCopy}
+	result := s.quiesceBackupShares(context.Background(), job, scopes)
+	if result.status != clusterModels.BackupQuiesceOpenFiles {
+		t.Fatalf("status = %q (%s)", result.status, result.detail)
+	}
+	if result.detail != "shares: office\nopen for writing: office/budget.xlsx" {
+		t.Fatalf("detail = %q", result.detail)
+	}
+	for _, call := range *calls {
+		if strings.HasPrefix(call, "smbcontrol") {
+			t.Fatalf("shadow copy mode must not disconnect clients: %v", *calls)
+		}
+	}
+
+	job.Recursive = true
+	result = s.quiesceBackupShares(context.Background(), job, scopes)
+	if !strings.HasPrefix(result.detail, "shares: archive, office\n") {
+		t.Fatalf("recursive detail = %q", result.detail)
+	}
+
+	result = s.quiesceBackupShares(context.Background(), job, []backupScope{{sourceDataset: "tank/data"}})
+	if result.status != clusterModels.BackupQuiesceNoShares {
+		t.Fatalf("unrelated dataset status = %q", result.status)
+	}
+}
+
+func TestQuiesceBackupSharesFlushClosesShares(t *testing.T) {
+	calls := stubShareQuiesce(t, nil)
+	s := newShareQuiesceTestService(t)
+	job := &clusterModels.BackupJob{ID: 1, ShareQuiesce: clusterModels.BackupShareQuiesceFlush, Recursive: true}
+
+	result := s.quiesceBackupShares(context.Background(), job, []backupScope{{sourceDataset: "tank/office"}})
+	if result.status != clusterModels.BackupQuiesceFlushed {
+		t.Fatalf("status = %q (%s)", result.status, result.detail)
+	}
+	want := []string{
+		"smbstatus --locks --json",
+		"smbcontrol smbd close-share archive",
+		"smbcontrol smbd close-share office",
+	}
+	if strings.Join(*calls, "|") != strings.Join(want, "|") {
+		t.Fatalf("calls = %v", *calls)
+	}
+
+	stubShareQuiesce(t, map[string]bool{"smbcontrol": true})
+	result = s.quiesceBackupShares(context.Background(), job, []backupScope{{sourceDataset: "tank/office"}})
+	if result.status != clusterModels.BackupQuiesceFailed ||
+		!strings.HasPrefix(result.detail, "smbcontrol_close_share_failed_archive") {
+		t.Fatalf("failed flush = %+v", result)
+	}
+
+	if got := s.quiesceBackupShares(context.Background(), &clusterModels.BackupJob{}, nil); got.status != "" {
+		t.Fatalf("disabled quiesce = %+v", got)
+	}
+}
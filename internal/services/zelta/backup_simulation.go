@@ -0,0 +1,322 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/logger"
+)
+
+const backupEventStatusSimulated = "simulated"
+
+// backupMatchRow is one dataset line of `zelta match -Hp -o
+// ds_suffix,match,xfer_size,src_written`.
+type backupMatchRow struct {
+	Suffix     string
+	Match      string
+	XferBytes  uint64
+	SrcWritten uint64
+}
+
+func parseZeltaMatchOutput(output string) []backupMatchRow {
+	rows := make([]backupMatchRow, 0)
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) < 4 {
+			continue
+		}
+
+		row := backupMatchRow{
+			Suffix: strings.TrimSpace(fields[0]),
+			Match:  strings.TrimSpace(fields[1]),
+		}
+		if row.Suffix == "-" {
+			row.Suffix = ""
+		}
+		if row.Match == "-" {
+			row.Match = ""
+		}
+		row.XferBytes, _ = strconv.ParseUint(strings.TrimSpace(fields[2]), 10, 64)
+		row.SrcWritten, _ = strconv.ParseUint(strings.TrimSpace(fields[3]), 10, 64)
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// withSimulatedBackupSnapshot returns snapshots plus the snapshot the run
+// would create on every dataset that already has one, and on root itself.
+// Non-recursive jobs only snapshot root.
+func withSimulatedBackupSnapshot(snapshots []SnapshotInfo, root, snapshotName string, recursive bool) []SnapshotInfo {
+	root = normalizeDatasetPath(root)
+	out := append([]SnapshotInfo{}, snapshots...)
+
+	seen := map[string]struct{}{}
+	datasets := []string{root}
+	seen[root] = struct{}{}
+	if recursive {
+		for _, snapshot := range snapshots {
+			dataset := snapshotDatasetName(snapshot.Name)
+			if dataset == "" {
+				continue
+			}
+			if _, ok := seen[dataset]; ok {
+				continue
+			}
+			seen[dataset] = struct{}{}
+			datasets = append(datasets, dataset)
+		}
+	}
+
+	for _, dataset := range datasets {
+		out = append(out, SnapshotInfo{
+			Name:      dataset + "@" + snapshotName,
+			ShortName: "@" + snapshotName,
+			Dataset:   dataset,
+		})
+	}
+
+	return out
+}
+
+// remapSnapshotsToTarget rewrites local snapshot names below localRoot onto
+// the matching dataset below remoteRoot.
+func remapSnapshotsToTarget(snapshots []SnapshotInfo, localRoot, remoteRoot string) []SnapshotInfo {
+	localRoot = normalizeDatasetPath(localRoot)
+	remoteRoot = normalizeDatasetPath(remoteRoot)
+
+	out := make([]SnapshotInfo, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		dataset := snapshotDatasetName(snapshot.Name)
+		rel, ok := strings.CutPrefix(dataset, localRoot)
+		if !ok || (rel != "" && !strings.HasPrefix(rel, "/")) {
+			continue
+		}
+		remoteDataset := remoteRoot + rel
+		out = append(out, SnapshotInfo{
+			Name:      remoteDataset + snapshotShortName(snapshot),
+			ShortName: snapshotShortName(snapshot),
+			Dataset:   remoteDataset,
+		})
+	}
+
+	return out
+}
+
+// simulateBackupJob walks the backup pipeline for a job without creating
+// snapshots, sending data or pruning anything, and records what would have
+// happened as a "simulated" backup event.
+func (s *Service) simulateBackupJob(ctx context.Context, job *clusterModels.BackupJob) error {
+	if job == nil || job.ID == 0 {
+		return fmt.Errorf("invalid_backup_job")
+	}
+
+	event := clusterModels.BackupEvent{
+		JobID:     &job.ID,
+		Mode:      job.Mode,
+		StartedAt: time.Now().UTC(),
+	}
+
+	lines := []string{"dry_run: no snapshots will be created, sent or pruned"}
+	output, scopes, simErr := s.simulateBackupScopes(ctx, job, &event)
+	lines = append(lines, output...)
+
+	if len(scopes) > 0 {
+		event.SourceDataset = scopes[0].sourceDataset
+		event.TargetEndpoint = job.Target.ZeltaEndpoint(scopes[0].destSuffix)
+	}
+
+	now := time.Now().UTC()
+	event.CompletedAt = &now
+	event.Status = backupEventStatusSimulated
+	if simErr != nil {
+		event.Status = "failed"
+		event.Error = simErr.Error()
+	}
+	event.Output = strings.Join(lines, "\n")
+
+	if err := s.DB.Create(&event).Error; err != nil {
+		return fmt.Errorf("create_backup_simulation_event_failed: %w", err)
+	}
+
+	logger.L.Info().
+		Uint("job_id", job.ID).
+		Uint("event_id", event.ID).
+		Str("status", event.Status).
+		Err(simErr).
+		Msg("backup_job_simulated")
+
+	return simErr
+}
+
+func (s *Service) simulateBackupScopes(
+	ctx context.Context,
+	job *clusterModels.BackupJob,
+	event *clusterModels.BackupEvent,
+) ([]string, []backupScope, error) {
+	if !job.Target.Enabled {
+		return nil, nil, fmt.Errorf("backup_target_disabled")
+	}
+	if err := s.ensureBackupTargetSSHKeyMaterialized(&job.Target); err != nil {
+		return nil, nil, fmt.Errorf("backup_target_ssh_key_materialize_failed: %w", err)
+	}
+
+	var sourceDataset string
+	vmSourceDatasets := []string{}
+	var vmExcludedDatasets []string
+	switch job.Mode {
+	case clusterModels.BackupJobModeDataset, clusterModels.BackupJobModeVM:
+		sourceDataset = normalizeDatasetPath(job.SourceDataset)
+	case clusterModels.BackupJobModeJail:
+		sourceDataset = normalizeDatasetPath(job.JailRootDataset)
+	default:
+		return nil, nil, fmt.Errorf("invalid_backup_job_mode")
+	}
+	if sourceDataset == "" {
+		return nil, nil, fmt.Errorf("source_dataset_required")
+	}
+	event.SourceDataset = sourceDataset
+
+	if job.Mode == clusterModels.BackupJobModeVM {
+		_, vmRID := inferRestoreDatasetKind(sourceDataset)
+		if vmRID == 0 {
+			return nil, nil, fmt.Errorf("invalid_vm_source_dataset")
+		}
+		sources, excluded, err := s.resolveVMBackupSourceDatasets(ctx, vmRID, sourceDataset, vmDatasetRulesForJob(job))
+		if err != nil {
+			return nil, nil, fmt.Errorf("resolve_vm_backup_sources_failed: %w", err)
+		}
+		for _, source := range sources {
+			source = normalizeDatasetPath(source)
+			if exists, err := s.localDatasetExists(ctx, source); err == nil && exists {
+				vmSourceDatasets = append(vmSourceDatasets, source)
+			}
+		}
+		if len(vmSourceDatasets) == 0 {
+			return nil, nil, fmt.Errorf("vm_source_datasets_not_found")
+		}
+		vmExcludedDatasets = excluded
+	}
+
+	destSuffix := s.backupDestSuffixForMode(job.Mode, strings.TrimSpace(job.DestSuffix), sourceDataset)
+	if job.Mode == clusterModels.BackupJobModeJail {
+		destSuffix = s.backupDestSuffixForJailSource(strings.TrimSpace(job.DestSuffix), sourceDataset)
+	}
+	scopes := s.backupRunScopes(job, sourceDataset, destSuffix, vmSourceDatasets)
+
+	snapPrefix := backupSnapshotPrefixForJob(job.ID)
+	snapshotName := backupSnapshotNameForJob(job.ID)
+	zeltaProfile, err := s.loadZeltaProfile(job.ZeltaProfileID)
+	if err != nil {
+		return nil, nil, err
+	}
+	extraEnv := withZeltaProfile(s.buildZeltaEnv(&job.Target), zeltaProfile)
+
+	lines := make([]string, 0)
+	for _, dataset := range vmExcludedDatasets {
+		lines = append(lines, "would_exclude: "+dataset)
+	}
+	var totalBytes uint64
+	for _, scope := range scopes {
+		source := normalizeDatasetPath(scope.sourceDataset)
+		endpoint := job.Target.ZeltaEndpoint(scope.destSuffix)
+		remoteActive := remoteActiveDatasetForSuffix(job.Target.BackupRoot, scope.destSuffix)
+
+		lines = append(lines, fmt.Sprintf("scope: %s -> %s", source, endpoint))
+		lines = append(lines, fmt.Sprintf("would_create_snapshot: %s@%s", source, snapshotName))
+
+		matchArgs := []string{"match", "-Hp", "-o", "ds_suffix,match,xfer_size,src_written"}
+		if !job.Recursive {
+			matchArgs = append(matchArgs, "--depth", "1")
+		}
+		if len(vmExcludedDatasets) > 0 {
+			matchArgs = append(matchArgs, "--exclude", strings.Join(vmExcludedDatasets, ","))
+		}
+		matchOut, matchErr := runZeltaWithEnv(ctx, extraEnv, append(matchArgs, source, endpoint)...)
+		if matchErr != nil {
+			return lines, scopes, fmt.Errorf("zelta_match_failed_%s: %w", source, matchErr)
+		}
+
+		for _, row := range parseZeltaMatchOutput(matchOut) {
+			dataset := source + row.Suffix
+			estimated := row.XferBytes + row.SrcWritten
+			totalBytes += estimated
+			if row.Match == "" {
+				lines = append(lines, fmt.Sprintf("full_send: %s estimated_bytes=%d", dataset, estimated))
+				continue
+			}
+			lines = append(lines, fmt.Sprintf(
+				"incremental_base: %s@%s estimated_bytes=%d",
+				dataset,
+				strings.TrimPrefix(row.Match, "@"),
+				estimated,
+			))
+		}
+
+		dryArgs := backupZeltaArgs(source, endpoint, snapshotName, job.Recursive, vmExcludedDatasets...)
+		dryArgs = append([]string{dryArgs[0], "--dryrun"}, dryArgs[1:]...)
+		if dryOut, dryErr := runZeltaWithEnv(ctx, extraEnv, dryArgs...); dryErr != nil {
+			lines = append(lines, fmt.Sprintf("zelta_dryrun_failed: %v", dryErr))
+		} else if trimmed := strings.TrimSpace(dryOut); trimmed != "" {
+			lines = append(lines, trimmed)
+		}
+
+		if job.PruneKeepLast <= 0 {
+			continue
+		}
+
+		localSnaps, err := s.listLocalSnapshotsForDataset(ctx, source)
+		if err != nil {
+			lines = append(lines, fmt.Sprintf("retention_local_skipped: %v", err))
+			continue
+		}
+		localAfter := withSimulatedBackupSnapshot(localSnaps, source, snapshotName, job.Recursive)
+		protect, protectErr := s.localRetentionProtectSet(ctx, &job.Target, source, remoteActive, snapPrefix, localAfter)
+		if protectErr != nil {
+			lines = append(lines, fmt.Sprintf("retention_local_skipped: %v", protectErr))
+		} else {
+			for _, name := range buildLocalRetentionPruneCandidates(localAfter, job.PruneKeepLast, protect, snapPrefix) {
+				lines = append(lines, "would_prune_local: "+name)
+			}
+		}
+
+		if !job.PruneTarget || remoteActive == "" {
+			continue
+		}
+		remoteSnaps, err := s.listRemoteSnapshotsForDatasetRecursive(ctx, &job.Target, remoteActive)
+		if err != nil {
+			lines = append(lines, fmt.Sprintf("retention_target_skipped: %v", err))
+			continue
+		}
+		simulated := remapSnapshotsToTarget(localAfter[len(localSnaps):], source, remoteActive)
+		remoteAfter := append(append([]SnapshotInfo{}, remoteSnaps...), simulated...)
+		for _, name := range buildBKRetentionPruneCandidates(
+			remoteAfter,
+			job.PruneKeepLast,
+			snapshotCandidateSet(snapshotNames(remoteAfter)),
+			snapPrefix,
+		) {
+			lines = append(lines, "would_prune_target: "+name)
+		}
+	}
+
+	lines = append(lines, fmt.Sprintf("estimated_total_bytes: %d", totalBytes))
+	return lines, scopes, nil
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseZeltaMatchOutput(t *testing.T) {
+	output := "-\t@bk_j1_a\t1024\t512\n" +
+		"/child\t-\t4096\t0\n" +
+		"garbage line\n"
+
+	rows := parseZeltaMatchOutput(output)
+	want := []backupMatchRow{
+		{Suffix: "", Match: "@bk_j1_a", XferBytes: 1024, SrcWritten: 512},
+		{Suffix: "/child", Match: "", XferBytes: 4096, SrcWritten: 0},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Fatalf("unexpected rows:\n got=%+v\nwant=%+v", rows, want)
+	}
+}
+
+func TestWithSimulatedBackupSnapshot_RetentionIncludesNewSnapshot(t *testing.T) {
+	prefix := backupSnapshotPrefixForJob(7)
+	existing := []SnapshotInfo{
+		{Name: "tank/data@" + prefix + "_a", Dataset: "tank/data"},
+		{Name: "tank/data@" + prefix + "_b", Dataset: "tank/data"},
+		{Name: "tank/data/child@" + prefix + "_b", Dataset: "tank/data/child"},
+	}
+
+	after := withSimulatedBackupSnapshot(existing, "tank/data", prefix+"_c", true)
+	if len(after) != 5 {
+		t.Fatalf("expected a simulated snapshot per dataset, got %+v", after)
+	}
+
+	candidates := buildLocalRetentionPruneCandidates(after, 2, nil, prefix)
+	want := []string{"tank/data@" + prefix + "_a"}
+	if !reflect.DeepEqual(candidates, want) {
+		t.Fatalf("unexpected prune candidates: %v", candidates)
+	}
+
+	nonRecursive := withSimulatedBackupSnapshot(existing, "tank/data", prefix+"_c", false)
+	if len(nonRecursive) != 4 || nonRecursive[3].Name != "tank/data@"+prefix+"_c" {
+		t.Fatalf("expected only the root to be snapshotted, got %+v", nonRecursive)
+	}
+}
+
+func TestRemapSnapshotsToTarget(t *testing.T) {
+	remapped := remapSnapshotsToTarget([]SnapshotInfo{
+		{Name: "tank/data@s1"},
+		{Name: "tank/data/child@s1"},
+		{Name: "tank/database@s1"},
+	}, "tank/data", "backup/active/data")
+
+	got := snapshotNames(remapped)
+	want := []string{"backup/active/data@s1", "backup/active/data/child@s1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected remapped snapshots: %v", got)
+	}
+}
@@ -0,0 +1,648 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/system"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	backupTenantUserPrefix = "sylve-bk-"
+	backupTenantHomeRoot   = "/home"
+)
+
+// backupTenantShellCommand is the hidden sylve subcommand a tenant's
+// authorized_keys forces, whatever command the client asked for.
+const backupTenantShellCommand = "backup-tenant-shell"
+
+// backupTenantLoginShell is the tenants' login shell. sshd runs forced
+// commands through the login shell, so nologin(8) itself cannot be used;
+// this one behaves like it for everything except the forced command.
+const backupTenantLoginShell = "/usr/local/libexec/sylve-backup-tenant-shell"
+
+var backupTenantExecutable = os.Executable
+
+var backupTenantNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,23}$`)
+
+// backupTenantNamespaceRegex takes a node UUID as well as a short prefix. A
+// namespace is a single dataset name below the tenant's dataset.
+var backupTenantNamespaceRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.:-]{0,63}$`)
+
+// backupTenantPermissions is the zfs allow set a tenant needs to receive
+// Zelta streams into its own namespace. Receive needs create and mount; the
+// rest are the properties a replicated stream carries. Pruning and rolling
+// back received data stay with the operator.
+var backupTenantPermissions = []string{
+	"create", "mount", "receive", "userprop", "canmount", "mountpoint",
+	"readonly", "compression", "recordsize", "volmode", "volsize",
+}
+
+// backupTenantWriteCommands are the subcommands that put data on the target.
+// With a namespace they have to stay inside it; the probes may still look at
+// the whole tenant dataset.
+var backupTenantWriteCommands = map[string]bool{
+	"create":  true,
+	"receive": true,
+	"recv":    true,
+}
+
+// backupTenantCommands is what a tenant's forced command lets through: the
+// probes a Sylve or Zelta source runs against a target and the receive. The
+// values are the options that take an argument.
+var backupTenantCommands = map[string]map[string]string{
+	"zfs": {
+		"version": "",
+		"list":    "odsSt",
+		"get":     "odst",
+		"create":  "obV",
+		"receive": "ox",
+		"recv":    "ox",
+	},
+	"zpool": {
+		"list": "oT",
+	},
+}
+
+// BackupTenantStatus is a tenant together with its live space accounting
+// and the most recent snapshot received into its namespace.
+type BackupTenantStatus struct {
+	clusterModels.BackupTenant
+	UsedBytes        uint64     `json:"usedBytes"`
+	AvailableBytes   uint64     `json:"availableBytes"`
+	LastSnapshot     string     `json:"lastSnapshot"`
+	LastSnapshotAt   *time.Time `json:"lastSnapshotAt"`
+	DatasetMissing   bool       `json:"datasetMissing"`
+	UsageError       string     `json:"usageError,omitempty"`
+	QuotaUsedPercent float64    `json:"quotaUsedPercent"`
+}
+
+func backupTenantSSHUser(name string) string {
+	return backupTenantUserPrefix + name
+}
+
+func validateBackupTenantRequest(req clusterServiceInterfaces.BackupTenantReq) (string, string, error) {
+	name := strings.ToLower(strings.TrimSpace(req.Name))
+	if !backupTenantNameRegex.MatchString(name) {
+		return "", "", fmt.Errorf("invalid_backup_tenant_name")
+	}
+
+	root := normalizeDatasetPath(req.BackupRoot)
+	if root == "" || strings.ContainsAny(root, "@# ") || strings.HasPrefix(root, "/") {
+		return "", "", fmt.Errorf("invalid_backup_tenant_root")
+	}
+
+	key := strings.TrimSpace(req.SSHPublicKey)
+	if strings.ContainsAny(key, "\r\n") {
+		return "", "", fmt.Errorf("invalid_backup_tenant_ssh_key")
+	}
+	if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key + "\n")); err != nil {
+		return "", "", fmt.Errorf("invalid_backup_tenant_ssh_key: %w", err)
+	}
+
+	return name, root + "/" + name, nil
+}
+
+// normalizeBackupTenantNamespace checks a tenant namespace. An empty one
+// lets the tenant's client write anywhere in its dataset.
+func normalizeBackupTenantNamespace(namespace string) (string, error) {
+	namespace = strings.TrimSpace(namespace)
+	if namespace == "" {
+		return "", nil
+	}
+	if !backupTenantNamespaceRegex.MatchString(namespace) || strings.Contains(namespace, "..") {
+		return "", fmt.Errorf("invalid_backup_tenant_namespace")
+	}
+	return namespace, nil
+}
+
+// backupTenantAuthorizedKey pins the tenant's key to the forced command for
+// its dataset and namespace and strips forwarding, pty and the like.
+func backupTenantAuthorizedKey(exe, dataset, namespace, publicKey string) (string, error) {
+	if exe == "" || strings.ContainsAny(exe, " \t\"'\\$`") {
+		return "", fmt.Errorf("invalid_backup_tenant_shell_executable")
+	}
+	command := fmt.Sprintf("%s %s --dataset %s", exe, backupTenantShellCommand, dataset)
+	if namespace != "" {
+		command += " --namespace " + namespace
+	}
+	return fmt.Sprintf(`restrict,command="%s" %s`, command, strings.TrimSpace(publicKey)), nil
+}
+
+// backupTenantLoginShellScript only ever execs the forced command and never
+// evaluates what it was handed, so the dataset and the optional namespace
+// are cut off the end of the fixed prefix. Neither can contain a space.
+func backupTenantLoginShellScript(exe string) string {
+	prefix := exe + " " + backupTenantShellCommand + " --dataset "
+	return fmt.Sprintf(`#!/bin/sh
+# Login shell for Sylve backup tenants. Like nologin(8) it refuses
+# interactive sessions; it only runs the command Sylve forces in the
+# tenant's authorized_keys.
+if [ "$#" -eq 2 ] && [ "$1" = "-c" ]; then
+	case "$2" in
+	'%[1]s'*' --namespace '*)
+		args="${2#'%[1]s'}"
+		exec %[2]s %[3]s --dataset "${args%%%% *}" --namespace "${args#* --namespace }"
+		;;
+	'%[1]s'*)
+		exec %[2]s %[3]s --dataset "${2#'%[1]s'}"
+		;;
+	esac
+fi
+echo "This account is currently not available."
+exit 1
+`, prefix, exe, backupTenantShellCommand)
+}
+
+func ensureBackupTenantLoginShell(exe string) error {
+	if err := os.MkdirAll(filepath.Dir(backupTenantLoginShell), 0755); err != nil {
+		return err
+	}
+	tmp := backupTenantLoginShell + ".tmp"
+	if err := os.WriteFile(tmp, []byte(backupTenantLoginShellScript(exe)), 0755); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp, 0755); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, backupTenantLoginShell)
+}
+
+// BackupTenantCommand checks the command a tenant's client asked for
+// (SSH_ORIGINAL_COMMAND) against backupTenantCommands and returns the argv to
+// run. Every dataset or pool operand has to fall inside the tenant's dataset,
+// and with a namespace, creates and receives inside dataset/namespace, so a
+// source cannot write over a lineage another source sent.
+func BackupTenantCommand(original, dataset, namespace string) ([]string, error) {
+	dataset = normalizeDatasetPath(dataset)
+	if dataset == "" || strings.ContainsAny(dataset, "@# ") {
+		return nil, fmt.Errorf("invalid_backup_tenant_dataset")
+	}
+	namespace, err := normalizeBackupTenantNamespace(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	argv, err := splitBackupTenantCommand(original)
+	if err != nil {
+		return nil, err
+	}
+	if len(argv) < 2 {
+		return nil, fmt.Errorf("backup_tenant_command_not_allowed")
+	}
+
+	subcommands, ok := backupTenantCommands[argv[0]]
+	if !ok {
+		return nil, fmt.Errorf("backup_tenant_command_not_allowed")
+	}
+	valueOptions, ok := subcommands[argv[1]]
+	if !ok {
+		return nil, fmt.Errorf("backup_tenant_command_not_allowed")
+	}
+
+	var operands []string
+	for i := 2; i < len(argv); i++ {
+		arg := argv[i]
+		if arg == "--" {
+			operands = append(operands, argv[i+1:]...)
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			operands = append(operands, arg)
+			continue
+		}
+		for j := 1; j < len(arg); j++ {
+			if !strings.ContainsRune(valueOptions, rune(arg[j])) {
+				continue
+			}
+			if j == len(arg)-1 {
+				i++
+				if i >= len(argv) {
+					return nil, fmt.Errorf("backup_tenant_command_option_missing_value")
+				}
+			}
+			break
+		}
+	}
+
+	// zfs get takes the property list before the datasets.
+	if argv[0] == "zfs" && argv[1] == "get" && len(operands) > 0 {
+		operands = operands[1:]
+	}
+
+	switch {
+	case argv[0] == "zfs" && argv[1] == "version":
+		if len(operands) > 0 {
+			return nil, fmt.Errorf("backup_tenant_command_not_allowed")
+		}
+	case len(operands) == 0:
+		// Without an operand list and get walk every dataset on the target.
+		return nil, fmt.Errorf("backup_tenant_command_operand_required")
+	}
+
+	pool := strings.SplitN(dataset, "/", 2)[0]
+	for _, operand := range operands {
+		if argv[0] == "zpool" {
+			if operand != pool {
+				return nil, fmt.Errorf("backup_tenant_command_outside_dataset")
+			}
+			continue
+		}
+		if !backupTenantOperandWithin(operand, dataset) {
+			return nil, fmt.Errorf("backup_tenant_command_outside_dataset")
+		}
+		if namespace != "" && backupTenantWriteCommands[argv[1]] &&
+			!backupTenantOperandWithin(operand, dataset+"/"+namespace) {
+			return nil, fmt.Errorf("backup_tenant_command_outside_namespace")
+		}
+	}
+
+	return argv, nil
+}
+
+func backupTenantOperandWithin(operand, dataset string) bool {
+	if operand == dataset {
+		return true
+	}
+	rest, ok := strings.CutPrefix(operand, dataset)
+	if !ok || rest == "" {
+		return false
+	}
+	switch rest[0] {
+	case '/', '@', '#':
+		return !strings.Contains(rest, "..")
+	}
+	return false
+}
+
+// splitBackupTenantCommand splits the command line ssh forwarded. Quoting
+// is honoured, but anything the remote shell would have interpreted is
+// rejected since no shell runs it.
+func splitBackupTenantCommand(line string) ([]string, error) {
+	var (
+		argv    []string
+		current strings.Builder
+		inWord  bool
+		quote   rune
+	)
+	for _, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+				continue
+			}
+			current.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t':
+			if inWord {
+				argv = append(argv, current.String())
+				current.Reset()
+				inWord = false
+			}
+		case strings.ContainsRune("|&;<>()$`\\*?[]{}~\n\r", r):
+			return nil, fmt.Errorf("backup_tenant_command_not_allowed")
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("backup_tenant_command_unterminated_quote")
+	}
+	if inWord {
+		argv = append(argv, current.String())
+	}
+	return argv, nil
+}
+
+func backupTenantQuotaValue(quotaBytes uint64) string {
+	if quotaBytes == 0 {
+		return "none"
+	}
+	return strconv.FormatUint(quotaBytes, 10)
+}
+
+// parseBackupTenantSpace parses `zfs get -H -p -o value used,available`.
+func parseBackupTenantSpace(output string) (uint64, uint64, error) {
+	var values []uint64
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		value, err := strconv.ParseUint(line, 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid_backup_tenant_space_value:%s", line)
+		}
+		values = append(values, value)
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, 0, err
+	}
+	if len(values) != 2 {
+		return 0, 0, fmt.Errorf("invalid_backup_tenant_space_output")
+	}
+	return values[0], values[1], nil
+}
+
+// latestBackupTenantSnapshot returns the newest entry of
+// `zfs list -H -p -r -t snapshot -o name,creation`.
+func latestBackupTenantSnapshot(output string) (string, *time.Time) {
+	var (
+		latestName string
+		latestUnix int64
+	)
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		created, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		if latestName == "" || created >= latestUnix {
+			latestName = fields[0]
+			latestUnix = created
+		}
+	}
+	if latestName == "" {
+		return "", nil
+	}
+	at := time.Unix(latestUnix, 0).UTC()
+	return latestName, &at
+}
+
+func (s *Service) CreateBackupTenant(ctx context.Context, req clusterServiceInterfaces.BackupTenantReq) (*clusterModels.BackupTenant, error) {
+	name, dataset, err := validateBackupTenantRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := normalizeBackupTenantNamespace(req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var count int64
+	if err := s.DB.Model(&clusterModels.BackupTenant{}).
+		Where("name = ? OR dataset = ?", name, dataset).
+		Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, fmt.Errorf("backup_tenant_already_exists")
+	}
+
+	if _, err := s.GZFS.ZFS.Get(ctx, normalizeDatasetPath(req.BackupRoot), false); err != nil {
+		return nil, fmt.Errorf("backup_tenant_root_not_found: %w", err)
+	}
+
+	user := backupTenantSSHUser(name)
+	if exists, err := system.UnixUserExists(user); err != nil {
+		return nil, fmt.Errorf("backup_tenant_user_lookup_failed: %w", err)
+	} else if exists {
+		return nil, fmt.Errorf("backup_tenant_user_already_exists")
+	}
+
+	if output, err := utils.RunCommandWithContext(
+		ctx,
+		"zfs", "create",
+		"-o", "quota="+backupTenantQuotaValue(req.QuotaBytes),
+		"-o", "canmount=off",
+		dataset,
+	); err != nil {
+		return nil, fmt.Errorf("backup_tenant_dataset_create_failed: %s: %w", strings.TrimSpace(output), err)
+	}
+
+	rollback := func() {
+		if err := system.DeleteUnixUser(user, true); err != nil {
+			logger.L.Debug().Err(err).Str("user", user).Msg("backup_tenant_rollback_user_delete_failed")
+		}
+		if output, err := utils.RunCommand("zfs", "destroy", "-r", dataset); err != nil {
+			logger.L.Warn().Err(err).Str("dataset", dataset).Str("output", output).Msg("backup_tenant_rollback_dataset_destroy_failed")
+		}
+	}
+
+	exe, err := backupTenantExecutable()
+	if err != nil {
+		rollback()
+		return nil, fmt.Errorf("backup_tenant_shell_executable_unavailable: %w", err)
+	}
+	authorizedKey, err := backupTenantAuthorizedKey(exe, dataset, namespace, req.SSHPublicKey)
+	if err != nil {
+		rollback()
+		return nil, err
+	}
+	if err := ensureBackupTenantLoginShell(exe); err != nil {
+		rollback()
+		return nil, fmt.Errorf("backup_tenant_login_shell_install_failed: %w", err)
+	}
+
+	home := backupTenantHomeRoot + "/" + user
+	if err := system.CreateUnixUserFull(system.UnixUserCreateOpts{
+		Name:       user,
+		Shell:      backupTenantLoginShell,
+		Dir:        home,
+		CreateHome: true,
+	}); err != nil {
+		rollback()
+		return nil, fmt.Errorf("backup_tenant_user_create_failed: %w", err)
+	}
+	if err := system.DisableUnixUserPassword(user); err != nil {
+		rollback()
+		return nil, fmt.Errorf("backup_tenant_user_password_disable_failed: %w", err)
+	}
+	if err := system.WriteSSHAuthorizedKey(home, authorizedKey); err != nil {
+		rollback()
+		return nil, fmt.Errorf("backup_tenant_ssh_key_write_failed: %w", err)
+	}
+	if uid, _, err := system.GetUnixUserInfo(user); err != nil {
+		rollback()
+		return nil, fmt.Errorf("backup_tenant_user_lookup_failed: %w", err)
+	} else if err := system.ChownHome(home, uid, user); err != nil {
+		rollback()
+		return nil, fmt.Errorf("backup_tenant_home_chown_failed: %w", err)
+	}
+
+	if output, err := utils.RunCommandWithContext(
+		ctx,
+		"zfs", "allow", "-u", user, strings.Join(backupTenantPermissions, ","), dataset,
+	); err != nil {
+		rollback()
+		return nil, fmt.Errorf("backup_tenant_delegation_failed: %s: %w", strings.TrimSpace(output), err)
+	}
+
+	tenant := clusterModels.BackupTenant{
+		Name:         name,
+		Dataset:      dataset,
+		SSHUser:      user,
+		SSHPublicKey: strings.TrimSpace(req.SSHPublicKey),
+		QuotaBytes:   req.QuotaBytes,
+		Namespace:    namespace,
+	}
+	if err := s.DB.Create(&tenant).Error; err != nil {
+		rollback()
+		return nil, err
+	}
+
+	return &tenant, nil
+}
+
+func (s *Service) ListBackupTenants(ctx context.Context) ([]BackupTenantStatus, error) {
+	var tenants []clusterModels.BackupTenant
+	if err := s.DB.Order("name ASC").Find(&tenants).Error; err != nil {
+		return nil, err
+	}
+
+	statuses := make([]BackupTenantStatus, 0, len(tenants))
+	for _, tenant := range tenants {
+		statuses = append(statuses, s.backupTenantStatus(ctx, tenant))
+	}
+	return statuses, nil
+}
+
+func (s *Service) backupTenantStatus(ctx context.Context, tenant clusterModels.BackupTenant) BackupTenantStatus {
+	status := BackupTenantStatus{BackupTenant: tenant}
+
+	output, err := utils.RunCommandWithContext(ctx, "zfs", "get", "-H", "-p", "-o", "value", "used,available", tenant.Dataset)
+	if err != nil {
+		if replicationDatasetMissingResult(output, err) {
+			status.DatasetMissing = true
+		} else {
+			status.UsageError = strings.TrimSpace(output)
+		}
+		return status
+	}
+	used, available, err := parseBackupTenantSpace(output)
+	if err != nil {
+		status.UsageError = err.Error()
+		return status
+	}
+	status.UsedBytes = used
+	status.AvailableBytes = available
+	if tenant.QuotaBytes > 0 {
+		status.QuotaUsedPercent = float64(used) / float64(tenant.QuotaBytes) * 100
+	}
+
+	output, err = utils.RunCommandWithContext(ctx, "zfs", "list", "-H", "-p", "-r", "-t", "snapshot", "-o", "name,creation", tenant.Dataset)
+	if err != nil {
+		status.UsageError = strings.TrimSpace(output)
+		return status
+	}
+	status.LastSnapshot, status.LastSnapshotAt = latestBackupTenantSnapshot(output)
+
+	return status
+}
+
+func (s *Service) SetBackupTenantQuota(ctx context.Context, id uint, quotaBytes uint64) error {
+	var tenant clusterModels.BackupTenant
+	if err := s.DB.First(&tenant, id).Error; err != nil {
+		return fmt.Errorf("backup_tenant_not_found: %w", err)
+	}
+
+	if output, err := utils.RunCommandWithContext(
+		ctx,
+		"zfs", "set", "quota="+backupTenantQuotaValue(quotaBytes), tenant.Dataset,
+	); err != nil {
+		return fmt.Errorf("backup_tenant_quota_set_failed: %s: %w", strings.TrimSpace(output), err)
+	}
+
+	return s.DB.Model(&tenant).Update("quota_bytes", quotaBytes).Error
+}
+
+// SetBackupTenantNamespace changes where the tenant's client may write by
+// rewriting the forced command in its authorized_keys. Data already received
+// outside the new namespace is left where it is.
+func (s *Service) SetBackupTenantNamespace(ctx context.Context, id uint, namespace string) error {
+	namespace, err := normalizeBackupTenantNamespace(namespace)
+	if err != nil {
+		return err
+	}
+
+	var tenant clusterModels.BackupTenant
+	if err := s.DB.First(&tenant, id).Error; err != nil {
+		return fmt.Errorf("backup_tenant_not_found: %w", err)
+	}
+
+	exe, err := backupTenantExecutable()
+	if err != nil {
+		return fmt.Errorf("backup_tenant_shell_executable_unavailable: %w", err)
+	}
+	authorizedKey, err := backupTenantAuthorizedKey(exe, tenant.Dataset, namespace, tenant.SSHPublicKey)
+	if err != nil {
+		return err
+	}
+	if err := ensureBackupTenantLoginShell(exe); err != nil {
+		return fmt.Errorf("backup_tenant_login_shell_install_failed: %w", err)
+	}
+	if err := system.WriteSSHAuthorizedKey(backupTenantHomeRoot+"/"+tenant.SSHUser, authorizedKey); err != nil {
+		return fmt.Errorf("backup_tenant_ssh_key_write_failed: %w", err)
+	}
+
+	if err := s.DB.Model(&tenant).Update("namespace", namespace).Error; err != nil {
+		return err
+	}
+
+	logger.L.Info().
+		Str("tenant", tenant.Name).
+		Str("namespace", namespace).
+		Msg("backup_tenant_namespace_updated")
+	return nil
+}
+
+// DeleteBackupTenant revokes the tenant's access. The received data is kept
+// unless destroyData is set, so an operator can still hand it back.
+func (s *Service) DeleteBackupTenant(ctx context.Context, id uint, destroyData bool) error {
+	var tenant clusterModels.BackupTenant
+	if err := s.DB.First(&tenant, id).Error; err != nil {
+		return fmt.Errorf("backup_tenant_not_found: %w", err)
+	}
+
+	if output, err := utils.RunCommandWithContext(ctx, "zfs", "unallow", "-u", tenant.SSHUser, tenant.Dataset); err != nil &&
+		!replicationDatasetMissingResult(output, err) {
+		return fmt.Errorf("backup_tenant_delegation_revoke_failed: %s: %w", strings.TrimSpace(output), err)
+	}
+
+	if exists, err := system.UnixUserExists(tenant.SSHUser); err != nil {
+		return fmt.Errorf("backup_tenant_user_lookup_failed: %w", err)
+	} else if exists {
+		if err := system.DeleteUnixUser(tenant.SSHUser, true); err != nil {
+			return fmt.Errorf("backup_tenant_user_delete_failed: %w", err)
+		}
+	}
+
+	if destroyData {
+		if output, err := utils.RunCommandWithContext(ctx, "zfs", "destroy", "-r", tenant.Dataset); err != nil &&
+			!replicationDatasetMissingResult(output, err) {
+			return fmt.Errorf("backup_tenant_dataset_destroy_failed: %s: %w", strings.TrimSpace(output), err)
+		}
+	}
+
+	return s.DB.Delete(&tenant).Error
+}
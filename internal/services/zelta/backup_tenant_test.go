@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+
+package zelta
+
+import (
+	"strings"
+	"testing"
+
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+)
+
+const testBackupTenantPublicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAILgW3/MbREakRZ0DGGD2+4Dg9odvA9wfc4tBj88ktlSj tenant"
+
+func TestValidateBackupTenantRequestScopesDatasetUnderRoot(t *testing.T) {
+	name, dataset, err := validateBackupTenantRequest(clusterServiceInterfaces.BackupTenantReq{
+		Name:         " Acme ",
+		BackupRoot:   "tank/sylve-backups/",
+		SSHPublicKey: testBackupTenantPublicKey,
+	})
+	if err != nil {
+		t.Fatalf("validate tenant: %v", err)
+	}
+	if name != "acme" || dataset != "tank/sylve-backups/acme" {
+		t.Fatalf("unexpected tenant namespace: name=%q dataset=%q", name, dataset)
+	}
+	if user := backupTenantSSHUser(name); user != "sylve-bk-acme" {
+		t.Fatalf("unexpected tenant user: %q", user)
+	}
+}
+
+func TestValidateBackupTenantRequestRejectsUnsafeInput(t *testing.T) {
+	for _, req := range []clusterServiceInterfaces.BackupTenantReq{
+		{Name: "../acme", BackupRoot: "tank/backups", SSHPublicKey: testBackupTenantPublicKey},
+		{Name: "acme/child", BackupRoot: "tank/backups", SSHPublicKey: testBackupTenantPublicKey},
+		{Name: "1acme", BackupRoot: "tank/backups", SSHPublicKey: testBackupTenantPublicKey},
+		{Name: "acme", BackupRoot: "tank/backups@snap", SSHPublicKey: testBackupTenantPublicKey},
+		{Name: "acme", BackupRoot: "/tank/backups", SSHPublicKey: testBackupTenantPublicKey},
+		{Name: "acme", BackupRoot: "tank/backups", SSHPublicKey: "not-a-key"},
+		{Name: "acme", BackupRoot: "tank/backups", SSHPublicKey: testBackupTenantPublicKey + "\n" + testBackupTenantPublicKey},
+	} {
+		if _, _, err := validateBackupTenantRequest(req); err == nil {
+			t.Fatalf("expected tenant request to be rejected: %+v", req)
+		}
+	}
+}
+
+func TestParseBackupTenantSpace(t *testing.T) {
+	used, available, err := parseBackupTenantSpace("1024\n4096\n")
+	if err != nil || used != 1024 || available != 4096 {
+		t.Fatalf("unexpected space: used=%d available=%d err=%v", used, available, err)
+	}
+	if _, _, err := parseBackupTenantSpace("1024\n"); err == nil {
+		t.Fatal("expected incomplete space output to be rejected")
+	}
+	if _, _, err := parseBackupTenantSpace("1024\n-\n"); err == nil {
+		t.Fatal("expected non-numeric space output to be rejected")
+	}
+}
+
+func TestLatestBackupTenantSnapshotPicksNewestCreation(t *testing.T) {
+	name, at := latestBackupTenantSnapshot(
+		"tank/b/acme/vm@zelta_1\t100\ntank/b/acme/jail@zelta_3\t300\ntank/b/acme/vm@zelta_2\t200\n",
+	)
+	if name != "tank/b/acme/jail@zelta_3" || at == nil || at.Unix() != 300 {
+		t.Fatalf("unexpected latest snapshot: %q %v", name, at)
+	}
+	if name, at := latestBackupTenantSnapshot(""); name != "" || at != nil {
+		t.Fatalf("expected no snapshot, got %q %v", name, at)
+	}
+	if got := backupTenantQuotaValue(0); got != "none" {
+		t.Fatalf("expected zero quota to clear the limit, got %q", got)
+	}
+}
+
+func TestBackupTenantAuthorizedKeyForcesTenantShell(t *testing.T) {
+	line, err := backupTenantAuthorizedKey("/usr/local/bin/sylve", "tank/backups/acme", "", testBackupTenantPublicKey+"\n")
+	if err != nil {
+		t.Fatalf("authorized key: %v", err)
+	}
+	want := `restrict,command="/usr/local/bin/sylve backup-tenant-shell --dataset tank/backups/acme" ` + testBackupTenantPublicKey
+	if line != want {
+		t.Fatalf("unexpected authorized key line:\n got %q\nwant %q", line, want)
+	}
+	if _, err := backupTenantAuthorizedKey("/opt/my sylve", "tank/backups/acme", "", testBackupTenantPublicKey); err == nil {
+		t.Fatal("expected executable path with a space to be rejected")
+	}
+
+	line, err = backupTenantAuthorizedKey("/usr/local/bin/sylve", "tank/backups/acme", "east", testBackupTenantPublicKey)
+	if err != nil {
+		t.Fatalf("authorized key with namespace: %v", err)
+	}
+	want = `restrict,command="/usr/local/bin/sylve backup-tenant-shell --dataset tank/backups/acme --namespace east" ` + testBackupTenantPublicKey
+	if line != want {
+		t.Fatalf("unexpected authorized key line:\n got %q\nwant %q", line, want)
+	}
+}
+
+func TestBackupTenantCommandAllowsReceiveIntoOwnDataset(t *testing.T) {
+	for _, command := range []string{
+		"zfs version",
+		"zpool list -H -o name tank",
+		"zfs list -H -o name -t filesystem -d 0 tank/backups/acme",
+		"zfs list -Hprt all -o name,guid 'tank/backups/acme/vm-1'",
+		"zfs get -Hpo value used,available tank/backups/acme",
+		"zfs create -p tank/backups/acme/vm-1",
+		"zfs receive -u -x mountpoint -o readonly=on tank/backups/acme/vm-1@snap",
+		"zfs recv -F -d tank/backups/acme",
+	} {
+		if _, err := BackupTenantCommand(command, "tank/backups/acme", ""); err != nil {
+			t.Fatalf("expected %q to be allowed: %v", command, err)
+		}
+	}
+}
+
+func TestBackupTenantCommandRejectsEverythingElse(t *testing.T) {
+	for _, command := range []string{
+		"",
+		"sh",
+		"/bin/sh -i",
+		"zfs destroy -r tank/backups/acme",
+		"zfs rollback tank/backups/acme@snap",
+		"zfs list",
+		"zfs list -H -o name tank/backups",
+		"zfs list tank/backups/acme-evil",
+		"zfs get all tank",
+		"zfs receive tank/backups/other",
+		"zfs create tank/backups/acme/../other",
+		"zpool list other",
+		"zfs list tank/backups/acme; sh",
+		"zfs list $(id) tank/backups/acme",
+		"zfs list 'tank/backups/acme",
+	} {
+		if _, err := BackupTenantCommand(command, "tank/backups/acme", ""); err == nil {
+			t.Fatalf("expected %q to be rejected", command)
+		}
+	}
+}
+
+func TestBackupTenantCommandKeepsWritesInNamespace(t *testing.T) {
+	const namespace = "6f1c2a7e-3b0d-4c5e-9a8f-1d2e3f4a5b6c"
+	for _, command := range []string{
+		"zfs list -H -o name -t filesystem -d 0 tank/backups/acme",
+		"zfs list -Hprt all -o name,guid tank/backups/acme/" + namespace + "/vm-1",
+		"zfs create -p tank/backups/acme/" + namespace,
+		"zfs receive -u tank/backups/acme/" + namespace + "/vm-1@snap",
+		"zfs recv -F -d tank/backups/acme/" + namespace,
+	} {
+		if _, err := BackupTenantCommand(command, "tank/backups/acme", namespace); err != nil {
+			t.Fatalf("expected %q to be allowed: %v", command, err)
+		}
+	}
+
+	for _, command := range []string{
+		"zfs create -p tank/backups/acme/vm-1",
+		"zfs receive -u tank/backups/acme/vm-1@snap",
+		"zfs recv -F -d tank/backups/acme",
+		"zfs receive tank/backups/acme/other-node/vm-1",
+		"zfs receive tank/backups/acme/" + namespace + "-evil/vm-1",
+	} {
+		_, err := BackupTenantCommand(command, "tank/backups/acme", namespace)
+		if err == nil || err.Error() != "backup_tenant_command_outside_namespace" {
+			t.Fatalf("expected %q to be kept out of other namespaces, got %v", command, err)
+		}
+	}
+
+	for _, namespace := range []string{"a/b", "..", "-x", "a b", "a..b"} {
+		if _, err := BackupTenantCommand("zfs version", "tank/backups/acme", namespace); err == nil {
+			t.Fatalf("expected namespace %q to be rejected", namespace)
+		}
+	}
+}
+
+func TestBackupTenantLoginShellOnlyExecsForcedCommand(t *testing.T) {
+	script := backupTenantLoginShellScript("/usr/local/bin/sylve")
+	if !strings.Contains(script, `'/usr/local/bin/sylve backup-tenant-shell --dataset '*)`) {
+		t.Fatalf("login shell does not match the forced command prefix:\n%s", script)
+	}
+	if !strings.Contains(script, `--dataset "${args%% *}" --namespace "${args#* --namespace }"`) {
+		t.Fatalf("login shell does not pass the namespace on:\n%s", script)
+	}
+	if strings.Contains(script, `eval`) || strings.Contains(script, `sh -c`) {
+		t.Fatalf("login shell must not evaluate its argument:\n%s", script)
+	}
+}
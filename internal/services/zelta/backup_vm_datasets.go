@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+
+package zelta
+
+import (
+	"path"
+	"slices"
+	"strings"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+)
+
+// vmDatasetRules holds a VM job's include and exclude patterns for the
+// storage datasets below each VM dataset root.
+type vmDatasetRules struct {
+	includes []string
+	excludes []string
+}
+
+func vmDatasetRulesForJob(job *clusterModels.BackupJob) vmDatasetRules {
+	if job == nil || job.Mode != clusterModels.BackupJobModeVM {
+		return vmDatasetRules{}
+	}
+	return vmDatasetRules{
+		includes: clusterModels.SplitBackupDatasetPatterns(job.VMDatasetIncludes),
+		excludes: clusterModels.SplitBackupDatasetPatterns(job.VMDatasetExcludes),
+	}
+}
+
+func (r vmDatasetRules) empty() bool {
+	return len(r.includes) == 0 && len(r.excludes) == 0
+}
+
+func vmDatasetPatternMatches(patterns []string, root, dataset string) bool {
+	rel := strings.TrimPrefix(strings.TrimPrefix(dataset, root), "/")
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, dataset); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// keeps reports whether a dataset below root is transferred. The root itself
+// always is: it carries the VM's layout, and Zelta excludes descendants of an
+// excluded dataset anyway.
+func (r vmDatasetRules) keeps(root, dataset string) bool {
+	if dataset == root {
+		return true
+	}
+	if len(r.includes) > 0 && !vmDatasetPatternMatches(r.includes, root, dataset) {
+		return false
+	}
+	return !vmDatasetPatternMatches(r.excludes, root, dataset)
+}
+
+// applyVMDatasetRules filters the VM dataset roots in sources against the
+// local datasets below them. It returns the roots still worth transferring
+// and the datasets to exclude from their recursive transfers. A root other
+// than the preferred one is dropped once every dataset below it is excluded.
+func applyVMDatasetRules(sources []string, preferred string, datasets []string, rules vmDatasetRules) ([]string, []string) {
+	if rules.empty() {
+		return sources, nil
+	}
+
+	sorted := slices.Clone(datasets)
+	slices.Sort(sorted)
+
+	kept := make([]string, 0, len(sources))
+	excluded := make([]string, 0)
+	for _, root := range sources {
+		children, dropped := 0, 0
+		for _, dataset := range sorted {
+			if !strings.HasPrefix(dataset, root+"/") {
+				continue
+			}
+			if slices.ContainsFunc(excluded, func(parent string) bool {
+				return strings.HasPrefix(dataset, parent+"/")
+			}) {
+				continue
+			}
+
+			children++
+			if !rules.keeps(root, dataset) {
+				dropped++
+				excluded = append(excluded, dataset)
+			}
+		}
+
+		if root != preferred && children > 0 && children == dropped {
+			continue
+		}
+		kept = append(kept, root)
+	}
+
+	return kept, excluded
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+
+package zelta
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+)
+
+var vmRuleDatasets = []string{
+	"zroot/sylve/virtual-machines/100",
+	"zroot/sylve/virtual-machines/100/raw-1",
+	"zroot/sylve/virtual-machines/100/zvol-2",
+	"zroot/sylve/virtual-machines/100/zvol-3",
+	"scratch/sylve/virtual-machines/100",
+	"scratch/sylve/virtual-machines/100/zvol-4",
+	"zroot/sylve/virtual-machines/1001/zvol-9",
+}
+
+var vmRuleSources = []string{
+	"zroot/sylve/virtual-machines/100",
+	"scratch/sylve/virtual-machines/100",
+}
+
+func TestApplyVMDatasetRulesExcludes(t *testing.T) {
+	rules := vmDatasetRulesForJob(&clusterModels.BackupJob{
+		Mode:              clusterModels.BackupJobModeVM,
+		VMDatasetExcludes: "zvol-3, scratch/sylve/virtual-machines/100/*",
+	})
+
+	kept, excluded := applyVMDatasetRules(vmRuleSources, vmRuleSources[0], vmRuleDatasets, rules)
+	if !slices.Equal(kept, []string{"zroot/sylve/virtual-machines/100"}) {
+		t.Fatalf("expected the scratch root to be dropped, got %v", kept)
+	}
+
+	want := []string{
+		"zroot/sylve/virtual-machines/100/zvol-3",
+		"scratch/sylve/virtual-machines/100/zvol-4",
+	}
+	slices.Sort(want)
+	slices.Sort(excluded)
+	if !slices.Equal(excluded, want) {
+		t.Fatalf("expected excluded %v, got %v", want, excluded)
+	}
+}
+
+func TestApplyVMDatasetRulesIncludes(t *testing.T) {
+	rules := vmDatasetRules{includes: []string{"raw-*"}}
+
+	kept, excluded := applyVMDatasetRules(vmRuleSources[:1], vmRuleSources[0], vmRuleDatasets, rules)
+	if !slices.Equal(kept, vmRuleSources[:1]) {
+		t.Fatalf("expected the preferred root to stay, got %v", kept)
+	}
+	if !slices.Equal(excluded, []string{
+		"zroot/sylve/virtual-machines/100/zvol-2",
+		"zroot/sylve/virtual-machines/100/zvol-3",
+	}) {
+		t.Fatalf("expected only raw disks to be kept, got excluded %v", excluded)
+	}
+}
+
+func TestApplyVMDatasetRulesEmpty(t *testing.T) {
+	kept, excluded := applyVMDatasetRules(vmRuleSources, vmRuleSources[0], vmRuleDatasets, vmDatasetRules{})
+	if !slices.Equal(kept, vmRuleSources) || excluded != nil {
+		t.Fatalf("expected no filtering without rules, got %v %v", kept, excluded)
+	}
+
+	rules := vmDatasetRulesForJob(&clusterModels.BackupJob{
+		Mode:              clusterModels.BackupJobModeDataset,
+		VMDatasetExcludes: "zvol-2",
+	})
+	if !rules.empty() {
+		t.Fatal("expected dataset jobs to carry no VM dataset rules")
+	}
+}
+
+func TestBackupArgsCarryVMDatasetExclusions(t *testing.T) {
+	excluded := []string{"zroot/sylve/virtual-machines/100/zvol-3", "zroot/sylve/virtual-machines/100/zvol-4"}
+
+	args := backupZeltaArgs("zroot/sylve/virtual-machines/100", "root@host:tank/target", "bk_j1_test", true, excluded...)
+	idx := slices.Index(args, "--exclude")
+	if idx < 0 || args[idx+1] != strings.Join(excluded, ",") {
+		t.Fatalf("expected --exclude with both datasets, got %v", args)
+	}
+	if got := args[len(args)-2:]; !slices.Equal(got, []string{"zroot/sylve/virtual-machines/100", "root@host:tank/target"}) {
+		t.Fatalf("source/target arguments moved: got %v", got)
+	}
+
+	seed := backupSeedSendArgs("zroot/sylve/virtual-machines/100", "bk_j1_test", true, false, excluded...)
+	if idx := slices.Index(seed, "-X"); idx < 0 || seed[idx+1] != strings.Join(excluded, ",") {
+		t.Fatalf("expected seed stream to exclude both datasets, got %v", seed)
+	}
+	if seed := backupSeedSendArgs("zroot/sylve/virtual-machines/100", "bk_j1_test", false, false, excluded...); slices.Contains(seed, "-X") {
+		t.Fatalf("expected no exclusion on a non-recursive stream, got %v", seed)
+	}
+}
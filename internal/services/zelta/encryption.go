@@ -32,7 +32,7 @@ func (s *Service) ReconcileEncryptionKeys() error {
 	for _, key := range keys {
 		keyPath := filepath.Join(EncryptionKeyDirectory, key.UUID)
 		existing, statErr := os.ReadFile(keyPath)
-		if statErr == nil && string(existing) == key.KeyData {
+		if statErr == nil && string(existing) == string(key.KeyData) {
 			continue
 		}
 
@@ -202,7 +202,7 @@ func (s *Service) ensureEncryptionKeyForDataset(ctx context.Context, ds *gzfs.Da
 	}
 
 	for _, key := range keys {
-		if err := ds.LoadKeyWithPassphrase(ctx, key.KeyData, false); err != nil {
+		if err := ds.LoadKeyWithPassphrase(ctx, string(key.KeyData), false); err != nil {
 			continue
 		}
 
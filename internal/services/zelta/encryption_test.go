@@ -240,7 +240,7 @@ func TestAutoDiscoverAndRegisterExternalFileKey(t *testing.T) {
 	if err != nil {
 		t.Fatalf("external key was not registered: %v", err)
 	}
-	if key.KeyData != passphrase || key.KeyFormat != "passphrase" {
+	if string(key.KeyData) != passphrase || key.KeyFormat != "passphrase" {
 		t.Fatalf("registered key mismatch: %+v", key)
 	}
 }
@@ -0,0 +1,376 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/layout"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	// guestCloneSnapshotPrefix shares the migration prefix so clone snapshots
+	// are reserved from user snapshot names and swept on the target after
+	// import by destroyDatasetMigrationSnapshots.
+	guestCloneSnapshotPrefix = "sylve-migrate-clone-"
+	guestCloneImportTimeout  = 10 * time.Minute
+)
+
+// guestCloneVMRoots returns the per-pool guest roots that make up a VM. Only
+// the sylve/virtual-machines/<rid> trees are copied; anything a VM references
+// outside of them stays with the source node.
+func guestCloneVMRoots(vm vmModels.VM) []string {
+	seen := make(map[string]struct{})
+	roots := make([]string, 0, len(vm.Storages))
+	for _, storage := range vm.Storages {
+		pool := strings.TrimSpace(storage.Dataset.Pool)
+		if pool == "" {
+			pool = strings.TrimSpace(storage.Pool)
+		}
+		if pool == "" || vm.RID == 0 {
+			continue
+		}
+		root := fmt.Sprintf("%s/%d", layout.VMsDataset(pool), vm.RID)
+		if _, ok := seen[root]; ok {
+			continue
+		}
+		seen[root] = struct{}{}
+		roots = append(roots, root)
+	}
+	sort.Strings(roots)
+	return roots
+}
+
+// CloneVMToNode copies a local VM to another cluster node under a new RID.
+// The clone ID is reserved cluster-wide through a clone guest operation for
+// the whole attempt, the guest roots are sent with zelta under the new RID and
+// the target registers them as a new VM. The source VM is never modified.
+func (s *Service) CloneVMToNode(
+	ctx context.Context,
+	taskID uint,
+	rid uint,
+	req clusterServiceInterfaces.CloneGuestRequest,
+) (retErr error) {
+	if s.Cluster == nil || s.Cluster.Raft == nil {
+		return fmt.Errorf("cluster_service_unavailable")
+	}
+	if rid == 0 || taskID == 0 {
+		return fmt.Errorf("invalid_clone_request")
+	}
+
+	localNodeID := strings.TrimSpace(s.Cluster.LocalNodeID())
+	targetNodeID := strings.TrimSpace(req.TargetNodeID)
+	if targetNodeID == "" || targetNodeID == localNodeID {
+		return fmt.Errorf("clone_target_must_be_another_node")
+	}
+	if req.CloneID == 0 || req.CloneID == rid {
+		return fmt.Errorf("invalid_clone_id")
+	}
+
+	vm, err := s.findVMByRID(rid)
+	if err != nil {
+		return fmt.Errorf("failed_to_load_vm: %w", err)
+	}
+	if vm == nil {
+		return fmt.Errorf("vm_not_found")
+	}
+	roots := guestCloneVMRoots(*vm)
+	if len(roots) == 0 {
+		return fmt.Errorf("vm_has_no_clonable_datasets")
+	}
+
+	if err := s.Cluster.RequireGuestIDAvailable(ctx, req.CloneID); err != nil {
+		return err
+	}
+
+	token := uuid.NewString()
+	acquire := clusterModels.ReplicationGuestOperationAcquire{
+		GuestType:    clusterModels.ReplicationGuestTypeVM,
+		GuestID:      req.CloneID,
+		Operation:    clusterModels.ReplicationGuestOperationClone,
+		Token:        token,
+		OwnerNodeID:  localNodeID,
+		TargetNodeID: targetNodeID,
+		TaskID:       taskID,
+		AcquiredAt:   time.Now().UTC(),
+	}
+	transition := clusterModels.ReplicationGuestOperationTransition{
+		GuestType:    clusterModels.ReplicationGuestTypeVM,
+		GuestID:      req.CloneID,
+		Operation:    clusterModels.ReplicationGuestOperationClone,
+		Token:        token,
+		TargetNodeID: targetNodeID,
+	}
+	if err := s.applyGuestMigrationInterlock(ctx, "acquire", acquire, transition); err != nil {
+		return fmt.Errorf("clone_id_reservation_failed: %w", err)
+	}
+	defer func() {
+		if err := s.applyGuestMigrationInterlock(context.Background(), "abort", acquire, transition); err != nil {
+			logger.L.Warn().Err(err).Uint("clone_id", req.CloneID).Msg("failed_to_release_clone_reservation")
+		}
+	}()
+
+	if err := s.VM.WriteVMJson(rid); err != nil {
+		return fmt.Errorf("failed_to_write_vm_metadata: %w", err)
+	}
+
+	identities, err := s.Cluster.ListClusterSSHIdentities()
+	if err != nil {
+		return err
+	}
+	identityByNode := make(map[string]clusterModels.ClusterSSHIdentity, len(identities))
+	for _, identity := range identities {
+		identityByNode[strings.TrimSpace(identity.NodeUUID)] = identity
+	}
+	privateKeyPath, err := s.Cluster.ClusterSSHPrivateKeyPath()
+	if err != nil {
+		return fmt.Errorf("cluster_ssh_private_key_path_failed: %w", err)
+	}
+
+	snapshotName := fmt.Sprintf("%s%d", guestCloneSnapshotPrefix, time.Now().UTC().Unix())
+	defer func() {
+		for _, root := range roots {
+			output, err := utils.RunCommandWithContext(context.Background(), "zfs", "destroy", "-r", root+"@"+snapshotName)
+			if err != nil && !strings.Contains(output, "could not find any snapshots") {
+				logger.L.Warn().Err(err).Str("dataset", root).Msg("failed_to_destroy_clone_snapshot")
+			}
+		}
+	}()
+
+	// Datasets received for this clone are only ours to destroy once we know
+	// the target did not already hold them.
+	type sentRoot struct {
+		target  *clusterModels.BackupTarget
+		dataset string
+	}
+	var sent []sentRoot
+	defer func() {
+		if retErr == nil {
+			return
+		}
+		for _, entry := range sent {
+			if _, err := s.runTargetSSH(context.Background(), entry.target, "zfs", "destroy", "-r", entry.dataset); err != nil {
+				logger.L.Warn().Err(err).Str("dataset", entry.dataset).Msg("failed_to_destroy_partial_clone_on_target")
+			}
+		}
+	}()
+
+	destRoots := make([]string, 0, len(roots))
+	for _, root := range roots {
+		destRoot := rewriteVMDatasetGuestID(root, req.CloneID)
+		target, destSuffix, err := s.replicationTargetSpec(targetNodeID, destRoot, identityByNode, privateKeyPath)
+		if err != nil {
+			return err
+		}
+
+		exists, _, err := s.remoteDatasetExists(ctx, target, destRoot)
+		if err != nil {
+			return fmt.Errorf("clone_target_dataset_check_failed_%s: %w", destRoot, err)
+		}
+		if exists {
+			return fmt.Errorf("clone_target_dataset_exists: %s", destRoot)
+		}
+
+		lease := s.transfers.acquire(target, transferClassReplication)
+		output, err := runZeltaWithEnv(
+			ctx,
+			lease.zeltaEnv(s.buildZeltaEnv(target)),
+			backupZeltaArgs(root, target.ZeltaEndpoint(destSuffix), snapshotName, true)...,
+		)
+		lease.release()
+		sent = append(sent, sentRoot{target: target, dataset: destRoot})
+		if err != nil {
+			return fmt.Errorf("clone_transfer_%s_failed: %s: %w", root, strings.TrimSpace(output), err)
+		}
+		destRoots = append(destRoots, destRoot)
+	}
+
+	// From here on the target owns the received roots and discards them
+	// itself if registration fails.
+	sent = nil
+	if _, err := s.forwardReplicationPolicyControlRead(targetNodeID, "clone/import-vm", map[string]any{
+		"guestId":            req.CloneID,
+		"operationToken":     token,
+		"sourceDatasetRoots": destRoots,
+		"withNetwork":        req.WithNetwork,
+	}, guestCloneImportTimeout); err != nil {
+		return fmt.Errorf("clone_import_on_target_failed: %w", err)
+	}
+
+	return nil
+}
+
+// RequireCloneTargetReservation checks that the local node is the target of
+// the clone reservation held for rid under token.
+func (s *Service) RequireCloneTargetReservation(ctx context.Context, rid uint, token string) error {
+	token = strings.TrimSpace(token)
+	if s.Cluster == nil {
+		return fmt.Errorf("cluster_service_unavailable")
+	}
+	if rid == 0 || token == "" {
+		return fmt.Errorf("clone_reservation_input_invalid")
+	}
+
+	var operation clusterModels.ReplicationGuestOperation
+	result := s.DB.WithContext(ctx).
+		Where("guest_type = ? AND guest_id = ?", clusterModels.ReplicationGuestTypeVM, rid).
+		Limit(1).
+		Find(&operation)
+	if result.Error != nil {
+		return fmt.Errorf("clone_reservation_lookup_failed: %w", result.Error)
+	}
+	if result.RowsAffected != 1 ||
+		operation.Operation != clusterModels.ReplicationGuestOperationClone ||
+		strings.TrimSpace(operation.Token) != token ||
+		strings.TrimSpace(operation.TargetNodeID) != strings.TrimSpace(s.Cluster.LocalNodeID()) {
+		return fmt.Errorf("clone_reservation_mismatch")
+	}
+	return nil
+}
+
+// ImportClonedVMWithRoots registers received clone roots as a new VM. The
+// metadata still describes the source guest, so it is reconciled as a new
+// guest taking its RID from the dataset path. Clones keep their pool layout,
+// so the metadata dataset is its own rebase root. Source NICs are only kept
+// when withNetwork is set.
+func (s *Service) ImportClonedVMWithRoots(
+	ctx context.Context,
+	rid uint,
+	roots []string,
+	withNetwork bool,
+) (warnings []string, err error) {
+	if rid == 0 {
+		return nil, fmt.Errorf("invalid_vm_rid")
+	}
+	s.migrationVMImportMu.Lock()
+	defer s.migrationVMImportMu.Unlock()
+
+	roots, err = s.ValidateMigratedVMRoots(ctx, rid, roots)
+	if err != nil {
+		return nil, err
+	}
+
+	metadataDataset := ""
+	var clonedMetadata *restoredVMMetadata
+	for _, dataset := range roots {
+		meta, readErr := s.readLocalRestoredVMMetadata(ctx, dataset, rid)
+		if readErr != nil {
+			return nil, fmt.Errorf("failed_to_read_cloned_vm_metadata_from_%s: %w", dataset, readErr)
+		}
+		if meta != nil {
+			metadataDataset = dataset
+			clonedMetadata = meta
+			break
+		}
+	}
+	if metadataDataset == "" || clonedMetadata == nil {
+		return nil, fmt.Errorf("cloned_vm_metadata_invalid")
+	}
+
+	registered := false
+	defer func() {
+		if err == nil || registered {
+			return
+		}
+		for _, dataset := range roots {
+			output, destroyErr := utils.RunCommandWithContext(context.Background(), "zfs", "destroy", "-r", dataset)
+			if destroyErr != nil {
+				logger.L.Warn().Err(destroyErr).Str("dataset", dataset).Str("output", output).Msg("failed_to_discard_unregistered_clone")
+			}
+		}
+	}()
+
+	if clonedMetadata.VM.VNCEnabled {
+		requestedPort := clonedMetadata.VM.VNCPort
+		resolvedPort, reassigned, resolveErr := s.resolveMigratedVMVNCPort(rid, requestedPort)
+		if resolveErr != nil {
+			return warnings, fmt.Errorf("failed_to_resolve_cloned_vm_vnc_port: %w", resolveErr)
+		}
+		if reassigned {
+			clonedMetadata.VM.VNCPort = resolvedPort
+			if err := s.writeVMMetadataToDataset(ctx, metadataDataset, clonedMetadata); err != nil {
+				return warnings, fmt.Errorf("failed_to_persist_cloned_vm_vnc_port: %w", err)
+			}
+			warnings = append(warnings, fmt.Sprintf(
+				"warning_target_vnc_port_reassigned: %d -> %d",
+				requestedPort,
+				resolvedPort,
+			))
+		}
+	}
+
+	if err := s.reconcileRestoredVMFromDatasetAsNew(ctx, metadataDataset, metadataDataset, withNetwork); err != nil {
+		s.cleanupOrphanedVMRegistration(rid)
+		return warnings, fmt.Errorf("failed_to_import_cloned_vm: %w", err)
+	}
+	registered = true
+
+	if err := activateMigratedDatasetRoots(ctx, roots, s.prepareReplicatedDatasetForActivation); err != nil {
+		return warnings, fmt.Errorf("failed_to_prepare_cloned_vm_datasets_for_activation: %w", err)
+	}
+
+	for _, dataset := range roots {
+		if err := s.destroyDatasetMigrationSnapshots(ctx, dataset); err != nil {
+			logger.L.Warn().Err(err).Str("dataset", dataset).Msg("failed_to_destroy_clone_snapshots_on_target")
+		}
+	}
+
+	return warnings, nil
+}
+
+// releaseStaleCloneReservations drops clone reservations owned by this node
+// whose lifecycle task is no longer active, e.g. after a restart interrupted
+// the clone before it could release its guest ID.
+func (s *Service) releaseStaleCloneReservations(ctx context.Context) {
+	if s.DB == nil || s.Cluster == nil || s.Cluster.Raft == nil {
+		return
+	}
+
+	var operations []clusterModels.ReplicationGuestOperation
+	if err := s.DB.WithContext(ctx).
+		Where("operation = ? AND owner_node_id = ?", clusterModels.ReplicationGuestOperationClone, s.Cluster.LocalNodeID()).
+		Find(&operations).Error; err != nil {
+		logger.L.Warn().Err(err).Msg("failed_to_list_clone_reservations")
+		return
+	}
+
+	for _, operation := range operations {
+		var active int64
+		if err := s.DB.WithContext(ctx).Model(&taskModels.GuestLifecycleTask{}).
+			Where("id = ? AND status IN ?", operation.TaskID, []string{
+				taskModels.LifecycleTaskStatusQueued,
+				taskModels.LifecycleTaskStatusRunning,
+			}).
+			Count(&active).Error; err != nil || active > 0 {
+			continue
+		}
+
+		transition := clusterModels.ReplicationGuestOperationTransition{
+			GuestType:    operation.GuestType,
+			GuestID:      operation.GuestID,
+			Operation:    operation.Operation,
+			Token:        operation.Token,
+			TargetNodeID: operation.TargetNodeID,
+		}
+		if err := s.applyGuestMigrationInterlock(ctx, "abort", clusterModels.ReplicationGuestOperationAcquire{}, transition); err != nil {
+			logger.L.Warn().Err(err).Uint("clone_id", operation.GuestID).Msg("failed_to_release_stale_clone_reservation")
+		}
+	}
+}
@@ -0,0 +1,498 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/config"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// A guest export is a plain tar archive. The payload entries come first:
+// one full zfs stream per guest dataset for the zfs format, or a single
+// nested tar of the jail's root filesystem for the tar format. The manifest
+// is always the last entry so a truncated archive never looks complete.
+const (
+	guestExportVersion       = 1
+	guestExportManifestFile  = "sylve-export.json"
+	guestExportRootFSFile    = "rootfs.tar"
+	guestExportOperation     = "guest_export"
+	guestExportSnapshotLabel = "sylve_export"
+	tarBlockSize             = 512
+)
+
+type GuestExportStream struct {
+	SourceDataset string `json:"sourceDataset"`
+	File          string `json:"file"`
+	Bytes         int64  `json:"bytes"`
+	SHA256        string `json:"sha256"`
+	Encrypted     bool   `json:"encrypted,omitempty"`
+}
+
+type GuestExportManifest struct {
+	Version      int                 `json:"version"`
+	GuestType    string              `json:"guestType"`
+	GuestID      uint                `json:"guestId"`
+	GuestName    string              `json:"guestName"`
+	Format       string              `json:"format"`
+	NodeID       string              `json:"nodeId"`
+	SnapshotName string              `json:"snapshotName"`
+	CreatedAt    time.Time           `json:"createdAt"`
+	Streams      []GuestExportStream `json:"streams"`
+	Guest        json.RawMessage     `json:"guest"`
+}
+
+func guestExportArchiveName(guestType string, guestID uint, format, token string) string {
+	return fmt.Sprintf("sylve-export-%s-%d-%s-%s.tar", guestType, guestID, format, token)
+}
+
+// guestExportEntryHeader renders the single 512 byte GNU header of a regular
+// file entry. GNU headers store sizes beyond 8GiB in base-256 inside the same
+// block, which lets a payload be streamed first and its header filled in
+// afterwards.
+func guestExportEntryHeader(name string, size int64, modTime time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0600,
+		Size:     size,
+		ModTime:  modTime,
+		Format:   tar.FormatGNU,
+	}); err != nil {
+		return nil, err
+	}
+	if buf.Len() != tarBlockSize {
+		return nil, fmt.Errorf("guest_export_header_size_unexpected: %d", buf.Len())
+	}
+	return buf.Bytes(), nil
+}
+
+// writeGuestExportEntry streams produce into the archive as one entry and
+// returns its size and sha256.
+func writeGuestExportEntry(file *os.File, name string, produce func(io.Writer) error) (int64, string, error) {
+	headerAt, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, "", err
+	}
+	if _, err := file.Write(make([]byte, tarBlockSize)); err != nil {
+		return 0, "", err
+	}
+
+	hasher := sha256.New()
+	counter := &countingWriter{}
+	if err := produce(io.MultiWriter(file, hasher, counter)); err != nil {
+		return 0, "", err
+	}
+
+	if pad := (tarBlockSize - counter.n%tarBlockSize) % tarBlockSize; pad > 0 {
+		if _, err := file.Write(make([]byte, pad)); err != nil {
+			return 0, "", err
+		}
+	}
+
+	header, err := guestExportEntryHeader(name, counter.n, time.Now().UTC())
+	if err != nil {
+		return 0, "", err
+	}
+	if _, err := file.WriteAt(header, headerAt); err != nil {
+		return 0, "", err
+	}
+	if _, err := file.Seek(0, io.SeekEnd); err != nil {
+		return 0, "", err
+	}
+
+	return counter.n, hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+func writeGuestExportManifest(file *os.File, manifest *GuestExportManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode_guest_export_manifest_failed: %w", err)
+	}
+
+	tw := tar.NewWriter(file)
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     guestExportManifestFile,
+		Mode:     0600,
+		Size:     int64(len(data)),
+		ModTime:  manifest.CreatedAt,
+		Format:   tar.FormatGNU,
+	}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(data); err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+func runToWriter(ctx context.Context, w io.Writer, name string, args ...string) error {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = w
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s_failed: %s: %w", name, strings.TrimSpace(stderr.String()), err)
+	}
+	return nil
+}
+
+func (s *Service) ListGuestExports() ([]clusterModels.GuestExport, error) {
+	var exports []clusterModels.GuestExport
+	if err := s.DB.Order("created_at DESC").Find(&exports).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range exports {
+		if exports[i].Status == clusterModels.GuestExportStatusRunning && !s.guestExportInProgress(&exports[i]) {
+			// The daemon restarted while this export was being written.
+			exports[i].Status = clusterModels.GuestExportStatusFailed
+			exports[i].Error = "guest_export_interrupted"
+			_ = s.DB.Model(&exports[i]).Updates(map[string]any{
+				"status": exports[i].Status,
+				"error":  exports[i].Error,
+			}).Error
+			_ = os.Remove(exports[i].Path)
+		}
+	}
+
+	return exports, nil
+}
+
+func (s *Service) guestExportInProgress(export *clusterModels.GuestExport) bool {
+	key := workloadOperationKey(export.GuestType, export.GuestID)
+	s.workloadOpMu.Lock()
+	defer s.workloadOpMu.Unlock()
+	return s.runningWorkloadOp[key] == guestExportOperation
+}
+
+// GetReadyGuestExport returns an export whose archive can be downloaded.
+func (s *Service) GetReadyGuestExport(id uint) (*clusterModels.GuestExport, error) {
+	var export clusterModels.GuestExport
+	if err := s.DB.First(&export, id).Error; err != nil {
+		return nil, err
+	}
+	if export.Status != clusterModels.GuestExportStatusReady {
+		return nil, fmt.Errorf("guest_export_not_ready: %s", export.Status)
+	}
+	if _, err := os.Stat(export.Path); err != nil {
+		return nil, fmt.Errorf("guest_export_archive_missing: %w", err)
+	}
+	return &export, nil
+}
+
+func (s *Service) DeleteGuestExport(id uint) error {
+	var export clusterModels.GuestExport
+	if err := s.DB.First(&export, id).Error; err != nil {
+		return err
+	}
+	if export.Status == clusterModels.GuestExportStatusRunning && s.guestExportInProgress(&export) {
+		return fmt.Errorf("guest_export_in_progress")
+	}
+	if err := os.Remove(export.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove_guest_export_archive_failed: %w", err)
+	}
+	return s.DB.Delete(&export).Error
+}
+
+// guestExportSources returns the guest's name, its datasets and its config
+// record, which goes into the manifest so the guest can be recreated by hand.
+func (s *Service) guestExportSources(ctx context.Context, guestType string, guestID uint, format string) (string, []string, any, error) {
+	switch guestType {
+	case clusterModels.BackupJobModeVM:
+		if format != clusterModels.GuestExportFormatZFS {
+			return "", nil, nil, fmt.Errorf("guest_export_tar_requires_jail")
+		}
+		vm, err := s.findVMByRID(guestID)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		if vm == nil {
+			return "", nil, nil, fmt.Errorf("vm_not_found_on_this_node")
+		}
+		if s.VM != nil {
+			if err := s.VM.WriteVMJson(guestID); err != nil {
+				return "", nil, nil, fmt.Errorf("write_vm_metadata_failed: %w", err)
+			}
+		}
+		sources, _, err := s.resolveVMBackupSourceDatasets(ctx, guestID, "", vmDatasetRules{})
+		if err != nil {
+			return "", nil, nil, err
+		}
+		if len(sources) == 0 {
+			return "", nil, nil, fmt.Errorf("vm_source_datasets_not_found")
+		}
+		return vm.Name, sources, vm, nil
+	case clusterModels.BackupJobModeJail:
+		var jail jailModels.Jail
+		if err := s.DB.Preload("Storages").Preload("Networks").Where("ct_id = ?", guestID).First(&jail).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return "", nil, nil, fmt.Errorf("jail_not_found_on_this_node")
+			}
+			return "", nil, nil, err
+		}
+		source, err := s.resolveJailReplicationSourceDataset(guestID)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		return jail.Name, []string{source}, jail, nil
+	default:
+		return "", nil, nil, fmt.Errorf("invalid_guest_type")
+	}
+}
+
+// StartGuestExport checks that the guest is stopped, snapshots it and then
+// writes the archive in the background. The guest stays locked against
+// starts, backups and migrations until the archive is complete.
+func (s *Service) StartGuestExport(ctx context.Context, req clusterServiceInterfaces.GuestExportReq) (*clusterModels.GuestExport, error) {
+	guestType := strings.ToLower(strings.TrimSpace(req.GuestType))
+	format := strings.ToLower(strings.TrimSpace(req.Format))
+	if format != clusterModels.GuestExportFormatZFS && format != clusterModels.GuestExportFormatTar {
+		return nil, fmt.Errorf("invalid_guest_export_format")
+	}
+
+	directory := strings.TrimSpace(req.Directory)
+	if directory == "" {
+		dataPath, err := config.GetDataPath()
+		if err != nil {
+			return nil, err
+		}
+		directory = filepath.Join(dataPath, "exports")
+		if err := os.MkdirAll(directory, 0700); err != nil {
+			return nil, fmt.Errorf("create_guest_export_directory_failed: %w", err)
+		}
+	}
+	if !filepath.IsAbs(directory) {
+		return nil, fmt.Errorf("guest_export_directory_must_be_absolute")
+	}
+	directory = filepath.Clean(directory)
+	if info, err := os.Stat(directory); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("guest_export_directory_unavailable")
+	}
+
+	if ok, holder := s.acquireWorkloadOperation(guestType, req.GuestID, guestExportOperation); !ok {
+		return nil, fmt.Errorf("guest_operation_in_progress: %s", holder)
+	}
+	held := true
+	defer func() {
+		if held {
+			s.releaseWorkloadOperation(guestType, req.GuestID)
+		}
+	}()
+
+	running, err := s.isReplicationGuestRunning(guestType, req.GuestID)
+	if err != nil {
+		return nil, fmt.Errorf("guest_state_check_failed: %w", err)
+	}
+	if running {
+		return nil, fmt.Errorf("guest_must_be_stopped")
+	}
+
+	name, sources, guest, err := s.guestExportSources(ctx, guestType, req.GuestID, format)
+	if err != nil {
+		return nil, err
+	}
+	guestJSON, err := json.Marshal(guest)
+	if err != nil {
+		return nil, fmt.Errorf("encode_guest_config_failed: %w", err)
+	}
+
+	acquired, datasetHolder, heldRoots := s.acquireDatasetOperations(sources)
+	if !acquired {
+		return nil, fmt.Errorf("backup_dataset_operation_conflict: holder=%s", datasetHolder)
+	}
+	releaseDatasets := true
+	defer func() {
+		if releaseDatasets {
+			s.releaseDatasetOperations(heldRoots)
+		}
+	}()
+
+	token := compactNowToken()
+	snapshotName := guestExportSnapshotLabel + "_" + token
+	var snapshotted []string
+	for _, source := range sources {
+		if output, err := utils.RunCommandWithContext(ctx, "zfs", "snapshot", "-r", source+"@"+snapshotName); err != nil {
+			s.destroyGuestExportSnapshots(snapshotted, snapshotName)
+			return nil, fmt.Errorf("guest_export_snapshot_failed: %s: %w", strings.TrimSpace(output), err)
+		}
+		snapshotted = append(snapshotted, source)
+	}
+
+	export := &clusterModels.GuestExport{
+		GuestType: guestType,
+		GuestID:   req.GuestID,
+		GuestName: name,
+		Format:    format,
+		Path:      filepath.Join(directory, guestExportArchiveName(guestType, req.GuestID, format, token)),
+		Status:    clusterModels.GuestExportStatusRunning,
+	}
+	if err := s.DB.Create(export).Error; err != nil {
+		s.destroyGuestExportSnapshots(snapshotted, snapshotName)
+		return nil, err
+	}
+
+	manifest := &GuestExportManifest{
+		Version:      guestExportVersion,
+		GuestType:    guestType,
+		GuestID:      req.GuestID,
+		GuestName:    name,
+		Format:       format,
+		NodeID:       s.localNodeID(),
+		SnapshotName: snapshotName,
+		CreatedAt:    export.CreatedAt.UTC(),
+		Guest:        guestJSON,
+	}
+
+	held, releaseDatasets = false, false
+	go func() {
+		defer s.releaseWorkloadOperation(guestType, req.GuestID)
+		defer s.releaseDatasetOperations(heldRoots)
+		defer s.destroyGuestExportSnapshots(snapshotted, snapshotName)
+		s.writeGuestExport(context.Background(), export, manifest, snapshotted)
+	}()
+
+	return export, nil
+}
+
+func (s *Service) writeGuestExport(
+	ctx context.Context,
+	export *clusterModels.GuestExport,
+	manifest *GuestExportManifest,
+	sources []string,
+) {
+	logger.L.Info().
+		Uint("export_id", export.ID).
+		Str("guest_type", export.GuestType).
+		Uint("guest_id", export.GuestID).
+		Str("path", export.Path).
+		Msg("guest_export_started")
+
+	size, err := s.writeGuestExportArchive(ctx, export, manifest, sources)
+
+	now := time.Now().UTC()
+	updates := map[string]any{"completed_at": &now}
+	if err != nil {
+		_ = os.Remove(export.Path)
+		updates["status"] = clusterModels.GuestExportStatusFailed
+		updates["error"] = err.Error()
+		logger.L.Warn().Err(err).Uint("export_id", export.ID).Msg("guest_export_failed")
+	} else {
+		updates["status"] = clusterModels.GuestExportStatusReady
+		updates["bytes"] = size
+		logger.L.Info().Uint("export_id", export.ID).Int64("bytes", size).Msg("guest_export_ready")
+	}
+
+	if err := s.DB.Model(&clusterModels.GuestExport{}).Where("id = ?", export.ID).Updates(updates).Error; err != nil {
+		logger.L.Warn().Err(err).Uint("export_id", export.ID).Msg("guest_export_status_update_failed")
+	}
+}
+
+func (s *Service) writeGuestExportArchive(
+	ctx context.Context,
+	export *clusterModels.GuestExport,
+	manifest *GuestExportManifest,
+	sources []string,
+) (int64, error) {
+	file, err := os.OpenFile(export.Path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return 0, fmt.Errorf("create_guest_export_archive_failed: %w", err)
+	}
+	defer file.Close()
+
+	for i, source := range sources {
+		stream := GuestExportStream{SourceDataset: source}
+
+		var produce func(io.Writer) error
+		if export.Format == clusterModels.GuestExportFormatTar {
+			mountpoint, err := s.runLocalZFSGet(ctx, "mountpoint", source)
+			if err != nil {
+				return 0, err
+			}
+			if !filepath.IsAbs(mountpoint) {
+				return 0, fmt.Errorf("guest_export_dataset_not_mounted: %s", source)
+			}
+			// Reading through .zfs/snapshot keeps the copy consistent even
+			// if the guest is started again before the archive is done.
+			snapshotDir := filepath.Join(mountpoint, ".zfs", "snapshot", manifest.SnapshotName)
+			stream.File = guestExportRootFSFile
+			produce = func(w io.Writer) error {
+				return runToWriter(ctx, w, "tar", "-c", "-f", "-", "--numeric-owner", "-C", snapshotDir, ".")
+			}
+		} else {
+			encrypted, err := s.isDatasetEncrypted(ctx, source)
+			if err != nil {
+				return 0, fmt.Errorf("guest_export_encryption_check_failed: %w", err)
+			}
+			stream.Encrypted = encrypted
+			stream.File = backupSeedStreamFileName(i)
+			args := backupSeedSendArgs(source, manifest.SnapshotName, true, encrypted)
+			produce = func(w io.Writer) error {
+				return runToWriter(ctx, w, "zfs", args...)
+			}
+		}
+
+		written, checksum, err := writeGuestExportEntry(file, stream.File, produce)
+		if err != nil {
+			return 0, err
+		}
+		stream.Bytes = written
+		stream.SHA256 = checksum
+		manifest.Streams = append(manifest.Streams, stream)
+	}
+
+	if err := writeGuestExportManifest(file, manifest); err != nil {
+		return 0, err
+	}
+	if err := file.Sync(); err != nil {
+		return 0, fmt.Errorf("sync_guest_export_archive_failed: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+func (s *Service) destroyGuestExportSnapshots(sources []string, snapshotName string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	for _, source := range sources {
+		if output, err := utils.RunCommandWithContext(ctx, "zfs", "destroy", "-r", source+"@"+snapshotName); err != nil {
+			logger.L.Warn().
+				Err(err).
+				Str("snapshot", source+"@"+snapshotName).
+				Str("output", strings.TrimSpace(output)).
+				Msg("guest_export_snapshot_cleanup_failed")
+		}
+	}
+}
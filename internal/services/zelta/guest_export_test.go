@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+
+package zelta
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGuestExportArchiveLayout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), guestExportArchiveName("jail", 101, "zfs", "20250101T000000Z"))
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		t.Fatalf("create archive: %v", err)
+	}
+	defer file.Close()
+
+	payloads := [][]byte{
+		bytes.Repeat([]byte("z"), 1000),
+		{},
+		bytes.Repeat([]byte("y"), tarBlockSize),
+	}
+	manifest := &GuestExportManifest{
+		Version:   guestExportVersion,
+		GuestType: "jail",
+		GuestID:   101,
+		Format:    "zfs",
+		CreatedAt: time.Unix(1700000000, 0).UTC(),
+		Guest:     json.RawMessage(`{"ctId":101}`),
+	}
+
+	for i, payload := range payloads {
+		name := backupSeedStreamFileName(i)
+		size, checksum, err := writeGuestExportEntry(file, name, func(w io.Writer) error {
+			_, err := w.Write(payload)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("write entry %d: %v", i, err)
+		}
+		sum := sha256.Sum256(payload)
+		if size != int64(len(payload)) || checksum != hex.EncodeToString(sum[:]) {
+			t.Fatalf("entry %d reported size %d checksum %s", i, size, checksum)
+		}
+		manifest.Streams = append(manifest.Streams, GuestExportStream{File: name, Bytes: size, SHA256: checksum})
+	}
+
+	if err := writeGuestExportManifest(file, manifest); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("rewind: %v", err)
+	}
+	tr := tar.NewReader(file)
+	for i, payload := range payloads {
+		header, err := tr.Next()
+		if err != nil {
+			t.Fatalf("read entry %d: %v", i, err)
+		}
+		if header.Name != backupSeedStreamFileName(i) || header.Size != int64(len(payload)) {
+			t.Fatalf("unexpected header for entry %d: %s size %d", i, header.Name, header.Size)
+		}
+		got, err := io.ReadAll(tr)
+		if err != nil || !bytes.Equal(got, payload) {
+			t.Fatalf("entry %d payload mismatch: %v", i, err)
+		}
+	}
+
+	header, err := tr.Next()
+	if err != nil || header.Name != guestExportManifestFile {
+		t.Fatalf("expected manifest as last entry, got %v %v", header, err)
+	}
+	var decoded GuestExportManifest
+	if err := json.NewDecoder(tr).Decode(&decoded); err != nil {
+		t.Fatalf("decode manifest: %v", err)
+	}
+	if len(decoded.Streams) != len(payloads) || decoded.GuestID != 101 {
+		t.Fatalf("unexpected manifest %+v", decoded)
+	}
+	if _, err := tr.Next(); err != io.EOF {
+		t.Fatalf("expected end of archive, got %v", err)
+	}
+}
+
+func TestGuestExportEntryHeaderLargeSize(t *testing.T) {
+	header, err := guestExportEntryHeader(backupSeedStreamFileName(0), 20<<30, time.Unix(0, 0))
+	if err != nil {
+		t.Fatalf("header for a 20GiB stream: %v", err)
+	}
+	if len(header) != tarBlockSize {
+		t.Fatalf("expected a single block header, got %d bytes", len(header))
+	}
+}
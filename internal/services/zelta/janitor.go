@@ -0,0 +1,458 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	"github.com/hashicorp/raft"
+	"gorm.io/gorm"
+)
+
+const (
+	staleDatasetKindRestoring = "restoring"
+	staleDatasetKindPreSylve  = "pre_sylve"
+
+	staleRestoringSuffix = ".restoring"
+	stalePreSylveMarker  = ".pre_sylve_"
+)
+
+const (
+	staleKeepReasonTooRecent        = "younger_than_min_age"
+	staleKeepReasonOperationRunning = "dataset_operation_running"
+	staleKeepReasonPromotionPending = "restore_promotion_pending"
+	staleKeepReasonJournalEntry     = "task_journal_entry"
+	staleKeepReasonBackupRunning    = "backup_job_running"
+)
+
+// StaleDataset is a leftover staging or pre-restore dataset found by the
+// janitor. Eligible datasets are old enough and referenced by nothing.
+type StaleDataset struct {
+	TargetID   uint      `json:"targetId"` // 0 for this node
+	TargetName string    `json:"targetName,omitempty"`
+	Dataset    string    `json:"dataset"`
+	Base       string    `json:"base"`
+	Kind       string    `json:"kind"`
+	CreatedAt  time.Time `json:"createdAt"`
+	Used       uint64    `json:"used"`
+	Eligible   bool      `json:"eligible"`
+	KeepReason string    `json:"keepReason,omitempty"`
+}
+
+type StaleDatasetReport struct {
+	ScannedAt   time.Time      `json:"scannedAt"`
+	MinAgeHours int            `json:"minAgeHours"`
+	Datasets    []StaleDataset `json:"datasets"`
+	Errors      []string       `json:"errors"`
+}
+
+type StaleDatasetCleanupResult struct {
+	Destroyed []StaleDataset `json:"destroyed"`
+	Skipped   []StaleDataset `json:"skipped"`
+	Reclaimed uint64         `json:"reclaimed"`
+}
+
+var janitorLocalZFSList = func(ctx context.Context) (string, error) {
+	output, err := utils.RunCommandWithContext(ctx, "zfs", "list", "-H", "-p", "-t", "filesystem,volume", "-o", "name,creation,used")
+	if err != nil {
+		return output, fmt.Errorf("%s: %w", strings.TrimSpace(output), err)
+	}
+	return output, nil
+}
+
+// classifyStaleDataset returns the kind and the dataset the leftover belongs
+// to, or an empty kind for anything else.
+func classifyStaleDataset(dataset string) (string, string) {
+	dataset = normalizeDatasetPath(dataset)
+	leaf := dataset
+	parent := ""
+	if idx := strings.LastIndex(dataset, "/"); idx >= 0 {
+		parent, leaf = dataset[:idx+1], dataset[idx+1:]
+	}
+
+	switch {
+	case strings.HasSuffix(leaf, staleRestoringSuffix) && len(leaf) > len(staleRestoringSuffix):
+		return staleDatasetKindRestoring, parent + strings.TrimSuffix(leaf, staleRestoringSuffix)
+	case strings.Index(leaf, stalePreSylveMarker) > 0:
+		return staleDatasetKindPreSylve, parent + leaf[:strings.Index(leaf, stalePreSylveMarker)]
+	}
+	return "", ""
+}
+
+// parseStaleDatasets picks leftovers from "name creation used" listings.
+// Children of a leftover are covered by its recursive destroy and skipped.
+func parseStaleDatasets(output string, targetID uint, targetName string) []StaleDataset {
+	var found []StaleDataset
+	var roots []string
+
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	sort.Strings(lines)
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		name := normalizeDatasetPath(fields[0])
+
+		covered := false
+		for _, root := range roots {
+			if strings.HasPrefix(name, root+"/") {
+				covered = true
+				break
+			}
+		}
+		if covered {
+			continue
+		}
+
+		kind, base := classifyStaleDataset(name)
+		if kind == "" {
+			continue
+		}
+
+		entry := StaleDataset{
+			TargetID:   targetID,
+			TargetName: targetName,
+			Dataset:    name,
+			Base:       base,
+			Kind:       kind,
+		}
+		if created, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+			entry.CreatedAt = time.Unix(created, 0).UTC()
+		}
+		if len(fields) > 2 {
+			entry.Used, _ = strconv.ParseUint(fields[2], 10, 64)
+		}
+
+		roots = append(roots, name)
+		found = append(found, entry)
+	}
+	return found
+}
+
+func (s *Service) GetStaleDatasetJanitor() (*clusterModels.StaleDatasetJanitor, error) {
+	var janitor clusterModels.StaleDatasetJanitor
+	if err := s.DB.Order("id ASC").First(&janitor).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &clusterModels.StaleDatasetJanitor{
+				MinAgeHours:    clusterModels.DefaultStaleDatasetMinAgeHours,
+				IncludeTargets: true,
+			}, nil
+		}
+		return nil, err
+	}
+	if janitor.MinAgeHours <= 0 {
+		janitor.MinAgeHours = clusterModels.DefaultStaleDatasetMinAgeHours
+	}
+	return &janitor, nil
+}
+
+func (s *Service) ConfigureStaleDatasetJanitor(req clusterServiceInterfaces.StaleDatasetJanitorReq) (*clusterModels.StaleDatasetJanitor, error) {
+	janitor, err := s.GetStaleDatasetJanitor()
+	if err != nil {
+		return nil, err
+	}
+
+	if req.AutoCleanup != nil {
+		janitor.AutoCleanup = *req.AutoCleanup
+	}
+	if req.MinAgeHours != nil {
+		if *req.MinAgeHours < 1 {
+			return nil, fmt.Errorf("invalid_min_age_hours")
+		}
+		janitor.MinAgeHours = *req.MinAgeHours
+	}
+	if req.IncludeTargets != nil {
+		janitor.IncludeTargets = *req.IncludeTargets
+	}
+
+	if err := s.DB.Save(janitor).Error; err != nil {
+		return nil, err
+	}
+	return janitor, nil
+}
+
+// targetJanitorAllowed limits target sweeps to one node: the raft leader,
+// or this node when it is not clustered.
+func (s *Service) targetJanitorAllowed() bool {
+	if s.Cluster == nil || s.Cluster.Raft == nil {
+		return true
+	}
+	return s.Cluster.Raft.State() == raft.Leader
+}
+
+// ScanStaleDatasets lists leftover datasets on this node and, when enabled,
+// on every backup target, and decides which of them are safe to destroy.
+// Listing failures are reported per location rather than aborting the scan.
+func (s *Service) ScanStaleDatasets(ctx context.Context) (*StaleDatasetReport, error) {
+	janitor, err := s.GetStaleDatasetJanitor()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	report := &StaleDatasetReport{
+		ScannedAt:   now,
+		MinAgeHours: janitor.MinAgeHours,
+		Datasets:    []StaleDataset{},
+		Errors:      []string{},
+	}
+
+	if output, err := janitorLocalZFSList(ctx); err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("local: %v", err))
+	} else {
+		for _, entry := range parseStaleDatasets(output, 0, "") {
+			if entry.Kind == staleDatasetKindRestoring {
+				entry.Base = s.restoreStagingDestination(entry.Dataset)
+			}
+			report.Datasets = append(report.Datasets, entry)
+		}
+	}
+
+	if janitor.IncludeTargets && s.targetJanitorAllowed() {
+		var targets []clusterModels.BackupTarget
+		if err := s.DB.Where("enabled = ?", true).Order("id ASC").Find(&targets).Error; err != nil {
+			return nil, fmt.Errorf("failed_to_list_backup_targets: %w", err)
+		}
+		for i := range targets {
+			target := &targets[i]
+			if err := s.ensureBackupTargetSSHKeyMaterialized(target); err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", target.Name, err))
+				continue
+			}
+			output, err := s.runTargetZFSList(ctx, target, "-t", "filesystem,volume", "-r", "-Hp", "-o", "name,creation,used", target.BackupRoot)
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", target.Name, err))
+				continue
+			}
+			report.Datasets = append(report.Datasets, parseStaleDatasets(output, target.ID, target.Name)...)
+		}
+	}
+
+	minAge := time.Duration(janitor.MinAgeHours) * time.Hour
+	for i := range report.Datasets {
+		entry := &report.Datasets[i]
+		reason, err := s.staleDatasetKeepReason(entry, now, minAge)
+		if err != nil {
+			return nil, err
+		}
+		entry.KeepReason = reason
+		entry.Eligible = reason == ""
+	}
+
+	return report, nil
+}
+
+func (s *Service) staleDatasetKeepReason(entry *StaleDataset, now time.Time, minAge time.Duration) (string, error) {
+	if entry.CreatedAt.IsZero() || now.Sub(entry.CreatedAt) < minAge {
+		return staleKeepReasonTooRecent, nil
+	}
+
+	if entry.TargetID == 0 {
+		acquired, _, roots := s.acquireDatasetOperations([]string{entry.Base})
+		if !acquired {
+			return staleKeepReasonOperationRunning, nil
+		}
+		s.releaseDatasetOperations(roots)
+
+		var pending int64
+		if err := s.DB.Model(&clusterModels.RestorePromotion{}).
+			Where("(restore_path = ? OR destination = ?) AND phase IN ?", entry.Dataset, entry.Base, []string{
+				clusterModels.RestorePromotionPhasePending,
+				clusterModels.RestorePromotionPhaseArchived,
+			}).
+			Count(&pending).Error; err != nil {
+			return "", fmt.Errorf("restore_promotion_journal_check_failed: %w", err)
+		}
+		if pending > 0 {
+			return staleKeepReasonPromotionPending, nil
+		}
+
+		var journaled int64
+		if err := s.DB.Model(&taskModels.JournalEntry{}).
+			Where("dataset = ? OR dataset = ?", entry.Base, entry.Dataset).
+			Count(&journaled).Error; err != nil {
+			return "", fmt.Errorf("task_journal_check_failed: %w", err)
+		}
+		if journaled > 0 {
+			return staleKeepReasonJournalEntry, nil
+		}
+		return "", nil
+	}
+
+	var journaled int64
+	if err := s.DB.Model(&taskModels.JournalEntry{}).
+		Where("target_id = ? AND (resume_dataset = ? OR resume_dataset LIKE ?)", entry.TargetID, entry.Base, entry.Base+"/%").
+		Count(&journaled).Error; err != nil {
+		return "", fmt.Errorf("task_journal_check_failed: %w", err)
+	}
+	if journaled > 0 {
+		return staleKeepReasonJournalEntry, nil
+	}
+
+	if s.Cluster != nil {
+		running, err := s.Cluster.RunningJobIDsForTarget(entry.TargetID)
+		if err != nil {
+			return "", fmt.Errorf("failed_to_check_running_jobs: %w", err)
+		}
+		if len(running) > 0 {
+			return staleKeepReasonBackupRunning, nil
+		}
+	}
+	return "", nil
+}
+
+// CleanupStaleDatasets destroys the given leftovers after re-scanning. Only
+// datasets the fresh scan still marks eligible are touched; an empty list
+// means every eligible dataset.
+func (s *Service) CleanupStaleDatasets(ctx context.Context, refs []clusterServiceInterfaces.StaleDatasetRef) (*StaleDatasetCleanupResult, error) {
+	report, err := s.ScanStaleDatasets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &StaleDatasetCleanupResult{
+		Destroyed: []StaleDataset{},
+		Skipped:   []StaleDataset{},
+	}
+
+	byKey := make(map[string]StaleDataset, len(report.Datasets))
+	for _, entry := range report.Datasets {
+		byKey[staleDatasetKey(entry.TargetID, entry.Dataset)] = entry
+	}
+
+	var selected []StaleDataset
+	if len(refs) == 0 {
+		for _, entry := range report.Datasets {
+			if entry.Eligible {
+				selected = append(selected, entry)
+			}
+		}
+	} else {
+		for _, ref := range refs {
+			entry, ok := byKey[staleDatasetKey(ref.TargetID, normalizeDatasetPath(ref.Dataset))]
+			if !ok {
+				result.Skipped = append(result.Skipped, StaleDataset{
+					TargetID:   ref.TargetID,
+					Dataset:    normalizeDatasetPath(ref.Dataset),
+					KeepReason: "not_a_stale_dataset",
+				})
+				continue
+			}
+			if !entry.Eligible {
+				result.Skipped = append(result.Skipped, entry)
+				continue
+			}
+			selected = append(selected, entry)
+		}
+	}
+
+	targets := map[uint]*clusterModels.BackupTarget{}
+	for _, entry := range selected {
+		if err := s.destroyStaleDataset(ctx, entry, targets); err != nil {
+			logger.L.Warn().
+				Err(err).
+				Uint("target_id", entry.TargetID).
+				Str("dataset", entry.Dataset).
+				Msg("stale_dataset_destroy_failed")
+			entry.Eligible = false
+			entry.KeepReason = "destroy_failed: " + err.Error()
+			result.Skipped = append(result.Skipped, entry)
+			continue
+		}
+		result.Destroyed = append(result.Destroyed, entry)
+		result.Reclaimed += entry.Used
+	}
+
+	return result, nil
+}
+
+func staleDatasetKey(targetID uint, dataset string) string {
+	return strconv.FormatUint(uint64(targetID), 10) + ":" + dataset
+}
+
+func (s *Service) destroyStaleDataset(ctx context.Context, entry StaleDataset, targets map[uint]*clusterModels.BackupTarget) error {
+	if entry.TargetID == 0 {
+		if entry.Kind == staleDatasetKindRestoring {
+			return s.DestroyStaleRestoreDataset(ctx, entry.Dataset)
+		}
+
+		acquired, holder, roots := s.acquireDatasetOperations([]string{entry.Base})
+		if !acquired {
+			return fmt.Errorf("restore_in_progress_for_dataset: %s", holder)
+		}
+		defer s.releaseDatasetOperations(roots)
+		return s.destroyLocalDataset(ctx, entry.Dataset, true)
+	}
+
+	target, ok := targets[entry.TargetID]
+	if !ok {
+		loaded, err := s.getRestoreTarget(entry.TargetID)
+		if err != nil {
+			return err
+		}
+		target = &loaded
+		targets[entry.TargetID] = target
+	}
+	if !datasetWithinRoot(target.BackupRoot, entry.Dataset) {
+		return fmt.Errorf("remote_dataset_outside_backup_root")
+	}
+
+	sshArgs := s.buildSSHArgs(target)
+	sshArgs = append(sshArgs, target.SSHHost, "zfs", "destroy", "-r", entry.Dataset)
+	output, err := utils.RunCommandWithContext(ctx, "ssh", sshArgs...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", strings.TrimSpace(output), err)
+	}
+	return nil
+}
+
+// runStaleDatasetJanitor is the periodic sweep. It only destroys when the
+// node has opted into auto cleanup.
+func (s *Service) runStaleDatasetJanitor(ctx context.Context) {
+	janitor, err := s.GetStaleDatasetJanitor()
+	if err != nil || janitor.ID == 0 || !janitor.AutoCleanup {
+		return
+	}
+
+	now := time.Now().UTC()
+	if janitor.LastRunAt != nil && now.Sub(*janitor.LastRunAt) < time.Hour {
+		return
+	}
+
+	result, err := s.CleanupStaleDatasets(ctx, nil)
+	updates := map[string]any{"last_run_at": now, "last_error": ""}
+	if err != nil {
+		updates["last_error"] = err.Error()
+		logger.L.Warn().Err(err).Msg("stale_dataset_janitor_failed")
+	} else {
+		updates["last_destroyed"] = len(result.Destroyed)
+		if len(result.Destroyed) > 0 {
+			logger.L.Info().
+				Int("destroyed", len(result.Destroyed)).
+				Uint64("reclaimed", result.Reclaimed).
+				Msg("stale_dataset_janitor_destroyed_datasets")
+		}
+	}
+
+	if err := s.DB.Model(&clusterModels.StaleDatasetJanitor{}).Where("id = ?", janitor.ID).Updates(updates).Error; err != nil {
+		logger.L.Warn().Err(err).Msg("stale_dataset_janitor_state_update_failed")
+	}
+}
@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+
+package zelta
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+)
+
+func TestClassifyStaleDataset(t *testing.T) {
+	tests := []struct {
+		dataset string
+		kind    string
+		base    string
+	}{
+		{dataset: "zroot/sylve/jails/105.restoring", kind: staleDatasetKindRestoring, base: "zroot/sylve/jails/105"},
+		{dataset: "zroot/sylve/virtual-machines/7.pre_sylve_1712345678", kind: staleDatasetKindPreSylve, base: "zroot/sylve/virtual-machines/7"},
+		{dataset: "tank/data.pre_sylve_x/child", kind: "", base: ""},
+		{dataset: "tank/.restoring"},
+		{dataset: "tank/data.pre_other"},
+		{dataset: "tank/data"},
+	}
+
+	for _, tc := range tests {
+		kind, base := classifyStaleDataset(tc.dataset)
+		if kind != tc.kind || base != tc.base {
+			t.Fatalf("%s: got kind=%q base=%q", tc.dataset, kind, base)
+		}
+	}
+}
+
+func TestParseStaleDatasetsSkipsChildren(t *testing.T) {
+	output := "zroot/sylve/jails/105.restoring\t1700000000\t4096\n" +
+		"zroot/sylve/jails/105.restoring/data.restoring\t1700000000\t1024\n" +
+		"zroot/sylve/jails/105\t1600000000\t8192\n" +
+		"zroot/sylve/virtual-machines/7.pre_sylve_abc\t1700000100\t2048\n"
+
+	found := parseStaleDatasets(output, 3, "offsite")
+	if len(found) != 2 {
+		t.Fatalf("expected two leftovers, got %+v", found)
+	}
+	if found[0].Dataset != "zroot/sylve/jails/105.restoring" || found[0].Used != 4096 || found[0].TargetID != 3 {
+		t.Fatalf("unexpected first entry %+v", found[0])
+	}
+	if !found[0].CreatedAt.Equal(time.Unix(1700000000, 0).UTC()) {
+		t.Fatalf("unexpected creation time %v", found[0].CreatedAt)
+	}
+	if found[1].Kind != staleDatasetKindPreSylve || found[1].TargetName != "offsite" {
+		t.Fatalf("unexpected second entry %+v", found[1])
+	}
+}
+
+func TestScanStaleDatasetsKeepsReferencedAndRecent(t *testing.T) {
+	db := newZeltaServiceTestDB(t,
+		&clusterModels.BackupTarget{},
+		&clusterModels.StaleDatasetJanitor{},
+		&clusterModels.RestorePromotion{},
+		&taskModels.JournalEntry{},
+	)
+	svc := newTestZeltaService(db)
+
+	old := time.Now().Add(-100 * time.Hour).Unix()
+	recent := time.Now().Add(-time.Hour).Unix()
+	prevList := janitorLocalZFSList
+	janitorLocalZFSList = func(context.Context) (string, error) {
+		return fmt.Sprintf(
+			"zroot/sylve/jails/1.restoring\t%d\t10\n"+
+				"zroot/sylve/jails/2.restoring\t%d\t10\n"+
+				"zroot/sylve/jails/3.restoring\t%d\t10\n"+
+				"zroot/sylve/jails/4.pre_sylve_x\t%d\t10\n"+
+				"zroot/sylve/jails/5.restoring\t%d\t10\n",
+			old, old, old, old, recent,
+		), nil
+	}
+	t.Cleanup(func() { janitorLocalZFSList = prevList })
+
+	if err := db.Create(&clusterModels.RestorePromotion{
+		RestorePath: "zroot/sylve/jails/2.restoring",
+		Destination: "zroot/sylve/jails/2",
+		Phase:       clusterModels.RestorePromotionPhasePending,
+	}).Error; err != nil {
+		t.Fatalf("failed to seed promotion: %v", err)
+	}
+	if err := db.Create(&taskModels.JournalEntry{Kind: taskModels.JournalKindRestoreJob, Dataset: "zroot/sylve/jails/3"}).Error; err != nil {
+		t.Fatalf("failed to seed journal: %v", err)
+	}
+
+	report, err := svc.ScanStaleDatasets(context.Background())
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+	if report.MinAgeHours != clusterModels.DefaultStaleDatasetMinAgeHours || len(report.Datasets) != 5 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	want := map[string]string{
+		"zroot/sylve/jails/1.restoring":   "",
+		"zroot/sylve/jails/2.restoring":   staleKeepReasonPromotionPending,
+		"zroot/sylve/jails/3.restoring":   staleKeepReasonJournalEntry,
+		"zroot/sylve/jails/4.pre_sylve_x": "",
+		"zroot/sylve/jails/5.restoring":   staleKeepReasonTooRecent,
+	}
+	for _, entry := range report.Datasets {
+		if entry.KeepReason != want[entry.Dataset] || entry.Eligible != (want[entry.Dataset] == "") {
+			t.Fatalf("%s: got eligible=%v reason=%q", entry.Dataset, entry.Eligible, entry.KeepReason)
+		}
+	}
+
+	acquired, _, roots := svc.acquireDatasetOperations([]string{"zroot/sylve/jails/1"})
+	if !acquired {
+		t.Fatal("expected to acquire dataset lock")
+	}
+	defer svc.releaseDatasetOperations(roots)
+
+	report, err = svc.ScanStaleDatasets(context.Background())
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+	for _, entry := range report.Datasets {
+		if entry.Dataset == "zroot/sylve/jails/1.restoring" && entry.KeepReason != staleKeepReasonOperationRunning {
+			t.Fatalf("expected running operation to keep dataset, got %+v", entry)
+		}
+	}
+}
+
+func TestConfigureStaleDatasetJanitor(t *testing.T) {
+	db := newZeltaServiceTestDB(t, &clusterModels.StaleDatasetJanitor{})
+	svc := newTestZeltaService(db)
+
+	auto := true
+	hours := 12
+	janitor, err := svc.ConfigureStaleDatasetJanitor(clusterServiceInterfaces.StaleDatasetJanitorReq{
+		AutoCleanup: &auto,
+		MinAgeHours: &hours,
+	})
+	if err != nil {
+		t.Fatalf("configure failed: %v", err)
+	}
+	if !janitor.AutoCleanup || janitor.MinAgeHours != 12 || !janitor.IncludeTargets || janitor.ID == 0 {
+		t.Fatalf("unexpected janitor %+v", janitor)
+	}
+
+	zero := 0
+	if _, err := svc.ConfigureStaleDatasetJanitor(clusterServiceInterfaces.StaleDatasetJanitorReq{MinAgeHours: &zero}); err == nil {
+		t.Fatal("expected invalid min age to be rejected")
+	}
+
+	var count int64
+	db.Model(&clusterModels.StaleDatasetJanitor{}).Count(&count)
+	if count != 1 {
+		t.Fatalf("expected a single janitor row, got %d", count)
+	}
+}
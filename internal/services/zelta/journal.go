@@ -0,0 +1,331 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/db"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"gorm.io/gorm"
+)
+
+const journalResumeCheckTimeout = 15 * time.Second
+
+// journalQueueNames are the queue jobs whose in-flight messages the journal
+// settles itself instead of letting goqite redeliver them blindly.
+var journalQueueNames = []string{
+	backupJobQueueName,
+	restoreJobQueueName,
+	restoreFromTargetQueueName,
+}
+
+// journalRemoteResumable reports whether the dataset on target holds a
+// partial receive zelta can resume.
+var journalRemoteResumable = func(ctx context.Context, s *Service, target *clusterModels.BackupTarget, dataset string) (bool, error) {
+	output, err := s.runTargetSSH(ctx, target, "zfs", "get", "-H", "-r", "-o", "value", "receive_resume_token", dataset)
+	if err != nil {
+		return false, err
+	}
+	for _, line := range strings.Split(output, "\n") {
+		if value := strings.TrimSpace(line); value != "" && value != "-" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type journalEntry struct {
+	db *gorm.DB
+	id uint
+}
+
+// journalBegin records an operation as in flight. The returned handle is
+// nil-safe so journaling never blocks the operation itself.
+func (s *Service) journalBegin(kind string, refID, targetID uint, dataset string) *journalEntry {
+	if s == nil || s.DB == nil {
+		return nil
+	}
+
+	entry := taskModels.JournalEntry{
+		Kind:      kind,
+		RefID:     refID,
+		TargetID:  targetID,
+		Phase:     taskModels.JournalPhasePreflight,
+		Dataset:   normalizeDatasetPath(dataset),
+		StartedAt: time.Now().UTC(),
+	}
+	if err := s.DB.Create(&entry).Error; err != nil {
+		logger.L.Warn().Err(err).Str("kind", kind).Uint("ref_id", refID).Msg("task_journal_begin_failed")
+		return nil
+	}
+	return &journalEntry{db: s.DB, id: entry.ID}
+}
+
+func (j *journalEntry) update(updates map[string]any) {
+	if j == nil {
+		return
+	}
+	if err := j.db.Model(&taskModels.JournalEntry{}).Where("id = ?", j.id).Updates(updates).Error; err != nil {
+		logger.L.Warn().Err(err).Uint("journal_id", j.id).Msg("task_journal_update_failed")
+	}
+}
+
+func (j *journalEntry) phase(phase, dataset string) {
+	j.update(map[string]any{
+		"phase":          phase,
+		"dataset":        normalizeDatasetPath(dataset),
+		"resume_dataset": "",
+	})
+}
+
+// transfer marks a send from dataset into resumeDataset on the target.
+func (j *journalEntry) transfer(dataset, resumeDataset string) {
+	j.update(map[string]any{
+		"phase":          taskModels.JournalPhaseTransfer,
+		"dataset":        normalizeDatasetPath(dataset),
+		"resume_dataset": normalizeDatasetPath(resumeDataset),
+	})
+}
+
+func (j *journalEntry) done() {
+	if j == nil {
+		return
+	}
+	if err := j.db.Delete(&taskModels.JournalEntry{}, j.id).Error; err != nil {
+		logger.L.Warn().Err(err).Uint("journal_id", j.id).Msg("task_journal_finish_failed")
+	}
+}
+
+func journalInterruptReason(entry taskModels.JournalEntry) string {
+	reason := "interrupted_by_restart: phase=" + entry.Phase
+	if entry.Dataset != "" {
+		reason += " dataset=" + entry.Dataset
+	}
+	if entry.ResumeDataset != "" {
+		reason += " target_dataset=" + entry.ResumeDataset
+	}
+	switch entry.Kind {
+	case taskModels.JournalKindRestoreJob, taskModels.JournalKindRestoreFromTarget:
+		if entry.Phase != taskModels.JournalPhasePreflight && entry.Dataset != "" {
+			reason += " staging=" + entry.Dataset + ".restoring"
+		}
+	}
+	return reason
+}
+
+// RecoverTaskJournal settles operations a previous process left in flight.
+// Queue messages owned by a journal row are dropped and settled from the
+// journal; other in-flight messages are requeued. Interrupted backup
+// transfers are checked for a resume token on the target in the background,
+// so an unreachable target cannot hold up startup; everything else fails
+// with the phase it was in. It must run before the queue starts.
+func (s *Service) RecoverTaskJournal(ctx context.Context) error {
+	if s == nil || s.DB == nil {
+		return nil
+	}
+
+	entries, err := s.interruptedJournalEntries()
+	if err != nil {
+		return err
+	}
+
+	dropped, requeued, err := db.SettleInFlightQueueMessages(ctx, func(name string, body []byte) bool {
+		return journalOwnsQueueMessage(entries, name, body)
+	}, journalQueueNames...)
+	if err != nil {
+		return fmt.Errorf("task_journal_queue_cleanup_failed: %w", err)
+	}
+	if dropped > 0 || requeued > 0 {
+		logger.L.Info().
+			Int("dropped", dropped).
+			Int("requeued", requeued).
+			Msg("task_journal_settled_in_flight_queue_messages")
+	}
+
+	if pending := s.recoverJournalEntries(entries); len(pending) > 0 {
+		go s.recoverJournalBackups(context.WithoutCancel(ctx), pending)
+	}
+	return nil
+}
+
+// interruptedJournalEntries returns the entries started before this process.
+func (s *Service) interruptedJournalEntries() ([]taskModels.JournalEntry, error) {
+	startedAt := s.startedAt
+	if startedAt.IsZero() {
+		startedAt = time.Now().UTC()
+	}
+
+	var entries []taskModels.JournalEntry
+	if err := s.DB.Where("started_at < ?", startedAt).Order("id ASC").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// journalOwnsQueueMessage reports whether a queued payload belongs to one of
+// the journaled operations.
+func journalOwnsQueueMessage(entries []taskModels.JournalEntry, name string, body []byte) bool {
+	switch name {
+	case backupJobQueueName:
+		var payload backupJobPayload
+		if json.Unmarshal(body, &payload) != nil || payload.DryRun {
+			return false
+		}
+		return slices.ContainsFunc(entries, func(entry taskModels.JournalEntry) bool {
+			return entry.Kind == taskModels.JournalKindBackupJob && entry.RefID == payload.JobID
+		})
+	case restoreJobQueueName:
+		var payload restoreJobPayload
+		if json.Unmarshal(body, &payload) != nil {
+			return false
+		}
+		return slices.ContainsFunc(entries, func(entry taskModels.JournalEntry) bool {
+			return entry.Kind == taskModels.JournalKindRestoreJob && entry.RefID == payload.JobID
+		})
+	case restoreFromTargetQueueName:
+		var payload restoreFromTargetPayload
+		if json.Unmarshal(body, &payload) != nil {
+			return false
+		}
+		dataset := normalizeDatasetPath(payload.DestinationDataset)
+		return slices.ContainsFunc(entries, func(entry taskModels.JournalEntry) bool {
+			return entry.Kind == taskModels.JournalKindRestoreFromTarget &&
+				entry.TargetID == payload.TargetID &&
+				entry.Dataset == dataset
+		})
+	}
+	return false
+}
+
+// recoverJournalEntries settles every entry that needs no remote check and
+// returns the backup transfers that may still be resumable.
+func (s *Service) recoverJournalEntries(entries []taskModels.JournalEntry) []taskModels.JournalEntry {
+	var pending []taskModels.JournalEntry
+	for _, entry := range entries {
+		if journalResumeCandidate(entry) {
+			pending = append(pending, entry)
+			continue
+		}
+		s.recoverJournalEntry(entry, false)
+		s.deleteJournalEntry(entry.ID)
+	}
+	return pending
+}
+
+func (s *Service) recoverJournalBackups(ctx context.Context, entries []taskModels.JournalEntry) {
+	for _, entry := range entries {
+		s.recoverJournalEntry(entry, s.resumeJournalBackup(ctx, entry))
+		s.deleteJournalEntry(entry.ID)
+	}
+}
+
+func (s *Service) deleteJournalEntry(id uint) {
+	if err := s.DB.Delete(&taskModels.JournalEntry{}, id).Error; err != nil {
+		logger.L.Warn().Err(err).Uint("journal_id", id).Msg("task_journal_entry_delete_failed")
+	}
+}
+
+func (s *Service) recoverJournalEntry(entry taskModels.JournalEntry, resumed bool) {
+	reason := journalInterruptReason(entry)
+	events := s.DB.Model(&clusterModels.BackupEvent{}).
+		Where("status = ? AND started_at >= ?", "running", entry.StartedAt.Add(-time.Second))
+
+	switch entry.Kind {
+	case taskModels.JournalKindBackupJob:
+		if resumed {
+			reason = strings.Replace(reason, "interrupted_by_restart", "interrupted_by_restart_resuming", 1)
+		}
+		s.interruptJournalEvents(events.Where("job_id = ? AND mode <> ?", entry.RefID, "restore"), reason)
+		if !resumed {
+			var job clusterModels.BackupJob
+			if err := s.DB.First(&job, entry.RefID).Error; err == nil {
+				s.updateBackupJobResult(&job, errors.New(reason), job.Encrypted)
+			}
+		}
+	case taskModels.JournalKindRestoreJob:
+		s.interruptJournalEvents(events.Where("job_id = ? AND mode = ?", entry.RefID, "restore"), reason)
+	case taskModels.JournalKindRestoreFromTarget:
+		s.interruptJournalEvents(
+			events.Where("mode = ? AND (target_endpoint = ? OR target_endpoint LIKE ?)", "restore", entry.Dataset, entry.Dataset+"/%"),
+			reason,
+		)
+	case taskModels.JournalKindNodeStandby:
+		if err := s.DB.Model(&clusterModels.NodeStandby{}).
+			Where("id = ? AND last_status = ?", entry.RefID, "running").
+			Updates(map[string]any{"last_status": "failed", "last_error": reason}).Error; err != nil {
+			logger.L.Warn().Err(err).Uint("standby_id", entry.RefID).Msg("task_journal_standby_update_failed")
+		}
+	}
+
+	logger.L.Warn().
+		Str("kind", entry.Kind).
+		Uint("ref_id", entry.RefID).
+		Str("reason", reason).
+		Msg("task_journal_recovered_interrupted_operation")
+}
+
+func (s *Service) interruptJournalEvents(query *gorm.DB, reason string) {
+	if err := query.Updates(map[string]any{
+		"status":       "interrupted",
+		"error":        reason,
+		"completed_at": time.Now().UTC(),
+	}).Error; err != nil {
+		logger.L.Warn().Err(err).Msg("task_journal_event_interrupt_failed")
+	}
+}
+
+// journalResumeCandidate reports whether entry is a backup transfer that a
+// resume token on the target could continue.
+func journalResumeCandidate(entry taskModels.JournalEntry) bool {
+	return entry.Kind == taskModels.JournalKindBackupJob &&
+		entry.Phase == taskModels.JournalPhaseTransfer &&
+		entry.ResumeDataset != "" &&
+		entry.TargetID != 0
+}
+
+func (s *Service) resumeJournalBackup(ctx context.Context, entry taskModels.JournalEntry) bool {
+	if !journalResumeCandidate(entry) {
+		return false
+	}
+
+	var target clusterModels.BackupTarget
+	if err := s.DB.First(&target, entry.TargetID).Error; err != nil || !target.Enabled {
+		return false
+	}
+	if err := s.ensureBackupTargetSSHKeyMaterialized(&target); err != nil {
+		logger.L.Warn().Err(err).Uint("target_id", target.ID).Msg("task_journal_resume_ssh_key_failed")
+		return false
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, journalResumeCheckTimeout)
+	defer cancel()
+	resumable, err := journalRemoteResumable(checkCtx, s, &target, entry.ResumeDataset)
+	if err != nil {
+		logger.L.Warn().Err(err).Str("dataset", entry.ResumeDataset).Msg("task_journal_resume_token_check_failed")
+		return false
+	}
+	if !resumable {
+		return false
+	}
+
+	if err := s.EnqueueBackupJob(ctx, entry.RefID, false); err != nil {
+		logger.L.Warn().Err(err).Uint("job_id", entry.RefID).Msg("task_journal_resume_enqueue_failed")
+		return false
+	}
+	return true
+}
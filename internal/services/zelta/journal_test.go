@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+
+package zelta
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
+)
+
+func TestJournalBeginAndDoneRemovesEntry(t *testing.T) {
+	db := newZeltaServiceTestDB(t, &taskModels.JournalEntry{})
+	svc := newTestZeltaService(db)
+
+	journal := svc.journalBegin(taskModels.JournalKindBackupJob, 7, 3, "zroot/data")
+	if journal == nil {
+		t.Fatal("expected journal entry")
+	}
+	journal.transfer("zroot/data", "backup/j-7/data")
+
+	var entry taskModels.JournalEntry
+	if err := db.First(&entry, journal.id).Error; err != nil {
+		t.Fatalf("load entry: %v", err)
+	}
+	if entry.Phase != taskModels.JournalPhaseTransfer || entry.ResumeDataset != "backup/j-7/data" {
+		t.Fatalf("unexpected entry: %#v", entry)
+	}
+
+	journal.done()
+
+	var count int64
+	db.Model(&taskModels.JournalEntry{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("expected journal to be empty, got %d entries", count)
+	}
+
+	var nilJournal *journalEntry
+	nilJournal.transfer("a", "b")
+	nilJournal.done()
+}
+
+func TestJournalInterruptReason(t *testing.T) {
+	reason := journalInterruptReason(taskModels.JournalEntry{
+		Kind:    taskModels.JournalKindRestoreJob,
+		Phase:   taskModels.JournalPhaseTransfer,
+		Dataset: "zroot/data",
+	})
+	if reason != "interrupted_by_restart: phase=transfer dataset=zroot/data staging=zroot/data.restoring" {
+		t.Fatalf("unexpected reason: %q", reason)
+	}
+
+	reason = journalInterruptReason(taskModels.JournalEntry{
+		Kind:          taskModels.JournalKindBackupJob,
+		Phase:         taskModels.JournalPhaseTransfer,
+		Dataset:       "zroot/data",
+		ResumeDataset: "backup/data",
+	})
+	if reason != "interrupted_by_restart: phase=transfer dataset=zroot/data target_dataset=backup/data" {
+		t.Fatalf("unexpected reason: %q", reason)
+	}
+}
+
+func TestRecoverJournalEntriesFailsInterruptedOperations(t *testing.T) {
+	db := newZeltaServiceTestDB(t,
+		&taskModels.JournalEntry{},
+		&clusterModels.BackupTarget{},
+		&clusterModels.BackupJob{},
+		&clusterModels.BackupEvent{},
+		&clusterModels.NodeStandby{},
+	)
+	svc := newTestZeltaService(db)
+
+	started := time.Now().UTC().Add(-time.Minute)
+	svc.startedAt = time.Now().UTC()
+
+	jobID := uint(4)
+	otherJobID := uint(5)
+	events := []clusterModels.BackupEvent{
+		{JobID: &jobID, Mode: "restore", Status: "running", StartedAt: started},
+		{JobID: &otherJobID, Mode: "restore", Status: "running", StartedAt: started},
+	}
+	if err := db.Create(&events).Error; err != nil {
+		t.Fatalf("seed events: %v", err)
+	}
+	standby := clusterModels.NodeStandby{TargetID: 1, LastStatus: "running"}
+	if err := db.Create(&standby).Error; err != nil {
+		t.Fatalf("seed standby: %v", err)
+	}
+	entries := []taskModels.JournalEntry{
+		{Kind: taskModels.JournalKindRestoreJob, RefID: jobID, Phase: taskModels.JournalPhasePromote, Dataset: "zroot/data", StartedAt: started},
+		{Kind: taskModels.JournalKindNodeStandby, RefID: standby.ID, Phase: taskModels.JournalPhasePreflight, StartedAt: started},
+		{Kind: taskModels.JournalKindRestoreJob, RefID: otherJobID, Phase: taskModels.JournalPhasePreflight, StartedAt: svc.startedAt.Add(time.Second)},
+	}
+	if err := db.Create(&entries).Error; err != nil {
+		t.Fatalf("seed journal: %v", err)
+	}
+
+	interrupted, err := svc.interruptedJournalEntries()
+	if err != nil {
+		t.Fatalf("interruptedJournalEntries: %v", err)
+	}
+	if pending := svc.recoverJournalEntries(interrupted); len(pending) != 0 {
+		t.Fatalf("expected no resumable backups, got %#v", pending)
+	}
+
+	var restored clusterModels.BackupEvent
+	db.First(&restored, events[0].ID)
+	if restored.Status != "interrupted" || !strings.Contains(restored.Error, "phase=promote") || restored.CompletedAt == nil {
+		t.Fatalf("expected restore event to be interrupted, got %#v", restored)
+	}
+
+	var untouched clusterModels.BackupEvent
+	db.First(&untouched, events[1].ID)
+	if untouched.Status != "running" {
+		t.Fatalf("entry from the current process must not be recovered, got %#v", untouched)
+	}
+
+	var gotStandby clusterModels.NodeStandby
+	db.First(&gotStandby, standby.ID)
+	if gotStandby.LastStatus != "failed" || !strings.HasPrefix(gotStandby.LastError, "interrupted_by_restart") {
+		t.Fatalf("expected standby to be failed, got %#v", gotStandby)
+	}
+
+	var remaining int64
+	db.Model(&taskModels.JournalEntry{}).Count(&remaining)
+	if remaining != 1 {
+		t.Fatalf("expected only the current entry to remain, got %d", remaining)
+	}
+}
+
+func TestJournalOwnsQueueMessage(t *testing.T) {
+	entries := []taskModels.JournalEntry{
+		{Kind: taskModels.JournalKindBackupJob, RefID: 7},
+		{Kind: taskModels.JournalKindRestoreFromTarget, TargetID: 2, Dataset: "zroot/restored"},
+	}
+	body := func(payload any) []byte {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			t.Fatalf("marshal payload: %v", err)
+		}
+		return b
+	}
+
+	cases := []struct {
+		name    string
+		queue   string
+		payload any
+		want    bool
+	}{
+		{"journaled backup", backupJobQueueName, backupJobPayload{JobID: 7}, true},
+		{"backup dry run", backupJobQueueName, backupJobPayload{JobID: 7, DryRun: true}, false},
+		{"other backup", backupJobQueueName, backupJobPayload{JobID: 8}, false},
+		{"restore of journaled backup job", restoreJobQueueName, restoreJobPayload{JobID: 7}, false},
+		{"journaled target restore", restoreFromTargetQueueName, restoreFromTargetPayload{TargetID: 2, DestinationDataset: "zroot/restored/"}, true},
+		{"target restore elsewhere", restoreFromTargetQueueName, restoreFromTargetPayload{TargetID: 3, DestinationDataset: "zroot/restored"}, false},
+	}
+	for _, tc := range cases {
+		if got := journalOwnsQueueMessage(entries, tc.queue, body(tc.payload)); got != tc.want {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestRecoverJournalEntriesDefersResumableBackups(t *testing.T) {
+	db := newZeltaServiceTestDB(t, &taskModels.JournalEntry{}, &clusterModels.NodeStandby{})
+	svc := newTestZeltaService(db)
+
+	entries := []taskModels.JournalEntry{
+		{Kind: taskModels.JournalKindBackupJob, RefID: 1, TargetID: 1, Phase: taskModels.JournalPhaseTransfer, ResumeDataset: "backup/data"},
+		{Kind: taskModels.JournalKindNodeStandby, RefID: 2, Phase: taskModels.JournalPhasePreflight},
+	}
+	if err := db.Create(&entries).Error; err != nil {
+		t.Fatalf("seed journal: %v", err)
+	}
+
+	pending := svc.recoverJournalEntries(entries)
+	if len(pending) != 1 || pending[0].ID != entries[0].ID {
+		t.Fatalf("expected the backup transfer to be deferred, got %#v", pending)
+	}
+
+	var remaining []taskModels.JournalEntry
+	db.Find(&remaining)
+	if len(remaining) != 1 || remaining[0].ID != entries[0].ID {
+		t.Fatalf("expected the deferred entry to stay journaled until checked, got %#v", remaining)
+	}
+}
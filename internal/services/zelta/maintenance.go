@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"fmt"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/logger"
+)
+
+// activeMaintenanceWindows loads the windows open at now. A failed lookup
+// leaves the watchdog acting as it would without windows: a missed failover
+// costs more than an unexpected one.
+func (s *Service) activeMaintenanceWindows(now time.Time) []clusterModels.MaintenanceWindow {
+	if s.DB == nil {
+		return nil
+	}
+
+	windows, err := clusterModels.ListActiveMaintenanceWindows(s.DB, now)
+	if err != nil {
+		logger.L.Warn().Err(err).Msg("maintenance_window_lookup_failed")
+		return nil
+	}
+	return windows
+}
+
+// holdForMaintenance reports whether a window covers the policy, its guest or
+// one of nodeIDs, and records the action it held back. Self-fencing never
+// asks: it protects data, not the on-call.
+func (s *Service) holdForMaintenance(
+	windows []clusterModels.MaintenanceWindow,
+	policy *clusterModels.ReplicationPolicy,
+	nodeIDs []string,
+	action string,
+	detail string,
+	now time.Time,
+) bool {
+	if len(windows) == 0 || policy == nil {
+		return false
+	}
+
+	subjects := make([]clusterModels.MaintenanceSubject, 0, len(nodeIDs)+1)
+	subjects = append(subjects, clusterModels.MaintenanceSubject{
+		GuestType: policy.GuestType,
+		GuestID:   policy.GuestID,
+		PolicyID:  policy.ID,
+	})
+	for _, nodeID := range nodeIDs {
+		subjects = append(subjects, clusterModels.MaintenanceSubject{NodeID: nodeID})
+	}
+
+	var window *clusterModels.MaintenanceWindow
+	for _, subject := range subjects {
+		if window = clusterModels.MatchMaintenanceWindow(windows, subject, now); window != nil {
+			break
+		}
+	}
+	if window == nil {
+		return false
+	}
+
+	logger.L.Info().
+		Uint("policy_id", policy.ID).
+		Uint("window_id", window.ID).
+		Str("action", action).
+		Str("detail", detail).
+		Msg("replication_watchdog_action_held_for_maintenance")
+
+	if err := clusterModels.RecordMaintenanceSuppression(s.DB, clusterModels.MaintenanceSuppression{
+		WindowID: window.ID,
+		Action:   action,
+		Key:      fmt.Sprintf("policy:%d", policy.ID),
+		Title:    fmt.Sprintf("%s %d: %s held", policy.GuestType, policy.GuestID, action),
+		Detail:   detail,
+	}, now); err != nil {
+		logger.L.Warn().Err(err).Uint("window_id", window.ID).Msg("maintenance_suppression_record_failed")
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"testing"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+)
+
+func TestHoldForMaintenanceRecordsHeldWatchdogActions(t *testing.T) {
+	db := newZeltaServiceTestDB(t, &clusterModels.MaintenanceWindow{}, &clusterModels.MaintenanceSuppression{})
+	s := &Service{DB: db}
+	now := time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+	if err := db.Create(&[]clusterModels.MaintenanceWindow{
+		{ID: 1, Scope: clusterModels.MaintenanceScopeNode, NodeID: "node-b", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+		{ID: 2, Scope: clusterModels.MaintenanceScopeGuest, GuestType: clusterModels.ReplicationGuestTypeJail, GuestID: 30, StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+	}).Error; err != nil {
+		t.Fatalf("failed to seed windows: %v", err)
+	}
+	windows := s.activeMaintenanceWindows(now)
+	if len(windows) != 2 {
+		t.Fatalf("expected two active windows, got %+v", windows)
+	}
+
+	vm := &clusterModels.ReplicationPolicy{ID: 7, GuestType: clusterModels.ReplicationGuestTypeVM, GuestID: 104}
+	if s.holdForMaintenance(windows, vm, []string{"node-a"}, clusterModels.MaintenanceActionFailover, "owner_node_down", now) {
+		t.Fatal("expected failover on a node outside maintenance to proceed")
+	}
+	if !s.holdForMaintenance(windows, vm, []string{"node-a", "node-b"}, clusterModels.MaintenanceActionFailback, "auto_failback", now) {
+		t.Fatal("expected failback towards a node in maintenance to be held")
+	}
+
+	jail := &clusterModels.ReplicationPolicy{ID: 8, GuestType: clusterModels.ReplicationGuestTypeJail, GuestID: 30}
+	for i := 0; i < 2; i++ {
+		if !s.holdForMaintenance(windows, jail, []string{"node-a"}, clusterModels.MaintenanceActionCrashRestart, "guest_not_running", now) {
+			t.Fatal("expected the crash restart of a guest in maintenance to be held")
+		}
+	}
+	if s.holdForMaintenance(nil, jail, []string{"node-b"}, clusterModels.MaintenanceActionFailover, "owner_node_down", now) {
+		t.Fatal("expected no hold without active windows")
+	}
+
+	var audit []clusterModels.MaintenanceSuppression
+	db.Order("id ASC").Find(&audit)
+	if len(audit) != 2 {
+		t.Fatalf("expected two audit rows, got %+v", audit)
+	}
+	if audit[0].WindowID != 1 || audit[0].Action != clusterModels.MaintenanceActionFailback || audit[0].Key != "policy:7" {
+		t.Fatalf("unexpected failback audit %+v", audit[0])
+	}
+	if audit[1].WindowID != 2 || audit[1].Occurrences != 2 || audit[1].Title != "jail 30: crash_restart held" {
+		t.Fatalf("unexpected crash restart audit %+v", audit[1])
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/alchemillahq/gzfs"
 	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/pkg/utils"
 )
@@ -195,11 +196,11 @@ func (s *Service) ImportMigratedJailWithRoots(
 }
 
 func (s *Service) ValidateMigratedVMRoots(ctx context.Context, rid uint, roots []string) ([]string, error) {
-	return s.validateMigratedGuestRoots(ctx, "virtual-machines", rid, roots)
+	return s.validateMigratedGuestRoots(ctx, layout.VirtualMachinesName(), rid, roots)
 }
 
 func (s *Service) ValidateMigratedJailRoots(ctx context.Context, ctID uint, roots []string) ([]string, error) {
-	return s.validateMigratedGuestRoots(ctx, "jails", ctID, roots)
+	return s.validateMigratedGuestRoots(ctx, layout.JailsName(), ctID, roots)
 }
 
 func (s *Service) validateMigratedGuestRoots(
@@ -208,7 +209,7 @@ func (s *Service) validateMigratedGuestRoots(
 	guestID uint,
 	roots []string,
 ) ([]string, error) {
-	if guestID == 0 || (guestDir != "virtual-machines" && guestDir != "jails") {
+	if guestID == 0 || (guestDir != layout.VirtualMachinesName() && guestDir != layout.JailsName()) {
 		return nil, fmt.Errorf("migration_dataset_manifest_identity_invalid")
 	}
 	if s == nil || s.GZFS == nil || s.GZFS.ZFS == nil {
@@ -221,7 +222,7 @@ func (s *Service) validateMigratedGuestRoots(
 	for _, root := range roots {
 		root = strings.TrimSpace(root)
 		parts := strings.Split(root, "/")
-		if len(parts) != 4 || parts[0] == "" || parts[1] != "sylve" ||
+		if len(parts) != 4 || parts[0] == "" || parts[1] != layout.RootName() ||
 			parts[2] != guestDir || parts[3] != wantID || strings.Contains(root, "@") {
 			return nil, fmt.Errorf("migration_dataset_manifest_root_invalid: %s", root)
 		}
@@ -382,7 +383,7 @@ func isGeneratedMigrationSnapshotName(shortName string) bool {
 	if suffix == name {
 		return false
 	}
-	for _, phasePrefix := range []string{"initial-", "final-", "pre-migration-"} {
+	for _, phasePrefix := range []string{"initial-", "final-", "pre-migration-", "clone-"} {
 		timestamp := strings.TrimPrefix(suffix, phasePrefix)
 		if timestamp == suffix || timestamp == "" {
 			continue
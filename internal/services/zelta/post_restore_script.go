@@ -0,0 +1,404 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
+	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"gorm.io/gorm"
+)
+
+const (
+	postRestoreScriptDefaultTimeout = 5 * time.Minute
+	postRestoreScriptMaxTimeout     = time.Hour
+	postRestoreScriptMaxLength      = 64 << 10
+	// postRestoreScriptOutputLimit bounds how much of a script's output is
+	// kept on the restore event.
+	postRestoreScriptOutputLimit = 64 << 10
+
+	// postRestoreGuestAgentWait is how long a VM that was started for its
+	// script gets to bring its guest agent up.
+	postRestoreGuestAgentWait = 5 * time.Minute
+	postRestoreGuestAgentPoll = 5 * time.Second
+)
+
+// postRestoreScriptResult is the outcome of one script run, in the form it is
+// reported on the restore event.
+type postRestoreScriptResult struct {
+	ExitCode  int
+	TimedOut  bool
+	Truncated bool
+	Output    string
+	Duration  time.Duration
+	Err       error
+}
+
+func validatePostRestoreScriptReq(req clusterServiceInterfaces.PostRestoreScriptReq) (clusterModels.PostRestoreScript, error) {
+	script := clusterModels.PostRestoreScript{
+		GuestType:      strings.TrimSpace(req.GuestType),
+		GuestID:        req.GuestID,
+		Script:         req.Script,
+		Interpreter:    strings.TrimSpace(req.Interpreter),
+		TimeoutSeconds: req.TimeoutSeconds,
+		Enabled:        true,
+	}
+	if req.Enabled != nil {
+		script.Enabled = *req.Enabled
+	}
+
+	if script.GuestType != clusterModels.BackupJobModeJail && script.GuestType != clusterModels.BackupJobModeVM {
+		return script, fmt.Errorf("post_restore_script_guest_type_invalid")
+	}
+	if script.GuestID == 0 {
+		return script, fmt.Errorf("post_restore_script_guest_id_required")
+	}
+	if err := validatePostRestoreScriptBody(script.Script); err != nil {
+		return script, err
+	}
+	if script.Interpreter == "" {
+		script.Interpreter = clusterModels.PostRestoreScriptDefaultInterpreter
+	}
+	if strings.ContainsRune(script.Interpreter, 0) {
+		return script, fmt.Errorf("post_restore_script_interpreter_invalid")
+	}
+	if script.TimeoutSeconds < 0 || time.Duration(script.TimeoutSeconds)*time.Second > postRestoreScriptMaxTimeout {
+		return script, fmt.Errorf("post_restore_script_timeout_invalid")
+	}
+
+	return script, nil
+}
+
+func validatePostRestoreScriptBody(script string) error {
+	if strings.TrimSpace(script) == "" {
+		return fmt.Errorf("post_restore_script_required")
+	}
+	if len(script) > postRestoreScriptMaxLength {
+		return fmt.Errorf("post_restore_script_too_long")
+	}
+	if strings.ContainsRune(script, 0) {
+		return fmt.Errorf("post_restore_script_invalid")
+	}
+	return nil
+}
+
+func (s *Service) ListPostRestoreScripts(ctx context.Context) ([]clusterModels.PostRestoreScript, error) {
+	var scripts []clusterModels.PostRestoreScript
+	if err := s.DB.WithContext(ctx).Order("guest_type ASC, guest_id ASC").Find(&scripts).Error; err != nil {
+		return nil, err
+	}
+	return scripts, nil
+}
+
+// SavePostRestoreScript creates or replaces the script of a guest; a guest
+// has at most one.
+func (s *Service) SavePostRestoreScript(
+	ctx context.Context,
+	req clusterServiceInterfaces.PostRestoreScriptReq,
+) (*clusterModels.PostRestoreScript, error) {
+	script, err := validatePostRestoreScriptReq(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var existing clusterModels.PostRestoreScript
+	err = s.DB.WithContext(ctx).
+		Where("guest_type = ? AND guest_id = ?", script.GuestType, script.GuestID).
+		First(&existing).Error
+	switch {
+	case err == nil:
+		script.ID = existing.ID
+		script.CreatedAt = existing.CreatedAt
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, err
+	}
+
+	if err := s.DB.WithContext(ctx).Save(&script).Error; err != nil {
+		return nil, err
+	}
+	return &script, nil
+}
+
+func (s *Service) DeletePostRestoreScript(ctx context.Context, id uint) error {
+	result := s.DB.WithContext(ctx).Delete(&clusterModels.PostRestoreScript{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("post_restore_script_not_found")
+	}
+	return nil
+}
+
+// postRestoreScriptFor returns the script to run for a restored guest. A
+// script given with the restore request replaces the guest's saved one for
+// that restore only and runs with the default interpreter and timeout.
+func (s *Service) postRestoreScriptFor(
+	guestType string,
+	guestID uint,
+	override string,
+) (clusterModels.PostRestoreScript, bool, error) {
+	if strings.TrimSpace(override) != "" {
+		return clusterModels.PostRestoreScript{
+			GuestType:   guestType,
+			GuestID:     guestID,
+			Script:      override,
+			Interpreter: clusterModels.PostRestoreScriptDefaultInterpreter,
+			Enabled:     true,
+		}, true, nil
+	}
+	if s.DB == nil {
+		return clusterModels.PostRestoreScript{}, false, nil
+	}
+
+	var script clusterModels.PostRestoreScript
+	err := s.DB.Where("guest_type = ? AND guest_id = ? AND enabled = ?", guestType, guestID, true).
+		First(&script).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return script, false, nil
+	}
+	if err != nil {
+		return script, false, err
+	}
+	return script, true, nil
+}
+
+func postRestoreScriptTimeout(script clusterModels.PostRestoreScript) time.Duration {
+	if script.TimeoutSeconds <= 0 {
+		return postRestoreScriptDefaultTimeout
+	}
+	return time.Duration(script.TimeoutSeconds) * time.Second
+}
+
+func postRestoreScriptCommand(script clusterModels.PostRestoreScript) []string {
+	interpreter := strings.TrimSpace(script.Interpreter)
+	if interpreter == "" {
+		interpreter = clusterModels.PostRestoreScriptDefaultInterpreter
+	}
+	return []string{interpreter, "-c", script.Script}
+}
+
+// runPostRestoreScript runs the post-restore script of a restored guest, if it
+// has one, and returns the report to add to the restore event. The restore has
+// already succeeded by then, so a failing script is reported rather than
+// returned. A guest that is not running is started for the script and stopped
+// again afterwards, leaving it as the restore left it.
+func (s *Service) runPostRestoreScript(ctx context.Context, guestType string, guestID uint, override string) string {
+	if guestID == 0 {
+		return ""
+	}
+
+	script, ok, err := s.postRestoreScriptFor(guestType, guestID, override)
+	if err != nil {
+		return formatPostRestoreScriptReport(guestType, guestID, postRestoreScriptResult{
+			Err: fmt.Errorf("post_restore_script_lookup_failed: %w", err),
+		})
+	}
+	if !ok {
+		return ""
+	}
+
+	started := time.Now()
+	var result postRestoreScriptResult
+	switch guestType {
+	case clusterModels.BackupJobModeJail:
+		result = s.runPostRestoreJailScript(ctx, guestID, script)
+	case clusterModels.BackupJobModeVM:
+		result = s.runPostRestoreVMScript(ctx, guestID, script)
+	default:
+		result.Err = fmt.Errorf("post_restore_script_guest_type_invalid")
+	}
+	result.Duration = time.Since(started)
+
+	event := logger.L.Info()
+	if result.Err != nil {
+		event = logger.L.Warn().Err(result.Err)
+	}
+	event.Str("guest_type", guestType).
+		Uint("guest_id", guestID).
+		Int("exit_code", result.ExitCode).
+		Bool("timed_out", result.TimedOut).
+		Dur("duration", result.Duration).
+		Msg("post_restore_script_finished")
+
+	return formatPostRestoreScriptReport(guestType, guestID, result)
+}
+
+func (s *Service) runPostRestoreJailScript(
+	ctx context.Context,
+	ctid uint,
+	script clusterModels.PostRestoreScript,
+) (result postRestoreScriptResult) {
+	if s.Jail == nil {
+		result.Err = fmt.Errorf("jail_service_unavailable")
+		return result
+	}
+
+	running, err := s.Jail.IsJailRunning(ctid)
+	if err != nil {
+		result.Err = fmt.Errorf("failed_to_check_jail_state: %w", err)
+		return result
+	}
+	if !running {
+		if err := s.Jail.JailAction(int(ctid), "start"); err != nil {
+			result.Err = fmt.Errorf("failed_to_start_jail: %w", err)
+			return result
+		}
+		defer func() {
+			if err := s.Jail.JailAction(int(ctid), "stop"); err != nil && result.Err == nil {
+				result.Err = fmt.Errorf("failed_to_stop_jail: %w", err)
+			}
+		}()
+	}
+
+	output, execResult, err := s.Jail.ExecInJail(ctx, ctid, jailServiceInterfaces.JailExecRequest{
+		Command: postRestoreScriptCommand(script),
+		Timeout: int(postRestoreScriptTimeout(script) / time.Second),
+	})
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	result.ExitCode = execResult.ExitCode
+	result.TimedOut = execResult.TimedOut
+	result.Truncated = execResult.Truncated
+	result.Output = output
+	if execResult.Error != "" {
+		result.Err = errors.New(execResult.Error)
+	}
+	return result
+}
+
+func (s *Service) runPostRestoreVMScript(
+	ctx context.Context,
+	rid uint,
+	script clusterModels.PostRestoreScript,
+) (result postRestoreScriptResult) {
+	if s.VM == nil {
+		result.Err = fmt.Errorf("vm_service_unavailable")
+		return result
+	}
+
+	shutOff, err := s.VM.IsDomainShutOff(rid)
+	if err != nil {
+		result.Err = fmt.Errorf("failed_to_check_vm_state: %w", err)
+		return result
+	}
+	if shutOff {
+		if err := s.startVMIfPresent(rid); err != nil {
+			result.Err = fmt.Errorf("failed_to_start_vm: %w", err)
+			return result
+		}
+		defer func() {
+			if err := s.stopVMIfPresent(rid); err != nil && result.Err == nil {
+				result.Err = fmt.Errorf("failed_to_stop_vm: %w", err)
+			}
+		}()
+	}
+
+	req := libvirtServiceInterfaces.VMExecRequest{
+		Command: postRestoreScriptCommand(script),
+		Timeout: int(postRestoreScriptTimeout(script) / time.Second),
+	}
+	execResult, err := execInGuestWhenReady(ctx, func() (libvirtServiceInterfaces.VMExecResult, error) {
+		return s.VM.ExecInGuest(ctx, rid, req)
+	}, postRestoreGuestAgentWait, postRestoreGuestAgentPoll)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	result.ExitCode = execResult.ExitCode
+	result.TimedOut = execResult.TimedOut
+	result.Truncated = execResult.StdoutTruncated || execResult.StderrTruncated
+	result.Output = execResult.Stdout
+	if execResult.Stderr != "" {
+		if result.Output != "" && !strings.HasSuffix(result.Output, "\n") {
+			result.Output += "\n"
+		}
+		result.Output += execResult.Stderr
+	}
+	return result
+}
+
+// execInGuestWhenReady retries exec until the guest agent accepts the
+// command, which takes a while after a VM has just been started. Only a
+// failure to start the command is retried, so it never runs twice.
+func execInGuestWhenReady(
+	ctx context.Context,
+	exec func() (libvirtServiceInterfaces.VMExecResult, error),
+	wait time.Duration,
+	poll time.Duration,
+) (libvirtServiceInterfaces.VMExecResult, error) {
+	deadline := time.Now().Add(wait)
+	for {
+		result, err := exec()
+		if err == nil || !strings.HasPrefix(err.Error(), "failed_to_start_guest_exec") || time.Now().After(deadline) {
+			return result, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-time.After(poll):
+		}
+	}
+}
+
+func formatPostRestoreScriptReport(guestType string, guestID uint, result postRestoreScriptResult) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "post_restore_script: guest=%s/%d", guestType, guestID)
+	if result.Err != nil {
+		fmt.Fprintf(&b, " error=%v", result.Err)
+	} else {
+		fmt.Fprintf(&b, " exit_code=%d", result.ExitCode)
+	}
+	if result.TimedOut {
+		b.WriteString(" timed_out=true")
+	}
+	if result.Duration > 0 {
+		fmt.Fprintf(&b, " duration=%s", result.Duration.Round(time.Millisecond))
+	}
+
+	output := strings.TrimRight(result.Output, "\n")
+	truncated := result.Truncated
+	if len(output) > postRestoreScriptOutputLimit {
+		output = output[len(output)-postRestoreScriptOutputLimit:]
+		truncated = true
+	}
+	if truncated {
+		b.WriteString(" output_truncated=true")
+	}
+	if output != "" {
+		b.WriteString("\n")
+		b.WriteString(output)
+	}
+	return b.String()
+}
+
+// appendRestoreOutput adds a line to the output a restore finalizes its event
+// with.
+func appendRestoreOutput(output, line string) string {
+	if strings.TrimSpace(line) == "" {
+		return output
+	}
+	if strings.TrimSpace(output) == "" {
+		return line
+	}
+	return strings.TrimRight(output, "\n") + "\n" + line
+}
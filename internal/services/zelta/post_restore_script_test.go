@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+
+package zelta
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
+	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
+)
+
+type postRestoreJailStub struct {
+	jailServiceInterfaces.JailServiceInterface
+	running bool
+	actions []string
+	req     jailServiceInterfaces.JailExecRequest
+	output  string
+	result  jailServiceInterfaces.JailExecResult
+}
+
+func (s *postRestoreJailStub) IsJailRunning(uint) (bool, error) {
+	return s.running, nil
+}
+
+func (s *postRestoreJailStub) JailAction(_ int, action string) error {
+	s.actions = append(s.actions, action)
+	return nil
+}
+
+func (s *postRestoreJailStub) ExecInJail(
+	_ context.Context,
+	_ uint,
+	req jailServiceInterfaces.JailExecRequest,
+) (string, jailServiceInterfaces.JailExecResult, error) {
+	s.actions = append(s.actions, "exec")
+	s.req = req
+	return s.output, s.result, nil
+}
+
+func TestSavePostRestoreScriptReplacesGuestScript(t *testing.T) {
+	svc := newTestZeltaService(newZeltaServiceTestDB(t, &clusterModels.PostRestoreScript{}))
+	ctx := context.Background()
+
+	first, err := svc.SavePostRestoreScript(ctx, clusterServiceInterfaces.PostRestoreScriptReq{
+		GuestType: clusterModels.BackupJobModeJail,
+		GuestID:   101,
+		Script:    "sysrc ifconfig_vnet0=DHCP",
+	})
+	if err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	if first.Interpreter != clusterModels.PostRestoreScriptDefaultInterpreter || !first.Enabled {
+		t.Fatalf("expected defaults to be filled in, got %+v", first)
+	}
+
+	disabled := false
+	second, err := svc.SavePostRestoreScript(ctx, clusterServiceInterfaces.PostRestoreScriptReq{
+		GuestType: clusterModels.BackupJobModeJail,
+		GuestID:   101,
+		Script:    "service netif restart",
+		Enabled:   &disabled,
+	})
+	if err != nil {
+		t.Fatalf("second save failed: %v", err)
+	}
+	if second.ID != first.ID {
+		t.Fatalf("expected the guest's script to be replaced, got ids %d and %d", first.ID, second.ID)
+	}
+
+	if _, ok, err := svc.postRestoreScriptFor(clusterModels.BackupJobModeJail, 101, ""); err != nil || ok {
+		t.Fatalf("expected a disabled script to be skipped, got ok=%v err=%v", ok, err)
+	}
+	script, ok, err := svc.postRestoreScriptFor(clusterModels.BackupJobModeJail, 101, "hostname restored")
+	if err != nil || !ok || script.Script != "hostname restored" {
+		t.Fatalf("expected the request override to be used, got %+v ok=%v err=%v", script, ok, err)
+	}
+
+	if _, err := svc.SavePostRestoreScript(ctx, clusterServiceInterfaces.PostRestoreScriptReq{
+		GuestType: "dataset",
+		GuestID:   1,
+		Script:    "true",
+	}); err == nil {
+		t.Fatal("expected a dataset guest type to be refused")
+	}
+}
+
+func TestRunPostRestoreScriptStartsAndStopsStoppedJail(t *testing.T) {
+	stub := &postRestoreJailStub{
+		output: "ifconfig_vnet0: 10.0.0.5 -> DHCP\n",
+		result: jailServiceInterfaces.JailExecResult{ExitCode: 0},
+	}
+	svc := &Service{Jail: stub}
+
+	report := svc.runPostRestoreScript(context.Background(), clusterModels.BackupJobModeJail, 101, "sysrc ifconfig_vnet0=DHCP")
+
+	if !slices.Equal(stub.actions, []string{"start", "exec", "stop"}) {
+		t.Fatalf("expected the jail to be started around the script, got %v", stub.actions)
+	}
+	if !slices.Equal(stub.req.Command, []string{"/bin/sh", "-c", "sysrc ifconfig_vnet0=DHCP"}) {
+		t.Fatalf("unexpected command %q", stub.req.Command)
+	}
+	if !strings.HasPrefix(report, "post_restore_script: guest=jail/101 exit_code=0") ||
+		!strings.HasSuffix(report, "\nifconfig_vnet0: 10.0.0.5 -> DHCP") {
+		t.Fatalf("unexpected report %q", report)
+	}
+
+	stub.actions = nil
+	stub.running = true
+	svc.runPostRestoreScript(context.Background(), clusterModels.BackupJobModeJail, 101, "true")
+	if !slices.Equal(stub.actions, []string{"exec"}) {
+		t.Fatalf("expected a running jail to be left alone, got %v", stub.actions)
+	}
+}
+
+func TestExecInGuestWhenReadyRetriesOnlyUnstartedCommands(t *testing.T) {
+	attempts := 0
+	result, err := execInGuestWhenReady(context.Background(), func() (libvirtServiceInterfaces.VMExecResult, error) {
+		attempts++
+		if attempts < 3 {
+			return libvirtServiceInterfaces.VMExecResult{}, errors.New("failed_to_start_guest_exec: agent not connected")
+		}
+		return libvirtServiceInterfaces.VMExecResult{PID: 9}, nil
+	}, time.Minute, time.Millisecond)
+	if err != nil || attempts != 3 || result.PID != 9 {
+		t.Fatalf("expected success on the third attempt, got %+v, %v after %d attempts", result, err, attempts)
+	}
+
+	attempts = 0
+	_, err = execInGuestWhenReady(context.Background(), func() (libvirtServiceInterfaces.VMExecResult, error) {
+		attempts++
+		return libvirtServiceInterfaces.VMExecResult{}, errors.New("failed_to_get_guest_exec_status: gone")
+	}, time.Minute, time.Millisecond)
+	if err == nil || attempts != 1 {
+		t.Fatalf("expected a started command not to be retried, got %v after %d attempts", err, attempts)
+	}
+}
+
+func TestFormatPostRestoreScriptReportKeepsOutputTail(t *testing.T) {
+	output := strings.Repeat("a", postRestoreScriptOutputLimit) + "tail"
+	report := formatPostRestoreScriptReport(clusterModels.BackupJobModeVM, 100, postRestoreScriptResult{
+		ExitCode: 2,
+		Output:   output,
+	})
+	if !strings.Contains(report, "exit_code=2 output_truncated=true") || !strings.HasSuffix(report, "tail") {
+		t.Fatalf("unexpected report header or tail: %q", report[:80])
+	}
+
+	report = formatPostRestoreScriptReport(clusterModels.BackupJobModeVM, 100, postRestoreScriptResult{
+		Err: errors.New("qemu_guest_agent_disabled"),
+	})
+	if report != "post_restore_script: guest=vm/100 error=qemu_guest_agent_disabled" {
+		t.Fatalf("unexpected error report %q", report)
+	}
+}
@@ -33,8 +33,10 @@ import (
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
 	"github.com/alchemillahq/sylve/internal/db/replicationguard"
 	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/internal/logger"
 	clusterService "github.com/alchemillahq/sylve/internal/services/cluster"
+	"github.com/alchemillahq/sylve/internal/webhooks"
 	"github.com/alchemillahq/sylve/pkg/utils"
 	"github.com/google/uuid"
 	"github.com/hashicorp/raft"
@@ -69,9 +71,11 @@ const (
 	replicationEventStatusFailed      = "failed"
 	replicationEventStatusDegraded    = "degraded"
 	replicationEventStatusInterrupted = "interrupted"
+	replicationEventStatusSkipped     = "skipped"
 
-	replicationFailoverRequestSafe  = "safe"
-	replicationFailoverRequestForce = "force"
+	replicationFailoverRequestSafe       = "safe"
+	replicationFailoverRequestForce      = "force"
+	replicationFailoverRequestSwitchover = "switchover"
 
 	replicationControlDefaultTimeout       = 30 * time.Second
 	replicationControlCatchupTimeout       = 2 * time.Hour
@@ -429,6 +433,13 @@ func (s *Service) registerReplicationJob() {
 			return nil
 		}
 
+		if policy.Paused {
+			// The policy was paused after this run was queued. Drop it and
+			// leave an event behind so the gap in the schedule is explained.
+			s.recordPausedReplicationRunDropped(policy)
+			return nil
+		}
+
 		if err := s.runReplicationPolicy(ctx, policy); err != nil {
 			if len(clusterService.ParseReplicationHAIneligibleReasons(err)) > 0 {
 				logger.L.Warn().
@@ -507,6 +518,9 @@ func (s *Service) EnqueueReplicationPolicyRun(ctx context.Context, policyID uint
 	if err != nil {
 		return err
 	}
+	if policy.Paused {
+		return fmt.Errorf("replication_policy_paused")
+	}
 	if !replicationPolicyAllowsRuns(policy) {
 		return fmt.Errorf("replication_policy_not_runnable")
 	}
@@ -701,6 +715,9 @@ func (s *Service) runReplicationMaintenanceLoop(ctx context.Context) {
 			if err := s.Cluster.EnsureAndPublishLocalSSHIdentity(); err != nil {
 				logger.L.Warn().Err(err).Msg("cluster_ssh_identity_sync_failed")
 			}
+			if err := s.Cluster.RotateLocalSSHIdentityIfDue(); err != nil {
+				logger.L.Warn().Err(err).Msg("cluster_ssh_key_rotation_failed")
+			}
 			lastSSHSync = now
 		}
 	})
@@ -719,7 +736,7 @@ func transitionStateInProgress(state string) bool {
 }
 
 func replicationPolicyAllowsRuns(policy *clusterModels.ReplicationPolicy) bool {
-	if policy == nil || !policy.Enabled || transitionStateInProgress(policy.TransitionState) {
+	if policy == nil || !policy.Enabled || policy.Paused || transitionStateInProgress(policy.TransitionState) {
 		return false
 	}
 	switch strings.ToLower(strings.TrimSpace(policy.ProtectionState)) {
@@ -875,11 +892,27 @@ func replicationFailoverRequestMode(mode string) string {
 	switch mode {
 	case replicationFailoverRequestForce:
 		return replicationFailoverRequestForce
+	case replicationFailoverRequestSwitchover:
+		return replicationFailoverRequestSwitchover
 	default:
 		return replicationFailoverRequestSafe
 	}
 }
 
+// replicationFailoverReason is the transition reason recorded for a manual
+// request. A switchover runs the safe demote/catch-up path; only the reason
+// tells the two apart in events and webhooks.
+func replicationFailoverReason(requestMode string) string {
+	switch requestMode {
+	case replicationFailoverRequestForce:
+		return "manual_force_failover"
+	case replicationFailoverRequestSwitchover:
+		return "manual_switchover"
+	default:
+		return "manual_failover"
+	}
+}
+
 func transitionPayloadFromPolicy(policy *clusterModels.ReplicationPolicy) clusterModels.ReplicationPolicyTransition {
 	if policy == nil {
 		return clusterModels.ReplicationPolicyTransition{}
@@ -2537,6 +2570,7 @@ func (s *Service) runReplicationPolicy(ctx context.Context, policy *clusterModel
 			Msg("replication_target_attempt_started")
 
 		generationID := fmt.Sprintf("replication-%d-%s-%d", policy.ID, compactNowToken(), eligibleTargets)
+		attemptStartedAt := s.now()
 		generationResult, attemptErr := s.runReplicationTargetGenerationAttempt(
 			policy,
 			target,
@@ -2553,6 +2587,7 @@ func (s *Service) runReplicationPolicy(ctx context.Context, policy *clusterModel
 				)
 			},
 		)
+		pruned := 0
 		if attemptErr == nil {
 			for _, sourceDataset := range sourceDatasets {
 				targetSpec, destSuffix, specErr := s.replicationTargetSpec(targetNodeID, sourceDataset, identityByNode, privateKeyPath)
@@ -2560,11 +2595,14 @@ func (s *Service) runReplicationPolicy(ctx context.Context, policy *clusterModel
 					logger.L.Warn().Err(specErr).Str("source_dataset", sourceDataset).Msg("replication_retention_target_spec_failed")
 					continue
 				}
-				if retentionErr := s.applyReplicationRetention(ctx, targetSpec, sourceDataset, destSuffix, event.ID, targetNodeID); retentionErr != nil {
+				removed, retentionErr := s.applyReplicationRetention(ctx, targetSpec, sourceDataset, destSuffix, policy.SourceBookmarks, event.ID, targetNodeID)
+				pruned += removed
+				if retentionErr != nil {
 					logger.L.Warn().Err(retentionErr).Str("source_dataset", sourceDataset).Msg("replication_retention_post_run_failed")
 				}
 			}
 		}
+		s.observeReplicationTransfer(policy, targetNodeID, event.ID, attemptStartedAt, pruned, attemptErr)
 
 		if attemptErr != nil {
 			// Track this target as failed but continue to remaining
@@ -2721,7 +2759,7 @@ func (s *Service) invalidateReplicationPolicyTargetReadiness(
 func splitDatasetForTarget(dataset string) (string, string) {
 	dataset = normalizeDatasetPath(dataset)
 	if dataset == "" {
-		return "zroot", "sylve"
+		return "zroot", layout.RootName()
 	}
 
 	idx := strings.Index(dataset, "/")
@@ -3090,6 +3128,10 @@ func (s *Service) replicatePolicyGenerationToTarget(
 		return result, err
 	}
 
+	zeltaProfile, err := s.loadZeltaProfile(policy.ZeltaProfileID)
+	if err != nil {
+		return result, err
+	}
 	sourceDatasets, err := s.replicationSourceDatasets(ctx, policy)
 	if err != nil {
 		return result, err
@@ -3279,11 +3321,33 @@ func (s *Service) replicatePolicyGenerationToTarget(
 		sourceSnapshotCleanupProven = snapshotCreatedHere && untrackedTargetCleanupProven && rollbackCleanupProven
 		return baseErr
 	}
+	// Staged transfers only succeed once the whole generation commits, so
+	// their records are closed with the final outcome of this attempt.
+	type pendingTransfer struct {
+		record *clusterModels.ReplicationEventTransfer
+		output string
+	}
+	var pendingTransfers []pendingTransfer
+	defer func() {
+		for _, pending := range pendingTransfers {
+			status := replicationTransferStatusSuccess
+			if retErr != nil {
+				status = replicationTransferStatusFailed
+			}
+			s.finishReplicationEventTransfer(pending.record, status, pending.output, retErr)
+		}
+	}()
 	for _, entry := range manifest {
 		targetSpec, destSuffix, specErr := s.replicationTargetSpec(targetNodeID, entry.SourceDataset, identityByNode, privateKeyPath)
 		if specErr != nil {
 			return result, specErr
 		}
+		transfer := s.startReplicationEventTransfer(
+			eventID,
+			targetNodeID,
+			entry.SourceDataset,
+			targetDatasetPath(targetSpec.BackupRoot, destSuffix),
+		)
 		opts := ReplicationZFSTransferOptions{
 			PolicyID:               policy.ID,
 			RunID:                  generationID,
@@ -3292,6 +3356,7 @@ func (s *Service) replicatePolicyGenerationToTarget(
 			SnapshotGUID:           entry.SnapshotGUID,
 			SnapshotAlreadyCreated: true,
 			GenerationName:         generationID,
+			ZeltaProfile:           zeltaProfile,
 		}
 		alreadyReady, readyErr := s.replicationDatasetGenerationReady(
 			ctx,
@@ -3301,9 +3366,11 @@ func (s *Service) replicatePolicyGenerationToTarget(
 			opts,
 		)
 		if readyErr != nil {
+			s.finishReplicationEventTransfer(transfer, replicationTransferStatusFailed, "", readyErr)
 			return result, rollbackCandidateGeneration(fmt.Errorf("verify_replication_dataset_generation_%s_failed: %w", entry.SourceDataset, readyErr))
 		}
 		if alreadyReady {
+			s.finishReplicationEventTransfer(transfer, replicationTransferStatusSkipped, "", nil)
 			sourceSnapshotCleanupProven = false
 			targetDataset := targetDatasetPath(targetSpec.BackupRoot, destSuffix)
 			staged = append(staged, replicationStagedDataset{
@@ -3321,6 +3388,7 @@ func (s *Service) replicatePolicyGenerationToTarget(
 			continue
 		}
 		var stagedResult ReplicationStagedTransferResult
+		var transferOutput string
 		sourceSnapshotCleanupProven = false
 		untrackedTargetCleanupProven = false
 		transferErr := s.withReplicationAuthorityMonitor(
@@ -3330,9 +3398,10 @@ func (s *Service) replicatePolicyGenerationToTarget(
 			transitionRunID,
 			func(transferCtx context.Context) error {
 				var sendErr error
-				// The callback receives both seed and transfer output. Intentionally
-				// ignore the returned aggregate so each event line is stored once.
-				stagedResult, _, sendErr = s.ReplicationZFSSendStaged(
+				// The callback receives both seed and transfer output. The returned
+				// aggregate only feeds this dataset's transfer record so each event
+				// line is stored once.
+				stagedResult, transferOutput, sendErr = s.ReplicationZFSSendStaged(
 					transferCtx,
 					targetSpec,
 					entry.SourceDataset,
@@ -3348,6 +3417,7 @@ func (s *Service) replicatePolicyGenerationToTarget(
 			},
 		)
 		if transferErr != nil {
+			s.finishReplicationEventTransfer(transfer, replicationTransferStatusFailed, transferOutput, transferErr)
 			targetDataset := targetDatasetPath(targetSpec.BackupRoot, destSuffix)
 			stagingDataset, stagingErr := replicationStagingDatasetPath(targetDataset, opts)
 			if stagingErr != nil {
@@ -3373,6 +3443,7 @@ func (s *Service) replicatePolicyGenerationToTarget(
 			options:       opts,
 			result:        stagedResult,
 		})
+		pendingTransfers = append(pendingTransfers, pendingTransfer{record: transfer, output: transferOutput})
 		untrackedTargetCleanupProven = true
 	}
 
@@ -3508,13 +3579,15 @@ func (s *Service) applyReplicationRetention(
 	target *clusterModels.BackupTarget,
 	sourceDataset string,
 	destSuffix string,
+	sourceBookmarks bool,
 	eventID uint,
 	targetNodeID string,
-) error {
+) (int, error) {
 	if target == nil {
-		return fmt.Errorf("replication_target_required")
+		return 0, fmt.Errorf("replication_target_required")
 	}
-	if err := s.retainReplicationSnapshots(ctx, target, sourceDataset, destSuffix, defaultReplicationPruneKeepLast); err != nil {
+	pruned, err := s.retainReplicationSnapshots(ctx, target, sourceDataset, destSuffix, defaultReplicationPruneKeepLast, sourceBookmarks)
+	if err != nil {
 		s.appendReplicationTargetEventOutputBestEffort(eventID, targetNodeID, fmt.Sprintf("replication_prune_warning: %v", err))
 		logger.L.Warn().
 			Err(err).
@@ -3525,7 +3598,7 @@ func (s *Service) applyReplicationRetention(
 	// Dataset-generation cleanup is performed by provenance-aware primitives
 	// only. The legacy name-pattern trimmer could destroy an unknown sibling
 	// that merely happened to use `_gen-` naming.
-	return nil
+	return pruned, nil
 }
 
 func (s *Service) trimLocalReplicationLineageDatasets(
@@ -3784,7 +3857,7 @@ func (s *Service) resolveJailReplicationSourceDataset(ctID uint) (string, error)
 		return "", fmt.Errorf("jail_pool_not_found")
 	}
 
-	return fmt.Sprintf("%s/sylve/jails/%d", pool, ctID), nil
+	return fmt.Sprintf("%s/%d", layout.JailsDataset(pool), ctID), nil
 }
 
 func (s *Service) updateReplicationPolicyResult(policy *clusterModels.ReplicationPolicy, runErr error) {
@@ -3930,6 +4003,34 @@ func isReplicationRaftNotLeaderError(err error) bool {
 		strings.Contains(message, "not leader")
 }
 
+func (s *Service) recordPausedReplicationRunDropped(policy *clusterModels.ReplicationPolicy) {
+	if s.DB == nil || policy == nil {
+		return
+	}
+
+	localNodeID := ""
+	if s.Cluster != nil {
+		localNodeID = strings.TrimSpace(s.Cluster.LocalNodeID())
+	}
+
+	now := s.now().UTC()
+	event := clusterModels.ReplicationEvent{
+		PolicyID:     &policy.ID,
+		EventType:    "replication",
+		Status:       replicationEventStatusSkipped,
+		Message:      "replication_run_dropped_policy_paused",
+		Error:        strings.TrimSpace(policy.PausedReason),
+		SourceNodeID: localNodeID,
+		GuestType:    policy.GuestType,
+		GuestID:      policy.GuestID,
+		StartedAt:    now,
+		CompletedAt:  &now,
+	}
+	if err := s.DB.Create(&event).Error; err != nil {
+		logger.L.Warn().Err(err).Uint("policy_id", policy.ID).Msg("failed_to_record_paused_replication_run_drop")
+	}
+}
+
 func (s *Service) finalizeReplicationEvent(event *clusterModels.ReplicationEvent, runErr error) error {
 	if event == nil || event.ID == 0 {
 		return fmt.Errorf("replication_event_required_for_finalization")
@@ -4406,6 +4507,7 @@ func (s *Service) runFailoverControllerTick(ctx context.Context) error {
 		return err
 	}
 	now := s.now().UTC()
+	windows := s.activeMaintenanceWindows(now)
 	for i := range policies {
 		policy := policies[i]
 		observedOwner := replicationPolicyOwnerNode(&policy)
@@ -4416,7 +4518,7 @@ func (s *Service) runFailoverControllerTick(ctx context.Context) error {
 			s.downMissesReset(policy.ID)
 			s.clearFailoverWarnings(policy.ID)
 		}
-		if !policy.Enabled {
+		if !policy.Enabled || policy.Paused {
 			continue
 		}
 		if transitionStateInProgress(policy.TransitionState) || s.IsPolicyTransitionRunning(policy.ID) {
@@ -4463,6 +4565,12 @@ func (s *Service) runFailoverControllerTick(ctx context.Context) error {
 				if sourceOnline {
 					fbVal := s.failbackHitsIncr(policy.ID)
 					if fbVal >= uint64(replicationFailbackHitLimit) {
+						// Hits keep counting, so failback follows once the window closes.
+						if s.holdForMaintenance(windows, &policy,
+							[]string{owner, strings.TrimSpace(policy.SourceNodeID)},
+							clusterModels.MaintenanceActionFailback, "auto_failback", now) {
+							continue
+						}
 						if err := s.failoverPolicyToNode(
 							ctx,
 							&policy,
@@ -4502,6 +4610,10 @@ func (s *Service) runFailoverControllerTick(ctx context.Context) error {
 		if failoverMode == clusterModels.ReplicationFailoverManual {
 			continue
 		}
+		if s.holdForMaintenance(windows, &policy, []string{owner},
+			clusterModels.MaintenanceActionFailover, "owner_node_down", now) {
+			continue
+		}
 
 		requireCompleteGeneration := failoverMode == clusterModels.ReplicationFailoverAutoForce
 		targetNodeID, selectErr := s.selectFailoverTargetWithReadiness(
@@ -4749,6 +4861,9 @@ func (s *Service) selectFailoverTargetWithReadiness(
 		if strings.ToLower(strings.TrimSpace(node.Status)) != "online" {
 			continue
 		}
+		if !nodeStorageFitForFailover(node, policy.PoolCapacityPct) {
+			continue
+		}
 		if requireCompleteGeneration && !replicationTargetEligibleForPromotion(
 			&target,
 			replicationPolicyOwnerEpoch(policy),
@@ -4801,6 +4916,21 @@ func nodeOnlineByID(nodeByID map[string]clusterModels.ClusterNode, nodeID string
 	return strings.ToLower(strings.TrimSpace(node.Status)) == "online"
 }
 
+// nodeStorageFitForFailover reports whether a node's pools can take a guest:
+// none degraded and none past the policy's capacity limit. Nodes that have
+// not reported their pools yet are given the benefit of the doubt.
+func nodeStorageFitForFailover(node clusterModels.ClusterNode, capacityPct int) bool {
+	if capacityPct <= 0 {
+		capacityPct = replicationLowPoolCapacityPercent
+	}
+	for _, pool := range node.Pools {
+		if !pool.Online() || pool.UsedPct > float64(capacityPct) {
+			return false
+		}
+	}
+	return true
+}
+
 func replicationPolicyHasTargetNode(policy *clusterModels.ReplicationPolicy, nodeID string) bool {
 	if policy == nil {
 		return false
@@ -4975,7 +5105,7 @@ func (s *Service) EnqueueReplicationPolicyFailover(
 	if ownerNodeID == "" || replicationPolicyOwnerEpoch(policy) == 0 {
 		return fmt.Errorf("replication_policy_owner_missing")
 	}
-	if requestMode == replicationFailoverRequestSafe && !nodeOnlineByID(nodeByID, ownerNodeID) {
+	if requestMode != replicationFailoverRequestForce && !nodeOnlineByID(nodeByID, ownerNodeID) {
 		return fmt.Errorf("safe_failover_requires_online_owner_use_force_for_owner_down")
 	}
 
@@ -5039,10 +5169,7 @@ func (s *Service) EnqueueReplicationPolicyFailover(
 	}
 
 	runID := fmt.Sprintf("failover-%d-%s", policyID, compactNowToken())
-	reason := "manual_failover"
-	if requestMode == replicationFailoverRequestForce {
-		reason = "manual_force_failover"
-	}
+	reason := replicationFailoverReason(requestMode)
 	requestedAt := s.now().UTC()
 	transition := clusterModels.ReplicationPolicyTransition{
 		State:                clusterModels.ReplicationTransitionStateDemoting,
@@ -5106,6 +5233,25 @@ func (s *Service) EnqueueReplicationPolicyFailover(
 	return nil
 }
 
+// EnqueueReplicationPolicySwitchover queues a planned role swap: the guest is
+// stopped on its owner, a final incremental sync is sent to targetNodeID, the
+// guest is activated there and the policy then replicates back the other way.
+// Unlike a failover the target is never picked automatically and the owner
+// must be online, so no data is lost.
+func (s *Service) EnqueueReplicationPolicySwitchover(policyID uint, targetNodeID string) error {
+	targetNodeID = strings.TrimSpace(targetNodeID)
+	if targetNodeID == "" {
+		return fmt.Errorf("replication_switchover_target_required")
+	}
+	return s.EnqueueReplicationPolicyFailover(
+		policyID,
+		targetNodeID,
+		replicationFailoverRequestSwitchover,
+		false,
+		true,
+	)
+}
+
 func (s *Service) requestReplicationPolicyFailover(
 	ctx context.Context,
 	policyID uint,
@@ -5178,7 +5324,7 @@ func (s *Service) requestReplicationPolicyFailover(
 	if ownerNodeID == "" {
 		return fmt.Errorf("replication_policy_owner_missing")
 	}
-	if requestMode == replicationFailoverRequestSafe && !nodeOnlineByID(nodeByID, ownerNodeID) {
+	if requestMode != replicationFailoverRequestForce && !nodeOnlineByID(nodeByID, ownerNodeID) {
 		return fmt.Errorf("safe_failover_requires_online_owner_use_force_for_owner_down")
 	}
 
@@ -5239,8 +5385,8 @@ func (s *Service) requestReplicationPolicyFailover(
 		MovePinnedSource:     movePinnedSource,
 		TriggerValidationRun: true,
 	}
-	requireDemoteAck := requestMode == replicationFailoverRequestSafe
-	reason := "manual_failover"
+	requireDemoteAck := requestMode != replicationFailoverRequestForce
+	reason := replicationFailoverReason(requestMode)
 	if requestMode == replicationFailoverRequestForce {
 		quorumOK, quorumErr := s.hasFailoverQuorum(nodeByID)
 		if quorumErr != nil {
@@ -5249,7 +5395,6 @@ func (s *Service) requestReplicationPolicyFailover(
 		if !quorumOK {
 			return fmt.Errorf("force_failover_requires_quorum")
 		}
-		reason = "manual_force_failover"
 	}
 
 	return s.failoverPolicyToNode(ctx, policy, targetNodeID, reason, requireDemoteAck, options)
@@ -5271,7 +5416,26 @@ func (s *Service) failoverPolicyToNode(
 	}
 	defer s.releasePolicyTransition(policy.ID)
 
-	return s.runPolicyOwnershipTransition(ctx, policy, targetNodeID, reason, requireDemoteAck, options)
+	previousOwner := replicationPolicyOwnerNode(policy)
+	err := s.runPolicyOwnershipTransition(ctx, policy, targetNodeID, reason, requireDemoteAck, options)
+
+	status, errMsg := "success", ""
+	if err != nil {
+		status, errMsg = "failed", err.Error()
+	}
+	webhooks.Publish(webhooks.EventReplicationFailover, map[string]any{
+		"policyId":     policy.ID,
+		"policyName":   policy.Name,
+		"guestType":    policy.GuestType,
+		"guestId":      policy.GuestID,
+		"fromNodeId":   previousOwner,
+		"targetNodeId": targetNodeID,
+		"reason":       reason,
+		"status":       status,
+		"error":        errMsg,
+	})
+
+	return err
 }
 
 func (s *Service) runPolicyOwnershipTransition(
@@ -6279,6 +6443,13 @@ func backupJobToReqWithRunner(job *clusterModels.BackupJob, runnerNodeID string)
 	req.PruneTarget = job.PruneTarget
 	req.StopBeforeBackup = job.StopBeforeBackup
 	req.Recursive = job.Recursive
+	req.VMDatasetIncludes = job.VMDatasetIncludes
+	req.VMDatasetExcludes = job.VMDatasetExcludes
+	req.PreBackupScript = job.PreBackupScript
+	req.PostBackupScript = job.PostBackupScript
+	req.HookTimeoutSeconds = job.HookTimeoutSeconds
+	req.HookFailurePolicy = job.HookFailurePolicy
+	req.ZeltaProfileID = job.ZeltaProfileID
 	req.CronExpr = strings.TrimSpace(job.CronExpr)
 	return req
 }
@@ -7876,6 +8047,17 @@ func (s *Service) activateReplicationPolicy(
 			return err
 		}
 	}
+	// Shared-storage pairs must hold the storage fence before the replica is
+	// made writable, otherwise a peer that only lost its cluster link could
+	// still be writing the same pool.
+	if err := s.enforceStorageFences(ctx, transitionRunID != ""); err != nil {
+		return fmt.Errorf("replication_activation_storage_fence_failed: %w", err)
+	}
+	if *desiredRunning && s.applicationGate != nil {
+		if err := s.applicationGate(ctx, policy.GuestType, policy.GuestID); err != nil {
+			return fmt.Errorf("replication_activation_application_gate_failed: %w", err)
+		}
+	}
 	if err := driver.activate(ctx, policy.GuestID, transitionRunID, *desiredRunning); err != nil {
 		// Activation may have prepared more than one guest root before a
 		// later root or guest registration failed.  Never leave a partially
@@ -8752,8 +8934,7 @@ func isReplicationLineageDatasetPath(dataset string) bool {
 	}
 	parts := strings.Split(dataset, "/")
 	for i := 0; i+1 < len(parts); i++ {
-		container := strings.ToLower(strings.TrimSpace(parts[i]))
-		if container != "virtual-machines" && container != "jails" {
+		if !layout.IsGuestClass(strings.TrimSpace(parts[i])) {
 			continue
 		}
 		guestLeaf := strings.ToLower(strings.TrimSpace(parts[i+1]))
@@ -8948,14 +9129,14 @@ func parseCanonicalReplicationGuestDataset(dataset string) (string, uint, string
 		return "", 0, "", false
 	}
 	parts := strings.Split(dataset, "/")
-	if len(parts) < 4 || strings.TrimSpace(parts[0]) == "" || parts[1] != "sylve" {
+	if len(parts) < 4 || strings.TrimSpace(parts[0]) == "" || parts[1] != layout.RootName() {
 		return "", 0, "", false
 	}
 	guestType := ""
 	switch parts[2] {
-	case "virtual-machines":
+	case layout.VirtualMachinesName():
 		guestType = clusterModels.ReplicationGuestTypeVM
-	case "jails":
+	case layout.JailsName():
 		guestType = clusterModels.ReplicationGuestTypeJail
 	default:
 		return "", 0, "", false
@@ -9853,6 +10034,7 @@ func (s *Service) recoverCrashedReplicationGuests(ctx context.Context) error {
 	if err := s.DB.Where("enabled = ?", true).Find(&policies).Error; err != nil {
 		return err
 	}
+	windows := s.activeMaintenanceWindows(time.Now().UTC())
 
 	for _, policy := range policies {
 		if transitionStateInProgress(policy.TransitionState) ||
@@ -9919,6 +10101,14 @@ func (s *Service) recoverCrashedReplicationGuests(ctx context.Context) error {
 			continue
 		}
 
+		// A guest stopped during maintenance is planned downtime, so it
+		// neither restarts nor spends crash attempts.
+		if s.holdForMaintenance(windows, &policy, []string{localNodeID},
+			clusterModels.MaintenanceActionCrashRestart, "guest_not_running", time.Now().UTC()) {
+			s.crashMissesReset(policy.ID)
+			continue
+		}
+
 		if policy.GuestType == clusterModels.ReplicationGuestTypeVM {
 			vm, lookupErr := s.findVMByRID(policy.GuestID)
 			if lookupErr != nil {
@@ -10154,6 +10344,7 @@ func (s *Service) checkLocalPoolHealth(ctx context.Context, localNodeID string)
 		return
 	}
 
+	windows := s.activeMaintenanceWindows(time.Now().UTC())
 	poolCache := make(map[string]*gzfs.ZPool)
 	for _, policy := range policies {
 		if transitionStateInProgress(policy.TransitionState) || s.IsPolicyTransitionRunning(policy.ID) ||
@@ -10219,7 +10410,8 @@ func (s *Service) checkLocalPoolHealth(ctx context.Context, localNodeID string)
 					misses = 3
 				}
 				s.poolDownMisses[counterKey] = misses
-				if misses >= 3 {
+				if misses >= 3 && !s.holdForMaintenance(windows, &policy, []string{localNodeID},
+					clusterModels.MaintenanceActionFailover, "pool_unhealthy", time.Now().UTC()) {
 					logger.L.Warn().
 						Str("pool", pool).
 						Str("state", string(state)).
@@ -10240,7 +10432,8 @@ func (s *Service) checkLocalPoolHealth(ctx context.Context, localNodeID string)
 					capacityPct = replicationLowPoolCapacityPercent
 				}
 				usedPercent := int(uint64(p.Alloc) * 100 / uint64(p.Size))
-				if usedPercent > capacityPct {
+				if usedPercent > capacityPct && !s.holdForMaintenance(windows, &policy, []string{localNodeID},
+					clusterModels.MaintenanceActionFailover, "pool_capacity_high", time.Now().UTC()) {
 					logger.L.Warn().
 						Str("pool", pool).
 						Int("used_pct", usedPercent).
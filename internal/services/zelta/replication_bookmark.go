@@ -0,0 +1,236 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/pkg/utils"
+)
+
+const (
+	// Bookmarks keep the incremental base of a pruned ha_ snapshot without
+	// pinning any blocks, so leaf sources can retain far fewer snapshots
+	// than the standby does.
+	defaultReplicationSourceBookmarkKeepLast = 4
+
+	replicationBookmarkSeparator = "#"
+)
+
+// isReplicationBookmarkBase reports whether an incremental base returned by
+// findCommonReplicationIncrementalBase refers to a source-side bookmark.
+func isReplicationBookmarkBase(base string) bool {
+	return strings.HasPrefix(strings.TrimSpace(base), replicationBookmarkSeparator)
+}
+
+func parseReplicationBookmarkIdentities(output, dataset string) ([]replicationSnapshotIdentity, error) {
+	dataset = normalizeDatasetPath(dataset)
+	if dataset == "" {
+		return []replicationSnapshotIdentity{}, nil
+	}
+
+	identities := make([]replicationSnapshotIdentity, 0)
+	prefix := dataset + replicationBookmarkSeparator + haSnapPrefix
+	scan := bufio.NewScanner(strings.NewReader(output))
+	for scan.Scan() {
+		line := strings.TrimSpace(scan.Text())
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 || !strings.HasPrefix(fields[0], prefix) {
+			continue
+		}
+		if len(fields) != 2 || strings.TrimSpace(fields[1]) == "" || fields[1] == "-" {
+			return nil, fmt.Errorf("invalid_replication_bookmark_identity:%s", fields[0])
+		}
+		identities = append(identities, replicationSnapshotIdentity{
+			Name: strings.TrimPrefix(fields[0], dataset+replicationBookmarkSeparator),
+			GUID: strings.TrimSpace(fields[1]),
+		})
+	}
+	if err := scan.Err(); err != nil {
+		return nil, err
+	}
+	return identities, nil
+}
+
+// latestCommonReplicationBookmark returns the newest local bookmark whose
+// snapshot still exists on the target with the same GUID. A bookmark keeps
+// the GUID of the snapshot it was created from, so a name match with a
+// different GUID is a lineage break rather than a usable base.
+func latestCommonReplicationBookmark(
+	localBookmarks []replicationSnapshotIdentity,
+	remoteSnaps []replicationSnapshotIdentity,
+) (string, error) {
+	if len(localBookmarks) == 0 || len(remoteSnaps) == 0 {
+		return "", nil
+	}
+
+	remoteByName := make(map[string]string, len(remoteSnaps))
+	for _, snap := range remoteSnaps {
+		remoteByName[snap.Name] = snap.GUID
+	}
+
+	for i := len(localBookmarks) - 1; i >= 0; i-- {
+		remoteGUID, ok := remoteByName[localBookmarks[i].Name]
+		if !ok {
+			continue
+		}
+		if localBookmarks[i].GUID == "" || remoteGUID == "" || localBookmarks[i].GUID != remoteGUID {
+			return "", fmt.Errorf("replication_bookmark_guid_mismatch:%s", localBookmarks[i].Name)
+		}
+		return localBookmarks[i].Name, nil
+	}
+
+	return "", nil
+}
+
+func (s *Service) listHaBookmarkIdentitiesLocal(ctx context.Context, dataset string) ([]replicationSnapshotIdentity, error) {
+	dataset = normalizeDatasetPath(dataset)
+	if dataset == "" {
+		return []replicationSnapshotIdentity{}, nil
+	}
+
+	output, err := utils.RunCommandWithContext(ctx, "zfs", "list", "-H", "-p", "-t", "bookmark", "-o", "name,guid", "-s", "creation", dataset)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "dataset does not exist") ||
+			strings.Contains(strings.ToLower(err.Error()), "no such") {
+			return []replicationSnapshotIdentity{}, nil
+		}
+		return nil, err
+	}
+
+	return parseReplicationBookmarkIdentities(output, dataset)
+}
+
+// replicationDatasetIsLeaf reports whether dataset has no descendants.
+// Bookmark incrementals cannot be combined with a replication stream (-R),
+// so only leaf datasets may trade source snapshots for bookmarks.
+func (s *Service) replicationDatasetIsLeaf(ctx context.Context, dataset string) (bool, error) {
+	dataset = normalizeDatasetPath(dataset)
+	if dataset == "" {
+		return false, fmt.Errorf("dataset_required")
+	}
+
+	output, err := utils.RunCommandWithContext(ctx, "zfs", "list", "-H", "-r", "-t", "filesystem,volume", "-o", "name", dataset)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", strings.TrimSpace(output), err)
+	}
+	datasets, err := parseReplicationDatasetTree(output, dataset)
+	if err != nil {
+		return false, err
+	}
+	return len(datasets) == 1, nil
+}
+
+// findCommonReplicationIncrementalBase prefers a common snapshot and falls
+// back to a common bookmark for leaf datasets. Bookmark bases are returned
+// with a leading "#" so they can be passed to zfs send -i unchanged.
+func (s *Service) findCommonReplicationIncrementalBase(
+	ctx context.Context,
+	target *clusterModels.BackupTarget,
+	sourceDataset string,
+	targetPath string,
+) (string, error) {
+	commonSnap, err := s.findCommonReplicationSnapshot(ctx, target, sourceDataset, targetPath)
+	if err != nil || commonSnap != "" {
+		return commonSnap, err
+	}
+
+	bookmark, _, err := s.findCommonReplicationBookmark(ctx, target, sourceDataset, targetPath)
+	if err != nil || bookmark == "" {
+		return "", err
+	}
+	return replicationBookmarkSeparator + bookmark, nil
+}
+
+// findCommonReplicationBookmark returns the newest bookmark that can seed an
+// incremental send to targetPath, together with its GUID. It returns an empty
+// name when the source has descendants or no bookmark matches.
+func (s *Service) findCommonReplicationBookmark(
+	ctx context.Context,
+	target *clusterModels.BackupTarget,
+	sourceDataset string,
+	targetPath string,
+) (string, string, error) {
+	leaf, err := s.replicationDatasetIsLeaf(ctx, sourceDataset)
+	if err != nil {
+		return "", "", fmt.Errorf("replication_source_tree_lookup_failed: %w", err)
+	}
+	if !leaf {
+		return "", "", nil
+	}
+
+	localBookmarks, err := s.listHaBookmarkIdentitiesLocal(ctx, sourceDataset)
+	if err != nil {
+		return "", "", fmt.Errorf("list_local_ha_bookmarks_failed: %w", err)
+	}
+	remoteSnaps, err := s.listHaSnapshotIdentitiesRemote(ctx, target, targetPath)
+	if err != nil {
+		return "", "", fmt.Errorf("list_remote_ha_snapshots_failed: %w", err)
+	}
+	bookmark, err := latestCommonReplicationBookmark(localBookmarks, remoteSnaps)
+	if err != nil || bookmark == "" {
+		return "", "", err
+	}
+	guid, err := replicationSnapshotGUID(localBookmarks, bookmark)
+	if err != nil {
+		return "", "", err
+	}
+	return bookmark, guid, nil
+}
+
+func (s *Service) bookmarkLocalSnapshot(ctx context.Context, dataset, snapName string) error {
+	dataset = normalizeDatasetPath(dataset)
+	snapshot := dataset + "@" + snapName
+	bookmark := dataset + replicationBookmarkSeparator + snapName
+	output, err := utils.RunCommandWithContext(ctx, "zfs", "bookmark", snapshot, bookmark)
+	if err != nil {
+		if strings.Contains(strings.ToLower(output), "already exists") {
+			return nil
+		}
+		return fmt.Errorf("%s: %w", strings.TrimSpace(output), err)
+	}
+	return nil
+}
+
+func (s *Service) destroyLocalBookmarkBestEffort(ctx context.Context, dataset, bookmarkName string) error {
+	bookmark := normalizeDatasetPath(dataset) + replicationBookmarkSeparator + bookmarkName
+	output, err := utils.RunCommandWithContext(ctx, "zfs", "destroy", bookmark)
+	if err != nil {
+		lower := strings.ToLower(output)
+		if strings.Contains(lower, "does not exist") || strings.Contains(lower, "no such") {
+			return nil
+		}
+		return fmt.Errorf("%s: %w", strings.TrimSpace(output), err)
+	}
+	return nil
+}
+
+// subtractSnapshotNames returns the names in names that are not in remove,
+// preserving their order.
+func subtractSnapshotNames(names []string, remove []string) []string {
+	drop := make(map[string]struct{}, len(remove))
+	for _, name := range remove {
+		drop[name] = struct{}{}
+	}
+
+	var kept []string
+	for _, name := range names {
+		if _, ok := drop[name]; !ok {
+			kept = append(kept, name)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+
+package zelta
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseReplicationBookmarkIdentitiesFiltersDatasetAndPrefix(t *testing.T) {
+	output := strings.Join([]string{
+		"tank/vm/disk#ha_1\t111",
+		"tank/vm/disk#manual\t222",
+		"tank/vm/disk/child#ha_2\t333",
+		"tank/vm/disk#ha_3\t444",
+	}, "\n")
+
+	got, err := parseReplicationBookmarkIdentities(output, "tank/vm/disk/")
+	if err != nil {
+		t.Fatalf("parse bookmarks: %v", err)
+	}
+	want := []replicationSnapshotIdentity{{Name: "ha_1", GUID: "111"}, {Name: "ha_3", GUID: "444"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected bookmarks: got %#v want %#v", got, want)
+	}
+
+	if _, err := parseReplicationBookmarkIdentities("tank/vm/disk#ha_1\t-", "tank/vm/disk"); err == nil {
+		t.Fatal("expected a bookmark without a GUID to be rejected")
+	}
+}
+
+func TestLatestCommonReplicationBookmarkRequiresMatchingGUID(t *testing.T) {
+	local := []replicationSnapshotIdentity{{Name: "ha_1", GUID: "1"}, {Name: "ha_2", GUID: "2"}}
+
+	got, err := latestCommonReplicationBookmark(local, []replicationSnapshotIdentity{
+		{Name: "ha_1", GUID: "1"},
+		{Name: "ha_2", GUID: "2"},
+		{Name: "ha_3", GUID: "3"},
+	})
+	if err != nil || got != "ha_2" {
+		t.Fatalf("expected newest common bookmark ha_2, got %q (%v)", got, err)
+	}
+
+	if _, err := latestCommonReplicationBookmark(local, []replicationSnapshotIdentity{{Name: "ha_2", GUID: "9"}}); err == nil {
+		t.Fatal("expected a GUID mismatch to break the lineage")
+	}
+
+	got, err = latestCommonReplicationBookmark(local, []replicationSnapshotIdentity{{Name: "ha_9", GUID: "9"}})
+	if err != nil || got != "" {
+		t.Fatalf("expected no common bookmark, got %q (%v)", got, err)
+	}
+}
+
+func TestReplicationZFSSendArgsUseBookmarkBaseWithoutReplicationStream(t *testing.T) {
+	joined := strings.Join(replicationZFSSendArgs("tank/source", "ha_next", "#ha_base", false, nil), " ")
+	if !strings.Contains(joined, "send -P -p -L -c -e -i #ha_base tank/source@ha_next") {
+		t.Fatalf("unexpected bookmark incremental send args: %q", joined)
+	}
+	if strings.Contains(joined, " -R") {
+		t.Fatalf("bookmark incrementals cannot use a replication stream: %q", joined)
+	}
+
+	raw := strings.Join(replicationZFSSendArgs("tank/source", "ha_next", "#ha_base", true, nil), " ")
+	if !strings.Contains(raw, "send --raw -P -p -i #ha_base tank/source@ha_next") {
+		t.Fatalf("unexpected raw bookmark incremental send args: %q", raw)
+	}
+}
+
+func TestSubtractSnapshotNamesPreservesOrder(t *testing.T) {
+	got := subtractSnapshotNames([]string{"ha_1", "ha_2", "ha_3", "ha_4"}, []string{"ha_3", "ha_1"})
+	if want := []string{"ha_2", "ha_4"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected names: got %v want %v", got, want)
+	}
+	if got := subtractSnapshotNames(nil, []string{"ha_1"}); len(got) != 0 {
+		t.Fatalf("expected no names, got %v", got)
+	}
+}
@@ -17,6 +17,7 @@ import (
 
 	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/internal/logger"
 )
 
@@ -190,7 +191,7 @@ func (d vmReplicationGuestDriver) replicationSourceDatasets(ctx context.Context,
 			continue
 		}
 		allowedPools[pool] = struct{}{}
-		addSource(fmt.Sprintf("%s/sylve/virtual-machines/%d", pool, vm.RID))
+		addSource(fmt.Sprintf("%s/%d", layout.VMsDataset(pool), vm.RID))
 	}
 
 	localDatasets, listErr := d.service.listLocalFilesystemDatasets(ctx)
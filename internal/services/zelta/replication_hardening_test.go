@@ -205,6 +205,42 @@ func TestForceFailoverSelectionRequiresCompleteGenerationAndUsesFreshest(t *test
 	}
 }
 
+func TestFailoverSelectionSkipsNodesWithUnfitStorage(t *testing.T) {
+	s := &Service{}
+	policy := &clusterModels.ReplicationPolicy{
+		PoolCapacityPct: 85,
+		Targets: []clusterModels.ReplicationPolicyTarget{
+			{NodeID: "node-degraded", Weight: 300},
+			{NodeID: "node-full", Weight: 200},
+			{NodeID: "node-fit", Weight: 100},
+		},
+	}
+	nodes := map[string]clusterModels.ClusterNode{
+		"node-degraded": {NodeUUID: "node-degraded", Status: "online", Pools: []clusterModels.NodePoolHealth{
+			{Name: "tank", State: "DEGRADED", UsedPct: 10},
+		}},
+		"node-full": {NodeUUID: "node-full", Status: "online", Pools: []clusterModels.NodePoolHealth{
+			{Name: "tank", State: "ONLINE", UsedPct: 92},
+		}},
+		"node-fit": {NodeUUID: "node-fit", Status: "online", Pools: []clusterModels.NodePoolHealth{
+			{Name: "tank", State: "ONLINE", UsedPct: 60},
+		}},
+	}
+
+	got, err := s.selectFailoverTargetWithReadiness(policy, "owner", nodes, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "node-fit" {
+		t.Fatalf("selected %q, want node-fit", got)
+	}
+
+	delete(nodes, "node-fit")
+	if _, err := s.selectFailoverTargetWithReadiness(policy, "owner", nodes, false, false); err == nil {
+		t.Fatal("expected no target when every node's storage is unfit")
+	}
+}
+
 func TestReplicationTargetGenerationRequiresWholeManifest(t *testing.T) {
 	now := time.Now().UTC()
 	target := readyReplicationTarget("node-b", "g1", 7, now, now.Add(time.Hour), 100)
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"testing"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+)
+
+func TestReplicationPausedPolicyBlocksRuns(t *testing.T) {
+	policy := &clusterModels.ReplicationPolicy{
+		Enabled:         true,
+		Paused:          true,
+		ProtectionState: clusterModels.ReplicationProtectionStateDegraded,
+	}
+	if replicationPolicyAllowsRuns(policy) {
+		t.Fatal("paused policy must not be runnable")
+	}
+
+	policy.Paused = false
+	if !replicationPolicyAllowsRuns(policy) {
+		t.Fatal("resumed policy should be runnable again")
+	}
+}
+
+func TestReplicationPolicyPauseResumeThroughRaft(t *testing.T) {
+	cSvc, localNodeID, cleanup := setupRaftClusterService(t)
+	defer cleanup()
+	db := cSvc.DB
+
+	past := time.Now().UTC().Add(-48 * time.Hour)
+	policy := &clusterModels.ReplicationPolicy{
+		ID: 9501, Name: "maintenance", GuestType: clusterModels.ReplicationGuestTypeVM,
+		GuestID: 9601, SourceNodeID: localNodeID,
+		OwnerEpoch: 1, SourceMode: clusterModels.ReplicationSourceModeFollowActive,
+		FailoverMode: clusterModels.ReplicationFailoverManual,
+		Enabled:      true, CronExpr: "0 * * * *", NextRunAt: &past,
+	}
+	if err := clusterModels.UpsertReplicationPolicyTxn(db, policy, nil); err != nil {
+		t.Fatalf("seed policy: %v", err)
+	}
+
+	if err := cSvc.ProposeReplicationPolicyPause(policy.ID, true, "", false); err == nil || err.Error() != "pause_reason_required" {
+		t.Fatalf("expected pause_reason_required, got %v", err)
+	}
+	if err := cSvc.ProposeReplicationPolicyPause(policy.ID, false, "", false); err == nil || err.Error() != "replication_policy_not_paused" {
+		t.Fatalf("expected replication_policy_not_paused, got %v", err)
+	}
+
+	if err := cSvc.ProposeReplicationPolicyPause(policy.ID, true, "target maintenance", false); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+
+	var paused clusterModels.ReplicationPolicy
+	db.First(&paused, policy.ID)
+	if !paused.Paused || paused.PausedReason != "target maintenance" || paused.PausedAt == nil || !paused.Enabled {
+		t.Fatalf("unexpected paused policy: paused=%v reason=%q at=%v enabled=%v",
+			paused.Paused, paused.PausedReason, paused.PausedAt, paused.Enabled)
+	}
+
+	svc := &Service{DB: db, Cluster: cSvc}
+	svc.recordPausedReplicationRunDropped(&paused)
+
+	var event clusterModels.ReplicationEvent
+	if err := db.Where("policy_id = ?", policy.ID).First(&event).Error; err != nil {
+		t.Fatalf("expected a dropped-run event: %v", err)
+	}
+	if event.Status != replicationEventStatusSkipped || event.Error != "target maintenance" || event.CompletedAt == nil {
+		t.Fatalf("unexpected dropped-run event: %+v", event)
+	}
+
+	if err := cSvc.ProposeReplicationPolicyPause(policy.ID, false, "", false); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+
+	var resumed clusterModels.ReplicationPolicy
+	db.First(&resumed, policy.ID)
+	if resumed.Paused || resumed.PausedReason != "" || resumed.PausedAt != nil {
+		t.Fatalf("expected pause state cleared, got %+v", resumed)
+	}
+	if resumed.NextRunAt == nil || !resumed.NextRunAt.After(time.Now().UTC().Add(-time.Minute)) {
+		t.Fatalf("expected NextRunAt re-baselined into the future, got %v", resumed.NextRunAt)
+	}
+}
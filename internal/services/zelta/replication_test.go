@@ -98,6 +98,23 @@ func TestReplicationFailoverRequestMode(t *testing.T) {
 	if replicationFailoverRequestMode("unknown") != replicationFailoverRequestSafe {
 		t.Fatal("unknown should default to safe")
 	}
+	if replicationFailoverRequestMode(" Switchover ") != replicationFailoverRequestSwitchover {
+		t.Fatal("expected switchover")
+	}
+	if replicationFailoverReason(replicationFailoverRequestSwitchover) != "manual_switchover" {
+		t.Fatal("switchover should record its own reason")
+	}
+	if replicationFailoverReason(replicationFailoverRequestForce) != "manual_force_failover" {
+		t.Fatal("expected force failover reason")
+	}
+}
+
+func TestEnqueueReplicationPolicySwitchoverRequiresTarget(t *testing.T) {
+	s := &Service{}
+	if err := s.EnqueueReplicationPolicySwitchover(1, "  "); err == nil ||
+		err.Error() != "replication_switchover_target_required" {
+		t.Fatalf("expected missing target error, got %v", err)
+	}
 }
 
 func TestReplicationGuestKey(t *testing.T) {
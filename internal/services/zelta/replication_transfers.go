@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"strings"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/logger"
+)
+
+const (
+	replicationTransferStatusRunning = "running"
+	replicationTransferStatusSuccess = "success"
+	replicationTransferStatusFailed  = "failed"
+	// replicationTransferStatusSkipped marks a dataset that already held the
+	// run's generation on the target, e.g. when a crashed run is replayed.
+	replicationTransferStatusSkipped = "skipped"
+)
+
+// startReplicationEventTransfer opens the child record for one target/dataset
+// transfer of a run. Records are best effort: a nil return only means the
+// breakdown is missing, never that the transfer should not run.
+func (s *Service) startReplicationEventTransfer(
+	eventID uint,
+	targetNodeID, sourceDataset, targetDataset string,
+) *clusterModels.ReplicationEventTransfer {
+	if s == nil || s.DB == nil || eventID == 0 {
+		return nil
+	}
+
+	transfer := &clusterModels.ReplicationEventTransfer{
+		EventID:       eventID,
+		TargetNodeID:  strings.TrimSpace(targetNodeID),
+		SourceDataset: normalizeDatasetPath(sourceDataset),
+		TargetDataset: normalizeDatasetPath(targetDataset),
+		Status:        replicationTransferStatusRunning,
+		StartedAt:     time.Now().UTC(),
+	}
+	if err := s.DB.Create(transfer).Error; err != nil {
+		logger.L.Warn().Err(err).Uint("event_id", eventID).Msg("create_replication_event_transfer_failed")
+		return nil
+	}
+	return transfer
+}
+
+// finishReplicationEventTransfer closes a transfer record. Byte counts are
+// parsed from the transfer's own zelta output so they are not mixed up with
+// other datasets of the same run.
+func (s *Service) finishReplicationEventTransfer(
+	transfer *clusterModels.ReplicationEventTransfer,
+	status string,
+	output string,
+	transferErr error,
+) {
+	if s == nil || s.DB == nil || transfer == nil || transfer.ID == 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	transfer.Status = status
+	transfer.CompletedAt = &now
+	if moved := parseMovedBytesFromOutput(output); moved != nil {
+		transfer.MovedBytes = moved
+	}
+	if total := parseTotalBytesFromOutput(output); total != nil {
+		transfer.TotalBytes = total
+	}
+	transfer.Error = ""
+	if transferErr != nil {
+		transfer.Error = transferErr.Error()
+	}
+
+	if err := s.DB.Model(&clusterModels.ReplicationEventTransfer{}).
+		Where("id = ?", transfer.ID).
+		Updates(map[string]any{
+			"status":       transfer.Status,
+			"moved_bytes":  transfer.MovedBytes,
+			"total_bytes":  transfer.TotalBytes,
+			"error":        transfer.Error,
+			"completed_at": transfer.CompletedAt,
+		}).Error; err != nil {
+		logger.L.Warn().Err(err).Uint("transfer_id", transfer.ID).Msg("finish_replication_event_transfer_failed")
+	}
+}
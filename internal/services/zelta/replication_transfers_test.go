@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+
+package zelta
+
+import (
+	"fmt"
+	"testing"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+)
+
+func TestReplicationEventTransferLifecycle(t *testing.T) {
+	db := newZeltaServiceTestDB(t, &clusterModels.ReplicationEventTransfer{})
+	svc := newTestZeltaService(db)
+
+	if svc.startReplicationEventTransfer(0, "node-b", "zroot/sylve/virtual-machines/100", "") != nil {
+		t.Fatal("expected no record without a parent event")
+	}
+
+	ok := svc.startReplicationEventTransfer(7, " node-b ", "zroot/sylve/virtual-machines/100/", "zroot/sylve/virtual-machines/100")
+	failed := svc.startReplicationEventTransfer(7, "node-c", "zroot/sylve/virtual-machines/100", "zroot/sylve/virtual-machines/100")
+	if ok == nil || failed == nil {
+		t.Fatal("expected transfer records")
+	}
+	if ok.Status != replicationTransferStatusRunning || ok.TargetNodeID != "node-b" || ok.SourceDataset != "zroot/sylve/virtual-machines/100" {
+		t.Fatalf("unexpected started record %+v", ok)
+	}
+
+	svc.finishReplicationEventTransfer(ok, replicationTransferStatusSuccess, `{"replicationSize":"2048"}`+"\n2KiB sent\n", nil)
+	svc.finishReplicationEventTransfer(failed, replicationTransferStatusFailed, "", fmt.Errorf("ssh_unreachable"))
+
+	var records []clusterModels.ReplicationEventTransfer
+	if err := db.Where("event_id = ?", 7).Order("id ASC").Find(&records).Error; err != nil {
+		t.Fatalf("list transfers: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected two transfers, got %d", len(records))
+	}
+	if records[0].Status != replicationTransferStatusSuccess || records[0].CompletedAt == nil ||
+		records[0].TotalBytes == nil || *records[0].TotalBytes != 2048 ||
+		records[0].MovedBytes == nil || *records[0].MovedBytes != 2048 {
+		t.Fatalf("unexpected successful transfer %+v", records[0])
+	}
+	if records[1].Status != replicationTransferStatusFailed || records[1].Error != "ssh_unreachable" || records[1].MovedBytes != nil {
+		t.Fatalf("unexpected failed transfer %+v", records[1])
+	}
+}
@@ -55,6 +55,8 @@ type ReplicationZFSTransferOptions struct {
 	SnapshotGUID           string
 	SnapshotAlreadyCreated bool
 	GenerationName         string
+	// ZeltaProfile supplies the zfs send options of the policy's profile.
+	ZeltaProfile *clusterModels.ZeltaProfile
 }
 
 // ReplicationStagedTransferResult describes a completed, verified staging
@@ -216,7 +218,7 @@ func (s *Service) CreateReplicationSnapshotGroup(
 		return err
 	}
 	seen := make(map[string]struct{}, len(sourceDatasets))
-	args := []string{"snapshot", "-r"}
+	roots := make([]string, 0, len(sourceDatasets))
 	for _, sourceDataset := range sourceDatasets {
 		sourceDataset = normalizeDatasetPath(sourceDataset)
 		if sourceDataset == "" {
@@ -226,11 +228,27 @@ func (s *Service) CreateReplicationSnapshotGroup(
 			continue
 		}
 		seen[sourceDataset] = struct{}{}
-		args = append(args, sourceDataset+"@"+snapshotName)
+		roots = append(roots, sourceDataset)
 	}
-	if len(args) == 2 {
+	if len(roots) == 0 {
 		return fmt.Errorf("source_datasets_required")
 	}
+
+	// A channel program takes and tags the whole group in one txg, so a
+	// snapshot carrying the group property is always part of a complete
+	// generation. Hosts without channel program support fall back to a
+	// single zfs snapshot call, which is atomic but untagged.
+	handled, err := snapshotGroupAtomic(ctx, roots, snapshotName, true, map[string]string{
+		replicationSnapshotGroupProperty: strings.Join(roots, ","),
+	})
+	if handled {
+		return err
+	}
+
+	args := []string{"snapshot", "-r"}
+	for _, root := range roots {
+		args = append(args, root+"@"+snapshotName)
+	}
 	output, err := utils.RunCommandWithContext(ctx, "zfs", args...)
 	if err != nil {
 		return fmt.Errorf("%s: %w", strings.TrimSpace(output), err)
@@ -246,6 +264,7 @@ func (s *Service) replicationZFSSendPreparedToPath(
 	targetPath string,
 	snapshotName string,
 	receiveProperties map[string]string,
+	profile *clusterModels.ZeltaProfile,
 	allowProvenForce bool,
 	authorizeProvenForce func() error,
 	resetProvenDataset func() error,
@@ -289,7 +308,7 @@ func (s *Service) replicationZFSSendPreparedToPath(
 
 	commonSnap, encrypted, err := prepareReplicationTransferMetadata(
 		func() (string, error) {
-			return s.findCommonReplicationSnapshot(ctx, target, sourceDataset, targetPath)
+			return s.findCommonReplicationIncrementalBase(ctx, target, sourceDataset, targetPath)
 		},
 		func() (bool, error) {
 			return s.isDatasetEncrypted(ctx, sourceDataset)
@@ -318,6 +337,7 @@ func (s *Service) replicationZFSSendPreparedToPath(
 						encrypted,
 						forceRecv,
 						receiveProperties,
+						profile.SendOptions(encrypted),
 					)
 				},
 				Abort: func() (string, error) {
@@ -503,11 +523,12 @@ func (s *Service) runReplicationPipelineWithProperties(
 	encrypted bool,
 	forceRecv bool,
 	receiveProperties map[string]string,
+	sendOptions []string,
 ) (string, error) {
 	if forceRecv && !hasCompleteReplicationProvenance(receiveProperties) {
 		return "", fmt.Errorf("replication_force_receive_provenance_required")
 	}
-	sendArgs := replicationZFSSendArgs(sourceDataset, snapName, commonSnap, encrypted)
+	sendArgs := replicationZFSSendArgs(sourceDataset, snapName, commonSnap, encrypted, sendOptions)
 
 	sshArgs := s.buildSSHArgs(target)
 	recvArgs := make([]string, 0, len(sshArgs)+10)
@@ -533,9 +554,12 @@ func (s *Service) runReplicationPipelineWithProperties(
 	sendCmd := exec.CommandContext(ctx, "zfs", sendArgs...)
 	recvCmd := exec.CommandContext(ctx, "ssh", recvArgs...)
 
+	lease := s.transfers.acquire(target, transferClassReplication)
+	defer lease.release()
+
 	pr, pw := io.Pipe()
 	sendCmd.Stdout = pw
-	recvCmd.Stdin = pr
+	recvCmd.Stdin = lease.reader(pr)
 
 	var sendStderr bytes.Buffer
 	var recvStderr bytes.Buffer
@@ -589,19 +613,36 @@ func (s *Service) runReplicationPipelineWithProperties(
 	return combined.String(), nil
 }
 
+// replicationZFSSendArgs builds the zfs send command line. sendOptions, from a
+// zelta profile, replace the stream options after the -P and stream flags;
+// nil keeps the defaults.
 func replicationZFSSendArgs(
 	sourceDataset string,
 	snapshotName string,
 	commonSnapshot string,
 	encrypted bool,
+	sendOptions []string,
 ) []string {
-	var args []string
-	if encrypted {
-		args = append(args, "send", "--raw", "-P", "-R")
-	} else {
-		args = append(args, "send", "-P", "-R", "-L", "-c", "-e")
+	// A bookmark cannot be the incremental source of a replication stream,
+	// so bookmark bases send the leaf dataset with its properties instead.
+	commonSnapshot = strings.TrimSpace(commonSnapshot)
+	streamFlag := "-R"
+	if isReplicationBookmarkBase(commonSnapshot) {
+		streamFlag = "-p"
 	}
-	if commonSnapshot = strings.TrimSpace(commonSnapshot); commonSnapshot != "" {
+
+	var args []string
+	switch {
+	case sendOptions != nil:
+		args = append(append(args, "send", "-P", streamFlag), sendOptions...)
+	case encrypted:
+		args = append(args, "send", "--raw", "-P", streamFlag)
+	default:
+		args = append(args, "send", "-P", streamFlag, "-L", "-c", "-e")
+	}
+	if isReplicationBookmarkBase(commonSnapshot) {
+		args = append(args, "-i", commonSnapshot)
+	} else if commonSnapshot != "" {
 		args = append(args, "-i", "@"+commonSnapshot)
 	}
 	return append(args, normalizeDatasetPath(sourceDataset)+"@"+strings.TrimSpace(snapshotName))
@@ -1870,30 +1911,52 @@ func (s *Service) trySeedFreshReplicationStaging(
 	if commonErr != nil {
 		return result, fmt.Errorf("replication_staging_seed_common_snapshot_lookup_failed: %w", commonErr)
 	}
-	if commonSnapshot == "" {
-		result.Output = "replication_staging_seed_skipped:no_common_snapshot"
-		return result, nil
-	}
-	localIdentities, lookupErr := s.listHaSnapshotIdentitiesLocal(ctx, sourceDataset)
-	if lookupErr != nil {
-		return result, fmt.Errorf("replication_staging_seed_source_snapshot_lookup_failed: %w", lookupErr)
-	}
-	commonGUID, guidErr := replicationSnapshotGUID(localIdentities, commonSnapshot)
-	if guidErr != nil {
-		return result, fmt.Errorf("replication_staging_seed_source_snapshot_guid_failed: %w", guidErr)
-	}
-	localTree, treeErr := s.replicationSnapshotTreeManifestLocal(
-		ctx,
-		sourceDataset,
-		sourceDataset,
-		commonSnapshot,
+	var (
+		commonGUID string
+		localTree  []ReplicationSnapshotManifestEntry
 	)
-	if treeErr != nil {
-		if isReplicationSnapshotTreeGenerationMismatch(treeErr) {
-			result.Output = "replication_staging_seed_skipped:recursive_tree_mismatch"
+	if commonSnapshot == "" {
+		// The source may have traded the common snapshot for a bookmark. A
+		// leaf bookmark still proves the lineage of the target snapshot.
+		bookmark, bookmarkGUID, bookmarkErr := s.findCommonReplicationBookmark(ctx, target, sourceDataset, targetDataset)
+		if bookmarkErr != nil {
+			return result, fmt.Errorf("replication_staging_seed_common_bookmark_lookup_failed: %w", bookmarkErr)
+		}
+		if bookmark == "" {
+			result.Output = "replication_staging_seed_skipped:no_common_snapshot"
 			return result, nil
 		}
-		return result, fmt.Errorf("replication_staging_seed_source_tree_failed: %w", treeErr)
+		commonSnapshot = bookmark
+		commonGUID = bookmarkGUID
+		localTree = []ReplicationSnapshotManifestEntry{{
+			SourceDataset: sourceDataset,
+			SnapshotName:  bookmark,
+			SnapshotGUID:  bookmarkGUID,
+		}}
+	} else {
+		localIdentities, lookupErr := s.listHaSnapshotIdentitiesLocal(ctx, sourceDataset)
+		if lookupErr != nil {
+			return result, fmt.Errorf("replication_staging_seed_source_snapshot_lookup_failed: %w", lookupErr)
+		}
+		var guidErr error
+		commonGUID, guidErr = replicationSnapshotGUID(localIdentities, commonSnapshot)
+		if guidErr != nil {
+			return result, fmt.Errorf("replication_staging_seed_source_snapshot_guid_failed: %w", guidErr)
+		}
+		var treeErr error
+		localTree, treeErr = s.replicationSnapshotTreeManifestLocal(
+			ctx,
+			sourceDataset,
+			sourceDataset,
+			commonSnapshot,
+		)
+		if treeErr != nil {
+			if isReplicationSnapshotTreeGenerationMismatch(treeErr) {
+				result.Output = "replication_staging_seed_skipped:recursive_tree_mismatch"
+				return result, nil
+			}
+			return result, fmt.Errorf("replication_staging_seed_source_tree_failed: %w", treeErr)
+		}
 	}
 	remoteTree, treeErr := s.replicationSnapshotTreeManifestRemote(
 		ctx,
@@ -2263,6 +2326,7 @@ func (s *Service) ReplicationZFSSendStaged(
 		stagingDataset,
 		opts.SnapshotName,
 		receiveProperties,
+		opts.ZeltaProfile,
 		true,
 		func() error {
 			return s.verifyExistingStagingDataset(
@@ -2767,48 +2831,91 @@ func (s *Service) retainReplicationSnapshots(
 	sourceDataset string,
 	destSuffix string,
 	keep int,
-) error {
+	sourceBookmarks bool,
+) (int, error) {
 	if keep <= 0 {
 		keep = defaultReplicationPruneKeepLast
 	}
 
 	sourceSnaps, err := s.listHaSnapshotsLocal(ctx, sourceDataset)
 	if err != nil {
-		return fmt.Errorf("list_source_snapshots_failed: %w", err)
+		return 0, fmt.Errorf("list_source_snapshots_failed: %w", err)
 	}
 
 	targetPath := targetDatasetPath(target.BackupRoot, destSuffix)
 	targetSnaps, err := s.listHaSnapshotsRemote(ctx, target, targetPath)
 	if err != nil {
-		return fmt.Errorf("list_target_snapshots_failed: %w", err)
+		return 0, fmt.Errorf("list_target_snapshots_failed: %w", err)
 	}
 
+	var errs []string
+	pruned := 0
 	common := intersectSnapshotNames(sourceSnaps, targetSnaps)
-	if len(common) <= keep {
-		return nil
-	}
 
-	stale := common[:len(common)-keep]
-	var errs []string
-	for _, snap := range stale {
-		if err := s.destroyLocalSnapshotBestEffort(ctx, sourceDataset, snap); err != nil {
-			errs = append(errs, fmt.Sprintf("destroy_source_%s_failed: %v", snap, err))
+	// Policies that opt in let leaf sources convert aged snapshots to
+	// bookmarks, which keep them usable as incremental bases without pinning
+	// blocks on the source. Everything else keeps the configured count.
+	sourceKeep := keep
+	leaf, leafErr := s.replicationDatasetIsLeaf(ctx, sourceDataset)
+	if leafErr != nil {
+		errs = append(errs, fmt.Sprintf("source_tree_lookup_failed: %v", leafErr))
+	}
+	bookmarkSource := sourceBookmarks && leaf
+	if bookmarkSource && sourceKeep > defaultReplicationSourceBookmarkKeepLast {
+		sourceKeep = defaultReplicationSourceBookmarkKeepLast
+	}
+
+	if len(common) > sourceKeep {
+		for _, snap := range common[:len(common)-sourceKeep] {
+			if bookmarkSource {
+				if err := s.bookmarkLocalSnapshot(ctx, sourceDataset, snap); err != nil {
+					errs = append(errs, fmt.Sprintf("bookmark_source_%s_failed: %v", snap, err))
+					continue
+				}
+			}
+			if err := s.destroyLocalSnapshotBestEffort(ctx, sourceDataset, snap); err != nil {
+				errs = append(errs, fmt.Sprintf("destroy_source_%s_failed: %v", snap, err))
+			} else {
+				pruned++
+			}
 		}
 	}
 
-	if target != nil {
+	var stale []string
+	if len(common) > keep {
+		stale = common[:len(common)-keep]
+	}
+
+	if target != nil && len(stale) > 0 {
 		for _, snap := range stale {
 			if err := s.destroyRemoteSnapshotBestEffort(ctx, target, targetPath, snap); err != nil {
 				errs = append(errs, fmt.Sprintf("destroy_target_%s_failed: %v", snap, err))
+			} else {
+				pruned++
+			}
+		}
+	}
+
+	if leaf {
+		bookmarks, err := s.listHaBookmarkIdentitiesLocal(ctx, sourceDataset)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("list_source_bookmarks_failed: %v", err))
+		} else {
+			// A bookmark is only useful while its snapshot is on the target.
+			retained := subtractSnapshotNames(targetSnaps, stale)
+			for _, bookmark := range subtractSnapshotNames(snapshotIdentityNames(bookmarks), retained) {
+				if err := s.destroyLocalBookmarkBestEffort(ctx, sourceDataset, bookmark); err != nil {
+					errs = append(errs, fmt.Sprintf("destroy_source_bookmark_%s_failed: %v", bookmark, err))
+				}
 			}
 		}
 	}
 
 	if len(errs) > 0 {
-		return fmt.Errorf("replication_retention_failed: %s", strings.Join(errs, "; "))
+		return pruned, fmt.Errorf("replication_retention_failed: %s", strings.Join(errs, "; "))
 	}
 
-	return nil
+	return pruned, nil
 }
 
 func intersectSnapshotNames(a, b []string) []string {
@@ -9,6 +9,8 @@ import (
 	"os/exec"
 	"strings"
 	"testing"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
 )
 
 func requireValidShellScript(t *testing.T, script string) {
@@ -530,7 +532,7 @@ func TestDestroyPreviousReplicationScriptGuardsBeforeDestroy(t *testing.T) {
 }
 
 func TestReplicationZFSSendArgsUseProvenIncrementalBase(t *testing.T) {
-	args := replicationZFSSendArgs("tank/source", "ha_next", "ha_base", true)
+	args := replicationZFSSendArgs("tank/source", "ha_next", "ha_base", true, nil)
 	joined := strings.Join(args, " ")
 	for _, expected := range []string{
 		"send --raw -P -R",
@@ -545,7 +547,7 @@ func TestReplicationZFSSendArgsUseProvenIncrementalBase(t *testing.T) {
 		t.Fatalf("encrypted replication must remain raw: %q", joined)
 	}
 
-	fullArgs := strings.Join(replicationZFSSendArgs("tank/source", "ha_first", "", false), " ")
+	fullArgs := strings.Join(replicationZFSSendArgs("tank/source", "ha_first", "", false, nil), " ")
 	if strings.Contains(fullArgs, " -i ") {
 		t.Fatalf("an unproven baseline must produce a full send: %q", fullArgs)
 	}
@@ -554,6 +556,22 @@ func TestReplicationZFSSendArgsUseProvenIncrementalBase(t *testing.T) {
 	}
 }
 
+func TestReplicationZFSSendArgsUseProfileOptions(t *testing.T) {
+	profile := &clusterModels.ZeltaProfile{Env: map[string]string{
+		"ZELTA_SEND_DEFAULT": "-L -c",
+		"ZELTA_SEND_RAW":     "-w -p",
+	}}
+
+	plain := strings.Join(replicationZFSSendArgs("tank/source", "ha_next", "ha_base", false, profile.SendOptions(false)), " ")
+	if plain != "send -P -R -L -c -i @ha_base tank/source@ha_next" {
+		t.Fatalf("unexpected profile send args: %q", plain)
+	}
+	raw := strings.Join(replicationZFSSendArgs("tank/source", "ha_next", "", true, profile.SendOptions(true)), " ")
+	if raw != "send -P -R -w -p tank/source@ha_next" {
+		t.Fatalf("unexpected profile raw send args: %q", raw)
+	}
+}
+
 func TestSeedReplicationStagingScriptValidatesBeforeCopyAndSupportsRaw(t *testing.T) {
 	opts := ReplicationZFSTransferOptions{
 		PolicyID:     7,
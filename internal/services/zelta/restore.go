@@ -19,6 +19,7 @@ import (
 
 	"github.com/alchemillahq/sylve/internal/db"
 	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
 	"github.com/alchemillahq/sylve/internal/db/replicationguard"
 	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/internal/services/cluster"
@@ -49,6 +50,9 @@ type restoreJobPayload struct {
 	JobID         uint   `json:"job_id"`
 	Snapshot      string `json:"snapshot"` // e.g. "@zelta_2026-02-18_12.00.00"
 	RemoteDataset string `json:"remote_dataset,omitempty"`
+	// PostRestoreScript replaces the guest's saved post-restore script for
+	// this restore.
+	PostRestoreScript string `json:"post_restore_script,omitempty"`
 }
 
 // ListRemoteSnapshots SSHs to the backup target and lists snapshots for a job's destination dataset.
@@ -88,10 +92,15 @@ func (s *Service) ListRemoteSnapshots(ctx context.Context, job *clusterModels.Ba
 }
 
 // EnqueueRestoreJob enqueues a restore job for async execution via goqite.
-func (s *Service) EnqueueRestoreJob(ctx context.Context, jobID uint, snapshot string) error {
+func (s *Service) EnqueueRestoreJob(ctx context.Context, jobID uint, snapshot string, postRestoreScript string) error {
 	if jobID == 0 {
 		return fmt.Errorf("invalid_job_id")
 	}
+	if postRestoreScript != "" {
+		if err := validatePostRestoreScriptBody(postRestoreScript); err != nil {
+			return err
+		}
+	}
 
 	snapshot = strings.TrimSpace(snapshot)
 	if snapshot == "" {
@@ -119,9 +128,10 @@ func (s *Service) EnqueueRestoreJob(ctx context.Context, jobID uint, snapshot st
 	s.releaseJob(jobID)
 
 	return db.EnqueueJSON(ctx, restoreJobQueueName, restoreJobPayload{
-		JobID:         jobID,
-		Snapshot:      normalizedSnapshot,
-		RemoteDataset: remoteDataset,
+		JobID:             jobID,
+		Snapshot:          normalizedSnapshot,
+		RemoteDataset:     remoteDataset,
+		PostRestoreScript: postRestoreScript,
 	})
 }
 
@@ -131,11 +141,13 @@ func (s *Service) EnqueueRestoreJob(ctx context.Context, jobID uint, snapshot st
 //  3. Archive the original dataset by renaming it (when present)
 //  4. Rename temp → original path and activate it
 //  5. Remove only the ownership-proven archive with ordinary recursive cleanup
+//  6. Run the guest's post-restore script, if any
 func (s *Service) runRestoreJob(
 	ctx context.Context,
 	job *clusterModels.BackupJob,
 	snapshot string,
 	remoteDataset string,
+	postRestoreScript string,
 ) (retErr error) {
 	if !s.acquireJob(job.ID) {
 		return fmt.Errorf("backup_job_already_running")
@@ -163,6 +175,8 @@ func (s *Service) runRestoreJob(
 		)
 	}
 	defer s.releaseRestoreDestination(sourceDataset)
+	journal := s.journalBegin(taskModels.JournalKindRestoreJob, job.ID, job.TargetID, sourceDataset)
+	defer journal.done()
 	restoreWorkloadType, restoreWorkloadID := backupJobGuestIdentity(job)
 	if restoreWorkloadType == "" && restoreWorkloadID == 0 {
 		restoreWorkloadType = clusterModels.BackupJobModeDataset
@@ -189,7 +203,7 @@ func (s *Service) runRestoreJob(
 	}
 
 	if job.Mode == clusterModels.BackupJobModeVM {
-		return s.runRestoreVMJob(ctx, job, snapshot, remoteDataset, sourceDataset)
+		return s.runRestoreVMJob(ctx, job, snapshot, remoteDataset, sourceDataset, postRestoreScript)
 	}
 
 	if strings.TrimSpace(remoteDataset) == "" {
@@ -236,9 +250,11 @@ func (s *Service) runRestoreJob(
 	// e.g. root@192.168.180.1:zroot/sylve-backups/jails/105@zelta_2026-02-18_12.00.00
 	remoteEndpoint := job.Target.SSHHost + ":" + remoteDataset + snapshot
 
-	// The temp local dataset for receiving the restore sits next to the original:
-	// e.g. zroot/sylve/jails/105 → zroot/sylve/jails/105.restoring
-	restorePath := sourceDataset + ".restoring"
+	// The temp local dataset for receiving the restore sits next to the original,
+	// e.g. zroot/sylve/jails/105 → zroot/sylve/jails/105.restoring, unless a
+	// scratch dataset is configured for staging.
+	staging := s.planRestoreStaging(sourceDataset)
+	restorePath := staging.Pull
 	stagingIdentity := newRestoreStagingIdentity(&job.ID, job.TargetID, sourceDataset)
 
 	// Pre-flight: ensure the destination pool exists before pulling data.
@@ -301,11 +317,15 @@ func (s *Service) runRestoreJob(
 			}
 		}()
 	}
-	if err := s.prepareRestoreStagingDataset(ctx, restorePath, stagingIdentity); err != nil {
+	if err := s.prepareRestoreStaging(ctx, staging, stagingIdentity); err != nil {
 		restoreErr := fmt.Errorf("restore_preflight_staging_check_failed: %w", err)
 		s.finalizeRestoreEvent(&event, restoreErr, "")
 		return restoreErr
 	}
+	if err := s.checkRestoreStagingSpace(ctx, &job.Target, remoteDataset, snapshot, restoreRecursive, staging); err != nil {
+		s.finalizeRestoreEvent(&event, err, "")
+		return err
+	}
 
 	logger.L.Info().
 		Uint("job_id", job.ID).
@@ -323,17 +343,30 @@ func (s *Service) runRestoreJob(
 	// Override recv flags: skip readonly=on (RECV_TOP default) so the restored
 	// dataset is writable. Setting RECV_TOP=no makes zelta treat it as falsy (0),
 	// which arr_join() skips. RECV_FS keeps its default flags.
-	extraEnv := s.buildZeltaEnv(&job.Target)
+	lease := s.transfers.acquire(&job.Target, transferClassRestore)
+	defer lease.release()
+
+	extraEnv := lease.zeltaEnv(s.buildZeltaEnv(&job.Target))
 	receiveTopOptions, err := stagingIdentity.receiveTopOptions()
 	if err != nil {
 		restoreErr = err
 		s.finalizeRestoreEvent(&event, restoreErr, output)
 		return restoreErr
 	}
+	zeltaProfile, err := s.loadZeltaProfile(job.ZeltaProfileID)
+	if err != nil {
+		restoreErr = err
+		s.finalizeRestoreEvent(&event, restoreErr, output)
+		return restoreErr
+	}
 	extraEnv = setEnvValue(extraEnv, "ZELTA_RECV_TOP", receiveTopOptions)
 	extraEnv = setEnvValue(extraEnv, "ZELTA_LOG_LEVEL", "3")
+	// The staging receive options carry the restore's identity, so a profile
+	// cannot replace them.
+	extraEnv = withZeltaProfile(extraEnv, zeltaProfile, "ZELTA_RECV_TOP")
 
 	restoreArgs := restoreZeltaArgs(remoteEndpoint, restorePath, restoreRecursive)
+	journal.transfer(sourceDataset, "")
 	output, restoreErr = runZeltaWithEnvStreaming(
 		ctx,
 		extraEnv,
@@ -369,6 +402,12 @@ func (s *Service) runRestoreJob(
 		return restoreErr
 	}
 	logger.L.Debug().Str("verify", restorePath).Msg("restore_dataset_verified")
+	if err := s.moveRestoreStaging(ctx, staging, snapshot, stagingIdentity); err != nil {
+		restoreErr = s.cleanupOwnedRestoreStagingAfterError(restorePath, stagingIdentity, err)
+		s.finalizeRestoreEvent(&event, restoreErr, output)
+		return restoreErr
+	}
+	restorePath = staging.Promote
 	if err := s.verifyRestoreManifest(
 		ctx,
 		restorePath,
@@ -407,6 +446,8 @@ func (s *Service) runRestoreJob(
 		_ = s.ensureLocalFilesystemPath(ctx, parent)
 	}
 
+	journal.phase(taskModels.JournalPhasePromote, sourceDataset)
+
 	// Step 4: Promote the staged dataset into place. A jail was stopped before
 	// staging began and remains fenced until this restore is fully finalized.
 	backupDataset := ""
@@ -556,6 +597,12 @@ func (s *Service) runRestoreJob(
 		}
 		jailRestoreFence = nil
 	}
+	if restoreWorkloadType == clusterModels.BackupJobModeJail {
+		output = appendRestoreOutput(
+			output,
+			s.runPostRestoreScript(ctx, restoreWorkloadType, restoreWorkloadID, postRestoreScript),
+		)
+	}
 
 	s.finalizeRestoreEvent(&event, nil, output)
 
@@ -1048,6 +1095,7 @@ func (s *Service) runRestoreVMJob(
 	snapshot string,
 	remoteDataset string,
 	sourceDataset string,
+	postRestoreScript string,
 ) error {
 	if strings.TrimSpace(remoteDataset) == "" {
 		remoteDataset = remoteDatasetForJob(job)
@@ -1068,6 +1116,7 @@ func (s *Service) runRestoreVMJob(
 		Snapshot:           strings.TrimSpace(snapshot),
 		DestinationDataset: normalizeRestoreDestinationDataset(sourceDataset),
 		RestoreNetwork:     &restoreNetwork,
+		PostRestoreScript:  postRestoreScript,
 	}
 
 	jobID := job.ID
@@ -1278,7 +1327,23 @@ func (s *Service) registerRestoreJob() {
 			return nil
 		}
 
-		if err := s.runRestoreJob(ctx, &job, payload.Snapshot, payload.RemoteDataset); err != nil {
+		restoreDataset := job.SourceDataset
+		if job.Mode == clusterModels.BackupJobModeJail {
+			restoreDataset = job.JailRootDataset
+		}
+		release, err := s.waitForHeavyOpSlot(
+			ctx,
+			fmt.Sprintf("restore-job:%d", job.ID),
+			fmt.Sprintf("restore job %d", job.ID),
+			restoreDataset,
+		)
+		if err != nil {
+			logger.L.Warn().Err(err).Uint("job_id", payload.JobID).Msg("queued_restore_job_slot_wait_failed")
+			return nil
+		}
+		defer release()
+
+		if err := s.runRestoreJob(ctx, &job, payload.Snapshot, payload.RemoteDataset, payload.PostRestoreScript); err != nil {
 			logger.L.Warn().Err(err).Uint("job_id", payload.JobID).Msg("queued_restore_job_failed")
 			return nil
 		}
@@ -0,0 +1,630 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/db"
+	"github.com/alchemillahq/sylve/internal/db/models"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/layout"
+	"github.com/alchemillahq/sylve/internal/logger"
+	notifier "github.com/alchemillahq/sylve/internal/notifications"
+	"gorm.io/gorm"
+)
+
+// A bulk restore is the workflow after losing a whole host: every guest that
+// still has an active lineage on a backup target is a candidate, the operator
+// picks a set, and the guests are restored onto this node in the chosen order
+// with a few running at once. Each guest goes through runRestoreFromTarget, so
+// the guards of a single out-of-band restore (guest ID checks, destination
+// locks, heavy-op slots, the task journal) all still apply.
+
+const (
+	defaultBulkRestoreConcurrency = 2
+	maxBulkRestoreConcurrency     = 4
+
+	errBulkRestoreCancelled = "bulk_restore_cancelled"
+)
+
+// BulkRestoreCandidate is a guest with an active lineage on a backup target.
+// SourcePool is the pool the guest lived on, when the backup path records it.
+type BulkRestoreCandidate struct {
+	Kind          string `json:"kind"`
+	GuestID       uint   `json:"guestId"`
+	RemoteDataset string `json:"remoteDataset"`
+	SourcePool    string `json:"sourcePool"`
+	SnapshotCount int    `json:"snapshotCount"`
+	Encrypted     bool   `json:"encrypted"`
+	Used          uint64 `json:"used"`
+}
+
+// ListBulkRestoreCandidates returns one entry per guest found on the target.
+func (s *Service) ListBulkRestoreCandidates(ctx context.Context, targetID uint) ([]BulkRestoreCandidate, error) {
+	datasets, err := s.ListRemoteTargetDatasets(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	return bulkRestoreCandidates(datasets), nil
+}
+
+// bulkRestoreCandidates keeps the guest root datasets of active lineages.
+// When several backup jobs hold the same guest, the lineage with the most
+// snapshots wins.
+func bulkRestoreCandidates(datasets []BackupTargetDatasetInfo) []BulkRestoreCandidate {
+	byGuest := make(map[string]BulkRestoreCandidate)
+	for _, dataset := range datasets {
+		if dataset.Lineage != "active" || dataset.OutOfBand {
+			continue
+		}
+		if dataset.Kind != clusterModels.BackupJobModeJail && dataset.Kind != clusterModels.BackupJobModeVM {
+			continue
+		}
+
+		parts := strings.Split(normalizeDatasetPath(dataset.BaseSuffix), "/")
+		n := len(parts)
+		if n < 2 || parts[n-1] == "" {
+			continue
+		}
+		segment := layout.JailsName()
+		if dataset.Kind == clusterModels.BackupJobModeVM {
+			segment = layout.VirtualMachinesName()
+		}
+		guestID, err := strconv.ParseUint(parts[n-1], 10, 64)
+		if parts[n-2] != segment || err != nil || guestID == 0 {
+			continue
+		}
+
+		sourcePool := ""
+		if n >= 4 && parts[n-3] == layout.RootName() {
+			sourcePool = parts[n-4]
+		}
+
+		candidate := BulkRestoreCandidate{
+			Kind:          dataset.Kind,
+			GuestID:       uint(guestID),
+			RemoteDataset: dataset.Name,
+			SourcePool:    sourcePool,
+			SnapshotCount: dataset.SnapshotCount,
+			Encrypted:     dataset.Encrypted,
+			Used:          dataset.Used,
+		}
+
+		key := fmt.Sprintf("%s/%d", candidate.Kind, candidate.GuestID)
+		if existing, ok := byGuest[key]; ok {
+			if existing.SnapshotCount > candidate.SnapshotCount ||
+				(existing.SnapshotCount == candidate.SnapshotCount && existing.RemoteDataset < candidate.RemoteDataset) {
+				continue
+			}
+		}
+		byGuest[key] = candidate
+	}
+
+	candidates := make([]BulkRestoreCandidate, 0, len(byGuest))
+	for _, candidate := range byGuest {
+		candidates = append(candidates, candidate)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Kind != candidates[j].Kind {
+			return candidates[i].Kind < candidates[j].Kind
+		}
+		return candidates[i].GuestID < candidates[j].GuestID
+	})
+	return candidates
+}
+
+// buildBulkRestoreItems turns the operator's selection into items in the
+// order it was given. Pool overrides the pool every guest is restored to.
+func buildBulkRestoreItems(
+	candidates []BulkRestoreCandidate,
+	guests []clusterServiceInterfaces.BulkRestoreGuestReq,
+	pool string,
+) ([]clusterModels.BulkRestoreItem, error) {
+	byDataset := make(map[string]BulkRestoreCandidate, len(candidates))
+	for _, candidate := range candidates {
+		byDataset[normalizeDatasetPath(candidate.RemoteDataset)] = candidate
+	}
+
+	pool = normalizeDatasetPath(pool)
+	seen := make(map[string]struct{}, len(guests))
+	items := make([]clusterModels.BulkRestoreItem, 0, len(guests))
+	for i, guest := range guests {
+		remoteDataset := normalizeDatasetPath(guest.RemoteDataset)
+		candidate, ok := byDataset[remoteDataset]
+		if !ok {
+			return nil, fmt.Errorf("bulk_restore_guest_not_found: %s", remoteDataset)
+		}
+
+		key := fmt.Sprintf("%s/%d", candidate.Kind, candidate.GuestID)
+		if _, dup := seen[key]; dup {
+			return nil, fmt.Errorf("bulk_restore_duplicate_guest: %s %d", candidate.Kind, candidate.GuestID)
+		}
+		seen[key] = struct{}{}
+
+		destinationPool := pool
+		if destinationPool == "" {
+			destinationPool = candidate.SourcePool
+		}
+		if destinationPool == "" {
+			return nil, fmt.Errorf("bulk_restore_pool_required: %s", remoteDataset)
+		}
+
+		destination := layout.JailDataset(destinationPool, candidate.GuestID)
+		if candidate.Kind == clusterModels.BackupJobModeVM {
+			destination = layout.VMDataset(destinationPool, candidate.GuestID)
+		}
+
+		items = append(items, clusterModels.BulkRestoreItem{
+			Position:           i,
+			Kind:               candidate.Kind,
+			GuestID:            candidate.GuestID,
+			RemoteDataset:      candidate.RemoteDataset,
+			Snapshot:           strings.TrimSpace(guest.Snapshot),
+			DestinationDataset: destination,
+			Status:             clusterModels.BulkRestoreItemPending,
+		})
+	}
+
+	return items, nil
+}
+
+// StartBulkRestore checks every selected guest against this node and starts
+// the restore in the background. Guests whose ID or datasets are already in
+// use are skipped up front. With DryRun nothing is stored or restored and the
+// checked plan is returned as is.
+func (s *Service) StartBulkRestore(ctx context.Context, req clusterServiceInterfaces.BulkRestoreReq) (*clusterModels.BulkRestore, error) {
+	concurrency := req.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBulkRestoreConcurrency
+	}
+	if concurrency > maxBulkRestoreConcurrency {
+		concurrency = maxBulkRestoreConcurrency
+	}
+	restoreNetwork := true
+	if req.RestoreNetwork != nil {
+		restoreNetwork = *req.RestoreNetwork
+	}
+
+	target, err := s.getRestoreTarget(req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := s.ListBulkRestoreCandidates(ctx, target.ID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := buildBulkRestoreItems(candidates, req.Guests, req.Pool)
+	if err != nil {
+		return nil, err
+	}
+
+	destinations := make([]string, 0, len(items))
+	for _, item := range items {
+		destinations = append(destinations, item.DestinationDataset)
+	}
+	if err := s.validateDestinationPoolsExist(ctx, destinations); err != nil {
+		return nil, err
+	}
+
+	restorable := 0
+	for i := range items {
+		if _, err := s.preflightOOBGuestRestoreDestination(ctx, &target, items[i].RemoteDataset, items[i].DestinationDataset); err != nil {
+			items[i].Status = clusterModels.BulkRestoreItemSkipped
+			items[i].Error = err.Error()
+			continue
+		}
+		restorable++
+	}
+
+	plan := &clusterModels.BulkRestore{
+		TargetID:       target.ID,
+		TargetName:     target.Name,
+		Pool:           normalizeDatasetPath(req.Pool),
+		Concurrency:    concurrency,
+		RestoreNetwork: restoreNetwork,
+		Status:         clusterModels.BulkRestoreStatusPlanned,
+		Items:          items,
+		StartedAt:      time.Now().UTC(),
+	}
+	plan.Total, plan.Completed, plan.Failed, plan.Skipped = bulkRestoreRollup(items)
+
+	if req.DryRun {
+		return plan, nil
+	}
+	if restorable == 0 {
+		return nil, fmt.Errorf("bulk_restore_nothing_to_restore")
+	}
+	if err := s.RegisterRestoreEncryptionKey(req.EncryptionKey, "passphrase"); err != nil {
+		return nil, err
+	}
+
+	s.bulkRestoreMu.Lock()
+	defer s.bulkRestoreMu.Unlock()
+	if s.bulkRestoreStop != nil {
+		return nil, fmt.Errorf("bulk_restore_already_running")
+	}
+
+	plan.Status = clusterModels.BulkRestoreStatusRunning
+	if err := s.DB.Create(plan).Error; err != nil {
+		return nil, err
+	}
+
+	dispatchCtx, stop := context.WithCancel(context.WithoutCancel(ctx))
+	s.bulkRestoreStop = stop
+	s.bulkRestoreID = plan.ID
+
+	go s.runBulkRestore(context.WithoutCancel(ctx), dispatchCtx, plan.ID)
+	return plan, nil
+}
+
+// CancelBulkRestore stops a running bulk restore from starting more guests.
+// Guests that are already being restored are left to finish.
+func (s *Service) CancelBulkRestore(id uint) error {
+	s.bulkRestoreMu.Lock()
+	defer s.bulkRestoreMu.Unlock()
+	if s.bulkRestoreStop == nil || s.bulkRestoreID != id {
+		return fmt.Errorf("bulk_restore_not_running")
+	}
+	s.bulkRestoreStop()
+	return nil
+}
+
+func (s *Service) ListBulkRestores(limit int) ([]clusterModels.BulkRestore, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	var restores []clusterModels.BulkRestore
+	err := s.DB.Preload("Items", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("position ASC")
+	}).Order("started_at DESC").Limit(limit).Find(&restores).Error
+	return restores, err
+}
+
+func (s *Service) GetBulkRestore(id uint) (*clusterModels.BulkRestore, error) {
+	var restore clusterModels.BulkRestore
+	err := s.DB.Preload("Items", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("position ASC")
+	}).First(&restore, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &restore, nil
+}
+
+// ReconcileBulkRestoresAfterRestart closes bulk restores that were running
+// when the process stopped. Their unfinished guests are not resumed; the
+// operator starts a new bulk restore for whatever is still missing.
+func (s *Service) ReconcileBulkRestoresAfterRestart() error {
+	var running []clusterModels.BulkRestore
+	if err := s.DB.Where("status = ?", clusterModels.BulkRestoreStatusRunning).Find(&running).Error; err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	for _, restore := range running {
+		if err := s.DB.Model(&clusterModels.BulkRestoreItem{}).
+			Where("bulk_restore_id = ? AND status IN ?", restore.ID, []string{
+				clusterModels.BulkRestoreItemPending,
+				clusterModels.BulkRestoreItemRunning,
+			}).
+			Updates(map[string]any{
+				"status":       clusterModels.BulkRestoreItemFailed,
+				"error":        "interrupted",
+				"completed_at": now,
+			}).Error; err != nil {
+			return err
+		}
+		if err := s.refreshBulkRestoreRollup(restore.ID); err != nil {
+			return err
+		}
+		if err := s.DB.Model(&clusterModels.BulkRestore{}).Where("id = ?", restore.ID).Updates(map[string]any{
+			"status":       clusterModels.BulkRestoreStatusInterrupted,
+			"completed_at": now,
+		}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) runBulkRestore(ctx, dispatchCtx context.Context, id uint) {
+	defer func() {
+		s.bulkRestoreMu.Lock()
+		if s.bulkRestoreStop != nil {
+			s.bulkRestoreStop()
+		}
+		s.bulkRestoreStop = nil
+		s.bulkRestoreID = 0
+		s.bulkRestoreMu.Unlock()
+	}()
+
+	plan, err := s.GetBulkRestore(id)
+	if err != nil {
+		logger.L.Warn().Err(err).Uint("bulk_restore_id", id).Msg("bulk_restore_load_failed")
+		return
+	}
+	target, targetErr := s.getRestoreTarget(plan.TargetID)
+
+	sem := make(chan struct{}, plan.Concurrency)
+	var wg sync.WaitGroup
+	for _, item := range plan.Items {
+		if item.Status != clusterModels.BulkRestoreItemPending {
+			continue
+		}
+		if targetErr != nil {
+			s.finishBulkRestoreItem(plan.ID, item.ID, clusterModels.BulkRestoreItemFailed, targetErr.Error())
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-dispatchCtx.Done():
+		}
+		if dispatchCtx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(item clusterModels.BulkRestoreItem) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			s.runBulkRestoreItem(ctx, dispatchCtx, &target, plan, item)
+		}(item)
+	}
+	wg.Wait()
+
+	cancelled := dispatchCtx.Err() != nil
+	if cancelled {
+		if err := s.DB.Model(&clusterModels.BulkRestoreItem{}).
+			Where("bulk_restore_id = ? AND status = ?", plan.ID, clusterModels.BulkRestoreItemPending).
+			Updates(map[string]any{
+				"status": clusterModels.BulkRestoreItemSkipped,
+				"error":  errBulkRestoreCancelled,
+			}).Error; err != nil {
+			logger.L.Warn().Err(err).Uint("bulk_restore_id", plan.ID).Msg("bulk_restore_cancel_pending_failed")
+		}
+	}
+	s.finalizeBulkRestore(plan.ID, cancelled)
+}
+
+func (s *Service) runBulkRestoreItem(
+	ctx, dispatchCtx context.Context,
+	target *clusterModels.BackupTarget,
+	plan *clusterModels.BulkRestore,
+	item clusterModels.BulkRestoreItem,
+) {
+	startedAt := time.Now().UTC()
+	if err := s.DB.Model(&clusterModels.BulkRestoreItem{}).Where("id = ?", item.ID).Updates(map[string]any{
+		"status":     clusterModels.BulkRestoreItemRunning,
+		"started_at": startedAt,
+	}).Error; err != nil {
+		logger.L.Warn().Err(err).Uint("bulk_restore_item_id", item.ID).Msg("bulk_restore_item_update_failed")
+	}
+
+	err := s.restoreBulkRestoreItem(ctx, dispatchCtx, target, plan, item)
+	switch {
+	case err == nil:
+		s.finishBulkRestoreItem(plan.ID, item.ID, clusterModels.BulkRestoreItemCompleted, "")
+	case dispatchCtx.Err() != nil && errors.Is(err, context.Canceled):
+		s.finishBulkRestoreItem(plan.ID, item.ID, clusterModels.BulkRestoreItemSkipped, errBulkRestoreCancelled)
+	default:
+		logger.L.Warn().
+			Err(err).
+			Uint("bulk_restore_id", plan.ID).
+			Str("remote_dataset", item.RemoteDataset).
+			Msg("bulk_restore_item_failed")
+		s.finishBulkRestoreItem(plan.ID, item.ID, clusterModels.BulkRestoreItemFailed, err.Error())
+	}
+}
+
+func (s *Service) restoreBulkRestoreItem(
+	ctx, dispatchCtx context.Context,
+	target *clusterModels.BackupTarget,
+	plan *clusterModels.BulkRestore,
+	item clusterModels.BulkRestoreItem,
+) (err error) {
+	defer recoverOperationPanic("bulk_restore_item", &err)
+
+	snapshot := item.Snapshot
+	if snapshot == "" {
+		snapshots, err := s.ListRemoteTargetDatasetSnapshots(ctx, target.ID, item.RemoteDataset)
+		if err != nil {
+			return err
+		}
+		snapshot = latestBulkRestoreSnapshot(snapshots)
+		if snapshot == "" {
+			return fmt.Errorf("no_restorable_snapshot")
+		}
+		if err := s.DB.Model(&clusterModels.BulkRestoreItem{}).Where("id = ?", item.ID).Update("snapshot", snapshot).Error; err != nil {
+			return err
+		}
+	}
+
+	remoteDataset, snapshot, err := parseRestoreSnapshotInput(snapshot, item.RemoteDataset)
+	if err != nil {
+		return err
+	}
+
+	release, err := s.waitForHeavyOpSlot(
+		dispatchCtx,
+		"restore-from-target:"+item.DestinationDataset,
+		"restore to "+item.DestinationDataset,
+		item.DestinationDataset,
+	)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	restoreNetwork := plan.RestoreNetwork
+	return s.runRestoreFromTarget(ctx, target, restoreFromTargetPayload{
+		TargetID:           target.ID,
+		RemoteDataset:      remoteDataset,
+		Snapshot:           snapshot,
+		DestinationDataset: item.DestinationDataset,
+		RestoreNetwork:     &restoreNetwork,
+	})
+}
+
+// latestBulkRestoreSnapshot picks the newest restore point of the active
+// lineage, falling back to the newest one overall. Snapshots arrive oldest
+// first.
+func latestBulkRestoreSnapshot(snapshots []SnapshotInfo) string {
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if !snapshots[i].OutOfBand && (snapshots[i].Lineage == "" || snapshots[i].Lineage == "active") {
+			return snapshots[i].Name
+		}
+	}
+	if len(snapshots) > 0 {
+		return snapshots[len(snapshots)-1].Name
+	}
+	return ""
+}
+
+func (s *Service) finishBulkRestoreItem(planID, itemID uint, status, errMsg string) {
+	if err := s.DB.Model(&clusterModels.BulkRestoreItem{}).Where("id = ?", itemID).Updates(map[string]any{
+		"status":       status,
+		"error":        errMsg,
+		"completed_at": time.Now().UTC(),
+	}).Error; err != nil {
+		logger.L.Warn().Err(err).Uint("bulk_restore_item_id", itemID).Msg("bulk_restore_item_update_failed")
+	}
+	if err := s.refreshBulkRestoreRollup(planID); err != nil {
+		logger.L.Warn().Err(err).Uint("bulk_restore_id", planID).Msg("bulk_restore_rollup_failed")
+	}
+	s.emitLeftPanelRefresh(fmt.Sprintf("bulk_restore_item_%d_%s", itemID, status))
+}
+
+func (s *Service) refreshBulkRestoreRollup(id uint) error {
+	var items []clusterModels.BulkRestoreItem
+	if err := s.DB.Where("bulk_restore_id = ?", id).Find(&items).Error; err != nil {
+		return err
+	}
+	total, completed, failed, skipped := bulkRestoreRollup(items)
+	return s.DB.Model(&clusterModels.BulkRestore{}).Where("id = ?", id).Updates(map[string]any{
+		"total":     total,
+		"completed": completed,
+		"failed":    failed,
+		"skipped":   skipped,
+	}).Error
+}
+
+func bulkRestoreRollup(items []clusterModels.BulkRestoreItem) (total, completed, failed, skipped int) {
+	for _, item := range items {
+		switch item.Status {
+		case clusterModels.BulkRestoreItemCompleted:
+			completed++
+		case clusterModels.BulkRestoreItemFailed:
+			failed++
+		case clusterModels.BulkRestoreItemSkipped:
+			skipped++
+		}
+	}
+	return len(items), completed, failed, skipped
+}
+
+func bulkRestoreOutcome(total, completed int, cancelled bool) string {
+	switch {
+	case cancelled:
+		return clusterModels.BulkRestoreStatusCancelled
+	case completed == total:
+		return clusterModels.BulkRestoreStatusCompleted
+	case completed == 0:
+		return clusterModels.BulkRestoreStatusFailed
+	default:
+		return clusterModels.BulkRestoreStatusPartial
+	}
+}
+
+func (s *Service) finalizeBulkRestore(id uint, cancelled bool) {
+	if err := s.refreshBulkRestoreRollup(id); err != nil {
+		logger.L.Warn().Err(err).Uint("bulk_restore_id", id).Msg("bulk_restore_rollup_failed")
+	}
+	plan, err := s.GetBulkRestore(id)
+	if err != nil {
+		logger.L.Warn().Err(err).Uint("bulk_restore_id", id).Msg("bulk_restore_load_failed")
+		return
+	}
+
+	now := time.Now().UTC()
+	plan.Status = bulkRestoreOutcome(plan.Total, plan.Completed, cancelled)
+	plan.Report = bulkRestoreReport(plan, now.Sub(plan.StartedAt))
+	plan.CompletedAt = &now
+	if err := s.DB.Model(&clusterModels.BulkRestore{}).Where("id = ?", id).Updates(map[string]any{
+		"status":       plan.Status,
+		"report":       plan.Report,
+		"completed_at": now,
+	}).Error; err != nil {
+		logger.L.Warn().Err(err).Uint("bulk_restore_id", id).Msg("bulk_restore_finalize_failed")
+	}
+
+	if s.TelemetryDB != nil {
+		auditStatus := "success"
+		if plan.Status != clusterModels.BulkRestoreStatusCompleted {
+			auditStatus = "failed"
+		}
+		db.FinalizeAsyncAuditRecord(s.TelemetryDB, "backup_bulk_restore", plan.ID, auditStatus, "", map[string]any{
+			"status":    plan.Status,
+			"completed": plan.Completed,
+			"failed":    plan.Failed,
+			"skipped":   plan.Skipped,
+		})
+	}
+
+	if _, err := notifier.Emit(context.Background(), bulkRestoreNotification(plan)); err != nil {
+		logger.L.Debug().Err(err).Uint("bulk_restore_id", id).Msg("bulk_restore_notification_failed")
+	}
+}
+
+// bulkRestoreReport is the summary stored on a finished bulk restore: one
+// line of totals followed by every guest that was not restored and why.
+func bulkRestoreReport(plan *clusterModels.BulkRestore, elapsed time.Duration) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Restored %d of %d guests from %s in %s.", plan.Completed, plan.Total, plan.TargetName, elapsed.Round(time.Second))
+	for _, item := range plan.Items {
+		if item.Status == clusterModels.BulkRestoreItemCompleted {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s %d (%s): %s", item.Kind, item.GuestID, item.Status, item.Error)
+	}
+	return b.String()
+}
+
+func bulkRestoreNotification(plan *clusterModels.BulkRestore) notifier.EventInput {
+	severity := models.NotificationSeverityInfo
+	switch plan.Status {
+	case clusterModels.BulkRestoreStatusFailed:
+		severity = models.NotificationSeverityError
+	case clusterModels.BulkRestoreStatusPartial, clusterModels.BulkRestoreStatusCancelled:
+		severity = models.NotificationSeverityWarning
+	}
+
+	return notifier.EventInput{
+		Kind:        "backup.bulk_restore." + plan.Status,
+		Title:       fmt.Sprintf("Bulk restore from %s %s", plan.TargetName, plan.Status),
+		Body:        plan.Report,
+		Severity:    string(severity),
+		Source:      "cluster.backups.bulk_restore",
+		Fingerprint: fmt.Sprintf("bulk_restore|%d", plan.ID),
+		Metadata: map[string]string{
+			"bulk_restore_id": strconv.FormatUint(uint64(plan.ID), 10),
+			"target_id":       strconv.FormatUint(uint64(plan.TargetID), 10),
+			"status":          plan.Status,
+		},
+	}
+}
@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+
+package zelta
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+)
+
+func bulkRestoreTestDataset(root, suffix string, snapshots int) BackupTargetDatasetInfo {
+	lineage, outOfBand, baseSuffix := classifyDatasetLineage(suffix)
+	kind, guestID := inferRestoreDatasetKind(baseSuffix)
+	info := BackupTargetDatasetInfo{
+		Name:          root + "/" + suffix,
+		Suffix:        suffix,
+		BaseSuffix:    baseSuffix,
+		Lineage:       lineage,
+		OutOfBand:     outOfBand,
+		SnapshotCount: snapshots,
+		Kind:          kind,
+	}
+	switch kind {
+	case clusterModels.BackupJobModeJail:
+		info.JailCTID = guestID
+	case clusterModels.BackupJobModeVM:
+		info.VMRID = guestID
+	}
+	return info
+}
+
+func TestBulkRestoreCandidatesKeepOneActiveRootPerGuest(t *testing.T) {
+	root := "backup/node1"
+	datasets := []BackupTargetDatasetInfo{
+		bulkRestoreTestDataset(root, "job-1/zroot/sylve/jails/101", 3),
+		bulkRestoreTestDataset(root, "job-1/zroot/sylve/jails/101_gen-2", 5),
+		bulkRestoreTestDataset(root, "job-4/zroot/sylve/jails/101", 7),
+		bulkRestoreTestDataset(root, "job-2/tank/sylve/virtual-machines/204", 2),
+		bulkRestoreTestDataset(root, "job-2/tank/sylve/virtual-machines/204/disk0", 2),
+		bulkRestoreTestDataset(root, "job-3/data/shares", 9),
+		bulkRestoreTestDataset(root, "jails/105", 1),
+	}
+
+	got := bulkRestoreCandidates(datasets)
+	if len(got) != 3 {
+		t.Fatalf("expected three guests, got %+v", got)
+	}
+	if got[0].Kind != clusterModels.BackupJobModeJail || got[0].GuestID != 101 ||
+		got[0].RemoteDataset != root+"/job-4/zroot/sylve/jails/101" || got[0].SourcePool != "zroot" {
+		t.Fatalf("expected jail 101 from the lineage with most snapshots, got %+v", got[0])
+	}
+	if got[1].GuestID != 105 || got[1].SourcePool != "" {
+		t.Fatalf("expected jail 105 without a known pool, got %+v", got[1])
+	}
+	if got[2].Kind != clusterModels.BackupJobModeVM || got[2].GuestID != 204 || got[2].SourcePool != "tank" {
+		t.Fatalf("expected vm 204 root dataset, got %+v", got[2])
+	}
+}
+
+func TestBuildBulkRestoreItems(t *testing.T) {
+	candidates := []BulkRestoreCandidate{
+		{Kind: clusterModels.BackupJobModeJail, GuestID: 101, RemoteDataset: "backup/job-1/zroot/sylve/jails/101", SourcePool: "zroot"},
+		{Kind: clusterModels.BackupJobModeJail, GuestID: 105, RemoteDataset: "backup/jails/105"},
+		{Kind: clusterModels.BackupJobModeVM, GuestID: 204, RemoteDataset: "backup/job-2/tank/sylve/virtual-machines/204", SourcePool: "tank"},
+	}
+	guests := []clusterServiceInterfaces.BulkRestoreGuestReq{
+		{RemoteDataset: "backup/job-2/tank/sylve/virtual-machines/204"},
+		{RemoteDataset: "backup/job-1/zroot/sylve/jails/101", Snapshot: "@zelta_1"},
+	}
+
+	items, err := buildBulkRestoreItems(candidates, guests, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2 || items[0].DestinationDataset != "tank/sylve/virtual-machines/204" || items[0].Position != 0 {
+		t.Fatalf("expected the vm first on its own pool, got %+v", items)
+	}
+	if items[1].DestinationDataset != "zroot/sylve/jails/101" || items[1].Snapshot != "@zelta_1" ||
+		items[1].Status != clusterModels.BulkRestoreItemPending {
+		t.Fatalf("unexpected jail item %+v", items[1])
+	}
+
+	items, err = buildBulkRestoreItems(candidates, append(guests, clusterServiceInterfaces.BulkRestoreGuestReq{RemoteDataset: "backup/jails/105"}), "newpool")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, item := range items {
+		if !strings.HasPrefix(item.DestinationDataset, "newpool/sylve/") {
+			t.Fatalf("expected the pool override to apply, got %s", item.DestinationDataset)
+		}
+	}
+
+	for name, input := range map[string][]clusterServiceInterfaces.BulkRestoreGuestReq{
+		"bulk_restore_guest_not_found": {{RemoteDataset: "backup/jails/999"}},
+		"bulk_restore_duplicate_guest": {guests[1], guests[1]},
+		"bulk_restore_pool_required":   {{RemoteDataset: "backup/jails/105"}},
+	} {
+		if _, err := buildBulkRestoreItems(candidates, input, ""); err == nil || !strings.Contains(err.Error(), name) {
+			t.Fatalf("expected %s, got %v", name, err)
+		}
+	}
+}
+
+func TestLatestBulkRestoreSnapshotPrefersActiveLineage(t *testing.T) {
+	snapshots := []SnapshotInfo{
+		{Name: "b/jails/101@zelta_1", Lineage: "active"},
+		{Name: "b/jails/101@zelta_2", Lineage: "active"},
+		{Name: "b/jails/101_gen-1@zelta_3", Lineage: "rotated", OutOfBand: true},
+	}
+	if got := latestBulkRestoreSnapshot(snapshots); got != "b/jails/101@zelta_2" {
+		t.Fatalf("expected newest active snapshot, got %q", got)
+	}
+	if got := latestBulkRestoreSnapshot(snapshots[2:]); got != "b/jails/101_gen-1@zelta_3" {
+		t.Fatalf("expected fallback to newest snapshot, got %q", got)
+	}
+	if got := latestBulkRestoreSnapshot(nil); got != "" {
+		t.Fatalf("expected no snapshot, got %q", got)
+	}
+}
+
+func TestBulkRestoreOutcomeAndReport(t *testing.T) {
+	items := []clusterModels.BulkRestoreItem{
+		{Kind: clusterModels.BackupJobModeJail, GuestID: 101, Status: clusterModels.BulkRestoreItemCompleted},
+		{Kind: clusterModels.BackupJobModeVM, GuestID: 204, Status: clusterModels.BulkRestoreItemFailed, Error: "zfs_recv_failed"},
+		{Kind: clusterModels.BackupJobModeJail, GuestID: 105, Status: clusterModels.BulkRestoreItemSkipped, Error: "guest_id_in_use"},
+	}
+	total, completed, failed, skipped := bulkRestoreRollup(items)
+	if total != 3 || completed != 1 || failed != 1 || skipped != 1 {
+		t.Fatalf("unexpected rollup %d %d %d %d", total, completed, failed, skipped)
+	}
+
+	if got := bulkRestoreOutcome(total, completed, false); got != clusterModels.BulkRestoreStatusPartial {
+		t.Fatalf("expected partial, got %s", got)
+	}
+	if got := bulkRestoreOutcome(3, 3, false); got != clusterModels.BulkRestoreStatusCompleted {
+		t.Fatalf("expected completed, got %s", got)
+	}
+	if got := bulkRestoreOutcome(3, 0, false); got != clusterModels.BulkRestoreStatusFailed {
+		t.Fatalf("expected failed, got %s", got)
+	}
+	if got := bulkRestoreOutcome(3, 3, true); got != clusterModels.BulkRestoreStatusCancelled {
+		t.Fatalf("expected cancelled, got %s", got)
+	}
+
+	plan := &clusterModels.BulkRestore{TargetName: "offsite", Total: total, Completed: completed, Items: items}
+	report := bulkRestoreReport(plan, 90*time.Second)
+	want := "Restored 1 of 3 guests from offsite in 1m30s.\n" +
+		"vm 204 (failed): zfs_recv_failed\n" +
+		"jail 105 (skipped): guest_id_in_use"
+	if report != want {
+		t.Fatalf("unexpected report:\n%s", report)
+	}
+}
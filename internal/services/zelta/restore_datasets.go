@@ -0,0 +1,267 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/logger"
+)
+
+const (
+	restoreDatasetStatusPending = "pending"
+	restoreDatasetStatusRunning = "running"
+	restoreDatasetStatusSuccess = "success"
+	restoreDatasetStatusFailed  = "failed"
+	restoreDatasetStatusSkipped = "skipped"
+
+	restoreDatasetStartMarker = "vm_dataset_restore_start:"
+)
+
+// BackupEventDatasetProgress is one dataset of a multi-dataset restore with
+// its live progress; MovedBytes of a running dataset is read from its staging
+// dataset.
+type BackupEventDatasetProgress struct {
+	clusterModels.BackupEventDataset
+	ProgressPercent *float64 `json:"progressPercent"`
+}
+
+// planRestoreEventDatasets records every dataset of a restore as pending up
+// front so the breakdown shows how many are left. Records are best effort: a
+// nil return only means the breakdown is missing, never that the restore
+// should not run.
+func (s *Service) planRestoreEventDatasets(eventID uint, plans []vmRestoreRootPlan) []*clusterModels.BackupEventDataset {
+	if s == nil || s.DB == nil || eventID == 0 || len(plans) == 0 {
+		return nil
+	}
+
+	records := make([]*clusterModels.BackupEventDataset, 0, len(plans))
+	for idx, plan := range plans {
+		records = append(records, &clusterModels.BackupEventDataset{
+			EventID:       eventID,
+			Position:      idx + 1,
+			SourceDataset: normalizeDatasetPath(plan.remote),
+			TargetDataset: normalizeDatasetPath(plan.destination),
+			Status:        restoreDatasetStatusPending,
+		})
+	}
+	if err := s.DB.Create(records).Error; err != nil {
+		logger.L.Warn().Err(err).Uint("event_id", eventID).Msg("create_backup_event_datasets_failed")
+		return nil
+	}
+	return records
+}
+
+func (s *Service) startRestoreEventDataset(record *clusterModels.BackupEventDataset) {
+	if s == nil || s.DB == nil || record == nil || record.ID == 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	record.Status = restoreDatasetStatusRunning
+	record.StartedAt = &now
+	if err := s.DB.Model(&clusterModels.BackupEventDataset{}).
+		Where("id = ?", record.ID).
+		Updates(map[string]any{
+			"status":     record.Status,
+			"started_at": record.StartedAt,
+		}).Error; err != nil {
+		logger.L.Warn().Err(err).Uint("dataset_id", record.ID).Msg("start_backup_event_dataset_failed")
+	}
+}
+
+// finishRestoreEventDataset closes a dataset record. Byte counts are parsed
+// from the dataset's own section of the shared event output so they are not
+// mixed up with the other datasets of the restore.
+func (s *Service) finishRestoreEventDataset(record *clusterModels.BackupEventDataset, restoreErr error) {
+	if s == nil || s.DB == nil || record == nil || record.ID == 0 {
+		return
+	}
+
+	output := ""
+	if event, err := s.GetLocalBackupEvent(record.EventID); err == nil && event != nil {
+		output = restoreDatasetOutputSection(event.Output, record.TargetDataset)
+	}
+
+	now := time.Now().UTC()
+	record.Status = restoreDatasetStatusSuccess
+	record.Error = ""
+	if restoreErr != nil {
+		record.Status = restoreDatasetStatusFailed
+		record.Error = restoreErr.Error()
+	}
+	record.CompletedAt = &now
+	record.TotalBytes = parseTotalBytesFromOutput(output)
+	record.MovedBytes = parseMovedBytesFromOutput(output)
+	if record.MovedBytes == nil && restoreErr == nil {
+		record.MovedBytes = record.TotalBytes
+	}
+
+	if err := s.DB.Model(&clusterModels.BackupEventDataset{}).
+		Where("id = ?", record.ID).
+		Updates(map[string]any{
+			"status":       record.Status,
+			"moved_bytes":  record.MovedBytes,
+			"total_bytes":  record.TotalBytes,
+			"error":        record.Error,
+			"completed_at": record.CompletedAt,
+		}).Error; err != nil {
+		logger.L.Warn().Err(err).Uint("dataset_id", record.ID).Msg("finish_backup_event_dataset_failed")
+	}
+}
+
+// closeRestoreEventDatasets settles whatever a finished restore left open:
+// datasets never reached are skipped, one cut off mid-transfer failed.
+func (s *Service) closeRestoreEventDatasets(eventID uint, restoreErr error) {
+	if s == nil || s.DB == nil || eventID == 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	if err := s.DB.Model(&clusterModels.BackupEventDataset{}).
+		Where("event_id = ? AND status = ?", eventID, restoreDatasetStatusPending).
+		Updates(map[string]any{
+			"status":       restoreDatasetStatusSkipped,
+			"completed_at": now,
+		}).Error; err != nil {
+		logger.L.Warn().Err(err).Uint("event_id", eventID).Msg("skip_backup_event_datasets_failed")
+	}
+
+	message := "restore_interrupted"
+	if restoreErr != nil {
+		message = restoreErr.Error()
+	}
+	if err := s.DB.Model(&clusterModels.BackupEventDataset{}).
+		Where("event_id = ? AND status = ?", eventID, restoreDatasetStatusRunning).
+		Updates(map[string]any{
+			"status":       restoreDatasetStatusFailed,
+			"error":        message,
+			"completed_at": now,
+		}).Error; err != nil {
+		logger.L.Warn().Err(err).Uint("event_id", eventID).Msg("fail_backup_event_datasets_failed")
+	}
+}
+
+// restoreDatasetOutputSection returns the part of a shared restore output
+// written while destination was being restored: from its start marker up to
+// the next dataset's marker.
+func restoreDatasetOutputSection(output, destination string) string {
+	destination = normalizeDatasetPath(destination)
+	if destination == "" {
+		return ""
+	}
+
+	lines := strings.Split(output, "\n")
+	start := -1
+	for idx, line := range lines {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, restoreDatasetStartMarker) && strings.HasSuffix(line, "-> "+destination) {
+			start = idx
+		}
+	}
+	if start < 0 {
+		return ""
+	}
+
+	end := len(lines)
+	for idx := start + 1; idx < len(lines); idx++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[idx]), restoreDatasetStartMarker) {
+			end = idx
+			break
+		}
+	}
+	return strings.Join(lines[start:end], "\n")
+}
+
+// restoreEventDatasetProgress builds the per-dataset breakdown of a restore
+// and folds it into out. Overall progress weights every dataset equally,
+// because the size of a dataset is only known once its transfer starts.
+func (s *Service) restoreEventDatasetProgress(ctx context.Context, out *BackupEventProgress) error {
+	if out == nil || out.Event == nil {
+		return nil
+	}
+
+	var records []clusterModels.BackupEventDataset
+	if err := s.DB.
+		Where("event_id = ?", out.Event.ID).
+		Order("position ASC").
+		Order("id ASC").
+		Find(&records).Error; err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	out.Datasets = make([]BackupEventDatasetProgress, 0, len(records))
+	out.DatasetCount = len(records)
+	var moved, total uint64
+	var haveMoved, haveTotal bool
+	var done float64
+	for _, record := range records {
+		entry := BackupEventDatasetProgress{BackupEventDataset: record}
+		switch record.Status {
+		case restoreDatasetStatusRunning:
+			out.CurrentDataset = record.Position
+			section := restoreDatasetOutputSection(out.Event.Output, record.TargetDataset)
+			entry.TotalBytes = parseTotalBytesFromOutput(section)
+			stagingDataset := s.restoreStagingDataset(record.TargetDataset)
+			out.ProgressDataset = stagingDataset
+			if used, err := zfsDatasetUsedBytes(s, ctx, stagingDataset); err != nil {
+				logger.L.Debug().
+					Uint("event_id", out.Event.ID).
+					Str("dataset", stagingDataset).
+					Err(err).
+					Msg("restore_progress_dataset_query_failed")
+			} else {
+				entry.MovedBytes = used
+			}
+			if entry.TotalBytes != nil && entry.MovedBytes != nil && *entry.MovedBytes > *entry.TotalBytes {
+				capped := *entry.TotalBytes
+				entry.MovedBytes = &capped
+			}
+			if entry.TotalBytes != nil && entry.MovedBytes != nil && *entry.TotalBytes > 0 {
+				fraction := float64(*entry.MovedBytes) / float64(*entry.TotalBytes)
+				pct := math.Round(fraction*10000) / 100
+				entry.ProgressPercent = &pct
+				done += fraction
+			}
+		case restoreDatasetStatusSuccess, restoreDatasetStatusFailed, restoreDatasetStatusSkipped:
+			done++
+			if record.Status == restoreDatasetStatusSuccess {
+				pct := float64(100)
+				entry.ProgressPercent = &pct
+			}
+		}
+		if entry.MovedBytes != nil {
+			moved += *entry.MovedBytes
+			haveMoved = true
+		}
+		if entry.TotalBytes != nil {
+			total += *entry.TotalBytes
+			haveTotal = true
+		}
+		out.Datasets = append(out.Datasets, entry)
+	}
+
+	out.MovedBytes, out.TotalBytes = nil, nil
+	if haveMoved {
+		out.MovedBytes = &moved
+	}
+	if haveTotal {
+		out.TotalBytes = &total
+	}
+	pct := math.Round(done/float64(len(records))*10000) / 100
+	out.ProgressPercent = &pct
+	return nil
+}
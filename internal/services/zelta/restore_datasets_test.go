@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+)
+
+func TestRestoreDatasetOutputSectionSplitsSharedOutput(t *testing.T) {
+	output := "vm_dataset_restore_start: host:backup/vm/100@s -> zroot/sylve/virtual-machines/100\n" +
+		"syncing: 2GiB\n" +
+		"2GiB sent\n" +
+		"vm_dataset_restore_start: host:backup/vm/100_disk@s -> tank/sylve/virtual-machines/100\n" +
+		"syncing: 8GiB\n"
+
+	first := restoreDatasetOutputSection(output, "zroot/sylve/virtual-machines/100")
+	if got := parseTotalBytesFromOutput(first); got == nil || *got != 2<<30 {
+		t.Fatalf("expected the first section to report 2G, got %v", got)
+	}
+	second := restoreDatasetOutputSection(output, "tank/sylve/virtual-machines/100")
+	if got := parseTotalBytesFromOutput(second); got == nil || *got != 8<<30 {
+		t.Fatalf("expected the second section to report 8G, got %v", got)
+	}
+	if restoreDatasetOutputSection(output, "tank/other") != "" {
+		t.Fatal("expected no section for a dataset that was never started")
+	}
+}
+
+func TestRestoreEventDatasetsAggregateIntoParentProgress(t *testing.T) {
+	s, db := newTestZeltaServiceWithDB(t, &clusterModels.BackupEvent{}, &clusterModels.BackupEventDataset{})
+
+	event := clusterModels.BackupEvent{
+		Mode:      "restore",
+		Status:    "running",
+		StartedAt: time.Now().UTC(),
+		Output: "vm_dataset_restore_start: host:backup/vm/100@s -> zroot/sylve/virtual-machines/100\n" +
+			"syncing: 2GiB\n" +
+			"2GiB sent\n",
+	}
+	if err := db.Create(&event).Error; err != nil {
+		t.Fatalf("failed to seed event: %v", err)
+	}
+
+	records := s.planRestoreEventDatasets(event.ID, []vmRestoreRootPlan{
+		{remote: "backup/vm/100", destination: "zroot/sylve/virtual-machines/100"},
+		{remote: "backup/vm/100_disk", destination: "tank/sylve/virtual-machines/100"},
+		{remote: "backup/vm/100_iso", destination: "ssd/sylve/virtual-machines/100"},
+	})
+	if len(records) != 3 {
+		t.Fatalf("expected three planned datasets, got %d", len(records))
+	}
+
+	s.startRestoreEventDataset(records[0])
+	s.finishRestoreEventDataset(records[0], nil)
+	s.startRestoreEventDataset(records[1])
+	s.finishRestoreEventDataset(records[1], errors.New("zelta_failed"))
+	s.closeRestoreEventDatasets(event.ID, errors.New("zelta_failed"))
+
+	out := &BackupEventProgress{Event: &event}
+	if err := s.restoreEventDatasetProgress(context.Background(), out); err != nil {
+		t.Fatalf("unexpected progress error: %v", err)
+	}
+	if out.DatasetCount != 3 || len(out.Datasets) != 3 {
+		t.Fatalf("expected three datasets in the breakdown, got %+v", out.Datasets)
+	}
+
+	first, second, third := out.Datasets[0], out.Datasets[1], out.Datasets[2]
+	if first.Status != restoreDatasetStatusSuccess || first.TotalBytes == nil || *first.TotalBytes != 2<<30 {
+		t.Fatalf("unexpected first dataset %+v", first)
+	}
+	if second.Status != restoreDatasetStatusFailed || second.Error != "zelta_failed" {
+		t.Fatalf("unexpected second dataset %+v", second)
+	}
+	if third.Status != restoreDatasetStatusSkipped || third.StartedAt != nil {
+		t.Fatalf("expected the unreached dataset to be skipped, got %+v", third)
+	}
+	if out.TotalBytes == nil || *out.TotalBytes != 2<<30 {
+		t.Fatalf("expected parent total of 2G, got %v", out.TotalBytes)
+	}
+	if out.ProgressPercent == nil || *out.ProgressPercent != 100 {
+		t.Fatalf("expected a settled restore to report 100%%, got %v", out.ProgressPercent)
+	}
+}
@@ -17,6 +17,7 @@ import (
 
 	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	"github.com/alchemillahq/sylve/internal/layout"
 )
 
 // requireNoManagedGuestsWithinRestore prevents a generic dataset-mode restore
@@ -103,7 +104,7 @@ func jailRestoreDatasetRoots(jail jailModels.Jail) []string {
 			roots = append(roots, name)
 		}
 		if pool := strings.TrimSpace(storage.Pool); pool != "" && jail.CTID > 0 {
-			roots = append(roots, fmt.Sprintf("%s/sylve/jails/%d", pool, jail.CTID))
+			roots = append(roots, fmt.Sprintf("%s/%d", layout.JailsDataset(pool), jail.CTID))
 		}
 	}
 	return roots
@@ -120,7 +121,7 @@ func vmRestoreDatasetRoots(vm vmModels.VM) []string {
 			pool = strings.TrimSpace(storage.Pool)
 		}
 		if pool != "" && vm.RID > 0 {
-			roots = append(roots, fmt.Sprintf("%s/sylve/virtual-machines/%d", pool, vm.RID))
+			roots = append(roots, fmt.Sprintf("%s/%d", layout.VMsDataset(pool), vm.RID))
 		}
 	}
 	return roots
@@ -261,6 +261,7 @@ func (s *Service) upsertRestoredJailState(
 			CPUSet:            append([]int(nil), restored.CPUSet...),
 			Memory:            restored.Memory,
 			DevFSRuleset:      restored.DevFSRuleset,
+			Mounts:            append([]jailModels.JailMount(nil), restored.Mounts...),
 			Fstab:             restored.Fstab,
 			CleanEnvironment:  restored.CleanEnvironment,
 			AdditionalOptions: restored.AdditionalOptions,
@@ -292,6 +293,7 @@ func (s *Service) upsertRestoredJailState(
 				"CPUSet",
 				"Memory",
 				"DevFSRuleset",
+				"Mounts",
 				"Fstab",
 				"CleanEnvironment",
 				"AdditionalOptions",
@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/logger"
+)
+
+type restorePromotionRecoveryAction string
+
+const (
+	// The promotion rename finished; only the journal is behind.
+	restorePromotionRecoverComplete restorePromotionRecoveryAction = "complete"
+	// No rename took effect, so there is nothing to undo.
+	restorePromotionRecoverAbandon restorePromotionRecoveryAction = "abandon"
+	// The live dataset was archived but the restore never took its place.
+	restorePromotionRecoverRollback restorePromotionRecoveryAction = "rollback"
+	// The datasets on disk do not match any state the promotion can produce.
+	restorePromotionRecoverManual restorePromotionRecoveryAction = "manual"
+)
+
+// resolveRestorePromotionRecovery derives the recovery action for an
+// interrupted promotion from what exists on disk. The journal phase is not
+// trusted on its own because a crash can land between a rename and the
+// journal update that records it.
+func resolveRestorePromotionRecovery(
+	hadBackup bool,
+	destinationExists bool,
+	backupExists bool,
+	restoreExists bool,
+) restorePromotionRecoveryAction {
+	switch {
+	case destinationExists && !restoreExists:
+		return restorePromotionRecoverComplete
+	case destinationExists && restoreExists && !backupExists:
+		return restorePromotionRecoverAbandon
+	case !destinationExists && hadBackup && backupExists:
+		return restorePromotionRecoverRollback
+	case !destinationExists && !hadBackup && restoreExists:
+		return restorePromotionRecoverAbandon
+	default:
+		return restorePromotionRecoverManual
+	}
+}
+
+// beginRestorePromotion records the intent to promote restorePath into
+// destination before any rename happens. A journal that cannot be written
+// only costs crash recovery, not the restore itself, so the promotion then
+// proceeds unjournaled as it did before the journal existed.
+func (s *Service) beginRestorePromotion(restorePath, destination, backupDataset string) *clusterModels.RestorePromotion {
+	if s == nil || s.DB == nil {
+		return nil
+	}
+
+	entry := &clusterModels.RestorePromotion{
+		RestorePath:   restorePath,
+		Destination:   destination,
+		BackupDataset: backupDataset,
+		Phase:         clusterModels.RestorePromotionPhasePending,
+	}
+	if err := s.DB.Create(entry).Error; err != nil {
+		logger.L.Warn().
+			Err(err).
+			Str("destination", destination).
+			Msg("restore_promotion_journal_write_failed")
+		return nil
+	}
+	return entry
+}
+
+func (s *Service) advanceRestorePromotion(entry *clusterModels.RestorePromotion, phase string, cause error) {
+	if s == nil || s.DB == nil || entry == nil {
+		return
+	}
+
+	updates := map[string]any{"phase": phase}
+	if cause != nil {
+		updates["error"] = cause.Error()
+	}
+	if err := s.DB.Model(entry).Updates(updates).Error; err != nil {
+		// The renames themselves already happened; recovery re-derives the
+		// real state from disk, so a stale phase is safe.
+		logger.L.Warn().
+			Err(err).
+			Uint("promotion_id", entry.ID).
+			Str("phase", phase).
+			Msg("restore_promotion_journal_update_failed")
+		return
+	}
+	entry.Phase = phase
+}
+
+// RecoverInterruptedRestorePromotions finishes or rolls back promotions that
+// were cut short by a crash or power loss. Entries whose on-disk state is
+// ambiguous are marked for manual recovery instead of being guessed at.
+func (s *Service) RecoverInterruptedRestorePromotions(ctx context.Context) error {
+	if s == nil || s.DB == nil {
+		return nil
+	}
+
+	var entries []clusterModels.RestorePromotion
+	if err := s.DB.
+		Where("phase IN ?", []string{
+			clusterModels.RestorePromotionPhasePending,
+			clusterModels.RestorePromotionPhaseArchived,
+		}).
+		Order("id ASC").
+		Find(&entries).Error; err != nil {
+		return fmt.Errorf("list_interrupted_restore_promotions_failed: %w", err)
+	}
+
+	var recoveryErrors []error
+	for i := range entries {
+		if err := s.recoverRestorePromotion(ctx, &entries[i]); err != nil {
+			recoveryErrors = append(recoveryErrors, fmt.Errorf(
+				"restore_promotion_recovery_failed: destination=%s: %w",
+				entries[i].Destination,
+				err,
+			))
+		}
+	}
+	return errors.Join(recoveryErrors...)
+}
+
+func (s *Service) recoverRestorePromotion(ctx context.Context, entry *clusterModels.RestorePromotion) error {
+	destinationExists, err := s.localDatasetExists(ctx, entry.Destination)
+	if err != nil {
+		return err
+	}
+	restoreExists, err := s.localDatasetExists(ctx, entry.RestorePath)
+	if err != nil {
+		return err
+	}
+	backupExists := false
+	if entry.BackupDataset != "" {
+		if backupExists, err = s.localDatasetExists(ctx, entry.BackupDataset); err != nil {
+			return err
+		}
+	}
+
+	action := resolveRestorePromotionRecovery(entry.BackupDataset != "", destinationExists, backupExists, restoreExists)
+	logger.L.Info().
+		Uint("promotion_id", entry.ID).
+		Str("destination", entry.Destination).
+		Str("phase", entry.Phase).
+		Str("action", string(action)).
+		Msg("recovering_interrupted_restore_promotion")
+
+	switch action {
+	case restorePromotionRecoverComplete:
+		s.advanceRestorePromotion(entry, clusterModels.RestorePromotionPhaseDone, nil)
+	case restorePromotionRecoverAbandon:
+		s.advanceRestorePromotion(entry, clusterModels.RestorePromotionPhaseRolledBack, nil)
+	case restorePromotionRecoverRollback:
+		if err := s.renameLocalDataset(ctx, entry.BackupDataset, entry.Destination); err != nil {
+			s.advanceRestorePromotion(entry, clusterModels.RestorePromotionPhaseManual, err)
+			return err
+		}
+		s.advanceRestorePromotion(entry, clusterModels.RestorePromotionPhaseRolledBack, nil)
+	default:
+		cause := fmt.Errorf(
+			"restore_promotion_state_ambiguous: destination_exists=%t backup_exists=%t restore_exists=%t",
+			destinationExists,
+			backupExists,
+			restoreExists,
+		)
+		s.advanceRestorePromotion(entry, clusterModels.RestorePromotionPhaseManual, cause)
+		return cause
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+
+package zelta
+
+import (
+	"errors"
+	"testing"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+)
+
+func TestResolveRestorePromotionRecovery(t *testing.T) {
+	tests := []struct {
+		name        string
+		hadBackup   bool
+		destination bool
+		backup      bool
+		restore     bool
+		want        restorePromotionRecoveryAction
+	}{
+		{"promoted", true, true, true, false, restorePromotionRecoverComplete},
+		{"promoted as new", false, true, false, false, restorePromotionRecoverComplete},
+		{"crashed before archive", true, true, false, true, restorePromotionRecoverAbandon},
+		{"crashed after archive", true, false, true, true, restorePromotionRecoverRollback},
+		{"crashed before promote as new", false, false, false, true, restorePromotionRecoverAbandon},
+		{"archive and restore both present with live", true, true, true, true, restorePromotionRecoverManual},
+		{"everything missing", true, false, false, false, restorePromotionRecoverManual},
+		{"restore lost after archive", true, false, false, true, restorePromotionRecoverManual},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := resolveRestorePromotionRecovery(tc.hadBackup, tc.destination, tc.backup, tc.restore)
+			if got != tc.want {
+				t.Fatalf("expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRestorePromotionJournalRecordsPhases(t *testing.T) {
+	svc, db := newTestZeltaServiceWithDB(t, &clusterModels.RestorePromotion{})
+
+	entry := svc.beginRestorePromotion("tank/vm/100.restoring", "tank/vm/100", "tank/vm/100.pre_restore_x")
+	if entry == nil || entry.ID == 0 {
+		t.Fatalf("expected journal entry to be created, got %#v", entry)
+	}
+
+	svc.advanceRestorePromotion(entry, clusterModels.RestorePromotionPhaseArchived, nil)
+	svc.advanceRestorePromotion(entry, clusterModels.RestorePromotionPhaseManual, errors.New("rename_failed"))
+
+	var stored clusterModels.RestorePromotion
+	if err := db.First(&stored, entry.ID).Error; err != nil {
+		t.Fatalf("failed to load journal entry: %v", err)
+	}
+	if stored.Phase != clusterModels.RestorePromotionPhaseManual || stored.Error != "rename_failed" {
+		t.Fatalf("unexpected journal entry: %#v", stored)
+	}
+}
+
+func TestRestorePromotionJournalDegradesWithoutTable(t *testing.T) {
+	svc, _ := newTestZeltaServiceWithDB(t, &clusterModels.BackupJob{})
+
+	if entry := svc.beginRestorePromotion("tank/a.restoring", "tank/a", ""); entry != nil {
+		t.Fatalf("expected promotion to run unjournaled, got %#v", entry)
+	}
+	svc.advanceRestorePromotion(nil, clusterModels.RestorePromotionPhaseDone, nil)
+}
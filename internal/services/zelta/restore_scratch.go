@@ -0,0 +1,392 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/alchemillahq/gzfs"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"gorm.io/gorm"
+)
+
+// restoreStagingPlan says where a restore is received and which dataset is
+// later promoted into place. They differ only when the scratch dataset sits
+// on another pool, since a rename cannot cross pools.
+type restoreStagingPlan struct {
+	Scratch string
+	Pull    string
+	Promote string
+}
+
+func (plan restoreStagingPlan) crossPool() bool {
+	return plan.Pull != plan.Promote
+}
+
+func datasetPool(dataset string) string {
+	pool, _, _ := strings.Cut(normalizeDatasetPath(dataset), "/")
+	return pool
+}
+
+// scratchStagingDataset mirrors the destination path below the scratch
+// dataset, so zroot/sylve/jails/105 stages as
+// <scratch>/zroot/sylve/jails/105.restoring and the destination can be read
+// back from the name.
+func scratchStagingDataset(scratch, destination string) string {
+	scratch = normalizeRestoreDestinationDataset(scratch)
+	destination = normalizeRestoreDestinationDataset(destination)
+	if scratch == "" {
+		return destination + staleRestoringSuffix
+	}
+	return scratch + "/" + destination + staleRestoringSuffix
+}
+
+// scratchStagingBase maps the base of a staging dataset found below the
+// scratch dataset back to the destination it belongs to.
+func scratchStagingBase(scratch, base string) string {
+	scratch = normalizeRestoreDestinationDataset(scratch)
+	if scratch == "" {
+		return base
+	}
+	if mapped, ok := strings.CutPrefix(base, scratch+"/"); ok && mapped != "" {
+		return mapped
+	}
+	return base
+}
+
+// parseRemoteSnapshotReferenced sums the referenced bytes of the snapshots
+// named snapshot in `zfs list -H -p -o name,referenced` output.
+func parseRemoteSnapshotReferenced(output, snapshot string) (uint64, error) {
+	var total uint64
+	found := false
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !strings.HasSuffix(fields[0], snapshot) {
+			continue
+		}
+		size, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("parse_restore_size_failed: line=%q", line)
+		}
+		total += size
+		found = true
+	}
+	if !found {
+		return 0, fmt.Errorf("restore_snapshot_size_not_found: snapshot=%s", snapshot)
+	}
+	return total, nil
+}
+
+func restoreStagingSpaceNeeded(estimate uint64, headroomPercent int) uint64 {
+	if headroomPercent <= 0 {
+		return estimate
+	}
+	return estimate + estimate*uint64(headroomPercent)/100
+}
+
+func (s *Service) GetRestoreScratch() (*clusterModels.RestoreScratch, error) {
+	var scratch clusterModels.RestoreScratch
+	if err := s.DB.Order("id ASC").First(&scratch).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &clusterModels.RestoreScratch{
+				HeadroomPercent: clusterModels.DefaultRestoreScratchHeadroomPercent,
+			}, nil
+		}
+		return nil, err
+	}
+	if scratch.HeadroomPercent < 0 {
+		scratch.HeadroomPercent = clusterModels.DefaultRestoreScratchHeadroomPercent
+	}
+	return &scratch, nil
+}
+
+func (s *Service) ConfigureRestoreScratch(
+	ctx context.Context,
+	req clusterServiceInterfaces.RestoreScratchReq,
+) (*clusterModels.RestoreScratch, error) {
+	scratch, err := s.GetRestoreScratch()
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Enabled != nil {
+		scratch.Enabled = *req.Enabled
+	}
+	if req.Dataset != nil {
+		scratch.Dataset = normalizeRestoreDestinationDataset(*req.Dataset)
+	}
+	if req.HeadroomPercent != nil {
+		if *req.HeadroomPercent < 0 || *req.HeadroomPercent > 100 {
+			return nil, fmt.Errorf("invalid_headroom_percent")
+		}
+		scratch.HeadroomPercent = *req.HeadroomPercent
+	}
+
+	if scratch.Enabled {
+		if scratch.Dataset == "" {
+			return nil, fmt.Errorf("restore_scratch_dataset_required")
+		}
+		if strings.HasSuffix(scratch.Dataset, staleRestoringSuffix) || strings.Contains(scratch.Dataset, "@") {
+			return nil, fmt.Errorf("invalid_restore_scratch_dataset")
+		}
+		ds, err := s.getLocalDataset(ctx, scratch.Dataset)
+		if err != nil {
+			return nil, fmt.Errorf("restore_scratch_dataset_check_failed: %w", err)
+		}
+		if ds == nil {
+			return nil, fmt.Errorf("restore_scratch_dataset_not_found: %s", scratch.Dataset)
+		}
+		if ds.Type != gzfs.DatasetTypeFilesystem {
+			return nil, fmt.Errorf("restore_scratch_dataset_not_filesystem: %s", scratch.Dataset)
+		}
+	}
+
+	if err := s.DB.Save(scratch).Error; err != nil {
+		return nil, err
+	}
+	return scratch, nil
+}
+
+// activeRestoreScratch returns the scratch dataset restores stage under, or
+// an empty string when staging stays next to the destination.
+func (s *Service) activeRestoreScratch() string {
+	if s == nil || s.DB == nil {
+		return ""
+	}
+	scratch, err := s.GetRestoreScratch()
+	if err != nil || !scratch.Enabled {
+		return ""
+	}
+	return scratch.Dataset
+}
+
+// restoreStagingDataset is the dataset a restore into destination is
+// received into, used for progress reporting.
+func (s *Service) restoreStagingDataset(destination string) string {
+	return scratchStagingDataset(s.activeRestoreScratch(), destination)
+}
+
+// restoreStagingDestination maps a staging dataset name back to the
+// destination it is restoring.
+func (s *Service) restoreStagingDestination(staging string) string {
+	base := strings.TrimSuffix(normalizeDatasetPath(staging), staleRestoringSuffix)
+	return scratchStagingBase(s.activeRestoreScratch(), base)
+}
+
+// planRestoreStaging picks where a restore into destination is staged.
+func (s *Service) planRestoreStaging(destination string) restoreStagingPlan {
+	destination = normalizeRestoreDestinationDataset(destination)
+	plan := restoreStagingPlan{
+		Pull:    destination + staleRestoringSuffix,
+		Promote: destination + staleRestoringSuffix,
+	}
+	if scratch := s.activeRestoreScratch(); scratch != "" {
+		plan.Scratch = scratch
+		plan.Pull = scratchStagingDataset(scratch, destination)
+		if datasetPool(scratch) == datasetPool(destination) {
+			plan.Promote = plan.Pull
+		}
+	}
+	return plan
+}
+
+// localAvailableBytes reports the space available to a dataset that may not
+// exist yet, from its nearest existing ancestor.
+func (s *Service) localAvailableBytes(ctx context.Context, dataset string) (string, uint64, error) {
+	current := normalizeRestoreDestinationDataset(dataset)
+	for current != "" {
+		ds, err := s.getLocalDataset(ctx, current)
+		if err != nil {
+			return current, 0, err
+		}
+		if ds != nil {
+			return current, ds.Available, nil
+		}
+		idx := strings.LastIndex(current, "/")
+		if idx < 0 {
+			break
+		}
+		current = current[:idx]
+	}
+	return "", 0, fmt.Errorf("destination_dataset_pool_missing: cannot find destination root '%s'", datasetPool(dataset))
+}
+
+func (s *Service) estimateRemoteRestoreSize(
+	ctx context.Context,
+	target *clusterModels.BackupTarget,
+	remoteDataset string,
+	snapshot string,
+	recursive bool,
+) (uint64, error) {
+	args := []string{"zfs", "list", "-H", "-p", "-t", "snapshot", "-o", "name,referenced"}
+	if recursive {
+		args = append(args, "-r", normalizeDatasetPath(remoteDataset))
+	} else {
+		args = append(args, normalizeDatasetPath(remoteDataset)+snapshot)
+	}
+	output, err := s.runTargetSSH(ctx, target, args...)
+	if err != nil {
+		return 0, fmt.Errorf("list_restore_size_failed: %w", err)
+	}
+	return parseRemoteSnapshotReferenced(output, snapshot)
+}
+
+// checkRestoreStagingSpace fails before anything is pulled when the staging
+// pool, or the destination pool a cross-pool staging is copied into, cannot
+// hold the snapshot plus the configured headroom. The estimate is the
+// snapshot's referenced size, so it is a floor rather than an exact figure.
+func (s *Service) checkRestoreStagingSpace(
+	ctx context.Context,
+	target *clusterModels.BackupTarget,
+	remoteDataset string,
+	snapshot string,
+	recursive bool,
+	plan restoreStagingPlan,
+) error {
+	headroom := clusterModels.DefaultRestoreScratchHeadroomPercent
+	if s.DB != nil {
+		if scratch, err := s.GetRestoreScratch(); err == nil {
+			headroom = scratch.HeadroomPercent
+		}
+	}
+
+	estimate, err := s.estimateRemoteRestoreSize(ctx, target, remoteDataset, snapshot, recursive)
+	if err != nil {
+		return err
+	}
+	needed := restoreStagingSpaceNeeded(estimate, headroom)
+
+	checks := []string{plan.Pull}
+	if plan.crossPool() {
+		checks = append(checks, plan.Promote)
+	}
+	for _, dataset := range checks {
+		owner, available, err := s.localAvailableBytes(ctx, dataset)
+		if err != nil {
+			return fmt.Errorf("restore_staging_space_check_failed: %w", err)
+		}
+		if available < needed {
+			return fmt.Errorf(
+				"restore_staging_insufficient_space: dataset=%s needed=%d available=%d",
+				owner,
+				needed,
+				available,
+			)
+		}
+	}
+	return nil
+}
+
+// prepareRestoreStaging runs the staging preflight for every dataset the
+// plan uses and creates the mirrored parents below the scratch dataset.
+func (s *Service) prepareRestoreStaging(
+	ctx context.Context,
+	plan restoreStagingPlan,
+	identity restoreStagingIdentity,
+) error {
+	if err := s.prepareRestoreStagingDataset(ctx, plan.Pull, identity); err != nil {
+		return err
+	}
+	if plan.crossPool() {
+		if err := s.prepareRestoreStagingDataset(ctx, plan.Promote, identity); err != nil {
+			return err
+		}
+	}
+	if idx := strings.LastIndex(plan.Pull, "/"); idx > 0 && plan.Scratch != "" {
+		if err := s.ensureLocalFilesystemPath(ctx, plan.Pull[:idx]); err != nil {
+			return fmt.Errorf("restore_scratch_parent_create_failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// moveRestoreStaging copies a staging tree received on another pool into a
+// .restoring sibling of the destination with a local send/recv, then drops
+// the scratch copy. The restore identity is set on the copy so the usual
+// ownership checks keep applying to it.
+func (s *Service) moveRestoreStaging(
+	ctx context.Context,
+	plan restoreStagingPlan,
+	snapshot string,
+	identity restoreStagingIdentity,
+) error {
+	if !plan.crossPool() {
+		return nil
+	}
+
+	receiveTopOptions, err := identity.receiveTopOptions()
+	if err != nil {
+		return err
+	}
+	if idx := strings.LastIndex(plan.Promote, "/"); idx > 0 {
+		if err := s.ensureLocalFilesystemPath(ctx, plan.Promote[:idx]); err != nil {
+			return fmt.Errorf("restore_staging_parent_create_failed: %w", err)
+		}
+	}
+
+	if err := sendRecvRestoreStaging(ctx, plan.Pull+snapshot, plan.Promote, strings.Fields(receiveTopOptions)); err != nil {
+		cleanupCtx, cancel := restoreRecoveryContext()
+		defer cancel()
+		return restoreErrorWithCleanup(
+			fmt.Errorf("restore_staging_move_failed: %w", err),
+			s.cleanupOwnedRestoreStaging(cleanupCtx, plan.Promote, identity),
+		)
+	}
+
+	if err := s.cleanupOwnedRestoreStaging(ctx, plan.Pull, identity); err != nil {
+		logger.L.Warn().
+			Err(err).
+			Str("dataset", plan.Pull).
+			Msg("restore_scratch_staging_cleanup_failed")
+	}
+	return nil
+}
+
+func sendRecvRestoreStaging(ctx context.Context, snapshot, destination string, recvOptions []string) error {
+	send := exec.CommandContext(ctx, "zfs", "send", "-w", "-R", snapshot)
+	recvArgs := append([]string{"recv", "-u"}, recvOptions...)
+	recv := exec.CommandContext(ctx, "zfs", append(recvArgs, destination)...)
+
+	pipeReader, pipeWriter := io.Pipe()
+	var sendErr, recvErr bytes.Buffer
+	send.Stdout = pipeWriter
+	send.Stderr = &sendErr
+	recv.Stdin = pipeReader
+	recv.Stderr = &recvErr
+
+	if err := recv.Start(); err != nil {
+		return fmt.Errorf("start_restore_staging_recv_failed: %w", err)
+	}
+	if err := send.Start(); err != nil {
+		_ = pipeWriter.Close()
+		_ = recv.Wait()
+		return fmt.Errorf("start_restore_staging_send_failed: %w", err)
+	}
+
+	sendWaitErr := send.Wait()
+	_ = pipeWriter.CloseWithError(sendWaitErr)
+	recvWaitErr := recv.Wait()
+
+	if sendWaitErr != nil {
+		return fmt.Errorf("restore_staging_send_failed: %s: %w", strings.TrimSpace(sendErr.String()), sendWaitErr)
+	}
+	if recvWaitErr != nil {
+		return fmt.Errorf("restore_staging_recv_failed: %s: %w", strings.TrimSpace(recvErr.String()), recvWaitErr)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import "testing"
+
+func TestScratchStagingDatasetRoundTrip(t *testing.T) {
+	staging := scratchStagingDataset("scratch/restore/", "zroot/sylve/jails/105")
+	if staging != "scratch/restore/zroot/sylve/jails/105.restoring" {
+		t.Fatalf("unexpected scratch staging dataset %q", staging)
+	}
+
+	kind, base := classifyStaleDataset(staging)
+	if kind != staleDatasetKindRestoring {
+		t.Fatalf("expected scratch staging to classify as restoring, got %q", kind)
+	}
+	if got := scratchStagingBase("scratch/restore", base); got != "zroot/sylve/jails/105" {
+		t.Fatalf("unexpected destination %q", got)
+	}
+
+	if got := scratchStagingDataset("", "zroot/sylve/jails/105"); got != "zroot/sylve/jails/105.restoring" {
+		t.Fatalf("expected staging next to the destination without scratch, got %q", got)
+	}
+	if got := scratchStagingBase("scratch/restore", "zroot/sylve/jails/105"); got != "zroot/sylve/jails/105" {
+		t.Fatalf("expected a base outside the scratch dataset to be kept, got %q", got)
+	}
+}
+
+func TestParseRemoteSnapshotReferenced(t *testing.T) {
+	output := "tank/backups/105@zelta_a\t100\n" +
+		"tank/backups/105@zelta_b\t400\n" +
+		"tank/backups/105/root@zelta_b\t600\n"
+
+	total, err := parseRemoteSnapshotReferenced(output, "@zelta_b")
+	if err != nil {
+		t.Fatalf("parse referenced: %v", err)
+	}
+	if total != 1000 {
+		t.Fatalf("expected 1000 bytes, got %d", total)
+	}
+
+	if _, err := parseRemoteSnapshotReferenced(output, "@zelta_c"); err == nil {
+		t.Fatal("expected a missing snapshot to fail")
+	}
+	if _, err := parseRemoteSnapshotReferenced("tank/backups/105@zelta_b\tlots\n", "@zelta_b"); err == nil {
+		t.Fatal("expected an unparsable size to fail")
+	}
+}
+
+func TestRestoreStagingSpaceNeeded(t *testing.T) {
+	if got := restoreStagingSpaceNeeded(1000, 10); got != 1100 {
+		t.Fatalf("expected 1100 with 10%% headroom, got %d", got)
+	}
+	if got := restoreStagingSpaceNeeded(1000, 0); got != 1000 {
+		t.Fatalf("expected no headroom at 0%%, got %d", got)
+	}
+}
+
+func TestRestoreStagingPlanCrossPool(t *testing.T) {
+	same := restoreStagingPlan{Pull: "zroot/scratch/zroot/a.restoring", Promote: "zroot/scratch/zroot/a.restoring"}
+	if same.crossPool() {
+		t.Fatal("expected a same-pool plan to promote from the pulled dataset")
+	}
+	cross := restoreStagingPlan{Pull: "fast/scratch/zroot/a.restoring", Promote: "zroot/a.restoring"}
+	if !cross.crossPool() {
+		t.Fatal("expected a cross-pool plan")
+	}
+	if datasetPool("fast/scratch") != "fast" || datasetPool("zroot") != "zroot" {
+		t.Fatal("unexpected pool names")
+	}
+}
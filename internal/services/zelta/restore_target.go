@@ -22,6 +22,8 @@ import (
 
 	"github.com/alchemillahq/sylve/internal/db"
 	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/pkg/utils"
 )
@@ -34,6 +36,9 @@ type restoreFromTargetPayload struct {
 	Snapshot           string `json:"snapshot"`
 	DestinationDataset string `json:"destination_dataset"`
 	RestoreNetwork     *bool  `json:"restore_network,omitempty"`
+	// PostRestoreScript replaces the guest's saved post-restore script for
+	// this restore.
+	PostRestoreScript string `json:"post_restore_script,omitempty"`
 }
 
 type BackupTargetDatasetInfo struct {
@@ -47,6 +52,10 @@ type BackupTargetDatasetInfo struct {
 	Kind          string `json:"kind"` // "dataset" | "jail" | "vm"
 	JailCTID      uint   `json:"jailCtId,omitempty"`
 	VMRID         uint   `json:"vmRid,omitempty"`
+	// Space accounting as reported by the target (bytes).
+	Used          uint64  `json:"used"`
+	Referenced    uint64  `json:"referenced"`
+	CompressRatio float64 `json:"compressRatio"`
 }
 
 type BackupJailMetadataInfo struct {
@@ -74,16 +83,16 @@ func canonicalGuestRestoreDestination(dataset, kind string, guestID uint) bool {
 	}
 
 	parts := strings.Split(dataset, "/")
-	if len(parts) != 4 || strings.TrimSpace(parts[0]) == "" || parts[1] != "sylve" {
+	if len(parts) != 4 || strings.TrimSpace(parts[0]) == "" || parts[1] != layout.RootName() {
 		return false
 	}
 
 	segment := ""
 	switch kind {
 	case clusterModels.BackupJobModeVM:
-		segment = "virtual-machines"
+		segment = layout.VirtualMachinesName()
 	case clusterModels.BackupJobModeJail:
-		segment = "jails"
+		segment = layout.JailsName()
 	default:
 		return false
 	}
@@ -116,10 +125,11 @@ func resolveOOBGuestRestoreDestination(
 	}
 	if !canonicalGuestRestoreDestination(destinationDataset, destinationKind, destinationID) {
 		return nil, fmt.Errorf(
-			"restore_guest_destination_must_be_canonical_root: expected pool/sylve/%s/%d",
+			"restore_guest_destination_must_be_canonical_root: expected pool/%s/%s/%d",
+			layout.RootName(),
 			map[string]string{
-				clusterModels.BackupJobModeVM:   "virtual-machines",
-				clusterModels.BackupJobModeJail: "jails",
+				clusterModels.BackupJobModeVM:   layout.VirtualMachinesName(),
+				clusterModels.BackupJobModeJail: layout.JailsName(),
 			}[destinationKind],
 			destinationID,
 		)
@@ -207,7 +217,7 @@ func (s *Service) ListRemoteTargetDatasets(ctx context.Context, targetID uint) (
 		return nil, err
 	}
 
-	fsOutput, err := s.runTargetZFSList(ctx, &target, "-t", "filesystem", "-r", "-Hp", "-o", "name,encryption", target.BackupRoot)
+	fsOutput, err := s.runTargetZFSList(ctx, &target, "-t", "filesystem", "-r", "-Hp", "-o", "name,encryption,used,referenced,compressratio", target.BackupRoot)
 	if err != nil {
 		return nil, fmt.Errorf("failed_to_list_target_datasets: %w", err)
 	}
@@ -243,6 +253,7 @@ func (s *Service) ListRemoteTargetDatasets(ctx context.Context, targetID uint) (
 		if dataset == "" {
 			continue
 		}
+		used, referenced, compressRatio := parseRemoteDatasetSpace(line)
 
 		snapCount := snapshotCountByDataset[dataset]
 		if snapCount < 1 {
@@ -276,6 +287,9 @@ func (s *Service) ListRemoteTargetDatasets(ctx context.Context, targetID uint) (
 			Kind:          kind,
 			JailCTID:      jailCTID,
 			VMRID:         vmRID,
+			Used:          used,
+			Referenced:    referenced,
+			CompressRatio: compressRatio,
 		})
 	}
 
@@ -313,10 +327,26 @@ func parseRemoteDatasetEncryption(line string) (dataset string, encrypted bool)
 		return dataset, false
 	}
 
-	encryption := strings.ToLower(strings.TrimSpace(fields[len(fields)-1]))
+	encryption := strings.ToLower(strings.TrimSpace(fields[1]))
 	return dataset, encryption != "" && encryption != "-" && encryption != "none" && encryption != "off"
 }
 
+// parseRemoteDatasetSpace reads the used, referenced and compressratio
+// columns that follow name and encryption. Missing or "-" values are zero.
+func parseRemoteDatasetSpace(line string) (used, referenced uint64, compressRatio float64) {
+	fields := strings.Fields(strings.TrimSpace(line))
+	if len(fields) > 2 {
+		used, _ = strconv.ParseUint(fields[2], 10, 64)
+	}
+	if len(fields) > 3 {
+		referenced, _ = strconv.ParseUint(fields[3], 10, 64)
+	}
+	if len(fields) > 4 {
+		compressRatio, _ = strconv.ParseFloat(strings.TrimSuffix(fields[4], "x"), 64)
+	}
+	return used, referenced, compressRatio
+}
+
 func (s *Service) ListRemoteTargetDatasetSnapshots(ctx context.Context, targetID uint, remoteDataset string) ([]SnapshotInfo, error) {
 	target, err := s.getRestoreTarget(targetID)
 	if err != nil {
@@ -459,10 +489,16 @@ func (s *Service) EnqueueRestoreFromTarget(
 	targetID uint,
 	remoteDataset, snapshot, destinationDataset string,
 	restoreNetwork bool,
+	postRestoreScript string,
 ) error {
 	if targetID == 0 {
 		return fmt.Errorf("invalid_target_id")
 	}
+	if postRestoreScript != "" {
+		if err := validatePostRestoreScriptBody(postRestoreScript); err != nil {
+			return err
+		}
+	}
 
 	remoteDataset = strings.TrimSpace(remoteDataset)
 	if remoteDataset == "" {
@@ -512,6 +548,7 @@ func (s *Service) EnqueueRestoreFromTarget(
 		Snapshot:           snapshot,
 		DestinationDataset: destinationDataset,
 		RestoreNetwork:     &restoreNetwork,
+		PostRestoreScript:  postRestoreScript,
 	})
 }
 
@@ -559,6 +596,22 @@ func (s *Service) registerRestoreFromTargetJob() {
 			return nil
 		}
 
+		release, err := s.waitForHeavyOpSlot(
+			ctx,
+			"restore-from-target:"+normalizeRestoreDestinationDataset(payload.DestinationDataset),
+			"restore to "+strings.TrimSpace(payload.DestinationDataset),
+			payload.DestinationDataset,
+		)
+		if err != nil {
+			logger.L.Warn().
+				Err(err).
+				Uint("target_id", payload.TargetID).
+				Str("destination_dataset", strings.TrimSpace(payload.DestinationDataset)).
+				Msg("queued_restore_from_target_job_slot_wait_failed")
+			return nil
+		}
+		defer release()
+
 		if err := s.runRestoreFromTarget(ctx, &target, payload); err != nil {
 			logger.L.Warn().
 				Err(err).
@@ -582,6 +635,8 @@ func (s *Service) runRestoreFromTarget(ctx context.Context, target *clusterModel
 		)
 	}
 	defer s.releaseRestoreDestination(payload.DestinationDataset)
+	journal := s.journalBegin(taskModels.JournalKindRestoreFromTarget, 0, target.ID, payload.DestinationDataset)
+	defer journal.done()
 	restoreWorkloadType, restoreWorkloadID := restoreWorkloadIdentityForDataset(payload.DestinationDataset)
 	if acquired, holder := s.acquireWorkloadOperation(
 		restoreWorkloadType,
@@ -693,6 +748,7 @@ func (s *Service) runRestoreFromTargetVM(
 	stopHeartbeat := s.startBackupEventHeartbeat(ctx, event.ID, time.Minute)
 	defer func() {
 		stopHeartbeat()
+		s.closeRestoreEventDatasets(event.ID, retErr)
 		output := ""
 		if current, err := s.GetLocalBackupEvent(event.ID); err == nil && current != nil {
 			output = current.Output
@@ -776,6 +832,7 @@ func (s *Service) runRestoreFromTargetVM(
 	if len(rootPlans) == 0 {
 		return fmt.Errorf("remote_vm_roots_not_found")
 	}
+	datasetRecords := s.planRestoreEventDatasets(event.ID, rootPlans)
 
 	candidateDestinations := make([]string, 0, len(rootPlans))
 	for _, plan := range rootPlans {
@@ -926,13 +983,18 @@ func (s *Service) runRestoreFromTargetVM(
 		return s.rollbackRestoredDatasetBackups(appliedBackups)
 	}
 
-	for _, plan := range rootPlans {
+	for idx, plan := range rootPlans {
 		runPayload := payload
 		runPayload.RemoteDataset = plan.remote
 		runPayload.DestinationDataset = plan.destination
 		disableNetworkRestore := false
 		runPayload.RestoreNetwork = &disableNetworkRestore
 
+		var datasetRecord *clusterModels.BackupEventDataset
+		if idx < len(datasetRecords) {
+			datasetRecord = datasetRecords[idx]
+		}
+		s.startRestoreEventDataset(datasetRecord)
 		backupDataset, err := s.runRestoreFromTargetSingleDataset(
 			ctx,
 			target,
@@ -943,6 +1005,7 @@ func (s *Service) runRestoreFromTargetVM(
 			false,
 			&event.ID,
 		)
+		s.finishRestoreEventDataset(datasetRecord, err)
 		if err != nil {
 			rollbackErr := rollbackAppliedBackups()
 			if rollbackErr != nil {
@@ -1036,6 +1099,15 @@ func (s *Service) runRestoreFromTargetVM(
 		vmRestoreFence = nil
 	}
 
+	if report := s.runPostRestoreScript(ctx, clusterModels.BackupJobModeVM, destRID, payload.PostRestoreScript); report != "" {
+		if appendErr := s.AppendBackupEventOutput(event.ID, report); appendErr != nil {
+			logger.L.Warn().
+				Err(appendErr).
+				Uint("event_id", event.ID).
+				Msg("append_post_restore_script_output_failed")
+		}
+	}
+
 	return nil
 }
 
@@ -1173,7 +1245,8 @@ func (s *Service) runRestoreFromTargetSingleDataset(
 	}
 
 	remoteEndpoint := target.SSHHost + ":" + remoteDataset + snapshot
-	restorePath := destinationDataset + ".restoring"
+	staging := s.planRestoreStaging(destinationDataset)
+	restorePath := staging.Pull
 	stagingIdentity := newRestoreStagingIdentity(jobID, target.ID, destinationDataset)
 	destinationKind, destinationGuestID := inferRestoreDatasetKind(destinationDataset)
 
@@ -1253,11 +1326,16 @@ func (s *Service) runRestoreFromTargetSingleDataset(
 			}
 		}()
 	}
-	if err := s.prepareRestoreStagingDataset(ctx, restorePath, stagingIdentity); err != nil {
+	if err := s.prepareRestoreStaging(ctx, staging, stagingIdentity); err != nil {
 		restoreErr = fmt.Errorf("restore_preflight_staging_check_failed: %w", err)
 		recordRestoreFailure(restoreErr)
 		return "", restoreErr
 	}
+	if err := s.checkRestoreStagingSpace(ctx, target, remoteDataset, snapshot, true, staging); err != nil {
+		restoreErr = err
+		recordRestoreFailure(restoreErr)
+		return "", restoreErr
+	}
 
 	if !ownsEvent {
 		appendEventOutput(fmt.Sprintf("vm_dataset_restore_start: %s -> %s", remoteEndpoint, destinationDataset))
@@ -1270,7 +1348,10 @@ func (s *Service) runRestoreFromTargetSingleDataset(
 		Str("snapshot", snapshot).
 		Msg("starting_target_dataset_restore")
 
-	extraEnv := s.buildZeltaEnv(target)
+	lease := s.transfers.acquire(target, transferClassRestore)
+	defer lease.release()
+
+	extraEnv := lease.zeltaEnv(s.buildZeltaEnv(target))
 	receiveTopOptions, err := stagingIdentity.receiveTopOptions()
 	if err != nil {
 		restoreErr = err
@@ -1317,6 +1398,12 @@ func (s *Service) runRestoreFromTargetSingleDataset(
 		}
 		return "", restoreErr
 	}
+	if err := s.moveRestoreStaging(ctx, staging, snapshot, stagingIdentity); err != nil {
+		restoreErr = s.cleanupOwnedRestoreStagingAfterError(restorePath, stagingIdentity, err)
+		recordRestoreFailure(restoreErr)
+		return "", restoreErr
+	}
+	restorePath = staging.Promote
 	if err := s.verifyRecursiveRestoreManifest(ctx, restorePath, snapshot, expectedManifest); err != nil {
 		restoreErr = s.cleanupOwnedRestoreStagingAfterError(restorePath, stagingIdentity, err)
 		recordRestoreFailure(restoreErr)
@@ -1537,6 +1624,15 @@ func (s *Service) runRestoreFromTargetSingleDataset(
 		}
 		jailRestoreFence = nil
 	}
+	if reconcileJail && ownsEvent {
+		guestType, guestID := restoreWorkloadIdentityForDataset(destinationDataset)
+		if guestType == clusterModels.BackupJobModeJail {
+			output = appendRestoreOutput(
+				output,
+				s.runPostRestoreScript(ctx, guestType, guestID, payload.PostRestoreScript),
+			)
+		}
+	}
 
 	if ownsEvent {
 		s.finalizeRestoreEvent(&event, nil, output)
@@ -1746,7 +1842,7 @@ func vmDestinationAnchor(dataset string) string {
 
 	parts := strings.Split(dataset, "/")
 	for idx := 0; idx < len(parts); idx++ {
-		if parts[idx] != "virtual-machines" {
+		if parts[idx] != layout.VirtualMachinesName() {
 			continue
 		}
 		if idx == 0 {
@@ -1771,7 +1867,7 @@ func canonicalVMDatasetRoot(dataset string, vmRID uint) string {
 	parts := strings.Split(dataset, "/")
 	vmIdx := -1
 	for idx := 0; idx+1 < len(parts); idx++ {
-		if parts[idx] != "virtual-machines" {
+		if parts[idx] != layout.VirtualMachinesName() {
 			continue
 		}
 		rid := extractDatasetGuestID(parts[idx+1])
@@ -1792,14 +1888,14 @@ func canonicalVMDatasetRoot(dataset string, vmRID uint) string {
 	}
 
 	for idx := vmIdx - 1; idx > 0; idx-- {
-		if parts[idx] != "sylve" {
+		if parts[idx] != layout.RootName() {
 			continue
 		}
 		pool := strings.TrimSpace(parts[idx-1])
 		if pool == "" {
 			break
 		}
-		return normalizeDatasetPath(strings.Join([]string{pool, "sylve", "virtual-machines", ridPart}, "/"))
+		return normalizeDatasetPath(strings.Join([]string{pool, layout.RootName(), layout.VirtualMachinesName(), ridPart}, "/"))
 	}
 
 	root := append([]string{}, parts[:vmIdx+2]...)
@@ -2453,7 +2549,7 @@ func vmDatasetRoot(dataset string) string {
 
 	parts := strings.Split(dataset, "/")
 	for idx := 0; idx+1 < len(parts); idx++ {
-		if parts[idx] != "virtual-machines" {
+		if parts[idx] != layout.VirtualMachinesName() {
 			continue
 		}
 		return strings.Join(parts[:idx+2], "/")
@@ -2612,11 +2708,11 @@ func inferRestoreDatasetKind(suffix string) (string, uint) {
 	parts := strings.Split(strings.Trim(suffix, "/"), "/")
 	for i := 0; i+1 < len(parts); i++ {
 		segment := strings.TrimSpace(parts[i])
-		if segment != "jails" && segment != "virtual-machines" {
+		if segment != layout.JailsName() && segment != layout.VirtualMachinesName() {
 			continue
 		}
 		if id := extractDatasetGuestID(parts[i+1]); id > 0 {
-			if segment == "jails" {
+			if segment == layout.JailsName() {
 				return clusterModels.BackupJobModeJail, uint(id)
 			}
 			return clusterModels.BackupJobModeVM, uint(id)
@@ -386,3 +386,73 @@ func TestRemoteDatasetForJob(t *testing.T) {
 		t.Fatal("remote dataset should not be empty")
 	}
 }
+
+func TestParseRemoteDatasetSpace(t *testing.T) {
+	line := "tank/backups/vm\taes-256-gcm\t4096\t2048\t1.53x"
+	if dataset, encrypted := parseRemoteDatasetEncryption(line); dataset != "tank/backups/vm" || !encrypted {
+		t.Fatalf("got dataset=%q encrypted=%v", dataset, encrypted)
+	}
+	used, referenced, ratio := parseRemoteDatasetSpace(line)
+	if used != 4096 || referenced != 2048 || ratio != 1.53 {
+		t.Fatalf("got used=%d referenced=%d ratio=%v", used, referenced, ratio)
+	}
+
+	used, referenced, ratio = parseRemoteDatasetSpace("tank/backups/legacy\toff")
+	if used != 0 || referenced != 0 || ratio != 0 {
+		t.Fatalf("expected zero space for short line, got %d %d %v", used, referenced, ratio)
+	}
+}
+
+func TestBuildTargetSpaceReport(t *testing.T) {
+	root := "backup/sylve"
+	datasets := []BackupTargetDatasetInfo{
+		{Name: root + "/jails/101", Suffix: "jails/101", BaseSuffix: "jails/101", Lineage: "active", SnapshotCount: 3, Kind: clusterModels.BackupJobModeJail, JailCTID: 101, Used: 100},
+		{Name: root + "/jails/101/data", Suffix: "jails/101/data", BaseSuffix: "jails/101/data", Lineage: "active", SnapshotCount: 3, Kind: clusterModels.BackupJobModeJail, JailCTID: 101, Used: 40},
+		{Name: root + "/jails/101_gen-abc", Suffix: "jails/101_gen-abc", BaseSuffix: "jails/101", Lineage: "rotated", OutOfBand: true, SnapshotCount: 2, Kind: clusterModels.BackupJobModeJail, JailCTID: 101, Used: 300},
+		{Name: root + "/virtual-machines/7_gen-x", Suffix: "virtual-machines/7_gen-x", BaseSuffix: "virtual-machines/7", Lineage: "rotated", OutOfBand: true, SnapshotCount: 1, Kind: clusterModels.BackupJobModeVM, VMRID: 7, Used: 50},
+		{Name: root + "/data/pinned_gen-1", Suffix: "data/pinned_gen-1", BaseSuffix: "data/pinned", Lineage: "rotated", OutOfBand: true, SnapshotCount: 1, Used: 10},
+		{Name: root + "/data/pinned", Suffix: "data/pinned", BaseSuffix: "data/pinned", Lineage: "active", SnapshotCount: 1, Used: 5},
+	}
+	destinations := map[string]struct{}{root + "/data/pinned_gen-1": {}}
+
+	report := buildTargetSpaceReport(root, datasets, false, destinations)
+
+	if len(report.Lineages) != 5 {
+		t.Fatalf("expected child dataset to fold into its lineage, got %d lineages", len(report.Lineages))
+	}
+	byDataset := map[string]BackupTargetLineageSpace{}
+	for _, lineage := range report.Lineages {
+		byDataset[lineage.Dataset] = lineage
+	}
+
+	active := byDataset[root+"/jails/101"]
+	if active.Datasets != 2 || active.SnapshotCount != 6 || active.SafeToDelete || active.KeepReason != lineageKeepReasonActive {
+		t.Fatalf("unexpected active lineage %+v", active)
+	}
+	if rotated := byDataset[root+"/jails/101_gen-abc"]; !rotated.SafeToDelete {
+		t.Fatalf("rotated lineage with an active copy should be deletable, got %+v", rotated)
+	}
+	if orphan := byDataset[root+"/virtual-machines/7_gen-x"]; orphan.SafeToDelete || orphan.KeepReason != lineageKeepReasonOnlyCopy {
+		t.Fatalf("only copy of a guest must be kept, got %+v", orphan)
+	}
+	if pinned := byDataset[root+"/data/pinned_gen-1"]; pinned.SafeToDelete || pinned.KeepReason != lineageKeepReasonJobTarget {
+		t.Fatalf("job destination must be kept, got %+v", pinned)
+	}
+	if report.Reclaimable != 300 {
+		t.Fatalf("expected 300 reclaimable bytes, got %d", report.Reclaimable)
+	}
+
+	if len(report.Guests) != 3 || report.Guests[0].BaseSuffix != "jails/101" {
+		t.Fatalf("expected jail 101 to dominate, got %+v", report.Guests)
+	}
+	if guest := report.Guests[0]; guest.Used != 400 || guest.ActiveUsed != 100 || guest.OutOfBandUsed != 300 || guest.Lineages != 2 {
+		t.Fatalf("unexpected guest totals %+v", guest)
+	}
+
+	running := buildTargetSpaceReport(root, datasets, true, nil)
+	for _, lineage := range running.Lineages {
+		if lineage.SafeToDelete {
+			t.Fatalf("nothing should be deletable while a job runs, got %+v", lineage)
+		}
+	}
+}
@@ -254,7 +254,7 @@ func TestListRemoteSnapshotsWithEphemeralZFS(t *testing.T) {
 			t.Fatalf("uncommitted restore point was advertised: %+v", snapshot)
 		}
 	}
-	if err := svc.runRestoreJob(ctx, &loaded, "@"+interruptedName, remoteDS); err == nil ||
+	if err := svc.runRestoreJob(ctx, &loaded, "@"+interruptedName, remoteDS, ""); err == nil ||
 		!strings.Contains(err.Error(), "not_committed") {
 		t.Fatalf("uncommitted restore point error = %v", err)
 	}
@@ -336,7 +336,7 @@ func TestRunRestoreJobDatasetWithEphemeralZFS(t *testing.T) {
 	svc.runningJobs = make(map[uint]struct{})
 	svc.runningWorkloadOp = make(map[string]string)
 
-	err = svc.runRestoreJob(ctx, &loaded, restoreSnapshot, "")
+	err = svc.runRestoreJob(ctx, &loaded, restoreSnapshot, "", "")
 	if err != nil {
 		t.Logf("restore result: %v", err)
 	}
@@ -518,7 +518,7 @@ func TestRecursiveRestoreSnapshotCoverageRejectsIncompleteTreeWithEphemeralZFS(t
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
 	defer cancel()
-	err := svc.runRestoreJob(ctx, &job, "@selected", "")
+	err := svc.runRestoreJob(ctx, &job, "@selected", "", "")
 	if err == nil {
 		t.Fatal("expected incomplete recursive snapshot coverage to fail")
 	}
@@ -615,7 +615,7 @@ func TestRunNonrecursiveRestoreJobSelectedRootOnlyWithEphemeralZFS(t *testing.T)
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
 	defer cancel()
-	if err := service.runRestoreJob(ctx, &job, "@selected", ""); err != nil {
+	if err := service.runRestoreJob(ctx, &job, "@selected", "", ""); err != nil {
 		t.Fatalf("nonrecursive restore failed: %v", err)
 	}
 
@@ -731,7 +731,7 @@ func TestRunRecursiveRestoreJobSelectedSnapshotWithEphemeralZFS(t *testing.T) {
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
 	defer cancel()
-	if err := svc.runRestoreJob(ctx, &job, "@selected", ""); err != nil {
+	if err := svc.runRestoreJob(ctx, &job, "@selected", "", ""); err != nil {
 		t.Fatalf("recursive restore failed: %v", err)
 	}
 
@@ -25,6 +25,7 @@ import (
 	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
 	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/internal/services/libvirt"
 	"gorm.io/gorm"
@@ -203,8 +204,11 @@ func (s *Service) reconcileRestoredVMFromDataset(
 			CloudInitData:          restored.CloudInitData,
 			CloudInitMetaData:      restored.CloudInitMetaData,
 			CloudInitNetworkConfig: restored.CloudInitNetworkConfig,
+			SSHKeys:                append([]string(nil), restored.SSHKeys...),
+			Tags:                   append([]string(nil), restored.Tags...),
 			ExtraBhyveOptions:      append([]string(nil), restored.ExtraBhyveOptions...),
 			IgnoreUMSR:             restored.IgnoreUMSR,
+			CPUFeatures:            append([]string(nil), restored.CPUFeatures...),
 			QemuGuestAgent:         restored.QemuGuestAgent,
 		}
 
@@ -248,8 +252,11 @@ func (s *Service) reconcileRestoredVMFromDataset(
 				"CloudInitData",
 				"CloudInitMetaData",
 				"CloudInitNetworkConfig",
+				"SSHKeys",
+				"Tags",
 				"ExtraBhyveOptions",
 				"IgnoreUMSR",
+				"CPUFeatures",
 				"QemuGuestAgent",
 			).Updates(&baseVM).Error; err != nil {
 				return fmt.Errorf("failed_to_update_restored_vm_record: %w", err)
@@ -448,7 +455,7 @@ func rewriteVMDatasetGuestID(dataset string, rid uint) string {
 
 	parts := strings.Split(dataset, "/")
 	for idx := 0; idx+1 < len(parts); idx++ {
-		if parts[idx] != "virtual-machines" || extractDatasetGuestID(parts[idx+1]) == 0 {
+		if parts[idx] != layout.VirtualMachinesName() || extractDatasetGuestID(parts[idx+1]) == 0 {
 			continue
 		}
 		parts[idx+1] = strconv.FormatUint(uint64(rid), 10)
@@ -549,8 +556,8 @@ func rebaseRestoredVMMetadataRoot(
 			}
 			if pool := strings.TrimSpace(storage.Pool); pool != "" {
 				addCandidateRoot(fmt.Sprintf(
-					"%s/sylve/virtual-machines/%d",
-					pool,
+					"%s/%d",
+					layout.VMsDataset(pool),
 					meta.VM.RID,
 				))
 			}
@@ -613,7 +620,7 @@ func rebaseRestoredVMMetadataRoot(
 
 func vmRootPool(root string) string {
 	parts := strings.Split(vmDatasetRoot(root), "/")
-	if len(parts) != 4 || parts[1] != "sylve" || parts[2] != "virtual-machines" {
+	if len(parts) != 4 || parts[1] != layout.RootName() || parts[2] != layout.VirtualMachinesName() {
 		return ""
 	}
 	if extractDatasetGuestID(parts[3]) == 0 {
@@ -666,9 +673,9 @@ func (s *Service) normalizeRestoredVMStorages(
 		} else {
 			switch cleaned.Type {
 			case vmModels.VMStorageTypeRaw:
-				datasetName = fmt.Sprintf("%s/sylve/virtual-machines/%d/raw-%d", cleaned.Pool, rid, originalID)
+				datasetName = fmt.Sprintf("%s/%d/raw-%d", layout.VMsDataset(cleaned.Pool), rid, originalID)
 			case vmModels.VMStorageTypeZVol:
-				datasetName = fmt.Sprintf("%s/sylve/virtual-machines/%d/zvol-%d", cleaned.Pool, rid, originalID)
+				datasetName = fmt.Sprintf("%s/%d/zvol-%d", layout.VMsDataset(cleaned.Pool), rid, originalID)
 			case vmModels.VMStorageTypeFilesystem:
 				logger.L.Warn().
 					Uint("rid", rid).
@@ -935,7 +942,7 @@ func inferRestoredVMRootDatasets(rid uint, storages []vmModels.Storage, destinat
 			continue
 		}
 
-		addRoot(fmt.Sprintf("%s/sylve/virtual-machines/%d", pool, rid))
+		addRoot(fmt.Sprintf("%s/%d", layout.VMsDataset(pool), rid))
 	}
 
 	destinationDataset = normalizeRestoreDestinationDataset(destinationDataset)
@@ -19,6 +19,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/alchemillahq/gzfs"
@@ -26,12 +27,15 @@ import (
 	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
 	infoModels "github.com/alchemillahq/sylve/internal/db/models/info"
 	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
 	jailServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/jail"
 	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
 	networkServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/network"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/alchemillahq/sylve/internal/services/cluster"
+	"github.com/alchemillahq/sylve/internal/webhooks"
 	"github.com/alchemillahq/sylve/pkg/utils"
 	"github.com/hashicorp/raft"
 	"github.com/robfig/cron/v3"
@@ -46,7 +50,8 @@ var (
 
 // backupJobPayload is the goqite queue payload for a backup job
 type backupJobPayload struct {
-	JobID uint `json:"job_id"`
+	JobID  uint `json:"job_id"`
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 const (
@@ -100,6 +105,21 @@ type Service struct {
 	runtimeMu    sync.RWMutex
 	runtimeClock replicationRuntimeClock
 
+	standbyRunning atomic.Bool
+
+	bulkRestoreMu   sync.Mutex
+	bulkRestoreID   uint
+	bulkRestoreStop context.CancelFunc
+
+	transfers     *transferArbiter
+	transferStats transferMetrics
+
+	applicationGate ApplicationGate
+	heavyOpGate     HeavyOpGate
+
+	onboardingMu sync.Mutex
+	onboardings  map[string]*BackupTargetOnboarding
+
 	// Local dataset seams keep host-level ZFS tests scoped to disposable pools.
 	// Production leaves them nil and uses gzfs directly.
 	localFilesystemDatasetLister func(context.Context) ([]string, error)
@@ -107,6 +127,35 @@ type Service struct {
 	localDatasetMounter          func(context.Context, string) error
 }
 
+// ApplicationGate waits for the guests a guest depends on in its
+// applications before the guest is started by a failover activation.
+type ApplicationGate func(ctx context.Context, guestType string, guestID uint) error
+
+func (s *Service) SetApplicationGate(fn ApplicationGate) {
+	s.applicationGate = fn
+}
+
+// HeavyOpGate waits for a slot on the pools a restore writes to and returns
+// the function that frees it.
+type HeavyOpGate func(ctx context.Context, key, label string, pools []string) (func(), error)
+
+func (s *Service) SetHeavyOpGate(fn HeavyOpGate) {
+	s.heavyOpGate = fn
+}
+
+// waitForHeavyOpSlot holds a restore back while its pool is busy with other
+// creates, clones and restores.
+func (s *Service) waitForHeavyOpSlot(ctx context.Context, key, label, dataset string) (func(), error) {
+	pool := parseZFSPoolNameFromDataset(dataset)
+	if s.heavyOpGate == nil || pool == "" {
+		return func() {}, nil
+	}
+	// Two restores may share a job or destination; the later one is turned
+	// away once it runs, but it must not share the earlier one's slot.
+	key = fmt.Sprintf("%s@%d", key, time.Now().UnixNano())
+	return s.heavyOpGate(ctx, key, label, []string{pool})
+}
+
 type BackupEventProgress struct {
 	Event           *clusterModels.BackupEvent `json:"event"`
 	ProgressDataset string                     `json:"progressDataset"`
@@ -114,6 +163,11 @@ type BackupEventProgress struct {
 	MovedBytes      *uint64                    `json:"movedBytes"`
 	TotalBytes      *uint64                    `json:"totalBytes"`
 	ProgressPercent *float64                   `json:"progressPercent"`
+	// Datasets breaks down restores that span several datasets, such as
+	// the disks of a VM; CurrentDataset is the 1-based position in transfer.
+	Datasets       []BackupEventDatasetProgress `json:"datasets"`
+	CurrentDataset int                          `json:"currentDataset"`
+	DatasetCount   int                          `json:"datasetCount"`
 }
 
 type BackupEventsResponse struct {
@@ -148,6 +202,7 @@ func NewService(
 		runningWorkloadOp:         make(map[string]string),
 		runningRestoreDestination: make(map[string]struct{}),
 		runtimeClock:              realReplicationRuntimeClock{},
+		transfers:                 newTransferArbiter(),
 	}
 }
 
@@ -196,7 +251,7 @@ func (s *Service) findVMByRID(rid uint) (*vmModels.VM, error) {
 	return &vm, nil
 }
 
-func backupZeltaArgs(sourceDataset, zeltaEndpoint, snapshotName string, recursive bool) []string {
+func backupZeltaArgs(sourceDataset, zeltaEndpoint, snapshotName string, recursive bool, exclude ...string) []string {
 	args := []string{
 		"backup",
 		"--json",
@@ -208,6 +263,9 @@ func backupZeltaArgs(sourceDataset, zeltaEndpoint, snapshotName string, recursiv
 	if !recursive {
 		args = append(args, "--depth", "1")
 	}
+	if len(exclude) > 0 {
+		args = append(args, "--exclude", strings.Join(exclude, ","))
+	}
 	return append(args, sourceDataset, zeltaEndpoint)
 }
 
@@ -218,10 +276,16 @@ func (s *Service) backupWithEventProgressSnapshotNameRecursive(
 	eventID uint,
 	snapshotName string,
 	recursive bool,
+	profile *clusterModels.ZeltaProfile,
+	exclude ...string,
 ) (string, error) {
 	zeltaEndpoint := target.ZeltaEndpoint(destSuffix)
-	extraEnv := s.buildZeltaEnv(target)
+	lease := s.transfers.acquire(target, transferClassBackup)
+	defer lease.release()
+
+	extraEnv := lease.zeltaEnv(s.buildZeltaEnv(target))
 	extraEnv = setEnvValue(extraEnv, "ZELTA_LOG_LEVEL", "3")
+	extraEnv = withZeltaProfile(extraEnv, profile)
 	snapshotName = strings.TrimSpace(snapshotName)
 	if snapshotName == "" {
 		snapshotName = zeltaSnapshotName("bk")
@@ -238,7 +302,7 @@ func (s *Service) backupWithEventProgressSnapshotNameRecursive(
 					Msg("append_backup_event_output_failed")
 			}
 		},
-		backupZeltaArgs(sourceDataset, zeltaEndpoint, snapshotName, recursive)...,
+		backupZeltaArgs(sourceDataset, zeltaEndpoint, snapshotName, recursive, exclude...)...,
 	)
 }
 
@@ -256,6 +320,13 @@ func (s *Service) RegisterJobs() {
 			return nil
 		}
 
+		if payload.DryRun {
+			if err := s.simulateBackupJob(ctx, &job); err != nil {
+				logger.L.Warn().Err(err).Uint("job_id", payload.JobID).Msg("queued_backup_job_simulation_failed")
+			}
+			return nil
+		}
+
 		if err := s.runBackupJob(ctx, &job); err != nil {
 			if isJobAlreadyRunningErr(err) {
 				s.releaseReservedJob(payload.JobID)
@@ -408,6 +479,7 @@ func (s *Service) StartBackupScheduler(ctx context.Context) {
 			if err := s.runBackupSchedulerTick(ctx); err != nil {
 				logger.L.Warn().Err(err).Msg("backup_scheduler_tick_failed")
 			}
+			s.runNodeStandbySchedulerTick(ctx, time.Now().UTC())
 		case <-cleanupTicker.C:
 			if err := s.ReconcileBackupTargetSSHKeys(); err != nil {
 				logger.L.Warn().Err(err).Msg("periodic_backup_target_ssh_key_reconcile_failed")
@@ -419,6 +491,8 @@ func (s *Service) StartBackupScheduler(ctx context.Context) {
 			if err := s.CleanupStaleEvents(ctx, 15*time.Minute); err != nil {
 				logger.L.Warn().Err(err).Msg("periodic_stale_event_cleanup_failed")
 			}
+			s.runStaleDatasetJanitor(ctx)
+			s.releaseStaleCloneReservations(ctx)
 		}
 	}
 }
@@ -516,7 +590,10 @@ func (s *Service) runBackupSchedulerTick(ctx context.Context) error {
 	return nil
 }
 
-func (s *Service) EnqueueBackupJob(ctx context.Context, jobID uint) error {
+// EnqueueBackupJob queues a run of the job. With dryRun set the run only
+// reports what it would do (see simulateBackupJob) and does not reserve the
+// job, so it can be simulated while a real run is pending.
+func (s *Service) EnqueueBackupJob(ctx context.Context, jobID uint, dryRun bool) error {
 	if jobID == 0 {
 		return fmt.Errorf("invalid_job_id")
 	}
@@ -526,6 +603,10 @@ func (s *Service) EnqueueBackupJob(ctx context.Context, jobID uint) error {
 		return err
 	}
 
+	if dryRun {
+		return db.EnqueueJSON(ctx, backupJobQueueName, backupJobPayload{JobID: jobID, DryRun: true})
+	}
+
 	if !s.reserveJob(jobID) {
 		return fmt.Errorf("backup_job_already_running")
 	}
@@ -542,6 +623,8 @@ func (s *Service) runBackupJob(ctx context.Context, job *clusterModels.BackupJob
 	}
 
 	defer s.releaseJob(job.ID)
+	journal := s.journalBegin(taskModels.JournalKindBackupJob, job.ID, job.TargetID, job.SourceDataset)
+	defer journal.done()
 	backupEventCreated := false
 	defer func() {
 		if backupEventCreated || s.TelemetryDB == nil {
@@ -659,6 +742,7 @@ func (s *Service) runBackupJob(ctx context.Context, job *clusterModels.BackupJob
 
 	vmRID := uint(0)
 	vmSourceDatasets := []string{}
+	var vmExcludedDatasets []string
 	if job.Mode == clusterModels.BackupJobModeVM {
 		_, parsedRID := inferRestoreDatasetKind(sourceDataset)
 		vmRID = parsedRID
@@ -668,13 +752,14 @@ func (s *Service) runBackupJob(ctx context.Context, job *clusterModels.BackupJob
 			return runErr
 		}
 
-		sources, err := s.resolveVMBackupSourceDatasets(ctx, vmRID, sourceDataset)
+		sources, excluded, err := s.resolveVMBackupSourceDatasets(ctx, vmRID, sourceDataset, vmDatasetRulesForJob(job))
 		if err != nil {
 			runErr := fmt.Errorf("resolve_vm_backup_sources_failed: %w", err)
 			s.updateBackupJobResult(job, runErr, false)
 			return runErr
 		}
 		vmSourceDatasets = sources
+		vmExcludedDatasets = excluded
 
 		preferredVMSource := normalizeDatasetPath(sourceDataset)
 		validatedSources := make([]string, 0, len(vmSourceDatasets))
@@ -818,6 +903,11 @@ func (s *Service) runBackupJob(ctx context.Context, job *clusterModels.BackupJob
 		s.updateBackupJobResult(job, runErr, encrypted)
 		return runErr
 	}
+	zeltaProfile, profileErr := s.loadZeltaProfile(job.ZeltaProfileID)
+	if profileErr != nil {
+		s.updateBackupJobResult(job, profileErr, encrypted)
+		return profileErr
+	}
 	event.TargetEndpoint = job.Target.ZeltaEndpoint(destSuffix)
 	if err := s.DB.Create(&event).Error; err != nil {
 		runErr := fmt.Errorf("create_backup_event_failed: %w", err)
@@ -849,10 +939,12 @@ func (s *Service) runBackupJob(ctx context.Context, job *clusterModels.BackupJob
 	var lastVMFailedSource string
 	var lastVMFailedDestSuffix string
 	var successfulSnapshotName string
+	var transferObs transferObservation
 
 	runDatasetBackupPass := func(datasetSource, datasetDestSuffix string) (string, backupOutputKind, error) {
 		successfulSnapshotName = ""
 		snapshotName := backupSnapshotNameForJob(job.ID)
+		journal.transfer(datasetSource, remoteActiveDatasetForSuffix(job.Target.BackupRoot, datasetDestSuffix))
 		partOutput, partErr := s.backupWithEventProgressSnapshotNameRecursive(
 			ctx,
 			&job.Target,
@@ -861,6 +953,7 @@ func (s *Service) runBackupJob(ctx context.Context, job *clusterModels.BackupJob
 			event.ID,
 			snapshotName,
 			job.Recursive,
+			zeltaProfile,
 		)
 		outcome := classifyBackupOutput(partOutput)
 		if partErr == nil {
@@ -868,6 +961,7 @@ func (s *Service) runBackupJob(ctx context.Context, job *clusterModels.BackupJob
 				partErr = errors.New(code)
 			} else {
 				successfulSnapshotName = snapshotName
+				transferObs.Created++
 			}
 		}
 		return partOutput, outcome, partErr
@@ -879,10 +973,16 @@ func (s *Service) runBackupJob(ctx context.Context, job *clusterModels.BackupJob
 		successfulSnapshotName = ""
 		vmSnapshotName := backupSnapshotNameForJob(job.ID)
 
+		for _, dataset := range vmExcludedDatasets {
+			output = appendOutput(output, "vm_dataset_excluded: "+dataset)
+		}
 		for idx, vmSource := range vmSourceDatasets {
 			vmDestSuffix := s.backupDestSuffixForVMSource(strings.TrimSpace(job.DestSuffix), vmSource)
 			output = appendOutput(output, fmt.Sprintf("vm_dataset_backup_start[%d/%d]: %s -> %s", idx+1, len(vmSourceDatasets), vmSource, job.Target.ZeltaEndpoint(vmDestSuffix)))
-			partOutput, partErr := s.backupWithEventProgressSnapshotNameRecursive(ctx, &job.Target, vmSource, vmDestSuffix, event.ID, vmSnapshotName, job.Recursive)
+			journal.transfer(vmSource, remoteActiveDatasetForSuffix(job.Target.BackupRoot, vmDestSuffix))
+			partOutput, partErr := s.backupWithEventProgressSnapshotNameRecursive(
+				ctx, &job.Target, vmSource, vmDestSuffix, event.ID, vmSnapshotName, job.Recursive, zeltaProfile, vmExcludedDatasets...,
+			)
 			output = appendOutput(output, partOutput)
 			if partErr == nil {
 				outcome := classifyBackupOutput(partOutput)
@@ -901,6 +1001,7 @@ func (s *Service) runBackupJob(ctx context.Context, job *clusterModels.BackupJob
 				lastVMFailedDestSuffix = vmDestSuffix
 				return partErr
 			}
+			transferObs.Created++
 		}
 
 		successfulSnapshotName = vmSnapshotName
@@ -912,6 +1013,11 @@ func (s *Service) runBackupJob(ctx context.Context, job *clusterModels.BackupJob
 		s.finalizeBackupEvent(&event, runErr, output)
 		s.updateBackupJobResult(job, runErr, encrypted)
 
+		transferObs.Duration = event.CompletedAt.Sub(event.StartedAt)
+		transferObs.Bytes = parseMovedBytesFromOutput(output)
+		transferObs.Err = runErr
+		s.transferStats.observe(backupTransferKey(job), transferObs)
+
 		logger.L.Info().
 			Uint("job_id", job.ID).
 			Str("status", event.Status).
@@ -1028,6 +1134,39 @@ func (s *Service) runBackupJob(ctx context.Context, job *clusterModels.BackupJob
 		}
 	}
 
+	if quiesce := s.quiesceBackupShares(ctx, job, backupScopes); quiesce.status != "" {
+		event.QuiesceStatus = quiesce.status
+		event.QuiesceDetail = quiesce.detail
+		output = appendOutput(output, "share_quiesce: "+quiesce.status)
+	}
+
+	// The post-backup hook also runs when the pre-backup hook or the run
+	// fails, so whatever the pre-backup hook paused is always resumed.
+	var postHookErr error
+	postHookPending := true
+	runPostHook := func() {
+		if !postHookPending {
+			return
+		}
+		postHookPending = false
+		hookOutput, hookErr := s.runBackupHook(context.WithoutCancel(ctx), job, event.ID, backupHookPhasePost, sourceDataset)
+		output = appendOutput(output, hookOutput)
+		postHookErr = hookErr
+	}
+	defer func() {
+		runPostHook()
+		if postHookErr != nil {
+			runErr = errors.Join(runErr, postHookErr)
+		}
+	}()
+
+	preHookOutput, preHookErr := s.runBackupHook(ctx, job, event.ID, backupHookPhasePre, sourceDataset)
+	output = appendOutput(output, preHookOutput)
+	if preHookErr != nil {
+		runErr = preHookErr
+		return runErr
+	}
+
 	backupTransferStarted = true
 	if job.Mode == clusterModels.BackupJobModeVM {
 		runErr = runVMBackupPass()
@@ -1069,8 +1208,10 @@ func (s *Service) runBackupJob(ctx context.Context, job *clusterModels.BackupJob
 		if abortErr != nil {
 			runErr = fmt.Errorf("backup_resume_abort_failed: %w (original: %v)", abortErr, runErr)
 		} else if job.Mode == clusterModels.BackupJobModeVM {
+			transferObs.Retries++
 			runErr = runVMBackupPass()
 		} else {
+			transferObs.Retries++
 			retryOutput, retryOutcome, retryErr := runDatasetBackupPass(sourceDataset, destSuffix)
 			output = appendOutput(output, retryOutput)
 			runErr = retryErr
@@ -1130,6 +1271,7 @@ func (s *Service) runBackupJob(ctx context.Context, job *clusterModels.BackupJob
 						Msg("backup_diverged_recovery_starting")
 
 					var recoverErr error
+					transferObs.Retries++
 					if job.Mode == clusterModels.BackupJobModeVM {
 						recoverErr = runVMBackupPass()
 					} else {
@@ -1176,6 +1318,7 @@ func (s *Service) runBackupJob(ctx context.Context, job *clusterModels.BackupJob
 					output = appendOutput(output, fmt.Sprintf("auto_archived_target_dataset: %s -> %s", fromDataset, archivedDataset))
 				}
 
+				transferObs.Retries++
 				if job.Mode == clusterModels.BackupJobModeVM {
 					runErr = runVMBackupPass()
 				} else {
@@ -1202,6 +1345,8 @@ func (s *Service) runBackupJob(ctx context.Context, job *clusterModels.BackupJob
 		}
 	}
 
+	runPostHook()
+
 	if runErr == nil {
 		const phase = "backup_phase: finalizing"
 		output = appendOutput(output, phase)
@@ -1327,6 +1472,7 @@ func (s *Service) runBackupJob(ctx context.Context, job *clusterModels.BackupJob
 				if err := s.destroyLocalBackupSnapshotsWithProof(ctx, pruneCandidates, retentionProofs.Source); err != nil {
 					logger.L.Warn().Err(err).Uint("job_id", job.ID).Str("source", scopeSource).Int("candidate_count", len(pruneCandidates)).Msg("backup_prune_destroy_failed")
 				} else {
+					transferObs.Pruned += len(pruneCandidates)
 					logger.L.Info().Uint("job_id", job.ID).Str("source", scopeSource).Int("pruned", len(pruneCandidates)).Msg("backup_prune_completed")
 				}
 			} else {
@@ -1361,6 +1507,7 @@ func (s *Service) runBackupJob(ctx context.Context, job *clusterModels.BackupJob
 				if err := s.destroyTargetBackupSnapshotsWithProof(ctx, &job.Target, targetPruneCandidates, retentionProofs.Target); err != nil {
 					logger.L.Warn().Err(err).Uint("job_id", job.ID).Str("source", scopeSource).Int("candidate_count", len(targetPruneCandidates)).Msg("backup_prune_target_destroy_failed")
 				} else {
+					transferObs.Pruned += len(targetPruneCandidates)
 					logger.L.Info().Uint("job_id", job.ID).Str("source", scopeSource).Int("pruned", len(targetPruneCandidates)).Msg("backup_prune_target_completed")
 				}
 			} else {
@@ -1508,7 +1655,32 @@ func jailJobLineageTail(destSuffix string) string {
 	return ""
 }
 
-func (s *Service) resolveVMBackupSourceDatasets(ctx context.Context, vmRID uint, preferred string) ([]string, error) {
+// resolveVMBackupSourceDatasets returns the VM dataset roots a backup of
+// the VM transfers, with the job's dataset rules applied, and the datasets
+// below them that the transfer has to exclude.
+func (s *Service) resolveVMBackupSourceDatasets(ctx context.Context, vmRID uint, preferred string, rules vmDatasetRules) ([]string, []string, error) {
+	sources, err := s.resolveVMBackupSourceRoots(ctx, vmRID, preferred)
+	if err != nil || rules.empty() {
+		return sources, nil, err
+	}
+
+	filesystems, err := s.listLocalFilesystemDatasets(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list_vm_datasets_for_rules_failed: %w", err)
+	}
+	volumes, err := s.listLocalVolumeDatasets(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list_vm_datasets_for_rules_failed: %w", err)
+	}
+
+	kept, excluded := applyVMDatasetRules(sources, normalizeDatasetPath(preferred), append(filesystems, volumes...), rules)
+	if len(kept) == 0 {
+		return nil, nil, fmt.Errorf("vm_source_datasets_not_found")
+	}
+	return kept, excluded, nil
+}
+
+func (s *Service) resolveVMBackupSourceRoots(ctx context.Context, vmRID uint, preferred string) ([]string, error) {
 	if vmRID == 0 {
 		return nil, fmt.Errorf("invalid_vm_rid")
 	}
@@ -1555,7 +1727,7 @@ func (s *Service) resolveVMBackupSourceDatasets(ctx context.Context, vmRID uint,
 				continue
 			}
 
-			addSource(fmt.Sprintf("%s/sylve/virtual-machines/%d", pool, vmRID))
+			addSource(fmt.Sprintf("%s/%d", layout.VMsDataset(pool), vmRID))
 		}
 	}
 
@@ -2001,6 +2173,22 @@ func (s *Service) finalizeBackupEvent(event *clusterModels.BackupEvent, runErr e
 		})
 	}
 
+	var jobID uint
+	if event.JobID != nil {
+		jobID = *event.JobID
+	}
+	webhooks.Publish(webhooks.EventBackupCompleted, map[string]any{
+		"eventId":        event.ID,
+		"jobId":          jobID,
+		"mode":           event.Mode,
+		"sourceDataset":  event.SourceDataset,
+		"targetEndpoint": event.TargetEndpoint,
+		"status":         event.Status,
+		"error":          event.Error,
+		"startedAt":      event.StartedAt,
+		"completedAt":    now,
+	})
+
 	s.emitLeftPanelRefresh(fmt.Sprintf("backup_event_finalized_%d", event.ID))
 }
 
@@ -2061,9 +2249,18 @@ func (s *Service) GetBackupEventProgress(ctx context.Context, id uint) (*BackupE
 	out.MovedBytes = parseMovedBytesFromOutput(event.Output)
 
 	if strings.EqualFold(event.Mode, "restore") {
+		if err := s.restoreEventDatasetProgress(ctx, out); err != nil {
+			logger.L.Debug().
+				Uint("event_id", id).
+				Err(err).
+				Msg("restore_progress_datasets_lookup_failed")
+		} else if out.DatasetCount > 0 {
+			return out, nil
+		}
+
 		progressDataset := strings.TrimSpace(event.TargetEndpoint)
 		if progressDataset != "" {
-			progressDataset += ".restoring"
+			progressDataset = s.restoreStagingDataset(progressDataset)
 			out.ProgressDataset = progressDataset
 			movedBytes, movedErr := zfsDatasetUsedBytes(s, ctx, progressDataset)
 			if movedErr != nil {
@@ -2315,6 +2512,12 @@ func (s *Service) buildZeltaEnv(target *clusterModels.BackupTarget) []string {
 		keyArg := fmt.Sprintf(" -i %s", target.SSHKeyPath)
 		sshBase += keyArg
 	}
+	if bind := s.replicationBindAddress(); bind != "" {
+		sshBase += " -b " + bind
+	}
+	if transport := sshTransportArgs(target); len(transport) > 0 {
+		sshBase += " " + strings.Join(transport, " ")
+	}
 	sshDefault := sshBase + " -n"
 	sshSend := sshDefault
 	sshRecv := sshBase
@@ -194,7 +194,7 @@ func (s *Service) ensureBackupTargetSSHKeyMaterialized(target *clusterModels.Bac
 	}
 
 	target.SSHKeyPath = strings.TrimSpace(target.SSHKeyPath)
-	keyData := strings.TrimSpace(target.SSHKey)
+	keyData := strings.TrimSpace(string(target.SSHKey))
 
 	if keyData == "" {
 		return nil
@@ -435,7 +435,7 @@ func sshControlPath(target *clusterModels.BackupTarget, keyPath string) string {
 
 func (s *Service) buildSSHArgs(target *clusterModels.BackupTarget) []string {
 	keyPath := ""
-	if target != nil && (strings.TrimSpace(target.SSHKey) != "" || strings.TrimSpace(target.SSHKeyPath) != "") {
+	if target != nil && (strings.TrimSpace(string(target.SSHKey)) != "" || strings.TrimSpace(target.SSHKeyPath) != "") {
 		keyPath = s.resolvedSSHKeyPath(target)
 	}
 
@@ -449,8 +449,9 @@ func (s *Service) buildSSHArgs(target *clusterModels.BackupTarget) []string {
 		"-o", "UpdateHostKeys=no",
 		"-o", "ControlMaster=auto",
 		"-o", fmt.Sprintf("ControlPath=%s", sshControlPath(target, keyPath)),
-		"-o", "ControlPersist=60",
+		"-o", fmt.Sprintf("ControlPersist=%d", target.SSHControlPersistSeconds()),
 	}
+	args = append(args, sshTransportArgs(target)...)
 
 	if target.SSHPort != 0 && target.SSHPort != 22 {
 		args = append(args, "-p", fmt.Sprintf("%d", target.SSHPort))
@@ -460,5 +461,37 @@ func (s *Service) buildSSHArgs(target *clusterModels.BackupTarget) []string {
 		args = append(args, "-i", keyPath)
 	}
 
+	if bind := s.replicationBindAddress(); bind != "" {
+		args = append(args, "-b", bind)
+	}
+
+	return args
+}
+
+// sshTransportArgs are the per-target cipher and compression options. They
+// matter most for the zfs send stream, where the OpenSSH defaults often cannot
+// keep up with a fast link.
+func sshTransportArgs(target *clusterModels.BackupTarget) []string {
+	var args []string
+	if ciphers := strings.TrimSpace(target.SSHCiphers); ciphers != "" {
+		args = append(args, "-o", "Ciphers="+ciphers)
+	}
+	if target.SSHCompression {
+		args = append(args, "-o", "Compression=yes")
+	}
 	return args
 }
+
+// replicationBindAddress is the local address outgoing transfers bind to when
+// this node has a dedicated replication network, so bulk traffic leaves over
+// that interface instead of the management LAN.
+func (s *Service) replicationBindAddress() string {
+	if s.DB == nil {
+		return ""
+	}
+	var c clusterModels.Cluster
+	if err := s.DB.Limit(1).Find(&c).Error; err != nil {
+		return ""
+	}
+	return strings.TrimSpace(c.ReplicationIP)
+}
@@ -11,10 +11,12 @@ package zelta
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
 	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/testutil"
 )
 
 func TestSaveSSHKeyWritesTrimmedKeyWithTrailingNewline(t *testing.T) {
@@ -79,6 +81,28 @@ func TestBuildSSHArgsDoesNotInventIdentityForPasswordlessTarget(t *testing.T) {
 	}
 }
 
+func TestBuildSSHArgsBindsToReplicationNetwork(t *testing.T) {
+	db := testutil.NewSQLiteTestDB(t, &clusterModels.Cluster{})
+	service := &Service{DB: db}
+	target := &clusterModels.BackupTarget{ID: 7, SSHHost: "root@10.99.0.20"}
+
+	if args := service.buildSSHArgs(target); slices.Contains(args, "-b") {
+		t.Fatalf("bind address set without a replication network: %v", args)
+	}
+
+	if err := db.Create(&clusterModels.Cluster{Enabled: true, RaftIP: "192.168.1.10", ReplicationIP: "10.99.0.10"}).Error; err != nil {
+		t.Fatalf("seed cluster: %v", err)
+	}
+	args := service.buildSSHArgs(target)
+	idx := slices.Index(args, "-b")
+	if idx < 0 || idx+1 >= len(args) || args[idx+1] != "10.99.0.10" {
+		t.Fatalf("expected -b 10.99.0.10, got %v", args)
+	}
+	if env := strings.Join(service.buildZeltaEnv(target), "\n"); !strings.Contains(env, "-b 10.99.0.10") {
+		t.Fatalf("zelta ssh commands are not bound: %s", env)
+	}
+}
+
 func TestTemporarySSHKeyIsNotRemovedAsOrphan(t *testing.T) {
 	resetZeltaTestGlobals(t)
 	SSHKeyDirectory = filepath.Join(t.TempDir(), "ssh")
@@ -376,3 +400,32 @@ func TestParseZFSPoolNameFromDataset(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildSSHArgsAppliesTargetTransportTuning(t *testing.T) {
+	t.Parallel()
+
+	service := &Service{}
+	target := &clusterModels.BackupTarget{
+		ID:                9,
+		SSHHost:           "root@10.99.0.20",
+		SSHCiphers:        "aes128-gcm@openssh.com",
+		SSHCompression:    true,
+		SSHControlPersist: 600,
+	}
+
+	args := strings.Join(service.buildSSHArgs(target), " ")
+	for _, want := range []string{"-o Ciphers=aes128-gcm@openssh.com", "-o Compression=yes", "-o ControlPersist=600"} {
+		if !strings.Contains(args, want) {
+			t.Fatalf("expected %q in ssh args: %s", want, args)
+		}
+	}
+	if env := strings.Join(service.buildZeltaEnv(target), "\n"); !strings.Contains(env, "-o Ciphers=aes128-gcm@openssh.com -o Compression=yes") {
+		t.Fatalf("zelta transfers are not tuned: %s", env)
+	}
+
+	defaults := strings.Join(service.buildSSHArgs(&clusterModels.BackupTarget{ID: 9, SSHHost: "root@10.99.0.20"}), " ")
+	if strings.Contains(defaults, "Ciphers=") || strings.Contains(defaults, "Compression=") ||
+		!strings.Contains(defaults, "ControlPersist=60") {
+		t.Fatalf("untuned target should keep OpenSSH defaults: %s", defaults)
+	}
+}
@@ -0,0 +1,232 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/logger"
+)
+
+const (
+	SSHProbeDefaultSizeMiB = 256
+	SSHProbeMaxSizeMiB     = 4096
+
+	// sshProbeBlockSize is the random block the probe stream repeats. It is
+	// far larger than any SSH compression window, so compression gains
+	// nothing on it, as with already compressed zfs send streams.
+	sshProbeBlockSize = 1 << 20
+	// sshProbeMargin is how much faster a tuned setting has to be before it
+	// is recommended over the OpenSSH defaults.
+	sshProbeMargin = 0.05
+)
+
+type SSHProbeCandidate struct {
+	Ciphers     string `json:"ciphers"`
+	Compression bool   `json:"compression"`
+}
+
+type SSHProbeMeasurement struct {
+	SSHProbeCandidate
+	Bytes      int64   `json:"bytes"`
+	DurationMs int64   `json:"durationMs"`
+	MiBps      float64 `json:"mibps"`
+	Error      string  `json:"error,omitempty"`
+}
+
+type SSHProbeReport struct {
+	TargetID     uint                  `json:"targetId"`
+	Current      SSHProbeCandidate     `json:"current"`
+	Measurements []SSHProbeMeasurement `json:"measurements"`
+	Recommended  *SSHProbeMeasurement  `json:"recommended,omitempty"`
+}
+
+// sshProbeCandidates are the settings the probe compares. The empty candidate
+// is the OpenSSH default the others are measured against.
+var sshProbeCandidates = []SSHProbeCandidate{
+	{},
+	{Ciphers: "aes128-gcm@openssh.com"},
+	{Ciphers: "aes256-gcm@openssh.com"},
+	{Ciphers: "chacha20-poly1305@openssh.com"},
+	{Ciphers: "aes128-ctr"},
+	{Ciphers: "aes128-gcm@openssh.com", Compression: true},
+}
+
+type sshProbeRunner func(ctx context.Context, candidate SSHProbeCandidate, stream io.Reader) error
+
+// sshProbeStream yields size bytes by repeating block.
+type sshProbeStream struct {
+	block     []byte
+	remaining int64
+	offset    int
+}
+
+func (r *sshProbeStream) Read(p []byte) (int, error) {
+	if r.remaining <= 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.block[r.offset:])
+	if int64(n) > r.remaining {
+		n = int(r.remaining)
+	}
+	r.offset = (r.offset + n) % len(r.block)
+	r.remaining -= int64(n)
+	return n, nil
+}
+
+// measureSSHCandidates pushes size bytes through every candidate in turn and
+// records how fast each one went.
+func measureSSHCandidates(
+	ctx context.Context,
+	candidates []SSHProbeCandidate,
+	block []byte,
+	size int64,
+	run sshProbeRunner,
+) []SSHProbeMeasurement {
+	measurements := make([]SSHProbeMeasurement, 0, len(candidates))
+	for _, candidate := range candidates {
+		measurement := SSHProbeMeasurement{SSHProbeCandidate: candidate}
+		if err := ctx.Err(); err != nil {
+			measurement.Error = err.Error()
+			measurements = append(measurements, measurement)
+			continue
+		}
+
+		stream := &sshProbeStream{block: block, remaining: size}
+		started := time.Now()
+		err := run(ctx, candidate, stream)
+		elapsed := time.Since(started)
+
+		measurement.Bytes = size - stream.remaining
+		measurement.DurationMs = elapsed.Milliseconds()
+		if err != nil {
+			measurement.Error = err.Error()
+		} else if elapsed > 0 {
+			measurement.MiBps = float64(measurement.Bytes) / (1 << 20) / elapsed.Seconds()
+		}
+		measurements = append(measurements, measurement)
+	}
+	return measurements
+}
+
+// recommendSSHCandidate picks the fastest successful measurement, keeping the
+// OpenSSH defaults unless another setting beats them by sshProbeMargin.
+func recommendSSHCandidate(measurements []SSHProbeMeasurement) *SSHProbeMeasurement {
+	var best, baseline *SSHProbeMeasurement
+	for i := range measurements {
+		m := &measurements[i]
+		if m.Error != "" || m.MiBps <= 0 {
+			continue
+		}
+		if m.SSHProbeCandidate == (SSHProbeCandidate{}) {
+			baseline = m
+		}
+		if best == nil || m.MiBps > best.MiBps {
+			best = m
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	if baseline != nil && best.MiBps < baseline.MiBps*(1+sshProbeMargin) {
+		best = baseline
+	}
+
+	recommended := *best
+	return &recommended
+}
+
+// sshProbeArgs are the target's SSH arguments with the candidate's transport
+// options in place of its own. Connection sharing is turned off, since a
+// shared connection keeps the cipher it was opened with, and stdin is kept
+// open for the probe stream.
+func (s *Service) sshProbeArgs(target clusterModels.BackupTarget, candidate SSHProbeCandidate) []string {
+	target.SSHCiphers = candidate.Ciphers
+	target.SSHCompression = candidate.Compression
+
+	base := s.buildSSHArgs(&target)
+	args := []string{"-o", "ControlMaster=no", "-o", "ControlPath=none"}
+	for i := 0; i < len(base); i++ {
+		switch {
+		case base[i] == "-n":
+			continue
+		case base[i] == "-o" && i+1 < len(base) && strings.HasPrefix(base[i+1], "Control"):
+			i++
+			continue
+		}
+		args = append(args, base[i])
+	}
+	return append(args, target.SSHHost, "cat > /dev/null")
+}
+
+// ProbeBackupTargetSSH measures SSH throughput to a target under a few cipher
+// and compression settings and recommends the fastest. The probe streams
+// incompressible data straight to the target, outside the target's bandwidth
+// limit, so it is meant to be run when the link is otherwise idle.
+func (s *Service) ProbeBackupTargetSSH(ctx context.Context, targetID uint, sizeMiB int) (*SSHProbeReport, error) {
+	if sizeMiB == 0 {
+		sizeMiB = SSHProbeDefaultSizeMiB
+	}
+	if sizeMiB < 0 || sizeMiB > SSHProbeMaxSizeMiB {
+		return nil, fmt.Errorf("ssh_probe_size_invalid")
+	}
+
+	target, err := s.getRestoreTarget(targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	block := make([]byte, sshProbeBlockSize)
+	if _, err := rand.Read(block); err != nil {
+		return nil, fmt.Errorf("ssh_probe_data_failed: %w", err)
+	}
+
+	run := func(ctx context.Context, candidate SSHProbeCandidate, stream io.Reader) error {
+		cmd := exec.CommandContext(ctx, "ssh", s.sshProbeArgs(target, candidate)...)
+		var stderr bytes.Buffer
+		cmd.Stdin = stream
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return fmt.Errorf("%s: %w", msg, err)
+			}
+			return err
+		}
+		return nil
+	}
+
+	measurements := measureSSHCandidates(ctx, sshProbeCandidates, block, int64(sizeMiB)<<20, run)
+	report := &SSHProbeReport{
+		TargetID: targetID,
+		Current: SSHProbeCandidate{
+			Ciphers:     target.SSHCiphers,
+			Compression: target.SSHCompression,
+		},
+		Measurements: measurements,
+		Recommended:  recommendSSHCandidate(measurements),
+	}
+
+	event := logger.L.Info().Uint("target_id", targetID).Int("size_mib", sizeMiB)
+	if report.Recommended != nil {
+		event = event.Str("recommended_ciphers", report.Recommended.Ciphers).
+			Bool("recommended_compression", report.Recommended.Compression).
+			Float64("recommended_mibps", report.Recommended.MiBps)
+	}
+	event.Msg("backup_target_ssh_probe_completed")
+
+	return report, nil
+}
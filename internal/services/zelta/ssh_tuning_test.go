@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+)
+
+func TestMeasureSSHCandidatesStreamsRequestedSize(t *testing.T) {
+	block := []byte("0123456789")
+	var received []int64
+	run := func(_ context.Context, candidate SSHProbeCandidate, stream io.Reader) error {
+		if candidate.Ciphers == "broken" {
+			return errors.New("no matching cipher found")
+		}
+		n, err := io.Copy(io.Discard, stream)
+		received = append(received, n)
+		return err
+	}
+
+	measurements := measureSSHCandidates(context.Background(), []SSHProbeCandidate{
+		{},
+		{Ciphers: "broken"},
+	}, block, 25, run)
+
+	if !slices.Equal(received, []int64{25}) {
+		t.Fatalf("expected one full 25 byte stream, got %v", received)
+	}
+	if measurements[0].Bytes != 25 || measurements[0].Error != "" {
+		t.Fatalf("unexpected baseline measurement %+v", measurements[0])
+	}
+	if measurements[1].Error == "" || measurements[1].MiBps != 0 {
+		t.Fatalf("expected the failing candidate to report its error, got %+v", measurements[1])
+	}
+}
+
+func TestRecommendSSHCandidateKeepsDefaultsWithinMargin(t *testing.T) {
+	measurement := func(ciphers string, mibps float64) SSHProbeMeasurement {
+		return SSHProbeMeasurement{SSHProbeCandidate: SSHProbeCandidate{Ciphers: ciphers}, MiBps: mibps}
+	}
+
+	if got := recommendSSHCandidate([]SSHProbeMeasurement{
+		measurement("", 400),
+		measurement("aes128-gcm@openssh.com", 410),
+	}); got == nil || got.Ciphers != "" {
+		t.Fatalf("expected the defaults within the margin, got %+v", got)
+	}
+
+	if got := recommendSSHCandidate([]SSHProbeMeasurement{
+		measurement("", 400),
+		measurement("aes128-gcm@openssh.com", 900),
+		{SSHProbeCandidate: SSHProbeCandidate{Ciphers: "aes256-ctr"}, MiBps: 2000, Error: "failed"},
+	}); got == nil || got.Ciphers != "aes128-gcm@openssh.com" {
+		t.Fatalf("expected the fastest working cipher, got %+v", got)
+	}
+
+	if got := recommendSSHCandidate(nil); got != nil {
+		t.Fatalf("expected no recommendation without measurements, got %+v", got)
+	}
+}
+
+func TestSSHProbeArgsDisableConnectionSharing(t *testing.T) {
+	args := (&Service{}).sshProbeArgs(clusterModels.BackupTarget{
+		ID:         3,
+		SSHHost:    "root@backup",
+		SSHCiphers: "aes256-ctr",
+	}, SSHProbeCandidate{Ciphers: "chacha20-poly1305@openssh.com"})
+
+	if slices.Contains(args, "-n") {
+		t.Fatalf("probe must keep stdin open: %v", args)
+	}
+	if slices.Contains(args, "ControlMaster=auto") || !slices.Contains(args, "ControlPath=none") {
+		t.Fatalf("probe must not share connections: %v", args)
+	}
+	if !slices.Contains(args, "Ciphers=chacha20-poly1305@openssh.com") || slices.Contains(args, "Ciphers=aes256-ctr") {
+		t.Fatalf("probe must use the candidate cipher only: %v", args)
+	}
+	if args[len(args)-2] != "root@backup" || args[len(args)-1] != "cat > /dev/null" {
+		t.Fatalf("unexpected remote command: %v", args[len(args)-2:])
+	}
+}
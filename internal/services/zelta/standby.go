@@ -0,0 +1,355 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/db/models"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	taskModels "github.com/alchemillahq/sylve/internal/db/models/task"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/layout"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// A node standby is a whole-node alternative to per-guest replication for
+// single-node installs. Every scheduled run exports the node database into
+// <pool>/sylve of the first usable pool and then sends each <pool>/sylve tree
+// recursively to <BackupRoot>/<DestSuffix>/<pool>. Because the export lives
+// inside the replicated tree, each standby generation carries a database that
+// matches its datasets exactly. ActivateNodeStandby on the standby host puts
+// the trees back at <pool>/sylve and installs the database.
+const (
+	nodeStandbyVersion       = 1
+	nodeStandbyDir           = ".sylve-standby"
+	nodeStandbyManifestFile  = "manifest.json"
+	nodeStandbyDatabaseFile  = "sylve.db"
+	nodeStandbySnapshotLabel = "sb"
+)
+
+var nodeStandbySuffixSanitizer = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+type NodeStandbyManifest struct {
+	Version      int       `json:"version"`
+	Hostname     string    `json:"hostname"`
+	NodeID       string    `json:"nodeId"`
+	SnapshotName string    `json:"snapshotName"`
+	CreatedAt    time.Time `json:"createdAt"`
+	Pools        []string  `json:"pools"`
+	// DatabasePool is the pool whose <pool>/sylve carries the database export.
+	DatabasePool string `json:"databasePool"`
+	// DatasetGUIDs maps every replicated dataset to its GUID on the source.
+	// Received datasets get new GUIDs, so activation uses this to rewrite the
+	// GUID references kept in the database.
+	DatasetGUIDs map[string]string `json:"datasetGuids"`
+}
+
+func defaultNodeStandbyDestSuffix() string {
+	hostname, err := os.Hostname()
+	if err != nil || strings.TrimSpace(hostname) == "" {
+		hostname = "node"
+	}
+	hostname = strings.SplitN(strings.TrimSpace(hostname), ".", 2)[0]
+	return "standby/" + strings.Trim(nodeStandbySuffixSanitizer.ReplaceAllString(hostname, "-"), "-")
+}
+
+func validateNodeStandbyDestSuffix(suffix string) error {
+	if suffix == "" || strings.HasPrefix(suffix, "/") || strings.ContainsAny(suffix, "@# ") {
+		return fmt.Errorf("invalid_standby_dest_suffix")
+	}
+	for _, part := range strings.Split(suffix, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("invalid_standby_dest_suffix")
+		}
+	}
+	return nil
+}
+
+func (s *Service) GetNodeStandby() (*clusterModels.NodeStandby, error) {
+	var standby clusterModels.NodeStandby
+	if err := s.DB.Preload("Target").Order("id ASC").First(&standby).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &standby, nil
+}
+
+func (s *Service) ConfigureNodeStandby(ctx context.Context, req clusterServiceInterfaces.NodeStandbyReq) (*clusterModels.NodeStandby, error) {
+	var target clusterModels.BackupTarget
+	if err := s.DB.First(&target, req.TargetID).Error; err != nil {
+		return nil, fmt.Errorf("backup_target_not_found: %w", err)
+	}
+
+	cronExpr := strings.TrimSpace(req.CronExpr)
+	nextAt, err := nextRunTime(cronExpr, time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("invalid_cron_expr: %w", err)
+	}
+
+	destSuffix := normalizeDatasetPath(strings.Trim(strings.TrimSpace(req.DestSuffix), "/"))
+	if destSuffix == "" {
+		destSuffix = defaultNodeStandbyDestSuffix()
+	}
+	if err := validateNodeStandbyDestSuffix(destSuffix); err != nil {
+		return nil, err
+	}
+
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	existing, err := s.GetNodeStandby()
+	if err != nil {
+		return nil, err
+	}
+
+	standby := clusterModels.NodeStandby{}
+	if existing != nil {
+		standby = *existing
+	}
+	standby.TargetID = target.ID
+	standby.Target = clusterModels.BackupTarget{}
+	standby.DestSuffix = destSuffix
+	standby.CronExpr = cronExpr
+	standby.Enabled = enabled
+	standby.NextRunAt = &nextAt
+
+	if err := s.DB.Save(&standby).Error; err != nil {
+		return nil, fmt.Errorf("save_node_standby_failed: %w", err)
+	}
+	return s.GetNodeStandby()
+}
+
+func (s *Service) DeleteNodeStandby() error {
+	return s.DB.Where("1 = 1").Delete(&clusterModels.NodeStandby{}).Error
+}
+
+// EnqueueNodeStandbyRun starts a standby run in the background. It fails
+// fast when a run is already in progress.
+func (s *Service) EnqueueNodeStandbyRun(ctx context.Context) error {
+	standby, err := s.GetNodeStandby()
+	if err != nil {
+		return err
+	}
+	if standby == nil {
+		return fmt.Errorf("node_standby_not_configured")
+	}
+	if !s.standbyRunning.CompareAndSwap(false, true) {
+		return fmt.Errorf("node_standby_already_running")
+	}
+
+	go func() {
+		defer s.standbyRunning.Store(false)
+		if err := s.runNodeStandby(context.WithoutCancel(ctx), standby); err != nil {
+			logger.L.Warn().Err(err).Msg("node_standby_run_failed")
+		}
+	}()
+	return nil
+}
+
+func (s *Service) runNodeStandbySchedulerTick(ctx context.Context, now time.Time) {
+	standby, err := s.GetNodeStandby()
+	if err != nil || standby == nil || !standby.Enabled {
+		return
+	}
+
+	nextAt, err := nextRunTime(standby.CronExpr, now)
+	if err != nil {
+		_ = s.DB.Model(&clusterModels.NodeStandby{}).Where("id = ?", standby.ID).Updates(map[string]any{
+			"last_status": "failed",
+			"last_error":  "invalid_cron_expr",
+			"next_run_at": nil,
+		}).Error
+		return
+	}
+	if standby.NextRunAt == nil || now.Sub(*standby.NextRunAt) > maxBackupCatchUpWindow {
+		_ = s.DB.Model(&clusterModels.NodeStandby{}).Where("id = ?", standby.ID).Update("next_run_at", nextAt).Error
+		return
+	}
+	if now.Before(*standby.NextRunAt) {
+		return
+	}
+	if !s.standbyRunning.CompareAndSwap(false, true) {
+		return
+	}
+	if err := s.DB.Model(&clusterModels.NodeStandby{}).Where("id = ?", standby.ID).Update("next_run_at", nextAt).Error; err != nil {
+		s.standbyRunning.Store(false)
+		logger.L.Warn().Err(err).Msg("failed_to_update_node_standby_next_run_at")
+		return
+	}
+
+	go func() {
+		defer s.standbyRunning.Store(false)
+		if err := s.runNodeStandby(ctx, standby); err != nil {
+			logger.L.Warn().Err(err).Msg("scheduled_node_standby_run_failed")
+		}
+	}()
+}
+
+func (s *Service) runNodeStandby(ctx context.Context, standby *clusterModels.NodeStandby) (resultErr error) {
+	startedAt := time.Now().UTC()
+	snapshotName := zeltaSnapshotName(nodeStandbySnapshotLabel)
+
+	if err := s.DB.Model(&clusterModels.NodeStandby{}).Where("id = ?", standby.ID).Updates(map[string]any{
+		"last_status": "running",
+		"last_error":  "",
+	}).Error; err != nil {
+		return err
+	}
+	journal := s.journalBegin(taskModels.JournalKindNodeStandby, standby.ID, standby.TargetID, "")
+	defer journal.done()
+	defer func() {
+		updates := map[string]any{"last_run_at": startedAt, "last_status": "success", "last_error": ""}
+		if resultErr != nil {
+			updates["last_status"] = "failed"
+			updates["last_error"] = resultErr.Error()
+		} else {
+			updates["last_snapshot"] = snapshotName
+		}
+		if err := s.DB.Model(&clusterModels.NodeStandby{}).Where("id = ?", standby.ID).Updates(updates).Error; err != nil {
+			logger.L.Warn().Err(err).Msg("failed_to_update_node_standby_result")
+		}
+	}()
+
+	target := standby.Target
+	if !target.Enabled {
+		return fmt.Errorf("backup_target_disabled")
+	}
+	if err := s.ensureBackupTargetSSHKeyMaterialized(&target); err != nil {
+		return err
+	}
+
+	var basic models.BasicSettings
+	if err := s.DB.First(&basic).Error; err != nil {
+		return fmt.Errorf("failed_to_get_basic_settings: %w", err)
+	}
+
+	roots := make([]string, 0, len(basic.Pools))
+	pools := make([]string, 0, len(basic.Pools))
+	for _, pool := range basic.Pools {
+		root := layout.RootDataset(normalizeDatasetPath(pool))
+		if _, err := utils.RunCommandWithContext(ctx, "zfs", "list", "-H", "-o", "name", root); err != nil {
+			logger.L.Warn().Str("dataset", root).Msg("node_standby_skipping_missing_pool_root")
+			continue
+		}
+		roots = append(roots, root)
+		pools = append(pools, normalizeDatasetPath(pool))
+	}
+	if len(roots) == 0 {
+		return fmt.Errorf("no_sylve_datasets_to_replicate")
+	}
+
+	guids := make(map[string]string)
+	for _, root := range roots {
+		output, err := utils.RunCommandWithContext(ctx, "zfs", "list", "-H", "-p", "-o", "name,guid", "-t", "filesystem,volume", "-r", root)
+		if err != nil {
+			return fmt.Errorf("list_sylve_datasets_failed: %w", err)
+		}
+		for name, guid := range parseNodeStandbyGUIDs(output) {
+			guids[name] = guid
+		}
+	}
+
+	hostname, _ := os.Hostname()
+	manifest := NodeStandbyManifest{
+		Version:      nodeStandbyVersion,
+		Hostname:     hostname,
+		NodeID:       s.localNodeID(),
+		SnapshotName: snapshotName,
+		CreatedAt:    startedAt,
+		Pools:        pools,
+		DatabasePool: pools[0],
+		DatasetGUIDs: guids,
+	}
+	if err := s.writeNodeStandbyExport(ctx, roots[0], manifest); err != nil {
+		return err
+	}
+
+	lease := s.transfers.acquire(&target, transferClassBackup)
+	defer lease.release()
+
+	for i, root := range roots {
+		destSuffix := standby.DestSuffix + "/" + pools[i]
+		output, err := runZeltaWithEnv(
+			ctx,
+			lease.zeltaEnv(s.buildZeltaEnv(&target)),
+			backupZeltaArgs(root, target.ZeltaEndpoint(destSuffix), snapshotName, true)...,
+		)
+		if err != nil {
+			return fmt.Errorf("node_standby_replicate_%s_failed: %w", pools[i], err)
+		}
+		logger.L.Debug().Str("dataset", root).Str("output", output).Msg("node_standby_pool_replicated")
+	}
+
+	return nil
+}
+
+// writeNodeStandbyExport writes the manifest and a consistent copy of the
+// database next to each other inside root so the following snapshot captures
+// both with the guest datasets.
+func (s *Service) writeNodeStandbyExport(ctx context.Context, root string, manifest NodeStandbyManifest) error {
+	mountpoint, err := utils.RunCommandWithContext(ctx, "zfs", "get", "-H", "-o", "value", "mountpoint", root)
+	if err != nil {
+		return fmt.Errorf("get_standby_export_mountpoint_failed: %w", err)
+	}
+	mountpoint = strings.TrimSpace(mountpoint)
+	if !strings.HasPrefix(mountpoint, "/") {
+		return fmt.Errorf("standby_export_dataset_not_mounted: %s", root)
+	}
+
+	dir := filepath.Join(mountpoint, nodeStandbyDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("create_standby_export_dir_failed: %w", err)
+	}
+
+	dbPath := filepath.Join(dir, nodeStandbyDatabaseFile)
+	tmpPath := dbPath + ".tmp"
+	_ = os.Remove(tmpPath)
+	if err := s.DB.WithContext(ctx).Exec("VACUUM INTO ?", tmpPath).Error; err != nil {
+		return fmt.Errorf("export_database_failed: %w", err)
+	}
+	if err := os.Rename(tmpPath, dbPath); err != nil {
+		return fmt.Errorf("export_database_failed: %w", err)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, nodeStandbyManifestFile), data, 0600); err != nil {
+		return fmt.Errorf("write_standby_manifest_failed: %w", err)
+	}
+	return nil
+}
+
+func parseNodeStandbyGUIDs(output string) map[string]string {
+	guids := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(strings.TrimSpace(line), "\t")
+		if len(fields) != 2 || fields[0] == "" || fields[1] == "" || fields[1] == "-" {
+			continue
+		}
+		guids[fields[0]] = fields[1]
+	}
+	return guids
+}
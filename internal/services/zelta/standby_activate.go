@@ -0,0 +1,426 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alchemillahq/sylve/internal/layout"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+// nodeStandbyGUIDTables lists the columns that reference datasets by GUID.
+var nodeStandbyGUIDTables = []string{"jail_storages", "vm_storage_datasets", "periodic_snapshots"}
+
+var standbyRunCommand = utils.RunCommandWithContext
+
+type NodeStandbyActivation struct {
+	Manifest       NodeStandbyManifest `json:"manifest"`
+	Datasets       []string            `json:"datasets"`
+	DatabasePath   string              `json:"databasePath"`
+	RewrittenGUIDs int                 `json:"rewrittenGuids"`
+	Warnings       []string            `json:"warnings"`
+}
+
+type nodeStandbyPlacement struct {
+	source      string
+	destination string
+	// aside is where an existing <pool>/sylve was moved when forced.
+	aside string
+	// copied is set when the standby tree sits on another pool and had to
+	// be received locally instead of renamed into place.
+	copied bool
+	placed bool
+}
+
+// ActivateNodeStandby adopts a node standby on the host it was replicated to.
+// Each <standbyRoot>/<pool> tree becomes <pool>/sylve again, and the database
+// exported with that generation is installed into dataPath with its dataset
+// GUIDs rewritten to the received copies. Sylve must not be running; starting
+// it afterwards brings the guests up from the adopted database.
+func ActivateNodeStandby(ctx context.Context, standbyRoot, dataPath string, force bool) (*NodeStandbyActivation, error) {
+	standbyRoot = normalizeDatasetPath(standbyRoot)
+	if standbyRoot == "" || strings.ContainsAny(standbyRoot, "@#") {
+		return nil, fmt.Errorf("invalid_standby_root")
+	}
+	dataPath = filepath.Clean(strings.TrimSpace(dataPath))
+	if dataPath == "" || dataPath == "." {
+		return nil, fmt.Errorf("invalid_data_path")
+	}
+
+	dbPath := filepath.Join(dataPath, "sylve.db")
+	if _, err := os.Stat(dbPath); err == nil && !force {
+		return nil, fmt.Errorf("database_exists_use_force: %s", dbPath)
+	}
+
+	output, err := standbyRunCommand(ctx, "zfs", "list", "-H", "-o", "name", "-t", "filesystem", "-d", "1", standbyRoot)
+	if err != nil {
+		return nil, fmt.Errorf("standby_root_not_found: %s: %w", strings.TrimSpace(output), err)
+	}
+
+	stamp := time.Now().UTC().Format("20060102150405")
+	placements, err := planNodeStandbyPlacements(standbyRoot, strings.Split(strings.TrimSpace(output), "\n"), stamp)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range placements {
+		pool := strings.SplitN(placements[i].destination, "/", 2)[0]
+		if out, err := standbyRunCommand(ctx, "zpool", "list", "-H", "-o", "name", pool); err != nil {
+			return nil, fmt.Errorf("standby_pool_missing_locally: %s: %s", pool, strings.TrimSpace(out))
+		}
+		if _, err := standbyRunCommand(ctx, "zfs", "list", "-H", "-o", "name", placements[i].destination); err == nil {
+			if !force {
+				return nil, fmt.Errorf("standby_destination_exists_use_force: %s", placements[i].destination)
+			}
+		} else {
+			placements[i].aside = ""
+		}
+	}
+
+	activation := &NodeStandbyActivation{DatabasePath: dbPath, Warnings: []string{}}
+	success := false
+	defer func() {
+		if !success {
+			rollbackNodeStandbyPlacements(context.WithoutCancel(ctx), placements)
+		}
+	}()
+
+	for i := range placements {
+		if err := placeNodeStandbyTree(ctx, &placements[i]); err != nil {
+			return nil, err
+		}
+		if err := enableNodeStandbyTree(ctx, placements[i].destination); err != nil {
+			return nil, err
+		}
+		activation.Datasets = append(activation.Datasets, placements[i].destination)
+	}
+
+	manifest, exportDir, err := findNodeStandbyExport(ctx, placements)
+	if err != nil {
+		return nil, err
+	}
+	activation.Manifest = *manifest
+
+	newGUIDs := make(map[string]string)
+	for _, placement := range placements {
+		out, err := standbyRunCommand(ctx, "zfs", "list", "-H", "-p", "-o", "name,guid", "-t", "filesystem,volume", "-r", placement.destination)
+		if err != nil {
+			return nil, fmt.Errorf("list_adopted_datasets_failed: %w", err)
+		}
+		for name, guid := range parseNodeStandbyGUIDs(out) {
+			newGUIDs[name] = guid
+		}
+	}
+	remap := nodeStandbyGUIDRemap(manifest.DatasetGUIDs, newGUIDs)
+	for _, pool := range manifest.Pools {
+		if !nodeStandbyPoolPlaced(placements, pool) {
+			activation.Warnings = append(activation.Warnings, "standby_pool_not_replicated: "+pool)
+		}
+	}
+
+	if err := os.MkdirAll(dataPath, 0755); err != nil {
+		return nil, fmt.Errorf("create_data_path_failed: %w", err)
+	}
+	stagedPath := dbPath + ".standby"
+	if err := copyNodeStandbyFile(filepath.Join(exportDir, nodeStandbyDatabaseFile), stagedPath); err != nil {
+		return nil, err
+	}
+	rewritten, err := rewriteNodeStandbyDatabaseGUIDs(stagedPath, remap)
+	if err != nil {
+		_ = os.Remove(stagedPath)
+		return nil, err
+	}
+	activation.RewrittenGUIDs = rewritten
+
+	if _, err := os.Stat(dbPath); err == nil {
+		if err := os.Rename(dbPath, dbPath+".pre-standby-"+stamp); err != nil {
+			_ = os.Remove(stagedPath)
+			return nil, fmt.Errorf("move_existing_database_failed: %w", err)
+		}
+		activation.Warnings = append(activation.Warnings, "previous_database_kept_at: "+dbPath+".pre-standby-"+stamp)
+	}
+	for _, suffix := range []string{"-wal", "-shm"} {
+		_ = os.Remove(dbPath + suffix)
+	}
+	if err := os.Rename(stagedPath, dbPath); err != nil {
+		return nil, fmt.Errorf("install_standby_database_failed: %w", err)
+	}
+
+	success = true
+	for _, placement := range placements {
+		if placement.copied {
+			activation.Warnings = append(activation.Warnings, "standby_copy_kept_at: "+placement.source)
+		}
+		if placement.aside != "" {
+			activation.Warnings = append(activation.Warnings, "previous_dataset_kept_at: "+placement.aside)
+		}
+	}
+	return activation, nil
+}
+
+func planNodeStandbyPlacements(standbyRoot string, children []string, stamp string) ([]nodeStandbyPlacement, error) {
+	var placements []nodeStandbyPlacement
+	for _, child := range children {
+		child = normalizeDatasetPath(child)
+		if child == "" || child == standbyRoot || !strings.HasPrefix(child, standbyRoot+"/") {
+			continue
+		}
+		pool := strings.TrimPrefix(child, standbyRoot+"/")
+		if pool == "" || strings.Contains(pool, "/") {
+			continue
+		}
+		destination := layout.RootDataset(pool)
+		placements = append(placements, nodeStandbyPlacement{
+			source:      child,
+			destination: destination,
+			aside:       destination + "-pre-standby-" + stamp,
+			copied:      strings.SplitN(child, "/", 2)[0] != pool,
+		})
+	}
+	if len(placements) == 0 {
+		return nil, fmt.Errorf("standby_root_has_no_pools")
+	}
+	return placements, nil
+}
+
+func placeNodeStandbyTree(ctx context.Context, placement *nodeStandbyPlacement) error {
+	if placement.aside != "" {
+		if out, err := standbyRunCommand(ctx, "zfs", "rename", placement.destination, placement.aside); err != nil {
+			return fmt.Errorf("move_existing_dataset_aside_failed: %s: %w", strings.TrimSpace(out), err)
+		}
+	}
+
+	if !placement.copied {
+		if out, err := standbyRunCommand(ctx, "zfs", "rename", placement.source, placement.destination); err != nil {
+			return fmt.Errorf("rename_standby_tree_failed: %s: %w", strings.TrimSpace(out), err)
+		}
+		placement.placed = true
+		return nil
+	}
+
+	out, err := standbyRunCommand(ctx, "zfs", "list", "-H", "-o", "name", "-t", "snapshot", "-s", "createtxg", "-d", "1", placement.source)
+	if err != nil {
+		return fmt.Errorf("list_standby_snapshots_failed: %w", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	latest := strings.TrimSpace(lines[len(lines)-1])
+	if latest == "" {
+		return fmt.Errorf("standby_tree_has_no_snapshots: %s", placement.source)
+	}
+
+	if err := sendRecvNodeStandbyTree(ctx, latest, placement.destination); err != nil {
+		return err
+	}
+	placement.placed = true
+	return nil
+}
+
+// enableNodeStandbyTree undoes the receive-side properties so the adopted
+// tree behaves like the original <pool>/sylve.
+func enableNodeStandbyTree(ctx context.Context, root string) error {
+	out, err := standbyRunCommand(ctx, "zfs", "list", "-H", "-o", "name,type,keystatus", "-t", "filesystem,volume", "-r", root)
+	if err != nil {
+		return fmt.Errorf("list_standby_tree_failed: %w", err)
+	}
+
+	var filesystems []string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Split(strings.TrimSpace(line), "\t")
+		if len(fields) != 3 {
+			continue
+		}
+		if fields[2] == "unavailable" {
+			return fmt.Errorf("standby_dataset_key_not_loaded: %s", fields[0])
+		}
+		if _, err := standbyRunCommand(ctx, "zfs", "set", "readonly=off", fields[0]); err != nil {
+			return fmt.Errorf("set_standby_readonly_off_failed: %s: %w", fields[0], err)
+		}
+		if fields[1] == "filesystem" {
+			if _, err := standbyRunCommand(ctx, "zfs", "set", "canmount=on", fields[0]); err != nil {
+				return fmt.Errorf("set_standby_canmount_failed: %s: %w", fields[0], err)
+			}
+			filesystems = append(filesystems, fields[0])
+		}
+	}
+
+	if _, err := standbyRunCommand(ctx, "zfs", "inherit", "mountpoint", root); err != nil {
+		return fmt.Errorf("inherit_standby_mountpoint_failed: %w", err)
+	}
+	for _, name := range filesystems {
+		if out, err := standbyRunCommand(ctx, "zfs", "mount", name); err != nil &&
+			!strings.Contains(strings.ToLower(out), "already mounted") {
+			return fmt.Errorf("mount_standby_dataset_failed: %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func findNodeStandbyExport(ctx context.Context, placements []nodeStandbyPlacement) (*NodeStandbyManifest, string, error) {
+	for _, placement := range placements {
+		mountpoint, err := standbyRunCommand(ctx, "zfs", "get", "-H", "-o", "value", "mountpoint", placement.destination)
+		if err != nil {
+			continue
+		}
+		dir := filepath.Join(strings.TrimSpace(mountpoint), nodeStandbyDir)
+		data, err := os.ReadFile(filepath.Join(dir, nodeStandbyManifestFile))
+		if err != nil {
+			continue
+		}
+
+		var manifest NodeStandbyManifest
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return nil, "", fmt.Errorf("parse_standby_manifest_failed: %w", err)
+		}
+		if manifest.Version != nodeStandbyVersion {
+			return nil, "", fmt.Errorf("unsupported_standby_version: %d", manifest.Version)
+		}
+		if _, err := os.Stat(filepath.Join(dir, nodeStandbyDatabaseFile)); err != nil {
+			return nil, "", fmt.Errorf("standby_database_missing: %w", err)
+		}
+		return &manifest, dir, nil
+	}
+	return nil, "", fmt.Errorf("standby_manifest_not_found")
+}
+
+// nodeStandbyGUIDRemap pairs source and received GUIDs by dataset name.
+func nodeStandbyGUIDRemap(source, received map[string]string) map[string]string {
+	remap := make(map[string]string)
+	for name, oldGUID := range source {
+		newGUID, ok := received[name]
+		if !ok || newGUID == "" || newGUID == oldGUID {
+			continue
+		}
+		remap[oldGUID] = newGUID
+	}
+	return remap
+}
+
+func nodeStandbyPoolPlaced(placements []nodeStandbyPlacement, pool string) bool {
+	for _, placement := range placements {
+		if placement.destination == layout.RootDataset(pool) {
+			return true
+		}
+	}
+	return false
+}
+
+func rewriteNodeStandbyDatabaseGUIDs(path string, remap map[string]string) (int, error) {
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{Logger: gormLogger.Discard})
+	if err != nil {
+		return 0, fmt.Errorf("open_standby_database_failed: %w", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return 0, err
+	}
+	defer sqlDB.Close()
+
+	rewritten := 0
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range nodeStandbyGUIDTables {
+			if !tx.Migrator().HasTable(table) {
+				continue
+			}
+			for oldGUID, newGUID := range remap {
+				result := tx.Table(table).Where("guid = ?", oldGUID).Update("guid", newGUID)
+				if result.Error != nil {
+					return fmt.Errorf("rewrite_%s_guid_failed: %w", table, result.Error)
+				}
+				rewritten += int(result.RowsAffected)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return rewritten, nil
+}
+
+func rollbackNodeStandbyPlacements(ctx context.Context, placements []nodeStandbyPlacement) {
+	for i := len(placements) - 1; i >= 0; i-- {
+		placement := placements[i]
+		if placement.placed {
+			if placement.copied {
+				_, _ = standbyRunCommand(ctx, "zfs", "destroy", "-r", placement.destination)
+			} else {
+				_, _ = standbyRunCommand(ctx, "zfs", "rename", placement.destination, placement.source)
+			}
+		}
+		if placement.aside != "" {
+			if _, err := standbyRunCommand(ctx, "zfs", "list", "-H", "-o", "name", placement.aside); err == nil {
+				_, _ = standbyRunCommand(ctx, "zfs", "rename", placement.aside, placement.destination)
+			}
+		}
+	}
+}
+
+func copyNodeStandbyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("open_standby_database_failed: %w", err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("stage_standby_database_failed: %w", err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return fmt.Errorf("stage_standby_database_failed: %w", err)
+	}
+	return out.Close()
+}
+
+func sendRecvNodeStandbyTree(ctx context.Context, snapshot, destination string) error {
+	send := exec.CommandContext(ctx, "zfs", "send", "-R", snapshot)
+	recv := exec.CommandContext(ctx, "zfs", "recv", "-u", destination)
+
+	pipeReader, pipeWriter := io.Pipe()
+	var sendErr, recvErr bytes.Buffer
+	send.Stdout = pipeWriter
+	send.Stderr = &sendErr
+	recv.Stdin = pipeReader
+	recv.Stderr = &recvErr
+
+	if err := recv.Start(); err != nil {
+		return fmt.Errorf("start_standby_recv_failed: %w", err)
+	}
+	if err := send.Start(); err != nil {
+		_ = pipeWriter.Close()
+		_ = recv.Wait()
+		return fmt.Errorf("start_standby_send_failed: %w", err)
+	}
+
+	sendWaitErr := send.Wait()
+	_ = pipeWriter.CloseWithError(sendWaitErr)
+	recvWaitErr := recv.Wait()
+
+	if sendWaitErr != nil {
+		return fmt.Errorf("standby_send_failed: %s: %w", strings.TrimSpace(sendErr.String()), sendWaitErr)
+	}
+	if recvWaitErr != nil {
+		return fmt.Errorf("standby_recv_failed: %s: %w", strings.TrimSpace(recvErr.String()), recvWaitErr)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+
+package zelta
+
+import (
+	"path/filepath"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestPlanNodeStandbyPlacements(t *testing.T) {
+	placements, err := planNodeStandbyPlacements("tank/standby/node1", []string{
+		"tank/standby/node1",
+		"tank/standby/node1/tank",
+		"tank/standby/node1/zroot",
+	}, "20250101000000")
+	if err != nil {
+		t.Fatalf("planNodeStandbyPlacements: %v", err)
+	}
+	if len(placements) != 2 {
+		t.Fatalf("expected 2 placements, got %#v", placements)
+	}
+
+	if placements[0].destination != "tank/sylve" || placements[0].copied {
+		t.Fatalf("same-pool tree should be renamed into place: %#v", placements[0])
+	}
+	if placements[1].destination != "zroot/sylve" || !placements[1].copied {
+		t.Fatalf("cross-pool tree should be received locally: %#v", placements[1])
+	}
+	if placements[1].aside != "zroot/sylve-pre-standby-20250101000000" {
+		t.Fatalf("unexpected aside name: %q", placements[1].aside)
+	}
+
+	if _, err := planNodeStandbyPlacements("tank/standby/node1", []string{"tank/standby/node1"}, "x"); err == nil {
+		t.Fatalf("expected an empty standby root to be rejected")
+	}
+}
+
+func TestValidateNodeStandbyDestSuffix(t *testing.T) {
+	for _, suffix := range []string{"standby/node1", "node1"} {
+		if err := validateNodeStandbyDestSuffix(suffix); err != nil {
+			t.Fatalf("expected %q to be valid: %v", suffix, err)
+		}
+	}
+	for _, suffix := range []string{"", "/abs", "a/../b", "a//b", "a@b", "a b"} {
+		if err := validateNodeStandbyDestSuffix(suffix); err == nil {
+			t.Fatalf("expected %q to be rejected", suffix)
+		}
+	}
+}
+
+func TestRewriteNodeStandbyDatabaseGUIDs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sylve.db")
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	for _, stmt := range []string{
+		"CREATE TABLE jail_storages (id INTEGER PRIMARY KEY, guid TEXT)",
+		"CREATE TABLE vm_storage_datasets (id INTEGER PRIMARY KEY, guid TEXT)",
+		"INSERT INTO jail_storages (guid) VALUES ('111'), ('999')",
+		"INSERT INTO vm_storage_datasets (guid) VALUES ('222')",
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("seed %q: %v", stmt, err)
+		}
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.Close()
+
+	source := map[string]string{"tank/sylve/jails/1": "111", "tank/sylve/virtual-machines/2": "222", "tank/sylve/gone": "333"}
+	received := map[string]string{"tank/sylve/jails/1": "1111", "tank/sylve/virtual-machines/2": "2222"}
+	remap := nodeStandbyGUIDRemap(source, received)
+	if len(remap) != 2 || remap["111"] != "1111" || remap["222"] != "2222" {
+		t.Fatalf("unexpected remap: %#v", remap)
+	}
+
+	rewritten, err := rewriteNodeStandbyDatabaseGUIDs(path, remap)
+	if err != nil {
+		t.Fatalf("rewriteNodeStandbyDatabaseGUIDs: %v", err)
+	}
+	if rewritten != 2 {
+		t.Fatalf("expected 2 rewritten rows, got %d", rewritten)
+	}
+
+	db, err = gorm.Open(sqlite.Open(path), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("reopen db: %v", err)
+	}
+	var guids []string
+	if err := db.Raw("SELECT guid FROM jail_storages ORDER BY id").Scan(&guids).Error; err != nil {
+		t.Fatalf("read jail storages: %v", err)
+	}
+	if len(guids) != 2 || guids[0] != "1111" || guids[1] != "999" {
+		t.Fatalf("unexpected jail storage guids: %v", guids)
+	}
+}
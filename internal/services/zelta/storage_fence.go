@@ -0,0 +1,331 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	zfsModels "github.com/alchemillahq/sylve/internal/db/models/zfs"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/utils"
+)
+
+const (
+	StorageFenceModeMMP  = "mmp"
+	StorageFenceModeSCSI = "scsi"
+
+	// Write Exclusive, Registrants Only: every registered node may write, so
+	// preempting a peer's registration is what cuts it off the LUN.
+	storageFenceReservationType = "wr_ex_ro"
+)
+
+var storageFenceRunCommand = utils.RunCommandWithContext
+
+var (
+	storageFenceDeviceRe = regexp.MustCompile(`^(/dev/)?[a-z]+[0-9]+$`)
+	storageFenceKeyRe    = regexp.MustCompile(`(?i)reservation key:\s*(0x[0-9a-f]+)`)
+)
+
+type StorageFenceRequest struct {
+	Pool           string   `json:"pool" binding:"required"`
+	Mode           string   `json:"mode" binding:"required"`
+	Devices        []string `json:"devices"`
+	ReservationKey string   `json:"reservationKey"`
+	Enabled        bool     `json:"enabled"`
+}
+
+func normalizeStorageFenceKey(key string) (string, error) {
+	key = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(key)), "0x")
+	if key == "" {
+		return "", fmt.Errorf("storage_fence_reservation_key_required")
+	}
+	value, err := strconv.ParseUint(key, 16, 64)
+	if err != nil || value == 0 {
+		return "", fmt.Errorf("invalid_storage_fence_reservation_key")
+	}
+	return fmt.Sprintf("0x%x", value), nil
+}
+
+func normalizeStorageFenceDevice(device string) (string, error) {
+	device = strings.TrimSpace(device)
+	if !storageFenceDeviceRe.MatchString(device) {
+		return "", fmt.Errorf("invalid_storage_fence_device: %s", device)
+	}
+	return strings.TrimPrefix(device, "/dev/"), nil
+}
+
+func validateStorageFenceRequest(req StorageFenceRequest) (zfsModels.StorageFence, error) {
+	fence := zfsModels.StorageFence{
+		Pool:    strings.TrimSpace(req.Pool),
+		Mode:    strings.ToLower(strings.TrimSpace(req.Mode)),
+		Devices: []string{},
+		Enabled: req.Enabled,
+	}
+	if fence.Pool == "" || strings.ContainsAny(fence.Pool, "/@# ") {
+		return fence, fmt.Errorf("invalid_pool_name")
+	}
+
+	switch fence.Mode {
+	case StorageFenceModeMMP:
+		if len(req.Devices) > 0 {
+			return fence, fmt.Errorf("storage_fence_mmp_takes_no_devices")
+		}
+	case StorageFenceModeSCSI:
+		if len(req.Devices) == 0 {
+			return fence, fmt.Errorf("storage_fence_devices_required")
+		}
+		seen := map[string]struct{}{}
+		for _, raw := range req.Devices {
+			device, err := normalizeStorageFenceDevice(raw)
+			if err != nil {
+				return fence, err
+			}
+			if _, ok := seen[device]; ok {
+				continue
+			}
+			seen[device] = struct{}{}
+			fence.Devices = append(fence.Devices, device)
+		}
+		key, err := normalizeStorageFenceKey(req.ReservationKey)
+		if err != nil {
+			return fence, err
+		}
+		fence.ReservationKey = key
+	default:
+		return fence, fmt.Errorf("invalid_storage_fence_mode")
+	}
+
+	return fence, nil
+}
+
+func (s *Service) ListStorageFences() ([]zfsModels.StorageFence, error) {
+	var fences []zfsModels.StorageFence
+	if err := s.DB.Order("pool ASC").Find(&fences).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_list_storage_fences: %w", err)
+	}
+	return fences, nil
+}
+
+// SaveStorageFence creates or replaces the fence configured for a pool.
+func (s *Service) SaveStorageFence(req StorageFenceRequest) (zfsModels.StorageFence, error) {
+	fence, err := validateStorageFenceRequest(req)
+	if err != nil {
+		return fence, err
+	}
+
+	var existing zfsModels.StorageFence
+	if err := s.DB.Where("pool = ?", fence.Pool).Limit(1).Find(&existing).Error; err != nil {
+		return fence, fmt.Errorf("failed_to_get_storage_fence: %w", err)
+	}
+	fence.ID = existing.ID
+	fence.CreatedAt = existing.CreatedAt
+
+	if err := s.DB.Save(&fence).Error; err != nil {
+		return fence, fmt.Errorf("failed_to_save_storage_fence: %w", err)
+	}
+	return fence, nil
+}
+
+func (s *Service) DeleteStorageFence(pool string) error {
+	result := s.DB.Where("pool = ?", strings.TrimSpace(pool)).Delete(&zfsModels.StorageFence{})
+	if result.Error != nil {
+		return fmt.Errorf("failed_to_delete_storage_fence: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("storage_fence_not_found")
+	}
+	return nil
+}
+
+// CheckStorageFence verifies that this node may own the pool. A pool that is
+// not imported is imported here, but only once the fence allows it and never
+// with -f. With preempt set, SCSI reservations held by the peer are taken
+// over; callers must already hold failover authority when asking for that.
+func (s *Service) CheckStorageFence(ctx context.Context, pool string, preempt bool) error {
+	var fence zfsModels.StorageFence
+	if err := s.DB.Where("pool = ?", strings.TrimSpace(pool)).First(&fence).Error; err != nil {
+		return fmt.Errorf("storage_fence_not_found")
+	}
+	return s.checkStorageFence(ctx, &fence, preempt)
+}
+
+func (s *Service) checkStorageFence(ctx context.Context, fence *zfsModels.StorageFence, preempt bool) error {
+	var err error
+	switch fence.Mode {
+	case StorageFenceModeMMP:
+		err = checkMultihostFence(ctx, fence.Pool)
+	case StorageFenceModeSCSI:
+		err = checkReservationFence(ctx, fence, preempt)
+	default:
+		err = fmt.Errorf("invalid_storage_fence_mode")
+	}
+
+	if err == nil && !storageFencePoolImported(ctx, fence.Pool) {
+		if out, importErr := storageFenceRunCommand(ctx, "zpool", "import", fence.Pool); importErr != nil {
+			err = fmt.Errorf("storage_fence_pool_import_failed: %s: %s", fence.Pool, strings.TrimSpace(out))
+		} else if fence.Mode == StorageFenceModeMMP {
+			err = checkMultihostFence(ctx, fence.Pool)
+		}
+	}
+
+	lastError := ""
+	if err != nil {
+		lastError = err.Error()
+	}
+	if updateErr := s.DB.Model(&zfsModels.StorageFence{}).
+		Where("id = ?", fence.ID).
+		Updates(map[string]any{"last_checked_at": time.Now().UTC(), "last_error": lastError}).Error; updateErr != nil {
+		logger.L.Warn().Err(updateErr).Str("pool", fence.Pool).Msg("storage_fence_status_update_failed")
+	}
+
+	return err
+}
+
+// enforceStorageFences runs every enabled fence on this node. It is called
+// before a replicated guest is activated on failover so that a guest never
+// starts from shared storage the peer may still be writing to.
+func (s *Service) enforceStorageFences(ctx context.Context, preempt bool) error {
+	var fences []zfsModels.StorageFence
+	if err := s.DB.Where("enabled = ?", true).Order("pool ASC").Find(&fences).Error; err != nil {
+		return fmt.Errorf("failed_to_list_storage_fences: %w", err)
+	}
+	for i := range fences {
+		if err := s.checkStorageFence(ctx, &fences[i], preempt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func storageFencePoolImported(ctx context.Context, pool string) bool {
+	_, err := storageFenceRunCommand(ctx, "zpool", "list", "-H", "-o", "name", pool)
+	return err == nil
+}
+
+// checkMultihostFence relies on MMP: with multihost=on, ZFS itself refuses to
+// import a pool whose activity it can see from another host, and suspends a
+// pool that loses its heartbeat. Both require a unique, non-zero hostid.
+func checkMultihostFence(ctx context.Context, pool string) error {
+	out, err := storageFenceRunCommand(ctx, "sysctl", "-n", "kern.hostid")
+	if err != nil {
+		return fmt.Errorf("storage_fence_hostid_unavailable: %s", strings.TrimSpace(out))
+	}
+	if hostID := strings.TrimSpace(out); hostID == "" || hostID == "0" {
+		return fmt.Errorf("storage_fence_hostid_unset")
+	}
+
+	if !storageFencePoolImported(ctx, pool) {
+		out, _ := storageFenceRunCommand(ctx, "zpool", "import")
+		state, found := importablePoolState(out, pool)
+		if !found {
+			return fmt.Errorf("storage_fence_pool_not_found: %s", pool)
+		}
+		if strings.Contains(state, "imported by another system") {
+			return fmt.Errorf("storage_fence_pool_active_elsewhere: %s", pool)
+		}
+		// The multihost property is only readable once imported; the caller
+		// checks again after the import, which itself runs the MMP activity
+		// test.
+		return nil
+	}
+
+	out, err = storageFenceRunCommand(ctx, "zpool", "get", "-H", "-o", "value", "multihost,health", pool)
+	if err != nil {
+		return fmt.Errorf("storage_fence_pool_property_failed: %s: %s", pool, strings.TrimSpace(out))
+	}
+	values := strings.Fields(out)
+	if len(values) != 2 {
+		return fmt.Errorf("storage_fence_pool_property_failed: %s: unexpected output", pool)
+	}
+	if values[0] != "on" {
+		return fmt.Errorf("storage_fence_multihost_disabled: %s", pool)
+	}
+	if values[1] == "SUSPENDED" {
+		return fmt.Errorf("storage_fence_pool_suspended: %s", pool)
+	}
+	return nil
+}
+
+// importablePoolState returns the `zpool import` stanza describing pool.
+func importablePoolState(output, pool string) (string, bool) {
+	var stanza []string
+	found := false
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "pool:") {
+			if found {
+				break
+			}
+			found = strings.TrimSpace(strings.TrimPrefix(trimmed, "pool:")) == pool
+		}
+		if found {
+			stanza = append(stanza, strings.ToLower(trimmed))
+		}
+	}
+	return strings.Join(stanza, "\n"), found
+}
+
+func readReservationKey(ctx context.Context, device string) (string, error) {
+	out, err := storageFenceRunCommand(ctx, "camcontrol", "persist", device, "-i", "read_reservation")
+	if err != nil {
+		return "", fmt.Errorf("storage_fence_read_reservation_failed: %s: %s", device, strings.TrimSpace(out))
+	}
+	match := storageFenceKeyRe.FindStringSubmatch(out)
+	if match == nil {
+		return "", nil
+	}
+	return normalizeStorageFenceKey(match[1])
+}
+
+// checkReservationFence makes sure this node holds the persistent
+// reservation on every LUN backing the pool, registering and reserving with
+// its own key when a LUN is free.
+func checkReservationFence(ctx context.Context, fence *zfsModels.StorageFence, preempt bool) error {
+	key := fence.ReservationKey
+	for _, device := range fence.Devices {
+		holder, err := readReservationKey(ctx, device)
+		if err != nil {
+			return err
+		}
+		if holder == key {
+			continue
+		}
+		if holder != "" && !preempt {
+			return fmt.Errorf("storage_fence_reserved_by_other_host: %s: %s", device, holder)
+		}
+
+		if out, err := storageFenceRunCommand(ctx, "camcontrol", "persist", device,
+			"-o", "register_ignore", "-K", key); err != nil {
+			return fmt.Errorf("storage_fence_register_failed: %s: %s", device, strings.TrimSpace(out))
+		}
+
+		args := []string{"persist", device, "-o", "reserve", "-k", key, "-T", storageFenceReservationType}
+		if holder != "" {
+			args = []string{"persist", device, "-o", "preempt", "-k", key, "-K", holder, "-T", storageFenceReservationType}
+			logger.L.Warn().Str("device", device).Str("holder", holder).Msg("storage_fence_preempting_reservation")
+		}
+		if out, err := storageFenceRunCommand(ctx, "camcontrol", args...); err != nil {
+			return fmt.Errorf("storage_fence_reserve_failed: %s: %s", device, strings.TrimSpace(out))
+		}
+
+		holder, err = readReservationKey(ctx, device)
+		if err != nil {
+			return err
+		}
+		if holder != key {
+			return fmt.Errorf("storage_fence_reserved_by_other_host: %s: %s", device, holder)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	zfsModels "github.com/alchemillahq/sylve/internal/db/models/zfs"
+	"github.com/alchemillahq/sylve/internal/testutil"
+)
+
+type fakeFenceHost struct {
+	imported    bool
+	multihost   string
+	reservation string
+	calls       []string
+}
+
+func (h *fakeFenceHost) run(_ context.Context, command string, args ...string) (string, error) {
+	call := strings.TrimSpace(command + " " + strings.Join(args, " "))
+	h.calls = append(h.calls, call)
+
+	switch {
+	case call == "sysctl -n kern.hostid":
+		return "1234\n", nil
+	case strings.HasPrefix(call, "zpool list"):
+		if !h.imported {
+			return "cannot open 'tank': no such pool", fmt.Errorf("exit status 1")
+		}
+		return "tank\n", nil
+	case call == "zpool import":
+		return "   pool: tank\n     id: 1\n  state: ONLINE\n status: The pool is currently imported by another system.\n", nil
+	case strings.HasPrefix(call, "zpool import "):
+		h.imported = true
+		return "", nil
+	case strings.HasPrefix(call, "zpool get"):
+		return h.multihost + "\nONLINE\n", nil
+	case strings.Contains(call, "-i read_reservation"):
+		if h.reservation == "" {
+			return "PRgeneration: 0x1\nNo reservation.\n", nil
+		}
+		return "PRgeneration: 0x2\nReservation Key: " + h.reservation + "\nType: Write Exclusive, Registrants Only\n", nil
+	case strings.Contains(call, "-o reserve"), strings.Contains(call, "-o preempt"):
+		for i := range args {
+			if args[i] == "-k" && i+1 < len(args) {
+				h.reservation = args[i+1]
+			}
+		}
+		return "", nil
+	}
+	return "", nil
+}
+
+func newStorageFenceTestService(t *testing.T, host *fakeFenceHost) *Service {
+	t.Helper()
+
+	prev := storageFenceRunCommand
+	storageFenceRunCommand = host.run
+	t.Cleanup(func() { storageFenceRunCommand = prev })
+
+	return &Service{DB: testutil.NewSQLiteTestDB(t, &zfsModels.StorageFence{})}
+}
+
+func TestValidateStorageFenceRequest(t *testing.T) {
+	fence, err := validateStorageFenceRequest(StorageFenceRequest{
+		Pool: "tank", Mode: "SCSI", Devices: []string{"/dev/da1", "da1", "da2"}, ReservationKey: "0xABCD",
+	})
+	if err != nil {
+		t.Fatalf("valid scsi fence: %v", err)
+	}
+	if fence.Mode != StorageFenceModeSCSI || len(fence.Devices) != 2 || fence.ReservationKey != "0xabcd" {
+		t.Fatalf("normalized fence = %+v", fence)
+	}
+
+	cases := map[string]StorageFenceRequest{
+		"invalid_pool_name":                      {Pool: "tank/vm", Mode: "mmp"},
+		"storage_fence_mmp_takes_no_devices":     {Pool: "tank", Mode: "mmp", Devices: []string{"da1"}},
+		"storage_fence_devices_required":         {Pool: "tank", Mode: "scsi", ReservationKey: "1"},
+		"invalid_storage_fence_device":           {Pool: "tank", Mode: "scsi", Devices: []string{"../da1"}, ReservationKey: "1"},
+		"storage_fence_reservation_key_required": {Pool: "tank", Mode: "scsi", Devices: []string{"da1"}},
+		"invalid_storage_fence_reservation_key":  {Pool: "tank", Mode: "scsi", Devices: []string{"da1"}, ReservationKey: "0"},
+		"invalid_storage_fence_mode":             {Pool: "tank", Mode: "stonith"},
+	}
+	for want, req := range cases {
+		if _, err := validateStorageFenceRequest(req); err == nil || !strings.HasPrefix(err.Error(), want) {
+			t.Fatalf("validateStorageFenceRequest(%+v) = %v, want %s", req, err, want)
+		}
+	}
+}
+
+func TestMultihostFenceRefusesPoolActiveElsewhere(t *testing.T) {
+	host := &fakeFenceHost{multihost: "on"}
+	svc := newStorageFenceTestService(t, host)
+	if _, err := svc.SaveStorageFence(StorageFenceRequest{Pool: "tank", Mode: "mmp", Enabled: true}); err != nil {
+		t.Fatalf("SaveStorageFence: %v", err)
+	}
+
+	err := svc.enforceStorageFences(context.Background(), true)
+	if err == nil || !strings.HasPrefix(err.Error(), "storage_fence_pool_active_elsewhere") {
+		t.Fatalf("enforceStorageFences = %v, want storage_fence_pool_active_elsewhere", err)
+	}
+	for _, call := range host.calls {
+		if call == "zpool import tank" {
+			t.Fatal("pool must not be imported while another host is active")
+		}
+	}
+
+	var stored zfsModels.StorageFence
+	svc.DB.First(&stored)
+	if !strings.HasPrefix(stored.LastError, "storage_fence_pool_active_elsewhere") || stored.LastCheckedAt.IsZero() {
+		t.Fatalf("stored fence status = %+v", stored)
+	}
+}
+
+func TestMultihostFenceRequiresMultihostOnImportedPool(t *testing.T) {
+	host := &fakeFenceHost{imported: true, multihost: "off"}
+	svc := newStorageFenceTestService(t, host)
+	if _, err := svc.SaveStorageFence(StorageFenceRequest{Pool: "tank", Mode: "mmp", Enabled: true}); err != nil {
+		t.Fatalf("SaveStorageFence: %v", err)
+	}
+
+	if err := svc.CheckStorageFence(context.Background(), "tank", false); err == nil ||
+		!strings.HasPrefix(err.Error(), "storage_fence_multihost_disabled") {
+		t.Fatalf("CheckStorageFence = %v, want storage_fence_multihost_disabled", err)
+	}
+
+	host.multihost = "on"
+	if err := svc.CheckStorageFence(context.Background(), "tank", false); err != nil {
+		t.Fatalf("CheckStorageFence with multihost=on: %v", err)
+	}
+}
+
+func TestReservationFencePreemptsOnlyWhenAllowed(t *testing.T) {
+	host := &fakeFenceHost{imported: true, reservation: "0xbeef"}
+	svc := newStorageFenceTestService(t, host)
+	if _, err := svc.SaveStorageFence(StorageFenceRequest{
+		Pool: "tank", Mode: "scsi", Devices: []string{"da1"}, ReservationKey: "0xcafe", Enabled: true,
+	}); err != nil {
+		t.Fatalf("SaveStorageFence: %v", err)
+	}
+
+	if err := svc.CheckStorageFence(context.Background(), "tank", false); err == nil ||
+		!strings.HasPrefix(err.Error(), "storage_fence_reserved_by_other_host") {
+		t.Fatalf("CheckStorageFence without preempt = %v", err)
+	}
+	if host.reservation != "0xbeef" {
+		t.Fatalf("reservation changed without preempt: %s", host.reservation)
+	}
+
+	if err := svc.CheckStorageFence(context.Background(), "tank", true); err != nil {
+		t.Fatalf("CheckStorageFence with preempt: %v", err)
+	}
+	if host.reservation != "0xcafe" {
+		t.Fatalf("reservation after preempt = %s, want 0xcafe", host.reservation)
+	}
+}
@@ -0,0 +1,381 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/ssh"
+)
+
+const backupTargetOnboardingTTL = 30 * time.Minute
+
+const (
+	OnboardingStepAuth       = "auth"
+	OnboardingStepZFS        = "zfs"
+	OnboardingStepPool       = "pool"
+	OnboardingStepBackupRoot = "backup_root"
+
+	OnboardingStepPassed  = "passed"
+	OnboardingStepFailed  = "failed"
+	OnboardingStepSkipped = "skipped"
+)
+
+// Overridden in tests.
+var onboardingRunCommand = utils.RunCommandWithContext
+
+var ErrBackupTargetOnboardingNotFound = fmt.Errorf("backup_target_onboarding_not_found")
+
+// BackupTargetOnboardingSetup is what an operator has to put on the target
+// before the connection test can pass.
+type BackupTargetOnboardingSetup struct {
+	SSHUser            string   `json:"sshUser"`
+	AuthorizedKeysFile string   `json:"authorizedKeysFile"`
+	AuthorizedKeysLine string   `json:"authorizedKeysLine"`
+	Commands           []string `json:"commands"`
+}
+
+type BackupTargetOnboardingStep struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// BackupTargetOnboarding is a pending SSH backup target. It only lives in
+// memory on the node that started it; the private key never leaves the node
+// until the target is created.
+type BackupTargetOnboarding struct {
+	ID         string                       `json:"id"`
+	SSHHost    string                       `json:"sshHost"`
+	SSHPort    int                          `json:"sshPort"`
+	BackupRoot string                       `json:"backupRoot"`
+	PublicKey  string                       `json:"publicKey"`
+	Setup      BackupTargetOnboardingSetup  `json:"setup"`
+	Steps      []BackupTargetOnboardingStep `json:"steps"`
+	Verified   bool                         `json:"verified"`
+	ExpiresAt  time.Time                    `json:"expiresAt"`
+
+	privateKey string
+}
+
+func generateOnboardingKeyPair(comment string) (string, string, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", fmt.Errorf("generate_ssh_key_failed: %w", err)
+	}
+
+	block, err := ssh.MarshalPrivateKey(priv, comment)
+	if err != nil {
+		return "", "", fmt.Errorf("marshal_ssh_private_key_failed: %w", err)
+	}
+
+	sshPub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		return "", "", fmt.Errorf("marshal_ssh_public_key_failed: %w", err)
+	}
+
+	publicKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshPub))) + " " + comment
+	return string(pem.EncodeToMemory(block)), publicKey, nil
+}
+
+func onboardingSSHUser(sshHost string) string {
+	if user, _, ok := strings.Cut(sshHost, "@"); ok && user != "" {
+		return user
+	}
+	return "root"
+}
+
+// backupTargetDelegatedPermissions is what a non-root SSH user needs on the
+// backup root: a tenant's receive set plus what Sylve's own retention uses to
+// prune and roll back received datasets.
+var backupTargetDelegatedPermissions = append(append([]string{}, backupTenantPermissions...),
+	"destroy", "rollback", "snapshot", "hold", "release",
+)
+
+// backupTargetOnboardingSetup builds the target-side configuration. Root
+// only needs the key. For any other user root creates the backup root and
+// delegates on it alone, so nothing above the root is handed out.
+func backupTargetOnboardingSetup(user, backupRoot, publicKey string) BackupTargetOnboardingSetup {
+	setup := BackupTargetOnboardingSetup{
+		SSHUser:            user,
+		AuthorizedKeysLine: "restrict " + publicKey,
+		Commands:           []string{},
+	}
+
+	if user == "root" {
+		setup.AuthorizedKeysFile = "/root/.ssh/authorized_keys"
+		setup.Commands = append(setup.Commands,
+			"grep -q '^PermitRootLogin prohibit-password' /etc/ssh/sshd_config || echo 'PermitRootLogin prohibit-password' >> /etc/ssh/sshd_config",
+			"service sshd reload",
+		)
+		return setup
+	}
+
+	setup.AuthorizedKeysFile = "~" + user + "/.ssh/authorized_keys"
+	setup.Commands = append(setup.Commands,
+		fmt.Sprintf("zfs create -p %s", backupRoot),
+		fmt.Sprintf("zfs allow -u %s %s %s", user, strings.Join(backupTargetDelegatedPermissions, ","), backupRoot),
+		"sysctl vfs.usermount=1",
+		"grep -q '^vfs.usermount=1' /etc/sysctl.conf || echo 'vfs.usermount=1' >> /etc/sysctl.conf",
+	)
+	return setup
+}
+
+func (s *Service) pruneBackupTargetOnboardingsLocked(now time.Time) {
+	for id, o := range s.onboardings {
+		if now.After(o.ExpiresAt) {
+			delete(s.onboardings, id)
+		}
+	}
+}
+
+func (s *Service) StartBackupTargetOnboarding(req clusterServiceInterfaces.BackupTargetOnboardingReq) (*BackupTargetOnboarding, error) {
+	sshHost := strings.TrimSpace(req.SSHHost)
+	if sshHost == "" || strings.ContainsAny(sshHost, " \t\r\n") || strings.HasPrefix(sshHost, "-") {
+		return nil, fmt.Errorf("invalid_ssh_host")
+	}
+
+	sshPort := req.SSHPort
+	if sshPort == 0 {
+		sshPort = 22
+	}
+	if sshPort < 1 || sshPort > 65535 {
+		return nil, fmt.Errorf("invalid_ssh_port")
+	}
+
+	backupRoot := normalizeDatasetPath(req.BackupRoot)
+	if backupRoot == "" || strings.ContainsAny(backupRoot, "@# ") || strings.HasPrefix(backupRoot, "/") {
+		return nil, fmt.Errorf("invalid_backup_root")
+	}
+
+	id := uuid.NewString()
+	privateKey, publicKey, err := generateOnboardingKeyPair("sylve-backup-" + id[:8])
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	onboarding := &BackupTargetOnboarding{
+		ID:         id,
+		SSHHost:    sshHost,
+		SSHPort:    sshPort,
+		BackupRoot: backupRoot,
+		PublicKey:  publicKey,
+		Setup:      backupTargetOnboardingSetup(onboardingSSHUser(sshHost), backupRoot, publicKey),
+		Steps:      []BackupTargetOnboardingStep{},
+		ExpiresAt:  now.Add(backupTargetOnboardingTTL),
+		privateKey: privateKey,
+	}
+
+	s.onboardingMu.Lock()
+	defer s.onboardingMu.Unlock()
+	if s.onboardings == nil {
+		s.onboardings = make(map[string]*BackupTargetOnboarding)
+	}
+	s.pruneBackupTargetOnboardingsLocked(now)
+	s.onboardings[id] = onboarding
+
+	out := *onboarding
+	return &out, nil
+}
+
+func (s *Service) GetBackupTargetOnboarding(id string) (*BackupTargetOnboarding, error) {
+	s.onboardingMu.Lock()
+	defer s.onboardingMu.Unlock()
+
+	s.pruneBackupTargetOnboardingsLocked(time.Now())
+	onboarding, ok := s.onboardings[id]
+	if !ok {
+		return nil, ErrBackupTargetOnboardingNotFound
+	}
+
+	out := *onboarding
+	out.Steps = append([]BackupTargetOnboardingStep(nil), onboarding.Steps...)
+	return &out, nil
+}
+
+func (s *Service) CancelBackupTargetOnboarding(id string) {
+	s.onboardingMu.Lock()
+	defer s.onboardingMu.Unlock()
+	delete(s.onboardings, id)
+}
+
+func (s *Service) onboardingRemote(ctx context.Context, target *clusterModels.BackupTarget, args ...string) (string, error) {
+	sshArgs := append(s.buildSSHArgs(target), target.SSHHost)
+	sshArgs = append(sshArgs, args...)
+	return onboardingRunCommand(ctx, "ssh", sshArgs...)
+}
+
+// runBackupTargetOnboardingSteps checks the target in order and stops at the
+// first failure, so the reported step is the one the operator has to fix.
+func (s *Service) runBackupTargetOnboardingSteps(ctx context.Context, target *clusterModels.BackupTarget, backupRoot, probeName string) []BackupTargetOnboardingStep {
+	pool := parseZFSPoolNameFromDataset(backupRoot)
+
+	checks := []struct {
+		name string
+		run  func() (string, error)
+	}{
+		{OnboardingStepAuth, func() (string, error) {
+			if output, err := s.onboardingRemote(ctx, target, "true"); err != nil {
+				return "", fmt.Errorf("ssh_auth_failed: %s", strings.TrimSpace(output+" "+err.Error()))
+			}
+			return "authenticated", nil
+		}},
+		{OnboardingStepZFS, func() (string, error) {
+			output, err := s.onboardingRemote(ctx, target, "zfs", "version")
+			if err != nil {
+				return "", fmt.Errorf("zfs_not_available: %s", strings.TrimSpace(output))
+			}
+			return strings.TrimSpace(strings.Split(output, "\n")[0]), nil
+		}},
+		{OnboardingStepPool, func() (string, error) {
+			output, err := s.onboardingRemote(ctx, target, "zpool", "list", "-H", "-o", "name", pool)
+			if err != nil || strings.TrimSpace(output) != pool {
+				return "", fmt.Errorf("backup_pool_not_found: %s", pool)
+			}
+			return pool, nil
+		}},
+		{OnboardingStepBackupRoot, func() (string, error) {
+			list := []string{"zfs", "list", "-H", "-o", "name", "-t", "filesystem", "-d", "0", backupRoot}
+			output, err := s.onboardingRemote(ctx, target, list...)
+			exists := err == nil && replicationDatasetListedExactly(output, backupRoot)
+			if err != nil && !replicationDatasetMissingResult(output, err) {
+				return "", fmt.Errorf("backup_root_check_failed: %s", strings.TrimSpace(output))
+			}
+
+			if onboardingSSHUser(target.SSHHost) != "root" {
+				// Delegation only covers the root, so it has to exist already;
+				// a throwaway child proves the delegation actually works.
+				if !exists {
+					return "", fmt.Errorf("backup_root_missing: create %s as root with the setup commands", backupRoot)
+				}
+				probe := backupRoot + "/" + probeName
+				if output, err := s.onboardingRemote(ctx, target, "zfs", "create", probe); err != nil {
+					return "", fmt.Errorf("backup_root_delegation_failed: %s", strings.TrimSpace(output))
+				}
+				if output, err := s.onboardingRemote(ctx, target, "zfs", "destroy", probe); err != nil {
+					return "", fmt.Errorf("backup_root_delegation_failed: %s", strings.TrimSpace(output))
+				}
+				return "delegated", nil
+			}
+
+			if exists {
+				return "exists", nil
+			}
+			if output, err := s.onboardingRemote(ctx, target, "zfs", "create", "-p", backupRoot); err != nil {
+				return "", fmt.Errorf("backup_root_create_failed: %s", strings.TrimSpace(output))
+			}
+			output, err = s.onboardingRemote(ctx, target, list...)
+			if err != nil || !replicationDatasetListedExactly(output, backupRoot) {
+				return "", fmt.Errorf("backup_root_create_verify_failed: %s", backupRoot)
+			}
+			return "created", nil
+		}},
+	}
+
+	steps := make([]BackupTargetOnboardingStep, 0, len(checks))
+	failed := false
+	for _, check := range checks {
+		if failed || ctx.Err() != nil {
+			steps = append(steps, BackupTargetOnboardingStep{Name: check.name, Status: OnboardingStepSkipped})
+			continue
+		}
+
+		detail, err := check.run()
+		if err != nil {
+			failed = true
+			steps = append(steps, BackupTargetOnboardingStep{Name: check.name, Status: OnboardingStepFailed, Detail: err.Error()})
+			continue
+		}
+		steps = append(steps, BackupTargetOnboardingStep{Name: check.name, Status: OnboardingStepPassed, Detail: detail})
+	}
+
+	return steps
+}
+
+func (s *Service) TestBackupTargetOnboarding(ctx context.Context, id string) (*BackupTargetOnboarding, error) {
+	onboarding, err := s.GetBackupTargetOnboarding(id)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPath, err := SaveTemporarySSHKey(onboarding.privateKey)
+	if err != nil {
+		return nil, err
+	}
+	defer RemoveTemporarySSHKey(keyPath)
+
+	target := &clusterModels.BackupTarget{
+		SSHHost:    onboarding.SSHHost,
+		SSHPort:    onboarding.SSHPort,
+		SSHKeyPath: keyPath,
+	}
+	steps := s.runBackupTargetOnboardingSteps(ctx, target, onboarding.BackupRoot, "sylve-onboarding-"+onboarding.ID[:8])
+
+	verified := true
+	for _, step := range steps {
+		if step.Status != OnboardingStepPassed {
+			verified = false
+			break
+		}
+	}
+
+	s.onboardingMu.Lock()
+	defer s.onboardingMu.Unlock()
+	current, ok := s.onboardings[id]
+	if !ok {
+		return nil, ErrBackupTargetOnboardingNotFound
+	}
+	current.Steps = steps
+	current.Verified = verified
+
+	out := *current
+	return &out, nil
+}
+
+// BackupTargetOnboardingRequest turns a verified onboarding into a create
+// request carrying the generated key. The onboarding stays around until the
+// caller cancels it, so a rejected create (a duplicate name, say) can be
+// retried without redoing the remote setup.
+func (s *Service) BackupTargetOnboardingRequest(id string, req clusterServiceInterfaces.BackupTargetOnboardingFinishReq) (clusterServiceInterfaces.BackupTargetReq, error) {
+	s.onboardingMu.Lock()
+	defer s.onboardingMu.Unlock()
+
+	s.pruneBackupTargetOnboardingsLocked(time.Now())
+	onboarding, ok := s.onboardings[id]
+	if !ok {
+		return clusterServiceInterfaces.BackupTargetReq{}, ErrBackupTargetOnboardingNotFound
+	}
+	if !onboarding.Verified {
+		return clusterServiceInterfaces.BackupTargetReq{}, fmt.Errorf("backup_target_onboarding_not_verified")
+	}
+
+	createRoot := true
+	return clusterServiceInterfaces.BackupTargetReq{
+		Name:               strings.TrimSpace(req.Name),
+		SSHHost:            onboarding.SSHHost,
+		SSHPort:            onboarding.SSHPort,
+		SSHKey:             onboarding.privateKey,
+		BackupRoot:         onboarding.BackupRoot,
+		CreateBackupRoot:   &createRoot,
+		BandwidthLimitKBps: req.BandwidthLimitKBps,
+		Description:        strings.TrimSpace(req.Description),
+		Enabled:            req.Enabled,
+	}, nil
+}
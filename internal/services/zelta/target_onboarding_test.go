@@ -0,0 +1,244 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"golang.org/x/crypto/ssh"
+)
+
+func stubOnboardingRemote(t *testing.T, respond func(remote []string) (string, error)) *[]string {
+	t.Helper()
+
+	resetZeltaTestGlobals(t)
+	SSHKeyDirectory = filepath.Join(t.TempDir(), "ssh")
+	if err := os.MkdirAll(SSHKeyDirectory, 0700); err != nil {
+		t.Fatalf("failed to create ssh key dir: %v", err)
+	}
+
+	prev := onboardingRunCommand
+	t.Cleanup(func() { onboardingRunCommand = prev })
+
+	var calls []string
+	onboardingRunCommand = func(_ context.Context, _ string, args ...string) (string, error) {
+		idx := slices.IndexFunc(args, func(arg string) bool { return strings.HasSuffix(arg, "@nas") })
+		remote := args[idx+1:]
+		calls = append(calls, strings.Join(remote, " "))
+		return respond(remote)
+	}
+	return &calls
+}
+
+func TestStartBackupTargetOnboardingGeneratesKeyAndSetup(t *testing.T) {
+	s := &Service{}
+
+	onboarding, err := s.StartBackupTargetOnboarding(clusterServiceInterfaces.BackupTargetOnboardingReq{
+		SSHHost:    "backup@nas",
+		BackupRoot: "tank/sylve/",
+	})
+	if err != nil {
+		t.Fatalf("unexpected start error: %v", err)
+	}
+
+	if _, err := ssh.ParsePrivateKey([]byte(onboarding.privateKey)); err != nil {
+		t.Fatalf("generated private key does not parse: %v", err)
+	}
+	if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(onboarding.Setup.AuthorizedKeysLine)); err != nil {
+		t.Fatalf("authorized_keys line does not parse: %v", err)
+	}
+	if onboarding.SSHPort != 22 || onboarding.BackupRoot != "tank/sylve" {
+		t.Fatalf("unexpected normalized request: %+v", onboarding)
+	}
+	if onboarding.Setup.SSHUser != "backup" || onboarding.Setup.Commands[0] != "zfs create -p tank/sylve" ||
+		!strings.HasPrefix(onboarding.Setup.Commands[1], "zfs allow -u backup create,") ||
+		!strings.HasSuffix(onboarding.Setup.Commands[1], " tank/sylve") {
+		t.Fatalf("unexpected setup: %+v", onboarding.Setup)
+	}
+
+	for _, req := range []clusterServiceInterfaces.BackupTargetOnboardingReq{
+		{SSHHost: "-oProxyCommand=x", BackupRoot: "tank"},
+		{SSHHost: "nas", SSHPort: 70000, BackupRoot: "tank"},
+		{SSHHost: "nas", BackupRoot: "/tank"},
+	} {
+		if _, err := s.StartBackupTargetOnboarding(req); err == nil {
+			t.Fatalf("expected %+v to be rejected", req)
+		}
+	}
+}
+
+func TestBackupTargetOnboardingStepsGateCreation(t *testing.T) {
+	poolMissing := true
+	calls := stubOnboardingRemote(t, func(remote []string) (string, error) {
+		switch remote[0] {
+		case "true":
+			return "", nil
+		case "zfs":
+			if remote[1] == "version" {
+				return "zfs-2.2.6-1\nzfs-kmod-2.2.6-1\n", nil
+			}
+			if remote[1] == "create" {
+				poolMissing = false
+				return "", nil
+			}
+			if poolMissing {
+				return "cannot open 'tank/sylve': dataset does not exist", fmt.Errorf("exit status 1")
+			}
+			return "tank/sylve\n", nil
+		case "zpool":
+			return "tank\n", nil
+		}
+		return "", fmt.Errorf("unexpected command %v", remote)
+	})
+
+	s := &Service{}
+	onboarding, err := s.StartBackupTargetOnboarding(clusterServiceInterfaces.BackupTargetOnboardingReq{
+		SSHHost:    "root@nas",
+		BackupRoot: "tank/sylve",
+	})
+	if err != nil {
+		t.Fatalf("unexpected start error: %v", err)
+	}
+
+	if _, err := s.BackupTargetOnboardingRequest(onboarding.ID, clusterServiceInterfaces.BackupTargetOnboardingFinishReq{Name: "nas"}); err == nil ||
+		err.Error() != "backup_target_onboarding_not_verified" {
+		t.Fatalf("expected unverified onboarding to be refused, got %v", err)
+	}
+
+	tested, err := s.TestBackupTargetOnboarding(context.Background(), onboarding.ID)
+	if err != nil {
+		t.Fatalf("unexpected test error: %v", err)
+	}
+	if !tested.Verified {
+		t.Fatalf("expected verified onboarding, got steps %+v", tested.Steps)
+	}
+	if last := tested.Steps[len(tested.Steps)-1]; last.Name != OnboardingStepBackupRoot || last.Detail != "created" {
+		t.Fatalf("expected backup root to be created, got %+v", last)
+	}
+	if !slices.Contains(*calls, "zfs create -p tank/sylve") {
+		t.Fatalf("expected backup root create, got %v", *calls)
+	}
+
+	req, err := s.BackupTargetOnboardingRequest(onboarding.ID, clusterServiceInterfaces.BackupTargetOnboardingFinishReq{Name: " nas "})
+	if err != nil {
+		t.Fatalf("unexpected finish error: %v", err)
+	}
+	if req.Name != "nas" || req.SSHHost != "root@nas" || req.SSHKey != onboarding.privateKey || req.BackupRoot != "tank/sylve" {
+		t.Fatalf("unexpected create request: %+v", req)
+	}
+
+	entries, _ := os.ReadDir(SSHKeyDirectory)
+	if len(entries) != 0 {
+		t.Fatalf("expected temporary key to be removed, found %d files", len(entries))
+	}
+}
+
+func TestBackupTargetOnboardingNonRootNeedsDelegatedRoot(t *testing.T) {
+	rootExists := false
+	calls := stubOnboardingRemote(t, func(remote []string) (string, error) {
+		switch remote[0] {
+		case "true":
+			return "", nil
+		case "zpool":
+			return "tank\n", nil
+		case "zfs":
+			switch remote[1] {
+			case "version":
+				return "zfs-2.2.6-1\n", nil
+			case "list":
+				if !rootExists {
+					return "cannot open 'tank/sylve': dataset does not exist", fmt.Errorf("exit status 1")
+				}
+				return "tank/sylve\n", nil
+			case "create", "destroy":
+				return "", nil
+			}
+		}
+		return "", fmt.Errorf("unexpected command %v", remote)
+	})
+
+	s := &Service{}
+	onboarding, err := s.StartBackupTargetOnboarding(clusterServiceInterfaces.BackupTargetOnboardingReq{
+		SSHHost:    "backup@nas",
+		BackupRoot: "tank/sylve",
+	})
+	if err != nil {
+		t.Fatalf("unexpected start error: %v", err)
+	}
+
+	tested, err := s.TestBackupTargetOnboarding(context.Background(), onboarding.ID)
+	if err != nil {
+		t.Fatalf("unexpected test error: %v", err)
+	}
+	last := tested.Steps[len(tested.Steps)-1]
+	if tested.Verified || last.Status != OnboardingStepFailed || !strings.Contains(last.Detail, "backup_root_missing") {
+		t.Fatalf("expected missing backup root to fail for a non-root user, got %+v", tested.Steps)
+	}
+	for _, call := range *calls {
+		if strings.HasPrefix(call, "zfs create") {
+			t.Fatalf("non-root onboarding must not create the backup root: %v", *calls)
+		}
+	}
+
+	rootExists = true
+	*calls = (*calls)[:0]
+	tested, err = s.TestBackupTargetOnboarding(context.Background(), onboarding.ID)
+	if err != nil {
+		t.Fatalf("unexpected test error: %v", err)
+	}
+	if last := tested.Steps[len(tested.Steps)-1]; !tested.Verified || last.Detail != "delegated" {
+		t.Fatalf("expected delegation probe to pass, got %+v", tested.Steps)
+	}
+	probe := "tank/sylve/sylve-onboarding-" + onboarding.ID[:8]
+	if !slices.Contains(*calls, "zfs create "+probe) || !slices.Contains(*calls, "zfs destroy "+probe) {
+		t.Fatalf("expected probe dataset create and destroy, got %v", *calls)
+	}
+}
+
+func TestBackupTargetOnboardingStopsAtFirstFailure(t *testing.T) {
+	calls := stubOnboardingRemote(t, func(remote []string) (string, error) {
+		return "Permission denied (publickey).", fmt.Errorf("exit status 255")
+	})
+
+	s := &Service{}
+	onboarding, err := s.StartBackupTargetOnboarding(clusterServiceInterfaces.BackupTargetOnboardingReq{
+		SSHHost:    "backup@nas",
+		BackupRoot: "tank/sylve",
+	})
+	if err != nil {
+		t.Fatalf("unexpected start error: %v", err)
+	}
+
+	tested, err := s.TestBackupTargetOnboarding(context.Background(), onboarding.ID)
+	if err != nil {
+		t.Fatalf("unexpected test error: %v", err)
+	}
+	if tested.Verified {
+		t.Fatalf("expected unverified onboarding")
+	}
+
+	statuses := make([]string, 0, len(tested.Steps))
+	for _, step := range tested.Steps {
+		statuses = append(statuses, step.Status)
+	}
+	want := []string{OnboardingStepFailed, OnboardingStepSkipped, OnboardingStepSkipped, OnboardingStepSkipped}
+	if !slices.Equal(statuses, want) || len(*calls) != 1 {
+		t.Fatalf("unexpected steps %v after calls %v", statuses, *calls)
+	}
+	if !strings.Contains(tested.Steps[0].Detail, "ssh_auth_failed") {
+		t.Fatalf("expected auth failure detail, got %q", tested.Steps[0].Detail)
+	}
+}
@@ -0,0 +1,350 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alchemillahq/gzfs"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+)
+
+const (
+	reattachActionCreate   = "create"
+	reattachActionAttached = "attached"
+	reattachActionRejected = "rejected"
+)
+
+// reattachLineagePattern matches the active lineage a job writes to:
+// <source>/j-<job id in base 36>/active.
+var reattachLineagePattern = regexp.MustCompile(`^(.+)/j-([0-9a-z]+)/active$`)
+
+// BackupTargetReattachItem is one job lineage found on a backup target and
+// what reattaching it did (or would do, in a dry run).
+type BackupTargetReattachItem struct {
+	JobID          uint   `json:"jobId"`
+	RemoteDataset  string `json:"remoteDataset"`
+	Mode           string `json:"mode"`
+	GuestID        uint   `json:"guestId,omitempty"`
+	SourceDataset  string `json:"sourceDataset,omitempty"`
+	SnapshotCount  int    `json:"snapshotCount"`
+	LatestSnapshot string `json:"latestSnapshot"`
+	Action         string `json:"action"`
+	// Moved is set when the guest now lives under a different dataset than
+	// the one it was backed up from. The job still owns its history, but
+	// the next run writes a new lineage next to the old one.
+	Moved bool   `json:"moved,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type BackupTargetReattachResult struct {
+	DryRun bool                       `json:"dryRun"`
+	Items  []BackupTargetReattachItem `json:"items"`
+	Failed int                        `json:"failed"`
+}
+
+type reattachLineage struct {
+	RemoteDataset  string
+	Source         string
+	JobID          uint
+	Mode           string
+	GuestID        uint
+	SnapshotCount  int
+	LatestSnapshot string
+}
+
+// findReattachLineages picks the active job lineages out of a target's
+// dataset and snapshot listings. Snapshots must be listed oldest first.
+func findReattachLineages(backupRoot string, datasets, snapshots []string) []reattachLineage {
+	byDataset := make(map[string][]string)
+	for _, name := range snapshots {
+		name = strings.TrimSpace(name)
+		idx := strings.Index(name, "@")
+		if idx <= 0 {
+			continue
+		}
+		byDataset[name[:idx]] = append(byDataset[name[:idx]], name[idx+1:])
+	}
+
+	lineages := []reattachLineage{}
+	for _, dataset := range datasets {
+		dataset = normalizeDatasetPath(dataset)
+		if !datasetWithinRoot(backupRoot, dataset) {
+			continue
+		}
+
+		match := reattachLineagePattern.FindStringSubmatch(relativeDatasetSuffix(backupRoot, dataset))
+		if match == nil {
+			continue
+		}
+		id, err := strconv.ParseUint(match[2], 36, 64)
+		if err != nil || id == 0 {
+			continue
+		}
+
+		prefix := backupSnapshotPrefixForJob(uint(id))
+		count, latest := 0, ""
+		for _, short := range byDataset[dataset] {
+			if isBKSnapshotShortName(short, prefix) {
+				count++
+				latest = short
+			}
+		}
+		if count == 0 {
+			continue
+		}
+
+		mode, guestID := inferRestoreDatasetKind(match[1])
+		lineages = append(lineages, reattachLineage{
+			RemoteDataset:  dataset,
+			Source:         match[1],
+			JobID:          uint(id),
+			Mode:           mode,
+			GuestID:        guestID,
+			SnapshotCount:  count,
+			LatestSnapshot: latest,
+		})
+	}
+
+	sort.Slice(lineages, func(i, j int) bool { return lineages[i].JobID < lineages[j].JobID })
+	return lineages
+}
+
+// resolveReattachSource finds the local dataset a lineage was backed up
+// from. Guest lineages record the full source path and may have moved pools
+// since; dataset lineages only keep a flattened name, so exactly one local
+// dataset has to flatten to it.
+func resolveReattachSource(lineage reattachLineage, local []string) (string, bool, error) {
+	if lineage.Mode == clusterModels.BackupJobModeDataset {
+		matches := []string{}
+		for _, dataset := range local {
+			if autoDestSuffix(dataset) == lineage.Source {
+				matches = append(matches, dataset)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			return "", false, fmt.Errorf("source_dataset_not_found")
+		case 1:
+			return matches[0], false, nil
+		default:
+			return "", false, fmt.Errorf("source_dataset_ambiguous: %s", strings.Join(matches, ", "))
+		}
+	}
+
+	if slices.Contains(local, lineage.Source) {
+		return lineage.Source, false, nil
+	}
+
+	for _, dataset := range local {
+		parts := strings.Split(dataset, "/")
+		if len(parts) < 2 || strconv.FormatUint(uint64(lineage.GuestID), 10) != parts[len(parts)-1] {
+			continue
+		}
+		if mode, guestID := inferRestoreDatasetKind(dataset); mode == lineage.Mode && guestID == lineage.GuestID {
+			return dataset, true, nil
+		}
+	}
+
+	return "", false, fmt.Errorf("guest_dataset_not_found")
+}
+
+// ReattachBackupTarget recreates the backup jobs of a previous cluster from
+// the lineages it left on a target, so their snapshots and retention carry
+// on. Each job keeps its old ID, which its lineage path and snapshot names
+// are derived from, and runs on this node, which must hold the adopted or
+// restored guests.
+func (s *Service) ReattachBackupTarget(
+	ctx context.Context,
+	targetID uint,
+	req clusterServiceInterfaces.BackupTargetReattachReq,
+) (*BackupTargetReattachResult, error) {
+	if s.Cluster == nil {
+		return nil, fmt.Errorf("cluster_service_unavailable")
+	}
+
+	target, err := s.getRestoreTarget(targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	fsOutput, err := s.runTargetZFSList(ctx, &target, "-t", "filesystem", "-r", "-H", "-o", "name", target.BackupRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed_to_list_target_datasets: %w", err)
+	}
+	snapOutput, err := s.runTargetZFSList(ctx, &target, "-t", "snapshot", "-r", "-H", "-o", "name", "-s", "createtxg", target.BackupRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed_to_list_target_snapshots: %w", err)
+	}
+
+	lineages := findReattachLineages(
+		target.BackupRoot,
+		strings.Split(strings.TrimSpace(fsOutput), "\n"),
+		strings.Split(strings.TrimSpace(snapOutput), "\n"),
+	)
+
+	local, err := s.listReattachLocalDatasets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var existing []clusterModels.BackupJob
+	if err := s.DB.Select("id", "target_id").Find(&existing).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_list_backup_jobs: %w", err)
+	}
+	existingTarget := make(map[uint]uint, len(existing))
+	for _, job := range existing {
+		existingTarget[job.ID] = job.TargetID
+	}
+
+	enabled := false
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	result := &BackupTargetReattachResult{DryRun: req.DryRun, Items: []BackupTargetReattachItem{}}
+	for _, lineage := range lineages {
+		if len(req.JobIDs) > 0 && !slices.Contains(req.JobIDs, lineage.JobID) {
+			continue
+		}
+
+		item := BackupTargetReattachItem{
+			JobID:          lineage.JobID,
+			RemoteDataset:  lineage.RemoteDataset,
+			Mode:           lineage.Mode,
+			GuestID:        lineage.GuestID,
+			SnapshotCount:  lineage.SnapshotCount,
+			LatestSnapshot: lineage.LatestSnapshot,
+			Action:         reattachActionCreate,
+		}
+
+		if jobTarget, ok := existingTarget[lineage.JobID]; ok {
+			if jobTarget == target.ID {
+				item.Action = reattachActionAttached
+			} else {
+				item.Error = fmt.Sprintf("backup_job_id_in_use: %d", lineage.JobID)
+			}
+			s.recordReattachItem(result, item)
+			continue
+		}
+
+		source, moved, err := resolveReattachSource(lineage, local)
+		if err != nil {
+			item.Error = err.Error()
+			s.recordReattachItem(result, item)
+			continue
+		}
+		item.SourceDataset = source
+		item.Moved = moved
+
+		name, err := s.reattachJobName(lineage, source)
+		if err != nil {
+			item.Error = err.Error()
+			s.recordReattachItem(result, item)
+			continue
+		}
+
+		input := clusterServiceInterfaces.BackupJobReq{
+			Name:          name,
+			TargetID:      target.ID,
+			RunnerNodeID:  s.localNodeID(),
+			Mode:          lineage.Mode,
+			SourceDataset: source,
+			PruneKeepLast: req.PruneKeepLast,
+			CronExpr:      req.CronExpr,
+			Enabled:       &enabled,
+		}
+		if lineage.Mode == clusterModels.BackupJobModeJail {
+			input.SourceDataset = ""
+			input.JailRootDataset = source
+		}
+
+		if !req.DryRun {
+			if err := s.Cluster.ReattachBackupJobClusterWide(clusterServiceInterfaces.BackupJobReattachReq{
+				ID:  lineage.JobID,
+				Job: input,
+			}); err != nil {
+				item.Error = err.Error()
+			}
+		}
+		s.recordReattachItem(result, item)
+	}
+
+	return result, nil
+}
+
+func (s *Service) recordReattachItem(result *BackupTargetReattachResult, item BackupTargetReattachItem) {
+	if item.Error != "" {
+		item.Action = reattachActionRejected
+		result.Failed++
+	}
+	result.Items = append(result.Items, item)
+}
+
+// listReattachLocalDatasets lists this node's filesystems, leaving out any
+// that sit under a backup root so a node that is also a target does not
+// mistake received copies for sources.
+func (s *Service) listReattachLocalDatasets(ctx context.Context) ([]string, error) {
+	if s.GZFS == nil || s.GZFS.ZFS == nil {
+		return nil, fmt.Errorf("gzfs_not_initialized")
+	}
+
+	datasets, err := s.GZFS.ZFS.List(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed_to_list_local_datasets: %w", err)
+	}
+
+	roots := s.listEnabledBackupRoots()
+	local := make([]string, 0, len(datasets))
+	for _, ds := range datasets {
+		if ds == nil || ds.Type != gzfs.DatasetTypeFilesystem {
+			continue
+		}
+		name := normalizeDatasetPath(ds.Name)
+		if name == "" || datasetWithinAnyRoot(name, roots) {
+			continue
+		}
+		local = append(local, name)
+	}
+
+	sort.Strings(local)
+	return local, nil
+}
+
+// reattachJobName names a recreated job after its guest, which also checks
+// that the guest has been adopted or restored on this cluster.
+func (s *Service) reattachJobName(lineage reattachLineage, source string) (string, error) {
+	token := compactIDToken(lineage.JobID)
+
+	switch lineage.Mode {
+	case clusterModels.BackupJobModeJail:
+		var jail jailModels.Jail
+		if err := s.DB.Select("name").Where("ct_id = ?", lineage.GuestID).First(&jail).Error; err != nil {
+			return "", fmt.Errorf("jail_not_found: %d", lineage.GuestID)
+		}
+		return fmt.Sprintf("%s (j-%s)", jail.Name, token), nil
+	case clusterModels.BackupJobModeVM:
+		var vm vmModels.VM
+		if err := s.DB.Select("name").Where("rid = ?", lineage.GuestID).First(&vm).Error; err != nil {
+			return "", fmt.Errorf("vm_not_found: %d", lineage.GuestID)
+		}
+		return fmt.Sprintf("%s (j-%s)", vm.Name, token), nil
+	default:
+		return fmt.Sprintf("%s (j-%s)", source, token), nil
+	}
+}
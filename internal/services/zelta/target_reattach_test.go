@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+
+package zelta
+
+import (
+	"testing"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+)
+
+func TestFindReattachLineages(t *testing.T) {
+	t.Parallel()
+
+	datasets := []string{
+		"backup",
+		"backup/zroot/sylve/jails/105",
+		"backup/zroot/sylve/jails/105/j-16",
+		"backup/zroot/sylve/jails/105/j-16/active",
+		"backup/zroot/sylve/jails/105/j-16/active/data",
+		"backup/zroot-data-media/j-1a/active",
+		"backup/zroot-data-empty/j-1b/active",
+		"backup/zroot/sylve/jails/105/j-16/archive_20250101",
+		"backup/other/j-!!/active",
+	}
+	snapshots := []string{
+		"backup/zroot/sylve/jails/105/j-16/active@bk_j16_aaa",
+		"backup/zroot/sylve/jails/105/j-16/active@manual",
+		"backup/zroot/sylve/jails/105/j-16/active@bk_j16_bbb",
+		"backup/zroot/sylve/jails/105/j-16/active/data@bk_j16_bbb",
+		"backup/zroot-data-media/j-1a/active@bk_j1a_ccc",
+		"backup/zroot-data-empty/j-1b/active@bk_j1c_ddd",
+	}
+
+	lineages := findReattachLineages("backup/", datasets, snapshots)
+	if len(lineages) != 2 {
+		t.Fatalf("expected 2 lineages, got %d: %+v", len(lineages), lineages)
+	}
+
+	jail := lineages[0]
+	if jail.JobID != 42 || jail.Mode != clusterModels.BackupJobModeJail || jail.GuestID != 105 {
+		t.Fatalf("unexpected jail lineage: %+v", jail)
+	}
+	if jail.Source != "zroot/sylve/jails/105" || jail.SnapshotCount != 2 || jail.LatestSnapshot != "bk_j16_bbb" {
+		t.Fatalf("unexpected jail lineage details: %+v", jail)
+	}
+
+	dataset := lineages[1]
+	if dataset.JobID != 46 || dataset.Mode != clusterModels.BackupJobModeDataset || dataset.Source != "zroot-data-media" {
+		t.Fatalf("unexpected dataset lineage: %+v", dataset)
+	}
+}
+
+func TestResolveReattachSource(t *testing.T) {
+	t.Parallel()
+
+	local := []string{
+		"tank/sylve/jails/105",
+		"zroot/data/media",
+		"zroot/data-media",
+		"zroot/sylve/virtual-machines/100",
+	}
+
+	tests := []struct {
+		name    string
+		lineage reattachLineage
+		want    string
+		moved   bool
+		wantErr bool
+	}{
+		{
+			name:    "guest on same dataset",
+			lineage: reattachLineage{Mode: clusterModels.BackupJobModeVM, GuestID: 100, Source: "zroot/sylve/virtual-machines/100"},
+			want:    "zroot/sylve/virtual-machines/100",
+		},
+		{
+			name:    "guest moved pools",
+			lineage: reattachLineage{Mode: clusterModels.BackupJobModeJail, GuestID: 105, Source: "zroot/sylve/jails/105"},
+			want:    "tank/sylve/jails/105",
+			moved:   true,
+		},
+		{
+			name:    "guest missing",
+			lineage: reattachLineage{Mode: clusterModels.BackupJobModeJail, GuestID: 106, Source: "zroot/sylve/jails/106"},
+			wantErr: true,
+		},
+		{
+			name:    "dataset flattened name is ambiguous",
+			lineage: reattachLineage{Mode: clusterModels.BackupJobModeDataset, Source: "zroot-data-media"},
+			wantErr: true,
+		},
+		{
+			name:    "dataset missing",
+			lineage: reattachLineage{Mode: clusterModels.BackupJobModeDataset, Source: "zroot-photos"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, moved, err := resolveReattachSource(tt.lineage, local)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want || moved != tt.moved {
+				t.Fatalf("got (%q, %v), want (%q, %v)", got, moved, tt.want, tt.moved)
+			}
+		})
+	}
+
+	unique := []string{"zroot/data/photos"}
+	got, _, err := resolveReattachSource(reattachLineage{Mode: clusterModels.BackupJobModeDataset, Source: "zroot-data-photos"}, unique)
+	if err != nil || got != "zroot/data/photos" {
+		t.Fatalf("expected unique dataset match, got %q (%v)", got, err)
+	}
+}
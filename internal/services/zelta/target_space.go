@@ -0,0 +1,348 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/utils"
+)
+
+const (
+	lineageKeepReasonActive       = "active_lineage"
+	lineageKeepReasonOnlyCopy     = "no_active_lineage"
+	lineageKeepReasonJobRunning   = "backup_job_running"
+	lineageKeepReasonJobTarget    = "backup_job_destination"
+	lineageKeepReasonNotListed    = "lineage_not_found"
+	lineageKeepReasonOutsideRoot  = "remote_dataset_outside_backup_root"
+	lineageKeepReasonDestroyError = "destroy_failed"
+)
+
+// BackupTargetLineageSpace is one top-level lineage on the target: the
+// dataset a job (or a rotation/restore) owns together with its children.
+type BackupTargetLineageSpace struct {
+	Dataset       string  `json:"dataset"`
+	Suffix        string  `json:"suffix"`
+	BaseSuffix    string  `json:"baseSuffix"`
+	Lineage       string  `json:"lineage"`
+	OutOfBand     bool    `json:"outOfBand"`
+	Kind          string  `json:"kind"`
+	JailCTID      uint    `json:"jailCtId,omitempty"`
+	VMRID         uint    `json:"vmRid,omitempty"`
+	Used          uint64  `json:"used"`
+	Referenced    uint64  `json:"referenced"`
+	CompressRatio float64 `json:"compressRatio"`
+	Datasets      int     `json:"datasets"`
+	SnapshotCount int     `json:"snapshotCount"`
+	SafeToDelete  bool    `json:"safeToDelete"`
+	KeepReason    string  `json:"keepReason,omitempty"`
+}
+
+// BackupTargetGuestSpace totals every lineage that shares a base suffix, so
+// the guests (or datasets) dominating the target sort to the top.
+type BackupTargetGuestSpace struct {
+	BaseSuffix    string `json:"baseSuffix"`
+	Kind          string `json:"kind"`
+	JailCTID      uint   `json:"jailCtId,omitempty"`
+	VMRID         uint   `json:"vmRid,omitempty"`
+	Used          uint64 `json:"used"`
+	ActiveUsed    uint64 `json:"activeUsed"`
+	OutOfBandUsed uint64 `json:"outOfBandUsed"`
+	Lineages      int    `json:"lineages"`
+}
+
+type BackupTargetSpaceReport struct {
+	TargetID    uint                       `json:"targetId"`
+	BackupRoot  string                     `json:"backupRoot"`
+	Used        uint64                     `json:"used"`
+	Available   uint64                     `json:"available"`
+	Reclaimable uint64                     `json:"reclaimable"`
+	JobsRunning bool                       `json:"jobsRunning"`
+	Guests      []BackupTargetGuestSpace   `json:"guests"`
+	Lineages    []BackupTargetLineageSpace `json:"lineages"`
+}
+
+type BackupTargetLineageSkip struct {
+	Dataset string `json:"dataset"`
+	Reason  string `json:"reason"`
+}
+
+type BackupTargetCleanupResult struct {
+	Destroyed []string                  `json:"destroyed"`
+	Skipped   []BackupTargetLineageSkip `json:"skipped"`
+	Reclaimed uint64                    `json:"reclaimed"`
+}
+
+// TargetSpaceReport accounts backup target space per lineage and per guest
+// and marks which out-of-band lineages can be removed without losing the
+// only copy of a guest.
+func (s *Service) TargetSpaceReport(ctx context.Context, targetID uint) (*BackupTargetSpaceReport, error) {
+	target, err := s.getRestoreTarget(targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	datasets, err := s.ListRemoteTargetDatasets(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	rootOutput, err := s.runTargetZFSList(ctx, &target, "-Hp", "-o", "used,available", target.BackupRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed_to_read_target_space: %w", err)
+	}
+
+	jobsRunning := false
+	if s.Cluster != nil {
+		running, err := s.Cluster.RunningJobIDsForTarget(targetID)
+		if err != nil {
+			return nil, fmt.Errorf("failed_to_check_running_jobs: %w", err)
+		}
+		jobsRunning = len(running) > 0
+	}
+
+	jobDestinations, err := s.targetJobDestinations(targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := buildTargetSpaceReport(target.BackupRoot, datasets, jobsRunning, jobDestinations)
+	report.TargetID = targetID
+	fields := strings.Fields(rootOutput)
+	if len(fields) >= 2 {
+		report.Used, _ = strconv.ParseUint(fields[0], 10, 64)
+		report.Available, _ = strconv.ParseUint(fields[1], 10, 64)
+	}
+
+	return report, nil
+}
+
+// targetJobDestinations lists the configured destination datasets of the
+// target's backup jobs. Guest jobs resolve their suffix at run time, so only
+// the configured suffix is known here.
+func (s *Service) targetJobDestinations(targetID uint) (map[string]struct{}, error) {
+	var jobs []clusterModels.BackupJob
+	if err := s.DB.Preload("Target").Where("target_id = ?", targetID).Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_list_backup_jobs: %w", err)
+	}
+
+	destinations := make(map[string]struct{}, len(jobs))
+	for _, job := range jobs {
+		suffix := normalizeDatasetPath(strings.Trim(job.DestSuffix, "/"))
+		if suffix == "" {
+			continue
+		}
+		destinations[normalizeDatasetPath(job.Target.BackupRoot+"/"+suffix)] = struct{}{}
+	}
+	return destinations, nil
+}
+
+func buildTargetSpaceReport(
+	backupRoot string,
+	datasets []BackupTargetDatasetInfo,
+	jobsRunning bool,
+	jobDestinations map[string]struct{},
+) *BackupTargetSpaceReport {
+	report := &BackupTargetSpaceReport{
+		BackupRoot:  backupRoot,
+		JobsRunning: jobsRunning,
+		Guests:      []BackupTargetGuestSpace{},
+		Lineages:    []BackupTargetLineageSpace{},
+	}
+
+	names := make([]string, 0, len(datasets))
+	byName := make(map[string]BackupTargetDatasetInfo, len(datasets))
+	for _, ds := range datasets {
+		names = append(names, ds.Name)
+		byName[ds.Name] = ds
+	}
+	sort.Strings(names)
+
+	// A lineage root is a listed dataset with no listed ancestor. Children
+	// are already included in the root's used space.
+	lineageIndex := map[string]int{}
+	for _, name := range names {
+		owner := ""
+		for parent := name; ; {
+			idx := strings.LastIndex(parent, "/")
+			if idx <= 0 {
+				break
+			}
+			parent = parent[:idx]
+			if _, ok := lineageIndex[parent]; ok {
+				owner = parent
+			}
+		}
+
+		ds := byName[name]
+		if owner != "" {
+			lineage := &report.Lineages[lineageIndex[owner]]
+			lineage.Datasets++
+			lineage.SnapshotCount += ds.SnapshotCount
+			continue
+		}
+
+		lineageIndex[name] = len(report.Lineages)
+		report.Lineages = append(report.Lineages, BackupTargetLineageSpace{
+			Dataset:       ds.Name,
+			Suffix:        ds.Suffix,
+			BaseSuffix:    ds.BaseSuffix,
+			Lineage:       ds.Lineage,
+			OutOfBand:     ds.OutOfBand,
+			Kind:          ds.Kind,
+			JailCTID:      ds.JailCTID,
+			VMRID:         ds.VMRID,
+			Used:          ds.Used,
+			Referenced:    ds.Referenced,
+			CompressRatio: ds.CompressRatio,
+			Datasets:      1,
+			SnapshotCount: ds.SnapshotCount,
+		})
+	}
+
+	activeBases := map[string]bool{}
+	for _, lineage := range report.Lineages {
+		if !lineage.OutOfBand && lineage.SnapshotCount > 0 {
+			activeBases[lineage.BaseSuffix] = true
+		}
+	}
+
+	guests := map[string]*BackupTargetGuestSpace{}
+	for i := range report.Lineages {
+		lineage := &report.Lineages[i]
+
+		switch {
+		case !lineage.OutOfBand:
+			lineage.KeepReason = lineageKeepReasonActive
+		case isJobDestination(lineage.Dataset, jobDestinations):
+			lineage.KeepReason = lineageKeepReasonJobTarget
+		case !activeBases[lineage.BaseSuffix]:
+			lineage.KeepReason = lineageKeepReasonOnlyCopy
+		case jobsRunning:
+			lineage.KeepReason = lineageKeepReasonJobRunning
+		default:
+			lineage.SafeToDelete = true
+			report.Reclaimable += lineage.Used
+		}
+
+		guest, ok := guests[lineage.BaseSuffix]
+		if !ok {
+			guest = &BackupTargetGuestSpace{
+				BaseSuffix: lineage.BaseSuffix,
+				Kind:       lineage.Kind,
+				JailCTID:   lineage.JailCTID,
+				VMRID:      lineage.VMRID,
+			}
+			guests[lineage.BaseSuffix] = guest
+		}
+		guest.Used += lineage.Used
+		guest.Lineages++
+		if lineage.OutOfBand {
+			guest.OutOfBandUsed += lineage.Used
+		} else {
+			guest.ActiveUsed += lineage.Used
+		}
+	}
+
+	for _, guest := range guests {
+		report.Guests = append(report.Guests, *guest)
+	}
+	sort.Slice(report.Guests, func(i, j int) bool {
+		if report.Guests[i].Used != report.Guests[j].Used {
+			return report.Guests[i].Used > report.Guests[j].Used
+		}
+		return report.Guests[i].BaseSuffix < report.Guests[j].BaseSuffix
+	})
+	sort.SliceStable(report.Lineages, func(i, j int) bool {
+		return report.Lineages[i].Used > report.Lineages[j].Used
+	})
+
+	return report
+}
+
+func isJobDestination(dataset string, jobDestinations map[string]struct{}) bool {
+	for destination := range jobDestinations {
+		if dataset == destination || strings.HasPrefix(destination, dataset+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// CleanupTargetLineages destroys the requested out-of-band lineages. Each
+// dataset is re-checked against a fresh space report; anything not marked
+// safe to delete is skipped with its reason rather than failing the batch.
+func (s *Service) CleanupTargetLineages(ctx context.Context, targetID uint, datasets []string) (*BackupTargetCleanupResult, error) {
+	if len(datasets) == 0 {
+		return nil, fmt.Errorf("datasets_required")
+	}
+
+	target, err := s.getRestoreTarget(targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := s.TargetSpaceReport(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	lineages := make(map[string]BackupTargetLineageSpace, len(report.Lineages))
+	for _, lineage := range report.Lineages {
+		lineages[lineage.Dataset] = lineage
+	}
+
+	result := &BackupTargetCleanupResult{
+		Destroyed: []string{},
+		Skipped:   []BackupTargetLineageSkip{},
+	}
+
+	for _, dataset := range utils.RemoveDuplicates(datasets) {
+		dataset = normalizeDatasetPath(dataset)
+		if !datasetWithinRoot(target.BackupRoot, dataset) || dataset == normalizeDatasetPath(target.BackupRoot) {
+			result.Skipped = append(result.Skipped, BackupTargetLineageSkip{Dataset: dataset, Reason: lineageKeepReasonOutsideRoot})
+			continue
+		}
+
+		lineage, ok := lineages[dataset]
+		if !ok {
+			result.Skipped = append(result.Skipped, BackupTargetLineageSkip{Dataset: dataset, Reason: lineageKeepReasonNotListed})
+			continue
+		}
+		if !lineage.SafeToDelete {
+			result.Skipped = append(result.Skipped, BackupTargetLineageSkip{Dataset: dataset, Reason: lineage.KeepReason})
+			continue
+		}
+
+		sshArgs := s.buildSSHArgs(&target)
+		sshArgs = append(sshArgs, target.SSHHost, "zfs", "destroy", "-r", dataset)
+		output, err := utils.RunCommandWithContext(ctx, "ssh", sshArgs...)
+		if err != nil {
+			logger.L.Warn().
+				Err(err).
+				Str("ssh_host", target.SSHHost).
+				Str("dataset", dataset).
+				Str("output", strings.TrimSpace(output)).
+				Msg("target_lineage_destroy_failed")
+			result.Skipped = append(result.Skipped, BackupTargetLineageSkip{Dataset: dataset, Reason: lineageKeepReasonDestroyError})
+			continue
+		}
+
+		result.Destroyed = append(result.Destroyed, dataset)
+		result.Reclaimed += lineage.Used
+	}
+
+	return result, nil
+}
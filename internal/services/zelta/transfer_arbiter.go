@@ -0,0 +1,273 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/alchemillahq/sylve/internal/config"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/ratelimit"
+)
+
+// Transfers to the same SSH endpoint share its bandwidth limit. Each running
+// transfer gets a share weighted by its priority class, so a restore started
+// after a disaster takes most of the link while scheduled backups keep
+// trickling instead of stalling into SSH timeouts.
+type transferClass string
+
+const (
+	transferClassRestore     transferClass = "restore"
+	transferClassReplication transferClass = "replication"
+	transferClassBackup      transferClass = "backup"
+)
+
+var transferClassWeights = map[transferClass]uint64{
+	transferClassRestore:     16,
+	transferClassReplication: 4,
+	transferClassBackup:      1,
+}
+
+// transferLimitCommand is the hidden sylve subcommand that paces an ssh
+// child for transfers run by zelta, whose pipes Sylve does not own.
+const transferLimitCommand = "transfer-limit"
+
+// TransferRateFileEnv carries the lease's rate file to transferLimitCommand.
+// zelta word-splits its remote commands, so the path cannot travel as
+// an argument.
+const TransferRateFileEnv = "SYLVE_TRANSFER_RATE_FILE"
+
+var transferExecutable = os.Executable
+
+type transferArbiter struct {
+	mu     sync.Mutex
+	nextID uint64
+	links  map[string]map[uint64]*transferLease
+}
+
+type transferLease struct {
+	arbiter  *transferArbiter
+	id       uint64
+	link     string
+	class    transferClass
+	limit    uint64
+	rate     atomic.Uint64
+	rateFile string
+}
+
+func newTransferArbiter() *transferArbiter {
+	return &transferArbiter{links: make(map[string]map[uint64]*transferLease)}
+}
+
+func transferLinkKey(target *clusterModels.BackupTarget) string {
+	host := target.SSHHost
+	if at := strings.LastIndex(host, "@"); at >= 0 {
+		host = host[at+1:]
+	}
+	port := target.SSHPort
+	if port == 0 {
+		port = 22
+	}
+	return fmt.Sprintf("%s:%d", strings.ToLower(strings.TrimSpace(host)), port)
+}
+
+// transferRates splits limit between transfers by class weight. A zero
+// limit leaves every transfer unlimited.
+func transferRates(limit uint64, classes []transferClass) []uint64 {
+	rates := make([]uint64, len(classes))
+	if limit == 0 {
+		return rates
+	}
+
+	var total uint64
+	for _, class := range classes {
+		total += transferClassWeights[class]
+	}
+	for i, class := range classes {
+		rate := limit * transferClassWeights[class] / total
+		if rate == 0 {
+			rate = 1
+		}
+		rates[i] = rate
+	}
+	return rates
+}
+
+// acquire registers a transfer to target. The returned lease is nil-safe so
+// services built without an arbiter behave as before.
+func (a *transferArbiter) acquire(target *clusterModels.BackupTarget, class transferClass) *transferLease {
+	if a == nil || target == nil {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.nextID++
+	lease := &transferLease{
+		arbiter: a,
+		id:      a.nextID,
+		link:    transferLinkKey(target),
+		class:   class,
+		limit:   target.BandwidthLimitKBps * 1024,
+	}
+	if lease.limit > 0 {
+		if dir, err := transferRateDir(); err == nil {
+			lease.rateFile = filepath.Join(dir, fmt.Sprintf("%d.rate", lease.id))
+		} else {
+			logger.L.Warn().Err(err).Msg("transfer_rate_dir_unavailable")
+		}
+	}
+
+	if a.links[lease.link] == nil {
+		a.links[lease.link] = make(map[uint64]*transferLease)
+	}
+	a.links[lease.link][lease.id] = lease
+	a.rebalanceLocked(lease.link)
+	return lease
+}
+
+func (l *transferLease) release() {
+	if l == nil {
+		return
+	}
+
+	a := l.arbiter
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.links[l.link], l.id)
+	if len(a.links[l.link]) == 0 {
+		delete(a.links, l.link)
+	} else {
+		a.rebalanceLocked(l.link)
+	}
+	if l.rateFile != "" {
+		_ = os.Remove(l.rateFile)
+	}
+}
+
+// rebalanceLocked recomputes the shares on a link. The link limit is the
+// tightest one configured by any target currently using it. Transfers to an
+// unlimited target are never paced, so they take no share of that limit.
+func (a *transferArbiter) rebalanceLocked(link string) {
+	leases := a.links[link]
+	var limit uint64
+	ordered := make([]*transferLease, 0, len(leases))
+	for _, lease := range leases {
+		if lease.limit == 0 {
+			lease.rate.Store(0)
+			continue
+		}
+		if limit == 0 || lease.limit < limit {
+			limit = lease.limit
+		}
+		ordered = append(ordered, lease)
+	}
+
+	classes := make([]transferClass, len(ordered))
+	for i, lease := range ordered {
+		classes[i] = lease.class
+	}
+	for i, rate := range transferRates(limit, classes) {
+		lease := ordered[i]
+		lease.rate.Store(rate)
+		if lease.rateFile != "" {
+			if err := os.WriteFile(lease.rateFile, []byte(strconv.FormatUint(rate, 10)), 0600); err != nil {
+				logger.L.Warn().Err(err).Str("file", lease.rateFile).Msg("transfer_rate_write_failed")
+			}
+		}
+	}
+}
+
+// reader paces r at the lease's current share.
+func (l *transferLease) reader(r io.Reader) io.Reader {
+	if l == nil || l.limit == 0 {
+		return r
+	}
+	return ratelimit.NewReader(r, l.rate.Load)
+}
+
+// zeltaEnv routes zelta's ssh transfer commands through the limiter when the
+// link is limited.
+func (l *transferLease) zeltaEnv(env []string) []string {
+	if l == nil || l.rateFile == "" {
+		return env
+	}
+
+	exe, err := transferExecutable()
+	if err != nil {
+		logger.L.Warn().Err(err).Msg("transfer_limit_executable_unavailable")
+		return env
+	}
+
+	prefix := fmt.Sprintf("%s %s -- ", exe, transferLimitCommand)
+	env = setEnvValue(env, TransferRateFileEnv, l.rateFile)
+	for _, key := range []string{"ZELTA_REMOTE_SEND", "ZELTA_REMOTE_RECV"} {
+		for _, entry := range env {
+			if value, ok := strings.CutPrefix(entry, key+"="); ok {
+				env = setEnvValue(env, key, prefix+value)
+				break
+			}
+		}
+	}
+	return env
+}
+
+func transferRateDir() (string, error) {
+	dataPath, err := config.GetDataPath()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(dataPath, "run", "transfers")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+type ActiveTransfer struct {
+	Link  string `json:"link"`
+	Class string `json:"class"`
+	Rate  uint64 `json:"rate"`
+}
+
+// ActiveTransfers reports the running transfers and their current share.
+func (s *Service) ActiveTransfers() []ActiveTransfer {
+	a := s.transfers
+	if a == nil {
+		return []ActiveTransfer{}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	out := []ActiveTransfer{}
+	for link, leases := range a.links {
+		for _, lease := range leases {
+			out = append(out, ActiveTransfer{Link: link, Class: string(lease.class), Rate: lease.rate.Load()})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Link != out[j].Link {
+			return out[i].Link < out[j].Link
+		}
+		return transferClassWeights[transferClass(out[i].Class)] > transferClassWeights[transferClass(out[j].Class)]
+	})
+	return out
+}
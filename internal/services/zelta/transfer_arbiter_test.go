@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+
+package zelta
+
+import (
+	"slices"
+	"testing"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+)
+
+func TestTransferRatesWeightByClass(t *testing.T) {
+	rates := transferRates(2100, []transferClass{transferClassRestore, transferClassReplication, transferClassBackup})
+	if rates[0] != 1600 || rates[1] != 400 || rates[2] != 100 {
+		t.Fatalf("unexpected rates: %v", rates)
+	}
+
+	unlimited := transferRates(0, []transferClass{transferClassRestore, transferClassBackup})
+	if unlimited[0] != 0 || unlimited[1] != 0 {
+		t.Fatalf("expected zero limit to leave transfers unlimited, got %v", unlimited)
+	}
+}
+
+func TestTransferArbiterRebalancesSharedLink(t *testing.T) {
+	arbiter := newTransferArbiter()
+	limited := &clusterModels.BackupTarget{SSHHost: "root@Backup.example", SSHPort: 22, BandwidthLimitKBps: 17}
+	unlimited := &clusterModels.BackupTarget{SSHHost: "backup.example"}
+
+	backup := arbiter.acquire(unlimited, transferClassBackup)
+	if got := backup.rate.Load(); got != 0 {
+		t.Fatalf("expected an unlimited link, got %d", got)
+	}
+
+	restore := arbiter.acquire(limited, transferClassRestore)
+	if backup.link != restore.link {
+		t.Fatalf("expected both targets on one link, got %q and %q", backup.link, restore.link)
+	}
+	if got := restore.rate.Load(); got != 17*1024 {
+		t.Fatalf("expected the unpaced backup to take no share, got restore rate %d", got)
+	}
+	if got := backup.rate.Load(); got != 0 {
+		t.Fatalf("expected the unlimited backup to stay unpaced, got %d", got)
+	}
+
+	replication := arbiter.acquire(limited, transferClassReplication)
+	if got := restore.rate.Load(); got != 17*1024*16/20 {
+		t.Fatalf("unexpected restore rate %d", got)
+	}
+	if got := replication.rate.Load(); got != 17*1024*4/20 {
+		t.Fatalf("unexpected replication rate %d", got)
+	}
+	replication.release()
+
+	restore.release()
+	if got := backup.rate.Load(); got != 0 {
+		t.Fatalf("expected backup to be unlimited once the limited restore left, got %d", got)
+	}
+	backup.release()
+	if len(arbiter.links) != 0 {
+		t.Fatalf("expected no links after release, got %v", arbiter.links)
+	}
+
+	var nilLease *transferLease
+	nilLease.release()
+	if (*transferArbiter)(nil).acquire(limited, transferClassBackup) != nil {
+		t.Fatal("expected a nil arbiter to hand out nil leases")
+	}
+}
+
+func TestTransferLeaseZeltaEnvWrapsRemoteTransfers(t *testing.T) {
+	prev := transferExecutable
+	transferExecutable = func() (string, error) { return "/usr/local/bin/sylve", nil }
+	t.Cleanup(func() { transferExecutable = prev })
+
+	env := []string{
+		"ZELTA_REMOTE_COMMAND=ssh -p 22",
+		"ZELTA_REMOTE_SEND=ssh -p 22",
+		"ZELTA_REMOTE_RECV=ssh -p 22",
+	}
+	lease := &transferLease{limit: 1024, rateFile: "/var/db/sylve data/run/transfers/1.rate"}
+
+	got := lease.zeltaEnv(append([]string(nil), env...))
+	want := "/usr/local/bin/sylve transfer-limit -- ssh -p 22"
+	if got[0] != env[0] {
+		t.Fatalf("expected remote command to stay unwrapped, got %q", got[0])
+	}
+	if !slices.Contains(got, "ZELTA_REMOTE_SEND="+want) || !slices.Contains(got, "ZELTA_REMOTE_RECV="+want) {
+		t.Fatalf("unexpected env: %v", got)
+	}
+	if !slices.Contains(got, TransferRateFileEnv+"="+lease.rateFile) {
+		t.Fatalf("expected the rate file in the environment, got %v", got)
+	}
+
+	if out := (&transferLease{}).zeltaEnv(env); out[1] != env[1] {
+		t.Fatalf("expected unlimited lease to keep env, got %v", out)
+	}
+}
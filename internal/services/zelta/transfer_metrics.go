@@ -0,0 +1,397 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/logger"
+)
+
+// Transfer metrics are kept per backup job and per replication policy target
+// and rendered in the Prometheus text format. Counters and histograms live in
+// memory and start over with the process, which Prometheus handles; the
+// last-success timestamp is read back from the event tables so an RPO alert
+// does not fire just because the node restarted.
+
+const (
+	transferKindBackup      = "backup"
+	transferKindReplication = "replication"
+)
+
+var (
+	transferDurationBuckets = []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800, 3600, 7200, 14400, 43200}
+	transferBytesBuckets    = []float64{1 << 20, 16 << 20, 128 << 20, 1 << 30, 8 << 30, 64 << 30, 512 << 30, 4 << 40}
+)
+
+type transferSeriesKey struct {
+	Kind      string
+	ID        uint
+	Name      string
+	Target    string
+	GuestType string
+	GuestID   uint
+}
+
+func backupTransferKey(job *clusterModels.BackupJob) transferSeriesKey {
+	guestType, guestID := backupJobGuestIdentity(job)
+	return transferSeriesKey{
+		Kind:      transferKindBackup,
+		ID:        job.ID,
+		Name:      job.Name,
+		Target:    job.Target.Name,
+		GuestType: guestType,
+		GuestID:   guestID,
+	}
+}
+
+func replicationTransferKey(policy *clusterModels.ReplicationPolicy, targetNodeID string) transferSeriesKey {
+	return transferSeriesKey{
+		Kind:      transferKindReplication,
+		ID:        policy.ID,
+		Name:      policy.Name,
+		Target:    strings.TrimSpace(targetNodeID),
+		GuestType: policy.GuestType,
+		GuestID:   policy.GuestID,
+	}
+}
+
+var transferLabelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func (k transferSeriesKey) labels(extra ...string) string {
+	guestID := ""
+	if k.GuestID != 0 {
+		guestID = strconv.FormatUint(uint64(k.GuestID), 10)
+	}
+	pairs := append([]string{
+		"kind", k.Kind,
+		"id", strconv.FormatUint(uint64(k.ID), 10),
+		"name", k.Name,
+		"target", k.Target,
+		"guest_type", k.GuestType,
+		"guest_id", guestID,
+	}, extra...)
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(pairs[i])
+		b.WriteString(`="`)
+		b.WriteString(transferLabelEscaper.Replace(pairs[i+1]))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+type transferHistogram struct {
+	counts []uint64 // per bucket, made cumulative when rendered
+	sum    float64
+	count  uint64
+}
+
+func (h *transferHistogram) observe(buckets []float64, value float64) {
+	if h.counts == nil {
+		h.counts = make([]uint64, len(buckets))
+	}
+	for i, bound := range buckets {
+		if value <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += value
+	h.count++
+}
+
+type transferSeries struct {
+	duration   transferHistogram
+	bytes      transferHistogram
+	succeeded  uint64
+	failed     uint64
+	created    uint64
+	pruned     uint64
+	retries    uint64
+	lastFailed bool
+}
+
+// transferObservation is one finished backup run or replication target
+// attempt. Bytes is nil when zelta did not report what it sent.
+type transferObservation struct {
+	Duration time.Duration
+	Bytes    *uint64
+	Created  int
+	Pruned   int
+	Retries  int
+	Err      error
+}
+
+type transferMetrics struct {
+	mu     sync.Mutex
+	series map[transferSeriesKey]*transferSeries
+}
+
+func (m *transferMetrics) observe(key transferSeriesKey, obs transferObservation) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.series == nil {
+		m.series = make(map[transferSeriesKey]*transferSeries)
+	}
+	series := m.series[key]
+	if series == nil {
+		series = &transferSeries{}
+		m.series[key] = series
+	}
+
+	series.duration.observe(transferDurationBuckets, obs.Duration.Seconds())
+	if obs.Bytes != nil {
+		series.bytes.observe(transferBytesBuckets, float64(*obs.Bytes))
+	}
+	series.created += uint64(max(obs.Created, 0))
+	series.pruned += uint64(max(obs.Pruned, 0))
+	series.retries += uint64(max(obs.Retries, 0))
+	// A run after a failed one is the scheduler retrying it.
+	if series.lastFailed {
+		series.retries++
+	}
+	if obs.Err != nil {
+		series.failed++
+	} else {
+		series.succeeded++
+	}
+	series.lastFailed = obs.Err != nil
+}
+
+func (m *transferMetrics) snapshot() map[transferSeriesKey]transferSeries {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make(map[transferSeriesKey]transferSeries, len(m.series))
+	for key, series := range m.series {
+		copied := *series
+		copied.duration.counts = append([]uint64(nil), series.duration.counts...)
+		copied.bytes.counts = append([]uint64(nil), series.bytes.counts...)
+		out[key] = copied
+	}
+	return out
+}
+
+// observeReplicationTransfer records one target attempt of a replication
+// run. Bytes and snapshot counts come from the attempt's transfer records.
+func (s *Service) observeReplicationTransfer(
+	policy *clusterModels.ReplicationPolicy,
+	targetNodeID string,
+	eventID uint,
+	startedAt time.Time,
+	pruned int,
+	attemptErr error,
+) {
+	obs := transferObservation{
+		Duration: s.now().Sub(startedAt),
+		Pruned:   pruned,
+		Err:      attemptErr,
+	}
+
+	if s.DB != nil && eventID != 0 {
+		var transfers []clusterModels.ReplicationEventTransfer
+		if err := s.DB.
+			Where("event_id = ? AND target_node_id = ? AND status = ?", eventID, strings.TrimSpace(targetNodeID), replicationTransferStatusSuccess).
+			Find(&transfers).Error; err != nil {
+			logger.L.Debug().Err(err).Uint("event_id", eventID).Msg("replication_transfer_metrics_lookup_failed")
+		}
+		var moved uint64
+		for _, transfer := range transfers {
+			obs.Created++
+			if transfer.MovedBytes != nil {
+				moved += *transfer.MovedBytes
+				obs.Bytes = &moved
+			}
+		}
+	}
+
+	s.transferStats.observe(replicationTransferKey(policy, targetNodeID), obs)
+}
+
+// lastTransferSuccesses returns when each job and policy target last
+// finished successfully on this node.
+func (s *Service) lastTransferSuccesses() map[transferSeriesKey]time.Time {
+	out := make(map[transferSeriesKey]time.Time)
+	if s.DB == nil {
+		return out
+	}
+
+	var backupEventIDs []uint
+	if err := s.DB.Model(&clusterModels.BackupEvent{}).
+		Where("status = ? AND job_id IS NOT NULL AND completed_at IS NOT NULL", "success").
+		Group("job_id").
+		Pluck("MAX(id)", &backupEventIDs).Error; err != nil {
+		logger.L.Warn().Err(err).Msg("backup_last_success_lookup_failed")
+	} else if len(backupEventIDs) > 0 {
+		var events []clusterModels.BackupEvent
+		s.DB.Where("id IN ?", backupEventIDs).Find(&events)
+		jobIDs := make([]uint, 0, len(events))
+		for _, event := range events {
+			jobIDs = append(jobIDs, *event.JobID)
+		}
+		var jobs []clusterModels.BackupJob
+		s.DB.Preload("Target").Where("id IN ?", jobIDs).Find(&jobs)
+		jobsByID := make(map[uint]*clusterModels.BackupJob, len(jobs))
+		for i := range jobs {
+			jobsByID[jobs[i].ID] = &jobs[i]
+		}
+		for _, event := range events {
+			if job := jobsByID[*event.JobID]; job != nil {
+				out[backupTransferKey(job)] = *event.CompletedAt
+			}
+		}
+	}
+
+	var transferIDs []uint
+	if err := s.DB.Table("replication_event_transfers AS t").
+		Joins("JOIN replication_events AS e ON e.id = t.event_id").
+		Where("t.status = ? AND t.completed_at IS NOT NULL AND e.policy_id IS NOT NULL", replicationTransferStatusSuccess).
+		Group("e.policy_id, t.target_node_id").
+		Pluck("MAX(t.id)", &transferIDs).Error; err != nil {
+		logger.L.Warn().Err(err).Msg("replication_last_success_lookup_failed")
+	} else if len(transferIDs) > 0 {
+		var transfers []clusterModels.ReplicationEventTransfer
+		s.DB.Where("id IN ?", transferIDs).Find(&transfers)
+		eventIDs := make([]uint, 0, len(transfers))
+		for _, transfer := range transfers {
+			eventIDs = append(eventIDs, transfer.EventID)
+		}
+		var events []clusterModels.ReplicationEvent
+		s.DB.Where("id IN ?", eventIDs).Find(&events)
+		policyIDByEvent := make(map[uint]uint, len(events))
+		policyIDs := make([]uint, 0, len(events))
+		for _, event := range events {
+			policyIDByEvent[event.ID] = *event.PolicyID
+			policyIDs = append(policyIDs, *event.PolicyID)
+		}
+		var policies []clusterModels.ReplicationPolicy
+		s.DB.Where("id IN ?", policyIDs).Find(&policies)
+		policiesByID := make(map[uint]*clusterModels.ReplicationPolicy, len(policies))
+		for i := range policies {
+			policiesByID[policies[i].ID] = &policies[i]
+		}
+		for _, transfer := range transfers {
+			if policy := policiesByID[policyIDByEvent[transfer.EventID]]; policy != nil {
+				key := replicationTransferKey(policy, transfer.TargetNodeID)
+				if transfer.CompletedAt.After(out[key]) {
+					out[key] = *transfer.CompletedAt
+				}
+			}
+		}
+	}
+
+	return out
+}
+
+func writeTransferHistogram(b *strings.Builder, name string, buckets []float64, key transferSeriesKey, h transferHistogram) {
+	var cumulative uint64
+	for i, bound := range buckets {
+		if i < len(h.counts) {
+			cumulative += h.counts[i]
+		}
+		fmt.Fprintf(b, "%s_bucket%s %d\n", name, key.labels("le", strconv.FormatFloat(bound, 'g', -1, 64)), cumulative)
+	}
+	fmt.Fprintf(b, "%s_bucket%s %d\n", name, key.labels("le", "+Inf"), h.count)
+	fmt.Fprintf(b, "%s_sum%s %s\n", name, key.labels(), strconv.FormatFloat(h.sum, 'g', -1, 64))
+	fmt.Fprintf(b, "%s_count%s %d\n", name, key.labels(), h.count)
+}
+
+// WriteTransferMetrics renders the backup and replication transfer metrics
+// of this node in the Prometheus text exposition format.
+func (s *Service) WriteTransferMetrics(w io.Writer) error {
+	series := s.transferStats.snapshot()
+	lastSuccess := s.lastTransferSuccesses()
+
+	keys := make([]transferSeriesKey, 0, len(series))
+	for key := range series {
+		keys = append(keys, key)
+	}
+	sortTransferKeys(keys)
+
+	successKeys := make([]transferSeriesKey, 0, len(lastSuccess))
+	for key := range lastSuccess {
+		successKeys = append(successKeys, key)
+	}
+	sortTransferKeys(successKeys)
+
+	var b strings.Builder
+	family := func(name, kind, help string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+	}
+
+	family("sylve_transfer_duration_seconds", "histogram", "Duration of finished backup runs and replication target attempts.")
+	for _, key := range keys {
+		writeTransferHistogram(&b, "sylve_transfer_duration_seconds", transferDurationBuckets, key, series[key].duration)
+	}
+
+	family("sylve_transfer_bytes", "histogram", "Bytes sent per backup run or replication target attempt, when zelta reported it.")
+	for _, key := range keys {
+		if series[key].bytes.count > 0 {
+			writeTransferHistogram(&b, "sylve_transfer_bytes", transferBytesBuckets, key, series[key].bytes)
+		}
+	}
+
+	family("sylve_transfer_runs_total", "counter", "Finished backup runs and replication target attempts by outcome.")
+	for _, key := range keys {
+		fmt.Fprintf(&b, "sylve_transfer_runs_total%s %d\n", key.labels("status", "success"), series[key].succeeded)
+		fmt.Fprintf(&b, "sylve_transfer_runs_total%s %d\n", key.labels("status", "failed"), series[key].failed)
+	}
+
+	counters := []struct {
+		name  string
+		help  string
+		value func(transferSeries) uint64
+	}{
+		{"sylve_transfer_snapshots_created_total", "Snapshots created and sent.", func(t transferSeries) uint64 { return t.created }},
+		{"sylve_transfer_snapshots_pruned_total", "Snapshots destroyed by retention on the source and target.", func(t transferSeries) uint64 { return t.pruned }},
+		{"sylve_transfer_retries_total", "Transfers run again after a failure, within a run or by the next scheduled run.", func(t transferSeries) uint64 { return t.retries }},
+	}
+	for _, counter := range counters {
+		family(counter.name, "counter", counter.help)
+		for _, key := range keys {
+			fmt.Fprintf(&b, "%s%s %d\n", counter.name, key.labels(), counter.value(series[key]))
+		}
+	}
+
+	family("sylve_transfer_last_success_timestamp_seconds", "gauge", "Unix time of the last successful backup run or replication to the target.")
+	for _, key := range successKeys {
+		fmt.Fprintf(&b, "sylve_transfer_last_success_timestamp_seconds%s %d\n", key.labels(), lastSuccess[key].Unix())
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func sortTransferKeys(keys []transferSeriesKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+		return a.Target < b.Target
+	})
+}
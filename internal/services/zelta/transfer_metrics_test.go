@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+)
+
+func TestWriteTransferMetricsRendersPerJobAndTargetSeries(t *testing.T) {
+	db := newZeltaServiceTestDB(
+		t,
+		&clusterModels.BackupTarget{},
+		&clusterModels.BackupJob{},
+		&clusterModels.BackupEvent{},
+		&clusterModels.ReplicationPolicy{},
+		&clusterModels.ReplicationEvent{},
+		&clusterModels.ReplicationEventTransfer{},
+	)
+	s := newTestZeltaService(db)
+
+	target := clusterModels.BackupTarget{ID: 1, Name: "offsite", BackupRoot: "tank/bk"}
+	job := clusterModels.BackupJob{
+		ID:            3,
+		Name:          "nightly \"vm\"",
+		TargetID:      1,
+		Target:        target,
+		Mode:          clusterModels.BackupJobModeVM,
+		SourceDataset: "zroot/sylve/virtual-machines/104",
+		CronExpr:      "0 2 * * *",
+	}
+	policy := clusterModels.ReplicationPolicy{ID: 7, Name: "web", GuestType: clusterModels.ReplicationGuestTypeJail, GuestID: 30}
+	if err := db.Create(&target).Error; err != nil {
+		t.Fatalf("failed to seed target: %v", err)
+	}
+	if err := db.Omit("Target").Create(&job).Error; err != nil {
+		t.Fatalf("failed to seed job: %v", err)
+	}
+	if err := db.Omit("Targets").Create(&policy).Error; err != nil {
+		t.Fatalf("failed to seed policy: %v", err)
+	}
+
+	finished := time.Date(2026, time.March, 1, 2, 30, 0, 0, time.UTC)
+	older := finished.Add(-24 * time.Hour)
+	for _, event := range []clusterModels.BackupEvent{
+		{JobID: &job.ID, Status: "success", StartedAt: older, CompletedAt: &older},
+		{JobID: &job.ID, Status: "success", StartedAt: finished, CompletedAt: &finished},
+		{JobID: &job.ID, Status: "failed", StartedAt: finished, CompletedAt: &finished},
+	} {
+		if err := db.Create(&event).Error; err != nil {
+			t.Fatalf("failed to seed backup event: %v", err)
+		}
+	}
+
+	replicationEvent := clusterModels.ReplicationEvent{PolicyID: &policy.ID, EventType: "replication", Status: "success", StartedAt: finished}
+	if err := db.Create(&replicationEvent).Error; err != nil {
+		t.Fatalf("failed to seed replication event: %v", err)
+	}
+	moved := uint64(3 << 20)
+	for _, transfer := range []clusterModels.ReplicationEventTransfer{
+		{EventID: replicationEvent.ID, TargetNodeID: "node-b", Status: replicationTransferStatusSuccess, MovedBytes: &moved, StartedAt: finished, CompletedAt: &finished},
+		{EventID: replicationEvent.ID, TargetNodeID: "node-b", Status: replicationTransferStatusSuccess, MovedBytes: &moved, StartedAt: finished, CompletedAt: &finished},
+		{EventID: replicationEvent.ID, TargetNodeID: "node-c", Status: replicationTransferStatusFailed, StartedAt: finished, CompletedAt: &finished},
+	} {
+		if err := db.Create(&transfer).Error; err != nil {
+			t.Fatalf("failed to seed transfer: %v", err)
+		}
+	}
+
+	backupBytes := uint64(2 << 30)
+	key := backupTransferKey(&job)
+	s.transferStats.observe(key, transferObservation{Duration: 90 * time.Second, Err: errors.New("backup_target_diverged")})
+	s.transferStats.observe(key, transferObservation{Duration: 20 * time.Minute, Bytes: &backupBytes, Created: 2, Pruned: 3, Retries: 1})
+	s.observeReplicationTransfer(&policy, "node-b", replicationEvent.ID, time.Now().Add(-4*time.Second), 1, nil)
+
+	var out strings.Builder
+	if err := s.WriteTransferMetrics(&out); err != nil {
+		t.Fatalf("WriteTransferMetrics failed: %v", err)
+	}
+	text := out.String()
+
+	backupLabels := `kind="backup",id="3",name="nightly \"vm\"",target="offsite",guest_type="vm",guest_id="104"`
+	replicationLabels := `kind="replication",id="7",name="web",target="node-b",guest_type="jail",guest_id="30"`
+	for _, want := range []string{
+		"# TYPE sylve_transfer_duration_seconds histogram",
+		`sylve_transfer_duration_seconds_bucket{` + backupLabels + `,le="60"} 0`,
+		`sylve_transfer_duration_seconds_bucket{` + backupLabels + `,le="120"} 1`,
+		`sylve_transfer_duration_seconds_bucket{` + backupLabels + `,le="+Inf"} 2`,
+		`sylve_transfer_duration_seconds_sum{` + backupLabels + `} 1290`,
+		`sylve_transfer_bytes_count{` + backupLabels + `} 1`,
+		`sylve_transfer_bytes_bucket{` + replicationLabels + `,le="1.6777216e+07"} 1`,
+		`sylve_transfer_bytes_sum{` + replicationLabels + `} 6.291456e+06`,
+		`sylve_transfer_runs_total{` + backupLabels + `,status="failed"} 1`,
+		`sylve_transfer_runs_total{` + backupLabels + `,status="success"} 1`,
+		`sylve_transfer_snapshots_created_total{` + backupLabels + `} 2`,
+		`sylve_transfer_snapshots_created_total{` + replicationLabels + `} 2`,
+		`sylve_transfer_snapshots_pruned_total{` + backupLabels + `} 3`,
+		`sylve_transfer_retries_total{` + backupLabels + `} 2`,
+		`sylve_transfer_last_success_timestamp_seconds{` + backupLabels + `} 1772332200`,
+		`sylve_transfer_last_success_timestamp_seconds{` + replicationLabels + `} 1772332200`,
+	} {
+		if !strings.Contains(text, want+"\n") {
+			t.Fatalf("expected metrics to contain %q, got:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, `target="node-c"`) {
+		t.Fatalf("expected no series for a target that never finished an attempt, got:\n%s", text)
+	}
+}
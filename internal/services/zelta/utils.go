@@ -16,6 +16,7 @@ import (
 	"strings"
 
 	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+	"github.com/alchemillahq/sylve/internal/layout"
 )
 
 func parseHumanSizeBytes(numStr, unitStr, suffixStr string) (uint64, bool) {
@@ -248,7 +249,7 @@ func autoDestSuffix(source string) string {
 	// Walk backwards looking for a known prefix segment.
 	for i := len(parts) - 1; i >= 0; i-- {
 		switch parts[i] {
-		case "jails", "virtual-machines":
+		case layout.JailsName(), layout.VirtualMachinesName():
 			// Return from this segment onward: jails/105, virtual-machines/100, etc.
 			return strings.Join(parts[i:], "/")
 		}
@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+)
+
+// loadZeltaProfile returns the profile a job or policy selected, or nil for
+// zelta's defaults.
+func (s *Service) loadZeltaProfile(id uint) (*clusterModels.ZeltaProfile, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	var profile clusterModels.ZeltaProfile
+	if err := s.DB.First(&profile, id).Error; err != nil {
+		return nil, fmt.Errorf("zelta_profile_not_found: %d: %w", id, err)
+	}
+	return &profile, nil
+}
+
+// withZeltaProfile applies a profile on top of env. Keys in owned are set by
+// the caller for the operation at hand and keep their value. The log level is
+// only ever raised, since backup outcomes are read from zelta's notices.
+func withZeltaProfile(env []string, profile *clusterModels.ZeltaProfile, owned ...string) []string {
+	for _, entry := range profile.ZeltaEnv() {
+		key, value, _ := strings.Cut(entry, "=")
+		if slices.Contains(owned, key) {
+			continue
+		}
+		if key == "ZELTA_LOG_LEVEL" && !raisesZeltaLogLevel(env, value) {
+			continue
+		}
+		env = setEnvValue(env, key, value)
+	}
+	return env
+}
+
+func raisesZeltaLogLevel(env []string, level string) bool {
+	want, err := strconv.Atoi(level)
+	if err != nil {
+		return false
+	}
+	for _, entry := range env {
+		if current, ok := strings.CutPrefix(entry, "ZELTA_LOG_LEVEL="); ok {
+			have, err := strconv.Atoi(current)
+			return err != nil || want > have
+		}
+	}
+	return true
+}
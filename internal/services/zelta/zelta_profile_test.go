@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"slices"
+	"testing"
+
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
+)
+
+func TestWithZeltaProfileKeepsOwnedKeysAndOnlyRaisesLogLevel(t *testing.T) {
+	env := []string{
+		"ZELTA_LOG_LEVEL=3",
+		"ZELTA_RECV_TOP=-o readonly=off",
+	}
+	profile := &clusterModels.ZeltaProfile{Env: map[string]string{
+		"ZELTA_LOG_LEVEL":    "2",
+		"ZELTA_RECV_TOP":     "-o readonly=on",
+		"ZELTA_SEND_DEFAULT": "-L -c",
+	}}
+
+	got := withZeltaProfile(env, profile, "ZELTA_RECV_TOP")
+	for _, want := range []string{"ZELTA_LOG_LEVEL=3", "ZELTA_RECV_TOP=-o readonly=off", "ZELTA_SEND_DEFAULT=-L -c"} {
+		if !slices.Contains(got, want) {
+			t.Fatalf("expected %q in %q", want, got)
+		}
+	}
+
+	profile.Env["ZELTA_LOG_LEVEL"] = "4"
+	if got := withZeltaProfile(env, profile); !slices.Contains(got, "ZELTA_LOG_LEVEL=4") ||
+		!slices.Contains(got, "ZELTA_RECV_TOP=-o readonly=on") {
+		t.Fatalf("expected the profile to raise the log level and set RECV_TOP, got %q", got)
+	}
+
+	if got := withZeltaProfile(env, nil); !slices.Equal(got, env) {
+		t.Fatalf("expected no profile to leave env alone, got %q", got)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/alchemillahq/gzfs"
+	clusterModels "github.com/alchemillahq/sylve/internal/db/models/cluster"
 	"github.com/alchemillahq/sylve/pkg/utils"
 )
 
@@ -70,6 +71,46 @@ func (s *Service) requireRestoreStagingDatasetAvailable(ctx context.Context, nam
 	return nil
 }
 
+// DestroyStaleRestoreDataset removes a leftover .restoring staging dataset
+// once an operator has confirmed it is abandoned. It refuses while a restore
+// holds the destination or a promotion journal entry still references it.
+func (s *Service) DestroyStaleRestoreDataset(ctx context.Context, name string) error {
+	name = normalizeDatasetPath(name)
+	if !strings.HasSuffix(name, staleRestoringSuffix) {
+		return fmt.Errorf("not_a_restore_staging_dataset: %s", name)
+	}
+	destination := s.restoreStagingDestination(name)
+	if destination == "" {
+		return fmt.Errorf("not_a_restore_staging_dataset: %s", name)
+	}
+
+	acquired, holder, roots := s.acquireDatasetOperations([]string{destination})
+	if !acquired {
+		return fmt.Errorf("restore_in_progress_for_dataset: %s", holder)
+	}
+	defer s.releaseDatasetOperations(roots)
+
+	if s.DB != nil {
+		var pending int64
+		if err := s.DB.Model(&clusterModels.RestorePromotion{}).
+			Where("restore_path = ? AND phase IN ?", name, []string{
+				clusterModels.RestorePromotionPhasePending,
+				clusterModels.RestorePromotionPhaseArchived,
+			}).
+			Count(&pending).Error; err != nil {
+			return fmt.Errorf("restore_promotion_journal_check_failed: %w", err)
+		}
+		if pending > 0 {
+			return fmt.Errorf("restore_promotion_pending_for_dataset: %s", name)
+		}
+	}
+
+	if err := s.destroyLocalDataset(ctx, name, true); err != nil {
+		return fmt.Errorf("destroy_stale_restore_dataset_failed: %w", err)
+	}
+	return nil
+}
+
 func (s *Service) destroyLocalDataset(ctx context.Context, name string, recursive bool) error {
 	ds, err := s.getLocalDataset(ctx, name)
 	if err != nil {
@@ -213,6 +254,9 @@ func restoreBackupDatasetCandidate(destination, token string, attempt int) strin
 	return candidate
 }
 
+// promoteRestoredDataset archives destination (when it exists) and renames
+// restorePath into its place. Channel programs cannot rename datasets, so
+// this cannot share a txg the way snapshotGroupAtomic does.
 func (s *Service) promoteRestoredDataset(ctx context.Context, restorePath, destination string) (string, error) {
 	restorePath = normalizeRestoreDestinationDataset(restorePath)
 	destination = normalizeRestoreDestinationDataset(destination)
@@ -246,20 +290,32 @@ func (s *Service) promoteRestoredDataset(ctx context.Context, restorePath, desti
 		if backupDataset == "" {
 			return "", fmt.Errorf("failed_to_allocate_restore_backup_dataset_name")
 		}
+	}
+
+	// Both renames are journaled so a crash between them is completed or
+	// rolled back at startup instead of leaving the guest without a live
+	// dataset.
+	journal := s.beginRestorePromotion(restorePath, destination, backupDataset)
 
+	if backupDataset != "" {
 		if err := s.renameLocalDataset(ctx, destination, backupDataset); err != nil {
+			s.advanceRestorePromotion(journal, clusterModels.RestorePromotionPhaseRolledBack, err)
 			return "", fmt.Errorf("failed_to_archive_destination_dataset_before_restore: %w", err)
 		}
+		s.advanceRestorePromotion(journal, clusterModels.RestorePromotionPhaseArchived, nil)
 	}
 
 	if err := s.renameLocalDataset(ctx, restorePath, destination); err != nil {
 		if backupDataset != "" {
 			if rollbackErr := s.renameLocalDataset(ctx, backupDataset, destination); rollbackErr != nil {
+				s.advanceRestorePromotion(journal, clusterModels.RestorePromotionPhaseManual, rollbackErr)
 				return "", fmt.Errorf("failed_to_promote_restored_dataset: %v; rollback_failed: %v", err, rollbackErr)
 			}
 		}
+		s.advanceRestorePromotion(journal, clusterModels.RestorePromotionPhaseRolledBack, err)
 		return "", fmt.Errorf("failed_to_promote_restored_dataset: %w", err)
 	}
+	s.advanceRestorePromotion(journal, clusterModels.RestorePromotionPhaseDone, nil)
 
 	return backupDataset, nil
 }
@@ -282,12 +338,15 @@ func (s *Service) promoteRestoredDatasetAsNew(ctx context.Context, restorePath,
 		return fmt.Errorf("restore_destination_guest_dataset_exists: dataset=%s", destination)
 	}
 
+	journal := s.beginRestorePromotion(restorePath, destination, "")
 	if err := s.renameLocalDataset(ctx, restorePath, destination); err != nil {
+		s.advanceRestorePromotion(journal, clusterModels.RestorePromotionPhaseRolledBack, err)
 		if exists, existsErr := s.localDatasetExists(ctx, destination); existsErr == nil && exists {
 			return fmt.Errorf("restore_destination_guest_dataset_exists: dataset=%s", destination)
 		}
 		return fmt.Errorf("failed_to_promote_restored_guest_dataset: %w", err)
 	}
+	s.advanceRestorePromotion(journal, clusterModels.RestorePromotionPhaseDone, nil)
 
 	return nil
 }
@@ -0,0 +1,251 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zelta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/utils"
+)
+
+// Channel programs run inside a single transaction group with no other
+// administrative operation interleaved. A failing sync operation is not
+// undone, so every script validates all of its work with zfs.check.*
+// before the first zfs.sync.* call. Dataset renames are not available to
+// channel programs, so restore promotion is out of scope here: it still
+// renames through the CLI and relies on the promotion journal instead.
+const (
+	zfsProgramInstructionLimit = "10000000"
+	zfsProgramMemoryLimit      = "10485760"
+
+	replicationSnapshotGroupProperty = "sylve:snapshot-group-roots"
+)
+
+// zfsProgramSnapshotGroupScript snapshots every root (and optionally every
+// descendant) with the same name and sets the given user properties on each
+// new snapshot. argv is: snapshot name, "1"/"0" recursive, property count,
+// property/value pairs, then the roots. The snapshots do not exist yet when
+// the properties are checked, so each one is checked against its dataset;
+// user properties are validated the same way on both.
+const zfsProgramSnapshotGroupScript = `
+args = ...
+argv = args["argv"]
+
+local snap = argv[1]
+local recursive = argv[2] == "1"
+local nprops = tonumber(argv[3])
+local props = {}
+local i = 4
+for p = 1, nprops do
+	props[argv[i]] = argv[i + 1]
+	i = i + 2
+end
+
+local targets = {}
+local function collect(ds)
+	table.insert(targets, ds)
+	if recursive then
+		for child in zfs.list.children(ds) do
+			collect(child)
+		end
+	end
+end
+while i <= #argv do
+	collect(argv[i])
+	i = i + 1
+end
+
+for _, ds in ipairs(targets) do
+	local err = zfs.check.snapshot(ds .. "@" .. snap)
+	if err ~= 0 then
+		error("snapshot_check_failed:" .. ds .. "@" .. snap .. ":" .. err)
+	end
+	for k, v in pairs(props) do
+		err = zfs.check.set_prop(ds, k, v)
+		if err ~= 0 then
+			error("set_prop_check_failed:" .. ds .. ":" .. k .. ":" .. err)
+		end
+	end
+end
+
+for _, ds in ipairs(targets) do
+	local name = ds .. "@" .. snap
+	local err = zfs.sync.snapshot(name)
+	if err ~= 0 then
+		error("snapshot_failed:" .. name .. ":" .. err)
+	end
+	for k, v in pairs(props) do
+		err = zfs.sync.set_prop(name, k, v)
+		if err ~= 0 then
+			error("set_prop_failed:" .. name .. ":" .. k .. ":" .. err)
+		end
+	end
+end
+
+return #targets
+`
+
+// zfsProgramPool returns the pool shared by every dataset. Channel programs
+// are scoped to one pool, so callers must split or fall back otherwise.
+func zfsProgramPool(datasets []string) (string, error) {
+	pool := ""
+	for _, dataset := range datasets {
+		dataset = normalizeDatasetPath(dataset)
+		if dataset == "" {
+			return "", fmt.Errorf("dataset_required")
+		}
+		current := dataset
+		if idx := strings.IndexAny(current, "/@#"); idx >= 0 {
+			current = current[:idx]
+		}
+		if pool == "" {
+			pool = current
+			continue
+		}
+		if current != pool {
+			return "", fmt.Errorf("zfs_program_datasets_span_pools: %s,%s", pool, current)
+		}
+	}
+	if pool == "" {
+		return "", fmt.Errorf("datasets_required")
+	}
+	return pool, nil
+}
+
+func zfsProgramSnapshotGroupArgs(
+	snapshotName string,
+	recursive bool,
+	properties map[string]string,
+	roots []string,
+) []string {
+	recursiveArg := "0"
+	if recursive {
+		recursiveArg = "1"
+	}
+
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	args := []string{snapshotName, recursiveArg, strconv.Itoa(len(names))}
+	for _, name := range names {
+		args = append(args, name, properties[name])
+	}
+	return append(args, roots...)
+}
+
+// parseZFSProgramReturn extracts the "return" member of zfs program -j output.
+func parseZFSProgramReturn(output string) (any, error) {
+	var decoded map[string]any
+	if err := json.Unmarshal([]byte(strings.TrimSpace(output)), &decoded); err != nil {
+		return nil, fmt.Errorf("invalid_zfs_program_output: %w", err)
+	}
+	value, ok := decoded["return"]
+	if !ok {
+		return nil, fmt.Errorf("zfs_program_output_missing_return")
+	}
+	return value, nil
+}
+
+// isZFSProgramUnsupported reports whether zfs program failed because the
+// host or pool cannot run channel programs at all, as opposed to the
+// script rejecting the requested operation.
+func isZFSProgramUnsupported(output string) bool {
+	lower := strings.ToLower(output)
+	return strings.Contains(lower, "unrecognized command") ||
+		strings.Contains(lower, "invalid command") ||
+		strings.Contains(lower, "operation not supported") ||
+		strings.Contains(lower, "feature not enabled")
+}
+
+// runZFSProgram runs script against pool and returns the decoded "return"
+// value. The script is passed through a private temporary file because zfs
+// program only accepts a path.
+func runZFSProgram(ctx context.Context, pool, script string, args ...string) (any, string, error) {
+	pool = strings.TrimSpace(pool)
+	if pool == "" {
+		return nil, "", fmt.Errorf("pool_required")
+	}
+
+	file, err := os.CreateTemp("", "sylve-zcp-*.lua")
+	if err != nil {
+		return nil, "", fmt.Errorf("create_zfs_program_file_failed: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString(script); err != nil {
+		file.Close()
+		return nil, "", fmt.Errorf("write_zfs_program_file_failed: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, "", fmt.Errorf("write_zfs_program_file_failed: %w", err)
+	}
+
+	cmdArgs := []string{
+		"program", "-j",
+		"-t", zfsProgramInstructionLimit,
+		"-m", zfsProgramMemoryLimit,
+		pool, file.Name(),
+	}
+	cmdArgs = append(cmdArgs, args...)
+	output, err := utils.RunCommandWithContext(ctx, "zfs", cmdArgs...)
+	if err != nil {
+		return nil, output, fmt.Errorf("zfs_program_failed: %s: %w", strings.TrimSpace(output), err)
+	}
+
+	value, err := parseZFSProgramReturn(output)
+	if err != nil {
+		return nil, output, err
+	}
+	return value, output, nil
+}
+
+// snapshotGroupAtomic creates snapshotName on every root in one txg and
+// tags each new snapshot with properties. It returns handled=false when the
+// roots span pools or the host cannot run channel programs, so the caller
+// can fall back to the CLI.
+func snapshotGroupAtomic(
+	ctx context.Context,
+	roots []string,
+	snapshotName string,
+	recursive bool,
+	properties map[string]string,
+) (bool, error) {
+	pool, err := zfsProgramPool(roots)
+	if err != nil {
+		return false, nil
+	}
+
+	_, output, err := runZFSProgram(
+		ctx,
+		pool,
+		zfsProgramSnapshotGroupScript,
+		zfsProgramSnapshotGroupArgs(snapshotName, recursive, properties, roots)...,
+	)
+	if err != nil {
+		if isZFSProgramUnsupported(output) {
+			logger.L.Debug().
+				Str("pool", pool).
+				Str("output", strings.TrimSpace(output)).
+				Msg("zfs_program_unsupported_falling_back")
+			return false, nil
+		}
+		return true, err
+	}
+	return true, nil
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+
+package zelta
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestZFSProgramPoolRequiresSinglePool(t *testing.T) {
+	pool, err := zfsProgramPool([]string{"tank/vm/100", "tank/jails/5/", "tank"})
+	if err != nil || pool != "tank" {
+		t.Fatalf("expected pool tank, got %q (%v)", pool, err)
+	}
+
+	if _, err := zfsProgramPool([]string{"tank/a", "zroot/b"}); err == nil {
+		t.Fatal("expected datasets on different pools to be rejected")
+	}
+	if _, err := zfsProgramPool(nil); err == nil {
+		t.Fatal("expected an empty dataset list to be rejected")
+	}
+}
+
+func TestZFSProgramSnapshotGroupArgsSortsProperties(t *testing.T) {
+	got := zfsProgramSnapshotGroupArgs("ha_1", true, map[string]string{
+		"sylve:b": "2",
+		"sylve:a": "1",
+	}, []string{"tank/a", "tank/b"})
+	want := []string{"ha_1", "1", "2", "sylve:a", "1", "sylve:b", "2", "tank/a", "tank/b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected args: got %v want %v", got, want)
+	}
+
+	got = zfsProgramSnapshotGroupArgs("ha_1", false, nil, []string{"tank/a"})
+	if want := []string{"ha_1", "0", "0", "tank/a"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected args without properties: got %v want %v", got, want)
+	}
+}
+
+func TestZFSProgramSnapshotGroupScriptChecksBeforeSync(t *testing.T) {
+	firstSync := strings.Index(zfsProgramSnapshotGroupScript, "zfs.sync.")
+	for _, check := range []string{"zfs.check.snapshot", "zfs.check.set_prop"} {
+		if idx := strings.Index(zfsProgramSnapshotGroupScript, check); idx < 0 || idx > firstSync {
+			t.Fatalf("expected %s before the first sync call", check)
+		}
+	}
+}
+
+func TestParseZFSProgramReturn(t *testing.T) {
+	value, err := parseZFSProgramReturn(`{"return": 3}`)
+	if err != nil || value != float64(3) {
+		t.Fatalf("unexpected return value %#v (%v)", value, err)
+	}
+	if _, err := parseZFSProgramReturn(`{}`); err == nil {
+		t.Fatal("expected output without return to be rejected")
+	}
+	if _, err := parseZFSProgramReturn(`Channel program execution failed`); err == nil {
+		t.Fatal("expected non-JSON output to be rejected")
+	}
+}
+
+func TestIsZFSProgramUnsupported(t *testing.T) {
+	if !isZFSProgramUnsupported("unrecognized command 'program'") {
+		t.Fatal("expected missing subcommand to be unsupported")
+	}
+	if isZFSProgramUnsupported(`[string "channel program"]:20: snapshot_check_failed:tank/a@ha_1:17`) {
+		t.Fatal("a script failure must not be treated as unsupported")
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/alchemillahq/sylve/internal/db"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
 	zfsServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/zfs"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/internal/logger"
 	"github.com/vmihailenco/msgpack/v5"
 )
@@ -156,7 +157,7 @@ func (s *Service) BulkDeleteDataset(ctx context.Context, guids []string) error {
 		available[ds.GUID] = ds
 	}
 
-	cantDelete := []string{"sylve", "sylve/virtual-machines", "sylve/jails"}
+	cantDelete := []string{layout.RootName(), layout.VMsPath(), layout.JailsPath()}
 
 	for _, guid := range guids {
 		if _, ok := available[guid]; !ok {
@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/internal/logger"
 )
 
@@ -101,7 +102,7 @@ func (s *Service) DeleteFilesystem(ctx context.Context, guid string) error {
 		return fmt.Errorf("filesystem with guid %s not found", guid)
 	}
 
-	noDelete := []string{"sylve", "sylve/virtual-machines", "sylve/jails"}
+	noDelete := []string{layout.RootName(), layout.VMsPath(), layout.JailsPath()}
 	for _, name := range noDelete {
 		if strings.HasSuffix(foundFS.Name, name) {
 			return fmt.Errorf("cannot_delete_critical_filesystem")
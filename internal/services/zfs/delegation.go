@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zfs
+
+import (
+	"context"
+	"fmt"
+	"os/user"
+	"slices"
+	"strings"
+
+	zfsModels "github.com/alchemillahq/sylve/internal/db/models/zfs"
+	zfsServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/zfs"
+	"github.com/alchemillahq/sylve/pkg/utils"
+)
+
+var (
+	delegationRunCommand = utils.RunCommandWithContext
+	delegationLookupUser = user.Lookup
+)
+
+// delegablePermissions covers what a replication user needs on either end:
+// snapshot/send/hold/release/bookmark on sources, receive/create/mount on the
+// datasets it receives into, and destroy/rollback for pruning and rewinding
+// replicas. Administrative permissions such as allow are never delegated.
+var delegablePermissions = []string{
+	"bookmark",
+	"create",
+	"destroy",
+	"hold",
+	"mount",
+	"receive",
+	"release",
+	"rollback",
+	"send",
+	"snapshot",
+	"userprop",
+}
+
+// defaultSourceDelegation is granted when a request names no permissions and
+// is what a backup source dataset needs.
+var defaultSourceDelegation = []string{"hold", "send", "snapshot"}
+
+func normalizeDelegationPermissions(perms []string) ([]string, error) {
+	if len(perms) == 0 {
+		return slices.Clone(defaultSourceDelegation), nil
+	}
+
+	var out []string
+	for _, perm := range perms {
+		perm = strings.ToLower(strings.TrimSpace(perm))
+		if !slices.Contains(delegablePermissions, perm) {
+			return nil, fmt.Errorf("invalid_delegation_permission: %s", perm)
+		}
+		if !slices.Contains(out, perm) {
+			out = append(out, perm)
+		}
+	}
+	slices.Sort(out)
+	return out, nil
+}
+
+func (s *Service) ListDelegations() ([]zfsModels.Delegation, error) {
+	var delegations []zfsModels.Delegation
+	if err := s.DB.Order("dataset ASC, user ASC").Find(&delegations).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_list_delegations: %w", err)
+	}
+	return delegations, nil
+}
+
+// Delegate grants a non-root user ZFS permissions on a dataset and its
+// descendants. Repeating it for the same dataset and user replaces the
+// earlier grant, revoking permissions that are no longer listed.
+func (s *Service) Delegate(ctx context.Context, req zfsServiceInterfaces.DelegationRequest) (zfsModels.Delegation, error) {
+	dataset := strings.TrimSpace(req.Dataset)
+	username := strings.TrimSpace(req.User)
+	delegation := zfsModels.Delegation{Dataset: dataset, User: username}
+
+	if dataset == "" || strings.ContainsAny(dataset, "@# ") {
+		return delegation, fmt.Errorf("invalid_dataset_name")
+	}
+
+	u, err := delegationLookupUser(username)
+	if err != nil {
+		return delegation, fmt.Errorf("delegation_user_not_found: %s", username)
+	}
+	if u.Uid == "0" {
+		return delegation, fmt.Errorf("delegation_user_is_root")
+	}
+
+	perms, err := normalizeDelegationPermissions(req.Permissions)
+	if err != nil {
+		return delegation, err
+	}
+	delegation.Permissions = perms
+
+	if out, err := delegationRunCommand(ctx, "zfs", "list", "-H", "-o", "name", dataset); err != nil {
+		return delegation, fmt.Errorf("dataset_not_found: %s", strings.TrimSpace(out))
+	}
+
+	var existing zfsModels.Delegation
+	if err := s.DB.Where("dataset = ? AND user = ?", dataset, username).Limit(1).Find(&existing).Error; err != nil {
+		return delegation, fmt.Errorf("failed_to_get_delegation: %w", err)
+	}
+
+	if out, err := delegationRunCommand(ctx, "zfs", "allow", "-u", username, strings.Join(perms, ","), dataset); err != nil {
+		return delegation, fmt.Errorf("zfs_allow_failed: %s", strings.TrimSpace(out))
+	}
+
+	var revoked []string
+	for _, perm := range existing.Permissions {
+		if !slices.Contains(perms, perm) {
+			revoked = append(revoked, perm)
+		}
+	}
+	if len(revoked) > 0 {
+		if out, err := delegationRunCommand(ctx, "zfs", "unallow", "-u", username, strings.Join(revoked, ","), dataset); err != nil {
+			return delegation, fmt.Errorf("zfs_unallow_failed: %s", strings.TrimSpace(out))
+		}
+	}
+
+	delegation.ID = existing.ID
+	delegation.CreatedAt = existing.CreatedAt
+	if err := s.DB.Save(&delegation).Error; err != nil {
+		return delegation, fmt.Errorf("failed_to_save_delegation: %w", err)
+	}
+	return delegation, nil
+}
+
+// RemoveDelegation revokes a grant. A dataset that no longer exists took its
+// permissions with it, so only the record is removed.
+func (s *Service) RemoveDelegation(ctx context.Context, id uint) error {
+	var delegation zfsModels.Delegation
+	if err := s.DB.First(&delegation, id).Error; err != nil {
+		return fmt.Errorf("delegation_not_found")
+	}
+
+	if len(delegation.Permissions) > 0 {
+		out, err := delegationRunCommand(ctx, "zfs", "unallow", "-u", delegation.User,
+			strings.Join(delegation.Permissions, ","), delegation.Dataset)
+		if err != nil && !strings.Contains(out, "does not exist") {
+			return fmt.Errorf("zfs_unallow_failed: %s", strings.TrimSpace(out))
+		}
+	}
+
+	if err := s.DB.Delete(&delegation).Error; err != nil {
+		return fmt.Errorf("failed_to_delete_delegation: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zfs
+
+import (
+	"context"
+	"fmt"
+	"os/user"
+	"slices"
+	"strings"
+	"testing"
+
+	zfsModels "github.com/alchemillahq/sylve/internal/db/models/zfs"
+	zfsServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/zfs"
+	"github.com/alchemillahq/sylve/internal/testutil"
+)
+
+func newDelegationTestService(t *testing.T) (*Service, *[]string) {
+	t.Helper()
+
+	var calls []string
+	prevRun, prevLookup := delegationRunCommand, delegationLookupUser
+	delegationRunCommand = func(_ context.Context, command string, args ...string) (string, error) {
+		calls = append(calls, command+" "+strings.Join(args, " "))
+		if args[0] == "list" && args[len(args)-1] == "tank/missing" {
+			return "cannot open 'tank/missing': dataset does not exist", fmt.Errorf("exit status 1")
+		}
+		return "", nil
+	}
+	delegationLookupUser = func(name string) (*user.User, error) {
+		switch name {
+		case "sylrep":
+			return &user.User{Username: name, Uid: "1001", Gid: "1001"}, nil
+		case "toor":
+			return &user.User{Username: name, Uid: "0", Gid: "0"}, nil
+		}
+		return nil, user.UnknownUserError(name)
+	}
+	t.Cleanup(func() { delegationRunCommand, delegationLookupUser = prevRun, prevLookup })
+
+	return &Service{DB: testutil.NewSQLiteTestDB(t, &zfsModels.Delegation{})}, &calls
+}
+
+func TestDelegateDefaultsToSourcePermissionsAndRevokesDropped(t *testing.T) {
+	svc, calls := newDelegationTestService(t)
+	ctx := context.Background()
+
+	first, err := svc.Delegate(ctx, zfsServiceInterfaces.DelegationRequest{Dataset: "tank/vm", User: "sylrep"})
+	if err != nil {
+		t.Fatalf("Delegate: %v", err)
+	}
+	if !slices.Equal(first.Permissions, []string{"hold", "send", "snapshot"}) {
+		t.Fatalf("default permissions = %v", first.Permissions)
+	}
+
+	second, err := svc.Delegate(ctx, zfsServiceInterfaces.DelegationRequest{
+		Dataset: "tank/vm", User: "sylrep", Permissions: []string{"SEND", "snapshot", "bookmark"},
+	})
+	if err != nil {
+		t.Fatalf("Delegate replace: %v", err)
+	}
+	if second.ID != first.ID {
+		t.Fatalf("replacing grant created a new record: %d != %d", second.ID, first.ID)
+	}
+	if !slices.Contains(*calls, "zfs unallow -u sylrep hold tank/vm") {
+		t.Fatalf("dropped permission was not revoked, calls = %v", *calls)
+	}
+
+	if err := svc.RemoveDelegation(ctx, second.ID); err != nil {
+		t.Fatalf("RemoveDelegation: %v", err)
+	}
+	if last := (*calls)[len(*calls)-1]; last != "zfs unallow -u sylrep bookmark,send,snapshot tank/vm" {
+		t.Fatalf("last call = %q", last)
+	}
+	if list, _ := svc.ListDelegations(); len(list) != 0 {
+		t.Fatalf("delegations after removal = %+v", list)
+	}
+}
+
+func TestDelegateRejectsUnsafeRequests(t *testing.T) {
+	svc, calls := newDelegationTestService(t)
+
+	cases := map[string]zfsServiceInterfaces.DelegationRequest{
+		"invalid_dataset_name":          {Dataset: "tank/vm@snap", User: "sylrep"},
+		"delegation_user_not_found":     {Dataset: "tank/vm", User: "nobody-here"},
+		"delegation_user_is_root":       {Dataset: "tank/vm", User: "toor"},
+		"invalid_delegation_permission": {Dataset: "tank/vm", User: "sylrep", Permissions: []string{"allow"}},
+		"dataset_not_found":             {Dataset: "tank/missing", User: "sylrep"},
+	}
+	for want, req := range cases {
+		if _, err := svc.Delegate(context.Background(), req); err == nil || !strings.HasPrefix(err.Error(), want) {
+			t.Fatalf("Delegate(%+v) = %v, want %s", req, err, want)
+		}
+	}
+
+	for _, call := range *calls {
+		if strings.HasPrefix(call, "zfs allow") {
+			t.Fatalf("rejected request reached zfs allow: %v", *calls)
+		}
+	}
+}
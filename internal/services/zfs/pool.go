@@ -21,6 +21,7 @@ import (
 	"github.com/alchemillahq/sylve/internal/db/models"
 	infoModels "github.com/alchemillahq/sylve/internal/db/models/info"
 	zfsServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/zfs"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/pkg/disk"
 	"github.com/alchemillahq/sylve/pkg/utils"
 	"gorm.io/gorm"
@@ -193,29 +194,8 @@ func buildVdevArgs(vdevs []zfsServiceInterfaces.Vdev) []string {
 }
 
 func (s *Service) ensureSylveDatasetsOnPool(ctx context.Context, poolName string) error {
-	requiredDatasets := []string{
-		"sylve",
-		"sylve/virtual-machines",
-		"sylve/jails",
-	}
-
-	for _, dataset := range requiredDatasets {
-		fullDatasetName := fmt.Sprintf("%s/%s", poolName, dataset)
-		found, err := s.GZFS.ZFS.Get(ctx, fullDatasetName, false)
-		if err != nil && !strings.Contains(strings.ToLower(err.Error()), "does not exist") {
-			return fmt.Errorf("failed_to_check_dataset_%s: %w", fullDatasetName, err)
-		}
-
-		if found != nil {
-			continue
-		}
-
-		if _, err := s.GZFS.ZFS.CreateFilesystem(ctx, fullDatasetName, nil); err != nil {
-			return fmt.Errorf("failed_to_create_dataset_%s: %w", fullDatasetName, err)
-		}
-	}
-
-	return nil
+	_, err := layout.EnsurePool(ctx, s.GZFS, poolName)
+	return err
 }
 
 func (s *Service) EditPool(ctx context.Context, name string, props map[string]string, spares []string) error {
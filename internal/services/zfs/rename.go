@@ -0,0 +1,402 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zfs
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/alchemillahq/gzfs"
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	zfsModels "github.com/alchemillahq/sylve/internal/db/models/zfs"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
+	"github.com/alchemillahq/sylve/internal/layout"
+	"github.com/alchemillahq/sylve/internal/logger"
+	"github.com/alchemillahq/sylve/pkg/utils"
+	"gorm.io/gorm"
+)
+
+var renameRunCommand = utils.RunCommandWithContext
+
+var snapshotShortNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.:-]+$`)
+
+// SetBackupJobDatasetRenamer lets dataset renames carry backup job source
+// paths along. Without one, backup jobs are left pointing at the old name.
+func (s *Service) SetBackupJobDatasetRenamer(r clusterServiceInterfaces.BackupJobDatasetRenamer) {
+	s.backupJobDatasetRenamer = r
+}
+
+// renamedDatasetPath returns name with the from prefix replaced by to when
+// name is from itself or one of its descendants.
+func renamedDatasetPath(name, from, to string) (string, bool) {
+	switch {
+	case name == from:
+		return to, true
+	case strings.HasPrefix(name, from+"/"):
+		return to + strings.TrimPrefix(name, from), true
+	default:
+		return name, false
+	}
+}
+
+// isSylveManagedDatasetPath reports whether name is one of the fixed layout
+// datasets Sylve resolves by path: <pool>/sylve, the guest type containers
+// and each guest's root (e.g. <pool>/sylve/virtual-machines/<rid>).
+func isSylveManagedDatasetPath(name string) bool {
+	parts := strings.Split(name, "/")
+	return len(parts) >= 2 && len(parts) <= 4 && parts[1] == layout.RootName()
+}
+
+func validateDatasetRename(from, to string) error {
+	if !strings.Contains(to, "/") || strings.Contains(to, "@") || strings.Contains(to, "//") {
+		return fmt.Errorf("invalid_dataset_name")
+	}
+	if strings.SplitN(from, "/", 2)[0] != strings.SplitN(to, "/", 2)[0] {
+		return fmt.Errorf("dataset_rename_across_pools_not_supported")
+	}
+	if from == to {
+		return fmt.Errorf("dataset_rename_name_unchanged")
+	}
+	if strings.HasPrefix(to, from+"/") {
+		return fmt.Errorf("dataset_rename_into_itself")
+	}
+	if isSylveManagedDatasetPath(from) || isSylveManagedDatasetPath(to) {
+		return fmt.Errorf("dataset_rename_managed_path")
+	}
+	return nil
+}
+
+// rewriteDatasetReferences points every local row that stores a dataset path
+// under from at the same path under to.
+func rewriteDatasetReferences(tx *gorm.DB, from, to string) error {
+	var datasets []vmModels.VMStorageDataset
+	if err := tx.Select("id", "name").Find(&datasets).Error; err != nil {
+		return fmt.Errorf("vm_storage_dataset_lookup_failed: %w", err)
+	}
+	for _, dataset := range datasets {
+		if moved, ok := renamedDatasetPath(dataset.Name, from, to); ok {
+			if err := tx.Model(&vmModels.VMStorageDataset{}).
+				Where("id = ?", dataset.ID).
+				Update("name", moved).Error; err != nil {
+				return fmt.Errorf("vm_storage_dataset_update_failed: %w", err)
+			}
+		}
+	}
+
+	var vmSnapshots []vmModels.VMSnapshot
+	if err := tx.Select("id", "root_datasets").Find(&vmSnapshots).Error; err != nil {
+		return fmt.Errorf("vm_snapshot_lookup_failed: %w", err)
+	}
+	for _, snapshot := range vmSnapshots {
+		changed := false
+		roots := make([]string, len(snapshot.RootDatasets))
+		for i, root := range snapshot.RootDatasets {
+			moved, ok := renamedDatasetPath(root, from, to)
+			roots[i] = moved
+			changed = changed || ok
+		}
+		if !changed {
+			continue
+		}
+		if err := tx.Model(&vmModels.VMSnapshot{ID: snapshot.ID}).
+			Update("root_datasets", roots).Error; err != nil {
+			return fmt.Errorf("vm_snapshot_update_failed: %w", err)
+		}
+	}
+
+	var jailSnapshots []jailModels.JailSnapshot
+	if err := tx.Select("id", "root_dataset").Find(&jailSnapshots).Error; err != nil {
+		return fmt.Errorf("jail_snapshot_lookup_failed: %w", err)
+	}
+	for _, snapshot := range jailSnapshots {
+		if moved, ok := renamedDatasetPath(snapshot.RootDataset, from, to); ok {
+			if err := tx.Model(&jailModels.JailSnapshot{}).
+				Where("id = ?", snapshot.ID).
+				Update("root_dataset", moved).Error; err != nil {
+				return fmt.Errorf("jail_snapshot_update_failed: %w", err)
+			}
+		}
+	}
+
+	var templates []jailModels.JailTemplate
+	if err := tx.Select("id", "root_dataset").Find(&templates).Error; err != nil {
+		return fmt.Errorf("jail_template_lookup_failed: %w", err)
+	}
+	for _, template := range templates {
+		if moved, ok := renamedDatasetPath(template.RootDataset, from, to); ok {
+			if err := tx.Model(&jailModels.JailTemplate{}).
+				Where("id = ?", template.ID).
+				Update("root_dataset", moved).Error; err != nil {
+				return fmt.Errorf("jail_template_update_failed: %w", err)
+			}
+		}
+	}
+
+	var delegations []zfsModels.Delegation
+	if err := tx.Select("id", "dataset").Find(&delegations).Error; err != nil {
+		return fmt.Errorf("delegation_lookup_failed: %w", err)
+	}
+	for _, delegation := range delegations {
+		if moved, ok := renamedDatasetPath(delegation.Dataset, from, to); ok {
+			if err := tx.Model(&zfsModels.Delegation{}).
+				Where("id = ?", delegation.ID).
+				Update("dataset", moved).Error; err != nil {
+				return fmt.Errorf("delegation_update_failed: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// renameAffectedVMs returns the RIDs of VMs with storage at or below from.
+// Renaming a storage dataset itself must keep its basename, since raw disk
+// paths are derived from it.
+func (s *Service) renameAffectedVMs(from, to string) ([]uint, error) {
+	var storages []vmModels.Storage
+	if err := s.DB.Preload("Dataset").Where("dataset_id IS NOT NULL").Find(&storages).Error; err != nil {
+		return nil, fmt.Errorf("vm_storage_lookup_failed: %w", err)
+	}
+
+	var vmIDs []uint
+	for _, storage := range storages {
+		name := strings.TrimSpace(storage.Dataset.Name)
+		if _, ok := renamedDatasetPath(name, from, to); !ok || storage.VMID == 0 {
+			continue
+		}
+		if name == from && path.Base(from) != path.Base(to) {
+			return nil, fmt.Errorf("vm_storage_dataset_basename_must_not_change")
+		}
+		if !slices.Contains(vmIDs, storage.VMID) {
+			vmIDs = append(vmIDs, storage.VMID)
+		}
+	}
+	if len(vmIDs) == 0 {
+		return nil, nil
+	}
+
+	var vms []vmModels.VM
+	if err := s.DB.Select("id", "rid").Where("id IN ?", vmIDs).Find(&vms).Error; err != nil {
+		return nil, fmt.Errorf("vm_lookup_failed: %w", err)
+	}
+
+	rids := make([]uint, 0, len(vms))
+	for _, vm := range vms {
+		rids = append(rids, vm.RID)
+	}
+	slices.Sort(rids)
+
+	return rids, nil
+}
+
+// RenameDataset renames a filesystem or volume and rewrites the Sylve
+// metadata that refers to it or its descendants. A failure to update the
+// metadata puts the dataset back under its old name.
+func (s *Service) RenameDataset(ctx context.Context, guid string, newName string) error {
+	s.syncMutex.Lock()
+	defer s.syncMutex.Unlock()
+
+	dataset, err := s.GZFS.ZFS.GetByGUID(ctx, guid, false)
+	if err != nil {
+		return err
+	}
+	if dataset.Type == gzfs.DatasetTypeSnapshot {
+		return fmt.Errorf("use_snapshot_rename")
+	}
+
+	from := dataset.Name
+	to := strings.Trim(strings.TrimSpace(newName), "/")
+	if err := validateDatasetRename(from, to); err != nil {
+		return err
+	}
+
+	rids, err := s.renameAffectedVMs(from, to)
+	if err != nil {
+		return err
+	}
+	for _, rid := range rids {
+		shutOff, err := s.Libvirt.IsDomainShutOff(rid)
+		if err != nil {
+			return fmt.Errorf("vm_state_lookup_failed: %w", err)
+		}
+		if !shutOff {
+			return fmt.Errorf("vm_must_be_shut_off: %d", rid)
+		}
+	}
+
+	if err := s.RequireReplicationDatasetMutationAllowed(ctx, from); err != nil {
+		return err
+	}
+	if err := s.RequireReplicationDatasetCreateAllowed(ctx, to); err != nil {
+		return err
+	}
+
+	if output, err := renameRunCommand(ctx, "zfs", "rename", from, to); err != nil {
+		return fmt.Errorf("zfs_rename_failed: %s: %w", strings.TrimSpace(output), err)
+	}
+
+	undoRename := func() {
+		if output, err := renameRunCommand(ctx, "zfs", "rename", to, from); err != nil {
+			logger.L.Error().Err(err).Str("from", to).Str("to", from).Str("output", output).
+				Msg("failed to restore dataset name after rename error")
+		}
+	}
+
+	if err := s.DB.Transaction(func(tx *gorm.DB) error {
+		return rewriteDatasetReferences(tx, from, to)
+	}); err != nil {
+		undoRename()
+		return fmt.Errorf("dataset_rename_metadata_update_failed: %w", err)
+	}
+
+	if s.backupJobDatasetRenamer != nil {
+		if err := s.backupJobDatasetRenamer.RenameBackupJobDatasetsClusterWide(from, to); err != nil {
+			if undoErr := s.DB.Transaction(func(tx *gorm.DB) error {
+				return rewriteDatasetReferences(tx, to, from)
+			}); undoErr != nil {
+				logger.L.Error().Err(undoErr).Str("dataset", to).
+					Msg("failed to restore dataset references after rename error")
+			}
+			undoRename()
+			return fmt.Errorf("dataset_rename_backup_jobs_update_failed: %w", err)
+		}
+	}
+
+	for _, rid := range rids {
+		if err := s.Libvirt.SyncVMDisks(rid); err != nil {
+			logger.L.Warn().Err(err).Uint("rid", rid).Msg("failed to sync vm disks after dataset rename")
+		}
+	}
+
+	s.SignalDSChange(dataset.Pool, to, "generic-dataset", "rename")
+
+	return nil
+}
+
+// managedSnapshotRoots returns the root datasets of the VM or jail snapshot
+// record that owns dataset@snapshotName, if any. Those are renamed as a unit.
+func (s *Service) managedSnapshotRoots(dataset, snapshotName string) ([]string, *vmModels.VMSnapshot, *jailModels.JailSnapshot, error) {
+	var vmSnapshots []vmModels.VMSnapshot
+	if err := s.DB.Where("snapshot_name = ?", snapshotName).Find(&vmSnapshots).Error; err != nil {
+		return nil, nil, nil, fmt.Errorf("vm_snapshot_lookup_failed: %w", err)
+	}
+	for i := range vmSnapshots {
+		for _, root := range vmSnapshots[i].RootDatasets {
+			if snapshotScopeContains(dataset, root, false) {
+				return vmSnapshots[i].RootDatasets, &vmSnapshots[i], nil, nil
+			}
+		}
+	}
+
+	var jailSnapshots []jailModels.JailSnapshot
+	if err := s.DB.Where("snapshot_name = ?", snapshotName).Find(&jailSnapshots).Error; err != nil {
+		return nil, nil, nil, fmt.Errorf("jail_snapshot_lookup_failed: %w", err)
+	}
+	for i := range jailSnapshots {
+		root := jailSnapshots[i].RootDataset
+		if snapshotScopeContains(dataset, root, false) {
+			return []string{root}, nil, &jailSnapshots[i], nil
+		}
+	}
+
+	return nil, nil, nil, nil
+}
+
+// RenameSnapshot renames a snapshot. Snapshots taken by the VM or jail
+// snapshot features are renamed across all their root datasets together and
+// their records updated so rollback keeps working.
+func (s *Service) RenameSnapshot(ctx context.Context, guid string, newName string) error {
+	s.syncMutex.Lock()
+	defer s.syncMutex.Unlock()
+
+	newName = strings.TrimSpace(newName)
+	if !snapshotShortNamePattern.MatchString(newName) {
+		return fmt.Errorf("invalid_snapshot_name")
+	}
+	if err := validateUserSnapshotNamespace(newName); err != nil {
+		return err
+	}
+
+	snapshot, err := s.GZFS.ZFS.GetByGUID(ctx, guid, false)
+	if err != nil {
+		return err
+	}
+	if snapshot.Type != gzfs.DatasetTypeSnapshot {
+		return fmt.Errorf("not_a_snapshot")
+	}
+
+	dataset, oldName, ok := strings.Cut(snapshot.Name, "@")
+	if !ok {
+		return fmt.Errorf("not_a_snapshot")
+	}
+	if oldName == newName {
+		return fmt.Errorf("snapshot_rename_name_unchanged")
+	}
+	if err := validateUserSnapshotNamespace(oldName); err != nil {
+		return err
+	}
+	if err := s.RequireReplicationDatasetMutationAllowed(ctx, dataset); err != nil {
+		return err
+	}
+
+	roots, vmSnapshot, jailSnapshot, err := s.managedSnapshotRoots(dataset, oldName)
+	if err != nil {
+		return err
+	}
+
+	if len(roots) == 0 {
+		if output, err := renameRunCommand(ctx, "zfs", "rename", snapshot.Name, dataset+"@"+newName); err != nil {
+			return fmt.Errorf("zfs_rename_failed: %s: %w", strings.TrimSpace(output), err)
+		}
+		s.SignalDSChange(snapshot.Pool, dataset+"@"+newName, "snapshot", "rename")
+		return nil
+	}
+
+	renamed := make([]string, 0, len(roots))
+	undo := func() {
+		for _, root := range renamed {
+			if output, err := renameRunCommand(ctx, "zfs", "rename", "-r", root+"@"+newName, root+"@"+oldName); err != nil {
+				logger.L.Error().Err(err).Str("dataset", root).Str("output", output).
+					Msg("failed to restore snapshot name after rename error")
+			}
+		}
+	}
+
+	for _, root := range roots {
+		if output, err := renameRunCommand(ctx, "zfs", "rename", "-r", root+"@"+oldName, root+"@"+newName); err != nil {
+			undo()
+			return fmt.Errorf("zfs_rename_failed: %s: %w", strings.TrimSpace(output), err)
+		}
+		renamed = append(renamed, root)
+	}
+
+	var dbErr error
+	if vmSnapshot != nil {
+		dbErr = s.DB.Model(&vmModels.VMSnapshot{}).
+			Where("id = ?", vmSnapshot.ID).
+			Update("snapshot_name", newName).Error
+	} else {
+		dbErr = s.DB.Model(&jailModels.JailSnapshot{}).
+			Where("id = ?", jailSnapshot.ID).
+			Update("snapshot_name", newName).Error
+	}
+	if dbErr != nil {
+		undo()
+		return fmt.Errorf("snapshot_rename_metadata_update_failed: %w", dbErr)
+	}
+
+	s.SignalDSChange(snapshot.Pool, dataset+"@"+newName, "snapshot", "rename")
+
+	return nil
+}
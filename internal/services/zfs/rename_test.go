@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: BSD-2-Clause
+
+package zfs
+
+import (
+	"slices"
+	"testing"
+
+	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
+	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
+	zfsModels "github.com/alchemillahq/sylve/internal/db/models/zfs"
+	"github.com/alchemillahq/sylve/internal/testutil"
+)
+
+func TestValidateDatasetRename(t *testing.T) {
+	cases := []struct {
+		from, to string
+		wantErr  string
+	}{
+		{"tank/data", "tank/archive/data", ""},
+		{"tank/sylve/virtual-machines/100/raw-1", "tank/disks/raw-1", ""},
+		{"tank/data", "other/data", "dataset_rename_across_pools_not_supported"},
+		{"tank/data", "tank/data", "dataset_rename_name_unchanged"},
+		{"tank/data", "tank/data/inner", "dataset_rename_into_itself"},
+		{"tank/data", "tank", "invalid_dataset_name"},
+		{"tank/data", "tank/data@snap", "invalid_dataset_name"},
+		{"tank/sylve/jails/105", "tank/jails/105", "dataset_rename_managed_path"},
+		{"tank/data", "tank/sylve/virtual-machines/100", "dataset_rename_managed_path"},
+	}
+
+	for _, tc := range cases {
+		err := validateDatasetRename(tc.from, tc.to)
+		if tc.wantErr == "" && err != nil {
+			t.Fatalf("%s -> %s: unexpected error %v", tc.from, tc.to, err)
+		}
+		if tc.wantErr != "" && (err == nil || err.Error() != tc.wantErr) {
+			t.Fatalf("%s -> %s: error = %v, want %s", tc.from, tc.to, err, tc.wantErr)
+		}
+	}
+}
+
+func TestRewriteDatasetReferencesMovesDescendantsOnly(t *testing.T) {
+	db := testutil.NewSQLiteTestDB(t,
+		&vmModels.VMStorageDataset{},
+		&vmModels.VMSnapshot{},
+		&jailModels.JailSnapshot{},
+		&jailModels.JailTemplate{},
+		&zfsModels.Delegation{},
+	)
+
+	fixtures := []any{
+		&vmModels.VMStorageDataset{Pool: "tank", Name: "tank/data/zvol-1"},
+		&vmModels.VMStorageDataset{Pool: "tank", Name: "tank/database/zvol-2"},
+		&vmModels.VMSnapshot{VMID: 1, RID: 100, Name: "s", SnapshotName: "svms_a", RootDatasets: []string{"tank/data", "tank/other"}},
+		&jailModels.JailSnapshot{JailID: 1, CTID: 105, Name: "s", SnapshotName: "sjs_a", RootDataset: "tank/data/jail"},
+		&jailModels.JailTemplate{Name: "t", RootDataset: "tank/data/template"},
+		&zfsModels.Delegation{Dataset: "tank/data", User: "sylrep"},
+	}
+	for _, fixture := range fixtures {
+		if err := db.Create(fixture).Error; err != nil {
+			t.Fatalf("create %T: %v", fixture, err)
+		}
+	}
+
+	if err := rewriteDatasetReferences(db, "tank/data", "tank/archive/data"); err != nil {
+		t.Fatalf("rewriteDatasetReferences: %v", err)
+	}
+
+	var datasets []vmModels.VMStorageDataset
+	db.Order("id").Find(&datasets)
+	if datasets[0].Name != "tank/archive/data/zvol-1" || datasets[1].Name != "tank/database/zvol-2" {
+		t.Fatalf("storage datasets = %q, %q", datasets[0].Name, datasets[1].Name)
+	}
+
+	var vmSnapshot vmModels.VMSnapshot
+	db.First(&vmSnapshot)
+	if !slices.Equal(vmSnapshot.RootDatasets, []string{"tank/archive/data", "tank/other"}) {
+		t.Fatalf("vm snapshot roots = %v", vmSnapshot.RootDatasets)
+	}
+
+	var jailSnapshot jailModels.JailSnapshot
+	db.First(&jailSnapshot)
+	if jailSnapshot.RootDataset != "tank/archive/data/jail" {
+		t.Fatalf("jail snapshot root = %q", jailSnapshot.RootDataset)
+	}
+
+	var template jailModels.JailTemplate
+	db.First(&template)
+	if template.RootDataset != "tank/archive/data/template" {
+		t.Fatalf("jail template root = %q", template.RootDataset)
+	}
+
+	var delegation zfsModels.Delegation
+	db.First(&delegation)
+	if delegation.Dataset != "tank/archive/data" {
+		t.Fatalf("delegation dataset = %q", delegation.Dataset)
+	}
+}
+
+func TestRenameAffectedVMsKeepsStorageBasename(t *testing.T) {
+	db := testutil.NewSQLiteTestDB(t, &vmModels.VM{}, &vmModels.Storage{}, &vmModels.VMStorageDataset{})
+
+	vm := vmModels.VM{RID: 100, Name: "vm"}
+	if err := db.Create(&vm).Error; err != nil {
+		t.Fatalf("create vm: %v", err)
+	}
+	dataset := vmModels.VMStorageDataset{Pool: "tank", Name: "tank/disks/raw-1"}
+	if err := db.Create(&dataset).Error; err != nil {
+		t.Fatalf("create dataset: %v", err)
+	}
+	if err := db.Create(&vmModels.Storage{
+		VMID: vm.ID, Type: vmModels.VMStorageTypeRaw, Pool: "tank", DatasetID: &dataset.ID,
+	}).Error; err != nil {
+		t.Fatalf("create storage: %v", err)
+	}
+
+	svc := &Service{DB: db}
+
+	rids, err := svc.renameAffectedVMs("tank/disks", "tank/vm-disks")
+	if err != nil || !slices.Equal(rids, []uint{100}) {
+		t.Fatalf("parent rename: rids=%v err=%v", rids, err)
+	}
+	if rids, err := svc.renameAffectedVMs("tank/disks/raw-1", "tank/fast/raw-1"); err != nil || len(rids) != 1 {
+		t.Fatalf("move keeping basename: rids=%v err=%v", rids, err)
+	}
+	if _, err := svc.renameAffectedVMs("tank/disks/raw-1", "tank/disks/raw-2"); err == nil ||
+		err.Error() != "vm_storage_dataset_basename_must_not_change" {
+		t.Fatalf("basename change error = %v", err)
+	}
+	if rids, err := svc.renameAffectedVMs("tank/other", "tank/moved"); err != nil || len(rids) != 0 {
+		t.Fatalf("unrelated rename: rids=%v err=%v", rids, err)
+	}
+}
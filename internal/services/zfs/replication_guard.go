@@ -13,6 +13,7 @@ import (
 	jailModels "github.com/alchemillahq/sylve/internal/db/models/jail"
 	vmModels "github.com/alchemillahq/sylve/internal/db/models/vm"
 	"github.com/alchemillahq/sylve/internal/db/replicationguard"
+	"github.com/alchemillahq/sylve/internal/layout"
 	"github.com/alchemillahq/sylve/pkg/utils"
 	"gorm.io/gorm"
 )
@@ -67,7 +68,7 @@ func (s *Service) protectedReplicationDatasetRoots(
 					}
 				}
 				if pool != "" {
-					roots[fmt.Sprintf("%s/sylve/virtual-machines/%d", pool, policy.GuestID)] = struct{}{}
+					roots[fmt.Sprintf("%s/%d", layout.VMsDataset(pool), policy.GuestID)] = struct{}{}
 				}
 			}
 		case clusterModels.ReplicationGuestTypeJail:
@@ -81,7 +82,7 @@ func (s *Service) protectedReplicationDatasetRoots(
 			}
 			for _, storage := range jail.Storages {
 				if pool := strings.TrimSpace(storage.Pool); pool != "" {
-					roots[fmt.Sprintf("%s/sylve/jails/%d", pool, policy.GuestID)] = struct{}{}
+					roots[fmt.Sprintf("%s/%d", layout.JailsDataset(pool), policy.GuestID)] = struct{}{}
 				}
 			}
 		}
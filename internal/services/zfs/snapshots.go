@@ -306,6 +306,11 @@ func (s *Service) AddPeriodicSnapshot(ctx context.Context, req zfsServiceInterfa
 		recursive = *req.Recursive
 	}
 
+	var bookmarkPruned bool
+	if req.BookmarkPruned != nil {
+		bookmarkPruned = *req.BookmarkPruned
+	}
+
 	if (interval == 0 && cronExpr == "") || (interval != 0 && cronExpr != "") {
 		return fmt.Errorf("invalid_schedule: specify either interval or cronExpr")
 	}
@@ -336,6 +341,8 @@ func (s *Service) AddPeriodicSnapshot(ctx context.Context, req zfsServiceInterfa
 		KeepWeekly:  rvals.KeepWeekly,
 		KeepMonthly: rvals.KeepMonthly,
 		KeepYearly:  rvals.KeepYearly,
+
+		BookmarkPruned: bookmarkPruned,
 	}
 
 	if err := s.DB.Create(&snapshot).Error; err != nil {
@@ -414,6 +421,9 @@ func (s *Service) ModifyPeriodicSnapshotRetention(req zfsServiceInterfaces.Modif
 	if req.KeepYearly != nil {
 		updates["KeepYearly"] = rvals.KeepYearly
 	}
+	if req.BookmarkPruned != nil {
+		updates["BookmarkPruned"] = *req.BookmarkPruned
+	}
 
 	switch rtype {
 	case retentionSimple:
@@ -439,7 +449,9 @@ func (s *Service) ModifyPeriodicSnapshotRetention(req zfsServiceInterfaces.Modif
 			}
 		}
 	case retentionNone:
-		return fmt.Errorf("no_retention_values_provided")
+		if req.BookmarkPruned == nil {
+			return fmt.Errorf("no_retention_values_provided")
+		}
 	}
 
 	if len(updates) == 0 {
@@ -488,6 +500,52 @@ func parseSnapshotTime(dsName, prefix, snapName string) (time.Time, bool) {
 	return t, true
 }
 
+// snapshotBookmarkNames maps every snapshot named short in a listing of
+// snapshots to the bookmark that preserves it.
+func snapshotBookmarkNames(output, short string) map[string]string {
+	bookmarks := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		name := strings.TrimSpace(line)
+		at := strings.LastIndex(name, "@")
+		if at <= 0 || name[at+1:] != short {
+			continue
+		}
+		bookmarks[name] = name[:at] + "#" + short
+	}
+	return bookmarks
+}
+
+// bookmarkSnapshot creates a bookmark for snapshotName and, when recursive,
+// for the snapshot of the same name on every descendant.
+func (s *Service) bookmarkSnapshot(ctx context.Context, snapshotName string, recursive bool) error {
+	at := strings.LastIndex(snapshotName, "@")
+	if at <= 0 {
+		return fmt.Errorf("invalid_snapshot_name")
+	}
+
+	bookmarks := map[string]string{
+		snapshotName: snapshotName[:at] + "#" + snapshotName[at+1:],
+	}
+	if recursive {
+		output, err := utils.RunCommandWithContext(
+			ctx, "zfs", "list", "-H", "-r", "-t", "snapshot", "-o", "name", snapshotName[:at],
+		)
+		if err != nil {
+			return fmt.Errorf("failed_to_list_snapshots: %w", err)
+		}
+		bookmarks = snapshotBookmarkNames(output, snapshotName[at+1:])
+	}
+
+	for snapshot, bookmark := range bookmarks {
+		output, err := utils.RunCommandWithContext(ctx, "zfs", "bookmark", snapshot, bookmark)
+		if err != nil && !strings.Contains(strings.ToLower(output), "already exists") {
+			return fmt.Errorf("failed_to_bookmark_snapshot: %s: %w", strings.TrimSpace(output), err)
+		}
+	}
+
+	return nil
+}
+
 func (s *Service) pruneSnapshots(
 	ctx context.Context,
 	job zfsModels.PeriodicSnapshot,
@@ -614,6 +672,13 @@ func (s *Service) pruneSnapshots(
 			continue
 		}
 
+		if job.BookmarkPruned {
+			if err := s.bookmarkSnapshot(ctx, sn.Name, job.Recursive); err != nil {
+				logger.L.Debug().Err(err).Msgf("Skip prune (bookmark failed) %s", sn.Name)
+				continue
+			}
+		}
+
 		if err := sn.Dataset.Destroy(ctx, job.Recursive, false); err != nil {
 			logger.L.Debug().Err(err).Msgf("Failed to prune snapshot %s", sn.Name)
 			continue
@@ -252,3 +252,27 @@ func TestPeriodicSnapshotSkipsDoNotAdvanceLastRunRealZFS(t *testing.T) {
 		}
 	}
 }
+
+func TestSnapshotBookmarkNamesMatchesOnlyExactShortName(t *testing.T) {
+	output := strings.Join([]string{
+		"tank/data@daily-2026-01-01-00-00",
+		"tank/data/child@daily-2026-01-01-00-00",
+		"tank/data/child@daily-2026-01-01-00-005",
+		"tank/data@other",
+		"",
+	}, "\n")
+
+	got := snapshotBookmarkNames(output, "daily-2026-01-01-00-00")
+	want := map[string]string{
+		"tank/data@daily-2026-01-01-00-00":       "tank/data#daily-2026-01-01-00-00",
+		"tank/data/child@daily-2026-01-01-00-00": "tank/data/child#daily-2026-01-01-00-00",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected bookmarks: %v", got)
+	}
+	for snapshot, bookmark := range want {
+		if got[snapshot] != bookmark {
+			t.Fatalf("expected %s -> %s, got %v", snapshot, bookmark, got)
+		}
+	}
+}
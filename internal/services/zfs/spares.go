@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	zfsModels "github.com/alchemillahq/sylve/internal/db/models/zfs"
+	zfsServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/zfs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetHotSpares lists the spares configured on a pool with their current
+// state (AVAIL, INUSE, ...) next to the pool's auto-replace policy.
+func (s *Service) GetHotSpares(ctx context.Context, guid string) (*zfsServiceInterfaces.PoolHotSpares, error) {
+	pool, err := s.GZFS.Zpool.GetByGUID(ctx, guid)
+	if err != nil || pool == nil {
+		return nil, fmt.Errorf("pool_not_found")
+	}
+
+	status, err := pool.Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed_to_get_pool_status: %v", err)
+	}
+
+	result := &zfsServiceInterfaces.PoolHotSpares{
+		Pool:   pool.Name,
+		Spares: []zfsServiceInterfaces.HotSpare{},
+	}
+	for name, spare := range status.Spares {
+		if spare == nil {
+			continue
+		}
+		result.Spares = append(result.Spares, zfsServiceInterfaces.HotSpare{
+			Name:  name,
+			Path:  spare.Path,
+			GUID:  spare.GUID,
+			State: spare.State,
+		})
+	}
+	sort.Slice(result.Spares, func(i, j int) bool { return result.Spares[i].Name < result.Spares[j].Name })
+
+	var policy zfsModels.HotSparePolicy
+	if err := s.DB.Where("pool = ?", pool.Name).First(&policy).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed_to_get_hot_spare_policy: %v", err)
+		}
+		return result, nil
+	}
+
+	result.AutoReplace = policy.AutoReplace
+	result.LastReplacedAt = policy.LastReplacedAt
+	result.LastFaulted = policy.LastFaulted
+	result.LastSpare = policy.LastSpare
+	result.LastError = policy.LastError
+	return result, nil
+}
+
+// SetHotSparePolicy turns automatic spare replacement on or off for a pool.
+// Enabling it requires at least one spare to already be configured.
+func (s *Service) SetHotSparePolicy(ctx context.Context, guid string, autoReplace bool) (*zfsServiceInterfaces.PoolHotSpares, error) {
+	spares, err := s.GetHotSpares(ctx, guid)
+	if err != nil {
+		return nil, err
+	}
+	if autoReplace && len(spares.Spares) == 0 {
+		return nil, fmt.Errorf("pool_has_no_spares")
+	}
+
+	policy := zfsModels.HotSparePolicy{Pool: spares.Pool, AutoReplace: autoReplace}
+	if err := s.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "pool"}},
+		DoUpdates: clause.AssignmentColumns([]string{"auto_replace", "updated_at"}),
+	}).Create(&policy).Error; err != nil {
+		return nil, fmt.Errorf("failed_to_save_hot_spare_policy: %v", err)
+	}
+
+	spares.AutoReplace = autoReplace
+	return spares, nil
+}
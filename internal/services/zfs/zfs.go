@@ -16,6 +16,7 @@ import (
 
 	"github.com/alchemillahq/gzfs"
 	"github.com/alchemillahq/sylve/internal/db/models"
+	clusterServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/cluster"
 	libvirtServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/libvirt"
 	zfsServiceInterfaces "github.com/alchemillahq/sylve/internal/interfaces/services/zfs"
 	"github.com/alchemillahq/sylve/internal/logger"
@@ -34,6 +35,7 @@ type Service struct {
 	cacheInvalidationMutex    sync.Mutex
 	cacheInvalidationSequence uint64
 	pendingCacheInvalidations map[string]uint64
+	backupJobDatasetRenamer   clusterServiceInterfaces.BackupJobDatasetRenamer
 }
 
 func NewZfsService(db *gorm.DB, telemetryDB *gorm.DB, libvirt libvirtServiceInterfaces.LibvirtServiceInterface, gzfsClient *gzfs.Client) zfsServiceInterfaces.ZfsServiceInterface {
@@ -8,6 +8,8 @@
 
 package internal
 
+import "github.com/alchemillahq/sylve/internal/apierror"
+
 type BaseConfigAdmin struct {
 	Email              string `json:"email"`
 	Password           string `json:"password"`
@@ -23,6 +25,32 @@ type Raft struct {
 	Reset bool `json:"reset"`
 }
 
+type ClusterConfig struct {
+	// PreferIPv6 picks AAAA records over A records when a cluster peer or
+	// this node's advertised address is given as a hostname on a
+	// dual-stack network.
+	PreferIPv6 bool `json:"preferIPv6"`
+	// ClockSkewWarnMs and ClockSkewCriticalMs are how far a member's clock
+	// may drift from the leader's before it is flagged. They default to
+	// 500 and 2000 milliseconds.
+	ClockSkewWarnMs     int `json:"clockSkewWarnMs"`
+	ClockSkewCriticalMs int `json:"clockSkewCriticalMs"`
+	// RefuseJoinOnClockSkew stops this node from joining a cluster whose
+	// leader's clock is off by more than ClockSkewCriticalMs.
+	RefuseJoinOnClockSkew bool `json:"refuseJoinOnClockSkew"`
+}
+
+// SecretsConfig says where the master key that unlocks stored secrets comes
+// from. MasterKeySource is "file" (the default, a key generated under the
+// data path unless MasterKeyFile names one), "env", "command" (for a KMS
+// client that prints the key) or "prompt" (asked for on the console at boot).
+type SecretsConfig struct {
+	MasterKeySource  string   `json:"masterKeySource"`
+	MasterKeyFile    string   `json:"masterKeyFile"`
+	MasterKeyEnv     string   `json:"masterKeyEnv"`
+	MasterKeyCommand []string `json:"masterKeyCommand"`
+}
+
 type DHTConfig struct {
 	Port    int  `json:"port"`
 	Enabled bool `json:"enabled"`
@@ -53,37 +81,84 @@ const (
 
 type JailsConfig struct {
 	DisableDevFS bool `json:"disableDevFS"`
+	// MountRoots are the host directories nullfs mounts into jails may come
+	// from. Empty allows /mnt, /media and /usr/home.
+	MountRoots []string `json:"mountRoots"`
 }
 
 type ZFSConfig struct {
 	Tune bool `json:"tune"`
+	// SlowCommandMs is the duration at which a zfs/zpool command is logged
+	// and kept in the slow-command trace. Zero uses the default of 1s.
+	SlowCommandMs int `json:"slowCommandMs"`
+}
+
+type GuestsConfig struct {
+	// StopOnExit shuts guests down whenever Sylve exits, not only when the
+	// rc script reports a host shutdown.
+	StopOnExit bool `json:"stopOnExit"`
+	// MetadataPort serves cloud-init metadata to VMs on standard switches
+	// when non-zero, on the link-local address 169.254.169.254 of their
+	// bridges. Guests are identified by their ARP entry and the bridge port
+	// their MAC was learned on, so hosts on the uplink get nothing.
+	MetadataPort int `json:"metadataPort"`
+	// HeavyOpsPerPool caps the creates, clones and restores that write to
+	// one pool at the same time; the rest wait in line. Defaults to 2.
+	HeavyOpsPerPool int `json:"heavyOpsPerPool"`
+	// RequireVerifiedImages refuses to attach downloaded images to VMs
+	// unless their checksum or signature was verified. Images that failed
+	// verification are refused either way.
+	RequireVerifiedImages bool `json:"requireVerifiedImages"`
+}
+
+// DatasetClassConfig names one class of Sylve-managed dataset and the
+// properties it is created with. Empty properties are inherited from the pool.
+type DatasetClassConfig struct {
+	Name        string `json:"name"`
+	Compression string `json:"compression"`
+	Recordsize  string `json:"recordsize"`
+}
+
+// DatasetLayoutConfig overrides the <pool>/sylve/{virtual-machines,jails,
+// bootstraps} layout. Every node in a cluster must use the same names, and
+// they should not change once guests exist on a pool.
+type DatasetLayoutConfig struct {
+	Root            string             `json:"root"`
+	VirtualMachines DatasetClassConfig `json:"virtualMachines"`
+	Jails           DatasetClassConfig `json:"jails"`
+	Bootstraps      DatasetClassConfig `json:"bootstraps"`
 }
 
 type SylveConfig struct {
-	Environment    Environment     `json:"environment"`
-	ProxyToVite    bool            `json:"proxyToVite"`
-	Profile        bool            `json:"profile"`
-	IP             string          `json:"ip"`
-	Port           int             `json:"port"`
-	HTTPPort       int             `json:"httpPort"`
-	LogLevel       int8            `json:"logLevel"`
-	WANInterfaces  []string        `json:"wanInterfaces"`
-	Admin          BaseConfigAdmin `json:"admin"`
-	DataPath       string          `json:"dataPath"`
-	TLS            TLSConfig       `json:"tlsConfig"`
-	Raft           Raft            `json:"raft"`
-	BTT            BTT             `json:"btt"`
-	Auth           AuthConfig      `json:"auth"`
-	Jails          JailsConfig     `json:"jails"`
-	ZFS            ZFSConfig       `json:"zfs"`
-	TrustedProxies []string        `json:"trustedProxies"`
+	Environment    Environment         `json:"environment"`
+	ProxyToVite    bool                `json:"proxyToVite"`
+	Profile        bool                `json:"profile"`
+	IP             string              `json:"ip"`
+	Port           int                 `json:"port"`
+	HTTPPort       int                 `json:"httpPort"`
+	LogLevel       int8                `json:"logLevel"`
+	WANInterfaces  []string            `json:"wanInterfaces"`
+	Admin          BaseConfigAdmin     `json:"admin"`
+	DataPath       string              `json:"dataPath"`
+	TLS            TLSConfig           `json:"tlsConfig"`
+	Raft           Raft                `json:"raft"`
+	Cluster        ClusterConfig       `json:"cluster"`
+	BTT            BTT                 `json:"btt"`
+	Auth           AuthConfig          `json:"auth"`
+	Secrets        SecretsConfig       `json:"secrets"`
+	Jails          JailsConfig         `json:"jails"`
+	ZFS            ZFSConfig           `json:"zfs"`
+	Guests         GuestsConfig        `json:"guests"`
+	Datasets       DatasetLayoutConfig `json:"datasets"`
+	TrustedProxies []string            `json:"trustedProxies"`
 }
 
 type APIResponse[T any] struct {
-	Status  string `json:"status"`
-	Message string `json:"message"`
-	Data    T      `json:"data"`
-	Error   string `json:"error"`
+	Status      string           `json:"status"`
+	Message     string           `json:"message"`
+	Data        T                `json:"data"`
+	Error       string           `json:"error"`
+	ErrorDetail *apierror.Detail `json:"errorDetail,omitempty"`
 }
 
 const MinimumVMStorageSize = 1024 * 1024 * 128
@@ -98,9 +173,9 @@ type BulkDeleteRequest struct {
 }
 
 type BulkUpdateRulesRequest struct {
-	IDs            []int  `json:"ids" binding:"required"`
-	UIEnabled      *bool  `json:"uiEnabled"`
-	NtfyEnabled    *bool  `json:"ntfyEnabled"`
-	EmailEnabled   *bool  `json:"emailEnabled"`
-	DiscordEnabled *bool  `json:"discordEnabled"`
+	IDs            []int `json:"ids" binding:"required"`
+	UIEnabled      *bool `json:"uiEnabled"`
+	NtfyEnabled    *bool `json:"ntfyEnabled"`
+	EmailEnabled   *bool `json:"emailEnabled"`
+	DiscordEnabled *bool `json:"discordEnabled"`
 }
@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/alchemillahq/sylve/internal/db/replicationguard"
+	"github.com/alchemillahq/sylve/internal/db/secrets"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
@@ -30,6 +31,18 @@ func NewSQLiteTestDB(t *testing.T, migrateModels ...any) *gorm.DB {
 	}
 	sqlDB.SetMaxOpenConns(1)
 
+	// Secret columns refuse to store plaintext, so give tests a throwaway
+	// data key the way SetupDatabase does.
+	if !secrets.Unlocked() {
+		key, err := secrets.NewDataKey()
+		if err != nil {
+			t.Fatalf("failed to create test data key: %v", err)
+		}
+		if err := secrets.SetDataKey(key); err != nil {
+			t.Fatalf("failed to install test data key: %v", err)
+		}
+	}
+
 	if len(migrateModels) > 0 {
 		if err := db.AutoMigrate(migrateModels...); err != nil {
 			t.Fatalf("failed to migrate test tables: %v", err)
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package webhooks
+
+import (
+	"strings"
+	"sync"
+)
+
+const (
+	EventGuestStarted        = "guest.started"
+	EventGuestStopped        = "guest.stopped"
+	EventBackupCompleted     = "backup.completed"
+	EventReplicationFailover = "replication.failover"
+	EventNodeStatusChanged   = "node.status_changed"
+	EventPing                = "webhook.ping"
+)
+
+// Events lists every event type a webhook can subscribe to.
+var Events = []string{
+	EventGuestStarted,
+	EventGuestStopped,
+	EventBackupCompleted,
+	EventReplicationFailover,
+	EventNodeStatusChanged,
+}
+
+type Publisher interface {
+	Publish(eventType string, data map[string]any)
+}
+
+var (
+	publisherMu sync.RWMutex
+	publisher   Publisher
+)
+
+func SetPublisher(next Publisher) {
+	publisherMu.Lock()
+	publisher = next
+	publisherMu.Unlock()
+}
+
+// Publish hands an event to the configured publisher. It never blocks on
+// delivery and is a no-op until a publisher is set, so callers can fire
+// events from hot paths without caring whether webhooks are in use.
+func Publish(eventType string, data map[string]any) {
+	publisherMu.RLock()
+	active := publisher
+	publisherMu.RUnlock()
+
+	if active == nil {
+		return
+	}
+
+	active.Publish(eventType, data)
+}
+
+// IsKnownEvent reports whether eventType is a subscribable event.
+func IsKnownEvent(eventType string) bool {
+	for _, known := range Events {
+		if known == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesFilter reports whether eventType is selected by filter. An empty
+// filter selects everything; entries may be exact event types, "*", or a
+// group wildcard such as "guest.*".
+func MatchesFilter(filter []string, eventType string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+
+	for _, entry := range filter {
+		entry = strings.TrimSpace(entry)
+		switch {
+		case entry == "*" || entry == eventType:
+			return true
+		case strings.HasSuffix(entry, ".*") && strings.HasPrefix(eventType, strings.TrimSuffix(entry, "*")):
+			return true
+		}
+	}
+	return false
+}
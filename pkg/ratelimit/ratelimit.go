@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package ratelimit
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rateWindow bounds how long past throughput is remembered, so a stream
+// that idled or was throttled harder earlier does not burst afterwards.
+const rateWindow = 2 * time.Second
+
+var sleep = time.Sleep
+
+// Reader paces reads so the throughput stays at or below rate() bytes per
+// second. The rate is consulted on every read and may change at any time;
+// zero means unlimited.
+type Reader struct {
+	r    io.Reader
+	rate func() uint64
+
+	windowStart time.Time
+	windowBytes uint64
+	windowRate  uint64
+	now         func() time.Time
+}
+
+func NewReader(r io.Reader, rate func() uint64) *Reader {
+	return &Reader{r: r, rate: rate, now: time.Now}
+}
+
+func (l *Reader) Read(p []byte) (int, error) {
+	rate := l.rate()
+	if rate == 0 {
+		return l.r.Read(p)
+	}
+
+	now := l.now()
+	if rate != l.windowRate || now.Sub(l.windowStart) > rateWindow {
+		l.windowStart = now
+		l.windowBytes = 0
+		l.windowRate = rate
+	}
+
+	// Never hand out more than a tenth of a second's worth at once so the
+	// pacing stays smooth at low rates.
+	chunk := rate / 10
+	if chunk < 4096 {
+		chunk = 4096
+	}
+	if uint64(len(p)) > chunk {
+		p = p[:chunk]
+	}
+
+	n, err := l.r.Read(p)
+	l.windowBytes += uint64(n)
+
+	due := time.Duration(float64(l.windowBytes) / float64(rate) * float64(time.Second))
+	if elapsed := l.now().Sub(l.windowStart); due > elapsed {
+		sleep(due - elapsed)
+	}
+	return n, err
+}
+
+// FileRate returns a rate func that reads a decimal bytes-per-second value
+// from path, re-reading it at most once a second. A missing or malformed
+// file keeps the last value that was read.
+func FileRate(path string) func() uint64 {
+	var mu sync.Mutex
+	var last uint64
+	var checked time.Time
+
+	return func() uint64 {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if time.Since(checked) < time.Second {
+			return last
+		}
+		checked = time.Now()
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return last
+		}
+		if value, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64); err == nil {
+			last = value
+		}
+		return last
+	}
+}
+
+// Run starts name with args and relays stdin to the child and the child's
+// stdout back, both paced by rate. It is used as a transparent prefix for
+// ssh commands whose data stream the caller cannot wrap directly.
+func Run(ctx context.Context, rate func() uint64, stdin io.Reader, stdout, stderr io.Writer, name string, args ...string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stderr = stderr
+
+	childIn, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	childOut, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	// The child may never read stdin (ssh -n), so this copy is not waited on.
+	go func() {
+		_, _ = io.Copy(childIn, NewReader(stdin, rate))
+		_ = childIn.Close()
+	}()
+
+	_, copyErr := io.Copy(stdout, NewReader(childOut, rate))
+	waitErr := cmd.Wait()
+	if waitErr != nil {
+		return waitErr
+	}
+	return copyErr
+}
@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package ratelimit
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReaderPacesToRate(t *testing.T) {
+	clock := time.Unix(0, 0)
+	var slept time.Duration
+	originalSleep := sleep
+	sleep = func(d time.Duration) {
+		slept += d
+		clock = clock.Add(d)
+	}
+	t.Cleanup(func() { sleep = originalSleep })
+
+	reader := NewReader(bytes.NewReader(make([]byte, 100_000)), func() uint64 { return 50_000 })
+	reader.now = func() time.Time { return clock }
+
+	n, err := io.Copy(io.Discard, reader)
+	if err != nil || n != 100_000 {
+		t.Fatalf("copy = %d, %v", n, err)
+	}
+	if slept < 1900*time.Millisecond || slept > 2100*time.Millisecond {
+		t.Fatalf("expected ~2s of pacing for 100KB at 50KB/s, slept %s", slept)
+	}
+}
+
+func TestReaderUnlimitedDoesNotSleep(t *testing.T) {
+	originalSleep := sleep
+	sleep = func(time.Duration) { t.Fatalf("unlimited reader must not sleep") }
+	t.Cleanup(func() { sleep = originalSleep })
+
+	n, err := io.Copy(io.Discard, NewReader(bytes.NewReader(make([]byte, 1<<20)), func() uint64 { return 0 }))
+	if err != nil || n != 1<<20 {
+		t.Fatalf("copy = %d, %v", n, err)
+	}
+}
+
+func TestFileRateKeepsLastValueOnBadInput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rate")
+	if err := os.WriteFile(path, []byte("1234\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	rate := FileRate(path)
+	if got := rate(); got != 1234 {
+		t.Fatalf("rate = %d", got)
+	}
+}
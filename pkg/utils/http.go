@@ -13,6 +13,7 @@ import (
 	"compress/gzip"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -175,6 +176,66 @@ func FlatHeaders(c *gin.Context) map[string]string {
 	return flatHeaders
 }
 
+var (
+	clusterTrustMu      sync.RWMutex
+	clusterTrustPool    *x509.CertPool
+	clusterTrustEnforce bool
+)
+
+// SetIntraClusterTrust pins intra-cluster HTTPS to the cluster CA. With a nil
+// pool, or while enforce is off, peers are accepted without verification as
+// they were before the cluster had a CA.
+func SetIntraClusterTrust(pool *x509.CertPool, enforce bool) {
+	clusterTrustMu.Lock()
+	changed := clusterTrustPool != pool || clusterTrustEnforce != enforce
+	clusterTrustPool = pool
+	clusterTrustEnforce = enforce
+	clusterTrustMu.Unlock()
+
+	// Kept-alive connections were verified under the old trust.
+	if changed {
+		intraClusterClient().CloseIdleConnections()
+	}
+}
+
+// IntraClusterTLSConfig returns the client TLS config for calls to other
+// cluster nodes. Peers are addressed by IP and their certificates are pinned
+// to the cluster CA rather than checked against a hostname, so the standard
+// verification is replaced by verifyIntraClusterPeer.
+func IntraClusterTLSConfig() *tls.Config {
+	return &tls.Config{
+		InsecureSkipVerify: true,
+		VerifyConnection:   verifyIntraClusterPeer,
+	}
+}
+
+func verifyIntraClusterPeer(cs tls.ConnectionState) error {
+	clusterTrustMu.RLock()
+	pool, enforce := clusterTrustPool, clusterTrustEnforce
+	clusterTrustMu.RUnlock()
+
+	if pool == nil || !enforce {
+		return nil
+	}
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("cluster_peer_certificate_missing")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range cs.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	if _, err := cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         pool,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}); err != nil {
+		return fmt.Errorf("cluster_peer_certificate_untrusted: %w", err)
+	}
+	return nil
+}
+
 func intraClusterClient() *http.Client {
 	once.Do(func() {
 		tr := &http.Transport{
@@ -189,7 +250,7 @@ func intraClusterClient() *http.Client {
 			}).DialContext,
 			TLSHandshakeTimeout:   5 * time.Second,
 			ExpectContinueTimeout: 1 * time.Second,
-			TLSClientConfig:       &tls.Config{InsecureSkipVerify: true},
+			TLSClientConfig:       IntraClusterTLSConfig(),
 		}
 		sharedClient = &http.Client{
 			Timeout:   8 * time.Second,
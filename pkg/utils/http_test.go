@@ -11,6 +11,7 @@ package utils
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -176,3 +177,35 @@ func TestGetIdFromParam(t *testing.T) {
 		}
 	})
 }
+
+func TestIntraClusterTrustPinsClusterCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	defer SetIntraClusterTrust(nil, false)
+
+	pinned := x509.NewCertPool()
+	pinned.AddCert(server.Certificate())
+	untrusted := x509.NewCertPool()
+
+	cases := []struct {
+		name    string
+		pool    *x509.CertPool
+		enforce bool
+		wantErr bool
+	}{
+		{"no cluster CA yet", nil, true, false},
+		{"not enforced", untrusted, false, false},
+		{"pinned CA", pinned, true, false},
+		{"foreign certificate", untrusted, true, true},
+	}
+
+	for _, tc := range cases {
+		SetIntraClusterTrust(tc.pool, tc.enforce)
+		_, err := HTTPGetStatus(server.URL, nil)
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("%s: err = %v, wantErr %v", tc.name, err, tc.wantErr)
+		}
+	}
+}
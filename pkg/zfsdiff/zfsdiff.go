@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+// Package zfsdiff runs `zfs diff` and parses its machine-readable output.
+package zfsdiff
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultLimit caps how many changes are returned per dataset so a diff
+// against a busy dataset cannot produce an unbounded response.
+const DefaultLimit = 10000
+
+type Change struct {
+	Change    string    `json:"change"`
+	FileType  string    `json:"fileType"`
+	Path      string    `json:"path"`
+	NewPath   string    `json:"newPath,omitempty"`
+	ChangedAt time.Time `json:"changedAt"`
+}
+
+// DatasetDiff is the diff of one dataset between two points. Skipped is set
+// instead of Changes when the dataset cannot be diffed (e.g. a zvol).
+type DatasetDiff struct {
+	Dataset   string   `json:"dataset"`
+	From      string   `json:"from"`
+	To        string   `json:"to"`
+	Changes   []Change `json:"changes"`
+	Truncated bool     `json:"truncated"`
+	Skipped   string   `json:"skipped,omitempty"`
+}
+
+var changeNames = map[string]string{
+	"+": "added",
+	"-": "removed",
+	"M": "modified",
+	"R": "renamed",
+}
+
+var fileTypeNames = map[string]string{
+	"F": "file",
+	"/": "directory",
+	"@": "symlink",
+	"B": "block-device",
+	"C": "character-device",
+	"P": "fifo",
+	"=": "socket",
+	">": "door",
+	"|": "pipe",
+}
+
+// unescape decodes the \NNN octal escapes zfs diff uses for whitespace and
+// non-printable bytes in paths.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+4 <= len(s) {
+			if v, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+
+	return b.String()
+}
+
+func parseTimestamp(s string) time.Time {
+	sec, frac, _ := strings.Cut(s, ".")
+	secs, err := strconv.ParseInt(sec, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+
+	nanos := int64(0)
+	if frac != "" {
+		if len(frac) > 9 {
+			frac = frac[:9]
+		}
+		frac += strings.Repeat("0", 9-len(frac))
+		nanos, _ = strconv.ParseInt(frac, 10, 64)
+	}
+
+	return time.Unix(secs, nanos).UTC()
+}
+
+// ParseLine parses one line of `zfs diff -H -F -t` output.
+func ParseLine(line string) (Change, error) {
+	fields := strings.Split(line, "\t")
+	if len(fields) < 4 {
+		return Change{}, fmt.Errorf("invalid_zfs_diff_line: %q", line)
+	}
+
+	change, ok := changeNames[fields[1]]
+	if !ok {
+		return Change{}, fmt.Errorf("unknown_zfs_diff_change: %q", fields[1])
+	}
+
+	fileType, ok := fileTypeNames[fields[2]]
+	if !ok {
+		fileType = "unknown"
+	}
+
+	parsed := Change{
+		Change:    change,
+		FileType:  fileType,
+		Path:      unescape(fields[3]),
+		ChangedAt: parseTimestamp(fields[0]),
+	}
+	if len(fields) > 4 {
+		parsed.NewPath = unescape(fields[4])
+	}
+
+	return parsed, nil
+}
+
+// Parse reads `zfs diff -H -F -t` output, stopping after limit changes.
+func Parse(r io.Reader, limit int) ([]Change, bool, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	changes := make([]Change, 0)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if len(changes) >= limit {
+			return changes, true, nil
+		}
+
+		change, err := ParseLine(line)
+		if err != nil {
+			return changes, false, err
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, false, scanner.Err()
+}
+
+// Overridden in tests.
+var runDiff = func(ctx context.Context, args ...string) (io.ReadCloser, func() error, error) {
+	cmd := exec.CommandContext(ctx, "zfs", args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return nil, nil, err
+	}
+
+	wait := func() error {
+		if err := cmd.Wait(); err != nil {
+			return fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return nil
+	}
+
+	return stdout, wait, nil
+}
+
+// Diff runs `zfs diff` from a snapshot to a later snapshot of the same
+// dataset, or to the live dataset when to is the dataset itself.
+func Diff(ctx context.Context, from, to string, limit int) ([]Change, bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stdout, wait, err := runDiff(ctx, "diff", "-H", "-F", "-t", from, to)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed_to_run_zfs_diff: %w", err)
+	}
+
+	changes, truncated, parseErr := Parse(stdout, limit)
+	if truncated {
+		cancel()
+		_, _ = io.Copy(io.Discard, stdout)
+		_ = wait()
+		return changes, true, nil
+	}
+
+	if err := wait(); err != nil {
+		return nil, false, fmt.Errorf("zfs_diff_failed: %w", err)
+	}
+	if parseErr != nil {
+		return nil, false, parseErr
+	}
+
+	return changes, false, nil
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zfsdiff
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleOutput = "1700000000.123456789\tM\t/\t/zroot/sylve/jails/101\n" +
+	"1700000001.5\t+\tF\t/zroot/sylve/jails/101/etc/my\\040file\n" +
+	"1700000002.000000000\tR\tF\t/zroot/sylve/jails/101/a\t/zroot/sylve/jails/101/b\n" +
+	"1700000003.000000000\t-\t@\t/zroot/sylve/jails/101/link\n"
+
+func TestParse(t *testing.T) {
+	changes, truncated, err := Parse(strings.NewReader(sampleOutput), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if truncated {
+		t.Fatalf("did not expect truncation")
+	}
+
+	want := []Change{
+		{Change: "modified", FileType: "directory", Path: "/zroot/sylve/jails/101", ChangedAt: time.Unix(1700000000, 123456789).UTC()},
+		{Change: "added", FileType: "file", Path: "/zroot/sylve/jails/101/etc/my file", ChangedAt: time.Unix(1700000001, 500000000).UTC()},
+		{Change: "renamed", FileType: "file", Path: "/zroot/sylve/jails/101/a", NewPath: "/zroot/sylve/jails/101/b", ChangedAt: time.Unix(1700000002, 0).UTC()},
+		{Change: "removed", FileType: "symlink", Path: "/zroot/sylve/jails/101/link", ChangedAt: time.Unix(1700000003, 0).UTC()},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Fatalf("unexpected changes:\n got=%+v\nwant=%+v", changes, want)
+	}
+}
+
+func TestParse_Truncates(t *testing.T) {
+	changes, truncated, err := Parse(strings.NewReader(sampleOutput), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !truncated || len(changes) != 2 {
+		t.Fatalf("expected 2 changes and truncation, got %d %v", len(changes), truncated)
+	}
+}
+
+func TestParseLine_RejectsUnknownChange(t *testing.T) {
+	if _, err := ParseLine("1\tX\tF\t/a"); err == nil {
+		t.Fatalf("expected error for unknown change type")
+	}
+	if _, err := ParseLine("garbage"); err == nil {
+		t.Fatalf("expected error for short line")
+	}
+}
+
+func TestDiff_PassesArgsAndSurfacesFailure(t *testing.T) {
+	prev := runDiff
+	t.Cleanup(func() { runDiff = prev })
+
+	var gotArgs []string
+	runDiff = func(_ context.Context, args ...string) (io.ReadCloser, func() error, error) {
+		gotArgs = args
+		return io.NopCloser(strings.NewReader(sampleOutput)), func() error { return nil }, nil
+	}
+
+	changes, _, err := Diff(context.Background(), "zroot/a@s1", "zroot/a", 0)
+	if err != nil || len(changes) != 4 {
+		t.Fatalf("expected 4 changes, got %d %v", len(changes), err)
+	}
+	if strings.Join(gotArgs, " ") != "diff -H -F -t zroot/a@s1 zroot/a" {
+		t.Fatalf("unexpected args: %v", gotArgs)
+	}
+
+	runDiff = func(_ context.Context, args ...string) (io.ReadCloser, func() error, error) {
+		return io.NopCloser(strings.NewReader("")), func() error { return errors.New("not a snapshot") }, nil
+	}
+	if _, _, err := Diff(context.Background(), "zroot/a@s1", "zroot/a", 0); err == nil || !strings.Contains(err.Error(), "zfs_diff_failed") {
+		t.Fatalf("expected zfs_diff_failed, got %v", err)
+	}
+}
@@ -0,0 +1,239 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+// Package zfstrace records zfs, zpool and zdb invocations made through gzfs
+// so slow storage operations can be found after the fact.
+package zfstrace
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultCapacity      = 512
+	DefaultSlowCapacity  = 128
+	DefaultSlowThreshold = time.Second
+
+	maxArgLength = 256
+)
+
+// Runner matches gzfs.Runner so a Tracer can wrap any gzfs runner.
+type Runner interface {
+	Run(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer, name string, args ...string) error
+}
+
+type localRunner struct{}
+
+func (localRunner) Run(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer, name string, args ...string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+	if stdout != nil {
+		cmd.Stdout = stdout
+	}
+	if stderr != nil {
+		cmd.Stderr = stderr
+	}
+
+	return cmd.Run()
+}
+
+type Entry struct {
+	Command    string    `json:"command"`
+	Args       []string  `json:"args"`
+	StartedAt  time.Time `json:"startedAt"`
+	DurationMs int64     `json:"durationMs"`
+	OK         bool      `json:"ok"`
+	ExitCode   int       `json:"exitCode"`
+	Error      string    `json:"error,omitempty"`
+	Slow       bool      `json:"slow"`
+}
+
+type Snapshot struct {
+	SlowThresholdMs int64   `json:"slowThresholdMs"`
+	Total           uint64  `json:"total"`
+	SlowTotal       uint64  `json:"slowTotal"`
+	Recent          []Entry `json:"recent"`
+	Slow            []Entry `json:"slow"`
+}
+
+type ring struct {
+	entries []Entry
+	next    int
+	full    bool
+}
+
+func newRing(capacity int) ring {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return ring{entries: make([]Entry, capacity)}
+}
+
+func (r *ring) add(e Entry) {
+	r.entries[r.next] = e
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// newestFirst returns up to limit entries, most recent first. A limit of
+// zero or less returns everything held.
+func (r *ring) newestFirst(limit int) []Entry {
+	size := r.next
+	if r.full {
+		size = len(r.entries)
+	}
+	if limit <= 0 || limit > size {
+		limit = size
+	}
+
+	out := make([]Entry, 0, limit)
+	for i := 0; i < limit; i++ {
+		idx := (r.next - 1 - i + len(r.entries)) % len(r.entries)
+		out = append(out, r.entries[idx])
+	}
+
+	return out
+}
+
+// Tracer is a Runner that times every command it runs and keeps the most
+// recent ones, plus a separate ring of slow ones, in memory.
+type Tracer struct {
+	next Runner
+	now  func() time.Time
+
+	mu        sync.Mutex
+	threshold time.Duration
+	recent    ring
+	slow      ring
+	total     uint64
+	slowTotal uint64
+
+	// OnSlow is called outside the lock for every command at or above the
+	// slow threshold.
+	OnSlow func(Entry)
+}
+
+func New(next Runner, capacity, slowCapacity int, threshold time.Duration) *Tracer {
+	if next == nil {
+		next = localRunner{}
+	}
+	if threshold <= 0 {
+		threshold = DefaultSlowThreshold
+	}
+
+	return &Tracer{
+		next:      next,
+		now:       time.Now,
+		threshold: threshold,
+		recent:    newRing(capacity),
+		slow:      newRing(slowCapacity),
+	}
+}
+
+func (t *Tracer) SetSlowThreshold(threshold time.Duration) {
+	if threshold <= 0 {
+		return
+	}
+
+	t.mu.Lock()
+	t.threshold = threshold
+	t.mu.Unlock()
+}
+
+func (t *Tracer) Run(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer, name string, args ...string) error {
+	started := t.now()
+	err := t.next.Run(ctx, stdin, stdout, stderr, name, args...)
+	duration := t.now().Sub(started)
+
+	entry := Entry{
+		Command:    name,
+		Args:       SanitizeArgs(args),
+		StartedAt:  started,
+		DurationMs: duration.Milliseconds(),
+		OK:         err == nil,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+		entry.ExitCode = -1
+
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			entry.ExitCode = exitErr.ExitCode()
+		}
+	}
+
+	t.mu.Lock()
+	entry.Slow = duration >= t.threshold
+	t.total++
+	t.recent.add(entry)
+	if entry.Slow {
+		t.slowTotal++
+		t.slow.add(entry)
+	}
+	onSlow := t.OnSlow
+	t.mu.Unlock()
+
+	if entry.Slow && onSlow != nil {
+		onSlow(entry)
+	}
+
+	return err
+}
+
+func (t *Tracer) Snapshot(limit int) Snapshot {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return Snapshot{
+		SlowThresholdMs: t.threshold.Milliseconds(),
+		Total:           t.total,
+		SlowTotal:       t.slowTotal,
+		Recent:          t.recent.newestFirst(limit),
+		Slow:            t.slow.newestFirst(limit),
+	}
+}
+
+var sensitiveKeys = []string{"key", "pass", "secret", "token", "auth"}
+
+// SanitizeArgs redacts the value of any name=value argument whose name looks
+// like it carries a credential and truncates very long arguments.
+func SanitizeArgs(args []string) []string {
+	out := make([]string, len(args))
+	for i, arg := range args {
+		if name, _, ok := strings.Cut(arg, "="); ok {
+			lower := strings.ToLower(name)
+			for _, key := range sensitiveKeys {
+				if strings.Contains(lower, key) {
+					arg = name + "=<redacted>"
+					break
+				}
+			}
+		}
+
+		if len(arg) > maxArgLength {
+			arg = arg[:maxArgLength] + "..."
+		}
+
+		out[i] = arg
+	}
+
+	return out
+}
+
+// Default is the tracer the daemon's gzfs client runs through.
+var Default = New(nil, DefaultCapacity, DefaultSlowCapacity, DefaultSlowThreshold)
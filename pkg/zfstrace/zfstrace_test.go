@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: BSD-2-Clause
+//
+// Copyright (c) 2025 The FreeBSD Foundation.
+//
+// This software was developed by Hayzam Sherif <hayzam@alchemilla.io>
+// of Alchemilla Ventures Pvt. Ltd. <hello@alchemilla.io>,
+// under sponsorship from the FreeBSD Foundation.
+
+package zfstrace
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type fakeRunner struct {
+	err error
+}
+
+func (f fakeRunner) Run(context.Context, io.Reader, io.Writer, io.Writer, string, ...string) error {
+	return f.err
+}
+
+func newTestTracer(next Runner, capacity int, step time.Duration) *Tracer {
+	tracer := New(next, capacity, capacity, time.Second)
+	clock := time.Unix(0, 0)
+	calls := 0
+	// Each Run reads the clock twice; every second read advances by step.
+	tracer.now = func() time.Time {
+		calls++
+		if calls%2 == 0 {
+			return clock.Add(step)
+		}
+		return clock
+	}
+	return tracer
+}
+
+func TestTracer_RecordsNewestFirstAndWraps(t *testing.T) {
+	tracer := newTestTracer(fakeRunner{}, 2, 10*time.Millisecond)
+
+	for _, name := range []string{"a", "b", "c"} {
+		if err := tracer.Run(context.Background(), nil, nil, nil, "zfs", "list", name); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	snap := tracer.Snapshot(0)
+	if snap.Total != 3 || snap.SlowTotal != 0 {
+		t.Fatalf("unexpected totals: %+v", snap)
+	}
+	if len(snap.Recent) != 2 || snap.Recent[0].Args[1] != "c" || snap.Recent[1].Args[1] != "b" {
+		t.Fatalf("expected newest two entries, got %+v", snap.Recent)
+	}
+	if snap.Recent[0].DurationMs != 10 || !snap.Recent[0].OK {
+		t.Fatalf("unexpected entry: %+v", snap.Recent[0])
+	}
+	if len(tracer.Snapshot(1).Recent) != 1 {
+		t.Fatalf("expected limit to cap entries")
+	}
+}
+
+func TestTracer_SlowCommandsAndErrors(t *testing.T) {
+	tracer := newTestTracer(fakeRunner{err: errors.New("dataset is busy")}, 4, 2*time.Second)
+
+	var slow []Entry
+	tracer.OnSlow = func(e Entry) { slow = append(slow, e) }
+
+	err := tracer.Run(context.Background(), nil, nil, nil, "zfs", "destroy", "tank/a")
+	if err == nil {
+		t.Fatalf("expected runner error to pass through")
+	}
+
+	snap := tracer.Snapshot(0)
+	if snap.SlowTotal != 1 || len(snap.Slow) != 1 || len(slow) != 1 {
+		t.Fatalf("expected one slow entry, got %+v", snap)
+	}
+	entry := snap.Slow[0]
+	if entry.OK || entry.Error != "dataset is busy" || entry.ExitCode != -1 || !entry.Slow {
+		t.Fatalf("unexpected slow entry: %+v", entry)
+	}
+}
+
+func TestSanitizeArgs(t *testing.T) {
+	long := make([]byte, maxArgLength+10)
+	for i := range long {
+		long[i] = 'x'
+	}
+
+	got := SanitizeArgs([]string{
+		"create",
+		"-o", "keylocation=file:///root/key",
+		"-o", "sylve:auth_token=abc",
+		"-o", "compression=lz4",
+		string(long),
+	})
+	want := []string{
+		"create",
+		"-o", "keylocation=<redacted>",
+		"-o", "sylve:auth_token=<redacted>",
+		"-o", "compression=lz4",
+		string(long[:maxArgLength]) + "...",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected sanitized args:\n got=%#v\nwant=%#v", got, want)
+	}
+}
@@ -1,3 +1,3 @@
 #!/bin/sh
 
-swag init -g internal/handlers/routes.go --parseDependency --parseInternal -q --output docs/swagger
+swag init -g internal/handlers/routes.go --parseDependency --parseInternal -q --outputTypes json,yaml --output docs/swagger
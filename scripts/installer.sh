@@ -540,6 +540,9 @@ install_rc_script_if_missing() {
 #                           Group to run sylve as.
 # sylve_args (str):         Set to "-config ${CONFIG_PATH}" by default.
 #                           Extra flags passed to sylve.
+#
+# On host shutdown sylve stops running guests before exiting. Raise
+# rcshutdown_timeout if guests need longer than its default to shut down.
 
 . /etc/rc.subr
 
@@ -557,11 +560,13 @@ export PATH="\${PATH}:/usr/local/bin:/usr/local/sbin"
 
 pidfile="/var/run/\${name}.pid"
 daemon_pidfile="/var/run/\${name}-daemon.pid"
+host_shutdown_marker="/var/run/\${name}.host-shutdown"
 procname="${BIN_PATH}"
 command="/usr/sbin/daemon"
 command_args="-f -c -R 5 -r -T \${name} -p \${pidfile} -P \${daemon_pidfile} \${procname} \${sylve_args}"
 
 start_precmd=sylve_startprecmd
+stop_precmd=sylve_stopprecmd
 stop_postcmd=sylve_stoppostcmd
 
 sylve_startprecmd()
@@ -572,6 +577,15 @@ sylve_startprecmd()
 	if [ ! -e \${pidfile} ]; then
 		install -o \${sylve_user} -g \${sylve_group} /dev/null \${pidfile}
 	fi
+	rm -f \${host_shutdown_marker}
+}
+
+# rc.shutdown stops services with faststop, which sets rc_fast.
+sylve_stopprecmd()
+{
+	if [ -n "\${rc_fast}" ]; then
+		install -o \${sylve_user} -g \${sylve_group} /dev/null \${host_shutdown_marker}
+	fi
 }
 
 sylve_stoppostcmd()
@@ -12,12 +12,13 @@ import { browser } from '$app/environment';
 import { deleteDB, storage } from '$lib';
 import { stopSSEEvents } from '$lib/api/events';
 import { useSafeGoto } from '$lib/hooks/navigation.svelte';
-import type { JWTClaims } from '$lib/types/auth';
+import type { JWTClaims, LoginHistory } from '$lib/types/auth';
 import type { APIResponse } from '$lib/types/common';
 import { kvStorage } from '$lib/types/db';
 import { handleAPIError, suspendAPICacheWrites } from '$lib/utils/http';
 import { buildLoginOptions, isPasskeySupported, serializeCredential } from '$lib/utils/passkeys';
 import { sha256 } from '$lib/utils/string';
+import { convertDbTime } from '$lib/utils/time';
 import { toast } from 'svelte-sonner';
 
 async function parseJSONResponse(response: Response): Promise<any> {
@@ -47,6 +48,57 @@ function applySuccessfulLogin(payload: any): boolean {
     return true;
 }
 
+async function getMOTD(): Promise<string> {
+    try {
+        const response = await fetch('/api/auth/motd', {
+            method: 'GET',
+            headers: {
+                Authorization: `Bearer ${storage.token}`
+            }
+        });
+
+        const responseData = await parseJSONResponse(response);
+        if (response.status === 200 && typeof responseData?.data?.motd === 'string') {
+            return responseData.data.motd;
+        }
+    } catch (error) {
+        console.warn('Failed to load message of the day', error);
+    }
+
+    return '';
+}
+
+// showLoginNotices tells the user about their previous login, any failed
+// attempts since, and the message of the day.
+async function showLoginNotices(history?: LoginHistory) {
+    if (history?.lastSuccessAt) {
+        const from = history.lastSuccessIp ? ` from ${history.lastSuccessIp}` : '';
+        toast.info(`Last login: ${convertDbTime(history.lastSuccessAt)}${from}`, {
+            position: 'bottom-center'
+        });
+    }
+
+    if (history && history.failedCount > 0 && history.lastFailureAt) {
+        const from = history.lastFailureIp ? ` from ${history.lastFailureIp}` : '';
+        toast.warning(
+            `${history.failedCount} failed login attempt(s) since your last login, most recently ${convertDbTime(history.lastFailureAt)}${from}`,
+            {
+                position: 'bottom-center',
+                duration: 15000
+            }
+        );
+    }
+
+    const motd = await getMOTD();
+    if (motd) {
+        toast.info('Message of the day', {
+            description: motd,
+            position: 'bottom-center',
+            duration: 15000
+        });
+    }
+}
+
 async function clearCachedAPIData() {
     try {
         await kvStorage.clear();
@@ -126,6 +178,7 @@ export async function login(
         if (response.status === 200 && responseData) {
             if (applySuccessfulLogin(responseData.data)) {
                 await clearCachedAPIData();
+                void showLoginNotices(responseData.data.loginHistory);
                 return true;
             } else {
                 toast.error('Invalid response received', {
@@ -193,6 +246,23 @@ export async function getLoginConfig(): Promise<{ pamEnabled: boolean }> {
     return { pamEnabled: true };
 }
 
+export async function getLoginBanner(): Promise<string> {
+    try {
+        const response = await fetch('/api/auth/login/banner', {
+            method: 'GET'
+        });
+
+        const responseData = await parseJSONResponse(response);
+        if (response.status === 200 && typeof responseData?.data?.banner === 'string') {
+            return responseData.data.banner;
+        }
+    } catch (error) {
+        console.warn('Failed to load login banner', error);
+    }
+
+    return '';
+}
+
 export async function loginWithPasskey(remember: boolean): Promise<boolean> {
     try {
         if (!isPasskeySupported()) {
@@ -248,6 +318,7 @@ export async function loginWithPasskey(remember: boolean): Promise<boolean> {
         const finishData = await parseJSONResponse(finishResponse);
         if (finishResponse.status === 200 && finishData?.data && applySuccessfulLogin(finishData.data)) {
             await clearCachedAPIData();
+            void showLoginNotices(finishData.data.loginHistory);
             return true;
         }
 
@@ -0,0 +1,14 @@
+import { LoginNoticesSchema, type LoginNotices } from '$lib/types/auth';
+import { type APIResponse } from '$lib/types/common';
+import { apiRequest } from '$lib/utils/http';
+
+export async function getLoginNotices(): Promise<LoginNotices | APIResponse> {
+	return await apiRequest('/auth/notices', LoginNoticesSchema, 'GET');
+}
+
+export async function updateLoginNotices(
+	banner: string,
+	motd: string
+): Promise<LoginNotices | APIResponse> {
+	return await apiRequest('/auth/notices', LoginNoticesSchema, 'PUT', { banner, motd });
+}
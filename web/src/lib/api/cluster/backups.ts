@@ -6,6 +6,7 @@ import {
     BackupJobSchema,
     BackupTargetDatasetInfoSchema,
     BackupTargetSchema,
+    BackupTargetOnboardingSchema,
     SnapshotInfoSchema,
     type BackupJailMetadataInfo,
     type BackupVMMetadataInfo,
@@ -14,7 +15,30 @@ import {
     type BackupJob,
     type BackupTargetDatasetInfo,
     type BackupTarget,
-    type SnapshotInfo
+    type BackupTargetOnboarding,
+    type SnapshotInfo,
+    BulkRestoreCandidateSchema,
+    BulkRestoreSchema,
+    type BulkRestoreCandidate,
+    type BulkRestore,
+    GuestExportSchema,
+    type GuestExport,
+    BackupConfigDocumentSchema,
+    BackupConfigImportResultSchema,
+    type BackupConfigDocument,
+    type BackupConfigImportResult,
+    PostRestoreScriptSchema,
+    type PostRestoreScript,
+    RestoreConfirmationTokenSchema,
+    type RestoreConfirmationToken,
+    RestorePolicySchema,
+    type RestorePolicy,
+    SSHProbeReportSchema,
+    type SSHProbeReport,
+    BackupTargetReattachResultSchema,
+    type BackupTargetReattachResult,
+    ZeltaProfileSchema,
+    type ZeltaProfile
 } from '$lib/types/cluster/backups';
 import { APIResponseSchema, type APIResponse } from '$lib/types/common';
 import { apiRequest, isAPIResponse } from '$lib/utils/http';
@@ -27,6 +51,9 @@ export type BackupTargetInput = {
     sshKey?: string;
     backupRoot: string;
     createBackupRoot: boolean;
+    sshCiphers?: string;
+    sshCompression?: boolean;
+    sshControlPersist?: number;
     description?: string;
     enabled: boolean;
 };
@@ -42,10 +69,24 @@ export type BackupJobInput = {
     pruneTarget: boolean;
     stopBeforeBackup: boolean;
     recursive: boolean;
+    shareQuiesce: '' | 'flush' | 'shadow_copy';
+    vmDatasetIncludes?: string;
+    vmDatasetExcludes?: string;
+    preBackupScript?: string;
+    postBackupScript?: string;
+    hookTimeoutSeconds?: number;
+    hookFailurePolicy?: 'fail' | 'ignore';
+    zeltaProfileId: number;
     cronExpr: string;
     enabled: boolean;
 };
 
+export type ZeltaProfileInput = {
+    name: string;
+    description?: string;
+    env: Record<string, string>;
+};
+
 export type RestoreFromTargetInput = {
     remoteDataset: string;
     snapshot: string;
@@ -54,6 +95,40 @@ export type RestoreFromTargetInput = {
     restoreNetwork?: boolean;
     encryptionKey?: string;
     encryptionKeyFormat?: 'passphrase';
+    postRestoreScript?: string;
+};
+
+// A job restore that overwrites an existing guest is confirmed with the
+// guest's name typed back or with a token from createRestoreConfirmation.
+export type RestoreConfirmationInput = {
+    confirmGuestName?: string;
+    confirmToken?: string;
+};
+
+export type PostRestoreScriptInput = {
+    guestType: 'jail' | 'vm';
+    guestId: number;
+    script: string;
+    interpreter?: string;
+    timeoutSeconds?: number;
+    enabled?: boolean;
+};
+
+export type BulkRestoreInput = {
+    targetId: number;
+    pool?: string;
+    concurrency?: number;
+    restoreNetwork?: boolean;
+    encryptionKey?: string;
+    dryRun?: boolean;
+    guests: { remoteDataset: string; snapshot?: string }[];
+};
+
+export type GuestExportInput = {
+    guestType: 'jail' | 'vm';
+    guestId: number;
+    format: 'zfs' | 'tar';
+    directory?: string;
 };
 
 export type BackupJobSnapshotsResult = {
@@ -76,6 +151,55 @@ export async function updateBackupTarget(
     return await apiRequest(`/cluster/backups/targets/${id}`, APIResponseSchema, 'PUT', input);
 }
 
+export async function probeBackupTargetSSH(
+    id: number,
+    sizeMiB = 0
+): Promise<{ report: SSHProbeReport | null; error: string }> {
+    const response = await apiRequest(
+        `/cluster/backups/targets/${id}/ssh-probe`,
+        SSHProbeReportSchema,
+        'POST',
+        { sizeMiB },
+        { raw: true }
+    );
+
+    if (!isAPIResponse(response)) {
+        return { report: null, error: 'Failed to probe the backup target' };
+    }
+
+    if (response.status === 'error') {
+        const error = Array.isArray(response.error) ? response.error.join(' ') : response.error;
+        return { report: null, error: error || response.message || 'Failed to probe the target' };
+    }
+
+    const report = SSHProbeReportSchema.safeParse(response.data);
+    if (!report.success) {
+        return { report: null, error: 'Invalid probe response from the backup target' };
+    }
+
+    return { report: report.data, error: '' };
+}
+
+export type BackupTargetReattachInput = {
+    dryRun: boolean;
+    jobIds?: number[];
+    cronExpr?: string;
+    pruneKeepLast?: number;
+    enabled?: boolean;
+};
+
+export async function reattachBackupTarget(
+    id: number,
+    input: BackupTargetReattachInput
+): Promise<BackupTargetReattachResult | APIResponse> {
+    return await apiRequest(
+        `/cluster/backups/targets/${id}/reattach`,
+        BackupTargetReattachResultSchema,
+        'POST',
+        input
+    );
+}
+
 export async function deleteBackupTarget(id: number): Promise<APIResponse> {
     return await apiRequest(`/cluster/backups/targets/${id}`, APIResponseSchema, 'DELETE');
 }
@@ -84,6 +208,44 @@ export async function validateBackupTarget(id: number): Promise<APIResponse> {
     return await apiRequest(`/cluster/backups/targets/validate/${id}`, APIResponseSchema, 'POST', {});
 }
 
+export async function startBackupTargetOnboarding(input: {
+    sshHost: string;
+    sshPort: number;
+    backupRoot: string;
+}): Promise<BackupTargetOnboarding> {
+    return await apiRequest(
+        '/cluster/backups/targets/onboarding',
+        BackupTargetOnboardingSchema,
+        'POST',
+        input
+    );
+}
+
+export async function testBackupTargetOnboarding(id: string): Promise<BackupTargetOnboarding> {
+    return await apiRequest(
+        `/cluster/backups/targets/onboarding/${id}/test`,
+        BackupTargetOnboardingSchema,
+        'POST',
+        {}
+    );
+}
+
+export async function finishBackupTargetOnboarding(
+    id: string,
+    input: { name: string; description?: string; bandwidthLimitKBps?: number; enabled?: boolean }
+): Promise<APIResponse> {
+    return await apiRequest(
+        `/cluster/backups/targets/onboarding/${id}/finish`,
+        APIResponseSchema,
+        'POST',
+        input
+    );
+}
+
+export async function cancelBackupTargetOnboarding(id: string): Promise<APIResponse> {
+    return await apiRequest(`/cluster/backups/targets/onboarding/${id}`, APIResponseSchema, 'DELETE');
+}
+
 export async function listBackupJobs(targetId?: number): Promise<BackupJob[]> {
     const params = new URLSearchParams();
     if (targetId && targetId > 0) {
@@ -117,8 +279,9 @@ export async function deleteBackupJob(id: number): Promise<APIResponse> {
     return await apiRequest(`/cluster/backups/jobs/${id}`, APIResponseSchema, 'DELETE');
 }
 
-export async function runBackupJob(id: number): Promise<APIResponse> {
-    return await apiRequest(`/cluster/backups/jobs/run/${id}`, APIResponseSchema, 'POST', {});
+export async function runBackupJob(id: number, dryRun: boolean = false): Promise<APIResponse> {
+    const query = dryRun ? '?dryRun=true' : '';
+    return await apiRequest(`/cluster/backups/jobs/run/${id}${query}`, APIResponseSchema, 'POST', {});
 }
 
 export async function getBackupEvents(
@@ -199,15 +362,89 @@ export async function listBackupJobSnapshots(jobId: number): Promise<BackupJobSn
 export async function restoreBackupJob(
     jobId: number,
     snapshot: string,
-    encryptionKey = ''
+    encryptionKey = '',
+    postRestoreScript = '',
+    confirmation: RestoreConfirmationInput = {}
 ): Promise<APIResponse> {
     return await apiRequest(`/cluster/backups/jobs/${jobId}/restore`, APIResponseSchema, 'POST', {
         snapshot,
         encryptionKey,
-        encryptionKeyFormat: 'passphrase'
+        encryptionKeyFormat: 'passphrase',
+        postRestoreScript,
+        ...confirmation
     });
 }
 
+export async function createRestoreConfirmation(
+    password: string
+): Promise<RestoreConfirmationToken | APIResponse> {
+    return await apiRequest(
+        '/cluster/backups/restore-confirmation',
+        RestoreConfirmationTokenSchema,
+        'POST',
+        { password }
+    );
+}
+
+export async function getRestorePolicy(): Promise<RestorePolicy | APIResponse> {
+    return await apiRequest('/cluster/backups/restore-policy', RestorePolicySchema, 'GET');
+}
+
+export async function updateRestorePolicy(input: RestorePolicy): Promise<APIResponse> {
+    return await apiRequest('/cluster/backups/restore-policy', APIResponseSchema, 'PUT', input);
+}
+
+export async function listPostRestoreScripts(): Promise<PostRestoreScript[]> {
+    return await apiRequest(
+        '/cluster/backups/post-restore-scripts',
+        z.array(PostRestoreScriptSchema),
+        'GET'
+    );
+}
+
+export async function savePostRestoreScript(
+    input: PostRestoreScriptInput
+): Promise<APIResponse> {
+    return await apiRequest(
+        '/cluster/backups/post-restore-scripts',
+        APIResponseSchema,
+        'PUT',
+        input
+    );
+}
+
+export async function deletePostRestoreScript(id: number): Promise<APIResponse> {
+    return await apiRequest(
+        `/cluster/backups/post-restore-scripts/${id}`,
+        APIResponseSchema,
+        'DELETE'
+    );
+}
+
+export async function listZeltaProfiles(): Promise<ZeltaProfile[]> {
+    return await apiRequest('/cluster/backups/zelta-profiles', z.array(ZeltaProfileSchema), 'GET');
+}
+
+export async function createZeltaProfile(input: ZeltaProfileInput): Promise<APIResponse> {
+    return await apiRequest('/cluster/backups/zelta-profiles', APIResponseSchema, 'POST', input);
+}
+
+export async function updateZeltaProfile(
+    id: number,
+    input: ZeltaProfileInput
+): Promise<APIResponse> {
+    return await apiRequest(
+        `/cluster/backups/zelta-profiles/${id}`,
+        APIResponseSchema,
+        'PUT',
+        input
+    );
+}
+
+export async function deleteZeltaProfile(id: number): Promise<APIResponse> {
+    return await apiRequest(`/cluster/backups/zelta-profiles/${id}`, APIResponseSchema, 'DELETE');
+}
+
 export async function listBackupTargetDatasets(
     targetId: number
 ): Promise<BackupTargetDatasetInfo[]> {
@@ -268,3 +505,104 @@ export async function restoreBackupFromTarget(
         input
     );
 }
+
+// Bulk restores run on the node that receives the guests, so every call is
+// sent to that node by hostname.
+export async function listBulkRestoreCandidates(
+    targetId: number,
+    hostname?: string
+): Promise<BulkRestoreCandidate[]> {
+    return await apiRequest(
+        `/cluster/backups/bulk-restore/candidates?targetId=${targetId}`,
+        z.array(BulkRestoreCandidateSchema),
+        'GET',
+        undefined,
+        { hostname }
+    );
+}
+
+export async function listBulkRestores(hostname?: string): Promise<BulkRestore[]> {
+    return await apiRequest(
+        '/cluster/backups/bulk-restore',
+        z.array(BulkRestoreSchema),
+        'GET',
+        undefined,
+        { hostname }
+    );
+}
+
+export async function startBulkRestore(
+    input: BulkRestoreInput,
+    hostname?: string
+): Promise<BulkRestore | APIResponse> {
+    return await apiRequest('/cluster/backups/bulk-restore', BulkRestoreSchema, 'POST', input, {
+        hostname
+    });
+}
+
+export async function cancelBulkRestore(id: number, hostname?: string): Promise<APIResponse> {
+    return await apiRequest(
+        `/cluster/backups/bulk-restore/${id}/cancel`,
+        APIResponseSchema,
+        'POST',
+        undefined,
+        { hostname }
+    );
+}
+
+export async function listGuestExports(hostname?: string): Promise<GuestExport[]> {
+    return await apiRequest(
+        '/cluster/backups/exports',
+        z.array(GuestExportSchema),
+        'GET',
+        undefined,
+        { hostname }
+    );
+}
+
+export async function startGuestExport(
+    input: GuestExportInput,
+    hostname?: string
+): Promise<GuestExport | APIResponse> {
+    return await apiRequest('/cluster/backups/exports', GuestExportSchema, 'POST', input, {
+        hostname
+    });
+}
+
+export async function deleteGuestExport(id: number, hostname?: string): Promise<APIResponse> {
+    return await apiRequest(
+        `/cluster/backups/exports/${id}`,
+        APIResponseSchema,
+        'DELETE',
+        undefined,
+        { hostname }
+    );
+}
+
+export async function exportBackupConfig(input: {
+    targetIds?: number[];
+    passphrase?: string;
+}): Promise<BackupConfigDocument> {
+    return await apiRequest(
+        '/cluster/backups/config/export',
+        BackupConfigDocumentSchema,
+        'POST',
+        input
+    );
+}
+
+export async function importBackupConfig(input: {
+    document: BackupConfigDocument;
+    passphrase?: string;
+    sshKeys?: Record<string, string>;
+    onConflict?: 'skip' | 'overwrite' | 'rename';
+    runnerNodeId?: string;
+    dryRun?: boolean;
+}): Promise<BackupConfigImportResult> {
+    return await apiRequest(
+        '/cluster/backups/config/import',
+        BackupConfigImportResultSchema,
+        'POST',
+        input
+    );
+}
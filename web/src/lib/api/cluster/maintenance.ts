@@ -0,0 +1,50 @@
+import {
+	MaintenanceSuppressionSchema,
+	MaintenanceWindowSchema,
+	type MaintenanceScope,
+	type MaintenanceSuppression,
+	type MaintenanceWindow
+} from '$lib/types/cluster/maintenance';
+import { APIResponseSchema, type APIResponse } from '$lib/types/common';
+import { apiRequest } from '$lib/utils/http';
+import { z } from 'zod/v4';
+
+export type MaintenanceWindowInput = {
+	scope: MaintenanceScope;
+	nodeId?: string;
+	guestType?: string;
+	guestId?: number;
+	policyId?: number;
+	reason: string;
+	startsAt?: string;
+	endsAt: string;
+};
+
+export async function listMaintenanceWindows(): Promise<MaintenanceWindow[] | APIResponse> {
+	return await apiRequest('/cluster/maintenance', z.array(MaintenanceWindowSchema), 'GET');
+}
+
+export async function createMaintenanceWindow(
+	input: MaintenanceWindowInput
+): Promise<APIResponse> {
+	return await apiRequest('/cluster/maintenance', APIResponseSchema, 'POST', input);
+}
+
+export async function endMaintenanceWindow(id: number): Promise<APIResponse> {
+	return await apiRequest(`/cluster/maintenance/${id}/end`, APIResponseSchema, 'POST');
+}
+
+export async function deleteMaintenanceWindow(id: number): Promise<APIResponse> {
+	return await apiRequest(`/cluster/maintenance/${id}`, APIResponseSchema, 'DELETE');
+}
+
+export async function listMaintenanceSuppressions(
+	windowId?: number
+): Promise<MaintenanceSuppression[] | APIResponse> {
+	const query = windowId ? `?windowId=${windowId}` : '';
+	return await apiRequest(
+		`/cluster/maintenance/suppressions${query}`,
+		z.array(MaintenanceSuppressionSchema),
+		'GET'
+	);
+}
@@ -2,11 +2,16 @@ import {
 	ReplicationEventProgressSchema,
 	ReplicationEventSchema,
 	ReplicationPolicySchema,
+	ReplicationPolicyTemplateSchema,
+	ReplicationPolicyTimelineSchema,
+	ReplicationTemplateEnrollmentSchema,
 	type ReplicationFailoverMode,
 	type ReplicationFailbackMode,
 	type ReplicationGuestType,
 	type ReplicationPolicy,
-	type ReplicationSourceMode
+	type ReplicationPolicyTemplate,
+	type ReplicationSourceMode,
+	type ReplicationTemplateEnrollment
 } from '$lib/types/cluster/replication';
 import { APIResponseSchema, type APIResponse } from '$lib/types/common';
 import { apiRequest } from '$lib/utils/http';
@@ -33,6 +38,21 @@ export type ReplicationPolicyInput = {
 	crashRestartMax?: number;
 	poolHealthCheck?: boolean;
 	poolCapacityPct?: number;
+	sourceBookmarks?: boolean;
+	zeltaProfileId?: number;
+};
+
+export type ReplicationPolicyTemplateInput = {
+	name: string;
+	description?: string;
+	guestType: '' | ReplicationGuestType;
+	tags: string[];
+	pools: string[];
+	targets: ReplicationPolicyTargetInput[];
+	cronExpr: string;
+	failbackMode: ReplicationFailbackMode;
+	failoverMode: ReplicationFailoverMode;
+	enabled: boolean;
 };
 
 export type ReplicationPolicyFailoverInput = {
@@ -61,10 +81,54 @@ export async function deleteReplicationPolicy(id: number): Promise<APIResponse>
 	return await apiRequest(`/cluster/replication/policies/${id}`, APIResponseSchema, 'DELETE');
 }
 
+export async function listReplicationPolicyTemplates(): Promise<ReplicationPolicyTemplate[]> {
+	return await apiRequest(
+		'/cluster/replication/templates',
+		z.array(ReplicationPolicyTemplateSchema),
+		'GET'
+	);
+}
+
+export async function createReplicationPolicyTemplate(
+	input: ReplicationPolicyTemplateInput
+): Promise<APIResponse> {
+	return await apiRequest('/cluster/replication/templates', APIResponseSchema, 'POST', input);
+}
+
+export async function updateReplicationPolicyTemplate(
+	id: number,
+	input: ReplicationPolicyTemplateInput
+): Promise<APIResponse> {
+	return await apiRequest(`/cluster/replication/templates/${id}`, APIResponseSchema, 'PUT', input);
+}
+
+export async function deleteReplicationPolicyTemplate(id: number): Promise<APIResponse> {
+	return await apiRequest(`/cluster/replication/templates/${id}`, APIResponseSchema, 'DELETE');
+}
+
+export async function enrollReplicationPolicyTemplates(): Promise<ReplicationTemplateEnrollment[]> {
+	return await apiRequest(
+		'/cluster/replication/templates/enroll',
+		z.array(ReplicationTemplateEnrollmentSchema),
+		'POST',
+		{}
+	);
+}
+
 export async function runReplicationPolicy(id: number): Promise<APIResponse> {
 	return await apiRequest(`/cluster/replication/policies/${id}/run`, APIResponseSchema, 'POST', {});
 }
 
+export async function pauseReplicationPolicy(id: number, reason: string): Promise<APIResponse> {
+	return await apiRequest(`/cluster/replication/policies/${id}/pause`, APIResponseSchema, 'POST', {
+		reason
+	});
+}
+
+export async function resumeReplicationPolicy(id: number): Promise<APIResponse> {
+	return await apiRequest(`/cluster/replication/policies/${id}/resume`, APIResponseSchema, 'POST', {});
+}
+
 export async function failoverReplicationPolicy(
 	id: number,
 	input: ReplicationPolicyFailoverInput
@@ -77,6 +141,18 @@ export async function failoverReplicationPolicy(
 	);
 }
 
+export async function switchoverReplicationPolicy(
+	id: number,
+	targetNodeId: string
+): Promise<APIResponse> {
+	return await apiRequest(
+		`/cluster/replication/policies/${id}/switchover`,
+		APIResponseSchema,
+		'POST',
+		{ targetNodeId }
+	);
+}
+
 export async function listReplicationEvents(
 	limit: number = 200,
 	policyId?: number,
@@ -97,6 +173,17 @@ export async function listReplicationEvents(
 	);
 }
 
+export async function getReplicationPolicyTimeline(
+	policyId: number,
+	limit: number = 200
+): Promise<z.infer<typeof ReplicationPolicyTimelineSchema>> {
+	return await apiRequest(
+		`/cluster/replication/policies/${policyId}/timeline?limit=${limit}`,
+		ReplicationPolicyTimelineSchema,
+		'GET'
+	);
+}
+
 export async function getReplicationEvent(
 	id: number
 ): Promise<z.infer<typeof ReplicationEventSchema>> {
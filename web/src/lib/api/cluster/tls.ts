@@ -0,0 +1,17 @@
+import { ClusterTLSStatusSchema, type ClusterTLSStatus } from '$lib/types/cluster/tls';
+import type { APIResponse } from '$lib/types/common';
+import { apiRequest } from '$lib/utils/http';
+
+export async function getClusterTLSStatus(): Promise<ClusterTLSStatus | APIResponse> {
+	return await apiRequest('/cluster/tls', ClusterTLSStatusSchema, 'GET');
+}
+
+export async function rotateClusterCA(): Promise<ClusterTLSStatus | APIResponse> {
+	return await apiRequest('/cluster/tls/ca/rotate', ClusterTLSStatusSchema, 'POST');
+}
+
+export async function setClusterTLSEnforce(
+	enforce: boolean
+): Promise<ClusterTLSStatus | APIResponse> {
+	return await apiRequest('/cluster/tls/enforce', ClusterTLSStatusSchema, 'PUT', { enforce });
+}
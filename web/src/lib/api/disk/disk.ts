@@ -10,10 +10,13 @@
 
 import { APIResponseSchema, type APIResponse } from '$lib/types/common';
 import {
+	DiskBenchmarkSchema,
 	DiskSchema,
 	SmartSelfTestDetailsSchema,
 	SmartSelfTestScheduleSchema,
 	type Disk,
+	type DiskBenchmark,
+	type DiskBenchmarkInput,
 	type SmartSelfTestDetails,
 	type SmartSelfTestSchedule,
 	type SmartSelfTestScheduleInput,
@@ -153,3 +156,15 @@ export async function createPartitions(disk: string, sizes: number[]): Promise<A
 		sizes
 	});
 }
+
+export async function listDiskBenchmarks(pool?: string): Promise<DiskBenchmark[]> {
+	const query = pool ? `?${new URLSearchParams({ pool }).toString()}` : '';
+	return await apiRequest(`/disk/benchmark${query}`, z.array(DiskBenchmarkSchema), 'GET');
+}
+
+export async function startDiskBenchmark(
+	input: DiskBenchmarkInput
+): Promise<DiskBenchmark | APIResponse> {
+	const response = await apiRequest('/disk/benchmark', DiskBenchmarkSchema, 'POST', input);
+	return normalizeDiskAPIResult(response, DiskBenchmarkSchema);
+}
@@ -1,4 +1,5 @@
 import { APIResponseSchema, type APIResponse } from '$lib/types/common';
+import { CPUSetTopologySchema, type CPUSetTopology } from '$lib/types/jail/jail';
 import { apiRequest } from '$lib/utils/http';
 
 export async function modifyRAM(ctId: number, bytes: number): Promise<APIResponse> {
@@ -8,9 +9,25 @@ export async function modifyRAM(ctId: number, bytes: number): Promise<APIRespons
 	});
 }
 
-export async function modifyCPU(ctId: number, cores: number): Promise<APIResponse> {
+export async function modifyCPU(
+	ctId: number,
+	cores: number,
+	cpuSet: number[] = []
+): Promise<APIResponse> {
 	return await apiRequest('/jail/cpu', APIResponseSchema, 'PUT', {
 		ctId: ctId,
-		cores: parseInt(cores.toString(), 10)
+		cores: parseInt(cores.toString(), 10),
+		cpuSet: cpuSet
 	});
 }
+
+export async function getCPUTopology(
+	ctId: number,
+	cores: number = 0
+): Promise<CPUSetTopology | APIResponse> {
+	return await apiRequest(
+		`/jail/cpu/topology?ctId=${ctId}&cores=${cores}`,
+		CPUSetTopologySchema,
+		'GET'
+	);
+}
@@ -6,6 +6,8 @@ import {
 } from '$lib/types/common';
 import {
 	JailLogsSchema,
+	JailProcessSchema,
+	JailResourceUsageSchema,
 	JailSchema,
 	JailStateSchema,
 	JailStatSchema,
@@ -15,6 +17,9 @@ import {
 	type ExecPhaseState,
 	type Jail,
 	type JailLogs,
+	type JailMount,
+	type JailProcess,
+	type JailResourceUsage,
 	type JailStat,
 	type JailState,
 	type SimpleJail,
@@ -38,6 +43,9 @@ export async function newJail(data: CreateData): Promise<APIResponse> {
 		bootstrapName: data.storage.bootstrapName,
 		fstab: data.storage.fstab,
 		resolvConf: data.network.resolvConf,
+		manageEtcFiles: data.network.manageEtcFiles,
+		dnsSearchDomain: data.network.dnsSearchDomain,
+		hostEntries: data.network.hostEntries,
 		switchName: data.network.switch,
 		dhcp: data.network.dhcp,
 		slaac: data.network.slaac,
@@ -205,6 +213,14 @@ export async function getStats(ctId: number, step: string): Promise<JailStat[]>
 	return await apiRequest(`/jail/stats/${ctId}/${step}`, z.array(JailStatSchema), 'GET');
 }
 
+export async function getJailProcesses(ctId: number): Promise<JailProcess[] | APIResponse> {
+	return await apiRequest(`/jail/${ctId}/processes`, z.array(JailProcessSchema), 'GET');
+}
+
+export async function getJailResourceUsage(ctId: number): Promise<JailResourceUsage | APIResponse> {
+	return await apiRequest(`/jail/${ctId}/usage`, JailResourceUsageSchema, 'GET');
+}
+
 export async function addNetwork(
 	ctId: number,
 	name: string,
@@ -333,12 +349,31 @@ export async function modifyFstab(ctId: number, fstab: string): Promise<APIRespo
 	});
 }
 
+export async function modifyMounts(ctId: number, mounts: JailMount[]): Promise<APIResponse> {
+	return await apiRequest(`/jail/options/mounts/${ctId}`, APIResponseSchema, 'PUT', {
+		mounts
+	});
+}
+
 export async function modifyResolvConf(ctId: number, resolvConf: string): Promise<APIResponse> {
 	return await apiRequest(`/jail/options/resolv-conf/${ctId}`, APIResponseSchema, 'PUT', {
 		resolvConf
 	});
 }
 
+export async function modifyEtcFiles(
+	ctId: number,
+	enabled: boolean,
+	dnsSearchDomain: string,
+	hostEntries: string
+): Promise<APIResponse> {
+	return await apiRequest(`/jail/options/etc-files/${ctId}`, APIResponseSchema, 'PUT', {
+		enabled,
+		dnsSearchDomain,
+		hostEntries
+	});
+}
+
 export async function modifyDevFSRules(ctId: number, devFSRules: string): Promise<APIResponse> {
 	return await apiRequest(`/jail/options/devfs-rules/${ctId}`, APIResponseSchema, 'PUT', {
 		devFSRules
@@ -1,5 +1,13 @@
 import { APIResponseSchema, type APIResponse } from '$lib/types/common';
-import { JailSnapshotSchema, type JailSnapshot } from '$lib/types/jail/snapshots';
+import {
+    JailSnapshotScheduleSchema,
+    JailSnapshotSchema,
+    PathVersionsSchema,
+    type JailSnapshot,
+    type JailSnapshotSchedule,
+    type PathVersions
+} from '$lib/types/jail/snapshots';
+import { ZFSDatasetDiffSchema, type ZFSDatasetDiff } from '$lib/types/zfs/diff';
 import { apiRequest } from '$lib/utils/http';
 import { z } from 'zod/v4';
 
@@ -30,3 +38,64 @@ export async function rollbackJailSnapshot(ctId: number, snapshotId: number): Pr
 export async function deleteJailSnapshot(ctId: number, snapshotId: number): Promise<APIResponse> {
     return await apiRequest(`/jail/snapshots/${ctId}/${snapshotId}`, APIResponseSchema, 'DELETE');
 }
+
+export async function diffJailSnapshot(
+    ctId: number,
+    snapshotId: number,
+    toSnapshotId?: number
+): Promise<ZFSDatasetDiff> {
+    const query = toSnapshotId ? `?to=${toSnapshotId}` : '';
+    return await apiRequest(
+        `/jail/snapshots/${ctId}/${snapshotId}/diff${query}`,
+        ZFSDatasetDiffSchema,
+        'GET'
+    );
+}
+
+export async function listJailPathVersions(ctId: number, path: string): Promise<PathVersions> {
+    return await apiRequest(
+        `/jail/snapshots/${ctId}/versions?path=${encodeURIComponent(path)}`,
+        PathVersionsSchema,
+        'GET'
+    );
+}
+
+export async function restoreJailPath(
+    ctId: number,
+    snapshot: string,
+    path: string,
+    asCopy: boolean
+): Promise<APIResponse> {
+    return await apiRequest(`/jail/snapshots/restore-path/${ctId}`, APIResponseSchema, 'POST', {
+        snapshot,
+        path,
+        asCopy
+    });
+}
+
+export async function getJailSnapshotSchedule(ctId: number): Promise<JailSnapshotSchedule | null> {
+    return await apiRequest(
+        `/jail/snapshots/schedule/${ctId}`,
+        JailSnapshotScheduleSchema.nullable(),
+        'GET'
+    );
+}
+
+export async function setJailSnapshotSchedule(
+    ctId: number,
+    cronExpr: string,
+    maxKeep: number,
+    namePattern: string,
+    enabled: boolean
+): Promise<APIResponse> {
+    return await apiRequest(`/jail/snapshots/schedule/${ctId}`, APIResponseSchema, 'PUT', {
+        cronExpr,
+        maxKeep,
+        namePattern,
+        enabled
+    });
+}
+
+export async function deleteJailSnapshotSchedule(ctId: number): Promise<APIResponse> {
+    return await apiRequest(`/jail/snapshots/schedule/${ctId}`, APIResponseSchema, 'DELETE');
+}
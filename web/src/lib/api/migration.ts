@@ -1,7 +1,9 @@
 import type { APIResponse } from '$lib/types/common';
 import {
+    CompatibilityReportSchema,
     ValidateResultSchema,
     MigrationTaskResponseSchema,
+    type CompatibilityReport,
     type ValidateResult,
     type MigrationTaskResponse
 } from '$lib/types/migration';
@@ -52,6 +54,25 @@ export async function validateMigration(
     );
 }
 
+export async function checkMigrationCompatibility(
+    rid: number,
+    targetNodeUuid: string,
+    hostname?: string
+): Promise<CompatibilityReport | APIResponse> {
+    const params = new URLSearchParams({
+        guestType: 'vm',
+        guestId: String(rid),
+        targetNodeUuid
+    });
+    return await apiRequest(
+        `/tasks/migration/compatibility?${params.toString()}`,
+        CompatibilityReportSchema,
+        'GET',
+        undefined,
+        { hostname }
+    );
+}
+
 export async function cancelMigration(
     taskId: number,
     hostname?: string
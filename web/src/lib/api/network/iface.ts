@@ -1,7 +1,26 @@
-import type { APIResponse } from '$lib/types/common';
-import { IfaceSchema, type Iface } from '$lib/types/network/iface';
+import { APIResponseSchema, type APIResponse } from '$lib/types/common';
+import {
+    HostInterfaceSchema,
+    IfaceSchema,
+    type HostInterface,
+    type Iface
+} from '$lib/types/network/iface';
 import { apiRequest } from '$lib/utils/http';
 
 export async function getInterfaces(): Promise<Iface[] | APIResponse> {
     return await apiRequest('/network/interface', IfaceSchema.array(), 'GET');
 }
+
+export async function getHostInterfaces(): Promise<HostInterface[] | APIResponse> {
+    return await apiRequest('/network/interface/config', HostInterfaceSchema.array(), 'GET');
+}
+
+export async function saveHostInterface(
+    payload: Partial<Omit<HostInterface, 'id' | 'createdAt' | 'updatedAt'>> & { name: string }
+): Promise<HostInterface | APIResponse> {
+    return await apiRequest('/network/interface/config', HostInterfaceSchema, 'PUT', payload);
+}
+
+export async function deleteHostInterface(id: number): Promise<APIResponse> {
+    return await apiRequest(`/network/interface/config/${id}`, APIResponseSchema, 'DELETE');
+}
@@ -0,0 +1,25 @@
+import { APIResponseSchema, type APIResponse } from '$lib/types/common';
+import { IsolationGroupSchema, type IsolationGroup } from '$lib/types/network/isolation';
+import { apiRequest } from '$lib/utils/http';
+import z from 'zod/v4';
+
+export async function getIsolationGroups(): Promise<IsolationGroup[] | APIResponse> {
+	return await apiRequest('/network/isolation-group', IsolationGroupSchema.array(), 'GET');
+}
+
+export async function createIsolationGroup(
+	payload: Partial<IsolationGroup>
+): Promise<number | APIResponse> {
+	return await apiRequest('/network/isolation-group', z.number(), 'POST', payload);
+}
+
+export async function updateIsolationGroup(
+	id: number,
+	payload: Partial<IsolationGroup>
+): Promise<APIResponse> {
+	return await apiRequest(`/network/isolation-group/${id}`, APIResponseSchema, 'PUT', payload);
+}
+
+export async function deleteIsolationGroup(id: number): Promise<APIResponse> {
+	return await apiRequest(`/network/isolation-group/${id}`, APIResponseSchema, 'DELETE');
+}
@@ -1,4 +1,9 @@
 import { APIResponseSchema, type APIResponse } from '$lib/types/common';
+import {
+    NetworkImportReportSchema,
+    type NetworkImportFormat,
+    type NetworkImportReport
+} from '$lib/types/network/import';
 import { NetworkObjectSchema, type NetworkObject } from '$lib/types/network/object';
 import { apiRequest } from '$lib/utils/http';
 import z from 'zod/v4';
@@ -38,3 +43,15 @@ export async function deleteNetworkObject(id: number): Promise<APIResponse> {
 export async function bulkDeleteNetworkObjects(ids: number[]): Promise<APIResponse> {
     return await apiRequest('/network/object/bulk-delete', APIResponseSchema, 'POST', { ids });
 }
+
+export async function importNetworkObjects(
+    format: NetworkImportFormat,
+    data: string,
+    dryRun: boolean
+): Promise<NetworkImportReport | APIResponse> {
+    return await apiRequest('/network/object/import', NetworkImportReportSchema, 'POST', {
+        format,
+        data,
+        dryRun
+    });
+}
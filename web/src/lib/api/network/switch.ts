@@ -1,5 +1,15 @@
 import { APIResponseSchema, type APIResponse } from '$lib/types/common';
-import { SwitchListSchema, type SwitchList } from '$lib/types/network/switch';
+import {
+	SwitchListSchema,
+	SwitchMTUReportSchema,
+	type SwitchList,
+	type SwitchMTUReport
+} from '$lib/types/network/switch';
+import {
+	NetworkImportReportSchema,
+	type NetworkImportFormat,
+	type NetworkImportReport
+} from '$lib/types/network/import';
 import { apiRequest } from '$lib/utils/http';
 
 export async function getSwitches(hostname?: string): Promise<SwitchList> {
@@ -114,3 +124,25 @@ export async function updateSwitch(
 
 	return await apiRequest('/network/switch/standard', APIResponseSchema, 'PUT', body);
 }
+
+export async function getSwitchMTUReport(id: number): Promise<SwitchMTUReport> {
+	return await apiRequest(`/network/switch/standard/${id}/mtu`, SwitchMTUReportSchema, 'GET');
+}
+
+export async function propagateSwitchMTU(id: number, mtu: number = 9000): Promise<SwitchMTUReport> {
+	return await apiRequest(`/network/switch/standard/${id}/mtu`, SwitchMTUReportSchema, 'POST', {
+		mtu
+	});
+}
+
+export async function importStandardSwitches(
+	format: NetworkImportFormat,
+	data: string,
+	dryRun: boolean
+): Promise<NetworkImportReport | APIResponse> {
+	return await apiRequest('/network/switch/standard/import', NetworkImportReportSchema, 'POST', {
+		format,
+		data,
+		dryRun
+	});
+}
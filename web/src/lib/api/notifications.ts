@@ -1,11 +1,16 @@
 import { APIResponseSchema, type APIResponse } from '$lib/types/common';
 import {
+	GuestAlertRuleSchema,
+	GuestAlertSchema,
 	NotificationConfigSchema,
 	NotificationRulesConfigSchema,
 	NotificationsCountSchema,
 	NotificationsDismissAllSchema,
 	NotificationsListSchema,
 	type BulkUpdateRulesInput,
+	type GuestAlert,
+	type GuestAlertRule,
+	type GuestAlertRuleInput,
 	type NotificationConfig,
 	type NotificationRulesConfig,
 	type NotificationsCount,
@@ -105,3 +110,28 @@ export async function testNotificationRule(payload: {
 }): Promise<APIResponse> {
 	return await apiRequest('/notifications/rules/test', APIResponseSchema, 'POST', payload);
 }
+
+export async function listGuestAlertRules(): Promise<GuestAlertRule[]> {
+	return await apiRequest('/notifications/guest-alerts/rules', GuestAlertRuleSchema.array(), 'GET');
+}
+
+export async function createGuestAlertRule(
+	payload: GuestAlertRuleInput
+): Promise<GuestAlertRule | APIResponse> {
+	return await apiRequest('/notifications/guest-alerts/rules', GuestAlertRuleSchema, 'POST', payload);
+}
+
+export async function updateGuestAlertRule(
+	id: number,
+	payload: GuestAlertRuleInput
+): Promise<GuestAlertRule | APIResponse> {
+	return await apiRequest(`/notifications/guest-alerts/rules/${id}`, GuestAlertRuleSchema, 'PUT', payload);
+}
+
+export async function deleteGuestAlertRule(id: number): Promise<APIResponse> {
+	return await apiRequest(`/notifications/guest-alerts/rules/${id}`, APIResponseSchema, 'DELETE');
+}
+
+export async function listActiveGuestAlerts(): Promise<GuestAlert[]> {
+	return await apiRequest('/notifications/guest-alerts/active', GuestAlertSchema.array(), 'GET');
+}
@@ -1,5 +1,10 @@
 import { APIResponseSchema, type APIResponse } from '$lib/types/common';
-import { SambaShareSchema, type SambaShare } from '$lib/types/samba/shares';
+import {
+    SambaShareQuotaSchema,
+    SambaShareSchema,
+    type SambaShare,
+    type SambaShareQuota
+} from '$lib/types/samba/shares';
 import { apiRequest } from '$lib/utils/http';
 import { z } from 'zod/v4';
 
@@ -76,3 +81,34 @@ export async function updateSambaShare(
 export async function deleteSambaShare(id: number): Promise<APIResponse> {
     return await apiRequest(`/samba/shares/${id}`, APIResponseSchema, 'DELETE');
 }
+
+export async function getSambaShareQuotas(id: number): Promise<SambaShareQuota[]> {
+    return await apiRequest(`/samba/shares/${id}/quotas`, z.array(SambaShareQuotaSchema), 'GET');
+}
+
+export async function setSambaShareQuota(
+    id: number,
+    type: SambaShareQuota['type'],
+    name: string,
+    quotaBytes: number,
+    quotaObjects?: number
+): Promise<APIResponse> {
+    return await apiRequest(`/samba/shares/${id}/quotas`, APIResponseSchema, 'PUT', {
+        type,
+        name,
+        quotaBytes,
+        quotaObjects
+    });
+}
+
+export async function removeSambaShareQuota(
+    id: number,
+    type: SambaShareQuota['type'],
+    name: string
+): Promise<APIResponse> {
+    return await apiRequest(
+        `/samba/shares/${id}/quotas/${type}/${encodeURIComponent(name)}`,
+        APIResponseSchema,
+        'DELETE'
+    );
+}
@@ -0,0 +1,16 @@
+import type { APIResponse } from '$lib/types/common';
+import { SearchResponseSchema, type SearchResponse } from '$lib/types/search';
+import { apiRequest } from '$lib/utils/http';
+
+export async function search(
+    query: string,
+    scope: 'cluster' | 'local' = 'cluster',
+    limit?: number
+): Promise<SearchResponse | APIResponse> {
+    const params = new URLSearchParams({ q: query, scope });
+    if (limit !== undefined) {
+        params.set('limit', String(limit));
+    }
+
+    return await apiRequest(`/search?${params.toString()}`, SearchResponseSchema, 'GET');
+}
@@ -2,8 +2,10 @@ import { APIResponseSchema, type APIResponse } from '$lib/types/common';
 import {
 	PCIDeviceSchema,
 	PPTDeviceSchema,
+	USBDeviceSchema,
 	type PCIDevice,
-	type PPTDevice
+	type PPTDevice,
+	type USBDevice
 } from '$lib/types/system/pci';
 import { apiRequest } from '$lib/utils/http';
 
@@ -11,6 +13,10 @@ export async function getPCIDevices(hostname?: string): Promise<PCIDevice[]> {
 	return await apiRequest('/system/pci-devices', PCIDeviceSchema.array(), 'GET', undefined, { hostname });
 }
 
+export async function getUSBDevices(hostname?: string): Promise<USBDevice[]> {
+	return await apiRequest('/system/usb-devices', USBDeviceSchema.array(), 'GET', undefined, { hostname });
+}
+
 export async function getPPTDevices(hostname?: string): Promise<PPTDevice[]> {
 	return await apiRequest('/system/ppt-devices', PPTDeviceSchema.array(), 'GET', undefined, { hostname });
 }
@@ -36,3 +42,7 @@ export async function importPPTDevice(domain: string, deviceID: string): Promise
 export async function removePPTDevice(deviceID: string): Promise<APIResponse> {
 	return await apiRequest(`/system/ppt-devices/${deviceID}`, APIResponseSchema, 'DELETE');
 }
+
+export async function reclaimPPTDevice(deviceID: string): Promise<APIResponse> {
+	return await apiRequest(`/system/ppt-devices/${deviceID}/reclaim`, APIResponseSchema, 'POST');
+}
@@ -45,3 +45,51 @@ export async function probeBasicHealth(): Promise<BasicHealth | null> {
 		return null;
 	}
 }
+
+export const SelfTestReportSchema = z.object({
+	pool: z.string(),
+	dataset: z.string(),
+	passed: z.boolean(),
+	startedAt: z.string(),
+	finishedAt: z.string(),
+	results: z.array(
+		z.object({
+			subsystem: z.string(),
+			status: z.enum(['pass', 'fail', 'skip']),
+			detail: z.string().optional(),
+			durationMs: z.number()
+		})
+	)
+});
+
+export type SelfTestReport = z.infer<typeof SelfTestReportSchema>;
+
+export async function runSelfTest(pool?: string): Promise<SelfTestReport | APIResponse> {
+	return await apiRequest('/system/selftest', SelfTestReportSchema, 'POST', { pool: pool ?? '' });
+}
+
+const ZFSTraceEntrySchema = z.object({
+	command: z.string(),
+	args: z.array(z.string()),
+	startedAt: z.string(),
+	durationMs: z.number(),
+	ok: z.boolean(),
+	exitCode: z.number(),
+	error: z.string().optional(),
+	slow: z.boolean()
+});
+
+export const ZFSTraceSchema = z.object({
+	slowThresholdMs: z.number(),
+	total: z.number(),
+	slowTotal: z.number(),
+	recent: z.array(ZFSTraceEntrySchema),
+	slow: z.array(ZFSTraceEntrySchema)
+});
+
+export type ZFSTrace = z.infer<typeof ZFSTraceSchema>;
+
+export async function getZFSTrace(limit?: number): Promise<ZFSTrace | APIResponse> {
+	const query = limit ? `?limit=${limit}` : '';
+	return await apiRequest(`/system/zfs-trace${query}`, ZFSTraceSchema, 'GET');
+}
@@ -0,0 +1,37 @@
+import { APIResponseSchema, type APIResponse } from '$lib/types/common';
+import {
+    type Application,
+    type ApplicationInput,
+    ApplicationSchema
+} from '$lib/types/task/applications';
+import { apiRequest } from '$lib/utils/http';
+import { z } from 'zod/v4';
+
+export async function getApplications(): Promise<Application[] | APIResponse> {
+    const result = await apiRequest('/applications', z.array(ApplicationSchema), 'GET');
+    return result ?? [];
+}
+
+export async function getApplication(id: number): Promise<Application | APIResponse> {
+    return await apiRequest(`/applications/${id}`, ApplicationSchema, 'GET');
+}
+
+export async function createApplication(input: ApplicationInput): Promise<APIResponse> {
+    return await apiRequest('/applications', APIResponseSchema, 'POST', input);
+}
+
+export async function updateApplication(id: number, input: ApplicationInput): Promise<APIResponse> {
+    return await apiRequest(`/applications/${id}`, APIResponseSchema, 'PUT', input);
+}
+
+export async function deleteApplication(id: number): Promise<APIResponse> {
+    return await apiRequest(`/applications/${id}`, APIResponseSchema, 'DELETE');
+}
+
+export async function startApplication(id: number): Promise<APIResponse> {
+    return await apiRequest(`/applications/${id}/start`, APIResponseSchema, 'POST', {});
+}
+
+export async function stopApplication(id: number): Promise<APIResponse> {
+    return await apiRequest(`/applications/${id}/stop`, APIResponseSchema, 'POST', {});
+}
@@ -1,5 +1,7 @@
 import type { APIResponse } from '$lib/types/common';
 import {
+    type HeavyOpsPool,
+    HeavyOpsPoolSchema,
     type LifecycleTask,
     isLifecycleTaskActive,
     LifecycleTaskSchema
@@ -79,3 +81,11 @@ export async function getRecentLifecycleTasks(
 
     return result ?? [];
 }
+
+export async function getHeavyOps(hostname?: string): Promise<HeavyOpsPool[] | APIResponse> {
+    const result = await apiRequest('/tasks/lifecycle/heavy-ops', z.array(HeavyOpsPoolSchema), 'GET', undefined, {
+        hostname
+    });
+
+    return result ?? [];
+}
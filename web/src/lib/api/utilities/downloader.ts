@@ -5,6 +5,7 @@ import {
 	UTypeGroupedDownloadSchema,
 	type DownloadPaths,
 	type Download,
+	type DownloadVerification,
 	type UTypeGroupedDownload
 } from '$lib/types/utilities/downloader';
 import { apiRequest } from '$lib/utils/http';
@@ -27,7 +28,8 @@ export async function startDownload(
 	filename?: string,
 	ignoreTLS?: boolean,
 	automaticExtraction?: boolean,
-	automaticRawConversion?: boolean
+	automaticRawConversion?: boolean,
+	verification?: DownloadVerification
 ): Promise<APIResponse> {
 	return await apiRequest('/utilities/downloads', APIResponseSchema, 'POST', {
 		url,
@@ -35,10 +37,23 @@ export async function startDownload(
 		ignoreTLS,
 		automaticExtraction,
 		automaticRawConversion,
-		downloadType
+		downloadType,
+		verification
 	});
 }
 
+export async function verifyDownload(
+	id: number,
+	verification: DownloadVerification
+): Promise<APIResponse> {
+	return await apiRequest(
+		`/utilities/downloads/${id}/verify`,
+		APIResponseSchema,
+		'POST',
+		verification
+	);
+}
+
 export async function updateDownload(
 	id: number,
 	data: {
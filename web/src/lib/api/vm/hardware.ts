@@ -1,5 +1,10 @@
 import { APIResponseSchema, type APIResponse } from '$lib/types/common';
-import type { CPUPin, VM } from '$lib/types/vm/vm';
+import {
+	CPUCapabilitiesSchema,
+	type CPUCapabilities,
+	type CPUPin,
+	type VM
+} from '$lib/types/vm/vm';
 import { apiRequest } from '$lib/utils/http';
 
 export async function modifyCPU(
@@ -47,3 +52,14 @@ export async function modifyPPT(rid: number, pciDevices: number[]): Promise<APIR
 		pciDevices
 	});
 }
+
+export async function getCPUCapabilities(vcpus?: number): Promise<CPUCapabilities> {
+	const query = vcpus ? `?vcpus=${vcpus}` : '';
+	return await apiRequest(`/vm/hardware/cpu/capabilities${query}`, CPUCapabilitiesSchema, 'GET');
+}
+
+export async function modifyCPUFeatures(rid: number, cpuFeatures: string[]): Promise<APIResponse> {
+	return await apiRequest(`/vm/hardware/cpu-features/${rid}`, APIResponseSchema, 'PUT', {
+		cpuFeatures
+	});
+}
@@ -1,5 +1,11 @@
 import { APIResponseSchema, type APIResponse } from '$lib/types/common';
-import { VMSnapshotSchema, type VMSnapshot } from '$lib/types/vm/snapshots';
+import {
+    VMSnapshotScheduleSchema,
+    VMSnapshotSchema,
+    type VMSnapshot,
+    type VMSnapshotSchedule
+} from '$lib/types/vm/snapshots';
+import { ZFSDatasetDiffSchema, type ZFSDatasetDiff } from '$lib/types/zfs/diff';
 import { apiRequest } from '$lib/utils/http';
 import { z } from 'zod/v4';
 
@@ -30,3 +36,43 @@ export async function rollbackVMSnapshot(rid: number, snapshotId: number): Promi
 export async function deleteVMSnapshot(rid: number, snapshotId: number): Promise<APIResponse> {
     return await apiRequest(`/vm/snapshots/${rid}/${snapshotId}`, APIResponseSchema, 'DELETE');
 }
+
+export async function diffVMSnapshot(
+    rid: number,
+    snapshotId: number,
+    toSnapshotId?: number
+): Promise<ZFSDatasetDiff[]> {
+    const query = toSnapshotId ? `?to=${toSnapshotId}` : '';
+    return await apiRequest(
+        `/vm/snapshots/${rid}/${snapshotId}/diff${query}`,
+        z.array(ZFSDatasetDiffSchema),
+        'GET'
+    );
+}
+
+export async function getVMSnapshotSchedule(rid: number): Promise<VMSnapshotSchedule | null> {
+    return await apiRequest(
+        `/vm/snapshots/schedule/${rid}`,
+        VMSnapshotScheduleSchema.nullable(),
+        'GET'
+    );
+}
+
+export async function setVMSnapshotSchedule(
+    rid: number,
+    cronExpr: string,
+    maxKeep: number,
+    namePattern: string,
+    enabled: boolean
+): Promise<APIResponse> {
+    return await apiRequest(`/vm/snapshots/schedule/${rid}`, APIResponseSchema, 'PUT', {
+        cronExpr,
+        maxKeep,
+        namePattern,
+        enabled
+    });
+}
+
+export async function deleteVMSnapshotSchedule(rid: number): Promise<APIResponse> {
+    return await apiRequest(`/vm/snapshots/schedule/${rid}`, APIResponseSchema, 'DELETE');
+}
@@ -12,8 +12,11 @@ import {
 	VMLogsSchema,
 	VMSchema,
 	VMTemplateSchema,
+	VMPresetInfoSchema,
 	VMStatSchema,
 	type CreateData,
+	type VMPreset,
+	type VMPresetInfo,
 	type QGAInfo,
 	type SimpleVm,
 	type SimpleVmTemplate,
@@ -114,10 +117,19 @@ export async function newVM(data: CreateData): Promise<APIResponse> {
 		cloudInitNetworkConfig: data.advanced.cloudInit.networkConfig,
 		extraBhyveOptions: toExtraBhyveOptions(data.advanced.extraBhyveOptions),
 		ignoreUMSR: data.advanced.ignoreUmsrs,
-		qemuGuestAgent: data.advanced.qemuGuestAgent
+		qemuGuestAgent: data.advanced.qemuGuestAgent,
+		preset: data.advanced.preset ?? ''
 	});
 }
 
+export async function getVMPreset(name: VMPreset): Promise<VMPresetInfo> {
+	return await apiRequest(`/vm/presets/${name}`, VMPresetInfoSchema, 'GET');
+}
+
+export async function fetchWindowsDriverMedia(): Promise<VMPresetInfo> {
+	return await apiRequest('/vm/presets/windows/driver-media', VMPresetInfoSchema, 'POST');
+}
+
 export async function deleteVM(
 	rid: number,
 	deleteMacs: boolean,
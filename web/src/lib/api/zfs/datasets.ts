@@ -135,6 +135,20 @@ export async function rollbackSnapshot(guid: string): Promise<APIResponse> {
 	});
 }
 
+export async function renameSnapshot(guid: string, newName: string): Promise<APIResponse> {
+	return await apiRequest(`/zfs/datasets/snapshot/rename`, APIResponseSchema, 'POST', {
+		guid: guid,
+		newName: newName
+	});
+}
+
+export async function renameDataset(guid: string, newName: string): Promise<APIResponse> {
+	return await apiRequest(`/zfs/datasets/rename`, APIResponseSchema, 'POST', {
+		guid: guid,
+		newName: newName
+	});
+}
+
 export async function createVolume(
 	name: string,
 	parent: string,
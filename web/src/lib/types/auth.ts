@@ -89,7 +89,24 @@ export const BasicSettingsSchema = z.object({
     initialized: z.boolean()
 });
 
+export const LoginNoticesSchema = z.object({
+    id: z.number().int(),
+    banner: z.string(),
+    motd: z.string(),
+    updatedAt: z.string()
+});
+
+export const LoginHistorySchema = z.object({
+    lastSuccessAt: z.string().nullable(),
+    lastSuccessIp: z.string(),
+    lastFailureAt: z.string().nullable(),
+    lastFailureIp: z.string(),
+    failedCount: z.number().int()
+});
+
 export type JWTClaims = z.infer<typeof JWTClaimsSchema>;
 export type User = z.infer<typeof UserSchema>;
 export type Group = z.infer<typeof GroupSchema>;
 export type Passkey = z.infer<typeof PasskeySchema>;
+export type LoginNotices = z.infer<typeof LoginNoticesSchema>;
+export type LoginHistory = z.infer<typeof LoginHistorySchema>;
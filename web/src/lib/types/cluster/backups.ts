@@ -8,12 +8,57 @@ export const BackupTargetSchema = z.object({
 	sshKeyPath: z.string().optional().default(''),
 	backupRoot: z.string(),
 	createBackupRoot: z.boolean().default(false),
+	sshCiphers: z.string().optional().default(''),
+	sshCompression: z.boolean().default(false),
+	sshControlPersist: z.number().int().default(0),
 	description: z.string().optional().default(''),
 	enabled: z.boolean().default(true),
 	createdAt: z.string().optional(),
 	updatedAt: z.string().optional()
 });
 
+export const SSHProbeCandidateSchema = z.object({
+	ciphers: z.string(),
+	compression: z.boolean()
+});
+
+export const SSHProbeMeasurementSchema = SSHProbeCandidateSchema.extend({
+	bytes: z.number(),
+	durationMs: z.number(),
+	mibps: z.number(),
+	error: z.string().optional().default('')
+});
+
+export const SSHProbeReportSchema = z.object({
+	targetId: z.number(),
+	current: SSHProbeCandidateSchema,
+	measurements: z.array(SSHProbeMeasurementSchema),
+	recommended: SSHProbeMeasurementSchema.nullable().optional()
+});
+
+export const BackupTargetOnboardingStepSchema = z.object({
+	name: z.enum(['auth', 'zfs', 'pool', 'backup_root']),
+	status: z.enum(['passed', 'failed', 'skipped']),
+	detail: z.string().optional().default('')
+});
+
+export const BackupTargetOnboardingSchema = z.object({
+	id: z.string(),
+	sshHost: z.string(),
+	sshPort: z.number(),
+	backupRoot: z.string(),
+	publicKey: z.string(),
+	setup: z.object({
+		sshUser: z.string(),
+		authorizedKeysFile: z.string(),
+		authorizedKeysLine: z.string(),
+		commands: z.array(z.string())
+	}),
+	steps: z.array(BackupTargetOnboardingStepSchema),
+	verified: z.boolean(),
+	expiresAt: z.string()
+});
+
 export const BackupJobSchema = z.object({
 	id: z.number(),
 	name: z.string(),
@@ -29,7 +74,15 @@ export const BackupJobSchema = z.object({
 	pruneTarget: z.boolean().default(false),
 	stopBeforeBackup: z.boolean().default(false),
 	recursive: z.boolean().default(false),
+	shareQuiesce: z.enum(['', 'flush', 'shadow_copy']).catch(''),
+	vmDatasetIncludes: z.string().optional().default(''),
+	vmDatasetExcludes: z.string().optional().default(''),
+	preBackupScript: z.string().optional().default(''),
+	postBackupScript: z.string().optional().default(''),
+	hookTimeoutSeconds: z.number().int().nonnegative().default(0),
+	hookFailurePolicy: z.enum(['fail', 'ignore']).catch('fail'),
 	encrypted: z.boolean().default(false),
+	zeltaProfileId: z.number().int().nonnegative().default(0),
 	cronExpr: z.string(),
 	enabled: z.boolean().default(true),
 	lastRunAt: z.string().nullable().optional(),
@@ -49,17 +102,37 @@ export const BackupEventSchema = z.object({
 	status: z.string().optional().default(''),
 	error: z.string().optional().default(''),
 	output: z.string().optional().default(''),
+	quiesceStatus: z.string().optional().default(''),
+	quiesceDetail: z.string().optional().default(''),
 	startedAt: z.string(),
 	completedAt: z.string().nullable().optional()
 });
 
+export const BackupEventDatasetSchema = z.object({
+	id: z.number(),
+	eventId: z.number(),
+	position: z.number(),
+	sourceDataset: z.string().optional().default(''),
+	targetDataset: z.string().optional().default(''),
+	status: z.string().optional().default(''),
+	movedBytes: z.number().nullable().optional(),
+	totalBytes: z.number().nullable().optional(),
+	error: z.string().optional().default(''),
+	startedAt: z.string().nullable().optional(),
+	completedAt: z.string().nullable().optional(),
+	progressPercent: z.number().nullable().optional()
+});
+
 export const BackupEventProgressSchema = z.object({
 	event: BackupEventSchema,
 	progressDataset: z.string().optional().default(''),
 	phase: z.string().optional().default(''),
 	movedBytes: z.number().nullable().optional(),
 	totalBytes: z.number().nullable().optional(),
-	progressPercent: z.number().nullable().optional()
+	progressPercent: z.number().nullable().optional(),
+	datasets: z.array(BackupEventDatasetSchema).nullable().optional().default([]),
+	currentDataset: z.number().optional().default(0),
+	datasetCount: z.number().optional().default(0)
 });
 
 export const SnapshotInfoSchema = z.object({
@@ -102,14 +175,197 @@ export const BackupVMMetadataInfoSchema = z.object({
 	pools: z.array(z.string()).default([])
 });
 
+export const BulkRestoreCandidateSchema = z.object({
+	kind: z.enum(['jail', 'vm']),
+	guestId: z.number().int().nonnegative(),
+	remoteDataset: z.string(),
+	sourcePool: z.string().default(''),
+	snapshotCount: z.number().int().nonnegative().default(0),
+	encrypted: z.boolean().default(false),
+	used: z.number().nonnegative().default(0)
+});
+
+export const BulkRestoreItemSchema = z.object({
+	id: z.number().int().nonnegative(),
+	position: z.number().int().nonnegative(),
+	kind: z.enum(['jail', 'vm']),
+	guestId: z.number().int().nonnegative(),
+	remoteDataset: z.string(),
+	snapshot: z.string().default(''),
+	destinationDataset: z.string(),
+	status: z.enum(['pending', 'running', 'completed', 'failed', 'skipped']),
+	error: z.string().default(''),
+	startedAt: z.string().nullable().optional(),
+	completedAt: z.string().nullable().optional()
+});
+
+export const BulkRestoreSchema = z.object({
+	id: z.number().int().nonnegative(),
+	targetId: z.number().int().nonnegative(),
+	targetName: z.string().default(''),
+	pool: z.string().default(''),
+	concurrency: z.number().int().nonnegative(),
+	restoreNetwork: z.boolean(),
+	status: z.enum(['planned', 'running', 'completed', 'partial', 'failed', 'cancelled', 'interrupted']),
+	total: z.number().int().nonnegative(),
+	completed: z.number().int().nonnegative(),
+	failed: z.number().int().nonnegative(),
+	skipped: z.number().int().nonnegative(),
+	report: z.string().default(''),
+	items: z.array(BulkRestoreItemSchema).default([]),
+	startedAt: z.string(),
+	completedAt: z.string().nullable().optional()
+});
+
+export const GuestExportSchema = z.object({
+	id: z.number().int().nonnegative(),
+	guestType: z.enum(['jail', 'vm']),
+	guestId: z.number().int().nonnegative(),
+	guestName: z.string().default(''),
+	format: z.enum(['zfs', 'tar']),
+	path: z.string(),
+	bytes: z.number().nonnegative().default(0),
+	status: z.enum(['running', 'ready', 'failed']),
+	error: z.string().default(''),
+	createdAt: z.string(),
+	completedAt: z.string().nullable().optional()
+});
+
+export const BackupConfigDocumentSchema = z.object({
+	version: z.number().int(),
+	exportedAt: z.string(),
+	keyWrap: z.object({ kdf: z.string(), salt: z.string() }).optional(),
+	targets: z.array(
+		z.object({
+			name: z.string(),
+			sshHost: z.string(),
+			sshPort: z.number().int(),
+			wrappedSshKey: z.string().optional(),
+			backupRoot: z.string(),
+			createBackupRoot: z.boolean(),
+			bandwidthLimitKBps: z.number().int().nonnegative(),
+			description: z.string(),
+			enabled: z.boolean()
+		})
+	),
+	jobs: z.array(
+		z.object({
+			name: z.string(),
+			target: z.string(),
+			mode: z.string(),
+			sourceDataset: z.string(),
+			jailRootDataset: z.string(),
+			pruneKeepLast: z.number().int(),
+			pruneTarget: z.boolean(),
+			stopBeforeBackup: z.boolean(),
+			recursive: z.boolean(),
+			shareQuiesce: z.enum(['', 'flush', 'shadow_copy']).optional(),
+			vmDatasetIncludes: z.string().optional(),
+			vmDatasetExcludes: z.string().optional(),
+			preBackupScript: z.string().optional(),
+			postBackupScript: z.string().optional(),
+			hookTimeoutSeconds: z.number().int().optional(),
+			hookFailurePolicy: z.enum(['fail', 'ignore']).optional(),
+			cronExpr: z.string(),
+			enabled: z.boolean()
+		})
+	)
+});
+
+export const BackupConfigImportResultSchema = z.object({
+	dryRun: z.boolean(),
+	items: z.array(
+		z.object({
+			kind: z.enum(['target', 'job']),
+			name: z.string(),
+			importedAs: z.string().optional(),
+			action: z.enum(['create', 'update', 'skip', 'rejected']),
+			error: z.string().optional()
+		})
+	),
+	failed: z.number().int().nonnegative()
+});
+
+export const PostRestoreScriptSchema = z.object({
+	id: z.number().int(),
+	guestType: z.enum(['jail', 'vm']),
+	guestId: z.number().int(),
+	script: z.string(),
+	interpreter: z.string(),
+	timeoutSeconds: z.number().int(),
+	enabled: z.boolean(),
+	createdAt: z.string(),
+	updatedAt: z.string()
+});
+
+export const RestoreOverwriteGuestSchema = z.object({
+	guestType: z.enum(['jail', 'vm']),
+	guestId: z.number().int(),
+	guestName: z.string()
+});
+
+export const RestoreConfirmationTokenSchema = z.object({
+	token: z.string(),
+	expiresIn: z.number().int()
+});
+
+export const RestorePolicySchema = z.object({
+	restoreOverwriteGroup: z.string()
+});
+
+export const ZeltaProfileSchema = z.object({
+	id: z.number().int(),
+	name: z.string(),
+	description: z.string().optional().default(''),
+	env: z.record(z.string(), z.string()).nullable().transform((env) => env ?? {}),
+	createdAt: z.string(),
+	updatedAt: z.string()
+});
+
+export const BackupTargetReattachItemSchema = z.object({
+	jobId: z.number().int(),
+	remoteDataset: z.string(),
+	mode: z.enum(['dataset', 'jail', 'vm']),
+	guestId: z.number().int().optional().default(0),
+	sourceDataset: z.string().optional().default(''),
+	snapshotCount: z.number().int(),
+	latestSnapshot: z.string(),
+	action: z.enum(['create', 'attached', 'rejected']),
+	moved: z.boolean().optional().default(false),
+	error: z.string().optional().default('')
+});
+
+export const BackupTargetReattachResultSchema = z.object({
+	dryRun: z.boolean(),
+	items: z.array(BackupTargetReattachItemSchema),
+	failed: z.number().int()
+});
+
 export type BackupTarget = z.infer<typeof BackupTargetSchema>;
+export type BackupTargetOnboardingStep = z.infer<typeof BackupTargetOnboardingStepSchema>;
+export type BackupTargetOnboarding = z.infer<typeof BackupTargetOnboardingSchema>;
 export type BackupJob = z.infer<typeof BackupJobSchema>;
 export type BackupEvent = z.infer<typeof BackupEventSchema>;
 export type BackupEventProgress = z.infer<typeof BackupEventProgressSchema>;
+export type BackupEventDataset = z.infer<typeof BackupEventDatasetSchema>;
 export type SnapshotInfo = z.infer<typeof SnapshotInfoSchema>;
 export type BackupTargetDatasetInfo = z.infer<typeof BackupTargetDatasetInfoSchema>;
 export type BackupJailMetadataInfo = z.infer<typeof BackupJailMetadataInfoSchema>;
 export type BackupVMMetadataInfo = z.infer<typeof BackupVMMetadataInfoSchema>;
+export type BulkRestoreCandidate = z.infer<typeof BulkRestoreCandidateSchema>;
+export type BulkRestoreItem = z.infer<typeof BulkRestoreItemSchema>;
+export type BulkRestore = z.infer<typeof BulkRestoreSchema>;
+export type GuestExport = z.infer<typeof GuestExportSchema>;
+export type BackupConfigDocument = z.infer<typeof BackupConfigDocumentSchema>;
+export type BackupConfigImportResult = z.infer<typeof BackupConfigImportResultSchema>;
+export type PostRestoreScript = z.infer<typeof PostRestoreScriptSchema>;
+export type RestoreOverwriteGuest = z.infer<typeof RestoreOverwriteGuestSchema>;
+export type RestoreConfirmationToken = z.infer<typeof RestoreConfirmationTokenSchema>;
+export type RestorePolicy = z.infer<typeof RestorePolicySchema>;
+export type SSHProbeReport = z.infer<typeof SSHProbeReportSchema>;
+export type ZeltaProfile = z.infer<typeof ZeltaProfileSchema>;
+export type BackupTargetReattachItem = z.infer<typeof BackupTargetReattachItemSchema>;
+export type BackupTargetReattachResult = z.infer<typeof BackupTargetReattachResultSchema>;
 export type BackupJobMode = BackupJob['mode'];
 export type BackupGuestKind = 'dataset' | 'jail' | 'vm';
 export type BackupSnapshotLineageMarker = 'CURR' | 'OOB' | 'INT';
@@ -28,6 +28,17 @@ export const ClusterDetailsSchema = z.object({
 	partial: z.boolean()
 });
 
+export const NodePoolHealthSchema = z.object({
+	name: z.string(),
+	state: z.string(),
+	size: z.number(),
+	alloc: z.number(),
+	usedPct: z.number(),
+	readErrors: z.number().default(0),
+	writeErrors: z.number().default(0),
+	checksumErrors: z.number().default(0)
+});
+
 export const ClusterNodeSchema = z.object({
 	id: z.number(),
 	nodeUUID: z.string(),
@@ -42,7 +53,13 @@ export const ClusterNodeSchema = z.object({
 	diskUsage: z.number(),
 	createdAt: z.string(),
 	updatedAt: z.string(),
-	guestIDs: z.union([z.array(z.number()), z.null()]).default([])
+	guestIDs: z.union([z.array(z.number()), z.null()]).default([]),
+	clockStatus: z.enum(['ok', 'warning', 'critical', 'unknown']).catch('unknown'),
+	clockSkewMs: z.number().default(0),
+	ntpSource: z.string().default(''),
+	ntpSynced: z.boolean().default(false),
+	ntpOffsetMs: z.number().default(0),
+	pools: z.union([z.array(NodePoolHealthSchema), z.null()]).default([])
 });
 
 export const NodeResourceSchema = z.object({
@@ -57,5 +74,6 @@ export const NodeResourceSchema = z.object({
 export type Cluster = z.infer<typeof ClusterSchema>;
 export type RaftNode = z.infer<typeof RaftNodeSchema>;
 export type ClusterDetails = z.infer<typeof ClusterDetailsSchema>;
+export type NodePoolHealth = z.infer<typeof NodePoolHealthSchema>;
 export type ClusterNode = z.infer<typeof ClusterNodeSchema>;
 export type NodeResource = z.infer<typeof NodeResourceSchema>;
@@ -0,0 +1,34 @@
+import { z } from 'zod/v4';
+
+export const MaintenanceScopeSchema = z.enum(['node', 'guest', 'policy']);
+
+export const MaintenanceWindowSchema = z.object({
+	id: z.number().int(),
+	scope: MaintenanceScopeSchema,
+	nodeId: z.string().optional().default(''),
+	guestType: z.string().optional().default(''),
+	guestId: z.number().int().optional().default(0),
+	policyId: z.number().int().optional().default(0),
+	reason: z.string().default(''),
+	createdBy: z.string().optional().default(''),
+	startsAt: z.string(),
+	endsAt: z.string(),
+	createdAt: z.string().optional(),
+	updatedAt: z.string().optional()
+});
+
+export const MaintenanceSuppressionSchema = z.object({
+	id: z.number().int(),
+	windowId: z.number().int(),
+	action: z.string(),
+	key: z.string(),
+	title: z.string().optional().default(''),
+	detail: z.string().optional().default(''),
+	occurrences: z.number().int().default(1),
+	firstAt: z.string(),
+	lastAt: z.string()
+});
+
+export type MaintenanceScope = z.infer<typeof MaintenanceScopeSchema>;
+export type MaintenanceWindow = z.infer<typeof MaintenanceWindowSchema>;
+export type MaintenanceSuppression = z.infer<typeof MaintenanceSuppressionSchema>;
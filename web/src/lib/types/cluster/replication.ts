@@ -36,10 +36,16 @@ export const ReplicationPolicySchema = z.object({
 	failoverMode: ReplicationFailoverModeSchema.default('manual'),
 	cronExpr: z.string().default(''),
 	enabled: z.boolean().default(true),
+	paused: z.boolean().optional().default(false),
+	pausedReason: z.string().optional().default(''),
+	pausedAt: z.string().nullable().optional(),
 	crashRecovery: z.boolean().optional().default(true),
 	crashRestartMax: z.number().int().optional().default(3),
 	poolHealthCheck: z.boolean().optional().default(true),
 	poolCapacityPct: z.number().int().optional().default(90),
+	sourceBookmarks: z.boolean().optional().default(false),
+	zeltaProfileId: z.number().int().optional().default(0),
+	templateId: z.number().int().optional().default(0),
 	protectionState: z.string().optional().default(''),
 	lastRunAt: z.string().nullable().optional(),
 	nextRunAt: z.string().nullable().optional(),
@@ -66,6 +72,36 @@ export const ReplicationPolicySchema = z.object({
 	updatedAt: z.string().optional()
 });
 
+export const ReplicationTemplateTargetSchema = z.object({
+	nodeId: z.string(),
+	weight: z.number().int()
+});
+
+export const ReplicationPolicyTemplateSchema = z.object({
+	id: z.number().int(),
+	name: z.string(),
+	description: z.string().optional().default(''),
+	guestType: z.enum(['', 'vm', 'jail']).default(''),
+	tags: z.array(z.string()).nullable().default([]),
+	pools: z.array(z.string()).nullable().default([]),
+	targets: z.array(ReplicationTemplateTargetSchema).nullable().default([]),
+	cronExpr: z.string(),
+	failbackMode: ReplicationFailbackModeSchema.default('manual'),
+	failoverMode: ReplicationFailoverModeSchema.default('manual'),
+	enabled: z.boolean().default(true),
+	createdAt: z.string().optional(),
+	updatedAt: z.string().optional()
+});
+
+export const ReplicationTemplateEnrollmentSchema = z.object({
+	templateId: z.number().int(),
+	guestType: ReplicationGuestTypeSchema,
+	guestId: z.number().int(),
+	action: z.enum(['create', 'update', 'failed']),
+	policyId: z.number().int().optional(),
+	error: z.string().optional()
+});
+
 export const ReplicationEventSchema = z.object({
 	id: z.number().int(),
 	policyId: z.number().int().nullable().optional(),
@@ -92,11 +128,54 @@ export const ReplicationEventProgressSchema = z.object({
 	progressPercent: z.number().nullable().optional()
 });
 
+export const ReplicationLeaseSchema = z.object({
+	id: z.number().int(),
+	policyId: z.number().int(),
+	guestType: z.string(),
+	guestId: z.number().int(),
+	ownerNodeId: z.string(),
+	ownerEpoch: z.number().int(),
+	expiresAt: z.string(),
+	version: z.number().int(),
+	lastReason: z.string().optional().default(''),
+	lastActor: z.string().optional().default('')
+});
+
+export const ReplicationTimelineEntrySchema = z.object({
+	kind: z.enum(['run', 'failover', 'lease', 'rotation', 'divergence']),
+	startedAt: z.string(),
+	endedAt: z.string().nullable().optional(),
+	status: z.string(),
+	message: z.string().optional().default(''),
+	error: z.string().optional().default(''),
+	eventId: z.number().int().optional(),
+	transitionRunId: z.string().optional().default(''),
+	sourceNodeId: z.string().optional().default(''),
+	targetNodeId: z.string().optional().default(''),
+	ownerEpoch: z.number().int().optional()
+});
+
+export const ReplicationPolicyTimelineSchema = z.object({
+	policyId: z.number().int(),
+	guestType: z.string(),
+	guestId: z.number().int(),
+	activeNodeId: z.string().optional().default(''),
+	ownerEpoch: z.number().int(),
+	transitionState: z.string().optional().default(''),
+	lease: ReplicationLeaseSchema.nullable(),
+	entries: z.array(ReplicationTimelineEntrySchema).default([])
+});
+
 export type ReplicationGuestType = z.infer<typeof ReplicationGuestTypeSchema>;
 export type ReplicationSourceMode = z.infer<typeof ReplicationSourceModeSchema>;
 export type ReplicationFailbackMode = z.infer<typeof ReplicationFailbackModeSchema>;
 export type ReplicationFailoverMode = z.infer<typeof ReplicationFailoverModeSchema>;
 export type ReplicationPolicyTarget = z.infer<typeof ReplicationPolicyTargetSchema>;
 export type ReplicationPolicy = z.infer<typeof ReplicationPolicySchema>;
+export type ReplicationPolicyTemplate = z.infer<typeof ReplicationPolicyTemplateSchema>;
+export type ReplicationTemplateEnrollment = z.infer<typeof ReplicationTemplateEnrollmentSchema>;
 export type ReplicationEvent = z.infer<typeof ReplicationEventSchema>;
 export type ReplicationEventProgress = z.infer<typeof ReplicationEventProgressSchema>;
+export type ReplicationLease = z.infer<typeof ReplicationLeaseSchema>;
+export type ReplicationTimelineEntry = z.infer<typeof ReplicationTimelineEntrySchema>;
+export type ReplicationPolicyTimeline = z.infer<typeof ReplicationPolicyTimelineSchema>;
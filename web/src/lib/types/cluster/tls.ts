@@ -0,0 +1,24 @@
+import { z } from 'zod/v4';
+
+export const ClusterTLSNodeStatusSchema = z.object({
+	nodeUUID: z.string(),
+	hostname: z.string().default(''),
+	issued: z.boolean(),
+	current: z.boolean(),
+	caFingerprint: z.string().default(''),
+	notAfter: z.string().nullable().optional()
+});
+
+export const ClusterTLSStatusSchema = z.object({
+	configured: z.boolean(),
+	fingerprint: z.string().default(''),
+	notAfter: z.string().nullable().optional(),
+	previousFingerprint: z.string().default(''),
+	rotatedAt: z.string().nullable().optional(),
+	enforce: z.boolean(),
+	ready: z.boolean(),
+	nodes: z.array(ClusterTLSNodeStatusSchema).default([])
+});
+
+export type ClusterTLSNodeStatus = z.infer<typeof ClusterTLSNodeStatusSchema>;
+export type ClusterTLSStatus = z.infer<typeof ClusterTLSStatusSchema>;
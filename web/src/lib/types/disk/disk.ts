@@ -274,3 +274,33 @@ export type SmartSelfTestScheduleInput = {
 	cronExpr: string;
 	enabled: boolean;
 };
+
+export const DiskBenchmarkSchema = z.object({
+	id: z.number(),
+	pool: z.string(),
+	target: z.enum(['pool', 'zvol']),
+	sizeBytes: z.number(),
+	tests: z.array(z.string()).nullable().default([]),
+	status: z.enum(['running', 'completed', 'failed']),
+	error: z.string().default(''),
+	hardwareFingerprint: z.string().default(''),
+	devices: z.array(z.string()).nullable().default([]),
+	hardwareChanged: z.boolean().default(false),
+	baselineId: z.number().nullable().optional().default(null),
+	seqReadMBps: z.number().default(0),
+	seqWriteMBps: z.number().default(0),
+	randReadIops: z.number().default(0),
+	randWriteIops: z.number().default(0),
+	regressions: z.array(z.string()).nullable().default([]),
+	startedAt: z.string(),
+	completedAt: z.string().nullable().optional().default(null)
+});
+
+export type DiskBenchmark = z.infer<typeof DiskBenchmarkSchema>;
+
+export interface DiskBenchmarkInput {
+	pool: string;
+	target: 'pool' | 'zvol';
+	sizeMB: number;
+	tests: string[];
+}
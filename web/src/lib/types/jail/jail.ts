@@ -30,6 +30,9 @@ export interface CreateData {
         dhcp: boolean;
         slaac: boolean;
         resolvConf: string;
+        manageEtcFiles: boolean;
+        dnsSearchDomain: string;
+        hostEntries: string;
         vlan: number;
     };
     hardware: {
@@ -122,6 +125,13 @@ export const JailTemplateHookSchema = z.object({
     script: z.string()
 });
 
+export const JailMountSchema = z.object({
+    hostPath: z.string().default(''),
+    jailPath: z.string(),
+    type: z.enum(['nullfs', 'tmpfs', 'procfs', 'fdescfs', 'linprocfs', 'linsysfs']),
+    readOnly: z.boolean().default(false)
+});
+
 export const JailTemplateSchema = z.object({
     id: z.number().int(),
     name: z.string(),
@@ -135,6 +145,7 @@ export const JailTemplateSchema = z.object({
     memory: z.number(),
     inheritIPv4: z.boolean(),
     inheritIPv6: z.boolean(),
+    mounts: z.array(JailMountSchema).nullable().default([]),
     fstab: z.string(),
     resolvConf: z.string(),
     devfsRuleset: z.string(),
@@ -159,8 +170,12 @@ export const JailSchema = SimpleJailSchema.extend({
     networks: z.array(NetworkSchema).optional().default([]),
     storages: z.array(JailStorageSchema).optional().default([]),
     type: z.enum(['freebsd', 'linux']),
+    mounts: z.array(JailMountSchema).nullable().default([]),
     fstab: z.string(),
     resolvConf: z.string(),
+    manageEtcFiles: z.boolean().default(false),
+    dnsSearchDomain: z.string().default(''),
+    hostEntries: z.string().default(''),
     devfsRuleset: z.string(),
     additionalOptions: z.string(),
     allowedOptions: z.array(z.string()).default([]),
@@ -168,6 +183,7 @@ export const JailSchema = SimpleJailSchema.extend({
     metadataMeta: z.string(),
     metadataEnv: z.string(),
     cores: z.number(),
+    cpuSet: z.array(z.number().int()).nullable().default([]),
     memory: z.number(),
     startedAt: z.string().nullable(),
     stoppedAt: z.string().nullable(),
@@ -195,6 +211,41 @@ export const JailStatSchema = z.object({
     createdAt: z.string()
 });
 
+export const JailProcessSchema = z.object({
+    pid: z.number().int(),
+    ppid: z.number().int(),
+    user: z.string(),
+    state: z.string(),
+    pcpu: z.number(),
+    pmem: z.number(),
+    rss: z.number(),
+    vsz: z.number(),
+    elapsed: z.number().int(),
+    command: z.string()
+});
+
+export const JailResourceUsageSchema = z.object({
+    ctId: z.number().int(),
+    counters: z.record(z.string(), z.number())
+});
+
+export const CPUSetCoreSchema = z.object({
+    core: z.number().int(),
+    socket: z.number().int(),
+    vms: z.array(z.number().int()).default([]),
+    jails: z.array(z.number().int()).default([])
+});
+
+export const CPUSetTopologySchema = z.object({
+    sockets: z.number().int(),
+    logicalCores: z.number().int(),
+    logicalPerSocket: z.number().int(),
+    threadsPerCore: z.number().int(),
+    cores: z.array(CPUSetCoreSchema),
+    current: z.array(z.number().int()).default([]),
+    suggested: z.array(z.number().int()).optional().default([])
+});
+
 export const ExecPhaseDefs = [
     {
         key: 'prestart',
@@ -240,10 +291,15 @@ export type Jail = z.infer<typeof JailSchema>;
 export type JailStorage = z.infer<typeof JailStorageSchema>;
 export type JailNetwork = z.infer<typeof NetworkSchema>;
 export type JailHook = z.infer<typeof JailHookSchema>;
+export type JailMount = z.infer<typeof JailMountSchema>;
 export type JailTemplate = z.infer<typeof JailTemplateSchema>;
 export type JailState = z.infer<typeof JailStateSchema>;
 export type JailLogs = z.infer<typeof JailLogsSchema>;
 export type JailStat = z.infer<typeof JailStatSchema>;
+export type JailProcess = z.infer<typeof JailProcessSchema>;
+export type JailResourceUsage = z.infer<typeof JailResourceUsageSchema>;
+export type CPUSetCore = z.infer<typeof CPUSetCoreSchema>;
+export type CPUSetTopology = z.infer<typeof CPUSetTopologySchema>;
 
 export type JailLifecycleAction = 'start' | 'stop';
 export type JailLifecycleBadgeVariant = 'default' | 'secondary' | 'destructive' | 'outline';
@@ -5,6 +5,7 @@ export const JailSnapshotSchema = z.object({
 	jid: z.number().int(),
 	ctId: z.number().int(),
 	parentSnapshotId: z.number().int().nullable().default(null),
+	scheduleId: z.number().int().nullable().default(null),
 	name: z.string(),
 	description: z.string().default(''),
 	snapshotName: z.string(),
@@ -14,3 +15,41 @@ export const JailSnapshotSchema = z.object({
 });
 
 export type JailSnapshot = z.infer<typeof JailSnapshotSchema>;
+
+export const JailSnapshotScheduleSchema = z.object({
+	id: z.number().int(),
+	jid: z.number().int(),
+	ctId: z.number().int(),
+	enabled: z.boolean(),
+	cronExpr: z.string(),
+	maxKeep: z.number().int().default(0),
+	namePattern: z.string().default(''),
+	lastRunAt: z.string().nullable().default(null),
+	nextRunAt: z.string().nullable().default(null),
+	lastStatus: z.string().default(''),
+	lastError: z.string().default(''),
+	createdAt: z.string(),
+	updatedAt: z.string()
+});
+
+export type JailSnapshotSchedule = z.infer<typeof JailSnapshotScheduleSchema>;
+
+export const PathVersionSchema = z.object({
+	snapshot: z.string(),
+	snapshotId: z.number().int().optional(),
+	label: z.string(),
+	createdAt: z.string(),
+	modifiedAt: z.string(),
+	size: z.number(),
+	isDir: z.boolean(),
+	matchesLive: z.boolean()
+});
+
+export const PathVersionsSchema = z.object({
+	path: z.string(),
+	exists: z.boolean(),
+	versions: z.array(PathVersionSchema).default([])
+});
+
+export type PathVersion = z.infer<typeof PathVersionSchema>;
+export type PathVersions = z.infer<typeof PathVersionsSchema>;
@@ -10,6 +10,23 @@ export const ValidateResultSchema = z.object({
     warnings: z.array(z.string()).catch([])
 });
 
+export const CompatibilityCheckSchema = z.object({
+    category: z.string(),
+    code: z.string(),
+    status: z.enum(['ok', 'warning', 'blocked']),
+    detail: z.string().optional(),
+    remedy: z.string().optional()
+});
+
+export const CompatibilityReportSchema = z.object({
+    guestType: z.string(),
+    guestId: z.number().int(),
+    targetNodeUuid: z.string(),
+    compatible: z.boolean(),
+    checks: z.array(CompatibilityCheckSchema).catch([]),
+    checkedAt: z.string()
+});
+
 export const MigrationTaskResponseSchema = z.object({
     taskId: z.number().int(),
     guestId: z.number().int(),
@@ -18,4 +35,6 @@ export const MigrationTaskResponseSchema = z.object({
 
 export type MigrateRequest = z.infer<typeof MigrateRequestSchema>;
 export type ValidateResult = z.infer<typeof ValidateResultSchema>;
+export type CompatibilityCheck = z.infer<typeof CompatibilityCheckSchema>;
+export type CompatibilityReport = z.infer<typeof CompatibilityReportSchema>;
 export type MigrationTaskResponse = z.infer<typeof MigrationTaskResponseSchema>;
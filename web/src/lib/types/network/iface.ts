@@ -84,3 +84,19 @@ export const IfaceSchema = z.object({
 
 export type Iface = z.infer<typeof IfaceSchema>;
 export type BridgeMember = z.infer<typeof BridgeMemberSchema>;
+
+export const HostInterfaceSchema = z.object({
+	id: z.number(),
+	name: z.string(),
+	description: z.string().default(''),
+	mtu: z.number().default(0),
+	ipv4: z.array(z.string()).default([]).nullable(),
+	ipv6: z.array(z.string()).default([]).nullable(),
+	media: z.string().default(''),
+	mediaOpt: z.string().default(''),
+	up: z.boolean(),
+	createdAt: z.string(),
+	updatedAt: z.string()
+});
+
+export type HostInterface = z.infer<typeof HostInterfaceSchema>;
@@ -0,0 +1,24 @@
+import { z } from 'zod/v4';
+
+export const NetworkImportItemSchema = z.object({
+	line: z.number().int(),
+	name: z.string(),
+	type: z.string().optional().default(''),
+	values: z.array(z.string()).optional().default([]),
+	action: z.enum(['create', 'exists', 'duplicate', 'invalid', 'failed']),
+	id: z.number().int().optional(),
+	duplicateOf: z.string().optional(),
+	error: z.string().optional()
+});
+
+export const NetworkImportReportSchema = z.object({
+	dryRun: z.boolean(),
+	items: z.array(NetworkImportItemSchema),
+	created: z.number().int(),
+	skipped: z.number().int(),
+	failed: z.number().int()
+});
+
+export type NetworkImportFormat = 'csv' | 'json';
+export type NetworkImportItem = z.infer<typeof NetworkImportItemSchema>;
+export type NetworkImportReport = z.infer<typeof NetworkImportReportSchema>;
@@ -0,0 +1,24 @@
+import { z } from 'zod/v4';
+
+export const IsolationGroupMemberSchema = z.object({
+	guestType: z.enum(['vm', 'jail']),
+	guestId: z.number().int()
+});
+
+export const IsolationGroupSchema = z.object({
+	id: z.number().int(),
+	switchId: z.number().int(),
+	name: z.string(),
+	description: z
+		.string()
+		.nullish()
+		.transform((value) => value ?? ''),
+	members: IsolationGroupMemberSchema.array()
+		.nullish()
+		.transform((value) => value ?? []),
+	createdAt: z.string(),
+	updatedAt: z.string()
+});
+
+export type IsolationGroupMember = z.infer<typeof IsolationGroupMemberSchema>;
+export type IsolationGroup = z.infer<typeof IsolationGroupSchema>;
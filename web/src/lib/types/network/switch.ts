@@ -54,3 +54,25 @@ export const SwitchListSchema = z.object({
 export type StandardSwitch = z.infer<typeof StandardSwitchSchema>;
 export type ManualSwitch = z.infer<typeof ManualSwitchSchema>;
 export type SwitchList = z.infer<typeof SwitchListSchema>;
+
+export const SwitchMTUMemberSchema = z.object({
+	name: z.string(),
+	kind: z.enum(['port', 'jail', 'guest']),
+	mtu: z.number(),
+	configuredMtu: z.number()
+});
+
+export const SwitchMTUReportSchema = z.object({
+	switchId: z.number(),
+	switchName: z.string(),
+	bridgeName: z.string(),
+	mtu: z.number(),
+	bridgeMtu: z.number(),
+	members: z.array(SwitchMTUMemberSchema),
+	vmCount: z.number(),
+	jailCount: z.number(),
+	mismatches: z.array(z.string())
+});
+
+export type SwitchMTUMember = z.infer<typeof SwitchMTUMemberSchema>;
+export type SwitchMTUReport = z.infer<typeof SwitchMTUReportSchema>;
@@ -174,3 +174,45 @@ export type BulkUpdateRulesInput = {
 	emailEnabled?: boolean;
 	discordEnabled?: boolean;
 };
+
+export const GuestAlertRuleSchema = z.object({
+	id: z.number().int().nonnegative(),
+	name: z.string(),
+	guestType: z.enum(['vm', 'jail']),
+	guestId: z.number().int().nonnegative(),
+	metric: z.enum(['cpu', 'memory', 'disk_latency']),
+	threshold: z.number(),
+	hysteresis: z.number().default(0),
+	forMinutes: z.number().int().nonnegative().default(0),
+	severity: z.string(),
+	enabled: z.boolean(),
+	createdAt: z.string(),
+	updatedAt: z.string()
+});
+
+export const GuestAlertSchema = z.object({
+	ruleId: z.number().int().nonnegative(),
+	ruleName: z.string(),
+	guestType: z.enum(['vm', 'jail']),
+	guestId: z.number().int().nonnegative(),
+	guestName: z.string().default(''),
+	metric: z.enum(['cpu', 'memory', 'disk_latency']),
+	threshold: z.number(),
+	value: z.number(),
+	since: z.string()
+});
+
+export type GuestAlertRule = z.infer<typeof GuestAlertRuleSchema>;
+export type GuestAlert = z.infer<typeof GuestAlertSchema>;
+
+export type GuestAlertRuleInput = {
+	name?: string;
+	guestType: 'vm' | 'jail';
+	guestId: number;
+	metric: 'cpu' | 'memory' | 'disk_latency';
+	threshold: number;
+	hysteresis?: number;
+	forMinutes?: number;
+	severity?: 'warning' | 'error' | 'critical';
+	enabled?: boolean;
+};
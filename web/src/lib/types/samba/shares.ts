@@ -42,4 +42,15 @@ export const SambaShareSchema = z.object({
     updatedAt: z.string()
 });
 
+export const SambaShareQuotaSchema = z.object({
+    type: z.enum(['user', 'group']),
+    name: z.string(),
+    usedBytes: z.number().default(0),
+    quotaBytes: z.number().default(0),
+    usedObjects: z.number().default(0),
+    quotaObjects: z.number().default(0),
+    quotaUsedPercent: z.number().default(0)
+});
+
 export type SambaShare = z.infer<typeof SambaShareSchema>;
+export type SambaShareQuota = z.infer<typeof SambaShareQuotaSchema>;
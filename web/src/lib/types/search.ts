@@ -0,0 +1,31 @@
+import { z } from 'zod/v4';
+
+export const SearchResultSchema = z.object({
+    type: z.enum([
+        'vm',
+        'jail',
+        'switch',
+        'dataset',
+        'backup_job',
+        'replication_policy',
+        'backup_event',
+        'replication_event',
+        'node'
+    ]),
+    id: z.string(),
+    name: z.string(),
+    match: z.string(),
+    detail: z.string().optional(),
+    nodeId: z.string().optional(),
+    hostname: z.string().optional(),
+    score: z.number().int()
+});
+
+export const SearchResponseSchema = z.object({
+    query: z.string(),
+    results: z.array(SearchResultSchema).catch([]),
+    unreachable: z.array(z.string()).catch([])
+});
+
+export type SearchResult = z.infer<typeof SearchResultSchema>;
+export type SearchResponse = z.infer<typeof SearchResponseSchema>;
@@ -23,8 +23,27 @@ export const PCIDeviceSchema = z.object({
 
 export const PPTDeviceSchema = z.object({
 	id: z.number().int(),
-	deviceID: z.string()
+	deviceID: z.string(),
+	missing: z.boolean().default(false),
+	missingReason: z.string().default('')
+});
+
+export const USBDeviceSchema = z.object({
+	ugen: z.string(),
+	bus: z.number().int(),
+	address: z.number().int(),
+	description: z.string(),
+	vendorId: z.string(),
+	productId: z.string(),
+	manufacturer: z.string().default(''),
+	product: z.string().default(''),
+	serial: z.string().default(''),
+	speed: z.string().default(''),
+	controller: z.string().default(''),
+	controllerDomain: z.number().int(),
+	controllerDeviceId: z.string().default('')
 });
 
 export type PCIDevice = z.infer<typeof PCIDeviceSchema>;
 export type PPTDevice = z.infer<typeof PPTDeviceSchema>;
+export type USBDevice = z.infer<typeof USBDeviceSchema>;
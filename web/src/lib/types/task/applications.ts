@@ -0,0 +1,39 @@
+import { z } from 'zod/v4';
+
+export const ApplicationMemberSchema = z.object({
+    id: z.number().int(),
+    applicationId: z.number().int(),
+    guestType: z.enum(['vm', 'jail']),
+    guestId: z.number().int(),
+    startOrder: z.number().int(),
+    healthCheck: z.enum(['running', 'tcp']),
+    healthTarget: z.string().nullable().optional(),
+    healthTimeoutSeconds: z.number().int()
+});
+
+export const ApplicationSchema = z.object({
+    id: z.number().int(),
+    name: z.string(),
+    description: z.string().nullable().optional(),
+    members: z.array(ApplicationMemberSchema).default([]),
+    createdAt: z.string(),
+    updatedAt: z.string()
+});
+
+export type ApplicationMember = z.infer<typeof ApplicationMemberSchema>;
+export type Application = z.infer<typeof ApplicationSchema>;
+
+export interface ApplicationMemberInput {
+    guestType: 'vm' | 'jail';
+    guestId: number;
+    startOrder: number;
+    healthCheck?: 'running' | 'tcp';
+    healthTarget?: string;
+    healthTimeoutSeconds?: number;
+}
+
+export interface ApplicationInput {
+    name: string;
+    description?: string;
+    members: ApplicationMemberInput[];
+}
@@ -12,6 +12,7 @@ export const LifecycleTaskSchema = z.object({
     error: z.string().nullable().optional(),
     payload: z.string().nullable().optional(),
     overrideRequested: z.boolean().default(false),
+    queuePosition: z.number().int().optional(),
     startedAt: z.string().nullable().optional(),
     finishedAt: z.string().nullable().optional(),
     createdAt: z.string(),
@@ -20,6 +21,33 @@ export const LifecycleTaskSchema = z.object({
 
 export type LifecycleTask = z.infer<typeof LifecycleTaskSchema>;
 
+export const HeavyOpSchema = z.object({
+    key: z.string(),
+    label: z.string(),
+    taskId: z.number().int().optional(),
+    pools: z.array(z.string()),
+    position: z.number().int().optional(),
+    since: z.string()
+});
+
+export const HeavyOpsPoolSchema = z.object({
+    pool: z.string(),
+    limit: z.number().int(),
+    running: z.array(HeavyOpSchema),
+    waiting: z.array(HeavyOpSchema)
+});
+
+export type HeavyOp = z.infer<typeof HeavyOpSchema>;
+export type HeavyOpsPool = z.infer<typeof HeavyOpsPoolSchema>;
+
+/** Toast text for a create that was queued behind other work on its pool. */
+export function queuedCreateMessage(kind: string, name: string, data: unknown): string {
+    const position = (data as { queuePosition?: number } | null)?.queuePosition;
+    return position
+        ? `${kind} ${name} queued, position ${position} on its pool`
+        : `${kind} ${name} queued until its pool has capacity`;
+}
+
 const activeLifecycleTaskStatuses = new Set<LifecycleTask['status']>(['queued', 'running']);
 
 export function isLifecycleTaskActive(task: LifecycleTask | null | undefined): task is LifecycleTask {
@@ -24,10 +24,24 @@ export const DownloadSchema = z.object({
 	automaticExtraction: z.boolean(),
 	automaticRawConversion: z.boolean(),
 	ignoreTLS: z.boolean(),
+	expectedSha256: z.string().optional(),
+	checksumUrl: z.string().optional(),
+	signatureUrl: z.string().optional(),
+	sha256: z.string().optional(),
+	verification: z.enum(['unverified', 'pending', 'verified', 'failed']).optional(),
+	verificationError: z.string().optional(),
+	verifiedAt: z.string().nullable().optional(),
 	createdAt: z.string(),
 	updatedAt: z.string()
 });
 
+export interface DownloadVerification {
+	sha256?: string;
+	checksumUrl?: string;
+	signatureUrl?: string;
+	signaturePublicKey?: string;
+}
+
 export const UTypeGroupedDownloadSchema = z.object({
 	uuid: z.string(),
 	label: z.string(),
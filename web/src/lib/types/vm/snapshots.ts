@@ -5,6 +5,7 @@ export const VMSnapshotSchema = z.object({
 	vmId: z.number().int(),
 	rid: z.number().int(),
 	parentSnapshotId: z.number().int().nullable().default(null),
+	scheduleId: z.number().int().nullable().default(null),
 	name: z.string(),
 	description: z.string().default(''),
 	snapshotName: z.string(),
@@ -14,3 +15,21 @@ export const VMSnapshotSchema = z.object({
 });
 
 export type VMSnapshot = z.infer<typeof VMSnapshotSchema>;
+
+export const VMSnapshotScheduleSchema = z.object({
+	id: z.number().int(),
+	vmId: z.number().int(),
+	rid: z.number().int(),
+	enabled: z.boolean(),
+	cronExpr: z.string(),
+	maxKeep: z.number().int().default(0),
+	namePattern: z.string().default(''),
+	lastRunAt: z.string().nullable().default(null),
+	nextRunAt: z.string().nullable().default(null),
+	lastStatus: z.string().default(''),
+	lastError: z.string().default(''),
+	createdAt: z.string(),
+	updatedAt: z.string()
+});
+
+export type VMSnapshotSchedule = z.infer<typeof VMSnapshotScheduleSchema>;
@@ -57,9 +57,41 @@ export interface CreateData {
         extraBhyveOptions: string;
         ignoreUmsrs: boolean;
         qemuGuestAgent: boolean;
+        preset?: VMPreset;
     };
 }
 
+export type VMPreset = '' | 'windows';
+
+export const VMPresetInfoSchema = z.object({
+    name: z.enum(['', 'windows']),
+    description: z.string(),
+    bootRom: z.string(),
+    timeOffset: z.enum(['utc', 'localtime']),
+    tpmEmulation: z.boolean(),
+    acpi: z.boolean(),
+    apic: z.boolean(),
+    ignoreUMSR: z.boolean(),
+    storageEmulationType: z.string(),
+    switchEmulationType: z.string(),
+    devices: z.array(
+        z.object({
+            device: z.string(),
+            model: z.string(),
+            notes: z.string()
+        })
+    ),
+    driverMedia: z
+        .object({
+            url: z.string(),
+            downloadUuid: z.string().optional(),
+            status: z.string()
+        })
+        .optional()
+});
+
+export type VMPresetInfo = z.infer<typeof VMPresetInfoSchema>;
+
 export type VMStorageType = 'raw' | 'zvol' | 'image' | 'filesystem';
 export type VMStorageEmulationType = 'virtio-blk' | 'virtio-9p' | 'ahci-hd' | 'ahci-cd' | 'nvme';
 
@@ -153,6 +185,7 @@ export const VMSchema = z.object({
     bootRom: z.enum(['uefi', 'none']),
     extraBhyveOptions: z.union([z.array(z.string()), z.null()]),
     ignoreUMSR: z.boolean(),
+    cpuFeatures: z.union([z.array(z.string()), z.null()]).default([]),
     qemuGuestAgent: z.boolean(),
     tpmEmulation: z.boolean(),
 
@@ -248,6 +281,7 @@ export const VMTemplateSchema = z.object({
     bootRom: z.enum(['uefi', 'none']),
     extraBhyveOptions: z.union([z.array(z.string()), z.null()]),
     ignoreUMSR: z.boolean(),
+    cpuFeatures: z.union([z.array(z.string()), z.null()]).default([]),
     qemuGuestAgent: z.boolean(),
     storages: z.array(VMTemplateStorageSchema).default([]),
     networks: z.array(VMTemplateNetworkSchema).default([]),
@@ -312,6 +346,33 @@ export type VMTemplate = z.infer<typeof VMTemplateSchema>;
 export type VMTemplateStorage = z.infer<typeof VMTemplateStorageSchema>;
 export type VMTemplateNetwork = z.infer<typeof VMTemplateNetworkSchema>;
 export type QGAInfo = z.infer<typeof QGAInfoSchema>;
+
+export const CPUTopologySchema = z.object({
+    sockets: z.number(),
+    cores: z.number(),
+    threads: z.number()
+});
+
+export const CPUCapabilitiesSchema = z.object({
+    vendor: z.string(),
+    brand: z.string(),
+    sockets: z.number(),
+    physicalCores: z.number(),
+    logicalCores: z.number(),
+    threadsPerCore: z.number(),
+    virtualization: z.boolean(),
+    features: z.array(
+        z.object({
+            name: z.string(),
+            bhyveArg: z.string(),
+            description: z.string(),
+            supported: z.boolean()
+        })
+    ),
+    suggested: CPUTopologySchema.optional()
+});
+
+export type CPUCapabilities = z.infer<typeof CPUCapabilitiesSchema>;
 export type VMLifecycleAction = 'start' | 'stop' | 'shutdown' | 'reboot';
 export type VMLifecycleBadgeVariant = 'default' | 'secondary' | 'destructive' | 'outline';
 export type OutcomeResponse = z.infer<typeof OutcomeResponseSchema>;
@@ -321,3 +382,30 @@ export interface VMLifecycleBadgeStyle {
     className: string;
     label: string;
 }
+
+export const VMLiveStatsSchema = z.object({
+    rid: z.number(),
+    sampledAt: z.string(),
+    cpuPercent: z.number(),
+    memoryRss: z.number(),
+    memoryPercent: z.number(),
+    disks: z.array(
+        z.object({
+            dataset: z.string(),
+            readIops: z.number(),
+            writeIops: z.number(),
+            readBytesRate: z.number(),
+            writeBytesRate: z.number()
+        })
+    ),
+    nics: z.array(
+        z.object({
+            name: z.string(),
+            mac: z.string(),
+            rxBytesRate: z.number(),
+            txBytesRate: z.number()
+        })
+    )
+});
+
+export type VMLiveStats = z.infer<typeof VMLiveStatsSchema>;
@@ -0,0 +1,21 @@
+import { z } from 'zod/v4';
+
+export const ZFSDiffChangeSchema = z.object({
+	change: z.enum(['added', 'removed', 'modified', 'renamed']),
+	fileType: z.string(),
+	path: z.string(),
+	newPath: z.string().optional(),
+	changedAt: z.string()
+});
+
+export const ZFSDatasetDiffSchema = z.object({
+	dataset: z.string(),
+	from: z.string(),
+	to: z.string(),
+	changes: z.array(ZFSDiffChangeSchema).nullable().default([]),
+	truncated: z.boolean(),
+	skipped: z.string().optional()
+});
+
+export type ZFSDiffChange = z.infer<typeof ZFSDiffChangeSchema>;
+export type ZFSDatasetDiff = z.infer<typeof ZFSDatasetDiffSchema>;
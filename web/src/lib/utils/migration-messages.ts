@@ -21,6 +21,20 @@ const REASON_MESSAGES: Record<string, string | ReasonFormatter> = {
 	// --- Hard blocks ---
 	target_check_unsupported:
 		'The target node runs an older version that cannot verify this guest\u2019s dependencies. Update the target node, then retry.',
+	target_virtualization_unavailable:
+		'The target node\u2019s CPU does not expose hardware virtualization. Enable VT-x/AMD-V in its firmware settings.',
+	target_insufficient_vcpus: (d) =>
+		`The target node does not have enough CPU cores for this VM${d ? ` (${d})` : ''}.`,
+	target_cpu_features_unsupported: (d) =>
+		`The target node cannot provide the VM\u2019s CPU features${d ? `: ${d}` : ''}.`,
+	target_boot_rom_unavailable: (d) =>
+		`The target node is missing the firmware for the VM\u2019s boot ROM${d ? ` \u201c${d}\u201d` : ''}. Install the bhyve firmware package there.`,
+	target_tpm_unavailable:
+		'The VM uses TPM emulation but swtpm is not installed on the target node.',
+	target_missing_passthrough_device: (d) =>
+		`The PCI device${d ? ` ${d}` : ''} is not reserved for passthrough on the target node.`,
+	target_insufficient_pool_space: (d) =>
+		`The target pool does not have enough free space${d ? ` (${d})` : ''}.`,
 	target_check_failed: (d) =>
 		`Could not verify the target node\u2019s readiness${d ? `: ${d}` : ''}.`,
 	target_missing_pool: (d) =>
@@ -54,6 +68,10 @@ const REASON_MESSAGES: Record<string, string | ReasonFormatter> = {
 	// --- Warnings (migration proceeds) ---
 	warning_pci_passthrough_not_migrated:
 		'PCI passthrough devices are host-specific and will be dropped on the target.',
+	warning_target_check_unsupported:
+		'The target node runs an older version and could not verify this VM\u2019s requirements.',
+	warning_target_capabilities_unreported:
+		'The target node runs an older version and did not report its CPU, firmware, TPM or passthrough capabilities.',
 	warning_cpu_pinning_reset: 'CPU pinning is host-specific and will be reset on the target.',
 	warning_target_insufficient_memory: (d) =>
 		`The target node may not have enough free memory${d ? ` (${d})` : ''}.`,
@@ -148,6 +148,37 @@ export function generateTableData(data: Download[]): { rows: Row[]; columns: Col
                 return value;
             }
         },
+        {
+            field: 'verification',
+            title: 'Verification',
+            formatter: (cell: CellComponent) => {
+                const value = cell.getValue();
+                const data = cell.getRow().getData();
+
+                switch (value) {
+                    case 'verified':
+                        return renderWithIcon(
+                            'mdi:shield-check',
+                            'Verified',
+                            'text-green-500',
+                            escapeHTML(data.sha256 || '')
+                        );
+                    case 'failed':
+                        return renderWithIcon(
+                            'mdi:shield-alert',
+                            'Failed',
+                            'text-red-500',
+                            escapeHTML(data.verificationError || '')
+                        );
+                    case 'pending':
+                        return renderWithIcon('mdi:shield-sync-outline', 'Pending');
+                    case 'unverified':
+                        return renderWithIcon('mdi:shield-off-outline', 'Unverified');
+                    default:
+                        return '-';
+                }
+            }
+        },
         {
             field: 'parentUUID',
             title: 'Parent UUID',
@@ -170,6 +201,9 @@ export function generateTableData(data: Download[]): { rows: Row[]; columns: Col
             status: download.status,
             automaticExtraction: download.automaticExtraction,
             automaticRawConversion: download.automaticRawConversion,
+            verification: download.verification || '-',
+            verificationError: download.verificationError,
+            sha256: download.sha256,
             children: []
         };
 
@@ -183,7 +217,8 @@ export function generateTableData(data: Download[]): { rows: Row[]; columns: Col
                 children: [],
                 progress: '-',
                 parentUUID: download.uuid,
-                status: '-'
+                status: '-',
+                verification: '-'
             };
 
             row.children?.push(childRow);
@@ -0,0 +1,7 @@
+import { getLoginNotices } from '$lib/api/auth/notices';
+
+export async function load() {
+	return {
+		notices: await getLoginNotices()
+	};
+}
@@ -0,0 +1,17 @@
+import { listDiskBenchmarks } from '$lib/api/disk/disk';
+import { getPools } from '$lib/api/zfs/pool';
+import { SEVEN_DAYS } from '$lib/utils';
+import { cachedFetch } from '$lib/utils/http';
+
+export async function load() {
+	const cacheDuration = SEVEN_DAYS;
+	const [benchmarks, pools] = await Promise.all([
+		cachedFetch('disk-benchmarks', async () => await listDiskBenchmarks(), cacheDuration),
+		cachedFetch('pool-list', async () => await getPools(false), cacheDuration)
+	]);
+
+	return {
+		benchmarks,
+		pools
+	};
+}
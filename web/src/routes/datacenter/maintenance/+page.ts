@@ -0,0 +1,17 @@
+import { getNodes } from '$lib/api/cluster/cluster';
+import { listMaintenanceWindows } from '$lib/api/cluster/maintenance';
+import type { ClusterNode } from '$lib/types/cluster/cluster';
+import type { MaintenanceWindow } from '$lib/types/cluster/maintenance';
+import { cachedFetch } from '$lib/utils/http';
+
+export async function load() {
+	const [windows, nodes] = await Promise.all([
+		cachedFetch('maintenance-windows', async () => listMaintenanceWindows(), 1000),
+		cachedFetch('cluster-nodes', async () => getNodes(), 1000)
+	]);
+
+	return {
+		windows: windows as MaintenanceWindow[],
+		nodes: nodes as ClusterNode[]
+	};
+}